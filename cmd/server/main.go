@@ -33,6 +33,7 @@ import (
 	"github.com/dukerupert/hiri/internal/repository"
 	"github.com/dukerupert/hiri/internal/router"
 	"github.com/dukerupert/hiri/internal/routes"
+	"github.com/dukerupert/hiri/internal/scheduler"
 	"github.com/dukerupert/hiri/internal/service"
 	"github.com/dukerupert/hiri/internal/shipping"
 	"github.com/dukerupert/hiri/internal/storage"
@@ -121,16 +122,13 @@ func run() error {
 
 	// Initialize services
 	productService := postgres.NewProductService(repo)
+	categoryService := service.NewCategoryService(repo)
+	reviewService := service.NewReviewService(repo, cfg.BaseURL, cfg.Reviews.RequestDelayDays)
+	whiteLabelService := service.NewWhiteLabelService(repo)
 	cartService := postgres.NewCartService(repo)
 	userService := postgres.NewUserService(repo)
 
-	// Parse tenant ID as UUID for password reset service
-	tenantUUID, err := uuid.Parse(cfg.TenantID)
-	if err != nil {
-		return fmt.Errorf("failed to parse tenant ID: %w", err)
-	}
-
-	// Convert to pgtype.UUID for repository queries
+	// Parse the configured tenant ID (single-tenant storefront)
 	var tenantPgUUID pgtype.UUID
 	if err := tenantPgUUID.Scan(cfg.TenantID); err != nil {
 		return fmt.Errorf("failed to convert tenant ID to pgtype.UUID: %w", err)
@@ -173,6 +171,10 @@ func run() error {
 	if err != nil {
 		return fmt.Errorf("failed to initialize email service: %w", err)
 	}
+	// Tenants' customized emails are sent in place of the defaults
+	emailService.SetOverrideStore(service.NewEmailOverrideStore(repo))
+	// Customer emails carry the tenant's logo, color and fonts
+	emailService.SetBrandingStore(service.NewEmailBrandingStore(repo))
 	logger.Info("Email service initialized")
 
 	// Initialize file storage for product images
@@ -183,6 +185,8 @@ func run() error {
 	}
 	logger.Info("File storage initialized", "provider", cfg.Storage.Provider)
 
+	brandingService := service.NewBrandingService(repo, fileStorage)
+
 	// Note: Background worker initialization moved after service creation
 
 	// Load templates with renderer
@@ -218,14 +222,22 @@ func run() error {
 
 	// Initialize order service
 	logger.Info("Initializing order service...")
-	orderService := service.NewOrderService(repo, billingProvider, shippingProvider)
+	orderService := service.NewOrderService(repo, pool, billingProvider, shippingProvider)
 	logger.Info("Order service initialized")
 
+	// Initialize refund service
+	logger.Info("Initializing refund service...")
+	refundService := service.NewRefundService(repo, billingProvider)
+	logger.Info("Refund service initialized")
+
 	// Initialize subscription service
 	logger.Info("Initializing subscription service...")
 	subscriptionService := service.NewSubscriptionService(repo, billingProvider)
 	logger.Info("Subscription service initialized")
 
+	// Initialize dunning service (retries failed subscription payments)
+	dunningService := service.NewDunningService(repo, billingProvider, subscriptionService, cfg.BaseURL)
+
 	// Initialize account service
 	logger.Info("Initializing account service...")
 	accountService := service.NewAccountService(repo)
@@ -241,6 +253,11 @@ func run() error {
 	taxCalculator := tax.NewNoTaxCalculator()
 	logger.Info("Tax calculator initialized")
 
+	// Initialize discount service
+	logger.Info("Initializing discount service...")
+	discountService := service.NewDiscountService(repo, pool)
+	logger.Info("Discount service initialized")
+
 	// Initialize checkout service
 	logger.Info("Initializing checkout service...")
 	checkoutService := service.NewCheckoutService(
@@ -250,6 +267,7 @@ func run() error {
 		shippingProvider,
 		taxCalculator,
 		addressValidator,
+		discountService,
 	)
 	logger.Info("Checkout service initialized")
 
@@ -263,17 +281,25 @@ func run() error {
 	invoiceService := service.NewInvoiceService(repo, paymentTermsService, billingProvider)
 	logger.Info("Invoice service initialized")
 
-	// Initialize background worker
-	logger.Info("Initializing background worker...")
-	workerConfig := worker.Config{
-		WorkerID:       fmt.Sprintf("worker-%s", uuid.New().String()[:8]),
-		PollInterval:   1 * time.Second,
-		MaxConcurrency: 5,
-		Queue:          "", // Process all queues
-		TenantID:       &tenantUUID,
-	}
-	bgWorker := worker.NewWorker(repo, emailService, invoiceService, workerConfig, logger)
-	logger.Info("Background worker initialized")
+	// Initialize wholesale order rules service
+	logger.Info("Initializing order rules service...")
+	orderRulesService := service.NewOrderRulesService(repo)
+	logger.Info("Order rules service initialized")
+
+	// Initialize on-account ordering service
+	logger.Info("Initializing on-account service...")
+	onAccountService := service.NewOnAccountService(repo, pool, checkoutService, invoiceService, orderRulesService)
+	logger.Info("On-account service initialized")
+
+	// Initialize standing order service
+	logger.Info("Initializing standing order service...")
+	standingOrderService := service.NewStandingOrderService(repo, cartService, checkoutService, onAccountService, cfg.BaseURL)
+	logger.Info("Standing order service initialized")
+
+	// Initialize reorder service (reorders and order templates)
+	logger.Info("Initializing reorder service...")
+	reorderService := service.NewReorderService(repo, cartService)
+	logger.Info("Reorder service initialized")
 
 	// ==========================================================================
 	// Build route dependencies
@@ -338,14 +364,26 @@ func run() error {
 
 	// Storefront dependencies
 	storefrontDeps := routes.StorefrontDeps{
+		// Customer sessions
+		UserService: userService,
+
+		// Tenant branding for the storefront layout
+		BrandingService: brandingService,
+
 		// Home
 		HomeHandler: storefront.NewHomeHandler(productService, renderer),
 
 		// Products (consolidated: list, detail, subscription products)
-		ProductHandler: storefront.NewProductHandler(productService, repo, renderer, cfg.TenantID),
+		ProductHandler: storefront.NewProductHandler(productService, reviewService, repo, renderer),
+
+		// Category landing pages
+		CollectionHandler: storefront.NewCollectionHandler(categoryService, productService, renderer),
+
+		// Product reviews
+		ReviewHandler: storefront.NewReviewHandler(reviewService, renderer),
 
 		// Cart (consolidated handler)
-		CartHandler: storefront.NewCartHandler(cartService, renderer, cookieConfig, cfg.TenantID),
+		CartHandler: storefront.NewCartHandler(cartService, orderRulesService, renderer, cookieConfig),
 
 		// Auth (consolidated: signup, login, logout, password reset, email verification)
 		AuthHandler: storefront.NewAuthHandler(
@@ -354,7 +392,6 @@ func run() error {
 			passwordResetService,
 			repo,
 			renderer,
-			tenantUUID,
 			cookieConfig,
 		),
 
@@ -364,35 +401,40 @@ func run() error {
 			cartService,
 			checkoutService,
 			orderService,
+			onAccountService,
+			orderRulesService,
 			repo,
 			cfg.Stripe.PublishableKey,
-			cfg.TenantID,
 		),
 
-		// Subscriptions (consolidated: list, detail, portal, checkout, create)
+		// Subscriptions (consolidated: list, detail, portal, checkout, create, update payment)
 		SubscriptionHandler: storefront.NewSubscriptionHandler(
 			subscriptionService,
+			dunningService,
 			productService,
 			accountService,
 			renderer,
-			cfg.TenantID,
 		),
 
 		// Account (consolidated: dashboard, orders, addresses, payment methods, profile)
 		AccountHandler: storefront.NewAccountHandler(
 			accountService,
 			subscriptionService,
+			reviewService,
 			repo,
 			renderer,
-			cfg.TenantID,
 		),
 
+		// Reorder (refill the cart from a past order)
+		ReorderHandler: storefront.NewReorderHandler(reorderService, cartService, renderer, cookieConfig),
+
 		// Wholesale
-		WholesaleApplicationHandler: storefront.NewWholesaleApplicationHandler(repo, renderer, cfg.TenantID),
-		WholesaleOrderingHandler:    storefront.NewWholesaleOrderingHandler(repo, cartService, renderer, cfg.TenantID, cookieConfig),
+		WholesaleApplicationHandler: storefront.NewWholesaleApplicationHandler(repo, renderer),
+		WholesaleOrderingHandler:    storefront.NewWholesaleOrderingHandler(repo, cartService, orderRulesService, reorderService, renderer, cookieConfig),
+		StandingOrderHandler:        storefront.NewStandingOrderHandler(standingOrderService, onAccountService, cartService, accountService, renderer),
 
 		// Static pages (legal, about, contact, etc.)
-		PagesHandler: storefront.NewPagesHandler(pageService, renderer, cfg.TenantID),
+		PagesHandler: storefront.NewPagesHandler(pageService, renderer),
 	}
 
 	// ==========================================================================
@@ -422,6 +464,48 @@ func run() error {
 	providerFactory := provider.MustNewDefaultFactory(providerValidator) // Panics only during startup if validator is nil
 	providerRegistry := provider.NewDefaultRegistry(repo, providerFactory, encryptor, 0) // 0 = default 1 hour TTL
 
+	// Initialize shipping label service (uses each tenant's configured shipping provider)
+	shippingLabelService := service.NewShippingLabelService(repo, providerRegistry, fileStorage)
+
+	// Initialize fulfillment batch service (buys labels for many orders in a background job)
+	fulfillmentBatchService := service.NewFulfillmentBatchService(repo, shippingLabelService, fileStorage)
+
+	// Initialize roast service (roast batches, allocations and rest period)
+	roastService := service.NewRoastService(repo)
+
+	// Initialize inventory service (stock ledger and low stock thresholds)
+	inventoryService := service.NewInventoryService(repo, cfg.BaseURL)
+
+	// Initialize shipment tracking service (records carrier webhooks and tracking polls)
+	shipmentTrackingService := service.NewShipmentTrackingService(repo, providerRegistry, cfg.Shipping.TrackingEmails)
+
+	// Initialize background worker
+	logger.Info("Initializing background worker...")
+	workerConfig := worker.Config{
+		WorkerID:         fmt.Sprintf("worker-%s", uuid.New().String()[:8]),
+		PollInterval:     10 * time.Second, // Fallback; jobs are dispatched on NOTIFY
+		MaxConcurrency:   5,                // Queues without their own limit
+		QueueConcurrency: worker.DefaultQueueConcurrency(),
+		Queue:            "",  // Process all queues
+		TenantID:         nil, // Process all tenants and system jobs
+	}
+	jobListener := worker.NewPGListener(pool, logger)
+	bgWorker := worker.NewWorker(repo, emailService, invoiceService, fulfillmentBatchService, shipmentTrackingService, inventoryService, reviewService, dunningService, standingOrderService, saasOnboardingService, jobListener, workerConfig, logger)
+	logger.Info("Background worker initialized")
+
+	// Initialize recurring job scheduler (only the instance holding the
+	// advisory lock enqueues jobs)
+	jobScheduler, err := scheduler.New(
+		repo,
+		scheduler.NewAdvisoryLock(pool, scheduler.LockKey),
+		scheduler.DefaultDefinitions(),
+		scheduler.Config{},
+		logger,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize job scheduler: %w", err)
+	}
+
 	// Initialize onboarding service
 	onboardingService := onboarding.NewService(repo)
 
@@ -437,16 +521,28 @@ func run() error {
 		ForgotPasswordHandler: admin.NewForgotPasswordHandler(operatorService, renderer),
 		ResetPasswordHandler:  admin.NewResetPasswordHandler(operatorService, renderer),
 		DashboardHandler:      admin.NewDashboardHandler(repo, renderer, onboardingService),
-		ProductHandler:        admin.NewProductHandler(repo, renderer, fileStorage),
-		OrderHandler:          admin.NewOrderHandler(repo, renderer),
-		CustomerHandler:       admin.NewCustomerHandler(repo, invoiceService, renderer),
-		SubscriptionHandler:   admin.NewSubscriptionHandler(repo, renderer),
+		ProductHandler:        admin.NewProductHandler(repo, categoryService, whiteLabelService, renderer, fileStorage),
+		WhiteLabelHandler:     admin.NewWhiteLabelHandler(whiteLabelService, renderer),
+		CategoryHandler:       admin.NewCategoryHandler(categoryService, renderer),
+		ReviewHandler:         admin.NewReviewHandler(reviewService, renderer),
+		OrderHandler:          admin.NewOrderHandler(repo, refundService, shippingLabelService, renderer),
+		FulfillmentHandler:    admin.NewFulfillmentHandler(fulfillmentBatchService, roastService, renderer),
+		RoastHandler:          admin.NewRoastHandler(roastService, repo, renderer),
+		InventoryHandler:      admin.NewInventoryHandler(inventoryService, roastService, renderer),
+		CustomerHandler:       admin.NewCustomerHandler(repo, invoiceService, orderRulesService, renderer),
+		SubscriptionHandler:   admin.NewSubscriptionHandler(repo, dunningService, renderer),
 		InvoiceHandler:        admin.NewInvoiceHandler(invoiceService, repo, renderer),
+		StandingOrderHandler:  admin.NewStandingOrderHandler(standingOrderService, renderer),
 		PriceListHandler:      admin.NewPriceListHandler(repo, renderer),
+		DiscountHandler:       admin.NewDiscountHandler(discountService, renderer),
 		TaxRateHandler:        admin.NewTaxRateHandler(repo, renderer),
 		IntegrationsHandler:   admin.NewIntegrationsHandler(repo, renderer, encryptor, providerValidator, providerRegistry),
 		CustomDomainHandler:   admin.NewCustomDomainHandler(customDomainService, renderer),
 		PageHandler:           admin.NewPageHandler(pageService, renderer),
+		EmailTemplateHandler:  admin.NewEmailTemplateHandler(service.NewEmailTemplateService(repo, emailService), renderer),
+		BrandingHandler:       admin.NewBrandingHandler(brandingService, renderer),
+		ScheduleHandler:       admin.NewScheduleHandler(repo, renderer),
+		JobHandler:            admin.NewJobHandler(service.NewJobService(repo), renderer),
 		OnboardingHandler:     admin.NewOnboardingHandler(onboardingService, renderer),
 	}
 
@@ -457,13 +553,18 @@ func run() error {
 	if webhookTestMode {
 		slog.Warn("Stripe webhook TEST MODE enabled - tenant isolation checks bypassed")
 	}
-	stripeWebhookHandler := webhook.NewStripeHandler(billingProvider, orderService, subscriptionService, webhook.StripeWebhookConfig{
+	stripeWebhookHandler := webhook.NewStripeHandler(billingProvider, orderService, subscriptionService, refundService, dunningService, webhook.StripeWebhookConfig{
 		WebhookSecret: cfg.Stripe.WebhookSecret,
 		TenantID:      cfg.TenantID,
 		TestMode:      webhookTestMode,
 	})
+	easyPostWebhookHandler := webhook.NewEasyPostHandler(shipmentTrackingService, webhook.EasyPostWebhookConfig{
+		WebhookSecret: cfg.Shipping.EasyPostWebhookSecret,
+		TenantID:      cfg.TenantID,
+	})
 	webhookDeps := routes.WebhookDeps{
-		StripeHandler: stripeWebhookHandler.HandleWebhook,
+		StripeHandler:   stripeWebhookHandler.HandleWebhook,
+		EasyPostHandler: easyPostWebhookHandler.HandleWebhook,
 	}
 
 	// ==========================================================================
@@ -524,7 +625,6 @@ func run() error {
 		middleware.Timeout(),
 		middleware.RateLimit(),
 		router.Logger(logger),
-		middleware.WithUser(userService),
 		telemetry.SentryContextMiddleware(cfg.TenantID, userExtractor), // Set tenant/user context for Sentry
 		middleware.WithRequestLogger(logger),
 		middleware.CSRF(csrfConfig),
@@ -550,10 +650,13 @@ func run() error {
 		DomainValidationHandler: api.NewDomainValidationHandler(customDomainService, logger),
 	}
 
-	// Determine tenant middleware for storefront routes
+	// Determine tenant middleware for storefront routes: resolve the tenant
+	// from the host, or serve the configured tenant in single-tenant mode
 	var tenantMiddleware func(http.Handler) http.Handler
 	if cfg.Domain.HostRouting {
 		tenantMiddleware = middleware.ResolveTenant(tenantCfg)
+	} else {
+		tenantMiddleware = middleware.WithTenant(&tenant.Tenant{ID: tenantPgUUID, Status: "active"})
 	}
 
 	// Register route groups
@@ -562,10 +665,9 @@ func run() error {
 	routes.RegisterAPIRoutes(r, apiDeps)
 	routes.RegisterWebhookRoutes(r, webhookDeps)
 
-	// Apply stricter rate limiting to auth endpoints
+	// Apply stricter rate limiting to admin login (storefront login and
+	// signup are rate limited in RegisterStorefrontRoutes)
 	authRouter := r.Group(middleware.StrictRateLimit())
-	authRouter.Post("/login", storefrontDeps.AuthHandler.HandleLogin)
-	authRouter.Post("/signup", storefrontDeps.AuthHandler.HandleSignup)
 	authRouter.Post("/admin/login", adminDeps.LoginHandler.HandleSubmit)
 
 	// SaaS marketing site router (separate, can be served on different port/domain)
@@ -588,13 +690,23 @@ func run() error {
 	defer shutdownCancel()
 
 	// Start background worker
+	workerDone := make(chan struct{})
 	go func() {
+		defer close(workerDone)
 		logger.Info("Starting background worker")
 		if err := bgWorker.Start(shutdownCtx); err != nil && err != context.Canceled {
 			logger.Error("Background worker error", "error", err)
 		}
 	}()
 
+	// Start recurring job scheduler
+	go func() {
+		logger.Info("Starting job scheduler")
+		if err := jobScheduler.Start(shutdownCtx); err != nil && err != context.Canceled {
+			logger.Error("Job scheduler error", "error", err)
+		}
+	}()
+
 	// Channel to listen for interrupt signals
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -724,6 +836,10 @@ func run() error {
 		}
 	}
 
+	// Wait for the worker to drain in-flight jobs (bounded by its
+	// ShutdownTimeout)
+	<-workerDone
+
 	logger.Info("Graceful shutdown complete")
 	return nil
 }
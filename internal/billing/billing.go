@@ -75,6 +75,11 @@ type Provider interface {
 	// Post-MVP: For subscription management.
 	CancelSubscription(ctx context.Context, params CancelSubscriptionParams) error
 
+	// UpdateSubscription changes a subscription's price, quantity, payment
+	// method or next billing date without prorating.
+	// SECURITY: Validates tenant_id ownership before updating.
+	UpdateSubscription(ctx context.Context, params UpdateSubscriptionParams) (*Subscription, error)
+
 	// CreateCustomerPortalSession creates a Stripe Customer Portal session.
 	// Returns session URL where customer can manage subscriptions and payment methods.
 	CreateCustomerPortalSession(ctx context.Context, params CreatePortalSessionParams) (*PortalSession, error)
@@ -401,6 +406,27 @@ type CancelSubscriptionParams struct {
 	CancellationReason string
 }
 
+// UpdateSubscriptionParams contains parameters for updating a subscription.
+// Empty fields are left unchanged.
+type UpdateSubscriptionParams struct {
+	SubscriptionID string
+	TenantID       string
+
+	// ItemID is the subscription item (si_...) to change. Required with
+	// PriceID or Quantity.
+	ItemID   string
+	PriceID  string // New price for the item (price_...)
+	Quantity int32  // New quantity for the item
+
+	DefaultPaymentMethodID string // pm_...
+
+	// TrialEnd moves the next billing date. No invoice is created until then.
+	TrialEnd *time.Time
+
+	// Metadata updates or adds metadata fields
+	Metadata map[string]string
+}
+
 // CreatePortalSessionParams contains parameters for creating a customer portal session.
 type CreatePortalSessionParams struct {
 	CustomerID string
@@ -493,9 +519,11 @@ type UpdateCustomerParams struct {
 // RefundParams contains parameters for creating a refund.
 type RefundParams struct {
 	PaymentIntentID string
+	TenantID        string // For multi-tenant isolation
 	AmountCents     int32  // If 0, refunds full amount
 	Reason          string // "duplicate", "fraudulent", "requested_by_customer"
 	Metadata        map[string]string
+	IdempotencyKey  string // Prevent duplicate refunds on retry
 }
 
 // Refund represents a payment refund.
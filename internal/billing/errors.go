@@ -108,6 +108,9 @@ var (
 	// ErrMissingSubscriptionID is returned when subscription_id is required but not provided.
 	ErrMissingSubscriptionID = newBillingError(codeInvalid, "Subscription ID is required")
 
+	// ErrMissingSubscriptionItemID is returned when a subscription item change has no item ID.
+	ErrMissingSubscriptionItemID = newBillingError(codeInvalid, "Subscription item ID is required")
+
 	// ErrMissingInvoiceID is returned when invoice_id is required but not provided.
 	ErrMissingInvoiceID = newBillingError(codeInvalid, "Invoice ID is required")
 
@@ -146,6 +149,9 @@ var (
 
 	// ErrMissingSubscriptionMetadata is returned when subscription metadata is missing tenant_id.
 	ErrMissingSubscriptionMetadata = newBillingError(codeInvalid, "Subscription missing tenant_id metadata")
+
+	// ErrRefundExceedsBalance is returned when a refund is larger than the unrefunded amount.
+	ErrRefundExceedsBalance = newBillingError(codeInvalid, "Refund amount exceeds remaining balance")
 )
 
 // ============================================================================
@@ -32,6 +32,9 @@ type MockProvider struct {
 	// Customers stores created customers for retrieval
 	Customers map[string]*Customer
 
+	// Refunds stores issued refunds keyed by refund ID
+	Refunds map[string]*Refund
+
 	// CallLog tracks method calls for test assertions
 	CallLog []string
 }
@@ -41,6 +44,7 @@ func NewMockProvider() *MockProvider {
 	return &MockProvider{
 		PaymentIntents: make(map[string]*PaymentIntent),
 		Customers:      make(map[string]*Customer),
+		Refunds:        make(map[string]*Refund),
 		CallLog:        []string{},
 	}
 }
@@ -227,6 +231,12 @@ func (m *MockProvider) CancelSubscription(ctx context.Context, params CancelSubs
 	return ErrNotImplemented
 }
 
+// UpdateSubscription updates a mock subscription.
+func (m *MockProvider) UpdateSubscription(ctx context.Context, params UpdateSubscriptionParams) (*Subscription, error) {
+	m.CallLog = append(m.CallLog, fmt.Sprintf("UpdateSubscription(%s)", params.SubscriptionID))
+	return nil, ErrNotImplemented
+}
+
 // CreateCustomerPortalSession creates a mock customer portal session.
 func (m *MockProvider) CreateCustomerPortalSession(ctx context.Context, params CreatePortalSessionParams) (*PortalSession, error) {
 	m.CallLog = append(m.CallLog, fmt.Sprintf("CreateCustomerPortalSession(%s)", params.CustomerID))
@@ -234,9 +244,46 @@ func (m *MockProvider) CreateCustomerPortalSession(ctx context.Context, params C
 }
 
 // RefundPayment refunds a mock payment.
+// Refunds the remaining balance when AmountCents is 0.
 func (m *MockProvider) RefundPayment(ctx context.Context, params RefundParams) (*Refund, error) {
-	m.CallLog = append(m.CallLog, "RefundPayment")
-	return nil, ErrNotImplemented
+	m.CallLog = append(m.CallLog, fmt.Sprintf("RefundPayment(%s, %d)", params.PaymentIntentID, params.AmountCents))
+
+	pi, exists := m.PaymentIntents[params.PaymentIntentID]
+	if !exists {
+		return nil, ErrPaymentIntentNotFound
+	}
+
+	// Validate tenant ownership
+	if pi.Metadata == nil || pi.Metadata["tenant_id"] != params.TenantID {
+		return nil, ErrPaymentIntentNotFound
+	}
+
+	var refunded int64
+	for _, r := range m.Refunds {
+		if r.PaymentID == pi.ID {
+			refunded += r.Amount
+		}
+	}
+
+	remaining := int64(pi.AmountCents) - refunded
+	amount := int64(params.AmountCents)
+	if amount == 0 {
+		amount = remaining
+	}
+	if amount <= 0 || amount > remaining {
+		return nil, ErrRefundExceedsBalance
+	}
+
+	refund := &Refund{
+		ID:        "re_" + uuid.New().String(),
+		PaymentID: pi.ID,
+		Amount:    amount,
+		Status:    "succeeded",
+		CreatedAt: time.Now(),
+	}
+
+	m.Refunds[refund.ID] = refund
+	return refund, nil
 }
 
 // CreateProduct creates a mock product.
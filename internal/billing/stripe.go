@@ -14,6 +14,7 @@ import (
 	"github.com/stripe/stripe-go/v83/paymentintent"
 	"github.com/stripe/stripe-go/v83/price"
 	"github.com/stripe/stripe-go/v83/product"
+	"github.com/stripe/stripe-go/v83/refund"
 	"github.com/stripe/stripe-go/v83/subscription"
 	"github.com/stripe/stripe-go/v83/webhook"
 )
@@ -887,6 +888,72 @@ func (s *StripeProvider) CancelSubscription(ctx context.Context, params CancelSu
 	return nil
 }
 
+// UpdateSubscription changes a subscription in place.
+//
+// Changes never prorate: a new price or quantity is charged from the next
+// invoice. Setting TrialEnd moves the next invoice to that date, which is how
+// a delivery is skipped or a new interval is started on the current schedule.
+//
+// SECURITY: Validates tenant_id ownership before updating.
+func (s *StripeProvider) UpdateSubscription(ctx context.Context, params UpdateSubscriptionParams) (*Subscription, error) {
+	// Validate required params
+	if params.SubscriptionID == "" {
+		return nil, ErrMissingSubscriptionID
+	}
+	if params.TenantID == "" {
+		return nil, ErrMissingTenantID
+	}
+	if (params.PriceID != "" || params.Quantity != 0) && params.ItemID == "" {
+		return nil, ErrMissingSubscriptionItemID
+	}
+	if params.Quantity < 0 {
+		return nil, ErrInvalidQuantity
+	}
+
+	// Verify tenant ownership
+	_, err := s.GetSubscription(ctx, GetSubscriptionParams{
+		SubscriptionID: params.SubscriptionID,
+		TenantID:       params.TenantID,
+	})
+	if err != nil {
+		return nil, err // Returns ErrSubscriptionNotFound if tenant mismatch
+	}
+
+	subParams := &stripe.SubscriptionParams{
+		ProrationBehavior: stripe.String("none"),
+	}
+
+	if params.ItemID != "" {
+		item := &stripe.SubscriptionItemsParams{ID: stripe.String(params.ItemID)}
+		if params.PriceID != "" {
+			item.Price = stripe.String(params.PriceID)
+		}
+		if params.Quantity > 0 {
+			item.Quantity = stripe.Int64(int64(params.Quantity))
+		}
+		subParams.Items = []*stripe.SubscriptionItemsParams{item}
+	}
+
+	if params.DefaultPaymentMethodID != "" {
+		subParams.DefaultPaymentMethod = stripe.String(params.DefaultPaymentMethodID)
+	}
+
+	if params.TrialEnd != nil {
+		subParams.TrialEnd = stripe.Int64(params.TrialEnd.Unix())
+	}
+
+	for key, value := range params.Metadata {
+		subParams.AddMetadata(key, value)
+	}
+
+	stripeSubscription, err := subscription.Update(params.SubscriptionID, subParams)
+	if err != nil {
+		return nil, wrapStripeError(err)
+	}
+
+	return buildSubscription(stripeSubscription), nil
+}
+
 // CreateCustomerPortalSession creates a Stripe Customer Portal session.
 //
 // Returns session URL where customer can:
@@ -1086,20 +1153,56 @@ func buildInvoice(stripeInvoice *stripe.Invoice) *Invoice {
 //   - "requested_by_customer": Customer requested refund
 //
 // Note: Stripe fees are not refunded for partial refunds.
+//
+// SECURITY: In multi-tenant systems, this method validates that the payment
+// intent belongs to the requesting tenant before issuing the refund.
 func (s *StripeProvider) RefundPayment(ctx context.Context, params RefundParams) (*Refund, error) {
-	// TODO: Post-MVP implementation
-	//
-	// Steps:
-	// 1. Validate params.PaymentIntentID
-	// 2. Build stripe.RefundParams:
-	//    - PaymentIntent: params.PaymentIntentID
-	//    - Amount: params.AmountCents (omit for full refund)
-	//    - Reason: params.Reason
-	//    - Metadata: params.Metadata
-	// 3. Call refund.New()
-	// 4. Map response to Refund
-	// 5. Return Refund
-	return nil, ErrNotImplemented
+	if params.PaymentIntentID == "" {
+		return nil, ErrMissingPaymentIntentID
+	}
+
+	// CRITICAL: Validate tenant_id is provided for multi-tenant isolation
+	if params.TenantID == "" {
+		return nil, ErrMissingTenantID
+	}
+
+	// Verify the payment intent belongs to the requesting tenant before refunding
+	_, err := s.GetPaymentIntent(ctx, GetPaymentIntentParams{
+		PaymentIntentID: params.PaymentIntentID,
+		TenantID:        params.TenantID,
+	})
+	if err != nil {
+		return nil, err // Returns ErrPaymentIntentNotFound if tenant mismatch
+	}
+
+	refundParams := &stripe.RefundParams{
+		PaymentIntent: stripe.String(params.PaymentIntentID),
+	}
+
+	// Omit amount for a full refund of the remaining balance
+	if params.AmountCents > 0 {
+		refundParams.Amount = stripe.Int64(int64(params.AmountCents))
+	}
+
+	if params.Reason != "" {
+		refundParams.Reason = stripe.String(params.Reason)
+	}
+
+	refundParams.AddMetadata("tenant_id", params.TenantID)
+	for k, v := range params.Metadata {
+		refundParams.AddMetadata(k, v)
+	}
+
+	if params.IdempotencyKey != "" {
+		refundParams.SetIdempotencyKey(params.IdempotencyKey)
+	}
+
+	stripeRefund, err := refund.New(refundParams)
+	if err != nil {
+		return nil, wrapStripeError(err)
+	}
+
+	return buildRefund(stripeRefund), nil
 }
 
 // Helper functions (not exported)
@@ -1282,6 +1385,27 @@ func buildProduct(stripeProduct *stripe.Product) *Product {
 	}
 }
 
+// buildRefund maps Stripe Refund to our Refund type.
+// Centralizes mapping logic used by RefundPayment method.
+func buildRefund(stripeRefund *stripe.Refund) *Refund {
+	if stripeRefund == nil {
+		return nil
+	}
+
+	r := &Refund{
+		ID:        stripeRefund.ID,
+		Amount:    stripeRefund.Amount,
+		Status:    string(stripeRefund.Status),
+		CreatedAt: time.Unix(stripeRefund.Created, 0),
+	}
+
+	if stripeRefund.PaymentIntent != nil {
+		r.PaymentID = stripeRefund.PaymentIntent.ID
+	}
+
+	return r
+}
+
 // validateAmount checks if amount meets Stripe's minimum requirements.
 func validateAmount(amountCents int32, currency string) error {
 	currencyLower := strings.ToLower(currency)
@@ -647,6 +647,67 @@ func TestCancelPaymentIntent(t *testing.T) {
 	}
 }
 
+// TestRefundPayment tests full and partial refunds against the mock provider
+func TestRefundPayment(t *testing.T) {
+	newSucceededPI := func(m *MockProvider) {
+		m.PaymentIntents["pi_test_123"] = &PaymentIntent{
+			ID:          "pi_test_123",
+			AmountCents: 5000,
+			Currency:    "usd",
+			Status:      "succeeded",
+			Metadata:    map[string]string{"tenant_id": "tenant_abc", "cart_id": "cart_123"},
+			CreatedAt:   time.Now(),
+		}
+	}
+
+	t.Run("partial refunds then refunds remaining balance", func(t *testing.T) {
+		mock := NewMockProvider()
+		newSucceededPI(mock)
+
+		partial, err := mock.RefundPayment(context.Background(), RefundParams{
+			PaymentIntentID: "pi_test_123",
+			TenantID:        "tenant_abc",
+			AmountCents:     1500,
+			Reason:          "requested_by_customer",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1500), partial.Amount)
+		assert.Equal(t, "pi_test_123", partial.PaymentID)
+
+		full, err := mock.RefundPayment(context.Background(), RefundParams{
+			PaymentIntentID: "pi_test_123",
+			TenantID:        "tenant_abc",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3500), full.Amount)
+	})
+
+	t.Run("rejects amount over remaining balance", func(t *testing.T) {
+		mock := NewMockProvider()
+		newSucceededPI(mock)
+
+		refund, err := mock.RefundPayment(context.Background(), RefundParams{
+			PaymentIntentID: "pi_test_123",
+			TenantID:        "tenant_abc",
+			AmountCents:     6000,
+		})
+		assert.True(t, errors.Is(err, ErrRefundExceedsBalance))
+		assert.Nil(t, refund)
+	})
+
+	t.Run("rejects other tenant", func(t *testing.T) {
+		mock := NewMockProvider()
+		newSucceededPI(mock)
+
+		_, err := mock.RefundPayment(context.Background(), RefundParams{
+			PaymentIntentID: "pi_test_123",
+			TenantID:        "tenant_other",
+			AmountCents:     1000,
+		})
+		assert.True(t, errors.Is(err, ErrPaymentIntentNotFound))
+	})
+}
+
 // TestVerifyWebhookSignature tests webhook signature verification
 func TestVerifyWebhookSignature(t *testing.T) {
 	tests := []struct {
@@ -968,17 +1029,6 @@ func TestPostMVP_MethodsReturnNotImplemented(t *testing.T) {
 		assert.Error(t, err)
 		assert.True(t, errors.Is(err, ErrNotImplemented))
 	})
-
-	t.Run("RefundPayment returns not implemented", func(t *testing.T) {
-		refund, err := mock.RefundPayment(ctx, RefundParams{
-			PaymentIntentID: "pi_test_123",
-			AmountCents:     1000,
-			Reason:          "requested_by_customer",
-		})
-		assert.Error(t, err)
-		assert.True(t, errors.Is(err, ErrNotImplemented))
-		assert.Nil(t, refund)
-	})
 }
 
 // TestStripeConfig_Validation tests configuration validation
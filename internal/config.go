@@ -19,6 +19,8 @@ type Config struct {
 	BaseURL       string
 	EncryptionKey string // Base64-encoded 32-byte key for encrypting provider credentials
 	Stripe        StripeConfig
+	Shipping      ShippingConfig
+	Reviews       ReviewConfig
 	Email         EmailConfig
 	Admin         AdminConfig
 	Storage       StorageConfig
@@ -82,6 +84,17 @@ type StripeConfig struct {
 	SaaSWebhookSecret string // Webhook secret for SaaS subscription events
 }
 
+// ShippingConfig contains shipping provider webhook configuration.
+type ShippingConfig struct {
+	EasyPostWebhookSecret string // Webhook secret for EasyPost tracker events
+	TrackingEmails        bool   // Email customers when a package is delivered or runs into a problem
+}
+
+// ReviewConfig contains product review settings.
+type ReviewConfig struct {
+	RequestDelayDays int // Days after delivery to email a review request; 0 disables the emails
+}
+
 type EmailConfig struct {
 	Host          string
 	Port          uint16
@@ -138,6 +151,13 @@ func NewConfig() (*Config, error) {
 			SaaSPriceID:      getEnv("STRIPE_SAAS_PRICE_ID", ""),       // Required for SaaS signup
 			SaaSWebhookSecret: getEnv("STRIPE_SAAS_WEBHOOK_SECRET", ""), // Separate webhook for SaaS events
 		},
+		Shipping: ShippingConfig{
+			EasyPostWebhookSecret: getEnv("EASYPOST_WEBHOOK_SECRET", ""), // Tracking falls back to polling when unset
+			TrackingEmails:        getEnvBool("SHIPMENT_TRACKING_EMAILS", true),
+		},
+		Reviews: ReviewConfig{
+			RequestDelayDays: int(getEnvInt("REVIEW_REQUEST_DELAY_DAYS", 7)),
+		},
 		Email: EmailConfig{
 			Host:          getEnv("SMTP_HOST", "localhost"),
 			Port:          getEnvInt("SMTP_PORT", 1025),
@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"regexp"
+)
+
+// Branding domain errors.
+var (
+	ErrInvalidBrandColor     = &Error{Code: EINVALID, Message: "Colors must be hex values like #2A7D7D"}
+	ErrInvalidBrandFont      = &Error{Code: EINVALID, Message: "Choose a font from the list"}
+	ErrInvalidBrandImageKind = &Error{Code: EINVALID, Message: "Unknown branding image"}
+	ErrBrandImageTooLarge    = &Error{Code: EINVALID, Message: "Images must be smaller than 2MB"}
+	ErrBrandLogoType         = &Error{Code: EINVALID, Message: "Logos must be PNG, JPEG or WebP images"}
+	ErrBrandFaviconType      = &Error{Code: EINVALID, Message: "Favicons must be PNG or ICO images"}
+)
+
+// BrandImageKind identifies an uploaded branding image.
+type BrandImageKind string
+
+// Branding images.
+const (
+	BrandImageLogo    BrandImageKind = "logo"
+	BrandImageFavicon BrandImageKind = "favicon"
+)
+
+// MaxBrandImageSize bounds logo and favicon uploads.
+const MaxBrandImageSize = 2 << 20
+
+// BrandFont is a font choice for the storefront and emails. Fonts are
+// system font stacks rather than web fonts, so they need no external
+// requests and render the same in email clients.
+type BrandFont struct {
+	Key   string
+	Name  string
+	Stack string // CSS font-family list
+}
+
+// BrandFonts are the fonts a tenant can choose from.
+var BrandFonts = []BrandFont{
+	{Key: "system", Name: "System", Stack: `ui-sans-serif, system-ui, -apple-system, "Segoe UI", Roboto, "Helvetica Neue", Arial, sans-serif`},
+	{Key: "humanist", Name: "Humanist", Stack: `Seravek, "Gill Sans Nova", Ubuntu, Calibri, "DejaVu Sans", source-sans-pro, sans-serif`},
+	{Key: "geometric", Name: "Geometric", Stack: `Avenir, Montserrat, Corbel, "URW Gothic", source-sans-pro, sans-serif`},
+	{Key: "classic", Name: "Classic serif", Stack: `Charter, "Bitstream Charter", "Sitka Text", Cambria, Georgia, serif`},
+	{Key: "oldstyle", Name: "Old style serif", Stack: `"Iowan Old Style", "Palatino Linotype", "URW Palladio L", P052, Georgia, serif`},
+	{Key: "slab", Name: "Slab serif", Stack: `Rockwell, "Rockwell Nova", "Roboto Slab", "DejaVu Serif", "Sitka Small", serif`},
+	{Key: "mono", Name: "Monospace", Stack: `ui-monospace, "Cascadia Code", "Source Code Pro", Menlo, Consolas, "DejaVu Sans Mono", monospace`},
+}
+
+// LookupBrandFont returns the font with the given key.
+func LookupBrandFont(key string) (BrandFont, bool) {
+	for _, f := range BrandFonts {
+		if f.Key == key {
+			return f, true
+		}
+	}
+	return BrandFont{}, false
+}
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// IsHexColor reports whether s is a six digit hex color such as #2A7D7D.
+func IsHexColor(s string) bool {
+	return hexColorPattern.MatchString(s)
+}
+
+// Branding is a tenant's logo, favicon, colors and fonts, applied to the
+// storefront and customer emails.
+type Branding struct {
+	LogoURL    string `json:"logo_url,omitempty"`
+	LogoKey    string `json:"logo_key,omitempty"` // Storage key, to delete a replaced logo
+	FaviconURL string `json:"favicon_url,omitempty"`
+	FaviconKey string `json:"favicon_key,omitempty"`
+
+	// PrimaryColor is used for buttons and links, AccentColor for
+	// highlights such as badges. Both are #RRGGBB.
+	PrimaryColor string `json:"primary_color,omitempty"`
+	AccentColor  string `json:"accent_color,omitempty"`
+
+	// HeadingFont and BodyFont are BrandFont keys.
+	HeadingFont string `json:"heading_font,omitempty"`
+	BodyFont    string `json:"body_font,omitempty"`
+}
+
+// DefaultBranding applies until a tenant sets their own. It matches the
+// stock storefront theme.
+func DefaultBranding() Branding {
+	return Branding{
+		PrimaryColor: "#2A7D7D",
+		AccentColor:  "#B5873A",
+		HeadingFont:  "system",
+		BodyFont:     "system",
+	}
+}
+
+// WithDefaults fills unset colors and fonts from DefaultBranding.
+func (b Branding) WithDefaults() Branding {
+	def := DefaultBranding()
+	if b.PrimaryColor == "" {
+		b.PrimaryColor = def.PrimaryColor
+	}
+	if b.AccentColor == "" {
+		b.AccentColor = def.AccentColor
+	}
+	if b.HeadingFont == "" {
+		b.HeadingFont = def.HeadingFont
+	}
+	if b.BodyFont == "" {
+		b.BodyFont = def.BodyFont
+	}
+	return b
+}
+
+// Validate checks the colors are hex values and the fonts are known.
+func (b Branding) Validate() error {
+	if !IsHexColor(b.PrimaryColor) || !IsHexColor(b.AccentColor) {
+		return ErrInvalidBrandColor
+	}
+	if _, ok := LookupBrandFont(b.HeadingFont); !ok {
+		return ErrInvalidBrandFont
+	}
+	if _, ok := LookupBrandFont(b.BodyFont); !ok {
+		return ErrInvalidBrandFont
+	}
+	return nil
+}
+
+// HeadingFontStack returns the CSS font-family list for headings.
+func (b Branding) HeadingFontStack() string {
+	return fontStack(b.HeadingFont)
+}
+
+// BodyFontStack returns the CSS font-family list for body text.
+func (b Branding) BodyFontStack() string {
+	return fontStack(b.BodyFont)
+}
+
+func fontStack(key string) string {
+	if f, ok := LookupBrandFont(key); ok {
+		return f.Stack
+	}
+	return BrandFonts[0].Stack
+}
+
+// BrandingService manages a tenant's branding.
+type BrandingService interface {
+	// GetBranding returns the tenant's branding, with defaults for anything
+	// unset.
+	GetBranding(ctx context.Context) (*Branding, error)
+
+	// UpdateBranding validates and saves the tenant's colors and fonts.
+	UpdateBranding(ctx context.Context, params UpdateBrandingParams) (*Branding, error)
+
+	// UploadImage stores a new logo or favicon, replacing the previous one.
+	UploadImage(ctx context.Context, params UploadBrandImageParams) (*Branding, error)
+
+	// RemoveImage deletes the logo or favicon.
+	RemoveImage(ctx context.Context, kind BrandImageKind) (*Branding, error)
+}
+
+// UpdateBrandingParams contains a tenant's colors and fonts.
+type UpdateBrandingParams struct {
+	PrimaryColor string
+	AccentColor  string
+	HeadingFont  string
+	BodyFont     string
+}
+
+// UploadBrandImageParams contains an uploaded logo or favicon.
+type UploadBrandImageParams struct {
+	Kind    BrandImageKind
+	Content io.Reader
+	Size    int64
+}
@@ -27,7 +27,12 @@ type CartService interface {
 	// GetCart retrieves an existing cart by session ID.
 	GetCart(ctx context.Context, sessionID string) (*Cart, error)
 
+	// SetUser links the cart to a signed-in customer and reprices its items
+	// from the customer's price list.
+	SetUser(ctx context.Context, cartID string, userID string) error
+
 	// AddItem adds a product SKU to the cart or updates quantity if already present.
+	// Items are priced at the quantity-break tier the line reaches.
 	AddItem(ctx context.Context, cartID string, skuID string, quantity int) (*CartSummary, error)
 
 	// UpdateItemQuantity updates the quantity of a cart item.
@@ -48,6 +53,7 @@ type CartService interface {
 type Cart struct {
 	ID        pgtype.UUID
 	TenantID  pgtype.UUID
+	UserID    pgtype.UUID
 	SessionID pgtype.UUID
 	CreatedAt pgtype.Timestamptz
 	UpdatedAt pgtype.Timestamptz
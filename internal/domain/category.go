@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Catalog organization domain errors.
+var (
+	ErrCategoryNotFound      = &Error{Code: ENOTFOUND, Message: "Category not found"}
+	ErrCategoryNameRequired  = &Error{Code: EINVALID, Message: "Category name is required"}
+	ErrDuplicateCategorySlug = &Error{Code: ECONFLICT, Message: "Another category already uses this URL"}
+	ErrInvalidCategoryParent = &Error{Code: EINVALID, Message: "A category can't be nested under itself or one of its subcategories"}
+	ErrTagNotFound           = &Error{Code: ENOTFOUND, Message: "Tag not found"}
+	ErrTagNameRequired       = &Error{Code: EINVALID, Message: "Tag name is required"}
+	ErrDuplicateTagSlug      = &Error{Code: ECONFLICT, Message: "Another tag already uses this URL"}
+	ErrInvalidCatalogSlug    = &Error{Code: EINVALID, Message: "URLs can only contain lowercase letters, numbers and hyphens"}
+)
+
+// CategoryService manages the nested categories and flat tags that organize
+// the catalog, their assignment to products and the storefront collection
+// pages built from categories.
+// Implementations should be tenant-scoped.
+type CategoryService interface {
+	// ListCategories returns every category in tree order: each category
+	// is followed by its subcategories.
+	ListCategories(ctx context.Context) ([]CategoryTreeItem, error)
+
+	// GetCategory retrieves a category by ID.
+	GetCategory(ctx context.Context, categoryID string) (*repository.ProductCategory, error)
+
+	// CreateCategory creates a category. The slug is derived from the name
+	// when empty.
+	CreateCategory(ctx context.Context, params CategoryParams) (*repository.ProductCategory, error)
+
+	// UpdateCategory updates a category. A category can't be moved under
+	// itself or one of its subcategories.
+	UpdateCategory(ctx context.Context, categoryID string, params CategoryParams) (*repository.ProductCategory, error)
+
+	// DeleteCategory deletes a category and its product assignments.
+	// Its subcategories move up to the deleted category's parent.
+	DeleteCategory(ctx context.Context, categoryID string) error
+
+	// ListTags returns every tag with the number of products tagged.
+	ListTags(ctx context.Context) ([]repository.ListProductTagsRow, error)
+
+	// GetTag retrieves a tag by ID.
+	GetTag(ctx context.Context, tagID string) (*repository.ProductTag, error)
+
+	// CreateTag creates a tag. The slug is derived from the name when empty.
+	CreateTag(ctx context.Context, params TagParams) (*repository.ProductTag, error)
+
+	// UpdateTag renames a tag.
+	UpdateTag(ctx context.Context, tagID string, params TagParams) (*repository.ProductTag, error)
+
+	// DeleteTag deletes a tag and removes it from products.
+	DeleteTag(ctx context.Context, tagID string) error
+
+	// GetProductAssignments returns the categories and tags of a product.
+	GetProductAssignments(ctx context.Context, productID string) (*ProductAssignments, error)
+
+	// SetProductAssignments replaces the categories and tags of a product.
+	// IDs that don't belong to the tenant are ignored.
+	SetProductAssignments(ctx context.Context, productID string, assignments ProductAssignments) error
+
+	// GetCollection returns an active category by slug for its storefront
+	// landing page, with its active ancestors and subcategories.
+	GetCollection(ctx context.Context, slug string) (*Collection, error)
+}
+
+// CategoryParams contains the editable fields of a category.
+type CategoryParams struct {
+	Name            string
+	Slug            string // Derived from Name when empty
+	Description     string
+	ParentID        string // Empty for a top-level category
+	SortOrder       int32
+	IsActive        bool
+	MetaTitle       string
+	MetaDescription string
+}
+
+// TagParams contains the editable fields of a tag.
+type TagParams struct {
+	Name string
+	Slug string // Derived from Name when empty
+}
+
+// CategoryTreeItem is a category with its depth in the category tree,
+// 0 for top-level categories.
+type CategoryTreeItem struct {
+	repository.ListProductCategoriesRow
+	Depth int
+}
+
+// ProductAssignments lists the category and tag IDs of a product.
+type ProductAssignments struct {
+	CategoryIDs []string
+	TagIDs      []string
+}
+
+// Collection is a category shown as a storefront landing page.
+type Collection struct {
+	Category      repository.ListProductCategoriesRow
+	Breadcrumbs   []repository.ListProductCategoriesRow // Active ancestors, top level first
+	Subcategories []repository.ListProductCategoriesRow // Active children
+}
@@ -36,6 +36,7 @@ type OrderTotalParams struct {
 	BillingAddress       address.Address
 	SelectedShippingRate shipping.Rate
 	DiscountCode         string
+	CustomerEmail        string // Used to enforce per-customer discount limits
 }
 
 // OrderTotal contains the breakdown of an order's total cost.
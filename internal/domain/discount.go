@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Discount code errors.
+var (
+	ErrDiscountCodeNotFound   = &Error{Code: ENOTFOUND, Message: "Discount code not found"}
+	ErrDiscountCodeInactive   = &Error{Code: EINVALID, Message: "Discount code is not active"}
+	ErrDiscountCodeNotStarted = &Error{Code: EINVALID, Message: "Discount code is not valid yet"}
+	ErrDiscountCodeExpired    = &Error{Code: EINVALID, Message: "Discount code has expired"}
+	ErrDiscountCodeExhausted  = &Error{Code: EINVALID, Message: "Discount code has reached its usage limit"}
+	ErrDiscountCustomerLimit  = &Error{Code: EINVALID, Message: "You have already used this discount code"}
+	ErrDiscountMinimumNotMet  = &Error{Code: EINVALID, Message: "Order does not meet the minimum for this discount code"}
+	ErrDuplicateDiscountCode  = &Error{Code: ECONFLICT, Message: "Discount code already exists"}
+	ErrInvalidDiscountType    = &Error{Code: EINVALID, Message: "Discount type must be percentage, fixed_amount or free_shipping"}
+	ErrDiscountAmountChanged  = &Error{Code: ECONFLICT, Message: "Discount no longer applies to this order, please review your total"}
+)
+
+// Discount types supported by discount_codes.discount_type.
+const (
+	DiscountTypePercentage   = "percentage"
+	DiscountTypeFixedAmount  = "fixed_amount"
+	DiscountTypeFreeShipping = "free_shipping"
+)
+
+// DiscountService validates promotional codes, computes discount amounts
+// and manages discount codes for the admin.
+type DiscountService interface {
+	// ApplyCode validates a code against the order and returns the discount it yields.
+	// Validation covers active flag, starts_at/expires_at, minimum order,
+	// global usage limit and per-customer usage limit.
+	ApplyCode(ctx context.Context, params ApplyDiscountParams) (*AppliedDiscount, error)
+
+	// RedeemCode atomically records one use of a discount code for an order.
+	// Returns ErrDiscountCodeExhausted if the code ran out in the meantime.
+	RedeemCode(ctx context.Context, params RedeemDiscountParams) error
+
+	// ListDiscountCodes lists all discount codes for the tenant.
+	ListDiscountCodes(ctx context.Context) ([]repository.DiscountCode, error)
+
+	// GetDiscountCode retrieves a discount code by ID.
+	GetDiscountCode(ctx context.Context, discountCodeID string) (*repository.DiscountCode, error)
+
+	// CreateDiscountCode creates a new discount code.
+	CreateDiscountCode(ctx context.Context, params DiscountCodeParams) (*repository.DiscountCode, error)
+
+	// UpdateDiscountCode updates an existing discount code.
+	UpdateDiscountCode(ctx context.Context, discountCodeID string, params DiscountCodeParams) (*repository.DiscountCode, error)
+
+	// DeleteDiscountCode deletes a discount code and its usage history.
+	DeleteDiscountCode(ctx context.Context, discountCodeID string) error
+}
+
+// ApplyDiscountParams contains the order context a code is validated against.
+type ApplyDiscountParams struct {
+	Code          string
+	SubtotalCents int32
+	ShippingCents int32
+	UserID        pgtype.UUID // Optional - resolved from CustomerEmail when not set
+	CustomerEmail string      // Optional - used for per-customer limits on guest checkout
+}
+
+// AppliedDiscount is the result of applying a valid code to an order.
+type AppliedDiscount struct {
+	DiscountCodeID pgtype.UUID
+	Code           string
+	DiscountType   string
+	DiscountCents  int32
+	FreeShipping   bool
+}
+
+// RedeemDiscountParams contains parameters for recording a redemption.
+type RedeemDiscountParams struct {
+	DiscountCodeID string
+	UserID         pgtype.UUID
+	OrderID        pgtype.UUID
+	DiscountCents  int32
+}
+
+// DiscountCodeParams contains the editable fields of a discount code.
+type DiscountCodeParams struct {
+	Code                  string
+	Description           string
+	DiscountType          string
+	DiscountValue         int32
+	MinimumOrderCents     *int32
+	UsageLimit            *int32
+	UsageLimitPerCustomer *int32
+	StartsAt              time.Time
+	ExpiresAt             *time.Time
+	IsActive              bool
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Dunning domain errors.
+var (
+	ErrInvalidDunningSchedule    = &Error{Code: EINVALID, Message: "Retry days must be 1 to 6 increasing days, each between 1 and 30"}
+	ErrInvalidDunningFinalAction = &Error{Code: EINVALID, Message: "Choose whether to pause or cancel after the final retry"}
+	ErrDunningLinkExpired        = &Error{Code: ENOTFOUND, Message: "This link has expired. Sign in to update your payment method."}
+)
+
+// Final actions once every retry of a failed subscription payment has failed.
+const (
+	DunningFinalActionPause  = "pause"
+	DunningFinalActionCancel = "cancel"
+)
+
+// Dunning statuses, as stored in subscription_dunning.status.
+const (
+	DunningStatusActive    = "active"
+	DunningStatusRecovered = "recovered"
+	DunningStatusPaused    = "paused"
+	DunningStatusCancelled = "cancelled"
+)
+
+const (
+	// MaxDunningRetries bounds the number of retries in a schedule.
+	MaxDunningRetries = 6
+
+	// MaxDunningRetryDay bounds how long after the failed renewal the last
+	// retry can be.
+	MaxDunningRetryDay = 30
+)
+
+// DunningSettings is a tenant's schedule for retrying failed subscription
+// payments.
+type DunningSettings struct {
+	// RetryDays are the days after the failed renewal on which payment is
+	// retried, e.g. [3, 5, 7]. A reminder is emailed after each failure.
+	RetryDays []int32 `json:"retry_days"`
+
+	// FinalAction is DunningFinalActionPause or DunningFinalActionCancel,
+	// taken when the last retry fails.
+	FinalAction string `json:"final_action"`
+}
+
+// DefaultDunningSettings apply until a tenant sets their own schedule.
+func DefaultDunningSettings() DunningSettings {
+	return DunningSettings{
+		RetryDays:   []int32{3, 5, 7},
+		FinalAction: DunningFinalActionPause,
+	}
+}
+
+// Validate checks the retry days are increasing and in range and the final
+// action is known.
+func (s DunningSettings) Validate() error {
+	if len(s.RetryDays) == 0 || len(s.RetryDays) > MaxDunningRetries {
+		return ErrInvalidDunningSchedule
+	}
+	var previous int32
+	for _, day := range s.RetryDays {
+		if day <= previous || day > MaxDunningRetryDay {
+			return ErrInvalidDunningSchedule
+		}
+		previous = day
+	}
+
+	switch s.FinalAction {
+	case DunningFinalActionPause, DunningFinalActionCancel:
+		return nil
+	}
+	return ErrInvalidDunningFinalAction
+}
+
+// NextRetryAt returns when to retry after the given number of payment
+// attempts, counting the failed renewal as the first. ok is false once every
+// retry has been made.
+func (s DunningSettings) NextRetryAt(startedAt time.Time, attempts int32) (next time.Time, ok bool) {
+	retry := int(attempts) - 1
+	if retry < 0 || retry >= len(s.RetryDays) {
+		return time.Time{}, false
+	}
+	return startedAt.AddDate(0, 0, int(s.RetryDays[retry])), true
+}
+
+// DunningService retries failed subscription payments on the tenant's
+// schedule, emails a reminder after each failure and pauses or cancels the
+// subscription when the final retry fails. Implementations should be
+// tenant-scoped.
+type DunningService interface {
+	// GetSettings returns the tenant's retry schedule, or the default.
+	GetSettings(ctx context.Context) (*DunningSettings, error)
+
+	// UpdateSettings validates and saves the tenant's retry schedule. It
+	// applies to retries scheduled from then on.
+	UpdateSettings(ctx context.Context, settings DunningSettings) error
+
+	// RecordPaymentFailure starts dunning when a subscription renewal payment
+	// fails: it schedules the first retry and emails the customer. Failures
+	// for a subscription already in dunning are ignored; retries are
+	// tracked by ProcessDue.
+	RecordPaymentFailure(ctx context.Context, params PaymentFailureParams) error
+
+	// RecordPaymentSuccess ends dunning for a paid invoice.
+	RecordPaymentSuccess(ctx context.Context, providerInvoiceID string) error
+
+	// ProcessDue retries every payment whose retry is due.
+	ProcessDue(ctx context.Context) (*DunningResult, error)
+
+	// GetForSubscription returns the subscription's most recent dunning, or
+	// nil if a payment has never failed.
+	GetForSubscription(ctx context.Context, subscriptionID string) (*repository.SubscriptionDunning, error)
+
+	// PaymentUpdateURL exchanges the token from a reminder's update payment
+	// link for a billing portal session where the customer can change their
+	// card. Returns ErrDunningLinkExpired once dunning has ended or a newer
+	// reminder has been sent.
+	PaymentUpdateURL(ctx context.Context, token string) (string, error)
+}
+
+// PaymentFailureParams identifies a failed subscription renewal payment.
+type PaymentFailureParams struct {
+	ProviderSubscriptionID string
+	ProviderInvoiceID      string
+}
+
+// DunningResult summarizes a ProcessDue run.
+type DunningResult struct {
+	Retried   int // Payments retried
+	Recovered int // Retries that were paid
+	Failed    int // Retries that failed with retries remaining
+	Exhausted int // Final retries that failed; subscription paused or cancelled
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Email template domain errors.
+var (
+	ErrEmailTemplateNotFound = &Error{Code: ENOTFOUND, Message: "Email template not found"}
+	ErrEmailSubjectRequired  = &Error{Code: EINVALID, Message: "Subject is required"}
+	ErrEmailBodyRequired     = &Error{Code: EINVALID, Message: "Email body is required"}
+)
+
+// EmailTemplateService manages a tenant's customized transactional emails.
+// Each customer-facing email can be overridden with the tenant's own
+// subject and body; emails without an active override use the default.
+type EmailTemplateService interface {
+	// ListTemplates returns every customizable email, noting which the
+	// tenant has customized.
+	ListTemplates(ctx context.Context) ([]EmailTemplateSummary, error)
+
+	// GetTemplate returns the tenant's version of an email, or the default
+	// when it hasn't been customized.
+	// Returns ErrEmailTemplateNotFound for an unknown type.
+	GetTemplate(ctx context.Context, templateType string) (*EmailTemplateDetail, error)
+
+	// SaveTemplate validates and saves the tenant's version of an email.
+	// A template that doesn't render against sample data returns EINVALID.
+	SaveTemplate(ctx context.Context, params SaveEmailTemplateParams) error
+
+	// ResetTemplate deletes the tenant's version, so the default is sent.
+	ResetTemplate(ctx context.Context, templateType string) error
+
+	// PreviewTemplate renders an unsaved version against sample data.
+	PreviewTemplate(ctx context.Context, params SaveEmailTemplateParams) (*EmailPreview, error)
+
+	// SendTestEmail sends an unsaved version, rendered against sample data,
+	// to the given address.
+	SendTestEmail(ctx context.Context, params SaveEmailTemplateParams, to string) error
+}
+
+// EmailTemplateSummary describes a customizable email for the settings list.
+type EmailTemplateSummary struct {
+	Type        string
+	Name        string
+	Description string
+	Customized  bool // The tenant has saved their own version
+	Active      bool // The tenant's version is sent instead of the default
+	UpdatedAt   time.Time
+}
+
+// EmailTemplateDetail is an email's editable content.
+type EmailTemplateDetail struct {
+	EmailTemplateSummary
+	Subject   string
+	BodyHTML  string
+	BodyText  string
+	Variables []EmailTemplateVariable
+	Functions []EmailTemplateVariable
+}
+
+// EmailTemplateVariable documents a value or function available to a template.
+type EmailTemplateVariable struct {
+	Name        string
+	Description string
+}
+
+// SaveEmailTemplateParams contains the edited content of an email.
+type SaveEmailTemplateParams struct {
+	Type     string
+	Subject  string
+	BodyHTML string
+	BodyText string // Optional; generated from BodyHTML when empty
+	Active   bool
+}
+
+// EmailPreview is an email rendered against sample data.
+type EmailPreview struct {
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"io"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Fulfillment batch domain errors.
+var (
+	ErrFulfillmentBatchNotFound = &Error{Code: ENOTFOUND, Message: "Fulfillment batch not found"}
+	ErrNoOrdersSelected         = &Error{Code: EINVALID, Message: "Select at least one order"}
+	ErrTooManyBatchOrders       = &Error{Code: EINVALID, Message: "A batch can include at most 100 orders"}
+	ErrOrdersNotInQueue         = &Error{Code: EINVALID, Message: "Some selected orders are no longer waiting to ship"}
+	ErrBatchDocumentNotReady    = &Error{Code: EINVALID, Message: "The batch document is not ready yet"}
+)
+
+// FulfillmentBatchService buys shipping labels for many orders at once and
+// produces the paperwork to pick, pack and ship them.
+// Implementations should be tenant-scoped.
+type FulfillmentBatchService interface {
+	// ListQueue returns paid orders with unshipped items that are not already
+	// part of a batch in progress, oldest first.
+	ListQueue(ctx context.Context) ([]repository.ListFulfillmentQueueRow, error)
+
+	// PickList aggregates the unshipped items of the given orders by SKU.
+	PickList(ctx context.Context, orderIDs []string) ([]PickListLine, error)
+
+	// CreateBatch records a batch for the given orders and queues a background
+	// job to buy their labels.
+	CreateBatch(ctx context.Context, orderIDs []string) (*repository.FulfillmentBatch, error)
+
+	// ProcessBatch buys labels for the batch's pending orders and stores the
+	// merged labels and packing slips document. Safe to retry.
+	ProcessBatch(ctx context.Context, batchID string) error
+
+	// GetBatch returns a batch with its orders.
+	GetBatch(ctx context.Context, batchID string) (*FulfillmentBatchDetail, error)
+
+	// ListBatches returns recent batches, newest first.
+	ListBatches(ctx context.Context) ([]repository.FulfillmentBatch, error)
+
+	// OpenDocument opens the batch's merged PDF.
+	// The caller must close the returned reader.
+	OpenDocument(ctx context.Context, batchID string) (io.ReadCloser, error)
+}
+
+// PickListLine is the total quantity of one SKU to pick across orders.
+// SKUs are per weight and grind, so each line is a single bag to pull.
+type PickListLine struct {
+	SKU                string
+	ProductName        string
+	VariantDescription string // e.g., "12oz - Whole Bean"
+	Quantity           int32
+	OrderCount         int
+}
+
+// FulfillmentBatchDetail aggregates a batch with its orders.
+type FulfillmentBatchDetail struct {
+	Batch  repository.FulfillmentBatch
+	Orders []repository.ListFulfillmentBatchOrdersRow
+}
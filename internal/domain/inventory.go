@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Inventory adjustment reasons, as stored in inventory_adjustments.reason.
+const (
+	// AdjustmentReasonOrder is stock sold on an order.
+	AdjustmentReasonOrder = "order"
+
+	// AdjustmentReasonOrderCancelled is stock returned by a cancelled order.
+	AdjustmentReasonOrderCancelled = "order_cancelled"
+
+	// AdjustmentReasonRefundRestock is stock returned by a refund.
+	AdjustmentReasonRefundRestock = "refund_restock"
+
+	// AdjustmentReasonCount sets stock to a physical count.
+	AdjustmentReasonCount = "count"
+
+	// AdjustmentReasonSpoilage removes stale or damaged stock.
+	AdjustmentReasonSpoilage = "spoilage"
+
+	// AdjustmentReasonRoastReceipt adds bags packed from a roast batch.
+	AdjustmentReasonRoastReceipt = "roast_receipt"
+)
+
+// Inventory domain errors.
+var (
+	ErrInvalidAdjustmentReason   = &Error{Code: EINVALID, Message: "Choose a reason for the adjustment"}
+	ErrInvalidAdjustmentQuantity = &Error{Code: EINVALID, Message: "Enter a whole number of units, more than zero"}
+	ErrInvalidStockCount         = &Error{Code: EINVALID, Message: "Enter the number of units counted, zero or more"}
+	ErrAdjustmentBelowZero       = &Error{Code: EINVALID, Message: "There is not enough stock to remove that many units"}
+	ErrInvalidLowStockThreshold  = &Error{Code: EINVALID, Message: "Low stock threshold must be zero or more"}
+)
+
+// InventoryService records stock changes in the inventory ledger and reports
+// SKUs running low. Implementations should be tenant-scoped.
+type InventoryService interface {
+	// Adjust changes a SKU's stock for a manual reason (count, spoilage or
+	// roast receipt) and records it in the ledger.
+	Adjust(ctx context.Context, params InventoryAdjustmentParams) (*repository.InventoryAdjustment, error)
+
+	// GetSKU returns a SKU's stock and low stock threshold.
+	GetSKU(ctx context.Context, skuID string) (*repository.GetInventorySKURow, error)
+
+	// ListAdjustments returns a SKU's most recent ledger entries, newest first.
+	ListAdjustments(ctx context.Context, skuID string, limit int32) ([]repository.ListSKUInventoryAdjustmentsRow, error)
+
+	// ListInventory returns the stock of every active SKU.
+	ListInventory(ctx context.Context) ([]repository.ListInventorySKUsRow, error)
+
+	// ListLowStock returns active SKUs at or below their low stock threshold,
+	// or out of stock when they have none.
+	ListLowStock(ctx context.Context) ([]repository.ListLowStockSKUsRow, error)
+
+	// SetLowStockThreshold sets the stock level at which a SKU is reported
+	// as low. Nil reports it only when out of stock.
+	SetLowStockThreshold(ctx context.Context, skuID string, threshold *int32) error
+
+	// SendLowStockDigest emails each active operator the SKUs running low.
+	// Nothing is sent when no SKU is low. Returns the number of SKUs listed.
+	SendLowStockDigest(ctx context.Context) (int, error)
+}
+
+// InventoryAdjustmentParams contains a manual stock adjustment.
+// Quantity is the units counted for a count, the units removed for spoilage
+// and the units added for a roast receipt.
+type InventoryAdjustmentParams struct {
+	SKUID        string
+	Reason       string
+	Quantity     int32
+	Note         string
+	RoastBatchID string // Optional, for roast receipts
+	OperatorID   string // Operator making the adjustment
+}
+
+// IsManualAdjustmentReason reports whether an operator can record an
+// adjustment with the given reason. Order and refund entries are written
+// only by those flows.
+func IsManualAdjustmentReason(reason string) bool {
+	switch reason {
+	case AdjustmentReasonCount, AdjustmentReasonSpoilage, AdjustmentReasonRoastReceipt:
+		return true
+	}
+	return false
+}
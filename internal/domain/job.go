@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Job console errors.
+var (
+	ErrJobNotFound         = &Error{Code: ENOTFOUND, Message: "Job not found"}
+	ErrJobNotRetryable     = &Error{Code: ECONFLICT, Message: "Only failed or cancelled jobs can be retried"}
+	ErrJobNotCancellable   = &Error{Code: ECONFLICT, Message: "Only pending jobs can be cancelled"}
+	ErrJobNotReschedulable = &Error{Code: ECONFLICT, Message: "Running and completed jobs cannot be rescheduled"}
+	ErrInvalidJobStatus    = &Error{Code: EINVALID, Message: "Unknown job status"}
+	ErrJobTypeRequired     = &Error{Code: EINVALID, Message: "Choose a job type to requeue"}
+)
+
+// Job statuses, as stored in jobs.status. A failed job has used all of its
+// retries; failed jobs make up the dead-letter view.
+const (
+	JobStatusPending    = "pending"
+	JobStatusProcessing = "processing"
+	JobStatusCompleted  = "completed"
+	JobStatusFailed     = "failed"
+	JobStatusCancelled  = "cancelled"
+)
+
+// JobFilter selects jobs in the jobs console. Empty fields match all.
+type JobFilter struct {
+	Queue   string
+	JobType string
+	Status  string
+
+	// TenantID narrows the platform view to one tenant. It is ignored for
+	// tenant operators, who only ever see their own jobs.
+	TenantID string
+
+	Limit  int32
+	Offset int32
+}
+
+// JobList is a page of jobs and the total matching the filter.
+type JobList struct {
+	Jobs  []repository.Job
+	Total int64
+}
+
+// JobService lets staff inspect and act on background jobs. Operators of
+// a tenant see that tenant's jobs; operators of the platform's master
+// tenant see every tenant's jobs and system jobs.
+type JobService interface {
+	// IsPlatform reports whether the current tenant sees all jobs.
+	IsPlatform(ctx context.Context) (bool, error)
+
+	// ListJobs returns the jobs matching the filter, newest first.
+	ListJobs(ctx context.Context, filter JobFilter) (*JobList, error)
+
+	// GetJob returns a job by ID.
+	GetJob(ctx context.Context, jobID string) (*repository.Job, error)
+
+	// RetryJob queues a failed or cancelled job to run now with a fresh
+	// set of retries.
+	RetryJob(ctx context.Context, jobID string) (*repository.Job, error)
+
+	// CancelJob cancels a pending job.
+	CancelJob(ctx context.Context, jobID string) (*repository.Job, error)
+
+	// RescheduleJob moves a pending, failed or cancelled job to run at the
+	// given time.
+	RescheduleJob(ctx context.Context, jobID string, at time.Time) (*repository.Job, error)
+
+	// RequeueFailed retries every failed job of a type, optionally only
+	// those that failed since a time, and returns how many were queued.
+	RequeueFailed(ctx context.Context, jobType string, failedSince *time.Time) (int64, error)
+
+	// ListFailedJobTypes summarizes the dead-letter view by job type.
+	ListFailedJobTypes(ctx context.Context) ([]repository.ListFailedJobTypesRow, error)
+
+	// QueueStats returns pending, processing and failed counts per queue.
+	QueueStats(ctx context.Context) ([]repository.ListJobQueueStatsRow, error)
+
+	// ListJobTypes returns the job types present, for filtering.
+	ListJobTypes(ctx context.Context) ([]string, error)
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/dukerupert/hiri/internal/address"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
+)
+
+// On-account ordering errors.
+var (
+	ErrOnAccountNotEnabled     = &Error{Code: EFORBIDDEN, Message: "Ordering on account is not enabled for this customer"}
+	ErrCreditLimitExceeded     = &Error{Code: EINVALID, Message: "Order exceeds your available credit"}
+	ErrShippingRateUnavailable = &Error{Code: EINVALID, Message: "Selected shipping rate is no longer available"}
+)
+
+// OnAccountService places wholesale orders billed to the customer's account
+// under their payment terms, instead of charged to a card.
+// Implementations should be tenant-scoped.
+type OnAccountService interface {
+	// GetAccountCredit returns a customer's payment terms and credit.
+	// Returns ErrOnAccountNotEnabled unless the customer is an active
+	// wholesale customer with payment terms.
+	GetAccountCredit(ctx context.Context, userID string) (*AccountCredit, error)
+
+	// PlaceOrder creates a wholesale order from the customer's cart and
+	// bills it to their account: invoiced immediately for the on_order
+	// billing cycle, otherwise left for the next consolidated invoice.
+	PlaceOrder(ctx context.Context, params PlaceOnAccountOrderParams) (*OrderDetail, error)
+}
+
+// AccountCredit describes what a customer can order on account.
+type AccountCredit struct {
+	PaymentTerms     repository.PaymentTerm
+	BillingCycle     BillingCycle
+	HasLimit         bool
+	LimitCents       int32
+	OutstandingCents int32 // Unpaid invoice balances plus uninvoiced orders
+}
+
+// AvailableCents returns the credit left before the limit is reached.
+// Only meaningful when HasLimit is true.
+func (c *AccountCredit) AvailableCents() int32 {
+	if c.OutstandingCents >= c.LimitCents {
+		return 0
+	}
+	return c.LimitCents - c.OutstandingCents
+}
+
+// Allows reports whether an order of totalCents fits within the credit limit.
+func (c *AccountCredit) Allows(totalCents int32) bool {
+	return !c.HasLimit || c.OutstandingCents+totalCents <= c.LimitCents
+}
+
+// PlaceOnAccountOrderParams contains parameters for placing an order on account.
+type PlaceOnAccountOrderParams struct {
+	CartID          string
+	UserID          string
+	ShippingAddress address.Address
+	BillingAddress  address.Address
+	ShippingRate    shipping.Rate // Re-quoted; matched by carrier and service
+	DiscountCode    string
+	CustomerNotes   string
+	PONumber        string
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Wholesale order rule errors.
+var (
+	ErrQuantityRuleNotMet  = &Error{Code: EINVALID, Message: "Some items don't meet their minimum or case-pack quantity"}
+	ErrInvalidQuantityRule = &Error{Code: EINVALID, Message: "Minimum quantity and case pack must be at least 1"}
+)
+
+// OrderRulesService enforces wholesale ordering requirements: the customer's
+// minimum order value and each SKU's minimum and case-pack quantities.
+// Quantity rules are set on the SKU and can be overridden per customer.
+// Implementations should be tenant-scoped.
+type OrderRulesService interface {
+	// CheckCart checks a customer's cart against their order rules.
+	// Only wholesale customers have rules; other carts always pass.
+	CheckCart(ctx context.Context, userID, cartID string) (*OrderRulesCheck, error)
+
+	// ListCustomerRules returns a customer's quantity rule overrides and the
+	// SKUs they can order.
+	ListCustomerRules(ctx context.Context, userID string) (*CustomerOrderRules, error)
+
+	// SetCustomerRule overrides a SKU's quantity rules for a customer.
+	SetCustomerRule(ctx context.Context, params SetCustomerRuleParams) error
+
+	// DeleteCustomerRule removes a customer's override so the SKU's own
+	// rules apply again.
+	DeleteCustomerRule(ctx context.Context, userID, skuID string) error
+}
+
+// QuantityRule is the quantity a wholesale order line must meet.
+// Zero values mean no rule.
+type QuantityRule struct {
+	MinQuantity int32
+	CasePack    int32
+}
+
+// EffectiveQuantityRule returns the rule for a SKU: the customer's override
+// if they have one, otherwise the SKU's own rules. An override replaces both
+// rules, so a NULL override column waives that rule for the customer.
+func EffectiveQuantityRule(skuMin, skuCasePack pgtype.Int4, hasCustomerRule bool, customerMin, customerCasePack pgtype.Int4) QuantityRule {
+	if hasCustomerRule {
+		return QuantityRule{MinQuantity: customerMin.Int32, CasePack: customerCasePack.Int32}
+	}
+	return QuantityRule{MinQuantity: skuMin.Int32, CasePack: skuCasePack.Int32}
+}
+
+// IsZero reports whether the rule allows any quantity.
+func (r QuantityRule) IsZero() bool {
+	return r.MinQuantity <= 1 && r.CasePack <= 1
+}
+
+// Check returns what's wrong with ordering quantity, or "" if it's allowed.
+func (r QuantityRule) Check(quantity int32) string {
+	if r.MinQuantity > 1 && quantity < r.MinQuantity {
+		return fmt.Sprintf("Order at least %d", r.MinQuantity)
+	}
+	if r.CasePack > 1 && quantity%r.CasePack != 0 {
+		return fmt.Sprintf("Order in cases of %d", r.CasePack)
+	}
+	return ""
+}
+
+// OrderRulesCheck is the result of checking a cart against order rules.
+type OrderRulesCheck struct {
+	MinimumSpendCents int32 // 0 when the customer has no minimum
+	SubtotalCents     int32
+	QuantityIssues    []QuantityIssue
+}
+
+// QuantityIssue is a cart line that breaks its quantity rule.
+type QuantityIssue struct {
+	SKUID       pgtype.UUID
+	ProductName string
+	SKU         string
+	Quantity    int32
+	Rule        QuantityRule
+	Problem     string
+}
+
+// RemainingCents returns how much more the customer must order to reach
+// their minimum order value.
+func (c *OrderRulesCheck) RemainingCents() int32 {
+	if c.SubtotalCents >= c.MinimumSpendCents {
+		return 0
+	}
+	return c.MinimumSpendCents - c.SubtotalCents
+}
+
+// OK reports whether the cart can be checked out.
+func (c *OrderRulesCheck) OK() bool {
+	return c.RemainingCents() == 0 && len(c.QuantityIssues) == 0
+}
+
+// Err returns the first unmet requirement, or nil if the cart passes.
+func (c *OrderRulesCheck) Err() error {
+	if c.RemainingCents() > 0 {
+		return ErrMinimumSpendNotMet
+	}
+	if len(c.QuantityIssues) > 0 {
+		return ErrQuantityRuleNotMet
+	}
+	return nil
+}
+
+// CustomerOrderRules is a customer's quantity rule overrides, with the SKUs
+// that can be given one.
+type CustomerOrderRules struct {
+	Overrides []repository.ListCustomerSKUOrderRulesRow
+	SKUs      []repository.ListCustomerOrderableSKUsRow
+}
+
+// SetCustomerRuleParams contains parameters for overriding a SKU's quantity
+// rules for a customer. Zero waives the rule.
+type SetCustomerRuleParams struct {
+	UserID      string
+	SKUID       string
+	MinQuantity int32
+	CasePack    int32
+}
@@ -0,0 +1,26 @@
+package domain
+
+// ErrInvalidPriceTier is returned for a quantity break below 2 units or with a
+// negative price.
+var ErrInvalidPriceTier = &Error{Code: EINVALID, Message: "Tier quantity must be at least 2 and the price can't be negative"}
+
+// PriceTier is a quantity-break price on a price list entry: a cart line of
+// at least MinQuantity units is priced at PriceCents each.
+type PriceTier struct {
+	MinQuantity int32
+	PriceCents  int32
+}
+
+// TierPrice returns the unit price for a line of quantity units: the price of
+// the largest tier the quantity reaches, or basePriceCents if it reaches none.
+func TierPrice(basePriceCents int32, tiers []PriceTier, quantity int32) int32 {
+	price := basePriceCents
+	var reached int32
+	for _, tier := range tiers {
+		if quantity >= tier.MinQuantity && tier.MinQuantity > reached {
+			price = tier.PriceCents
+			reached = tier.MinQuantity
+		}
+	}
+	return price
+}
@@ -284,11 +284,13 @@ type ProductService interface {
 
 // ProductFilter contains optional filters for product listing.
 type ProductFilter struct {
-	RoastLevel  *string
-	Origin      *string
-	TastingNote *string
-	Status      *ProductStatus
-	Visibility  *ProductVisibility
+	RoastLevel   *string
+	Origin       *string
+	TastingNote  *string
+	CategorySlug *string // Includes products in the category's subcategories
+	TagSlug      *string
+	Status       *ProductStatus
+	Visibility   *ProductVisibility
 }
 
 // ProductFilterOptions contains available values for each filter.
@@ -296,6 +298,15 @@ type ProductFilterOptions struct {
 	RoastLevels  []string
 	Origins      []string
 	TastingNotes []string
+	Categories   []FilterFacet
+	Tags         []FilterFacet
+}
+
+// FilterFacet is a category or tag shoppers can filter products by.
+type FilterFacet struct {
+	Slug         string
+	Name         string
+	ProductCount int32
 }
 
 // CreateProductParams contains parameters for creating a product.
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Refund-related domain errors.
+var (
+	ErrOrderNotRefundable    = &Error{Code: EINVALID, Message: "Order cannot be refunded in its current status"}
+	ErrOrderNotPaidByCard    = &Error{Code: EINVALID, Message: "Order has no card payment to refund"}
+	ErrInvalidRefundMode     = &Error{Code: EINVALID, Message: "Refund type must be full, items, or amount"}
+	ErrInvalidRefundAmount   = &Error{Code: EINVALID, Message: "Refund amount must be greater than zero"}
+	ErrRefundExceedsBalance  = &Error{Code: EINVALID, Message: "Refund amount exceeds the unrefunded balance"}
+	ErrRefundItemNotFound    = &Error{Code: EINVALID, Message: "Refund item does not belong to this order"}
+	ErrRefundQuantityInvalid = &Error{Code: EINVALID, Message: "Refund quantity exceeds the unrefunded quantity"}
+	ErrOrderNotCancellable   = &Error{Code: EINVALID, Message: "Only orders that haven't shipped can be cancelled; void any shipping labels first"}
+	ErrOrderInvoiced         = &Error{Code: EINVALID, Message: "Order is on an open invoice; void the invoice before cancelling"}
+)
+
+// Refund modes accepted by RefundService.RefundOrder.
+const (
+	RefundModeFull   = "full"   // Refund the remaining balance and all unrefunded items
+	RefundModeItems  = "items"  // Refund selected line items and quantities
+	RefundModeAmount = "amount" // Refund an arbitrary amount without line items
+)
+
+// RefundService provides business logic for refunding orders.
+// Implementations should be tenant-scoped.
+type RefundService interface {
+	// RefundOrder issues a refund through the billing provider and records it
+	// against the order, updating order and payment status.
+	RefundOrder(ctx context.Context, params RefundOrderParams) (*repository.Refund, error)
+
+	// ListRefunds returns all refunds recorded for an order, newest first.
+	ListRefunds(ctx context.Context, orderID string) ([]repository.Refund, error)
+
+	// RecordProviderRefunds records refunds issued outside the app, such as
+	// from the Stripe dashboard. Called from the charge.refunded webhook.
+	// Idempotent: refunds that are already recorded are skipped.
+	RecordProviderRefunds(ctx context.Context, params ProviderRefundParams) error
+
+	// CancelOrder cancels an order that hasn't shipped. A card payment is
+	// refunded in full, or voided if it hasn't been captured.
+	CancelOrder(ctx context.Context, params CancelOrderParams) error
+}
+
+// RefundOrderParams contains parameters for refunding an order.
+type RefundOrderParams struct {
+	OrderID     string
+	Mode        string // full, items, or amount
+	Items       []RefundItemParams
+	AmountCents int32  // Required for amount mode
+	Reason      string // duplicate, fraudulent, requested_by_customer
+	Note        string // Internal note shown in the admin
+	Restock     bool   // Return refunded units to inventory
+}
+
+// CancelOrderParams contains parameters for cancelling an order.
+type CancelOrderParams struct {
+	OrderID string
+	Reason  string // Recorded in the order's status history
+	Restock bool   // Return the order's units to inventory
+}
+
+// RefundItemParams identifies an order line item and quantity to refund.
+type RefundItemParams struct {
+	OrderItemID string
+	Quantity    int32
+}
+
+// ProviderRefundParams describes a charge refunded at the payment provider.
+type ProviderRefundParams struct {
+	TenantID            pgtype.UUID
+	PaymentIntentID     string
+	ChargeID            string
+	AmountRefundedCents int64 // Cumulative amount refunded on the charge
+	Refunds             []ProviderRefund
+}
+
+// ProviderRefund is a single refund reported by the payment provider.
+type ProviderRefund struct {
+	ID          string
+	AmountCents int64
+	Reason      string
+	Status      string
+	Metadata    map[string]string
+}
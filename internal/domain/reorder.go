@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Order template errors.
+var (
+	ErrOrderTemplateNotFound = &Error{Code: ENOTFOUND, Message: "Order template not found"}
+	ErrOrderTemplateName     = &Error{Code: EINVALID, Message: "Give the template a name of up to 100 characters"}
+	ErrOrderTemplateEmpty    = &Error{Code: EINVALID, Message: "Enter a quantity for at least one item to save a template"}
+)
+
+// MaxOrderTemplateNameLength bounds an order template's name.
+const MaxOrderTemplateNameLength = 100
+
+// ReorderService helps customers order the same things again: refilling the
+// cart from a past order, and saving named order templates that prefill the
+// wholesale order form. Implementations should be tenant-scoped.
+type ReorderService interface {
+	// Reorder adds the items from one of the customer's orders to the cart,
+	// priced from their price list today. Items that are no longer available
+	// or out of stock are left out, and items with less stock than was
+	// ordered are added at the quantity in stock; each is reported in the
+	// result's warnings. The cart should already belong to the customer.
+	Reorder(ctx context.Context, userID, orderID, cartID string) (*ReorderResult, error)
+
+	// ListTemplates returns a customer's order templates by name.
+	ListTemplates(ctx context.Context, userID string) ([]repository.ListOrderTemplatesForUserRow, error)
+
+	// GetTemplate returns one of a customer's order templates with its
+	// quantities.
+	GetTemplate(ctx context.Context, userID, templateID string) (*OrderTemplateDetail, error)
+
+	// SaveTemplate saves the quantities under a name. Saving with the name of
+	// an existing template replaces its quantities.
+	SaveTemplate(ctx context.Context, params SaveOrderTemplateParams) (*OrderTemplateDetail, error)
+
+	// DeleteTemplate deletes one of a customer's order templates.
+	DeleteTemplate(ctx context.Context, userID, templateID string) error
+}
+
+// ReorderResult is what Reorder added to the cart.
+type ReorderResult struct {
+	ItemsAdded int
+	Warnings   []string // Items left out or added at a lower quantity, and price changes
+}
+
+// OrderTemplateDetail is an order template with its quantities, keyed by
+// SKU ID.
+type OrderTemplateDetail struct {
+	repository.OrderTemplate
+	Quantities map[string]int32
+}
+
+// SaveOrderTemplateParams contains parameters for saving an order template.
+// Quantities are keyed by SKU ID; zero quantities are ignored.
+type SaveOrderTemplateParams struct {
+	UserID     string
+	Name       string
+	Quantities map[string]int
+}
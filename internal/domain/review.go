@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Product review domain errors.
+var (
+	ErrReviewNotFound       = &Error{Code: ENOTFOUND, Message: "Review not found"}
+	ErrInvalidRating        = &Error{Code: EINVALID, Message: "Choose a rating from 1 to 5 stars"}
+	ErrReviewTitleTooLong   = &Error{Code: EINVALID, Message: "Review titles can be up to 255 characters"}
+	ErrAlreadyReviewed      = &Error{Code: ECONFLICT, Message: "You've already reviewed this product"}
+	ErrProductNotReviewable = &Error{Code: EINVALID, Message: "You can review a product once an order containing it has been delivered"}
+	ErrCannotVoteOwnReview  = &Error{Code: EINVALID, Message: "You can't vote on your own review"}
+)
+
+// Review moderation statuses.
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// ReviewService manages product reviews: submission by customers who
+// received the product, moderation by the roaster, the ratings shown on
+// product pages, helpful votes and the post-delivery request emails.
+// Implementations should be tenant-scoped.
+type ReviewService interface {
+	// ListReviewableItems returns the products in the given orders that
+	// the customer can review, with their existing review if any. Orders
+	// that aren't delivered are skipped.
+	ListReviewableItems(ctx context.Context, userID string, orderIDs []string) ([]repository.ListReviewableItemsForOrdersRow, error)
+
+	// GetReviewableItem returns a product from a customer's delivered order.
+	GetReviewableItem(ctx context.Context, userID, orderID, productID string) (*repository.GetReviewableOrderProductRow, error)
+
+	// SubmitReview creates a verified purchase review awaiting moderation.
+	// A customer can review each product once.
+	SubmitReview(ctx context.Context, params SubmitReviewParams) (*repository.ProductReview, error)
+
+	// ListReviewsForModeration returns the reviews with a moderation status,
+	// oldest first.
+	ListReviewsForModeration(ctx context.Context, status string, limit, offset int32) ([]repository.ListReviewsForModerationRow, error)
+
+	// CountReviewsByStatus returns the number of reviews in each moderation
+	// status.
+	CountReviewsByStatus(ctx context.Context) (map[string]int32, error)
+
+	// ApproveReview publishes a review on the product page.
+	ApproveReview(ctx context.Context, reviewID, notes string) (*repository.ProductReview, error)
+
+	// RejectReview hides a review from the product page.
+	RejectReview(ctx context.Context, reviewID, notes string) (*repository.ProductReview, error)
+
+	// ReplyToReview sets the public reply shown under a review. An empty
+	// reply removes it.
+	ReplyToReview(ctx context.Context, reviewID, reply string) (*repository.ProductReview, error)
+
+	// GetProductRatings returns the rating summary and approved reviews of
+	// a product, most helpful first.
+	GetProductRatings(ctx context.Context, productID string, limit int32) (*ProductRatings, error)
+
+	// VoteHelpful records whether a customer found a review helpful.
+	// Voting again changes the customer's vote. Customers can't vote on
+	// their own review.
+	VoteHelpful(ctx context.Context, userID, reviewID string, helpful bool) (*repository.ProductReview, error)
+
+	// SendReviewRequests enqueues a review request email for each retail
+	// order delivered the configured number of days ago. Each order is
+	// emailed at most once. Returns the number of emails enqueued.
+	SendReviewRequests(ctx context.Context) (int, error)
+}
+
+// SubmitReviewParams contains the fields of a new review.
+type SubmitReviewParams struct {
+	UserID    string
+	OrderID   string // Delivered order containing the product
+	ProductID string
+	Rating    int32
+	Title     string
+	Body      string
+}
+
+// ProductRatings is the review section of a product page.
+type ProductRatings struct {
+	Summary repository.GetProductRatingSummaryRow
+	Reviews []repository.ListApprovedReviewsForProductRow
+}
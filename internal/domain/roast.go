@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Roast schedule domain errors.
+var (
+	ErrRoastBatchNotFound       = &Error{Code: ENOTFOUND, Message: "Roast batch not found"}
+	ErrRoastBatchCancelled      = &Error{Code: EINVALID, Message: "This roast batch has been cancelled"}
+	ErrRoastBatchAlreadyRoasted = &Error{Code: EINVALID, Message: "Only batches that have not been roasted can be cancelled"}
+	ErrRoastProductRequired     = &Error{Code: EINVALID, Message: "Choose the coffee to roast"}
+	ErrRoastDateRequired        = &Error{Code: EINVALID, Message: "Enter the roast date"}
+	ErrRoastDateInFuture        = &Error{Code: EINVALID, Message: "The roast date cannot be in the future"}
+	ErrInvalidRoastWeight       = &Error{Code: EINVALID, Message: "Weights must be positive whole grams"}
+	ErrInvalidRestDays          = &Error{Code: EINVALID, Message: "Rest period must be between 0 and 30 days"}
+	ErrRoastAllocationMissing   = &Error{Code: ENOTFOUND, Message: "Allocation not found"}
+	ErrInvalidPlanWindow        = &Error{Code: EINVALID, Message: "Plan between 1 and 31 days ahead"}
+	ErrInvalidRoastLoss         = &Error{Code: EINVALID, Message: "Roast loss must be between 0 and 50 percent"}
+)
+
+const (
+	// MaxRoastRestDays bounds the rest period between roasting and shipping.
+	MaxRoastRestDays = 30
+
+	// MaxProductionPlanDays bounds the production plan window.
+	MaxProductionPlanDays = 31
+
+	// MaxRoastLossPercent bounds a product's roast loss.
+	MaxRoastLossPercent = 50
+)
+
+// RoastService plans roast batches, allocates orders and subscription
+// renewals to them and holds orders until their coffee has rested.
+// Implementations should be tenant-scoped.
+type RoastService interface {
+	// ListBatches returns batches planned or roasted on or after since,
+	// soonest first, with allocation totals.
+	ListBatches(ctx context.Context, since time.Time) ([]repository.ListRoastBatchesRow, error)
+
+	// GetBatch returns a batch with its allocations.
+	GetBatch(ctx context.Context, batchID string) (*RoastBatchDetail, error)
+
+	// CreateBatch plans a roast of one product.
+	CreateBatch(ctx context.Context, params RoastBatchParams) (*repository.RoastBatch, error)
+
+	// UpdateBatch changes a batch's plan.
+	UpdateBatch(ctx context.Context, batchID string, params RoastBatchParams) (*repository.RoastBatch, error)
+
+	// RecordRoast marks a batch roasted with its roast date and yield.
+	// Recording again corrects them.
+	RecordRoast(ctx context.Context, batchID string, params RecordRoastParams) (*repository.RoastBatch, error)
+
+	// CancelBatch cancels a batch that has not been roasted and releases
+	// its allocations.
+	CancelBatch(ctx context.Context, batchID string) error
+
+	// AllocateOpenOrders allocates unshipped items of the batch's product on
+	// paid orders not allocated to another batch.
+	// Returns the number of items allocated.
+	AllocateOpenOrders(ctx context.Context, batchID string) (int64, error)
+
+	// AllocateRenewals allocates the batch's product on active subscriptions
+	// renewing from today through the given date.
+	// Returns the number of renewals allocated.
+	AllocateRenewals(ctx context.Context, batchID string, through time.Time) (int64, error)
+
+	// RemoveAllocation releases one allocation from a batch.
+	RemoveAllocation(ctx context.Context, batchID, allocationID string) error
+
+	// ListRestingOrders returns paid orders held out of the fulfillment queue
+	// until their coffee is roasted and rested.
+	ListRestingOrders(ctx context.Context) ([]repository.ListRestingOrdersRow, error)
+
+	// GetRestDays returns how many days roasted coffee rests before
+	// allocated orders can ship.
+	GetRestDays(ctx context.Context) (int32, error)
+
+	// SetRestDays sets the rest period, from 0 to MaxRoastRestDays days.
+	SetRestDays(ctx context.Context, days int32) error
+
+	// ProductionPlan totals the coffee to roast per product per day for the
+	// given number of days from start: unshipped paid orders, pending
+	// wholesale orders with a requested delivery date and subscription
+	// renewals, less coffee already roasted for them.
+	ProductionPlan(ctx context.Context, start time.Time, days int) (*ProductionPlan, error)
+
+	// ListRoastLoss returns the roast loss of each active product.
+	ListRoastLoss(ctx context.Context) ([]repository.ListProductRoastLossRow, error)
+
+	// SetRoastLoss sets the percent of green weight a product loses when
+	// roasted, from 0 to MaxRoastLossPercent.
+	SetRoastLoss(ctx context.Context, productID string, percent float64) error
+}
+
+// RoastBatchParams contains the plan for a roast batch.
+// ProductID is ignored when updating a batch.
+type RoastBatchParams struct {
+	ProductID        string
+	PlannedFor       time.Time
+	GreenLot         string
+	GreenWeightGrams int32 // 0 if not known yet
+	Notes            string
+}
+
+// RecordRoastParams contains the outcome of a roast.
+type RecordRoastParams struct {
+	RoastedOn          time.Time
+	RoastedWeightGrams int32 // 0 if not weighed
+}
+
+// RoastBatchDetail aggregates a batch with its allocations.
+type RoastBatchDetail struct {
+	Batch       repository.GetRoastBatchRow
+	Allocations []repository.ListRoastBatchAllocationsRow
+}
+
+// ProductionPlan is the coffee to roast per day over a date range.
+type ProductionPlan struct {
+	Start time.Time
+	End   time.Time // Inclusive
+	Days  []ProductionDay
+}
+
+// ProductionDay is the coffee to roast on one day.
+type ProductionDay struct {
+	Date         time.Time
+	Lines        []ProductionLine
+	RoastedGrams int
+	GreenGrams   int
+}
+
+// ProductionLine is the coffee to roast of one product on one day.
+// Green weight is estimated from the roasted weight and the product's
+// roast loss.
+type ProductionLine struct {
+	ProductID         string
+	ProductName       string
+	Units             int
+	OrderGrams        int // Unshipped retail and renewal orders
+	WholesaleGrams    int // Paid and pending wholesale orders
+	SubscriptionGrams int // Upcoming subscription renewals
+	RoastedGrams      int
+	RoastLossPercent  float64
+	GreenGrams        int
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/dukerupert/hiri/internal/shipping"
+)
+
+// ShipmentTrackingService records carrier tracking updates against shipments
+// and moves shipments and orders along as packages travel.
+// Implementations should be tenant-scoped.
+type ShipmentTrackingService interface {
+	// RecordTracking stores the events of a provider tracking update for the
+	// shipment with its tracking number, advances the shipment's status and
+	// marks the order delivered once all of its shipments have arrived.
+	// Updates for tracking numbers we did not ship are ignored.
+	RecordTracking(ctx context.Context, info *shipping.TrackingInfo) error
+
+	// PollTracking asks the tenant's shipping provider for updates on
+	// shipments still in transit that have not been refreshed recently.
+	// Used for providers without tracking webhooks.
+	PollTracking(ctx context.Context) (*TrackingPollResult, error)
+}
+
+// TrackingPollResult summarizes a tracking poll.
+type TrackingPollResult struct {
+	Checked int // Shipments asked about
+	Updated int // Shipments whose status changed
+	Failed  int // Shipments the provider could not track; retried next poll
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"io"
+
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
+)
+
+// Shipping label domain errors.
+var (
+	ErrNoLabelProvider     = &Error{Code: EINVALID, Message: "Connect a shipping provider in Integrations to buy labels"}
+	ErrOrderNotShippable   = &Error{Code: EINVALID, Message: "Order cannot be shipped in its current status"}
+	ErrNoShippingAddress   = &Error{Code: EINVALID, Message: "Order has no shipping address"}
+	ErrNoWarehouseAddress  = &Error{Code: EINVALID, Message: "Add a warehouse address before buying labels"}
+	ErrInvalidPackage      = &Error{Code: EINVALID, Message: "Package weight and dimensions must be greater than zero"}
+	ErrRateRequired        = &Error{Code: EINVALID, Message: "Select a shipping rate"}
+	ErrNoLabelRates        = &Error{Code: EINVALID, Message: "No shipping rates are available for this package"}
+	ErrShipmentHasNoLabel  = &Error{Code: EINVALID, Message: "Shipment has no purchased label"}
+	ErrLabelAlreadyVoided  = &Error{Code: EINVALID, Message: "Label has already been voided"}
+	ErrLabelAlreadyShipped = &Error{Code: EINVALID, Message: "Label cannot be voided after the package is in transit"}
+)
+
+// ShippingLabelService buys and voids shipping labels for orders through the
+// tenant's configured shipping provider.
+// Implementations should be tenant-scoped.
+type ShippingLabelService interface {
+	// EstimatePackage suggests a package for the order's unshipped items.
+	EstimatePackage(ctx context.Context, orderID string) (shipping.Package, error)
+
+	// GetRates returns label rates for shipping the order in the given package,
+	// cheapest first.
+	GetRates(ctx context.Context, orderID string, pkg shipping.Package) ([]shipping.Rate, error)
+
+	// BuyLabel purchases a label for the order's unshipped items, stores the
+	// label file, records the shipment, marks the order shipped and queues the
+	// shipping confirmation email.
+	BuyLabel(ctx context.Context, params BuyLabelParams) (*repository.Shipment, error)
+
+	// VoidLabel voids one of an order's shipment labels with the provider,
+	// cancels the shipment and returns its items to the unfulfilled pool.
+	VoidLabel(ctx context.Context, orderID, shipmentID string) error
+
+	// OpenLabel opens the stored label file for a shipment.
+	// The caller must close the returned reader.
+	OpenLabel(ctx context.Context, shipmentID string) (io.ReadCloser, *repository.Shipment, error)
+}
+
+// BuyLabelParams contains parameters for purchasing a shipping label.
+type BuyLabelParams struct {
+	OrderID string
+	RateID  string           // Rate ID returned by GetRates
+	Package shipping.Package // Package the rate was quoted for
+}
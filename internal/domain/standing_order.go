@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Standing order errors.
+var (
+	ErrStandingOrderNotFound        = &Error{Code: ENOTFOUND, Message: "Standing order not found"}
+	ErrStandingOrderCancelled       = &Error{Code: EINVALID, Message: "This standing order has been cancelled"}
+	ErrStandingOrderEmpty           = &Error{Code: EINVALID, Message: "A standing order needs at least one item"}
+	ErrStandingOrderNotActive       = &Error{Code: EINVALID, Message: "Resume this standing order to skip a delivery"}
+	ErrStandingOrderAddress         = &Error{Code: EINVALID, Message: "Choose a delivery address from your address book"}
+	ErrInvalidStandingOrderSchedule = &Error{Code: EINVALID, Message: "Choose a delivery day and a delivery every 1 to 4 weeks"}
+	ErrInvalidStandingOrderSettings = &Error{Code: EINVALID, Message: "Cutoff must be 1 to 14 days before delivery and reminders 0 to 7 days before the cutoff"}
+)
+
+// Standing order statuses, as stored in standing_orders.status.
+const (
+	StandingOrderStatusActive    = "active"
+	StandingOrderStatusPaused    = "paused"
+	StandingOrderStatusCancelled = "cancelled"
+)
+
+const (
+	// MaxStandingOrderIntervalWeeks bounds the weeks between deliveries.
+	MaxStandingOrderIntervalWeeks = 4
+
+	// MaxStandingOrderCutoffDays bounds how far ahead of delivery an order
+	// is generated.
+	MaxStandingOrderCutoffDays = 14
+
+	// MaxStandingOrderReminderDays bounds how far ahead of the cutoff the
+	// reminder is sent.
+	MaxStandingOrderReminderDays = 7
+)
+
+// StandingOrderSettings are a tenant's lead times for standing orders.
+type StandingOrderSettings struct {
+	// CutoffDays is how many days before delivery the order is generated.
+	// Customers can change or skip a delivery until then.
+	CutoffDays int32 `json:"cutoff_days"`
+
+	// ReminderDays is how many days before the cutoff the customer is
+	// emailed. Zero sends no reminder.
+	ReminderDays int32 `json:"reminder_days"`
+}
+
+// DefaultStandingOrderSettings apply until a tenant sets their own lead times.
+func DefaultStandingOrderSettings() StandingOrderSettings {
+	return StandingOrderSettings{
+		CutoffDays:   2,
+		ReminderDays: 1,
+	}
+}
+
+// Validate checks the lead times are in range.
+func (s StandingOrderSettings) Validate() error {
+	if s.CutoffDays < 1 || s.CutoffDays > MaxStandingOrderCutoffDays {
+		return ErrInvalidStandingOrderSettings
+	}
+	if s.ReminderDays < 0 || s.ReminderDays > MaxStandingOrderReminderDays {
+		return ErrInvalidStandingOrderSettings
+	}
+	return nil
+}
+
+// CutoffDate returns the day the order for a delivery is generated.
+func (s StandingOrderSettings) CutoffDate(delivery time.Time) time.Time {
+	return delivery.AddDate(0, 0, -int(s.CutoffDays))
+}
+
+// NextDeliveryDate returns the first delivery on the ISO weekday (1 = Monday,
+// 7 = Sunday) whose cutoff is after today, so it can still be changed.
+func (s StandingOrderSettings) NextDeliveryDate(today time.Time, weekday int32) time.Time {
+	earliest := today.AddDate(0, 0, int(s.CutoffDays)+1)
+	days := (int(weekday)%7 - int(earliest.Weekday()) + 7) % 7
+	return earliest.AddDate(0, 0, days)
+}
+
+// ValidStandingOrderSchedule reports whether a cadence and ISO delivery
+// weekday can be used for a standing order.
+func ValidStandingOrderSchedule(intervalWeeks, weekday int32) bool {
+	return intervalWeeks >= 1 && intervalWeeks <= MaxStandingOrderIntervalWeeks &&
+		weekday >= 1 && weekday <= 7
+}
+
+// StandingOrderService manages wholesale standing orders: a saved basket
+// that is ordered on account on a fixed cadence and delivery day. Orders are
+// generated by ProcessDue at the tenant's cutoff and billed through the
+// customer's payment terms. Implementations should be tenant-scoped.
+type StandingOrderService interface {
+	// GetSettings returns the tenant's lead times, or the default.
+	GetSettings(ctx context.Context) (*StandingOrderSettings, error)
+
+	// UpdateSettings validates and saves the tenant's lead times. Deliveries
+	// already scheduled keep their dates.
+	UpdateSettings(ctx context.Context, settings StandingOrderSettings) error
+
+	// ListForCustomer returns a customer's standing orders, cancelled last.
+	ListForCustomer(ctx context.Context, userID string) ([]StandingOrderDetail, error)
+
+	// GetForCustomer returns one of a customer's standing orders with its
+	// items and delivery address.
+	GetForCustomer(ctx context.Context, userID, standingOrderID string) (*StandingOrderDetail, error)
+
+	// CreateFromCart saves the items in the customer's cart as a new
+	// standing order. Returns ErrOnAccountNotEnabled unless the customer can
+	// order on account. The cart itself is left as it is.
+	CreateFromCart(ctx context.Context, params CreateStandingOrderParams) (*StandingOrderDetail, error)
+
+	// UpdateItems sets the quantity of each SKU, keyed by SKU ID. A quantity
+	// of zero removes the SKU; the last item can't be removed.
+	UpdateItems(ctx context.Context, userID, standingOrderID string, quantities map[string]int) error
+
+	// UpdateSchedule changes the name, cadence, delivery day and address. A
+	// new delivery day keeps the next delivery in the same week, unless its
+	// cutoff has passed.
+	UpdateSchedule(ctx context.Context, params UpdateStandingOrderScheduleParams) error
+
+	// Skip moves an active standing order to its following delivery.
+	// Returns ErrStandingOrderNotActive for a paused standing order.
+	Skip(ctx context.Context, userID, standingOrderID string) error
+
+	// Pause stops generating orders until the standing order is resumed.
+	Pause(ctx context.Context, userID, standingOrderID string) error
+
+	// Resume restarts a paused standing order from its next delivery day.
+	Resume(ctx context.Context, userID, standingOrderID string) error
+
+	// Cancel stops a standing order for good.
+	Cancel(ctx context.Context, userID, standingOrderID string) error
+
+	// ListAll returns every standing order in the tenant, for operators.
+	ListAll(ctx context.Context) ([]repository.ListStandingOrdersRow, error)
+
+	// ProcessDue places the orders whose cutoff has been reached and emails
+	// reminders for those whose cutoff is coming up. A standing order that
+	// can't be placed records why and moves to its following delivery, so
+	// one customer's problem doesn't hold up the rest.
+	ProcessDue(ctx context.Context) (*StandingOrderResult, error)
+}
+
+// StandingOrderDetail is a standing order with the dates customers see.
+// Items and ShippingAddress are only loaded by GetForCustomer.
+type StandingOrderDetail struct {
+	repository.StandingOrder
+	CutoffDate      time.Time
+	Items           []repository.ListStandingOrderItemsRow
+	ShippingAddress *repository.GetAddressByIDForUserRow
+}
+
+// DeliveryDay returns the name of the delivery weekday, e.g. "Tuesday".
+func (d StandingOrderDetail) DeliveryDay() string {
+	return time.Weekday(d.DeliveryWeekday % 7).String()
+}
+
+// Editable reports whether the standing order can still be changed.
+func (d StandingOrderDetail) Editable() bool {
+	return d.Status != StandingOrderStatusCancelled
+}
+
+// CreateStandingOrderParams contains parameters for saving a cart as a
+// standing order.
+type CreateStandingOrderParams struct {
+	UserID          string
+	CartID          string
+	Name            string
+	IntervalWeeks   int32
+	DeliveryWeekday int32 // ISO weekday: 1 = Monday, 7 = Sunday
+	AddressID       string
+}
+
+// UpdateStandingOrderScheduleParams contains parameters for changing a
+// standing order's schedule.
+type UpdateStandingOrderScheduleParams struct {
+	UserID          string
+	StandingOrderID string
+	Name            string
+	IntervalWeeks   int32
+	DeliveryWeekday int32 // ISO weekday: 1 = Monday, 7 = Sunday
+	AddressID       string
+}
+
+// StandingOrderResult summarizes a ProcessDue run.
+type StandingOrderResult struct {
+	Placed   int // Orders placed on account
+	Failed   int // Standing orders that couldn't be placed; see last_error
+	Reminded int // Reminder emails queued
+}
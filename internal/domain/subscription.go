@@ -9,16 +9,19 @@ import (
 
 // Subscription-related domain errors.
 var (
-	ErrSubscriptionNotFound   = &Error{Code: ENOTFOUND, Message: "Subscription not found"}
-	ErrNoPaymentMethod        = &Error{Code: EPAYMENT, Message: "No payment method on file"}
-	ErrInvalidBillingInterval = &Error{Code: EINVALID, Message: "Invalid billing interval"}
-	ErrSubscriptionNotActive  = &Error{Code: EINVALID, Message: "Subscription is not active"}
-	ErrSubscriptionNotPaused  = &Error{Code: EINVALID, Message: "Subscription is not paused"}
-	ErrInvoiceNotFound        = &Error{Code: ENOTFOUND, Message: "Invoice not found"}
-	ErrInvoiceAlreadyProcessed = &Error{Code: ECONFLICT, Message: "Invoice already processed"}
-	ErrPaymentMethodOwnership = &Error{Code: EINVALID, Message: "Payment method does not belong to user"}
-	ErrInvoiceNotSubscription = &Error{Code: EINVALID, Message: "Invoice is not for a subscription"}
-	ErrSubscriptionHasNoItems = &Error{Code: EINVALID, Message: "Subscription has no items"}
+	ErrSubscriptionNotFound      = &Error{Code: ENOTFOUND, Message: "Subscription not found"}
+	ErrNoPaymentMethod           = &Error{Code: EPAYMENT, Message: "No payment method on file"}
+	ErrInvalidBillingInterval    = &Error{Code: EINVALID, Message: "Invalid billing interval"}
+	ErrSubscriptionNotActive     = &Error{Code: EINVALID, Message: "Subscription is not active"}
+	ErrSubscriptionNotPaused     = &Error{Code: EINVALID, Message: "Subscription is not paused"}
+	ErrInvoiceNotFound           = &Error{Code: ENOTFOUND, Message: "Invoice not found"}
+	ErrInvoiceAlreadyProcessed   = &Error{Code: ECONFLICT, Message: "Invoice already processed"}
+	ErrPaymentMethodOwnership    = &Error{Code: EINVALID, Message: "Payment method does not belong to user"}
+	ErrInvoiceNotSubscription    = &Error{Code: EINVALID, Message: "Invoice is not for a subscription"}
+	ErrSubscriptionHasNoItems    = &Error{Code: EINVALID, Message: "Subscription has no items"}
+	ErrSubscriptionNotChangeable = &Error{Code: EINVALID, Message: "Only active or paused subscriptions can be changed"}
+	ErrInvalidResumeDate         = &Error{Code: EINVALID, Message: "Choose a resume date in the future"}
+	ErrAddressOwnership          = &Error{Code: EINVALID, Message: "Address does not belong to user"}
 )
 
 // SubscriptionService provides business logic for subscription operations.
@@ -62,6 +65,36 @@ type SubscriptionService interface {
 	// Supports immediate or end-of-period cancellation.
 	CancelSubscription(ctx context.Context, params CancelSubscriptionParams) (*SubscriptionDetail, error)
 
+	// SkipNextDelivery skips the upcoming delivery, moving the next billing
+	// date forward by one interval. Only active subscriptions can skip.
+	SkipNextDelivery(ctx context.Context, params SkipDeliveryParams) (*SubscriptionDetail, error)
+
+	// ChangeFrequency changes how often the subscription is delivered.
+	// The next delivery stays on its current date; the new interval applies
+	// from then on.
+	ChangeFrequency(ctx context.Context, params ChangeFrequencyParams) (*SubscriptionDetail, error)
+
+	// ChangeItem swaps the subscribed SKU (size or grind) or its quantity.
+	// The new price is charged from the next delivery.
+	ChangeItem(ctx context.Context, params ChangeItemParams) (*SubscriptionDetail, error)
+
+	// ChangeShippingAddress ships future deliveries to another of the
+	// customer's saved addresses.
+	ChangeShippingAddress(ctx context.Context, params ChangeShippingAddressParams) (*SubscriptionDetail, error)
+
+	// ChangePaymentMethod charges future deliveries to another of the
+	// customer's saved payment methods.
+	ChangePaymentMethod(ctx context.Context, params ChangePaymentMethodParams) (*SubscriptionDetail, error)
+
+	// ListSKUOptions returns the active SKUs of the same coffee that a
+	// subscription on the given SKU can switch to, priced from the default
+	// price list.
+	ListSKUOptions(ctx context.Context, tenantID, skuID pgtype.UUID) ([]SubscriptionSKUOption, error)
+
+	// ListSubscriptionEvents returns a subscription's schedule events, newest
+	// first: deliveries, skips, pauses, changes and cancellation.
+	ListSubscriptionEvents(ctx context.Context, tenantID, subscriptionID pgtype.UUID, limit int32) ([]SubscriptionEvent, error)
+
 	// CreateCustomerPortalSession creates a Stripe Customer Portal session.
 	//
 	// Returns URL where customer can manage subscriptions, payment methods, and invoices.
@@ -192,6 +225,70 @@ type CancelSubscriptionParams struct {
 	CancellationReason string
 }
 
+// SkipDeliveryParams contains parameters for skipping the next delivery.
+type SkipDeliveryParams struct {
+	// TenantID is required for multi-tenant isolation
+	TenantID pgtype.UUID
+
+	// SubscriptionID is our database subscription ID
+	SubscriptionID pgtype.UUID
+}
+
+// ChangeFrequencyParams contains parameters for changing a subscription's
+// billing interval.
+type ChangeFrequencyParams struct {
+	// TenantID is required for multi-tenant isolation
+	TenantID pgtype.UUID
+
+	// SubscriptionID is our database subscription ID
+	SubscriptionID pgtype.UUID
+
+	// BillingInterval: "weekly", "biweekly", "monthly", "every_6_weeks", "every_2_months"
+	BillingInterval string
+}
+
+// ChangeItemParams contains parameters for changing a subscription's SKU or
+// quantity.
+type ChangeItemParams struct {
+	// TenantID is required for multi-tenant isolation
+	TenantID pgtype.UUID
+
+	// SubscriptionID is our database subscription ID
+	SubscriptionID pgtype.UUID
+
+	// ProductSKUID is the SKU to deliver from now on (may be unchanged)
+	ProductSKUID pgtype.UUID
+
+	// Quantity of items per delivery
+	Quantity int32
+}
+
+// ChangeShippingAddressParams contains parameters for changing where a
+// subscription ships.
+type ChangeShippingAddressParams struct {
+	// TenantID is required for multi-tenant isolation
+	TenantID pgtype.UUID
+
+	// SubscriptionID is our database subscription ID
+	SubscriptionID pgtype.UUID
+
+	// AddressID must be one of the subscriber's saved addresses
+	AddressID pgtype.UUID
+}
+
+// ChangePaymentMethodParams contains parameters for changing how a
+// subscription is paid.
+type ChangePaymentMethodParams struct {
+	// TenantID is required for multi-tenant isolation
+	TenantID pgtype.UUID
+
+	// SubscriptionID is our database subscription ID
+	SubscriptionID pgtype.UUID
+
+	// PaymentMethodID must be one of the subscriber's saved payment methods
+	PaymentMethodID pgtype.UUID
+}
+
 // PortalSessionParams contains parameters for creating portal session.
 type PortalSessionParams struct {
 	// TenantID is required for multi-tenant isolation
@@ -308,6 +405,29 @@ type SubscriptionPaymentMethodDetail struct {
 	DisplayExpYear  int32
 }
 
+// SubscriptionSKUOption is a SKU a subscription can switch to.
+type SubscriptionSKUOption struct {
+	ID          pgtype.UUID
+	SKU         string
+	WeightValue string
+	WeightUnit  string
+	Grind       string
+	PriceCents  int32
+}
+
+// SubscriptionEvent is an entry in a subscription's schedule.
+type SubscriptionEvent struct {
+	ID          pgtype.UUID
+	EventType   string // "billing", "skip", "pause", "resume", "cancel", "frequency_change", ...
+	Status      string // "scheduled", "completed", "failed", "cancelled"
+	ScheduledAt time.Time
+	CreatedAt   time.Time
+
+	// Metadata describes the event, e.g. "from" and "to" for a change or
+	// "reason" for a cancellation
+	Metadata map[string]string
+}
+
 // UpcomingInvoiceDetail contains next invoice preview.
 type UpcomingInvoiceDetail struct {
 	AmountDueCents int32
@@ -344,6 +464,22 @@ func IsValidBillingInterval(interval string) bool {
 	return false
 }
 
+// AddBillingInterval returns the billing date one interval after t.
+func AddBillingInterval(t time.Time, interval string) time.Time {
+	switch interval {
+	case BillingIntervalWeekly:
+		return t.AddDate(0, 0, 7)
+	case BillingIntervalBiweekly:
+		return t.AddDate(0, 0, 14)
+	case BillingIntervalEvery6Weeks:
+		return t.AddDate(0, 0, 42)
+	case BillingIntervalEvery2Months:
+		return t.AddDate(0, 2, 0)
+	default:
+		return t.AddDate(0, 1, 0)
+	}
+}
+
 // MapBillingIntervalToStripe converts our billing interval to Stripe's interval format.
 // Returns interval (week/month) and interval_count.
 func MapBillingIntervalToStripe(interval string) (stripeInterval string, intervalCount int32, err error) {
@@ -81,6 +81,7 @@ type Customer struct {
 	BillingCycle               pgtype.Text
 	BillingCycleDay            pgtype.Int4
 	CustomerReference          pgtype.Text
+	CreditLimitCents           pgtype.Int4
 }
 
 // FullName returns the customer's full name.
@@ -307,6 +308,7 @@ type UpdateWholesaleCustomerParams struct {
 	EmailOrders       string
 	EmailDispatches   string
 	EmailInvoices     string
+	CreditLimitCents  int32 // 0 for no limit
 }
 
 // =============================================================================
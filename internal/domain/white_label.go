@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// White-label product domain errors.
+var (
+	ErrWhiteLabelBaseInvalid     = &Error{Code: EINVALID, Message: "White-label products can only be created from a standard product"}
+	ErrWhiteLabelCustomerInvalid = &Error{Code: EINVALID, Message: "Choose a wholesale customer for this white-label product"}
+	ErrWhiteLabelNameRequired    = &Error{Code: EINVALID, Message: "White-label product name is required"}
+	ErrWhiteLabelPriceRequired   = &Error{Code: EINVALID, Message: "Set a price for at least one size"}
+	ErrWhiteLabelInvalidPrice    = &Error{Code: EINVALID, Message: "Prices can't be negative"}
+	ErrDuplicateWhiteLabel       = &Error{Code: ECONFLICT, Message: "This customer already has a white-label product with this name"}
+	ErrWhiteLabelPriceList       = &Error{Code: EINVALID, Message: "Give this customer their own price list before creating a white-label product"}
+)
+
+// WhiteLabelService manages white-label (private-label) products: copies of
+// a base product sold to one wholesale customer under their own name, label
+// artwork and pricing. White-label SKUs sell from the stock of the base SKU
+// they were cloned from, and only appear in that customer's wholesale
+// ordering matrix.
+// Implementations should be tenant-scoped.
+type WhiteLabelService interface {
+	// GetWhiteLabelBase returns a product and its active SKUs for cloning.
+	// White-label products can't be used as a base.
+	GetWhiteLabelBase(ctx context.Context, productID string) (*WhiteLabelBase, error)
+
+	// ListEligibleCustomers returns the wholesale customers a white-label
+	// product can be created for.
+	ListEligibleCustomers(ctx context.Context) ([]repository.ListWholesaleCustomersRow, error)
+
+	// CreateWhiteLabelProduct clones a base product and the priced SKUs into
+	// an active white-label product for a wholesale customer. Prices are
+	// added to the default price list and the customer's price list.
+	CreateWhiteLabelProduct(ctx context.Context, params CreateWhiteLabelParams) (*repository.Product, error)
+
+	// ListWhiteLabelProducts returns the white-label products cloned from a
+	// base product.
+	ListWhiteLabelProducts(ctx context.Context, baseProductID string) ([]repository.ListWhiteLabelProductsRow, error)
+
+	// GetWhiteLabelDetails returns the base product and customer of a
+	// white-label product.
+	GetWhiteLabelDetails(ctx context.Context, productID string) (*repository.GetWhiteLabelDetailsRow, error)
+}
+
+// WhiteLabelBase is a product that can be cloned into white-label products.
+type WhiteLabelBase struct {
+	Product repository.Product
+	SKUs    []repository.ProductSku
+}
+
+// CreateWhiteLabelParams contains the fields of a new white-label product.
+type CreateWhiteLabelParams struct {
+	BaseProductID    string
+	CustomerID       string // Wholesale customer the product is sold to
+	Name             string
+	ShortDescription string
+	Description      string
+
+	// Prices maps base SKU IDs to the customer's price in cents. Base SKUs
+	// without a price are not offered under the white label.
+	Prices map[string]int32
+}
@@ -0,0 +1,84 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// Branding is a tenant's logo, color and fonts, applied to the email layout.
+type Branding struct {
+	LogoURL      string
+	PrimaryColor string // #RRGGBB, for buttons and links
+	HeadingFont  string // CSS font-family list
+	BodyFont     string // CSS font-family list
+}
+
+// BrandingStore looks up the branding for the tenant on the context.
+// It returns nil when the email should use the default look.
+type BrandingStore interface {
+	Branding(ctx context.Context) (*Branding, error)
+}
+
+// SetBrandingStore enables tenant branding. Without a store every email
+// uses the default look.
+func (s *Service) SetBrandingStore(store BrandingStore) {
+	s.branding = store
+}
+
+// brandingFor returns the branding for the tenant on the context. A failed
+// lookup is logged and the default look is used, so it never blocks a send.
+func (s *Service) brandingFor(ctx context.Context) Branding {
+	if s.branding == nil {
+		return Branding{}
+	}
+
+	branding, err := s.branding.Branding(ctx)
+	if err != nil {
+		s.logger.Warn("failed to load email branding, using default", "error", err)
+		return Branding{}
+	}
+	if branding == nil {
+		return Branding{}
+	}
+	return *branding
+}
+
+// withBranding clones an unexecuted template and binds the brand function
+// to the given branding.
+func withBranding(tmpl *template.Template, branding Branding) (*template.Template, error) {
+	clone, err := tmpl.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone template: %w", err)
+	}
+	return clone.Funcs(template.FuncMap{
+		"brand": func() Branding { return branding },
+	}), nil
+}
+
+var (
+	hexColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+	// Font lists are names, quotes, commas and spaces
+	fontListPattern = regexp.MustCompile(`^[A-Za-z0-9 ,"'-]+$`)
+)
+
+// CSS returns rules that override the layout's default colors and fonts.
+// Values that aren't a hex color or a plain font list are ignored.
+func (b Branding) CSS() template.CSS {
+	var css strings.Builder
+
+	if fontListPattern.MatchString(b.BodyFont) {
+		fmt.Fprintf(&css, "body, p { font-family: %s; }\n", b.BodyFont)
+	}
+	if fontListPattern.MatchString(b.HeadingFont) {
+		fmt.Fprintf(&css, "h1, h2, h3 { font-family: %s; }\n", b.HeadingFont)
+	}
+	if hexColorPattern.MatchString(b.PrimaryColor) {
+		fmt.Fprintf(&css, ".button, .button:hover { background-color: %s; }\n", b.PrimaryColor)
+		fmt.Fprintf(&css, ".email-footer a { color: %s; }\n", b.PrimaryColor)
+	}
+
+	return template.CSS(css.String())
+}
@@ -0,0 +1,82 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBranding returns a fixed branding.
+type fakeBranding struct {
+	branding *Branding
+	err      error
+}
+
+func (f *fakeBranding) Branding(context.Context) (*Branding, error) {
+	return f.branding, f.err
+}
+
+func TestService_AppliesBranding(t *testing.T) {
+	svc, sender := newTestService(t)
+	svc.SetBrandingStore(&fakeBranding{branding: &Branding{
+		LogoURL:      "https://cdn.example.com/logo.png",
+		PrimaryColor: "#AA0011",
+		HeadingFont:  `Rockwell, "Roboto Slab", serif`,
+	}})
+	svc.SetOverrideStore(&fakeOverrides{overrides: map[string]*TemplateOverride{
+		"email_verification": {Subject: "Verify", BodyHTML: "<p>Custom</p>"},
+	}})
+
+	// Each template is sent twice: cached templates must stay cloneable
+	for i := 0; i < 2; i++ {
+		require.NoError(t, svc.SendPasswordReset(context.Background(), PasswordResetEmail{Email: "jamie@example.com", FirstName: "Jamie"}))
+		require.NoError(t, svc.SendEmailVerification(context.Background(), EmailVerificationEmail{Email: "jamie@example.com", FirstName: "Jamie"}))
+	}
+
+	require.Len(t, sender.sent, 4)
+	for _, sent := range sender.sent {
+		assert.Contains(t, sent.HTMLBody, `<img src="https://cdn.example.com/logo.png"`)
+		assert.Contains(t, sent.HTMLBody, ".button, .button:hover { background-color: #AA0011; }")
+		assert.Contains(t, sent.HTMLBody, `h1, h2, h3 { font-family: Rockwell, "Roboto Slab", serif; }`)
+		assert.NotContains(t, sent.HTMLBody, "<h1>Hiri Coffee</h1>", "the logo replaces the name")
+	}
+}
+
+func TestService_BrandingFallsBackToDefault(t *testing.T) {
+	tests := []struct {
+		name  string
+		store BrandingStore
+	}{
+		{name: "no store"},
+		{name: "no tenant", store: &fakeBranding{}},
+		{name: "store error", store: &fakeBranding{err: errors.New("connection refused")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, sender := newTestService(t)
+			if tt.store != nil {
+				svc.SetBrandingStore(tt.store)
+			}
+
+			require.NoError(t, svc.SendPasswordReset(context.Background(), PasswordResetEmail{Email: "jamie@example.com", FirstName: "Jamie"}))
+
+			require.Len(t, sender.sent, 1)
+			assert.Contains(t, sender.sent[0].HTMLBody, "<h1>Hiri Coffee</h1>")
+			assert.NotContains(t, sender.sent[0].HTMLBody, ".button, .button:hover")
+		})
+	}
+}
+
+func TestBranding_CSSIgnoresUnsafeValues(t *testing.T) {
+	css := Branding{
+		PrimaryColor: "red; } body { display: none",
+		HeadingFont:  "Georgia; } </style><script>",
+		BodyFont:     "Georgia, serif",
+	}.CSS()
+
+	assert.Equal(t, "body, p { font-family: Georgia, serif; }\n", string(css))
+}
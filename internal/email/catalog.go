@@ -0,0 +1,474 @@
+package email
+
+import (
+	"strings"
+	"time"
+)
+
+// TemplateInfo describes an email a tenant can customize.
+type TemplateInfo struct {
+	Type        string // Template file name without .html, e.g. "order_confirmation"
+	Name        string
+	Description string
+
+	// DefaultSubject is the built-in subject as a template, so it can be
+	// edited like any other override.
+	DefaultSubject string
+
+	Variables []TemplateVariable
+
+	// sample builds example data for previews and test sends.
+	sample func(now time.Time) EmailTemplate
+}
+
+// TemplateVariable documents a field available to a template.
+type TemplateVariable struct {
+	Name        string // e.g. "{{.OrderNumber}}"
+	Description string
+}
+
+// TemplateFunctions documents the functions available to every template.
+var TemplateFunctions = []TemplateVariable{
+	{Name: `{{formatPrice .TotalCents}}`, Description: "Cents as a price, e.g. 24.50"},
+	{Name: `{{printf "%.2f" (divf .TotalCents 100)}}`, Description: "Divide, for custom number formatting"},
+	{Name: `{{.OrderDate.Format "January 2, 2006"}}`, Description: "Format a date"},
+	{Name: `{{if .Field}}...{{else}}...{{end}}`, Description: "Show content only when a value is set"},
+	{Name: `{{range .Items}}...{{end}}`, Description: "Repeat content for each item in a list"},
+}
+
+// templateType returns the customization type for a template file name.
+func templateType(templateName string) string {
+	return strings.TrimSuffix(templateName, ".html")
+}
+
+var (
+	customerVariables = []TemplateVariable{
+		{Name: "{{.CustomerName}}", Description: "Customer's name"},
+	}
+	itemVariables = TemplateVariable{
+		Name:        "{{range .Items}}",
+		Description: "Line items, each with .ProductName, .VariantName, .Quantity, .PriceCents and .TotalCents",
+	}
+	addressVariables = TemplateVariable{
+		Name:        "{{.ShippingAddr}}",
+		Description: "Shipping address, with .Name, .Company, .Line1, .Line2, .City, .State, .PostalCode and .Country",
+	}
+)
+
+// customizableTemplates lists the customer-facing emails, in the order the
+// settings page shows them. Platform emails to operators are not included.
+var customizableTemplates = []TemplateInfo{
+	{
+		Type:           "password_reset",
+		Name:           "Password reset",
+		Description:    "Sent when a customer asks to reset their password",
+		DefaultSubject: "Reset Your Password",
+		Variables: []TemplateVariable{
+			{Name: "{{.FirstName}}", Description: "Customer's first name"},
+			{Name: "{{.ResetURL}}", Description: "Link to choose a new password"},
+			{Name: "{{.ExpiresAt}}", Description: "When the link expires"},
+		},
+		sample: func(now time.Time) EmailTemplate {
+			return PasswordResetEmail{
+				FirstName: "Jamie",
+				ResetURL:  "https://example.com/reset-password?token=sample",
+				ExpiresAt: now.Add(time.Hour),
+			}
+		},
+	},
+	{
+		Type:           "email_verification",
+		Name:           "Email verification",
+		Description:    "Sent after signup to confirm the customer's email address",
+		DefaultSubject: "Verify Your Email Address",
+		Variables: []TemplateVariable{
+			{Name: "{{.FirstName}}", Description: "Customer's first name"},
+			{Name: "{{.VerifyURL}}", Description: "Link to verify the address"},
+			{Name: "{{.ExpiresAt}}", Description: "When the link expires"},
+		},
+		sample: func(now time.Time) EmailTemplate {
+			return EmailVerificationEmail{
+				FirstName: "Jamie",
+				VerifyURL: "https://example.com/verify-email?token=sample",
+				ExpiresAt: now.Add(24 * time.Hour),
+			}
+		},
+	},
+	{
+		Type:           "order_confirmation",
+		Name:           "Order confirmation",
+		Description:    "Sent when an order is paid",
+		DefaultSubject: "Order Confirmation - {{.OrderNumber}}",
+		Variables: append(append([]TemplateVariable{}, customerVariables...),
+			TemplateVariable{Name: "{{.OrderNumber}}", Description: "Order number"},
+			TemplateVariable{Name: "{{.OrderDate}}", Description: "When the order was placed"},
+			itemVariables,
+			TemplateVariable{Name: "{{.SubtotalCents}}", Description: "Subtotal in cents"},
+			TemplateVariable{Name: "{{.ShippingCents}}", Description: "Shipping in cents"},
+			TemplateVariable{Name: "{{.TaxCents}}", Description: "Tax in cents"},
+			TemplateVariable{Name: "{{.TotalCents}}", Description: "Order total in cents"},
+			addressVariables,
+			TemplateVariable{Name: "{{.BillingAddr}}", Description: "Billing address, with the same fields as .ShippingAddr"},
+		),
+		sample: func(now time.Time) EmailTemplate {
+			return OrderConfirmationEmail{
+				OrderNumber:   "ORD-1042",
+				CustomerName:  "Jamie Rivera",
+				OrderDate:     now,
+				Items:         sampleOrderItems(now),
+				SubtotalCents: 4200,
+				ShippingCents: 800,
+				TaxCents:      350,
+				TotalCents:    5350,
+				ShippingAddr:  sampleAddress(),
+				BillingAddr:   sampleAddress(),
+			}
+		},
+	},
+	{
+		Type:           "shipping_confirmation",
+		Name:           "Shipping confirmation",
+		Description:    "Sent when an order ships",
+		DefaultSubject: "Your Order Has Shipped - {{.OrderNumber}}",
+		Variables: append(append([]TemplateVariable{}, customerVariables...),
+			TemplateVariable{Name: "{{.OrderNumber}}", Description: "Order number"},
+			TemplateVariable{Name: "{{.ShippedDate}}", Description: "When the order shipped"},
+			itemVariables,
+			addressVariables,
+			TemplateVariable{Name: "{{.Carrier}}", Description: "Shipping carrier"},
+			TemplateVariable{Name: "{{.TrackingNumber}}", Description: "Tracking number"},
+			TemplateVariable{Name: "{{.TrackingURL}}", Description: "Link to track the package"},
+		),
+		sample: func(now time.Time) EmailTemplate {
+			return ShippingConfirmationEmail{
+				OrderNumber:    "ORD-1042",
+				CustomerName:   "Jamie Rivera",
+				ShippedDate:    now,
+				Items:          sampleOrderItems(now),
+				ShippingAddr:   sampleAddress(),
+				Carrier:        "USPS",
+				TrackingNumber: "9400100000000000000000",
+				TrackingURL:    "https://tools.usps.com/go/TrackConfirmAction?tLabels=9400100000000000000000",
+			}
+		},
+	},
+	{
+		Type:           "shipment_update",
+		Name:           "Delivery update",
+		Description:    "Sent when a package is delivered, or the carrier reports a problem",
+		DefaultSubject: "{{if .Delivered}}Your Order Has Been Delivered{{else}}There's a Problem With Your Delivery{{end}} - {{.OrderNumber}}",
+		Variables: append(append([]TemplateVariable{}, customerVariables...),
+			TemplateVariable{Name: "{{.OrderNumber}}", Description: "Order number"},
+			TemplateVariable{Name: "{{.Delivered}}", Description: "True when the package arrived, false for a delivery problem"},
+			TemplateVariable{Name: "{{.Status}}", Description: "Shipment status: delivered, failed or returned"},
+			TemplateVariable{Name: "{{.Message}}", Description: "Latest carrier message, if any"},
+			TemplateVariable{Name: "{{.UpdatedAt}}", Description: "When the carrier reported the update"},
+			TemplateVariable{Name: "{{.Carrier}}", Description: "Shipping carrier"},
+			TemplateVariable{Name: "{{.TrackingNumber}}", Description: "Tracking number"},
+			TemplateVariable{Name: "{{.TrackingURL}}", Description: "Link to track the package"},
+		),
+		sample: func(now time.Time) EmailTemplate {
+			return ShipmentUpdateEmail{
+				CustomerName:   "Jamie Rivera",
+				OrderNumber:    "ORD-1042",
+				Status:         "delivered",
+				Message:        "Delivered, front door",
+				UpdatedAt:      now,
+				Carrier:        "USPS",
+				TrackingNumber: "9400100000000000000000",
+				TrackingURL:    "https://tools.usps.com/go/TrackConfirmAction?tLabels=9400100000000000000000",
+			}
+		},
+	},
+	{
+		Type:           "refund_confirmation",
+		Name:           "Refund confirmation",
+		Description:    "Sent when an order is fully or partly refunded",
+		DefaultSubject: "Your Refund Has Been Issued - {{.OrderNumber}}",
+		Variables: append(append([]TemplateVariable{}, customerVariables...),
+			TemplateVariable{Name: "{{.OrderNumber}}", Description: "Order number"},
+			TemplateVariable{Name: "{{.RefundedDate}}", Description: "When the refund was issued"},
+			TemplateVariable{Name: "{{.RefundCents}}", Description: "This refund in cents"},
+			TemplateVariable{Name: "{{.TotalRefundedCents}}", Description: "Everything refunded on the order so far, in cents"},
+			TemplateVariable{Name: "{{.OrderTotalCents}}", Description: "Order total in cents"},
+			TemplateVariable{Name: "{{.FullyRefunded}}", Description: "True when the whole order has been refunded"},
+		),
+		sample: func(now time.Time) EmailTemplate {
+			return RefundConfirmationEmail{
+				CustomerName:       "Jamie Rivera",
+				OrderNumber:        "ORD-1042",
+				RefundedDate:       now,
+				RefundCents:        1800,
+				TotalRefundedCents: 1800,
+				OrderTotalCents:    5350,
+			}
+		},
+	},
+	{
+		Type:           "subscription_welcome",
+		Name:           "Subscription welcome",
+		Description:    "Sent when a customer starts a subscription",
+		DefaultSubject: "Welcome to Your Coffee Subscription",
+		Variables: append(append([]TemplateVariable{}, customerVariables...),
+			TemplateVariable{Name: "{{.ProductName}}", Description: "Subscribed product"},
+			TemplateVariable{Name: "{{.Frequency}}", Description: "Delivery frequency, e.g. Every 2 weeks"},
+			TemplateVariable{Name: "{{.NextDeliveryDate}}", Description: "Date of the next delivery"},
+			TemplateVariable{Name: "{{.ManagementURL}}", Description: "Link to manage the subscription"},
+			addressVariables,
+			TemplateVariable{Name: "{{.SubscriptionTotal}}", Description: "Price per delivery in cents"},
+		),
+		sample: func(now time.Time) EmailTemplate {
+			return SubscriptionWelcomeEmail{
+				CustomerName:      "Jamie Rivera",
+				ProductName:       "House Espresso",
+				Frequency:         "Every 2 weeks",
+				NextDeliveryDate:  now.AddDate(0, 0, 14),
+				ManagementURL:     "https://example.com/account/subscriptions",
+				ShippingAddr:      sampleAddress(),
+				SubscriptionTotal: 2100,
+			}
+		},
+	},
+	{
+		Type:           "subscription_payment_failed",
+		Name:           "Subscription payment failed",
+		Description:    "Sent when a renewal payment or retry fails",
+		DefaultSubject: `{{if eq .FinalAction "pause"}}Your Subscription Has Been Paused{{else if eq .FinalAction "cancel"}}Your Subscription Has Been Cancelled{{else}}Subscription Payment Issue{{end}}`,
+		Variables: append(append([]TemplateVariable{}, customerVariables...),
+			TemplateVariable{Name: "{{.ProductName}}", Description: "Subscribed product"},
+			TemplateVariable{Name: "{{.FailedDate}}", Description: "When the payment failed"},
+			TemplateVariable{Name: "{{.RetryDate}}", Description: "Date of the next retry"},
+			TemplateVariable{Name: "{{.AttemptNumber}}", Description: "Which attempt failed"},
+			TemplateVariable{Name: "{{.FinalAction}}", Description: "pause or cancel once the last retry has failed, otherwise empty"},
+			TemplateVariable{Name: "{{.UpdatePaymentURL}}", Description: "Link to update the card"},
+			TemplateVariable{Name: "{{.ManagementURL}}", Description: "Link to manage the subscription"},
+		),
+		sample: func(now time.Time) EmailTemplate {
+			return SubscriptionPaymentFailedEmail{
+				CustomerName:     "Jamie Rivera",
+				ProductName:      "House Espresso",
+				FailedDate:       now,
+				RetryDate:        now.AddDate(0, 0, 3),
+				AttemptNumber:    1,
+				UpdatePaymentURL: "https://example.com/subscriptions/update-payment/sample",
+				ManagementURL:    "https://example.com/account/subscriptions",
+			}
+		},
+	},
+	{
+		Type:           "subscription_cancelled",
+		Name:           "Subscription cancelled",
+		Description:    "Sent when a subscription is cancelled",
+		DefaultSubject: "Subscription Cancelled",
+		Variables: append(append([]TemplateVariable{}, customerVariables...),
+			TemplateVariable{Name: "{{.ProductName}}", Description: "Subscribed product"},
+			TemplateVariable{Name: "{{.CancelledDate}}", Description: "When the subscription was cancelled"},
+			TemplateVariable{Name: "{{.HasFinalDelivery}}", Description: "True when one more delivery is on its way"},
+			TemplateVariable{Name: "{{.FinalDeliveryDate}}", Description: "Date of the final delivery"},
+			TemplateVariable{Name: "{{.ReactivationURL}}", Description: "Link to start the subscription again"},
+		),
+		sample: func(now time.Time) EmailTemplate {
+			return SubscriptionCancelledEmail{
+				CustomerName:    "Jamie Rivera",
+				ProductName:     "House Espresso",
+				CancelledDate:   now,
+				ReactivationURL: "https://example.com/subscribe",
+			}
+		},
+	},
+	{
+		Type:           "invoice_sent",
+		Name:           "Invoice",
+		Description:    "Sent to wholesale customers with a new invoice",
+		DefaultSubject: "Invoice {{.InvoiceNumber}} from Hiri",
+		Variables: append(append([]TemplateVariable{}, customerVariables...),
+			TemplateVariable{Name: "{{.InvoiceNumber}}", Description: "Invoice number"},
+			TemplateVariable{Name: "{{.InvoiceDate}}", Description: "Invoice date"},
+			TemplateVariable{Name: "{{.DueDate}}", Description: "Payment due date"},
+			TemplateVariable{Name: "{{.PaymentTerms}}", Description: "Payment terms, e.g. Net 30"},
+			TemplateVariable{Name: "{{range .Items}}", Description: "Invoice lines, each with .Description, .Quantity, .UnitCents and .TotalCents"},
+			TemplateVariable{Name: "{{.SubtotalCents}}", Description: "Subtotal in cents"},
+			TemplateVariable{Name: "{{.ShippingCents}}", Description: "Shipping in cents"},
+			TemplateVariable{Name: "{{.TaxCents}}", Description: "Tax in cents"},
+			TemplateVariable{Name: "{{.DiscountCents}}", Description: "Discount in cents"},
+			TemplateVariable{Name: "{{.TotalCents}}", Description: "Invoice total in cents"},
+			TemplateVariable{Name: "{{.PaymentURL}}", Description: "Link to pay the invoice"},
+		),
+		sample: func(now time.Time) EmailTemplate {
+			return InvoiceSentEmail{
+				CustomerName:  "Corner Cafe",
+				InvoiceNumber: "INV-2026-0042",
+				InvoiceDate:   now,
+				DueDate:       now.AddDate(0, 0, 30),
+				PaymentTerms:  "Net 30",
+				Items: []InvoiceItem{
+					{Description: "House Espresso, 5 lb", Quantity: 4, UnitCents: 6500, TotalCents: 26000},
+				},
+				SubtotalCents: 26000,
+				ShippingCents: 1500,
+				TotalCents:    27500,
+				PaymentURL:    "https://example.com/invoices/sample",
+			}
+		},
+	},
+	{
+		Type:           "invoice_reminder",
+		Name:           "Invoice reminder",
+		Description:    "Sent before an invoice is due, and after it is past due",
+		DefaultSubject: `Payment Reminder - Invoice {{.InvoiceNumber}}{{if eq .ReminderType "past_due"}} Past Due{{end}}`,
+		Variables: append(append([]TemplateVariable{}, customerVariables...),
+			TemplateVariable{Name: "{{.InvoiceNumber}}", Description: "Invoice number"},
+			TemplateVariable{Name: "{{.DueDate}}", Description: "Payment due date"},
+			TemplateVariable{Name: "{{.BalanceCents}}", Description: "Balance due in cents"},
+			TemplateVariable{Name: "{{.ReminderType}}", Description: "approaching_due or past_due"},
+			TemplateVariable{Name: "{{.DaysBefore}}", Description: "Days until the due date"},
+			TemplateVariable{Name: "{{.DaysOverdue}}", Description: "Days past the due date"},
+			TemplateVariable{Name: "{{.PaymentURL}}", Description: "Link to pay the invoice"},
+		),
+		sample: func(now time.Time) EmailTemplate {
+			return InvoiceReminderEmail{
+				CustomerName:  "Corner Cafe",
+				InvoiceNumber: "INV-2026-0042",
+				DueDate:       now.AddDate(0, 0, 3),
+				BalanceCents:  27500,
+				ReminderType:  "approaching_due",
+				DaysBefore:    3,
+				PaymentURL:    "https://example.com/invoices/sample",
+			}
+		},
+	},
+	{
+		Type:           "invoice_overdue",
+		Name:           "Invoice overdue",
+		Description:    "Sent when an invoice becomes overdue",
+		DefaultSubject: "Invoice {{.InvoiceNumber}} is Overdue",
+		Variables: append(append([]TemplateVariable{}, customerVariables...),
+			TemplateVariable{Name: "{{.InvoiceNumber}}", Description: "Invoice number"},
+			TemplateVariable{Name: "{{.DueDate}}", Description: "Payment due date"},
+			TemplateVariable{Name: "{{.BalanceCents}}", Description: "Balance due in cents"},
+			TemplateVariable{Name: "{{.DaysOverdue}}", Description: "Days past the due date"},
+			TemplateVariable{Name: "{{.PaymentURL}}", Description: "Link to pay the invoice"},
+		),
+		sample: func(now time.Time) EmailTemplate {
+			return InvoiceOverdueEmail{
+				CustomerName:  "Corner Cafe",
+				InvoiceNumber: "INV-2026-0042",
+				DueDate:       now.AddDate(0, 0, -1),
+				BalanceCents:  27500,
+				DaysOverdue:   1,
+				PaymentURL:    "https://example.com/invoices/sample",
+			}
+		},
+	},
+	{
+		Type:           "wholesale_approved",
+		Name:           "Wholesale approved",
+		Description:    "Sent when a wholesale application is approved",
+		DefaultSubject: "Your Wholesale Account Has Been Approved!",
+		Variables: append(append([]TemplateVariable{}, customerVariables...),
+			TemplateVariable{Name: "{{.LoginURL}}", Description: "Link to sign in and order"},
+		),
+		sample: func(time.Time) EmailTemplate {
+			return WholesaleApprovedEmail{
+				CustomerName: "Corner Cafe",
+				LoginURL:     "https://example.com/login",
+			}
+		},
+	},
+	{
+		Type:           "wholesale_rejected",
+		Name:           "Wholesale declined",
+		Description:    "Sent when a wholesale application is declined",
+		DefaultSubject: "Your Wholesale Application Status",
+		Variables: append(append([]TemplateVariable{}, customerVariables...),
+			TemplateVariable{Name: "{{.RejectionReason}}", Description: "Reason given for declining, if any"},
+			TemplateVariable{Name: "{{.ShopURL}}", Description: "Link to the retail store"},
+		),
+		sample: func(time.Time) EmailTemplate {
+			return WholesaleRejectedEmail{
+				CustomerName:    "Corner Cafe",
+				RejectionReason: "We aren't taking on new accounts in your area yet.",
+				ShopURL:         "https://example.com/products",
+			}
+		},
+	},
+	{
+		Type:           "review_request",
+		Name:           "Review request",
+		Description:    "Sent a few days after an order is delivered, asking for product reviews",
+		DefaultSubject: "How Was Your Coffee? - {{.OrderNumber}}",
+		Variables: append(append([]TemplateVariable{}, customerVariables...),
+			TemplateVariable{Name: "{{.OrderNumber}}", Description: "Order number"},
+			TemplateVariable{Name: "{{range .Items}}", Description: "Products in the order, each with .ProductName and .ReviewURL"},
+		),
+		sample: func(time.Time) EmailTemplate {
+			return ReviewRequestEmail{
+				CustomerName: "Jane Doe",
+				OrderNumber:  "ORD-1042",
+				Items: []ReviewRequestItem{
+					{ProductName: "House Espresso", ReviewURL: "https://example.com/account/reviews/new"},
+					{ProductName: "Ethiopia Guji", ReviewURL: "https://example.com/account/reviews/new"},
+				},
+			}
+		},
+	},
+	{
+		Type:           "standing_order_reminder",
+		Name:           "Standing order reminder",
+		Description:    "Sent to wholesale customers before their standing order is placed, so they can change or skip it",
+		DefaultSubject: `Your Standing Order for {{.DeliveryDate.Format "Monday, January 2"}}`,
+		Variables: append(append([]TemplateVariable{}, customerVariables...),
+			TemplateVariable{Name: "{{.StandingOrderName}}", Description: "Name of the standing order"},
+			TemplateVariable{Name: "{{.DeliveryDate}}", Description: "Delivery the order is for"},
+			TemplateVariable{Name: "{{.CutoffDate}}", Description: "Day the order is placed; it can be changed or skipped until then"},
+			TemplateVariable{Name: "{{range .Items}}", Description: "Items to be ordered, each with .ProductName, .SKU and .Quantity"},
+			TemplateVariable{Name: "{{.ManageURL}}", Description: "Link to change or skip the standing order"},
+		),
+		sample: func(now time.Time) EmailTemplate {
+			return StandingOrderReminderEmail{
+				CustomerName:      "Corner Cafe",
+				StandingOrderName: "Weekly espresso",
+				DeliveryDate:      now.AddDate(0, 0, 3),
+				CutoffDate:        now.AddDate(0, 0, 1),
+				Items: []StandingOrderReminderItem{
+					{ProductName: "House Espresso", SKU: "ESP-5LB-WB", Quantity: 4},
+					{ProductName: "Ethiopia Guji", SKU: "ETH-5LB-WB", Quantity: 2},
+				},
+				ManageURL: "https://example.com/account/standing-orders/sample",
+			}
+		},
+	},
+}
+
+// CustomizableTemplates returns the emails a tenant can customize.
+func CustomizableTemplates() []TemplateInfo {
+	return customizableTemplates
+}
+
+// LookupTemplate returns the customizable email with the given type.
+func LookupTemplate(templateType string) (TemplateInfo, bool) {
+	for _, info := range customizableTemplates {
+		if info.Type == templateType {
+			return info, true
+		}
+	}
+	return TemplateInfo{}, false
+}
+
+func sampleOrderItems(now time.Time) []OrderItem {
+	return []OrderItem{
+		{ProductName: "House Espresso", VariantName: "12oz, Whole Bean", Quantity: 2, PriceCents: 1600, TotalCents: 3200, RoastDate: now.AddDate(0, 0, -2)},
+		{ProductName: "Ethiopia Guji", VariantName: "12oz, Ground", Quantity: 1, PriceCents: 1000, TotalCents: 1000},
+	}
+}
+
+func sampleAddress() Address {
+	return Address{
+		Name:       "Jamie Rivera",
+		Line1:      "123 Main St",
+		City:       "Helena",
+		State:      "MT",
+		PostalCode: "59601",
+		Country:    "US",
+	}
+}
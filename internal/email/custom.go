@@ -0,0 +1,193 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// TemplateOverride is a tenant's own subject and body for an email. The
+// body replaces the content of the standard layout; BodyText is optional
+// and generated from the HTML when empty.
+type TemplateOverride struct {
+	Subject  string
+	BodyHTML string
+	BodyText string
+}
+
+// OverrideStore looks up the active override for the tenant on the
+// context. It returns nil when the tenant uses the default template.
+type OverrideStore interface {
+	ActiveOverride(ctx context.Context, templateType string) (*TemplateOverride, error)
+}
+
+// Rendered is an email ready to send.
+type Rendered struct {
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// SetOverrideStore makes the service render tenant overrides in place of
+// the default templates for the customizable emails.
+func (s *Service) SetOverrideStore(store OverrideStore) {
+	s.overrides = store
+}
+
+// render renders data with the tenant's override when one is active. A
+// broken override is logged and the default template used, so a tenant's
+// edit can never stop an email from going out.
+func (s *Service) render(ctx context.Context, data EmailTemplate) (*Rendered, error) {
+	name := data.TemplateName()
+	branding := s.brandingFor(ctx)
+
+	if s.overrides != nil {
+		if _, ok := LookupTemplate(templateType(name)); ok {
+			override, err := s.overrides.ActiveOverride(ctx, templateType(name))
+			if err != nil {
+				s.logger.Warn("failed to load email template override, using default",
+					"template", name, "error", err)
+			} else if override != nil {
+				rendered, err := s.renderOverride(override, data, branding)
+				if err == nil {
+					return rendered, nil
+				}
+				s.logger.Error("failed to render email template override, using default",
+					"template", name, "error", err)
+			}
+		}
+	}
+
+	htmlBody, textBody, err := s.renderTemplate(name, data, branding)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rendered{
+		Subject:  data.Subject(),
+		HTMLBody: htmlBody,
+		TextBody: textBody,
+	}, nil
+}
+
+// renderOverride renders an override's subject and body inside the layout.
+func (s *Service) renderOverride(override *TemplateOverride, data EmailTemplate, branding Branding) (*Rendered, error) {
+	subject, err := executeText("subject", override.Subject, data)
+	if err != nil {
+		return nil, err
+	}
+	// A subject is a single line
+	subject = strings.Join(strings.Fields(subject), " ")
+	if subject == "" {
+		return nil, fmt.Errorf("subject is empty")
+	}
+
+	tmpl, err := withBranding(s.layout, branding)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmpl.New("email_content").Parse(override.BodyHTML); err != nil {
+		return nil, err
+	}
+
+	var htmlBuf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&htmlBuf, "email_layout", data); err != nil {
+		return nil, err
+	}
+	htmlBody := htmlBuf.String()
+
+	textBody := generatePlainText(htmlBody)
+	if strings.TrimSpace(override.BodyText) != "" {
+		textBody, err = executeText("body_text", override.BodyText, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Rendered{
+		Subject:  subject,
+		HTMLBody: htmlBody,
+		TextBody: textBody,
+	}, nil
+}
+
+// executeText renders a plain text template such as a subject line.
+func executeText(name, text string, data any) (string, error) {
+	tmpl, err := texttemplate.New(name).Funcs(texttemplate.FuncMap(emailTemplateFuncs())).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// DefaultTemplate returns the built-in subject and body of a customizable
+// email, as the starting point for an override.
+func (s *Service) DefaultTemplate(templateType string) (*TemplateOverride, error) {
+	info, ok := LookupTemplate(templateType)
+	if !ok {
+		return nil, ErrTemplateNotFound(templateType)
+	}
+
+	return &TemplateOverride{
+		Subject:  info.DefaultSubject,
+		BodyHTML: s.defaultBodies[templateType+".html"],
+	}, nil
+}
+
+// Preview renders a customizable email against sample data, with the
+// branding of the tenant on the context. A nil override previews the
+// default template. An override that does not parse or render returns
+// ErrInvalidTemplate.
+func (s *Service) Preview(ctx context.Context, templateType string, override *TemplateOverride) (*Rendered, error) {
+	info, ok := LookupTemplate(templateType)
+	if !ok {
+		return nil, ErrTemplateNotFound(templateType)
+	}
+	data := info.sample(time.Now())
+	branding := s.brandingFor(ctx)
+
+	if override == nil {
+		htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data, branding)
+		if err != nil {
+			return nil, err
+		}
+		return &Rendered{Subject: data.Subject(), HTMLBody: htmlBody, TextBody: textBody}, nil
+	}
+
+	rendered, err := s.renderOverride(override, data, branding)
+	if err != nil {
+		return nil, ErrInvalidTemplate(err)
+	}
+	return rendered, nil
+}
+
+// SendTest sends a preview of a customizable email to the given address.
+func (s *Service) SendTest(ctx context.Context, to, templateType string, override *TemplateOverride) error {
+	rendered, err := s.Preview(ctx, templateType, override)
+	if err != nil {
+		return err
+	}
+
+	email := &Email{
+		To:       []string{to},
+		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
+		Subject:  "[Test] " + rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
+	}
+
+	_, err = s.sender.Send(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to send test email: %w", err)
+	}
+
+	return nil
+}
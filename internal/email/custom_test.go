@@ -0,0 +1,181 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSender records sent emails.
+type fakeSender struct {
+	sent []*Email
+}
+
+func (f *fakeSender) Send(_ context.Context, email *Email) (string, error) {
+	f.sent = append(f.sent, email)
+	return "msg-1", nil
+}
+
+func (f *fakeSender) SendTemplate(context.Context, string, []string, map[string]interface{}) (string, error) {
+	return "", ErrNotImplemented
+}
+
+// fakeOverrides returns a fixed override per template type.
+type fakeOverrides struct {
+	overrides map[string]*TemplateOverride
+	err       error
+}
+
+func (f *fakeOverrides) ActiveOverride(_ context.Context, templateType string) (*TemplateOverride, error) {
+	return f.overrides[templateType], f.err
+}
+
+func newTestService(t *testing.T) (*Service, *fakeSender) {
+	t.Helper()
+
+	sender := &fakeSender{}
+	svc, err := NewService(sender, "shop@example.com", "Example Roasters", "../../web/templates", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	return svc, sender
+}
+
+func TestCustomizableTemplates(t *testing.T) {
+	svc, _ := newTestService(t)
+	now := time.Now()
+
+	for _, info := range CustomizableTemplates() {
+		t.Run(info.Type, func(t *testing.T) {
+			data := info.sample(now)
+			assert.Equal(t, info.Type, templateType(data.TemplateName()))
+
+			// The editable default subject matches the built-in one
+			subject, err := executeText("subject", info.DefaultSubject, data)
+			require.NoError(t, err)
+			assert.Equal(t, data.Subject(), subject)
+
+			// The default body renders as an override exactly like the template
+			def, err := svc.DefaultTemplate(info.Type)
+			require.NoError(t, err)
+			require.NotEmpty(t, def.BodyHTML)
+
+			want, err := svc.Preview(context.Background(), info.Type, nil)
+			require.NoError(t, err)
+			got, err := svc.Preview(context.Background(), info.Type, def)
+			require.NoError(t, err)
+			assert.Equal(t, want.Subject, got.Subject)
+			assert.Equal(t, content(want.HTMLBody), content(got.HTMLBody))
+		})
+	}
+}
+
+// content returns an email's HTML after the <title>, which overrides
+// leave as the layout default.
+func content(html string) string {
+	_, after, _ := strings.Cut(html, "</title>")
+	return strings.Join(strings.Fields(after), " ")
+}
+
+func TestService_RendersTenantOverride(t *testing.T) {
+	svc, sender := newTestService(t)
+	svc.SetOverrideStore(&fakeOverrides{overrides: map[string]*TemplateOverride{
+		"password_reset": {
+			Subject:  "Reset your {{.FirstName}} password",
+			BodyHTML: `<p>Hey {{.FirstName}}, <a href="{{.ResetURL}}">reset here</a>.</p>`,
+			BodyText: "Hey {{.FirstName}}, reset at {{.ResetURL}}",
+		},
+	}})
+
+	err := svc.SendPasswordReset(context.Background(), PasswordResetEmail{
+		Email:     "jamie@example.com",
+		FirstName: "Jamie",
+		ResetURL:  "https://example.com/reset?token=abc",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, sender.sent, 1)
+	sent := sender.sent[0]
+	assert.Equal(t, "Reset your Jamie password", sent.Subject)
+	assert.Contains(t, sent.HTMLBody, "Hey Jamie")
+	assert.Contains(t, sent.HTMLBody, "email-footer", "override is rendered inside the layout")
+	assert.Equal(t, "Hey Jamie, reset at https://example.com/reset?token=abc", sent.TextBody)
+}
+
+func TestService_BrokenOverrideFallsBackToDefault(t *testing.T) {
+	tests := []struct {
+		name  string
+		store *fakeOverrides
+	}{
+		{
+			name: "unknown field",
+			store: &fakeOverrides{overrides: map[string]*TemplateOverride{
+				"password_reset": {Subject: "Hi", BodyHTML: "{{.NoSuchField}}"},
+			}},
+		},
+		{
+			name:  "store error",
+			store: &fakeOverrides{err: errors.New("connection refused")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, sender := newTestService(t)
+			svc.SetOverrideStore(tt.store)
+
+			err := svc.SendPasswordReset(context.Background(), PasswordResetEmail{Email: "jamie@example.com", FirstName: "Jamie"})
+
+			require.NoError(t, err)
+			require.Len(t, sender.sent, 1)
+			assert.Equal(t, "Reset Your Password", sender.sent[0].Subject)
+		})
+	}
+}
+
+func TestService_PreviewInvalidOverride(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	tests := []struct {
+		name     string
+		override *TemplateOverride
+	}{
+		{name: "parse error", override: &TemplateOverride{Subject: "Hi", BodyHTML: "{{if .FirstName}}"}},
+		{name: "unknown field", override: &TemplateOverride{Subject: "{{.OrderTotal}}", BodyHTML: "<p>Hi</p>"}},
+		{name: "empty subject", override: &TemplateOverride{Subject: "  ", BodyHTML: "<p>Hi</p>"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Preview(context.Background(), "password_reset", tt.override)
+
+			var emailErr *EmailError
+			require.ErrorAs(t, err, &emailErr)
+			assert.Equal(t, codeInvalid, emailErr.Code)
+		})
+	}
+}
+
+func TestService_SendTest(t *testing.T) {
+	svc, sender := newTestService(t)
+
+	err := svc.SendTest(context.Background(), "owner@example.com", "order_confirmation", &TemplateOverride{
+		Subject:  "Thanks for order {{.OrderNumber}}",
+		BodyHTML: "<p>{{range .Items}}{{.ProductName}} {{end}}</p>",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, []string{"owner@example.com"}, sender.sent[0].To)
+	assert.Equal(t, "[Test] Thanks for order ORD-1042", sender.sent[0].Subject)
+	assert.Contains(t, sender.sent[0].HTMLBody, "House Espresso")
+
+	_, ok := LookupTemplate("operator_setup")
+	assert.False(t, ok, "platform emails are not customizable")
+	assert.Error(t, svc.SendTest(context.Background(), "owner@example.com", "operator_setup", nil))
+}
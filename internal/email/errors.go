@@ -1,6 +1,9 @@
 package email
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // ============================================================================
 // EMAIL ERROR CODES
@@ -67,3 +70,19 @@ func ErrTemplateNotFound(templateName string) error {
 		Message: fmt.Sprintf("Email template %s not found", templateName),
 	}
 }
+
+// ErrInvalidTemplate creates an error for a template override that does not
+// parse or render.
+func ErrInvalidTemplate(err error) error {
+	return &EmailError{
+		Code:    codeInvalid,
+		Message: fmt.Sprintf("Template error: %v", err),
+	}
+}
+
+// IsInvalidTemplate reports whether err is from an override that does not
+// parse or render.
+func IsInvalidTemplate(err error) bool {
+	var emailErr *EmailError
+	return errors.As(err, &emailErr) && emailErr.Code == codeInvalid
+}
@@ -17,6 +17,10 @@ type Service struct {
 	fromAddress   string
 	fromName      string
 	templateCache map[string]*template.Template // Map of template name to composed template
+	layout        *template.Template            // Unexecuted layout, cloned for tenant overrides
+	defaultBodies map[string]string             // Map of template name to email_content source
+	overrides     OverrideStore
+	branding      BrandingStore
 	logger        *slog.Logger
 }
 
@@ -45,6 +49,7 @@ func NewService(sender Sender, fromAddress, fromName, templateDir string, logger
 
 	// Create a composed template for each content template
 	templateCache := make(map[string]*template.Template)
+	defaultBodies := make(map[string]string)
 	for _, contentPath := range contentFiles {
 		filename := filepath.Base(contentPath)
 		// Skip the layout file
@@ -65,6 +70,10 @@ func NewService(sender Sender, fromAddress, fromName, templateDir string, logger
 		}
 
 		templateCache[filename] = tmpl
+		// Keep the content source before execution adds escapers to the tree
+		if content := tmpl.Lookup("email_content"); content != nil && content.Tree != nil {
+			defaultBodies[filename] = strings.TrimSpace(content.Tree.Root.String())
+		}
 		logger.Debug("loaded email template", "template", filename)
 	}
 
@@ -75,6 +84,8 @@ func NewService(sender Sender, fromAddress, fromName, templateDir string, logger
 		fromAddress:   fromAddress,
 		fromName:      fromName,
 		templateCache: templateCache,
+		layout:        layoutTmpl,
+		defaultBodies: defaultBodies,
 		logger:        logger,
 	}, nil
 }
@@ -150,12 +161,16 @@ func emailTemplateFuncs() template.FuncMap {
 		"formatPrice": func(cents int64) string {
 			return fmt.Sprintf("%.2f", float64(cents)/100.0)
 		},
+		// Tenant branding, bound per send by withBranding
+		"brand": func() Branding {
+			return Branding{}
+		},
 	}
 }
 
 // SendPasswordReset sends a password reset email
 func (s *Service) SendPasswordReset(ctx context.Context, data PasswordResetEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render password reset template: %w", err)
 	}
@@ -163,9 +178,9 @@ func (s *Service) SendPasswordReset(ctx context.Context, data PasswordResetEmail
 	email := &Email{
 		To:       []string{data.Email},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -183,7 +198,7 @@ func (s *Service) SendEmailVerification(ctx context.Context, data EmailVerificat
 		"template", data.TemplateName(),
 	)
 
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		s.logger.Error("failed to render email verification template", "error", err)
 		return fmt.Errorf("failed to render email verification template: %w", err)
@@ -191,16 +206,16 @@ func (s *Service) SendEmailVerification(ctx context.Context, data EmailVerificat
 
 	s.logger.Info("email verification template rendered",
 		"email", data.Email,
-		"html_length", len(htmlBody),
-		"text_length", len(textBody),
+		"html_length", len(rendered.HTMLBody),
+		"text_length", len(rendered.TextBody),
 	)
 
 	email := &Email{
 		To:       []string{data.Email},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	s.logger.Info("sending email verification via sender",
@@ -225,17 +240,17 @@ func (s *Service) SendEmailVerification(ctx context.Context, data EmailVerificat
 
 // SendOrderConfirmation sends an order confirmation email
 func (s *Service) SendOrderConfirmation(ctx context.Context, data OrderConfirmationEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render order confirmation template: %w", err)
 	}
 
 	email := &Email{
-		To:       []string{data.CustomerName},
+		To:       []string{data.Email},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -248,7 +263,7 @@ func (s *Service) SendOrderConfirmation(ctx context.Context, data OrderConfirmat
 
 // SendShippingConfirmation sends a shipping confirmation email
 func (s *Service) SendShippingConfirmation(ctx context.Context, data ShippingConfirmationEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render shipping confirmation template: %w", err)
 	}
@@ -256,9 +271,9 @@ func (s *Service) SendShippingConfirmation(ctx context.Context, data ShippingCon
 	email := &Email{
 		To:       []string{data.CustomerName},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -269,9 +284,55 @@ func (s *Service) SendShippingConfirmation(ctx context.Context, data ShippingCon
 	return nil
 }
 
+// SendShipmentUpdate sends a delivery or delivery problem email
+func (s *Service) SendShipmentUpdate(ctx context.Context, data ShipmentUpdateEmail) error {
+	rendered, err := s.render(ctx, data)
+	if err != nil {
+		return fmt.Errorf("failed to render shipment update template: %w", err)
+	}
+
+	email := &Email{
+		To:       []string{data.Email},
+		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
+	}
+
+	_, err = s.sender.Send(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to send shipment update email: %w", err)
+	}
+
+	return nil
+}
+
+// SendRefundConfirmation sends a refund confirmation email
+func (s *Service) SendRefundConfirmation(ctx context.Context, data RefundConfirmationEmail) error {
+	rendered, err := s.render(ctx, data)
+	if err != nil {
+		return fmt.Errorf("failed to render refund confirmation template: %w", err)
+	}
+
+	email := &Email{
+		To:       []string{data.Email},
+		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
+	}
+
+	_, err = s.sender.Send(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to send refund confirmation email: %w", err)
+	}
+
+	return nil
+}
+
 // SendSubscriptionWelcome sends a subscription welcome email
 func (s *Service) SendSubscriptionWelcome(ctx context.Context, data SubscriptionWelcomeEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render subscription welcome template: %w", err)
 	}
@@ -279,9 +340,9 @@ func (s *Service) SendSubscriptionWelcome(ctx context.Context, data Subscription
 	email := &Email{
 		To:       []string{data.CustomerName},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -294,17 +355,17 @@ func (s *Service) SendSubscriptionWelcome(ctx context.Context, data Subscription
 
 // SendSubscriptionPaymentFailed sends a subscription payment failed email
 func (s *Service) SendSubscriptionPaymentFailed(ctx context.Context, data SubscriptionPaymentFailedEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render subscription payment failed template: %w", err)
 	}
 
 	email := &Email{
-		To:       []string{data.CustomerName},
+		To:       []string{data.Email},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -317,7 +378,7 @@ func (s *Service) SendSubscriptionPaymentFailed(ctx context.Context, data Subscr
 
 // SendSubscriptionCancelled sends a subscription cancelled email
 func (s *Service) SendSubscriptionCancelled(ctx context.Context, data SubscriptionCancelledEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render subscription cancelled template: %w", err)
 	}
@@ -325,9 +386,9 @@ func (s *Service) SendSubscriptionCancelled(ctx context.Context, data Subscripti
 	email := &Email{
 		To:       []string{data.CustomerName},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -340,7 +401,7 @@ func (s *Service) SendSubscriptionCancelled(ctx context.Context, data Subscripti
 
 // SendInvoiceSent sends an invoice sent notification email
 func (s *Service) SendInvoiceSent(ctx context.Context, data InvoiceSentEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render invoice sent template: %w", err)
 	}
@@ -348,9 +409,9 @@ func (s *Service) SendInvoiceSent(ctx context.Context, data InvoiceSentEmail) er
 	email := &Email{
 		To:       []string{data.Email},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -363,7 +424,7 @@ func (s *Service) SendInvoiceSent(ctx context.Context, data InvoiceSentEmail) er
 
 // SendInvoiceReminder sends an invoice payment reminder email
 func (s *Service) SendInvoiceReminder(ctx context.Context, data InvoiceReminderEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render invoice reminder template: %w", err)
 	}
@@ -371,9 +432,9 @@ func (s *Service) SendInvoiceReminder(ctx context.Context, data InvoiceReminderE
 	email := &Email{
 		To:       []string{data.Email},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -386,7 +447,7 @@ func (s *Service) SendInvoiceReminder(ctx context.Context, data InvoiceReminderE
 
 // SendInvoiceOverdue sends an invoice overdue notification email
 func (s *Service) SendInvoiceOverdue(ctx context.Context, data InvoiceOverdueEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render invoice overdue template: %w", err)
 	}
@@ -394,9 +455,9 @@ func (s *Service) SendInvoiceOverdue(ctx context.Context, data InvoiceOverdueEma
 	email := &Email{
 		To:       []string{data.Email},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -411,7 +472,7 @@ func (s *Service) SendInvoiceOverdue(ctx context.Context, data InvoiceOverdueEma
 
 // SendOperatorSetup sends an operator account setup email
 func (s *Service) SendOperatorSetup(ctx context.Context, data OperatorSetupEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render operator setup template: %w", err)
 	}
@@ -419,9 +480,9 @@ func (s *Service) SendOperatorSetup(ctx context.Context, data OperatorSetupEmail
 	email := &Email{
 		To:       []string{data.Email},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -434,7 +495,7 @@ func (s *Service) SendOperatorSetup(ctx context.Context, data OperatorSetupEmail
 
 // SendOperatorPasswordReset sends an operator password reset email
 func (s *Service) SendOperatorPasswordReset(ctx context.Context, data OperatorPasswordResetEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render operator password reset template: %w", err)
 	}
@@ -442,9 +503,9 @@ func (s *Service) SendOperatorPasswordReset(ctx context.Context, data OperatorPa
 	email := &Email{
 		To:       []string{data.Email},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -457,7 +518,7 @@ func (s *Service) SendOperatorPasswordReset(ctx context.Context, data OperatorPa
 
 // SendPlatformPaymentFailed sends a platform subscription payment failure email
 func (s *Service) SendPlatformPaymentFailed(ctx context.Context, data PlatformPaymentFailedEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render platform payment failed template: %w", err)
 	}
@@ -465,9 +526,9 @@ func (s *Service) SendPlatformPaymentFailed(ctx context.Context, data PlatformPa
 	email := &Email{
 		To:       []string{data.Email},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -480,7 +541,7 @@ func (s *Service) SendPlatformPaymentFailed(ctx context.Context, data PlatformPa
 
 // SendPlatformSuspended sends a platform subscription suspended email
 func (s *Service) SendPlatformSuspended(ctx context.Context, data PlatformSuspendedEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render platform suspended template: %w", err)
 	}
@@ -488,9 +549,9 @@ func (s *Service) SendPlatformSuspended(ctx context.Context, data PlatformSuspen
 	email := &Email{
 		To:       []string{data.Email},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -505,7 +566,7 @@ func (s *Service) SendPlatformSuspended(ctx context.Context, data PlatformSuspen
 
 // SendWholesaleApproved sends a wholesale application approved email
 func (s *Service) SendWholesaleApproved(ctx context.Context, data WholesaleApprovedEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render wholesale approved template: %w", err)
 	}
@@ -513,9 +574,9 @@ func (s *Service) SendWholesaleApproved(ctx context.Context, data WholesaleAppro
 	email := &Email{
 		To:       []string{data.Email},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -528,7 +589,7 @@ func (s *Service) SendWholesaleApproved(ctx context.Context, data WholesaleAppro
 
 // SendWholesaleRejected sends a wholesale application rejected email
 func (s *Service) SendWholesaleRejected(ctx context.Context, data WholesaleRejectedEmail) error {
-	htmlBody, textBody, err := s.renderTemplate(data.TemplateName(), data)
+	rendered, err := s.render(ctx, data)
 	if err != nil {
 		return fmt.Errorf("failed to render wholesale rejected template: %w", err)
 	}
@@ -536,9 +597,9 @@ func (s *Service) SendWholesaleRejected(ctx context.Context, data WholesaleRejec
 	email := &Email{
 		To:       []string{data.Email},
 		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
-		Subject:  data.Subject(),
-		HTMLBody: htmlBody,
-		TextBody: textBody,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
 	}
 
 	_, err = s.sender.Send(ctx, email)
@@ -549,15 +610,96 @@ func (s *Service) SendWholesaleRejected(ctx context.Context, data WholesaleRejec
 	return nil
 }
 
+// Review Email Methods
+
+// SendReviewRequest sends a post-delivery review request email
+func (s *Service) SendReviewRequest(ctx context.Context, data ReviewRequestEmail) error {
+	rendered, err := s.render(ctx, data)
+	if err != nil {
+		return fmt.Errorf("failed to render review request template: %w", err)
+	}
+
+	email := &Email{
+		To:       []string{data.Email},
+		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
+	}
+
+	_, err = s.sender.Send(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to send review request email: %w", err)
+	}
+
+	return nil
+}
+
+// Standing Order Email Methods
+
+// SendStandingOrderReminder sends the reminder before a standing order's cutoff
+func (s *Service) SendStandingOrderReminder(ctx context.Context, data StandingOrderReminderEmail) error {
+	rendered, err := s.render(ctx, data)
+	if err != nil {
+		return fmt.Errorf("failed to render standing order reminder template: %w", err)
+	}
+
+	email := &Email{
+		To:       []string{data.Email},
+		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
+	}
+
+	_, err = s.sender.Send(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to send standing order reminder email: %w", err)
+	}
+
+	return nil
+}
+
+// Inventory Email Methods
+
+// SendLowStockDigest sends the daily low stock digest to an operator
+func (s *Service) SendLowStockDigest(ctx context.Context, data LowStockDigestEmail) error {
+	rendered, err := s.render(ctx, data)
+	if err != nil {
+		return fmt.Errorf("failed to render low stock digest template: %w", err)
+	}
+
+	email := &Email{
+		To:       []string{data.Email},
+		From:     fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress),
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
+	}
+
+	_, err = s.sender.Send(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to send low stock digest email: %w", err)
+	}
+
+	return nil
+}
+
 // Helper method to render a template
-func (s *Service) renderTemplate(templateName string, data interface{}) (string, string, error) {
-	tmpl, ok := s.templateCache[templateName]
+func (s *Service) renderTemplate(templateName string, data interface{}, branding Branding) (string, string, error) {
+	cached, ok := s.templateCache[templateName]
 	if !ok {
 		return "", "", ErrTemplateNotFound(templateName)
 	}
 
+	// Cached templates are never executed, so they can be cloned per send
+	tmpl, err := withBranding(cached, branding)
+	if err != nil {
+		return "", "", err
+	}
+
 	var htmlBuf bytes.Buffer
-	err := tmpl.ExecuteTemplate(&htmlBuf, "email_layout", data)
+	err = tmpl.ExecuteTemplate(&htmlBuf, "email_layout", data)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to execute template %s: %w", templateName, err)
 	}
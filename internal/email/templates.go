@@ -1,6 +1,9 @@
 package email
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // EmailTemplate defines the interface for email templates
 type EmailTemplate interface {
@@ -42,6 +45,7 @@ func (e EmailVerificationEmail) TemplateName() string {
 
 // OrderConfirmationEmail represents an order confirmation email
 type OrderConfirmationEmail struct {
+	Email         string
 	OrderNumber   string
 	CustomerName  string
 	OrderDate     time.Time
@@ -83,6 +87,55 @@ func (e ShippingConfirmationEmail) TemplateName() string {
 	return "shipping_confirmation.html"
 }
 
+// ShipmentUpdateEmail represents a delivery or delivery problem email
+type ShipmentUpdateEmail struct {
+	Email          string
+	CustomerName   string
+	OrderNumber    string
+	Status         string // Shipment status: delivered, failed or returned
+	Message        string // Latest carrier message, if any
+	UpdatedAt      time.Time
+	Carrier        string
+	TrackingNumber string
+	TrackingURL    string
+}
+
+// Delivered reports whether the package has arrived.
+func (e ShipmentUpdateEmail) Delivered() bool {
+	return e.Status == "delivered"
+}
+
+func (e ShipmentUpdateEmail) Subject() string {
+	if e.Delivered() {
+		return "Your Order Has Been Delivered - " + e.OrderNumber
+	}
+	return "There's a Problem With Your Delivery - " + e.OrderNumber
+}
+
+func (e ShipmentUpdateEmail) TemplateName() string {
+	return "shipment_update.html"
+}
+
+// RefundConfirmationEmail represents a refund confirmation email
+type RefundConfirmationEmail struct {
+	Email              string
+	CustomerName       string
+	OrderNumber        string
+	RefundedDate       time.Time
+	RefundCents        int64
+	TotalRefundedCents int64
+	OrderTotalCents    int64
+	FullyRefunded      bool
+}
+
+func (e RefundConfirmationEmail) Subject() string {
+	return "Your Refund Has Been Issued - " + e.OrderNumber
+}
+
+func (e RefundConfirmationEmail) TemplateName() string {
+	return "refund_confirmation.html"
+}
+
 // SubscriptionWelcomeEmail represents a subscription welcome email
 type SubscriptionWelcomeEmail struct {
 	CustomerName      string
@@ -102,17 +155,28 @@ func (e SubscriptionWelcomeEmail) TemplateName() string {
 	return "subscription_welcome.html"
 }
 
-// SubscriptionPaymentFailedEmail represents a failed subscription payment email
+// SubscriptionPaymentFailedEmail represents a failed subscription payment email.
+// FinalAction is set ("pause" or "cancel") once the last retry has failed;
+// until then RetryDate is the next retry.
 type SubscriptionPaymentFailedEmail struct {
+	Email            string
 	CustomerName     string
 	ProductName      string
 	FailedDate       time.Time
 	RetryDate        time.Time
+	AttemptNumber    int
+	FinalAction      string
 	UpdatePaymentURL string
 	ManagementURL    string
 }
 
 func (e SubscriptionPaymentFailedEmail) Subject() string {
+	switch e.FinalAction {
+	case "pause":
+		return "Your Subscription Has Been Paused"
+	case "cancel":
+		return "Your Subscription Has Been Cancelled"
+	}
 	return "Subscription Payment Issue"
 }
 
@@ -216,6 +280,11 @@ type OrderItem struct {
 	PriceCents  int64
 	TotalCents  int64
 	ImageURL    string // Optional product image
+
+	// RoastDate is the date of the roast batch the item is allocated to;
+	// zero when not allocated. RoastPlanned marks a roast not yet done.
+	RoastDate    time.Time
+	RoastPlanned bool
 }
 
 // InvoiceItem represents a line item on an invoice
@@ -334,3 +403,87 @@ func (e WholesaleRejectedEmail) Subject() string {
 func (e WholesaleRejectedEmail) TemplateName() string {
 	return "wholesale_rejected.html"
 }
+
+// Review Emails
+
+// ReviewRequestEmail represents the email asking a customer to review the
+// products in a delivered order
+type ReviewRequestEmail struct {
+	Email        string
+	CustomerName string
+	OrderNumber  string
+	Items        []ReviewRequestItem
+}
+
+// ReviewRequestItem represents a product a customer can review
+type ReviewRequestItem struct {
+	ProductName string
+	ReviewURL   string
+}
+
+func (e ReviewRequestEmail) Subject() string {
+	return "How Was Your Coffee? - " + e.OrderNumber
+}
+
+func (e ReviewRequestEmail) TemplateName() string {
+	return "review_request.html"
+}
+
+// Standing Order Emails
+
+// StandingOrderReminderEmail represents the email reminding a wholesale
+// customer that their standing order will be placed at the cutoff
+type StandingOrderReminderEmail struct {
+	Email             string
+	CustomerName      string
+	StandingOrderName string
+	DeliveryDate      time.Time
+	CutoffDate        time.Time
+	Items             []StandingOrderReminderItem
+	ManageURL         string
+}
+
+// StandingOrderReminderItem represents a line in a standing order reminder
+type StandingOrderReminderItem struct {
+	ProductName string
+	SKU         string
+	Quantity    int32
+}
+
+func (e StandingOrderReminderEmail) Subject() string {
+	return "Your Standing Order for " + e.DeliveryDate.Format("Monday, January 2")
+}
+
+func (e StandingOrderReminderEmail) TemplateName() string {
+	return "standing_order_reminder.html"
+}
+
+// Inventory Emails
+
+// LowStockDigestEmail represents the daily email listing SKUs running low
+type LowStockDigestEmail struct {
+	Email        string
+	Name         string
+	Items        []LowStockItem
+	InventoryURL string
+}
+
+// LowStockItem represents a SKU in a low stock digest
+type LowStockItem struct {
+	ProductName       string
+	SKU               string
+	Variant           string // e.g. "12 oz, whole bean"
+	InventoryQuantity int32
+	LowStockThreshold *int32 // Nil when the SKU is only reported once it runs out
+}
+
+func (e LowStockDigestEmail) Subject() string {
+	if len(e.Items) == 1 {
+		return "1 SKU Is Running Low"
+	}
+	return fmt.Sprintf("%d SKUs Are Running Low", len(e.Items))
+}
+
+func (e LowStockDigestEmail) TemplateName() string {
+	return "low_stock_digest.html"
+}
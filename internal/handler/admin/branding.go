@@ -0,0 +1,145 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+)
+
+// BrandingHandler handles the storefront and email branding settings page
+type BrandingHandler struct {
+	brandingService domain.BrandingService
+	renderer        *handler.Renderer
+}
+
+// NewBrandingHandler creates a new branding handler
+func NewBrandingHandler(brandingService domain.BrandingService, renderer *handler.Renderer) *BrandingHandler {
+	return &BrandingHandler{
+		brandingService: brandingService,
+		renderer:        renderer,
+	}
+}
+
+// Show handles GET /admin/settings/branding
+func (h *BrandingHandler) Show(w http.ResponseWriter, r *http.Request) {
+	branding, err := h.brandingService.GetBranding(r.Context())
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	var notice string
+	if r.URL.Query().Get("saved") != "" {
+		notice = "Branding saved."
+	}
+
+	h.render(w, r, branding, notice, "")
+}
+
+func (h *BrandingHandler) render(w http.ResponseWriter, r *http.Request, branding *domain.Branding, notice, errMsg string) {
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"CSRFToken":   middleware.GetCSRFToken(r.Context()),
+		"Branding":    branding,
+		"Fonts":       domain.BrandFonts,
+		"Notice":      notice,
+		"Error":       errMsg,
+	}
+
+	h.renderer.RenderHTTP(w, "admin/branding", data)
+}
+
+// renderError re-renders the page with the saved branding and an error.
+func (h *BrandingHandler) renderError(w http.ResponseWriter, r *http.Request, branding *domain.Branding, err error) {
+	if domain.ErrorCode(err) != domain.EINVALID {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	if branding == nil {
+		branding, err = h.brandingService.GetBranding(r.Context())
+		if err != nil {
+			handler.ErrorResponse(w, r, err)
+			return
+		}
+	}
+
+	h.render(w, r, branding, "", domain.ErrorMessage(err))
+}
+
+// Update handles POST /admin/settings/branding
+func (h *BrandingHandler) Update(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	params := domain.UpdateBrandingParams{
+		PrimaryColor: r.FormValue("primary_color"),
+		AccentColor:  r.FormValue("accent_color"),
+		HeadingFont:  r.FormValue("heading_font"),
+		BodyFont:     r.FormValue("body_font"),
+	}
+
+	if _, err := h.brandingService.UpdateBranding(r.Context(), params); err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			// Keep the submitted values so they can be corrected
+			branding, getErr := h.brandingService.GetBranding(r.Context())
+			if getErr != nil {
+				handler.ErrorResponse(w, r, getErr)
+				return
+			}
+			branding.PrimaryColor = params.PrimaryColor
+			branding.AccentColor = params.AccentColor
+			branding.HeadingFont = params.HeadingFont
+			branding.BodyFont = params.BodyFont
+			h.renderError(w, r, branding, err)
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/settings/branding?saved=1", http.StatusSeeOther)
+}
+
+// UploadImage handles POST /admin/settings/branding/{kind}
+// Uploads a new logo or favicon.
+func (h *BrandingHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	file, fileHeader, err := r.FormFile("image")
+	if err != nil {
+		h.renderError(w, r, nil, domain.Errorf(domain.EINVALID, "", "Choose an image to upload"))
+		return
+	}
+	defer file.Close()
+
+	_, err = h.brandingService.UploadImage(r.Context(), domain.UploadBrandImageParams{
+		Kind:    domain.BrandImageKind(r.PathValue("kind")),
+		Content: file,
+		Size:    fileHeader.Size,
+	})
+	if err != nil {
+		h.renderError(w, r, nil, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/settings/branding?saved=1", http.StatusSeeOther)
+}
+
+// RemoveImage handles POST /admin/settings/branding/{kind}/remove
+func (h *BrandingHandler) RemoveImage(w http.ResponseWriter, r *http.Request) {
+	_, err := h.brandingService.RemoveImage(r.Context(), domain.BrandImageKind(r.PathValue("kind")))
+	if err != nil {
+		h.renderError(w, r, nil, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/settings/branding?saved=1", http.StatusSeeOther)
+}
@@ -0,0 +1,281 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// CategoryHandler handles product category and tag admin routes
+type CategoryHandler struct {
+	categoryService domain.CategoryService
+	renderer        *handler.Renderer
+}
+
+// NewCategoryHandler creates a new category handler
+func NewCategoryHandler(categoryService domain.CategoryService, renderer *handler.Renderer) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService: categoryService,
+		renderer:        renderer,
+	}
+}
+
+// List handles GET /admin/categories
+func (h *CategoryHandler) List(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.categoryService.ListCategories(r.Context())
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"CSRFToken":   middleware.GetCSRFToken(r.Context()),
+		"Categories":  categories,
+	}
+
+	h.renderer.RenderHTTP(w, "admin/categories", data)
+}
+
+// ShowForm handles GET /admin/categories/new and GET /admin/categories/{id}/edit
+func (h *CategoryHandler) ShowForm(w http.ResponseWriter, r *http.Request) {
+	category := repository.ProductCategory{IsActive: true}
+
+	if id := r.PathValue("id"); id != "" {
+		c, err := h.categoryService.GetCategory(r.Context(), id)
+		if err != nil {
+			handler.ErrorResponse(w, r, err)
+			return
+		}
+		category = *c
+	}
+
+	h.renderForm(w, r, category, "")
+}
+
+// HandleForm handles POST /admin/categories/new and POST /admin/categories/{id}/edit
+func (h *CategoryHandler) HandleForm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	params := parseCategoryForm(r)
+
+	id := r.PathValue("id")
+	var err error
+	if id != "" {
+		_, err = h.categoryService.UpdateCategory(ctx, id, params)
+	} else {
+		_, err = h.categoryService.CreateCategory(ctx, params)
+	}
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID || domain.ErrorCode(err) == domain.ECONFLICT {
+			category := categoryFromForm(params)
+			if id != "" {
+				if current, getErr := h.categoryService.GetCategory(ctx, id); getErr == nil {
+					category.ID = current.ID
+				}
+			}
+			h.renderForm(w, r, category, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/categories", http.StatusSeeOther)
+}
+
+// Delete handles POST /admin/categories/{id}/delete
+func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Category ID required"))
+		return
+	}
+
+	if err := h.categoryService.DeleteCategory(r.Context(), id); err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/categories", http.StatusSeeOther)
+}
+
+// ListTags handles GET /admin/tags
+func (h *CategoryHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	h.renderTags(w, r, "")
+}
+
+// CreateTag handles POST /admin/tags/new
+func (h *CategoryHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	_, err := h.categoryService.CreateTag(r.Context(), domain.TagParams{
+		Name: strings.TrimSpace(r.FormValue("name")),
+		Slug: strings.TrimSpace(r.FormValue("slug")),
+	})
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID || domain.ErrorCode(err) == domain.ECONFLICT {
+			h.renderTags(w, r, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/tags", http.StatusSeeOther)
+}
+
+// UpdateTag handles POST /admin/tags/{id}/edit
+func (h *CategoryHandler) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	_, err := h.categoryService.UpdateTag(r.Context(), r.PathValue("id"), domain.TagParams{
+		Name: strings.TrimSpace(r.FormValue("name")),
+		Slug: strings.TrimSpace(r.FormValue("slug")),
+	})
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID || domain.ErrorCode(err) == domain.ECONFLICT {
+			h.renderTags(w, r, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/tags", http.StatusSeeOther)
+}
+
+// DeleteTag handles POST /admin/tags/{id}/delete
+func (h *CategoryHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Tag ID required"))
+		return
+	}
+
+	if err := h.categoryService.DeleteTag(r.Context(), id); err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/tags", http.StatusSeeOther)
+}
+
+func (h *CategoryHandler) renderForm(w http.ResponseWriter, r *http.Request, category repository.ProductCategory, errMsg string) {
+	categories, err := h.categoryService.ListCategories(r.Context())
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	// A category can't be nested under itself or its subcategories, so
+	// leave its own branch out of the parent choices
+	type parentOption struct {
+		ID    string
+		Label string
+	}
+	parents := make([]parentOption, 0, len(categories))
+	skipDepth := -1
+	for _, c := range categories {
+		if skipDepth >= 0 {
+			if c.Depth > skipDepth {
+				continue
+			}
+			skipDepth = -1
+		}
+		if category.ID.Valid && c.ID == category.ID {
+			skipDepth = c.Depth
+			continue
+		}
+		parents = append(parents, parentOption{
+			ID:    formatUUID(c.ID),
+			Label: strings.Repeat("— ", c.Depth) + c.Name,
+		})
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"CSRFToken":   middleware.GetCSRFToken(r.Context()),
+		"Category":    category,
+		"ParentID":    formatUUID(category.ParentID),
+		"Parents":     parents,
+		"Error":       errMsg,
+	}
+
+	if errMsg != "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+
+	h.renderer.RenderHTTP(w, "admin/category_form", data)
+}
+
+func (h *CategoryHandler) renderTags(w http.ResponseWriter, r *http.Request, errMsg string) {
+	tags, err := h.categoryService.ListTags(r.Context())
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"CSRFToken":   middleware.GetCSRFToken(r.Context()),
+		"Tags":        tags,
+		"Error":       errMsg,
+	}
+
+	if errMsg != "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+
+	h.renderer.RenderHTTP(w, "admin/tags", data)
+}
+
+// parseCategoryForm converts the submitted form into service parameters.
+func parseCategoryForm(r *http.Request) domain.CategoryParams {
+	sortOrder, _ := strconv.Atoi(strings.TrimSpace(r.FormValue("sort_order")))
+
+	return domain.CategoryParams{
+		Name:            strings.TrimSpace(r.FormValue("name")),
+		Slug:            strings.TrimSpace(r.FormValue("slug")),
+		Description:     strings.TrimSpace(r.FormValue("description")),
+		ParentID:        r.FormValue("parent_id"),
+		SortOrder:       int32(sortOrder),
+		IsActive:        r.FormValue("is_active") == "on",
+		MetaTitle:       strings.TrimSpace(r.FormValue("meta_title")),
+		MetaDescription: strings.TrimSpace(r.FormValue("meta_description")),
+	}
+}
+
+// categoryFromForm rebuilds a category from the submitted form so the form
+// can be re-rendered with the user's input after a validation error.
+func categoryFromForm(params domain.CategoryParams) repository.ProductCategory {
+	c := repository.ProductCategory{
+		Name:            params.Name,
+		Slug:            params.Slug,
+		Description:     makePgText(params.Description),
+		SortOrder:       params.SortOrder,
+		IsActive:        params.IsActive,
+		MetaTitle:       makePgText(params.MetaTitle),
+		MetaDescription: makePgText(params.MetaDescription),
+	}
+	if params.ParentID != "" {
+		_ = c.ParentID.Scan(params.ParentID)
+	}
+	return c
+}
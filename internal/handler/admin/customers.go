@@ -1,27 +1,34 @@
 package admin
 
 import (
+	"context"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/dukerupert/hiri/internal/domain"
 	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
 	"github.com/dukerupert/hiri/internal/repository"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // CustomerHandler handles all customer-related admin routes
 type CustomerHandler struct {
-	repo           repository.Querier
-	invoiceService domain.InvoiceService
-	renderer       *handler.Renderer
+	repo              repository.Querier
+	invoiceService    domain.InvoiceService
+	orderRulesService domain.OrderRulesService
+	renderer          *handler.Renderer
 }
 
 // NewCustomerHandler creates a new customer handler
-func NewCustomerHandler(repo repository.Querier, invoiceService domain.InvoiceService, renderer *handler.Renderer) *CustomerHandler {
+func NewCustomerHandler(repo repository.Querier, invoiceService domain.InvoiceService, orderRulesService domain.OrderRulesService, renderer *handler.Renderer) *CustomerHandler {
 	return &CustomerHandler{
-		repo:           repo,
-		invoiceService: invoiceService,
-		renderer:       renderer,
+		repo:              repo,
+		invoiceService:    invoiceService,
+		orderRulesService: orderRulesService,
+		renderer:          renderer,
 	}
 }
 
@@ -146,11 +153,17 @@ func (h *CustomerHandler) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var invoices []repository.Invoice
+	var orderRules *domain.CustomerOrderRules
 	if customer.AccountType == "wholesale" {
 		invoices, err = h.invoiceService.ListInvoicesForUser(ctx, customerID, 20, 0)
 		if err != nil {
 			invoices = nil
 		}
+
+		orderRules, err = h.orderRulesService.ListCustomerRules(ctx, customerID)
+		if err != nil {
+			orderRules = nil
+		}
 	}
 
 	addresses, err := h.repo.ListAddressesForUser(ctx, repository.ListAddressesForUserParams{
@@ -181,13 +194,25 @@ func (h *CustomerHandler) Detail(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Unpaid invoices plus orders on account not yet invoiced
+	var outstandingCents int32
+	if paymentTerms != nil {
+		outstandingCents, _ = h.repo.GetCustomerOutstandingBalance(ctx, repository.GetCustomerOutstandingBalanceParams{
+			TenantID: tenantID,
+			UserID:   customerUUID,
+		})
+	}
+
 	data := map[string]interface{}{
-		"CurrentPath":  r.URL.Path,
-		"Customer":     customer,
-		"FullName":     fullName,
-		"Invoices":     invoices,
-		"Addresses":    addresses,
-		"PaymentTerms": paymentTerms,
+		"CurrentPath":      r.URL.Path,
+		"Customer":         customer,
+		"FullName":         fullName,
+		"Invoices":         invoices,
+		"Addresses":        addresses,
+		"PaymentTerms":     paymentTerms,
+		"OutstandingCents": outstandingCents,
+		"OrderRules":       orderRules,
+		"CSRFToken":        middleware.GetCSRFToken(ctx),
 	}
 
 	h.renderer.RenderHTTP(w, "admin/customer_detail", data)
@@ -244,6 +269,7 @@ func (h *CustomerHandler) Edit(w http.ResponseWriter, r *http.Request) {
 		"FullName":      fullName,
 		"PaymentTerms":  paymentTerms,
 		"StatusOptions": []string{"active", "suspended", "closed"},
+		"CSRFToken":     middleware.GetCSRFToken(ctx),
 	}
 
 	h.renderer.RenderHTTP(w, "admin/customer_edit", data)
@@ -324,10 +350,126 @@ func (h *CustomerHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if customer.AccountType == "wholesale" {
+		if err := h.updateWholesaleTerms(ctx, r, customer, params); err != nil {
+			handler.ErrorResponse(w, r, err)
+			return
+		}
+	}
+
 	// Redirect back to detail page
 	http.Redirect(w, r, "/admin/customers/"+customerID, http.StatusSeeOther)
 }
 
+// updateWholesaleTerms saves a wholesale customer's payment terms, billing
+// cycle, credit limit and minimum order, keeping their other wholesale
+// settings.
+func (h *CustomerHandler) updateWholesaleTerms(ctx context.Context, r *http.Request, customer repository.User, details repository.AdminUpdateCustomerParams) error {
+	params := repository.UpdateWholesaleCustomerParams{
+		ID:                customer.ID,
+		CompanyName:       details.CompanyName,
+		CustomerReference: customer.CustomerReference,
+		InternalNote:      details.InternalNote,
+		EmailOrders:       customer.EmailOrders,
+		EmailDispatches:   customer.EmailDispatches,
+		EmailInvoices:     customer.EmailInvoices,
+	}
+
+	if termsID := r.FormValue("payment_terms_id"); termsID != "" {
+		if err := params.PaymentTermsID.Scan(termsID); err != nil {
+			return domain.Errorf(domain.EINVALID, "", "Invalid payment terms")
+		}
+	}
+
+	switch cycle := domain.BillingCycle(r.FormValue("billing_cycle")); cycle {
+	case "":
+	case domain.BillingCycleWeekly, domain.BillingCycleBiweekly, domain.BillingCycleMonthly, domain.BillingCycleOnOrder:
+		params.BillingCycle = pgtype.Text{String: string(cycle), Valid: true}
+	default:
+		return domain.Errorf(domain.EINVALID, "", "Invalid billing cycle")
+	}
+
+	if day := strings.TrimSpace(r.FormValue("billing_cycle_day")); day != "" {
+		n, err := strconv.Atoi(day)
+		if err != nil || n < 1 || n > 28 {
+			return domain.Errorf(domain.EINVALID, "", "Billing day must be between 1 and 28")
+		}
+		params.BillingCycleDay = pgtype.Int4{Int32: int32(n), Valid: true}
+	}
+
+	if limit := strings.TrimSpace(r.FormValue("credit_limit")); limit != "" {
+		dollars, err := strconv.ParseFloat(limit, 64)
+		if err != nil || dollars < 0 {
+			return domain.Errorf(domain.EINVALID, "", "Enter the credit limit in dollars, e.g. 5000.00")
+		}
+		params.CreditLimitCents = pgtype.Int4{Int32: int32(math.Round(dollars * 100)), Valid: true}
+	}
+
+	if minimum := strings.TrimSpace(r.FormValue("minimum_order")); minimum != "" {
+		dollars, err := strconv.ParseFloat(minimum, 64)
+		if err != nil || dollars < 0 {
+			return domain.Errorf(domain.EINVALID, "", "Enter the minimum order in dollars, e.g. 150.00")
+		}
+		params.MinimumSpendCents = pgtype.Int4{Int32: int32(math.Round(dollars * 100)), Valid: true}
+	}
+
+	return h.repo.UpdateWholesaleCustomer(ctx, params)
+}
+
+// SetOrderRule handles POST /admin/customers/{id}/order-rules
+func (h *CustomerHandler) SetOrderRule(w http.ResponseWriter, r *http.Request) {
+	customerID := r.PathValue("id")
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	// Blank waives the rule for this customer
+	var minQuantity, casePack int
+	if v := strings.TrimSpace(r.FormValue("min_quantity")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			handler.ErrorResponse(w, r, domain.ErrInvalidQuantityRule)
+			return
+		}
+		minQuantity = n
+	}
+	if v := strings.TrimSpace(r.FormValue("case_pack")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			handler.ErrorResponse(w, r, domain.ErrInvalidQuantityRule)
+			return
+		}
+		casePack = n
+	}
+
+	err := h.orderRulesService.SetCustomerRule(r.Context(), domain.SetCustomerRuleParams{
+		UserID:      customerID,
+		SKUID:       r.FormValue("sku_id"),
+		MinQuantity: int32(minQuantity),
+		CasePack:    int32(casePack),
+	})
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/customers/"+customerID, http.StatusSeeOther)
+}
+
+// DeleteOrderRule handles POST /admin/customers/{id}/order-rules/{sku_id}/delete
+func (h *CustomerHandler) DeleteOrderRule(w http.ResponseWriter, r *http.Request) {
+	customerID := r.PathValue("id")
+
+	if err := h.orderRulesService.DeleteCustomerRule(r.Context(), customerID, r.PathValue("sku_id")); err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/customers/"+customerID, http.StatusSeeOther)
+}
+
 // WholesaleApproval handles POST /admin/customers/{id}/wholesale/{action}
 func (h *CustomerHandler) WholesaleApproval(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
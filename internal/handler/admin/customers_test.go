@@ -0,0 +1,112 @@
+package admin
+
+import (
+	"context"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/cookie"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var csrfInputPattern = regexp.MustCompile(`name="csrf_token" value="([^"]+)"`)
+
+func newTestUUID(t *testing.T, s string) pgtype.UUID {
+	t.Helper()
+	var id pgtype.UUID
+	require.NoError(t, id.Scan(s))
+	return id
+}
+
+// withOperator serves requests as an operator of the tenant, behind the CSRF
+// middleware the admin routes use.
+func withOperator(tenantID pgtype.UUID, next http.Handler) http.Handler {
+	csrf := middleware.CSRF(middleware.DefaultCSRFConfig(cookie.NewConfig("hiri.test", false)))
+	return csrf(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		operator := &repository.TenantOperator{TenantID: tenantID}
+		ctx := context.WithValue(r.Context(), middleware.OperatorContextKey, operator)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}))
+}
+
+func TestCustomerHandler_EditFormSavesWholesaleTerms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	renderer, err := handler.NewRenderer("../../../web/templates")
+	require.NoError(t, err)
+
+	tenantID := newTestUUID(t, "11111111-1111-1111-1111-111111111111")
+	customerID := "22222222-2222-2222-2222-222222222222"
+	customer := repository.User{
+		ID:          newTestUUID(t, customerID),
+		TenantID:    tenantID,
+		Email:       "buyer@cafe.test",
+		AccountType: "wholesale",
+		Status:      "active",
+	}
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), customer.ID).Return(customer, nil).Times(2)
+	mockRepo.EXPECT().ListPaymentTerms(gomock.Any(), tenantID).Return(nil, nil)
+	mockRepo.EXPECT().
+		AdminUpdateCustomer(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, params repository.AdminUpdateCustomerParams) error {
+			assert.Equal(t, "Bean There", params.CompanyName.String)
+			return nil
+		})
+	mockRepo.EXPECT().
+		UpdateWholesaleCustomer(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, params repository.UpdateWholesaleCustomerParams) error {
+			assert.Equal(t, pgtype.Int4{Int32: 500000, Valid: true}, params.CreditLimitCents)
+			assert.Equal(t, pgtype.Int4{Int32: 15000, Valid: true}, params.MinimumSpendCents)
+			return nil
+		})
+
+	h := NewCustomerHandler(mockRepo, nil, nil, renderer)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/customers/{id}/edit", h.Edit)
+	mux.HandleFunc("POST /admin/customers/{id}", h.Update)
+	server := withOperator(tenantID, mux)
+
+	// Load the form to get the CSRF cookie and the token rendered into it
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/customers/"+customerID+"/edit", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	match := csrfInputPattern.FindStringSubmatch(rec.Body.String())
+	require.NotNil(t, match, "edit form has no csrf_token input")
+	cookies := rec.Result().Cookies()
+	require.NotEmpty(t, cookies)
+
+	// Tokens are base64, so a "+" is rendered escaped
+	form := url.Values{
+		"csrf_token":    {html.UnescapeString(match[1])},
+		"company_name":  {"Bean There"},
+		"status":        {"active"},
+		"credit_limit":  {"5000.00"},
+		"minimum_order": {"150.00"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/admin/customers/"+customerID, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/admin/customers/"+customerID, rec.Header().Get("Location"))
+}
@@ -0,0 +1,272 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// discountDateLayout matches the value format of <input type="datetime-local">
+const discountDateLayout = "2006-01-02T15:04"
+
+// DiscountHandler handles discount code admin routes
+type DiscountHandler struct {
+	discountService domain.DiscountService
+	renderer        *handler.Renderer
+}
+
+// NewDiscountHandler creates a new discount handler
+func NewDiscountHandler(discountService domain.DiscountService, renderer *handler.Renderer) *DiscountHandler {
+	return &DiscountHandler{
+		discountService: discountService,
+		renderer:        renderer,
+	}
+}
+
+// List handles GET /admin/discounts
+func (h *DiscountHandler) List(w http.ResponseWriter, r *http.Request) {
+	codes, err := h.discountService.ListDiscountCodes(r.Context())
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"CSRFToken":   middleware.GetCSRFToken(r.Context()),
+		"Discounts":   codes,
+		"Now":         time.Now(),
+	}
+
+	h.renderer.RenderHTTP(w, "admin/discounts", data)
+}
+
+// ShowForm handles GET /admin/discounts/new and GET /admin/discounts/{id}/edit
+func (h *DiscountHandler) ShowForm(w http.ResponseWriter, r *http.Request) {
+	var discount repository.DiscountCode
+
+	if id := r.PathValue("id"); id != "" {
+		code, err := h.discountService.GetDiscountCode(r.Context(), id)
+		if err != nil {
+			handler.ErrorResponse(w, r, err)
+			return
+		}
+		discount = *code
+	}
+
+	h.renderForm(w, r, discount, "")
+}
+
+// HandleForm handles POST /admin/discounts/new and POST /admin/discounts/{id}/edit
+func (h *DiscountHandler) HandleForm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	params, err := parseDiscountForm(r)
+	if err != nil {
+		h.renderForm(w, r, discountFromForm(r), domain.ErrorMessage(err))
+		return
+	}
+
+	id := r.PathValue("id")
+	if id != "" {
+		_, err = h.discountService.UpdateDiscountCode(ctx, id, params)
+	} else {
+		_, err = h.discountService.CreateDiscountCode(ctx, params)
+	}
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID || domain.ErrorCode(err) == domain.ECONFLICT {
+			discount := discountFromForm(r)
+			if id != "" {
+				if current, getErr := h.discountService.GetDiscountCode(ctx, id); getErr == nil {
+					discount.ID = current.ID
+					discount.UsageCount = current.UsageCount
+				}
+			}
+			h.renderForm(w, r, discount, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/discounts", http.StatusSeeOther)
+}
+
+// Delete handles POST /admin/discounts/{id}/delete
+func (h *DiscountHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Discount ID required"))
+		return
+	}
+
+	if err := h.discountService.DeleteDiscountCode(r.Context(), id); err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/discounts", http.StatusSeeOther)
+}
+
+func (h *DiscountHandler) renderForm(w http.ResponseWriter, r *http.Request, discount repository.DiscountCode, errMsg string) {
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"CSRFToken":   middleware.GetCSRFToken(r.Context()),
+		"Discount":    discount,
+		"Error":       errMsg,
+		"StartsAt":    formatDiscountDate(discount.StartsAt.Time, discount.StartsAt.Valid),
+		"ExpiresAt":   formatDiscountDate(discount.ExpiresAt.Time, discount.ExpiresAt.Valid),
+		"DiscountTypes": []struct {
+			Value string
+			Label string
+		}{
+			{domain.DiscountTypePercentage, "Percentage off"},
+			{domain.DiscountTypeFixedAmount, "Fixed amount off"},
+			{domain.DiscountTypeFreeShipping, "Free shipping"},
+		},
+	}
+
+	if errMsg != "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+
+	h.renderer.RenderHTTP(w, "admin/discount_form", data)
+}
+
+// parseDiscountForm converts the submitted form into service parameters.
+// Fixed amounts and minimum order are entered in dollars and stored in cents.
+func parseDiscountForm(r *http.Request) (domain.DiscountCodeParams, error) {
+	params := domain.DiscountCodeParams{
+		Code:         strings.TrimSpace(r.FormValue("code")),
+		Description:  strings.TrimSpace(r.FormValue("description")),
+		DiscountType: r.FormValue("discount_type"),
+		IsActive:     r.FormValue("is_active") == "on",
+	}
+
+	switch params.DiscountType {
+	case domain.DiscountTypePercentage:
+		pct, err := strconv.Atoi(strings.TrimSpace(r.FormValue("discount_value")))
+		if err != nil {
+			return params, domain.Errorf(domain.EINVALID, "", "Invalid percentage")
+		}
+		params.DiscountValue = int32(pct)
+	case domain.DiscountTypeFixedAmount:
+		cents, err := parseDollarsToCents(r.FormValue("discount_value"))
+		if err != nil || cents == nil {
+			return params, domain.Errorf(domain.EINVALID, "", "Invalid discount amount")
+		}
+		params.DiscountValue = *cents
+	}
+
+	minimum, err := parseDollarsToCents(r.FormValue("minimum_order"))
+	if err != nil {
+		return params, domain.Errorf(domain.EINVALID, "", "Invalid minimum order amount")
+	}
+	params.MinimumOrderCents = minimum
+
+	if params.UsageLimit, err = parseOptionalCount(r.FormValue("usage_limit")); err != nil {
+		return params, domain.Errorf(domain.EINVALID, "", "Invalid usage limit")
+	}
+	if params.UsageLimitPerCustomer, err = parseOptionalCount(r.FormValue("usage_limit_per_customer")); err != nil {
+		return params, domain.Errorf(domain.EINVALID, "", "Invalid per-customer limit")
+	}
+
+	if v := strings.TrimSpace(r.FormValue("starts_at")); v != "" {
+		t, err := time.ParseInLocation(discountDateLayout, v, time.Local)
+		if err != nil {
+			return params, domain.Errorf(domain.EINVALID, "", "Invalid start date")
+		}
+		params.StartsAt = t
+	}
+	if v := strings.TrimSpace(r.FormValue("expires_at")); v != "" {
+		t, err := time.ParseInLocation(discountDateLayout, v, time.Local)
+		if err != nil {
+			return params, domain.Errorf(domain.EINVALID, "", "Invalid expiry date")
+		}
+		params.ExpiresAt = &t
+	}
+
+	return params, nil
+}
+
+// discountFromForm rebuilds a discount code from the submitted form so the
+// form can be re-rendered with the user's input after a validation error.
+func discountFromForm(r *http.Request) repository.DiscountCode {
+	var d repository.DiscountCode
+	d.Code = strings.TrimSpace(r.FormValue("code"))
+	d.Description.String = strings.TrimSpace(r.FormValue("description"))
+	d.Description.Valid = d.Description.String != ""
+	d.DiscountType = r.FormValue("discount_type")
+	d.IsActive = r.FormValue("is_active") == "on"
+
+	if params, err := parseDiscountForm(r); err == nil {
+		d.DiscountValue = params.DiscountValue
+		if params.MinimumOrderCents != nil {
+			d.MinimumOrderCents.Int32, d.MinimumOrderCents.Valid = *params.MinimumOrderCents, true
+		}
+		if params.UsageLimit != nil {
+			d.UsageLimit.Int32, d.UsageLimit.Valid = *params.UsageLimit, true
+		}
+		if params.UsageLimitPerCustomer != nil {
+			d.UsageLimitPerCustomer.Int32, d.UsageLimitPerCustomer.Valid = *params.UsageLimitPerCustomer, true
+		}
+		if !params.StartsAt.IsZero() {
+			d.StartsAt.Time, d.StartsAt.Valid = params.StartsAt, true
+		}
+		if params.ExpiresAt != nil {
+			d.ExpiresAt.Time, d.ExpiresAt.Valid = *params.ExpiresAt, true
+		}
+	}
+
+	return d
+}
+
+// parseDollarsToCents parses an optional dollar amount such as "12.50".
+func parseDollarsToCents(s string) (*int32, error) {
+	s = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), "$"))
+	if s == "" {
+		return nil, nil
+	}
+
+	dollars, err := strconv.ParseFloat(s, 64)
+	if err != nil || dollars < 0 {
+		return nil, domain.Errorf(domain.EINVALID, "", "Invalid amount")
+	}
+
+	cents := int32(dollars*100 + 0.5)
+	return &cents, nil
+}
+
+// parseOptionalCount parses an optional positive integer limit.
+func parseOptionalCount(s string) (*int32, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return nil, domain.Errorf(domain.EINVALID, "", "Invalid limit")
+	}
+
+	v := int32(n)
+	return &v, nil
+}
+
+func formatDiscountDate(t time.Time, valid bool) string {
+	if !valid {
+		return ""
+	}
+	return t.Local().Format(discountDateLayout)
+}
@@ -0,0 +1,193 @@
+package admin
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+)
+
+// EmailTemplateHandler handles the transactional email settings pages
+type EmailTemplateHandler struct {
+	emailTemplateService domain.EmailTemplateService
+	renderer             *handler.Renderer
+}
+
+// NewEmailTemplateHandler creates a new email template handler
+func NewEmailTemplateHandler(emailTemplateService domain.EmailTemplateService, renderer *handler.Renderer) *EmailTemplateHandler {
+	return &EmailTemplateHandler{
+		emailTemplateService: emailTemplateService,
+		renderer:             renderer,
+	}
+}
+
+// emailEditorState is what the editor shows besides the saved template.
+type emailEditorState struct {
+	Draft   *domain.SaveEmailTemplateParams // Submitted values, shown instead of the saved ones
+	Preview *domain.EmailPreview
+	Notice  string
+	Error   string
+}
+
+// List handles GET /admin/settings/emails
+func (h *EmailTemplateHandler) List(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.emailTemplateService.ListTemplates(r.Context())
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"Templates":   templates,
+	}
+
+	h.renderer.RenderHTTP(w, "admin/email_templates", data)
+}
+
+// Edit handles GET /admin/settings/emails/{type}
+func (h *EmailTemplateHandler) Edit(w http.ResponseWriter, r *http.Request) {
+	var state emailEditorState
+	switch {
+	case r.URL.Query().Get("saved") != "":
+		state.Notice = "Email saved."
+	case r.URL.Query().Get("reset") != "":
+		state.Notice = "Email reset to the default."
+	}
+
+	h.renderEdit(w, r, state)
+}
+
+func (h *EmailTemplateHandler) renderEdit(w http.ResponseWriter, r *http.Request, state emailEditorState) {
+	ctx := r.Context()
+
+	tmpl, err := h.emailTemplateService.GetTemplate(ctx, r.PathValue("type"))
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	if state.Draft != nil {
+		tmpl.Subject = state.Draft.Subject
+		tmpl.BodyHTML = state.Draft.BodyHTML
+		tmpl.BodyText = state.Draft.BodyText
+		tmpl.Active = state.Draft.Active
+	}
+
+	var testTo string
+	if operator := middleware.GetOperatorFromContext(ctx); operator != nil {
+		testTo = operator.Email
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"CSRFToken":   middleware.GetCSRFToken(ctx),
+		"Template":    tmpl,
+		"Preview":     state.Preview,
+		"TestTo":      testTo,
+		"Notice":      state.Notice,
+		"Error":       state.Error,
+	}
+
+	h.renderer.RenderHTTP(w, "admin/email_template_edit", data)
+}
+
+// draftFromForm reads the editor form.
+func draftFromForm(r *http.Request) (*domain.SaveEmailTemplateParams, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, domain.Errorf(domain.EINVALID, "", "Invalid form data")
+	}
+
+	return &domain.SaveEmailTemplateParams{
+		Type:     r.PathValue("type"),
+		Subject:  r.FormValue("subject"),
+		BodyHTML: r.FormValue("body_html"),
+		BodyText: r.FormValue("body_text"),
+		Active:   r.FormValue("is_active") == "on" || r.FormValue("is_active") == "true",
+	}, nil
+}
+
+// Save handles POST /admin/settings/emails/{type}
+func (h *EmailTemplateHandler) Save(w http.ResponseWriter, r *http.Request) {
+	draft, err := draftFromForm(r)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	if err := h.emailTemplateService.SaveTemplate(r.Context(), *draft); err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderEdit(w, r, emailEditorState{Draft: draft, Error: domain.ErrorMessage(err)})
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/settings/emails/"+url.PathEscape(draft.Type)+"?saved=1", http.StatusSeeOther)
+}
+
+// Preview handles POST /admin/settings/emails/{type}/preview
+// Renders the unsaved draft against sample data.
+func (h *EmailTemplateHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	draft, err := draftFromForm(r)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	preview, err := h.emailTemplateService.PreviewTemplate(r.Context(), *draft)
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderEdit(w, r, emailEditorState{Draft: draft, Error: domain.ErrorMessage(err)})
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	h.renderEdit(w, r, emailEditorState{Draft: draft, Preview: preview})
+}
+
+// SendTest handles POST /admin/settings/emails/{type}/test
+// Sends the unsaved draft to the signed-in operator.
+func (h *EmailTemplateHandler) SendTest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	draft, err := draftFromForm(r)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	operator := middleware.GetOperatorFromContext(ctx)
+	if operator == nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EUNAUTHORIZED, "", "Not signed in"))
+		return
+	}
+
+	if err := h.emailTemplateService.SendTestEmail(ctx, *draft, operator.Email); err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderEdit(w, r, emailEditorState{Draft: draft, Error: domain.ErrorMessage(err)})
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	h.renderEdit(w, r, emailEditorState{Draft: draft, Notice: "Test email sent to " + operator.Email + "."})
+}
+
+// Reset handles POST /admin/settings/emails/{type}/reset
+func (h *EmailTemplateHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	templateType := r.PathValue("type")
+
+	if err := h.emailTemplateService.ResetTemplate(r.Context(), templateType); err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/settings/emails/"+url.PathEscape(templateType)+"?reset=1", http.StatusSeeOther)
+}
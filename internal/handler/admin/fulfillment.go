@@ -0,0 +1,188 @@
+package admin
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+)
+
+// FulfillmentHandler handles the fulfillment queue and batch label routes
+type FulfillmentHandler struct {
+	batchService domain.FulfillmentBatchService
+	roastService domain.RoastService
+	renderer     *handler.Renderer
+}
+
+// NewFulfillmentHandler creates a new fulfillment handler
+func NewFulfillmentHandler(batchService domain.FulfillmentBatchService, roastService domain.RoastService, renderer *handler.Renderer) *FulfillmentHandler {
+	return &FulfillmentHandler{
+		batchService: batchService,
+		roastService: roastService,
+		renderer:     renderer,
+	}
+}
+
+// Queue handles GET /admin/fulfillment
+func (h *FulfillmentHandler) Queue(w http.ResponseWriter, r *http.Request) {
+	h.renderQueue(w, r, nil, "")
+}
+
+// PickList handles GET /admin/fulfillment/pick-list?order_id=...
+func (h *FulfillmentHandler) PickList(w http.ResponseWriter, r *http.Request) {
+	orderIDs := r.URL.Query()["order_id"]
+
+	lines, err := h.batchService.PickList(r.Context(), orderIDs)
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderQueue(w, r, orderIDs, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	var totalUnits int32
+	for _, line := range lines {
+		totalUnits += line.Quantity
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"Lines":       lines,
+		"OrderIDs":    orderIDs,
+		"OrderCount":  len(orderIDs),
+		"TotalUnits":  totalUnits,
+	}
+
+	h.renderer.RenderHTTP(w, "admin/pick_list", data)
+}
+
+// CreateBatch handles POST /admin/fulfillment/batches
+func (h *FulfillmentHandler) CreateBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := middleware.GetLogger(ctx, slog.Default())
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	orderIDs := r.Form["order_id"]
+
+	batch, err := h.batchService.CreateBatch(ctx, orderIDs)
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderQueue(w, r, orderIDs, domain.ErrorMessage(err))
+			return
+		}
+		logger.Error("failed to create fulfillment batch", "error", err)
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	logger.Info("fulfillment batch created",
+		"batch_id", formatUUID(batch.ID),
+		"order_count", batch.OrderCount)
+
+	http.Redirect(w, r, "/admin/fulfillment/batches/"+formatUUID(batch.ID), http.StatusSeeOther)
+}
+
+// Batch handles GET /admin/fulfillment/batches/{id}
+// While the batch is running the page polls itself with htmx.
+func (h *FulfillmentHandler) Batch(w http.ResponseWriter, r *http.Request) {
+	detail, err := h.batchService.GetBatch(r.Context(), r.PathValue("id"))
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	status := detail.Batch.Status
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"Batch":       detail.Batch,
+		"Orders":      detail.Orders,
+		"InProgress":  status == "pending" || status == "processing",
+	}
+
+	h.renderer.RenderHTTP(w, "admin/fulfillment_batch", data)
+}
+
+// Document handles GET /admin/fulfillment/batches/{id}/document
+// Streams the merged labels and packing slips PDF so it can be printed.
+func (h *FulfillmentHandler) Document(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := middleware.GetLogger(ctx, slog.Default())
+	batchID := r.PathValue("id")
+
+	body, err := h.batchService.OpenDocument(ctx, batchID)
+	if err != nil {
+		if code := domain.ErrorCode(err); code == domain.ENOTFOUND || code == domain.EINVALID {
+			handler.NotFoundResponse(w, r)
+			return
+		}
+		logger.Error("failed to open batch document", "error", err, "batch_id", batchID)
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `inline; filename="batch-`+batchID+`.pdf"`)
+	if _, err := io.Copy(w, body); err != nil {
+		logger.Warn("failed to stream batch document", "error", err, "batch_id", batchID)
+	}
+}
+
+// renderQueue renders the fulfillment queue, keeping the given orders selected.
+// A non-empty errMsg is shown above the queue with a 422 status.
+func (h *FulfillmentHandler) renderQueue(w http.ResponseWriter, r *http.Request, selected []string, errMsg string) {
+	ctx := r.Context()
+
+	orders, err := h.batchService.ListQueue(ctx)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	batches, err := h.batchService.ListBatches(ctx)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	resting, err := h.roastService.ListRestingOrders(ctx)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	selectedSet := make(map[string]bool, len(selected))
+	for _, id := range selected {
+		selectedSet[id] = true
+	}
+
+	var totalUnits int32
+	for _, order := range orders {
+		totalUnits += order.UnitsRemaining
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"CSRFToken":   middleware.GetCSRFToken(ctx),
+		"Orders":      orders,
+		"TotalUnits":  totalUnits,
+		"Batches":     batches,
+		"Resting":     resting,
+		"Selected":    selectedSet,
+		"Error":       errMsg,
+	}
+
+	if errMsg != "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	h.renderer.RenderHTTP(w, "admin/fulfillment", data)
+}
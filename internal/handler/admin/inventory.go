@@ -0,0 +1,203 @@
+package admin
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/service"
+)
+
+// inventoryHistoryLimit is how many ledger entries the SKU page shows.
+const inventoryHistoryLimit = 100
+
+// roastReceiptDays is how far back the roast receipt form lists batches.
+const roastReceiptDays = 14
+
+// InventoryHandler handles the inventory admin routes
+type InventoryHandler struct {
+	inventoryService domain.InventoryService
+	roastService     domain.RoastService
+	renderer         *handler.Renderer
+}
+
+// NewInventoryHandler creates a new inventory handler
+func NewInventoryHandler(inventoryService domain.InventoryService, roastService domain.RoastService, renderer *handler.Renderer) *InventoryHandler {
+	return &InventoryHandler{
+		inventoryService: inventoryService,
+		roastService:     roastService,
+		renderer:         renderer,
+	}
+}
+
+// List handles GET /admin/inventory
+func (h *InventoryHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	skus, err := h.inventoryService.ListInventory(ctx)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	lowOnly := r.URL.Query().Get("low") == "1"
+	var lowCount int
+	filtered := make([]repository.ListInventorySKUsRow, 0, len(skus))
+	for _, sku := range skus {
+		if sku.IsLow {
+			lowCount++
+		}
+		if !lowOnly || sku.IsLow {
+			filtered = append(filtered, sku)
+		}
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"SKUs":        filtered,
+		"LowCount":    lowCount,
+		"LowOnly":     lowOnly,
+	}
+
+	h.renderer.RenderHTTP(w, "admin/inventory", data)
+}
+
+// Detail handles GET /admin/inventory/skus/{id}
+func (h *InventoryHandler) Detail(w http.ResponseWriter, r *http.Request) {
+	h.renderDetail(w, r, "")
+}
+
+// Adjust handles POST /admin/inventory/skus/{id}/adjust
+func (h *InventoryHandler) Adjust(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := middleware.GetLogger(ctx, slog.Default())
+	skuID := r.PathValue("id")
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	quantity, err := strconv.ParseInt(strings.TrimSpace(r.FormValue("quantity")), 10, 32)
+	if err != nil {
+		h.renderDetail(w, r, service.ErrInvalidAdjustmentQuantity.Message)
+		return
+	}
+
+	params := domain.InventoryAdjustmentParams{
+		SKUID:        skuID,
+		Reason:       r.FormValue("reason"),
+		Quantity:     int32(quantity),
+		Note:         strings.TrimSpace(r.FormValue("note")),
+		RoastBatchID: r.FormValue("roast_batch_id"),
+	}
+	if operator := middleware.GetOperatorFromContext(ctx); operator != nil {
+		params.OperatorID = formatUUID(operator.ID)
+	}
+
+	adjustment, err := h.inventoryService.Adjust(ctx, params)
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderDetail(w, r, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	logger.Info("inventory adjusted",
+		"sku_id", skuID,
+		"reason", adjustment.Reason,
+		"quantity_change", adjustment.QuantityChange,
+		"quantity_after", adjustment.QuantityAfter)
+
+	http.Redirect(w, r, "/admin/inventory/skus/"+skuID, http.StatusSeeOther)
+}
+
+// SaveThreshold handles POST /admin/inventory/skus/{id}/threshold
+func (h *InventoryHandler) SaveThreshold(w http.ResponseWriter, r *http.Request) {
+	skuID := r.PathValue("id")
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	// An empty threshold reports the SKU only when it runs out
+	var threshold *int32
+	if s := strings.TrimSpace(r.FormValue("low_stock_threshold")); s != "" {
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			h.renderDetail(w, r, service.ErrInvalidLowStockThreshold.Message)
+			return
+		}
+		value := int32(n)
+		threshold = &value
+	}
+
+	if err := h.inventoryService.SetLowStockThreshold(r.Context(), skuID, threshold); err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderDetail(w, r, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/inventory/skus/"+skuID, http.StatusSeeOther)
+}
+
+// renderDetail renders a SKU's stock, ledger and adjustment form. A non-empty
+// errMsg is shown above the form with a 422 status.
+func (h *InventoryHandler) renderDetail(w http.ResponseWriter, r *http.Request, errMsg string) {
+	ctx := r.Context()
+	skuID := r.PathValue("id")
+
+	sku, err := h.inventoryService.GetSKU(ctx, skuID)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	adjustments, err := h.inventoryService.ListAdjustments(ctx, skuID, inventoryHistoryLimit)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	// Recent roasts of this coffee that bags can be received from
+	batches, err := h.roastService.ListBatches(ctx, time.Now().AddDate(0, 0, -roastReceiptDays))
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+	var roasted []repository.ListRoastBatchesRow
+	for _, batch := range batches {
+		if batch.Status == "roasted" && batch.ProductID == sku.ProductID {
+			roasted = append(roasted, batch)
+		}
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath":  r.URL.Path,
+		"CSRFToken":    middleware.GetCSRFToken(ctx),
+		"SKU":          sku,
+		"Adjustments":  adjustments,
+		"RoastBatches": roasted,
+		"FormReason":   r.FormValue("reason"),
+		"FormQuantity": r.FormValue("quantity"),
+		"FormNote":     r.FormValue("note"),
+		"Error":        errMsg,
+	}
+
+	if errMsg != "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	h.renderer.RenderHTTP(w, "admin/inventory_sku", data)
+}
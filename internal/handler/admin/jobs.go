@@ -0,0 +1,457 @@
+package admin
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// jobsPageSize is how many jobs the console lists per page.
+const jobsPageSize = 50
+
+// rescheduleTimeLayout is the value format of a datetime-local input.
+const rescheduleTimeLayout = "2006-01-02T15:04"
+
+// JobHandler handles the background job console
+type JobHandler struct {
+	jobService domain.JobService
+	renderer   *handler.Renderer
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(jobService domain.JobService, renderer *handler.Renderer) *JobHandler {
+	return &JobHandler{
+		jobService: jobService,
+		renderer:   renderer,
+	}
+}
+
+// jobFilterFromQuery reads the console filters from the query string.
+func jobFilterFromQuery(query url.Values) domain.JobFilter {
+	filter := domain.JobFilter{
+		Queue:    strings.TrimSpace(query.Get("queue")),
+		JobType:  strings.TrimSpace(query.Get("job_type")),
+		Status:   strings.TrimSpace(query.Get("status")),
+		TenantID: strings.TrimSpace(query.Get("tenant")),
+		Limit:    jobsPageSize,
+	}
+
+	if page, err := strconv.Atoi(query.Get("page")); err == nil && page > 1 {
+		filter.Offset = int32((page - 1) * jobsPageSize)
+	}
+
+	return filter
+}
+
+// List handles GET /admin/jobs
+func (h *JobHandler) List(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w, r, "admin/jobs", jobFilterFromQuery(r.URL.Query()), "")
+}
+
+// DeadLetter handles GET /admin/jobs/dead-letter
+func (h *JobHandler) DeadLetter(w http.ResponseWriter, r *http.Request) {
+	filter := jobFilterFromQuery(r.URL.Query())
+	filter.Status = domain.JobStatusFailed
+
+	h.renderList(w, r, "admin/jobs_dead_letter", filter, "")
+}
+
+// renderList renders the jobs list or the dead-letter view
+func (h *JobHandler) renderList(w http.ResponseWriter, r *http.Request, page string, filter domain.JobFilter, errorMsg string) {
+	ctx := r.Context()
+
+	isPlatform, err := h.jobService.IsPlatform(ctx)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	list, err := h.jobService.ListJobs(ctx, filter)
+	if err != nil {
+		if domain.ErrorCode(err) != domain.EINVALID {
+			handler.ErrorResponse(w, r, err)
+			return
+		}
+		errorMsg = domain.ErrorMessage(err)
+		list = &domain.JobList{}
+	}
+
+	stats, err := h.jobService.QueueStats(ctx)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	jobTypes, err := h.jobService.ListJobTypes(ctx)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	currentPage := int(filter.Offset)/jobsPageSize + 1
+	totalPages := int((list.Total + jobsPageSize - 1) / jobsPageSize)
+
+	// Pagination links keep the current filters
+	query := r.URL.Query()
+	query.Del("page")
+
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"CSRFToken":   middleware.GetCSRFToken(ctx),
+		"Jobs":        list.Jobs,
+		"Total":       list.Total,
+		"Filter":      filter,
+		"Stats":       stats,
+		"JobTypes":    jobTypes,
+		"IsPlatform":  isPlatform,
+		"Page":        currentPage,
+		"TotalPages":  totalPages,
+		"HasPrev":     currentPage > 1,
+		"HasNext":     currentPage < totalPages,
+		"PrevPage":    currentPage - 1,
+		"NextPage":    currentPage + 1,
+		"FilterQuery": query.Encode(),
+		"Error":       errorMsg,
+	}
+
+	if filter.Status == domain.JobStatusFailed {
+		failedTypes, err := h.jobService.ListFailedJobTypes(ctx)
+		if err != nil {
+			handler.ErrorResponse(w, r, err)
+			return
+		}
+		data["FailedTypes"] = failedTypes
+		data["Requeued"] = r.URL.Query().Get("requeued")
+	}
+
+	h.renderer.RenderHTTP(w, page, data)
+}
+
+// Detail handles GET /admin/jobs/{id}
+func (h *JobHandler) Detail(w http.ResponseWriter, r *http.Request) {
+	h.renderDetail(w, r, "")
+}
+
+// renderDetail renders the job detail page, optionally with an error message
+func (h *JobHandler) renderDetail(w http.ResponseWriter, r *http.Request, errorMsg string) {
+	ctx := r.Context()
+
+	job, err := h.jobService.GetJob(ctx, r.PathValue("id"))
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	isPlatform, err := h.jobService.IsPlatform(ctx)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath":  r.URL.Path,
+		"CSRFToken":    middleware.GetCSRFToken(ctx),
+		"Job":          job,
+		"Payload":      prettyJSON(job.Payload),
+		"ErrorDetails": prettyJSON(job.ErrorDetails),
+		"IsPlatform":   isPlatform,
+		"Error":        errorMsg,
+	}
+
+	h.renderer.RenderHTTP(w, "admin/job_detail", data)
+}
+
+// Retry handles POST /admin/jobs/{id}/retry
+func (h *JobHandler) Retry(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobService.RetryJob(r.Context(), r.PathValue("id"))
+	h.afterAction(w, r, "retried", job, err)
+}
+
+// Cancel handles POST /admin/jobs/{id}/cancel
+func (h *JobHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobService.CancelJob(r.Context(), r.PathValue("id"))
+	h.afterAction(w, r, "cancelled", job, err)
+}
+
+// Reschedule handles POST /admin/jobs/{id}/reschedule
+func (h *JobHandler) Reschedule(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	// The form shows times in UTC, like the rest of the jobs console
+	at, err := time.Parse(rescheduleTimeLayout, strings.TrimSpace(r.FormValue("scheduled_at")))
+	if err != nil {
+		h.renderDetail(w, r, "Enter a valid date and time")
+		return
+	}
+
+	job, err := h.jobService.RescheduleJob(r.Context(), r.PathValue("id"), at)
+	h.afterAction(w, r, "rescheduled", job, err)
+}
+
+// afterAction redirects back to the job after a successful action, or shows
+// why the action was not allowed.
+func (h *JobHandler) afterAction(w http.ResponseWriter, r *http.Request, action string, job *repository.Job, err error) {
+	if err != nil {
+		switch domain.ErrorCode(err) {
+		case domain.ECONFLICT, domain.EINVALID:
+			h.renderDetail(w, r, domain.ErrorMessage(err))
+		default:
+			handler.ErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	logger := middleware.GetLogger(r.Context(), slog.Default())
+	logger.Info("job "+action,
+		"job_id", formatUUID(job.ID),
+		"job_type", job.JobType,
+		"scheduled_at", job.ScheduledAt.Time)
+
+	http.Redirect(w, r, "/admin/jobs/"+formatUUID(job.ID), http.StatusSeeOther)
+}
+
+// Requeue handles POST /admin/jobs/requeue
+func (h *JobHandler) Requeue(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	deadLetter := domain.JobFilter{Status: domain.JobStatusFailed, Limit: jobsPageSize}
+
+	var failedSince *time.Time
+	if s := strings.TrimSpace(r.FormValue("failed_since")); s != "" {
+		since, err := time.Parse(rescheduleTimeLayout, s)
+		if err != nil {
+			h.renderList(w, r, "admin/jobs_dead_letter", deadLetter, "Enter a valid date and time")
+			return
+		}
+		failedSince = &since
+	}
+
+	jobType := r.FormValue("job_type")
+	count, err := h.jobService.RequeueFailed(r.Context(), jobType, failedSince)
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderList(w, r, "admin/jobs_dead_letter", deadLetter, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	logger := middleware.GetLogger(r.Context(), slog.Default())
+	logger.Info("failed jobs requeued", "job_type", jobType, "count", count)
+
+	http.Redirect(w, r, "/admin/jobs/dead-letter?requeued="+strconv.FormatInt(count, 10), http.StatusSeeOther)
+}
+
+// prettyJSON indents a JSON column for display.
+func prettyJSON(raw []byte) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return string(raw)
+	}
+
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return string(raw)
+	}
+
+	return string(out)
+}
+
+// =============================================================================
+// JSON API
+// =============================================================================
+
+// jobJSON is a job as returned by the jobs API. The payload and error
+// details are embedded as JSON rather than base64-encoded bytes.
+type jobJSON struct {
+	ID                    string             `json:"id"`
+	TenantID              string             `json:"tenant_id,omitempty"`
+	JobType               string             `json:"job_type"`
+	Queue                 string             `json:"queue"`
+	Status                string             `json:"status"`
+	Priority              int32              `json:"priority"`
+	Payload               json.RawMessage    `json:"payload"`
+	RetryCount            int32              `json:"retry_count"`
+	MaxRetries            int32              `json:"max_retries"`
+	ScheduledAt           pgtype.Timestamptz `json:"scheduled_at"`
+	ProcessingStartedAt   pgtype.Timestamptz `json:"processing_started_at"`
+	ProcessingCompletedAt pgtype.Timestamptz `json:"processing_completed_at"`
+	WorkerID              *string            `json:"worker_id,omitempty"`
+	HeartbeatAt           pgtype.Timestamptz `json:"heartbeat_at"`
+	ErrorMessage          *string            `json:"error_message,omitempty"`
+	ErrorDetails          json.RawMessage    `json:"error_details,omitempty"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+}
+
+func newJobJSON(job repository.Job) jobJSON {
+	out := jobJSON{
+		ID:                    formatUUID(job.ID),
+		TenantID:              formatUUID(job.TenantID),
+		JobType:               job.JobType,
+		Queue:                 job.Queue,
+		Status:                job.Status,
+		Priority:              job.Priority,
+		Payload:               json.RawMessage(job.Payload),
+		RetryCount:            job.RetryCount,
+		MaxRetries:            job.MaxRetries,
+		ScheduledAt:           job.ScheduledAt,
+		ProcessingStartedAt:   job.ProcessingStartedAt,
+		ProcessingCompletedAt: job.ProcessingCompletedAt,
+		HeartbeatAt:           job.HeartbeatAt,
+		CreatedAt:             job.CreatedAt,
+		UpdatedAt:             job.UpdatedAt,
+	}
+	if len(job.Payload) == 0 {
+		out.Payload = json.RawMessage("null")
+	}
+	if job.WorkerID.Valid {
+		out.WorkerID = &job.WorkerID.String
+	}
+	if job.ErrorMessage.Valid {
+		out.ErrorMessage = &job.ErrorMessage.String
+	}
+	if len(job.ErrorDetails) > 0 {
+		out.ErrorDetails = json.RawMessage(job.ErrorDetails)
+	}
+	return out
+}
+
+// writeJobAPIError writes a service error as a JSON error response
+func writeJobAPIError(w http.ResponseWriter, r *http.Request, err error) {
+	status := handler.ErrorCodeToHTTPStatus(domain.ErrorCode(err))
+	if status >= http.StatusInternalServerError {
+		logger := middleware.GetLogger(r.Context(), slog.Default())
+		logger.Error("jobs api request failed", "path", r.URL.Path, "error", err)
+	}
+	writeJSONError(w, domain.ErrorMessage(err), status)
+}
+
+func writeJobJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// ListJSON handles GET /admin/api/jobs
+func (h *JobHandler) ListJSON(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := jobFilterFromQuery(query)
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 {
+		filter.Limit = int32(limit)
+	}
+	if offset, err := strconv.Atoi(query.Get("offset")); err == nil && offset >= 0 {
+		filter.Offset = int32(offset)
+	}
+
+	list, err := h.jobService.ListJobs(r.Context(), filter)
+	if err != nil {
+		writeJobAPIError(w, r, err)
+		return
+	}
+
+	jobs := make([]jobJSON, 0, len(list.Jobs))
+	for _, job := range list.Jobs {
+		jobs = append(jobs, newJobJSON(job))
+	}
+
+	writeJobJSON(w, map[string]interface{}{
+		"jobs":  jobs,
+		"total": list.Total,
+	})
+}
+
+// GetJSON handles GET /admin/api/jobs/{id}
+func (h *JobHandler) GetJSON(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobService.GetJob(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeJobAPIError(w, r, err)
+		return
+	}
+
+	writeJobJSON(w, newJobJSON(*job))
+}
+
+// RetryJSON handles POST /admin/api/jobs/{id}/retry
+func (h *JobHandler) RetryJSON(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobService.RetryJob(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeJobAPIError(w, r, err)
+		return
+	}
+
+	writeJobJSON(w, newJobJSON(*job))
+}
+
+// CancelJSON handles POST /admin/api/jobs/{id}/cancel
+func (h *JobHandler) CancelJSON(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobService.CancelJob(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeJobAPIError(w, r, err)
+		return
+	}
+
+	writeJobJSON(w, newJobJSON(*job))
+}
+
+// RescheduleJSON handles POST /admin/api/jobs/{id}/reschedule
+func (h *JobHandler) RescheduleJSON(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ScheduledAt time.Time `json:"scheduled_at"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.jobService.RescheduleJob(r.Context(), r.PathValue("id"), req.ScheduledAt)
+	if err != nil {
+		writeJobAPIError(w, r, err)
+		return
+	}
+
+	writeJobJSON(w, newJobJSON(*job))
+}
+
+// RequeueJSON handles POST /admin/api/jobs/requeue
+func (h *JobHandler) RequeueJSON(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		JobType     string     `json:"job_type"`
+		FailedSince *time.Time `json:"failed_since"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	count, err := h.jobService.RequeueFailed(r.Context(), req.JobType, req.FailedSince)
+	if err != nil {
+		writeJobAPIError(w, r, err)
+		return
+	}
+
+	writeJobJSON(w, map[string]interface{}{
+		"job_type": req.JobType,
+		"requeued": count,
+	})
+}
@@ -3,30 +3,53 @@ package admin
 import (
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/dukerupert/hiri/internal/domain"
 	"github.com/dukerupert/hiri/internal/handler"
 	"github.com/dukerupert/hiri/internal/jobs"
 	"github.com/dukerupert/hiri/internal/middleware"
 	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // OrderHandler handles all order-related admin routes
 type OrderHandler struct {
-	repo     repository.Querier
-	renderer *handler.Renderer
+	repo          repository.Querier
+	refundService domain.RefundService
+	labelService  domain.ShippingLabelService
+	renderer      *handler.Renderer
 }
 
 // NewOrderHandler creates a new order handler
-func NewOrderHandler(repo repository.Querier, renderer *handler.Renderer) *OrderHandler {
+func NewOrderHandler(repo repository.Querier, refundService domain.RefundService, labelService domain.ShippingLabelService, renderer *handler.Renderer) *OrderHandler {
 	return &OrderHandler{
-		repo:     repo,
-		renderer: renderer,
+		repo:          repo,
+		refundService: refundService,
+		labelService:  labelService,
+		renderer:      renderer,
 	}
 }
 
+// orderDetailState carries form state when re-rendering the order detail page
+type orderDetailState struct {
+	RefundError  string
+	CancelError  string
+	LabelError   string
+	LabelPackage *shipping.Package
+	LabelRates   []shipping.Rate
+}
+
+// refundableItem pairs an order line item with its refunded quantity
+type refundableItem struct {
+	Item               repository.GetOrderItemsRow
+	RefundedQuantity   int32
+	RefundableQuantity int32
+}
+
 // List handles GET /admin/orders
 func (h *OrderHandler) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -76,6 +99,13 @@ func (h *OrderHandler) Detail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.renderDetail(w, r, tenantID, orderUUID, orderDetailState{})
+}
+
+// renderDetail renders the order detail page, optionally with refund, cancel, or label form state
+func (h *OrderHandler) renderDetail(w http.ResponseWriter, r *http.Request, tenantID, orderUUID pgtype.UUID, state orderDetailState) {
+	ctx := r.Context()
+
 	order, err := h.repo.GetOrderWithDetails(ctx, repository.GetOrderWithDetailsParams{
 		TenantID: tenantID,
 		ID:       orderUUID,
@@ -85,28 +115,262 @@ func (h *OrderHandler) Detail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	items, err := h.repo.GetOrderItems(r.Context(), orderUUID)
+	items, err := h.repo.GetOrderItems(ctx, orderUUID)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	shipments, err := h.repo.GetShipmentsByOrderID(ctx, orderUUID)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	trackingEvents, err := h.repo.ListOrderTrackingEvents(ctx, repository.ListOrderTrackingEventsParams{
+		TenantID: tenantID,
+		OrderID:  orderUUID,
+	})
 	if err != nil {
 		handler.InternalErrorResponse(w, r, err)
 		return
 	}
 
-	shipments, err := h.repo.GetShipmentsByOrderID(r.Context(), orderUUID)
+	// Group tracking events by shipment for each shipment's timeline
+	eventsByShipment := make(map[string][]repository.ShipmentTrackingEvent)
+	for _, event := range trackingEvents {
+		key := formatUUID(event.ShipmentID)
+		eventsByShipment[key] = append(eventsByShipment[key], event)
+	}
+
+	refunds, err := h.repo.ListRefundsForOrder(ctx, repository.ListRefundsForOrderParams{
+		TenantID: tenantID,
+		OrderID:  orderUUID,
+	})
 	if err != nil {
 		handler.InternalErrorResponse(w, r, err)
 		return
 	}
 
+	refundedQuantities, err := h.repo.ListRefundedQuantitiesForOrder(ctx, repository.ListRefundedQuantitiesForOrderParams{
+		TenantID: tenantID,
+		OrderID:  orderUUID,
+	})
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	refundedByItem := make(map[[16]byte]int32, len(refundedQuantities))
+	for _, rq := range refundedQuantities {
+		refundedByItem[rq.OrderItemID.Bytes] = rq.RefundedQuantity
+	}
+
+	refundableItems := make([]refundableItem, len(items))
+	for i, item := range items {
+		refunded := refundedByItem[item.ID.Bytes]
+		refundableItems[i] = refundableItem{
+			Item:               item,
+			RefundedQuantity:   refunded,
+			RefundableQuantity: item.Quantity - refunded,
+		}
+	}
+
+	var refundedCents int32
+	for _, refund := range refunds {
+		refundedCents += refund.AmountCents
+	}
+
+	canRefund := order.ProviderPaymentID.Valid &&
+		strings.HasPrefix(order.ProviderPaymentID.String, "pi_") &&
+		refundedCents < order.TotalCents &&
+		(order.Status == "paid" || order.Status == "processing" || order.Status == "shipped" ||
+			order.Status == "delivered" || order.Status == "partially_refunded")
+
+	// Orders can be cancelled until they ship; voided labels don't count
+	canCancel := order.Status == "pending" || order.Status == "payment_processing" || order.Status == "paid" ||
+		order.Status == "processing" || order.Status == "partially_refunded" || order.Status == "refunded"
+	for _, shipment := range shipments {
+		if shipment.Status != "cancelled" {
+			canCancel = false
+			break
+		}
+	}
+
+	var hasUnshippedItems bool
+	for _, item := range items {
+		if item.QuantityDispatched < item.Quantity {
+			hasUnshippedItems = true
+			break
+		}
+	}
+
+	canBuyLabel := hasUnshippedItems && order.ShippingAddressLine1.Valid &&
+		(order.Status == "paid" || order.Status == "processing" || order.Status == "shipped" ||
+			order.Status == "partially_refunded")
+
+	labelPackage := state.LabelPackage
+	if canBuyLabel && labelPackage == nil {
+		if pkg, err := h.labelService.EstimatePackage(ctx, formatUUID(orderUUID)); err == nil {
+			labelPackage = &pkg
+		}
+	}
+
 	data := map[string]interface{}{
-		"CurrentPath": r.URL.Path,
-		"Order":       order,
-		"OrderItems":  items,
-		"Shipments":   shipments,
+		"CurrentPath":     r.URL.Path,
+		"CSRFToken":       middleware.GetCSRFToken(ctx),
+		"Order":           order,
+		"OrderItems":      items,
+		"Shipments":       shipments,
+		"TrackingEvents":  eventsByShipment,
+		"Refunds":         refunds,
+		"RefundableItems": refundableItems,
+		"RefundedCents":   refundedCents,
+		"RefundableCents": order.TotalCents - refundedCents,
+		"CanRefund":       canRefund,
+		"RefundError":     state.RefundError,
+		"CanCancel":       canCancel,
+		"CancelError":     state.CancelError,
+		"CanBuyLabel":     canBuyLabel,
+		"LabelPackage":    labelPackage,
+		"LabelRates":      state.LabelRates,
+		"LabelError":      state.LabelError,
+	}
+
+	if state.RefundError != "" || state.CancelError != "" || state.LabelError != "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
 	}
 
 	h.renderer.RenderHTTP(w, "admin/order_detail", data)
 }
 
+// Refund handles POST /admin/orders/{id}/refund
+func (h *OrderHandler) Refund(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := getTenantID(ctx)
+	if !tenantID.Valid {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EUNAUTHORIZED, "", "No tenant context"))
+		return
+	}
+
+	logger := middleware.GetLogger(ctx, slog.Default())
+
+	orderID := r.PathValue("id")
+	if orderID == "" {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Order ID required"))
+		return
+	}
+
+	var orderUUID pgtype.UUID
+	if err := orderUUID.Scan(orderID); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid order ID"))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	params := domain.RefundOrderParams{
+		OrderID: orderID,
+		Mode:    r.FormValue("mode"),
+		Reason:  r.FormValue("reason"),
+		Note:    r.FormValue("note"),
+		Restock: r.FormValue("restock") == "on",
+	}
+
+	switch params.Mode {
+	case domain.RefundModeAmount:
+		cents, err := parseDollarsToCents(r.FormValue("amount"))
+		if err != nil || cents == nil {
+			h.renderDetail(w, r, tenantID, orderUUID, orderDetailState{RefundError: "Enter a valid refund amount"})
+			return
+		}
+		params.AmountCents = *cents
+	case domain.RefundModeItems:
+		// Quantities are submitted as qty_<order item id>
+		for key, values := range r.PostForm {
+			itemID, ok := strings.CutPrefix(key, "qty_")
+			if !ok || len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+				continue
+			}
+			qty, err := strconv.Atoi(strings.TrimSpace(values[0]))
+			if err != nil || qty < 0 {
+				h.renderDetail(w, r, tenantID, orderUUID, orderDetailState{RefundError: "Enter valid refund quantities"})
+				return
+			}
+			params.Items = append(params.Items, domain.RefundItemParams{
+				OrderItemID: itemID,
+				Quantity:    int32(qty),
+			})
+		}
+	}
+
+	refund, err := h.refundService.RefundOrder(ctx, params)
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderDetail(w, r, tenantID, orderUUID, orderDetailState{RefundError: domain.ErrorMessage(err)})
+			return
+		}
+		logger.Error("failed to refund order", "error", err, "order_id", orderID)
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	logger.Info("order refunded", "order_id", orderID, "refund_id", refund.ProviderRefundID, "amount_cents", refund.AmountCents)
+
+	http.Redirect(w, r, "/admin/orders/"+orderID, http.StatusSeeOther)
+}
+
+// Cancel handles POST /admin/orders/{id}/cancel
+func (h *OrderHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := getTenantID(ctx)
+	if !tenantID.Valid {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EUNAUTHORIZED, "", "No tenant context"))
+		return
+	}
+
+	logger := middleware.GetLogger(ctx, slog.Default())
+
+	orderID := r.PathValue("id")
+	if orderID == "" {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Order ID required"))
+		return
+	}
+
+	var orderUUID pgtype.UUID
+	if err := orderUUID.Scan(orderID); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid order ID"))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	err := h.refundService.CancelOrder(ctx, domain.CancelOrderParams{
+		OrderID: orderID,
+		Reason:  r.FormValue("reason"),
+		Restock: r.FormValue("restock") == "on",
+	})
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderDetail(w, r, tenantID, orderUUID, orderDetailState{CancelError: domain.ErrorMessage(err)})
+			return
+		}
+		logger.Error("failed to cancel order", "error", err, "order_id", orderID)
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	logger.Info("order cancelled", "order_id", orderID)
+
+	http.Redirect(w, r, "/admin/orders/"+orderID, http.StatusSeeOther)
+}
+
 // UpdateStatus handles POST /admin/orders/{id}/status
 func (h *OrderHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -253,7 +517,7 @@ func (h *OrderHandler) CreateShipment(w http.ResponseWriter, r *http.Request) {
 				}
 
 				// Build tracking URL based on carrier
-				trackingURL := buildTrackingURL(carrier, trackingNumber)
+				trackingURL := shipping.TrackingURL(carrier, trackingNumber)
 
 				payload := jobs.ShippingConfirmationPayload{
 					OrderID:        orderUUIDGoogle,
@@ -276,20 +540,3 @@ func (h *OrderHandler) CreateShipment(w http.ResponseWriter, r *http.Request) {
 
 	http.Redirect(w, r, "/admin/orders/"+orderID, http.StatusSeeOther)
 }
-
-// buildTrackingURL constructs a tracking URL for common carriers
-func buildTrackingURL(carrier, trackingNumber string) string {
-	switch carrier {
-	case "USPS", "usps":
-		return "https://tools.usps.com/go/TrackConfirmAction?tLabels=" + trackingNumber
-	case "UPS", "ups":
-		return "https://www.ups.com/track?tracknum=" + trackingNumber
-	case "FedEx", "fedex", "FEDEX":
-		return "https://www.fedex.com/fedextrack/?trknbr=" + trackingNumber
-	case "DHL", "dhl":
-		return "https://www.dhl.com/en/express/tracking.html?AWB=" + trackingNumber
-	default:
-		// Return empty string if carrier not recognized
-		return ""
-	}
-}
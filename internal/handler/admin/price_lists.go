@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -8,7 +9,9 @@ import (
 
 	"github.com/dukerupert/hiri/internal/domain"
 	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
 	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -109,10 +112,21 @@ func (h *PriceListHandler) Detail(w http.ResponseWriter, r *http.Request) {
 		entries = nil
 	}
 
+	tiers, err := h.repo.ListPriceListTiers(ctx, repository.ListPriceListTiersParams{
+		TenantID:    tenantID,
+		PriceListID: priceListUUID,
+	})
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
 	data := map[string]interface{}{
 		"CurrentPath": r.URL.Path,
 		"PriceList":   priceList,
 		"Entries":     entries,
+		"Tiers":       tiers,
+		"CSRFToken":   middleware.GetCSRFToken(ctx),
 	}
 
 	h.renderer.RenderHTTP(w, "admin/price_list_detail", data)
@@ -286,6 +300,100 @@ func (h *PriceListHandler) UpdateEntry(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/admin/price-lists/"+priceListID, http.StatusSeeOther)
 }
 
+// SaveTier handles POST /admin/price-lists/{id}/tiers
+// Sets the quantity-break price of an entry at a minimum quantity. Open carts
+// aren't repriced: a line picks up the change when its quantity next changes.
+func (h *PriceListHandler) SaveTier(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := getTenantID(ctx)
+	if !tenantID.Valid {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EUNAUTHORIZED, "", "No tenant context"))
+		return
+	}
+
+	priceListID := r.PathValue("id")
+	var priceListUUID pgtype.UUID
+	if err := priceListUUID.Scan(priceListID); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid price list ID"))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	var entryUUID pgtype.UUID
+	if err := entryUUID.Scan(r.FormValue("entry_id")); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid price entry"))
+		return
+	}
+
+	minQuantity, err := strconv.Atoi(strings.TrimSpace(r.FormValue("min_quantity")))
+	if err != nil || minQuantity < 2 {
+		handler.ErrorResponse(w, r, domain.ErrInvalidPriceTier)
+		return
+	}
+
+	priceCents, err := parseDollarsToCents(r.FormValue("price"))
+	if err != nil || priceCents == nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Enter the tier price in dollars, e.g. 12.50"))
+		return
+	}
+
+	_, err = h.repo.UpsertPriceListEntryTier(ctx, repository.UpsertPriceListEntryTierParams{
+		MinQuantity:      int32(minQuantity),
+		PriceCents:       *priceCents,
+		TenantID:         tenantID,
+		PriceListID:      priceListUUID,
+		PriceListEntryID: entryUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			handler.NotFoundResponse(w, r)
+			return
+		}
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/price-lists/"+priceListID, http.StatusSeeOther)
+}
+
+// DeleteTier handles POST /admin/price-lists/{id}/tiers/{tier_id}/delete
+// Like SaveTier, it leaves the prices of open carts as they are.
+func (h *PriceListHandler) DeleteTier(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := getTenantID(ctx)
+	if !tenantID.Valid {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EUNAUTHORIZED, "", "No tenant context"))
+		return
+	}
+
+	priceListID := r.PathValue("id")
+	var priceListUUID, tierUUID pgtype.UUID
+	if err := priceListUUID.Scan(priceListID); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid price list ID"))
+		return
+	}
+	if err := tierUUID.Scan(r.PathValue("tier_id")); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid tier ID"))
+		return
+	}
+
+	err := h.repo.DeletePriceListEntryTier(ctx, repository.DeletePriceListEntryTierParams{
+		TenantID:    tenantID,
+		PriceListID: priceListUUID,
+		ID:          tierUUID,
+	})
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/price-lists/"+priceListID, http.StatusSeeOther)
+}
+
 // Delete handles POST /admin/price-lists/{id}/delete
 func (h *PriceListHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
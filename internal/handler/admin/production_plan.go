@@ -0,0 +1,184 @@
+package admin
+
+import (
+	"encoding/csv"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+	"github.com/dukerupert/hiri/internal/service"
+)
+
+// productionPlanDays is how many days the production plan covers by default.
+const productionPlanDays = 7
+
+// gramsPerPound converts planned weights for the CSV export.
+const gramsPerPound = 453.592
+
+// Plan handles GET /admin/roasts/plan
+func (h *RoastHandler) Plan(w http.ResponseWriter, r *http.Request) {
+	h.renderPlan(w, r, "")
+}
+
+// PlanCSV handles GET /admin/roasts/plan/export
+// Downloads the production plan with one row per product per day.
+func (h *RoastHandler) PlanCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := middleware.GetLogger(ctx, slog.Default())
+
+	plan, err := h.productionPlan(r)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	filename := "production-plan-" + plan.Start.Format(roastDateLayout) + ".csv"
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{
+		"roast_date", "product", "units",
+		"order_grams", "wholesale_grams", "subscription_grams",
+		"roasted_grams", "roasted_lb", "roast_loss_percent", "green_grams", "green_lb",
+	})
+	for _, day := range plan.Days {
+		for _, line := range day.Lines {
+			_ = cw.Write([]string{
+				day.Date.Format(roastDateLayout),
+				line.ProductName,
+				strconv.Itoa(line.Units),
+				strconv.Itoa(line.OrderGrams),
+				strconv.Itoa(line.WholesaleGrams),
+				strconv.Itoa(line.SubscriptionGrams),
+				strconv.Itoa(line.RoastedGrams),
+				strconv.FormatFloat(float64(line.RoastedGrams)/gramsPerPound, 'f', 2, 64),
+				strconv.FormatFloat(line.RoastLossPercent, 'f', 1, 64),
+				strconv.Itoa(line.GreenGrams),
+				strconv.FormatFloat(float64(line.GreenGrams)/gramsPerPound, 'f', 2, 64),
+			})
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		logger.Warn("failed to write production plan export", "error", err)
+	}
+}
+
+// SaveRoastLoss handles POST /admin/roasts/plan/roast-loss
+func (h *RoastHandler) SaveRoastLoss(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	percent, err := strconv.ParseFloat(strings.TrimSpace(r.FormValue("roast_loss_percent")), 64)
+	if err != nil {
+		h.renderPlan(w, r, service.ErrInvalidRoastLoss.Message)
+		return
+	}
+
+	if err := h.roastService.SetRoastLoss(r.Context(), r.FormValue("product_id"), percent); err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderPlan(w, r, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	redirect := "/admin/roasts/plan"
+	if query := planQuery(r); query != "" {
+		redirect += "?" + query
+	}
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+// renderPlan renders the production plan with each product's roast loss.
+// A non-empty errMsg is shown above the plan with a 422 status.
+func (h *RoastHandler) renderPlan(w http.ResponseWriter, r *http.Request, errMsg string) {
+	ctx := r.Context()
+
+	plan, err := h.productionPlan(r)
+	if err != nil {
+		if domain.ErrorCode(err) != domain.EINVALID {
+			handler.ErrorResponse(w, r, err)
+			return
+		}
+		errMsg = domain.ErrorMessage(err)
+		plan, err = h.roastService.ProductionPlan(ctx, time.Now(), productionPlanDays)
+		if err != nil {
+			handler.ErrorResponse(w, r, err)
+			return
+		}
+	}
+
+	roastLoss, err := h.roastService.ListRoastLoss(ctx)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	var roastedGrams, greenGrams int
+	for _, day := range plan.Days {
+		roastedGrams += day.RoastedGrams
+		greenGrams += day.GreenGrams
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath":  r.URL.Path,
+		"CSRFToken":    middleware.GetCSRFToken(ctx),
+		"Plan":         plan,
+		"RoastLoss":    roastLoss,
+		"RoastedGrams": roastedGrams,
+		"GreenGrams":   greenGrams,
+		"Start":        plan.Start.Format(roastDateLayout),
+		"Days":         int(plan.End.Sub(plan.Start).Hours()/24) + 1,
+		"MaxDays":      domain.MaxProductionPlanDays,
+		"MaxRoastLoss": domain.MaxRoastLossPercent,
+		"Error":        errMsg,
+	}
+
+	if errMsg != "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	h.renderer.RenderHTTP(w, "admin/production_plan", data)
+}
+
+// productionPlan loads the plan for the start date and day count in the
+// request, defaulting to productionPlanDays from today.
+func (h *RoastHandler) productionPlan(r *http.Request) (*domain.ProductionPlan, error) {
+	start, err := parseRoastDate(r.FormValue("start"))
+	if err != nil {
+		return nil, err
+	}
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	days := productionPlanDays
+	if s := strings.TrimSpace(r.FormValue("days")); s != "" {
+		if days, err = strconv.Atoi(s); err != nil {
+			return nil, service.ErrInvalidPlanWindow
+		}
+	}
+
+	return h.roastService.ProductionPlan(r.Context(), start, days)
+}
+
+// planQuery keeps the plan's start date and day count across requests.
+func planQuery(r *http.Request) string {
+	query := url.Values{}
+	for _, key := range []string{"start", "days"} {
+		if v := strings.TrimSpace(r.FormValue(key)); v != "" {
+			query.Set(key, v)
+		}
+	}
+	return query.Encode()
+}
@@ -26,17 +26,21 @@ import (
 
 // ProductHandler handles all product-related admin routes
 type ProductHandler struct {
-	repo     repository.Querier
-	renderer *handler.Renderer
-	storage  storage.Storage
+	repo              repository.Querier
+	categoryService   domain.CategoryService
+	whiteLabelService domain.WhiteLabelService
+	renderer          *handler.Renderer
+	storage           storage.Storage
 }
 
 // NewProductHandler creates a new product handler
-func NewProductHandler(repo repository.Querier, renderer *handler.Renderer, storage storage.Storage) *ProductHandler {
+func NewProductHandler(repo repository.Querier, categoryService domain.CategoryService, whiteLabelService domain.WhiteLabelService, renderer *handler.Renderer, storage storage.Storage) *ProductHandler {
 	return &ProductHandler{
-		repo:     repo,
-		renderer: renderer,
-		storage:  storage,
+		repo:              repo,
+		categoryService:   categoryService,
+		whiteLabelService: whiteLabelService,
+		renderer:          renderer,
+		storage:           storage,
 	}
 }
 
@@ -116,6 +120,7 @@ func (h *ProductHandler) Detail(w http.ResponseWriter, r *http.Request) {
 		BasePriceDollars     string
 		TrackInventory       bool
 		StockQuantity        int32
+		SharesBaseStock      bool
 		IsActive             bool
 	}
 
@@ -145,6 +150,7 @@ func (h *ProductHandler) Detail(w http.ResponseWriter, r *http.Request) {
 			BasePriceDollars:     fmt.Sprintf("%.2f", float64(sku.BasePriceCents)/100),
 			TrackInventory:       sku.InventoryPolicy == "deny",
 			StockQuantity:        sku.InventoryQuantity,
+			SharesBaseStock:      sku.BaseSkuID.Valid,
 			IsActive:             sku.IsActive,
 		}
 	}
@@ -157,6 +163,24 @@ func (h *ProductHandler) Detail(w http.ResponseWriter, r *http.Request) {
 		"CSRFToken":   middleware.GetCSRFToken(r.Context()),
 	}
 
+	// White-label products link back to their base product and customer;
+	// standard products list their white-label variants
+	if product.IsWhiteLabel {
+		details, err := h.whiteLabelService.GetWhiteLabelDetails(ctx, productID)
+		if err != nil {
+			handler.ErrorResponse(w, r, err)
+			return
+		}
+		data["WhiteLabel"] = details
+	} else {
+		whiteLabels, err := h.whiteLabelService.ListWhiteLabelProducts(ctx, productID)
+		if err != nil {
+			handler.ErrorResponse(w, r, err)
+			return
+		}
+		data["WhiteLabels"] = whiteLabels
+	}
+
 	h.renderer.RenderHTTP(w, "admin/product_detail", data)
 }
 
@@ -199,10 +223,42 @@ func (h *ProductHandler) ShowForm(w http.ResponseWriter, r *http.Request) {
 		product.Visibility = "public"
 	}
 
+	categories, err := h.categoryService.ListCategories(ctx)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+	tags, err := h.categoryService.ListTags(ctx)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	selectedCategories := make(map[string]bool)
+	selectedTags := make(map[string]bool)
+	if productID != "" {
+		assignments, err := h.categoryService.GetProductAssignments(ctx, productID)
+		if err != nil {
+			handler.InternalErrorResponse(w, r, err)
+			return
+		}
+		for _, id := range assignments.CategoryIDs {
+			selectedCategories[id] = true
+		}
+		for _, id := range assignments.TagIDs {
+			selectedTags[id] = true
+		}
+	}
+
 	data := map[string]interface{}{
 		"CurrentPath":        r.URL.Path,
+		"CSRFToken":          middleware.GetCSRFToken(ctx),
 		"Product":            product,
 		"TastingNotesString": tastingNotesString,
+		"Categories":         categories,
+		"Tags":               tags,
+		"SelectedCategories": selectedCategories,
+		"SelectedTags":       selectedTags,
 	}
 
 	h.renderer.RenderHTTP(w, "admin/product_form", data)
@@ -225,6 +281,11 @@ func (h *ProductHandler) HandleForm(w http.ResponseWriter, r *http.Request) {
 	productID := r.PathValue("id")
 	isEdit := productID != ""
 
+	assignments := domain.ProductAssignments{
+		CategoryIDs: r.Form["category_ids"],
+		TagIDs:      r.Form["tag_ids"],
+	}
+
 	tastingNotesStr := strings.TrimSpace(r.FormValue("tasting_notes"))
 	var tastingNotes []string
 	if tastingNotesStr != "" {
@@ -291,6 +352,11 @@ func (h *ProductHandler) HandleForm(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := h.categoryService.SetProductAssignments(ctx, productID, assignments); err != nil {
+			handler.InternalErrorResponse(w, r, err)
+			return
+		}
+
 		http.Redirect(w, r, "/admin/products", http.StatusSeeOther)
 	} else {
 		elevationMin := pgtype.Int4{}
@@ -320,7 +386,7 @@ func (h *ProductHandler) HandleForm(w http.ResponseWriter, r *http.Request) {
 		baseProductID := pgtype.UUID{Valid: false}
 		whiteLabelCustomerID := pgtype.UUID{Valid: false}
 
-		_, err := h.repo.CreateProduct(ctx, repository.CreateProductParams{
+		product, err := h.repo.CreateProduct(ctx, repository.CreateProductParams{
 			TenantID:             tenantID,
 			Name:                 r.FormValue("name"),
 			Slug:                 r.FormValue("slug"),
@@ -346,6 +412,11 @@ func (h *ProductHandler) HandleForm(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := h.categoryService.SetProductAssignments(ctx, formatUUID(product.ID), assignments); err != nil {
+			handler.InternalErrorResponse(w, r, err)
+			return
+		}
+
 		http.Redirect(w, r, "/admin/products", http.StatusSeeOther)
 	}
 }
@@ -450,7 +521,7 @@ func (h *ProductHandler) HandleSKUForm(w http.ResponseWriter, r *http.Request) {
 	weightUnit := r.FormValue("weight_unit")
 	grind := r.FormValue("grind")
 	basePriceStr := r.FormValue("base_price")
-	inventoryQty, _ := strconv.Atoi(r.FormValue("inventory_quantity"))
+	inventoryQty, _ := strconv.Atoi(r.FormValue("stock_quantity"))
 	lowStockStr := r.FormValue("low_stock_threshold")
 	inventoryPolicy := r.FormValue("inventory_policy")
 	isActive := r.FormValue("is_active") == "true"
@@ -475,6 +546,16 @@ func (h *ProductHandler) HandleSKUForm(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Blank or zero means the SKU has no wholesale quantity rule
+	wholesaleMinQuantity := pgtype.Int4{}
+	if n, err := strconv.Atoi(r.FormValue("wholesale_min_quantity")); err == nil && n > 0 {
+		wholesaleMinQuantity = pgtype.Int4{Int32: int32(n), Valid: true}
+	}
+	wholesaleCasePack := pgtype.Int4{}
+	if n, err := strconv.Atoi(r.FormValue("wholesale_case_pack")); err == nil && n > 0 {
+		wholesaleCasePack = pgtype.Int4{Int32: int32(n), Valid: true}
+	}
+
 	weightGrams := calculateWeightGrams(weightValue, weightUnit)
 
 	var weightNumeric pgtype.Numeric
@@ -492,19 +573,20 @@ func (h *ProductHandler) HandleSKUForm(w http.ResponseWriter, r *http.Request) {
 		}
 
 		_, err = h.repo.UpdateProductSKU(ctx, repository.UpdateProductSKUParams{
-			TenantID:          tenantID,
-			ID:                skuUUID,
-			Sku:               skuCode,
-			WeightValue:       weightNumeric,
-			WeightUnit:        weightUnit,
-			Grind:             grind,
-			BasePriceCents:    basePriceCents,
-			InventoryQuantity: int32(inventoryQty),
-			InventoryPolicy:   inventoryPolicy,
-			LowStockThreshold: lowStockThreshold,
-			IsActive:          isActive,
-			WeightGrams:       weightGrams,
-			RequiresShipping:  true,
+			TenantID:             tenantID,
+			ID:                   skuUUID,
+			Sku:                  skuCode,
+			WeightValue:          weightNumeric,
+			WeightUnit:           weightUnit,
+			Grind:                grind,
+			BasePriceCents:       basePriceCents,
+			InventoryPolicy:      inventoryPolicy,
+			LowStockThreshold:    lowStockThreshold,
+			IsActive:             isActive,
+			WeightGrams:          weightGrams,
+			RequiresShipping:     true,
+			WholesaleMinQuantity: wholesaleMinQuantity,
+			WholesaleCasePack:    wholesaleCasePack,
 		})
 		if err != nil {
 			handler.InternalErrorResponse(w, r, err)
@@ -514,19 +596,21 @@ func (h *ProductHandler) HandleSKUForm(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/admin/products/"+productID, http.StatusSeeOther)
 	} else {
 		newSKU, err := h.repo.CreateProductSKU(ctx, repository.CreateProductSKUParams{
-			TenantID:          tenantID,
-			ProductID:         productUUID,
-			Sku:               skuCode,
-			WeightValue:       weightNumeric,
-			WeightUnit:        weightUnit,
-			Grind:             grind,
-			BasePriceCents:    basePriceCents,
-			InventoryQuantity: int32(inventoryQty),
-			InventoryPolicy:   inventoryPolicy,
-			LowStockThreshold: lowStockThreshold,
-			IsActive:          isActive,
-			WeightGrams:       weightGrams,
-			RequiresShipping:  true,
+			TenantID:             tenantID,
+			ProductID:            productUUID,
+			Sku:                  skuCode,
+			WeightValue:          weightNumeric,
+			WeightUnit:           weightUnit,
+			Grind:                grind,
+			BasePriceCents:       basePriceCents,
+			InventoryQuantity:    0,
+			InventoryPolicy:      inventoryPolicy,
+			LowStockThreshold:    lowStockThreshold,
+			IsActive:             isActive,
+			WeightGrams:          weightGrams,
+			RequiresShipping:     true,
+			WholesaleMinQuantity: wholesaleMinQuantity,
+			WholesaleCasePack:    wholesaleCasePack,
 		})
 		if err != nil {
 			handler.InternalErrorResponse(w, r, err)
@@ -537,6 +621,26 @@ func (h *ProductHandler) HandleSKUForm(w http.ResponseWriter, r *http.Request) {
 			fmt.Printf("Warning: failed to create default pricing: %v\n", err)
 		}
 
+		// Opening stock goes through the ledger like any other stock change
+		if inventoryQty > 0 {
+			var operatorID pgtype.UUID
+			if operator := middleware.GetOperatorFromContext(ctx); operator != nil {
+				operatorID = operator.ID
+			}
+			_, err := h.repo.AdjustSKUInventory(ctx, repository.AdjustSKUInventoryParams{
+				TenantID:   tenantID,
+				ID:         newSKU.ID,
+				Counted:    pgtype.Int4{Int32: int32(inventoryQty), Valid: true},
+				Reason:     domain.AdjustmentReasonCount,
+				OperatorID: operatorID,
+				Note:       pgtype.Text{String: "Opening stock", Valid: true},
+			})
+			if err != nil {
+				handler.InternalErrorResponse(w, r, err)
+				return
+			}
+		}
+
 		http.Redirect(w, r, "/admin/products/"+productID, http.StatusSeeOther)
 	}
 }
@@ -0,0 +1,135 @@
+package admin
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+)
+
+// reviewsPerPage is the page size of the moderation queue.
+const reviewsPerPage = 25
+
+// reviewStatusTabs are the moderation statuses shown as tabs, in order.
+var reviewStatusTabs = []string{
+	domain.ReviewStatusPending,
+	domain.ReviewStatusApproved,
+	domain.ReviewStatusRejected,
+}
+
+// ReviewHandler handles product review moderation admin routes
+type ReviewHandler struct {
+	reviewService domain.ReviewService
+	renderer      *handler.Renderer
+}
+
+// NewReviewHandler creates a new review handler
+func NewReviewHandler(reviewService domain.ReviewService, renderer *handler.Renderer) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+		renderer:      renderer,
+	}
+}
+
+// List handles GET /admin/reviews - the moderation queue, filtered by status
+func (h *ReviewHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	status := reviewStatusParam(r.URL.Query().Get("status"))
+
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	reviews, err := h.reviewService.ListReviewsForModeration(ctx, status, reviewsPerPage, int32((page-1)*reviewsPerPage))
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	counts, err := h.reviewService.CountReviewsByStatus(ctx)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"CSRFToken":   middleware.GetCSRFToken(ctx),
+		"Reviews":     reviews,
+		"Status":      status,
+		"Tabs":        reviewStatusTabs,
+		"Counts":      counts,
+		"Page":        page,
+		"HasPrevPage": page > 1,
+		"HasNextPage": int(counts[status]) > page*reviewsPerPage,
+	}
+
+	h.renderer.RenderHTTP(w, "admin/reviews", data)
+}
+
+// Approve handles POST /admin/reviews/{id}/approve
+func (h *ReviewHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	if _, err := h.reviewService.ApproveReview(r.Context(), r.PathValue("id"), r.FormValue("notes")); err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	redirectToReviews(w, r)
+}
+
+// Reject handles POST /admin/reviews/{id}/reject
+func (h *ReviewHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	if _, err := h.reviewService.RejectReview(r.Context(), r.PathValue("id"), r.FormValue("notes")); err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	redirectToReviews(w, r)
+}
+
+// Reply handles POST /admin/reviews/{id}/reply - an empty reply removes it
+func (h *ReviewHandler) Reply(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	if _, err := h.reviewService.ReplyToReview(r.Context(), r.PathValue("id"), r.FormValue("reply")); err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	redirectToReviews(w, r)
+}
+
+// redirectToReviews returns to the moderation tab the action was taken from.
+func redirectToReviews(w http.ResponseWriter, r *http.Request) {
+	query := url.Values{}
+	query.Set("status", reviewStatusParam(r.FormValue("return_status")))
+	http.Redirect(w, r, "/admin/reviews?"+query.Encode(), http.StatusSeeOther)
+}
+
+// reviewStatusParam returns a known moderation status, defaulting to pending.
+func reviewStatusParam(status string) string {
+	for _, tab := range reviewStatusTabs {
+		if status == tab {
+			return status
+		}
+	}
+	return domain.ReviewStatusPending
+}
@@ -0,0 +1,367 @@
+package admin
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/service"
+)
+
+// roastDateLayout matches the value format of <input type="date">
+const roastDateLayout = "2006-01-02"
+
+// roastHistoryDays is how far back the roast schedule lists batches.
+const roastHistoryDays = 14
+
+// renewalWindowDays is how far past a batch's planned date the renewal
+// allocation form looks by default.
+const renewalWindowDays = 7
+
+// RoastHandler handles the roast schedule admin routes
+type RoastHandler struct {
+	roastService domain.RoastService
+	repo         repository.Querier
+	renderer     *handler.Renderer
+}
+
+// NewRoastHandler creates a new roast schedule handler
+func NewRoastHandler(roastService domain.RoastService, repo repository.Querier, renderer *handler.Renderer) *RoastHandler {
+	return &RoastHandler{
+		roastService: roastService,
+		repo:         repo,
+		renderer:     renderer,
+	}
+}
+
+// List handles GET /admin/roasts
+func (h *RoastHandler) List(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w, r, "")
+}
+
+// Create handles POST /admin/roasts
+func (h *RoastHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := middleware.GetLogger(ctx, slog.Default())
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	params, err := parseRoastBatchForm(r)
+	if err != nil {
+		h.renderList(w, r, domain.ErrorMessage(err))
+		return
+	}
+	params.ProductID = r.FormValue("product_id")
+
+	batch, err := h.roastService.CreateBatch(ctx, params)
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderList(w, r, domain.ErrorMessage(err))
+			return
+		}
+		logger.Error("failed to create roast batch", "error", err)
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/roasts/"+formatUUID(batch.ID), http.StatusSeeOther)
+}
+
+// Detail handles GET /admin/roasts/{id}
+func (h *RoastHandler) Detail(w http.ResponseWriter, r *http.Request) {
+	h.renderDetail(w, r, "")
+}
+
+// Update handles POST /admin/roasts/{id}
+func (h *RoastHandler) Update(w http.ResponseWriter, r *http.Request) {
+	batchID := r.PathValue("id")
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	params, err := parseRoastBatchForm(r)
+	if err == nil {
+		_, err = h.roastService.UpdateBatch(r.Context(), batchID, params)
+	}
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderDetail(w, r, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/roasts/"+batchID, http.StatusSeeOther)
+}
+
+// Record handles POST /admin/roasts/{id}/roasted
+func (h *RoastHandler) Record(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := middleware.GetLogger(ctx, slog.Default())
+	batchID := r.PathValue("id")
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	var params domain.RecordRoastParams
+	roastedOn, err := parseRoastDate(r.FormValue("roasted_on"))
+	if err == nil {
+		params.RoastedOn = roastedOn
+		params.RoastedWeightGrams, err = parseGrams(r.FormValue("roasted_weight_grams"))
+	}
+	if err == nil {
+		_, err = h.roastService.RecordRoast(ctx, batchID, params)
+	}
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderDetail(w, r, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	logger.Info("roast recorded", "batch_id", batchID, "roasted_on", params.RoastedOn.Format(roastDateLayout))
+
+	http.Redirect(w, r, "/admin/roasts/"+batchID, http.StatusSeeOther)
+}
+
+// Cancel handles POST /admin/roasts/{id}/cancel
+func (h *RoastHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	batchID := r.PathValue("id")
+
+	if err := h.roastService.CancelBatch(r.Context(), batchID); err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderDetail(w, r, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/roasts/"+batchID, http.StatusSeeOther)
+}
+
+// AllocateOrders handles POST /admin/roasts/{id}/allocate/orders
+func (h *RoastHandler) AllocateOrders(w http.ResponseWriter, r *http.Request) {
+	batchID := r.PathValue("id")
+
+	allocated, err := h.roastService.AllocateOpenOrders(r.Context(), batchID)
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderDetail(w, r, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/roasts/"+batchID+"?allocated="+strconv.FormatInt(allocated, 10), http.StatusSeeOther)
+}
+
+// AllocateRenewals handles POST /admin/roasts/{id}/allocate/renewals
+func (h *RoastHandler) AllocateRenewals(w http.ResponseWriter, r *http.Request) {
+	batchID := r.PathValue("id")
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	through, err := parseRoastDate(r.FormValue("renews_through"))
+	var allocated int64
+	if err == nil {
+		allocated, err = h.roastService.AllocateRenewals(r.Context(), batchID, through)
+	}
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderDetail(w, r, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/roasts/"+batchID+"?allocated="+strconv.FormatInt(allocated, 10), http.StatusSeeOther)
+}
+
+// RemoveAllocation handles POST /admin/roasts/{id}/allocations/{allocationID}/delete
+func (h *RoastHandler) RemoveAllocation(w http.ResponseWriter, r *http.Request) {
+	batchID := r.PathValue("id")
+
+	if err := h.roastService.RemoveAllocation(r.Context(), batchID, r.PathValue("allocationID")); err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/roasts/"+batchID, http.StatusSeeOther)
+}
+
+// SaveRestDays handles POST /admin/roasts/rest-days
+func (h *RoastHandler) SaveRestDays(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	days, err := strconv.Atoi(strings.TrimSpace(r.FormValue("rest_days")))
+	if err != nil {
+		h.renderList(w, r, service.ErrInvalidRestDays.Message)
+		return
+	}
+
+	if err := h.roastService.SetRestDays(r.Context(), int32(days)); err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderList(w, r, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/roasts", http.StatusSeeOther)
+}
+
+// renderList renders the roast schedule.
+// A non-empty errMsg is shown above the schedule with a 422 status.
+func (h *RoastHandler) renderList(w http.ResponseWriter, r *http.Request, errMsg string) {
+	ctx := r.Context()
+
+	batches, err := h.roastService.ListBatches(ctx, time.Now().AddDate(0, 0, -roastHistoryDays))
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	restDays, err := h.roastService.GetRestDays(ctx)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	products, err := h.repo.ListAllProducts(ctx, getTenantID(ctx))
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath":  r.URL.Path,
+		"CSRFToken":    middleware.GetCSRFToken(ctx),
+		"Batches":      batches,
+		"Products":     products,
+		"RestDays":     restDays,
+		"MaxRestDays":  domain.MaxRoastRestDays,
+		"Today":        time.Now().Format(roastDateLayout),
+		"HistoryDays":  roastHistoryDays,
+		"Error":        errMsg,
+		"FormProduct":  r.FormValue("product_id"),
+		"FormPlanned":  r.FormValue("planned_for"),
+		"FormGreenLot": r.FormValue("green_lot"),
+	}
+
+	if errMsg != "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	h.renderer.RenderHTTP(w, "admin/roasts", data)
+}
+
+// renderDetail renders a roast batch with its allocations.
+// A non-empty errMsg is shown above the batch with a 422 status.
+func (h *RoastHandler) renderDetail(w http.ResponseWriter, r *http.Request, errMsg string) {
+	ctx := r.Context()
+
+	detail, err := h.roastService.GetBatch(ctx, r.PathValue("id"))
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	var allocatedUnits int32
+	for _, allocation := range detail.Allocations {
+		allocatedUnits += allocation.Quantity
+	}
+
+	batch := detail.Batch
+	data := map[string]interface{}{
+		"CurrentPath":    r.URL.Path,
+		"CSRFToken":      middleware.GetCSRFToken(ctx),
+		"Batch":          batch,
+		"Allocations":    detail.Allocations,
+		"AllocatedUnits": allocatedUnits,
+		"PlannedFor":     batch.PlannedFor.Time.Format(roastDateLayout),
+		"RenewsThrough":  batch.PlannedFor.Time.AddDate(0, 0, renewalWindowDays).Format(roastDateLayout),
+		"Today":          time.Now().Format(roastDateLayout),
+		"Allocated":      r.URL.Query().Get("allocated"),
+		"Error":          errMsg,
+	}
+
+	if errMsg != "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	h.renderer.RenderHTTP(w, "admin/roast_batch", data)
+}
+
+// parseRoastBatchForm converts the submitted plan into service parameters.
+func parseRoastBatchForm(r *http.Request) (domain.RoastBatchParams, error) {
+	params := domain.RoastBatchParams{
+		GreenLot: strings.TrimSpace(r.FormValue("green_lot")),
+		Notes:    strings.TrimSpace(r.FormValue("notes")),
+	}
+
+	plannedFor, err := parseRoastDate(r.FormValue("planned_for"))
+	if err != nil {
+		return params, err
+	}
+	params.PlannedFor = plannedFor
+
+	params.GreenWeightGrams, err = parseGrams(r.FormValue("green_weight_grams"))
+	if err != nil {
+		return params, err
+	}
+
+	return params, nil
+}
+
+// parseRoastDate parses a date input. An empty value gives the zero time,
+// which the service rejects where a date is required.
+func parseRoastDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.ParseInLocation(roastDateLayout, s, time.Local)
+	if err != nil {
+		return time.Time{}, domain.Errorf(domain.EINVALID, "", "Invalid date")
+	}
+	return t, nil
+}
+
+// parseGrams parses an optional weight in whole grams.
+func parseGrams(s string) (int32, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	grams, err := strconv.Atoi(s)
+	if err != nil || grams < 0 {
+		return 0, service.ErrInvalidRoastWeight
+	}
+	return int32(grams), nil
+}
@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/scheduler"
+)
+
+// ScheduleHandler handles the recurring job schedules page
+type ScheduleHandler struct {
+	repo     repository.Querier
+	renderer *handler.Renderer
+}
+
+// NewScheduleHandler creates a new schedule handler
+func NewScheduleHandler(repo repository.Querier, renderer *handler.Renderer) *ScheduleHandler {
+	return &ScheduleHandler{
+		repo:     repo,
+		renderer: renderer,
+	}
+}
+
+// ListPage handles GET /admin/settings/schedules
+func (h *ScheduleHandler) ListPage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := getTenantID(ctx)
+	if !tenantID.Valid {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EUNAUTHORIZED, "", "No tenant context"))
+		return
+	}
+
+	schedules, err := h.repo.ListJobSchedulesForTenant(ctx, tenantID)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	type DisplaySchedule struct {
+		repository.JobSchedule
+		Name        string
+		Description string
+	}
+
+	displaySchedules := make([]DisplaySchedule, 0, len(schedules))
+	for _, s := range schedules {
+		display := DisplaySchedule{JobSchedule: s, Name: s.JobType}
+		if def, ok := scheduler.LookupDefinition(s.JobType); ok {
+			display.Name = def.Name
+			display.Description = def.Description
+		}
+		displaySchedules = append(displaySchedules, display)
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"Schedules":   displaySchedules,
+	}
+
+	h.renderer.RenderHTTP(w, "admin/schedules", data)
+}
@@ -0,0 +1,241 @@
+package admin
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// LabelRates handles POST /admin/orders/{id}/labels/rates
+// Fetches label rates for the submitted package and re-renders the order page.
+func (h *OrderHandler) LabelRates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := getTenantID(ctx)
+	if !tenantID.Valid {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EUNAUTHORIZED, "", "No tenant context"))
+		return
+	}
+
+	logger := middleware.GetLogger(ctx, slog.Default())
+
+	orderID := r.PathValue("id")
+	var orderUUID pgtype.UUID
+	if err := orderUUID.Scan(orderID); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid order ID"))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	pkg, ok := parsePackageForm(r)
+	if !ok {
+		h.renderDetail(w, r, tenantID, orderUUID, orderDetailState{
+			LabelError:   "Enter a whole-number weight and dimensions",
+			LabelPackage: &pkg,
+		})
+		return
+	}
+
+	rates, err := h.labelService.GetRates(ctx, orderID, pkg)
+	if err != nil {
+		h.renderDetail(w, r, tenantID, orderUUID, orderDetailState{
+			LabelError:   labelErrorMessage(logger, "failed to get label rates", orderID, err),
+			LabelPackage: &pkg,
+		})
+		return
+	}
+
+	state := orderDetailState{LabelPackage: &pkg, LabelRates: rates}
+	if len(rates) == 0 {
+		state.LabelError = "No rates are available for this package"
+	}
+	h.renderDetail(w, r, tenantID, orderUUID, state)
+}
+
+// BuyLabel handles POST /admin/orders/{id}/labels
+func (h *OrderHandler) BuyLabel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := getTenantID(ctx)
+	if !tenantID.Valid {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EUNAUTHORIZED, "", "No tenant context"))
+		return
+	}
+
+	logger := middleware.GetLogger(ctx, slog.Default())
+
+	orderID := r.PathValue("id")
+	var orderUUID pgtype.UUID
+	if err := orderUUID.Scan(orderID); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid order ID"))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	pkg, ok := parsePackageForm(r)
+	if !ok {
+		h.renderDetail(w, r, tenantID, orderUUID, orderDetailState{
+			LabelError:   "Enter a whole-number weight and dimensions",
+			LabelPackage: &pkg,
+		})
+		return
+	}
+
+	shipment, err := h.labelService.BuyLabel(ctx, domain.BuyLabelParams{
+		OrderID: orderID,
+		RateID:  r.FormValue("rate_id"),
+		Package: pkg,
+	})
+	if err != nil {
+		h.renderDetail(w, r, tenantID, orderUUID, orderDetailState{
+			LabelError:   labelErrorMessage(logger, "failed to buy label", orderID, err),
+			LabelPackage: &pkg,
+		})
+		return
+	}
+
+	logger.Info("shipping label purchased",
+		"order_id", orderID,
+		"shipment_number", shipment.ShipmentNumber,
+		"carrier", shipment.Carrier,
+		"tracking_number", shipment.TrackingNumber.String)
+
+	http.Redirect(w, r, "/admin/orders/"+orderID, http.StatusSeeOther)
+}
+
+// VoidLabel handles POST /admin/orders/{id}/shipments/{shipmentID}/void
+func (h *OrderHandler) VoidLabel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := getTenantID(ctx)
+	if !tenantID.Valid {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EUNAUTHORIZED, "", "No tenant context"))
+		return
+	}
+
+	logger := middleware.GetLogger(ctx, slog.Default())
+
+	orderID := r.PathValue("id")
+	var orderUUID pgtype.UUID
+	if err := orderUUID.Scan(orderID); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid order ID"))
+		return
+	}
+
+	shipmentID := r.PathValue("shipmentID")
+	if err := h.labelService.VoidLabel(ctx, orderID, shipmentID); err != nil {
+		if domain.ErrorCode(err) == domain.ENOTFOUND {
+			handler.NotFoundResponse(w, r)
+			return
+		}
+		h.renderDetail(w, r, tenantID, orderUUID, orderDetailState{
+			LabelError: labelErrorMessage(logger, "failed to void label", orderID, err),
+		})
+		return
+	}
+
+	logger.Info("shipping label voided", "order_id", orderID, "shipment_id", shipmentID)
+
+	http.Redirect(w, r, "/admin/orders/"+orderID, http.StatusSeeOther)
+}
+
+// Label handles GET /admin/orders/{id}/shipments/{shipmentID}/label
+// Streams the stored label file so it can be printed.
+func (h *OrderHandler) Label(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := getTenantID(ctx)
+	if !tenantID.Valid {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EUNAUTHORIZED, "", "No tenant context"))
+		return
+	}
+
+	logger := middleware.GetLogger(ctx, slog.Default())
+
+	body, shipment, err := h.labelService.OpenLabel(ctx, r.PathValue("shipmentID"))
+	if err != nil {
+		if code := domain.ErrorCode(err); code == domain.ENOTFOUND || code == domain.EINVALID {
+			handler.NotFoundResponse(w, r)
+			return
+		}
+		logger.Error("failed to open label", "error", err, "shipment_id", r.PathValue("shipmentID"))
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+	defer body.Close()
+
+	// The shipment must belong to the order in the URL
+	if formatUUID(shipment.OrderID) != r.PathValue("id") {
+		handler.NotFoundResponse(w, r)
+		return
+	}
+
+	filename := shipment.ShipmentNumber + path.Ext(shipment.LabelStorageKey.String)
+	contentType := mime.TypeByExtension(path.Ext(filename))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `inline; filename="`+filename+`"`)
+	if _, err := io.Copy(w, body); err != nil {
+		logger.Warn("failed to stream label", "error", err, "shipment_id", r.PathValue("shipmentID"))
+	}
+}
+
+// parsePackageForm reads package weight and dimensions from the label forms.
+// It returns false if any value is missing or not a whole number.
+func parsePackageForm(r *http.Request) (shipping.Package, bool) {
+	var pkg shipping.Package
+	ok := true
+	for _, field := range []struct {
+		name string
+		dst  *int32
+	}{
+		{"weight_grams", &pkg.WeightGrams},
+		{"length_cm", &pkg.LengthCm},
+		{"width_cm", &pkg.WidthCm},
+		{"height_cm", &pkg.HeightCm},
+	} {
+		v, err := strconv.ParseInt(strings.TrimSpace(r.FormValue(field.name)), 10, 32)
+		if err != nil {
+			ok = false
+			continue
+		}
+		*field.dst = int32(v)
+	}
+	return pkg, ok
+}
+
+// labelErrorMessage converts a label service error into a message for the
+// order page. Validation and carrier errors are shown as-is; anything else is
+// logged and replaced with a generic message.
+func labelErrorMessage(logger *slog.Logger, msg, orderID string, err error) string {
+	if domain.ErrorCode(err) == domain.EINVALID {
+		return domain.ErrorMessage(err)
+	}
+
+	var shipErr *shipping.ShippingError
+	if errors.As(err, &shipErr) {
+		logger.Warn(msg, "error", err, "order_id", orderID)
+		return shipErr.Message
+	}
+
+	logger.Error(msg, "error", err, "order_id", orderID)
+	return "The shipping provider could not complete the request. Please try again."
+}
@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+)
+
+// StandingOrderHandler handles the wholesale standing order admin routes
+type StandingOrderHandler struct {
+	standingOrderService domain.StandingOrderService
+	renderer             *handler.Renderer
+}
+
+// NewStandingOrderHandler creates a new standing order handler
+func NewStandingOrderHandler(standingOrderService domain.StandingOrderService, renderer *handler.Renderer) *StandingOrderHandler {
+	return &StandingOrderHandler{
+		standingOrderService: standingOrderService,
+		renderer:             renderer,
+	}
+}
+
+// List handles GET /admin/standing-orders
+func (h *StandingOrderHandler) List(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w, r, "")
+}
+
+// SaveSettings handles POST /admin/standing-orders/settings
+func (h *StandingOrderHandler) SaveSettings(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	cutoffDays, err := strconv.Atoi(r.FormValue("cutoff_days"))
+	if err != nil {
+		h.renderList(w, r, domain.ErrInvalidStandingOrderSettings.Message)
+		return
+	}
+	reminderDays, err := strconv.Atoi(r.FormValue("reminder_days"))
+	if err != nil {
+		h.renderList(w, r, domain.ErrInvalidStandingOrderSettings.Message)
+		return
+	}
+
+	err = h.standingOrderService.UpdateSettings(r.Context(), domain.StandingOrderSettings{
+		CutoffDays:   int32(cutoffDays),
+		ReminderDays: int32(reminderDays),
+	})
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderList(w, r, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/standing-orders", http.StatusSeeOther)
+}
+
+// renderList renders the standing orders with the lead time settings.
+// A non-empty errMsg is shown above the settings with a 422 status.
+func (h *StandingOrderHandler) renderList(w http.ResponseWriter, r *http.Request, errMsg string) {
+	ctx := r.Context()
+
+	standingOrders, err := h.standingOrderService.ListAll(ctx)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	settings, err := h.standingOrderService.GetSettings(ctx)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath":     r.URL.Path,
+		"CSRFToken":       middleware.GetCSRFToken(ctx),
+		"StandingOrders":  standingOrders,
+		"Settings":        settings,
+		"MaxCutoffDays":   domain.MaxStandingOrderCutoffDays,
+		"MaxReminderDays": domain.MaxStandingOrderReminderDays,
+		"Error":           errMsg,
+	}
+
+	if errMsg != "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	h.renderer.RenderHTTP(w, "admin/standing_orders", data)
+}
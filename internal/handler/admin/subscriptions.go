@@ -2,29 +2,77 @@ package admin
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/dukerupert/hiri/internal/domain"
 	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
 	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/service"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // SubscriptionHandler handles all subscription-related admin routes
 type SubscriptionHandler struct {
-	repo     repository.Querier
-	renderer *handler.Renderer
+	repo           repository.Querier
+	dunningService domain.DunningService
+	renderer       *handler.Renderer
 }
 
 // NewSubscriptionHandler creates a new subscription handler
-func NewSubscriptionHandler(repo repository.Querier, renderer *handler.Renderer) *SubscriptionHandler {
+func NewSubscriptionHandler(repo repository.Querier, dunningService domain.DunningService, renderer *handler.Renderer) *SubscriptionHandler {
 	return &SubscriptionHandler{
-		repo:     repo,
-		renderer: renderer,
+		repo:           repo,
+		dunningService: dunningService,
+		renderer:       renderer,
 	}
 }
 
 // List handles GET /admin/subscriptions
 func (h *SubscriptionHandler) List(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w, r, "")
+}
+
+// SaveDunningSettings handles POST /admin/subscriptions/dunning
+func (h *SubscriptionHandler) SaveDunningSettings(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	// Accept "3, 5, 7" as well as "3 5 7"
+	fields := strings.FieldsFunc(r.FormValue("retry_days"), func(c rune) bool {
+		return c == ',' || c == ' '
+	})
+	settings := domain.DunningSettings{
+		RetryDays:   make([]int32, 0, len(fields)),
+		FinalAction: r.FormValue("final_action"),
+	}
+	for _, field := range fields {
+		day, err := strconv.Atoi(field)
+		if err != nil {
+			h.renderList(w, r, service.ErrInvalidDunningSchedule.Message)
+			return
+		}
+		settings.RetryDays = append(settings.RetryDays, int32(day))
+	}
+
+	if err := h.dunningService.UpdateSettings(r.Context(), settings); err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderList(w, r, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/subscriptions", http.StatusSeeOther)
+}
+
+// renderList renders the subscriptions with the failed payment settings.
+// A non-empty errMsg is shown above the settings with a 422 status.
+func (h *SubscriptionHandler) renderList(w http.ResponseWriter, r *http.Request, errMsg string) {
 	ctx := r.Context()
 	tenantID := getTenantID(ctx)
 	if !tenantID.Valid {
@@ -49,12 +97,32 @@ func (h *SubscriptionHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	dunning, err := h.dunningService.GetSettings(ctx)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	retryDays := r.FormValue("retry_days")
+	if errMsg == "" {
+		retryDays = formatRetryDays(dunning.RetryDays)
+	}
+
 	data := map[string]interface{}{
 		"CurrentPath":   r.URL.Path,
+		"CSRFToken":     middleware.GetCSRFToken(ctx),
 		"Stats":         stats,
 		"Subscriptions": subscriptions,
+		"Dunning":       dunning,
+		"RetryDays":     retryDays,
+		"MaxRetries":    domain.MaxDunningRetries,
+		"MaxRetryDay":   domain.MaxDunningRetryDay,
+		"Error":         errMsg,
 	}
 
+	if errMsg != "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
 	h.renderer.RenderHTTP(w, "admin/subscriptions", data)
 }
 
@@ -99,12 +167,35 @@ func (h *SubscriptionHandler) Detail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	dunning, err := h.dunningService.GetForSubscription(ctx, subscriptionIDStr)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	dunningSettings, err := h.dunningService.GetSettings(ctx)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
 	data := map[string]interface{}{
-		"CurrentPath":  r.URL.Path,
-		"Subscription": subscription,
-		"Items":        items,
-		"Orders":       nil,
+		"CurrentPath":     r.URL.Path,
+		"Subscription":    subscription,
+		"Items":           items,
+		"Orders":          nil,
+		"Dunning":         dunning,
+		"DunningSettings": dunningSettings,
 	}
 
 	h.renderer.RenderHTTP(w, "admin/subscription_detail", data)
 }
+
+// formatRetryDays formats retry days for the settings form, e.g. "3, 5, 7".
+func formatRetryDays(days []int32) string {
+	formatted := make([]string, len(days))
+	for i, day := range days {
+		formatted[i] = strconv.Itoa(int(day))
+	}
+	return strings.Join(formatted, ", ")
+}
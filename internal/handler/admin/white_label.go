@@ -0,0 +1,145 @@
+package admin
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+)
+
+// WhiteLabelHandler handles creating white-label products from a base product
+type WhiteLabelHandler struct {
+	whiteLabelService domain.WhiteLabelService
+	renderer          *handler.Renderer
+}
+
+// NewWhiteLabelHandler creates a new white-label handler
+func NewWhiteLabelHandler(whiteLabelService domain.WhiteLabelService, renderer *handler.Renderer) *WhiteLabelHandler {
+	return &WhiteLabelHandler{
+		whiteLabelService: whiteLabelService,
+		renderer:          renderer,
+	}
+}
+
+// whiteLabelSKURow is a base SKU with the price entered for the white label
+type whiteLabelSKURow struct {
+	ID        string
+	Sku       string
+	Label     string
+	BasePrice string
+	Price     string
+}
+
+// ShowForm handles GET /admin/products/{id}/white-label/new
+func (h *WhiteLabelHandler) ShowForm(w http.ResponseWriter, r *http.Request) {
+	h.renderForm(w, r, domain.CreateWhiteLabelParams{}, nil, "")
+}
+
+// Create handles POST /admin/products/{id}/white-label/new
+func (h *WhiteLabelHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	params := domain.CreateWhiteLabelParams{
+		BaseProductID:    r.PathValue("id"),
+		CustomerID:       r.FormValue("customer_id"),
+		Name:             strings.TrimSpace(r.FormValue("name")),
+		ShortDescription: strings.TrimSpace(r.FormValue("short_description")),
+		Description:      strings.TrimSpace(r.FormValue("description")),
+		Prices:           make(map[string]int32),
+	}
+
+	// Price fields are named "price[{sku_id}]"; blank prices leave the size out
+	entered := make(map[string]string)
+	for key, values := range r.Form {
+		if !strings.HasPrefix(key, "price[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		skuID := strings.TrimSuffix(strings.TrimPrefix(key, "price["), "]")
+		value := strings.TrimSpace(values[0])
+		entered[skuID] = value
+		if value == "" {
+			continue
+		}
+
+		dollars, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			h.renderForm(w, r, params, entered, "Enter prices in dollars, e.g. 12.50")
+			return
+		}
+		params.Prices[skuID] = int32(math.Round(dollars * 100))
+	}
+
+	product, err := h.whiteLabelService.CreateWhiteLabelProduct(r.Context(), params)
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID || domain.ErrorCode(err) == domain.ECONFLICT {
+			h.renderForm(w, r, params, entered, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/products/"+formatUUID(product.ID), http.StatusSeeOther)
+}
+
+// renderForm renders the white-label form. entered holds the submitted
+// prices by base SKU ID; nil prefills each size with its base price.
+func (h *WhiteLabelHandler) renderForm(w http.ResponseWriter, r *http.Request, params domain.CreateWhiteLabelParams, entered map[string]string, errMsg string) {
+	ctx := r.Context()
+
+	base, err := h.whiteLabelService.GetWhiteLabelBase(ctx, r.PathValue("id"))
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	customers, err := h.whiteLabelService.ListEligibleCustomers(ctx)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	skus := make([]whiteLabelSKURow, len(base.SKUs))
+	for i, sku := range base.SKUs {
+		id := formatUUID(sku.ID)
+		basePrice := fmt.Sprintf("%.2f", float64(sku.BasePriceCents)/100)
+
+		price := basePrice
+		if entered != nil {
+			price = entered[id]
+		}
+
+		label := sku.Grind
+		if weight, err := sku.WeightValue.Float64Value(); err == nil && weight.Valid {
+			label = fmt.Sprintf("%g %s · %s", weight.Float64, sku.WeightUnit, sku.Grind)
+		}
+
+		skus[i] = whiteLabelSKURow{
+			ID:        id,
+			Sku:       sku.Sku,
+			Label:     label,
+			BasePrice: basePrice,
+			Price:     price,
+		}
+	}
+
+	data := map[string]interface{}{
+		"CurrentPath": r.URL.Path,
+		"CSRFToken":   middleware.GetCSRFToken(ctx),
+		"Product":     base.Product,
+		"SKUs":        skus,
+		"Customers":   customers,
+		"Form":        params,
+		"Error":       errMsg,
+	}
+
+	h.renderer.RenderHTTP(w, "admin/white_label_form", data)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/dukerupert/hiri/internal/domain"
+)
+
+// brandShades derive the theme's color scale from a single brand color.
+// The storefront stylesheet reads its teal (primary) and amber (accent)
+// palettes from these CSS variables, so overriding them rethemes every
+// utility class that uses them.
+var brandShades = []struct {
+	shade string
+	mix   string // color-mix() of the brand color, or "" for the color itself
+}{
+	{"50", "6%, white"},
+	{"100", "18%, white"},
+	{"200", "32%, white"},
+	{"600", "88%, white"},
+	{"700", ""},
+	{"800", "78%, black"},
+	{"900", "58%, black"},
+}
+
+// BrandingCSS returns the style rules that apply a tenant's colors and fonts
+// to the storefront layout. Values left at their defaults keep the stock
+// theme, and a nil branding or invalid values are ignored.
+func BrandingCSS(branding *domain.Branding) template.CSS {
+	if branding == nil {
+		return ""
+	}
+	def := domain.DefaultBranding()
+
+	var vars strings.Builder
+	writeBrandPalette(&vars, "teal", branding.PrimaryColor, def.PrimaryColor)
+	writeBrandPalette(&vars, "amber", branding.AccentColor, def.AccentColor)
+	if _, ok := domain.LookupBrandFont(branding.BodyFont); ok && branding.BodyFont != def.BodyFont {
+		fmt.Fprintf(&vars, "  --font-sans: %s;\n", branding.BodyFontStack())
+	}
+
+	var css strings.Builder
+	if vars.Len() > 0 {
+		fmt.Fprintf(&css, ":root {\n%s}\n", vars.String())
+	}
+	if _, ok := domain.LookupBrandFont(branding.HeadingFont); ok && branding.HeadingFont != def.HeadingFont {
+		fmt.Fprintf(&css, "h1, h2, h3, h4 { font-family: %s; }\n", branding.HeadingFontStack())
+	}
+
+	return template.CSS(css.String())
+}
+
+func writeBrandPalette(css *strings.Builder, palette, color, defaultColor string) {
+	if !domain.IsHexColor(color) || strings.EqualFold(color, defaultColor) {
+		return
+	}
+	for _, s := range brandShades {
+		if s.mix == "" {
+			fmt.Fprintf(css, "  --color-%s-%s: %s;\n", palette, s.shade, color)
+			continue
+		}
+		fmt.Fprintf(css, "  --color-%s-%s: color-mix(in srgb, %s %s);\n", palette, s.shade, color, s.mix)
+	}
+}
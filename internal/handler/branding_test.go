@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrandingCSS(t *testing.T) {
+	branding := domain.DefaultBranding()
+	branding.PrimaryColor = "#AA0011"
+	branding.HeadingFont = "slab"
+
+	css := string(BrandingCSS(&branding))
+
+	assert.Contains(t, css, "--color-teal-700: #AA0011;")
+	assert.Contains(t, css, "--color-teal-800: color-mix(in srgb, #AA0011 78%, black);")
+	assert.Contains(t, css, "h1, h2, h3, h4 { font-family: Rockwell,")
+	// Defaults keep the stock theme
+	assert.NotContains(t, css, "amber")
+	assert.NotContains(t, css, "--font-sans")
+
+	defaults := domain.DefaultBranding()
+	assert.Empty(t, BrandingCSS(&defaults))
+	assert.Empty(t, BrandingCSS(nil))
+}
+
+func TestBrandingCSS_IgnoresInvalidValues(t *testing.T) {
+	css := string(BrandingCSS(&domain.Branding{
+		PrimaryColor: "red;}</style>",
+		AccentColor:  "#B5873A",
+		HeadingFont:  "Comic Sans",
+		BodyFont:     "mono",
+	}))
+
+	assert.NotContains(t, css, "teal")
+	assert.NotContains(t, css, "</style>")
+	assert.NotContains(t, css, "h1, h2")
+	assert.Contains(t, css, "--font-sans: ui-monospace")
+}
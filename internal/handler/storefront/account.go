@@ -28,9 +28,9 @@ import (
 type AccountHandler struct {
 	accountService      service.AccountService
 	subscriptionService domain.SubscriptionService
+	reviewService       domain.ReviewService
 	repo                repository.Querier
 	renderer            *handler.Renderer
-	tenantID            pgtype.UUID
 	logger              *slog.Logger
 }
 
@@ -38,21 +38,16 @@ type AccountHandler struct {
 func NewAccountHandler(
 	accountService service.AccountService,
 	subscriptionService domain.SubscriptionService,
+	reviewService domain.ReviewService,
 	repo repository.Querier,
 	renderer *handler.Renderer,
-	tenantID string,
 ) *AccountHandler {
-	var tenantUUID pgtype.UUID
-	if err := tenantUUID.Scan(tenantID); err != nil {
-		panic(fmt.Sprintf("invalid tenant ID: %v", err))
-	}
-
 	return &AccountHandler{
 		accountService:      accountService,
 		subscriptionService: subscriptionService,
+		reviewService:       reviewService,
 		repo:                repo,
 		renderer:            renderer,
-		tenantID:            tenantUUID,
 		logger:              slog.Default().With("handler", "account"),
 	}
 }
@@ -64,6 +59,7 @@ func NewAccountHandler(
 // Dashboard handles GET /account - shows account dashboard
 func (h *AccountHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -72,13 +68,13 @@ func (h *AccountHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get account summary (addresses, payment methods, orders)
-	accountSummary, err := h.accountService.GetAccountSummary(ctx, h.tenantID, user.ID)
+	accountSummary, err := h.accountService.GetAccountSummary(ctx, tenantID, user.ID)
 	if err != nil {
 		accountSummary = service.AccountSummary{}
 	}
 
 	// Get subscription counts
-	subscriptionCounts, err := h.subscriptionService.GetSubscriptionCountsForUser(ctx, h.tenantID, user.ID)
+	subscriptionCounts, err := h.subscriptionService.GetSubscriptionCountsForUser(ctx, tenantID, user.ID)
 	if err != nil {
 		subscriptionCounts = domain.SubscriptionCounts{}
 	}
@@ -112,11 +108,31 @@ type OrderSummary struct {
 	IsSubscription    bool
 	StatusColor       string
 	StatusLabel       string
+	TrackingEvents    []TrackingEventDisplay
+	ReviewItems       []ReviewItemDisplay // Products to review; delivered orders only
+}
+
+// ReviewItemDisplay represents a product from a delivered order and the
+// customer's review of it, if any
+type ReviewItemDisplay struct {
+	ProductID    string
+	ProductName  string
+	ProductSlug  string
+	ReviewStatus string // Empty when not yet reviewed
+	Rating       int32
+}
+
+// TrackingEventDisplay represents a carrier tracking event for template rendering
+type TrackingEventDisplay struct {
+	Description string
+	Location    string
+	OccurredAt  time.Time
 }
 
 // OrderList handles GET /account/orders - shows order history
 func (h *AccountHandler) OrderList(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -140,7 +156,7 @@ func (h *AccountHandler) OrderList(w http.ResponseWriter, r *http.Request) {
 
 	// Fetch orders for user
 	orders, err := h.repo.ListOrdersForUser(ctx, repository.ListOrdersForUserParams{
-		TenantID: h.tenantID,
+		TenantID: tenantID,
 		UserID:   user.ID,
 		Limit:    limit,
 		Offset:   offset,
@@ -152,13 +168,44 @@ func (h *AccountHandler) OrderList(w http.ResponseWriter, r *http.Request) {
 
 	// Get total count for pagination
 	totalCount, err := h.repo.CountOrdersForUser(ctx, repository.CountOrdersForUserParams{
-		TenantID: h.tenantID,
+		TenantID: tenantID,
 		UserID:   user.ID,
 	})
 	if err != nil {
 		totalCount = 0
 	}
 
+	// Load carrier tracking timelines for shipped orders
+	eventsByOrder := make(map[[16]byte][]TrackingEventDisplay)
+	var trackedIDs []pgtype.UUID
+	for _, o := range orders {
+		if o.TrackingNumber.Valid {
+			trackedIDs = append(trackedIDs, o.ID)
+		}
+	}
+	if len(trackedIDs) > 0 {
+		events, err := h.repo.ListTrackingEventsForOrders(ctx, repository.ListTrackingEventsForOrdersParams{
+			TenantID: tenantID,
+			OrderIds: trackedIDs,
+		})
+		if err != nil {
+			handler.InternalErrorResponse(w, r, err)
+			return
+		}
+
+		for _, e := range events {
+			description := e.Message.String
+			if description == "" {
+				description = getShipmentStatusLabel(e.Status)
+			}
+			eventsByOrder[e.OrderID.Bytes] = append(eventsByOrder[e.OrderID.Bytes], TrackingEventDisplay{
+				Description: description,
+				Location:    e.Location.String,
+				OccurredAt:  e.EventAt.Time,
+			})
+		}
+	}
+
 	// Transform orders for display
 	displayOrders := make([]OrderSummary, 0, len(orders))
 	for _, o := range orders {
@@ -177,6 +224,7 @@ func (h *AccountHandler) OrderList(w http.ResponseWriter, r *http.Request) {
 			CreatedAt:         o.CreatedAt.Time,
 			IsSubscription:    o.SubscriptionID.Valid,
 			ShipmentStatus:    o.ShipmentStatus,
+			TrackingEvents:    eventsByOrder[o.ID.Bytes],
 		}
 
 		// Format UUID as string
@@ -207,6 +255,35 @@ func (h *AccountHandler) OrderList(w http.ResponseWriter, r *http.Request) {
 		displayOrders = append(displayOrders, summary)
 	}
 
+	// Attach the products customers can review to delivered orders
+	var deliveredIDs []string
+	for _, o := range displayOrders {
+		if o.Status == "delivered" {
+			deliveredIDs = append(deliveredIDs, o.ID)
+		}
+	}
+	if len(deliveredIDs) > 0 {
+		items, err := h.reviewService.ListReviewableItems(ctx, user.ID.String(), deliveredIDs)
+		if err != nil {
+			// Reviews are optional here; still show the order history
+			h.logger.Error("failed to list reviewable items", "error", err)
+		}
+		itemsByOrder := make(map[string][]ReviewItemDisplay)
+		for _, item := range items {
+			orderID := item.OrderID.String()
+			itemsByOrder[orderID] = append(itemsByOrder[orderID], ReviewItemDisplay{
+				ProductID:    item.ProductID.String(),
+				ProductName:  item.ProductName,
+				ProductSlug:  item.ProductSlug,
+				ReviewStatus: item.ReviewStatus.String,
+				Rating:       item.ReviewRating.Int32,
+			})
+		}
+		for i := range displayOrders {
+			displayOrders[i].ReviewItems = itemsByOrder[displayOrders[i].ID]
+		}
+	}
+
 	// Calculate pagination
 	totalPages := int(totalCount) / int(limit)
 	if int(totalCount)%int(limit) > 0 {
@@ -219,6 +296,7 @@ func (h *AccountHandler) OrderList(w http.ResponseWriter, r *http.Request) {
 	data["TotalPages"] = totalPages
 	data["TotalCount"] = totalCount
 	data["StatusFilter"] = statusFilter
+	data["Success"] = r.URL.Query().Get("success")
 	data["HasPrevPage"] = page > 1
 	data["HasNextPage"] = page < totalPages
 	data["PrevPage"] = page - 1
@@ -247,11 +325,35 @@ func getOrderStatusDisplay(status, fulfillmentStatus string) (label, color strin
 		return "Cancelled", "red"
 	case "refunded":
 		return "Refunded", "neutral"
+	case "partially_refunded":
+		return "Partially Refunded", "neutral"
 	default:
 		return status, "neutral"
 	}
 }
 
+// getShipmentStatusLabel returns the display label for a shipment tracking status
+func getShipmentStatusLabel(status string) string {
+	switch status {
+	case "label_created":
+		return "Label created"
+	case "in_transit":
+		return "In transit"
+	case "out_for_delivery":
+		return "Out for delivery"
+	case "delivered":
+		return "Delivered"
+	case "failed":
+		return "Delivery problem"
+	case "returned":
+		return "Returned to sender"
+	case "cancelled":
+		return "Cancelled"
+	default:
+		return status
+	}
+}
+
 // =============================================================================
 // Address Management
 // =============================================================================
@@ -278,6 +380,7 @@ type AddressDisplay struct {
 // AddressList handles GET /account/addresses
 func (h *AccountHandler) AddressList(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -286,7 +389,7 @@ func (h *AccountHandler) AddressList(w http.ResponseWriter, r *http.Request) {
 	}
 
 	addresses, err := h.repo.ListAddressesForUser(ctx, repository.ListAddressesForUserParams{
-		TenantID: h.tenantID,
+		TenantID: tenantID,
 		UserID:   user.ID,
 	})
 	if err != nil {
@@ -308,6 +411,7 @@ func (h *AccountHandler) AddressList(w http.ResponseWriter, r *http.Request) {
 // AddressCreate handles POST /account/addresses
 func (h *AccountHandler) AddressCreate(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -339,7 +443,7 @@ func (h *AccountHandler) AddressCreate(w http.ResponseWriter, r *http.Request) {
 
 	// Create the address
 	address, err := h.repo.CreateAddress(ctx, repository.CreateAddressParams{
-		TenantID:     h.tenantID,
+		TenantID:     tenantID,
 		FullName:     pgtype.Text{String: fullName, Valid: true},
 		Company:      pgtype.Text{String: strings.TrimSpace(r.FormValue("company")), Valid: r.FormValue("company") != ""},
 		AddressLine1: addressLine1,
@@ -359,7 +463,7 @@ func (h *AccountHandler) AddressCreate(w http.ResponseWriter, r *http.Request) {
 
 	// Check if this is the first address (make it default)
 	count, _ := h.repo.CountAddressesForUser(ctx, repository.CountAddressesForUserParams{
-		TenantID: h.tenantID,
+		TenantID: tenantID,
 		UserID:   user.ID,
 	})
 	isFirstAddress := count.AddressCount == 0
@@ -371,7 +475,7 @@ func (h *AccountHandler) AddressCreate(w http.ResponseWriter, r *http.Request) {
 	// If setting as default, clear other defaults first
 	if isDefaultShipping {
 		_ = h.repo.SetDefaultShippingAddress(ctx, repository.SetDefaultShippingAddressParams{
-			TenantID:  h.tenantID,
+			TenantID:  tenantID,
 			UserID:    user.ID,
 			AddressID: address.ID,
 		})
@@ -384,7 +488,7 @@ func (h *AccountHandler) AddressCreate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	_, err = h.repo.CreateCustomerAddress(ctx, repository.CreateCustomerAddressParams{
-		TenantID:          h.tenantID,
+		TenantID:          tenantID,
 		UserID:            user.ID,
 		AddressID:         address.ID,
 		IsDefaultShipping: isDefaultShipping,
@@ -409,6 +513,7 @@ func (h *AccountHandler) AddressCreate(w http.ResponseWriter, r *http.Request) {
 // AddressUpdate handles POST /account/addresses/{id}
 func (h *AccountHandler) AddressUpdate(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -431,7 +536,7 @@ func (h *AccountHandler) AddressUpdate(w http.ResponseWriter, r *http.Request) {
 	// Verify user owns this address
 	_, err := h.repo.GetAddressByIDForUser(ctx, repository.GetAddressByIDForUserParams{
 		ID:       addressUUID,
-		TenantID: h.tenantID,
+		TenantID: tenantID,
 		UserID:   user.ID,
 	})
 	if err != nil {
@@ -463,7 +568,7 @@ func (h *AccountHandler) AddressUpdate(w http.ResponseWriter, r *http.Request) {
 
 	// Update the address
 	_, err = h.repo.UpdateAddress(ctx, repository.UpdateAddressParams{
-		TenantID:     h.tenantID,
+		TenantID:     tenantID,
 		ID:           addressUUID,
 		FullName:     pgtype.Text{String: fullName, Valid: true},
 		Company:      pgtype.Text{String: strings.TrimSpace(r.FormValue("company")), Valid: r.FormValue("company") != ""},
@@ -484,7 +589,7 @@ func (h *AccountHandler) AddressUpdate(w http.ResponseWriter, r *http.Request) {
 	// Handle default shipping update
 	if r.FormValue("is_default_shipping") == "on" {
 		_ = h.repo.SetDefaultShippingAddress(ctx, repository.SetDefaultShippingAddressParams{
-			TenantID:  h.tenantID,
+			TenantID:  tenantID,
 			UserID:    user.ID,
 			AddressID: addressUUID,
 		})
@@ -502,6 +607,7 @@ func (h *AccountHandler) AddressUpdate(w http.ResponseWriter, r *http.Request) {
 // AddressDelete handles POST /account/addresses/{id}/delete
 func (h *AccountHandler) AddressDelete(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -523,7 +629,7 @@ func (h *AccountHandler) AddressDelete(w http.ResponseWriter, r *http.Request) {
 
 	// Delete the customer-address link (address itself remains for order history)
 	err := h.repo.DeleteCustomerAddress(ctx, repository.DeleteCustomerAddressParams{
-		TenantID:  h.tenantID,
+		TenantID:  tenantID,
 		UserID:    user.ID,
 		AddressID: addressUUID,
 	})
@@ -544,6 +650,7 @@ func (h *AccountHandler) AddressDelete(w http.ResponseWriter, r *http.Request) {
 // AddressSetDefault handles POST /account/addresses/{id}/default
 func (h *AccountHandler) AddressSetDefault(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -566,7 +673,7 @@ func (h *AccountHandler) AddressSetDefault(w http.ResponseWriter, r *http.Reques
 	// Verify user owns this address
 	_, err := h.repo.GetAddressByIDForUser(ctx, repository.GetAddressByIDForUserParams{
 		ID:       addressUUID,
-		TenantID: h.tenantID,
+		TenantID: tenantID,
 		UserID:   user.ID,
 	})
 	if err != nil {
@@ -576,7 +683,7 @@ func (h *AccountHandler) AddressSetDefault(w http.ResponseWriter, r *http.Reques
 
 	// Set as default shipping address
 	err = h.repo.SetDefaultShippingAddress(ctx, repository.SetDefaultShippingAddressParams{
-		TenantID:  h.tenantID,
+		TenantID:  tenantID,
 		UserID:    user.ID,
 		AddressID: addressUUID,
 	})
@@ -597,6 +704,7 @@ func (h *AccountHandler) AddressSetDefault(w http.ResponseWriter, r *http.Reques
 // AddressGetJSON handles GET /account/addresses/{id}/json (for modal editing)
 func (h *AccountHandler) AddressGetJSON(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -618,7 +726,7 @@ func (h *AccountHandler) AddressGetJSON(w http.ResponseWriter, r *http.Request)
 
 	address, err := h.repo.GetAddressByIDForUser(ctx, repository.GetAddressByIDForUserParams{
 		ID:       addressUUID,
-		TenantID: h.tenantID,
+		TenantID: tenantID,
 		UserID:   user.ID,
 	})
 	if err != nil {
@@ -714,6 +822,7 @@ func (h *AccountHandler) renderAddressError(w http.ResponseWriter, r *http.Reque
 // PaymentMethodList handles GET /account/payment-methods
 func (h *AccountHandler) PaymentMethodList(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -722,7 +831,7 @@ func (h *AccountHandler) PaymentMethodList(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Get payment methods
-	paymentMethods, err := h.accountService.ListPaymentMethods(ctx, h.tenantID, user.ID)
+	paymentMethods, err := h.accountService.ListPaymentMethods(ctx, tenantID, user.ID)
 	if err != nil {
 		handler.InternalErrorResponse(w, r, err)
 		return
@@ -737,6 +846,7 @@ func (h *AccountHandler) PaymentMethodList(w http.ResponseWriter, r *http.Reques
 // PaymentMethodSetDefault handles POST /account/payment-methods/{id}/default
 func (h *AccountHandler) PaymentMethodSetDefault(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -760,7 +870,7 @@ func (h *AccountHandler) PaymentMethodSetDefault(w http.ResponseWriter, r *http.
 	// Verify ownership and get billing_customer_id
 	pm, err := h.repo.GetPaymentMethodByID(ctx, repository.GetPaymentMethodByIDParams{
 		ID:       paymentMethodID,
-		TenantID: h.tenantID,
+		TenantID: tenantID,
 		UserID:   user.ID,
 	})
 	if err != nil {
@@ -791,6 +901,7 @@ func (h *AccountHandler) PaymentMethodSetDefault(w http.ResponseWriter, r *http.
 // PaymentMethodPortal handles GET /account/payment-methods/portal
 func (h *AccountHandler) PaymentMethodPortal(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -800,7 +911,7 @@ func (h *AccountHandler) PaymentMethodPortal(w http.ResponseWriter, r *http.Requ
 
 	// Create portal session with return URL to payment methods page
 	portalURL, err := h.subscriptionService.CreateCustomerPortalSession(ctx, domain.PortalSessionParams{
-		TenantID:  h.tenantID,
+		TenantID:  tenantID,
 		UserID:    user.ID,
 		ReturnURL: "/account/payment-methods",
 	})
@@ -838,6 +949,7 @@ func (h *AccountHandler) ProfileShow(w http.ResponseWriter, r *http.Request) {
 // ProfileUpdate handles POST /account/settings/profile
 func (h *AccountHandler) ProfileUpdate(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -872,7 +984,7 @@ func (h *AccountHandler) ProfileUpdate(w http.ResponseWriter, r *http.Request) {
 	// Update profile (tenant-scoped for security)
 	err := h.repo.UpdateUserProfile(ctx, repository.UpdateUserProfileParams{
 		ID:        user.ID,
-		TenantID:  h.tenantID,
+		TenantID:  tenantID,
 		FirstName: pgtype.Text{String: firstName, Valid: firstName != ""},
 		LastName:  pgtype.Text{String: lastName, Valid: lastName != ""},
 		Phone:     pgtype.Text{String: phone, Valid: phone != ""},
@@ -898,6 +1010,7 @@ func (h *AccountHandler) ProfileUpdate(w http.ResponseWriter, r *http.Request) {
 // PasswordChange handles POST /account/settings/password
 func (h *AccountHandler) PasswordChange(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -949,7 +1062,7 @@ func (h *AccountHandler) PasswordChange(w http.ResponseWriter, r *http.Request)
 	// Update password (tenant-scoped for security)
 	err = h.repo.UpdateUserPassword(ctx, repository.UpdateUserPasswordParams{
 		ID:           user.ID,
-		TenantID:     h.tenantID,
+		TenantID:     tenantID,
 		PasswordHash: pgtype.Text{String: newHash, Valid: true},
 	})
 	if err != nil {
@@ -33,7 +33,6 @@ type AuthHandler struct {
 	passwordResetService service.PasswordResetService
 	repo                 repository.Querier
 	renderer             *handler.Renderer
-	tenantID             uuid.UUID
 	cookieConfig         *cookie.Config
 }
 
@@ -44,7 +43,6 @@ func NewAuthHandler(
 	passwordResetService service.PasswordResetService,
 	repo repository.Querier,
 	renderer *handler.Renderer,
-	tenantID uuid.UUID,
 	cookieConfig *cookie.Config,
 ) *AuthHandler {
 	return &AuthHandler{
@@ -53,7 +51,6 @@ func NewAuthHandler(
 		passwordResetService: passwordResetService,
 		repo:                 repo,
 		renderer:             renderer,
-		tenantID:             tenantID,
 		cookieConfig:         cookieConfig,
 	}
 }
@@ -124,7 +121,7 @@ func (h *AuthHandler) HandleSignup(w http.ResponseWriter, r *http.Request) {
 	ipAddress := middleware.GetClientIP(r)
 	userAgent := r.UserAgent()
 
-	err = h.verificationService.SendVerificationEmail(ctx, h.tenantID, userID, email, firstName, ipAddress, userAgent)
+	err = h.verificationService.SendVerificationEmail(ctx, getTenantUUID(ctx), userID, email, firstName, ipAddress, userAgent)
 	if err != nil {
 		logger.Error("signup: failed to send verification email", "error", err)
 	}
@@ -281,7 +278,7 @@ func (h *AuthHandler) HandleForgotPassword(w http.ResponseWriter, r *http.Reques
 	userAgent := r.UserAgent()
 
 	// Always returns nil to prevent enumeration
-	_, _ = h.passwordResetService.RequestPasswordReset(ctx, h.tenantID, email, ipAddress, userAgent)
+	_, _ = h.passwordResetService.RequestPasswordReset(ctx, getTenantUUID(ctx), email, ipAddress, userAgent)
 
 	http.Redirect(w, r, "/forgot-password?success=true", http.StatusSeeOther)
 }
@@ -302,7 +299,7 @@ func (h *AuthHandler) ShowResetPasswordForm(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	_, err := h.passwordResetService.ValidateResetToken(ctx, h.tenantID, token)
+	_, err := h.passwordResetService.ValidateResetToken(ctx, getTenantUUID(ctx), token)
 	if err != nil {
 		data["Error"] = "This password reset link is invalid or has expired"
 		h.renderer.RenderHTTP(w, "reset_password", data)
@@ -349,7 +346,7 @@ func (h *AuthHandler) HandleResetPassword(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err := h.passwordResetService.ResetPassword(ctx, h.tenantID, token, newPassword)
+	err := h.passwordResetService.ResetPassword(ctx, getTenantUUID(ctx), token, newPassword)
 	if err != nil {
 		var errMsg string
 		if domain.ErrorCode(err) == domain.EINVALID {
@@ -383,7 +380,7 @@ func (h *AuthHandler) HandleVerifyEmail(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err := h.verificationService.VerifyEmail(ctx, h.tenantID, token)
+	err := h.verificationService.VerifyEmail(ctx, getTenantUUID(ctx), token)
 	if err != nil {
 		logger.Warn("verify email: verification failed", "error", err)
 		data := BaseTemplateData(r)
@@ -439,11 +436,8 @@ func (h *AuthHandler) HandleResendVerification(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	var tenantUUID pgtype.UUID
-	_ = tenantUUID.Scan(h.tenantID.String())
-
 	user, err := h.repo.GetUserByEmail(ctx, repository.GetUserByEmailParams{
-		TenantID: tenantUUID,
+		TenantID: getTenantID(ctx),
 		Email:    email,
 	})
 	if err != nil {
@@ -477,7 +471,7 @@ func (h *AuthHandler) HandleResendVerification(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	err = h.verificationService.SendVerificationEmail(ctx, h.tenantID, userID, email, user.FirstName.String, ipAddress, userAgent)
+	err = h.verificationService.SendVerificationEmail(ctx, getTenantUUID(ctx), userID, email, user.FirstName.String, ipAddress, userAgent)
 	if err != nil {
 		if domain.ErrorCode(err) == domain.ERATELIMIT {
 			logger.Warn("resend verification: rate limit exceeded", "email", email)
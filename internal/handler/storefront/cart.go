@@ -7,24 +7,25 @@ import (
 	"github.com/dukerupert/hiri/internal/cookie"
 	"github.com/dukerupert/hiri/internal/domain"
 	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
 	"github.com/dukerupert/hiri/internal/telemetry"
 )
 
 // CartHandler handles all cart-related storefront routes
 type CartHandler struct {
-	cartService  domain.CartService
-	renderer     *handler.Renderer
-	cookieConfig *cookie.Config
-	tenantID     string
+	cartService       domain.CartService
+	orderRulesService domain.OrderRulesService
+	renderer          *handler.Renderer
+	cookieConfig      *cookie.Config
 }
 
 // NewCartHandler creates a new cart handler
-func NewCartHandler(cartService domain.CartService, renderer *handler.Renderer, cookieConfig *cookie.Config, tenantID string) *CartHandler {
+func NewCartHandler(cartService domain.CartService, orderRulesService domain.OrderRulesService, renderer *handler.Renderer, cookieConfig *cookie.Config) *CartHandler {
 	return &CartHandler{
-		cartService:  cartService,
-		renderer:     renderer,
-		cookieConfig: cookieConfig,
-		tenantID:     tenantID,
+		cartService:       cartService,
+		orderRulesService: orderRulesService,
+		renderer:          renderer,
+		cookieConfig:      cookieConfig,
 	}
 }
 
@@ -34,6 +35,7 @@ func (h *CartHandler) View(w http.ResponseWriter, r *http.Request) {
 
 	sessionID := GetSessionIDFromCookie(r)
 	var summary *domain.CartSummary
+	var orderRules *domain.OrderRulesCheck
 
 	if sessionID != "" {
 		cart, err := h.cartService.GetCart(ctx, sessionID)
@@ -43,17 +45,29 @@ func (h *CartHandler) View(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if cart != nil {
+			if err := linkCartToUser(r, h.cartService, cart); err != nil {
+				handler.InternalErrorResponse(w, r, err)
+				return
+			}
+
 			cartSummary, err := h.cartService.GetCartSummary(ctx, cart.ID.String())
 			if err != nil {
 				handler.InternalErrorResponse(w, r, err)
 				return
 			}
 			summary = cartSummary
+
+			orderRules, err = checkOrderRules(r, h.orderRulesService, cart.ID.String())
+			if err != nil {
+				handler.InternalErrorResponse(w, r, err)
+				return
+			}
 		}
 	}
 
 	data := BaseTemplateData(r)
 	data["Summary"] = summary
+	data["OrderRules"] = orderRules
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	h.renderer.RenderHTTP(w, "cart", data)
@@ -93,6 +107,11 @@ func (h *CartHandler) Add(w http.ResponseWriter, r *http.Request) {
 		SetSessionCookie(w, newSessionID, h.cookieConfig)
 	}
 
+	if err := linkCartToUser(r, h.cartService, cart); err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
 	_, err = h.cartService.AddItem(ctx, cart.ID.String(), skuID, quantity)
 	if err != nil {
 		errCode := domain.ErrorCode(err)
@@ -110,8 +129,9 @@ func (h *CartHandler) Add(w http.ResponseWriter, r *http.Request) {
 
 	// Track add to cart
 	if telemetry.Business != nil {
-		telemetry.Business.CartUpdated.WithLabelValues(h.tenantID, "add").Inc()
-		telemetry.Business.CartItemsAdd.WithLabelValues(h.tenantID).Add(float64(quantity))
+		tenantID := getTenantID(ctx).String()
+		telemetry.Business.CartUpdated.WithLabelValues(tenantID, "add").Inc()
+		telemetry.Business.CartItemsAdd.WithLabelValues(tenantID).Add(float64(quantity))
 	}
 
 	tmpl, err := h.renderer.Execute("cart_added")
@@ -165,17 +185,26 @@ func (h *CartHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	// Track cart update
 	if telemetry.Business != nil {
-		telemetry.Business.CartUpdated.WithLabelValues(h.tenantID, "update_quantity").Inc()
+		telemetry.Business.CartUpdated.WithLabelValues(getTenantID(ctx).String(), "update_quantity").Inc()
+	}
+
+	orderRules, err := checkOrderRules(r, h.orderRulesService, cart.ID.String())
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
 	}
 
-	tmpl, err := h.renderer.Execute("cart_summary")
+	// The summary is defined alongside the cart page
+	tmpl, err := h.renderer.Execute("cart")
 	if err != nil {
 		handler.InternalErrorResponse(w, r, err)
 		return
 	}
 
 	data := map[string]interface{}{
-		"Summary": summary,
+		"Summary":    summary,
+		"OrderRules": orderRules,
+		"User":       middleware.GetUserFromContext(r.Context()),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -216,7 +245,7 @@ func (h *CartHandler) Remove(w http.ResponseWriter, r *http.Request) {
 
 	// Track cart item removal
 	if telemetry.Business != nil {
-		telemetry.Business.CartUpdated.WithLabelValues(h.tenantID, "remove").Inc()
+		telemetry.Business.CartUpdated.WithLabelValues(getTenantID(ctx).String(), "remove").Inc()
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -237,3 +266,23 @@ func (h *CartHandler) renderCartError(w http.ResponseWriter, r *http.Request, me
 		handler.InternalErrorResponse(w, r, err)
 	}
 }
+
+// checkOrderRules checks the cart of a signed-in wholesale customer against
+// their minimum order and quantity rules. Returns nil for everyone else.
+func checkOrderRules(r *http.Request, orderRulesService domain.OrderRulesService, cartID string) (*domain.OrderRulesCheck, error) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil || user.AccountType != "wholesale" {
+		return nil, nil
+	}
+	return orderRulesService.CheckCart(r.Context(), user.ID.String(), cartID)
+}
+
+// linkCartToUser links the cart to the signed-in customer, if it isn't
+// already, so it's priced from their price list.
+func linkCartToUser(r *http.Request, cartService domain.CartService, cart *domain.Cart) error {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil || cart.UserID == user.ID {
+		return nil
+	}
+	return cartService.SetUser(r.Context(), cart.ID.String(), user.ID.String())
+}
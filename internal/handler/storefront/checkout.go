@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/dukerupert/hiri/internal/address"
@@ -24,9 +26,10 @@ type CheckoutHandler struct {
 	cartService          domain.CartService
 	checkoutService      service.CheckoutService
 	orderService         domain.OrderService
+	onAccountService     domain.OnAccountService
+	orderRulesService    domain.OrderRulesService
 	repo                 repository.Querier
 	stripePublishableKey string
-	tenantID             pgtype.UUID
 }
 
 // NewCheckoutHandler creates a new checkout handler
@@ -35,27 +38,26 @@ func NewCheckoutHandler(
 	cartService domain.CartService,
 	checkoutService service.CheckoutService,
 	orderService domain.OrderService,
+	onAccountService domain.OnAccountService,
+	orderRulesService domain.OrderRulesService,
 	repo repository.Querier,
 	stripePublishableKey string,
-	tenantID string,
 ) *CheckoutHandler {
-	var tenantUUID pgtype.UUID
-	_ = tenantUUID.Scan(tenantID) // Error is silently ignored; tenant ID is validated elsewhere
-
 	return &CheckoutHandler{
 		renderer:             renderer,
 		cartService:          cartService,
 		checkoutService:      checkoutService,
 		orderService:         orderService,
+		onAccountService:     onAccountService,
+		orderRulesService:    orderRulesService,
 		repo:                 repo,
 		stripePublishableKey: stripePublishableKey,
-		tenantID:             tenantUUID,
 	}
 }
 
 // Page handles GET /checkout
 func (h *CheckoutHandler) Page(w http.ResponseWriter, r *http.Request) {
-	tenantID := h.tenantID.String()
+	tenantID := getTenantID(r.Context())
 
 	sessionID := GetSessionIDFromCookie(r)
 	if sessionID == "" {
@@ -69,6 +71,11 @@ func (h *CheckoutHandler) Page(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := linkCartToUser(r, h.cartService, cart); err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
 	cartSummary, err := h.cartService.GetCartSummary(r.Context(), cart.ID.String())
 	if err != nil {
 		handler.InternalErrorResponse(w, r, err)
@@ -80,10 +87,22 @@ func (h *CheckoutHandler) Page(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Wholesale carts that miss the order minimums go back to the cart,
+	// which explains what's missing
+	orderRules, err := checkOrderRules(r, h.orderRulesService, cart.ID.String())
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+	if orderRules != nil && !orderRules.OK() {
+		http.Redirect(w, r, "/cart", http.StatusSeeOther)
+		return
+	}
+
 	// Track checkout started
 	if telemetry.Business != nil {
-		telemetry.Business.CheckoutStarted.WithLabelValues(tenantID).Inc()
-		telemetry.Business.CartValue.WithLabelValues(tenantID).Observe(float64(cartSummary.Subtotal))
+		telemetry.Business.CheckoutStarted.WithLabelValues(tenantID.String()).Inc()
+		telemetry.Business.CartValue.WithLabelValues(tenantID.String()).Observe(float64(cartSummary.Subtotal))
 	}
 
 	data := BaseTemplateData(r)
@@ -115,7 +134,7 @@ func (h *CheckoutHandler) Page(w http.ResponseWriter, r *http.Request) {
 
 		// Try to get default shipping address
 		defaultAddr, err := h.repo.GetDefaultShippingAddress(r.Context(), repository.GetDefaultShippingAddressParams{
-			TenantID: h.tenantID,
+			TenantID: tenantID,
 			UserID:   user.ID,
 		})
 		if err == nil {
@@ -130,6 +149,11 @@ func (h *CheckoutHandler) Page(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 		// If no default address found, that's fine - fields will just be empty
+
+		// Wholesale customers with payment terms can skip the card payment
+		if credit, err := h.onAccountService.GetAccountCredit(r.Context(), user.ID.String()); err == nil {
+			data["AccountCredit"] = credit
+		}
 	}
 
 	h.renderer.RenderHTTP(w, "storefront/checkout", data)
@@ -225,6 +249,8 @@ func (h *CheckoutHandler) CalculateTotal(w http.ResponseWriter, r *http.Request)
 		ShippingAddress      address.Address `json:"shipping_address"`
 		BillingAddress       address.Address `json:"billing_address"`
 		SelectedShippingRate shipping.Rate   `json:"selected_shipping_rate"`
+		DiscountCode         string          `json:"discount_code"`
+		CustomerEmail        string          `json:"customer_email"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		logger.Error("Failed to decode calculate total request", "error", err)
@@ -239,6 +265,8 @@ func (h *CheckoutHandler) CalculateTotal(w http.ResponseWriter, r *http.Request)
 		ShippingAddress:      req.ShippingAddress,
 		BillingAddress:       req.BillingAddress,
 		SelectedShippingRate: req.SelectedShippingRate,
+		DiscountCode:         req.DiscountCode,
+		CustomerEmail:        req.CustomerEmail,
 	}
 
 	total, err := h.checkoutService.CalculateOrderTotal(r.Context(), params)
@@ -265,6 +293,7 @@ func (h *CheckoutHandler) CalculateTotal(w http.ResponseWriter, r *http.Request)
 // CreatePaymentIntent handles POST /checkout/create-payment-intent
 func (h *CheckoutHandler) CreatePaymentIntent(w http.ResponseWriter, r *http.Request) {
 	logger := middleware.GetLogger(r.Context())
+	tenantID := getTenantID(r.Context())
 
 	var req struct {
 		CartID          string              `json:"cart_id"`
@@ -282,6 +311,18 @@ func (h *CheckoutHandler) CreatePaymentIntent(w http.ResponseWriter, r *http.Req
 
 	logger.Info("Creating payment intent", "cart_id", req.CartID, "email", req.CustomerEmail)
 
+	orderRules, err := checkOrderRules(r, h.orderRulesService, req.CartID)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+	if orderRules != nil {
+		if err := orderRules.Err(); err != nil {
+			handler.ErrorResponse(w, r, err)
+			return
+		}
+	}
+
 	params := service.PaymentIntentParams{
 		CartID:          req.CartID,
 		OrderTotal:      req.OrderTotal,
@@ -296,7 +337,7 @@ func (h *CheckoutHandler) CreatePaymentIntent(w http.ResponseWriter, r *http.Req
 		logger.Error("Failed to create payment intent", "error", err, "cart_id", req.CartID)
 		// Track payment attempt failure
 		if telemetry.Business != nil {
-			telemetry.Business.PaymentFailed.WithLabelValues(h.tenantID.String(), "one_time", "create_failed").Inc()
+			telemetry.Business.PaymentFailed.WithLabelValues(tenantID.String(), "one_time", "create_failed").Inc()
 		}
 		// Use context-based capture - tenant/user context set by middleware
 		telemetry.CaptureErrorFromContext(r.Context(), err, map[string]interface{}{
@@ -309,7 +350,7 @@ func (h *CheckoutHandler) CreatePaymentIntent(w http.ResponseWriter, r *http.Req
 
 	// Track payment attempt
 	if telemetry.Business != nil {
-		telemetry.Business.PaymentAttempts.WithLabelValues(h.tenantID.String(), "one_time").Inc()
+		telemetry.Business.PaymentAttempts.WithLabelValues(tenantID.String(), "one_time").Inc()
 	}
 
 	logger.Info("Payment intent created", "payment_intent_id", paymentIntent.ID, "amount_cents", paymentIntent.AmountCents)
@@ -330,13 +371,84 @@ func (h *CheckoutHandler) CreatePaymentIntent(w http.ResponseWriter, r *http.Req
 	}
 }
 
+// PlaceOrderOnAccount handles POST /checkout/place-order-on-account
+func (h *CheckoutHandler) PlaceOrderOnAccount(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context())
+
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		handler.ErrorResponse(w, r, domain.ErrOnAccountNotEnabled)
+		return
+	}
+
+	var req struct {
+		ShippingAddress address.Address `json:"shipping_address"`
+		BillingAddress  address.Address `json:"billing_address"`
+		ShippingRate    shipping.Rate   `json:"shipping_rate"`
+		DiscountCode    string          `json:"discount_code"`
+		CustomerNotes   string          `json:"customer_notes"`
+		PONumber        string          `json:"po_number"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("Failed to decode on-account order request", "error", err)
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid request body"))
+		return
+	}
+
+	// The order is billed to the signed-in customer, so the cart comes from
+	// their session rather than the request
+	cart, err := h.cartService.GetCart(r.Context(), GetSessionIDFromCookie(r))
+	if err != nil {
+		handler.ErrorResponse(w, r, domain.ErrCartNotFound)
+		return
+	}
+
+	order, err := h.onAccountService.PlaceOrder(r.Context(), domain.PlaceOnAccountOrderParams{
+		CartID:          cart.ID.String(),
+		UserID:          user.ID.String(),
+		ShippingAddress: req.ShippingAddress,
+		BillingAddress:  req.BillingAddress,
+		ShippingRate:    req.ShippingRate,
+		DiscountCode:    req.DiscountCode,
+		CustomerNotes:   strings.TrimSpace(req.CustomerNotes),
+		PONumber:        strings.TrimSpace(req.PONumber),
+	})
+	if err != nil {
+		logger.Error("Failed to place order on account", "error", err, "cart_id", cart.ID.String())
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	logger.Info("Order placed on account", "order_number", order.Order.OrderNumber, "total_cents", order.Order.TotalCents)
+
+	resp := struct {
+		OrderNumber string `json:"order_number"`
+		RedirectURL string `json:"redirect_url"`
+	}{
+		OrderNumber: order.Order.OrderNumber,
+		RedirectURL: "/order-confirmation?order=" + url.QueryEscape(order.Order.OrderNumber),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logger.Error("Failed to encode on-account order response", "error", err)
+	}
+}
+
 // OrderConfirmation handles GET /order-confirmation
 func (h *CheckoutHandler) OrderConfirmation(w http.ResponseWriter, r *http.Request) {
 	logger := middleware.GetLogger(r.Context())
+	tenantID := getTenantID(r.Context())
 
 	paymentIntentID := r.URL.Query().Get("payment_intent")
 	redirectStatus := r.URL.Query().Get("redirect_status")
 
+	// Orders placed on account have no payment intent
+	if orderNumber := r.URL.Query().Get("order"); orderNumber != "" && paymentIntentID == "" {
+		h.onAccountConfirmation(w, r, orderNumber)
+		return
+	}
+
 	if redirectStatus != "succeeded" {
 		data := BaseTemplateData(r)
 		data["PaymentIntentID"] = paymentIntentID
@@ -346,7 +458,7 @@ func (h *CheckoutHandler) OrderConfirmation(w http.ResponseWriter, r *http.Reque
 	}
 
 	order, err := h.repo.GetOrderByPaymentIntentID(r.Context(), repository.GetOrderByPaymentIntentIDParams{
-		TenantID:          h.tenantID,
+		TenantID:          tenantID,
 		ProviderPaymentID: paymentIntentID,
 	})
 	if err != nil {
@@ -363,19 +475,50 @@ func (h *CheckoutHandler) OrderConfirmation(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	h.renderOrderConfirmation(w, r, order.ID, BaseTemplateData(r))
+}
+
+// onAccountConfirmation shows the confirmation for an order placed on
+// account by the signed-in customer.
+func (h *CheckoutHandler) onAccountConfirmation(w http.ResponseWriter, r *http.Request, orderNumber string) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		handler.NotFoundResponse(w, r)
+		return
+	}
+
+	order, err := h.repo.GetOrderByNumber(r.Context(), repository.GetOrderByNumberParams{
+		TenantID:    getTenantID(r.Context()),
+		OrderNumber: orderNumber,
+	})
+	if err != nil || order.UserID != user.ID {
+		handler.NotFoundResponse(w, r)
+		return
+	}
+
+	data := BaseTemplateData(r)
+	data["OnAccount"] = true
+	h.renderOrderConfirmation(w, r, order.ID, data)
+}
+
+// renderOrderConfirmation renders a placed order and clears the session cart.
+func (h *CheckoutHandler) renderOrderConfirmation(w http.ResponseWriter, r *http.Request, orderID pgtype.UUID, data map[string]interface{}) {
+	logger := middleware.GetLogger(r.Context())
+	tenantID := getTenantID(r.Context())
+
 	orderDetails, err := h.repo.GetOrderWithDetails(r.Context(), repository.GetOrderWithDetailsParams{
-		TenantID: h.tenantID,
-		ID:       order.ID,
+		TenantID: tenantID,
+		ID:       orderID,
 	})
 	if err != nil {
-		logger.Error("Failed to get order details", "error", err, "order_id", order.ID)
+		logger.Error("Failed to get order details", "error", err, "order_id", orderID)
 		handler.InternalErrorResponse(w, r, err)
 		return
 	}
 
-	orderItems, err := h.repo.GetOrderItems(r.Context(), order.ID)
+	orderItems, err := h.repo.GetOrderItems(r.Context(), orderID)
 	if err != nil {
-		logger.Error("Failed to get order items", "error", err, "order_id", order.ID)
+		logger.Error("Failed to get order items", "error", err, "order_id", orderID)
 		handler.InternalErrorResponse(w, r, err)
 		return
 	}
@@ -387,7 +530,7 @@ func (h *CheckoutHandler) OrderConfirmation(w http.ResponseWriter, r *http.Reque
 			if err := h.cartService.ClearCart(r.Context(), cart.ID.String()); err != nil {
 				logger.Error("Failed to clear cart after successful payment", "error", err, "cart_id", cart.ID.String())
 			} else {
-				logger.Debug("Cart cleared after successful payment", "cart_id", cart.ID.String(), "payment_intent", paymentIntentID)
+				logger.Debug("Cart cleared after order", "cart_id", cart.ID.String(), "order_id", orderID)
 			}
 		}
 	}
@@ -417,6 +560,7 @@ func (h *CheckoutHandler) OrderConfirmation(w http.ResponseWriter, r *http.Reque
 		SubtotalCents                int32
 		ShippingCents                int32
 		TaxCents                     int32
+		DiscountCents                int32
 		TotalCents                   int32
 		BillingAddressSameAsShipping bool
 	}
@@ -435,7 +579,6 @@ func (h *CheckoutHandler) OrderConfirmation(w http.ResponseWriter, r *http.Reque
 
 	billingAddressSameAsShipping := orderDetails.ShippingAddressLine1.String == orderDetails.BillingAddressLine1.String
 
-	data := BaseTemplateData(r)
 	data["Status"] = "succeeded"
 	data["Order"] = OrderData{
 		OrderNumber:                  orderDetails.OrderNumber,
@@ -444,6 +587,7 @@ func (h *CheckoutHandler) OrderConfirmation(w http.ResponseWriter, r *http.Reque
 		SubtotalCents:                orderDetails.SubtotalCents,
 		ShippingCents:                orderDetails.ShippingCents,
 		TaxCents:                     orderDetails.TaxCents,
+		DiscountCents:                orderDetails.DiscountCents,
 		TotalCents:                   orderDetails.TotalCents,
 		BillingAddressSameAsShipping: billingAddressSameAsShipping,
 	}
@@ -0,0 +1,56 @@
+package storefront
+
+import (
+	"net/http"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+)
+
+// CollectionHandler handles category landing pages
+type CollectionHandler struct {
+	categoryService domain.CategoryService
+	productService  domain.ProductService
+	renderer        *handler.Renderer
+}
+
+// NewCollectionHandler creates a new collection handler
+func NewCollectionHandler(
+	categoryService domain.CategoryService,
+	productService domain.ProductService,
+	renderer *handler.Renderer,
+) *CollectionHandler {
+	return &CollectionHandler{
+		categoryService: categoryService,
+		productService:  productService,
+		renderer:        renderer,
+	}
+}
+
+// Show handles GET /collections/{slug} - shows a category with the products
+// in it and its subcategories
+func (h *CollectionHandler) Show(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	slug := r.PathValue("slug")
+
+	collection, err := h.categoryService.GetCollection(ctx, slug)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	products, err := h.productService.ListProductsFiltered(ctx, domain.ProductFilter{
+		CategorySlug: &collection.Category.Slug,
+	})
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	data := BaseTemplateData(r)
+	data["Collection"] = collection
+	data["Category"] = collection.Category
+	data["Products"] = newProductDisplays(products)
+
+	h.renderer.RenderHTTP(w, "storefront/collection", data)
+}
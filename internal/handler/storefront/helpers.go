@@ -25,5 +25,10 @@ func BaseTemplateData(r *http.Request) map[string]interface{} {
 		data["CSRFToken"] = csrfToken
 	}
 
+	// Add tenant branding for the layout
+	if branding := middleware.GetBrandingFromContext(r.Context()); branding != nil {
+		data["Branding"] = branding
+	}
+
 	return data
 }
@@ -1,7 +1,6 @@
 package storefront
 
 import (
-	"fmt"
 	"html/template"
 	"net/http"
 	"time"
@@ -9,27 +8,19 @@ import (
 	"github.com/dukerupert/hiri/internal/domain"
 	"github.com/dukerupert/hiri/internal/handler"
 	"github.com/dukerupert/hiri/internal/middleware"
-	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // PagesHandler handles static content pages (legal, about, contact, etc.)
 type PagesHandler struct {
 	pageService domain.PageService
 	renderer    *handler.Renderer
-	tenantID    pgtype.UUID
 }
 
 // NewPagesHandler creates a new pages handler
-func NewPagesHandler(pageService domain.PageService, renderer *handler.Renderer, tenantID string) *PagesHandler {
-	var tenantUUID pgtype.UUID
-	if err := tenantUUID.Scan(tenantID); err != nil {
-		panic(fmt.Sprintf("invalid tenant ID: %v", err))
-	}
-
+func NewPagesHandler(pageService domain.PageService, renderer *handler.Renderer) *PagesHandler {
 	return &PagesHandler{
 		pageService: pageService,
 		renderer:    renderer,
-		tenantID:    tenantUUID,
 	}
 }
 
@@ -48,9 +39,10 @@ type PageData struct {
 // renderPage is a helper that fetches and renders a page by slug
 func (h *PagesHandler) renderPage(w http.ResponseWriter, r *http.Request, slug string) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	page, err := h.pageService.GetPublishedPage(ctx, domain.GetPageParams{
-		TenantID: h.tenantID,
+		TenantID: tenantID,
 		Slug:     slug,
 	})
 
@@ -3,6 +3,7 @@ package storefront
 import (
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
 	"strings"
 
@@ -20,31 +21,29 @@ import (
 // - Subscription product selection (public)
 type ProductHandler struct {
 	productService domain.ProductService
+	reviewService  domain.ReviewService
 	repo           repository.Querier
 	renderer       *handler.Renderer
-	tenantID       pgtype.UUID
 }
 
 // NewProductHandler creates a new consolidated product handler
 func NewProductHandler(
 	productService domain.ProductService,
+	reviewService domain.ReviewService,
 	repo repository.Querier,
 	renderer *handler.Renderer,
-	tenantID string,
 ) *ProductHandler {
-	var tenantUUID pgtype.UUID
-	if err := tenantUUID.Scan(tenantID); err != nil {
-		panic(fmt.Sprintf("invalid tenant ID: %v", err))
-	}
-
 	return &ProductHandler{
 		productService: productService,
+		reviewService:  reviewService,
 		repo:           repo,
 		renderer:       renderer,
-		tenantID:       tenantUUID,
 	}
 }
 
+// productReviewLimit is how many reviews the product page shows.
+const productReviewLimit = 20
+
 // =============================================================================
 // Product List
 // =============================================================================
@@ -65,97 +64,90 @@ type ProductDisplay struct {
 	BasePrice        pgtype.Int4
 }
 
-// convertToInt4 converts an interface{} (from subquery result) to pgtype.Int4
-func convertToInt4(v interface{}) pgtype.Int4 {
-	if v == nil {
-		return pgtype.Int4{Valid: false}
+// newProductDisplays converts product list items for template rendering
+func newProductDisplays(items []domain.ProductListItem) []ProductDisplay {
+	products := make([]ProductDisplay, len(items))
+	for i, p := range items {
+		products[i] = ProductDisplay{
+			ID:               p.ID,
+			TenantID:         p.TenantID,
+			Name:             p.Name,
+			Slug:             p.Slug,
+			ShortDescription: p.ShortDescription,
+			Origin:           p.Origin,
+			RoastLevel:       p.RoastLevel,
+			TastingNotes:     p.TastingNotes,
+			SortOrder:        p.SortOrder,
+			ImageURL:         p.PrimaryImageURL,
+			ImageAlt:         p.PrimaryImageAlt,
+			BasePrice:        p.MinPriceCents,
+		}
 	}
-	switch val := v.(type) {
-	case int32:
-		return pgtype.Int4{Int32: val, Valid: true}
-	case int64:
-		return pgtype.Int4{Int32: int32(val), Valid: true}
-	case int:
-		return pgtype.Int4{Int32: int32(val), Valid: true}
-	default:
-		return pgtype.Int4{Valid: false}
+	return products
+}
+
+// optionalFilter returns nil for an empty filter value
+func optionalFilter(value string) *string {
+	if value == "" {
+		return nil
 	}
+	return &value
 }
 
 // List handles GET /products - shows product listing with filters
 func (h *ProductHandler) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	tenantID := h.tenantID.String()
+	tenantID := getTenantID(ctx)
 
 	// Parse filter params
 	roastLevel := r.URL.Query().Get("roast")
 	origin := r.URL.Query().Get("origin")
 	tastingNote := r.URL.Query().Get("note")
+	category := r.URL.Query().Get("category")
+	tag := r.URL.Query().Get("tag")
 
 	// Track product searches/filters
 	if telemetry.Business != nil {
 		filterType := "none"
-		if roastLevel != "" {
+		if category != "" {
+			filterType = "category"
+		} else if roastLevel != "" {
 			filterType = "roast"
 		} else if origin != "" {
 			filterType = "origin"
 		} else if tastingNote != "" {
 			filterType = "note"
+		} else if tag != "" {
+			filterType = "tag"
 		}
-		telemetry.Business.ProductSearches.WithLabelValues(tenantID, filterType).Inc()
+		telemetry.Business.ProductSearches.WithLabelValues(tenantID.String(), filterType).Inc()
 	}
 
 	// Get filter options for the UI
-	filterOptions, err := h.repo.GetProductFilterOptions(ctx, h.tenantID)
+	filterOptions, err := h.productService.GetFilterOptions(ctx)
 	if err != nil {
-		filterOptions = repository.GetProductFilterOptionsRow{}
+		slog.Error("failed to get product filter options", "error", err)
+		filterOptions = &domain.ProductFilterOptions{}
 	}
 
 	var products []ProductDisplay
 
 	// Check if any filters are applied
-	if roastLevel != "" || origin != "" || tastingNote != "" {
-		// Use filtered query
-		var roastLevelParam, originParam, tastingNoteParam pgtype.Text
-		if roastLevel != "" {
-			roastLevelParam = pgtype.Text{String: roastLevel, Valid: true}
-		}
-		if origin != "" {
-			originParam = pgtype.Text{String: origin, Valid: true}
-		}
-		if tastingNote != "" {
-			tastingNoteParam = pgtype.Text{String: tastingNote, Valid: true}
-		}
-
-		filteredProducts, err := h.repo.ListActiveProductsFiltered(ctx, repository.ListActiveProductsFilteredParams{
-			TenantID:    h.tenantID,
-			RoastLevel:  roastLevelParam,
-			Origin:      originParam,
-			TastingNote: tastingNoteParam,
+	if roastLevel != "" || origin != "" || tastingNote != "" || category != "" || tag != "" {
+		filteredProducts, err := h.productService.ListProductsFiltered(ctx, domain.ProductFilter{
+			RoastLevel:   optionalFilter(roastLevel),
+			Origin:       optionalFilter(origin),
+			TastingNote:  optionalFilter(tastingNote),
+			CategorySlug: optionalFilter(category),
+			TagSlug:      optionalFilter(tag),
 		})
 		if err != nil {
-			slog.Error("failed to list filtered products", "error", err, "roast", roastLevel, "origin", origin, "note", tastingNote)
+			slog.Error("failed to list filtered products", "error", err, "roast", roastLevel, "origin", origin, "note", tastingNote, "category", category, "tag", tag)
 			handler.InternalErrorResponse(w, r, err)
 			return
 		}
 
-		products = make([]ProductDisplay, len(filteredProducts))
-		for i, p := range filteredProducts {
-			products[i] = ProductDisplay{
-				ID:               p.ID,
-				TenantID:         p.TenantID,
-				Name:             p.Name,
-				Slug:             p.Slug,
-				ShortDescription: p.ShortDescription,
-				Origin:           p.Origin,
-				RoastLevel:       p.RoastLevel,
-				TastingNotes:     p.TastingNotes,
-				SortOrder:        p.SortOrder,
-				ImageURL:         p.PrimaryImageUrl,
-				ImageAlt:         p.PrimaryImageAlt,
-				BasePrice:        convertToInt4(p.BasePrice),
-			}
-		}
+		products = newProductDisplays(filteredProducts)
 	} else {
 		// No filters - use original query
 		allProducts, err := h.productService.ListProducts(ctx)
@@ -164,34 +156,15 @@ func (h *ProductHandler) List(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		products = make([]ProductDisplay, len(allProducts))
-		for i, p := range allProducts {
-			products[i] = ProductDisplay{
-				ID:               p.ID,
-				TenantID:         p.TenantID,
-				Name:             p.Name,
-				Slug:             p.Slug,
-				ShortDescription: p.ShortDescription,
-				Origin:           p.Origin,
-				RoastLevel:       p.RoastLevel,
-				TastingNotes:     p.TastingNotes,
-				SortOrder:        p.SortOrder,
-				ImageURL:         p.PrimaryImageURL,
-				ImageAlt:         p.PrimaryImageAlt,
-			}
-		}
+		products = newProductDisplays(allProducts)
 	}
 
 	// Calculate active filter count
 	activeFilters := 0
-	if roastLevel != "" {
-		activeFilters++
-	}
-	if origin != "" {
-		activeFilters++
-	}
-	if tastingNote != "" {
-		activeFilters++
+	for _, value := range []string{roastLevel, origin, tastingNote, category, tag} {
+		if value != "" {
+			activeFilters++
+		}
 	}
 
 	data := BaseTemplateData(r)
@@ -199,9 +172,13 @@ func (h *ProductHandler) List(w http.ResponseWriter, r *http.Request) {
 	data["RoastLevels"] = filterOptions.RoastLevels
 	data["Origins"] = filterOptions.Origins
 	data["TastingNotes"] = filterOptions.TastingNotes
+	data["Categories"] = filterOptions.Categories
+	data["Tags"] = filterOptions.Tags
 	data["SelectedRoast"] = roastLevel
 	data["SelectedOrigin"] = origin
 	data["SelectedNote"] = tastingNote
+	data["SelectedCategory"] = category
+	data["SelectedTag"] = tag
 	data["ActiveFilterCount"] = activeFilters
 	data["HasFilters"] = activeFilters > 0
 
@@ -216,7 +193,7 @@ func (h *ProductHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *ProductHandler) Detail(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	slug := r.PathValue("slug")
-	tenantID := h.tenantID.String()
+	tenantID := getTenantID(ctx)
 
 	if slug == "" {
 		handler.NotFoundResponse(w, r)
@@ -236,7 +213,7 @@ func (h *ProductHandler) Detail(w http.ResponseWriter, r *http.Request) {
 
 	// Track product view
 	if telemetry.Business != nil {
-		telemetry.Business.ProductViews.WithLabelValues(tenantID, slug).Inc()
+		telemetry.Business.ProductViews.WithLabelValues(tenantID.String(), slug).Inc()
 	}
 
 	// Extract unique weights and grinds for option selectors
@@ -266,12 +243,20 @@ func (h *ProductHandler) Detail(w http.ResponseWriter, r *http.Request) {
 		grinds = append(grinds, g)
 	}
 
+	ratings, err := h.reviewService.GetProductRatings(ctx, detail.Product.ID.String(), productReviewLimit)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
 	data := BaseTemplateData(r)
 	data["Product"] = detail.Product
 	data["SKUs"] = detail.SKUs
 	data["Weights"] = weights
 	data["Grinds"] = grinds
 	data["Images"] = detail.Images
+	data["Ratings"] = ratings
+	data["AverageStars"] = int(math.Round(ratings.Summary.AverageRating))
 	data["RequestPath"] = r.URL.Path
 
 	h.renderer.RenderHTTP(w, "storefront/product_detail", data)
@@ -307,11 +292,11 @@ type SubscriptionSKU struct {
 // SubscribeProducts handles GET /subscribe - shows products available for subscription
 func (h *ProductHandler) SubscribeProducts(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	tenantID := h.tenantID.String()
+	tenantID := getTenantID(ctx)
 
 	// Track subscription page view
 	if telemetry.Business != nil {
-		telemetry.Business.SubscribePageView.WithLabelValues(tenantID).Inc()
+		telemetry.Business.SubscribePageView.WithLabelValues(tenantID.String()).Inc()
 	}
 
 	// Get all active products
@@ -322,7 +307,7 @@ func (h *ProductHandler) SubscribeProducts(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Get default price list
-	priceList, err := h.repo.GetDefaultPriceList(ctx, h.tenantID)
+	priceList, err := h.repo.GetDefaultPriceList(ctx, tenantID)
 	if err != nil {
 		handler.InternalErrorResponse(w, r, err)
 		return
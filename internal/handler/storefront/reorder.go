@@ -0,0 +1,88 @@
+package storefront
+
+import (
+	"net/http"
+
+	"github.com/dukerupert/hiri/internal/cookie"
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+)
+
+// ReorderHandler refills the cart from a customer's past orders
+type ReorderHandler struct {
+	reorderService domain.ReorderService
+	cartService    domain.CartService
+	renderer       *handler.Renderer
+	cookieConfig   *cookie.Config
+}
+
+// NewReorderHandler creates a new reorder handler
+func NewReorderHandler(
+	reorderService domain.ReorderService,
+	cartService domain.CartService,
+	renderer *handler.Renderer,
+	cookieConfig *cookie.Config,
+) *ReorderHandler {
+	return &ReorderHandler{
+		reorderService: reorderService,
+		cartService:    cartService,
+		renderer:       renderer,
+		cookieConfig:   cookieConfig,
+	}
+}
+
+// Reorder handles POST /account/orders/{id}/reorder - adds the order's items
+// to the cart at today's prices. HTMX requests get the items added and any
+// availability warnings in place; others are sent to the cart.
+func (h *ReorderHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := middleware.GetUserFromContext(ctx)
+	if user == nil {
+		handler.UnauthorizedResponse(w, r)
+		return
+	}
+
+	sessionID := GetSessionIDFromCookie(r)
+	cart, newSessionID, err := h.cartService.GetOrCreateCart(ctx, sessionID)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	if newSessionID != sessionID {
+		SetSessionCookie(w, newSessionID, h.cookieConfig)
+	}
+
+	// The cart must be the customer's so it's priced from their price list
+	if err := linkCartToUser(r, h.cartService, cart); err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	result, err := h.reorderService.Reorder(ctx, user.ID.String(), r.PathValue("id"), cart.ID.String())
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/cart", http.StatusSeeOther)
+		return
+	}
+
+	tmpl, err := h.renderer.Execute("storefront/orders")
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	if result.ItemsAdded > 0 {
+		w.Header().Set("HX-Trigger", "cartUpdated")
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := tmpl.ExecuteTemplate(w, "reorder_result", result); err != nil {
+		handler.InternalErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,166 @@
+package storefront
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+)
+
+// ReviewHandler handles customer product reviews:
+// - Review form for a product in a delivered order
+// - Review submission
+// - Helpful votes on published reviews
+type ReviewHandler struct {
+	reviewService domain.ReviewService
+	renderer      *handler.Renderer
+}
+
+// NewReviewHandler creates a new review handler
+func NewReviewHandler(reviewService domain.ReviewService, renderer *handler.Renderer) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+		renderer:      renderer,
+	}
+}
+
+// New handles GET /account/reviews/new - shows the review form for a
+// product in one of the customer's delivered orders
+func (h *ReviewHandler) New(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := middleware.GetUserFromContext(ctx)
+	if user == nil {
+		http.Redirect(w, r, "/login?return_to="+url.QueryEscape(r.URL.RequestURI()), http.StatusSeeOther)
+		return
+	}
+
+	orderID := r.URL.Query().Get("order_id")
+	productID := r.URL.Query().Get("product_id")
+
+	item, err := h.reviewService.GetReviewableItem(ctx, user.ID.String(), orderID, productID)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	data := BaseTemplateData(r)
+	data["Item"] = item
+	data["Rating"] = 0
+	h.renderer.RenderHTTP(w, "storefront/review_form", data)
+}
+
+// Create handles POST /account/reviews - submits a review for moderation
+func (h *ReviewHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := middleware.GetUserFromContext(ctx)
+	if user == nil {
+		handler.UnauthorizedResponse(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	orderID := r.FormValue("order_id")
+	productID := r.FormValue("product_id")
+	rating, _ := strconv.Atoi(r.FormValue("rating"))
+
+	_, err := h.reviewService.SubmitReview(ctx, domain.SubmitReviewParams{
+		UserID:    user.ID.String(),
+		OrderID:   orderID,
+		ProductID: productID,
+		Rating:    int32(rating),
+		Title:     r.FormValue("title"),
+		Body:      r.FormValue("body"),
+	})
+	if err != nil {
+		code := domain.ErrorCode(err)
+		if code != domain.EINVALID && code != domain.ECONFLICT {
+			handler.ErrorResponse(w, r, err)
+			return
+		}
+
+		// Redisplay the form with what the customer wrote
+		item, itemErr := h.reviewService.GetReviewableItem(ctx, user.ID.String(), orderID, productID)
+		if itemErr != nil {
+			handler.ErrorResponse(w, r, itemErr)
+			return
+		}
+
+		data := BaseTemplateData(r)
+		data["Item"] = item
+		data["Rating"] = rating
+		data["Title"] = strings.TrimSpace(r.FormValue("title"))
+		data["Body"] = strings.TrimSpace(r.FormValue("body"))
+		data["Error"] = domain.ErrorMessage(err)
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		h.renderer.RenderHTTP(w, "storefront/review_form", data)
+		return
+	}
+
+	http.Redirect(w, r, "/account/orders?success=review", http.StatusSeeOther)
+}
+
+// Vote handles POST /reviews/{id}/helpful - records whether the customer
+// found a review helpful. htmx requests get the updated vote buttons.
+func (h *ReviewHandler) Vote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := middleware.GetUserFromContext(ctx)
+	if user == nil {
+		handler.UnauthorizedResponse(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	helpful := r.FormValue("helpful") == "true"
+	review, err := h.reviewService.VoteHelpful(ctx, user.ID.String(), r.PathValue("id"), helpful)
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	if r.Header.Get("HX-Request") != "true" {
+		// Only return to product pages to avoid an open redirect
+		redirectTo := "/products"
+		if returnTo := r.FormValue("return_to"); strings.HasPrefix(returnTo, "/products/") {
+			redirectTo = returnTo
+		}
+		http.Redirect(w, r, redirectTo, http.StatusSeeOther)
+		return
+	}
+
+	// The vote buttons are a component of the product page
+	tmpl, err := h.renderer.Execute("storefront/product_detail")
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"ID":              review.ID,
+		"HelpfulCount":    review.HelpfulCount,
+		"NotHelpfulCount": review.NotHelpfulCount,
+		"CSRFToken":       middleware.GetCSRFToken(ctx),
+		"CanVote":         true,
+		"Voted":           true,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := tmpl.ExecuteTemplate(w, "sf-review-votes", data); err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+}
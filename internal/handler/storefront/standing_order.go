@@ -0,0 +1,397 @@
+package storefront
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+	"github.com/dukerupert/hiri/internal/service"
+)
+
+// StandingOrderHandler handles a wholesale customer's standing orders:
+// - Standing order listing, and saving the cart as a standing order
+// - Standing order detail view
+// - Changes before the cutoff (items, schedule, skip, pause, resume, cancel)
+type StandingOrderHandler struct {
+	standingOrderService domain.StandingOrderService
+	onAccountService     domain.OnAccountService
+	cartService          domain.CartService
+	accountService       service.AccountService
+	renderer             *handler.Renderer
+}
+
+// NewStandingOrderHandler creates a new standing order handler
+func NewStandingOrderHandler(
+	standingOrderService domain.StandingOrderService,
+	onAccountService domain.OnAccountService,
+	cartService domain.CartService,
+	accountService service.AccountService,
+	renderer *handler.Renderer,
+) *StandingOrderHandler {
+	return &StandingOrderHandler{
+		standingOrderService: standingOrderService,
+		onAccountService:     onAccountService,
+		cartService:          cartService,
+		accountService:       accountService,
+		renderer:             renderer,
+	}
+}
+
+// deliveryWeekday is an option on the delivery day select.
+type deliveryWeekday struct {
+	Value int32 // ISO weekday
+	Label string
+}
+
+// deliveryWeekdays are the days a standing order can be delivered on.
+var deliveryWeekdays = []deliveryWeekday{
+	{Value: 1, Label: "Monday"},
+	{Value: 2, Label: "Tuesday"},
+	{Value: 3, Label: "Wednesday"},
+	{Value: 4, Label: "Thursday"},
+	{Value: 5, Label: "Friday"},
+	{Value: 6, Label: "Saturday"},
+	{Value: 7, Label: "Sunday"},
+}
+
+// List handles GET /account/standing-orders - shows the customer's standing
+// orders and offers to save the current cart as a new one
+func (h *StandingOrderHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := getTenantID(ctx)
+
+	user := middleware.GetUserFromContext(ctx)
+	if user == nil {
+		http.Redirect(w, r, "/login?return_to=/account/standing-orders", http.StatusSeeOther)
+		return
+	}
+
+	data := BaseTemplateData(r)
+	data["Error"] = r.URL.Query().Get("error")
+	data["Success"] = r.URL.Query().Get("success")
+
+	// Standing orders are billed on account, so only customers with payment
+	// terms can use them
+	if _, err := h.onAccountService.GetAccountCredit(ctx, user.ID.String()); err != nil {
+		if domain.ErrorCode(err) != domain.EFORBIDDEN {
+			handler.InternalErrorResponse(w, r, err)
+			return
+		}
+		data["NotOnAccount"] = true
+		h.renderer.RenderHTTP(w, "storefront/standing_orders", data)
+		return
+	}
+
+	standingOrders, err := h.standingOrderService.ListForCustomer(ctx, user.ID.String())
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+	data["StandingOrders"] = standingOrders
+
+	// The new standing order form is shown while the cart has items
+	if sessionID := GetSessionIDFromCookie(r); sessionID != "" {
+		cart, err := h.cartService.GetCart(ctx, sessionID)
+		if err != nil && domain.ErrorCode(err) != domain.ENOTFOUND {
+			handler.InternalErrorResponse(w, r, err)
+			return
+		}
+		if cart != nil {
+			summary, err := h.cartService.GetCartSummary(ctx, cart.ID.String())
+			if err != nil {
+				handler.InternalErrorResponse(w, r, err)
+				return
+			}
+			if len(summary.Items) > 0 {
+				data["Cart"] = summary
+			}
+		}
+	}
+
+	addresses, err := h.accountService.ListAddresses(ctx, tenantID, user.ID)
+	if err != nil {
+		addresses = []service.UserAddress{}
+	}
+	data["Addresses"] = addresses
+	data["Weekdays"] = deliveryWeekdays
+
+	h.renderer.RenderHTTP(w, "storefront/standing_orders", data)
+}
+
+// Create handles POST /account/standing-orders - saves the cart as a new
+// standing order
+func (h *StandingOrderHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := middleware.GetUserFromContext(ctx)
+	if user == nil {
+		handler.UnauthorizedResponse(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return
+	}
+
+	cart, err := h.cartService.GetCart(ctx, GetSessionIDFromCookie(r))
+	if err != nil {
+		h.redirectToList(w, r, "error="+url.QueryEscape("Add the items you want delivered to your cart first"))
+		return
+	}
+
+	intervalWeeks, weekday, ok := parseSchedule(r)
+	if !ok {
+		h.redirectToList(w, r, "error="+url.QueryEscape(domain.ErrorMessage(domain.ErrInvalidStandingOrderSchedule)))
+		return
+	}
+
+	standingOrder, err := h.standingOrderService.CreateFromCart(ctx, domain.CreateStandingOrderParams{
+		UserID:          user.ID.String(),
+		CartID:          cart.ID.String(),
+		Name:            strings.TrimSpace(r.FormValue("name")),
+		IntervalWeeks:   intervalWeeks,
+		DeliveryWeekday: weekday,
+		AddressID:       r.FormValue("address_id"),
+	})
+	if err != nil {
+		if code := domain.ErrorCode(err); code != domain.EINVALID && code != domain.EFORBIDDEN {
+			handler.InternalErrorResponse(w, r, err)
+			return
+		}
+		h.redirectToList(w, r, "error="+url.QueryEscape(domain.ErrorMessage(err)))
+		return
+	}
+
+	h.redirectToStandingOrder(w, r, standingOrder.ID.String(), "success=created")
+}
+
+// Detail handles GET /account/standing-orders/{id} - shows a standing order
+// with its items and schedule
+func (h *StandingOrderHandler) Detail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := getTenantID(ctx)
+
+	user := middleware.GetUserFromContext(ctx)
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	standingOrder, err := h.standingOrderService.GetForCustomer(ctx, user.ID.String(), r.PathValue("id"))
+	if err != nil {
+		if domain.ErrorCode(err) == domain.ENOTFOUND {
+			handler.NotFoundResponse(w, r)
+			return
+		}
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	data := BaseTemplateData(r)
+	data["StandingOrder"] = standingOrder
+	data["Success"] = r.URL.Query().Get("success")
+	data["Error"] = r.URL.Query().Get("error")
+	data["Weekdays"] = deliveryWeekdays
+
+	if standingOrder.Editable() {
+		addresses, err := h.accountService.ListAddresses(ctx, tenantID, user.ID)
+		if err != nil {
+			addresses = []service.UserAddress{}
+		}
+		data["Addresses"] = addresses
+	}
+
+	h.renderer.RenderHTTP(w, "storefront/standing_order_detail", data)
+}
+
+// UpdateItems handles POST /account/standing-orders/{id}/items - sets the
+// quantity of each item; zero removes it
+func (h *StandingOrderHandler) UpdateItems(w http.ResponseWriter, r *http.Request) {
+	standingOrder, userID, ok := h.ownedStandingOrder(w, r)
+	if !ok {
+		return
+	}
+
+	skuIDs := r.Form["product_sku_id"]
+	values := r.Form["quantity"]
+	if len(skuIDs) != len(values) {
+		h.redirectWithError(w, r, standingOrder.ID.String(), "Invalid form data")
+		return
+	}
+
+	quantities := make(map[string]int, len(skuIDs))
+	for i, skuID := range skuIDs {
+		quantity, err := strconv.Atoi(strings.TrimSpace(values[i]))
+		if err != nil {
+			h.redirectWithError(w, r, standingOrder.ID.String(), domain.ErrorMessage(domain.ErrInvalidQuantity))
+			return
+		}
+		quantities[skuID] = quantity
+	}
+
+	err := h.standingOrderService.UpdateItems(r.Context(), userID, standingOrder.ID.String(), quantities)
+	h.redirectAfterChange(w, r, standingOrder.ID.String(), "items", err)
+}
+
+// UpdateSchedule handles POST /account/standing-orders/{id}/schedule - changes
+// the name, cadence, delivery day and address
+func (h *StandingOrderHandler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	standingOrder, userID, ok := h.ownedStandingOrder(w, r)
+	if !ok {
+		return
+	}
+
+	intervalWeeks, weekday, ok := parseSchedule(r)
+	if !ok {
+		h.redirectWithError(w, r, standingOrder.ID.String(), domain.ErrorMessage(domain.ErrInvalidStandingOrderSchedule))
+		return
+	}
+
+	err := h.standingOrderService.UpdateSchedule(r.Context(), domain.UpdateStandingOrderScheduleParams{
+		UserID:          userID,
+		StandingOrderID: standingOrder.ID.String(),
+		Name:            strings.TrimSpace(r.FormValue("name")),
+		IntervalWeeks:   intervalWeeks,
+		DeliveryWeekday: weekday,
+		AddressID:       r.FormValue("address_id"),
+	})
+	h.redirectAfterChange(w, r, standingOrder.ID.String(), "schedule", err)
+}
+
+// Skip handles POST /account/standing-orders/{id}/skip - skips the next
+// delivery
+func (h *StandingOrderHandler) Skip(w http.ResponseWriter, r *http.Request) {
+	standingOrder, userID, ok := h.ownedStandingOrder(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.standingOrderService.Skip(r.Context(), userID, standingOrder.ID.String())
+	h.redirectAfterChange(w, r, standingOrder.ID.String(), "skipped", err)
+}
+
+// Pause handles POST /account/standing-orders/{id}/pause
+func (h *StandingOrderHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	standingOrder, userID, ok := h.ownedStandingOrder(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.standingOrderService.Pause(r.Context(), userID, standingOrder.ID.String())
+	h.redirectAfterChange(w, r, standingOrder.ID.String(), "paused", err)
+}
+
+// Resume handles POST /account/standing-orders/{id}/resume
+func (h *StandingOrderHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	standingOrder, userID, ok := h.ownedStandingOrder(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.standingOrderService.Resume(r.Context(), userID, standingOrder.ID.String())
+	h.redirectAfterChange(w, r, standingOrder.ID.String(), "resumed", err)
+}
+
+// Cancel handles POST /account/standing-orders/{id}/cancel
+func (h *StandingOrderHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	standingOrder, userID, ok := h.ownedStandingOrder(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.standingOrderService.Cancel(r.Context(), userID, standingOrder.ID.String())
+	h.redirectAfterChange(w, r, standingOrder.ID.String(), "cancelled", err)
+}
+
+// parseSchedule reads the cadence and delivery day from the form.
+func parseSchedule(r *http.Request) (intervalWeeks, weekday int32, ok bool) {
+	interval, err := strconv.Atoi(r.FormValue("interval_weeks"))
+	if err != nil {
+		return 0, 0, false
+	}
+	day, err := strconv.Atoi(r.FormValue("delivery_weekday"))
+	if err != nil {
+		return 0, 0, false
+	}
+	if !domain.ValidStandingOrderSchedule(int32(interval), int32(day)) {
+		return 0, 0, false
+	}
+	return int32(interval), int32(day), true
+}
+
+// ownedStandingOrder parses the form and loads the signed-in customer's
+// standing order from the path. It writes the response and returns false
+// when the standing order cannot be changed by this customer.
+func (h *StandingOrderHandler) ownedStandingOrder(w http.ResponseWriter, r *http.Request) (*domain.StandingOrderDetail, string, bool) {
+	ctx := r.Context()
+
+	user := middleware.GetUserFromContext(ctx)
+	if user == nil {
+		handler.UnauthorizedResponse(w, r)
+		return nil, "", false
+	}
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return nil, "", false
+	}
+
+	// Loading with the user ID validates ownership
+	standingOrder, err := h.standingOrderService.GetForCustomer(ctx, user.ID.String(), r.PathValue("id"))
+	if err != nil {
+		if domain.ErrorCode(err) == domain.ENOTFOUND {
+			handler.NotFoundResponse(w, r)
+			return nil, "", false
+		}
+		handler.InternalErrorResponse(w, r, err)
+		return nil, "", false
+	}
+
+	return standingOrder, user.ID.String(), true
+}
+
+// redirectAfterChange returns to the standing order page, reporting success
+// or the reason the change was refused. Unexpected errors get an error page.
+func (h *StandingOrderHandler) redirectAfterChange(w http.ResponseWriter, r *http.Request, standingOrderID, success string, err error) {
+	if err != nil {
+		if domain.ErrorCode(err) != domain.EINVALID {
+			handler.InternalErrorResponse(w, r, err)
+			return
+		}
+		h.redirectWithError(w, r, standingOrderID, domain.ErrorMessage(err))
+		return
+	}
+
+	h.redirectToStandingOrder(w, r, standingOrderID, "success="+success)
+}
+
+// redirectWithError returns to the standing order page with an error message
+func (h *StandingOrderHandler) redirectWithError(w http.ResponseWriter, r *http.Request, standingOrderID, message string) {
+	h.redirectToStandingOrder(w, r, standingOrderID, "error="+url.QueryEscape(message))
+}
+
+// redirectToStandingOrder redirects to the standing order page with a query
+// string
+func (h *StandingOrderHandler) redirectToStandingOrder(w http.ResponseWriter, r *http.Request, standingOrderID, query string) {
+	h.redirect(w, r, fmt.Sprintf("/account/standing-orders/%s?%s", standingOrderID, query))
+}
+
+// redirectToList redirects to the standing order list with a query string
+func (h *StandingOrderHandler) redirectToList(w http.ResponseWriter, r *http.Request, query string) {
+	h.redirect(w, r, "/account/standing-orders?"+query)
+}
+
+func (h *StandingOrderHandler) redirect(w http.ResponseWriter, r *http.Request, location string) {
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("HX-Redirect", location)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, location, http.StatusSeeOther)
+}
@@ -3,7 +3,10 @@ package storefront
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dukerupert/hiri/internal/domain"
 	"github.com/dukerupert/hiri/internal/handler"
@@ -15,36 +18,33 @@ import (
 // SubscriptionHandler handles all account-related subscription operations:
 // - Subscription listing
 // - Subscription detail view
+// - In-app changes (skip, frequency, item, address, payment method, pause, resume, cancel)
 // - Customer portal redirect
+// - Update payment method links from failed payment emails
 // - Subscription checkout
 // - Subscription creation
 type SubscriptionHandler struct {
 	subscriptionService domain.SubscriptionService
+	dunningService      domain.DunningService
 	productService      domain.ProductService
 	accountService      service.AccountService
 	renderer            *handler.Renderer
-	tenantID            pgtype.UUID
 }
 
 // NewSubscriptionHandler creates a new consolidated subscription handler
 func NewSubscriptionHandler(
 	subscriptionService domain.SubscriptionService,
+	dunningService domain.DunningService,
 	productService domain.ProductService,
 	accountService service.AccountService,
 	renderer *handler.Renderer,
-	tenantID string,
 ) *SubscriptionHandler {
-	var tenantUUID pgtype.UUID
-	if err := tenantUUID.Scan(tenantID); err != nil {
-		panic(fmt.Sprintf("invalid tenant ID: %v", err))
-	}
-
 	return &SubscriptionHandler{
 		subscriptionService: subscriptionService,
+		dunningService:      dunningService,
 		productService:      productService,
 		accountService:      accountService,
 		renderer:            renderer,
-		tenantID:            tenantUUID,
 	}
 }
 
@@ -55,6 +55,7 @@ func NewSubscriptionHandler(
 // List handles GET /account/subscriptions - shows all subscriptions for the user
 func (h *SubscriptionHandler) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	// Get authenticated user from context (RequireAuth middleware ensures this exists)
 	user := middleware.GetUserFromContext(ctx)
@@ -64,7 +65,7 @@ func (h *SubscriptionHandler) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	subscriptions, err := h.subscriptionService.ListSubscriptionsForUser(ctx, domain.ListSubscriptionsParams{
-		TenantID: h.tenantID,
+		TenantID: tenantID,
 		UserID:   user.ID,
 		Limit:    50,
 		Offset:   0,
@@ -88,6 +89,7 @@ func (h *SubscriptionHandler) List(w http.ResponseWriter, r *http.Request) {
 // Detail handles GET /account/subscriptions/{id} - shows a single subscription
 func (h *SubscriptionHandler) Detail(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	// Get authenticated user from context
 	user := middleware.GetUserFromContext(ctx)
@@ -110,7 +112,7 @@ func (h *SubscriptionHandler) Detail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	subscription, err := h.subscriptionService.GetSubscription(ctx, domain.GetSubscriptionParams{
-		TenantID:               h.tenantID,
+		TenantID:               tenantID,
 		SubscriptionID:         subscriptionID,
 		UserID:                 user.ID, // Include user ID for ownership validation
 		IncludeUpcomingInvoice: true,
@@ -123,10 +125,323 @@ func (h *SubscriptionHandler) Detail(w http.ResponseWriter, r *http.Request) {
 
 	data := BaseTemplateData(r)
 	data["Subscription"] = subscription
+	data["Success"] = r.URL.Query().Get("success")
+	data["Error"] = r.URL.Query().Get("error")
+	data["CancellationReasons"] = cancellationReasons
+
+	changeable := subscription.Status == "active" || subscription.Status == "paused"
+	data["Changeable"] = changeable
+
+	if changeable {
+		// Options for the change forms; each falls back to empty so the
+		// page still renders
+		if len(subscription.Items) > 0 {
+			skuOptions, err := h.subscriptionService.ListSKUOptions(ctx, tenantID, subscription.Items[0].ProductSKUID)
+			if err != nil {
+				skuOptions = []domain.SubscriptionSKUOption{}
+			}
+			data["SKUOptions"] = skuOptions
+		}
+
+		addresses, err := h.accountService.ListAddresses(ctx, tenantID, user.ID)
+		if err != nil {
+			addresses = []service.UserAddress{}
+		}
+		data["Addresses"] = addresses
+
+		paymentMethods, err := h.accountService.ListPaymentMethods(ctx, tenantID, user.ID)
+		if err != nil {
+			paymentMethods = []service.UserPaymentMethod{}
+		}
+		data["PaymentMethods"] = paymentMethods
+	}
+
+	events, err := h.subscriptionService.ListSubscriptionEvents(ctx, tenantID, subscriptionID, 20)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+	data["Events"] = events
+
+	// A paused subscription with a scheduled resume event resumes on that date
+	for _, event := range events {
+		if event.EventType == "resume" && event.Status == "scheduled" {
+			data["PausedUntil"] = event.ScheduledAt
+			break
+		}
+	}
 
 	h.renderer.RenderHTTP(w, "storefront/subscription_detail", data)
 }
 
+// =============================================================================
+// Subscription Changes
+// =============================================================================
+
+// cancellationReason is an option on the cancel form.
+type cancellationReason struct {
+	Value string
+	Label string
+}
+
+// cancellationReasons are the reasons a customer can give for cancelling.
+var cancellationReasons = []cancellationReason{
+	{Value: "too_much_coffee", Label: "I have too much coffee"},
+	{Value: "too_expensive", Label: "It costs too much"},
+	{Value: "taste", Label: "I didn't enjoy the coffee"},
+	{Value: "delivery", Label: "Deliveries were late or damaged"},
+	{Value: "moving", Label: "I'm moving"},
+	{Value: "other", Label: "Something else"},
+}
+
+// Skip handles POST /account/subscriptions/{id}/skip - skips the next delivery
+func (h *SubscriptionHandler) Skip(w http.ResponseWriter, r *http.Request) {
+	subscription, ok := h.ownedSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	_, err := h.subscriptionService.SkipNextDelivery(r.Context(), domain.SkipDeliveryParams{
+		TenantID:       getTenantID(r.Context()),
+		SubscriptionID: subscription.ID,
+	})
+	h.redirectAfterChange(w, r, subscription.ID, "skipped", err)
+}
+
+// ChangeFrequency handles POST /account/subscriptions/{id}/frequency
+func (h *SubscriptionHandler) ChangeFrequency(w http.ResponseWriter, r *http.Request) {
+	subscription, ok := h.ownedSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	_, err := h.subscriptionService.ChangeFrequency(r.Context(), domain.ChangeFrequencyParams{
+		TenantID:        getTenantID(r.Context()),
+		SubscriptionID:  subscription.ID,
+		BillingInterval: r.FormValue("billing_interval"),
+	})
+	h.redirectAfterChange(w, r, subscription.ID, "frequency", err)
+}
+
+// ChangeItem handles POST /account/subscriptions/{id}/item - changes the
+// quantity, bag size or grind
+func (h *SubscriptionHandler) ChangeItem(w http.ResponseWriter, r *http.Request) {
+	subscription, ok := h.ownedSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	var productSKUID pgtype.UUID
+	if err := productSKUID.Scan(r.FormValue("product_sku_id")); err != nil {
+		h.redirectWithError(w, r, subscription.ID, "Choose a size and grind")
+		return
+	}
+
+	quantity, err := strconv.Atoi(r.FormValue("quantity"))
+	if err != nil || quantity < 1 {
+		h.redirectWithError(w, r, subscription.ID, "Enter a quantity of at least 1")
+		return
+	}
+
+	_, err = h.subscriptionService.ChangeItem(r.Context(), domain.ChangeItemParams{
+		TenantID:       getTenantID(r.Context()),
+		SubscriptionID: subscription.ID,
+		ProductSKUID:   productSKUID,
+		Quantity:       int32(quantity),
+	})
+	h.redirectAfterChange(w, r, subscription.ID, "item", err)
+}
+
+// ChangeAddress handles POST /account/subscriptions/{id}/address
+func (h *SubscriptionHandler) ChangeAddress(w http.ResponseWriter, r *http.Request) {
+	subscription, ok := h.ownedSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	var addressID pgtype.UUID
+	if err := addressID.Scan(r.FormValue("address_id")); err != nil {
+		h.redirectWithError(w, r, subscription.ID, "Choose a shipping address")
+		return
+	}
+
+	_, err := h.subscriptionService.ChangeShippingAddress(r.Context(), domain.ChangeShippingAddressParams{
+		TenantID:       getTenantID(r.Context()),
+		SubscriptionID: subscription.ID,
+		AddressID:      addressID,
+	})
+	h.redirectAfterChange(w, r, subscription.ID, "address", err)
+}
+
+// ChangePaymentMethod handles POST /account/subscriptions/{id}/payment-method
+func (h *SubscriptionHandler) ChangePaymentMethod(w http.ResponseWriter, r *http.Request) {
+	subscription, ok := h.ownedSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	var paymentMethodID pgtype.UUID
+	if err := paymentMethodID.Scan(r.FormValue("payment_method_id")); err != nil {
+		h.redirectWithError(w, r, subscription.ID, "Choose a payment method")
+		return
+	}
+
+	_, err := h.subscriptionService.ChangePaymentMethod(r.Context(), domain.ChangePaymentMethodParams{
+		TenantID:        getTenantID(r.Context()),
+		SubscriptionID:  subscription.ID,
+		PaymentMethodID: paymentMethodID,
+	})
+	h.redirectAfterChange(w, r, subscription.ID, "payment_method", err)
+}
+
+// Pause handles POST /account/subscriptions/{id}/pause - pauses deliveries,
+// optionally until a date
+func (h *SubscriptionHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	subscription, ok := h.ownedSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	var resumesAt *time.Time
+	if resumeOn := r.FormValue("resume_on"); resumeOn != "" {
+		date, err := time.Parse("2006-01-02", resumeOn)
+		if err != nil {
+			h.redirectWithError(w, r, subscription.ID, "Enter a valid resume date")
+			return
+		}
+		resumesAt = &date
+	}
+
+	_, err := h.subscriptionService.PauseSubscription(r.Context(), domain.PauseSubscriptionParams{
+		TenantID:       getTenantID(r.Context()),
+		SubscriptionID: subscription.ID,
+		ResumesAt:      resumesAt,
+	})
+	h.redirectAfterChange(w, r, subscription.ID, "paused", err)
+}
+
+// Resume handles POST /account/subscriptions/{id}/resume
+func (h *SubscriptionHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	subscription, ok := h.ownedSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	_, err := h.subscriptionService.ResumeSubscription(r.Context(), domain.ResumeSubscriptionParams{
+		TenantID:       getTenantID(r.Context()),
+		SubscriptionID: subscription.ID,
+	})
+	h.redirectAfterChange(w, r, subscription.ID, "resumed", err)
+}
+
+// Cancel handles POST /account/subscriptions/{id}/cancel - cancels at the end
+// of the current period with the customer's reason
+func (h *SubscriptionHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	subscription, ok := h.ownedSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	reason := r.FormValue("reason")
+	validReason := false
+	for _, option := range cancellationReasons {
+		if option.Value == reason {
+			validReason = true
+			break
+		}
+	}
+	if !validReason {
+		h.redirectWithError(w, r, subscription.ID, "Let us know why you're cancelling")
+		return
+	}
+
+	note := strings.TrimSpace(r.FormValue("note"))
+	if len(note) > 500 {
+		h.redirectWithError(w, r, subscription.ID, "Keep your note under 500 characters")
+		return
+	}
+	if note != "" {
+		reason += ": " + note
+	}
+
+	_, err := h.subscriptionService.CancelSubscription(r.Context(), domain.CancelSubscriptionParams{
+		TenantID:           getTenantID(r.Context()),
+		SubscriptionID:     subscription.ID,
+		CancelAtPeriodEnd:  true,
+		CancellationReason: reason,
+	})
+	h.redirectAfterChange(w, r, subscription.ID, "cancelled", err)
+}
+
+// ownedSubscription parses the form and loads the signed-in customer's
+// subscription from the path. It writes the response and returns false when
+// the subscription cannot be changed by this customer.
+func (h *SubscriptionHandler) ownedSubscription(w http.ResponseWriter, r *http.Request) (*domain.SubscriptionDetail, bool) {
+	ctx := r.Context()
+	tenantID := getTenantID(ctx)
+
+	user := middleware.GetUserFromContext(ctx)
+	if user == nil {
+		handler.UnauthorizedResponse(w, r)
+		return nil, false
+	}
+
+	if err := r.ParseForm(); err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid form data"))
+		return nil, false
+	}
+
+	var subscriptionID pgtype.UUID
+	if err := subscriptionID.Scan(r.PathValue("id")); err != nil {
+		handler.NotFoundResponse(w, r)
+		return nil, false
+	}
+
+	// Loading with the user ID validates ownership
+	subscription, err := h.subscriptionService.GetSubscription(ctx, domain.GetSubscriptionParams{
+		TenantID:       tenantID,
+		SubscriptionID: subscriptionID,
+		UserID:         user.ID,
+	})
+	if err != nil {
+		handler.NotFoundResponse(w, r)
+		return nil, false
+	}
+
+	return subscription, true
+}
+
+// redirectAfterChange returns to the subscription page, reporting success or
+// the reason the change was refused. Unexpected errors get an error page.
+func (h *SubscriptionHandler) redirectAfterChange(w http.ResponseWriter, r *http.Request, subscriptionID pgtype.UUID, success string, err error) {
+	if err != nil {
+		if domain.ErrorCode(err) != domain.EINVALID {
+			handler.InternalErrorResponse(w, r, err)
+			return
+		}
+		h.redirectWithError(w, r, subscriptionID, domain.ErrorMessage(err))
+		return
+	}
+
+	h.redirectToSubscription(w, r, subscriptionID, "success="+success)
+}
+
+// redirectWithError returns to the subscription page with an error message
+func (h *SubscriptionHandler) redirectWithError(w http.ResponseWriter, r *http.Request, subscriptionID pgtype.UUID, message string) {
+	h.redirectToSubscription(w, r, subscriptionID, "error="+url.QueryEscape(message))
+}
+
+// redirectToSubscription redirects to the subscription page with a query string
+func (h *SubscriptionHandler) redirectToSubscription(w http.ResponseWriter, r *http.Request, subscriptionID pgtype.UUID, query string) {
+	location := fmt.Sprintf("/account/subscriptions/%s?%s", subscriptionID.String(), query)
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("HX-Redirect", location)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, location, http.StatusSeeOther)
+}
+
 // =============================================================================
 // Customer Portal
 // =============================================================================
@@ -134,6 +449,7 @@ func (h *SubscriptionHandler) Detail(w http.ResponseWriter, r *http.Request) {
 // Portal handles GET /account/subscriptions/portal - redirects to Stripe portal
 func (h *SubscriptionHandler) Portal(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	// Get authenticated user from context
 	user := middleware.GetUserFromContext(ctx)
@@ -149,7 +465,7 @@ func (h *SubscriptionHandler) Portal(w http.ResponseWriter, r *http.Request) {
 	}
 
 	portalURL, err := h.subscriptionService.CreateCustomerPortalSession(ctx, domain.PortalSessionParams{
-		TenantID:  h.tenantID,
+		TenantID:  tenantID,
 		UserID:    user.ID,
 		ReturnURL: returnURL,
 	})
@@ -162,6 +478,21 @@ func (h *SubscriptionHandler) Portal(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, portalURL, http.StatusSeeOther)
 }
 
+// UpdatePayment handles GET /subscriptions/update-payment/{token}
+// The link in a failed payment email opens the billing portal without
+// signing in, so the customer can fix their card before the next retry.
+func (h *SubscriptionHandler) UpdatePayment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	portalURL, err := h.dunningService.PaymentUpdateURL(ctx, r.PathValue("token"))
+	if err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, portalURL, http.StatusSeeOther)
+}
+
 // =============================================================================
 // Subscription Checkout
 // =============================================================================
@@ -169,6 +500,7 @@ func (h *SubscriptionHandler) Portal(w http.ResponseWriter, r *http.Request) {
 // Checkout handles GET /subscribe/checkout - shows subscription checkout page
 func (h *SubscriptionHandler) Checkout(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	// Get authenticated user from context
 	user := middleware.GetUserFromContext(ctx)
@@ -216,14 +548,14 @@ func (h *SubscriptionHandler) Checkout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get user's saved addresses
-	addresses, err := h.accountService.ListAddresses(ctx, h.tenantID, user.ID)
+	addresses, err := h.accountService.ListAddresses(ctx, tenantID, user.ID)
 	if err != nil {
 		// Continue with empty addresses - user can add new one
 		addresses = []service.UserAddress{}
 	}
 
 	// Get user's saved payment methods
-	paymentMethods, err := h.accountService.ListPaymentMethods(ctx, h.tenantID, user.ID)
+	paymentMethods, err := h.accountService.ListPaymentMethods(ctx, tenantID, user.ID)
 	if err != nil {
 		// Continue with empty payment methods - user can add new one
 		paymentMethods = []service.UserPaymentMethod{}
@@ -253,6 +585,7 @@ func (h *SubscriptionHandler) Checkout(w http.ResponseWriter, r *http.Request) {
 // Create handles POST /subscribe - creates a new subscription
 func (h *SubscriptionHandler) Create(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	tenantID := getTenantID(ctx)
 
 	// Get authenticated user from context
 	user := middleware.GetUserFromContext(ctx)
@@ -314,7 +647,7 @@ func (h *SubscriptionHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	// Create subscription
 	subscription, err := h.subscriptionService.CreateSubscription(ctx, domain.CreateSubscriptionParams{
-		TenantID:          h.tenantID,
+		TenantID:          tenantID,
 		UserID:            user.ID,
 		ProductSKUID:      productSKUID,
 		Quantity:          quantity,
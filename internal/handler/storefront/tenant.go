@@ -0,0 +1,22 @@
+package storefront
+
+import (
+	"context"
+
+	"github.com/dukerupert/hiri/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// getTenantID returns the ID of the tenant the request was resolved to
+// (set by tenant middleware). Storefront routes are registered behind
+// RequireTenant, so the ID is always valid inside a handler.
+func getTenantID(ctx context.Context) pgtype.UUID {
+	return tenant.IDFromContext(ctx)
+}
+
+// getTenantUUID returns the request's tenant ID as a uuid.UUID for services
+// that take one (email verification, password reset).
+func getTenantUUID(ctx context.Context) uuid.UUID {
+	return uuid.UUID(getTenantID(ctx).Bytes)
+}
@@ -16,20 +16,13 @@ import (
 type WholesaleApplicationHandler struct {
 	repo     repository.Querier
 	renderer *handler.Renderer
-	tenantID pgtype.UUID
 }
 
 // NewWholesaleApplicationHandler creates a new wholesale application handler
-func NewWholesaleApplicationHandler(repo repository.Querier, renderer *handler.Renderer, tenantID string) *WholesaleApplicationHandler {
-	var tenantUUID pgtype.UUID
-	if err := tenantUUID.Scan(tenantID); err != nil {
-		panic(fmt.Sprintf("invalid tenant ID: %v", err))
-	}
-
+func NewWholesaleApplicationHandler(repo repository.Querier, renderer *handler.Renderer) *WholesaleApplicationHandler {
 	return &WholesaleApplicationHandler{
 		repo:     repo,
 		renderer: renderer,
-		tenantID: tenantUUID,
 	}
 }
 
@@ -1,8 +1,12 @@
 package storefront
 
 import (
+	"context"
 	"fmt"
+	"html"
 	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -17,32 +21,30 @@ import (
 
 // WholesaleOrderingHandler handles the wholesale ordering matrix view
 type WholesaleOrderingHandler struct {
-	repo         repository.Querier
-	cartService  domain.CartService
-	renderer     *handler.Renderer
-	tenantID     pgtype.UUID
-	cookieConfig *cookie.Config
+	repo              repository.Querier
+	cartService       domain.CartService
+	orderRulesService domain.OrderRulesService
+	reorderService    domain.ReorderService
+	renderer          *handler.Renderer
+	cookieConfig      *cookie.Config
 }
 
 // NewWholesaleOrderingHandler creates a new wholesale ordering handler
 func NewWholesaleOrderingHandler(
 	repo repository.Querier,
 	cartService domain.CartService,
+	orderRulesService domain.OrderRulesService,
+	reorderService domain.ReorderService,
 	renderer *handler.Renderer,
-	tenantID string,
 	cookieConfig *cookie.Config,
 ) *WholesaleOrderingHandler {
-	var tenantUUID pgtype.UUID
-	if err := tenantUUID.Scan(tenantID); err != nil {
-		panic(fmt.Sprintf("invalid tenant ID: %v", err))
-	}
-
 	return &WholesaleOrderingHandler{
-		repo:         repo,
-		cartService:  cartService,
-		renderer:     renderer,
-		tenantID:     tenantUUID,
-		cookieConfig: cookieConfig,
+		repo:              repo,
+		cartService:       cartService,
+		orderRulesService: orderRulesService,
+		reorderService:    reorderService,
+		renderer:          renderer,
+		cookieConfig:      cookieConfig,
 	}
 }
 
@@ -53,6 +55,7 @@ type ProductGroup struct {
 	ProductSlug string
 	Origin      string
 	ImageURL    string
+	WhiteLabel  bool
 	SKUs        []WholesaleSKU
 }
 
@@ -65,12 +68,15 @@ type WholesaleSKU struct {
 	PriceCents       int32
 	StockStatus      string
 	InventoryQty     int32
+	Rule             domain.QuantityRule
+	Tiers            []domain.PriceTier
+	Quantity         int32 // Prefilled from an order template
 }
 
 // Order handles GET /wholesale/order - shows the wholesale ordering matrix
 func (h *WholesaleOrderingHandler) Order(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	tenantID := h.tenantID.String()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -92,51 +98,79 @@ func (h *WholesaleOrderingHandler) Order(w http.ResponseWriter, r *http.Request)
 
 	// Track wholesale page view
 	if telemetry.Business != nil {
-		telemetry.Business.ProductViews.WithLabelValues(tenantID, "wholesale_ordering").Inc()
+		telemetry.Business.ProductViews.WithLabelValues(tenantID.String(), "wholesale_ordering").Inc()
 	}
 
-	// Get user's price list (or default if not set)
-	var priceListID pgtype.UUID
-	userPriceListID, err := h.repo.GetPriceListForUser(ctx, user.ID)
-	if err == nil && userPriceListID.Valid {
-		priceListID = userPriceListID
-	} else {
-		// Fall back to default price list
-		priceList, err := h.repo.GetDefaultPriceList(ctx, h.tenantID)
-		if err != nil {
-			handler.InternalErrorResponse(w, r, err)
-			return
-		}
-		priceListID = priceList.ID
+	// Get products with SKUs for wholesale ordering
+	priceListID, err := h.matrixPriceList(ctx, tenantID, user.ID)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
 	}
 
-	// Get products with SKUs for wholesale ordering
-	rows, err := h.repo.ListProductsWithSKUsForWholesale(ctx, repository.ListProductsWithSKUsForWholesaleParams{
-		TenantID:    h.tenantID,
+	rows, err := h.listMatrixRows(ctx, tenantID, user.ID, priceListID)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	// Quantity breaks are shown under each SKU's price
+	tierRows, err := h.repo.ListPriceListTiers(ctx, repository.ListPriceListTiersParams{
+		TenantID:    tenantID,
 		PriceListID: priceListID,
 	})
 	if err != nil {
 		handler.InternalErrorResponse(w, r, err)
 		return
 	}
+	tiers := make(map[string][]domain.PriceTier)
+	for _, row := range tierRows {
+		skuID := row.ProductSkuID.String()
+		tiers[skuID] = append(tiers[skuID], domain.PriceTier{MinQuantity: row.MinQuantity, PriceCents: row.PriceCents})
+	}
 
 	// Group SKUs by product
-	productGroups := h.groupByProduct(rows)
+	productGroups := h.groupByProduct(rows, tiers)
 
-	// Get current cart summary
+	// A saved order template prefills the quantities
+	templates, err := h.reorderService.ListTemplates(ctx, user.ID.String())
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+	var template *domain.OrderTemplateDetail
+	var templateMissing int
+	if templateID := r.URL.Query().Get("template"); templateID != "" {
+		template, err = h.reorderService.GetTemplate(ctx, user.ID.String(), templateID)
+		if err != nil {
+			handler.ErrorResponse(w, r, err)
+			return
+		}
+		templateMissing = prefillQuantities(productGroups, template.Quantities)
+	}
+
+	// Get current cart summary and how far it is from the order minimums
 	sessionID := GetSessionIDFromCookie(r)
 	var cartSummary *domain.CartSummary
+	var orderRules *domain.OrderRulesCheck
 	if sessionID != "" {
 		cart, err := h.cartService.GetCart(ctx, sessionID)
 		if err == nil && cart != nil {
 			cartSummary, _ = h.cartService.GetCartSummary(ctx, cart.ID.String())
+			orderRules, _ = h.orderRulesService.CheckCart(ctx, user.ID.String(), cart.ID.String())
 		}
 	}
 
 	data := BaseTemplateData(r)
 	data["Products"] = productGroups
 	data["CartSummary"] = cartSummary
+	data["OrderRules"] = orderRules
 	data["User"] = user
+	data["Templates"] = templates
+	data["Template"] = template
+	data["TemplateMissing"] = templateMissing
+	data["MaxTemplateNameLength"] = domain.MaxOrderTemplateNameLength
+	data["Success"] = r.URL.Query().Get("success")
 
 	h.renderer.RenderHTTP(w, "storefront/wholesale_order", data)
 }
@@ -144,7 +178,7 @@ func (h *WholesaleOrderingHandler) Order(w http.ResponseWriter, r *http.Request)
 // BatchAdd handles POST /wholesale/cart/batch - adds multiple items to cart
 func (h *WholesaleOrderingHandler) BatchAdd(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	tenantID := h.tenantID.String()
+	tenantID := getTenantID(ctx)
 
 	user := middleware.GetUserFromContext(ctx)
 	if user == nil {
@@ -174,33 +208,51 @@ func (h *WholesaleOrderingHandler) BatchAdd(w http.ResponseWriter, r *http.Reque
 		SetSessionCookie(w, newSessionID, h.cookieConfig)
 	}
 
-	// Process all quantity inputs
-	// Form fields are named "qty[{sku_id}]"
-	itemsAdded := 0
-	var addErrors []string
+	if err := linkCartToUser(r, h.cartService, cart); err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
 
-	for key, values := range r.Form {
-		if !strings.HasPrefix(key, "qty[") || !strings.HasSuffix(key, "]") {
-			continue
-		}
+	// Only SKUs in this customer's matrix can be ordered, which keeps other
+	// customers' white-label products out of the cart
+	orderable, err := h.orderableSKUs(ctx, tenantID, user.ID)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+	quantities := parseMatrixQuantities(r.Form, orderable)
 
-		// Extract SKU ID from key
-		skuID := strings.TrimSuffix(strings.TrimPrefix(key, "qty["), "]")
-		if skuID == "" {
-			continue
-		}
+	// Quantity rules apply to the cart line, so check what's already in the
+	// cart plus what's being added. Nothing is added unless every line passes.
+	cartSummary, err := h.cartService.GetCartSummary(ctx, cart.ID.String())
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+	inCart := make(map[string]int32, len(cartSummary.Items))
+	for _, item := range cartSummary.Items {
+		inCart[item.SKUID.String()] = item.Quantity
+	}
 
-		// Get quantity
-		qtyStr := values[0]
-		if qtyStr == "" || qtyStr == "0" {
-			continue
+	var ruleErrors []string
+	for skuID, qty := range quantities {
+		row := orderable[skuID]
+		rule := domain.EffectiveQuantityRule(row.WholesaleMinQuantity, row.WholesaleCasePack,
+			row.HasCustomerRule, row.CustomerMinQuantity, row.CustomerCasePack)
+		if problem := rule.Check(inCart[skuID] + int32(qty)); problem != "" {
+			ruleErrors = append(ruleErrors, fmt.Sprintf("%s (%s): %s", row.ProductName, row.SkuCode, problem))
 		}
+	}
+	if len(ruleErrors) > 0 {
+		sort.Strings(ruleErrors)
+		h.renderError(w, r, strings.Join(ruleErrors, "\n"))
+		return
+	}
 
-		qty, err := strconv.Atoi(qtyStr)
-		if err != nil || qty < 1 {
-			continue
-		}
+	itemsAdded := 0
+	var addErrors []string
 
+	for skuID, qty := range quantities {
 		// Add to cart
 		_, err = h.cartService.AddItem(ctx, cart.ID.String(), skuID, qty)
 		if err != nil {
@@ -213,7 +265,7 @@ func (h *WholesaleOrderingHandler) BatchAdd(w http.ResponseWriter, r *http.Reque
 
 	// Track wholesale cart additions
 	if telemetry.Business != nil && itemsAdded > 0 {
-		telemetry.Business.ProductAddToCart.WithLabelValues(tenantID, "wholesale_batch").Add(float64(itemsAdded))
+		telemetry.Business.ProductAddToCart.WithLabelValues(tenantID.String(), "wholesale_batch").Add(float64(itemsAdded))
 	}
 
 	if len(addErrors) > 0 && itemsAdded == 0 {
@@ -230,19 +282,205 @@ func (h *WholesaleOrderingHandler) BatchAdd(w http.ResponseWriter, r *http.Reque
 			return
 		}
 
+		orderRules, err := h.orderRulesService.CheckCart(ctx, user.ID.String(), cart.ID.String())
+		if err != nil {
+			h.renderError(w, r, "Failed to get cart")
+			return
+		}
+
+		tmpl, err := h.renderer.Execute("storefront/wholesale_order")
+		if err != nil {
+			handler.InternalErrorResponse(w, r, err)
+			return
+		}
+
 		w.Header().Set("HX-Trigger", "cartUpdated")
-		h.renderer.RenderHTTP(w, "storefront/wholesale_cart_summary", map[string]interface{}{
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := tmpl.ExecuteTemplate(w, "wholesale_cart_summary", map[string]interface{}{
 			"CartSummary": cartSummary,
+			"OrderRules":  orderRules,
 			"ItemsAdded":  itemsAdded,
-		})
+		}); err != nil {
+			handler.InternalErrorResponse(w, r, err)
+		}
 		return
 	}
 
 	http.Redirect(w, r, "/cart", http.StatusSeeOther)
 }
 
-// groupByProduct groups the flat SKU rows into product groups
-func (h *WholesaleOrderingHandler) groupByProduct(rows []repository.ListProductsWithSKUsForWholesaleRow) []ProductGroup {
+// SaveTemplate handles POST /wholesale/templates - saves the quantities
+// entered on the order form as a named order template
+func (h *WholesaleOrderingHandler) SaveTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := getTenantID(ctx)
+
+	user := middleware.GetUserFromContext(ctx)
+	if user == nil {
+		handler.UnauthorizedResponse(w, r)
+		return
+	}
+
+	if user.AccountType != "wholesale" {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EFORBIDDEN, "", "Wholesale account required"))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderError(w, r, "Invalid form data")
+		return
+	}
+
+	orderable, err := h.orderableSKUs(ctx, tenantID, user.ID)
+	if err != nil {
+		handler.InternalErrorResponse(w, r, err)
+		return
+	}
+
+	template, err := h.reorderService.SaveTemplate(ctx, domain.SaveOrderTemplateParams{
+		UserID:     user.ID.String(),
+		Name:       r.FormValue("template_name"),
+		Quantities: parseMatrixQuantities(r.Form, orderable),
+	})
+	if err != nil {
+		if domain.ErrorCode(err) == domain.EINVALID {
+			h.renderError(w, r, domain.ErrorMessage(err))
+			return
+		}
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	h.redirect(w, r, "/wholesale/order?template="+template.ID.String()+"&success=template_saved")
+}
+
+// DeleteTemplate handles POST /wholesale/templates/{id}/delete
+func (h *WholesaleOrderingHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := middleware.GetUserFromContext(ctx)
+	if user == nil {
+		handler.UnauthorizedResponse(w, r)
+		return
+	}
+
+	if err := h.reorderService.DeleteTemplate(ctx, user.ID.String(), r.PathValue("id")); err != nil {
+		handler.ErrorResponse(w, r, err)
+		return
+	}
+
+	h.redirect(w, r, "/wholesale/order?success=template_deleted")
+}
+
+// orderableSKUs returns the SKUs in the customer's matrix, keyed by SKU ID
+func (h *WholesaleOrderingHandler) orderableSKUs(ctx context.Context, tenantID, userID pgtype.UUID) (map[string]repository.ListProductsWithSKUsForWholesaleRow, error) {
+	priceListID, err := h.matrixPriceList(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := h.listMatrixRows(ctx, tenantID, userID, priceListID)
+	if err != nil {
+		return nil, err
+	}
+	orderable := make(map[string]repository.ListProductsWithSKUsForWholesaleRow, len(rows))
+	for _, row := range rows {
+		orderable[row.SkuID.String()] = row
+	}
+	return orderable, nil
+}
+
+// parseMatrixQuantities returns the quantities entered on the order form for
+// orderable SKUs, keyed by SKU ID. Form fields are named "qty[{sku_id}]";
+// blank and zero quantities are left out.
+func parseMatrixQuantities(form url.Values, orderable map[string]repository.ListProductsWithSKUsForWholesaleRow) map[string]int {
+	quantities := make(map[string]int)
+	for key, values := range form {
+		if !strings.HasPrefix(key, "qty[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+
+		// Extract SKU ID from key
+		skuID := strings.TrimSuffix(strings.TrimPrefix(key, "qty["), "]")
+		if _, ok := orderable[skuID]; skuID == "" || !ok {
+			continue
+		}
+
+		// Get quantity
+		qtyStr := values[0]
+		if qtyStr == "" || qtyStr == "0" {
+			continue
+		}
+
+		qty, err := strconv.Atoi(qtyStr)
+		if err != nil || qty < 1 {
+			continue
+		}
+
+		quantities[skuID] = qty
+	}
+	return quantities
+}
+
+// prefillQuantities sets each SKU's quantity from an order template. It
+// returns how many of the template's SKUs can't be ordered now: no longer in
+// the customer's matrix, or out of stock.
+func prefillQuantities(groups []ProductGroup, quantities map[string]int32) int {
+	prefilled := 0
+	for i := range groups {
+		for j := range groups[i].SKUs {
+			sku := &groups[i].SKUs[j]
+			quantity, ok := quantities[sku.SKUID]
+			if !ok || sku.StockStatus == "Out of Stock" {
+				continue
+			}
+			sku.Quantity = quantity
+			prefilled++
+		}
+	}
+	return len(quantities) - prefilled
+}
+
+// redirect sends the customer to location, using HX-Redirect for HTMX
+// requests so the whole page is loaded.
+func (h *WholesaleOrderingHandler) redirect(w http.ResponseWriter, r *http.Request, location string) {
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("HX-Redirect", location)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, location, http.StatusSeeOther)
+}
+
+// matrixPriceList returns the customer's price list, or the default price
+// list if they don't have one.
+func (h *WholesaleOrderingHandler) matrixPriceList(ctx context.Context, tenantID, userID pgtype.UUID) (pgtype.UUID, error) {
+	userPriceListID, err := h.repo.GetPriceListForUser(ctx, userID)
+	if err == nil && userPriceListID.Valid {
+		return userPriceListID, nil
+	}
+
+	// Fall back to default price list
+	priceList, err := h.repo.GetDefaultPriceList(ctx, tenantID)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return priceList.ID, nil
+}
+
+// listMatrixRows returns the SKUs a wholesale customer can order, priced from
+// the price list returned by matrixPriceList. Includes white-label products
+// made for the customer.
+func (h *WholesaleOrderingHandler) listMatrixRows(ctx context.Context, tenantID, userID, priceListID pgtype.UUID) ([]repository.ListProductsWithSKUsForWholesaleRow, error) {
+	return h.repo.ListProductsWithSKUsForWholesale(ctx, repository.ListProductsWithSKUsForWholesaleParams{
+		TenantID:    tenantID,
+		PriceListID: priceListID,
+		CustomerID:  userID,
+	})
+}
+
+// groupByProduct groups the flat SKU rows into product groups, with each
+// SKU's quantity-break prices
+func (h *WholesaleOrderingHandler) groupByProduct(rows []repository.ListProductsWithSKUsForWholesaleRow, tiers map[string][]domain.PriceTier) []ProductGroup {
 	groupMap := make(map[string]*ProductGroup)
 	var orderedKeys []string
 
@@ -257,6 +495,7 @@ func (h *WholesaleOrderingHandler) groupByProduct(rows []repository.ListProducts
 				ProductSlug: row.ProductSlug,
 				Origin:      row.ProductOrigin.String,
 				ImageURL:    row.ProductImageUrl.String,
+				WhiteLabel:  row.ProductIsWhiteLabel,
 				SKUs:        []WholesaleSKU{},
 			}
 			groupMap[productID] = group
@@ -292,6 +531,9 @@ func (h *WholesaleOrderingHandler) groupByProduct(rows []repository.ListProducts
 			PriceCents:   row.PriceCents,
 			StockStatus:  stockStatus,
 			InventoryQty: row.InventoryQuantity,
+			Rule: domain.EffectiveQuantityRule(row.WholesaleMinQuantity, row.WholesaleCasePack,
+				row.HasCustomerRule, row.CustomerMinQuantity, row.CustomerCasePack),
+			Tiers: tiers[row.SkuID.String()],
 		})
 	}
 
@@ -304,13 +546,18 @@ func (h *WholesaleOrderingHandler) groupByProduct(rows []repository.ListProducts
 	return result
 }
 
-// renderError sends an error response
+// renderError sends an error response. Each line of the message is shown as
+// its own paragraph.
 func (h *WholesaleOrderingHandler) renderError(w http.ResponseWriter, r *http.Request, message string) {
 	if r.Header.Get("HX-Request") == "true" {
 		w.Header().Set("HX-Retarget", "#error-message")
 		w.Header().Set("HX-Reswap", "innerHTML")
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte(fmt.Sprintf(`<div class="rounded-md bg-red-50 p-4"><p class="text-sm text-red-700">%s</p></div>`, message)))
+		var lines strings.Builder
+		for _, line := range strings.Split(message, "\n") {
+			fmt.Fprintf(&lines, `<p class="text-sm text-red-700">%s</p>`, html.EscapeString(line))
+		}
+		_, _ = w.Write([]byte(fmt.Sprintf(`<div class="rounded-md bg-red-50 p-4 space-y-1">%s</div>`, lines.String())))
 		return
 	}
 	handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "%s", message))
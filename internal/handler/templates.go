@@ -201,5 +201,8 @@ func TemplateFuncs() template.FuncMap {
 				u.Bytes[0:4], u.Bytes[4:6], u.Bytes[6:8],
 				u.Bytes[8:10], u.Bytes[10:16])
 		},
+
+		// Tenant branding
+		"brandingCSS": BrandingCSS,
 	}
 }
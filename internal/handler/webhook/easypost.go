@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/handler"
+	"github.com/dukerupert/hiri/internal/middleware"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/dukerupert/hiri/internal/tenant"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxEasyPostPayloadBytes bounds webhook bodies; trackers carry their full event history.
+const maxEasyPostPayloadBytes = 1 << 20
+
+// EasyPostHandler handles EasyPost tracker webhook events
+type EasyPostHandler struct {
+	trackingService domain.ShipmentTrackingService
+	config          EasyPostWebhookConfig
+}
+
+// EasyPostWebhookConfig contains configuration for EasyPost webhook handling
+type EasyPostWebhookConfig struct {
+	// WebhookSecret is the webhook secret set on the EasyPost webhook.
+	// When empty every webhook is rejected and tracking relies on polling.
+	WebhookSecret string
+
+	// TenantID is the tenant whose shipments the webhook updates
+	TenantID string
+}
+
+// NewEasyPostHandler creates a new EasyPost webhook handler
+func NewEasyPostHandler(trackingService domain.ShipmentTrackingService, config EasyPostWebhookConfig) *EasyPostHandler {
+	return &EasyPostHandler{
+		trackingService: trackingService,
+		config:          config,
+	}
+}
+
+// HandleWebhook processes incoming EasyPost webhook events.
+// Tracker updates are recorded against the shipment with the same tracking
+// number; other events are acknowledged and ignored.
+func (h *EasyPostHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLogger(r.Context(), slog.Default())
+
+	// Only accept POST requests
+	if r.Method != http.MethodPost {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Method not allowed"))
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxEasyPostPayloadBytes))
+	if err != nil {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Error reading request body"))
+		return
+	}
+
+	signature := r.Header.Get("X-Hmac-Signature")
+	if signature == "" {
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Missing signature"))
+		return
+	}
+
+	info, err := shipping.ParseEasyPostWebhook(payload, signature, h.config.WebhookSecret)
+	if err != nil {
+		if errors.Is(err, shipping.ErrInvalidWebhookSignature) {
+			logger.Warn("easypost webhook signature verification failed")
+			handler.ErrorResponse(w, r, domain.Errorf(domain.EUNAUTHORIZED, "", "Invalid signature"))
+			return
+		}
+		logger.Warn("failed to parse easypost webhook", "error", err)
+		handler.ErrorResponse(w, r, domain.Errorf(domain.EINVALID, "", "Invalid JSON"))
+		return
+	}
+
+	if info != nil {
+		var tenantID pgtype.UUID
+		if err := tenantID.Scan(h.config.TenantID); err != nil {
+			handler.InternalErrorResponse(w, r, err)
+			return
+		}
+		ctx := tenant.NewContext(r.Context(), &tenant.Tenant{ID: tenantID})
+
+		// Failing here makes EasyPost retry the update later
+		if err := h.trackingService.RecordTracking(ctx, info); err != nil {
+			logger.Error("failed to record tracking update",
+				"error", err,
+				"tracking_number", info.TrackingNumber)
+			handler.InternalErrorResponse(w, r, err)
+			return
+		}
+
+		logger.Info("tracking update recorded",
+			"tracking_number", info.TrackingNumber,
+			"status", info.Status)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`{"received": true}`))
+}
@@ -0,0 +1,216 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/dukerupert/hiri/internal/tenant"
+)
+
+const (
+	testEasyPostSecret   = "whsec_test"
+	testEasyPostTenantID = "123e4567-e89b-12d3-a456-426614174000"
+)
+
+const testTrackerDeliveredEvent = `{
+  "object": "Event",
+  "description": "tracker.updated",
+  "result": {
+    "object": "Tracker",
+    "tracking_code": "9400100000000000000000",
+    "status": "delivered",
+    "tracking_details": [
+      {"object": "TrackingDetail", "message": "Delivered, Front Door", "status": "delivered", "datetime": "2026-03-03T15:04:00Z"}
+    ]
+  }
+}`
+
+// mockShipmentTrackingService implements domain.ShipmentTrackingService for testing
+type mockShipmentTrackingService struct {
+	recordTrackingFunc func(ctx context.Context, info *shipping.TrackingInfo) error
+	recorded           []*shipping.TrackingInfo
+}
+
+func (m *mockShipmentTrackingService) RecordTracking(ctx context.Context, info *shipping.TrackingInfo) error {
+	m.recorded = append(m.recorded, info)
+	if m.recordTrackingFunc != nil {
+		return m.recordTrackingFunc(ctx, info)
+	}
+	return nil
+}
+
+func (m *mockShipmentTrackingService) PollTracking(ctx context.Context) (*domain.TrackingPollResult, error) {
+	return nil, errors.New("not implemented")
+}
+
+func signEasyPostPayload(payload, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "hmac-sha256-hex=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestEasyPostHandler_HandleWebhook_Security(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		signature      string
+		secret         string
+		expectedStatus int
+		description    string
+	}{
+		{
+			name:           "rejects_GET_request",
+			method:         http.MethodGet,
+			signature:      signEasyPostPayload(testTrackerDeliveredEvent, testEasyPostSecret),
+			secret:         testEasyPostSecret,
+			expectedStatus: http.StatusBadRequest,
+			description:    "Only POST requests should be accepted",
+		},
+		{
+			name:           "rejects_missing_signature",
+			method:         http.MethodPost,
+			signature:      "",
+			secret:         testEasyPostSecret,
+			expectedStatus: http.StatusBadRequest,
+			description:    "Missing X-Hmac-Signature header must be rejected",
+		},
+		{
+			name:           "rejects_invalid_signature",
+			method:         http.MethodPost,
+			signature:      signEasyPostPayload(testTrackerDeliveredEvent, "whsec_other"),
+			secret:         testEasyPostSecret,
+			expectedStatus: http.StatusUnauthorized,
+			description:    "Invalid signature must be rejected with 401",
+		},
+		{
+			name:           "rejects_when_secret_not_configured",
+			method:         http.MethodPost,
+			signature:      signEasyPostPayload(testTrackerDeliveredEvent, ""),
+			secret:         "",
+			expectedStatus: http.StatusUnauthorized,
+			description:    "Webhooks must be rejected until a secret is configured",
+		},
+		{
+			name:           "accepts_valid_signature",
+			method:         http.MethodPost,
+			signature:      signEasyPostPayload(testTrackerDeliveredEvent, testEasyPostSecret),
+			secret:         testEasyPostSecret,
+			expectedStatus: http.StatusOK,
+			description:    "Valid signature should be accepted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trackingService := &mockShipmentTrackingService{}
+			h := NewEasyPostHandler(trackingService, EasyPostWebhookConfig{
+				WebhookSecret: tt.secret,
+				TenantID:      testEasyPostTenantID,
+			})
+
+			req := httptest.NewRequest(tt.method, "/webhooks/easypost", strings.NewReader(testTrackerDeliveredEvent))
+			if tt.signature != "" {
+				req.Header.Set("X-Hmac-Signature", tt.signature)
+			}
+
+			rr := httptest.NewRecorder()
+			h.HandleWebhook(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("%s: expected status %d, got %d", tt.description, tt.expectedStatus, rr.Code)
+			}
+			if tt.expectedStatus != http.StatusOK && len(trackingService.recorded) != 0 {
+				t.Errorf("%s: rejected webhook must not record tracking", tt.description)
+			}
+		})
+	}
+}
+
+func TestEasyPostHandler_HandleWebhook_TrackerUpdated(t *testing.T) {
+	var gotTenant string
+	trackingService := &mockShipmentTrackingService{
+		recordTrackingFunc: func(ctx context.Context, info *shipping.TrackingInfo) error {
+			if tn := tenant.FromContext(ctx); tn != nil {
+				gotTenant = tn.ID.String()
+			}
+			return nil
+		},
+	}
+	h := NewEasyPostHandler(trackingService, EasyPostWebhookConfig{
+		WebhookSecret: testEasyPostSecret,
+		TenantID:      testEasyPostTenantID,
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/easypost", strings.NewReader(testTrackerDeliveredEvent))
+	req.Header.Set("X-Hmac-Signature", signEasyPostPayload(testTrackerDeliveredEvent, testEasyPostSecret))
+	rr := httptest.NewRecorder()
+	h.HandleWebhook(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if len(trackingService.recorded) != 1 {
+		t.Fatalf("expected one tracking update, got %d", len(trackingService.recorded))
+	}
+	info := trackingService.recorded[0]
+	if info.TrackingNumber != "9400100000000000000000" || info.Status != shipping.TrackingStatusDelivered {
+		t.Errorf("unexpected tracking update: %+v", info)
+	}
+	if gotTenant != testEasyPostTenantID {
+		t.Errorf("expected tenant %s in context, got %q", testEasyPostTenantID, gotTenant)
+	}
+}
+
+func TestEasyPostHandler_HandleWebhook_ErrorHandling(t *testing.T) {
+	t.Run("ignores_non_tracker_events", func(t *testing.T) {
+		payload := `{"object": "Event", "description": "batch.updated", "result": {"object": "Batch"}}`
+		trackingService := &mockShipmentTrackingService{}
+		h := NewEasyPostHandler(trackingService, EasyPostWebhookConfig{
+			WebhookSecret: testEasyPostSecret,
+			TenantID:      testEasyPostTenantID,
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/easypost", strings.NewReader(payload))
+		req.Header.Set("X-Hmac-Signature", signEasyPostPayload(payload, testEasyPostSecret))
+		rr := httptest.NewRecorder()
+		h.HandleWebhook(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rr.Code)
+		}
+		if len(trackingService.recorded) != 0 {
+			t.Errorf("expected no tracking updates, got %d", len(trackingService.recorded))
+		}
+	})
+
+	t.Run("returns_500_when_recording_fails", func(t *testing.T) {
+		trackingService := &mockShipmentTrackingService{
+			recordTrackingFunc: func(ctx context.Context, info *shipping.TrackingInfo) error {
+				return errors.New("database unavailable")
+			},
+		}
+		h := NewEasyPostHandler(trackingService, EasyPostWebhookConfig{
+			WebhookSecret: testEasyPostSecret,
+			TenantID:      testEasyPostTenantID,
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/easypost", strings.NewReader(testTrackerDeliveredEvent))
+		req.Header.Set("X-Hmac-Signature", signEasyPostPayload(testTrackerDeliveredEvent, testEasyPostSecret))
+		rr := httptest.NewRecorder()
+		h.HandleWebhook(rr, req)
+
+		// EasyPost retries non-2xx responses
+		if rr.Code != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", rr.Code)
+		}
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/dukerupert/hiri/internal/domain"
 	"github.com/dukerupert/hiri/internal/handler"
 	"github.com/dukerupert/hiri/internal/telemetry"
+	"github.com/dukerupert/hiri/internal/tenant"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stripe/stripe-go/v83"
 )
@@ -22,6 +23,8 @@ type StripeHandler struct {
 	provider            billing.Provider
 	orderService        domain.OrderService
 	subscriptionService domain.SubscriptionService
+	refundService       domain.RefundService
+	dunningService      domain.DunningService
 	config              StripeWebhookConfig
 }
 
@@ -44,11 +47,13 @@ type StripeWebhookConfig struct {
 }
 
 // NewStripeHandler creates a new Stripe webhook handler
-func NewStripeHandler(provider billing.Provider, orderService domain.OrderService, subscriptionService domain.SubscriptionService, config StripeWebhookConfig) *StripeHandler {
+func NewStripeHandler(provider billing.Provider, orderService domain.OrderService, subscriptionService domain.SubscriptionService, refundService domain.RefundService, dunningService domain.DunningService, config StripeWebhookConfig) *StripeHandler {
 	return &StripeHandler{
 		provider:            provider,
 		orderService:        orderService,
 		subscriptionService: subscriptionService,
+		refundService:       refundService,
+		dunningService:      dunningService,
 		config:              config,
 	}
 }
@@ -155,6 +160,9 @@ func (h *StripeHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 		// Usually no action needed - just for monitoring
 		log.Printf("Payment intent created: %s", event.ID)
 
+	case "charge.refunded":
+		h.handleChargeRefunded(event)
+
 	// Subscription webhook events
 	case "invoice.payment_succeeded":
 		h.handleInvoicePaymentSucceeded(event)
@@ -262,7 +270,6 @@ func (h *StripeHandler) handlePaymentIntentSucceeded(event stripe.Event) {
 		order.Order.TotalCents,
 		order.Order.Currency)
 
-	// TODO: Send order confirmation email to customer
 	// TODO: Trigger fulfillment workflow (send to warehouse system)
 	// TODO: Update analytics/reporting
 }
@@ -328,6 +335,98 @@ func (h *StripeHandler) handlePaymentIntentCanceled(event stripe.Event) {
 	// cartService.MarkAbandoned(paymentIntent.Metadata["cart_id"])
 }
 
+// handleChargeRefunded processes refunded charge events
+// Records refunds issued outside the app (e.g. from the Stripe dashboard) so
+// order records stay in sync. Refunds issued from the admin are already
+// recorded and are skipped by the refund service.
+func (h *StripeHandler) handleChargeRefunded(event stripe.Event) {
+	var charge stripe.Charge
+	if err := json.Unmarshal(event.Data.Raw, &charge); err != nil {
+		log.Printf("Error parsing charge from webhook: %v", err)
+		return
+	}
+
+	log.Printf("Charge refunded: %s (refunded: %d of %d %s)",
+		charge.ID,
+		charge.AmountRefunded,
+		charge.Amount,
+		charge.Currency)
+
+	if charge.PaymentIntent == nil || charge.PaymentIntent.ID == "" {
+		log.Printf("Charge %s has no payment intent, skipping", charge.ID)
+		return
+	}
+
+	// Charges inherit tenant_id metadata from the payment intent
+	tenantID := charge.Metadata["tenant_id"]
+	if tenantID == "" && charge.PaymentIntent.Metadata != nil {
+		tenantID = charge.PaymentIntent.Metadata["tenant_id"]
+	}
+
+	// Verify this charge belongs to our tenant
+	if tenantID != h.config.TenantID {
+		if h.config.TestMode {
+			log.Printf("[TEST MODE] Tenant mismatch ignored - expected: %s, got: %s",
+				h.config.TenantID, tenantID)
+			log.Printf("[TEST MODE] ✓ Webhook received and parsed successfully")
+			return
+		}
+		log.Printf("WARNING: Charge belongs to different tenant (expected: %s, got: %s)",
+			h.config.TenantID, tenantID)
+		return
+	}
+
+	// Convert tenant ID to pgtype.UUID
+	var tenantUUID pgtype.UUID
+	if err := tenantUUID.Scan(tenantID); err != nil {
+		log.Printf("ERROR: Invalid tenant ID format: %s", tenantID)
+		return
+	}
+
+	params := domain.ProviderRefundParams{
+		TenantID:            tenantUUID,
+		PaymentIntentID:     charge.PaymentIntent.ID,
+		ChargeID:            charge.ID,
+		AmountRefundedCents: charge.AmountRefunded,
+	}
+
+	if charge.Refunds != nil {
+		for _, r := range charge.Refunds.Data {
+			if r == nil {
+				continue
+			}
+			params.Refunds = append(params.Refunds, domain.ProviderRefund{
+				ID:          r.ID,
+				AmountCents: r.Amount,
+				Reason:      string(r.Reason),
+				Status:      string(r.Status),
+				Metadata:    r.Metadata,
+			})
+		}
+	}
+
+	ctx := context.Background()
+	if err := h.refundService.RecordProviderRefunds(ctx, params); err != nil {
+		log.Printf("ERROR: Failed to record refunds for charge %s: %v", charge.ID, err)
+
+		if telemetry.Business != nil {
+			telemetry.Business.WebhookFailed.WithLabelValues(tenantID, "charge.refunded", "refund_recording_failed").Inc()
+		}
+		telemetry.CaptureErrorWithTenant(err, tenantID, map[string]interface{}{
+			"charge_id":         charge.ID,
+			"payment_intent_id": charge.PaymentIntent.ID,
+			"amount_refunded":   charge.AmountRefunded,
+		})
+		return
+	}
+
+	if telemetry.Business != nil {
+		telemetry.Business.WebhookProcessed.WithLabelValues(tenantID, "charge.refunded").Inc()
+	}
+
+	log.Printf("Refunds recorded for charge %s (payment intent: %s)", charge.ID, charge.PaymentIntent.ID)
+}
+
 // getSubscriptionFromInvoice extracts subscription info from invoice using Stripe v83 API structure
 // Returns nil if invoice is not for a subscription
 func getSubscriptionFromInvoice(invoice *stripe.Invoice) *stripe.Subscription {
@@ -392,8 +491,14 @@ func (h *StripeHandler) handleInvoicePaymentSucceeded(event stripe.Event) {
 		return
 	}
 
-	// Create order from subscription invoice
+	// A paid invoice ends any payment retries for it
 	ctx := context.Background()
+	tenantCtx := tenant.NewContext(ctx, &tenant.Tenant{ID: tenantUUID})
+	if err := h.dunningService.RecordPaymentSuccess(tenantCtx, invoice.ID); err != nil {
+		log.Printf("ERROR: Failed to end payment retries for invoice %s: %v", invoice.ID, err)
+	}
+
+	// Create order from subscription invoice
 	order, err := h.subscriptionService.CreateOrderFromSubscriptionInvoice(ctx, invoice.ID, tenantUUID)
 	if err != nil {
 		// Check if this is an idempotency case (order already exists)
@@ -420,7 +525,7 @@ func (h *StripeHandler) handleInvoicePaymentSucceeded(event stripe.Event) {
 }
 
 // handleInvoicePaymentFailed processes failed invoice payment events
-// Updates subscription status to past_due
+// Updates subscription status to past_due and starts payment retries
 func (h *StripeHandler) handleInvoicePaymentFailed(event stripe.Event) {
 	var invoice stripe.Invoice
 	if err := json.Unmarshal(event.Data.Raw, &invoice); err != nil {
@@ -485,8 +590,20 @@ func (h *StripeHandler) handleInvoicePaymentFailed(event stripe.Event) {
 
 	log.Printf("Subscription %s status updated to past_due", subscription.ID)
 
-	// TODO: Send payment failure email to customer
-	// TODO: Attempt retry logic based on dunning settings
+	// The first payment is taken at checkout, where the customer sees the
+	// failure; renewals are retried on the tenant's dunning schedule
+	if invoice.BillingReason == stripe.InvoiceBillingReasonSubscriptionCreate {
+		return
+	}
+
+	tenantCtx := tenant.NewContext(ctx, &tenant.Tenant{ID: tenantUUID})
+	err = h.dunningService.RecordPaymentFailure(tenantCtx, domain.PaymentFailureParams{
+		ProviderSubscriptionID: subscription.ID,
+		ProviderInvoiceID:      invoice.ID,
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to start payment retries for subscription %s: %v", subscription.ID, err)
+	}
 }
 
 // handleSubscriptionUpdated processes subscription update events
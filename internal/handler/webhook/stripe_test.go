@@ -7,11 +7,13 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/dukerupert/hiri/internal/billing"
 	"github.com/dukerupert/hiri/internal/domain"
 	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/tenant"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stripe/stripe-go/v83"
 )
@@ -74,6 +76,9 @@ func (m *mockBillingProvider) ResumeSubscription(ctx context.Context, params bil
 func (m *mockBillingProvider) CancelSubscription(ctx context.Context, params billing.CancelSubscriptionParams) error {
 	return errors.New("not implemented")
 }
+func (m *mockBillingProvider) UpdateSubscription(ctx context.Context, params billing.UpdateSubscriptionParams) (*billing.Subscription, error) {
+	return nil, errors.New("not implemented")
+}
 func (m *mockBillingProvider) CreateCustomerPortalSession(ctx context.Context, params billing.CreatePortalSessionParams) (*billing.PortalSession, error) {
 	return nil, errors.New("not implemented")
 }
@@ -122,6 +127,70 @@ func (m *mockOrderService) GetOrderByNumber(ctx context.Context, orderNumber str
 	return nil, errors.New("not implemented")
 }
 
+// mockRefundService implements domain.RefundService for testing
+type mockRefundService struct {
+	recordProviderRefundsFunc func(ctx context.Context, params domain.ProviderRefundParams) error
+}
+
+func (m *mockRefundService) RefundOrder(ctx context.Context, params domain.RefundOrderParams) (*repository.Refund, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockRefundService) ListRefunds(ctx context.Context, orderID string) ([]repository.Refund, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockRefundService) RecordProviderRefunds(ctx context.Context, params domain.ProviderRefundParams) error {
+	if m.recordProviderRefundsFunc != nil {
+		return m.recordProviderRefundsFunc(ctx, params)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockRefundService) CancelOrder(ctx context.Context, params domain.CancelOrderParams) error {
+	return errors.New("not implemented")
+}
+
+// mockDunningService implements domain.DunningService for testing
+type mockDunningService struct {
+	recordPaymentFailureFunc func(ctx context.Context, params domain.PaymentFailureParams) error
+	recordPaymentSuccessFunc func(ctx context.Context, providerInvoiceID string) error
+}
+
+func (m *mockDunningService) GetSettings(ctx context.Context) (*domain.DunningSettings, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockDunningService) UpdateSettings(ctx context.Context, settings domain.DunningSettings) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockDunningService) RecordPaymentFailure(ctx context.Context, params domain.PaymentFailureParams) error {
+	if m.recordPaymentFailureFunc != nil {
+		return m.recordPaymentFailureFunc(ctx, params)
+	}
+	return nil
+}
+
+func (m *mockDunningService) RecordPaymentSuccess(ctx context.Context, providerInvoiceID string) error {
+	if m.recordPaymentSuccessFunc != nil {
+		return m.recordPaymentSuccessFunc(ctx, providerInvoiceID)
+	}
+	return nil
+}
+
+func (m *mockDunningService) ProcessDue(ctx context.Context) (*domain.DunningResult, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockDunningService) GetForSubscription(ctx context.Context, subscriptionID string) (*repository.SubscriptionDunning, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockDunningService) PaymentUpdateURL(ctx context.Context, token string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
 // mockSubscriptionService implements domain.SubscriptionService for testing
 type mockSubscriptionService struct {
 	createOrderFromSubscriptionInvoiceFunc func(ctx context.Context, invoiceID string, tenantID pgtype.UUID) (*domain.OrderDetail, error)
@@ -166,6 +235,34 @@ func (m *mockSubscriptionService) CancelSubscription(ctx context.Context, params
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockSubscriptionService) SkipNextDelivery(ctx context.Context, params domain.SkipDeliveryParams) (*domain.SubscriptionDetail, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockSubscriptionService) ChangeFrequency(ctx context.Context, params domain.ChangeFrequencyParams) (*domain.SubscriptionDetail, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockSubscriptionService) ChangeItem(ctx context.Context, params domain.ChangeItemParams) (*domain.SubscriptionDetail, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockSubscriptionService) ChangeShippingAddress(ctx context.Context, params domain.ChangeShippingAddressParams) (*domain.SubscriptionDetail, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockSubscriptionService) ChangePaymentMethod(ctx context.Context, params domain.ChangePaymentMethodParams) (*domain.SubscriptionDetail, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockSubscriptionService) ListSKUOptions(ctx context.Context, tenantID, skuID pgtype.UUID) ([]domain.SubscriptionSKUOption, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockSubscriptionService) ListSubscriptionEvents(ctx context.Context, tenantID, subscriptionID pgtype.UUID, limit int32) ([]domain.SubscriptionEvent, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockSubscriptionService) CreateCustomerPortalSession(ctx context.Context, params domain.PortalSessionParams) (string, error) {
 	return "", errors.New("not implemented")
 }
@@ -206,6 +303,37 @@ func createTestPaymentIntentEvent(tenantID, cartID string) stripe.Event {
 	}
 }
 
+func createTestChargeRefundedEvent(tenantID string) stripe.Event {
+	return stripe.Event{
+		ID:   "evt_test_charge_123",
+		Type: stripe.EventType("charge.refunded"),
+		Data: &stripe.EventData{
+			Raw: json.RawMessage(`{
+				"id": "ch_test_123",
+				"amount": 2500,
+				"amount_refunded": 1000,
+				"currency": "usd",
+				"payment_intent": "pi_test_123",
+				"metadata": {
+					"tenant_id": "` + tenantID + `"
+				},
+				"refunds": {
+					"object": "list",
+					"data": [
+						{
+							"id": "re_test_123",
+							"amount": 1000,
+							"reason": "requested_by_customer",
+							"status": "succeeded",
+							"metadata": {}
+						}
+					]
+				}
+			}`),
+		},
+	}
+}
+
 func createTestInvoiceEvent(eventType, tenantID, subscriptionID string) stripe.Event {
 	evtType := stripe.EventType(eventType)
 	return stripe.Event{
@@ -306,6 +434,8 @@ func TestStripeHandler_HandleWebhook_Security(t *testing.T) {
 				mockProvider,
 				&mockOrderService{},
 				&mockSubscriptionService{},
+				&mockRefundService{},
+				&mockDunningService{},
 				StripeWebhookConfig{
 					WebhookSecret: "test_secret",
 					TenantID:      "tenant_123",
@@ -437,6 +567,8 @@ func TestStripeHandler_HandleWebhook_PaymentIntentSucceeded(t *testing.T) {
 				mockProvider,
 				mockOrderSvc,
 				&mockSubscriptionService{},
+				&mockRefundService{},
+				&mockDunningService{},
 				StripeWebhookConfig{
 					WebhookSecret: "test_secret",
 					TenantID:      tt.configTenantID,
@@ -556,6 +688,8 @@ func TestStripeHandler_HandleWebhook_InvoicePaymentSucceeded(t *testing.T) {
 				mockProvider,
 				&mockOrderService{},
 				mockSubSvc,
+				&mockRefundService{},
+				&mockDunningService{},
 				StripeWebhookConfig{
 					WebhookSecret: "test_secret",
 					TenantID:      tt.configTenantID,
@@ -591,6 +725,100 @@ func TestStripeHandler_HandleWebhook_InvoicePaymentSucceeded(t *testing.T) {
 	}
 }
 
+func TestStripeHandler_HandleWebhook_InvoicePaymentFailed(t *testing.T) {
+	tests := []struct {
+		name               string
+		tenantID           string
+		billingReason      string
+		expectDunningStart bool
+		description        string
+	}{
+		{
+			name:               "starts_retries_for_renewal",
+			tenantID:           "123e4567-e89b-12d3-a456-426614174000",
+			billingReason:      "subscription_cycle",
+			expectDunningStart: true,
+			description:        "A failed renewal should start payment retries",
+		},
+		{
+			name:               "skips_first_payment",
+			tenantID:           "123e4567-e89b-12d3-a456-426614174000",
+			billingReason:      "subscription_create",
+			expectDunningStart: false,
+			description:        "The first payment fails at checkout and is not retried",
+		},
+		{
+			name:               "rejects_mismatched_tenant",
+			tenantID:           "123e4567-e89b-12d3-a456-426614174999",
+			billingReason:      "subscription_cycle",
+			expectDunningStart: false,
+			description:        "Mismatched tenant_id should skip processing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dunningStarted := false
+
+			mockProvider := &mockBillingProvider{
+				verifyWebhookSignatureFunc: func(payload []byte, signature string, secret string) error {
+					return nil
+				},
+			}
+
+			mockSubSvc := &mockSubscriptionService{
+				syncSubscriptionFromWebhookFunc: func(ctx context.Context, params domain.SyncSubscriptionParams) error {
+					return nil
+				},
+			}
+
+			mockDunningSvc := &mockDunningService{
+				recordPaymentFailureFunc: func(ctx context.Context, params domain.PaymentFailureParams) error {
+					dunningStarted = true
+					if tenant.FromContext(ctx) == nil {
+						t.Error("expected tenant in context")
+					}
+					if params.ProviderSubscriptionID != "sub_123" || params.ProviderInvoiceID != "in_test_123" {
+						t.Errorf("unexpected payment failure params: %+v", params)
+					}
+					return nil
+				},
+			}
+
+			handler := NewStripeHandler(
+				mockProvider,
+				&mockOrderService{},
+				mockSubSvc,
+				&mockRefundService{},
+				mockDunningSvc,
+				StripeWebhookConfig{
+					WebhookSecret: "test_secret",
+					TenantID:      "123e4567-e89b-12d3-a456-426614174000",
+				},
+			)
+
+			event := createTestInvoiceEvent("invoice.payment_failed", tt.tenantID, "sub_123")
+			event.Data.Raw = json.RawMessage(strings.Replace(string(event.Data.Raw),
+				`"currency": "usd",`, `"currency": "usd", "billing_reason": "`+tt.billingReason+`",`, 1))
+			payload := mustMarshalEvent(t, event)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/stripe", bytes.NewReader(payload))
+			req.Header.Set("Stripe-Signature", "valid_signature")
+
+			rr := httptest.NewRecorder()
+			handler.HandleWebhook(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Errorf("%s: expected status 200, got %d", tt.description, rr.Code)
+			}
+
+			if dunningStarted != tt.expectDunningStart {
+				t.Errorf("%s: expected retries started = %v, got %v", tt.description, tt.expectDunningStart, dunningStarted)
+			}
+		})
+	}
+}
+
 func TestStripeHandler_HandleWebhook_SubscriptionUpdated(t *testing.T) {
 	validTenantUUID := pgtype.UUID{}
 	_ = validTenantUUID.Scan("123e4567-e89b-12d3-a456-426614174000")
@@ -669,6 +897,8 @@ func TestStripeHandler_HandleWebhook_SubscriptionUpdated(t *testing.T) {
 				mockProvider,
 				&mockOrderService{},
 				mockSubSvc,
+				&mockRefundService{},
+				&mockDunningService{},
 				StripeWebhookConfig{
 					WebhookSecret: "test_secret",
 					TenantID:      tt.configTenantID,
@@ -764,6 +994,8 @@ func TestStripeHandler_HandleWebhook_SubscriptionDeleted(t *testing.T) {
 				mockProvider,
 				&mockOrderService{},
 				mockSubSvc,
+				&mockRefundService{},
+				&mockDunningService{},
 				StripeWebhookConfig{
 					WebhookSecret: "test_secret",
 					TenantID:      tt.configTenantID,
@@ -839,6 +1071,8 @@ func TestStripeHandler_HandleWebhook_ErrorHandling(t *testing.T) {
 				mockProvider,
 				&mockOrderService{},
 				&mockSubscriptionService{},
+				&mockRefundService{},
+				&mockDunningService{},
 				StripeWebhookConfig{
 					WebhookSecret: "test_secret",
 					TenantID:      "tenant_123",
@@ -940,6 +1174,8 @@ func TestStripeHandler_HandleWebhook_AlwaysReturns200ForValidEvents(t *testing.T
 				mockProvider,
 				mockOrderSvc,
 				&mockSubscriptionService{},
+				&mockRefundService{},
+				&mockDunningService{},
 				StripeWebhookConfig{
 					WebhookSecret: "test_secret",
 					TenantID:      "tenant_123",
@@ -976,6 +1212,93 @@ func TestStripeHandler_HandleWebhook_AlwaysReturns200ForValidEvents(t *testing.T
 	}
 }
 
+func TestStripeHandler_HandleWebhook_ChargeRefunded(t *testing.T) {
+	const tenantID = "6f1c2a9e-3b7d-4c1a-9e2f-8d5b4a3c2e1f"
+
+	tests := []struct {
+		name              string
+		chargeTenantID    string
+		serviceError      error
+		expectServiceCall bool
+		description       string
+	}{
+		{
+			name:              "records_refund_for_matching_tenant",
+			chargeTenantID:    tenantID,
+			expectServiceCall: true,
+			description:       "Refunds should be recorded when tenant_id matches",
+		},
+		{
+			name:              "rejects_mismatched_tenant",
+			chargeTenantID:    "1b2c3d4e-0000-0000-0000-000000000000",
+			expectServiceCall: false,
+			description:       "Mismatched tenant_id should skip processing",
+		},
+		{
+			name:              "handles_service_error",
+			chargeTenantID:    tenantID,
+			serviceError:      errors.New("database error"),
+			expectServiceCall: true,
+			description:       "Service errors should be logged but webhook returns 200",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *domain.ProviderRefundParams
+
+			mockRefundSvc := &mockRefundService{
+				recordProviderRefundsFunc: func(ctx context.Context, params domain.ProviderRefundParams) error {
+					got = &params
+					return tt.serviceError
+				},
+			}
+
+			handler := NewStripeHandler(
+				&mockBillingProvider{},
+				&mockOrderService{},
+				&mockSubscriptionService{},
+				mockRefundSvc,
+				&mockDunningService{},
+				StripeWebhookConfig{
+					WebhookSecret: "test_secret",
+					TenantID:      tenantID,
+				},
+			)
+
+			payload := mustMarshalEvent(t, createTestChargeRefundedEvent(tt.chargeTenantID))
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/stripe", bytes.NewReader(payload))
+			req.Header.Set("Stripe-Signature", "valid_signature")
+
+			rr := httptest.NewRecorder()
+			handler.HandleWebhook(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Errorf("%s: expected status 200, got %d", tt.description, rr.Code)
+			}
+
+			if (got != nil) != tt.expectServiceCall {
+				t.Fatalf("%s: expected service call = %v, got %v", tt.description, tt.expectServiceCall, got != nil)
+			}
+
+			if got == nil {
+				return
+			}
+
+			if got.PaymentIntentID != "pi_test_123" || got.ChargeID != "ch_test_123" {
+				t.Errorf("unexpected payment intent/charge: %s/%s", got.PaymentIntentID, got.ChargeID)
+			}
+			if got.AmountRefundedCents != 1000 {
+				t.Errorf("expected amount refunded 1000, got %d", got.AmountRefundedCents)
+			}
+			if len(got.Refunds) != 1 || got.Refunds[0].ID != "re_test_123" || got.Refunds[0].AmountCents != 1000 {
+				t.Errorf("unexpected refunds: %+v", got.Refunds)
+			}
+		})
+	}
+}
+
 func TestStripeHandler_HandleWebhook_TestModeSkipsWithoutMetadata(t *testing.T) {
 	// In test mode, events triggered by Stripe CLI may not have required metadata.
 	// These should be logged but not fail.
@@ -998,6 +1321,8 @@ func TestStripeHandler_HandleWebhook_TestModeSkipsWithoutMetadata(t *testing.T)
 		mockProvider,
 		mockOrderSvc,
 		&mockSubscriptionService{},
+		&mockRefundService{},
+		&mockDunningService{},
 		StripeWebhookConfig{
 			WebhookSecret: "test_secret",
 			TenantID:      "tenant_123",
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/dukerupert/hiri/internal/repository"
@@ -24,16 +23,15 @@ type CleanupExpiredTokensPayload struct {
 }
 
 // EnqueueCleanupExpiredTokens enqueues a job to clean up expired tokens
-// This should be called on a schedule (e.g., daily) to remove expired
-// email verification and password reset tokens
-func EnqueueCleanupExpiredTokens(ctx context.Context, q repository.Querier, tenantID uuid.UUID) error {
+// The scheduler runs this daily to remove expired email verification and
+// password reset tokens. It is a system job, so it has no tenant.
+func EnqueueCleanupExpiredTokens(ctx context.Context, q repository.Querier) error {
 	payloadJSON, err := json.Marshal(CleanupExpiredTokensPayload{})
 	if err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
 	_, err = q.EnqueueJob(ctx, repository.EnqueueJobParams{
-		TenantID:   pgtype.UUID{Bytes: tenantID, Valid: true},
 		JobType:    JobTypeCleanupExpiredTokens,
 		Queue:      "cleanup",
 		Payload:    payloadJSON,
@@ -19,6 +19,8 @@ const (
 	JobTypeEmailVerification         = "email:email_verification"
 	JobTypeOrderConfirmation         = "email:order_confirmation"
 	JobTypeShippingConfirmation      = "email:shipping_confirmation"
+	JobTypeShipmentUpdate            = "email:shipment_update"
+	JobTypeRefundConfirmation        = "email:refund_confirmation"
 	JobTypeSubscriptionWelcome       = "email:subscription_welcome"
 	JobTypeSubscriptionPaymentFailed = "email:subscription_payment_failed"
 	JobTypeSubscriptionCancelled     = "email:subscription_cancelled"
@@ -35,6 +37,15 @@ const (
 	// Wholesale application email jobs
 	JobTypeWholesaleApproved = "email:wholesale_approved"
 	JobTypeWholesaleRejected = "email:wholesale_rejected"
+
+	// Review email jobs
+	JobTypeReviewRequest = "email:review_request"
+
+	// Standing order email jobs
+	JobTypeStandingOrderReminder = "email:standing_order_reminder"
+
+	// Inventory email jobs
+	JobTypeLowStockDigest = "email:low_stock_digest"
 )
 
 // Email job payloads (JSON-serializable)
@@ -66,6 +77,31 @@ type OrderConfirmationPayload struct {
 	ShippingCents int64     `json:"shipping_cents"`
 	TaxCents      int64     `json:"tax_cents"`
 	TotalCents    int64     `json:"total_cents"`
+
+	Items           []OrderItemData `json:"items"`
+	ShippingAddress AddressData     `json:"shipping_address"`
+}
+
+// OrderItemData represents a line item in an order email
+type OrderItemData struct {
+	ProductName  string     `json:"product_name"`
+	VariantName  string     `json:"variant_name"`
+	Quantity     int        `json:"quantity"`
+	TotalCents   int64      `json:"total_cents"`
+	RoastDate    *time.Time `json:"roast_date,omitempty"`    // Roast batch date, if allocated
+	RoastPlanned bool       `json:"roast_planned,omitempty"` // RoastDate is the planned date
+}
+
+// AddressData represents a postal address in an email
+type AddressData struct {
+	Name       string `json:"name"`
+	Company    string `json:"company"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
 }
 
 // ShippingConfirmationPayload represents the payload for a shipping confirmation email job
@@ -79,6 +115,33 @@ type ShippingConfirmationPayload struct {
 	TrackingURL    string    `json:"tracking_url"`
 }
 
+// ShipmentUpdatePayload represents the payload for a delivery or delivery problem email job
+type ShipmentUpdatePayload struct {
+	OrderID        uuid.UUID `json:"order_id"`
+	Email          string    `json:"email"`
+	CustomerName   string    `json:"customer_name"`
+	OrderNumber    string    `json:"order_number"`
+	Status         string    `json:"status"`
+	Message        string    `json:"message"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	Carrier        string    `json:"carrier"`
+	TrackingNumber string    `json:"tracking_number"`
+	TrackingURL    string    `json:"tracking_url"`
+}
+
+// RefundConfirmationPayload represents the payload for a refund confirmation email job
+type RefundConfirmationPayload struct {
+	OrderID            uuid.UUID `json:"order_id"`
+	Email              string    `json:"email"`
+	CustomerName       string    `json:"customer_name"`
+	OrderNumber        string    `json:"order_number"`
+	RefundCents        int64     `json:"refund_cents"`
+	TotalRefundedCents int64     `json:"total_refunded_cents"`
+	OrderTotalCents    int64     `json:"order_total_cents"`
+	FullyRefunded      bool      `json:"fully_refunded"`
+	RefundedAt         time.Time `json:"refunded_at"`
+}
+
 // SubscriptionWelcomePayload represents the payload for a subscription welcome email job
 type SubscriptionWelcomePayload struct {
 	SubscriptionID uuid.UUID `json:"subscription_id"`
@@ -90,12 +153,16 @@ type SubscriptionWelcomePayload struct {
 
 // SubscriptionPaymentFailedPayload represents the payload for a subscription payment failed email job
 type SubscriptionPaymentFailedPayload struct {
-	SubscriptionID uuid.UUID `json:"subscription_id"`
-	Email          string    `json:"email"`
-	CustomerName   string    `json:"customer_name"`
-	ProductName    string    `json:"product_name"`
-	FailedDate     time.Time `json:"failed_date"`
-	RetryDate      time.Time `json:"retry_date"`
+	SubscriptionID   uuid.UUID `json:"subscription_id"`
+	Email            string    `json:"email"`
+	CustomerName     string    `json:"customer_name"`
+	ProductName      string    `json:"product_name"`
+	FailedDate       time.Time `json:"failed_date"`
+	RetryDate        time.Time `json:"retry_date"`
+	AttemptNumber    int       `json:"attempt_number"`
+	FinalAction      string    `json:"final_action,omitempty"`
+	UpdatePaymentURL string    `json:"update_payment_url,omitempty"`
+	ManagementURL    string    `json:"management_url"`
 }
 
 // SubscriptionCancelledPayload represents the payload for a subscription cancelled email job
@@ -208,6 +275,63 @@ type WholesaleRejectedPayload struct {
 	ShopURL         string `json:"shop_url"`
 }
 
+// Review Email Payloads
+
+// ReviewRequestPayload represents the payload for a review request email job
+type ReviewRequestPayload struct {
+	Email        string                  `json:"email"`
+	CustomerName string                  `json:"customer_name"`
+	OrderNumber  string                  `json:"order_number"`
+	Items        []ReviewRequestItemData `json:"items"`
+}
+
+// ReviewRequestItemData represents a product in a review request payload
+type ReviewRequestItemData struct {
+	ProductName string `json:"product_name"`
+	ReviewURL   string `json:"review_url"`
+}
+
+// Standing Order Email Payloads
+
+// StandingOrderReminderPayload represents the payload for a standing order
+// reminder email job
+type StandingOrderReminderPayload struct {
+	Email             string                          `json:"email"`
+	CustomerName      string                          `json:"customer_name"`
+	StandingOrderName string                          `json:"standing_order_name"`
+	DeliveryDate      time.Time                       `json:"delivery_date"`
+	CutoffDate        time.Time                       `json:"cutoff_date"`
+	Items             []StandingOrderReminderItemData `json:"items"`
+	ManageURL         string                          `json:"manage_url"`
+}
+
+// StandingOrderReminderItemData represents a line in a standing order
+// reminder payload
+type StandingOrderReminderItemData struct {
+	ProductName string `json:"product_name"`
+	SKU         string `json:"sku"`
+	Quantity    int32  `json:"quantity"`
+}
+
+// Inventory Email Payloads
+
+// LowStockDigestPayload represents the payload for a low stock digest email job
+type LowStockDigestPayload struct {
+	Email        string             `json:"email"`
+	Name         string             `json:"name"`
+	Items        []LowStockItemData `json:"items"`
+	InventoryURL string             `json:"inventory_url"`
+}
+
+// LowStockItemData represents a SKU in a low stock digest payload
+type LowStockItemData struct {
+	ProductName       string `json:"product_name"`
+	SKU               string `json:"sku"`
+	Variant           string `json:"variant"`
+	InventoryQuantity int32  `json:"inventory_quantity"`
+	LowStockThreshold *int32 `json:"low_stock_threshold,omitempty"`
+}
+
 // Job enqueueing functions
 
 // EnqueuePasswordResetEmail enqueues a password reset email job
@@ -310,6 +434,56 @@ func EnqueueShippingConfirmationEmail(ctx context.Context, q repository.Querier,
 	return err
 }
 
+// EnqueueShipmentUpdateEmail enqueues a delivery or delivery problem email job
+func EnqueueShipmentUpdateEmail(ctx context.Context, q repository.Querier, tenantID uuid.UUID, payload ShipmentUpdatePayload) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	_, err = q.EnqueueJob(ctx, repository.EnqueueJobParams{
+		TenantID:   pgtype.UUID{Bytes: tenantID, Valid: true},
+		JobType:    JobTypeShipmentUpdate,
+		Queue:      "email",
+		Payload:    payloadJSON,
+		Priority:   100,
+		MaxRetries: 3,
+		ScheduledAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		TimeoutSeconds: 30,
+		Metadata:       []byte("{}"),
+	})
+
+	return err
+}
+
+// EnqueueRefundConfirmationEmail enqueues a refund confirmation email job
+func EnqueueRefundConfirmationEmail(ctx context.Context, q repository.Querier, tenantID uuid.UUID, payload RefundConfirmationPayload) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	_, err = q.EnqueueJob(ctx, repository.EnqueueJobParams{
+		TenantID:   pgtype.UUID{Bytes: tenantID, Valid: true},
+		JobType:    JobTypeRefundConfirmation,
+		Queue:      "email",
+		Payload:    payloadJSON,
+		Priority:   100,
+		MaxRetries: 3,
+		ScheduledAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		TimeoutSeconds: 30,
+		Metadata:       []byte("{}"),
+	})
+
+	return err
+}
+
 // EnqueueSubscriptionWelcomeEmail enqueues a subscription welcome email job
 func EnqueueSubscriptionWelcomeEmail(ctx context.Context, q repository.Querier, tenantID uuid.UUID, payload SubscriptionWelcomePayload) error {
 	payloadJSON, err := json.Marshal(payload)
@@ -614,6 +788,84 @@ func EnqueueWholesaleRejectedEmail(ctx context.Context, q repository.Querier, te
 	return err
 }
 
+// Inventory Email Enqueue Functions
+
+// EnqueueReviewRequestEmail enqueues a post-delivery review request email job
+func EnqueueReviewRequestEmail(ctx context.Context, q repository.Querier, tenantID uuid.UUID, payload ReviewRequestPayload) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	_, err = q.EnqueueJob(ctx, repository.EnqueueJobParams{
+		TenantID:   pgtype.UUID{Bytes: tenantID, Valid: true},
+		JobType:    JobTypeReviewRequest,
+		Queue:      "email",
+		Payload:    payloadJSON,
+		Priority:   150, // Marketing, not time sensitive
+		MaxRetries: 3,
+		ScheduledAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		TimeoutSeconds: 30,
+		Metadata:       []byte("{}"),
+	})
+
+	return err
+}
+
+// EnqueueStandingOrderReminderEmail enqueues a reminder before a standing
+// order's cutoff
+func EnqueueStandingOrderReminderEmail(ctx context.Context, q repository.Querier, tenantID uuid.UUID, payload StandingOrderReminderPayload) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	_, err = q.EnqueueJob(ctx, repository.EnqueueJobParams{
+		TenantID:   pgtype.UUID{Bytes: tenantID, Valid: true},
+		JobType:    JobTypeStandingOrderReminder,
+		Queue:      "email",
+		Payload:    payloadJSON,
+		Priority:   100, // Must arrive before the cutoff
+		MaxRetries: 3,
+		ScheduledAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		TimeoutSeconds: 30,
+		Metadata:       []byte("{}"),
+	})
+
+	return err
+}
+
+// EnqueueLowStockDigestEmail enqueues a low stock digest email job
+func EnqueueLowStockDigestEmail(ctx context.Context, q repository.Querier, tenantID uuid.UUID, payload LowStockDigestPayload) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	_, err = q.EnqueueJob(ctx, repository.EnqueueJobParams{
+		TenantID:   pgtype.UUID{Bytes: tenantID, Valid: true},
+		JobType:    JobTypeLowStockDigest,
+		Queue:      "email",
+		Payload:    payloadJSON,
+		Priority:   150, // Daily digest
+		MaxRetries: 3,
+		ScheduledAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		TimeoutSeconds: 30,
+		Metadata:       []byte("{}"),
+	})
+
+	return err
+}
+
 // ProcessEmailJob processes an email job based on its type
 func ProcessEmailJob(ctx context.Context, job *repository.Job, emailService *email.Service, queries *repository.Queries) error {
 	switch job.JobType {
@@ -653,17 +905,43 @@ func ProcessEmailJob(ctx context.Context, job *repository.Job, emailService *ema
 			return fmt.Errorf("failed to unmarshal order confirmation payload: %w", err)
 		}
 
+		// Convert items
+		items := make([]email.OrderItem, len(payload.Items))
+		for i, item := range payload.Items {
+			items[i] = email.OrderItem{
+				ProductName:  item.ProductName,
+				VariantName:  item.VariantName,
+				Quantity:     item.Quantity,
+				TotalCents:   item.TotalCents,
+				RoastPlanned: item.RoastPlanned,
+			}
+			if item.RoastDate != nil {
+				items[i].RoastDate = *item.RoastDate
+			}
+		}
+
+		addr := payload.ShippingAddress
 		emailData := email.OrderConfirmationEmail{
+			Email:         payload.Email,
 			OrderNumber:   payload.OrderNumber,
-			CustomerName:  payload.Email,
+			CustomerName:  payload.CustomerName,
 			OrderDate:     payload.OrderDate,
-			Items:         []email.OrderItem{},
+			Items:         items,
 			SubtotalCents: payload.SubtotalCents,
 			ShippingCents: payload.ShippingCents,
 			TaxCents:      payload.TaxCents,
 			TotalCents:    payload.TotalCents,
-			ShippingAddr:  email.Address{},
-			BillingAddr:   email.Address{},
+			ShippingAddr: email.Address{
+				Name:       addr.Name,
+				Company:    addr.Company,
+				Line1:      addr.Line1,
+				Line2:      addr.Line2,
+				City:       addr.City,
+				State:      addr.State,
+				PostalCode: addr.PostalCode,
+				Country:    addr.Country,
+			},
+			BillingAddr: email.Address{},
 		}
 
 		return emailService.SendOrderConfirmation(ctx, emailData)
@@ -687,6 +965,45 @@ func ProcessEmailJob(ctx context.Context, job *repository.Job, emailService *ema
 
 		return emailService.SendShippingConfirmation(ctx, emailData)
 
+	case JobTypeShipmentUpdate:
+		var payload ShipmentUpdatePayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal shipment update payload: %w", err)
+		}
+
+		emailData := email.ShipmentUpdateEmail{
+			Email:          payload.Email,
+			CustomerName:   payload.CustomerName,
+			OrderNumber:    payload.OrderNumber,
+			Status:         payload.Status,
+			Message:        payload.Message,
+			UpdatedAt:      payload.UpdatedAt,
+			Carrier:        payload.Carrier,
+			TrackingNumber: payload.TrackingNumber,
+			TrackingURL:    payload.TrackingURL,
+		}
+
+		return emailService.SendShipmentUpdate(ctx, emailData)
+
+	case JobTypeRefundConfirmation:
+		var payload RefundConfirmationPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal refund confirmation payload: %w", err)
+		}
+
+		emailData := email.RefundConfirmationEmail{
+			Email:              payload.Email,
+			CustomerName:       payload.CustomerName,
+			OrderNumber:        payload.OrderNumber,
+			RefundedDate:       payload.RefundedAt,
+			RefundCents:        payload.RefundCents,
+			TotalRefundedCents: payload.TotalRefundedCents,
+			OrderTotalCents:    payload.OrderTotalCents,
+			FullyRefunded:      payload.FullyRefunded,
+		}
+
+		return emailService.SendRefundConfirmation(ctx, emailData)
+
 	case JobTypeSubscriptionWelcome:
 		var payload SubscriptionWelcomePayload
 		if err := json.Unmarshal(job.Payload, &payload); err != nil {
@@ -712,12 +1029,15 @@ func ProcessEmailJob(ctx context.Context, job *repository.Job, emailService *ema
 		}
 
 		emailData := email.SubscriptionPaymentFailedEmail{
-			CustomerName:     payload.Email,
+			Email:            payload.Email,
+			CustomerName:     payload.CustomerName,
 			ProductName:      payload.ProductName,
 			FailedDate:       payload.FailedDate,
 			RetryDate:        payload.RetryDate,
-			UpdatePaymentURL: "/account/payment-methods",
-			ManagementURL:    "/account/subscriptions",
+			AttemptNumber:    payload.AttemptNumber,
+			FinalAction:      payload.FinalAction,
+			UpdatePaymentURL: payload.UpdatePaymentURL,
+			ManagementURL:    payload.ManagementURL,
 		}
 
 		return emailService.SendSubscriptionPaymentFailed(ctx, emailData)
@@ -901,6 +1221,85 @@ func ProcessEmailJob(ctx context.Context, job *repository.Job, emailService *ema
 
 		return emailService.SendWholesaleRejected(ctx, emailData)
 
+	// Review Email Jobs
+	case JobTypeReviewRequest:
+		var payload ReviewRequestPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal review request payload: %w", err)
+		}
+
+		items := make([]email.ReviewRequestItem, len(payload.Items))
+		for i, item := range payload.Items {
+			items[i] = email.ReviewRequestItem{
+				ProductName: item.ProductName,
+				ReviewURL:   item.ReviewURL,
+			}
+		}
+
+		emailData := email.ReviewRequestEmail{
+			Email:        payload.Email,
+			CustomerName: payload.CustomerName,
+			OrderNumber:  payload.OrderNumber,
+			Items:        items,
+		}
+
+		return emailService.SendReviewRequest(ctx, emailData)
+
+	// Standing Order Email Jobs
+	case JobTypeStandingOrderReminder:
+		var payload StandingOrderReminderPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal standing order reminder payload: %w", err)
+		}
+
+		items := make([]email.StandingOrderReminderItem, len(payload.Items))
+		for i, item := range payload.Items {
+			items[i] = email.StandingOrderReminderItem{
+				ProductName: item.ProductName,
+				SKU:         item.SKU,
+				Quantity:    item.Quantity,
+			}
+		}
+
+		emailData := email.StandingOrderReminderEmail{
+			Email:             payload.Email,
+			CustomerName:      payload.CustomerName,
+			StandingOrderName: payload.StandingOrderName,
+			DeliveryDate:      payload.DeliveryDate,
+			CutoffDate:        payload.CutoffDate,
+			Items:             items,
+			ManageURL:         payload.ManageURL,
+		}
+
+		return emailService.SendStandingOrderReminder(ctx, emailData)
+
+	// Inventory Email Jobs
+	case JobTypeLowStockDigest:
+		var payload LowStockDigestPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal low stock digest payload: %w", err)
+		}
+
+		items := make([]email.LowStockItem, len(payload.Items))
+		for i, item := range payload.Items {
+			items[i] = email.LowStockItem{
+				ProductName:       item.ProductName,
+				SKU:               item.SKU,
+				Variant:           item.Variant,
+				InventoryQuantity: item.InventoryQuantity,
+				LowStockThreshold: item.LowStockThreshold,
+			}
+		}
+
+		emailData := email.LowStockDigestEmail{
+			Email:        payload.Email,
+			Name:         payload.Name,
+			Items:        items,
+			InventoryURL: payload.InventoryURL,
+		}
+
+		return emailService.SendLowStockDigest(ctx, emailData)
+
 	default:
 		return fmt.Errorf("unknown job type: %s", job.JobType)
 	}
@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Job type constants for fulfillment jobs
+const (
+	JobTypeProcessFulfillmentBatch = "fulfillment:process_batch"
+	JobTypePollShipmentTracking    = "fulfillment:poll_tracking"
+)
+
+// ProcessFulfillmentBatchPayload represents the payload for buying a batch's labels
+type ProcessFulfillmentBatchPayload struct {
+	BatchID uuid.UUID `json:"batch_id"`
+}
+
+// EnqueueProcessFulfillmentBatch enqueues a job to buy labels for a fulfillment batch
+func EnqueueProcessFulfillmentBatch(ctx context.Context, q repository.Querier, tenantID uuid.UUID, payload ProcessFulfillmentBatchPayload) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	_, err = q.EnqueueJob(ctx, repository.EnqueueJobParams{
+		TenantID:   pgtype.UUID{Bytes: tenantID, Valid: true},
+		JobType:    JobTypeProcessFulfillmentBatch,
+		Queue:      "fulfillment",
+		Payload:    payloadJSON,
+		Priority:   50, // Staff are waiting on the labels
+		MaxRetries: 3,  // Purchased orders are skipped on retry
+		ScheduledAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		TimeoutSeconds: 900, // One carrier round trip per order, up to 100 orders
+		Metadata:       []byte("{}"),
+	})
+
+	return err
+}
+
+// EnqueuePollShipmentTracking enqueues a job to refresh tracking for in-transit shipments
+func EnqueuePollShipmentTracking(ctx context.Context, q repository.Querier, tenantID uuid.UUID) error {
+	_, err := q.EnqueueJob(ctx, repository.EnqueueJobParams{
+		TenantID:   pgtype.UUID{Bytes: tenantID, Valid: true},
+		JobType:    JobTypePollShipmentTracking,
+		Queue:      "fulfillment",
+		Payload:    []byte("{}"),
+		Priority:   200, // Background refresh; webhooks cover most updates
+		MaxRetries: 1,   // The next scheduled poll picks up where this one stopped
+		ScheduledAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		TimeoutSeconds: 600, // One carrier request per shipment, up to 100 shipments
+		Metadata:       []byte("{}"),
+	})
+
+	return err
+}
@@ -0,0 +1,45 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Job type constants for inventory jobs
+const (
+	JobTypeCheckLowStock = "inventory:check_low_stock"
+)
+
+// EnqueueCheckLowStock enqueues a job to email operators the SKUs running low
+func EnqueueCheckLowStock(ctx context.Context, q repository.Querier, tenantID uuid.UUID) error {
+	_, err := q.EnqueueJob(ctx, repository.EnqueueJobParams{
+		TenantID:   pgtype.UUID{Bytes: tenantID, Valid: true},
+		JobType:    JobTypeCheckLowStock,
+		Queue:      "inventory",
+		Payload:    []byte("{}"),
+		Priority:   200, // Daily digest; nobody is waiting on it
+		MaxRetries: 3,
+		ScheduledAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		TimeoutSeconds: 60,
+		Metadata:       []byte("{}"),
+	})
+
+	return err
+}
+
+// IsInventoryJob checks if a job type is an inventory job
+func IsInventoryJob(jobType string) bool {
+	switch jobType {
+	case JobTypeCheckLowStock:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Job type constants for SaaS onboarding jobs
+const (
+	JobTypeExpireGracePeriods = "onboarding:expire_grace_periods"
+)
+
+// EnqueueExpireGracePeriods enqueues a job to suspend tenants whose
+// payment grace period has ended. It is a system job, so it has no tenant.
+func EnqueueExpireGracePeriods(ctx context.Context, q repository.Querier) error {
+	_, err := q.EnqueueJob(ctx, repository.EnqueueJobParams{
+		JobType:    JobTypeExpireGracePeriods,
+		Queue:      "onboarding",
+		Payload:    []byte("{}"),
+		Priority:   50,
+		MaxRetries: 3,
+		ScheduledAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		TimeoutSeconds: 120,
+		Metadata:       []byte("{}"),
+	})
+
+	return err
+}
+
+// IsOnboardingJob checks if a job type is an onboarding job
+func IsOnboardingJob(jobType string) bool {
+	switch jobType {
+	case JobTypeExpireGracePeriods:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Job type constants for review jobs
+const (
+	JobTypeSendReviewRequests = "reviews:send_requests"
+)
+
+// EnqueueSendReviewRequests enqueues a job to email review requests for
+// recently delivered orders
+func EnqueueSendReviewRequests(ctx context.Context, q repository.Querier, tenantID uuid.UUID) error {
+	_, err := q.EnqueueJob(ctx, repository.EnqueueJobParams{
+		TenantID:   pgtype.UUID{Bytes: tenantID, Valid: true},
+		JobType:    JobTypeSendReviewRequests,
+		Queue:      "reviews",
+		Payload:    []byte("{}"),
+		Priority:   200, // Daily sweep; nobody is waiting on it
+		MaxRetries: 3,
+		ScheduledAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		TimeoutSeconds: 120,
+		Metadata:       []byte("{}"),
+	})
+
+	return err
+}
+
+// IsReviewJob checks if a job type is a review job
+func IsReviewJob(jobType string) bool {
+	switch jobType {
+	case JobTypeSendReviewRequests:
+		return true
+	}
+	return false
+}
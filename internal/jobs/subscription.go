@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Job type constants for subscription jobs
+const (
+	JobTypeProcessDunning = "subscription:process_dunning"
+)
+
+// EnqueueProcessDunning enqueues a job to retry failed subscription payments
+// that are due
+func EnqueueProcessDunning(ctx context.Context, q repository.Querier, tenantID uuid.UUID) error {
+	_, err := q.EnqueueJob(ctx, repository.EnqueueJobParams{
+		TenantID:   pgtype.UUID{Bytes: tenantID, Valid: true},
+		JobType:    JobTypeProcessDunning,
+		Queue:      "subscription",
+		Payload:    []byte("{}"),
+		Priority:   100,
+		MaxRetries: 3,
+		ScheduledAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		TimeoutSeconds: 300, // Up to 100 payment retries against Stripe
+		Metadata:       []byte("{}"),
+	})
+
+	return err
+}
+
+// IsSubscriptionJob checks if a job type is a subscription job
+func IsSubscriptionJob(jobType string) bool {
+	switch jobType {
+	case JobTypeProcessDunning:
+		return true
+	}
+	return false
+}
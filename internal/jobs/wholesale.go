@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Job type constants for wholesale jobs
+const (
+	JobTypeProcessStandingOrders = "wholesale:process_standing_orders"
+)
+
+// EnqueueProcessStandingOrders enqueues a job to place standing orders whose
+// cutoff has been reached and remind customers of upcoming ones
+func EnqueueProcessStandingOrders(ctx context.Context, q repository.Querier, tenantID uuid.UUID) error {
+	_, err := q.EnqueueJob(ctx, repository.EnqueueJobParams{
+		TenantID:   pgtype.UUID{Bytes: tenantID, Valid: true},
+		JobType:    JobTypeProcessStandingOrders,
+		Queue:      "wholesale",
+		Payload:    []byte("{}"),
+		Priority:   100,
+		MaxRetries: 3,
+		ScheduledAt: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		TimeoutSeconds: 600, // Up to 100 orders, each re-quoting shipping
+		Metadata:       []byte("{}"),
+	})
+
+	return err
+}
+
+// IsWholesaleJob checks if a job type is a wholesale job
+func IsWholesaleJob(jobType string) bool {
+	switch jobType {
+	case JobTypeProcessStandingOrders:
+		return true
+	}
+	return false
+}
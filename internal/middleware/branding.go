@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/dukerupert/hiri/internal/domain"
+)
+
+// BrandingContextKey is the context key for storing the tenant's branding
+const BrandingContextKey contextKey = "branding"
+
+// WithBranding loads the tenant's branding and adds it to the request context
+// for the storefront layout. Must run after the tenant is resolved.
+// If the branding can't be loaded the request continues with the default look.
+func WithBranding(brandingService domain.BrandingService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			branding, err := brandingService.GetBranding(r.Context())
+			if err != nil {
+				slog.Warn("branding: failed to load tenant branding",
+					"error", err,
+					"path", r.URL.Path,
+				)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), BrandingContextKey, branding)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetBrandingFromContext retrieves the tenant's branding from the context.
+// Returns nil when WithBranding hasn't run or failed.
+func GetBrandingFromContext(ctx context.Context) *domain.Branding {
+	branding, ok := ctx.Value(BrandingContextKey).(*domain.Branding)
+	if !ok {
+		return nil
+	}
+	return branding
+}
@@ -136,7 +136,7 @@ func RequireActiveTenant(queries *repository.Queries) func(http.Handler) http.Ha
 			}
 
 			// Add tenant to context
-			ctx := context.WithValue(r.Context(), TenantContextKey, &tenant)
+			ctx := withOperatorTenant(r.Context(), &tenant)
 
 			// Check tenant status
 			switch tenant.Status {
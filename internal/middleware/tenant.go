@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/dukerupert/hiri/internal/repository"
 	"github.com/dukerupert/hiri/internal/tenant"
 )
 
@@ -225,54 +226,31 @@ func respondTenantInternalError(w http.ResponseWriter, r *http.Request, err erro
 	w.Write([]byte("Internal Server Error"))
 }
 
-// MIGRATION NOTES:
+// WithTenant creates middleware that serves every request as the given tenant.
 //
-// There are two sources of tenant context in the application:
-//
-// 1. Storefront routes (subdomain/custom domain routing):
-//    - Set by ResolveTenant middleware
-//    - Uses tenant.NewContext() / tenant.FromContext()
-//    - Tenant resolved from host header
-//
-// 2. Admin routes (operator session):
-//    - Set by RequireActiveTenant middleware in operator.go
-//    - Uses TenantContextKey from operator.go
-//    - Tenant resolved from operator's TenantID
-//
-// After migration, both should use the tenant package consistently.
-// The operator middleware should be updated to use tenant.NewContext()
-// instead of context.WithValue(ctx, TenantContextKey, &tenant).
-//
-// TODO: Update operator.go to use tenant package:
-//
-// BEFORE (current):
-//   ctx = context.WithValue(r.Context(), TenantContextKey, &tenant)
-//
-// AFTER (migrated):
-//   t := &tenant.Tenant{
-//       ID:     repoTenant.ID,
-//       Slug:   repoTenant.Slug,
-//       Name:   repoTenant.Name,
-//       Status: repoTenant.Status,
-//   }
-//   ctx = tenant.NewContext(r.Context(), t)
-//
-// This ensures services can use tenant.IDFromContext() regardless of
-// whether the request came via storefront or admin routes.
-
-// bridgeTenantContext creates a tenant.Tenant from the repository.Tenant
-// stored by operator middleware. This is a temporary bridge during migration.
-//
-// TODO: Remove after operator.go is updated to use tenant package directly.
-func bridgeTenantContext(ctx context.Context) *tenant.Tenant {
-	// Check for tenant from new tenant package first
-	if t := tenant.FromContext(ctx); t != nil {
-		return t
+// This is used in single-tenant mode (host routing disabled), where the
+// storefront belongs to the configured tenant. It is applied ahead of
+// WithUser so sessions are looked up within the tenant.
+func WithTenant(t *tenant.Tenant) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := tenant.NewContext(r.Context(), t)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
 	}
+}
 
-	// Fall back to operator middleware's tenant context
-	// This requires importing repository package which creates a dependency cycle
-	// For now, this is documented as a TODO for the implementer
-
-	return nil
+// withOperatorTenant adds the operator's tenant to the context.
+//
+// The tenant is stored under TenantContextKey for admin handlers and
+// through tenant.NewContext so services can use tenant.IDFromContext,
+// whether the request came via storefront or admin routes.
+func withOperatorTenant(ctx context.Context, t *repository.Tenant) context.Context {
+	ctx = context.WithValue(ctx, TenantContextKey, t)
+	return tenant.NewContext(ctx, &tenant.Tenant{
+		ID:     t.ID,
+		Slug:   t.Slug,
+		Name:   t.Name,
+		Status: t.Status,
+	})
 }
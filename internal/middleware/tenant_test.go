@@ -615,6 +615,33 @@ func Test_RequireTenant(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// TESTS: WithTenant Middleware
+// =============================================================================
+
+func Test_WithTenant(t *testing.T) {
+	defaultTenant := newActiveTenant("acme", "Acme Coffee")
+
+	var tenantCtx *tenant.Tenant
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantCtx = tenant.FromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	wrappedHandler := WithTenant(defaultTenant)(RequireTenant(handler))
+
+	// Any host is served as the configured tenant
+	req := httptest.NewRequest("GET", "/products", nil)
+	req.Host = "localhost:3000"
+	rec := httptest.NewRecorder()
+
+	wrappedHandler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, tenantCtx)
+	assert.Equal(t, defaultTenant.ID, tenantCtx.ID)
+}
+
 // =============================================================================
 // TESTS: User Authentication (auth.go)
 // =============================================================================
@@ -0,0 +1,320 @@
+// Package pdf writes simple multi-page PDF documents.
+//
+// It covers what fulfillment paperwork needs and nothing more: single lines
+// of text in the standard Helvetica fonts, rules, and raster images such as
+// shipping labels. Every PDF reader ships the standard fonts, so no font data
+// is embedded.
+//
+// Coordinates and sizes are in points (1/72 inch), measured from the top-left
+// corner of the page.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// PointsPerInch converts inches to points.
+const PointsPerInch = 72.0
+
+// Font selects one of the standard PDF fonts.
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+// resourceName returns the font's name in page resources.
+func (f Font) resourceName() string {
+	if f == HelveticaBold {
+		return "F2"
+	}
+	return "F1"
+}
+
+// Document is a PDF document under construction.
+type Document struct {
+	pages []*Page
+}
+
+// Page is a single page of a Document.
+type Page struct {
+	width   float64
+	height  float64
+	content bytes.Buffer
+	images  []*pageImage
+}
+
+// pageImage is an image XObject drawn on a page.
+type pageImage struct {
+	width      int
+	height     int
+	colorSpace string // DeviceGray or DeviceRGB
+	data       []byte // zlib-compressed samples
+}
+
+// New creates an empty document.
+func New() *Document {
+	return &Document{}
+}
+
+// AddPage appends a page of the given size and returns it for drawing.
+func (d *Document) AddPage(width, height float64) *Page {
+	p := &Page{width: width, height: height}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// PageCount returns the number of pages added so far.
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Width returns the page width in points.
+func (p *Page) Width() float64 {
+	return p.width
+}
+
+// Height returns the page height in points.
+func (p *Page) Height() float64 {
+	return p.height
+}
+
+// Text draws a single line of text with its baseline at y.
+// Characters outside Windows-1252 are replaced.
+func (p *Page) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		font.resourceName(), num(size), num(x), num(p.height-y), escapeText(s))
+}
+
+// Line draws a straight line of the given stroke width.
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "q %s w %s %s m %s %s l S Q\n",
+		num(width), num(x1), num(p.height-y1), num(x2), num(p.height-y2))
+}
+
+// Image draws img scaled to fit the box at (x, y) with size (w, h),
+// preserving its aspect ratio and centring it in the box.
+// Transparent pixels are drawn as white.
+func (p *Page) Image(img image.Image, x, y, w, h float64) error {
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return fmt.Errorf("pdf: empty image")
+	}
+
+	pi, err := newPageImage(img)
+	if err != nil {
+		return err
+	}
+	p.images = append(p.images, pi)
+
+	scale := min(w/float64(pi.width), h/float64(pi.height))
+	drawW := float64(pi.width) * scale
+	drawH := float64(pi.height) * scale
+	drawX := x + (w-drawW)/2
+	drawY := y + (h-drawH)/2
+
+	fmt.Fprintf(&p.content, "q %s 0 0 %s %s %s cm /Im%d Do Q\n",
+		num(drawW), num(drawH), num(drawX), num(p.height-drawY-drawH), len(p.images))
+	return nil
+}
+
+// newPageImage flattens img onto white and compresses its samples.
+// Images with no colour are stored as greyscale, which keeps labels small.
+func newPageImage(img image.Image) (*pageImage, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	gray := make([]byte, 0, width*height)
+	rgb := make([]byte, 0, width*height*3)
+	isGray := true
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			// Colours are alpha-premultiplied; add the uncovered white
+			white := 0xffff - a
+			r8, g8, b8 := byte((r+white)>>8), byte((g+white)>>8), byte((b+white)>>8)
+			if r8 != g8 || g8 != b8 {
+				isGray = false
+			}
+			gray = append(gray, r8)
+			rgb = append(rgb, r8, g8, b8)
+		}
+	}
+
+	samples, colorSpace := rgb, "DeviceRGB"
+	if isGray {
+		samples, colorSpace = gray, "DeviceGray"
+	}
+
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(samples); err != nil {
+		return nil, fmt.Errorf("pdf: failed to compress image: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("pdf: failed to compress image: %w", err)
+	}
+
+	return &pageImage{width: width, height: height, colorSpace: colorSpace, data: buf.Bytes()}, nil
+}
+
+// WriteTo writes the document in PDF format.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	out := &pdfWriter{w: w}
+
+	// Objects 1-4 are fixed; pages and their resources follow
+	const (
+		catalogObj = 1
+		pagesObj   = 2
+		fontObj    = 3
+		boldObj    = 4
+	)
+
+	// Assign object numbers: page, content stream, then one per image
+	pageObjs := make([]int, len(d.pages))
+	next := 5
+	for i, p := range d.pages {
+		pageObjs[i] = next
+		next += 2 + len(p.images)
+	}
+
+	out.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+
+	out.object(catalogObj, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj))
+
+	kids := make([]string, len(pageObjs))
+	for i, obj := range pageObjs {
+		kids[i] = fmt.Sprintf("%d 0 R", obj)
+	}
+	out.object(pagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+
+	out.object(fontObj, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	out.object(boldObj, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, p := range d.pages {
+		pageObj := pageObjs[i]
+		contentObj := pageObj + 1
+
+		var xobjects strings.Builder
+		for j := range p.images {
+			fmt.Fprintf(&xobjects, " /Im%d %d 0 R", j+1, contentObj+1+j)
+		}
+
+		out.object(pageObj, fmt.Sprintf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> /XObject <<%s >> >> /Contents %d 0 R >>",
+			pagesObj, num(p.width), num(p.height), fontObj, boldObj, xobjects.String(), contentObj))
+
+		var content bytes.Buffer
+		zw := zlib.NewWriter(&content)
+		if _, err := zw.Write(p.content.Bytes()); err != nil {
+			return out.n, fmt.Errorf("pdf: failed to compress page: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return out.n, fmt.Errorf("pdf: failed to compress page: %w", err)
+		}
+		out.stream(contentObj, "/Filter /FlateDecode", content.Bytes())
+
+		for j, img := range p.images {
+			out.stream(contentObj+1+j, fmt.Sprintf(
+				"/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /FlateDecode",
+				img.width, img.height, img.colorSpace), img.data)
+		}
+	}
+
+	// Cross-reference table: one entry per object, object 0 is the free list head
+	xrefOffset := out.n
+	out.printf("xref\n0 %d\n0000000000 65535 f \n", next)
+	for obj := 1; obj < next; obj++ {
+		out.printf("%010d 00000 n \n", out.offsets[obj])
+	}
+	out.printf("trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", next, catalogObj, xrefOffset)
+
+	return out.n, out.err
+}
+
+// pdfWriter tracks byte offsets of objects as they are written.
+type pdfWriter struct {
+	w       io.Writer
+	n       int64
+	offsets map[int]int64
+	err     error
+}
+
+func (pw *pdfWriter) printf(format string, args ...any) {
+	if pw.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(pw.w, format, args...)
+	pw.n += int64(n)
+	pw.err = err
+}
+
+func (pw *pdfWriter) write(b []byte) {
+	if pw.err != nil {
+		return
+	}
+	n, err := pw.w.Write(b)
+	pw.n += int64(n)
+	pw.err = err
+}
+
+func (pw *pdfWriter) object(num int, body string) {
+	pw.mark(num)
+	pw.printf("%d 0 obj\n%s\nendobj\n", num, body)
+}
+
+func (pw *pdfWriter) stream(num int, dict string, data []byte) {
+	pw.mark(num)
+	pw.printf("%d 0 obj\n<< %s /Length %d >>\nstream\n", num, dict, len(data))
+	pw.write(data)
+	pw.printf("\nendstream\nendobj\n")
+}
+
+func (pw *pdfWriter) mark(num int) {
+	if pw.offsets == nil {
+		pw.offsets = make(map[int]int64)
+	}
+	pw.offsets[num] = pw.n
+}
+
+// winAnsi encodes text for the standard fonts' WinAnsiEncoding.
+var winAnsi = encoding.ReplaceUnsupported(charmap.Windows1252.NewEncoder())
+
+// escapeText encodes s as the contents of a PDF literal string.
+func escapeText(s string) string {
+	encoded, err := winAnsi.String(s)
+	if err != nil {
+		encoded = s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(encoded); i++ {
+		c := encoded[i]
+		switch c {
+		case '\\', '(', ')':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\n', '\r', '\t':
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// num formats a coordinate with at most two decimal places.
+func num(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
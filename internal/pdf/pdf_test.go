@@ -0,0 +1,95 @@
+package pdf
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_WriteTo(t *testing.T) {
+	label := image.NewGray(image.Rect(0, 0, 40, 60))
+	for y := 0; y < 60; y++ {
+		label.SetGray(y%40, y, color.Gray{Y: 0})
+	}
+
+	doc := New()
+	first := doc.AddPage(4*PointsPerInch, 6*PointsPerInch)
+	require.NoError(t, first.Image(label, 0, 0, first.Width(), first.Height()))
+	second := doc.AddPage(4*PointsPerInch, 6*PointsPerInch)
+	second.Text(18, 30, HelveticaBold, 14, "Packing Slip (ORD-1001)")
+	second.Line(18, 40, 270, 40, 0.5)
+
+	var buf bytes.Buffer
+	n, err := doc.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+
+	out := buf.String()
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-1.4")))
+	assert.Contains(t, out, "/Count 2")
+	assert.Contains(t, out, "/ColorSpace /DeviceGray")
+	assert.Contains(t, out, "/MediaBox [0 0 288 432]")
+	assert.True(t, bytes.HasSuffix(buf.Bytes(), []byte("%%EOF\n")))
+
+	// startxref must point at the cross-reference table
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(out)
+	require.Len(t, m, 2)
+	offset, err := strconv.Atoi(m[1])
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(buf.Bytes()[offset:], []byte("xref\n")))
+
+	// Every xref entry must point at the start of its object
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(out, -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		pos, _ := strconv.Atoi(entry[1])
+		assert.True(t, bytes.HasPrefix(buf.Bytes()[pos:], []byte(strconv.Itoa(i+1)+" 0 obj")), "object %d", i+1)
+	}
+}
+
+func TestImage_ColorUsesRGB(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+
+	doc := New()
+	page := doc.AddPage(100, 100)
+	require.NoError(t, page.Image(img, 0, 0, 100, 100))
+
+	var buf bytes.Buffer
+	_, err := doc.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "/ColorSpace /DeviceRGB")
+}
+
+func TestEscapeText(t *testing.T) {
+	assert.Equal(t, `a \(b\) \\ c`, escapeText(`a (b) \ c`))
+	assert.Equal(t, "caf\xe9", escapeText("café"))
+	assert.Equal(t, "line one two", escapeText("line one\ntwo"))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "Short", Truncate(Helvetica, 10, 100, "Short"))
+
+	long := "Ethiopia Yirgacheffe Natural Process Limited Release"
+	truncated := Truncate(Helvetica, 10, 100, long)
+	assert.Less(t, len(truncated), len(long))
+	assert.LessOrEqual(t, TextWidth(Helvetica, 10, truncated), 100.0)
+	assert.Contains(t, truncated, "...")
+}
+
+func TestWrap(t *testing.T) {
+	lines := Wrap(Helvetica, 10, 100, "Please leave the package at the side door behind the gate")
+	require.Greater(t, len(lines), 1)
+	for _, line := range lines {
+		assert.LessOrEqual(t, TextWidth(Helvetica, 10, line), 100.0)
+	}
+	assert.Equal(t, "Please leave the", lines[0])
+
+	assert.Empty(t, Wrap(Helvetica, 10, 100, "   "))
+}
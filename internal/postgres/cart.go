@@ -31,6 +31,11 @@ func NewCartService(repo repository.Querier) *CartService {
 
 // GetOrCreateCart retrieves an existing cart or creates a new session and cart.
 func (s *CartService) GetOrCreateCart(ctx context.Context, sessionID string) (*domain.Cart, string, error) {
+	tenantID, err := service.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
 	var sessionUUID pgtype.UUID
 
 	if sessionID == "" {
@@ -56,7 +61,10 @@ func (s *CartService) GetOrCreateCart(ctx context.Context, sessionID string) (*d
 		sessionUUID = session.ID
 	} else {
 		if err := sessionUUID.Scan(sessionID); err == nil {
-			cart, err := s.repo.GetCartBySessionID(ctx, sessionUUID)
+			cart, err := s.repo.GetCartBySessionID(ctx, repository.GetCartBySessionIDParams{
+				TenantID:  tenantID,
+				SessionID: sessionUUID,
+			})
 			if err == nil {
 				return mapRepoCartToDomain(cart), sessionID, nil
 			}
@@ -68,7 +76,10 @@ func (s *CartService) GetOrCreateCart(ctx context.Context, sessionID string) (*d
 			if err == nil {
 				sessionUUID = session.ID
 
-				cart, err := s.repo.GetCartBySessionID(ctx, sessionUUID)
+				cart, err := s.repo.GetCartBySessionID(ctx, repository.GetCartBySessionIDParams{
+					TenantID:  tenantID,
+					SessionID: sessionUUID,
+				})
 				if err == nil {
 					return mapRepoCartToDomain(cart), sessionID, nil
 				}
@@ -96,11 +107,6 @@ func (s *CartService) GetOrCreateCart(ctx context.Context, sessionID string) (*d
 		}
 	}
 
-	tenantID, err := service.ExtractTenantID(ctx)
-	if err != nil {
-		return nil, "", err
-	}
-
 	cart, err := s.repo.CreateCart(ctx, repository.CreateCartParams{
 		TenantID:  tenantID,
 		SessionID: sessionUUID,
@@ -114,10 +120,18 @@ func (s *CartService) GetOrCreateCart(ctx context.Context, sessionID string) (*d
 
 // GetCart retrieves an existing cart by session ID.
 func (s *CartService) GetCart(ctx context.Context, sessionID string) (*domain.Cart, error) {
+	tenantID, err := service.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var sessionUUID pgtype.UUID
 
 	if err := sessionUUID.Scan(sessionID); err == nil {
-		cart, err := s.repo.GetCartBySessionID(ctx, sessionUUID)
+		cart, err := s.repo.GetCartBySessionID(ctx, repository.GetCartBySessionIDParams{
+			TenantID:  tenantID,
+			SessionID: sessionUUID,
+		})
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				return nil, domain.ErrCartNotFound
@@ -135,7 +149,10 @@ func (s *CartService) GetCart(ctx context.Context, sessionID string) (*domain.Ca
 		return nil, fmt.Errorf("failed to get session by token: %w", err)
 	}
 
-	cart, err := s.repo.GetCartBySessionID(ctx, session.ID)
+	cart, err := s.repo.GetCartBySessionID(ctx, repository.GetCartBySessionIDParams{
+		TenantID:  tenantID,
+		SessionID: session.ID,
+	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrCartNotFound
@@ -146,6 +163,56 @@ func (s *CartService) GetCart(ctx context.Context, sessionID string) (*domain.Ca
 	return mapRepoCartToDomain(cart), nil
 }
 
+// SetUser links the cart to a signed-in customer and reprices its items from
+// the customer's price list.
+func (s *CartService) SetUser(ctx context.Context, cartID string, userID string) error {
+	tenantID, err := service.ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	var cartUUID pgtype.UUID
+	if err := cartUUID.Scan(cartID); err != nil {
+		return fmt.Errorf("invalid cart ID: %w", err)
+	}
+
+	var userUUID pgtype.UUID
+	if err := userUUID.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	cart, err := s.getCart(ctx, tenantID, cartUUID)
+	if err != nil {
+		return err
+	}
+	if cart.UserID == userUUID {
+		return nil
+	}
+
+	err = s.repo.SetCartUser(ctx, repository.SetCartUserParams{
+		TenantID: tenantID,
+		ID:       cartUUID,
+		UserID:   userUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set cart user: %w", err)
+	}
+	cart.UserID = userUUID
+
+	items, err := s.repo.GetCartItems(ctx, cartUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get cart items: %w", err)
+	}
+
+	for _, item := range items {
+		if err := s.repriceItem(ctx, tenantID, cart, item.ProductSkuID, item.Quantity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // AddItem adds a product SKU to the cart or updates quantity if already present.
 func (s *CartService) AddItem(ctx context.Context, cartID string, skuID string, quantity int) (*domain.CartSummary, error) {
 	tenantID, err := service.ExtractTenantID(ctx)
@@ -162,6 +229,11 @@ func (s *CartService) AddItem(ctx context.Context, cartID string, skuID string,
 		return nil, fmt.Errorf("invalid cart ID: %w", err)
 	}
 
+	cart, err := s.getCart(ctx, tenantID, cartUUID)
+	if err != nil {
+		return nil, err
+	}
+
 	var skuUUID pgtype.UUID
 	if err := skuUUID.Scan(skuID); err != nil {
 		return nil, fmt.Errorf("invalid SKU ID: %w", err)
@@ -175,33 +247,46 @@ func (s *CartService) AddItem(ctx context.Context, cartID string, skuID string,
 		return nil, fmt.Errorf("failed to get SKU: %w", err)
 	}
 
-	priceList, err := s.repo.GetDefaultPriceList(ctx, tenantID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get default price list: %w", err)
-	}
-
-	price, err := s.repo.GetPriceForSKU(ctx, repository.GetPriceForSKUParams{
-		PriceListID:  priceList.ID,
-		ProductSkuID: sku.ID,
+	product, err := s.repo.GetProductByID(ctx, repository.GetProductByIDParams{
+		TenantID: tenantID,
+		ID:       sku.ProductID,
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, domain.ErrPriceNotFound
+			return nil, domain.ErrSKUNotFound
 		}
-		return nil, fmt.Errorf("failed to get price for SKU: %w", err)
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	// White-label products can only be ordered by the customer they're made for
+	if product.IsWhiteLabel && (!cart.UserID.Valid || cart.UserID != product.WhiteLabelCustomerID) {
+		return nil, domain.ErrSKUNotFound
+	}
+
+	price, err := s.unitPrice(ctx, tenantID, cart, sku.ID, int32(quantity))
+	if err != nil {
+		return nil, err
 	}
 
-	_, err = s.repo.AddCartItem(ctx, repository.AddCartItemParams{
+	item, err := s.repo.AddCartItem(ctx, repository.AddCartItemParams{
 		TenantID:       tenantID,
 		CartID:         cartUUID,
 		ProductSkuID:   skuUUID,
 		Quantity:       int32(quantity),
-		UnitPriceCents: price.PriceCents,
+		UnitPriceCents: price,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to add cart item: %w", err)
 	}
 
+	// The SKU may already have been in the cart, so the line's quantity can
+	// reach a tier this addition alone doesn't
+	if item.Quantity != int32(quantity) {
+		if err := s.repriceItem(ctx, tenantID, cart, item.ProductSkuID, item.Quantity); err != nil {
+			return nil, err
+		}
+	}
+
 	return s.GetCartSummary(ctx, cartID)
 }
 
@@ -215,17 +300,27 @@ func (s *CartService) UpdateItemQuantity(ctx context.Context, cartID string, sku
 		return nil, domain.ErrInvalidQuantity
 	}
 
+	tenantID, err := service.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var cartUUID pgtype.UUID
 	if err := cartUUID.Scan(cartID); err != nil {
 		return nil, fmt.Errorf("invalid cart ID: %w", err)
 	}
 
+	cart, err := s.getCart(ctx, tenantID, cartUUID)
+	if err != nil {
+		return nil, err
+	}
+
 	var skuUUID pgtype.UUID
 	if err := skuUUID.Scan(skuID); err != nil {
 		return nil, fmt.Errorf("invalid SKU ID: %w", err)
 	}
 
-	err := s.repo.UpdateCartItemQuantity(ctx, repository.UpdateCartItemQuantityParams{
+	err = s.repo.UpdateCartItemQuantity(ctx, repository.UpdateCartItemQuantityParams{
 		CartID:       cartUUID,
 		ProductSkuID: skuUUID,
 		Quantity:     int32(quantity),
@@ -234,22 +329,35 @@ func (s *CartService) UpdateItemQuantity(ctx context.Context, cartID string, sku
 		return nil, fmt.Errorf("failed to update cart item quantity: %w", err)
 	}
 
+	if err := s.repriceItem(ctx, tenantID, cart, skuUUID, int32(quantity)); err != nil {
+		return nil, err
+	}
+
 	return s.GetCartSummary(ctx, cartID)
 }
 
 // RemoveItem removes a product SKU from the cart.
 func (s *CartService) RemoveItem(ctx context.Context, cartID string, skuID string) (*domain.CartSummary, error) {
+	tenantID, err := service.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var cartUUID pgtype.UUID
 	if err := cartUUID.Scan(cartID); err != nil {
 		return nil, fmt.Errorf("invalid cart ID: %w", err)
 	}
 
+	if _, err := s.getCart(ctx, tenantID, cartUUID); err != nil {
+		return nil, err
+	}
+
 	var skuUUID pgtype.UUID
 	if err := skuUUID.Scan(skuID); err != nil {
 		return nil, fmt.Errorf("invalid SKU ID: %w", err)
 	}
 
-	err := s.repo.RemoveCartItem(ctx, repository.RemoveCartItemParams{
+	err = s.repo.RemoveCartItem(ctx, repository.RemoveCartItemParams{
 		CartID:       cartUUID,
 		ProductSkuID: skuUUID,
 	})
@@ -262,17 +370,19 @@ func (s *CartService) RemoveItem(ctx context.Context, cartID string, skuID strin
 
 // GetCartSummary retrieves a cart with all items and calculated totals.
 func (s *CartService) GetCartSummary(ctx context.Context, cartID string) (*domain.CartSummary, error) {
+	tenantID, err := service.ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var cartUUID pgtype.UUID
 	if err := cartUUID.Scan(cartID); err != nil {
 		return nil, fmt.Errorf("invalid cart ID: %w", err)
 	}
 
-	cart, err := s.repo.GetCartByID(ctx, cartUUID)
+	cart, err := s.getCart(ctx, tenantID, cartUUID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, domain.ErrCartNotFound
-		}
-		return nil, fmt.Errorf("failed to get cart: %w", err)
+		return nil, err
 	}
 
 	items, err := s.repo.GetCartItems(ctx, cartUUID)
@@ -327,11 +437,20 @@ func (s *CartService) GetCartSummary(ctx context.Context, cartID string) (*domai
 
 // ClearCart removes all items from a cart.
 func (s *CartService) ClearCart(ctx context.Context, cartID string) error {
+	tenantID, err := service.ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
 	var cartUUID pgtype.UUID
 	if err := cartUUID.Scan(cartID); err != nil {
 		return fmt.Errorf("invalid cart ID: %w", err)
 	}
 
+	if _, err := s.getCart(ctx, tenantID, cartUUID); err != nil {
+		return err
+	}
+
 	if err := s.repo.ClearCart(ctx, cartUUID); err != nil {
 		return fmt.Errorf("failed to clear cart: %w", err)
 	}
@@ -339,11 +458,108 @@ func (s *CartService) ClearCart(ctx context.Context, cartID string) error {
 	return nil
 }
 
+// getCart retrieves a cart by ID within the tenant. A cart belonging to
+// another tenant is reported as not found.
+func (s *CartService) getCart(ctx context.Context, tenantID, cartID pgtype.UUID) (repository.Cart, error) {
+	cart, err := s.repo.GetCartByID(ctx, repository.GetCartByIDParams{
+		TenantID: tenantID,
+		ID:       cartID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.Cart{}, domain.ErrCartNotFound
+		}
+		return repository.Cart{}, fmt.Errorf("failed to get cart: %w", err)
+	}
+	return cart, nil
+}
+
+// unitPrice returns the unit price of a SKU for a cart line of quantity
+// units. A cart linked to a customer is priced from the customer's price list,
+// falling back to the default price list for SKUs it doesn't include.
+func (s *CartService) unitPrice(ctx context.Context, tenantID pgtype.UUID, cart repository.Cart, skuID pgtype.UUID, quantity int32) (int32, error) {
+	var entry repository.PriceListEntry
+	found := false
+
+	if cart.UserID.Valid {
+		priceListID, err := s.repo.GetPriceListForUser(ctx, cart.UserID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("failed to get customer price list: %w", err)
+		}
+		if err == nil && priceListID.Valid {
+			entry, err = s.repo.GetPriceForSKU(ctx, repository.GetPriceForSKUParams{
+				PriceListID:  priceListID,
+				ProductSkuID: skuID,
+			})
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return 0, fmt.Errorf("failed to get price for SKU: %w", err)
+			}
+			found = err == nil
+		}
+	}
+
+	if !found {
+		priceList, err := s.repo.GetDefaultPriceList(ctx, tenantID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get default price list: %w", err)
+		}
+
+		entry, err = s.repo.GetPriceForSKU(ctx, repository.GetPriceForSKUParams{
+			PriceListID:  priceList.ID,
+			ProductSkuID: skuID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return 0, domain.ErrPriceNotFound
+			}
+			return 0, fmt.Errorf("failed to get price for SKU: %w", err)
+		}
+	}
+
+	rows, err := s.repo.ListPriceTiersForEntry(ctx, repository.ListPriceTiersForEntryParams{
+		TenantID:         tenantID,
+		PriceListEntryID: entry.ID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get price tiers: %w", err)
+	}
+
+	tiers := make([]domain.PriceTier, 0, len(rows))
+	for _, row := range rows {
+		tiers = append(tiers, domain.PriceTier{MinQuantity: row.MinQuantity, PriceCents: row.PriceCents})
+	}
+
+	return domain.TierPrice(entry.PriceCents, tiers, quantity), nil
+}
+
+// repriceItem sets a cart line's unit price for its quantity. A SKU that no
+// longer has a price keeps the price it was added at.
+func (s *CartService) repriceItem(ctx context.Context, tenantID pgtype.UUID, cart repository.Cart, skuID pgtype.UUID, quantity int32) error {
+	price, err := s.unitPrice(ctx, tenantID, cart, skuID, quantity)
+	if err != nil {
+		if errors.Is(err, domain.ErrPriceNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	err = s.repo.UpdateCartItemPrice(ctx, repository.UpdateCartItemPriceParams{
+		CartID:         cart.ID,
+		ProductSkuID:   skuID,
+		UnitPriceCents: price,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update cart item price: %w", err)
+	}
+	return nil
+}
+
 // mapRepoCartToDomain converts a repository.Cart to domain.Cart.
 func mapRepoCartToDomain(c repository.Cart) *domain.Cart {
 	return &domain.Cart{
 		ID:        c.ID,
 		TenantID:  c.TenantID,
+		UserID:    c.UserID,
 		SessionID: c.SessionID,
 		CreatedAt: c.CreatedAt,
 		UpdatedAt: c.UpdatedAt,
@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// pricingStore holds a cart and the price lists it can be priced from: a
+// default list, and a customer list with a quantity break at 20 bags.
+type pricingStore struct {
+	tenantID pgtype.UUID
+	cart     repository.Cart
+
+	product       repository.Product
+	skuID         pgtype.UUID
+	defaultListID pgtype.UUID
+	customerList  repository.PriceListEntry
+	defaultEntry  repository.PriceListEntry
+}
+
+func newPricingStore() *pricingStore {
+	s := &pricingStore{
+		tenantID:      newTestUUID(),
+		skuID:         newTestUUID(),
+		defaultListID: newTestUUID(),
+	}
+	s.cart = repository.Cart{ID: newTestUUID(), TenantID: s.tenantID, UserID: newTestUUID(), Status: "active"}
+	s.product = repository.Product{ID: newTestUUID(), TenantID: s.tenantID}
+	s.customerList = repository.PriceListEntry{ID: newTestUUID(), PriceListID: newTestUUID(), ProductSkuID: s.skuID, PriceCents: 1400}
+	s.defaultEntry = repository.PriceListEntry{ID: newTestUUID(), PriceListID: s.defaultListID, ProductSkuID: s.skuID, PriceCents: 1800}
+	return s
+}
+
+// expect installs the cart and price lookups on the mock.
+func (s *pricingStore) expect(mockRepo *repository.MockQuerier) {
+	mockRepo.EXPECT().GetCartByID(gomock.Any(), repository.GetCartByIDParams{TenantID: s.tenantID, ID: s.cart.ID}).
+		Return(s.cart, nil).AnyTimes()
+	mockRepo.EXPECT().GetSKUByID(gomock.Any(), s.skuID).
+		Return(repository.ProductSku{ID: s.skuID, TenantID: s.tenantID, ProductID: s.product.ID}, nil).AnyTimes()
+	mockRepo.EXPECT().GetProductByID(gomock.Any(), repository.GetProductByIDParams{TenantID: s.tenantID, ID: s.product.ID}).
+		Return(s.product, nil).AnyTimes()
+	mockRepo.EXPECT().GetPriceListForUser(gomock.Any(), s.cart.UserID).
+		Return(s.customerList.PriceListID, nil).AnyTimes()
+	mockRepo.EXPECT().GetDefaultPriceList(gomock.Any(), s.tenantID).
+		Return(repository.PriceList{ID: s.defaultListID, TenantID: s.tenantID}, nil).AnyTimes()
+	mockRepo.EXPECT().GetPriceForSKU(gomock.Any(), repository.GetPriceForSKUParams{PriceListID: s.customerList.PriceListID, ProductSkuID: s.skuID}).
+		Return(s.customerList, nil).AnyTimes()
+	mockRepo.EXPECT().GetPriceForSKU(gomock.Any(), repository.GetPriceForSKUParams{PriceListID: s.defaultListID, ProductSkuID: s.skuID}).
+		Return(s.defaultEntry, nil).AnyTimes()
+	mockRepo.EXPECT().ListPriceTiersForEntry(gomock.Any(), repository.ListPriceTiersForEntryParams{TenantID: s.tenantID, PriceListEntryID: s.customerList.ID}).
+		Return([]repository.PriceListEntryTier{{PriceListEntryID: s.customerList.ID, MinQuantity: 20, PriceCents: 1250}}, nil).AnyTimes()
+	mockRepo.EXPECT().ListPriceTiersForEntry(gomock.Any(), repository.ListPriceTiersForEntryParams{TenantID: s.tenantID, PriceListEntryID: s.defaultEntry.ID}).
+		Return(nil, nil).AnyTimes()
+	mockRepo.EXPECT().GetCartItems(gomock.Any(), s.cart.ID).
+		Return(nil, nil).AnyTimes()
+}
+
+func TestCartService_AddItem_PriceTiers(t *testing.T) {
+	tests := []struct {
+		name        string
+		guest       bool
+		inCart      int32
+		quantity    int
+		wantPrice   int32
+		wantReprice int32 // 0 when the line keeps the price it was added at
+	}{
+		{name: "below the quantity break", quantity: 12, wantPrice: 1400},
+		{name: "at the quantity break", quantity: 20, wantPrice: 1250},
+		{name: "line reaches the quantity break", inCart: 8, quantity: 12, wantPrice: 1400, wantReprice: 1250},
+		{name: "guest cart uses the default price list", guest: true, quantity: 20, wantPrice: 1800},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockQuerier(ctrl)
+			store := newPricingStore()
+			if tt.guest {
+				store.cart.UserID = pgtype.UUID{}
+			}
+			store.expect(mockRepo)
+
+			mockRepo.EXPECT().AddCartItem(gomock.Any(), repository.AddCartItemParams{
+				TenantID:       store.tenantID,
+				CartID:         store.cart.ID,
+				ProductSkuID:   store.skuID,
+				Quantity:       int32(tt.quantity),
+				UnitPriceCents: tt.wantPrice,
+			}).Return(repository.CartItem{
+				CartID:         store.cart.ID,
+				ProductSkuID:   store.skuID,
+				Quantity:       tt.inCart + int32(tt.quantity),
+				UnitPriceCents: tt.wantPrice,
+			}, nil)
+			if tt.wantReprice > 0 {
+				mockRepo.EXPECT().UpdateCartItemPrice(gomock.Any(), repository.UpdateCartItemPriceParams{
+					CartID:         store.cart.ID,
+					ProductSkuID:   store.skuID,
+					UnitPriceCents: tt.wantReprice,
+				}).Return(nil)
+			}
+
+			carts := NewCartService(mockRepo)
+			_, err := carts.AddItem(tenantContext(store.tenantID), store.cart.ID.String(), store.skuID.String(), tt.quantity)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCartService_AddItem_FallsBackToDefaultPriceList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	store := newPricingStore()
+
+	// The SKU isn't on the customer's price list
+	mockRepo.EXPECT().GetPriceForSKU(gomock.Any(), repository.GetPriceForSKUParams{PriceListID: store.customerList.PriceListID, ProductSkuID: store.skuID}).
+		Return(repository.PriceListEntry{}, pgx.ErrNoRows)
+	store.expect(mockRepo)
+
+	mockRepo.EXPECT().AddCartItem(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg repository.AddCartItemParams) (repository.CartItem, error) {
+			assert.Equal(t, int32(1800), arg.UnitPriceCents)
+			return repository.CartItem{Quantity: arg.Quantity, UnitPriceCents: arg.UnitPriceCents}, nil
+		})
+
+	carts := NewCartService(mockRepo)
+	_, err := carts.AddItem(tenantContext(store.tenantID), store.cart.ID.String(), store.skuID.String(), 2)
+	require.NoError(t, err)
+}
+
+func TestCartService_AddItem_WhiteLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		owner   bool
+		guest   bool
+		wantErr error
+	}{
+		{name: "the customer it's made for", owner: true},
+		{name: "another customer", wantErr: domain.ErrSKUNotFound},
+		{name: "guest", guest: true, wantErr: domain.ErrSKUNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockQuerier(ctrl)
+			store := newPricingStore()
+			store.product.IsWhiteLabel = true
+			store.product.WhiteLabelCustomerID = newTestUUID()
+			if tt.owner {
+				store.product.WhiteLabelCustomerID = store.cart.UserID
+			}
+			if tt.guest {
+				store.cart.UserID = pgtype.UUID{}
+			}
+			store.expect(mockRepo)
+
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().AddCartItem(gomock.Any(), gomock.Any()).
+					Return(repository.CartItem{Quantity: 2, UnitPriceCents: 1400}, nil)
+			} else {
+				mockRepo.EXPECT().AddCartItem(gomock.Any(), gomock.Any()).Times(0)
+			}
+
+			carts := NewCartService(mockRepo)
+			_, err := carts.AddItem(tenantContext(store.tenantID), store.cart.ID.String(), store.skuID.String(), 2)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCartService_SetUser(t *testing.T) {
+	t.Run("reprices items from the customer's price list", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		store := newPricingStore()
+		userID := store.cart.UserID
+		store.cart.UserID = pgtype.UUID{}
+
+		mockRepo.EXPECT().GetCartByID(gomock.Any(), gomock.Any()).Return(store.cart, nil)
+		mockRepo.EXPECT().SetCartUser(gomock.Any(), repository.SetCartUserParams{
+			TenantID: store.tenantID,
+			ID:       store.cart.ID,
+			UserID:   userID,
+		}).Return(nil)
+		mockRepo.EXPECT().GetCartItems(gomock.Any(), store.cart.ID).Return([]repository.GetCartItemsRow{
+			{ProductSkuID: store.skuID, Quantity: 24, UnitPriceCents: 1800},
+		}, nil)
+		mockRepo.EXPECT().GetPriceListForUser(gomock.Any(), userID).Return(store.customerList.PriceListID, nil)
+		mockRepo.EXPECT().GetPriceForSKU(gomock.Any(), gomock.Any()).Return(store.customerList, nil)
+		mockRepo.EXPECT().ListPriceTiersForEntry(gomock.Any(), repository.ListPriceTiersForEntryParams{TenantID: store.tenantID, PriceListEntryID: store.customerList.ID}).
+			Return([]repository.PriceListEntryTier{{MinQuantity: 20, PriceCents: 1250}}, nil)
+		mockRepo.EXPECT().UpdateCartItemPrice(gomock.Any(), repository.UpdateCartItemPriceParams{
+			CartID:         store.cart.ID,
+			ProductSkuID:   store.skuID,
+			UnitPriceCents: 1250,
+		}).Return(nil)
+
+		carts := NewCartService(mockRepo)
+		err := carts.SetUser(tenantContext(store.tenantID), store.cart.ID.String(), userID.String())
+		require.NoError(t, err)
+	})
+
+	t.Run("cart already linked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		store := newPricingStore()
+
+		// SetCartUser has no expectation, so gomock fails the test if it is called
+		mockRepo.EXPECT().GetCartByID(gomock.Any(), gomock.Any()).Return(store.cart, nil)
+
+		carts := NewCartService(mockRepo)
+		err := carts.SetUser(tenantContext(store.tenantID), store.cart.ID.String(), store.cart.UserID.String())
+		require.NoError(t, err)
+	})
+
+	t.Run("another tenant's cart", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		store := newPricingStore()
+
+		mockRepo.EXPECT().GetCartByID(gomock.Any(), gomock.Any()).Return(repository.Cart{}, pgx.ErrNoRows)
+
+		carts := NewCartService(mockRepo)
+		err := carts.SetUser(tenantContext(newTestUUID()), store.cart.ID.String(), newTestUUID().String())
+		assert.ErrorIs(t, err, domain.ErrCartNotFound)
+	})
+}
@@ -73,10 +73,12 @@ func (s *ProductService) ListProductsFiltered(ctx context.Context, filter domain
 	}
 
 	rows, err := s.repo.ListActiveProductsFiltered(ctx, repository.ListActiveProductsFilteredParams{
-		TenantID:    tenantID,
-		RoastLevel:  pgTextFromPtr(filter.RoastLevel),
-		Origin:      pgTextFromPtr(filter.Origin),
-		TastingNote: pgTextFromPtr(filter.TastingNote),
+		TenantID:     tenantID,
+		RoastLevel:   pgTextFromPtr(filter.RoastLevel),
+		Origin:       pgTextFromPtr(filter.Origin),
+		TastingNote:  pgTextFromPtr(filter.TastingNote),
+		CategorySlug: pgTextFromPtr(filter.CategorySlug),
+		TagSlug:      pgTextFromPtr(filter.TagSlug),
 	})
 	if err != nil {
 		return nil, domain.Internal(err, "product.list_filtered", "failed to list filtered products")
@@ -98,6 +100,7 @@ func (s *ProductService) ListProductsFiltered(ctx context.Context, filter domain
 			SortOrder:        row.SortOrder,
 			PrimaryImageURL:  row.PrimaryImageUrl,
 			PrimaryImageAlt:  row.PrimaryImageAlt,
+			MinPriceCents:    pgInt4FromAny(row.BasePrice),
 		}
 	}
 
@@ -296,11 +299,31 @@ func (s *ProductService) GetFilterOptions(ctx context.Context) (*domain.ProductF
 	origins, _ := options.Origins.([]string)
 	tastingNotes, _ := options.TastingNotes.([]string)
 
-	return &domain.ProductFilterOptions{
+	categories, err := s.repo.ListCategoryFacets(ctx, tenantID)
+	if err != nil {
+		return nil, domain.Internal(err, "product.get_filter_options", "failed to get category facets")
+	}
+
+	tags, err := s.repo.ListTagFacets(ctx, tenantID)
+	if err != nil {
+		return nil, domain.Internal(err, "product.get_filter_options", "failed to get tag facets")
+	}
+
+	filterOptions := &domain.ProductFilterOptions{
 		RoastLevels:  roastLevels,
 		Origins:      origins,
 		TastingNotes: tastingNotes,
-	}, nil
+		Categories:   make([]domain.FilterFacet, len(categories)),
+		Tags:         make([]domain.FilterFacet, len(tags)),
+	}
+	for i, c := range categories {
+		filterOptions.Categories[i] = domain.FilterFacet{Slug: c.Slug, Name: c.Name, ProductCount: c.ProductCount}
+	}
+	for i, t := range tags {
+		filterOptions.Tags[i] = domain.FilterFacet{Slug: t.Slug, Name: t.Name, ProductCount: t.ProductCount}
+	}
+
+	return filterOptions, nil
 }
 
 // =============================================================================
@@ -488,7 +511,7 @@ func (s *ProductService) CreateSKU(ctx context.Context, params domain.CreateSKUP
 		WeightUnit:        params.WeightUnit,
 		Grind:             params.Grind,
 		BasePriceCents:    params.BasePriceCents,
-		InventoryQuantity: params.InventoryQuantity,
+		InventoryQuantity: 0,
 		InventoryPolicy:   string(params.InventoryPolicy),
 		LowStockThreshold: params.LowStockThreshold,
 		WeightGrams:       params.WeightGrams,
@@ -498,6 +521,21 @@ func (s *ProductService) CreateSKU(ctx context.Context, params domain.CreateSKUP
 		return nil, domain.Internal(err, "product.create_sku", "failed to create SKU")
 	}
 
+	// Opening stock is recorded as the first count in the inventory ledger
+	if params.InventoryQuantity > 0 {
+		_, err = s.repo.AdjustSKUInventory(ctx, repository.AdjustSKUInventoryParams{
+			TenantID: tenantID,
+			ID:       sku.ID,
+			Counted:  pgtype.Int4{Int32: params.InventoryQuantity, Valid: true},
+			Reason:   domain.AdjustmentReasonCount,
+			Note:     pgtype.Text{String: "Opening stock", Valid: true},
+		})
+		if err != nil {
+			return nil, domain.Internal(err, "product.create_sku", "failed to record opening stock")
+		}
+		sku.InventoryQuantity = params.InventoryQuantity
+	}
+
 	result := mapRepoSKUToDomain(sku)
 	return &result, nil
 }
@@ -535,9 +573,8 @@ func (s *ProductService) UpdateSKU(ctx context.Context, id pgtype.UUID, params d
 	if params.BasePriceCents != nil {
 		basePriceCents = *params.BasePriceCents
 	}
-	inventoryQuantity := existing.InventoryQuantity
-	if params.InventoryQuantity != nil {
-		inventoryQuantity = *params.InventoryQuantity
+	if params.InventoryQuantity != nil && *params.InventoryQuantity < 0 {
+		return domain.ErrInvalidStockCount
 	}
 	inventoryPolicy := existing.InventoryPolicy
 	if params.InventoryPolicy != nil {
@@ -560,17 +597,32 @@ func (s *ProductService) UpdateSKU(ctx context.Context, id pgtype.UUID, params d
 		WeightUnit:        weightUnit,
 		Grind:             grind,
 		BasePriceCents:    basePriceCents,
-		InventoryQuantity: inventoryQuantity,
 		InventoryPolicy:   inventoryPolicy,
 		LowStockThreshold: params.LowStockThreshold,
 		WeightGrams:       params.WeightGrams,
 		RequiresShipping:  requiresShipping,
 		IsActive:          isActive,
+		// Wholesale quantity rules are managed from the SKU form
+		WholesaleMinQuantity: existing.WholesaleMinQuantity,
+		WholesaleCasePack:    existing.WholesaleCasePack,
 	})
 	if err != nil {
 		return domain.Internal(err, "product.update_sku", "failed to update SKU")
 	}
 
+	// A new quantity is recorded as a stock count in the inventory ledger
+	if params.InventoryQuantity != nil && *params.InventoryQuantity != existing.InventoryQuantity {
+		_, err = s.repo.AdjustSKUInventory(ctx, repository.AdjustSKUInventoryParams{
+			TenantID: tenantID,
+			ID:       id,
+			Counted:  pgtype.Int4{Int32: *params.InventoryQuantity, Valid: true},
+			Reason:   domain.AdjustmentReasonCount,
+		})
+		if err != nil {
+			return domain.Internal(err, "product.update_sku", "failed to record stock count")
+		}
+	}
+
 	return nil
 }
 
@@ -790,6 +842,18 @@ func getInventoryMessage(sku repository.ProductSku) string {
 	return "In stock"
 }
 
+// pgInt4FromAny converts an untyped subquery result to pgtype.Int4.
+func pgInt4FromAny(v interface{}) pgtype.Int4 {
+	switch n := v.(type) {
+	case int32:
+		return pgtype.Int4{Int32: n, Valid: true}
+	case int64:
+		return pgtype.Int4{Int32: int32(n), Valid: true}
+	default:
+		return pgtype.Int4{Valid: false}
+	}
+}
+
 // pgTextFromPtr creates a pgtype.Text from a string pointer.
 func pgTextFromPtr(s *string) pgtype.Text {
 	if s == nil {
@@ -0,0 +1,221 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/service"
+	"github.com/dukerupert/hiri/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// These tests check that the storefront services scope every carts, orders
+// and products query to the tenant on the request context. Expectations match
+// the exact query params, so a query sent with another tenant's ID, or
+// without one, fails the test as an unexpected call. The queries return what
+// the tenant_id filter in their SQL would for the requesting tenant.
+
+func newTestUUID() pgtype.UUID {
+	return pgtype.UUID{Bytes: uuid.New(), Valid: true}
+}
+
+func tenantContext(tenantID pgtype.UUID) context.Context {
+	return tenant.NewContext(context.Background(), &tenant.Tenant{ID: tenantID, Status: "active"})
+}
+
+func TestTenantIsolation_Products(t *testing.T) {
+	tenantB := newTestUUID()
+
+	t.Run("list is scoped to the tenant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		mockRepo.EXPECT().ListActiveProducts(gomock.Any(), tenantB).Return([]repository.ListActiveProductsRow{
+			{ID: newTestUUID(), TenantID: tenantB, Name: "Bravo Blend", Slug: "bravo-blend"},
+		}, nil)
+
+		items, err := NewProductService(mockRepo).ListProducts(tenantContext(tenantB))
+
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, tenantB, items[0].TenantID)
+	})
+
+	t.Run("product lookup by slug is scoped to the tenant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		mockRepo.EXPECT().GetProductBySlug(gomock.Any(), repository.GetProductBySlugParams{
+			TenantID: tenantB,
+			Slug:     "alpha-espresso",
+		}).Return(repository.Product{}, sql.ErrNoRows)
+
+		_, err := NewProductService(mockRepo).GetProductDetail(tenantContext(tenantB), "alpha-espresso")
+
+		assert.ErrorIs(t, err, domain.ErrProductNotFound)
+	})
+
+	t.Run("no tenant on the context", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+
+		_, err := NewProductService(mockRepo).ListProducts(context.Background())
+
+		assert.ErrorIs(t, err, tenant.ErrNoTenant)
+	})
+}
+
+func TestTenantIsolation_Carts(t *testing.T) {
+	tenantA, tenantB := newTestUUID(), newTestUUID()
+	cartA := repository.Cart{ID: newTestUUID(), TenantID: tenantA, SessionID: newTestUUID(), Status: "active"}
+	ctxB := tenantContext(tenantB)
+
+	// expectCartLookup expects tenant B's lookup of cart A by ID, which the
+	// tenant_id filter turns into no rows.
+	expectCartLookup := func(mockRepo *repository.MockQuerier) {
+		mockRepo.EXPECT().GetCartByID(gomock.Any(), repository.GetCartByIDParams{
+			TenantID: tenantB,
+			ID:       cartA.ID,
+		}).Return(repository.Cart{}, sql.ErrNoRows)
+	}
+
+	t.Run("session lookup is scoped to the tenant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		mockRepo.EXPECT().GetCartBySessionID(gomock.Any(), repository.GetCartBySessionIDParams{
+			TenantID:  tenantB,
+			SessionID: cartA.SessionID,
+		}).Return(repository.Cart{}, sql.ErrNoRows)
+
+		_, err := NewCartService(mockRepo).GetCart(ctxB, cartA.SessionID.String())
+
+		assert.ErrorIs(t, err, domain.ErrCartNotFound)
+	})
+
+	t.Run("cart lookup by ID is scoped to the tenant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		expectCartLookup(mockRepo)
+
+		_, err := NewCartService(mockRepo).GetCartSummary(ctxB, cartA.ID.String())
+
+		assert.ErrorIs(t, err, domain.ErrCartNotFound)
+	})
+
+	t.Run("items cannot be added to another tenant's cart", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		expectCartLookup(mockRepo)
+
+		// AddCartItem has no expectation, so gomock fails the test if it is called
+		_, err := NewCartService(mockRepo).AddItem(ctxB, cartA.ID.String(), newTestUUID().String(), 1)
+
+		assert.ErrorIs(t, err, domain.ErrCartNotFound)
+	})
+
+	t.Run("items cannot be removed from another tenant's cart", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		expectCartLookup(mockRepo)
+
+		_, err := NewCartService(mockRepo).RemoveItem(ctxB, cartA.ID.String(), newTestUUID().String())
+
+		assert.ErrorIs(t, err, domain.ErrCartNotFound)
+	})
+
+	t.Run("another tenant's cart cannot be cleared", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		expectCartLookup(mockRepo)
+
+		err := NewCartService(mockRepo).ClearCart(ctxB, cartA.ID.String())
+
+		assert.ErrorIs(t, err, domain.ErrCartNotFound)
+	})
+
+	t.Run("a shared session gets its own cart per tenant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		cartB := repository.Cart{ID: newTestUUID(), TenantID: tenantB, SessionID: cartA.SessionID, Status: "active"}
+		mockRepo.EXPECT().GetCartBySessionID(gomock.Any(), repository.GetCartBySessionIDParams{
+			TenantID:  tenantB,
+			SessionID: cartA.SessionID,
+		}).Return(repository.Cart{}, sql.ErrNoRows)
+		mockRepo.EXPECT().CreateCart(gomock.Any(), repository.CreateCartParams{
+			TenantID:  tenantB,
+			SessionID: cartA.SessionID,
+		}).Return(cartB, nil)
+		mockRepo.EXPECT().GetCartBySessionID(gomock.Any(), repository.GetCartBySessionIDParams{
+			TenantID:  tenantA,
+			SessionID: cartA.SessionID,
+		}).Return(cartA, nil)
+
+		carts := NewCartService(mockRepo)
+
+		cart, _, err := carts.GetOrCreateCart(ctxB, cartA.SessionID.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, cartB.ID, cart.ID)
+
+		// The original tenant still sees its own cart
+		cart, _, err = carts.GetOrCreateCart(tenantContext(tenantA), cartA.SessionID.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, cartA.ID, cart.ID)
+	})
+
+	t.Run("no tenant on the context", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+
+		_, err := NewCartService(mockRepo).GetCartSummary(context.Background(), cartA.ID.String())
+
+		assert.ErrorIs(t, err, tenant.ErrNoTenant)
+	})
+}
+
+func TestTenantIsolation_Orders(t *testing.T) {
+	tenantB := newTestUUID()
+	orderA := repository.Order{ID: newTestUUID(), TenantID: newTestUUID(), OrderNumber: "ORD-1001", Status: "paid"}
+	ctxB := tenantContext(tenantB)
+
+	t.Run("order lookup by ID is scoped to the tenant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		mockRepo.EXPECT().GetOrder(gomock.Any(), repository.GetOrderParams{
+			TenantID: tenantB,
+			ID:       orderA.ID,
+		}).Return(repository.Order{}, sql.ErrNoRows)
+
+		_, err := service.NewOrderService(mockRepo, nil, nil, nil).GetOrder(ctxB, orderA.ID.String())
+
+		assert.ErrorIs(t, err, service.ErrOrderNotFound)
+	})
+
+	t.Run("order lookup by number is scoped to the tenant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		mockRepo.EXPECT().GetOrderByNumber(gomock.Any(), repository.GetOrderByNumberParams{
+			TenantID:    tenantB,
+			OrderNumber: orderA.OrderNumber,
+		}).Return(repository.Order{}, sql.ErrNoRows)
+
+		_, err := service.NewOrderService(mockRepo, nil, nil, nil).GetOrderByNumber(ctxB, orderA.OrderNumber)
+
+		assert.ErrorIs(t, err, service.ErrOrderNotFound)
+	})
+
+	t.Run("no tenant on the context", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+
+		_, err := service.NewOrderService(mockRepo, nil, nil, nil).GetOrder(context.Background(), orderA.ID.String())
+
+		assert.ErrorIs(t, err, tenant.ErrNoTenant)
+	})
+}
@@ -67,6 +67,7 @@ func mapRepoUserToDomain(u repository.User) *domain.Customer {
 		BillingCycle:               u.BillingCycle,
 		BillingCycleDay:            u.BillingCycleDay,
 		CustomerReference:          u.CustomerReference,
+		CreditLimitCents:           u.CreditLimitCents,
 	}
 }
 
@@ -591,6 +592,7 @@ func (s *UserService) UpdateWholesaleCustomer(ctx context.Context, userID string
 		EmailOrders:       pgtype.Text{String: params.EmailOrders, Valid: params.EmailOrders != ""},
 		EmailDispatches:   pgtype.Text{String: params.EmailDispatches, Valid: params.EmailDispatches != ""},
 		EmailInvoices:     pgtype.Text{String: params.EmailInvoices, Valid: params.EmailInvoices != ""},
+		CreditLimitCents:  pgtype.Int4{Int32: params.CreditLimitCents, Valid: params.CreditLimitCents > 0},
 	}); err != nil {
 		return fmt.Errorf("failed to update wholesale customer: %w", err)
 	}
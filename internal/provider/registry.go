@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/dukerupert/hiri/internal/billing"
 	"github.com/dukerupert/hiri/internal/crypto"
 	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
 	"github.com/dukerupert/hiri/internal/tax"
 	"github.com/jackc/pgx/v5/pgtype"
 	"golang.org/x/sync/singleflight"
@@ -34,7 +36,10 @@ type ProviderRegistry interface {
 	// Returns cached instance if available and not expired, otherwise loads from database.
 	GetBillingProvider(ctx context.Context, tenantID pgtype.UUID) (billing.Provider, error)
 
-	// TODO: Add GetShippingProvider(ctx context.Context, tenantID pgtype.UUID) (shipping.Provider, error)
+	// GetShippingProvider returns the active shipping provider for the given tenant.
+	// Returns cached instance if available and not expired, otherwise loads from database.
+	GetShippingProvider(ctx context.Context, tenantID pgtype.UUID) (shipping.Provider, error)
+
 	// TODO: Add GetEmailProvider(ctx context.Context, tenantID pgtype.UUID) (email.Provider, error)
 
 	// InvalidateCache removes cached provider instances for the given tenant.
@@ -191,6 +196,49 @@ func (r *DefaultRegistry) GetBillingProvider(ctx context.Context, tenantID pgtyp
 	return result.(billing.Provider), nil
 }
 
+// GetShippingProvider returns the shipping provider for the tenant.
+func (r *DefaultRegistry) GetShippingProvider(ctx context.Context, tenantID pgtype.UUID) (shipping.Provider, error) {
+	key := makeCacheKey(tenantID, ProviderTypeShipping)
+	keyString := fmt.Sprintf("%s:%s", key.tenantID, key.providerType)
+
+	// Check cache first
+	if cached, ok := r.cache.Load(key); ok {
+		entry := cached.(cacheEntry)
+		if entry.expiresAt.After(time.Now()) {
+			return entry.provider.(shipping.Provider), nil
+		}
+	}
+
+	// Use singleflight to ensure only one goroutine loads the provider for this key
+	result, err, _ := r.loadGroup.Do(keyString, func() (interface{}, error) {
+		// Double-check cache inside singleflight to handle race between cache check and Do
+		if cached, ok := r.cache.Load(key); ok {
+			entry := cached.(cacheEntry)
+			if entry.expiresAt.After(time.Now()) {
+				return entry.provider.(shipping.Provider), nil
+			}
+		}
+
+		provider, err := r.loadShippingProvider(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		r.cache.Store(key, cacheEntry{
+			provider:  provider,
+			expiresAt: time.Now().Add(r.cacheTTL),
+		})
+
+		return provider, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(shipping.Provider), nil
+}
+
 // InvalidateCache removes cached provider instances for the given tenant and type.
 func (r *DefaultRegistry) InvalidateCache(tenantID pgtype.UUID, providerType ProviderType) {
 	key := makeCacheKey(tenantID, providerType)
@@ -240,70 +288,66 @@ func (r *DefaultRegistry) loadBillingProvider(ctx context.Context, tenantID pgty
 	return provider, nil
 }
 
+// loadShippingProvider loads shipping provider configuration from database and creates instance.
+func (r *DefaultRegistry) loadShippingProvider(ctx context.Context, tenantID pgtype.UUID) (shipping.Provider, error) {
+	config, err := r.loadConfig(ctx, tenantID, ProviderTypeShipping)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load shipping provider config: %w", err)
+	}
+
+	if config == nil {
+		return nil, ErrNoProviderConfigured("shipping")
+	}
+
+	provider, err := r.factory.CreateShippingProvider(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shipping provider: %w", err)
+	}
+
+	return provider, nil
+}
+
 // loadConfig loads and decrypts provider configuration from database.
 // Returns the default/highest priority active config for the given tenant and type.
 func (r *DefaultRegistry) loadConfig(ctx context.Context, tenantID pgtype.UUID, providerType ProviderType) (*TenantProviderConfig, error) {
-	// NOTE: Repository query method for provider configs does not exist yet.
-	// This is a stub implementation that should be replaced once the database
-	// queries are implemented via sqlc.
-	//
-	// Expected query signature:
-	// GetActiveProviderConfigs(ctx context.Context, tenantID pgtype.UUID, providerType string) ([]ProviderConfig, error)
-	//
-	// For now, return nil to indicate no provider is configured.
-	// When the database layer is ready, uncomment and implement the following:
-	//
-	// configs, err := r.repo.GetActiveProviderConfigs(ctx, tenantID, string(providerType))
-	// if err != nil {
-	//     return nil, fmt.Errorf("failed to query provider configs: %w", err)
-	// }
-	//
-	// if len(configs) == 0 {
-	//     return nil, nil
-	// }
-	//
-	// var selectedConfig *repository.ProviderConfig
-	// for i := range configs {
-	//     if configs[i].IsDefault {
-	//         selectedConfig = &configs[i]
-	//         break
-	//     }
-	// }
-	//
-	// if selectedConfig == nil {
-	//     selectedConfig = &configs[0]
-	//     for i := range configs {
-	//         if configs[i].Priority < selectedConfig.Priority {
-	//             selectedConfig = &configs[i]
-	//         }
-	//     }
-	// }
-	//
-	// decrypted, err := r.encryptor.Decrypt(selectedConfig.ConfigJSON)
-	// if err != nil {
-	//     return nil, fmt.Errorf("failed to decrypt config: %w", err)
-	// }
-	//
-	// var configMap map[string]interface{}
-	// if err := encoding/json.Unmarshal(decrypted, &configMap); err != nil {
-	//     return nil, fmt.Errorf("failed to unmarshal config JSON: %w", err)
-	// }
-	//
-	// return &TenantProviderConfig{
-	//     ID:         selectedConfig.ID,
-	//     TenantID:   selectedConfig.TenantID,
-	//     Type:       ProviderType(selectedConfig.Type),
-	//     Name:       ProviderName(selectedConfig.Name),
-	//     IsActive:   selectedConfig.IsActive,
-	//     IsDefault:  selectedConfig.IsDefault,
-	//     Priority:   selectedConfig.Priority,
-	//     Config:     configMap,
-	//     ConfigJSON: selectedConfig.ConfigJSON,
-	//     CreatedAt:  selectedConfig.CreatedAt.Time,
-	//     UpdatedAt:  selectedConfig.UpdatedAt.Time,
-	// }, nil
-
-	return nil, nil
+	// Ordered by is_default DESC, priority ASC, so the first row is the one to use
+	configs, err := r.repo.GetActiveProviderConfigs(ctx, repository.GetActiveProviderConfigsParams{
+		TenantID: tenantID,
+		Type:     string(providerType),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query provider configs: %w", err)
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	selected := configs[0]
+
+	decrypted, err := r.encryptor.Decrypt([]byte(selected.ConfigEncrypted))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt config: %w", err)
+	}
+
+	var configMap map[string]interface{}
+	if err := json.Unmarshal(decrypted, &configMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config JSON: %w", err)
+	}
+
+	return &TenantProviderConfig{
+		ID:         selected.ID,
+		TenantID:   selected.TenantID,
+		Type:       ProviderType(selected.Type),
+		Name:       ProviderName(selected.Name),
+		IsActive:   selected.IsActive,
+		IsDefault:  selected.IsDefault,
+		Priority:   selected.Priority,
+		Config:     configMap,
+		ConfigJSON: []byte(selected.ConfigEncrypted),
+		CreatedAt:  selected.CreatedAt.Time,
+		UpdatedAt:  selected.UpdatedAt.Time,
+	}, nil
 }
 
 // makeCacheKey creates a cache key from tenant ID and provider type.
@@ -123,13 +123,19 @@ SELECT
     created_at,
     updated_at
 FROM carts
-WHERE id = $1
+WHERE tenant_id = $1
+  AND id = $2
 LIMIT 1
 `
 
-// Get cart by ID
-func (q *Queries) GetCartByID(ctx context.Context, id pgtype.UUID) (Cart, error) {
-	row := q.db.QueryRow(ctx, getCartByID, id)
+type GetCartByIDParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	ID       pgtype.UUID `json:"id"`
+}
+
+// Get cart by ID within a tenant
+func (q *Queries) GetCartByID(ctx context.Context, arg GetCartByIDParams) (Cart, error) {
+	row := q.db.QueryRow(ctx, getCartByID, arg.TenantID, arg.ID)
 	var i Cart
 	err := row.Scan(
 		&i.ID,
@@ -163,14 +169,22 @@ SELECT
     created_at,
     updated_at
 FROM carts
-WHERE session_id = $1
+WHERE tenant_id = $1
+  AND session_id = $2
   AND status = 'active'
 LIMIT 1
 `
 
-// Get active cart for a session
-func (q *Queries) GetCartBySessionID(ctx context.Context, sessionID pgtype.UUID) (Cart, error) {
-	row := q.db.QueryRow(ctx, getCartBySessionID, sessionID)
+type GetCartBySessionIDParams struct {
+	TenantID  pgtype.UUID `json:"tenant_id"`
+	SessionID pgtype.UUID `json:"session_id"`
+}
+
+// Get active cart for a session within a tenant. A session cookie can be
+// shared across storefronts on the same base domain, so each tenant has its
+// own cart for the session.
+func (q *Queries) GetCartBySessionID(ctx context.Context, arg GetCartBySessionIDParams) (Cart, error) {
+	row := q.db.QueryRow(ctx, getCartBySessionID, arg.TenantID, arg.SessionID)
 	var i Cart
 	err := row.Scan(
 		&i.ID,
@@ -306,6 +320,48 @@ func (q *Queries) RemoveCartItem(ctx context.Context, arg RemoveCartItemParams)
 	return err
 }
 
+const setCartUser = `-- name: SetCartUser :exec
+UPDATE carts
+SET
+    user_id = $3,
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2
+`
+
+type SetCartUserParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	ID       pgtype.UUID `json:"id"`
+	UserID   pgtype.UUID `json:"user_id"`
+}
+
+// Link a cart to the signed-in customer it's priced for
+func (q *Queries) SetCartUser(ctx context.Context, arg SetCartUserParams) error {
+	_, err := q.db.Exec(ctx, setCartUser, arg.TenantID, arg.ID, arg.UserID)
+	return err
+}
+
+const updateCartItemPrice = `-- name: UpdateCartItemPrice :exec
+UPDATE cart_items
+SET
+    unit_price_cents = $3,
+    updated_at = NOW()
+WHERE cart_id = $1
+  AND product_sku_id = $2
+`
+
+type UpdateCartItemPriceParams struct {
+	CartID         pgtype.UUID `json:"cart_id"`
+	ProductSkuID   pgtype.UUID `json:"product_sku_id"`
+	UnitPriceCents int32       `json:"unit_price_cents"`
+}
+
+// Update the unit price of a cart item after its quantity or price list changes
+func (q *Queries) UpdateCartItemPrice(ctx context.Context, arg UpdateCartItemPriceParams) error {
+	_, err := q.db.Exec(ctx, updateCartItemPrice, arg.CartID, arg.ProductSkuID, arg.UnitPriceCents)
+	return err
+}
+
 const updateCartItemQuantity = `-- name: UpdateCartItemQuantity :exec
 UPDATE cart_items
 SET
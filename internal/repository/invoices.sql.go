@@ -341,6 +341,42 @@ func (q *Queries) GenerateInvoiceNumber(ctx context.Context, tenantID pgtype.UUI
 	return next_invoice_number, err
 }
 
+const getCustomerOutstandingBalance = `-- name: GetCustomerOutstandingBalance :one
+SELECT (
+    COALESCE((
+        SELECT SUM(i.balance_cents)
+        FROM invoices i
+        WHERE i.tenant_id = $1
+          AND i.user_id = $2
+          AND i.status NOT IN ('paid', 'cancelled', 'void')
+    ), 0)
+    + COALESCE((
+        SELECT SUM(o.total_cents)
+        FROM orders o
+        LEFT JOIN invoice_orders io ON io.order_id = o.id
+        WHERE o.tenant_id = $1
+          AND o.user_id = $2
+          AND o.order_type = 'wholesale'
+          AND o.status IN ('paid', 'processing', 'shipped', 'delivered')
+          AND io.id IS NULL
+    ), 0)
+)::integer AS outstanding_cents
+`
+
+type GetCustomerOutstandingBalanceParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	UserID   pgtype.UUID `json:"user_id"`
+}
+
+// Get what a wholesale customer owes on account: unpaid invoice balances
+// plus wholesale orders not yet invoiced. Used to enforce credit limits.
+func (q *Queries) GetCustomerOutstandingBalance(ctx context.Context, arg GetCustomerOutstandingBalanceParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getCustomerOutstandingBalance, arg.TenantID, arg.UserID)
+	var outstanding_cents int32
+	err := row.Scan(&outstanding_cents)
+	return outstanding_cents, err
+}
+
 const getInvoiceByID = `-- name: GetInvoiceByID :one
 SELECT id, tenant_id, user_id, invoice_number, status, subtotal_cents, tax_cents, shipping_cents, discount_cents, total_cents, paid_cents, balance_cents, currency, payment_terms, due_date, billing_customer_id, provider, provider_invoice_id, billing_address_id, customer_notes, internal_notes, metadata, sent_at, viewed_at, paid_at, voided_at, created_at, updated_at, payment_terms_id, billing_period_start, billing_period_end, is_proforma FROM invoices
 WHERE id = $1
@@ -26,36 +26,92 @@ func (q *Queries) CancelJob(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const cancelJobInScope = `-- name: CancelJobInScope :one
+UPDATE jobs
+SET
+    status = 'cancelled',
+    processing_completed_at = NOW()
+WHERE id = $1
+  AND (tenant_id = $2 OR $2::uuid IS NULL)
+  AND status = 'pending'
+RETURNING id, tenant_id, job_type, queue, status, payload, priority, max_retries, retry_count, retry_backoff_seconds, scheduled_at, processing_started_at, processing_completed_at, worker_id, error_message, error_details, timeout_seconds, metadata, created_at, updated_at, heartbeat_at
+`
+
+type CancelJobInScopeParams struct {
+	ID       pgtype.UUID `json:"id"`
+	TenantID pgtype.UUID `json:"tenant_id"`
+}
+
+// Cancel a pending job within the console scope
+func (q *Queries) CancelJobInScope(ctx context.Context, arg CancelJobInScopeParams) (Job, error) {
+	row := q.db.QueryRow(ctx, cancelJobInScope, arg.ID, arg.TenantID)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.JobType,
+		&i.Queue,
+		&i.Status,
+		&i.Payload,
+		&i.Priority,
+		&i.MaxRetries,
+		&i.RetryCount,
+		&i.RetryBackoffSeconds,
+		&i.ScheduledAt,
+		&i.ProcessingStartedAt,
+		&i.ProcessingCompletedAt,
+		&i.WorkerID,
+		&i.ErrorMessage,
+		&i.ErrorDetails,
+		&i.TimeoutSeconds,
+		&i.Metadata,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.HeartbeatAt,
+	)
+	return i, err
+}
+
 const claimNextJob = `-- name: ClaimNextJob :one
 UPDATE jobs
 SET
     status = 'processing',
     processing_started_at = NOW(),
+    heartbeat_at = NOW(),
     worker_id = $1
 WHERE id = (
     SELECT j.id
     FROM jobs j
     WHERE j.status = 'pending'
       AND j.scheduled_at <= NOW()
-      AND (j.tenant_id = $2 OR $2 IS NULL)
+      AND (j.tenant_id = $2 OR $2::uuid IS NULL)
       AND (j.queue = $3 OR $3 = '')
+      AND j.queue <> ALL(COALESCE($4::text[], '{}'))
     ORDER BY j.priority ASC, j.scheduled_at ASC
     FOR UPDATE SKIP LOCKED
     LIMIT 1
 )
-RETURNING id, tenant_id, job_type, queue, status, payload, priority, max_retries, retry_count, retry_backoff_seconds, scheduled_at, processing_started_at, processing_completed_at, worker_id, error_message, error_details, timeout_seconds, metadata, created_at, updated_at
+RETURNING id, tenant_id, job_type, queue, status, payload, priority, max_retries, retry_count, retry_backoff_seconds, scheduled_at, processing_started_at, processing_completed_at, worker_id, error_message, error_details, timeout_seconds, metadata, created_at, updated_at, heartbeat_at
 `
 
 type ClaimNextJobParams struct {
-	WorkerID pgtype.Text `json:"worker_id"`
-	TenantID pgtype.UUID `json:"tenant_id"`
-	Queue    string      `json:"queue"`
+	WorkerID      pgtype.Text `json:"worker_id"`
+	TenantID      pgtype.UUID `json:"tenant_id"`
+	Queue         string      `json:"queue"`
+	ExcludeQueues []string    `json:"exclude_queues"`
 }
 
 // Claim the next pending job using SKIP LOCKED for safe concurrent access
-// This query finds the highest priority job that's ready to run
+// This query finds the highest priority job that's ready to run. An empty
+// queue matches any queue except exclude_queues, which have their own
+// concurrency limits.
 func (q *Queries) ClaimNextJob(ctx context.Context, arg ClaimNextJobParams) (Job, error) {
-	row := q.db.QueryRow(ctx, claimNextJob, arg.WorkerID, arg.TenantID, arg.Queue)
+	row := q.db.QueryRow(ctx, claimNextJob,
+		arg.WorkerID,
+		arg.TenantID,
+		arg.Queue,
+		arg.ExcludeQueues,
+	)
 	var i Job
 	err := row.Scan(
 		&i.ID,
@@ -78,6 +134,7 @@ func (q *Queries) ClaimNextJob(ctx context.Context, arg ClaimNextJobParams) (Job
 		&i.Metadata,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HeartbeatAt,
 	)
 	return i, err
 }
@@ -88,15 +145,51 @@ SET
     status = 'completed',
     processing_completed_at = NOW()
 WHERE id = $1
+  AND worker_id = $2
   AND status = 'processing'
 `
 
-// Mark a job as completed
-func (q *Queries) CompleteJob(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, completeJob, id)
+type CompleteJobParams struct {
+	ID       pgtype.UUID `json:"id"`
+	WorkerID pgtype.Text `json:"worker_id"`
+}
+
+// Mark a job as completed. The worker must still own the job: a job the
+// reaper took back from a silent worker may be running elsewhere.
+func (q *Queries) CompleteJob(ctx context.Context, arg CompleteJobParams) error {
+	_, err := q.db.Exec(ctx, completeJob, arg.ID, arg.WorkerID)
 	return err
 }
 
+const countJobs = `-- name: CountJobs :one
+SELECT COUNT(*)
+FROM jobs
+WHERE (tenant_id = $1 OR $1::uuid IS NULL)
+  AND (queue = $2 OR $2::varchar = '')
+  AND (job_type = $3 OR $3::varchar = '')
+  AND (status = $4 OR $4::varchar = '')
+`
+
+type CountJobsParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	Queue    string      `json:"queue"`
+	JobType  string      `json:"job_type"`
+	Status   string      `json:"status"`
+}
+
+// Count of jobs matching the console filters
+func (q *Queries) CountJobs(ctx context.Context, arg CountJobsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countJobs,
+		arg.TenantID,
+		arg.Queue,
+		arg.JobType,
+		arg.Status,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countJobsByStatus = `-- name: CountJobsByStatus :one
 SELECT COUNT(*)
 FROM jobs
@@ -143,7 +236,7 @@ INSERT INTO jobs (
     metadata
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9
-) RETURNING id, tenant_id, job_type, queue, status, payload, priority, max_retries, retry_count, retry_backoff_seconds, scheduled_at, processing_started_at, processing_completed_at, worker_id, error_message, error_details, timeout_seconds, metadata, created_at, updated_at
+) RETURNING id, tenant_id, job_type, queue, status, payload, priority, max_retries, retry_count, retry_backoff_seconds, scheduled_at, processing_started_at, processing_completed_at, worker_id, error_message, error_details, timeout_seconds, metadata, created_at, updated_at, heartbeat_at
 `
 
 type EnqueueJobParams struct {
@@ -193,6 +286,7 @@ func (q *Queries) EnqueueJob(ctx context.Context, arg EnqueueJobParams) (Job, er
 		&i.Metadata,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HeartbeatAt,
 	)
 	return i, err
 }
@@ -206,8 +300,7 @@ SET
     END,
     retry_count = retry_count + 1,
     scheduled_at = CASE
-        WHEN retry_count + 1 < max_retries
-        THEN NOW() + (retry_backoff_seconds * POWER(2, retry_count) || ' seconds')::INTERVAL
+        WHEN retry_count + 1 < max_retries THEN $1::timestamptz
         ELSE scheduled_at
     END,
     processing_completed_at = CASE
@@ -224,21 +317,30 @@ SET
     END,
     error_message = $2,
     error_details = $3
-WHERE id = $1
+WHERE id = $4
+  AND worker_id = $5
   AND status = 'processing'
-RETURNING id, tenant_id, job_type, queue, status, payload, priority, max_retries, retry_count, retry_backoff_seconds, scheduled_at, processing_started_at, processing_completed_at, worker_id, error_message, error_details, timeout_seconds, metadata, created_at, updated_at
+RETURNING id, tenant_id, job_type, queue, status, payload, priority, max_retries, retry_count, retry_backoff_seconds, scheduled_at, processing_started_at, processing_completed_at, worker_id, error_message, error_details, timeout_seconds, metadata, created_at, updated_at, heartbeat_at
 `
 
 type FailJobParams struct {
-	ID           pgtype.UUID `json:"id"`
-	ErrorMessage pgtype.Text `json:"error_message"`
-	ErrorDetails []byte      `json:"error_details"`
+	RetryAt      pgtype.Timestamptz `json:"retry_at"`
+	ErrorMessage pgtype.Text        `json:"error_message"`
+	ErrorDetails []byte             `json:"error_details"`
+	ID           pgtype.UUID        `json:"id"`
+	WorkerID     pgtype.Text        `json:"worker_id"`
 }
 
-// Mark a job as failed or reschedule it for retry
+// Mark a job as failed or reschedule it for retry at retry_at
 // If retry_count < max_retries, reschedule; otherwise mark as failed
 func (q *Queries) FailJob(ctx context.Context, arg FailJobParams) (Job, error) {
-	row := q.db.QueryRow(ctx, failJob, arg.ID, arg.ErrorMessage, arg.ErrorDetails)
+	row := q.db.QueryRow(ctx, failJob,
+		arg.RetryAt,
+		arg.ErrorMessage,
+		arg.ErrorDetails,
+		arg.ID,
+		arg.WorkerID,
+	)
 	var i Job
 	err := row.Scan(
 		&i.ID,
@@ -261,12 +363,13 @@ func (q *Queries) FailJob(ctx context.Context, arg FailJobParams) (Job, error) {
 		&i.Metadata,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HeartbeatAt,
 	)
 	return i, err
 }
 
 const getJobByID = `-- name: GetJobByID :one
-SELECT id, tenant_id, job_type, queue, status, payload, priority, max_retries, retry_count, retry_backoff_seconds, scheduled_at, processing_started_at, processing_completed_at, worker_id, error_message, error_details, timeout_seconds, metadata, created_at, updated_at
+SELECT id, tenant_id, job_type, queue, status, payload, priority, max_retries, retry_count, retry_backoff_seconds, scheduled_at, processing_started_at, processing_completed_at, worker_id, error_message, error_details, timeout_seconds, metadata, created_at, updated_at, heartbeat_at
 FROM jobs
 WHERE id = $1
 LIMIT 1
@@ -297,6 +400,49 @@ func (q *Queries) GetJobByID(ctx context.Context, id pgtype.UUID) (Job, error) {
 		&i.Metadata,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HeartbeatAt,
+	)
+	return i, err
+}
+
+const getJobInScope = `-- name: GetJobInScope :one
+SELECT id, tenant_id, job_type, queue, status, payload, priority, max_retries, retry_count, retry_backoff_seconds, scheduled_at, processing_started_at, processing_completed_at, worker_id, error_message, error_details, timeout_seconds, metadata, created_at, updated_at, heartbeat_at
+FROM jobs
+WHERE id = $1
+  AND (tenant_id = $2 OR $2::uuid IS NULL)
+`
+
+type GetJobInScopeParams struct {
+	ID       pgtype.UUID `json:"id"`
+	TenantID pgtype.UUID `json:"tenant_id"`
+}
+
+// Fetch a job by ID within the console scope
+func (q *Queries) GetJobInScope(ctx context.Context, arg GetJobInScopeParams) (Job, error) {
+	row := q.db.QueryRow(ctx, getJobInScope, arg.ID, arg.TenantID)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.JobType,
+		&i.Queue,
+		&i.Status,
+		&i.Payload,
+		&i.Priority,
+		&i.MaxRetries,
+		&i.RetryCount,
+		&i.RetryBackoffSeconds,
+		&i.ScheduledAt,
+		&i.ProcessingStartedAt,
+		&i.ProcessingCompletedAt,
+		&i.WorkerID,
+		&i.ErrorMessage,
+		&i.ErrorDetails,
+		&i.TimeoutSeconds,
+		&i.Metadata,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.HeartbeatAt,
 	)
 	return i, err
 }
@@ -334,8 +480,210 @@ func (q *Queries) GetJobStats(ctx context.Context, tenantID pgtype.UUID) (GetJob
 	return i, err
 }
 
+const heartbeatWorkerJobs = `-- name: HeartbeatWorkerJobs :execrows
+UPDATE jobs
+SET heartbeat_at = NOW()
+WHERE worker_id = $1
+  AND status = 'processing'
+`
+
+// Record that a worker is still running its claimed jobs
+func (q *Queries) HeartbeatWorkerJobs(ctx context.Context, workerID pgtype.Text) (int64, error) {
+	result, err := q.db.Exec(ctx, heartbeatWorkerJobs, workerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listFailedJobTypes = `-- name: ListFailedJobTypes :many
+SELECT
+    job_type,
+    COUNT(*) AS failed_count,
+    MAX(processing_completed_at)::timestamptz AS last_failed_at
+FROM jobs
+WHERE status = 'failed'
+  AND (tenant_id = $1 OR $1::uuid IS NULL)
+GROUP BY job_type
+ORDER BY failed_count DESC, job_type ASC
+`
+
+type ListFailedJobTypesRow struct {
+	JobType      string             `json:"job_type"`
+	FailedCount  int64              `json:"failed_count"`
+	LastFailedAt pgtype.Timestamptz `json:"last_failed_at"`
+}
+
+// Dead-lettered jobs grouped by type, for the dead-letter view
+func (q *Queries) ListFailedJobTypes(ctx context.Context, tenantID pgtype.UUID) ([]ListFailedJobTypesRow, error) {
+	rows, err := q.db.Query(ctx, listFailedJobTypes, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListFailedJobTypesRow{}
+	for rows.Next() {
+		var i ListFailedJobTypesRow
+		if err := rows.Scan(
+			&i.JobType,
+			&i.FailedCount,
+			&i.LastFailedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobQueueStats = `-- name: ListJobQueueStats :many
+SELECT
+    queue,
+    COUNT(*) FILTER (WHERE status = 'pending') AS pending_count,
+    COUNT(*) FILTER (WHERE status = 'processing') AS processing_count,
+    COUNT(*) FILTER (WHERE status = 'failed') AS failed_count
+FROM jobs
+WHERE (tenant_id = $1 OR $1::uuid IS NULL)
+GROUP BY queue
+ORDER BY queue ASC
+`
+
+type ListJobQueueStatsRow struct {
+	Queue           string `json:"queue"`
+	PendingCount    int64  `json:"pending_count"`
+	ProcessingCount int64  `json:"processing_count"`
+	FailedCount     int64  `json:"failed_count"`
+}
+
+// Pending, processing and failed counts per queue
+func (q *Queries) ListJobQueueStats(ctx context.Context, tenantID pgtype.UUID) ([]ListJobQueueStatsRow, error) {
+	rows, err := q.db.Query(ctx, listJobQueueStats, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListJobQueueStatsRow{}
+	for rows.Next() {
+		var i ListJobQueueStatsRow
+		if err := rows.Scan(
+			&i.Queue,
+			&i.PendingCount,
+			&i.ProcessingCount,
+			&i.FailedCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobTypes = `-- name: ListJobTypes :many
+SELECT DISTINCT job_type
+FROM jobs
+WHERE (tenant_id = $1 OR $1::uuid IS NULL)
+ORDER BY job_type ASC
+`
+
+// Job types present in the queue, for the console filters
+func (q *Queries) ListJobTypes(ctx context.Context, tenantID pgtype.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, listJobTypes, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var job_type string
+		if err := rows.Scan(&job_type); err != nil {
+			return nil, err
+		}
+		items = append(items, job_type)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobs = `-- name: ListJobs :many
+SELECT id, tenant_id, job_type, queue, status, payload, priority, max_retries, retry_count, retry_backoff_seconds, scheduled_at, processing_started_at, processing_completed_at, worker_id, error_message, error_details, timeout_seconds, metadata, created_at, updated_at, heartbeat_at
+FROM jobs
+WHERE (tenant_id = $1 OR $1::uuid IS NULL)
+  AND (queue = $2 OR $2::varchar = '')
+  AND (job_type = $3 OR $3::varchar = '')
+  AND (status = $4 OR $4::varchar = '')
+ORDER BY created_at DESC
+LIMIT $5 OFFSET $6
+`
+
+type ListJobsParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	Queue    string      `json:"queue"`
+	JobType  string      `json:"job_type"`
+	Status   string      `json:"status"`
+	Limit    int32       `json:"limit"`
+	Offset   int32       `json:"offset"`
+}
+
+// Jobs matching the console filters, newest first. Empty filters match all.
+func (q *Queries) ListJobs(ctx context.Context, arg ListJobsParams) ([]Job, error) {
+	rows, err := q.db.Query(ctx, listJobs,
+		arg.TenantID,
+		arg.Queue,
+		arg.JobType,
+		arg.Status,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Job{}
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.JobType,
+			&i.Queue,
+			&i.Status,
+			&i.Payload,
+			&i.Priority,
+			&i.MaxRetries,
+			&i.RetryCount,
+			&i.RetryBackoffSeconds,
+			&i.ScheduledAt,
+			&i.ProcessingStartedAt,
+			&i.ProcessingCompletedAt,
+			&i.WorkerID,
+			&i.ErrorMessage,
+			&i.ErrorDetails,
+			&i.TimeoutSeconds,
+			&i.Metadata,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.HeartbeatAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listJobsByStatus = `-- name: ListJobsByStatus :many
-SELECT id, tenant_id, job_type, queue, status, payload, priority, max_retries, retry_count, retry_backoff_seconds, scheduled_at, processing_started_at, processing_completed_at, worker_id, error_message, error_details, timeout_seconds, metadata, created_at, updated_at
+SELECT id, tenant_id, job_type, queue, status, payload, priority, max_retries, retry_count, retry_backoff_seconds, scheduled_at, processing_started_at, processing_completed_at, worker_id, error_message, error_details, timeout_seconds, metadata, created_at, updated_at, heartbeat_at
 FROM jobs
 WHERE status = $1
   AND (tenant_id = $2 OR $2 IS NULL)
@@ -386,6 +734,7 @@ func (q *Queries) ListJobsByStatus(ctx context.Context, arg ListJobsByStatusPara
 			&i.Metadata,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.HeartbeatAt,
 		); err != nil {
 			return nil, err
 		}
@@ -396,3 +745,181 @@ func (q *Queries) ListJobsByStatus(ctx context.Context, arg ListJobsByStatusPara
 	}
 	return items, nil
 }
+
+const reapStaleJobs = `-- name: ReapStaleJobs :many
+UPDATE jobs
+SET
+    status = CASE
+        WHEN retry_count + 1 < max_retries THEN 'pending'
+        ELSE 'failed'
+    END,
+    retry_count = retry_count + 1,
+    scheduled_at = CASE
+        WHEN retry_count + 1 < max_retries THEN NOW()
+        ELSE scheduled_at
+    END,
+    processing_completed_at = CASE
+        WHEN retry_count + 1 >= max_retries THEN NOW()
+        ELSE NULL
+    END,
+    worker_id = CASE
+        WHEN retry_count + 1 < max_retries THEN NULL
+        ELSE worker_id
+    END,
+    processing_started_at = CASE
+        WHEN retry_count + 1 < max_retries THEN NULL
+        ELSE processing_started_at
+    END,
+    error_message = 'Worker ' || COALESCE(worker_id, 'unknown') || ' stopped responding',
+    error_details = jsonb_build_object(
+        'worker_id', worker_id,
+        'last_heartbeat_at', COALESCE(heartbeat_at, processing_started_at)
+    )
+WHERE status = 'processing'
+  AND COALESCE(heartbeat_at, processing_started_at) < $1::timestamptz
+RETURNING id, job_type, status, retry_count
+`
+
+type ReapStaleJobsRow struct {
+	ID         pgtype.UUID `json:"id"`
+	JobType    string      `json:"job_type"`
+	Status     string      `json:"status"`
+	RetryCount int32       `json:"retry_count"`
+}
+
+// Take back processing jobs whose worker stopped sending heartbeats, e.g.
+// after a crash or a deploy that killed the process. The lost run counts
+// as an attempt, so a job that keeps killing its worker ends up failed.
+func (q *Queries) ReapStaleJobs(ctx context.Context, staleBefore pgtype.Timestamptz) ([]ReapStaleJobsRow, error) {
+	rows, err := q.db.Query(ctx, reapStaleJobs, staleBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ReapStaleJobsRow{}
+	for rows.Next() {
+		var i ReapStaleJobsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.JobType,
+			&i.Status,
+			&i.RetryCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const releaseWorkerJobs = `-- name: ReleaseWorkerJobs :execrows
+UPDATE jobs
+SET
+    status = 'pending',
+    worker_id = NULL,
+    processing_started_at = NULL,
+    heartbeat_at = NULL
+WHERE worker_id = $1
+  AND status = 'processing'
+`
+
+// Put a stopping worker's unfinished jobs back in the queue without using
+// up a retry
+func (q *Queries) ReleaseWorkerJobs(ctx context.Context, workerID pgtype.Text) (int64, error) {
+	result, err := q.db.Exec(ctx, releaseWorkerJobs, workerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const requeueFailedJobs = `-- name: RequeueFailedJobs :execrows
+UPDATE jobs
+SET
+    status = 'pending',
+    scheduled_at = NOW(),
+    retry_count = 0,
+    worker_id = NULL,
+    processing_started_at = NULL,
+    processing_completed_at = NULL,
+    error_message = NULL,
+    error_details = NULL
+WHERE status = 'failed'
+  AND job_type = $1
+  AND (tenant_id = $2 OR $2::uuid IS NULL)
+  AND (processing_completed_at >= $3 OR $3::timestamptz IS NULL)
+`
+
+type RequeueFailedJobsParams struct {
+	JobType     string             `json:"job_type"`
+	TenantID    pgtype.UUID        `json:"tenant_id"`
+	FailedSince pgtype.Timestamptz `json:"failed_since"`
+}
+
+// Requeue every dead-lettered (failed) job of a type, e.g. after an
+// outage. failed_since limits it to jobs that failed from then on.
+func (q *Queries) RequeueFailedJobs(ctx context.Context, arg RequeueFailedJobsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, requeueFailedJobs, arg.JobType, arg.TenantID, arg.FailedSince)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const requeueJob = `-- name: RequeueJob :one
+UPDATE jobs
+SET
+    status = 'pending',
+    scheduled_at = $1,
+    retry_count = CASE WHEN status = 'pending' THEN retry_count ELSE 0 END,
+    worker_id = NULL,
+    processing_started_at = NULL,
+    processing_completed_at = NULL,
+    error_message = NULL,
+    error_details = NULL
+WHERE id = $2
+  AND (tenant_id = $3 OR $3::uuid IS NULL)
+  AND status IN ('pending', 'failed', 'cancelled')
+RETURNING id, tenant_id, job_type, queue, status, payload, priority, max_retries, retry_count, retry_backoff_seconds, scheduled_at, processing_started_at, processing_completed_at, worker_id, error_message, error_details, timeout_seconds, metadata, created_at, updated_at, heartbeat_at
+`
+
+type RequeueJobParams struct {
+	ScheduledAt pgtype.Timestamptz `json:"scheduled_at"`
+	ID          pgtype.UUID        `json:"id"`
+	TenantID    pgtype.UUID        `json:"tenant_id"`
+}
+
+// Put a pending, failed or cancelled job back in the queue to run at
+// scheduled_at. Failed and cancelled jobs get a fresh set of retries; the
+// previous attempt's error stays in job_history.
+func (q *Queries) RequeueJob(ctx context.Context, arg RequeueJobParams) (Job, error) {
+	row := q.db.QueryRow(ctx, requeueJob, arg.ScheduledAt, arg.ID, arg.TenantID)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.JobType,
+		&i.Queue,
+		&i.Status,
+		&i.Payload,
+		&i.Priority,
+		&i.MaxRetries,
+		&i.RetryCount,
+		&i.RetryBackoffSeconds,
+		&i.ScheduledAt,
+		&i.ProcessingStartedAt,
+		&i.ProcessingCompletedAt,
+		&i.WorkerID,
+		&i.ErrorMessage,
+		&i.ErrorDetails,
+		&i.TimeoutSeconds,
+		&i.Metadata,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.HeartbeatAt,
+	)
+	return i, err
+}
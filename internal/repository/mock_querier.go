@@ -98,6 +98,21 @@ func (mr *MockQuerierMockRecorder) AddCartItem(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCartItem", reflect.TypeOf((*MockQuerier)(nil).AddCartItem), ctx, arg)
 }
 
+// AdjustSKUInventory mocks base method.
+func (m *MockQuerier) AdjustSKUInventory(ctx context.Context, arg AdjustSKUInventoryParams) (InventoryAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustSKUInventory", ctx, arg)
+	ret0, _ := ret[0].(InventoryAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustSKUInventory indicates an expected call of AdjustSKUInventory.
+func (mr *MockQuerierMockRecorder) AdjustSKUInventory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustSKUInventory", reflect.TypeOf((*MockQuerier)(nil).AdjustSKUInventory), ctx, arg)
+}
+
 // AdminUpdateCustomer mocks base method.
 func (m *MockQuerier) AdminUpdateCustomer(ctx context.Context, arg AdminUpdateCustomerParams) error {
 	m.ctrl.T.Helper()
@@ -112,6 +127,94 @@ func (mr *MockQuerierMockRecorder) AdminUpdateCustomer(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminUpdateCustomer", reflect.TypeOf((*MockQuerier)(nil).AdminUpdateCustomer), ctx, arg)
 }
 
+// AdvanceShipmentTrackingStatus mocks base method.
+func (m *MockQuerier) AdvanceShipmentTrackingStatus(ctx context.Context, arg AdvanceShipmentTrackingStatusParams) (Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceShipmentTrackingStatus", ctx, arg)
+	ret0, _ := ret[0].(Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceShipmentTrackingStatus indicates an expected call of AdvanceShipmentTrackingStatus.
+func (mr *MockQuerierMockRecorder) AdvanceShipmentTrackingStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceShipmentTrackingStatus", reflect.TypeOf((*MockQuerier)(nil).AdvanceShipmentTrackingStatus), ctx, arg)
+}
+
+// AllocateOpenOrderItemsToRoastBatch mocks base method.
+func (m *MockQuerier) AllocateOpenOrderItemsToRoastBatch(ctx context.Context, arg AllocateOpenOrderItemsToRoastBatchParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateOpenOrderItemsToRoastBatch", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocateOpenOrderItemsToRoastBatch indicates an expected call of AllocateOpenOrderItemsToRoastBatch.
+func (mr *MockQuerierMockRecorder) AllocateOpenOrderItemsToRoastBatch(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateOpenOrderItemsToRoastBatch", reflect.TypeOf((*MockQuerier)(nil).AllocateOpenOrderItemsToRoastBatch), ctx, arg)
+}
+
+// AllocateOrderToRoastBatches mocks base method.
+func (m *MockQuerier) AllocateOrderToRoastBatches(ctx context.Context, arg AllocateOrderToRoastBatchesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateOrderToRoastBatches", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocateOrderToRoastBatches indicates an expected call of AllocateOrderToRoastBatches.
+func (mr *MockQuerierMockRecorder) AllocateOrderToRoastBatches(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateOrderToRoastBatches", reflect.TypeOf((*MockQuerier)(nil).AllocateOrderToRoastBatches), ctx, arg)
+}
+
+// AllocateRenewalsToRoastBatch mocks base method.
+func (m *MockQuerier) AllocateRenewalsToRoastBatch(ctx context.Context, arg AllocateRenewalsToRoastBatchParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateRenewalsToRoastBatch", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocateRenewalsToRoastBatch indicates an expected call of AllocateRenewalsToRoastBatch.
+func (mr *MockQuerierMockRecorder) AllocateRenewalsToRoastBatch(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateRenewalsToRoastBatch", reflect.TypeOf((*MockQuerier)(nil).AllocateRenewalsToRoastBatch), ctx, arg)
+}
+
+// AssignProductCategories mocks base method.
+func (m *MockQuerier) AssignProductCategories(ctx context.Context, arg AssignProductCategoriesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignProductCategories", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignProductCategories indicates an expected call of AssignProductCategories.
+func (mr *MockQuerierMockRecorder) AssignProductCategories(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignProductCategories", reflect.TypeOf((*MockQuerier)(nil).AssignProductCategories), ctx, arg)
+}
+
+// AssignProductTags mocks base method.
+func (m *MockQuerier) AssignProductTags(ctx context.Context, arg AssignProductTagsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignProductTags", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignProductTags indicates an expected call of AssignProductTags.
+func (mr *MockQuerierMockRecorder) AssignProductTags(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignProductTags", reflect.TypeOf((*MockQuerier)(nil).AssignProductTags), ctx, arg)
+}
+
 // CancelJob mocks base method.
 func (m *MockQuerier) CancelJob(ctx context.Context, id pgtype.UUID) error {
 	m.ctrl.T.Helper()
@@ -126,6 +229,36 @@ func (mr *MockQuerierMockRecorder) CancelJob(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJob", reflect.TypeOf((*MockQuerier)(nil).CancelJob), ctx, id)
 }
 
+// CancelJobInScope mocks base method.
+func (m *MockQuerier) CancelJobInScope(ctx context.Context, arg CancelJobInScopeParams) (Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelJobInScope", ctx, arg)
+	ret0, _ := ret[0].(Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelJobInScope indicates an expected call of CancelJobInScope.
+func (mr *MockQuerierMockRecorder) CancelJobInScope(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJobInScope", reflect.TypeOf((*MockQuerier)(nil).CancelJobInScope), ctx, arg)
+}
+
+// CancelRoastBatch mocks base method.
+func (m *MockQuerier) CancelRoastBatch(ctx context.Context, arg CancelRoastBatchParams) (RoastBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelRoastBatch", ctx, arg)
+	ret0, _ := ret[0].(RoastBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelRoastBatch indicates an expected call of CancelRoastBatch.
+func (mr *MockQuerierMockRecorder) CancelRoastBatch(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelRoastBatch", reflect.TypeOf((*MockQuerier)(nil).CancelRoastBatch), ctx, arg)
+}
+
 // CancelTenant mocks base method.
 func (m *MockQuerier) CancelTenant(ctx context.Context, id pgtype.UUID) error {
 	m.ctrl.T.Helper()
@@ -350,6 +483,21 @@ func (mr *MockQuerierMockRecorder) CheckWholesalePricing(ctx, tenantID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckWholesalePricing", reflect.TypeOf((*MockQuerier)(nil).CheckWholesalePricing), ctx, tenantID)
 }
 
+// ClaimCartForOrder mocks base method.
+func (m *MockQuerier) ClaimCartForOrder(ctx context.Context, arg ClaimCartForOrderParams) (pgtype.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimCartForOrder", ctx, arg)
+	ret0, _ := ret[0].(pgtype.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimCartForOrder indicates an expected call of ClaimCartForOrder.
+func (mr *MockQuerierMockRecorder) ClaimCartForOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimCartForOrder", reflect.TypeOf((*MockQuerier)(nil).ClaimCartForOrder), ctx, arg)
+}
+
 // ClaimNextJob mocks base method.
 func (m *MockQuerier) ClaimNextJob(ctx context.Context, arg ClaimNextJobParams) (Job, error) {
 	m.ctrl.T.Helper()
@@ -365,6 +513,21 @@ func (mr *MockQuerierMockRecorder) ClaimNextJob(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNextJob", reflect.TypeOf((*MockQuerier)(nil).ClaimNextJob), ctx, arg)
 }
 
+// ClaimStandingOrderDelivery mocks base method.
+func (m *MockQuerier) ClaimStandingOrderDelivery(ctx context.Context, arg ClaimStandingOrderDeliveryParams) (StandingOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimStandingOrderDelivery", ctx, arg)
+	ret0, _ := ret[0].(StandingOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimStandingOrderDelivery indicates an expected call of ClaimStandingOrderDelivery.
+func (mr *MockQuerierMockRecorder) ClaimStandingOrderDelivery(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimStandingOrderDelivery", reflect.TypeOf((*MockQuerier)(nil).ClaimStandingOrderDelivery), ctx, arg)
+}
+
 // CleanupStalePendingDomains mocks base method.
 func (m *MockQuerier) CleanupStalePendingDomains(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -421,18 +584,47 @@ func (mr *MockQuerierMockRecorder) ClearTenantGracePeriod(ctx, id any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearTenantGracePeriod", reflect.TypeOf((*MockQuerier)(nil).ClearTenantGracePeriod), ctx, id)
 }
 
+// CloseScheduledSubscriptionEvents mocks base method.
+func (m *MockQuerier) CloseScheduledSubscriptionEvents(ctx context.Context, arg CloseScheduledSubscriptionEventsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseScheduledSubscriptionEvents", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseScheduledSubscriptionEvents indicates an expected call of CloseScheduledSubscriptionEvents.
+func (mr *MockQuerierMockRecorder) CloseScheduledSubscriptionEvents(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseScheduledSubscriptionEvents", reflect.TypeOf((*MockQuerier)(nil).CloseScheduledSubscriptionEvents), ctx, arg)
+}
+
+// CompleteFulfillmentBatch mocks base method.
+func (m *MockQuerier) CompleteFulfillmentBatch(ctx context.Context, arg CompleteFulfillmentBatchParams) (FulfillmentBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteFulfillmentBatch", ctx, arg)
+	ret0, _ := ret[0].(FulfillmentBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteFulfillmentBatch indicates an expected call of CompleteFulfillmentBatch.
+func (mr *MockQuerierMockRecorder) CompleteFulfillmentBatch(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteFulfillmentBatch", reflect.TypeOf((*MockQuerier)(nil).CompleteFulfillmentBatch), ctx, arg)
+}
+
 // CompleteJob mocks base method.
-func (m *MockQuerier) CompleteJob(ctx context.Context, id pgtype.UUID) error {
+func (m *MockQuerier) CompleteJob(ctx context.Context, arg CompleteJobParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CompleteJob", ctx, id)
+	ret := m.ctrl.Call(m, "CompleteJob", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CompleteJob indicates an expected call of CompleteJob.
-func (mr *MockQuerierMockRecorder) CompleteJob(ctx, id any) *gomock.Call {
+func (mr *MockQuerierMockRecorder) CompleteJob(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteJob", reflect.TypeOf((*MockQuerier)(nil).CompleteJob), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteJob", reflect.TypeOf((*MockQuerier)(nil).CompleteJob), ctx, arg)
 }
 
 // CountActiveOperatorSessions mocks base method.
@@ -465,6 +657,21 @@ func (mr *MockQuerierMockRecorder) CountAddressesForUser(ctx, arg any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAddressesForUser", reflect.TypeOf((*MockQuerier)(nil).CountAddressesForUser), ctx, arg)
 }
 
+// CountDiscountCodeUsageForUser mocks base method.
+func (m *MockQuerier) CountDiscountCodeUsageForUser(ctx context.Context, arg CountDiscountCodeUsageForUserParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDiscountCodeUsageForUser", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDiscountCodeUsageForUser indicates an expected call of CountDiscountCodeUsageForUser.
+func (mr *MockQuerierMockRecorder) CountDiscountCodeUsageForUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDiscountCodeUsageForUser", reflect.TypeOf((*MockQuerier)(nil).CountDiscountCodeUsageForUser), ctx, arg)
+}
+
 // CountInvoices mocks base method.
 func (m *MockQuerier) CountInvoices(ctx context.Context, tenantID pgtype.UUID) (int64, error) {
 	m.ctrl.T.Helper()
@@ -480,6 +687,21 @@ func (mr *MockQuerierMockRecorder) CountInvoices(ctx, tenantID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountInvoices", reflect.TypeOf((*MockQuerier)(nil).CountInvoices), ctx, tenantID)
 }
 
+// CountJobs mocks base method.
+func (m *MockQuerier) CountJobs(ctx context.Context, arg CountJobsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountJobs", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountJobs indicates an expected call of CountJobs.
+func (mr *MockQuerierMockRecorder) CountJobs(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountJobs", reflect.TypeOf((*MockQuerier)(nil).CountJobs), ctx, arg)
+}
+
 // CountJobsByStatus mocks base method.
 func (m *MockQuerier) CountJobsByStatus(ctx context.Context, arg CountJobsByStatusParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -615,6 +837,21 @@ func (mr *MockQuerierMockRecorder) CountRecentVerificationRequestsByUser(ctx, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecentVerificationRequestsByUser", reflect.TypeOf((*MockQuerier)(nil).CountRecentVerificationRequestsByUser), ctx, arg)
 }
 
+// CountReviewsByStatus mocks base method.
+func (m *MockQuerier) CountReviewsByStatus(ctx context.Context, tenantID pgtype.UUID) ([]CountReviewsByStatusRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReviewsByStatus", ctx, tenantID)
+	ret0, _ := ret[0].([]CountReviewsByStatusRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReviewsByStatus indicates an expected call of CountReviewsByStatus.
+func (mr *MockQuerierMockRecorder) CountReviewsByStatus(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReviewsByStatus", reflect.TypeOf((*MockQuerier)(nil).CountReviewsByStatus), ctx, tenantID)
+}
+
 // CountSubscriptions mocks base method.
 func (m *MockQuerier) CountSubscriptions(ctx context.Context, tenantID pgtype.UUID) (int64, error) {
 	m.ctrl.T.Helper()
@@ -765,6 +1002,21 @@ func (mr *MockQuerierMockRecorder) CreateCustomerAddress(ctx, arg any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomerAddress", reflect.TypeOf((*MockQuerier)(nil).CreateCustomerAddress), ctx, arg)
 }
 
+// CreateDiscountCode mocks base method.
+func (m *MockQuerier) CreateDiscountCode(ctx context.Context, arg CreateDiscountCodeParams) (DiscountCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDiscountCode", ctx, arg)
+	ret0, _ := ret[0].(DiscountCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDiscountCode indicates an expected call of CreateDiscountCode.
+func (mr *MockQuerierMockRecorder) CreateDiscountCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDiscountCode", reflect.TypeOf((*MockQuerier)(nil).CreateDiscountCode), ctx, arg)
+}
+
 // CreateEmailVerificationToken mocks base method.
 func (m *MockQuerier) CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) (EmailVerificationToken, error) {
 	m.ctrl.T.Helper()
@@ -780,6 +1032,36 @@ func (mr *MockQuerierMockRecorder) CreateEmailVerificationToken(ctx, arg any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailVerificationToken", reflect.TypeOf((*MockQuerier)(nil).CreateEmailVerificationToken), ctx, arg)
 }
 
+// CreateFulfillmentBatch mocks base method.
+func (m *MockQuerier) CreateFulfillmentBatch(ctx context.Context, arg CreateFulfillmentBatchParams) (FulfillmentBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFulfillmentBatch", ctx, arg)
+	ret0, _ := ret[0].(FulfillmentBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFulfillmentBatch indicates an expected call of CreateFulfillmentBatch.
+func (mr *MockQuerierMockRecorder) CreateFulfillmentBatch(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFulfillmentBatch", reflect.TypeOf((*MockQuerier)(nil).CreateFulfillmentBatch), ctx, arg)
+}
+
+// CreateFulfillmentBatchOrder mocks base method.
+func (m *MockQuerier) CreateFulfillmentBatchOrder(ctx context.Context, arg CreateFulfillmentBatchOrderParams) (FulfillmentBatchOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFulfillmentBatchOrder", ctx, arg)
+	ret0, _ := ret[0].(FulfillmentBatchOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFulfillmentBatchOrder indicates an expected call of CreateFulfillmentBatchOrder.
+func (mr *MockQuerierMockRecorder) CreateFulfillmentBatchOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFulfillmentBatchOrder", reflect.TypeOf((*MockQuerier)(nil).CreateFulfillmentBatchOrder), ctx, arg)
+}
+
 // CreateInvoice mocks base method.
 func (m *MockQuerier) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (Invoice, error) {
 	m.ctrl.T.Helper()
@@ -840,6 +1122,21 @@ func (mr *MockQuerierMockRecorder) CreateInvoicePayment(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoicePayment", reflect.TypeOf((*MockQuerier)(nil).CreateInvoicePayment), ctx, arg)
 }
 
+// CreateLabelShipment mocks base method.
+func (m *MockQuerier) CreateLabelShipment(ctx context.Context, arg CreateLabelShipmentParams) (Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLabelShipment", ctx, arg)
+	ret0, _ := ret[0].(Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLabelShipment indicates an expected call of CreateLabelShipment.
+func (mr *MockQuerierMockRecorder) CreateLabelShipment(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLabelShipment", reflect.TypeOf((*MockQuerier)(nil).CreateLabelShipment), ctx, arg)
+}
+
 // CreateOperatorSession mocks base method.
 func (m *MockQuerier) CreateOperatorSession(ctx context.Context, arg CreateOperatorSessionParams) (OperatorSession, error) {
 	m.ctrl.T.Helper()
@@ -885,6 +1182,20 @@ func (mr *MockQuerierMockRecorder) CreateOrderItem(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderItem", reflect.TypeOf((*MockQuerier)(nil).CreateOrderItem), ctx, arg)
 }
 
+// CreateOrderTemplateItem mocks base method.
+func (m *MockQuerier) CreateOrderTemplateItem(ctx context.Context, arg CreateOrderTemplateItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderTemplateItem", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrderTemplateItem indicates an expected call of CreateOrderTemplateItem.
+func (mr *MockQuerierMockRecorder) CreateOrderTemplateItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderTemplateItem", reflect.TypeOf((*MockQuerier)(nil).CreateOrderTemplateItem), ctx, arg)
+}
+
 // CreatePasswordResetToken mocks base method.
 func (m *MockQuerier) CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) (PasswordResetToken, error) {
 	m.ctrl.T.Helper()
@@ -990,6 +1301,21 @@ func (mr *MockQuerierMockRecorder) CreateProduct(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockQuerier)(nil).CreateProduct), ctx, arg)
 }
 
+// CreateProductCategory mocks base method.
+func (m *MockQuerier) CreateProductCategory(ctx context.Context, arg CreateProductCategoryParams) (ProductCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductCategory", ctx, arg)
+	ret0, _ := ret[0].(ProductCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductCategory indicates an expected call of CreateProductCategory.
+func (mr *MockQuerierMockRecorder) CreateProductCategory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductCategory", reflect.TypeOf((*MockQuerier)(nil).CreateProductCategory), ctx, arg)
+}
+
 // CreateProductImage mocks base method.
 func (m *MockQuerier) CreateProductImage(ctx context.Context, arg CreateProductImageParams) (ProductImage, error) {
 	m.ctrl.T.Helper()
@@ -1005,6 +1331,21 @@ func (mr *MockQuerierMockRecorder) CreateProductImage(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductImage", reflect.TypeOf((*MockQuerier)(nil).CreateProductImage), ctx, arg)
 }
 
+// CreateProductReview mocks base method.
+func (m *MockQuerier) CreateProductReview(ctx context.Context, arg CreateProductReviewParams) (ProductReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductReview", ctx, arg)
+	ret0, _ := ret[0].(ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductReview indicates an expected call of CreateProductReview.
+func (mr *MockQuerierMockRecorder) CreateProductReview(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductReview", reflect.TypeOf((*MockQuerier)(nil).CreateProductReview), ctx, arg)
+}
+
 // CreateProductSKU mocks base method.
 func (m *MockQuerier) CreateProductSKU(ctx context.Context, arg CreateProductSKUParams) (ProductSku, error) {
 	m.ctrl.T.Helper()
@@ -1020,6 +1361,21 @@ func (mr *MockQuerierMockRecorder) CreateProductSKU(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductSKU", reflect.TypeOf((*MockQuerier)(nil).CreateProductSKU), ctx, arg)
 }
 
+// CreateProductTag mocks base method.
+func (m *MockQuerier) CreateProductTag(ctx context.Context, arg CreateProductTagParams) (ProductTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductTag", ctx, arg)
+	ret0, _ := ret[0].(ProductTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductTag indicates an expected call of CreateProductTag.
+func (mr *MockQuerierMockRecorder) CreateProductTag(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductTag", reflect.TypeOf((*MockQuerier)(nil).CreateProductTag), ctx, arg)
+}
+
 // CreateProviderConfig mocks base method.
 func (m *MockQuerier) CreateProviderConfig(ctx context.Context, arg CreateProviderConfigParams) (TenantProviderConfig, error) {
 	m.ctrl.T.Helper()
@@ -1035,6 +1391,66 @@ func (mr *MockQuerierMockRecorder) CreateProviderConfig(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProviderConfig", reflect.TypeOf((*MockQuerier)(nil).CreateProviderConfig), ctx, arg)
 }
 
+// CreateRefund mocks base method.
+func (m *MockQuerier) CreateRefund(ctx context.Context, arg CreateRefundParams) (Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefund", ctx, arg)
+	ret0, _ := ret[0].(Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRefund indicates an expected call of CreateRefund.
+func (mr *MockQuerierMockRecorder) CreateRefund(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefund", reflect.TypeOf((*MockQuerier)(nil).CreateRefund), ctx, arg)
+}
+
+// CreateRefundItem mocks base method.
+func (m *MockQuerier) CreateRefundItem(ctx context.Context, arg CreateRefundItemParams) (RefundItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefundItem", ctx, arg)
+	ret0, _ := ret[0].(RefundItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRefundItem indicates an expected call of CreateRefundItem.
+func (mr *MockQuerierMockRecorder) CreateRefundItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefundItem", reflect.TypeOf((*MockQuerier)(nil).CreateRefundItem), ctx, arg)
+}
+
+// CreateReviewRequest mocks base method.
+func (m *MockQuerier) CreateReviewRequest(ctx context.Context, arg CreateReviewRequestParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReviewRequest", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReviewRequest indicates an expected call of CreateReviewRequest.
+func (mr *MockQuerierMockRecorder) CreateReviewRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReviewRequest", reflect.TypeOf((*MockQuerier)(nil).CreateReviewRequest), ctx, arg)
+}
+
+// CreateRoastBatch mocks base method.
+func (m *MockQuerier) CreateRoastBatch(ctx context.Context, arg CreateRoastBatchParams) (RoastBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRoastBatch", ctx, arg)
+	ret0, _ := ret[0].(RoastBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRoastBatch indicates an expected call of CreateRoastBatch.
+func (mr *MockQuerierMockRecorder) CreateRoastBatch(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRoastBatch", reflect.TypeOf((*MockQuerier)(nil).CreateRoastBatch), ctx, arg)
+}
+
 // CreateSession mocks base method.
 func (m *MockQuerier) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
 	m.ctrl.T.Helper()
@@ -1080,6 +1496,20 @@ func (mr *MockQuerierMockRecorder) CreateShipmentItem(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipmentItem", reflect.TypeOf((*MockQuerier)(nil).CreateShipmentItem), ctx, arg)
 }
 
+// CreateShipmentTrackingEvent mocks base method.
+func (m *MockQuerier) CreateShipmentTrackingEvent(ctx context.Context, arg CreateShipmentTrackingEventParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShipmentTrackingEvent", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShipmentTrackingEvent indicates an expected call of CreateShipmentTrackingEvent.
+func (mr *MockQuerierMockRecorder) CreateShipmentTrackingEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipmentTrackingEvent", reflect.TypeOf((*MockQuerier)(nil).CreateShipmentTrackingEvent), ctx, arg)
+}
+
 // CreateShippingRate mocks base method.
 func (m *MockQuerier) CreateShippingRate(ctx context.Context, arg CreateShippingRateParams) (TenantShippingRate, error) {
 	m.ctrl.T.Helper()
@@ -1095,6 +1525,21 @@ func (mr *MockQuerierMockRecorder) CreateShippingRate(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShippingRate", reflect.TypeOf((*MockQuerier)(nil).CreateShippingRate), ctx, arg)
 }
 
+// CreateStandingOrder mocks base method.
+func (m *MockQuerier) CreateStandingOrder(ctx context.Context, arg CreateStandingOrderParams) (StandingOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStandingOrder", ctx, arg)
+	ret0, _ := ret[0].(StandingOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStandingOrder indicates an expected call of CreateStandingOrder.
+func (mr *MockQuerierMockRecorder) CreateStandingOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStandingOrder", reflect.TypeOf((*MockQuerier)(nil).CreateStandingOrder), ctx, arg)
+}
+
 // CreateSubscription mocks base method.
 func (m *MockQuerier) CreateSubscription(ctx context.Context, arg CreateSubscriptionParams) (Subscription, error) {
 	m.ctrl.T.Helper()
@@ -1110,17 +1555,32 @@ func (mr *MockQuerierMockRecorder) CreateSubscription(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockQuerier)(nil).CreateSubscription), ctx, arg)
 }
 
-// CreateSubscriptionItem mocks base method.
-func (m *MockQuerier) CreateSubscriptionItem(ctx context.Context, arg CreateSubscriptionItemParams) (SubscriptionItem, error) {
+// CreateSubscriptionDunning mocks base method.
+func (m *MockQuerier) CreateSubscriptionDunning(ctx context.Context, arg CreateSubscriptionDunningParams) (SubscriptionDunning, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSubscriptionItem", ctx, arg)
-	ret0, _ := ret[0].(SubscriptionItem)
+	ret := m.ctrl.Call(m, "CreateSubscriptionDunning", ctx, arg)
+	ret0, _ := ret[0].(SubscriptionDunning)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSubscriptionItem indicates an expected call of CreateSubscriptionItem.
-func (mr *MockQuerierMockRecorder) CreateSubscriptionItem(ctx, arg any) *gomock.Call {
+// CreateSubscriptionDunning indicates an expected call of CreateSubscriptionDunning.
+func (mr *MockQuerierMockRecorder) CreateSubscriptionDunning(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscriptionDunning", reflect.TypeOf((*MockQuerier)(nil).CreateSubscriptionDunning), ctx, arg)
+}
+
+// CreateSubscriptionItem mocks base method.
+func (m *MockQuerier) CreateSubscriptionItem(ctx context.Context, arg CreateSubscriptionItemParams) (SubscriptionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscriptionItem", ctx, arg)
+	ret0, _ := ret[0].(SubscriptionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscriptionItem indicates an expected call of CreateSubscriptionItem.
+func (mr *MockQuerierMockRecorder) CreateSubscriptionItem(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscriptionItem", reflect.TypeOf((*MockQuerier)(nil).CreateSubscriptionItem), ctx, arg)
 }
@@ -1230,6 +1690,21 @@ func (mr *MockQuerierMockRecorder) CreateWebhookEvent(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookEvent", reflect.TypeOf((*MockQuerier)(nil).CreateWebhookEvent), ctx, arg)
 }
 
+// CreateWhiteLabelSKU mocks base method.
+func (m *MockQuerier) CreateWhiteLabelSKU(ctx context.Context, arg CreateWhiteLabelSKUParams) (ProductSku, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWhiteLabelSKU", ctx, arg)
+	ret0, _ := ret[0].(ProductSku)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWhiteLabelSKU indicates an expected call of CreateWhiteLabelSKU.
+func (mr *MockQuerierMockRecorder) CreateWhiteLabelSKU(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWhiteLabelSKU", reflect.TypeOf((*MockQuerier)(nil).CreateWhiteLabelSKU), ctx, arg)
+}
+
 // DeactivateCustomDomain mocks base method.
 func (m *MockQuerier) DeactivateCustomDomain(ctx context.Context, id pgtype.UUID) error {
 	m.ctrl.T.Helper()
@@ -1272,6 +1747,49 @@ func (mr *MockQuerierMockRecorder) DeleteCustomerAddress(ctx, arg any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomerAddress", reflect.TypeOf((*MockQuerier)(nil).DeleteCustomerAddress), ctx, arg)
 }
 
+// DeleteCustomerSKUOrderRule mocks base method.
+func (m *MockQuerier) DeleteCustomerSKUOrderRule(ctx context.Context, arg DeleteCustomerSKUOrderRuleParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomerSKUOrderRule", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomerSKUOrderRule indicates an expected call of DeleteCustomerSKUOrderRule.
+func (mr *MockQuerierMockRecorder) DeleteCustomerSKUOrderRule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomerSKUOrderRule", reflect.TypeOf((*MockQuerier)(nil).DeleteCustomerSKUOrderRule), ctx, arg)
+}
+
+// DeleteDiscountCode mocks base method.
+func (m *MockQuerier) DeleteDiscountCode(ctx context.Context, arg DeleteDiscountCodeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDiscountCode", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDiscountCode indicates an expected call of DeleteDiscountCode.
+func (mr *MockQuerierMockRecorder) DeleteDiscountCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDiscountCode", reflect.TypeOf((*MockQuerier)(nil).DeleteDiscountCode), ctx, arg)
+}
+
+// DeleteEmailTemplate mocks base method.
+func (m *MockQuerier) DeleteEmailTemplate(ctx context.Context, arg DeleteEmailTemplateParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmailTemplate", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEmailTemplate indicates an expected call of DeleteEmailTemplate.
+func (mr *MockQuerierMockRecorder) DeleteEmailTemplate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmailTemplate", reflect.TypeOf((*MockQuerier)(nil).DeleteEmailTemplate), ctx, arg)
+}
+
 // DeleteExpiredEmailVerificationTokens mocks base method.
 func (m *MockQuerier) DeleteExpiredEmailVerificationTokens(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -1412,6 +1930,34 @@ func (mr *MockQuerierMockRecorder) DeleteOperatorSessionsByOperatorID(ctx, opera
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOperatorSessionsByOperatorID", reflect.TypeOf((*MockQuerier)(nil).DeleteOperatorSessionsByOperatorID), ctx, operatorID)
 }
 
+// DeleteOrderTemplate mocks base method.
+func (m *MockQuerier) DeleteOrderTemplate(ctx context.Context, arg DeleteOrderTemplateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrderTemplate", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrderTemplate indicates an expected call of DeleteOrderTemplate.
+func (mr *MockQuerierMockRecorder) DeleteOrderTemplate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrderTemplate", reflect.TypeOf((*MockQuerier)(nil).DeleteOrderTemplate), ctx, arg)
+}
+
+// DeleteOrderTemplateItems mocks base method.
+func (m *MockQuerier) DeleteOrderTemplateItems(ctx context.Context, arg DeleteOrderTemplateItemsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrderTemplateItems", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrderTemplateItems indicates an expected call of DeleteOrderTemplateItems.
+func (mr *MockQuerierMockRecorder) DeleteOrderTemplateItems(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrderTemplateItems", reflect.TypeOf((*MockQuerier)(nil).DeleteOrderTemplateItems), ctx, arg)
+}
+
 // DeletePaymentMethod mocks base method.
 func (m *MockQuerier) DeletePaymentMethod(ctx context.Context, arg DeletePaymentMethodParams) error {
 	m.ctrl.T.Helper()
@@ -1468,6 +2014,20 @@ func (mr *MockQuerierMockRecorder) DeletePriceListEntry(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePriceListEntry", reflect.TypeOf((*MockQuerier)(nil).DeletePriceListEntry), ctx, arg)
 }
 
+// DeletePriceListEntryTier mocks base method.
+func (m *MockQuerier) DeletePriceListEntryTier(ctx context.Context, arg DeletePriceListEntryTierParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePriceListEntryTier", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePriceListEntryTier indicates an expected call of DeletePriceListEntryTier.
+func (mr *MockQuerierMockRecorder) DeletePriceListEntryTier(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePriceListEntryTier", reflect.TypeOf((*MockQuerier)(nil).DeletePriceListEntryTier), ctx, arg)
+}
+
 // DeleteProduct mocks base method.
 func (m *MockQuerier) DeleteProduct(ctx context.Context, arg DeleteProductParams) error {
 	m.ctrl.T.Helper()
@@ -1482,6 +2042,20 @@ func (mr *MockQuerierMockRecorder) DeleteProduct(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockQuerier)(nil).DeleteProduct), ctx, arg)
 }
 
+// DeleteProductCategory mocks base method.
+func (m *MockQuerier) DeleteProductCategory(ctx context.Context, arg DeleteProductCategoryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductCategory", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductCategory indicates an expected call of DeleteProductCategory.
+func (mr *MockQuerierMockRecorder) DeleteProductCategory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductCategory", reflect.TypeOf((*MockQuerier)(nil).DeleteProductCategory), ctx, arg)
+}
+
 // DeleteProductImage mocks base method.
 func (m *MockQuerier) DeleteProductImage(ctx context.Context, arg DeleteProductImageParams) error {
 	m.ctrl.T.Helper()
@@ -1510,6 +2084,20 @@ func (mr *MockQuerierMockRecorder) DeleteProductSKU(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductSKU", reflect.TypeOf((*MockQuerier)(nil).DeleteProductSKU), ctx, arg)
 }
 
+// DeleteProductTag mocks base method.
+func (m *MockQuerier) DeleteProductTag(ctx context.Context, arg DeleteProductTagParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductTag", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductTag indicates an expected call of DeleteProductTag.
+func (mr *MockQuerierMockRecorder) DeleteProductTag(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductTag", reflect.TypeOf((*MockQuerier)(nil).DeleteProductTag), ctx, arg)
+}
+
 // DeleteProviderConfig mocks base method.
 func (m *MockQuerier) DeleteProviderConfig(ctx context.Context, arg DeleteProviderConfigParams) error {
 	m.ctrl.T.Helper()
@@ -1524,6 +2112,35 @@ func (mr *MockQuerierMockRecorder) DeleteProviderConfig(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProviderConfig", reflect.TypeOf((*MockQuerier)(nil).DeleteProviderConfig), ctx, arg)
 }
 
+// DeleteRoastBatchAllocation mocks base method.
+func (m *MockQuerier) DeleteRoastBatchAllocation(ctx context.Context, arg DeleteRoastBatchAllocationParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRoastBatchAllocation", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRoastBatchAllocation indicates an expected call of DeleteRoastBatchAllocation.
+func (mr *MockQuerierMockRecorder) DeleteRoastBatchAllocation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoastBatchAllocation", reflect.TypeOf((*MockQuerier)(nil).DeleteRoastBatchAllocation), ctx, arg)
+}
+
+// DeleteRoastBatchAllocations mocks base method.
+func (m *MockQuerier) DeleteRoastBatchAllocations(ctx context.Context, arg DeleteRoastBatchAllocationsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRoastBatchAllocations", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRoastBatchAllocations indicates an expected call of DeleteRoastBatchAllocations.
+func (mr *MockQuerierMockRecorder) DeleteRoastBatchAllocations(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoastBatchAllocations", reflect.TypeOf((*MockQuerier)(nil).DeleteRoastBatchAllocations), ctx, arg)
+}
+
 // DeleteSession mocks base method.
 func (m *MockQuerier) DeleteSession(ctx context.Context, token string) error {
 	m.ctrl.T.Helper()
@@ -1566,6 +2183,20 @@ func (mr *MockQuerierMockRecorder) DeleteShippingRatesByProvider(ctx, arg any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShippingRatesByProvider", reflect.TypeOf((*MockQuerier)(nil).DeleteShippingRatesByProvider), ctx, arg)
 }
 
+// DeleteStandingOrderItem mocks base method.
+func (m *MockQuerier) DeleteStandingOrderItem(ctx context.Context, arg DeleteStandingOrderItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStandingOrderItem", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStandingOrderItem indicates an expected call of DeleteStandingOrderItem.
+func (mr *MockQuerierMockRecorder) DeleteStandingOrderItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStandingOrderItem", reflect.TypeOf((*MockQuerier)(nil).DeleteStandingOrderItem), ctx, arg)
+}
+
 // DeleteTaxRate mocks base method.
 func (m *MockQuerier) DeleteTaxRate(ctx context.Context, arg DeleteTaxRateParams) error {
 	m.ctrl.T.Helper()
@@ -1623,6 +2254,34 @@ func (mr *MockQuerierMockRecorder) EnqueueJob(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueJob", reflect.TypeOf((*MockQuerier)(nil).EnqueueJob), ctx, arg)
 }
 
+// EnsureJobSchedule mocks base method.
+func (m *MockQuerier) EnsureJobSchedule(ctx context.Context, arg EnsureJobScheduleParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureJobSchedule", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureJobSchedule indicates an expected call of EnsureJobSchedule.
+func (mr *MockQuerierMockRecorder) EnsureJobSchedule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureJobSchedule", reflect.TypeOf((*MockQuerier)(nil).EnsureJobSchedule), ctx, arg)
+}
+
+// FailFulfillmentBatch mocks base method.
+func (m *MockQuerier) FailFulfillmentBatch(ctx context.Context, arg FailFulfillmentBatchParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailFulfillmentBatch", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailFulfillmentBatch indicates an expected call of FailFulfillmentBatch.
+func (mr *MockQuerierMockRecorder) FailFulfillmentBatch(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailFulfillmentBatch", reflect.TypeOf((*MockQuerier)(nil).FailFulfillmentBatch), ctx, arg)
+}
+
 // FailJob mocks base method.
 func (m *MockQuerier) FailJob(ctx context.Context, arg FailJobParams) (Job, error) {
 	m.ctrl.T.Helper()
@@ -1668,6 +2327,21 @@ func (mr *MockQuerierMockRecorder) GetActiveCustomDomains(ctx any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveCustomDomains", reflect.TypeOf((*MockQuerier)(nil).GetActiveCustomDomains), ctx)
 }
 
+// GetActiveEmailTemplate mocks base method.
+func (m *MockQuerier) GetActiveEmailTemplate(ctx context.Context, arg GetActiveEmailTemplateParams) (EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveEmailTemplate", ctx, arg)
+	ret0, _ := ret[0].(EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveEmailTemplate indicates an expected call of GetActiveEmailTemplate.
+func (mr *MockQuerierMockRecorder) GetActiveEmailTemplate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveEmailTemplate", reflect.TypeOf((*MockQuerier)(nil).GetActiveEmailTemplate), ctx, arg)
+}
+
 // GetActiveProviderConfigs mocks base method.
 func (m *MockQuerier) GetActiveProviderConfigs(ctx context.Context, arg GetActiveProviderConfigsParams) ([]TenantProviderConfig, error) {
 	m.ctrl.T.Helper()
@@ -1683,6 +2357,21 @@ func (mr *MockQuerierMockRecorder) GetActiveProviderConfigs(ctx, arg any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveProviderConfigs", reflect.TypeOf((*MockQuerier)(nil).GetActiveProviderConfigs), ctx, arg)
 }
 
+// GetActiveSubscriptionDunning mocks base method.
+func (m *MockQuerier) GetActiveSubscriptionDunning(ctx context.Context, arg GetActiveSubscriptionDunningParams) (SubscriptionDunning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSubscriptionDunning", ctx, arg)
+	ret0, _ := ret[0].(SubscriptionDunning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSubscriptionDunning indicates an expected call of GetActiveSubscriptionDunning.
+func (mr *MockQuerierMockRecorder) GetActiveSubscriptionDunning(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSubscriptionDunning", reflect.TypeOf((*MockQuerier)(nil).GetActiveSubscriptionDunning), ctx, arg)
+}
+
 // GetAddressByID mocks base method.
 func (m *MockQuerier) GetAddressByID(ctx context.Context, id pgtype.UUID) (Address, error) {
 	m.ctrl.T.Helper()
@@ -1789,33 +2478,33 @@ func (mr *MockQuerierMockRecorder) GetBillingCustomerForUser(ctx, arg any) *gomo
 }
 
 // GetCartByID mocks base method.
-func (m *MockQuerier) GetCartByID(ctx context.Context, id pgtype.UUID) (Cart, error) {
+func (m *MockQuerier) GetCartByID(ctx context.Context, arg GetCartByIDParams) (Cart, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCartByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetCartByID", ctx, arg)
 	ret0, _ := ret[0].(Cart)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCartByID indicates an expected call of GetCartByID.
-func (mr *MockQuerierMockRecorder) GetCartByID(ctx, id any) *gomock.Call {
+func (mr *MockQuerierMockRecorder) GetCartByID(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartByID", reflect.TypeOf((*MockQuerier)(nil).GetCartByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartByID", reflect.TypeOf((*MockQuerier)(nil).GetCartByID), ctx, arg)
 }
 
 // GetCartBySessionID mocks base method.
-func (m *MockQuerier) GetCartBySessionID(ctx context.Context, sessionID pgtype.UUID) (Cart, error) {
+func (m *MockQuerier) GetCartBySessionID(ctx context.Context, arg GetCartBySessionIDParams) (Cart, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCartBySessionID", ctx, sessionID)
+	ret := m.ctrl.Call(m, "GetCartBySessionID", ctx, arg)
 	ret0, _ := ret[0].(Cart)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCartBySessionID indicates an expected call of GetCartBySessionID.
-func (mr *MockQuerierMockRecorder) GetCartBySessionID(ctx, sessionID any) *gomock.Call {
+func (mr *MockQuerierMockRecorder) GetCartBySessionID(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartBySessionID", reflect.TypeOf((*MockQuerier)(nil).GetCartBySessionID), ctx, sessionID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartBySessionID", reflect.TypeOf((*MockQuerier)(nil).GetCartBySessionID), ctx, arg)
 }
 
 // GetCartItemCount mocks base method.
@@ -1893,6 +2582,21 @@ func (mr *MockQuerierMockRecorder) GetCustomDomainsByStatus(ctx, customDomainSta
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomDomainsByStatus", reflect.TypeOf((*MockQuerier)(nil).GetCustomDomainsByStatus), ctx, customDomainStatus)
 }
 
+// GetCustomerOutstandingBalance mocks base method.
+func (m *MockQuerier) GetCustomerOutstandingBalance(ctx context.Context, arg GetCustomerOutstandingBalanceParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerOutstandingBalance", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerOutstandingBalance indicates an expected call of GetCustomerOutstandingBalance.
+func (mr *MockQuerierMockRecorder) GetCustomerOutstandingBalance(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerOutstandingBalance", reflect.TypeOf((*MockQuerier)(nil).GetCustomerOutstandingBalance), ctx, arg)
+}
+
 // GetCustomersForBillingCycle mocks base method.
 func (m *MockQuerier) GetCustomersForBillingCycle(ctx context.Context, arg GetCustomersForBillingCycleParams) ([]GetCustomersForBillingCycleRow, error) {
 	m.ctrl.T.Helper()
@@ -1998,6 +2702,51 @@ func (mr *MockQuerierMockRecorder) GetDefaultShippingAddress(ctx, arg any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultShippingAddress", reflect.TypeOf((*MockQuerier)(nil).GetDefaultShippingAddress), ctx, arg)
 }
 
+// GetDiscountCodeByCode mocks base method.
+func (m *MockQuerier) GetDiscountCodeByCode(ctx context.Context, arg GetDiscountCodeByCodeParams) (DiscountCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiscountCodeByCode", ctx, arg)
+	ret0, _ := ret[0].(DiscountCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiscountCodeByCode indicates an expected call of GetDiscountCodeByCode.
+func (mr *MockQuerierMockRecorder) GetDiscountCodeByCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscountCodeByCode", reflect.TypeOf((*MockQuerier)(nil).GetDiscountCodeByCode), ctx, arg)
+}
+
+// GetDiscountCodeByID mocks base method.
+func (m *MockQuerier) GetDiscountCodeByID(ctx context.Context, arg GetDiscountCodeByIDParams) (DiscountCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiscountCodeByID", ctx, arg)
+	ret0, _ := ret[0].(DiscountCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiscountCodeByID indicates an expected call of GetDiscountCodeByID.
+func (mr *MockQuerierMockRecorder) GetDiscountCodeByID(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscountCodeByID", reflect.TypeOf((*MockQuerier)(nil).GetDiscountCodeByID), ctx, arg)
+}
+
+// GetEmailTemplate mocks base method.
+func (m *MockQuerier) GetEmailTemplate(ctx context.Context, arg GetEmailTemplateParams) (EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailTemplate", ctx, arg)
+	ret0, _ := ret[0].(EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailTemplate indicates an expected call of GetEmailTemplate.
+func (mr *MockQuerierMockRecorder) GetEmailTemplate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailTemplate", reflect.TypeOf((*MockQuerier)(nil).GetEmailTemplate), ctx, arg)
+}
+
 // GetEmailVerificationToken mocks base method.
 func (m *MockQuerier) GetEmailVerificationToken(ctx context.Context, arg GetEmailVerificationTokenParams) (GetEmailVerificationTokenRow, error) {
 	m.ctrl.T.Helper()
@@ -2013,6 +2762,36 @@ func (mr *MockQuerierMockRecorder) GetEmailVerificationToken(ctx, arg any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailVerificationToken", reflect.TypeOf((*MockQuerier)(nil).GetEmailVerificationToken), ctx, arg)
 }
 
+// GetFulfillmentBatch mocks base method.
+func (m *MockQuerier) GetFulfillmentBatch(ctx context.Context, arg GetFulfillmentBatchParams) (FulfillmentBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFulfillmentBatch", ctx, arg)
+	ret0, _ := ret[0].(FulfillmentBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFulfillmentBatch indicates an expected call of GetFulfillmentBatch.
+func (mr *MockQuerierMockRecorder) GetFulfillmentBatch(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFulfillmentBatch", reflect.TypeOf((*MockQuerier)(nil).GetFulfillmentBatch), ctx, arg)
+}
+
+// GetInventorySKU mocks base method.
+func (m *MockQuerier) GetInventorySKU(ctx context.Context, arg GetInventorySKUParams) (GetInventorySKURow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventorySKU", ctx, arg)
+	ret0, _ := ret[0].(GetInventorySKURow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventorySKU indicates an expected call of GetInventorySKU.
+func (mr *MockQuerierMockRecorder) GetInventorySKU(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventorySKU", reflect.TypeOf((*MockQuerier)(nil).GetInventorySKU), ctx, arg)
+}
+
 // GetInvoiceByID mocks base method.
 func (m *MockQuerier) GetInvoiceByID(ctx context.Context, arg GetInvoiceByIDParams) (Invoice, error) {
 	m.ctrl.T.Helper()
@@ -2163,6 +2942,21 @@ func (mr *MockQuerierMockRecorder) GetJobByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobByID", reflect.TypeOf((*MockQuerier)(nil).GetJobByID), ctx, id)
 }
 
+// GetJobInScope mocks base method.
+func (m *MockQuerier) GetJobInScope(ctx context.Context, arg GetJobInScopeParams) (Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobInScope", ctx, arg)
+	ret0, _ := ret[0].(Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobInScope indicates an expected call of GetJobInScope.
+func (mr *MockQuerierMockRecorder) GetJobInScope(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobInScope", reflect.TypeOf((*MockQuerier)(nil).GetJobInScope), ctx, arg)
+}
+
 // GetJobStats mocks base method.
 func (m *MockQuerier) GetJobStats(ctx context.Context, tenantID pgtype.UUID) (GetJobStatsRow, error) {
 	m.ctrl.T.Helper()
@@ -2178,6 +2972,21 @@ func (mr *MockQuerierMockRecorder) GetJobStats(ctx, tenantID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobStats", reflect.TypeOf((*MockQuerier)(nil).GetJobStats), ctx, tenantID)
 }
 
+// GetLatestSubscriptionDunning mocks base method.
+func (m *MockQuerier) GetLatestSubscriptionDunning(ctx context.Context, arg GetLatestSubscriptionDunningParams) (SubscriptionDunning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestSubscriptionDunning", ctx, arg)
+	ret0, _ := ret[0].(SubscriptionDunning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestSubscriptionDunning indicates an expected call of GetLatestSubscriptionDunning.
+func (mr *MockQuerierMockRecorder) GetLatestSubscriptionDunning(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSubscriptionDunning", reflect.TypeOf((*MockQuerier)(nil).GetLatestSubscriptionDunning), ctx, arg)
+}
+
 // GetOperatorSessionByTokenHash mocks base method.
 func (m *MockQuerier) GetOperatorSessionByTokenHash(ctx context.Context, tokenHash string) (OperatorSession, error) {
 	m.ctrl.T.Helper()
@@ -2283,6 +3092,36 @@ func (mr *MockQuerierMockRecorder) GetOrderItemsWithFulfillment(ctx, orderID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItemsWithFulfillment", reflect.TypeOf((*MockQuerier)(nil).GetOrderItemsWithFulfillment), ctx, orderID)
 }
 
+// GetOrderPackageWeight mocks base method.
+func (m *MockQuerier) GetOrderPackageWeight(ctx context.Context, arg GetOrderPackageWeightParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderPackageWeight", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderPackageWeight indicates an expected call of GetOrderPackageWeight.
+func (mr *MockQuerierMockRecorder) GetOrderPackageWeight(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderPackageWeight", reflect.TypeOf((*MockQuerier)(nil).GetOrderPackageWeight), ctx, arg)
+}
+
+// GetOrderRefundedTotal mocks base method.
+func (m *MockQuerier) GetOrderRefundedTotal(ctx context.Context, arg GetOrderRefundedTotalParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderRefundedTotal", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderRefundedTotal indicates an expected call of GetOrderRefundedTotal.
+func (mr *MockQuerierMockRecorder) GetOrderRefundedTotal(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderRefundedTotal", reflect.TypeOf((*MockQuerier)(nil).GetOrderRefundedTotal), ctx, arg)
+}
+
 // GetOrderStats mocks base method.
 func (m *MockQuerier) GetOrderStats(ctx context.Context, arg GetOrderStatsParams) (GetOrderStatsRow, error) {
 	m.ctrl.T.Helper()
@@ -2298,6 +3137,21 @@ func (mr *MockQuerierMockRecorder) GetOrderStats(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStats", reflect.TypeOf((*MockQuerier)(nil).GetOrderStats), ctx, arg)
 }
 
+// GetOrderTemplateForUser mocks base method.
+func (m *MockQuerier) GetOrderTemplateForUser(ctx context.Context, arg GetOrderTemplateForUserParams) (OrderTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderTemplateForUser", ctx, arg)
+	ret0, _ := ret[0].(OrderTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderTemplateForUser indicates an expected call of GetOrderTemplateForUser.
+func (mr *MockQuerierMockRecorder) GetOrderTemplateForUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderTemplateForUser", reflect.TypeOf((*MockQuerier)(nil).GetOrderTemplateForUser), ctx, arg)
+}
+
 // GetOrderWithDetails mocks base method.
 func (m *MockQuerier) GetOrderWithDetails(ctx context.Context, arg GetOrderWithDetailsParams) (GetOrderWithDetailsRow, error) {
 	m.ctrl.T.Helper()
@@ -2568,6 +3422,36 @@ func (mr *MockQuerierMockRecorder) GetProductBySlug(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductBySlug", reflect.TypeOf((*MockQuerier)(nil).GetProductBySlug), ctx, arg)
 }
 
+// GetProductCategory mocks base method.
+func (m *MockQuerier) GetProductCategory(ctx context.Context, arg GetProductCategoryParams) (ProductCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductCategory", ctx, arg)
+	ret0, _ := ret[0].(ProductCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductCategory indicates an expected call of GetProductCategory.
+func (mr *MockQuerierMockRecorder) GetProductCategory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductCategory", reflect.TypeOf((*MockQuerier)(nil).GetProductCategory), ctx, arg)
+}
+
+// GetProductCategoryBySlug mocks base method.
+func (m *MockQuerier) GetProductCategoryBySlug(ctx context.Context, arg GetProductCategoryBySlugParams) (ProductCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductCategoryBySlug", ctx, arg)
+	ret0, _ := ret[0].(ProductCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductCategoryBySlug indicates an expected call of GetProductCategoryBySlug.
+func (mr *MockQuerierMockRecorder) GetProductCategoryBySlug(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductCategoryBySlug", reflect.TypeOf((*MockQuerier)(nil).GetProductCategoryBySlug), ctx, arg)
+}
+
 // GetProductFilterOptions mocks base method.
 func (m *MockQuerier) GetProductFilterOptions(ctx context.Context, tenantID pgtype.UUID) (GetProductFilterOptionsRow, error) {
 	m.ctrl.T.Helper()
@@ -2598,10 +3482,55 @@ func (mr *MockQuerierMockRecorder) GetProductImages(ctx, productID any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductImages", reflect.TypeOf((*MockQuerier)(nil).GetProductImages), ctx, productID)
 }
 
-// GetProductSKUs mocks base method.
-func (m *MockQuerier) GetProductSKUs(ctx context.Context, productID pgtype.UUID) ([]ProductSku, error) {
+// GetProductRatingSummary mocks base method.
+func (m *MockQuerier) GetProductRatingSummary(ctx context.Context, arg GetProductRatingSummaryParams) (GetProductRatingSummaryRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductSKUs", ctx, productID)
+	ret := m.ctrl.Call(m, "GetProductRatingSummary", ctx, arg)
+	ret0, _ := ret[0].(GetProductRatingSummaryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductRatingSummary indicates an expected call of GetProductRatingSummary.
+func (mr *MockQuerierMockRecorder) GetProductRatingSummary(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductRatingSummary", reflect.TypeOf((*MockQuerier)(nil).GetProductRatingSummary), ctx, arg)
+}
+
+// GetProductReview mocks base method.
+func (m *MockQuerier) GetProductReview(ctx context.Context, arg GetProductReviewParams) (ProductReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductReview", ctx, arg)
+	ret0, _ := ret[0].(ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductReview indicates an expected call of GetProductReview.
+func (mr *MockQuerierMockRecorder) GetProductReview(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductReview", reflect.TypeOf((*MockQuerier)(nil).GetProductReview), ctx, arg)
+}
+
+// GetProductReviewForUser mocks base method.
+func (m *MockQuerier) GetProductReviewForUser(ctx context.Context, arg GetProductReviewForUserParams) (ProductReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductReviewForUser", ctx, arg)
+	ret0, _ := ret[0].(ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductReviewForUser indicates an expected call of GetProductReviewForUser.
+func (mr *MockQuerierMockRecorder) GetProductReviewForUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductReviewForUser", reflect.TypeOf((*MockQuerier)(nil).GetProductReviewForUser), ctx, arg)
+}
+
+// GetProductSKUs mocks base method.
+func (m *MockQuerier) GetProductSKUs(ctx context.Context, productID pgtype.UUID) ([]ProductSku, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductSKUs", ctx, productID)
 	ret0, _ := ret[0].([]ProductSku)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
@@ -2613,6 +3542,36 @@ func (mr *MockQuerierMockRecorder) GetProductSKUs(ctx, productID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductSKUs", reflect.TypeOf((*MockQuerier)(nil).GetProductSKUs), ctx, productID)
 }
 
+// GetProductTag mocks base method.
+func (m *MockQuerier) GetProductTag(ctx context.Context, arg GetProductTagParams) (ProductTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductTag", ctx, arg)
+	ret0, _ := ret[0].(ProductTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductTag indicates an expected call of GetProductTag.
+func (mr *MockQuerierMockRecorder) GetProductTag(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductTag", reflect.TypeOf((*MockQuerier)(nil).GetProductTag), ctx, arg)
+}
+
+// GetProductTagBySlug mocks base method.
+func (m *MockQuerier) GetProductTagBySlug(ctx context.Context, arg GetProductTagBySlugParams) (ProductTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductTagBySlug", ctx, arg)
+	ret0, _ := ret[0].(ProductTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductTagBySlug indicates an expected call of GetProductTagBySlug.
+func (mr *MockQuerierMockRecorder) GetProductTagBySlug(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductTagBySlug", reflect.TypeOf((*MockQuerier)(nil).GetProductTagBySlug), ctx, arg)
+}
+
 // GetProductsForCustomer mocks base method.
 func (m *MockQuerier) GetProductsForCustomer(ctx context.Context, arg GetProductsForCustomerParams) ([]Product, error) {
 	m.ctrl.T.Helper()
@@ -2658,6 +3617,51 @@ func (mr *MockQuerierMockRecorder) GetPublishedTenantPage(ctx, arg any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedTenantPage", reflect.TypeOf((*MockQuerier)(nil).GetPublishedTenantPage), ctx, arg)
 }
 
+// GetRefundByProviderID mocks base method.
+func (m *MockQuerier) GetRefundByProviderID(ctx context.Context, arg GetRefundByProviderIDParams) (Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefundByProviderID", ctx, arg)
+	ret0, _ := ret[0].(Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefundByProviderID indicates an expected call of GetRefundByProviderID.
+func (mr *MockQuerierMockRecorder) GetRefundByProviderID(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefundByProviderID", reflect.TypeOf((*MockQuerier)(nil).GetRefundByProviderID), ctx, arg)
+}
+
+// GetReviewableOrderProduct mocks base method.
+func (m *MockQuerier) GetReviewableOrderProduct(ctx context.Context, arg GetReviewableOrderProductParams) (GetReviewableOrderProductRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewableOrderProduct", ctx, arg)
+	ret0, _ := ret[0].(GetReviewableOrderProductRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewableOrderProduct indicates an expected call of GetReviewableOrderProduct.
+func (mr *MockQuerierMockRecorder) GetReviewableOrderProduct(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewableOrderProduct", reflect.TypeOf((*MockQuerier)(nil).GetReviewableOrderProduct), ctx, arg)
+}
+
+// GetRoastBatch mocks base method.
+func (m *MockQuerier) GetRoastBatch(ctx context.Context, arg GetRoastBatchParams) (GetRoastBatchRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoastBatch", ctx, arg)
+	ret0, _ := ret[0].(GetRoastBatchRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoastBatch indicates an expected call of GetRoastBatch.
+func (mr *MockQuerierMockRecorder) GetRoastBatch(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoastBatch", reflect.TypeOf((*MockQuerier)(nil).GetRoastBatch), ctx, arg)
+}
+
 // GetSKUByID mocks base method.
 func (m *MockQuerier) GetSKUByID(ctx context.Context, id pgtype.UUID) (ProductSku, error) {
 	m.ctrl.T.Helper()
@@ -2703,6 +3707,51 @@ func (mr *MockQuerierMockRecorder) GetSessionByToken(ctx, token any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByToken", reflect.TypeOf((*MockQuerier)(nil).GetSessionByToken), ctx, token)
 }
 
+// GetShipment mocks base method.
+func (m *MockQuerier) GetShipment(ctx context.Context, arg GetShipmentParams) (Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipment", ctx, arg)
+	ret0, _ := ret[0].(Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipment indicates an expected call of GetShipment.
+func (mr *MockQuerierMockRecorder) GetShipment(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipment", reflect.TypeOf((*MockQuerier)(nil).GetShipment), ctx, arg)
+}
+
+// GetShipmentByTrackingNumber mocks base method.
+func (m *MockQuerier) GetShipmentByTrackingNumber(ctx context.Context, arg GetShipmentByTrackingNumberParams) (Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipmentByTrackingNumber", ctx, arg)
+	ret0, _ := ret[0].(Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipmentByTrackingNumber indicates an expected call of GetShipmentByTrackingNumber.
+func (mr *MockQuerierMockRecorder) GetShipmentByTrackingNumber(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipmentByTrackingNumber", reflect.TypeOf((*MockQuerier)(nil).GetShipmentByTrackingNumber), ctx, arg)
+}
+
+// GetShipmentForOrder mocks base method.
+func (m *MockQuerier) GetShipmentForOrder(ctx context.Context, arg GetShipmentForOrderParams) (Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipmentForOrder", ctx, arg)
+	ret0, _ := ret[0].(Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipmentForOrder indicates an expected call of GetShipmentForOrder.
+func (mr *MockQuerierMockRecorder) GetShipmentForOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipmentForOrder", reflect.TypeOf((*MockQuerier)(nil).GetShipmentForOrder), ctx, arg)
+}
+
 // GetShipmentHistory mocks base method.
 func (m *MockQuerier) GetShipmentHistory(ctx context.Context, orderItemID pgtype.UUID) ([]GetShipmentHistoryRow, error) {
 	m.ctrl.T.Helper()
@@ -2793,6 +3842,21 @@ func (mr *MockQuerierMockRecorder) GetSkippedItems(ctx, tenantID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSkippedItems", reflect.TypeOf((*MockQuerier)(nil).GetSkippedItems), ctx, tenantID)
 }
 
+// GetStandingOrderForUser mocks base method.
+func (m *MockQuerier) GetStandingOrderForUser(ctx context.Context, arg GetStandingOrderForUserParams) (StandingOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStandingOrderForUser", ctx, arg)
+	ret0, _ := ret[0].(StandingOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStandingOrderForUser indicates an expected call of GetStandingOrderForUser.
+func (mr *MockQuerierMockRecorder) GetStandingOrderForUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStandingOrderForUser", reflect.TypeOf((*MockQuerier)(nil).GetStandingOrderForUser), ctx, arg)
+}
+
 // GetSubscriptionByID mocks base method.
 func (m *MockQuerier) GetSubscriptionByID(ctx context.Context, arg GetSubscriptionByIDParams) (Subscription, error) {
 	m.ctrl.T.Helper()
@@ -2838,6 +3902,21 @@ func (mr *MockQuerierMockRecorder) GetSubscriptionCountsForUser(ctx, arg any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionCountsForUser", reflect.TypeOf((*MockQuerier)(nil).GetSubscriptionCountsForUser), ctx, arg)
 }
 
+// GetSubscriptionDunningByToken mocks base method.
+func (m *MockQuerier) GetSubscriptionDunningByToken(ctx context.Context, arg GetSubscriptionDunningByTokenParams) (SubscriptionDunning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionDunningByToken", ctx, arg)
+	ret0, _ := ret[0].(SubscriptionDunning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionDunningByToken indicates an expected call of GetSubscriptionDunningByToken.
+func (mr *MockQuerierMockRecorder) GetSubscriptionDunningByToken(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionDunningByToken", reflect.TypeOf((*MockQuerier)(nil).GetSubscriptionDunningByToken), ctx, arg)
+}
+
 // GetSubscriptionScheduleEventByInvoiceID mocks base method.
 func (m *MockQuerier) GetSubscriptionScheduleEventByInvoiceID(ctx context.Context, arg GetSubscriptionScheduleEventByInvoiceIDParams) (SubscriptionSchedule, error) {
 	m.ctrl.T.Helper()
@@ -2913,6 +3992,21 @@ func (mr *MockQuerierMockRecorder) GetTaxRateByState(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRateByState", reflect.TypeOf((*MockQuerier)(nil).GetTaxRateByState), ctx, arg)
 }
 
+// GetTenantBranding mocks base method.
+func (m *MockQuerier) GetTenantBranding(ctx context.Context, id pgtype.UUID) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenantBranding", ctx, id)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenantBranding indicates an expected call of GetTenantBranding.
+func (mr *MockQuerierMockRecorder) GetTenantBranding(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantBranding", reflect.TypeOf((*MockQuerier)(nil).GetTenantBranding), ctx, id)
+}
+
 // GetTenantByCustomDomain mocks base method.
 func (m *MockQuerier) GetTenantByCustomDomain(ctx context.Context, customDomain pgtype.Text) (GetTenantByCustomDomainRow, error) {
 	m.ctrl.T.Helper()
@@ -2988,6 +4082,21 @@ func (mr *MockQuerierMockRecorder) GetTenantByStripeSubscriptionID(ctx, stripeSu
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantByStripeSubscriptionID", reflect.TypeOf((*MockQuerier)(nil).GetTenantByStripeSubscriptionID), ctx, stripeSubscriptionID)
 }
 
+// GetTenantDunningSettings mocks base method.
+func (m *MockQuerier) GetTenantDunningSettings(ctx context.Context, id pgtype.UUID) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenantDunningSettings", ctx, id)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenantDunningSettings indicates an expected call of GetTenantDunningSettings.
+func (mr *MockQuerierMockRecorder) GetTenantDunningSettings(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantDunningSettings", reflect.TypeOf((*MockQuerier)(nil).GetTenantDunningSettings), ctx, id)
+}
+
 // GetTenantOperatorByEmail mocks base method.
 func (m *MockQuerier) GetTenantOperatorByEmail(ctx context.Context, email string) (TenantOperator, error) {
 	m.ctrl.T.Helper()
@@ -3093,6 +4202,36 @@ func (mr *MockQuerierMockRecorder) GetTenantPage(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantPage", reflect.TypeOf((*MockQuerier)(nil).GetTenantPage), ctx, arg)
 }
 
+// GetTenantRoastRestDays mocks base method.
+func (m *MockQuerier) GetTenantRoastRestDays(ctx context.Context, id pgtype.UUID) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenantRoastRestDays", ctx, id)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenantRoastRestDays indicates an expected call of GetTenantRoastRestDays.
+func (mr *MockQuerierMockRecorder) GetTenantRoastRestDays(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantRoastRestDays", reflect.TypeOf((*MockQuerier)(nil).GetTenantRoastRestDays), ctx, id)
+}
+
+// GetTenantStandingOrderSettings mocks base method.
+func (m *MockQuerier) GetTenantStandingOrderSettings(ctx context.Context, id pgtype.UUID) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenantStandingOrderSettings", ctx, id)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenantStandingOrderSettings indicates an expected call of GetTenantStandingOrderSettings.
+func (mr *MockQuerierMockRecorder) GetTenantStandingOrderSettings(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantStandingOrderSettings", reflect.TypeOf((*MockQuerier)(nil).GetTenantStandingOrderSettings), ctx, id)
+}
+
 // GetTenantWarehouseAddress mocks base method.
 func (m *MockQuerier) GetTenantWarehouseAddress(ctx context.Context, tenantID pgtype.UUID) (GetTenantWarehouseAddressRow, error) {
 	m.ctrl.T.Helper()
@@ -3258,6 +4397,21 @@ func (mr *MockQuerierMockRecorder) GetWebhookEventByProviderID(ctx, arg any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookEventByProviderID", reflect.TypeOf((*MockQuerier)(nil).GetWebhookEventByProviderID), ctx, arg)
 }
 
+// GetWhiteLabelDetails mocks base method.
+func (m *MockQuerier) GetWhiteLabelDetails(ctx context.Context, arg GetWhiteLabelDetailsParams) (GetWhiteLabelDetailsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWhiteLabelDetails", ctx, arg)
+	ret0, _ := ret[0].(GetWhiteLabelDetailsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWhiteLabelDetails indicates an expected call of GetWhiteLabelDetails.
+func (mr *MockQuerierMockRecorder) GetWhiteLabelDetails(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWhiteLabelDetails", reflect.TypeOf((*MockQuerier)(nil).GetWhiteLabelDetails), ctx, arg)
+}
+
 // GetWhiteLabelProductsForCustomer mocks base method.
 func (m *MockQuerier) GetWhiteLabelProductsForCustomer(ctx context.Context, arg GetWhiteLabelProductsForCustomerParams) ([]Product, error) {
 	m.ctrl.T.Helper()
@@ -3288,6 +4442,35 @@ func (mr *MockQuerierMockRecorder) GetWholesaleCustomer(ctx, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWholesaleCustomer", reflect.TypeOf((*MockQuerier)(nil).GetWholesaleCustomer), ctx, id)
 }
 
+// HeartbeatWorkerJobs mocks base method.
+func (m *MockQuerier) HeartbeatWorkerJobs(ctx context.Context, workerID pgtype.Text) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeartbeatWorkerJobs", ctx, workerID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeartbeatWorkerJobs indicates an expected call of HeartbeatWorkerJobs.
+func (mr *MockQuerierMockRecorder) HeartbeatWorkerJobs(ctx, workerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeartbeatWorkerJobs", reflect.TypeOf((*MockQuerier)(nil).HeartbeatWorkerJobs), ctx, workerID)
+}
+
+// IncrementSKUStock mocks base method.
+func (m *MockQuerier) IncrementSKUStock(ctx context.Context, arg IncrementSKUStockParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementSKUStock", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementSKUStock indicates an expected call of IncrementSKUStock.
+func (mr *MockQuerierMockRecorder) IncrementSKUStock(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementSKUStock", reflect.TypeOf((*MockQuerier)(nil).IncrementSKUStock), ctx, arg)
+}
+
 // InvalidateUserEmailVerificationTokens mocks base method.
 func (m *MockQuerier) InvalidateUserEmailVerificationTokens(ctx context.Context, arg InvalidateUserEmailVerificationTokensParams) error {
 	m.ctrl.T.Helper()
@@ -3331,6 +4514,21 @@ func (mr *MockQuerierMockRecorder) IsItemSkipped(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsItemSkipped", reflect.TypeOf((*MockQuerier)(nil).IsItemSkipped), ctx, arg)
 }
 
+// IsMasterTenant mocks base method.
+func (m *MockQuerier) IsMasterTenant(ctx context.Context, id pgtype.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsMasterTenant", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsMasterTenant indicates an expected call of IsMasterTenant.
+func (mr *MockQuerierMockRecorder) IsMasterTenant(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMasterTenant", reflect.TypeOf((*MockQuerier)(nil).IsMasterTenant), ctx, id)
+}
+
 // ListActiveProducts mocks base method.
 func (m *MockQuerier) ListActiveProducts(ctx context.Context, tenantID pgtype.UUID) ([]ListActiveProductsRow, error) {
 	m.ctrl.T.Helper()
@@ -3451,173 +4649,578 @@ func (mr *MockQuerierMockRecorder) ListAllProducts(ctx, tenantID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllProducts", reflect.TypeOf((*MockQuerier)(nil).ListAllProducts), ctx, tenantID)
 }
 
-// ListInvoices mocks base method.
-func (m *MockQuerier) ListInvoices(ctx context.Context, arg ListInvoicesParams) ([]ListInvoicesRow, error) {
+// ListApprovedReviewsForProduct mocks base method.
+func (m *MockQuerier) ListApprovedReviewsForProduct(ctx context.Context, arg ListApprovedReviewsForProductParams) ([]ListApprovedReviewsForProductRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListInvoices", ctx, arg)
-	ret0, _ := ret[0].([]ListInvoicesRow)
+	ret := m.ctrl.Call(m, "ListApprovedReviewsForProduct", ctx, arg)
+	ret0, _ := ret[0].([]ListApprovedReviewsForProductRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListInvoices indicates an expected call of ListInvoices.
-func (mr *MockQuerierMockRecorder) ListInvoices(ctx, arg any) *gomock.Call {
+// ListApprovedReviewsForProduct indicates an expected call of ListApprovedReviewsForProduct.
+func (mr *MockQuerierMockRecorder) ListApprovedReviewsForProduct(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoices", reflect.TypeOf((*MockQuerier)(nil).ListInvoices), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApprovedReviewsForProduct", reflect.TypeOf((*MockQuerier)(nil).ListApprovedReviewsForProduct), ctx, arg)
 }
 
-// ListInvoicesByStatus mocks base method.
-func (m *MockQuerier) ListInvoicesByStatus(ctx context.Context, arg ListInvoicesByStatusParams) ([]ListInvoicesByStatusRow, error) {
+// ListCartOrderRules mocks base method.
+func (m *MockQuerier) ListCartOrderRules(ctx context.Context, arg ListCartOrderRulesParams) ([]ListCartOrderRulesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListInvoicesByStatus", ctx, arg)
-	ret0, _ := ret[0].([]ListInvoicesByStatusRow)
+	ret := m.ctrl.Call(m, "ListCartOrderRules", ctx, arg)
+	ret0, _ := ret[0].([]ListCartOrderRulesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListInvoicesByStatus indicates an expected call of ListInvoicesByStatus.
-func (mr *MockQuerierMockRecorder) ListInvoicesByStatus(ctx, arg any) *gomock.Call {
+// ListCartOrderRules indicates an expected call of ListCartOrderRules.
+func (mr *MockQuerierMockRecorder) ListCartOrderRules(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoicesByStatus", reflect.TypeOf((*MockQuerier)(nil).ListInvoicesByStatus), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCartOrderRules", reflect.TypeOf((*MockQuerier)(nil).ListCartOrderRules), ctx, arg)
 }
 
-// ListInvoicesForUser mocks base method.
-func (m *MockQuerier) ListInvoicesForUser(ctx context.Context, arg ListInvoicesForUserParams) ([]Invoice, error) {
+// ListCategoryFacets mocks base method.
+func (m *MockQuerier) ListCategoryFacets(ctx context.Context, tenantID pgtype.UUID) ([]ListCategoryFacetsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListInvoicesForUser", ctx, arg)
-	ret0, _ := ret[0].([]Invoice)
+	ret := m.ctrl.Call(m, "ListCategoryFacets", ctx, tenantID)
+	ret0, _ := ret[0].([]ListCategoryFacetsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListInvoicesForUser indicates an expected call of ListInvoicesForUser.
-func (mr *MockQuerierMockRecorder) ListInvoicesForUser(ctx, arg any) *gomock.Call {
+// ListCategoryFacets indicates an expected call of ListCategoryFacets.
+func (mr *MockQuerierMockRecorder) ListCategoryFacets(ctx, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoicesForUser", reflect.TypeOf((*MockQuerier)(nil).ListInvoicesForUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryFacets", reflect.TypeOf((*MockQuerier)(nil).ListCategoryFacets), ctx, tenantID)
 }
 
-// ListJobsByStatus mocks base method.
-func (m *MockQuerier) ListJobsByStatus(ctx context.Context, arg ListJobsByStatusParams) ([]Job, error) {
+// ListCategoryIDsForProduct mocks base method.
+func (m *MockQuerier) ListCategoryIDsForProduct(ctx context.Context, arg ListCategoryIDsForProductParams) ([]pgtype.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListJobsByStatus", ctx, arg)
-	ret0, _ := ret[0].([]Job)
+	ret := m.ctrl.Call(m, "ListCategoryIDsForProduct", ctx, arg)
+	ret0, _ := ret[0].([]pgtype.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListJobsByStatus indicates an expected call of ListJobsByStatus.
-func (mr *MockQuerierMockRecorder) ListJobsByStatus(ctx, arg any) *gomock.Call {
+// ListCategoryIDsForProduct indicates an expected call of ListCategoryIDsForProduct.
+func (mr *MockQuerierMockRecorder) ListCategoryIDsForProduct(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobsByStatus", reflect.TypeOf((*MockQuerier)(nil).ListJobsByStatus), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryIDsForProduct", reflect.TypeOf((*MockQuerier)(nil).ListCategoryIDsForProduct), ctx, arg)
 }
 
-// ListOrders mocks base method.
-func (m *MockQuerier) ListOrders(ctx context.Context, arg ListOrdersParams) ([]ListOrdersRow, error) {
+// ListCustomerOrderableSKUs mocks base method.
+func (m *MockQuerier) ListCustomerOrderableSKUs(ctx context.Context, arg ListCustomerOrderableSKUsParams) ([]ListCustomerOrderableSKUsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOrders", ctx, arg)
-	ret0, _ := ret[0].([]ListOrdersRow)
+	ret := m.ctrl.Call(m, "ListCustomerOrderableSKUs", ctx, arg)
+	ret0, _ := ret[0].([]ListCustomerOrderableSKUsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListOrders indicates an expected call of ListOrders.
-func (mr *MockQuerierMockRecorder) ListOrders(ctx, arg any) *gomock.Call {
+// ListCustomerOrderableSKUs indicates an expected call of ListCustomerOrderableSKUs.
+func (mr *MockQuerierMockRecorder) ListCustomerOrderableSKUs(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockQuerier)(nil).ListOrders), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomerOrderableSKUs", reflect.TypeOf((*MockQuerier)(nil).ListCustomerOrderableSKUs), ctx, arg)
 }
 
-// ListOrdersByStatus mocks base method.
-func (m *MockQuerier) ListOrdersByStatus(ctx context.Context, arg ListOrdersByStatusParams) ([]ListOrdersByStatusRow, error) {
+// ListCustomerSKUOrderRules mocks base method.
+func (m *MockQuerier) ListCustomerSKUOrderRules(ctx context.Context, arg ListCustomerSKUOrderRulesParams) ([]ListCustomerSKUOrderRulesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOrdersByStatus", ctx, arg)
-	ret0, _ := ret[0].([]ListOrdersByStatusRow)
+	ret := m.ctrl.Call(m, "ListCustomerSKUOrderRules", ctx, arg)
+	ret0, _ := ret[0].([]ListCustomerSKUOrderRulesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListOrdersByStatus indicates an expected call of ListOrdersByStatus.
-func (mr *MockQuerierMockRecorder) ListOrdersByStatus(ctx, arg any) *gomock.Call {
+// ListCustomerSKUOrderRules indicates an expected call of ListCustomerSKUOrderRules.
+func (mr *MockQuerierMockRecorder) ListCustomerSKUOrderRules(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrdersByStatus", reflect.TypeOf((*MockQuerier)(nil).ListOrdersByStatus), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomerSKUOrderRules", reflect.TypeOf((*MockQuerier)(nil).ListCustomerSKUOrderRules), ctx, arg)
 }
 
-// ListOrdersBySubscription mocks base method.
-func (m *MockQuerier) ListOrdersBySubscription(ctx context.Context, arg ListOrdersBySubscriptionParams) ([]ListOrdersBySubscriptionRow, error) {
+// ListDiscountCodes mocks base method.
+func (m *MockQuerier) ListDiscountCodes(ctx context.Context, tenantID pgtype.UUID) ([]DiscountCode, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOrdersBySubscription", ctx, arg)
-	ret0, _ := ret[0].([]ListOrdersBySubscriptionRow)
+	ret := m.ctrl.Call(m, "ListDiscountCodes", ctx, tenantID)
+	ret0, _ := ret[0].([]DiscountCode)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListOrdersBySubscription indicates an expected call of ListOrdersBySubscription.
-func (mr *MockQuerierMockRecorder) ListOrdersBySubscription(ctx, arg any) *gomock.Call {
+// ListDiscountCodes indicates an expected call of ListDiscountCodes.
+func (mr *MockQuerierMockRecorder) ListDiscountCodes(ctx, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrdersBySubscription", reflect.TypeOf((*MockQuerier)(nil).ListOrdersBySubscription), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDiscountCodes", reflect.TypeOf((*MockQuerier)(nil).ListDiscountCodes), ctx, tenantID)
 }
 
-// ListOrdersForUser mocks base method.
-func (m *MockQuerier) ListOrdersForUser(ctx context.Context, arg ListOrdersForUserParams) ([]ListOrdersForUserRow, error) {
+// ListDueJobSchedules mocks base method.
+func (m *MockQuerier) ListDueJobSchedules(ctx context.Context, nextRunAt pgtype.Timestamptz) ([]JobSchedule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOrdersForUser", ctx, arg)
-	ret0, _ := ret[0].([]ListOrdersForUserRow)
+	ret := m.ctrl.Call(m, "ListDueJobSchedules", ctx, nextRunAt)
+	ret0, _ := ret[0].([]JobSchedule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListOrdersForUser indicates an expected call of ListOrdersForUser.
-func (mr *MockQuerierMockRecorder) ListOrdersForUser(ctx, arg any) *gomock.Call {
+// ListDueJobSchedules indicates an expected call of ListDueJobSchedules.
+func (mr *MockQuerierMockRecorder) ListDueJobSchedules(ctx, nextRunAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrdersForUser", reflect.TypeOf((*MockQuerier)(nil).ListOrdersForUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueJobSchedules", reflect.TypeOf((*MockQuerier)(nil).ListDueJobSchedules), ctx, nextRunAt)
 }
 
-// ListOverdueInvoices mocks base method.
-func (m *MockQuerier) ListOverdueInvoices(ctx context.Context, tenantID pgtype.UUID) ([]ListOverdueInvoicesRow, error) {
+// ListDueStandingOrders mocks base method.
+func (m *MockQuerier) ListDueStandingOrders(ctx context.Context, arg ListDueStandingOrdersParams) ([]StandingOrder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOverdueInvoices", ctx, tenantID)
-	ret0, _ := ret[0].([]ListOverdueInvoicesRow)
+	ret := m.ctrl.Call(m, "ListDueStandingOrders", ctx, arg)
+	ret0, _ := ret[0].([]StandingOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListOverdueInvoices indicates an expected call of ListOverdueInvoices.
-func (mr *MockQuerierMockRecorder) ListOverdueInvoices(ctx, tenantID any) *gomock.Call {
+// ListDueStandingOrders indicates an expected call of ListDueStandingOrders.
+func (mr *MockQuerierMockRecorder) ListDueStandingOrders(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdueInvoices", reflect.TypeOf((*MockQuerier)(nil).ListOverdueInvoices), ctx, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueStandingOrders", reflect.TypeOf((*MockQuerier)(nil).ListDueStandingOrders), ctx, arg)
 }
 
-// ListPaymentMethodsForUser mocks base method.
-func (m *MockQuerier) ListPaymentMethodsForUser(ctx context.Context, arg ListPaymentMethodsForUserParams) ([]ListPaymentMethodsForUserRow, error) {
+// ListDueSubscriptionDunning mocks base method.
+func (m *MockQuerier) ListDueSubscriptionDunning(ctx context.Context, tenantID pgtype.UUID) ([]SubscriptionDunning, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPaymentMethodsForUser", ctx, arg)
-	ret0, _ := ret[0].([]ListPaymentMethodsForUserRow)
+	ret := m.ctrl.Call(m, "ListDueSubscriptionDunning", ctx, tenantID)
+	ret0, _ := ret[0].([]SubscriptionDunning)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPaymentMethodsForUser indicates an expected call of ListPaymentMethodsForUser.
-func (mr *MockQuerierMockRecorder) ListPaymentMethodsForUser(ctx, arg any) *gomock.Call {
+// ListDueSubscriptionDunning indicates an expected call of ListDueSubscriptionDunning.
+func (mr *MockQuerierMockRecorder) ListDueSubscriptionDunning(ctx, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentMethodsForUser", reflect.TypeOf((*MockQuerier)(nil).ListPaymentMethodsForUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueSubscriptionDunning", reflect.TypeOf((*MockQuerier)(nil).ListDueSubscriptionDunning), ctx, tenantID)
 }
 
-// ListPaymentTerms mocks base method.
-func (m *MockQuerier) ListPaymentTerms(ctx context.Context, tenantID pgtype.UUID) ([]PaymentTerm, error) {
+// ListEmailTemplates mocks base method.
+func (m *MockQuerier) ListEmailTemplates(ctx context.Context, tenantID pgtype.UUID) ([]EmailTemplate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPaymentTerms", ctx, tenantID)
-	ret0, _ := ret[0].([]PaymentTerm)
+	ret := m.ctrl.Call(m, "ListEmailTemplates", ctx, tenantID)
+	ret0, _ := ret[0].([]EmailTemplate)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPaymentTerms indicates an expected call of ListPaymentTerms.
-func (mr *MockQuerierMockRecorder) ListPaymentTerms(ctx, tenantID any) *gomock.Call {
+// ListEmailTemplates indicates an expected call of ListEmailTemplates.
+func (mr *MockQuerierMockRecorder) ListEmailTemplates(ctx, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentTerms", reflect.TypeOf((*MockQuerier)(nil).ListPaymentTerms), ctx, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmailTemplates", reflect.TypeOf((*MockQuerier)(nil).ListEmailTemplates), ctx, tenantID)
 }
 
-// ListPriceListEntries mocks base method.
-func (m *MockQuerier) ListPriceListEntries(ctx context.Context, priceListID pgtype.UUID) ([]ListPriceListEntriesRow, error) {
+// ListFailedJobTypes mocks base method.
+func (m *MockQuerier) ListFailedJobTypes(ctx context.Context, tenantID pgtype.UUID) ([]ListFailedJobTypesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFailedJobTypes", ctx, tenantID)
+	ret0, _ := ret[0].([]ListFailedJobTypesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFailedJobTypes indicates an expected call of ListFailedJobTypes.
+func (mr *MockQuerierMockRecorder) ListFailedJobTypes(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFailedJobTypes", reflect.TypeOf((*MockQuerier)(nil).ListFailedJobTypes), ctx, tenantID)
+}
+
+// ListFulfillmentBatchOrders mocks base method.
+func (m *MockQuerier) ListFulfillmentBatchOrders(ctx context.Context, arg ListFulfillmentBatchOrdersParams) ([]ListFulfillmentBatchOrdersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFulfillmentBatchOrders", ctx, arg)
+	ret0, _ := ret[0].([]ListFulfillmentBatchOrdersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFulfillmentBatchOrders indicates an expected call of ListFulfillmentBatchOrders.
+func (mr *MockQuerierMockRecorder) ListFulfillmentBatchOrders(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFulfillmentBatchOrders", reflect.TypeOf((*MockQuerier)(nil).ListFulfillmentBatchOrders), ctx, arg)
+}
+
+// ListFulfillmentBatches mocks base method.
+func (m *MockQuerier) ListFulfillmentBatches(ctx context.Context, arg ListFulfillmentBatchesParams) ([]FulfillmentBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFulfillmentBatches", ctx, arg)
+	ret0, _ := ret[0].([]FulfillmentBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFulfillmentBatches indicates an expected call of ListFulfillmentBatches.
+func (mr *MockQuerierMockRecorder) ListFulfillmentBatches(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFulfillmentBatches", reflect.TypeOf((*MockQuerier)(nil).ListFulfillmentBatches), ctx, arg)
+}
+
+// ListFulfillmentQueue mocks base method.
+func (m *MockQuerier) ListFulfillmentQueue(ctx context.Context, tenantID pgtype.UUID) ([]ListFulfillmentQueueRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFulfillmentQueue", ctx, tenantID)
+	ret0, _ := ret[0].([]ListFulfillmentQueueRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFulfillmentQueue indicates an expected call of ListFulfillmentQueue.
+func (mr *MockQuerierMockRecorder) ListFulfillmentQueue(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFulfillmentQueue", reflect.TypeOf((*MockQuerier)(nil).ListFulfillmentQueue), ctx, tenantID)
+}
+
+// ListFulfillmentQueueByIDs mocks base method.
+func (m *MockQuerier) ListFulfillmentQueueByIDs(ctx context.Context, arg ListFulfillmentQueueByIDsParams) ([]ListFulfillmentQueueByIDsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFulfillmentQueueByIDs", ctx, arg)
+	ret0, _ := ret[0].([]ListFulfillmentQueueByIDsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFulfillmentQueueByIDs indicates an expected call of ListFulfillmentQueueByIDs.
+func (mr *MockQuerierMockRecorder) ListFulfillmentQueueByIDs(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFulfillmentQueueByIDs", reflect.TypeOf((*MockQuerier)(nil).ListFulfillmentQueueByIDs), ctx, arg)
+}
+
+// ListInventorySKUs mocks base method.
+func (m *MockQuerier) ListInventorySKUs(ctx context.Context, tenantID pgtype.UUID) ([]ListInventorySKUsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInventorySKUs", ctx, tenantID)
+	ret0, _ := ret[0].([]ListInventorySKUsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInventorySKUs indicates an expected call of ListInventorySKUs.
+func (mr *MockQuerierMockRecorder) ListInventorySKUs(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInventorySKUs", reflect.TypeOf((*MockQuerier)(nil).ListInventorySKUs), ctx, tenantID)
+}
+
+// ListInvoices mocks base method.
+func (m *MockQuerier) ListInvoices(ctx context.Context, arg ListInvoicesParams) ([]ListInvoicesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvoices", ctx, arg)
+	ret0, _ := ret[0].([]ListInvoicesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvoices indicates an expected call of ListInvoices.
+func (mr *MockQuerierMockRecorder) ListInvoices(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoices", reflect.TypeOf((*MockQuerier)(nil).ListInvoices), ctx, arg)
+}
+
+// ListInvoicesByStatus mocks base method.
+func (m *MockQuerier) ListInvoicesByStatus(ctx context.Context, arg ListInvoicesByStatusParams) ([]ListInvoicesByStatusRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvoicesByStatus", ctx, arg)
+	ret0, _ := ret[0].([]ListInvoicesByStatusRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvoicesByStatus indicates an expected call of ListInvoicesByStatus.
+func (mr *MockQuerierMockRecorder) ListInvoicesByStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoicesByStatus", reflect.TypeOf((*MockQuerier)(nil).ListInvoicesByStatus), ctx, arg)
+}
+
+// ListInvoicesForUser mocks base method.
+func (m *MockQuerier) ListInvoicesForUser(ctx context.Context, arg ListInvoicesForUserParams) ([]Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvoicesForUser", ctx, arg)
+	ret0, _ := ret[0].([]Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvoicesForUser indicates an expected call of ListInvoicesForUser.
+func (mr *MockQuerierMockRecorder) ListInvoicesForUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoicesForUser", reflect.TypeOf((*MockQuerier)(nil).ListInvoicesForUser), ctx, arg)
+}
+
+// ListJobQueueStats mocks base method.
+func (m *MockQuerier) ListJobQueueStats(ctx context.Context, tenantID pgtype.UUID) ([]ListJobQueueStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobQueueStats", ctx, tenantID)
+	ret0, _ := ret[0].([]ListJobQueueStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobQueueStats indicates an expected call of ListJobQueueStats.
+func (mr *MockQuerierMockRecorder) ListJobQueueStats(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobQueueStats", reflect.TypeOf((*MockQuerier)(nil).ListJobQueueStats), ctx, tenantID)
+}
+
+// ListJobSchedulesForTenant mocks base method.
+func (m *MockQuerier) ListJobSchedulesForTenant(ctx context.Context, tenantID pgtype.UUID) ([]JobSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobSchedulesForTenant", ctx, tenantID)
+	ret0, _ := ret[0].([]JobSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobSchedulesForTenant indicates an expected call of ListJobSchedulesForTenant.
+func (mr *MockQuerierMockRecorder) ListJobSchedulesForTenant(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobSchedulesForTenant", reflect.TypeOf((*MockQuerier)(nil).ListJobSchedulesForTenant), ctx, tenantID)
+}
+
+// ListJobTypes mocks base method.
+func (m *MockQuerier) ListJobTypes(ctx context.Context, tenantID pgtype.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobTypes", ctx, tenantID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobTypes indicates an expected call of ListJobTypes.
+func (mr *MockQuerierMockRecorder) ListJobTypes(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobTypes", reflect.TypeOf((*MockQuerier)(nil).ListJobTypes), ctx, tenantID)
+}
+
+// ListJobs mocks base method.
+func (m *MockQuerier) ListJobs(ctx context.Context, arg ListJobsParams) ([]Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobs", ctx, arg)
+	ret0, _ := ret[0].([]Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobs indicates an expected call of ListJobs.
+func (mr *MockQuerierMockRecorder) ListJobs(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockQuerier)(nil).ListJobs), ctx, arg)
+}
+
+// ListJobsByStatus mocks base method.
+func (m *MockQuerier) ListJobsByStatus(ctx context.Context, arg ListJobsByStatusParams) ([]Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobsByStatus", ctx, arg)
+	ret0, _ := ret[0].([]Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobsByStatus indicates an expected call of ListJobsByStatus.
+func (mr *MockQuerierMockRecorder) ListJobsByStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobsByStatus", reflect.TypeOf((*MockQuerier)(nil).ListJobsByStatus), ctx, arg)
+}
+
+// ListLowStockSKUs mocks base method.
+func (m *MockQuerier) ListLowStockSKUs(ctx context.Context, tenantID pgtype.UUID) ([]ListLowStockSKUsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLowStockSKUs", ctx, tenantID)
+	ret0, _ := ret[0].([]ListLowStockSKUsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLowStockSKUs indicates an expected call of ListLowStockSKUs.
+func (mr *MockQuerierMockRecorder) ListLowStockSKUs(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLowStockSKUs", reflect.TypeOf((*MockQuerier)(nil).ListLowStockSKUs), ctx, tenantID)
+}
+
+// ListOrderItemRoastDates mocks base method.
+func (m *MockQuerier) ListOrderItemRoastDates(ctx context.Context, arg ListOrderItemRoastDatesParams) ([]ListOrderItemRoastDatesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderItemRoastDates", ctx, arg)
+	ret0, _ := ret[0].([]ListOrderItemRoastDatesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderItemRoastDates indicates an expected call of ListOrderItemRoastDates.
+func (mr *MockQuerierMockRecorder) ListOrderItemRoastDates(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderItemRoastDates", reflect.TypeOf((*MockQuerier)(nil).ListOrderItemRoastDates), ctx, arg)
+}
+
+// ListOrderTemplateItems mocks base method.
+func (m *MockQuerier) ListOrderTemplateItems(ctx context.Context, arg ListOrderTemplateItemsParams) ([]OrderTemplateItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderTemplateItems", ctx, arg)
+	ret0, _ := ret[0].([]OrderTemplateItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderTemplateItems indicates an expected call of ListOrderTemplateItems.
+func (mr *MockQuerierMockRecorder) ListOrderTemplateItems(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderTemplateItems", reflect.TypeOf((*MockQuerier)(nil).ListOrderTemplateItems), ctx, arg)
+}
+
+// ListOrderTemplatesForUser mocks base method.
+func (m *MockQuerier) ListOrderTemplatesForUser(ctx context.Context, arg ListOrderTemplatesForUserParams) ([]ListOrderTemplatesForUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderTemplatesForUser", ctx, arg)
+	ret0, _ := ret[0].([]ListOrderTemplatesForUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderTemplatesForUser indicates an expected call of ListOrderTemplatesForUser.
+func (mr *MockQuerierMockRecorder) ListOrderTemplatesForUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderTemplatesForUser", reflect.TypeOf((*MockQuerier)(nil).ListOrderTemplatesForUser), ctx, arg)
+}
+
+// ListOrderTrackingEvents mocks base method.
+func (m *MockQuerier) ListOrderTrackingEvents(ctx context.Context, arg ListOrderTrackingEventsParams) ([]ShipmentTrackingEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderTrackingEvents", ctx, arg)
+	ret0, _ := ret[0].([]ShipmentTrackingEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderTrackingEvents indicates an expected call of ListOrderTrackingEvents.
+func (mr *MockQuerierMockRecorder) ListOrderTrackingEvents(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderTrackingEvents", reflect.TypeOf((*MockQuerier)(nil).ListOrderTrackingEvents), ctx, arg)
+}
+
+// ListOrders mocks base method.
+func (m *MockQuerier) ListOrders(ctx context.Context, arg ListOrdersParams) ([]ListOrdersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", ctx, arg)
+	ret0, _ := ret[0].([]ListOrdersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockQuerierMockRecorder) ListOrders(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockQuerier)(nil).ListOrders), ctx, arg)
+}
+
+// ListOrdersByStatus mocks base method.
+func (m *MockQuerier) ListOrdersByStatus(ctx context.Context, arg ListOrdersByStatusParams) ([]ListOrdersByStatusRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrdersByStatus", ctx, arg)
+	ret0, _ := ret[0].([]ListOrdersByStatusRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrdersByStatus indicates an expected call of ListOrdersByStatus.
+func (mr *MockQuerierMockRecorder) ListOrdersByStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrdersByStatus", reflect.TypeOf((*MockQuerier)(nil).ListOrdersByStatus), ctx, arg)
+}
+
+// ListOrdersBySubscription mocks base method.
+func (m *MockQuerier) ListOrdersBySubscription(ctx context.Context, arg ListOrdersBySubscriptionParams) ([]ListOrdersBySubscriptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrdersBySubscription", ctx, arg)
+	ret0, _ := ret[0].([]ListOrdersBySubscriptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrdersBySubscription indicates an expected call of ListOrdersBySubscription.
+func (mr *MockQuerierMockRecorder) ListOrdersBySubscription(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrdersBySubscription", reflect.TypeOf((*MockQuerier)(nil).ListOrdersBySubscription), ctx, arg)
+}
+
+// ListOrdersDueForReviewRequest mocks base method.
+func (m *MockQuerier) ListOrdersDueForReviewRequest(ctx context.Context, arg ListOrdersDueForReviewRequestParams) ([]ListOrdersDueForReviewRequestRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrdersDueForReviewRequest", ctx, arg)
+	ret0, _ := ret[0].([]ListOrdersDueForReviewRequestRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrdersDueForReviewRequest indicates an expected call of ListOrdersDueForReviewRequest.
+func (mr *MockQuerierMockRecorder) ListOrdersDueForReviewRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrdersDueForReviewRequest", reflect.TypeOf((*MockQuerier)(nil).ListOrdersDueForReviewRequest), ctx, arg)
+}
+
+// ListOrdersForUser mocks base method.
+func (m *MockQuerier) ListOrdersForUser(ctx context.Context, arg ListOrdersForUserParams) ([]ListOrdersForUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrdersForUser", ctx, arg)
+	ret0, _ := ret[0].([]ListOrdersForUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrdersForUser indicates an expected call of ListOrdersForUser.
+func (mr *MockQuerierMockRecorder) ListOrdersForUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrdersForUser", reflect.TypeOf((*MockQuerier)(nil).ListOrdersForUser), ctx, arg)
+}
+
+// ListOverdueInvoices mocks base method.
+func (m *MockQuerier) ListOverdueInvoices(ctx context.Context, tenantID pgtype.UUID) ([]ListOverdueInvoicesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOverdueInvoices", ctx, tenantID)
+	ret0, _ := ret[0].([]ListOverdueInvoicesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOverdueInvoices indicates an expected call of ListOverdueInvoices.
+func (mr *MockQuerierMockRecorder) ListOverdueInvoices(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdueInvoices", reflect.TypeOf((*MockQuerier)(nil).ListOverdueInvoices), ctx, tenantID)
+}
+
+// ListPaymentMethodsForUser mocks base method.
+func (m *MockQuerier) ListPaymentMethodsForUser(ctx context.Context, arg ListPaymentMethodsForUserParams) ([]ListPaymentMethodsForUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPaymentMethodsForUser", ctx, arg)
+	ret0, _ := ret[0].([]ListPaymentMethodsForUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaymentMethodsForUser indicates an expected call of ListPaymentMethodsForUser.
+func (mr *MockQuerierMockRecorder) ListPaymentMethodsForUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentMethodsForUser", reflect.TypeOf((*MockQuerier)(nil).ListPaymentMethodsForUser), ctx, arg)
+}
+
+// ListPaymentTerms mocks base method.
+func (m *MockQuerier) ListPaymentTerms(ctx context.Context, tenantID pgtype.UUID) ([]PaymentTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPaymentTerms", ctx, tenantID)
+	ret0, _ := ret[0].([]PaymentTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaymentTerms indicates an expected call of ListPaymentTerms.
+func (mr *MockQuerierMockRecorder) ListPaymentTerms(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentTerms", reflect.TypeOf((*MockQuerier)(nil).ListPaymentTerms), ctx, tenantID)
+}
+
+// ListPriceListEntries mocks base method.
+func (m *MockQuerier) ListPriceListEntries(ctx context.Context, priceListID pgtype.UUID) ([]ListPriceListEntriesRow, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListPriceListEntries", ctx, priceListID)
 	ret0, _ := ret[0].([]ListPriceListEntriesRow)
@@ -3631,34 +5234,334 @@ func (mr *MockQuerierMockRecorder) ListPriceListEntries(ctx, priceListID any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPriceListEntries", reflect.TypeOf((*MockQuerier)(nil).ListPriceListEntries), ctx, priceListID)
 }
 
-// ListProductsWithSKUsForWholesale mocks base method.
-func (m *MockQuerier) ListProductsWithSKUsForWholesale(ctx context.Context, arg ListProductsWithSKUsForWholesaleParams) ([]ListProductsWithSKUsForWholesaleRow, error) {
+// ListPriceListTiers mocks base method.
+func (m *MockQuerier) ListPriceListTiers(ctx context.Context, arg ListPriceListTiersParams) ([]ListPriceListTiersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPriceListTiers", ctx, arg)
+	ret0, _ := ret[0].([]ListPriceListTiersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPriceListTiers indicates an expected call of ListPriceListTiers.
+func (mr *MockQuerierMockRecorder) ListPriceListTiers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPriceListTiers", reflect.TypeOf((*MockQuerier)(nil).ListPriceListTiers), ctx, arg)
+}
+
+// ListPriceTiersForEntry mocks base method.
+func (m *MockQuerier) ListPriceTiersForEntry(ctx context.Context, arg ListPriceTiersForEntryParams) ([]PriceListEntryTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPriceTiersForEntry", ctx, arg)
+	ret0, _ := ret[0].([]PriceListEntryTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPriceTiersForEntry indicates an expected call of ListPriceTiersForEntry.
+func (mr *MockQuerierMockRecorder) ListPriceTiersForEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPriceTiersForEntry", reflect.TypeOf((*MockQuerier)(nil).ListPriceTiersForEntry), ctx, arg)
+}
+
+// ListProductCategories mocks base method.
+func (m *MockQuerier) ListProductCategories(ctx context.Context, tenantID pgtype.UUID) ([]ListProductCategoriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductCategories", ctx, tenantID)
+	ret0, _ := ret[0].([]ListProductCategoriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductCategories indicates an expected call of ListProductCategories.
+func (mr *MockQuerierMockRecorder) ListProductCategories(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductCategories", reflect.TypeOf((*MockQuerier)(nil).ListProductCategories), ctx, tenantID)
+}
+
+// ListProductRoastLoss mocks base method.
+func (m *MockQuerier) ListProductRoastLoss(ctx context.Context, tenantID pgtype.UUID) ([]ListProductRoastLossRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductRoastLoss", ctx, tenantID)
+	ret0, _ := ret[0].([]ListProductRoastLossRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductRoastLoss indicates an expected call of ListProductRoastLoss.
+func (mr *MockQuerierMockRecorder) ListProductRoastLoss(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductRoastLoss", reflect.TypeOf((*MockQuerier)(nil).ListProductRoastLoss), ctx, tenantID)
+}
+
+// ListProductTags mocks base method.
+func (m *MockQuerier) ListProductTags(ctx context.Context, tenantID pgtype.UUID) ([]ListProductTagsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductTags", ctx, tenantID)
+	ret0, _ := ret[0].([]ListProductTagsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductTags indicates an expected call of ListProductTags.
+func (mr *MockQuerierMockRecorder) ListProductTags(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductTags", reflect.TypeOf((*MockQuerier)(nil).ListProductTags), ctx, tenantID)
+}
+
+// ListProductionDemand mocks base method.
+func (m *MockQuerier) ListProductionDemand(ctx context.Context, arg ListProductionDemandParams) ([]ListProductionDemandRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductionDemand", ctx, arg)
+	ret0, _ := ret[0].([]ListProductionDemandRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductionDemand indicates an expected call of ListProductionDemand.
+func (mr *MockQuerierMockRecorder) ListProductionDemand(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductionDemand", reflect.TypeOf((*MockQuerier)(nil).ListProductionDemand), ctx, arg)
+}
+
+// ListProductsWithSKUsForWholesale mocks base method.
+func (m *MockQuerier) ListProductsWithSKUsForWholesale(ctx context.Context, arg ListProductsWithSKUsForWholesaleParams) ([]ListProductsWithSKUsForWholesaleRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductsWithSKUsForWholesale", ctx, arg)
+	ret0, _ := ret[0].([]ListProductsWithSKUsForWholesaleRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductsWithSKUsForWholesale indicates an expected call of ListProductsWithSKUsForWholesale.
+func (mr *MockQuerierMockRecorder) ListProductsWithSKUsForWholesale(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsWithSKUsForWholesale", reflect.TypeOf((*MockQuerier)(nil).ListProductsWithSKUsForWholesale), ctx, arg)
+}
+
+// ListProviderConfigs mocks base method.
+func (m *MockQuerier) ListProviderConfigs(ctx context.Context, arg ListProviderConfigsParams) ([]TenantProviderConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProviderConfigs", ctx, arg)
+	ret0, _ := ret[0].([]TenantProviderConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProviderConfigs indicates an expected call of ListProviderConfigs.
+func (mr *MockQuerierMockRecorder) ListProviderConfigs(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProviderConfigs", reflect.TypeOf((*MockQuerier)(nil).ListProviderConfigs), ctx, arg)
+}
+
+// ListRefundedQuantitiesForOrder mocks base method.
+func (m *MockQuerier) ListRefundedQuantitiesForOrder(ctx context.Context, arg ListRefundedQuantitiesForOrderParams) ([]ListRefundedQuantitiesForOrderRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRefundedQuantitiesForOrder", ctx, arg)
+	ret0, _ := ret[0].([]ListRefundedQuantitiesForOrderRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRefundedQuantitiesForOrder indicates an expected call of ListRefundedQuantitiesForOrder.
+func (mr *MockQuerierMockRecorder) ListRefundedQuantitiesForOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRefundedQuantitiesForOrder", reflect.TypeOf((*MockQuerier)(nil).ListRefundedQuantitiesForOrder), ctx, arg)
+}
+
+// ListRefundsForOrder mocks base method.
+func (m *MockQuerier) ListRefundsForOrder(ctx context.Context, arg ListRefundsForOrderParams) ([]Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRefundsForOrder", ctx, arg)
+	ret0, _ := ret[0].([]Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRefundsForOrder indicates an expected call of ListRefundsForOrder.
+func (mr *MockQuerierMockRecorder) ListRefundsForOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRefundsForOrder", reflect.TypeOf((*MockQuerier)(nil).ListRefundsForOrder), ctx, arg)
+}
+
+// ListReorderItems mocks base method.
+func (m *MockQuerier) ListReorderItems(ctx context.Context, arg ListReorderItemsParams) ([]ListReorderItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReorderItems", ctx, arg)
+	ret0, _ := ret[0].([]ListReorderItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReorderItems indicates an expected call of ListReorderItems.
+func (mr *MockQuerierMockRecorder) ListReorderItems(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReorderItems", reflect.TypeOf((*MockQuerier)(nil).ListReorderItems), ctx, arg)
+}
+
+// ListRestingOrders mocks base method.
+func (m *MockQuerier) ListRestingOrders(ctx context.Context, tenantID pgtype.UUID) ([]ListRestingOrdersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRestingOrders", ctx, tenantID)
+	ret0, _ := ret[0].([]ListRestingOrdersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRestingOrders indicates an expected call of ListRestingOrders.
+func (mr *MockQuerierMockRecorder) ListRestingOrders(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRestingOrders", reflect.TypeOf((*MockQuerier)(nil).ListRestingOrders), ctx, tenantID)
+}
+
+// ListReviewableItemsForOrders mocks base method.
+func (m *MockQuerier) ListReviewableItemsForOrders(ctx context.Context, arg ListReviewableItemsForOrdersParams) ([]ListReviewableItemsForOrdersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviewableItemsForOrders", ctx, arg)
+	ret0, _ := ret[0].([]ListReviewableItemsForOrdersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviewableItemsForOrders indicates an expected call of ListReviewableItemsForOrders.
+func (mr *MockQuerierMockRecorder) ListReviewableItemsForOrders(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviewableItemsForOrders", reflect.TypeOf((*MockQuerier)(nil).ListReviewableItemsForOrders), ctx, arg)
+}
+
+// ListReviewsForModeration mocks base method.
+func (m *MockQuerier) ListReviewsForModeration(ctx context.Context, arg ListReviewsForModerationParams) ([]ListReviewsForModerationRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviewsForModeration", ctx, arg)
+	ret0, _ := ret[0].([]ListReviewsForModerationRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviewsForModeration indicates an expected call of ListReviewsForModeration.
+func (mr *MockQuerierMockRecorder) ListReviewsForModeration(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviewsForModeration", reflect.TypeOf((*MockQuerier)(nil).ListReviewsForModeration), ctx, arg)
+}
+
+// ListRoastBatchAllocations mocks base method.
+func (m *MockQuerier) ListRoastBatchAllocations(ctx context.Context, arg ListRoastBatchAllocationsParams) ([]ListRoastBatchAllocationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoastBatchAllocations", ctx, arg)
+	ret0, _ := ret[0].([]ListRoastBatchAllocationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoastBatchAllocations indicates an expected call of ListRoastBatchAllocations.
+func (mr *MockQuerierMockRecorder) ListRoastBatchAllocations(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoastBatchAllocations", reflect.TypeOf((*MockQuerier)(nil).ListRoastBatchAllocations), ctx, arg)
+}
+
+// ListRoastBatches mocks base method.
+func (m *MockQuerier) ListRoastBatches(ctx context.Context, arg ListRoastBatchesParams) ([]ListRoastBatchesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoastBatches", ctx, arg)
+	ret0, _ := ret[0].([]ListRoastBatchesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoastBatches indicates an expected call of ListRoastBatches.
+func (mr *MockQuerierMockRecorder) ListRoastBatches(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoastBatches", reflect.TypeOf((*MockQuerier)(nil).ListRoastBatches), ctx, arg)
+}
+
+// ListSKUInventoryAdjustments mocks base method.
+func (m *MockQuerier) ListSKUInventoryAdjustments(ctx context.Context, arg ListSKUInventoryAdjustmentsParams) ([]ListSKUInventoryAdjustmentsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSKUInventoryAdjustments", ctx, arg)
+	ret0, _ := ret[0].([]ListSKUInventoryAdjustmentsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSKUInventoryAdjustments indicates an expected call of ListSKUInventoryAdjustments.
+func (mr *MockQuerierMockRecorder) ListSKUInventoryAdjustments(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSKUInventoryAdjustments", reflect.TypeOf((*MockQuerier)(nil).ListSKUInventoryAdjustments), ctx, arg)
+}
+
+// ListShipmentsToTrack mocks base method.
+func (m *MockQuerier) ListShipmentsToTrack(ctx context.Context, arg ListShipmentsToTrackParams) ([]Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShipmentsToTrack", ctx, arg)
+	ret0, _ := ret[0].([]Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShipmentsToTrack indicates an expected call of ListShipmentsToTrack.
+func (mr *MockQuerierMockRecorder) ListShipmentsToTrack(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShipmentsToTrack", reflect.TypeOf((*MockQuerier)(nil).ListShipmentsToTrack), ctx, arg)
+}
+
+// ListStandingOrderItems mocks base method.
+func (m *MockQuerier) ListStandingOrderItems(ctx context.Context, arg ListStandingOrderItemsParams) ([]ListStandingOrderItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStandingOrderItems", ctx, arg)
+	ret0, _ := ret[0].([]ListStandingOrderItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStandingOrderItems indicates an expected call of ListStandingOrderItems.
+func (mr *MockQuerierMockRecorder) ListStandingOrderItems(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStandingOrderItems", reflect.TypeOf((*MockQuerier)(nil).ListStandingOrderItems), ctx, arg)
+}
+
+// ListStandingOrders mocks base method.
+func (m *MockQuerier) ListStandingOrders(ctx context.Context, tenantID pgtype.UUID) ([]ListStandingOrdersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStandingOrders", ctx, tenantID)
+	ret0, _ := ret[0].([]ListStandingOrdersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStandingOrders indicates an expected call of ListStandingOrders.
+func (mr *MockQuerierMockRecorder) ListStandingOrders(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStandingOrders", reflect.TypeOf((*MockQuerier)(nil).ListStandingOrders), ctx, tenantID)
+}
+
+// ListStandingOrdersForUser mocks base method.
+func (m *MockQuerier) ListStandingOrdersForUser(ctx context.Context, arg ListStandingOrdersForUserParams) ([]StandingOrder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProductsWithSKUsForWholesale", ctx, arg)
-	ret0, _ := ret[0].([]ListProductsWithSKUsForWholesaleRow)
+	ret := m.ctrl.Call(m, "ListStandingOrdersForUser", ctx, arg)
+	ret0, _ := ret[0].([]StandingOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListProductsWithSKUsForWholesale indicates an expected call of ListProductsWithSKUsForWholesale.
-func (mr *MockQuerierMockRecorder) ListProductsWithSKUsForWholesale(ctx, arg any) *gomock.Call {
+// ListStandingOrdersForUser indicates an expected call of ListStandingOrdersForUser.
+func (mr *MockQuerierMockRecorder) ListStandingOrdersForUser(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsWithSKUsForWholesale", reflect.TypeOf((*MockQuerier)(nil).ListProductsWithSKUsForWholesale), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStandingOrdersForUser", reflect.TypeOf((*MockQuerier)(nil).ListStandingOrdersForUser), ctx, arg)
 }
 
-// ListProviderConfigs mocks base method.
-func (m *MockQuerier) ListProviderConfigs(ctx context.Context, arg ListProviderConfigsParams) ([]TenantProviderConfig, error) {
+// ListStandingOrdersToRemind mocks base method.
+func (m *MockQuerier) ListStandingOrdersToRemind(ctx context.Context, arg ListStandingOrdersToRemindParams) ([]ListStandingOrdersToRemindRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProviderConfigs", ctx, arg)
-	ret0, _ := ret[0].([]TenantProviderConfig)
+	ret := m.ctrl.Call(m, "ListStandingOrdersToRemind", ctx, arg)
+	ret0, _ := ret[0].([]ListStandingOrdersToRemindRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListProviderConfigs indicates an expected call of ListProviderConfigs.
-func (mr *MockQuerierMockRecorder) ListProviderConfigs(ctx, arg any) *gomock.Call {
+// ListStandingOrdersToRemind indicates an expected call of ListStandingOrdersToRemind.
+func (mr *MockQuerierMockRecorder) ListStandingOrdersToRemind(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProviderConfigs", reflect.TypeOf((*MockQuerier)(nil).ListProviderConfigs), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStandingOrdersToRemind", reflect.TypeOf((*MockQuerier)(nil).ListStandingOrdersToRemind), ctx, arg)
 }
 
 // ListSubscriptionItemsForSubscription mocks base method.
@@ -3676,6 +5579,21 @@ func (mr *MockQuerierMockRecorder) ListSubscriptionItemsForSubscription(ctx, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptionItemsForSubscription", reflect.TypeOf((*MockQuerier)(nil).ListSubscriptionItemsForSubscription), ctx, arg)
 }
 
+// ListSubscriptionScheduleEvents mocks base method.
+func (m *MockQuerier) ListSubscriptionScheduleEvents(ctx context.Context, arg ListSubscriptionScheduleEventsParams) ([]SubscriptionSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptionScheduleEvents", ctx, arg)
+	ret0, _ := ret[0].([]SubscriptionSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptionScheduleEvents indicates an expected call of ListSubscriptionScheduleEvents.
+func (mr *MockQuerierMockRecorder) ListSubscriptionScheduleEvents(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptionScheduleEvents", reflect.TypeOf((*MockQuerier)(nil).ListSubscriptionScheduleEvents), ctx, arg)
+}
+
 // ListSubscriptions mocks base method.
 func (m *MockQuerier) ListSubscriptions(ctx context.Context, arg ListSubscriptionsParams) ([]ListSubscriptionsRow, error) {
 	m.ctrl.T.Helper()
@@ -3721,6 +5639,36 @@ func (mr *MockQuerierMockRecorder) ListSubscriptionsForUser(ctx, arg any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptionsForUser", reflect.TypeOf((*MockQuerier)(nil).ListSubscriptionsForUser), ctx, arg)
 }
 
+// ListTagFacets mocks base method.
+func (m *MockQuerier) ListTagFacets(ctx context.Context, tenantID pgtype.UUID) ([]ListTagFacetsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagFacets", ctx, tenantID)
+	ret0, _ := ret[0].([]ListTagFacetsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagFacets indicates an expected call of ListTagFacets.
+func (mr *MockQuerierMockRecorder) ListTagFacets(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagFacets", reflect.TypeOf((*MockQuerier)(nil).ListTagFacets), ctx, tenantID)
+}
+
+// ListTagIDsForProduct mocks base method.
+func (m *MockQuerier) ListTagIDsForProduct(ctx context.Context, arg ListTagIDsForProductParams) ([]pgtype.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagIDsForProduct", ctx, arg)
+	ret0, _ := ret[0].([]pgtype.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagIDsForProduct indicates an expected call of ListTagIDsForProduct.
+func (mr *MockQuerierMockRecorder) ListTagIDsForProduct(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagIDsForProduct", reflect.TypeOf((*MockQuerier)(nil).ListTagIDsForProduct), ctx, arg)
+}
+
 // ListTaxRates mocks base method.
 func (m *MockQuerier) ListTaxRates(ctx context.Context, tenantID pgtype.UUID) ([]TaxRate, error) {
 	m.ctrl.T.Helper()
@@ -3766,6 +5714,21 @@ func (mr *MockQuerierMockRecorder) ListTenantPages(ctx, tenantID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTenantPages", reflect.TypeOf((*MockQuerier)(nil).ListTenantPages), ctx, tenantID)
 }
 
+// ListTrackingEventsForOrders mocks base method.
+func (m *MockQuerier) ListTrackingEventsForOrders(ctx context.Context, arg ListTrackingEventsForOrdersParams) ([]ListTrackingEventsForOrdersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrackingEventsForOrders", ctx, arg)
+	ret0, _ := ret[0].([]ListTrackingEventsForOrdersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrackingEventsForOrders indicates an expected call of ListTrackingEventsForOrders.
+func (mr *MockQuerierMockRecorder) ListTrackingEventsForOrders(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrackingEventsForOrders", reflect.TypeOf((*MockQuerier)(nil).ListTrackingEventsForOrders), ctx, arg)
+}
+
 // ListUpcomingScheduleEvents mocks base method.
 func (m *MockQuerier) ListUpcomingScheduleEvents(ctx context.Context, arg ListUpcomingScheduleEventsParams) ([]ListUpcomingScheduleEventsRow, error) {
 	m.ctrl.T.Helper()
@@ -3811,6 +5774,21 @@ func (mr *MockQuerierMockRecorder) ListUsersByAccountType(ctx, arg any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersByAccountType", reflect.TypeOf((*MockQuerier)(nil).ListUsersByAccountType), ctx, arg)
 }
 
+// ListWhiteLabelProducts mocks base method.
+func (m *MockQuerier) ListWhiteLabelProducts(ctx context.Context, arg ListWhiteLabelProductsParams) ([]ListWhiteLabelProductsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWhiteLabelProducts", ctx, arg)
+	ret0, _ := ret[0].([]ListWhiteLabelProductsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWhiteLabelProducts indicates an expected call of ListWhiteLabelProducts.
+func (mr *MockQuerierMockRecorder) ListWhiteLabelProducts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWhiteLabelProducts", reflect.TypeOf((*MockQuerier)(nil).ListWhiteLabelProducts), ctx, arg)
+}
+
 // ListWholesaleApplications mocks base method.
 func (m *MockQuerier) ListWholesaleApplications(ctx context.Context, tenantID pgtype.UUID) ([]ListWholesaleApplicationsRow, error) {
 	m.ctrl.T.Helper()
@@ -3856,6 +5834,36 @@ func (mr *MockQuerierMockRecorder) ListWholesaleOrders(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWholesaleOrders", reflect.TypeOf((*MockQuerier)(nil).ListWholesaleOrders), ctx, arg)
 }
 
+// LockCustomerForOrder mocks base method.
+func (m *MockQuerier) LockCustomerForOrder(ctx context.Context, arg LockCustomerForOrderParams) (pgtype.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCustomerForOrder", ctx, arg)
+	ret0, _ := ret[0].(pgtype.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockCustomerForOrder indicates an expected call of LockCustomerForOrder.
+func (mr *MockQuerierMockRecorder) LockCustomerForOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCustomerForOrder", reflect.TypeOf((*MockQuerier)(nil).LockCustomerForOrder), ctx, arg)
+}
+
+// LockDiscountCode mocks base method.
+func (m *MockQuerier) LockDiscountCode(ctx context.Context, arg LockDiscountCodeParams) (DiscountCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockDiscountCode", ctx, arg)
+	ret0, _ := ret[0].(DiscountCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockDiscountCode indicates an expected call of LockDiscountCode.
+func (mr *MockQuerierMockRecorder) LockDiscountCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockDiscountCode", reflect.TypeOf((*MockQuerier)(nil).LockDiscountCode), ctx, arg)
+}
+
 // MarkDomainVerificationFailed mocks base method.
 func (m *MockQuerier) MarkDomainVerificationFailed(ctx context.Context, arg MarkDomainVerificationFailedParams) error {
 	m.ctrl.T.Helper()
@@ -3912,6 +5920,34 @@ func (mr *MockQuerierMockRecorder) MarkEmailVerificationTokenUsed(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerificationTokenUsed", reflect.TypeOf((*MockQuerier)(nil).MarkEmailVerificationTokenUsed), ctx, arg)
 }
 
+// MarkFulfillmentBatchOrderFailed mocks base method.
+func (m *MockQuerier) MarkFulfillmentBatchOrderFailed(ctx context.Context, arg MarkFulfillmentBatchOrderFailedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFulfillmentBatchOrderFailed", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFulfillmentBatchOrderFailed indicates an expected call of MarkFulfillmentBatchOrderFailed.
+func (mr *MockQuerierMockRecorder) MarkFulfillmentBatchOrderFailed(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFulfillmentBatchOrderFailed", reflect.TypeOf((*MockQuerier)(nil).MarkFulfillmentBatchOrderFailed), ctx, arg)
+}
+
+// MarkFulfillmentBatchOrderPurchased mocks base method.
+func (m *MockQuerier) MarkFulfillmentBatchOrderPurchased(ctx context.Context, arg MarkFulfillmentBatchOrderPurchasedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFulfillmentBatchOrderPurchased", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFulfillmentBatchOrderPurchased indicates an expected call of MarkFulfillmentBatchOrderPurchased.
+func (mr *MockQuerierMockRecorder) MarkFulfillmentBatchOrderPurchased(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFulfillmentBatchOrderPurchased", reflect.TypeOf((*MockQuerier)(nil).MarkFulfillmentBatchOrderPurchased), ctx, arg)
+}
+
 // MarkInvoiceViewed mocks base method.
 func (m *MockQuerier) MarkInvoiceViewed(ctx context.Context, arg MarkInvoiceViewedParams) error {
 	m.ctrl.T.Helper()
@@ -3926,6 +5962,21 @@ func (mr *MockQuerierMockRecorder) MarkInvoiceViewed(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkInvoiceViewed", reflect.TypeOf((*MockQuerier)(nil).MarkInvoiceViewed), ctx, arg)
 }
 
+// MarkOrderDeliveredIfComplete mocks base method.
+func (m *MockQuerier) MarkOrderDeliveredIfComplete(ctx context.Context, arg MarkOrderDeliveredIfCompleteParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOrderDeliveredIfComplete", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOrderDeliveredIfComplete indicates an expected call of MarkOrderDeliveredIfComplete.
+func (mr *MockQuerierMockRecorder) MarkOrderDeliveredIfComplete(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOrderDeliveredIfComplete", reflect.TypeOf((*MockQuerier)(nil).MarkOrderDeliveredIfComplete), ctx, arg)
+}
+
 // MarkPasswordResetTokenUsed mocks base method.
 func (m *MockQuerier) MarkPasswordResetTokenUsed(ctx context.Context, arg MarkPasswordResetTokenUsedParams) error {
 	m.ctrl.T.Helper()
@@ -3940,32 +5991,293 @@ func (mr *MockQuerierMockRecorder) MarkPasswordResetTokenUsed(ctx, arg any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPasswordResetTokenUsed", reflect.TypeOf((*MockQuerier)(nil).MarkPasswordResetTokenUsed), ctx, arg)
 }
 
-// RecalculateOrderFulfillmentStatus mocks base method.
-func (m *MockQuerier) RecalculateOrderFulfillmentStatus(ctx context.Context, arg RecalculateOrderFulfillmentStatusParams) error {
+// MarkStandingOrderReminded mocks base method.
+func (m *MockQuerier) MarkStandingOrderReminded(ctx context.Context, arg MarkStandingOrderRemindedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkStandingOrderReminded", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkStandingOrderReminded indicates an expected call of MarkStandingOrderReminded.
+func (mr *MockQuerierMockRecorder) MarkStandingOrderReminded(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkStandingOrderReminded", reflect.TypeOf((*MockQuerier)(nil).MarkStandingOrderReminded), ctx, arg)
+}
+
+// ModerateProductReview mocks base method.
+func (m *MockQuerier) ModerateProductReview(ctx context.Context, arg ModerateProductReviewParams) (ProductReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateProductReview", ctx, arg)
+	ret0, _ := ret[0].(ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerateProductReview indicates an expected call of ModerateProductReview.
+func (mr *MockQuerierMockRecorder) ModerateProductReview(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateProductReview", reflect.TypeOf((*MockQuerier)(nil).ModerateProductReview), ctx, arg)
+}
+
+// ReapStaleJobs mocks base method.
+func (m *MockQuerier) ReapStaleJobs(ctx context.Context, staleBefore pgtype.Timestamptz) ([]ReapStaleJobsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReapStaleJobs", ctx, staleBefore)
+	ret0, _ := ret[0].([]ReapStaleJobsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReapStaleJobs indicates an expected call of ReapStaleJobs.
+func (mr *MockQuerierMockRecorder) ReapStaleJobs(ctx, staleBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReapStaleJobs", reflect.TypeOf((*MockQuerier)(nil).ReapStaleJobs), ctx, staleBefore)
+}
+
+// RecalculateOrderFulfillmentStatus mocks base method.
+func (m *MockQuerier) RecalculateOrderFulfillmentStatus(ctx context.Context, arg RecalculateOrderFulfillmentStatusParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecalculateOrderFulfillmentStatus", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecalculateOrderFulfillmentStatus indicates an expected call of RecalculateOrderFulfillmentStatus.
+func (mr *MockQuerierMockRecorder) RecalculateOrderFulfillmentStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecalculateOrderFulfillmentStatus", reflect.TypeOf((*MockQuerier)(nil).RecalculateOrderFulfillmentStatus), ctx, arg)
+}
+
+// RecordDiscountCodeUsage mocks base method.
+func (m *MockQuerier) RecordDiscountCodeUsage(ctx context.Context, arg RecordDiscountCodeUsageParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordDiscountCodeUsage", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordDiscountCodeUsage indicates an expected call of RecordDiscountCodeUsage.
+func (mr *MockQuerierMockRecorder) RecordDiscountCodeUsage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordDiscountCodeUsage", reflect.TypeOf((*MockQuerier)(nil).RecordDiscountCodeUsage), ctx, arg)
+}
+
+// RecordRoastBatchRoasted mocks base method.
+func (m *MockQuerier) RecordRoastBatchRoasted(ctx context.Context, arg RecordRoastBatchRoastedParams) (RoastBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordRoastBatchRoasted", ctx, arg)
+	ret0, _ := ret[0].(RoastBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordRoastBatchRoasted indicates an expected call of RecordRoastBatchRoasted.
+func (mr *MockQuerierMockRecorder) RecordRoastBatchRoasted(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRoastBatchRoasted", reflect.TypeOf((*MockQuerier)(nil).RecordRoastBatchRoasted), ctx, arg)
+}
+
+// RecordStandingOrderGenerated mocks base method.
+func (m *MockQuerier) RecordStandingOrderGenerated(ctx context.Context, arg RecordStandingOrderGeneratedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordStandingOrderGenerated", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordStandingOrderGenerated indicates an expected call of RecordStandingOrderGenerated.
+func (mr *MockQuerierMockRecorder) RecordStandingOrderGenerated(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordStandingOrderGenerated", reflect.TypeOf((*MockQuerier)(nil).RecordStandingOrderGenerated), ctx, arg)
+}
+
+// RecordSubscriptionDunningAttempt mocks base method.
+func (m *MockQuerier) RecordSubscriptionDunningAttempt(ctx context.Context, arg RecordSubscriptionDunningAttemptParams) (SubscriptionDunning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSubscriptionDunningAttempt", ctx, arg)
+	ret0, _ := ret[0].(SubscriptionDunning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordSubscriptionDunningAttempt indicates an expected call of RecordSubscriptionDunningAttempt.
+func (mr *MockQuerierMockRecorder) RecordSubscriptionDunningAttempt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSubscriptionDunningAttempt", reflect.TypeOf((*MockQuerier)(nil).RecordSubscriptionDunningAttempt), ctx, arg)
+}
+
+// RefreshReviewHelpfulCounts mocks base method.
+func (m *MockQuerier) RefreshReviewHelpfulCounts(ctx context.Context, arg RefreshReviewHelpfulCountsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshReviewHelpfulCounts", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshReviewHelpfulCounts indicates an expected call of RefreshReviewHelpfulCounts.
+func (mr *MockQuerierMockRecorder) RefreshReviewHelpfulCounts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshReviewHelpfulCounts", reflect.TypeOf((*MockQuerier)(nil).RefreshReviewHelpfulCounts), ctx, arg)
+}
+
+// ReleaseOrderItemDispatchedQuantity mocks base method.
+func (m *MockQuerier) ReleaseOrderItemDispatchedQuantity(ctx context.Context, arg ReleaseOrderItemDispatchedQuantityParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseOrderItemDispatchedQuantity", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseOrderItemDispatchedQuantity indicates an expected call of ReleaseOrderItemDispatchedQuantity.
+func (mr *MockQuerierMockRecorder) ReleaseOrderItemDispatchedQuantity(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseOrderItemDispatchedQuantity", reflect.TypeOf((*MockQuerier)(nil).ReleaseOrderItemDispatchedQuantity), ctx, arg)
+}
+
+// ReleaseWorkerJobs mocks base method.
+func (m *MockQuerier) ReleaseWorkerJobs(ctx context.Context, workerID pgtype.Text) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseWorkerJobs", ctx, workerID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseWorkerJobs indicates an expected call of ReleaseWorkerJobs.
+func (mr *MockQuerierMockRecorder) ReleaseWorkerJobs(ctx, workerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseWorkerJobs", reflect.TypeOf((*MockQuerier)(nil).ReleaseWorkerJobs), ctx, workerID)
+}
+
+// RemoveCartItem mocks base method.
+func (m *MockQuerier) RemoveCartItem(ctx context.Context, arg RemoveCartItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCartItem", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCartItem indicates an expected call of RemoveCartItem.
+func (mr *MockQuerierMockRecorder) RemoveCartItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCartItem", reflect.TypeOf((*MockQuerier)(nil).RemoveCartItem), ctx, arg)
+}
+
+// ReparentProductCategories mocks base method.
+func (m *MockQuerier) ReparentProductCategories(ctx context.Context, arg ReparentProductCategoriesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReparentProductCategories", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReparentProductCategories indicates an expected call of ReparentProductCategories.
+func (mr *MockQuerierMockRecorder) ReparentProductCategories(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReparentProductCategories", reflect.TypeOf((*MockQuerier)(nil).ReparentProductCategories), ctx, arg)
+}
+
+// ReplyToProductReview mocks base method.
+func (m *MockQuerier) ReplyToProductReview(ctx context.Context, arg ReplyToProductReviewParams) (ProductReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplyToProductReview", ctx, arg)
+	ret0, _ := ret[0].(ProductReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplyToProductReview indicates an expected call of ReplyToProductReview.
+func (mr *MockQuerierMockRecorder) ReplyToProductReview(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplyToProductReview", reflect.TypeOf((*MockQuerier)(nil).ReplyToProductReview), ctx, arg)
+}
+
+// RequeueFailedJobs mocks base method.
+func (m *MockQuerier) RequeueFailedJobs(ctx context.Context, arg RequeueFailedJobsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueFailedJobs", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueFailedJobs indicates an expected call of RequeueFailedJobs.
+func (mr *MockQuerierMockRecorder) RequeueFailedJobs(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueFailedJobs", reflect.TypeOf((*MockQuerier)(nil).RequeueFailedJobs), ctx, arg)
+}
+
+// RequeueJob mocks base method.
+func (m *MockQuerier) RequeueJob(ctx context.Context, arg RequeueJobParams) (Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueJob", ctx, arg)
+	ret0, _ := ret[0].(Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueJob indicates an expected call of RequeueJob.
+func (mr *MockQuerierMockRecorder) RequeueJob(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueJob", reflect.TypeOf((*MockQuerier)(nil).RequeueJob), ctx, arg)
+}
+
+// ResolveSubscriptionDunning mocks base method.
+func (m *MockQuerier) ResolveSubscriptionDunning(ctx context.Context, arg ResolveSubscriptionDunningParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveSubscriptionDunning", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveSubscriptionDunning indicates an expected call of ResolveSubscriptionDunning.
+func (mr *MockQuerierMockRecorder) ResolveSubscriptionDunning(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveSubscriptionDunning", reflect.TypeOf((*MockQuerier)(nil).ResolveSubscriptionDunning), ctx, arg)
+}
+
+// ResolveSubscriptionDunningForInvoice mocks base method.
+func (m *MockQuerier) ResolveSubscriptionDunningForInvoice(ctx context.Context, arg ResolveSubscriptionDunningForInvoiceParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveSubscriptionDunningForInvoice", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveSubscriptionDunningForInvoice indicates an expected call of ResolveSubscriptionDunningForInvoice.
+func (mr *MockQuerierMockRecorder) ResolveSubscriptionDunningForInvoice(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveSubscriptionDunningForInvoice", reflect.TypeOf((*MockQuerier)(nil).ResolveSubscriptionDunningForInvoice), ctx, arg)
+}
+
+// RestockCancelledOrderItem mocks base method.
+func (m *MockQuerier) RestockCancelledOrderItem(ctx context.Context, arg RestockCancelledOrderItemParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RecalculateOrderFulfillmentStatus", ctx, arg)
+	ret := m.ctrl.Call(m, "RestockCancelledOrderItem", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RecalculateOrderFulfillmentStatus indicates an expected call of RecalculateOrderFulfillmentStatus.
-func (mr *MockQuerierMockRecorder) RecalculateOrderFulfillmentStatus(ctx, arg any) *gomock.Call {
+// RestockCancelledOrderItem indicates an expected call of RestockCancelledOrderItem.
+func (mr *MockQuerierMockRecorder) RestockCancelledOrderItem(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecalculateOrderFulfillmentStatus", reflect.TypeOf((*MockQuerier)(nil).RecalculateOrderFulfillmentStatus), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestockCancelledOrderItem", reflect.TypeOf((*MockQuerier)(nil).RestockCancelledOrderItem), ctx, arg)
 }
 
-// RemoveCartItem mocks base method.
-func (m *MockQuerier) RemoveCartItem(ctx context.Context, arg RemoveCartItemParams) error {
+// SetCartUser mocks base method.
+func (m *MockQuerier) SetCartUser(ctx context.Context, arg SetCartUserParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveCartItem", ctx, arg)
+	ret := m.ctrl.Call(m, "SetCartUser", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RemoveCartItem indicates an expected call of RemoveCartItem.
-func (mr *MockQuerierMockRecorder) RemoveCartItem(ctx, arg any) *gomock.Call {
+// SetCartUser indicates an expected call of SetCartUser.
+func (mr *MockQuerierMockRecorder) SetCartUser(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCartItem", reflect.TypeOf((*MockQuerier)(nil).RemoveCartItem), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCartUser", reflect.TypeOf((*MockQuerier)(nil).SetCartUser), ctx, arg)
 }
 
 // SetCustomDomain mocks base method.
@@ -4066,6 +6378,20 @@ func (mr *MockQuerierMockRecorder) SetOperatorSetupToken(ctx, arg any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOperatorSetupToken", reflect.TypeOf((*MockQuerier)(nil).SetOperatorSetupToken), ctx, arg)
 }
 
+// SetOrderStatusChangeReason mocks base method.
+func (m *MockQuerier) SetOrderStatusChangeReason(ctx context.Context, arg SetOrderStatusChangeReasonParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrderStatusChangeReason", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOrderStatusChangeReason indicates an expected call of SetOrderStatusChangeReason.
+func (mr *MockQuerierMockRecorder) SetOrderStatusChangeReason(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrderStatusChangeReason", reflect.TypeOf((*MockQuerier)(nil).SetOrderStatusChangeReason), ctx, arg)
+}
+
 // SetPrimaryImage mocks base method.
 func (m *MockQuerier) SetPrimaryImage(ctx context.Context, arg SetPrimaryImageParams) error {
 	m.ctrl.T.Helper()
@@ -4109,6 +6435,35 @@ func (mr *MockQuerierMockRecorder) SkipItem(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SkipItem", reflect.TypeOf((*MockQuerier)(nil).SkipItem), ctx, arg)
 }
 
+// SkipStandingOrderDelivery mocks base method.
+func (m *MockQuerier) SkipStandingOrderDelivery(ctx context.Context, arg SkipStandingOrderDeliveryParams) (StandingOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SkipStandingOrderDelivery", ctx, arg)
+	ret0, _ := ret[0].(StandingOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SkipStandingOrderDelivery indicates an expected call of SkipStandingOrderDelivery.
+func (mr *MockQuerierMockRecorder) SkipStandingOrderDelivery(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SkipStandingOrderDelivery", reflect.TypeOf((*MockQuerier)(nil).SkipStandingOrderDelivery), ctx, arg)
+}
+
+// StartFulfillmentBatch mocks base method.
+func (m *MockQuerier) StartFulfillmentBatch(ctx context.Context, arg StartFulfillmentBatchParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartFulfillmentBatch", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartFulfillmentBatch indicates an expected call of StartFulfillmentBatch.
+func (mr *MockQuerierMockRecorder) StartFulfillmentBatch(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartFulfillmentBatch", reflect.TypeOf((*MockQuerier)(nil).StartFulfillmentBatch), ctx, arg)
+}
+
 // StartTenantGracePeriod mocks base method.
 func (m *MockQuerier) StartTenantGracePeriod(ctx context.Context, id pgtype.UUID) error {
 	m.ctrl.T.Helper()
@@ -4195,6 +6550,48 @@ func (mr *MockQuerierMockRecorder) TenantSlugExists(ctx, slug any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantSlugExists", reflect.TypeOf((*MockQuerier)(nil).TenantSlugExists), ctx, slug)
 }
 
+// TouchShipmentTrackingChecked mocks base method.
+func (m *MockQuerier) TouchShipmentTrackingChecked(ctx context.Context, arg TouchShipmentTrackingCheckedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchShipmentTrackingChecked", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchShipmentTrackingChecked indicates an expected call of TouchShipmentTrackingChecked.
+func (mr *MockQuerierMockRecorder) TouchShipmentTrackingChecked(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchShipmentTrackingChecked", reflect.TypeOf((*MockQuerier)(nil).TouchShipmentTrackingChecked), ctx, arg)
+}
+
+// UnassignProductCategoriesExcept mocks base method.
+func (m *MockQuerier) UnassignProductCategoriesExcept(ctx context.Context, arg UnassignProductCategoriesExceptParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignProductCategoriesExcept", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnassignProductCategoriesExcept indicates an expected call of UnassignProductCategoriesExcept.
+func (mr *MockQuerierMockRecorder) UnassignProductCategoriesExcept(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignProductCategoriesExcept", reflect.TypeOf((*MockQuerier)(nil).UnassignProductCategoriesExcept), ctx, arg)
+}
+
+// UnassignProductTagsExcept mocks base method.
+func (m *MockQuerier) UnassignProductTagsExcept(ctx context.Context, arg UnassignProductTagsExceptParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignProductTagsExcept", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnassignProductTagsExcept indicates an expected call of UnassignProductTagsExcept.
+func (mr *MockQuerierMockRecorder) UnassignProductTagsExcept(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignProductTagsExcept", reflect.TypeOf((*MockQuerier)(nil).UnassignProductTagsExcept), ctx, arg)
+}
+
 // UnsetDefaultProvider mocks base method.
 func (m *MockQuerier) UnsetDefaultProvider(ctx context.Context, arg UnsetDefaultProviderParams) error {
 	m.ctrl.T.Helper()
@@ -4253,6 +6650,20 @@ func (mr *MockQuerierMockRecorder) UpdateBillingCustomer(ctx, arg any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBillingCustomer", reflect.TypeOf((*MockQuerier)(nil).UpdateBillingCustomer), ctx, arg)
 }
 
+// UpdateCartItemPrice mocks base method.
+func (m *MockQuerier) UpdateCartItemPrice(ctx context.Context, arg UpdateCartItemPriceParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCartItemPrice", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCartItemPrice indicates an expected call of UpdateCartItemPrice.
+func (mr *MockQuerierMockRecorder) UpdateCartItemPrice(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCartItemPrice", reflect.TypeOf((*MockQuerier)(nil).UpdateCartItemPrice), ctx, arg)
+}
+
 // UpdateCartItemQuantity mocks base method.
 func (m *MockQuerier) UpdateCartItemQuantity(ctx context.Context, arg UpdateCartItemQuantityParams) error {
 	m.ctrl.T.Helper()
@@ -4295,6 +6706,21 @@ func (mr *MockQuerierMockRecorder) UpdateCustomDomainHealthCheck(ctx, arg any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomDomainHealthCheck", reflect.TypeOf((*MockQuerier)(nil).UpdateCustomDomainHealthCheck), ctx, arg)
 }
 
+// UpdateDiscountCode mocks base method.
+func (m *MockQuerier) UpdateDiscountCode(ctx context.Context, arg UpdateDiscountCodeParams) (DiscountCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDiscountCode", ctx, arg)
+	ret0, _ := ret[0].(DiscountCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDiscountCode indicates an expected call of UpdateDiscountCode.
+func (mr *MockQuerierMockRecorder) UpdateDiscountCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDiscountCode", reflect.TypeOf((*MockQuerier)(nil).UpdateDiscountCode), ctx, arg)
+}
+
 // UpdateInvoiceProviderID mocks base method.
 func (m *MockQuerier) UpdateInvoiceProviderID(ctx context.Context, arg UpdateInvoiceProviderIDParams) error {
 	m.ctrl.T.Helper()
@@ -4323,6 +6749,20 @@ func (mr *MockQuerierMockRecorder) UpdateInvoiceStatus(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvoiceStatus", reflect.TypeOf((*MockQuerier)(nil).UpdateInvoiceStatus), ctx, arg)
 }
 
+// UpdateJobScheduleRun mocks base method.
+func (m *MockQuerier) UpdateJobScheduleRun(ctx context.Context, arg UpdateJobScheduleRunParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobScheduleRun", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJobScheduleRun indicates an expected call of UpdateJobScheduleRun.
+func (mr *MockQuerierMockRecorder) UpdateJobScheduleRun(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobScheduleRun", reflect.TypeOf((*MockQuerier)(nil).UpdateJobScheduleRun), ctx, arg)
+}
+
 // UpdateOperatorLastLogin mocks base method.
 func (m *MockQuerier) UpdateOperatorLastLogin(ctx context.Context, id pgtype.UUID) error {
 	m.ctrl.T.Helper()
@@ -4436,6 +6876,20 @@ func (mr *MockQuerierMockRecorder) UpdateOrderStatus(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockQuerier)(nil).UpdateOrderStatus), ctx, arg)
 }
 
+// UpdatePaymentRefund mocks base method.
+func (m *MockQuerier) UpdatePaymentRefund(ctx context.Context, arg UpdatePaymentRefundParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentRefund", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentRefund indicates an expected call of UpdatePaymentRefund.
+func (mr *MockQuerierMockRecorder) UpdatePaymentRefund(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentRefund", reflect.TypeOf((*MockQuerier)(nil).UpdatePaymentRefund), ctx, arg)
+}
+
 // UpdatePaymentStatus mocks base method.
 func (m *MockQuerier) UpdatePaymentStatus(ctx context.Context, arg UpdatePaymentStatusParams) (Payment, error) {
 	m.ctrl.T.Helper()
@@ -4510,6 +6964,21 @@ func (mr *MockQuerierMockRecorder) UpdateProduct(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockQuerier)(nil).UpdateProduct), ctx, arg)
 }
 
+// UpdateProductCategory mocks base method.
+func (m *MockQuerier) UpdateProductCategory(ctx context.Context, arg UpdateProductCategoryParams) (ProductCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductCategory", ctx, arg)
+	ret0, _ := ret[0].(ProductCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProductCategory indicates an expected call of UpdateProductCategory.
+func (mr *MockQuerierMockRecorder) UpdateProductCategory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductCategory", reflect.TypeOf((*MockQuerier)(nil).UpdateProductCategory), ctx, arg)
+}
+
 // UpdateProductImage mocks base method.
 func (m *MockQuerier) UpdateProductImage(ctx context.Context, arg UpdateProductImageParams) (ProductImage, error) {
 	m.ctrl.T.Helper()
@@ -4525,6 +6994,21 @@ func (mr *MockQuerierMockRecorder) UpdateProductImage(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductImage", reflect.TypeOf((*MockQuerier)(nil).UpdateProductImage), ctx, arg)
 }
 
+// UpdateProductRoastLoss mocks base method.
+func (m *MockQuerier) UpdateProductRoastLoss(ctx context.Context, arg UpdateProductRoastLossParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductRoastLoss", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProductRoastLoss indicates an expected call of UpdateProductRoastLoss.
+func (mr *MockQuerierMockRecorder) UpdateProductRoastLoss(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductRoastLoss", reflect.TypeOf((*MockQuerier)(nil).UpdateProductRoastLoss), ctx, arg)
+}
+
 // UpdateProductSKU mocks base method.
 func (m *MockQuerier) UpdateProductSKU(ctx context.Context, arg UpdateProductSKUParams) (ProductSku, error) {
 	m.ctrl.T.Helper()
@@ -4540,6 +7024,21 @@ func (mr *MockQuerierMockRecorder) UpdateProductSKU(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductSKU", reflect.TypeOf((*MockQuerier)(nil).UpdateProductSKU), ctx, arg)
 }
 
+// UpdateProductTag mocks base method.
+func (m *MockQuerier) UpdateProductTag(ctx context.Context, arg UpdateProductTagParams) (ProductTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductTag", ctx, arg)
+	ret0, _ := ret[0].(ProductTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProductTag indicates an expected call of UpdateProductTag.
+func (mr *MockQuerierMockRecorder) UpdateProductTag(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductTag", reflect.TypeOf((*MockQuerier)(nil).UpdateProductTag), ctx, arg)
+}
+
 // UpdateProviderConfig mocks base method.
 func (m *MockQuerier) UpdateProviderConfig(ctx context.Context, arg UpdateProviderConfigParams) (TenantProviderConfig, error) {
 	m.ctrl.T.Helper()
@@ -4555,6 +7054,36 @@ func (mr *MockQuerierMockRecorder) UpdateProviderConfig(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProviderConfig", reflect.TypeOf((*MockQuerier)(nil).UpdateProviderConfig), ctx, arg)
 }
 
+// UpdateRoastBatch mocks base method.
+func (m *MockQuerier) UpdateRoastBatch(ctx context.Context, arg UpdateRoastBatchParams) (RoastBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRoastBatch", ctx, arg)
+	ret0, _ := ret[0].(RoastBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRoastBatch indicates an expected call of UpdateRoastBatch.
+func (mr *MockQuerierMockRecorder) UpdateRoastBatch(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoastBatch", reflect.TypeOf((*MockQuerier)(nil).UpdateRoastBatch), ctx, arg)
+}
+
+// UpdateSKULowStockThreshold mocks base method.
+func (m *MockQuerier) UpdateSKULowStockThreshold(ctx context.Context, arg UpdateSKULowStockThresholdParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSKULowStockThreshold", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSKULowStockThreshold indicates an expected call of UpdateSKULowStockThreshold.
+func (mr *MockQuerierMockRecorder) UpdateSKULowStockThreshold(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSKULowStockThreshold", reflect.TypeOf((*MockQuerier)(nil).UpdateSKULowStockThreshold), ctx, arg)
+}
+
 // UpdateSessionData mocks base method.
 func (m *MockQuerier) UpdateSessionData(ctx context.Context, arg UpdateSessionDataParams) error {
 	m.ctrl.T.Helper()
@@ -4583,6 +7112,36 @@ func (mr *MockQuerierMockRecorder) UpdateShipmentStatus(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShipmentStatus", reflect.TypeOf((*MockQuerier)(nil).UpdateShipmentStatus), ctx, arg)
 }
 
+// UpdateStandingOrderSchedule mocks base method.
+func (m *MockQuerier) UpdateStandingOrderSchedule(ctx context.Context, arg UpdateStandingOrderScheduleParams) (StandingOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStandingOrderSchedule", ctx, arg)
+	ret0, _ := ret[0].(StandingOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStandingOrderSchedule indicates an expected call of UpdateStandingOrderSchedule.
+func (mr *MockQuerierMockRecorder) UpdateStandingOrderSchedule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStandingOrderSchedule", reflect.TypeOf((*MockQuerier)(nil).UpdateStandingOrderSchedule), ctx, arg)
+}
+
+// UpdateStandingOrderStatus mocks base method.
+func (m *MockQuerier) UpdateStandingOrderStatus(ctx context.Context, arg UpdateStandingOrderStatusParams) (StandingOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStandingOrderStatus", ctx, arg)
+	ret0, _ := ret[0].(StandingOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStandingOrderStatus indicates an expected call of UpdateStandingOrderStatus.
+func (mr *MockQuerierMockRecorder) UpdateStandingOrderStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStandingOrderStatus", reflect.TypeOf((*MockQuerier)(nil).UpdateStandingOrderStatus), ctx, arg)
+}
+
 // UpdateSubscriptionCancellation mocks base method.
 func (m *MockQuerier) UpdateSubscriptionCancellation(ctx context.Context, arg UpdateSubscriptionCancellationParams) (Subscription, error) {
 	m.ctrl.T.Helper()
@@ -4598,6 +7157,21 @@ func (mr *MockQuerierMockRecorder) UpdateSubscriptionCancellation(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriptionCancellation", reflect.TypeOf((*MockQuerier)(nil).UpdateSubscriptionCancellation), ctx, arg)
 }
 
+// UpdateSubscriptionItem mocks base method.
+func (m *MockQuerier) UpdateSubscriptionItem(ctx context.Context, arg UpdateSubscriptionItemParams) (SubscriptionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscriptionItem", ctx, arg)
+	ret0, _ := ret[0].(SubscriptionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscriptionItem indicates an expected call of UpdateSubscriptionItem.
+func (mr *MockQuerierMockRecorder) UpdateSubscriptionItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriptionItem", reflect.TypeOf((*MockQuerier)(nil).UpdateSubscriptionItem), ctx, arg)
+}
+
 // UpdateSubscriptionPauseResume mocks base method.
 func (m *MockQuerier) UpdateSubscriptionPauseResume(ctx context.Context, arg UpdateSubscriptionPauseResumeParams) (Subscription, error) {
 	m.ctrl.T.Helper()
@@ -4643,6 +7217,21 @@ func (mr *MockQuerierMockRecorder) UpdateSubscriptionScheduleEvent(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriptionScheduleEvent", reflect.TypeOf((*MockQuerier)(nil).UpdateSubscriptionScheduleEvent), ctx, arg)
 }
 
+// UpdateSubscriptionSettings mocks base method.
+func (m *MockQuerier) UpdateSubscriptionSettings(ctx context.Context, arg UpdateSubscriptionSettingsParams) (Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscriptionSettings", ctx, arg)
+	ret0, _ := ret[0].(Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscriptionSettings indicates an expected call of UpdateSubscriptionSettings.
+func (mr *MockQuerierMockRecorder) UpdateSubscriptionSettings(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriptionSettings", reflect.TypeOf((*MockQuerier)(nil).UpdateSubscriptionSettings), ctx, arg)
+}
+
 // UpdateSubscriptionStatus mocks base method.
 func (m *MockQuerier) UpdateSubscriptionStatus(ctx context.Context, arg UpdateSubscriptionStatusParams) (Subscription, error) {
 	m.ctrl.T.Helper()
@@ -4673,6 +7262,34 @@ func (mr *MockQuerierMockRecorder) UpdateTaxRate(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaxRate", reflect.TypeOf((*MockQuerier)(nil).UpdateTaxRate), ctx, arg)
 }
 
+// UpdateTenantBranding mocks base method.
+func (m *MockQuerier) UpdateTenantBranding(ctx context.Context, arg UpdateTenantBrandingParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTenantBranding", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTenantBranding indicates an expected call of UpdateTenantBranding.
+func (mr *MockQuerierMockRecorder) UpdateTenantBranding(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTenantBranding", reflect.TypeOf((*MockQuerier)(nil).UpdateTenantBranding), ctx, arg)
+}
+
+// UpdateTenantDunningSettings mocks base method.
+func (m *MockQuerier) UpdateTenantDunningSettings(ctx context.Context, arg UpdateTenantDunningSettingsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTenantDunningSettings", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTenantDunningSettings indicates an expected call of UpdateTenantDunningSettings.
+func (mr *MockQuerierMockRecorder) UpdateTenantDunningSettings(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTenantDunningSettings", reflect.TypeOf((*MockQuerier)(nil).UpdateTenantDunningSettings), ctx, arg)
+}
+
 // UpdateTenantPage mocks base method.
 func (m *MockQuerier) UpdateTenantPage(ctx context.Context, arg UpdateTenantPageParams) (TenantPage, error) {
 	m.ctrl.T.Helper()
@@ -4703,6 +7320,34 @@ func (mr *MockQuerierMockRecorder) UpdateTenantProfile(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTenantProfile", reflect.TypeOf((*MockQuerier)(nil).UpdateTenantProfile), ctx, arg)
 }
 
+// UpdateTenantRoastRestDays mocks base method.
+func (m *MockQuerier) UpdateTenantRoastRestDays(ctx context.Context, arg UpdateTenantRoastRestDaysParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTenantRoastRestDays", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTenantRoastRestDays indicates an expected call of UpdateTenantRoastRestDays.
+func (mr *MockQuerierMockRecorder) UpdateTenantRoastRestDays(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTenantRoastRestDays", reflect.TypeOf((*MockQuerier)(nil).UpdateTenantRoastRestDays), ctx, arg)
+}
+
+// UpdateTenantStandingOrderSettings mocks base method.
+func (m *MockQuerier) UpdateTenantStandingOrderSettings(ctx context.Context, arg UpdateTenantStandingOrderSettingsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTenantStandingOrderSettings", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTenantStandingOrderSettings indicates an expected call of UpdateTenantStandingOrderSettings.
+func (mr *MockQuerierMockRecorder) UpdateTenantStandingOrderSettings(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTenantStandingOrderSettings", reflect.TypeOf((*MockQuerier)(nil).UpdateTenantStandingOrderSettings), ctx, arg)
+}
+
 // UpdateTenantStripeCustomer mocks base method.
 func (m *MockQuerier) UpdateTenantStripeCustomer(ctx context.Context, arg UpdateTenantStripeCustomerParams) error {
 	m.ctrl.T.Helper()
@@ -4829,6 +7474,51 @@ func (mr *MockQuerierMockRecorder) UpdateWholesaleCustomer(ctx, arg any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWholesaleCustomer", reflect.TypeOf((*MockQuerier)(nil).UpdateWholesaleCustomer), ctx, arg)
 }
 
+// UpsertCustomerSKUOrderRule mocks base method.
+func (m *MockQuerier) UpsertCustomerSKUOrderRule(ctx context.Context, arg UpsertCustomerSKUOrderRuleParams) (CustomerSkuOrderRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCustomerSKUOrderRule", ctx, arg)
+	ret0, _ := ret[0].(CustomerSkuOrderRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertCustomerSKUOrderRule indicates an expected call of UpsertCustomerSKUOrderRule.
+func (mr *MockQuerierMockRecorder) UpsertCustomerSKUOrderRule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCustomerSKUOrderRule", reflect.TypeOf((*MockQuerier)(nil).UpsertCustomerSKUOrderRule), ctx, arg)
+}
+
+// UpsertEmailTemplate mocks base method.
+func (m *MockQuerier) UpsertEmailTemplate(ctx context.Context, arg UpsertEmailTemplateParams) (EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertEmailTemplate", ctx, arg)
+	ret0, _ := ret[0].(EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertEmailTemplate indicates an expected call of UpsertEmailTemplate.
+func (mr *MockQuerierMockRecorder) UpsertEmailTemplate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEmailTemplate", reflect.TypeOf((*MockQuerier)(nil).UpsertEmailTemplate), ctx, arg)
+}
+
+// UpsertOrderTemplate mocks base method.
+func (m *MockQuerier) UpsertOrderTemplate(ctx context.Context, arg UpsertOrderTemplateParams) (OrderTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertOrderTemplate", ctx, arg)
+	ret0, _ := ret[0].(OrderTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertOrderTemplate indicates an expected call of UpsertOrderTemplate.
+func (mr *MockQuerierMockRecorder) UpsertOrderTemplate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOrderTemplate", reflect.TypeOf((*MockQuerier)(nil).UpsertOrderTemplate), ctx, arg)
+}
+
 // UpsertPriceListEntry mocks base method.
 func (m *MockQuerier) UpsertPriceListEntry(ctx context.Context, arg UpsertPriceListEntryParams) error {
 	m.ctrl.T.Helper()
@@ -4843,6 +7533,49 @@ func (mr *MockQuerierMockRecorder) UpsertPriceListEntry(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPriceListEntry", reflect.TypeOf((*MockQuerier)(nil).UpsertPriceListEntry), ctx, arg)
 }
 
+// UpsertPriceListEntryTier mocks base method.
+func (m *MockQuerier) UpsertPriceListEntryTier(ctx context.Context, arg UpsertPriceListEntryTierParams) (PriceListEntryTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPriceListEntryTier", ctx, arg)
+	ret0, _ := ret[0].(PriceListEntryTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertPriceListEntryTier indicates an expected call of UpsertPriceListEntryTier.
+func (mr *MockQuerierMockRecorder) UpsertPriceListEntryTier(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPriceListEntryTier", reflect.TypeOf((*MockQuerier)(nil).UpsertPriceListEntryTier), ctx, arg)
+}
+
+// UpsertReviewHelpfulness mocks base method.
+func (m *MockQuerier) UpsertReviewHelpfulness(ctx context.Context, arg UpsertReviewHelpfulnessParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertReviewHelpfulness", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertReviewHelpfulness indicates an expected call of UpsertReviewHelpfulness.
+func (mr *MockQuerierMockRecorder) UpsertReviewHelpfulness(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertReviewHelpfulness", reflect.TypeOf((*MockQuerier)(nil).UpsertReviewHelpfulness), ctx, arg)
+}
+
+// UpsertStandingOrderItem mocks base method.
+func (m *MockQuerier) UpsertStandingOrderItem(ctx context.Context, arg UpsertStandingOrderItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertStandingOrderItem", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertStandingOrderItem indicates an expected call of UpsertStandingOrderItem.
+func (mr *MockQuerierMockRecorder) UpsertStandingOrderItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertStandingOrderItem", reflect.TypeOf((*MockQuerier)(nil).UpsertStandingOrderItem), ctx, arg)
+}
+
 // UpsertTenantPage mocks base method.
 func (m *MockQuerier) UpsertTenantPage(ctx context.Context, arg UpsertTenantPageParams) (TenantPage, error) {
 	m.ctrl.T.Helper()
@@ -4886,3 +7619,17 @@ func (mr *MockQuerierMockRecorder) VerifyUserEmail(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserEmail", reflect.TypeOf((*MockQuerier)(nil).VerifyUserEmail), ctx, id)
 }
+
+// VoidShipmentLabel mocks base method.
+func (m *MockQuerier) VoidShipmentLabel(ctx context.Context, arg VoidShipmentLabelParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidShipmentLabel", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VoidShipmentLabel indicates an expected call of VoidShipmentLabel.
+func (mr *MockQuerierMockRecorder) VoidShipmentLabel(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidShipmentLabel", reflect.TypeOf((*MockQuerier)(nil).VoidShipmentLabel), ctx, arg)
+}
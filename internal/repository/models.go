@@ -86,6 +86,20 @@ type CustomerAddress struct {
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
 }
 
+// Per-customer overrides of SKU wholesale quantity rules
+type CustomerSkuOrderRule struct {
+	ID           pgtype.UUID `json:"id"`
+	TenantID     pgtype.UUID `json:"tenant_id"`
+	UserID       pgtype.UUID `json:"user_id"`
+	ProductSkuID pgtype.UUID `json:"product_sku_id"`
+	// Minimum quantity per order line for this customer (NULL for no minimum)
+	MinQuantity pgtype.Int4 `json:"min_quantity"`
+	// Case pack for this customer (NULL to allow any quantity)
+	CasePack  pgtype.Int4        `json:"case_pack"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
 // Promotional discount codes
 type DiscountCode struct {
 	ID                    pgtype.UUID        `json:"id"`
@@ -148,6 +162,54 @@ type EmailVerificationToken struct {
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
+// Batch label purchases for multiple orders
+type FulfillmentBatch struct {
+	ID              pgtype.UUID `json:"id"`
+	TenantID        pgtype.UUID `json:"tenant_id"`
+	Status          string      `json:"status"`
+	OrderCount      int32       `json:"order_count"`
+	LabelsPurchased int32       `json:"labels_purchased"`
+	LabelsFailed    int32       `json:"labels_failed"`
+	// Storage key of the merged labels and packing slips PDF
+	DocumentStorageKey pgtype.Text        `json:"document_storage_key"`
+	ErrorMessage       pgtype.Text        `json:"error_message"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	CompletedAt        pgtype.Timestamptz `json:"completed_at"`
+}
+
+// Orders included in a fulfillment batch
+type FulfillmentBatchOrder struct {
+	ID           pgtype.UUID        `json:"id"`
+	TenantID     pgtype.UUID        `json:"tenant_id"`
+	BatchID      pgtype.UUID        `json:"batch_id"`
+	OrderID      pgtype.UUID        `json:"order_id"`
+	ShipmentID   pgtype.UUID        `json:"shipment_id"`
+	Position     int32              `json:"position"`
+	Status       string             `json:"status"`
+	ErrorMessage pgtype.Text        `json:"error_message"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Ledger of SKU stock changes with their reason
+type InventoryAdjustment struct {
+	ID           pgtype.UUID `json:"id"`
+	TenantID     pgtype.UUID `json:"tenant_id"`
+	ProductSkuID pgtype.UUID `json:"product_sku_id"`
+	// order: sold, refund_restock: returned by a refund, count: stock count, spoilage: written off, roast_receipt: bagged from a roast
+	Reason         string      `json:"reason"`
+	QuantityChange int32       `json:"quantity_change"`
+	QuantityAfter  int32       `json:"quantity_after"`
+	OrderID        pgtype.UUID `json:"order_id"`
+	RefundID       pgtype.UUID `json:"refund_id"`
+	RoastBatchID   pgtype.UUID `json:"roast_batch_id"`
+	// Operator who made a manual adjustment
+	OperatorID pgtype.UUID        `json:"operator_id"`
+	Note       pgtype.Text        `json:"note"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
 // Wholesale billing invoices
 type Invoice struct {
 	ID            pgtype.UUID `json:"id"`
@@ -270,6 +332,22 @@ type Job struct {
 	Metadata              []byte             `json:"metadata"`
 	CreatedAt             pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	HeartbeatAt           pgtype.Timestamptz `json:"heartbeat_at"`
+}
+
+// Recurring background jobs per tenant, or system-wide when tenant_id is NULL
+type JobSchedule struct {
+	ID        pgtype.UUID        `json:"id"`
+	TenantID  pgtype.UUID        `json:"tenant_id"`
+	JobType   string             `json:"job_type"`
+	CronSpec  string             `json:"cron_spec"`
+	Enabled   bool               `json:"enabled"`
+	LastRunAt pgtype.Timestamptz `json:"last_run_at"`
+	// When the job is next enqueued; a run missed while no scheduler was running is enqueued once on startup
+	NextRunAt pgtype.Timestamptz `json:"next_run_at"`
+	LastError pgtype.Text        `json:"last_error"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
 // Completed and failed jobs for analysis
@@ -388,6 +466,26 @@ type OrderStatusHistory struct {
 	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 }
 
+// Named wholesale order form quantities saved by a customer
+type OrderTemplate struct {
+	ID        pgtype.UUID        `json:"id"`
+	TenantID  pgtype.UUID        `json:"tenant_id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// SKUs and quantities in an order template; replaced whenever the template is saved
+type OrderTemplateItem struct {
+	ID              pgtype.UUID        `json:"id"`
+	TenantID        pgtype.UUID        `json:"tenant_id"`
+	OrderTemplateID pgtype.UUID        `json:"order_template_id"`
+	ProductSkuID    pgtype.UUID        `json:"product_sku_id"`
+	Quantity        int32              `json:"quantity"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
 // Secure password reset tokens with rate limiting
 type PasswordResetToken struct {
 	ID       pgtype.UUID `json:"id"`
@@ -487,6 +585,19 @@ type PriceListEntry struct {
 	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
 }
 
+// Quantity-break prices for a price list entry
+type PriceListEntryTier struct {
+	ID               pgtype.UUID `json:"id"`
+	TenantID         pgtype.UUID `json:"tenant_id"`
+	PriceListEntryID pgtype.UUID `json:"price_list_entry_id"`
+	// Cart line quantity at which this tier applies
+	MinQuantity int32 `json:"min_quantity"`
+	// Unit price at this quantity and above
+	PriceCents int32              `json:"price_cents"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
 // Coffee products (base offerings)
 type Product struct {
 	ID               pgtype.UUID `json:"id"`
@@ -519,6 +630,8 @@ type Product struct {
 	BaseProductID pgtype.UUID `json:"base_product_id"`
 	// The specific customer (user) this white-label product is restricted to
 	WhiteLabelCustomerID pgtype.UUID `json:"white_label_customer_id"`
+	// Percent of green weight lost when roasting
+	RoastLossPercent pgtype.Numeric `json:"roast_loss_percent"`
 }
 
 // Hierarchical product categories
@@ -579,6 +692,8 @@ type ProductReview struct {
 	IsVerifiedPurchase bool               `json:"is_verified_purchase"`
 	CreatedAt          pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	ReplyText          pgtype.Text        `json:"reply_text"`
+	RepliedAt          pgtype.Timestamptz `json:"replied_at"`
 }
 
 // Product variants by weight and grind
@@ -600,6 +715,12 @@ type ProductSku struct {
 	RequiresShipping  bool               `json:"requires_shipping"`
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	// Base product SKU whose inventory a white-label SKU draws from
+	BaseSkuID pgtype.UUID `json:"base_sku_id"`
+	// Minimum quantity per wholesale order line (NULL for no minimum)
+	WholesaleMinQuantity pgtype.Int4 `json:"wholesale_min_quantity"`
+	// Wholesale quantities must be a multiple of this (NULL to allow any quantity)
+	WholesaleCasePack pgtype.Int4 `json:"wholesale_case_pack"`
 }
 
 // Flexible product tags
@@ -619,6 +740,35 @@ type ProductTagAssignment struct {
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
+// Refunds issued against order payments
+type Refund struct {
+	ID               pgtype.UUID `json:"id"`
+	TenantID         pgtype.UUID `json:"tenant_id"`
+	OrderID          pgtype.UUID `json:"order_id"`
+	PaymentID        pgtype.UUID `json:"payment_id"`
+	Provider         string      `json:"provider"`
+	ProviderRefundID string      `json:"provider_refund_id"`
+	AmountCents      int32       `json:"amount_cents"`
+	Reason           pgtype.Text `json:"reason"`
+	Note             pgtype.Text `json:"note"`
+	Status           string      `json:"status"`
+	// admin: issued from the admin dashboard, provider: issued outside the app and received via webhook
+	Source    string             `json:"source"`
+	Restocked bool               `json:"restocked"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// Order line items covered by a refund
+type RefundItem struct {
+	ID          pgtype.UUID        `json:"id"`
+	TenantID    pgtype.UUID        `json:"tenant_id"`
+	RefundID    pgtype.UUID        `json:"refund_id"`
+	OrderItemID pgtype.UUID        `json:"order_item_id"`
+	Quantity    int32              `json:"quantity"`
+	AmountCents int32              `json:"amount_cents"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
 // User votes on review helpfulness
 type ReviewHelpfulness struct {
 	ID        pgtype.UUID        `json:"id"`
@@ -629,6 +779,44 @@ type ReviewHelpfulness struct {
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
+// Review request emails sent after delivery
+type ReviewRequest struct {
+	ID       pgtype.UUID        `json:"id"`
+	TenantID pgtype.UUID        `json:"tenant_id"`
+	OrderID  pgtype.UUID        `json:"order_id"`
+	SentAt   pgtype.Timestamptz `json:"sent_at"`
+}
+
+// Planned and completed roasts per product
+type RoastBatch struct {
+	ID         pgtype.UUID `json:"id"`
+	TenantID   pgtype.UUID `json:"tenant_id"`
+	ProductID  pgtype.UUID `json:"product_id"`
+	Status     string      `json:"status"`
+	PlannedFor pgtype.Date `json:"planned_for"`
+	RoastedOn  pgtype.Date `json:"roasted_on"`
+	// Green coffee lot or purchase reference
+	GreenLot           pgtype.Text        `json:"green_lot"`
+	GreenWeightGrams   pgtype.Int4        `json:"green_weight_grams"`
+	RoastedWeightGrams pgtype.Int4        `json:"roasted_weight_grams"`
+	Notes              pgtype.Text        `json:"notes"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Order items and subscription renewals allocated to a roast batch
+type RoastBatchAllocation struct {
+	ID                 pgtype.UUID        `json:"id"`
+	TenantID           pgtype.UUID        `json:"tenant_id"`
+	RoastBatchID       pgtype.UUID        `json:"roast_batch_id"`
+	ProductSkuID       pgtype.UUID        `json:"product_sku_id"`
+	Quantity           int32              `json:"quantity"`
+	OrderItemID        pgtype.UUID        `json:"order_item_id"`
+	SubscriptionItemID pgtype.UUID        `json:"subscription_item_id"`
+	RenewsOn           pgtype.Date        `json:"renews_on"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+}
+
 // Sessions for cart persistence and user authentication
 type Session struct {
 	ID pgtype.UUID `json:"id"`
@@ -669,6 +857,14 @@ type Shipment struct {
 	FailedAt           pgtype.Timestamptz `json:"failed_at"`
 	CreatedAt          pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	// Storage key of the label file copied from the shipping provider
+	LabelStorageKey pgtype.Text `json:"label_storage_key"`
+	// When the purchased label was voided with the shipping provider
+	LabelVoidedAt pgtype.Timestamptz `json:"label_voided_at"`
+	// Provider-hosted PNG/JPEG rendition of the label
+	LabelImageUrl pgtype.Text `json:"label_image_url"`
+	// When tracking was last refreshed from the provider
+	TrackingCheckedAt pgtype.Timestamptz `json:"tracking_checked_at"`
 }
 
 // Links order items to shipments (supports partial shipments)
@@ -731,6 +927,40 @@ type ShippingRate struct {
 	CreatedAt             pgtype.Timestamptz `json:"created_at"`
 }
 
+// Recurring wholesale orders placed on account
+type StandingOrder struct {
+	ID            pgtype.UUID `json:"id"`
+	TenantID      pgtype.UUID `json:"tenant_id"`
+	UserID        pgtype.UUID `json:"user_id"`
+	Name          string      `json:"name"`
+	Status        string      `json:"status"`
+	IntervalWeeks int32       `json:"interval_weeks"`
+	// ISO weekday of delivery: 1 = Monday, 7 = Sunday
+	DeliveryWeekday int32 `json:"delivery_weekday"`
+	// Delivery the next generated order is for
+	NextDeliveryDate  pgtype.Date `json:"next_delivery_date"`
+	ShippingAddressID pgtype.UUID `json:"shipping_address_id"`
+	// Delivery date the last reminder email was sent for
+	RemindedForDate pgtype.Date        `json:"reminded_for_date"`
+	LastOrderID     pgtype.UUID        `json:"last_order_id"`
+	LastGeneratedAt pgtype.Timestamptz `json:"last_generated_at"`
+	// Why the last generation failed, cleared on success
+	LastError pgtype.Text        `json:"last_error"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// SKUs and quantities in a standing order; priced when each order is generated
+type StandingOrderItem struct {
+	ID              pgtype.UUID        `json:"id"`
+	TenantID        pgtype.UUID        `json:"tenant_id"`
+	StandingOrderID pgtype.UUID        `json:"standing_order_id"`
+	ProductSkuID    pgtype.UUID        `json:"product_sku_id"`
+	Quantity        int32              `json:"quantity"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
 // Customer subscription instances
 type Subscription struct {
 	ID                 pgtype.UUID `json:"id"`
@@ -763,6 +993,24 @@ type Subscription struct {
 	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
 }
 
+// Payment retries and reminders for a failed subscription invoice
+type SubscriptionDunning struct {
+	ID                pgtype.UUID        `json:"id"`
+	TenantID          pgtype.UUID        `json:"tenant_id"`
+	SubscriptionID    pgtype.UUID        `json:"subscription_id"`
+	ProviderInvoiceID string             `json:"provider_invoice_id"`
+	Status            string             `json:"status"`
+	AttemptCount      int32              `json:"attempt_count"`
+	NextAttemptAt     pgtype.Timestamptz `json:"next_attempt_at"`
+	LastAttemptAt     pgtype.Timestamptz `json:"last_attempt_at"`
+	LastFailureReason pgtype.Text        `json:"last_failure_reason"`
+	UpdateTokenHash   pgtype.Text        `json:"update_token_hash"`
+	StartedAt         pgtype.Timestamptz `json:"started_at"`
+	ResolvedAt        pgtype.Timestamptz `json:"resolved_at"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+}
+
 // Products included in subscriptions
 type SubscriptionItem struct {
 	ID             pgtype.UUID        `json:"id"`
@@ -981,6 +1229,8 @@ type User struct {
 	BillingCycleDay pgtype.Int4 `json:"billing_cycle_day"`
 	// Your internal customer reference (visible to customer)
 	CustomerReference pgtype.Text `json:"customer_reference"`
+	// Maximum outstanding balance in cents when ordering on account (NULL for no limit)
+	CreditLimitCents pgtype.Int4 `json:"credit_limit_cents"`
 }
 
 // Price list assignment to users
@@ -11,6 +11,30 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const claimCartForOrder = `-- name: ClaimCartForOrder :one
+UPDATE carts
+SET status = 'converted',
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2
+  AND status <> 'converted'
+RETURNING id
+`
+
+type ClaimCartForOrderParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	ID       pgtype.UUID `json:"id"`
+}
+
+// Marks cart as converted unless another order already did
+// Returns no rows when the cart was already converted
+func (q *Queries) ClaimCartForOrder(ctx context.Context, arg ClaimCartForOrderParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, claimCartForOrder, arg.TenantID, arg.ID)
+	var id pgtype.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const countOrders = `-- name: CountOrders :one
 SELECT COUNT(*)
 FROM orders
@@ -63,9 +87,10 @@ INSERT INTO orders (
     customer_notes,
     subscription_id,
     customer_po_number,
-    requested_delivery_date
+    requested_delivery_date,
+    discount_cents
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
 )
 RETURNING id, tenant_id, user_id, order_number, order_type, status, subtotal_cents, tax_cents, shipping_cents, discount_cents, total_cents, currency, payment_id, payment_status, shipping_address_id, billing_address_id, shipping_method, shipping_carrier, customer_notes, internal_notes, fulfillment_status, cart_id, subscription_id, metadata, paid_at, shipped_at, delivered_at, cancelled_at, created_at, updated_at, customer_po_number, requested_delivery_date
 `
@@ -88,6 +113,7 @@ type CreateOrderParams struct {
 	SubscriptionID        pgtype.UUID `json:"subscription_id"`
 	CustomerPoNumber      pgtype.Text `json:"customer_po_number"`
 	RequestedDeliveryDate pgtype.Date `json:"requested_delivery_date"`
+	DiscountCents         int32       `json:"discount_cents"`
 }
 
 // Creates a new order record with all required fields
@@ -111,6 +137,7 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order
 		arg.SubscriptionID,
 		arg.CustomerPoNumber,
 		arg.RequestedDeliveryDate,
+		arg.DiscountCents,
 	)
 	var i Order
 	err := row.Scan(
@@ -225,7 +252,7 @@ INSERT INTO shipments (
 ) VALUES (
     $1, $2, $3, $4, $5, 'pending'
 )
-RETURNING id, tenant_id, order_id, shipment_number, shipping_method_id, carrier, service_name, tracking_number, tracking_url, status, shipping_cost_cents, label_cost_cents, weight_grams, length_cm, width_cm, height_cm, provider, provider_shipment_id, provider_label_id, label_url, metadata, label_created_at, shipped_at, delivered_at, failed_at, created_at, updated_at
+RETURNING id, tenant_id, order_id, shipment_number, shipping_method_id, carrier, service_name, tracking_number, tracking_url, status, shipping_cost_cents, label_cost_cents, weight_grams, length_cm, width_cm, height_cm, provider, provider_shipment_id, provider_label_id, label_url, metadata, label_created_at, shipped_at, delivered_at, failed_at, created_at, updated_at, label_storage_key, label_voided_at, label_image_url, tracking_checked_at
 `
 
 type CreateShipmentParams struct {
@@ -274,6 +301,10 @@ func (q *Queries) CreateShipment(ctx context.Context, arg CreateShipmentParams)
 		&i.FailedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LabelStorageKey,
+		&i.LabelVoidedAt,
+		&i.LabelImageUrl,
+		&i.TrackingCheckedAt,
 	)
 	return i, err
 }
@@ -320,24 +351,49 @@ func (q *Queries) CreateShipmentItem(ctx context.Context, arg CreateShipmentItem
 }
 
 const decrementSKUStock = `-- name: DecrementSKUStock :exec
-UPDATE product_skus
-SET inventory_quantity = inventory_quantity - $3,
-    updated_at = NOW()
-WHERE tenant_id = $1
-  AND id = $2
-  AND inventory_quantity >= $3
+WITH updated AS (
+    UPDATE product_skus
+    SET inventory_quantity = inventory_quantity - $3,
+        updated_at = NOW()
+    WHERE tenant_id = $1
+      AND id = (
+          SELECT COALESCE(base_sku_id, id)
+          FROM product_skus
+          WHERE tenant_id = $1
+            AND id = $2
+      )
+      AND inventory_quantity >= $3  -- Ensures sufficient stock
+    RETURNING tenant_id, id, inventory_quantity
+)
+INSERT INTO inventory_adjustments (
+    tenant_id,
+    product_sku_id,
+    reason,
+    quantity_change,
+    quantity_after,
+    order_id
+)
+SELECT tenant_id, id, 'order', -$3::integer, inventory_quantity, $4
+FROM updated
 `
 
 type DecrementSKUStockParams struct {
 	TenantID          pgtype.UUID `json:"tenant_id"`
 	ID                pgtype.UUID `json:"id"`
 	InventoryQuantity int32       `json:"inventory_quantity"`
+	OrderID           pgtype.UUID `json:"order_id"`
 }
 
-// Decrements inventory for a SKU after order placement
+// Decrements inventory for a SKU after order placement and records the sale
+// in the inventory ledger. White-label SKUs sell from their base SKU's stock.
 // Uses optimistic locking to prevent overselling
 func (q *Queries) DecrementSKUStock(ctx context.Context, arg DecrementSKUStockParams) error {
-	_, err := q.db.Exec(ctx, decrementSKUStock, arg.TenantID, arg.ID, arg.InventoryQuantity)
+	_, err := q.db.Exec(ctx, decrementSKUStock,
+		arg.TenantID,
+		arg.ID,
+		arg.InventoryQuantity,
+		arg.OrderID,
+	)
 	return err
 }
 
@@ -704,6 +760,7 @@ SELECT
     o.subtotal_cents,
     o.shipping_cents,
     o.tax_cents,
+    o.discount_cents,
     o.total_cents,
     o.currency,
     o.customer_notes,
@@ -755,6 +812,7 @@ type GetOrderWithDetailsRow struct {
 	SubtotalCents        int32              `json:"subtotal_cents"`
 	ShippingCents        int32              `json:"shipping_cents"`
 	TaxCents             int32              `json:"tax_cents"`
+	DiscountCents        int32              `json:"discount_cents"`
 	TotalCents           int32              `json:"total_cents"`
 	Currency             string             `json:"currency"`
 	CustomerNotes        pgtype.Text        `json:"customer_notes"`
@@ -797,6 +855,7 @@ func (q *Queries) GetOrderWithDetails(ctx context.Context, arg GetOrderWithDetai
 		&i.SubtotalCents,
 		&i.ShippingCents,
 		&i.TaxCents,
+		&i.DiscountCents,
 		&i.TotalCents,
 		&i.Currency,
 		&i.CustomerNotes,
@@ -1082,7 +1141,7 @@ func (q *Queries) GetShipmentItems(ctx context.Context, shipmentID pgtype.UUID)
 }
 
 const getShipmentsByOrderID = `-- name: GetShipmentsByOrderID :many
-SELECT id, tenant_id, order_id, shipment_number, shipping_method_id, carrier, service_name, tracking_number, tracking_url, status, shipping_cost_cents, label_cost_cents, weight_grams, length_cm, width_cm, height_cm, provider, provider_shipment_id, provider_label_id, label_url, metadata, label_created_at, shipped_at, delivered_at, failed_at, created_at, updated_at FROM shipments
+SELECT id, tenant_id, order_id, shipment_number, shipping_method_id, carrier, service_name, tracking_number, tracking_url, status, shipping_cost_cents, label_cost_cents, weight_grams, length_cm, width_cm, height_cm, provider, provider_shipment_id, provider_label_id, label_url, metadata, label_created_at, shipped_at, delivered_at, failed_at, created_at, updated_at, label_storage_key, label_voided_at, label_image_url, tracking_checked_at FROM shipments
 WHERE order_id = $1
 ORDER BY created_at DESC
 `
@@ -1125,6 +1184,10 @@ func (q *Queries) GetShipmentsByOrderID(ctx context.Context, orderID pgtype.UUID
 			&i.FailedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.LabelStorageKey,
+			&i.LabelVoidedAt,
+			&i.LabelImageUrl,
+			&i.TrackingCheckedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1590,6 +1653,81 @@ func (q *Queries) ListOrdersForUser(ctx context.Context, arg ListOrdersForUserPa
 	return items, nil
 }
 
+const listReorderItems = `-- name: ListReorderItems :many
+SELECT
+    oi.product_sku_id,
+    oi.product_name,
+    oi.sku,
+    oi.quantity,
+    oi.unit_price_cents,
+    (ps.is_active AND p.status = 'active' AND (
+        (p.is_white_label = FALSE AND (p.visibility = 'public' OR (p.visibility = 'wholesale_only' AND u.account_type = 'wholesale')))
+        OR
+        (p.is_white_label = TRUE AND p.white_label_customer_id = o.user_id)
+    ))::boolean AS available,
+    stock.inventory_quantity,
+    stock.inventory_policy
+FROM orders o
+INNER JOIN users u ON u.id = o.user_id
+INNER JOIN order_items oi ON oi.order_id = o.id
+INNER JOIN product_skus ps ON ps.id = oi.product_sku_id
+INNER JOIN products p ON p.id = ps.product_id
+INNER JOIN product_skus stock ON stock.id = COALESCE(ps.base_sku_id, ps.id)
+WHERE o.tenant_id = $1
+  AND o.id = $2
+  AND o.user_id = $3
+ORDER BY oi.created_at ASC
+`
+
+type ListReorderItemsParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	ID       pgtype.UUID `json:"id"`
+	UserID   pgtype.UUID `json:"user_id"`
+}
+
+type ListReorderItemsRow struct {
+	ProductSkuID      pgtype.UUID `json:"product_sku_id"`
+	ProductName       string      `json:"product_name"`
+	Sku               string      `json:"sku"`
+	Quantity          int32       `json:"quantity"`
+	UnitPriceCents    int32       `json:"unit_price_cents"`
+	Available         bool        `json:"available"`
+	InventoryQuantity int32       `json:"inventory_quantity"`
+	InventoryPolicy   string      `json:"inventory_policy"`
+}
+
+// Items from a customer's past order with what can be ordered today. A SKU is
+// available while it and its product are active and the customer can still
+// see the product; white-label SKUs use the stock of their base SKU.
+func (q *Queries) ListReorderItems(ctx context.Context, arg ListReorderItemsParams) ([]ListReorderItemsRow, error) {
+	rows, err := q.db.Query(ctx, listReorderItems, arg.TenantID, arg.ID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListReorderItemsRow{}
+	for rows.Next() {
+		var i ListReorderItemsRow
+		if err := rows.Scan(
+			&i.ProductSkuID,
+			&i.ProductName,
+			&i.Sku,
+			&i.Quantity,
+			&i.UnitPriceCents,
+			&i.Available,
+			&i.InventoryQuantity,
+			&i.InventoryPolicy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listWholesaleOrders = `-- name: ListWholesaleOrders :many
 
 SELECT
@@ -1705,6 +1843,39 @@ func (q *Queries) RecalculateOrderFulfillmentStatus(ctx context.Context, arg Rec
 	return err
 }
 
+const setOrderStatusChangeReason = `-- name: SetOrderStatusChangeReason :exec
+UPDATE order_status_history
+SET change_reason = $4
+WHERE id = (
+    SELECT h.id
+    FROM order_status_history h
+    WHERE h.tenant_id = $1
+      AND h.order_id = $2
+      AND h.to_status = $3
+    ORDER BY h.created_at DESC
+    LIMIT 1
+)
+`
+
+type SetOrderStatusChangeReasonParams struct {
+	TenantID     pgtype.UUID `json:"tenant_id"`
+	OrderID      pgtype.UUID `json:"order_id"`
+	ToStatus     string      `json:"to_status"`
+	ChangeReason pgtype.Text `json:"change_reason"`
+}
+
+// Records why an order's status changed on the history row the status
+// trigger wrote for it
+func (q *Queries) SetOrderStatusChangeReason(ctx context.Context, arg SetOrderStatusChangeReasonParams) error {
+	_, err := q.db.Exec(ctx, setOrderStatusChangeReason,
+		arg.TenantID,
+		arg.OrderID,
+		arg.ToStatus,
+		arg.ChangeReason,
+	)
+	return err
+}
+
 const updateCartStatus = `-- name: UpdateCartStatus :exec
 
 UPDATE carts
@@ -137,6 +137,27 @@ func (q *Queries) DeletePriceListEntry(ctx context.Context, arg DeletePriceListE
 	return err
 }
 
+const deletePriceListEntryTier = `-- name: DeletePriceListEntryTier :exec
+DELETE FROM price_list_entry_tiers t
+USING price_list_entries ple
+WHERE ple.id = t.price_list_entry_id
+  AND t.tenant_id = $1
+  AND ple.price_list_id = $2
+  AND t.id = $3
+`
+
+type DeletePriceListEntryTierParams struct {
+	TenantID    pgtype.UUID `json:"tenant_id"`
+	PriceListID pgtype.UUID `json:"price_list_id"`
+	ID          pgtype.UUID `json:"id"`
+}
+
+// Delete a quantity-break price from a price list
+func (q *Queries) DeletePriceListEntryTier(ctx context.Context, arg DeletePriceListEntryTierParams) error {
+	_, err := q.db.Exec(ctx, deletePriceListEntryTier, arg.TenantID, arg.PriceListID, arg.ID)
+	return err
+}
+
 const getDefaultPriceList = `-- name: GetDefaultPriceList :one
 SELECT
     id,
@@ -519,6 +540,110 @@ func (q *Queries) ListPriceListEntries(ctx context.Context, priceListID pgtype.U
 	return items, nil
 }
 
+const listPriceListTiers = `-- name: ListPriceListTiers :many
+SELECT
+    t.id,
+    t.price_list_entry_id,
+    ple.product_sku_id,
+    t.min_quantity,
+    t.price_cents,
+    ps.sku,
+    p.name as product_name
+FROM price_list_entry_tiers t
+INNER JOIN price_list_entries ple ON ple.id = t.price_list_entry_id
+INNER JOIN product_skus ps ON ps.id = ple.product_sku_id
+INNER JOIN products p ON p.id = ps.product_id
+WHERE t.tenant_id = $1
+  AND ple.price_list_id = $2
+ORDER BY p.name ASC, ps.sku ASC, t.min_quantity ASC
+`
+
+type ListPriceListTiersParams struct {
+	TenantID    pgtype.UUID `json:"tenant_id"`
+	PriceListID pgtype.UUID `json:"price_list_id"`
+}
+
+type ListPriceListTiersRow struct {
+	ID               pgtype.UUID `json:"id"`
+	PriceListEntryID pgtype.UUID `json:"price_list_entry_id"`
+	ProductSkuID     pgtype.UUID `json:"product_sku_id"`
+	MinQuantity      int32       `json:"min_quantity"`
+	PriceCents       int32       `json:"price_cents"`
+	Sku              string      `json:"sku"`
+	ProductName      string      `json:"product_name"`
+}
+
+// List the quantity-break prices of every entry on a price list
+func (q *Queries) ListPriceListTiers(ctx context.Context, arg ListPriceListTiersParams) ([]ListPriceListTiersRow, error) {
+	rows, err := q.db.Query(ctx, listPriceListTiers, arg.TenantID, arg.PriceListID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPriceListTiersRow{}
+	for rows.Next() {
+		var i ListPriceListTiersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PriceListEntryID,
+			&i.ProductSkuID,
+			&i.MinQuantity,
+			&i.PriceCents,
+			&i.Sku,
+			&i.ProductName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPriceTiersForEntry = `-- name: ListPriceTiersForEntry :many
+SELECT id, tenant_id, price_list_entry_id, min_quantity, price_cents, created_at, updated_at
+FROM price_list_entry_tiers
+WHERE tenant_id = $1
+  AND price_list_entry_id = $2
+ORDER BY min_quantity ASC
+`
+
+type ListPriceTiersForEntryParams struct {
+	TenantID         pgtype.UUID `json:"tenant_id"`
+	PriceListEntryID pgtype.UUID `json:"price_list_entry_id"`
+}
+
+// Get the quantity-break prices for a price list entry, lowest quantity first
+func (q *Queries) ListPriceTiersForEntry(ctx context.Context, arg ListPriceTiersForEntryParams) ([]PriceListEntryTier, error) {
+	rows, err := q.db.Query(ctx, listPriceTiersForEntry, arg.TenantID, arg.PriceListEntryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PriceListEntryTier{}
+	for rows.Next() {
+		var i PriceListEntryTier
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.PriceListEntryID,
+			&i.MinQuantity,
+			&i.PriceCents,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updatePriceList = `-- name: UpdatePriceList :one
 UPDATE price_lists
 SET
@@ -646,3 +771,53 @@ func (q *Queries) UpsertPriceListEntry(ctx context.Context, arg UpsertPriceListE
 	)
 	return err
 }
+
+const upsertPriceListEntryTier = `-- name: UpsertPriceListEntryTier :one
+INSERT INTO price_list_entry_tiers (
+    tenant_id,
+    price_list_entry_id,
+    min_quantity,
+    price_cents
+)
+SELECT ple.tenant_id, ple.id, $1, $2
+FROM price_list_entries ple
+WHERE ple.tenant_id = $3
+  AND ple.price_list_id = $4
+  AND ple.id = $5
+ON CONFLICT (price_list_entry_id, min_quantity) DO UPDATE
+SET
+    price_cents = EXCLUDED.price_cents,
+    updated_at = NOW()
+RETURNING id, tenant_id, price_list_entry_id, min_quantity, price_cents, created_at, updated_at
+`
+
+type UpsertPriceListEntryTierParams struct {
+	MinQuantity      int32       `json:"min_quantity"`
+	PriceCents       int32       `json:"price_cents"`
+	TenantID         pgtype.UUID `json:"tenant_id"`
+	PriceListID      pgtype.UUID `json:"price_list_id"`
+	PriceListEntryID pgtype.UUID `json:"price_list_entry_id"`
+}
+
+// Create or update the quantity-break price at a quantity. Returns no row if
+// the entry isn't on the tenant's price list.
+func (q *Queries) UpsertPriceListEntryTier(ctx context.Context, arg UpsertPriceListEntryTierParams) (PriceListEntryTier, error) {
+	row := q.db.QueryRow(ctx, upsertPriceListEntryTier,
+		arg.MinQuantity,
+		arg.PriceCents,
+		arg.TenantID,
+		arg.PriceListID,
+		arg.PriceListEntryID,
+	)
+	var i PriceListEntryTier
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.PriceListEntryID,
+		&i.MinQuantity,
+		&i.PriceCents,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -35,7 +35,7 @@ INSERT INTO products (
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19
 )
-RETURNING id, tenant_id, name, slug, description, short_description, origin, region, producer, process, roast_level, elevation_min, elevation_max, variety, harvest_year, tasting_notes, status, visibility, meta_title, meta_description, sort_order, created_at, updated_at, is_white_label, base_product_id, white_label_customer_id
+RETURNING id, tenant_id, name, slug, description, short_description, origin, region, producer, process, roast_level, elevation_min, elevation_max, variety, harvest_year, tasting_notes, status, visibility, meta_title, meta_description, sort_order, created_at, updated_at, is_white_label, base_product_id, white_label_customer_id, roast_loss_percent
 `
 
 type CreateProductParams struct {
@@ -111,6 +111,7 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.IsWhiteLabel,
 		&i.BaseProductID,
 		&i.WhiteLabelCustomerID,
+		&i.RoastLossPercent,
 	)
 	return i, err
 }
@@ -188,27 +189,31 @@ INSERT INTO product_skus (
     low_stock_threshold,
     is_active,
     weight_grams,
-    requires_shipping
+    requires_shipping,
+    wholesale_min_quantity,
+    wholesale_case_pack
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 )
-RETURNING id, tenant_id, product_id, sku, weight_value, weight_unit, grind, base_price_cents, inventory_quantity, inventory_policy, low_stock_threshold, is_active, weight_grams, requires_shipping, created_at, updated_at
+RETURNING id, tenant_id, product_id, sku, weight_value, weight_unit, grind, base_price_cents, inventory_quantity, inventory_policy, low_stock_threshold, is_active, weight_grams, requires_shipping, created_at, updated_at, base_sku_id, wholesale_min_quantity, wholesale_case_pack
 `
 
 type CreateProductSKUParams struct {
-	TenantID          pgtype.UUID    `json:"tenant_id"`
-	ProductID         pgtype.UUID    `json:"product_id"`
-	Sku               string         `json:"sku"`
-	WeightValue       pgtype.Numeric `json:"weight_value"`
-	WeightUnit        string         `json:"weight_unit"`
-	Grind             string         `json:"grind"`
-	BasePriceCents    int32          `json:"base_price_cents"`
-	InventoryQuantity int32          `json:"inventory_quantity"`
-	InventoryPolicy   string         `json:"inventory_policy"`
-	LowStockThreshold pgtype.Int4    `json:"low_stock_threshold"`
-	IsActive          bool           `json:"is_active"`
-	WeightGrams       pgtype.Int4    `json:"weight_grams"`
-	RequiresShipping  bool           `json:"requires_shipping"`
+	TenantID             pgtype.UUID    `json:"tenant_id"`
+	ProductID            pgtype.UUID    `json:"product_id"`
+	Sku                  string         `json:"sku"`
+	WeightValue          pgtype.Numeric `json:"weight_value"`
+	WeightUnit           string         `json:"weight_unit"`
+	Grind                string         `json:"grind"`
+	BasePriceCents       int32          `json:"base_price_cents"`
+	InventoryQuantity    int32          `json:"inventory_quantity"`
+	InventoryPolicy      string         `json:"inventory_policy"`
+	LowStockThreshold    pgtype.Int4    `json:"low_stock_threshold"`
+	IsActive             bool           `json:"is_active"`
+	WeightGrams          pgtype.Int4    `json:"weight_grams"`
+	RequiresShipping     bool           `json:"requires_shipping"`
+	WholesaleMinQuantity pgtype.Int4    `json:"wholesale_min_quantity"`
+	WholesaleCasePack    pgtype.Int4    `json:"wholesale_case_pack"`
 }
 
 // Create a new product SKU
@@ -227,6 +232,8 @@ func (q *Queries) CreateProductSKU(ctx context.Context, arg CreateProductSKUPara
 		arg.IsActive,
 		arg.WeightGrams,
 		arg.RequiresShipping,
+		arg.WholesaleMinQuantity,
+		arg.WholesaleCasePack,
 	)
 	var i ProductSku
 	err := row.Scan(
@@ -246,6 +253,95 @@ func (q *Queries) CreateProductSKU(ctx context.Context, arg CreateProductSKUPara
 		&i.RequiresShipping,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BaseSkuID,
+		&i.WholesaleMinQuantity,
+		&i.WholesaleCasePack,
+	)
+	return i, err
+}
+
+const createWhiteLabelSKU = `-- name: CreateWhiteLabelSKU :one
+INSERT INTO product_skus (
+    tenant_id,
+    product_id,
+    sku,
+    weight_value,
+    weight_unit,
+    grind,
+    base_price_cents,
+    inventory_quantity,
+    inventory_policy,
+    low_stock_threshold,
+    is_active,
+    weight_grams,
+    requires_shipping,
+    base_sku_id,
+    wholesale_min_quantity,
+    wholesale_case_pack
+)
+SELECT
+    base.tenant_id,
+    $2,
+    $3,
+    base.weight_value,
+    base.weight_unit,
+    base.grind,
+    $4,
+    0,
+    base.inventory_policy,
+    base.low_stock_threshold,
+    TRUE,
+    base.weight_grams,
+    base.requires_shipping,
+    base.id,
+    base.wholesale_min_quantity,
+    base.wholesale_case_pack
+FROM product_skus base
+WHERE base.tenant_id = $1
+  AND base.id = $5
+RETURNING id, tenant_id, product_id, sku, weight_value, weight_unit, grind, base_price_cents, inventory_quantity, inventory_policy, low_stock_threshold, is_active, weight_grams, requires_shipping, created_at, updated_at, base_sku_id, wholesale_min_quantity, wholesale_case_pack
+`
+
+type CreateWhiteLabelSKUParams struct {
+	TenantID       pgtype.UUID `json:"tenant_id"`
+	ProductID      pgtype.UUID `json:"product_id"`
+	Sku            string      `json:"sku"`
+	BasePriceCents int32       `json:"base_price_cents"`
+	BaseSkuID      pgtype.UUID `json:"base_sku_id"`
+}
+
+// Clone a base product SKU into a white-label product. The clone keeps the
+// bag size, grind, stock policy and wholesale quantity rules, and draws from
+// the base SKU's inventory.
+func (q *Queries) CreateWhiteLabelSKU(ctx context.Context, arg CreateWhiteLabelSKUParams) (ProductSku, error) {
+	row := q.db.QueryRow(ctx, createWhiteLabelSKU,
+		arg.TenantID,
+		arg.ProductID,
+		arg.Sku,
+		arg.BasePriceCents,
+		arg.BaseSkuID,
+	)
+	var i ProductSku
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.ProductID,
+		&i.Sku,
+		&i.WeightValue,
+		&i.WeightUnit,
+		&i.Grind,
+		&i.BasePriceCents,
+		&i.InventoryQuantity,
+		&i.InventoryPolicy,
+		&i.LowStockThreshold,
+		&i.IsActive,
+		&i.WeightGrams,
+		&i.RequiresShipping,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BaseSkuID,
+		&i.WholesaleMinQuantity,
+		&i.WholesaleCasePack,
 	)
 	return i, err
 }
@@ -308,7 +404,7 @@ func (q *Queries) DeleteProductSKU(ctx context.Context, arg DeleteProductSKUPara
 }
 
 const getBaseProductForWhiteLabel = `-- name: GetBaseProductForWhiteLabel :one
-SELECT base.id, base.tenant_id, base.name, base.slug, base.description, base.short_description, base.origin, base.region, base.producer, base.process, base.roast_level, base.elevation_min, base.elevation_max, base.variety, base.harvest_year, base.tasting_notes, base.status, base.visibility, base.meta_title, base.meta_description, base.sort_order, base.created_at, base.updated_at, base.is_white_label, base.base_product_id, base.white_label_customer_id
+SELECT base.id, base.tenant_id, base.name, base.slug, base.description, base.short_description, base.origin, base.region, base.producer, base.process, base.roast_level, base.elevation_min, base.elevation_max, base.variety, base.harvest_year, base.tasting_notes, base.status, base.visibility, base.meta_title, base.meta_description, base.sort_order, base.created_at, base.updated_at, base.is_white_label, base.base_product_id, base.white_label_customer_id, base.roast_loss_percent
 FROM products p
 INNER JOIN products base ON base.id = p.base_product_id
 WHERE p.id = $1
@@ -346,6 +442,7 @@ func (q *Queries) GetBaseProductForWhiteLabel(ctx context.Context, id pgtype.UUI
 		&i.IsWhiteLabel,
 		&i.BaseProductID,
 		&i.WhiteLabelCustomerID,
+		&i.RoastLossPercent,
 	)
 	return i, err
 }
@@ -405,7 +502,7 @@ func (q *Queries) GetPrimaryImage(ctx context.Context, productID pgtype.UUID) (P
 }
 
 const getProductByID = `-- name: GetProductByID :one
-SELECT id, tenant_id, name, slug, description, short_description, origin, region, producer, process, roast_level, elevation_min, elevation_max, variety, harvest_year, tasting_notes, status, visibility, meta_title, meta_description, sort_order, created_at, updated_at, is_white_label, base_product_id, white_label_customer_id
+SELECT id, tenant_id, name, slug, description, short_description, origin, region, producer, process, roast_level, elevation_min, elevation_max, variety, harvest_year, tasting_notes, status, visibility, meta_title, meta_description, sort_order, created_at, updated_at, is_white_label, base_product_id, white_label_customer_id, roast_loss_percent
 FROM products
 WHERE tenant_id = $1
   AND id = $2
@@ -448,16 +545,18 @@ func (q *Queries) GetProductByID(ctx context.Context, arg GetProductByIDParams)
 		&i.IsWhiteLabel,
 		&i.BaseProductID,
 		&i.WhiteLabelCustomerID,
+		&i.RoastLossPercent,
 	)
 	return i, err
 }
 
 const getProductBySlug = `-- name: GetProductBySlug :one
-SELECT id, tenant_id, name, slug, description, short_description, origin, region, producer, process, roast_level, elevation_min, elevation_max, variety, harvest_year, tasting_notes, status, visibility, meta_title, meta_description, sort_order, created_at, updated_at, is_white_label, base_product_id, white_label_customer_id
+SELECT id, tenant_id, name, slug, description, short_description, origin, region, producer, process, roast_level, elevation_min, elevation_max, variety, harvest_year, tasting_notes, status, visibility, meta_title, meta_description, sort_order, created_at, updated_at, is_white_label, base_product_id, white_label_customer_id, roast_loss_percent
 FROM products
 WHERE tenant_id = $1
   AND slug = $2
   AND status = 'active'
+  AND is_white_label = FALSE
 LIMIT 1
 `
 
@@ -497,6 +596,7 @@ func (q *Queries) GetProductBySlug(ctx context.Context, arg GetProductBySlugPara
 		&i.IsWhiteLabel,
 		&i.BaseProductID,
 		&i.WhiteLabelCustomerID,
+		&i.RoastLossPercent,
 	)
 	return i, err
 }
@@ -505,15 +605,15 @@ const getProductFilterOptions = `-- name: GetProductFilterOptions :one
 SELECT
     (SELECT ARRAY_AGG(DISTINCT p2.roast_level ORDER BY p2.roast_level)
      FROM products p2
-     WHERE p2.tenant_id = $1 AND p2.status = 'active' AND p2.visibility = 'public' AND p2.roast_level IS NOT NULL
+     WHERE p2.tenant_id = $1 AND p2.status = 'active' AND p2.visibility = 'public' AND p2.is_white_label = FALSE AND p2.roast_level IS NOT NULL
     ) as roast_levels,
     (SELECT ARRAY_AGG(DISTINCT p3.origin ORDER BY p3.origin)
      FROM products p3
-     WHERE p3.tenant_id = $1 AND p3.status = 'active' AND p3.visibility = 'public' AND p3.origin IS NOT NULL
+     WHERE p3.tenant_id = $1 AND p3.status = 'active' AND p3.visibility = 'public' AND p3.is_white_label = FALSE AND p3.origin IS NOT NULL
     ) as origins,
     (SELECT ARRAY_AGG(DISTINCT note ORDER BY note)
      FROM products p4, UNNEST(p4.tasting_notes) AS note
-     WHERE p4.tenant_id = $1 AND p4.status = 'active' AND p4.visibility = 'public'
+     WHERE p4.tenant_id = $1 AND p4.status = 'active' AND p4.visibility = 'public' AND p4.is_white_label = FALSE
     ) as tasting_notes
 `
 
@@ -602,7 +702,10 @@ SELECT
     weight_grams,
     requires_shipping,
     created_at,
-    updated_at
+    updated_at,
+    base_sku_id,
+    wholesale_min_quantity,
+    wholesale_case_pack
 FROM product_skus
 WHERE product_id = $1
   AND is_active = TRUE
@@ -636,6 +739,9 @@ func (q *Queries) GetProductSKUs(ctx context.Context, productID pgtype.UUID) ([]
 			&i.RequiresShipping,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BaseSkuID,
+			&i.WholesaleMinQuantity,
+			&i.WholesaleCasePack,
 		); err != nil {
 			return nil, err
 		}
@@ -648,7 +754,7 @@ func (q *Queries) GetProductSKUs(ctx context.Context, productID pgtype.UUID) ([]
 }
 
 const getProductsForCustomer = `-- name: GetProductsForCustomer :many
-SELECT p.id, p.tenant_id, p.name, p.slug, p.description, p.short_description, p.origin, p.region, p.producer, p.process, p.roast_level, p.elevation_min, p.elevation_max, p.variety, p.harvest_year, p.tasting_notes, p.status, p.visibility, p.meta_title, p.meta_description, p.sort_order, p.created_at, p.updated_at, p.is_white_label, p.base_product_id, p.white_label_customer_id
+SELECT p.id, p.tenant_id, p.name, p.slug, p.description, p.short_description, p.origin, p.region, p.producer, p.process, p.roast_level, p.elevation_min, p.elevation_max, p.variety, p.harvest_year, p.tasting_notes, p.status, p.visibility, p.meta_title, p.meta_description, p.sort_order, p.created_at, p.updated_at, p.is_white_label, p.base_product_id, p.white_label_customer_id, p.roast_loss_percent
 FROM products p
 WHERE p.tenant_id = $1
   AND p.status = 'active'
@@ -704,6 +810,7 @@ func (q *Queries) GetProductsForCustomer(ctx context.Context, arg GetProductsFor
 			&i.IsWhiteLabel,
 			&i.BaseProductID,
 			&i.WhiteLabelCustomerID,
+			&i.RoastLossPercent,
 		); err != nil {
 			return nil, err
 		}
@@ -732,7 +839,10 @@ SELECT
     weight_grams,
     requires_shipping,
     created_at,
-    updated_at
+    updated_at,
+    base_sku_id,
+    wholesale_min_quantity,
+    wholesale_case_pack
 FROM product_skus
 WHERE id = $1
   AND is_active = TRUE
@@ -760,6 +870,9 @@ func (q *Queries) GetSKUByID(ctx context.Context, id pgtype.UUID) (ProductSku, e
 		&i.RequiresShipping,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BaseSkuID,
+		&i.WholesaleMinQuantity,
+		&i.WholesaleCasePack,
 	)
 	return i, err
 }
@@ -838,8 +951,56 @@ func (q *Queries) GetSKUWithProduct(ctx context.Context, arg GetSKUWithProductPa
 	return i, err
 }
 
+const getWhiteLabelDetails = `-- name: GetWhiteLabelDetails :one
+SELECT
+    base.id as base_product_id,
+    base.name as base_product_name,
+    u.id as customer_id,
+    u.email as customer_email,
+    u.first_name as customer_first_name,
+    u.last_name as customer_last_name,
+    u.company_name as customer_company_name
+FROM products p
+INNER JOIN products base ON base.id = p.base_product_id
+INNER JOIN users u ON u.id = p.white_label_customer_id
+WHERE p.tenant_id = $1
+  AND p.id = $2
+  AND p.is_white_label = TRUE
+`
+
+type GetWhiteLabelDetailsParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	ID       pgtype.UUID `json:"id"`
+}
+
+type GetWhiteLabelDetailsRow struct {
+	BaseProductID       pgtype.UUID `json:"base_product_id"`
+	BaseProductName     string      `json:"base_product_name"`
+	CustomerID          pgtype.UUID `json:"customer_id"`
+	CustomerEmail       string      `json:"customer_email"`
+	CustomerFirstName   pgtype.Text `json:"customer_first_name"`
+	CustomerLastName    pgtype.Text `json:"customer_last_name"`
+	CustomerCompanyName pgtype.Text `json:"customer_company_name"`
+}
+
+// Get the base product and customer of a white-label product
+func (q *Queries) GetWhiteLabelDetails(ctx context.Context, arg GetWhiteLabelDetailsParams) (GetWhiteLabelDetailsRow, error) {
+	row := q.db.QueryRow(ctx, getWhiteLabelDetails, arg.TenantID, arg.ID)
+	var i GetWhiteLabelDetailsRow
+	err := row.Scan(
+		&i.BaseProductID,
+		&i.BaseProductName,
+		&i.CustomerID,
+		&i.CustomerEmail,
+		&i.CustomerFirstName,
+		&i.CustomerLastName,
+		&i.CustomerCompanyName,
+	)
+	return i, err
+}
+
 const getWhiteLabelProductsForCustomer = `-- name: GetWhiteLabelProductsForCustomer :many
-SELECT p.id, p.tenant_id, p.name, p.slug, p.description, p.short_description, p.origin, p.region, p.producer, p.process, p.roast_level, p.elevation_min, p.elevation_max, p.variety, p.harvest_year, p.tasting_notes, p.status, p.visibility, p.meta_title, p.meta_description, p.sort_order, p.created_at, p.updated_at, p.is_white_label, p.base_product_id, p.white_label_customer_id
+SELECT p.id, p.tenant_id, p.name, p.slug, p.description, p.short_description, p.origin, p.region, p.producer, p.process, p.roast_level, p.elevation_min, p.elevation_max, p.variety, p.harvest_year, p.tasting_notes, p.status, p.visibility, p.meta_title, p.meta_description, p.sort_order, p.created_at, p.updated_at, p.is_white_label, p.base_product_id, p.white_label_customer_id, p.roast_loss_percent
 FROM products p
 WHERE p.tenant_id = $1
   AND p.is_white_label = TRUE
@@ -890,6 +1051,7 @@ func (q *Queries) GetWhiteLabelProductsForCustomer(ctx context.Context, arg GetW
 			&i.IsWhiteLabel,
 			&i.BaseProductID,
 			&i.WhiteLabelCustomerID,
+			&i.RoastLossPercent,
 		); err != nil {
 			return nil, err
 		}
@@ -919,6 +1081,7 @@ LEFT JOIN product_images pi ON pi.product_id = p.id AND pi.is_primary = TRUE
 WHERE p.tenant_id = $1
   AND p.status = 'active'
   AND p.visibility = 'public'
+  AND p.is_white_label = FALSE
 ORDER BY p.sort_order ASC, p.created_at DESC
 `
 
@@ -993,17 +1156,47 @@ LEFT JOIN product_images pi ON pi.product_id = p.id AND pi.is_primary = TRUE
 WHERE p.tenant_id = $1
   AND p.status = 'active'
   AND p.visibility = 'public'
+  AND p.is_white_label = FALSE
   AND ($2::text IS NULL OR p.roast_level = $2::text)
   AND ($3::text IS NULL OR p.origin = $3::text)
   AND ($4::text IS NULL OR $4::text = ANY(p.tasting_notes))
+  AND ($5::text IS NULL OR EXISTS (
+      SELECT 1
+      FROM product_category_assignments pca
+      WHERE pca.product_id = p.id
+        AND pca.category_id IN (
+            WITH RECURSIVE category_tree AS (
+                SELECT pc.id
+                FROM product_categories pc
+                WHERE pc.tenant_id = $1
+                  AND pc.slug = $5::text
+                  AND pc.is_active = TRUE
+                UNION ALL
+                SELECT child.id
+                FROM product_categories child
+                JOIN category_tree ct ON child.parent_id = ct.id
+                WHERE child.is_active = TRUE
+            )
+            SELECT id FROM category_tree
+        )
+  ))
+  AND ($6::text IS NULL OR EXISTS (
+      SELECT 1
+      FROM product_tag_assignments pta
+      JOIN product_tags pt ON pt.id = pta.tag_id
+      WHERE pta.product_id = p.id
+        AND pt.slug = $6::text
+  ))
 ORDER BY p.sort_order ASC, p.created_at DESC
 `
 
 type ListActiveProductsFilteredParams struct {
-	TenantID    pgtype.UUID `json:"tenant_id"`
-	RoastLevel  pgtype.Text `json:"roast_level"`
-	Origin      pgtype.Text `json:"origin"`
-	TastingNote pgtype.Text `json:"tasting_note"`
+	TenantID     pgtype.UUID `json:"tenant_id"`
+	RoastLevel   pgtype.Text `json:"roast_level"`
+	Origin       pgtype.Text `json:"origin"`
+	TastingNote  pgtype.Text `json:"tasting_note"`
+	CategorySlug pgtype.Text `json:"category_slug"`
+	TagSlug      pgtype.Text `json:"tag_slug"`
 }
 
 type ListActiveProductsFilteredRow struct {
@@ -1021,13 +1214,16 @@ type ListActiveProductsFilteredRow struct {
 	BasePrice        interface{} `json:"base_price"`
 }
 
-// List active products with optional filters for roast level and origin
+// List active products with optional filters for roast level, origin, tasting
+// note, category (including its subcategories) and tag
 func (q *Queries) ListActiveProductsFiltered(ctx context.Context, arg ListActiveProductsFilteredParams) ([]ListActiveProductsFilteredRow, error) {
 	rows, err := q.db.Query(ctx, listActiveProductsFiltered,
 		arg.TenantID,
 		arg.RoastLevel,
 		arg.Origin,
 		arg.TastingNote,
+		arg.CategorySlug,
+		arg.TagSlug,
 	)
 	if err != nil {
 		return nil, err
@@ -1143,52 +1339,74 @@ SELECT
     p.name as product_name,
     p.slug as product_slug,
     p.origin as product_origin,
+    p.is_white_label as product_is_white_label,
     pi.url as product_image_url,
     ps.id as sku_id,
     ps.sku as sku_code,
     ps.weight_value,
     ps.weight_unit,
     ps.grind,
-    ps.inventory_quantity,
-    ps.inventory_policy,
-    ps.low_stock_threshold,
-    ple.price_cents
+    stock.inventory_quantity,
+    stock.inventory_policy,
+    stock.low_stock_threshold,
+    ple.price_cents,
+    ps.wholesale_min_quantity,
+    ps.wholesale_case_pack,
+    (rule.id IS NOT NULL)::boolean AS has_customer_rule,
+    rule.min_quantity AS customer_min_quantity,
+    rule.case_pack AS customer_case_pack
 FROM products p
 INNER JOIN product_skus ps ON ps.product_id = p.id AND ps.is_active = TRUE
+INNER JOIN product_skus stock ON stock.id = COALESCE(ps.base_sku_id, ps.id)
 INNER JOIN price_list_entries ple ON ple.product_sku_id = ps.id AND ple.price_list_id = $2
 LEFT JOIN product_images pi ON pi.product_id = p.id AND pi.is_primary = TRUE
+LEFT JOIN customer_sku_order_rules rule ON rule.product_sku_id = ps.id AND rule.user_id = $3
 WHERE p.tenant_id = $1
   AND p.status = 'active'
-  AND (p.visibility = 'public' OR p.visibility = 'wholesale_only')
+  AND (
+    (p.is_white_label = FALSE AND (p.visibility = 'public' OR p.visibility = 'wholesale_only'))
+    OR
+    (p.is_white_label = TRUE AND p.white_label_customer_id = $3)
+  )
 ORDER BY p.sort_order ASC, p.name ASC, ps.weight_value ASC, ps.grind ASC
 `
 
 type ListProductsWithSKUsForWholesaleParams struct {
 	TenantID    pgtype.UUID `json:"tenant_id"`
 	PriceListID pgtype.UUID `json:"price_list_id"`
+	CustomerID  pgtype.UUID `json:"customer_id"`
 }
 
 type ListProductsWithSKUsForWholesaleRow struct {
-	ProductID         pgtype.UUID    `json:"product_id"`
-	ProductName       string         `json:"product_name"`
-	ProductSlug       string         `json:"product_slug"`
-	ProductOrigin     pgtype.Text    `json:"product_origin"`
-	ProductImageUrl   pgtype.Text    `json:"product_image_url"`
-	SkuID             pgtype.UUID    `json:"sku_id"`
-	SkuCode           string         `json:"sku_code"`
-	WeightValue       pgtype.Numeric `json:"weight_value"`
-	WeightUnit        string         `json:"weight_unit"`
-	Grind             string         `json:"grind"`
-	InventoryQuantity int32          `json:"inventory_quantity"`
-	InventoryPolicy   string         `json:"inventory_policy"`
-	LowStockThreshold pgtype.Int4    `json:"low_stock_threshold"`
-	PriceCents        int32          `json:"price_cents"`
+	ProductID            pgtype.UUID    `json:"product_id"`
+	ProductName          string         `json:"product_name"`
+	ProductSlug          string         `json:"product_slug"`
+	ProductOrigin        pgtype.Text    `json:"product_origin"`
+	ProductIsWhiteLabel  bool           `json:"product_is_white_label"`
+	ProductImageUrl      pgtype.Text    `json:"product_image_url"`
+	SkuID                pgtype.UUID    `json:"sku_id"`
+	SkuCode              string         `json:"sku_code"`
+	WeightValue          pgtype.Numeric `json:"weight_value"`
+	WeightUnit           string         `json:"weight_unit"`
+	Grind                string         `json:"grind"`
+	InventoryQuantity    int32          `json:"inventory_quantity"`
+	InventoryPolicy      string         `json:"inventory_policy"`
+	LowStockThreshold    pgtype.Int4    `json:"low_stock_threshold"`
+	PriceCents           int32          `json:"price_cents"`
+	WholesaleMinQuantity pgtype.Int4    `json:"wholesale_min_quantity"`
+	WholesaleCasePack    pgtype.Int4    `json:"wholesale_case_pack"`
+	HasCustomerRule      bool           `json:"has_customer_rule"`
+	CustomerMinQuantity  pgtype.Int4    `json:"customer_min_quantity"`
+	CustomerCasePack     pgtype.Int4    `json:"customer_case_pack"`
 }
 
 // Get all active products with their SKUs and prices for wholesale ordering matrix view
 // This query denormalizes the data for efficient display in a table format
+// White-label products are only included for their customer, with the stock
+// of the base product SKU they draw from. Quantity rules are the SKU's, plus
+// the customer's override if they have one.
 func (q *Queries) ListProductsWithSKUsForWholesale(ctx context.Context, arg ListProductsWithSKUsForWholesaleParams) ([]ListProductsWithSKUsForWholesaleRow, error) {
-	rows, err := q.db.Query(ctx, listProductsWithSKUsForWholesale, arg.TenantID, arg.PriceListID)
+	rows, err := q.db.Query(ctx, listProductsWithSKUsForWholesale, arg.TenantID, arg.PriceListID, arg.CustomerID)
 	if err != nil {
 		return nil, err
 	}
@@ -1201,6 +1419,7 @@ func (q *Queries) ListProductsWithSKUsForWholesale(ctx context.Context, arg List
 			&i.ProductName,
 			&i.ProductSlug,
 			&i.ProductOrigin,
+			&i.ProductIsWhiteLabel,
 			&i.ProductImageUrl,
 			&i.SkuID,
 			&i.SkuCode,
@@ -1211,6 +1430,81 @@ func (q *Queries) ListProductsWithSKUsForWholesale(ctx context.Context, arg List
 			&i.InventoryPolicy,
 			&i.LowStockThreshold,
 			&i.PriceCents,
+			&i.WholesaleMinQuantity,
+			&i.WholesaleCasePack,
+			&i.HasCustomerRule,
+			&i.CustomerMinQuantity,
+			&i.CustomerCasePack,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWhiteLabelProducts = `-- name: ListWhiteLabelProducts :many
+SELECT
+    p.id,
+    p.name,
+    p.slug,
+    p.status,
+    p.created_at,
+    u.id as customer_id,
+    u.email as customer_email,
+    u.first_name as customer_first_name,
+    u.last_name as customer_last_name,
+    u.company_name as customer_company_name
+FROM products p
+INNER JOIN users u ON u.id = p.white_label_customer_id
+WHERE p.tenant_id = $1
+  AND p.base_product_id = $2
+  AND p.is_white_label = TRUE
+ORDER BY u.company_name ASC, p.name ASC
+`
+
+type ListWhiteLabelProductsParams struct {
+	TenantID      pgtype.UUID `json:"tenant_id"`
+	BaseProductID pgtype.UUID `json:"base_product_id"`
+}
+
+type ListWhiteLabelProductsRow struct {
+	ID                  pgtype.UUID        `json:"id"`
+	Name                string             `json:"name"`
+	Slug                string             `json:"slug"`
+	Status              string             `json:"status"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	CustomerID          pgtype.UUID        `json:"customer_id"`
+	CustomerEmail       string             `json:"customer_email"`
+	CustomerFirstName   pgtype.Text        `json:"customer_first_name"`
+	CustomerLastName    pgtype.Text        `json:"customer_last_name"`
+	CustomerCompanyName pgtype.Text        `json:"customer_company_name"`
+}
+
+// List the white-label variants of a base product with their customers
+func (q *Queries) ListWhiteLabelProducts(ctx context.Context, arg ListWhiteLabelProductsParams) ([]ListWhiteLabelProductsRow, error) {
+	rows, err := q.db.Query(ctx, listWhiteLabelProducts, arg.TenantID, arg.BaseProductID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListWhiteLabelProductsRow{}
+	for rows.Next() {
+		var i ListWhiteLabelProductsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Slug,
+			&i.Status,
+			&i.CreatedAt,
+			&i.CustomerID,
+			&i.CustomerEmail,
+			&i.CustomerFirstName,
+			&i.CustomerLastName,
+			&i.CustomerCompanyName,
 		); err != nil {
 			return nil, err
 		}
@@ -1261,7 +1555,7 @@ SET
     updated_at = NOW()
 WHERE tenant_id = $1
   AND id = $2
-RETURNING id, tenant_id, name, slug, description, short_description, origin, region, producer, process, roast_level, elevation_min, elevation_max, variety, harvest_year, tasting_notes, status, visibility, meta_title, meta_description, sort_order, created_at, updated_at, is_white_label, base_product_id, white_label_customer_id
+RETURNING id, tenant_id, name, slug, description, short_description, origin, region, producer, process, roast_level, elevation_min, elevation_max, variety, harvest_year, tasting_notes, status, visibility, meta_title, meta_description, sort_order, created_at, updated_at, is_white_label, base_product_id, white_label_customer_id, roast_loss_percent
 `
 
 type UpdateProductParams struct {
@@ -1333,6 +1627,7 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		&i.IsWhiteLabel,
 		&i.BaseProductID,
 		&i.WhiteLabelCustomerID,
+		&i.RoastLossPercent,
 	)
 	return i, err
 }
@@ -1402,35 +1697,38 @@ SET
     weight_unit = $5,
     grind = $6,
     base_price_cents = $7,
-    inventory_quantity = $8,
-    inventory_policy = $9,
-    low_stock_threshold = $10,
-    is_active = $11,
-    weight_grams = $12,
-    requires_shipping = $13,
+    inventory_policy = $8,
+    low_stock_threshold = $9,
+    is_active = $10,
+    weight_grams = $11,
+    requires_shipping = $12,
+    wholesale_min_quantity = $13,
+    wholesale_case_pack = $14,
     updated_at = NOW()
 WHERE tenant_id = $1
   AND id = $2
-RETURNING id, tenant_id, product_id, sku, weight_value, weight_unit, grind, base_price_cents, inventory_quantity, inventory_policy, low_stock_threshold, is_active, weight_grams, requires_shipping, created_at, updated_at
+RETURNING id, tenant_id, product_id, sku, weight_value, weight_unit, grind, base_price_cents, inventory_quantity, inventory_policy, low_stock_threshold, is_active, weight_grams, requires_shipping, created_at, updated_at, base_sku_id, wholesale_min_quantity, wholesale_case_pack
 `
 
 type UpdateProductSKUParams struct {
-	TenantID          pgtype.UUID    `json:"tenant_id"`
-	ID                pgtype.UUID    `json:"id"`
-	Sku               string         `json:"sku"`
-	WeightValue       pgtype.Numeric `json:"weight_value"`
-	WeightUnit        string         `json:"weight_unit"`
-	Grind             string         `json:"grind"`
-	BasePriceCents    int32          `json:"base_price_cents"`
-	InventoryQuantity int32          `json:"inventory_quantity"`
-	InventoryPolicy   string         `json:"inventory_policy"`
-	LowStockThreshold pgtype.Int4    `json:"low_stock_threshold"`
-	IsActive          bool           `json:"is_active"`
-	WeightGrams       pgtype.Int4    `json:"weight_grams"`
-	RequiresShipping  bool           `json:"requires_shipping"`
+	TenantID             pgtype.UUID    `json:"tenant_id"`
+	ID                   pgtype.UUID    `json:"id"`
+	Sku                  string         `json:"sku"`
+	WeightValue          pgtype.Numeric `json:"weight_value"`
+	WeightUnit           string         `json:"weight_unit"`
+	Grind                string         `json:"grind"`
+	BasePriceCents       int32          `json:"base_price_cents"`
+	InventoryPolicy      string         `json:"inventory_policy"`
+	LowStockThreshold    pgtype.Int4    `json:"low_stock_threshold"`
+	IsActive             bool           `json:"is_active"`
+	WeightGrams          pgtype.Int4    `json:"weight_grams"`
+	RequiresShipping     bool           `json:"requires_shipping"`
+	WholesaleMinQuantity pgtype.Int4    `json:"wholesale_min_quantity"`
+	WholesaleCasePack    pgtype.Int4    `json:"wholesale_case_pack"`
 }
 
-// Update an existing product SKU
+// Update an existing product SKU. Stock changes go through the inventory
+// ledger (AdjustSKUInventory).
 func (q *Queries) UpdateProductSKU(ctx context.Context, arg UpdateProductSKUParams) (ProductSku, error) {
 	row := q.db.QueryRow(ctx, updateProductSKU,
 		arg.TenantID,
@@ -1440,12 +1738,13 @@ func (q *Queries) UpdateProductSKU(ctx context.Context, arg UpdateProductSKUPara
 		arg.WeightUnit,
 		arg.Grind,
 		arg.BasePriceCents,
-		arg.InventoryQuantity,
 		arg.InventoryPolicy,
 		arg.LowStockThreshold,
 		arg.IsActive,
 		arg.WeightGrams,
 		arg.RequiresShipping,
+		arg.WholesaleMinQuantity,
+		arg.WholesaleCasePack,
 	)
 	var i ProductSku
 	err := row.Scan(
@@ -1465,6 +1764,9 @@ func (q *Queries) UpdateProductSKU(ctx context.Context, arg UpdateProductSKUPara
 		&i.RequiresShipping,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BaseSkuID,
+		&i.WholesaleMinQuantity,
+		&i.WholesaleCasePack,
 	)
 	return i, err
 }
@@ -21,10 +21,34 @@ type Querier interface {
 	ActivateTenant(ctx context.Context, id pgtype.UUID) error
 	// Add an item to cart (or update quantity if exists)
 	AddCartItem(ctx context.Context, arg AddCartItemParams) (CartItem, error)
+	// Changes a SKU's stock by quantity_change, or sets it to counted when given,
+	// and records the change in the inventory ledger. Returns no rows if the SKU
+	// does not exist or its stock would go below zero.
+	AdjustSKUInventory(ctx context.Context, arg AdjustSKUInventoryParams) (InventoryAdjustment, error)
 	// Admin update customer details
 	AdminUpdateCustomer(ctx context.Context, arg AdminUpdateCustomerParams) error
+	// Moves a shipment to a new tracking status
+	// Returns no row when the shipment is already in that status or has finished
+	AdvanceShipmentTrackingStatus(ctx context.Context, arg AdvanceShipmentTrackingStatusParams) (Shipment, error)
+	// Allocates unshipped items of the batch's product on paid orders that are
+	// not allocated to another batch
+	AllocateOpenOrderItemsToRoastBatch(ctx context.Context, arg AllocateOpenOrderItemsToRoastBatchParams) (int64, error)
+	// Allocates a new order's items: renewal orders take the allocation made for
+	// the renewal, other items go to the next planned batch of their product
+	AllocateOrderToRoastBatches(ctx context.Context, arg AllocateOrderToRoastBatchesParams) (int64, error)
+	// Allocates the batch's product on active subscriptions renewing between
+	// today and the given date
+	AllocateRenewalsToRoastBatch(ctx context.Context, arg AllocateRenewalsToRoastBatchParams) (int64, error)
+	// Assign a product to categories, ignoring IDs that don't belong to the tenant
+	AssignProductCategories(ctx context.Context, arg AssignProductCategoriesParams) error
+	// Tag a product, ignoring IDs that don't belong to the tenant
+	AssignProductTags(ctx context.Context, arg AssignProductTagsParams) error
 	// Cancel a pending job
 	CancelJob(ctx context.Context, id pgtype.UUID) error
+	// Cancel a pending job within the console scope
+	CancelJobInScope(ctx context.Context, arg CancelJobInScopeParams) (Job, error)
+	// Cancels a batch that has not been roasted
+	CancelRoastBatch(ctx context.Context, arg CancelRoastBatchParams) (RoastBatch, error)
 	// Cancel a tenant subscription
 	CancelTenant(ctx context.Context, id pgtype.UUID) error
 	// ============================================================================
@@ -77,9 +101,18 @@ type Querier interface {
 	// Validation: wholesale_pricing
 	// True if at least one wholesale price list with entries exists
 	CheckWholesalePricing(ctx context.Context, tenantID pgtype.UUID) (bool, error)
+	// Marks cart as converted unless another order already did
+	// Returns no rows when the cart was already converted
+	ClaimCartForOrder(ctx context.Context, arg ClaimCartForOrderParams) (pgtype.UUID, error)
 	// Claim the next pending job using SKIP LOCKED for safe concurrent access
-	// This query finds the highest priority job that's ready to run
+	// This query finds the highest priority job that's ready to run. An empty
+	// queue matches any queue except exclude_queues, which have their own
+	// concurrency limits.
 	ClaimNextJob(ctx context.Context, arg ClaimNextJobParams) (Job, error)
+	// Moves a due standing order on to its following delivery before the due
+	// delivery is placed. Returns no row when another run has already claimed
+	// the delivery.
+	ClaimStandingOrderDelivery(ctx context.Context, arg ClaimStandingOrderDeliveryParams) (StandingOrder, error)
 	// ============================================================================
 	// BACKGROUND JOBS - CLEANUP
 	// ============================================================================
@@ -92,14 +125,24 @@ type Querier interface {
 	ClearOperatorSetupToken(ctx context.Context, id pgtype.UUID) error
 	// Clear grace period after successful payment
 	ClearTenantGracePeriod(ctx context.Context, id pgtype.UUID) error
-	// Mark a job as completed
-	CompleteJob(ctx context.Context, id pgtype.UUID) error
+	// Marks a subscription's still-scheduled events of one type as completed or
+	// cancelled, e.g. a pending resume once the subscription is resumed
+	CloseScheduledSubscriptionEvents(ctx context.Context, arg CloseScheduledSubscriptionEventsParams) error
+	// Marks a batch as completed with its merged document and label counts
+	CompleteFulfillmentBatch(ctx context.Context, arg CompleteFulfillmentBatchParams) (FulfillmentBatch, error)
+	// Mark a job as completed. The worker must still own the job: a job the
+	// reaper took back from a silent worker may be running elsewhere.
+	CompleteJob(ctx context.Context, arg CompleteJobParams) error
 	// Count active sessions for an operator
 	CountActiveOperatorSessions(ctx context.Context, operatorID pgtype.UUID) (int64, error)
 	// Count addresses for a user (for account dashboard)
 	CountAddressesForUser(ctx context.Context, arg CountAddressesForUserParams) (CountAddressesForUserRow, error)
+	// Count how many times a customer has redeemed a discount code
+	CountDiscountCodeUsageForUser(ctx context.Context, arg CountDiscountCodeUsageForUserParams) (int64, error)
 	// Count invoices for pagination
 	CountInvoices(ctx context.Context, tenantID pgtype.UUID) (int64, error)
+	// Count of jobs matching the console filters
+	CountJobs(ctx context.Context, arg CountJobsParams) (int64, error)
 	// Count jobs by status
 	CountJobsByStatus(ctx context.Context, arg CountJobsByStatusParams) (int64, error)
 	// Count operators for a tenant
@@ -120,6 +163,8 @@ type Querier interface {
 	// Count recent email verification requests for a specific user (rate limiting)
 	// Scoped by tenant_id to ensure rate limits are per-tenant
 	CountRecentVerificationRequestsByUser(ctx context.Context, arg CountRecentVerificationRequestsByUserParams) (int64, error)
+	// Count a tenant's reviews in each moderation status
+	CountReviewsByStatus(ctx context.Context, tenantID pgtype.UUID) ([]CountReviewsByStatusRow, error)
 	// Count total subscriptions for pagination
 	CountSubscriptions(ctx context.Context, tenantID pgtype.UUID) (int64, error)
 	// Counts subscriptions by status for pagination
@@ -142,8 +187,14 @@ type Querier interface {
 	CreateCart(ctx context.Context, arg CreateCartParams) (Cart, error)
 	// Link an address to a user
 	CreateCustomerAddress(ctx context.Context, arg CreateCustomerAddressParams) (CustomerAddress, error)
+	// Create a new discount code
+	CreateDiscountCode(ctx context.Context, arg CreateDiscountCodeParams) (DiscountCode, error)
 	// Create a new email verification token
 	CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) (EmailVerificationToken, error)
+	// Creates a fulfillment batch awaiting label purchase
+	CreateFulfillmentBatch(ctx context.Context, arg CreateFulfillmentBatchParams) (FulfillmentBatch, error)
+	// Adds an order to a fulfillment batch
+	CreateFulfillmentBatchOrder(ctx context.Context, arg CreateFulfillmentBatchOrderParams) (FulfillmentBatchOrder, error)
 	// Invoice Queries
 	// Manages wholesale billing invoices
 	// =============================================================================
@@ -167,6 +218,8 @@ type Querier interface {
 	// Record a payment against an invoice
 	// Note: The update_invoice_balance trigger automatically updates invoice totals
 	CreateInvoicePayment(ctx context.Context, arg CreateInvoicePaymentParams) (InvoicePayment, error)
+	// Records a shipment for a purchased shipping label
+	CreateLabelShipment(ctx context.Context, arg CreateLabelShipmentParams) (Shipment, error)
 	// Operator Sessions: Sessions for tenant operators (separate from customer sessions)
 	// Create a new operator session
 	CreateOperatorSession(ctx context.Context, arg CreateOperatorSessionParams) (OperatorSession, error)
@@ -176,6 +229,7 @@ type Querier interface {
 	// Creates an order line item linked to a specific order
 	// Captures product state at time of purchase
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (OrderItem, error)
+	CreateOrderTemplateItem(ctx context.Context, arg CreateOrderTemplateItemParams) error
 	// Create a new password reset token
 	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) (PasswordResetToken, error)
 	// Create a new payment record
@@ -193,14 +247,31 @@ type Querier interface {
 	CreatePriceListEntry(ctx context.Context, arg CreatePriceListEntryParams) (PriceListEntry, error)
 	// Create a new product
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
+	// Create a new category
+	CreateProductCategory(ctx context.Context, arg CreateProductCategoryParams) (ProductCategory, error)
 	// Create a new product image
 	CreateProductImage(ctx context.Context, arg CreateProductImageParams) (ProductImage, error)
+	// Create a review awaiting moderation
+	CreateProductReview(ctx context.Context, arg CreateProductReviewParams) (ProductReview, error)
 	// Create a new product SKU
 	CreateProductSKU(ctx context.Context, arg CreateProductSKUParams) (ProductSku, error)
+	// Create a new tag
+	CreateProductTag(ctx context.Context, arg CreateProductTagParams) (ProductTag, error)
 	// Creates a new tenant provider configuration.
 	// If is_default is true, this will be the default provider for this type.
 	// The config_encrypted field should contain base64-encoded AES-256-GCM encrypted JSON.
 	CreateProviderConfig(ctx context.Context, arg CreateProviderConfigParams) (TenantProviderConfig, error)
+	// Records a refund issued through the payment provider
+	// Returns no rows if the provider refund has already been recorded,
+	// which keeps admin refunds and charge.refunded webhooks idempotent
+	CreateRefund(ctx context.Context, arg CreateRefundParams) (Refund, error)
+	// Records a line item (and quantity) covered by a refund
+	CreateRefundItem(ctx context.Context, arg CreateRefundItemParams) (RefundItem, error)
+	// Record that an order was sent a review request. Affects no rows if one
+	// was already sent.
+	CreateReviewRequest(ctx context.Context, arg CreateReviewRequestParams) (int64, error)
+	// Plans a roast of one product
+	CreateRoastBatch(ctx context.Context, arg CreateRoastBatchParams) (RoastBatch, error)
 	// Create a new session
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	// Create a shipment record for an order
@@ -210,14 +281,20 @@ type Querier interface {
 	// =============================================================================
 	// Create a shipment line item for partial fulfillment
 	CreateShipmentItem(ctx context.Context, arg CreateShipmentItemParams) (ShipmentItem, error)
+	// Records a carrier tracking event, ignoring events already stored
+	CreateShipmentTrackingEvent(ctx context.Context, arg CreateShipmentTrackingEventParams) error
 	// Creates a new shipping rate (manual or cached from provider).
 	// For manual rates, valid_until should be NULL.
 	// For provider-cached rates, valid_until should be a future timestamp.
 	CreateShippingRate(ctx context.Context, arg CreateShippingRateParams) (TenantShippingRate, error)
+	CreateStandingOrder(ctx context.Context, arg CreateStandingOrderParams) (StandingOrder, error)
 	// Subscription queries for the SubscriptionService
 	// Creates a new subscription record
 	// Returns the complete subscription with generated ID and timestamps
 	CreateSubscription(ctx context.Context, arg CreateSubscriptionParams) (Subscription, error)
+	// Starts dunning for a failed invoice. Returns no rows when the
+	// subscription is already in dunning.
+	CreateSubscriptionDunning(ctx context.Context, arg CreateSubscriptionDunningParams) (SubscriptionDunning, error)
 	// Creates a subscription item (product in subscription)
 	// Captures pricing at time of subscription creation
 	CreateSubscriptionItem(ctx context.Context, arg CreateSubscriptionItemParams) (SubscriptionItem, error)
@@ -239,15 +316,26 @@ type Querier interface {
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	// Record incoming webhook event for idempotency
 	CreateWebhookEvent(ctx context.Context, arg CreateWebhookEventParams) (WebhookEvent, error)
+	// Clone a base product SKU into a white-label product. The clone keeps the
+	// bag size, grind, stock policy and wholesale quantity rules, and draws from
+	// the base SKU's inventory.
+	CreateWhiteLabelSKU(ctx context.Context, arg CreateWhiteLabelSKUParams) (ProductSku, error)
 	// Deactivate a custom domain (set back to 'none')
 	// Removes all custom domain data
 	// Used when tenant removes their custom domain
 	DeactivateCustomDomain(ctx context.Context, id pgtype.UUID) error
-	// Decrements inventory for a SKU after order placement
+	// Decrements inventory for a SKU after order placement and records the sale
+	// in the inventory ledger. White-label SKUs sell from their base SKU's stock.
 	// Uses optimistic locking to prevent overselling
 	DecrementSKUStock(ctx context.Context, arg DecrementSKUStockParams) error
 	// Remove association between user and address
 	DeleteCustomerAddress(ctx context.Context, arg DeleteCustomerAddressParams) error
+	// Remove a customer's override so the SKU's own rules apply again
+	DeleteCustomerSKUOrderRule(ctx context.Context, arg DeleteCustomerSKUOrderRuleParams) error
+	// Delete a discount code (usage history is removed by cascade)
+	DeleteDiscountCode(ctx context.Context, arg DeleteDiscountCodeParams) error
+	// Remove a tenant's customized email, so the default is sent again
+	DeleteEmailTemplate(ctx context.Context, arg DeleteEmailTemplateParams) (int64, error)
 	// Delete expired email verification tokens (cleanup job)
 	DeleteExpiredEmailVerificationTokens(ctx context.Context) error
 	// Clean up expired operator sessions (background job)
@@ -273,6 +361,9 @@ type Querier interface {
 	DeleteOperatorSessionByID(ctx context.Context, id pgtype.UUID) error
 	// Delete all sessions for an operator (e.g., password change, force logout)
 	DeleteOperatorSessionsByOperatorID(ctx context.Context, operatorID pgtype.UUID) error
+	DeleteOrderTemplate(ctx context.Context, arg DeleteOrderTemplateParams) error
+	// Clears an order template's items before it is saved again
+	DeleteOrderTemplateItems(ctx context.Context, arg DeleteOrderTemplateItemsParams) error
 	// Delete a payment method
 	DeletePaymentMethod(ctx context.Context, arg DeletePaymentMethodParams) error
 	// Soft delete by deactivating (preserves referential integrity)
@@ -281,15 +372,25 @@ type Querier interface {
 	DeletePriceList(ctx context.Context, arg DeletePriceListParams) error
 	// Delete a price list entry
 	DeletePriceListEntry(ctx context.Context, arg DeletePriceListEntryParams) error
+	// Delete a quantity-break price from a price list
+	DeletePriceListEntryTier(ctx context.Context, arg DeletePriceListEntryTierParams) error
 	// Soft delete a product (set status to 'archived')
 	DeleteProduct(ctx context.Context, arg DeleteProductParams) error
+	// Delete a category and its product assignments
+	DeleteProductCategory(ctx context.Context, arg DeleteProductCategoryParams) error
 	// Delete a product image
 	DeleteProductImage(ctx context.Context, arg DeleteProductImageParams) error
 	// Soft delete a product SKU (set is_active to false)
 	DeleteProductSKU(ctx context.Context, arg DeleteProductSKUParams) error
+	// Delete a tag and its product assignments
+	DeleteProductTag(ctx context.Context, arg DeleteProductTagParams) error
 	// Deletes a provider configuration.
 	// Cascades to tenant_shipping_rates if this is a shipping provider.
 	DeleteProviderConfig(ctx context.Context, arg DeleteProviderConfigParams) error
+	// Releases one allocation from a batch
+	DeleteRoastBatchAllocation(ctx context.Context, arg DeleteRoastBatchAllocationParams) (int64, error)
+	// Releases all of a batch's allocations
+	DeleteRoastBatchAllocations(ctx context.Context, arg DeleteRoastBatchAllocationsParams) error
 	// Delete a session
 	DeleteSession(ctx context.Context, token string) error
 	// Deletes a specific shipping rate.
@@ -297,6 +398,7 @@ type Querier interface {
 	// Deletes all shipping rates for a specific provider config.
 	// Used when removing a shipping provider configuration.
 	DeleteShippingRatesByProvider(ctx context.Context, arg DeleteShippingRatesByProviderParams) error
+	DeleteStandingOrderItem(ctx context.Context, arg DeleteStandingOrderItemParams) error
 	// Delete a tax rate
 	DeleteTaxRate(ctx context.Context, arg DeleteTaxRateParams) error
 	// Delete an operator (for cleanup/testing)
@@ -305,7 +407,13 @@ type Querier interface {
 	DeleteTenantPage(ctx context.Context, arg DeleteTenantPageParams) error
 	// Insert a new job into the queue
 	EnqueueJob(ctx context.Context, arg EnqueueJobParams) (Job, error)
-	// Mark a job as failed or reschedule it for retry
+	// Creates the schedule for a job type unless the tenant (or the system,
+	// when tenant_id is NULL) already has one. Existing schedules keep their
+	// cron expression and run times.
+	EnsureJobSchedule(ctx context.Context, arg EnsureJobScheduleParams) error
+	// Marks a batch as failed
+	FailFulfillmentBatch(ctx context.Context, arg FailFulfillmentBatchParams) error
+	// Mark a job as failed or reschedule it for retry at retry_at
 	// If retry_count < max_retries, reschedule; otherwise mark as failed
 	FailJob(ctx context.Context, arg FailJobParams) (Job, error)
 	// Generate next invoice number for a tenant
@@ -317,10 +425,13 @@ type Querier interface {
 	// Get all tenants with active custom domains for health monitoring
 	// Used by daily background job to verify CNAME records are still valid
 	GetActiveCustomDomains(ctx context.Context) ([]GetActiveCustomDomainsRow, error)
+	// Get a tenant's customized email by type, if it is switched on (for sending)
+	GetActiveEmailTemplate(ctx context.Context, arg GetActiveEmailTemplateParams) (EmailTemplate, error)
 	// Retrieves all active provider configurations for a tenant and type.
 	// Results are ordered by is_default DESC (default first), then priority ASC (lower priority number first).
 	// Used by registry to load the best provider for a tenant.
 	GetActiveProviderConfigs(ctx context.Context, arg GetActiveProviderConfigsParams) ([]TenantProviderConfig, error)
+	GetActiveSubscriptionDunning(ctx context.Context, arg GetActiveSubscriptionDunningParams) (SubscriptionDunning, error)
 	// Get a single address by ID (no user validation - for system use)
 	GetAddressByID(ctx context.Context, id pgtype.UUID) (Address, error)
 	// Get a single address by ID (validates user ownership via customer_addresses)
@@ -343,10 +454,12 @@ type Querier interface {
 	// Retrieves billing customer record for user
 	// Used to get Stripe customer ID for subscription creation
 	GetBillingCustomerForUser(ctx context.Context, arg GetBillingCustomerForUserParams) (BillingCustomer, error)
-	// Get cart by ID
-	GetCartByID(ctx context.Context, id pgtype.UUID) (Cart, error)
-	// Get active cart for a session
-	GetCartBySessionID(ctx context.Context, sessionID pgtype.UUID) (Cart, error)
+	// Get cart by ID within a tenant
+	GetCartByID(ctx context.Context, arg GetCartByIDParams) (Cart, error)
+	// Get active cart for a session within a tenant. A session cookie can be
+	// shared across storefronts on the same base domain, so each tenant has its
+	// own cart for the session.
+	GetCartBySessionID(ctx context.Context, arg GetCartBySessionIDParams) (Cart, error)
 	// Get total number of items in cart
 	GetCartItemCount(ctx context.Context, cartID pgtype.UUID) (int32, error)
 	// Get all items in a cart with product details
@@ -363,6 +476,9 @@ type Querier interface {
 	// Get all custom domains filtered by status
 	// Used for admin reporting and monitoring
 	GetCustomDomainsByStatus(ctx context.Context, customDomainStatus string) ([]GetCustomDomainsByStatusRow, error)
+	// Get what a wholesale customer owes on account: unpaid invoice balances
+	// plus wholesale orders not yet invoiced. Used to enforce credit limits.
+	GetCustomerOutstandingBalance(ctx context.Context, arg GetCustomerOutstandingBalanceParams) (int32, error)
 	// Get wholesale customers due for consolidated invoice generation
 	// Used by billing cycle job to find accounts ready for invoicing
 	GetCustomersForBillingCycle(ctx context.Context, arg GetCustomersForBillingCycleParams) ([]GetCustomersForBillingCycleRow, error)
@@ -381,8 +497,18 @@ type Querier interface {
 	GetDefaultProviderConfig(ctx context.Context, arg GetDefaultProviderConfigParams) (TenantProviderConfig, error)
 	// Get the default shipping address for a user
 	GetDefaultShippingAddress(ctx context.Context, arg GetDefaultShippingAddressParams) (GetDefaultShippingAddressRow, error)
+	// Get a discount code by its (upper-cased) code within a tenant
+	GetDiscountCodeByCode(ctx context.Context, arg GetDiscountCodeByCodeParams) (DiscountCode, error)
+	// Get a discount code by ID within a tenant
+	GetDiscountCodeByID(ctx context.Context, arg GetDiscountCodeByIDParams) (DiscountCode, error)
+	// Get a tenant's customized email by type (for admin)
+	GetEmailTemplate(ctx context.Context, arg GetEmailTemplateParams) (EmailTemplate, error)
 	// Get a valid (unused, non-expired) email verification token with user details
 	GetEmailVerificationToken(ctx context.Context, arg GetEmailVerificationTokenParams) (GetEmailVerificationTokenRow, error)
+	// Retrieves a fulfillment batch with tenant scoping
+	GetFulfillmentBatch(ctx context.Context, arg GetFulfillmentBatchParams) (FulfillmentBatch, error)
+	// Retrieves a SKU's stock and low stock threshold with its product name
+	GetInventorySKU(ctx context.Context, arg GetInventorySKUParams) (GetInventorySKURow, error)
 	// Get invoice by ID
 	GetInvoiceByID(ctx context.Context, arg GetInvoiceByIDParams) (Invoice, error)
 	// Get invoice by invoice number
@@ -406,8 +532,12 @@ type Querier interface {
 	GetInvoiceWithDetails(ctx context.Context, arg GetInvoiceWithDetailsParams) (GetInvoiceWithDetailsRow, error)
 	// Fetch a job by ID
 	GetJobByID(ctx context.Context, id pgtype.UUID) (Job, error)
+	// Fetch a job by ID within the console scope
+	GetJobInScope(ctx context.Context, arg GetJobInScopeParams) (Job, error)
 	// Get job queue statistics
 	GetJobStats(ctx context.Context, tenantID pgtype.UUID) (GetJobStatsRow, error)
+	// Most recent dunning for a subscription, open or closed
+	GetLatestSubscriptionDunning(ctx context.Context, arg GetLatestSubscriptionDunningParams) (SubscriptionDunning, error)
 	// Get a valid (non-expired) operator session by token hash
 	GetOperatorSessionByTokenHash(ctx context.Context, tokenHash string) (OperatorSession, error)
 	// Get all active sessions for an operator (for "active sessions" UI)
@@ -424,8 +554,15 @@ type Querier interface {
 	GetOrderItems(ctx context.Context, orderID pgtype.UUID) ([]GetOrderItemsRow, error)
 	// Get order items with fulfillment status for partial shipment display
 	GetOrderItemsWithFulfillment(ctx context.Context, orderID pgtype.UUID) ([]GetOrderItemsWithFulfillmentRow, error)
+	// Estimates the packed weight of an order's unshipped items
+	// SKUs without a weight fall back to the supplied default
+	GetOrderPackageWeight(ctx context.Context, arg GetOrderPackageWeightParams) (int64, error)
+	// Sums all refunds recorded against an order
+	GetOrderRefundedTotal(ctx context.Context, arg GetOrderRefundedTotalParams) (int64, error)
 	// Get order statistics for dashboard
 	GetOrderStats(ctx context.Context, arg GetOrderStatsParams) (GetOrderStatsRow, error)
+	// Gets an order template, scoped to the customer who saved it
+	GetOrderTemplateForUser(ctx context.Context, arg GetOrderTemplateForUserParams) (OrderTemplate, error)
 	// Get complete order details including addresses and payment info
 	GetOrderWithDetails(ctx context.Context, arg GetOrderWithDetailsParams) (GetOrderWithDetailsRow, error)
 	// Get order with wholesale-specific fields
@@ -462,12 +599,26 @@ type Querier interface {
 	GetProductByID(ctx context.Context, arg GetProductByIDParams) (Product, error)
 	// Get a single product by slug with all details
 	GetProductBySlug(ctx context.Context, arg GetProductBySlugParams) (Product, error)
+	// Get a single category by ID
+	GetProductCategory(ctx context.Context, arg GetProductCategoryParams) (ProductCategory, error)
+	// Get a single category by slug (includes inactive)
+	GetProductCategoryBySlug(ctx context.Context, arg GetProductCategoryBySlugParams) (ProductCategory, error)
 	// Get distinct filter values for the product filters UI
 	GetProductFilterOptions(ctx context.Context, tenantID pgtype.UUID) (GetProductFilterOptionsRow, error)
 	// Get all images for a product
 	GetProductImages(ctx context.Context, productID pgtype.UUID) ([]ProductImage, error)
+	// Get the average rating and rating distribution of a product's approved reviews
+	GetProductRatingSummary(ctx context.Context, arg GetProductRatingSummaryParams) (GetProductRatingSummaryRow, error)
+	// Get a single review by ID
+	GetProductReview(ctx context.Context, arg GetProductReviewParams) (ProductReview, error)
+	// Get a customer's review of a product
+	GetProductReviewForUser(ctx context.Context, arg GetProductReviewForUserParams) (ProductReview, error)
 	// Get all active SKUs for a product
 	GetProductSKUs(ctx context.Context, productID pgtype.UUID) ([]ProductSku, error)
+	// Get a single tag by ID
+	GetProductTag(ctx context.Context, arg GetProductTagParams) (ProductTag, error)
+	// Get a single tag by slug
+	GetProductTagBySlug(ctx context.Context, arg GetProductTagBySlugParams) (ProductTag, error)
 	// Get all products available to a specific customer
 	GetProductsForCustomer(ctx context.Context, arg GetProductsForCustomerParams) ([]Product, error)
 	// Retrieves a specific provider configuration by ID.
@@ -475,12 +626,24 @@ type Querier interface {
 	GetProviderConfig(ctx context.Context, arg GetProviderConfigParams) (TenantProviderConfig, error)
 	// Get a published page by tenant and slug (for storefront)
 	GetPublishedTenantPage(ctx context.Context, arg GetPublishedTenantPageParams) (TenantPage, error)
+	// Retrieves a refund by its provider refund ID with tenant scoping
+	GetRefundByProviderID(ctx context.Context, arg GetRefundByProviderIDParams) (Refund, error)
+	// Get a product from a customer's delivered order, for a verified purchase review
+	GetReviewableOrderProduct(ctx context.Context, arg GetReviewableOrderProductParams) (GetReviewableOrderProductRow, error)
+	// Retrieves a roast batch with its product name
+	GetRoastBatch(ctx context.Context, arg GetRoastBatchParams) (GetRoastBatchRow, error)
 	// Get a single SKU by ID
 	GetSKUByID(ctx context.Context, id pgtype.UUID) (ProductSku, error)
 	// Get a SKU with its product details (for checkout display)
 	GetSKUWithProduct(ctx context.Context, arg GetSKUWithProductParams) (GetSKUWithProductRow, error)
 	// Get session by token
 	GetSessionByToken(ctx context.Context, token string) (Session, error)
+	// Retrieves a shipment by ID with tenant scoping
+	GetShipment(ctx context.Context, arg GetShipmentParams) (Shipment, error)
+	// Finds the most recent shipment for a carrier tracking number
+	GetShipmentByTrackingNumber(ctx context.Context, arg GetShipmentByTrackingNumberParams) (Shipment, error)
+	// Retrieves one of an order's shipments with tenant scoping
+	GetShipmentForOrder(ctx context.Context, arg GetShipmentForOrderParams) (Shipment, error)
 	// Get shipment history for an order item
 	GetShipmentHistory(ctx context.Context, orderItemID pgtype.UUID) ([]GetShipmentHistoryRow, error)
 	// Get items in a shipment
@@ -498,6 +661,8 @@ type Querier interface {
 	// ============================================================================
 	// Get all skipped items for a tenant
 	GetSkippedItems(ctx context.Context, tenantID pgtype.UUID) ([]OnboardingItemSkip, error)
+	// Get a standing order, validating it belongs to the customer
+	GetStandingOrderForUser(ctx context.Context, arg GetStandingOrderForUserParams) (StandingOrder, error)
 	// Retrieves subscription by database ID with tenant scoping
 	GetSubscriptionByID(ctx context.Context, arg GetSubscriptionByIDParams) (Subscription, error)
 	// Retrieves subscription by Stripe subscription ID
@@ -506,6 +671,8 @@ type Querier interface {
 	// Subscription summary for customer account page
 	// Get subscription counts by status for a user (for account dashboard)
 	GetSubscriptionCountsForUser(ctx context.Context, arg GetSubscriptionCountsForUserParams) (GetSubscriptionCountsForUserRow, error)
+	// Open dunning whose latest reminder carried the update payment link token
+	GetSubscriptionDunningByToken(ctx context.Context, arg GetSubscriptionDunningByTokenParams) (SubscriptionDunning, error)
 	// Checks if an invoice has already been processed (idempotency)
 	// Invoice ID is stored in metadata->>'invoice_id'
 	GetSubscriptionScheduleEventByInvoiceID(ctx context.Context, arg GetSubscriptionScheduleEventByInvoiceIDParams) (SubscriptionSchedule, error)
@@ -518,6 +685,8 @@ type Querier interface {
 	GetSubscriptionWithDetails(ctx context.Context, arg GetSubscriptionWithDetailsParams) (GetSubscriptionWithDetailsRow, error)
 	// Get the active tax rate for a specific state within a tenant
 	GetTaxRateByState(ctx context.Context, arg GetTaxRateByStateParams) (TaxRate, error)
+	// Branding from the tenant's settings, '{}' if unset
+	GetTenantBranding(ctx context.Context, id pgtype.UUID) ([]byte, error)
 	// ============================================================================
 	// CUSTOM DOMAIN QUERIES
 	// ============================================================================
@@ -541,6 +710,8 @@ type Querier interface {
 	GetTenantByStripeCustomerID(ctx context.Context, stripeCustomerID pgtype.Text) (Tenant, error)
 	// Get tenant by Stripe subscription ID (for webhook processing)
 	GetTenantByStripeSubscriptionID(ctx context.Context, stripeSubscriptionID pgtype.Text) (Tenant, error)
+	// Retry schedule and final action from the tenant's settings, '{}' if unset
+	GetTenantDunningSettings(ctx context.Context, id pgtype.UUID) ([]byte, error)
 	// Get operator by email (global lookup for login)
 	GetTenantOperatorByEmail(ctx context.Context, email string) (TenantOperator, error)
 	// Get operator by email within a specific tenant
@@ -555,6 +726,10 @@ type Querier interface {
 	GetTenantOperatorBySetupToken(ctx context.Context, setupTokenHash pgtype.Text) (TenantOperator, error)
 	// Get a single page by tenant and slug
 	GetTenantPage(ctx context.Context, arg GetTenantPageParams) (TenantPage, error)
+	// Days roasted coffee rests before allocated orders can ship
+	GetTenantRoastRestDays(ctx context.Context, id pgtype.UUID) (int32, error)
+	// Cutoff and reminder lead times from the tenant's settings, '{}' if unset
+	GetTenantStandingOrderSettings(ctx context.Context, id pgtype.UUID) ([]byte, error)
 	// Checkout queries
 	// Get the primary warehouse address for a tenant (for shipping origin calculations)
 	// Used by CheckoutService.GetShippingRates to determine shipping origin
@@ -585,6 +760,8 @@ type Querier interface {
 	// Webhook event queries for subscription idempotency
 	// Check if webhook event was already processed
 	GetWebhookEventByProviderID(ctx context.Context, arg GetWebhookEventByProviderIDParams) (WebhookEvent, error)
+	// Get the base product and customer of a white-label product
+	GetWhiteLabelDetails(ctx context.Context, arg GetWhiteLabelDetailsParams) (GetWhiteLabelDetailsRow, error)
 	// Get all white-label products for a specific customer
 	GetWhiteLabelProductsForCustomer(ctx context.Context, arg GetWhiteLabelProductsForCustomerParams) ([]Product, error)
 	// =============================================================================
@@ -592,6 +769,11 @@ type Querier interface {
 	// =============================================================================
 	// Get wholesale customer with payment terms details
 	GetWholesaleCustomer(ctx context.Context, id pgtype.UUID) (GetWholesaleCustomerRow, error)
+	// Record that a worker is still running its claimed jobs
+	HeartbeatWorkerJobs(ctx context.Context, workerID pgtype.Text) (int64, error)
+	// Returns refunded units to inventory and records the restock in the
+	// inventory ledger. White-label SKUs restock their base SKU.
+	IncrementSKUStock(ctx context.Context, arg IncrementSKUStockParams) error
 	// Mark all unused email verification tokens for a user as used
 	// (Called after successful email verification to invalidate other tokens)
 	InvalidateUserEmailVerificationTokens(ctx context.Context, arg InvalidateUserEmailVerificationTokensParams) error
@@ -600,9 +782,13 @@ type Querier interface {
 	InvalidateUserPasswordResetTokens(ctx context.Context, arg InvalidateUserPasswordResetTokensParams) error
 	// Check if a specific item is skipped
 	IsItemSkipped(ctx context.Context, arg IsItemSkippedParams) (bool, error)
+	// Whether the tenant is the platform owner, whose operators can see
+	// every tenant's background jobs
+	IsMasterTenant(ctx context.Context, id pgtype.UUID) (bool, error)
 	// List all active products for a tenant with their primary image
 	ListActiveProducts(ctx context.Context, tenantID pgtype.UUID) ([]ListActiveProductsRow, error)
-	// List active products with optional filters for roast level and origin
+	// List active products with optional filters for roast level, origin, tasting
+	// note, category (including its subcategories) and tag
 	ListActiveProductsFiltered(ctx context.Context, arg ListActiveProductsFilteredParams) ([]ListActiveProductsFilteredRow, error)
 	// Lists only active/trial subscriptions for a customer
 	// Used for checking if user has active subscriptions
@@ -618,6 +804,48 @@ type Querier interface {
 	// Admin queries
 	// List all products for admin (includes inactive and all visibility levels)
 	ListAllProducts(ctx context.Context, tenantID pgtype.UUID) ([]ListAllProductsRow, error)
+	// List a product's approved reviews, most helpful first
+	ListApprovedReviewsForProduct(ctx context.Context, arg ListApprovedReviewsForProductParams) ([]ListApprovedReviewsForProductRow, error)
+	// Get each cart line with the SKU's quantity rules and the customer's
+	// override, if any
+	ListCartOrderRules(ctx context.Context, arg ListCartOrderRulesParams) ([]ListCartOrderRulesRow, error)
+	// List active categories with the number of storefront products in each,
+	// counting products in their active subcategories
+	ListCategoryFacets(ctx context.Context, tenantID pgtype.UUID) ([]ListCategoryFacetsRow, error)
+	// List the categories a product is assigned to
+	ListCategoryIDsForProduct(ctx context.Context, arg ListCategoryIDsForProductParams) ([]pgtype.UUID, error)
+	// Active SKUs a wholesale customer can order: catalog SKUs plus their own
+	// white-label SKUs
+	ListCustomerOrderableSKUs(ctx context.Context, arg ListCustomerOrderableSKUsParams) ([]ListCustomerOrderableSKUsRow, error)
+	// List a customer's quantity rule overrides alongside the SKU's own rules
+	ListCustomerSKUOrderRules(ctx context.Context, arg ListCustomerSKUOrderRulesParams) ([]ListCustomerSKUOrderRulesRow, error)
+	// List all discount codes for a tenant (admin view)
+	ListDiscountCodes(ctx context.Context, tenantID pgtype.UUID) ([]DiscountCode, error)
+	// Enabled schedules whose next run has passed. Schedules of tenants that
+	// are no longer active are skipped.
+	ListDueJobSchedules(ctx context.Context, nextRunAt pgtype.Timestamptz) ([]JobSchedule, error)
+	// Active standing orders whose cutoff has been reached
+	ListDueStandingOrders(ctx context.Context, arg ListDueStandingOrdersParams) ([]StandingOrder, error)
+	// Open dunning with a retry due
+	ListDueSubscriptionDunning(ctx context.Context, tenantID pgtype.UUID) ([]SubscriptionDunning, error)
+	// List a tenant's customized emails (for admin)
+	ListEmailTemplates(ctx context.Context, tenantID pgtype.UUID) ([]EmailTemplate, error)
+	// Dead-lettered jobs grouped by type, for the dead-letter view
+	ListFailedJobTypes(ctx context.Context, tenantID pgtype.UUID) ([]ListFailedJobTypesRow, error)
+	// Lists the orders in a batch in print order with their shipment details
+	ListFulfillmentBatchOrders(ctx context.Context, arg ListFulfillmentBatchOrdersParams) ([]ListFulfillmentBatchOrdersRow, error)
+	// Lists recent fulfillment batches, newest first
+	ListFulfillmentBatches(ctx context.Context, arg ListFulfillmentBatchesParams) ([]FulfillmentBatch, error)
+	// Paid orders with unshipped items that are not already queued in a batch
+	// and are not waiting on a roast
+	ListFulfillmentQueue(ctx context.Context, tenantID pgtype.UUID) ([]ListFulfillmentQueueRow, error)
+	// Selected orders that are still in the fulfillment queue
+	ListFulfillmentQueueByIDs(ctx context.Context, arg ListFulfillmentQueueByIDsParams) ([]ListFulfillmentQueueByIDsRow, error)
+	// Lists the stock of active SKUs on products that are not archived. A SKU is
+	// low when its stock is at or below its threshold, or out of stock when it
+	// has no threshold. White-label SKUs are left out as they sell from the stock
+	// of their base SKU.
+	ListInventorySKUs(ctx context.Context, tenantID pgtype.UUID) ([]ListInventorySKUsRow, error)
 	// List all invoices for admin with customer details
 	ListInvoices(ctx context.Context, arg ListInvoicesParams) ([]ListInvoicesRow, error)
 	// List invoices filtered by status
@@ -627,8 +855,29 @@ type Querier interface {
 	// =============================================================================
 	// List invoices for a customer
 	ListInvoicesForUser(ctx context.Context, arg ListInvoicesForUserParams) ([]Invoice, error)
+	// Pending, processing and failed counts per queue
+	ListJobQueueStats(ctx context.Context, tenantID pgtype.UUID) ([]ListJobQueueStatsRow, error)
+	// All schedules for a tenant, for the admin schedules page
+	ListJobSchedulesForTenant(ctx context.Context, tenantID pgtype.UUID) ([]JobSchedule, error)
+	// Job types present in the queue, for the console filters
+	ListJobTypes(ctx context.Context, tenantID pgtype.UUID) ([]string, error)
+	// Jobs matching the console filters, newest first. Empty filters match all.
+	ListJobs(ctx context.Context, arg ListJobsParams) ([]Job, error)
 	// List jobs by status for monitoring
 	ListJobsByStatus(ctx context.Context, arg ListJobsByStatusParams) ([]Job, error)
+	// Active SKUs at or below their low stock threshold, or out of stock when
+	// they have no threshold, emptiest first. White-label SKUs are left out as
+	// they sell from the stock of their base SKU.
+	ListLowStockSKUs(ctx context.Context, tenantID pgtype.UUID) ([]ListLowStockSKUsRow, error)
+	// Roast dates of an order's allocated items. roasted_on is NULL until the
+	// batch is roasted.
+	ListOrderItemRoastDates(ctx context.Context, arg ListOrderItemRoastDatesParams) ([]ListOrderItemRoastDatesRow, error)
+	// SKUs and quantities in an order template
+	ListOrderTemplateItems(ctx context.Context, arg ListOrderTemplateItemsParams) ([]OrderTemplateItem, error)
+	// A customer's order templates with their item counts, by name
+	ListOrderTemplatesForUser(ctx context.Context, arg ListOrderTemplatesForUserParams) ([]ListOrderTemplatesForUserRow, error)
+	// Lists tracking events for all shipments of an order, newest first
+	ListOrderTrackingEvents(ctx context.Context, arg ListOrderTrackingEventsParams) ([]ShipmentTrackingEvent, error)
 	// Admin queries
 	// List all orders for admin with pagination
 	ListOrders(ctx context.Context, arg ListOrdersParams) ([]ListOrdersRow, error)
@@ -637,6 +886,8 @@ type Querier interface {
 	// Get all orders for a specific subscription
 	// Used by subscription detail page to show order history
 	ListOrdersBySubscription(ctx context.Context, arg ListOrdersBySubscriptionParams) ([]ListOrdersBySubscriptionRow, error)
+	// List retail orders delivered in a window that haven't been sent a review request
+	ListOrdersDueForReviewRequest(ctx context.Context, arg ListOrdersDueForReviewRequestParams) ([]ListOrdersDueForReviewRequestRow, error)
 	// List orders for a user (storefront order history)
 	ListOrdersForUser(ctx context.Context, arg ListOrdersForUserParams) ([]ListOrdersForUserRow, error)
 	// List invoices that are past due
@@ -647,16 +898,73 @@ type Querier interface {
 	ListPaymentTerms(ctx context.Context, tenantID pgtype.UUID) ([]PaymentTerm, error)
 	// List all entries for a price list with product/SKU details
 	ListPriceListEntries(ctx context.Context, priceListID pgtype.UUID) ([]ListPriceListEntriesRow, error)
+	// List the quantity-break prices of every entry on a price list
+	ListPriceListTiers(ctx context.Context, arg ListPriceListTiersParams) ([]ListPriceListTiersRow, error)
+	// Get the quantity-break prices for a price list entry, lowest quantity first
+	ListPriceTiersForEntry(ctx context.Context, arg ListPriceTiersForEntryParams) ([]PriceListEntryTier, error)
+	// List all categories for a tenant with the number of products assigned directly
+	ListProductCategories(ctx context.Context, tenantID pgtype.UUID) ([]ListProductCategoriesRow, error)
+	// Roast loss of each active product, for production planning
+	ListProductRoastLoss(ctx context.Context, tenantID pgtype.UUID) ([]ListProductRoastLossRow, error)
+	// List all tags for a tenant with the number of products tagged
+	ListProductTags(ctx context.Context, tenantID pgtype.UUID) ([]ListProductTagsRow, error)
+	// Coffee to roast per product per day from start_date through end_date:
+	// unshipped items on paid orders (due on their requested delivery date, or
+	// start_date when unset or overdue), pending wholesale orders by requested
+	// delivery date, and subscription renewals by next billing date. Coffee
+	// allocated to a batch that has been roasted is left out. Grams use each
+	// SKU's bag size. White-label SKUs count towards their base product, which
+	// is the coffee that gets roasted.
+	ListProductionDemand(ctx context.Context, arg ListProductionDemandParams) ([]ListProductionDemandRow, error)
 	// Get all active products with their SKUs and prices for wholesale ordering matrix view
 	// This query denormalizes the data for efficient display in a table format
+	// White-label products are only included for their customer, with the stock
+	// of the base product SKU they draw from. Quantity rules are the SKU's, plus
+	// the customer's override if they have one.
 	ListProductsWithSKUsForWholesale(ctx context.Context, arg ListProductsWithSKUsForWholesaleParams) ([]ListProductsWithSKUsForWholesaleRow, error)
 	// Lists all provider configurations for a tenant, optionally filtered by type.
 	// Used in admin UI to show all configured providers.
 	// If type is empty string, returns all types.
 	ListProviderConfigs(ctx context.Context, arg ListProviderConfigsParams) ([]TenantProviderConfig, error)
+	// Sums refunded quantities per order line item
+	ListRefundedQuantitiesForOrder(ctx context.Context, arg ListRefundedQuantitiesForOrderParams) ([]ListRefundedQuantitiesForOrderRow, error)
+	// Lists all refunds for an order, newest first
+	ListRefundsForOrder(ctx context.Context, arg ListRefundsForOrderParams) ([]Refund, error)
+	// Items from a customer's past order with what can be ordered today. A SKU is
+	// available while it and its product are active and the customer can still
+	// see the product; white-label SKUs use the stock of their base SKU.
+	ListReorderItems(ctx context.Context, arg ListReorderItemsParams) ([]ListReorderItemsRow, error)
+	// Paid orders held out of the fulfillment queue until their coffee is roasted
+	// and rested. ready_on is NULL while any allocated batch is still planned.
+	ListRestingOrders(ctx context.Context, tenantID pgtype.UUID) ([]ListRestingOrdersRow, error)
+	// List the products in a customer's delivered orders with the customer's
+	// review of each product, if any
+	ListReviewableItemsForOrders(ctx context.Context, arg ListReviewableItemsForOrdersParams) ([]ListReviewableItemsForOrdersRow, error)
+	// List reviews with a moderation status, oldest first
+	ListReviewsForModeration(ctx context.Context, arg ListReviewsForModerationParams) ([]ListReviewsForModerationRow, error)
+	// Lists a batch's allocations with the order or subscription they are for
+	ListRoastBatchAllocations(ctx context.Context, arg ListRoastBatchAllocationsParams) ([]ListRoastBatchAllocationsRow, error)
+	// Lists batches planned or roasted on or after the given date, soonest first,
+	// with allocation totals. Allocated grams use each SKU's bag size.
+	ListRoastBatches(ctx context.Context, arg ListRoastBatchesParams) ([]ListRoastBatchesRow, error)
+	// A SKU's stock history, newest first, with what caused each change
+	ListSKUInventoryAdjustments(ctx context.Context, arg ListSKUInventoryAdjustmentsParams) ([]ListSKUInventoryAdjustmentsRow, error)
+	// Lists shipments still in transit whose tracking has not been refreshed since the cutoff
+	ListShipmentsToTrack(ctx context.Context, arg ListShipmentsToTrackParams) ([]Shipment, error)
+	// Items in a standing order with product details
+	ListStandingOrderItems(ctx context.Context, arg ListStandingOrderItemsParams) ([]ListStandingOrderItemsRow, error)
+	// All standing orders with the customer and basket size, for the admin
+	ListStandingOrders(ctx context.Context, tenantID pgtype.UUID) ([]ListStandingOrdersRow, error)
+	// A customer's standing orders, cancelled ones last
+	ListStandingOrdersForUser(ctx context.Context, arg ListStandingOrdersForUserParams) ([]StandingOrder, error)
+	// Active standing orders with a cutoff coming up that the customer hasn't
+	// been reminded about
+	ListStandingOrdersToRemind(ctx context.Context, arg ListStandingOrdersToRemindParams) ([]ListStandingOrdersToRemindRow, error)
 	// Lists all items in a subscription with product details
 	// Includes product name, SKU, and image for display
 	ListSubscriptionItemsForSubscription(ctx context.Context, arg ListSubscriptionItemsForSubscriptionParams) ([]ListSubscriptionItemsForSubscriptionRow, error)
+	// Lists a subscription's events, newest first, for its history
+	ListSubscriptionScheduleEvents(ctx context.Context, arg ListSubscriptionScheduleEventsParams) ([]SubscriptionSchedule, error)
 	// Admin queries
 	// List all subscriptions for admin with pagination
 	ListSubscriptions(ctx context.Context, arg ListSubscriptionsParams) ([]ListSubscriptionsRow, error)
@@ -666,12 +974,18 @@ type Querier interface {
 	// Lists all subscriptions for a customer with pagination
 	// Returns newest first
 	ListSubscriptionsForUser(ctx context.Context, arg ListSubscriptionsForUserParams) ([]Subscription, error)
+	// List tags on at least one storefront product with their product counts
+	ListTagFacets(ctx context.Context, tenantID pgtype.UUID) ([]ListTagFacetsRow, error)
+	// List the tags on a product
+	ListTagIDsForProduct(ctx context.Context, arg ListTagIDsForProductParams) ([]pgtype.UUID, error)
 	// List all tax rates for a tenant (admin view)
 	ListTaxRates(ctx context.Context, tenantID pgtype.UUID) ([]TaxRate, error)
 	// List all operators for a tenant (for future multi-user support)
 	ListTenantOperators(ctx context.Context, tenantID pgtype.UUID) ([]TenantOperator, error)
 	// List all pages for a tenant (for admin)
 	ListTenantPages(ctx context.Context, tenantID pgtype.UUID) ([]TenantPage, error)
+	// Lists tracking events for the given orders' shipments, newest first
+	ListTrackingEventsForOrders(ctx context.Context, arg ListTrackingEventsForOrdersParams) ([]ListTrackingEventsForOrdersRow, error)
 	// Lists upcoming scheduled events for processing
 	// Used by background job to process subscription renewals
 	ListUpcomingScheduleEvents(ctx context.Context, arg ListUpcomingScheduleEventsParams) ([]ListUpcomingScheduleEventsRow, error)
@@ -679,6 +993,8 @@ type Querier interface {
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
 	// List users filtered by account type
 	ListUsersByAccountType(ctx context.Context, arg ListUsersByAccountTypeParams) ([]User, error)
+	// List the white-label variants of a base product with their customers
+	ListWhiteLabelProducts(ctx context.Context, arg ListWhiteLabelProductsParams) ([]ListWhiteLabelProductsRow, error)
 	// List pending wholesale applications
 	ListWholesaleApplications(ctx context.Context, tenantID pgtype.UUID) ([]ListWholesaleApplicationsRow, error)
 	// List wholesale customers with payment terms and billing info
@@ -688,6 +1004,12 @@ type Querier interface {
 	// =============================================================================
 	// List wholesale orders with customer details
 	ListWholesaleOrders(ctx context.Context, arg ListWholesaleOrdersParams) ([]ListWholesaleOrdersRow, error)
+	// Lock a customer row for the rest of the transaction so their on-account
+	// orders are placed one at a time and each credit check sees the last order
+	LockCustomerForOrder(ctx context.Context, arg LockCustomerForOrderParams) (pgtype.UUID, error)
+	// Lock a discount code row for the rest of the transaction so usage limit
+	// checks and the usage insert are serialized across concurrent orders.
+	LockDiscountCode(ctx context.Context, arg LockDiscountCodeParams) (DiscountCode, error)
 	// Mark domain verification as failed with error message
 	// Parameters:
 	//   $1: tenant_id (UUID)
@@ -701,14 +1023,64 @@ type Querier interface {
 	MarkDomainVerifying(ctx context.Context, id pgtype.UUID) error
 	// Mark an email verification token as used
 	MarkEmailVerificationTokenUsed(ctx context.Context, arg MarkEmailVerificationTokenUsedParams) error
+	// Records why a label could not be bought for a batch order
+	MarkFulfillmentBatchOrderFailed(ctx context.Context, arg MarkFulfillmentBatchOrderFailedParams) error
+	// Records the shipment created for a batch order
+	MarkFulfillmentBatchOrderPurchased(ctx context.Context, arg MarkFulfillmentBatchOrderPurchasedParams) error
 	// Mark invoice as viewed (first view only)
 	MarkInvoiceViewed(ctx context.Context, arg MarkInvoiceViewedParams) error
+	// Marks a shipped, fully fulfilled order delivered once all of its shipments are delivered
+	MarkOrderDeliveredIfComplete(ctx context.Context, arg MarkOrderDeliveredIfCompleteParams) (int64, error)
 	// Mark a password reset token as used
 	MarkPasswordResetTokenUsed(ctx context.Context, arg MarkPasswordResetTokenUsedParams) error
+	MarkStandingOrderReminded(ctx context.Context, arg MarkStandingOrderRemindedParams) error
+	// Approve or reject a review
+	ModerateProductReview(ctx context.Context, arg ModerateProductReviewParams) (ProductReview, error)
+	// Take back processing jobs whose worker stopped sending heartbeats, e.g.
+	// after a crash or a deploy that killed the process. The lost run counts
+	// as an attempt, so a job that keeps killing its worker ends up failed.
+	ReapStaleJobs(ctx context.Context, staleBefore pgtype.Timestamptz) ([]ReapStaleJobsRow, error)
 	// Update order fulfillment status based on item statuses
 	RecalculateOrderFulfillmentStatus(ctx context.Context, arg RecalculateOrderFulfillmentStatusParams) error
+	// Count one use of a discount code and record the redemption. Callers must
+	// hold the row lock from LockDiscountCode and have checked the limits.
+	RecordDiscountCodeUsage(ctx context.Context, arg RecordDiscountCodeUsageParams) error
+	// Records the roast date and yield; may be repeated to correct them
+	RecordRoastBatchRoasted(ctx context.Context, arg RecordRoastBatchRoastedParams) (RoastBatch, error)
+	// Records the outcome of placing a claimed delivery. last_order_id is kept
+	// when the attempt failed.
+	RecordStandingOrderGenerated(ctx context.Context, arg RecordStandingOrderGeneratedParams) error
+	// Records a failed retry. next_attempt_at is null after the final retry.
+	RecordSubscriptionDunningAttempt(ctx context.Context, arg RecordSubscriptionDunningAttemptParams) (SubscriptionDunning, error)
+	// Recount the helpful and not helpful votes on a review
+	RefreshReviewHelpfulCounts(ctx context.Context, arg RefreshReviewHelpfulCountsParams) error
+	// Returns dispatched units to the unfulfilled pool when a shipment is cancelled
+	ReleaseOrderItemDispatchedQuantity(ctx context.Context, arg ReleaseOrderItemDispatchedQuantityParams) error
+	// Put a stopping worker's unfinished jobs back in the queue without using
+	// up a retry
+	ReleaseWorkerJobs(ctx context.Context, workerID pgtype.Text) (int64, error)
 	// Remove an item from cart
 	RemoveCartItem(ctx context.Context, arg RemoveCartItemParams) error
+	// Move the subcategories of a category to a new parent (NULL for top level)
+	ReparentProductCategories(ctx context.Context, arg ReparentProductCategoriesParams) error
+	// Set or clear the roaster's public reply to a review
+	ReplyToProductReview(ctx context.Context, arg ReplyToProductReviewParams) (ProductReview, error)
+	// Requeue every dead-lettered (failed) job of a type, e.g. after an
+	// outage. failed_since limits it to jobs that failed from then on.
+	RequeueFailedJobs(ctx context.Context, arg RequeueFailedJobsParams) (int64, error)
+	// Put a pending, failed or cancelled job back in the queue to run at
+	// scheduled_at. Failed and cancelled jobs get a fresh set of retries; the
+	// previous attempt's error stays in job_history.
+	RequeueJob(ctx context.Context, arg RequeueJobParams) (Job, error)
+	// Closes dunning as recovered, paused or cancelled
+	ResolveSubscriptionDunning(ctx context.Context, arg ResolveSubscriptionDunningParams) error
+	// Closes dunning as recovered once its invoice is paid
+	ResolveSubscriptionDunningForInvoice(ctx context.Context, arg ResolveSubscriptionDunningForInvoiceParams) (int64, error)
+	// Returns a cancelled order's units to inventory and records the restock in
+	// the inventory ledger. White-label SKUs restock their base SKU.
+	RestockCancelledOrderItem(ctx context.Context, arg RestockCancelledOrderItemParams) error
+	// Link a cart to the signed-in customer it's priced for
+	SetCartUser(ctx context.Context, arg SetCartUserParams) error
 	// ============================================================================
 	// CUSTOM DOMAIN MANAGEMENT
 	// ============================================================================
@@ -731,12 +1103,19 @@ type Querier interface {
 	SetOperatorResetToken(ctx context.Context, arg SetOperatorResetTokenParams) error
 	// Set or refresh setup token for an operator
 	SetOperatorSetupToken(ctx context.Context, arg SetOperatorSetupTokenParams) error
+	// Records why an order's status changed on the history row the status
+	// trigger wrote for it
+	SetOrderStatusChangeReason(ctx context.Context, arg SetOrderStatusChangeReasonParams) error
 	// Set a product image as primary (and unset others)
 	SetPrimaryImage(ctx context.Context, arg SetPrimaryImageParams) error
 	// Update tenant status
 	SetTenantStatus(ctx context.Context, arg SetTenantStatusParams) error
 	// Mark an item as skipped (idempotent - updates timestamp if already skipped)
 	SkipItem(ctx context.Context, arg SkipItemParams) (OnboardingItemSkip, error)
+	// Moves a standing order to its following delivery
+	SkipStandingOrderDelivery(ctx context.Context, arg SkipStandingOrderDeliveryParams) (StandingOrder, error)
+	// Marks a batch as being processed by the background worker
+	StartFulfillmentBatch(ctx context.Context, arg StartFulfillmentBatchParams) error
 	// Start grace period after payment failure
 	StartTenantGracePeriod(ctx context.Context, id pgtype.UUID) error
 	// Submit a wholesale application (updates user profile with business info)
@@ -749,6 +1128,12 @@ type Querier interface {
 	TenantPageExists(ctx context.Context, arg TenantPageExistsParams) (bool, error)
 	// Check if a slug is already taken
 	TenantSlugExists(ctx context.Context, slug string) (bool, error)
+	// Records that tracking was refreshed without a status change
+	TouchShipmentTrackingChecked(ctx context.Context, arg TouchShipmentTrackingCheckedParams) error
+	// Remove a product from every category not in the given list
+	UnassignProductCategoriesExcept(ctx context.Context, arg UnassignProductCategoriesExceptParams) error
+	// Remove every tag not in the given list from a product
+	UnassignProductTagsExcept(ctx context.Context, arg UnassignProductTagsExceptParams) error
 	// Removes is_default flag from all providers of a given type for a tenant.
 	// Used before setting a new default provider.
 	UnsetDefaultProvider(ctx context.Context, arg UnsetDefaultProviderParams) error
@@ -758,6 +1143,8 @@ type Querier interface {
 	UpdateAddress(ctx context.Context, arg UpdateAddressParams) (Address, error)
 	// Update a billing customer
 	UpdateBillingCustomer(ctx context.Context, arg UpdateBillingCustomerParams) (BillingCustomer, error)
+	// Update the unit price of a cart item after its quantity or price list changes
+	UpdateCartItemPrice(ctx context.Context, arg UpdateCartItemPriceParams) error
 	// Update quantity of a cart item
 	UpdateCartItemQuantity(ctx context.Context, arg UpdateCartItemQuantityParams) error
 	// Ensures sufficient stock
@@ -770,10 +1157,14 @@ type Querier interface {
 	//   $2: is_healthy (BOOLEAN) - true if CNAME still valid
 	//   $3: error_message (TEXT) - NULL if healthy, error message if unhealthy
 	UpdateCustomDomainHealthCheck(ctx context.Context, arg UpdateCustomDomainHealthCheckParams) error
+	// Update an existing discount code
+	UpdateDiscountCode(ctx context.Context, arg UpdateDiscountCodeParams) (DiscountCode, error)
 	// Link invoice to billing provider
 	UpdateInvoiceProviderID(ctx context.Context, arg UpdateInvoiceProviderIDParams) error
 	// Update invoice status
 	UpdateInvoiceStatus(ctx context.Context, arg UpdateInvoiceStatusParams) error
+	// Records a run (or a failed attempt to enqueue one) and the next run time
+	UpdateJobScheduleRun(ctx context.Context, arg UpdateJobScheduleRunParams) error
 	// Update last login timestamp
 	UpdateOperatorLastLogin(ctx context.Context, id pgtype.UUID) error
 	// Update operator password (for password resets)
@@ -793,6 +1184,8 @@ type Querier interface {
 	UpdateOrderPaymentID(ctx context.Context, arg UpdateOrderPaymentIDParams) error
 	// Update order status
 	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) error
+	// Updates the refunded amount and status of a payment
+	UpdatePaymentRefund(ctx context.Context, arg UpdatePaymentRefundParams) error
 	// Update payment status
 	UpdatePaymentStatus(ctx context.Context, arg UpdatePaymentStatusParams) (Payment, error)
 	// Update payment terms
@@ -803,19 +1196,39 @@ type Querier interface {
 	UpdatePriceListEntry(ctx context.Context, arg UpdatePriceListEntryParams) (PriceListEntry, error)
 	// Update an existing product
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error)
+	// Update an existing category
+	UpdateProductCategory(ctx context.Context, arg UpdateProductCategoryParams) (ProductCategory, error)
 	// Update an existing product image
 	UpdateProductImage(ctx context.Context, arg UpdateProductImageParams) (ProductImage, error)
-	// Update an existing product SKU
+	// Sets the percent of green weight a product loses when roasted
+	UpdateProductRoastLoss(ctx context.Context, arg UpdateProductRoastLossParams) (int64, error)
+	// Update an existing product SKU. Stock changes go through the inventory
+	// ledger (AdjustSKUInventory).
 	UpdateProductSKU(ctx context.Context, arg UpdateProductSKUParams) (ProductSku, error)
+	// Rename a tag
+	UpdateProductTag(ctx context.Context, arg UpdateProductTagParams) (ProductTag, error)
 	// Updates an existing provider configuration.
 	// Note: Changing is_default requires handling the previous default.
 	UpdateProviderConfig(ctx context.Context, arg UpdateProviderConfigParams) (TenantProviderConfig, error)
+	// Updates the plan of a batch that has not been cancelled
+	UpdateRoastBatch(ctx context.Context, arg UpdateRoastBatchParams) (RoastBatch, error)
+	// Sets the stock level at which a SKU is reported as low. NULL reports it
+	// only when out of stock.
+	UpdateSKULowStockThreshold(ctx context.Context, arg UpdateSKULowStockThresholdParams) (int64, error)
 	// Update session data and extend expiration
 	UpdateSessionData(ctx context.Context, arg UpdateSessionDataParams) error
 	// Update shipment status
 	UpdateShipmentStatus(ctx context.Context, arg UpdateShipmentStatusParams) error
+	// Changes the name, cadence, delivery day and address. A moved delivery
+	// date gets a new reminder.
+	UpdateStandingOrderSchedule(ctx context.Context, arg UpdateStandingOrderScheduleParams) (StandingOrder, error)
+	// Pauses, resumes or cancels a standing order, moving the next delivery
+	// when resuming
+	UpdateStandingOrderStatus(ctx context.Context, arg UpdateStandingOrderStatusParams) (StandingOrder, error)
 	// Marks subscription as cancelled or scheduled for cancellation
 	UpdateSubscriptionCancellation(ctx context.Context, arg UpdateSubscriptionCancellationParams) (Subscription, error)
+	// Swaps a subscription item's SKU or quantity, at the price of the new SKU
+	UpdateSubscriptionItem(ctx context.Context, arg UpdateSubscriptionItemParams) (SubscriptionItem, error)
 	// Updates subscription status for pause/resume operations
 	UpdateSubscriptionPauseResume(ctx context.Context, arg UpdateSubscriptionPauseResumeParams) (Subscription, error)
 	// Updates subscription with Stripe subscription ID after creation
@@ -823,15 +1236,26 @@ type Querier interface {
 	UpdateSubscriptionProviderID(ctx context.Context, arg UpdateSubscriptionProviderIDParams) (Subscription, error)
 	// Updates subscription schedule event status after processing
 	UpdateSubscriptionScheduleEvent(ctx context.Context, arg UpdateSubscriptionScheduleEventParams) (SubscriptionSchedule, error)
+	// Applies a customer's change to frequency, next delivery, shipping address,
+	// payment method or price. NULL leaves a field unchanged.
+	UpdateSubscriptionSettings(ctx context.Context, arg UpdateSubscriptionSettingsParams) (Subscription, error)
 	// Updates subscription status and related timestamps
 	// Used when syncing from Stripe webhooks
 	UpdateSubscriptionStatus(ctx context.Context, arg UpdateSubscriptionStatusParams) (Subscription, error)
 	// Update an existing tax rate
 	UpdateTaxRate(ctx context.Context, arg UpdateTaxRateParams) (TaxRate, error)
+	// Sets the branding in the tenant's settings
+	UpdateTenantBranding(ctx context.Context, arg UpdateTenantBrandingParams) error
+	// Sets the retry schedule and final action in the tenant's settings
+	UpdateTenantDunningSettings(ctx context.Context, arg UpdateTenantDunningSettingsParams) error
 	// Update an existing page
 	UpdateTenantPage(ctx context.Context, arg UpdateTenantPageParams) (TenantPage, error)
 	// Update tenant profile information
 	UpdateTenantProfile(ctx context.Context, arg UpdateTenantProfileParams) (Tenant, error)
+	// Sets the rest period in the tenant's settings
+	UpdateTenantRoastRestDays(ctx context.Context, arg UpdateTenantRoastRestDaysParams) error
+	// Sets the cutoff and reminder lead times in the tenant's settings
+	UpdateTenantStandingOrderSettings(ctx context.Context, arg UpdateTenantStandingOrderSettingsParams) error
 	// Set Stripe customer ID for a tenant
 	UpdateTenantStripeCustomer(ctx context.Context, arg UpdateTenantStripeCustomerParams) error
 	// Set Stripe subscription ID for a tenant
@@ -850,8 +1274,22 @@ type Querier interface {
 	UpdateWholesaleApplicationWithTerms(ctx context.Context, arg UpdateWholesaleApplicationWithTermsParams) error
 	// Update wholesale customer settings
 	UpdateWholesaleCustomer(ctx context.Context, arg UpdateWholesaleCustomerParams) error
+	// Set a customer's quantity rules for a SKU, replacing any existing override
+	UpsertCustomerSKUOrderRule(ctx context.Context, arg UpsertCustomerSKUOrderRuleParams) (CustomerSkuOrderRule, error)
+	// Create or update a tenant's customized email
+	UpsertEmailTemplate(ctx context.Context, arg UpsertEmailTemplateParams) (EmailTemplate, error)
+	// Creates a customer's order template, or touches the one with the same name
+	// so its items can be replaced
+	UpsertOrderTemplate(ctx context.Context, arg UpsertOrderTemplateParams) (OrderTemplate, error)
 	// Create or update a price list entry
 	UpsertPriceListEntry(ctx context.Context, arg UpsertPriceListEntryParams) error
+	// Create or update the quantity-break price at a quantity. Returns no row if
+	// the entry isn't on the tenant's price list.
+	UpsertPriceListEntryTier(ctx context.Context, arg UpsertPriceListEntryTierParams) (PriceListEntryTier, error)
+	// Record or change a customer's helpful vote on a review
+	UpsertReviewHelpfulness(ctx context.Context, arg UpsertReviewHelpfulnessParams) error
+	// Adds a SKU to a standing order or sets its quantity
+	UpsertStandingOrderItem(ctx context.Context, arg UpsertStandingOrderItemParams) error
 	// Create or update a page (useful for seeding defaults)
 	UpsertTenantPage(ctx context.Context, arg UpsertTenantPageParams) (TenantPage, error)
 	// ============================================================================
@@ -863,6 +1301,8 @@ type Querier interface {
 	ValidateDomainForCaddy(ctx context.Context, customDomain pgtype.Text) (bool, error)
 	// Mark user email as verified
 	VerifyUserEmail(ctx context.Context, id pgtype.UUID) error
+	// Marks a shipment's label as voided and cancels the shipment
+	VoidShipmentLabel(ctx context.Context, arg VoidShipmentLabelParams) error
 }
 
 var _ Querier = (*Queries)(nil)
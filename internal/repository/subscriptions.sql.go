@@ -11,6 +11,37 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const closeScheduledSubscriptionEvents = `-- name: CloseScheduledSubscriptionEvents :exec
+UPDATE subscription_schedule
+SET
+    status = $4,
+    processed_at = NOW(),
+    updated_at = NOW()
+WHERE subscription_id = $1
+  AND tenant_id = $2
+  AND event_type = $3
+  AND status = 'scheduled'
+`
+
+type CloseScheduledSubscriptionEventsParams struct {
+	SubscriptionID pgtype.UUID `json:"subscription_id"`
+	TenantID       pgtype.UUID `json:"tenant_id"`
+	EventType      string      `json:"event_type"`
+	Status         string      `json:"status"`
+}
+
+// Marks a subscription's still-scheduled events of one type as completed or
+// cancelled, e.g. a pending resume once the subscription is resumed
+func (q *Queries) CloseScheduledSubscriptionEvents(ctx context.Context, arg CloseScheduledSubscriptionEventsParams) error {
+	_, err := q.db.Exec(ctx, closeScheduledSubscriptionEvents,
+		arg.SubscriptionID,
+		arg.TenantID,
+		arg.EventType,
+		arg.Status,
+	)
+	return err
+}
+
 const countSubscriptions = `-- name: CountSubscriptions :one
 SELECT COUNT(*)
 FROM subscriptions
@@ -1021,6 +1052,57 @@ func (q *Queries) ListSubscriptionItemsForSubscription(ctx context.Context, arg
 	return items, nil
 }
 
+const listSubscriptionScheduleEvents = `-- name: ListSubscriptionScheduleEvents :many
+SELECT id, tenant_id, subscription_id, event_type, status, order_id, payment_id, error_message, retry_count, scheduled_at, processed_at, failed_at, metadata, created_at, updated_at FROM subscription_schedule
+WHERE subscription_id = $1
+  AND tenant_id = $2
+ORDER BY created_at DESC
+LIMIT $3
+`
+
+type ListSubscriptionScheduleEventsParams struct {
+	SubscriptionID pgtype.UUID `json:"subscription_id"`
+	TenantID       pgtype.UUID `json:"tenant_id"`
+	Limit          int32       `json:"limit"`
+}
+
+// Lists a subscription's events, newest first, for its history
+func (q *Queries) ListSubscriptionScheduleEvents(ctx context.Context, arg ListSubscriptionScheduleEventsParams) ([]SubscriptionSchedule, error) {
+	rows, err := q.db.Query(ctx, listSubscriptionScheduleEvents, arg.SubscriptionID, arg.TenantID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SubscriptionSchedule{}
+	for rows.Next() {
+		var i SubscriptionSchedule
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.SubscriptionID,
+			&i.EventType,
+			&i.Status,
+			&i.OrderID,
+			&i.PaymentID,
+			&i.ErrorMessage,
+			&i.RetryCount,
+			&i.ScheduledAt,
+			&i.ProcessedAt,
+			&i.FailedAt,
+			&i.Metadata,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSubscriptions = `-- name: ListSubscriptions :many
 
 SELECT
@@ -1379,6 +1461,49 @@ func (q *Queries) UpdateSubscriptionCancellation(ctx context.Context, arg Update
 	return i, err
 }
 
+const updateSubscriptionItem = `-- name: UpdateSubscriptionItem :one
+UPDATE subscription_items
+SET
+    product_sku_id = $3,
+    quantity = $4,
+    unit_price_cents = $5,
+    updated_at = NOW()
+WHERE id = $1 AND tenant_id = $2
+RETURNING id, tenant_id, subscription_id, product_sku_id, quantity, unit_price_cents, metadata, created_at, updated_at
+`
+
+type UpdateSubscriptionItemParams struct {
+	ID             pgtype.UUID `json:"id"`
+	TenantID       pgtype.UUID `json:"tenant_id"`
+	ProductSkuID   pgtype.UUID `json:"product_sku_id"`
+	Quantity       int32       `json:"quantity"`
+	UnitPriceCents int32       `json:"unit_price_cents"`
+}
+
+// Swaps a subscription item's SKU or quantity, at the price of the new SKU
+func (q *Queries) UpdateSubscriptionItem(ctx context.Context, arg UpdateSubscriptionItemParams) (SubscriptionItem, error) {
+	row := q.db.QueryRow(ctx, updateSubscriptionItem,
+		arg.ID,
+		arg.TenantID,
+		arg.ProductSkuID,
+		arg.Quantity,
+		arg.UnitPriceCents,
+	)
+	var i SubscriptionItem
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.SubscriptionID,
+		&i.ProductSkuID,
+		&i.Quantity,
+		&i.UnitPriceCents,
+		&i.Metadata,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateSubscriptionPauseResume = `-- name: UpdateSubscriptionPauseResume :one
 UPDATE subscriptions
 SET
@@ -1544,6 +1669,75 @@ func (q *Queries) UpdateSubscriptionScheduleEvent(ctx context.Context, arg Updat
 	return i, err
 }
 
+const updateSubscriptionSettings = `-- name: UpdateSubscriptionSettings :one
+UPDATE subscriptions
+SET
+    billing_interval = COALESCE($3, billing_interval),
+    next_billing_date = COALESCE($4, next_billing_date),
+    shipping_address_id = COALESCE($5, shipping_address_id),
+    payment_method_id = COALESCE($6, payment_method_id),
+    subtotal_cents = COALESCE($7, subtotal_cents),
+    total_cents = COALESCE($7 + shipping_cents + tax_cents, total_cents),
+    updated_at = NOW()
+WHERE id = $1 AND tenant_id = $2
+RETURNING id, tenant_id, user_id, subscription_plan_id, billing_interval, status, billing_customer_id, provider, provider_subscription_id, subtotal_cents, tax_cents, total_cents, currency, shipping_address_id, shipping_method_id, shipping_cents, payment_method_id, trial_ends_at, current_period_start, current_period_end, next_billing_date, cancel_at_period_end, cancelled_at, cancellation_reason, metadata, created_at, updated_at
+`
+
+type UpdateSubscriptionSettingsParams struct {
+	ID                pgtype.UUID        `json:"id"`
+	TenantID          pgtype.UUID        `json:"tenant_id"`
+	BillingInterval   pgtype.Text        `json:"billing_interval"`
+	NextBillingDate   pgtype.Timestamptz `json:"next_billing_date"`
+	ShippingAddressID pgtype.UUID        `json:"shipping_address_id"`
+	PaymentMethodID   pgtype.UUID        `json:"payment_method_id"`
+	SubtotalCents     pgtype.Int4        `json:"subtotal_cents"`
+}
+
+// Applies a customer's change to frequency, next delivery, shipping address,
+// payment method or price. NULL leaves a field unchanged.
+func (q *Queries) UpdateSubscriptionSettings(ctx context.Context, arg UpdateSubscriptionSettingsParams) (Subscription, error) {
+	row := q.db.QueryRow(ctx, updateSubscriptionSettings,
+		arg.ID,
+		arg.TenantID,
+		arg.BillingInterval,
+		arg.NextBillingDate,
+		arg.ShippingAddressID,
+		arg.PaymentMethodID,
+		arg.SubtotalCents,
+	)
+	var i Subscription
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.UserID,
+		&i.SubscriptionPlanID,
+		&i.BillingInterval,
+		&i.Status,
+		&i.BillingCustomerID,
+		&i.Provider,
+		&i.ProviderSubscriptionID,
+		&i.SubtotalCents,
+		&i.TaxCents,
+		&i.TotalCents,
+		&i.Currency,
+		&i.ShippingAddressID,
+		&i.ShippingMethodID,
+		&i.ShippingCents,
+		&i.PaymentMethodID,
+		&i.TrialEndsAt,
+		&i.CurrentPeriodStart,
+		&i.CurrentPeriodEnd,
+		&i.NextBillingDate,
+		&i.CancelAtPeriodEnd,
+		&i.CancelledAt,
+		&i.CancellationReason,
+		&i.Metadata,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateSubscriptionStatus = `-- name: UpdateSubscriptionStatus :one
 UPDATE subscriptions
 SET
@@ -337,6 +337,21 @@ func (q *Queries) GetTenantsWithExpiredGracePeriod(ctx context.Context) ([]Tenan
 	return items, nil
 }
 
+const isMasterTenant = `-- name: IsMasterTenant :one
+SELECT COALESCE((settings->>'is_master')::BOOLEAN, FALSE)::BOOLEAN
+FROM tenants
+WHERE id = $1
+`
+
+// Whether the tenant is the platform owner, whose operators can see
+// every tenant's background jobs
+func (q *Queries) IsMasterTenant(ctx context.Context, id pgtype.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, isMasterTenant, id)
+	var column_1 bool
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
 const listActiveTenants = `-- name: ListActiveTenants :many
 SELECT id, name, slug, email, phone, website, business_name, tax_id, settings, status, trial_ends_at, created_at, updated_at, stripe_customer_id, stripe_subscription_id, grace_period_started_at, custom_domain, custom_domain_status, custom_domain_verification_token, custom_domain_verified_at, custom_domain_activated_at, custom_domain_last_checked_at, custom_domain_error_message
 FROM tenants
@@ -87,7 +87,7 @@ INSERT INTO users (
 ) VALUES (
     $1, $2, $3, $4, $5, 'admin', 'active', true
 ) ON CONFLICT (tenant_id, email) DO NOTHING
-RETURNING id, tenant_id, email, password_hash, email_verified, account_type, first_name, last_name, phone, company_name, tax_id, business_type, status, wholesale_application_status, wholesale_application_notes, wholesale_approved_at, wholesale_approved_by, payment_terms, metadata, created_at, updated_at, internal_note, minimum_spend_cents, email_orders, email_dispatches, email_invoices, payment_terms_id, billing_cycle, billing_cycle_day, customer_reference
+RETURNING id, tenant_id, email, password_hash, email_verified, account_type, first_name, last_name, phone, company_name, tax_id, business_type, status, wholesale_application_status, wholesale_application_notes, wholesale_approved_at, wholesale_approved_by, payment_terms, metadata, created_at, updated_at, internal_note, minimum_spend_cents, email_orders, email_dispatches, email_invoices, payment_terms_id, billing_cycle, billing_cycle_day, customer_reference, credit_limit_cents
 `
 
 type CreateAdminUserParams struct {
@@ -140,6 +140,7 @@ func (q *Queries) CreateAdminUser(ctx context.Context, arg CreateAdminUserParams
 		&i.BillingCycle,
 		&i.BillingCycleDay,
 		&i.CustomerReference,
+		&i.CreditLimitCents,
 	)
 	return i, err
 }
@@ -155,7 +156,7 @@ INSERT INTO users (
     status
 ) VALUES (
     $1, $2, $3, $4, $5, 'retail', 'active'
-) RETURNING id, tenant_id, email, password_hash, email_verified, account_type, first_name, last_name, phone, company_name, tax_id, business_type, status, wholesale_application_status, wholesale_application_notes, wholesale_approved_at, wholesale_approved_by, payment_terms, metadata, created_at, updated_at, internal_note, minimum_spend_cents, email_orders, email_dispatches, email_invoices, payment_terms_id, billing_cycle, billing_cycle_day, customer_reference
+) RETURNING id, tenant_id, email, password_hash, email_verified, account_type, first_name, last_name, phone, company_name, tax_id, business_type, status, wholesale_application_status, wholesale_application_notes, wholesale_approved_at, wholesale_approved_by, payment_terms, metadata, created_at, updated_at, internal_note, minimum_spend_cents, email_orders, email_dispatches, email_invoices, payment_terms_id, billing_cycle, billing_cycle_day, customer_reference, credit_limit_cents
 `
 
 type CreateUserParams struct {
@@ -207,6 +208,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.BillingCycle,
 		&i.BillingCycleDay,
 		&i.CustomerReference,
+		&i.CreditLimitCents,
 	)
 	return i, err
 }
@@ -278,7 +280,7 @@ func (q *Queries) GetCustomersForBillingCycle(ctx context.Context, arg GetCustom
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, tenant_id, email, password_hash, email_verified, account_type, first_name, last_name, phone, company_name, tax_id, business_type, status, wholesale_application_status, wholesale_application_notes, wholesale_approved_at, wholesale_approved_by, payment_terms, metadata, created_at, updated_at, internal_note, minimum_spend_cents, email_orders, email_dispatches, email_invoices, payment_terms_id, billing_cycle, billing_cycle_day, customer_reference
+SELECT id, tenant_id, email, password_hash, email_verified, account_type, first_name, last_name, phone, company_name, tax_id, business_type, status, wholesale_application_status, wholesale_application_notes, wholesale_approved_at, wholesale_approved_by, payment_terms, metadata, created_at, updated_at, internal_note, minimum_spend_cents, email_orders, email_dispatches, email_invoices, payment_terms_id, billing_cycle, billing_cycle_day, customer_reference, credit_limit_cents
 FROM users
 WHERE tenant_id = $1
   AND email = $2
@@ -326,12 +328,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, arg GetUserByEmailParams)
 		&i.BillingCycle,
 		&i.BillingCycleDay,
 		&i.CustomerReference,
+		&i.CreditLimitCents,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, tenant_id, email, password_hash, email_verified, account_type, first_name, last_name, phone, company_name, tax_id, business_type, status, wholesale_application_status, wholesale_application_notes, wholesale_approved_at, wholesale_approved_by, payment_terms, metadata, created_at, updated_at, internal_note, minimum_spend_cents, email_orders, email_dispatches, email_invoices, payment_terms_id, billing_cycle, billing_cycle_day, customer_reference
+SELECT id, tenant_id, email, password_hash, email_verified, account_type, first_name, last_name, phone, company_name, tax_id, business_type, status, wholesale_application_status, wholesale_application_notes, wholesale_approved_at, wholesale_approved_by, payment_terms, metadata, created_at, updated_at, internal_note, minimum_spend_cents, email_orders, email_dispatches, email_invoices, payment_terms_id, billing_cycle, billing_cycle_day, customer_reference, credit_limit_cents
 FROM users
 WHERE id = $1
   AND status != 'closed'
@@ -373,12 +376,13 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 		&i.BillingCycle,
 		&i.BillingCycleDay,
 		&i.CustomerReference,
+		&i.CreditLimitCents,
 	)
 	return i, err
 }
 
 const getUserByIDAndTenant = `-- name: GetUserByIDAndTenant :one
-SELECT id, tenant_id, email, password_hash, email_verified, account_type, first_name, last_name, phone, company_name, tax_id, business_type, status, wholesale_application_status, wholesale_application_notes, wholesale_approved_at, wholesale_approved_by, payment_terms, metadata, created_at, updated_at, internal_note, minimum_spend_cents, email_orders, email_dispatches, email_invoices, payment_terms_id, billing_cycle, billing_cycle_day, customer_reference
+SELECT id, tenant_id, email, password_hash, email_verified, account_type, first_name, last_name, phone, company_name, tax_id, business_type, status, wholesale_application_status, wholesale_application_notes, wholesale_approved_at, wholesale_approved_by, payment_terms, metadata, created_at, updated_at, internal_note, minimum_spend_cents, email_orders, email_dispatches, email_invoices, payment_terms_id, billing_cycle, billing_cycle_day, customer_reference, credit_limit_cents
 FROM users
 WHERE id = $1
   AND tenant_id = $2
@@ -426,6 +430,7 @@ func (q *Queries) GetUserByIDAndTenant(ctx context.Context, arg GetUserByIDAndTe
 		&i.BillingCycle,
 		&i.BillingCycleDay,
 		&i.CustomerReference,
+		&i.CreditLimitCents,
 	)
 	return i, err
 }
@@ -497,7 +502,7 @@ func (q *Queries) GetUserStats(ctx context.Context, tenantID pgtype.UUID) (GetUs
 const getWholesaleCustomer = `-- name: GetWholesaleCustomer :one
 
 SELECT
-    u.id, u.tenant_id, u.email, u.password_hash, u.email_verified, u.account_type, u.first_name, u.last_name, u.phone, u.company_name, u.tax_id, u.business_type, u.status, u.wholesale_application_status, u.wholesale_application_notes, u.wholesale_approved_at, u.wholesale_approved_by, u.payment_terms, u.metadata, u.created_at, u.updated_at, u.internal_note, u.minimum_spend_cents, u.email_orders, u.email_dispatches, u.email_invoices, u.payment_terms_id, u.billing_cycle, u.billing_cycle_day, u.customer_reference,
+    u.id, u.tenant_id, u.email, u.password_hash, u.email_verified, u.account_type, u.first_name, u.last_name, u.phone, u.company_name, u.tax_id, u.business_type, u.status, u.wholesale_application_status, u.wholesale_application_notes, u.wholesale_approved_at, u.wholesale_approved_by, u.payment_terms, u.metadata, u.created_at, u.updated_at, u.internal_note, u.minimum_spend_cents, u.email_orders, u.email_dispatches, u.email_invoices, u.payment_terms_id, u.billing_cycle, u.billing_cycle_day, u.customer_reference, u.credit_limit_cents,
     pt.name as payment_terms_name,
     pt.code as payment_terms_code,
     pt.days as payment_terms_days,
@@ -543,6 +548,7 @@ type GetWholesaleCustomerRow struct {
 	BillingCycle               pgtype.Text        `json:"billing_cycle"`
 	BillingCycleDay            pgtype.Int4        `json:"billing_cycle_day"`
 	CustomerReference          pgtype.Text        `json:"customer_reference"`
+	CreditLimitCents           pgtype.Int4        `json:"credit_limit_cents"`
 	PaymentTermsName           pgtype.Text        `json:"payment_terms_name"`
 	PaymentTermsCode           pgtype.Text        `json:"payment_terms_code"`
 	PaymentTermsDays           pgtype.Int4        `json:"payment_terms_days"`
@@ -587,6 +593,7 @@ func (q *Queries) GetWholesaleCustomer(ctx context.Context, id pgtype.UUID) (Get
 		&i.BillingCycle,
 		&i.BillingCycleDay,
 		&i.CustomerReference,
+		&i.CreditLimitCents,
 		&i.PaymentTermsName,
 		&i.PaymentTermsCode,
 		&i.PaymentTermsDays,
@@ -596,7 +603,7 @@ func (q *Queries) GetWholesaleCustomer(ctx context.Context, id pgtype.UUID) (Get
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, tenant_id, email, password_hash, email_verified, account_type, first_name, last_name, phone, company_name, tax_id, business_type, status, wholesale_application_status, wholesale_application_notes, wholesale_approved_at, wholesale_approved_by, payment_terms, metadata, created_at, updated_at, internal_note, minimum_spend_cents, email_orders, email_dispatches, email_invoices, payment_terms_id, billing_cycle, billing_cycle_day, customer_reference
+SELECT id, tenant_id, email, password_hash, email_verified, account_type, first_name, last_name, phone, company_name, tax_id, business_type, status, wholesale_application_status, wholesale_application_notes, wholesale_approved_at, wholesale_approved_by, payment_terms, metadata, created_at, updated_at, internal_note, minimum_spend_cents, email_orders, email_dispatches, email_invoices, payment_terms_id, billing_cycle, billing_cycle_day, customer_reference, credit_limit_cents
 FROM users
 WHERE tenant_id = $1
   AND status != 'closed'
@@ -651,6 +658,7 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.BillingCycle,
 			&i.BillingCycleDay,
 			&i.CustomerReference,
+			&i.CreditLimitCents,
 		); err != nil {
 			return nil, err
 		}
@@ -663,7 +671,7 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 }
 
 const listUsersByAccountType = `-- name: ListUsersByAccountType :many
-SELECT id, tenant_id, email, password_hash, email_verified, account_type, first_name, last_name, phone, company_name, tax_id, business_type, status, wholesale_application_status, wholesale_application_notes, wholesale_approved_at, wholesale_approved_by, payment_terms, metadata, created_at, updated_at, internal_note, minimum_spend_cents, email_orders, email_dispatches, email_invoices, payment_terms_id, billing_cycle, billing_cycle_day, customer_reference
+SELECT id, tenant_id, email, password_hash, email_verified, account_type, first_name, last_name, phone, company_name, tax_id, business_type, status, wholesale_application_status, wholesale_application_notes, wholesale_approved_at, wholesale_approved_by, payment_terms, metadata, created_at, updated_at, internal_note, minimum_spend_cents, email_orders, email_dispatches, email_invoices, payment_terms_id, billing_cycle, billing_cycle_day, customer_reference, credit_limit_cents
 FROM users
 WHERE tenant_id = $1
   AND account_type = $2
@@ -717,6 +725,7 @@ func (q *Queries) ListUsersByAccountType(ctx context.Context, arg ListUsersByAcc
 			&i.BillingCycle,
 			&i.BillingCycleDay,
 			&i.CustomerReference,
+			&i.CreditLimitCents,
 		); err != nil {
 			return nil, err
 		}
@@ -874,6 +883,28 @@ func (q *Queries) ListWholesaleCustomers(ctx context.Context, arg ListWholesaleC
 	return items, nil
 }
 
+const lockCustomerForOrder = `-- name: LockCustomerForOrder :one
+SELECT id
+FROM users
+WHERE tenant_id = $1
+  AND id = $2
+FOR UPDATE
+`
+
+type LockCustomerForOrderParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	ID       pgtype.UUID `json:"id"`
+}
+
+// Lock a customer row for the rest of the transaction so their on-account
+// orders are placed one at a time and each credit check sees the last order
+func (q *Queries) LockCustomerForOrder(ctx context.Context, arg LockCustomerForOrderParams) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, lockCustomerForOrder, arg.TenantID, arg.ID)
+	var id pgtype.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const submitWholesaleApplication = `-- name: SubmitWholesaleApplication :exec
 UPDATE users
 SET
@@ -1053,6 +1084,7 @@ SET
     email_orders = $9,
     email_dispatches = $10,
     email_invoices = $11,
+    credit_limit_cents = $12,
     updated_at = NOW()
 WHERE id = $1
 `
@@ -1069,6 +1101,7 @@ type UpdateWholesaleCustomerParams struct {
 	EmailOrders       pgtype.Text `json:"email_orders"`
 	EmailDispatches   pgtype.Text `json:"email_dispatches"`
 	EmailInvoices     pgtype.Text `json:"email_invoices"`
+	CreditLimitCents  pgtype.Int4 `json:"credit_limit_cents"`
 }
 
 // Update wholesale customer settings
@@ -1085,6 +1118,7 @@ func (q *Queries) UpdateWholesaleCustomer(ctx context.Context, arg UpdateWholesa
 		arg.EmailOrders,
 		arg.EmailDispatches,
 		arg.EmailInvoices,
+		arg.CreditLimitCents,
 	)
 	return err
 }
@@ -50,6 +50,28 @@ func RegisterAdminRoutes(
 	admin.Get("/admin/products/{id}/edit", deps.ProductHandler.ShowForm)
 	admin.Post("/admin/products/{id}/edit", deps.ProductHandler.HandleForm)
 
+	// White-label products
+	admin.Get("/admin/products/{id}/white-label/new", deps.WhiteLabelHandler.ShowForm)
+	admin.Post("/admin/products/{id}/white-label/new", deps.WhiteLabelHandler.Create)
+
+	// Category and tag management
+	admin.Get("/admin/categories", deps.CategoryHandler.List)
+	admin.Get("/admin/categories/new", deps.CategoryHandler.ShowForm)
+	admin.Post("/admin/categories/new", deps.CategoryHandler.HandleForm)
+	admin.Get("/admin/categories/{id}/edit", deps.CategoryHandler.ShowForm)
+	admin.Post("/admin/categories/{id}/edit", deps.CategoryHandler.HandleForm)
+	admin.Post("/admin/categories/{id}/delete", deps.CategoryHandler.Delete)
+	admin.Get("/admin/tags", deps.CategoryHandler.ListTags)
+	admin.Post("/admin/tags/new", deps.CategoryHandler.CreateTag)
+	admin.Post("/admin/tags/{id}/edit", deps.CategoryHandler.UpdateTag)
+	admin.Post("/admin/tags/{id}/delete", deps.CategoryHandler.DeleteTag)
+
+	// Review moderation
+	admin.Get("/admin/reviews", deps.ReviewHandler.List)
+	admin.Post("/admin/reviews/{id}/approve", deps.ReviewHandler.Approve)
+	admin.Post("/admin/reviews/{id}/reject", deps.ReviewHandler.Reject)
+	admin.Post("/admin/reviews/{id}/reply", deps.ReviewHandler.Reply)
+
 	// SKU management
 	admin.Get("/admin/products/{product_id}/skus/new", deps.ProductHandler.ShowSKUForm)
 	admin.Post("/admin/products/{product_id}/skus/new", deps.ProductHandler.HandleSKUForm)
@@ -69,6 +91,40 @@ func RegisterAdminRoutes(
 	admin.Get("/admin/orders/{id}", deps.OrderHandler.Detail)
 	admin.Post("/admin/orders/{id}/status", deps.OrderHandler.UpdateStatus)
 	admin.Post("/admin/orders/{id}/shipments", deps.OrderHandler.CreateShipment)
+	admin.Post("/admin/orders/{id}/refund", deps.OrderHandler.Refund)
+	admin.Post("/admin/orders/{id}/cancel", deps.OrderHandler.Cancel)
+	admin.Post("/admin/orders/{id}/labels/rates", deps.OrderHandler.LabelRates)
+	admin.Post("/admin/orders/{id}/labels", deps.OrderHandler.BuyLabel)
+	admin.Post("/admin/orders/{id}/shipments/{shipmentID}/void", deps.OrderHandler.VoidLabel)
+	admin.Get("/admin/orders/{id}/shipments/{shipmentID}/label", deps.OrderHandler.Label)
+
+	// Fulfillment queue and batch labels
+	admin.Get("/admin/fulfillment", deps.FulfillmentHandler.Queue)
+	admin.Get("/admin/fulfillment/pick-list", deps.FulfillmentHandler.PickList)
+	admin.Post("/admin/fulfillment/batches", deps.FulfillmentHandler.CreateBatch)
+	admin.Get("/admin/fulfillment/batches/{id}", deps.FulfillmentHandler.Batch)
+	admin.Get("/admin/fulfillment/batches/{id}/document", deps.FulfillmentHandler.Document)
+
+	// Inventory
+	admin.Get("/admin/inventory", deps.InventoryHandler.List)
+	admin.Get("/admin/inventory/skus/{id}", deps.InventoryHandler.Detail)
+	admin.Post("/admin/inventory/skus/{id}/adjust", deps.InventoryHandler.Adjust)
+	admin.Post("/admin/inventory/skus/{id}/threshold", deps.InventoryHandler.SaveThreshold)
+
+	// Roast schedule
+	admin.Get("/admin/roasts", deps.RoastHandler.List)
+	admin.Post("/admin/roasts", deps.RoastHandler.Create)
+	admin.Post("/admin/roasts/rest-days", deps.RoastHandler.SaveRestDays)
+	admin.Get("/admin/roasts/plan", deps.RoastHandler.Plan)
+	admin.Get("/admin/roasts/plan/export", deps.RoastHandler.PlanCSV)
+	admin.Post("/admin/roasts/plan/roast-loss", deps.RoastHandler.SaveRoastLoss)
+	admin.Get("/admin/roasts/{id}", deps.RoastHandler.Detail)
+	admin.Post("/admin/roasts/{id}", deps.RoastHandler.Update)
+	admin.Post("/admin/roasts/{id}/roasted", deps.RoastHandler.Record)
+	admin.Post("/admin/roasts/{id}/cancel", deps.RoastHandler.Cancel)
+	admin.Post("/admin/roasts/{id}/allocate/orders", deps.RoastHandler.AllocateOrders)
+	admin.Post("/admin/roasts/{id}/allocate/renewals", deps.RoastHandler.AllocateRenewals)
+	admin.Post("/admin/roasts/{id}/allocations/{allocationID}/delete", deps.RoastHandler.RemoveAllocation)
 
 	// Customer management
 	admin.Get("/admin/customers", deps.CustomerHandler.List)
@@ -76,9 +132,12 @@ func RegisterAdminRoutes(
 	admin.Get("/admin/customers/{id}/edit", deps.CustomerHandler.Edit)
 	admin.Post("/admin/customers/{id}", deps.CustomerHandler.Update)
 	admin.Post("/admin/customers/{id}/wholesale/{action}", deps.CustomerHandler.WholesaleApproval)
+	admin.Post("/admin/customers/{id}/order-rules", deps.CustomerHandler.SetOrderRule)
+	admin.Post("/admin/customers/{id}/order-rules/{sku_id}/delete", deps.CustomerHandler.DeleteOrderRule)
 
 	// Subscription management
 	admin.Get("/admin/subscriptions", deps.SubscriptionHandler.List)
+	admin.Post("/admin/subscriptions/dunning", deps.SubscriptionHandler.SaveDunningSettings)
 	admin.Get("/admin/subscriptions/{id}", deps.SubscriptionHandler.Detail)
 
 	// Invoice management
@@ -91,6 +150,10 @@ func RegisterAdminRoutes(
 	admin.Get("/admin/invoices/{id}/payment", deps.InvoiceHandler.ShowPaymentForm)
 	admin.Post("/admin/invoices/{id}/payment", deps.InvoiceHandler.HandlePayment)
 
+	// Standing orders
+	admin.Get("/admin/standing-orders", deps.StandingOrderHandler.List)
+	admin.Post("/admin/standing-orders/settings", deps.StandingOrderHandler.SaveSettings)
+
 	// Price list management
 	admin.Get("/admin/price-lists", deps.PriceListHandler.List)
 	admin.Get("/admin/price-lists/new", deps.PriceListHandler.ShowForm)
@@ -99,8 +162,18 @@ func RegisterAdminRoutes(
 	admin.Get("/admin/price-lists/{id}/edit", deps.PriceListHandler.ShowForm)
 	admin.Post("/admin/price-lists/{id}/edit", deps.PriceListHandler.HandleForm)
 	admin.Post("/admin/price-lists/{id}/entries", deps.PriceListHandler.UpdateEntry)
+	admin.Post("/admin/price-lists/{id}/tiers", deps.PriceListHandler.SaveTier)
+	admin.Post("/admin/price-lists/{id}/tiers/{tier_id}/delete", deps.PriceListHandler.DeleteTier)
 	admin.Post("/admin/price-lists/{id}/delete", deps.PriceListHandler.Delete)
 
+	// Discount code management
+	admin.Get("/admin/discounts", deps.DiscountHandler.List)
+	admin.Get("/admin/discounts/new", deps.DiscountHandler.ShowForm)
+	admin.Post("/admin/discounts/new", deps.DiscountHandler.HandleForm)
+	admin.Get("/admin/discounts/{id}/edit", deps.DiscountHandler.ShowForm)
+	admin.Post("/admin/discounts/{id}/edit", deps.DiscountHandler.HandleForm)
+	admin.Post("/admin/discounts/{id}/delete", deps.DiscountHandler.Delete)
+
 	// Settings: Tax rates
 	admin.Get("/admin/settings/tax-rates", deps.TaxRateHandler.ListPage)
 	admin.Post("/admin/settings/tax-rates", deps.TaxRateHandler.Create)
@@ -121,12 +194,44 @@ func RegisterAdminRoutes(
 	admin.Post("/admin/settings/domain/activate", deps.CustomDomainHandler.ActivateDomain)
 	admin.Delete("/admin/settings/domain", deps.CustomDomainHandler.RemoveDomain)
 
+	// Settings: Scheduled jobs
+	admin.Get("/admin/settings/schedules", deps.ScheduleHandler.ListPage)
+
+	// Background job console
+	admin.Get("/admin/jobs", deps.JobHandler.List)
+	admin.Get("/admin/jobs/dead-letter", deps.JobHandler.DeadLetter)
+	admin.Post("/admin/jobs/requeue", deps.JobHandler.Requeue)
+	admin.Get("/admin/jobs/{id}", deps.JobHandler.Detail)
+	admin.Post("/admin/jobs/{id}/retry", deps.JobHandler.Retry)
+	admin.Post("/admin/jobs/{id}/cancel", deps.JobHandler.Cancel)
+	admin.Post("/admin/jobs/{id}/reschedule", deps.JobHandler.Reschedule)
+	admin.Get("/admin/api/jobs", deps.JobHandler.ListJSON)
+	admin.Post("/admin/api/jobs/requeue", deps.JobHandler.RequeueJSON)
+	admin.Get("/admin/api/jobs/{id}", deps.JobHandler.GetJSON)
+	admin.Post("/admin/api/jobs/{id}/retry", deps.JobHandler.RetryJSON)
+	admin.Post("/admin/api/jobs/{id}/cancel", deps.JobHandler.CancelJSON)
+	admin.Post("/admin/api/jobs/{id}/reschedule", deps.JobHandler.RescheduleJSON)
+
 	// Settings: Store pages
 	admin.Get("/admin/settings/pages", deps.PageHandler.ListPage)
 	admin.Get("/admin/settings/pages/{slug}", deps.PageHandler.EditPage)
 	admin.Post("/admin/settings/pages/{slug}", deps.PageHandler.UpdatePage)
 	admin.Post("/admin/settings/pages/initialize", deps.PageHandler.InitializePages)
 
+	// Settings: Transactional emails
+	admin.Get("/admin/settings/emails", deps.EmailTemplateHandler.List)
+	admin.Get("/admin/settings/emails/{type}", deps.EmailTemplateHandler.Edit)
+	admin.Post("/admin/settings/emails/{type}", deps.EmailTemplateHandler.Save)
+	admin.Post("/admin/settings/emails/{type}/preview", deps.EmailTemplateHandler.Preview)
+	admin.Post("/admin/settings/emails/{type}/test", deps.EmailTemplateHandler.SendTest)
+	admin.Post("/admin/settings/emails/{type}/reset", deps.EmailTemplateHandler.Reset)
+
+	// Settings: Branding (logo, favicon, colors and fonts)
+	admin.Get("/admin/settings/branding", deps.BrandingHandler.Show)
+	admin.Post("/admin/settings/branding", deps.BrandingHandler.Update)
+	admin.Post("/admin/settings/branding/{kind}", deps.BrandingHandler.UploadImage)
+	admin.Post("/admin/settings/branding/{kind}/remove", deps.BrandingHandler.RemoveImage)
+
 	// Onboarding checklist
 	admin.Get("/admin/onboarding", deps.OnboardingHandler.GetStatus)
 	admin.Get("/admin/api/onboarding", deps.OnboardingHandler.GetStatusJSON)
@@ -3,6 +3,7 @@ package routes
 import (
 	"net/http"
 
+	"github.com/dukerupert/hiri/internal/domain"
 	"github.com/dukerupert/hiri/internal/handler/admin"
 	"github.com/dukerupert/hiri/internal/handler/api"
 	"github.com/dukerupert/hiri/internal/handler/saas"
@@ -35,12 +36,24 @@ type SaaSDeps struct {
 
 // StorefrontDeps contains dependencies for storefront routes
 type StorefrontDeps struct {
+	// UserService loads the signed-in customer from the session cookie
+	UserService domain.UserService
+
+	// BrandingService loads the tenant's branding for the storefront layout
+	BrandingService domain.BrandingService
+
 	// Home
 	HomeHandler http.Handler
 
 	// Products (consolidated: list, detail, subscription products)
 	ProductHandler *storefront.ProductHandler
 
+	// Category landing pages
+	CollectionHandler *storefront.CollectionHandler
+
+	// Product reviews (submission and helpful votes)
+	ReviewHandler *storefront.ReviewHandler
+
 	// Cart
 	CartHandler *storefront.CartHandler
 
@@ -56,9 +69,13 @@ type StorefrontDeps struct {
 	// Account (consolidated: dashboard, orders, addresses, payment methods, profile)
 	AccountHandler *storefront.AccountHandler
 
+	// Reorder (refill the cart from a past order)
+	ReorderHandler *storefront.ReorderHandler
+
 	// Wholesale
 	WholesaleApplicationHandler *storefront.WholesaleApplicationHandler
 	WholesaleOrderingHandler    *storefront.WholesaleOrderingHandler
+	StandingOrderHandler        *storefront.StandingOrderHandler
 
 	// Static pages (legal, about, contact, etc.)
 	PagesHandler *storefront.PagesHandler
@@ -76,11 +93,23 @@ type AdminDeps struct {
 	DashboardHandler http.Handler
 
 	// Products
-	ProductHandler *admin.ProductHandler
+	ProductHandler    *admin.ProductHandler
+	WhiteLabelHandler *admin.WhiteLabelHandler
+	CategoryHandler   *admin.CategoryHandler
+	ReviewHandler     *admin.ReviewHandler
 
 	// Orders
 	OrderHandler *admin.OrderHandler
 
+	// Fulfillment
+	FulfillmentHandler *admin.FulfillmentHandler
+
+	// Roast schedule
+	RoastHandler *admin.RoastHandler
+
+	// Inventory
+	InventoryHandler *admin.InventoryHandler
+
 	// Customers
 	CustomerHandler *admin.CustomerHandler
 
@@ -90,14 +119,26 @@ type AdminDeps struct {
 	// Invoices
 	InvoiceHandler *admin.InvoiceHandler
 
+	// Standing orders
+	StandingOrderHandler *admin.StandingOrderHandler
+
 	// Price Lists
 	PriceListHandler *admin.PriceListHandler
 
+	// Discounts
+	DiscountHandler *admin.DiscountHandler
+
 	// Settings
-	TaxRateHandler      *admin.TaxRateHandler
-	IntegrationsHandler *admin.IntegrationsHandler
-	CustomDomainHandler *admin.CustomDomainHandler
-	PageHandler         *admin.PageHandler
+	TaxRateHandler       *admin.TaxRateHandler
+	IntegrationsHandler  *admin.IntegrationsHandler
+	CustomDomainHandler  *admin.CustomDomainHandler
+	PageHandler          *admin.PageHandler
+	EmailTemplateHandler *admin.EmailTemplateHandler
+	BrandingHandler      *admin.BrandingHandler
+	ScheduleHandler      *admin.ScheduleHandler
+
+	// Background jobs
+	JobHandler *admin.JobHandler
 
 	// Onboarding
 	OnboardingHandler *admin.OnboardingHandler
@@ -105,7 +146,8 @@ type AdminDeps struct {
 
 // WebhookDeps contains dependencies for webhook routes
 type WebhookDeps struct {
-	StripeHandler http.HandlerFunc
+	StripeHandler   http.HandlerFunc
+	EasyPostHandler http.HandlerFunc
 }
 
 // APIDeps contains dependencies for API routes
@@ -10,24 +10,20 @@ import (
 // RegisterStorefrontRoutes registers all customer-facing storefront routes.
 // These routes are for the tenant's e-commerce storefront.
 //
-// When multi-tenancy is enabled, these routes will be served per-tenant
-// based on subdomain or custom domain.
-//
-// If tenantMiddleware is provided and not nil, it will be applied to wrap
-// all storefront routes with tenant resolution. This should be the
-// middleware.ResolveTenant middleware configured with TenantConfig.
+// tenantMiddleware puts the tenant for the request on the context. This is
+// middleware.ResolveTenant when routing by host (subdomain or custom domain),
+// or middleware.WithTenant for the configured tenant in single-tenant mode.
+// Handlers and services read the tenant from the context, so every storefront
+// route is wrapped with RequireTenant. The customer session is loaded after
+// the tenant is resolved, so a session only applies within its own tenant,
+// followed by the tenant's branding for the storefront layout.
 func RegisterStorefrontRoutes(r *router.Router, deps StorefrontDeps, tenantMiddleware func(http.Handler) http.Handler) {
-	// If tenant middleware is provided, wrap all storefront routes
-	var storefrontRouter *router.Router
-	if tenantMiddleware != nil {
-		storefrontRouter = r.Group(
-			tenantMiddleware,
-			middleware.RequireTenant,
-		)
-	} else {
-		// No tenant middleware - use router directly (single-tenant mode)
-		storefrontRouter = r
-	}
+	storefrontRouter := r.Group(
+		tenantMiddleware,
+		middleware.RequireTenant,
+		middleware.WithUser(deps.UserService),
+		middleware.WithBranding(deps.BrandingService),
+	)
 
 	// Home page
 	storefrontRouter.Get("/", deps.HomeHandler.ServeHTTP)
@@ -40,6 +36,7 @@ func RegisterStorefrontRoutes(r *router.Router, deps StorefrontDeps, tenantMiddl
 	// Product browsing
 	storefrontRouter.Get("/products", deps.ProductHandler.List)
 	storefrontRouter.Get("/products/{slug}", deps.ProductHandler.Detail)
+	storefrontRouter.Get("/collections/{slug}", deps.CollectionHandler.Show)
 
 	// Shopping cart
 	storefrontRouter.Get("/cart", deps.CartHandler.View)
@@ -47,12 +44,17 @@ func RegisterStorefrontRoutes(r *router.Router, deps StorefrontDeps, tenantMiddl
 	storefrontRouter.Post("/cart/update", deps.CartHandler.Update)
 	storefrontRouter.Post("/cart/remove", deps.CartHandler.Remove)
 
-	// Authentication (GET routes only - POST routes registered separately with rate limiting)
+	// Authentication
 	storefrontRouter.Get("/signup", deps.AuthHandler.ShowSignupForm)
 	storefrontRouter.Get("/signup-success", deps.AuthHandler.ShowSignupSuccess)
 	storefrontRouter.Get("/login", deps.AuthHandler.ShowLoginForm)
 	storefrontRouter.Post("/logout", deps.AuthHandler.HandleLogout)
 
+	// Login and signup submissions get stricter rate limiting
+	authRouter := storefrontRouter.Group(middleware.StrictRateLimit())
+	authRouter.Post("/login", deps.AuthHandler.HandleLogin)
+	authRouter.Post("/signup", deps.AuthHandler.HandleSignup)
+
 	// Password Reset
 	storefrontRouter.Get("/forgot-password", deps.AuthHandler.ShowForgotPasswordForm)
 	storefrontRouter.Post("/forgot-password", deps.AuthHandler.HandleForgotPassword)
@@ -70,15 +72,20 @@ func RegisterStorefrontRoutes(r *router.Router, deps StorefrontDeps, tenantMiddl
 	storefrontRouter.Post("/checkout/shipping-rates", deps.CheckoutHandler.GetShippingRates)
 	storefrontRouter.Post("/checkout/calculate-total", deps.CheckoutHandler.CalculateTotal)
 	storefrontRouter.Post("/checkout/create-payment-intent", deps.CheckoutHandler.CreatePaymentIntent)
+	storefrontRouter.Post("/checkout/place-order-on-account", deps.CheckoutHandler.PlaceOrderOnAccount)
 	storefrontRouter.Get("/order-confirmation", deps.CheckoutHandler.OrderConfirmation)
 
 	// Subscription product selection (public)
 	storefrontRouter.Get("/subscribe", deps.ProductHandler.SubscribeProducts)
 
+	// Update payment method link from failed payment emails (token is the credential)
+	storefrontRouter.Get("/subscriptions/update-payment/{token}", deps.SubscriptionHandler.UpdatePayment)
+
 	// Account routes (require authentication)
 	account := storefrontRouter.Group(middleware.RequireAuth)
 	account.Get("/account", deps.AccountHandler.Dashboard)
 	account.Get("/account/orders", deps.AccountHandler.OrderList)
+	account.Post("/account/orders/{id}/reorder", deps.ReorderHandler.Reorder)
 	account.Get("/account/addresses", deps.AccountHandler.AddressList)
 	account.Post("/account/addresses", deps.AccountHandler.AddressCreate)
 	account.Post("/account/addresses/{id}", deps.AccountHandler.AddressUpdate)
@@ -88,9 +95,22 @@ func RegisterStorefrontRoutes(r *router.Router, deps StorefrontDeps, tenantMiddl
 	account.Get("/account/subscriptions", deps.SubscriptionHandler.List)
 	account.Get("/account/subscriptions/portal", deps.SubscriptionHandler.Portal)
 	account.Get("/account/subscriptions/{id}", deps.SubscriptionHandler.Detail)
+	account.Post("/account/subscriptions/{id}/skip", deps.SubscriptionHandler.Skip)
+	account.Post("/account/subscriptions/{id}/frequency", deps.SubscriptionHandler.ChangeFrequency)
+	account.Post("/account/subscriptions/{id}/item", deps.SubscriptionHandler.ChangeItem)
+	account.Post("/account/subscriptions/{id}/address", deps.SubscriptionHandler.ChangeAddress)
+	account.Post("/account/subscriptions/{id}/payment-method", deps.SubscriptionHandler.ChangePaymentMethod)
+	account.Post("/account/subscriptions/{id}/pause", deps.SubscriptionHandler.Pause)
+	account.Post("/account/subscriptions/{id}/resume", deps.SubscriptionHandler.Resume)
+	account.Post("/account/subscriptions/{id}/cancel", deps.SubscriptionHandler.Cancel)
 	account.Get("/subscribe/checkout", deps.SubscriptionHandler.Checkout)
 	account.Post("/subscribe", deps.SubscriptionHandler.Create)
 
+	// Product reviews (require authentication)
+	account.Get("/account/reviews/new", deps.ReviewHandler.New)
+	account.Post("/account/reviews", deps.ReviewHandler.Create)
+	account.Post("/reviews/{id}/helpful", deps.ReviewHandler.Vote)
+
 	// Wholesale application (require authentication)
 	account.Get("/wholesale/apply", deps.WholesaleApplicationHandler.Form)
 	account.Post("/wholesale/apply", deps.WholesaleApplicationHandler.Submit)
@@ -99,6 +119,19 @@ func RegisterStorefrontRoutes(r *router.Router, deps StorefrontDeps, tenantMiddl
 	// Wholesale ordering (require authentication + wholesale account)
 	account.Get("/wholesale/order", deps.WholesaleOrderingHandler.Order)
 	account.Post("/wholesale/cart/batch", deps.WholesaleOrderingHandler.BatchAdd)
+	account.Post("/wholesale/templates", deps.WholesaleOrderingHandler.SaveTemplate)
+	account.Post("/wholesale/templates/{id}/delete", deps.WholesaleOrderingHandler.DeleteTemplate)
+
+	// Standing orders (require authentication + payment terms)
+	account.Get("/account/standing-orders", deps.StandingOrderHandler.List)
+	account.Post("/account/standing-orders", deps.StandingOrderHandler.Create)
+	account.Get("/account/standing-orders/{id}", deps.StandingOrderHandler.Detail)
+	account.Post("/account/standing-orders/{id}/items", deps.StandingOrderHandler.UpdateItems)
+	account.Post("/account/standing-orders/{id}/schedule", deps.StandingOrderHandler.UpdateSchedule)
+	account.Post("/account/standing-orders/{id}/skip", deps.StandingOrderHandler.Skip)
+	account.Post("/account/standing-orders/{id}/pause", deps.StandingOrderHandler.Pause)
+	account.Post("/account/standing-orders/{id}/resume", deps.StandingOrderHandler.Resume)
+	account.Post("/account/standing-orders/{id}/cancel", deps.StandingOrderHandler.Cancel)
 
 	// Payment methods (require authentication)
 	account.Get("/account/payment-methods", deps.AccountHandler.PaymentMethodList)
@@ -12,4 +12,5 @@ import (
 // signature (e.g., Stripe signature verification).
 func RegisterWebhookRoutes(r *router.Router, deps WebhookDeps) {
 	r.Post("/webhooks/stripe", deps.StripeHandler)
+	r.Post("/webhooks/easypost", deps.EasyPostHandler)
 }
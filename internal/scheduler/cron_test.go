@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"too few fields", "0 2 * *"},
+		{"too many fields", "0 2 * * * *"},
+		{"minute out of range", "60 * * * *"},
+		{"hour out of range", "0 24 * * *"},
+		{"day of month zero", "0 0 0 * *"},
+		{"backwards range", "0 5-2 * * *"},
+		{"zero step", "*/0 * * * *"},
+		{"not a number", "0 two * * *"},
+		{"unknown descriptor", "@fortnightly"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCron(tt.spec)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCron_Next(t *testing.T) {
+	// Wednesday
+	from := time.Date(2026, time.March, 11, 14, 37, 20, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		spec string
+		want time.Time
+	}{
+		{"every minute", "* * * * *", time.Date(2026, time.March, 11, 14, 38, 0, 0, time.UTC)},
+		{"every 15 minutes", "*/15 * * * *", time.Date(2026, time.March, 11, 14, 45, 0, 0, time.UTC)},
+		{"hourly", "@hourly", time.Date(2026, time.March, 11, 15, 0, 0, 0, time.UTC)},
+		{"every two hours", "0 */2 * * *", time.Date(2026, time.March, 11, 16, 0, 0, 0, time.UTC)},
+		{"nightly, later today", "30 22 * * *", time.Date(2026, time.March, 11, 22, 30, 0, 0, time.UTC)},
+		{"nightly, tomorrow", "0 2 * * *", time.Date(2026, time.March, 12, 2, 0, 0, 0, time.UTC)},
+		{"list of hours", "0 6,18 * * *", time.Date(2026, time.March, 11, 18, 0, 0, 0, time.UTC)},
+		{"weekdays", "0 9 * * 1-5", time.Date(2026, time.March, 12, 9, 0, 0, 0, time.UTC)},
+		{"sunday as 7", "0 0 * * 7", time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"weekly", "@weekly", time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"monthly", "@monthly", time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"month rolls into next year", "0 0 1 1 *", time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"31st skips short months", "0 0 31 * *", time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC)},
+		{"day of month or day of week", "0 0 20 * 5", time.Date(2026, time.March, 13, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.spec)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, cron.Next(from))
+		})
+	}
+}
+
+func TestCron_Next_OnTheMinute(t *testing.T) {
+	cron, err := ParseCron("0 2 * * *")
+	require.NoError(t, err)
+
+	// A run due now is not returned again
+	at := time.Date(2026, time.March, 11, 2, 0, 0, 0, time.UTC)
+	assert.Equal(t, at.AddDate(0, 0, 1), cron.Next(at))
+}
+
+func TestCron_Next_NeverMatches(t *testing.T) {
+	cron, err := ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+
+	assert.True(t, cron.Next(time.Now()).IsZero())
+}
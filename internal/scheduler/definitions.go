@@ -0,0 +1,144 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Scope says whether a schedule runs once per tenant or once for the
+// whole platform.
+type Scope string
+
+const (
+	// ScopeTenant jobs get one schedule per active tenant
+	ScopeTenant Scope = "tenant"
+
+	// ScopeSystem jobs have a single schedule with no tenant, and the
+	// job they enqueue works across all tenants
+	ScopeSystem Scope = "system"
+)
+
+// EnqueueFunc enqueues the job for one run of a schedule. tenantID is
+// uuid.Nil for system schedules. runAt is the time the run was due.
+type EnqueueFunc func(ctx context.Context, q repository.Querier, tenantID uuid.UUID, runAt time.Time) error
+
+// Definition describes a recurring job and its default schedule. New
+// tenants get the default cron expression; an existing schedule row keeps
+// the expression stored on it.
+type Definition struct {
+	JobType     string
+	Name        string
+	Description string
+	Scope       Scope
+	DefaultSpec string
+	Enqueue     EnqueueFunc
+}
+
+// DefaultDefinitions returns the platform's recurring jobs. Times are UTC.
+func DefaultDefinitions() []Definition {
+	return []Definition{
+		{
+			JobType:     jobs.JobTypeMarkOverdueInvoices,
+			Name:        "Mark overdue invoices",
+			Description: "Marks unpaid invoices past their due date as overdue and emails the customer",
+			Scope:       ScopeTenant,
+			DefaultSpec: "0 2 * * *",
+			Enqueue: func(ctx context.Context, q repository.Querier, tenantID uuid.UUID, _ time.Time) error {
+				return jobs.EnqueueMarkOverdueInvoices(ctx, q, tenantID, time.Now())
+			},
+		},
+		{
+			JobType:     jobs.JobTypeGenerateConsolidatedInvoice,
+			Name:        "Consolidated invoices",
+			Description: "Invoices wholesale customers whose weekly, biweekly or monthly billing period ended",
+			Scope:       ScopeTenant,
+			DefaultSpec: "0 3 * * *",
+			Enqueue:     enqueueConsolidatedInvoices,
+		},
+		{
+			JobType:     jobs.JobTypePollShipmentTracking,
+			Name:        "Shipment tracking",
+			Description: "Polls carriers for shipments that have not had a tracking update",
+			Scope:       ScopeTenant,
+			DefaultSpec: "0 */2 * * *",
+			Enqueue: func(ctx context.Context, q repository.Querier, tenantID uuid.UUID, _ time.Time) error {
+				return jobs.EnqueuePollShipmentTracking(ctx, q, tenantID)
+			},
+		},
+		{
+			JobType:     jobs.JobTypeCheckLowStock,
+			Name:        "Low stock digest",
+			Description: "Emails operators the SKUs at or below their low stock threshold",
+			Scope:       ScopeTenant,
+			DefaultSpec: "0 13 * * *",
+			Enqueue: func(ctx context.Context, q repository.Querier, tenantID uuid.UUID, _ time.Time) error {
+				return jobs.EnqueueCheckLowStock(ctx, q, tenantID)
+			},
+		},
+		{
+			JobType:     jobs.JobTypeSendReviewRequests,
+			Name:        "Review requests",
+			Description: "Emails customers asking for product reviews a few days after their order is delivered",
+			Scope:       ScopeTenant,
+			DefaultSpec: "0 16 * * *",
+			Enqueue: func(ctx context.Context, q repository.Querier, tenantID uuid.UUID, _ time.Time) error {
+				return jobs.EnqueueSendReviewRequests(ctx, q, tenantID)
+			},
+		},
+		{
+			JobType:     jobs.JobTypeProcessDunning,
+			Name:        "Payment retries",
+			Description: "Retries failed subscription payments that are due on the retry schedule",
+			Scope:       ScopeTenant,
+			DefaultSpec: "0 * * * *",
+			Enqueue: func(ctx context.Context, q repository.Querier, tenantID uuid.UUID, _ time.Time) error {
+				return jobs.EnqueueProcessDunning(ctx, q, tenantID)
+			},
+		},
+		{
+			JobType:     jobs.JobTypeProcessStandingOrders,
+			Name:        "Standing orders",
+			Description: "Places wholesale standing orders at their cutoff and reminds customers before it. The run time is the cutoff time",
+			Scope:       ScopeTenant,
+			DefaultSpec: "0 12 * * *",
+			Enqueue: func(ctx context.Context, q repository.Querier, tenantID uuid.UUID, _ time.Time) error {
+				return jobs.EnqueueProcessStandingOrders(ctx, q, tenantID)
+			},
+		},
+		{
+			JobType:     jobs.JobTypeCleanupExpiredTokens,
+			Name:        "Expired token cleanup",
+			Description: "Deletes expired email verification and password reset tokens",
+			Scope:       ScopeSystem,
+			DefaultSpec: "30 4 * * *",
+			Enqueue: func(ctx context.Context, q repository.Querier, _ uuid.UUID, _ time.Time) error {
+				return jobs.EnqueueCleanupExpiredTokens(ctx, q)
+			},
+		},
+		{
+			JobType:     jobs.JobTypeExpireGracePeriods,
+			Name:        "Grace period expiry",
+			Description: "Suspends tenants whose payment grace period has ended",
+			Scope:       ScopeSystem,
+			DefaultSpec: "0 * * * *",
+			Enqueue: func(ctx context.Context, q repository.Querier, _ uuid.UUID, _ time.Time) error {
+				return jobs.EnqueueExpireGracePeriods(ctx, q)
+			},
+		},
+	}
+}
+
+// LookupDefinition returns the default definition for a job type.
+func LookupDefinition(jobType string) (Definition, bool) {
+	for _, def := range DefaultDefinitions() {
+		if def.JobType == jobType {
+			return def, true
+		}
+	}
+	return Definition{}, false
+}
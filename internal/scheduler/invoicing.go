@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// enqueueConsolidatedInvoices enqueues a consolidated invoice job for each
+// wholesale customer whose billing period ends on the day of runAt.
+// Generating an invoice only picks up uninvoiced orders, so enqueuing a
+// customer twice for the same period does not bill them twice.
+func enqueueConsolidatedInvoices(ctx context.Context, q repository.Querier, tenantID uuid.UUID, runAt time.Time) error {
+	cycles := []domain.BillingCycle{
+		domain.BillingCycleWeekly,
+		domain.BillingCycleBiweekly,
+		domain.BillingCycleMonthly,
+	}
+
+	for _, cycle := range cycles {
+		customers, err := q.GetCustomersForBillingCycle(ctx, repository.GetCustomersForBillingCycleParams{
+			TenantID:     pgtype.UUID{Bytes: tenantID, Valid: true},
+			BillingCycle: pgtype.Text{String: string(cycle), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get %s billing customers: %w", cycle, err)
+		}
+
+		for _, customer := range customers {
+			start, end, due := billingPeriodEnding(cycle, customer.BillingCycleDay, runAt)
+			if !due {
+				continue
+			}
+
+			err := jobs.EnqueueGenerateConsolidatedInvoice(ctx, q, tenantID, jobs.GenerateConsolidatedInvoicePayload{
+				UserID:             uuid.UUID(customer.ID.Bytes),
+				BillingPeriodStart: start,
+				BillingPeriodEnd:   end,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to enqueue consolidated invoice: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// billingPeriodEnding reports whether a billing period ends on the day of
+// asOf, and returns the period. The billing day is the ISO weekday (1 is
+// Monday) for weekly and biweekly cycles and the day of the month for
+// monthly cycles, defaulting to 1. Biweekly periods end in even ISO weeks.
+func billingPeriodEnding(cycle domain.BillingCycle, billingDay pgtype.Int4, asOf time.Time) (start, end time.Time, due bool) {
+	day := 1
+	if billingDay.Valid {
+		day = int(billingDay.Int32)
+	}
+
+	asOf = asOf.UTC()
+	end = time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch cycle {
+	case domain.BillingCycleWeekly:
+		if isoWeekday(end) != day {
+			return time.Time{}, time.Time{}, false
+		}
+		return end.AddDate(0, 0, -7), end, true
+
+	case domain.BillingCycleBiweekly:
+		_, week := end.ISOWeek()
+		if isoWeekday(end) != day || week%2 != 0 {
+			return time.Time{}, time.Time{}, false
+		}
+		return end.AddDate(0, 0, -14), end, true
+
+	case domain.BillingCycleMonthly:
+		if end.Day() != day {
+			return time.Time{}, time.Time{}, false
+		}
+		return end.AddDate(0, -1, 0), end, true
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+// isoWeekday returns the ISO day of the week, 1 (Monday) to 7 (Sunday).
+func isoWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(t.Weekday())
+}
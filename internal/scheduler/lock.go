@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LockKey is the Postgres advisory lock key held by the leading scheduler.
+// Any int64 works as long as nothing else uses it; this one spells "hirisch".
+const LockKey int64 = 0x68697269736368
+
+// Locker elects a single scheduler across instances. TryLock is called on
+// every tick and reports whether this instance holds the lock, taking it
+// if it is free.
+type Locker interface {
+	TryLock(ctx context.Context) (bool, error)
+	Unlock(ctx context.Context) error
+}
+
+// AdvisoryLock is a Locker backed by a session-level Postgres advisory
+// lock. The lock lives as long as the database session, so the holder
+// keeps one pool connection checked out while it leads. If that
+// connection dies the lock is released by Postgres and another instance
+// takes over on its next tick.
+type AdvisoryLock struct {
+	pool *pgxpool.Pool
+	key  int64
+	conn *pgxpool.Conn
+}
+
+// NewAdvisoryLock creates an advisory lock on key.
+func NewAdvisoryLock(pool *pgxpool.Pool, key int64) *AdvisoryLock {
+	return &AdvisoryLock{
+		pool: pool,
+		key:  key,
+	}
+}
+
+// TryLock takes the lock if it is free, or confirms this instance still
+// holds it.
+func (l *AdvisoryLock) TryLock(ctx context.Context) (bool, error) {
+	if l.conn != nil {
+		if err := l.conn.Ping(ctx); err == nil {
+			return true, nil
+		}
+		// The session may be gone and the lock with it; close the
+		// connection rather than return it to the pool
+		l.closeConn(ctx)
+	}
+
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	var locked bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&locked); err != nil {
+		conn.Release()
+		return false, fmt.Errorf("failed to take advisory lock: %w", err)
+	}
+	if !locked {
+		conn.Release()
+		return false, nil
+	}
+
+	l.conn = conn
+	return true, nil
+}
+
+// Unlock releases the lock if this instance holds it.
+func (l *AdvisoryLock) Unlock(ctx context.Context) error {
+	if l.conn == nil {
+		return nil
+	}
+
+	if _, err := l.conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		l.closeConn(ctx)
+		return fmt.Errorf("failed to release advisory lock: %w", err)
+	}
+
+	l.conn.Release()
+	l.conn = nil
+	return nil
+}
+
+// closeConn closes the held connection, which ends the session and so
+// releases the lock, instead of handing a locked session back to the pool.
+func (l *AdvisoryLock) closeConn(ctx context.Context) {
+	conn := l.conn.Hijack()
+	l.conn = nil
+	_ = conn.Close(ctx)
+}
@@ -0,0 +1,267 @@
+// Package scheduler enqueues recurring background jobs.
+//
+// Schedules are stored in the job_schedules table: one row per tenant for
+// tenant jobs (overdue invoices, payment retries, ...) and one row with no
+// tenant for system jobs (token cleanup, grace period expiry). Every
+// instance runs a Scheduler, but only the one holding the Postgres
+// advisory lock enqueues jobs; the others wait to take over. The worker
+// then runs the jobs like any other.
+//
+// A schedule whose next run passed while no scheduler was running (during
+// a deploy, say) is enqueued once when the leader next ticks, however many
+// runs were missed, and its next run is then computed from the current time.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// Config holds scheduler configuration
+type Config struct {
+	// TickInterval is how often to check for due schedules
+	TickInterval time.Duration
+
+	// SyncInterval is how often the leader creates missing schedules,
+	// such as those of newly activated tenants
+	SyncInterval time.Duration
+}
+
+// invalidScheduleRetry is how long a schedule with an unknown job type or
+// an invalid cron expression waits before it is looked at again.
+const invalidScheduleRetry = time.Hour
+
+// Scheduler enqueues jobs for due schedules while it holds the lock.
+type Scheduler struct {
+	config      Config
+	queries     repository.Querier
+	locker      Locker
+	definitions []Definition
+	byJobType   map[string]Definition
+	logger      *slog.Logger
+
+	// now is replaced in tests
+	now func() time.Time
+
+	leader   bool
+	lastSync time.Time
+}
+
+// New creates a scheduler for the given job definitions.
+func New(queries repository.Querier, locker Locker, definitions []Definition, config Config, logger *slog.Logger) (*Scheduler, error) {
+	if config.TickInterval == 0 {
+		config.TickInterval = 30 * time.Second
+	}
+	if config.SyncInterval == 0 {
+		config.SyncInterval = 5 * time.Minute
+	}
+
+	byJobType := make(map[string]Definition, len(definitions))
+	for _, def := range definitions {
+		if _, err := ParseCron(def.DefaultSpec); err != nil {
+			return nil, fmt.Errorf("invalid default schedule for %s: %w", def.JobType, err)
+		}
+		byJobType[def.JobType] = def
+	}
+
+	return &Scheduler{
+		config:      config,
+		queries:     queries,
+		locker:      locker,
+		definitions: definitions,
+		byJobType:   byJobType,
+		logger:      logger,
+		now:         time.Now,
+	}, nil
+}
+
+// Start runs the scheduler until the context is cancelled, then releases
+// the lock so another instance can lead straight away.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.logger.Info("scheduler starting",
+		"tick_interval", s.config.TickInterval,
+		"definitions", len(s.definitions),
+	)
+
+	ticker := time.NewTicker(s.config.TickInterval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			unlockCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := s.locker.Unlock(unlockCtx); err != nil {
+				s.logger.Warn("failed to release scheduler lock", "error", err)
+			}
+			s.logger.Info("scheduler shutting down")
+			return ctx.Err()
+
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick enqueues due jobs if this instance is the leader.
+func (s *Scheduler) tick(ctx context.Context) {
+	leader, err := s.locker.TryLock(ctx)
+	if err != nil {
+		s.logger.Error("failed to check scheduler lock", "error", err)
+	}
+	if !leader {
+		if s.leader {
+			s.logger.Warn("scheduler lost leadership")
+		}
+		s.leader = false
+		return
+	}
+	if !s.leader {
+		s.logger.Info("scheduler became leader")
+		s.leader = true
+		s.lastSync = time.Time{}
+	}
+
+	now := s.now().UTC()
+
+	if now.Sub(s.lastSync) >= s.config.SyncInterval {
+		if err := s.sync(ctx, now); err != nil {
+			s.logger.Error("failed to sync job schedules", "error", err)
+		} else {
+			s.lastSync = now
+		}
+	}
+
+	if err := s.runDue(ctx, now); err != nil {
+		s.logger.Error("failed to run due schedules", "error", err)
+	}
+}
+
+// sync creates the default schedule for each system job and for each
+// tenant job of every active tenant. Existing schedules are left alone.
+func (s *Scheduler) sync(ctx context.Context, now time.Time) error {
+	var tenants []repository.Tenant
+	for _, def := range s.definitions {
+		if def.Scope == ScopeTenant {
+			var err error
+			tenants, err = s.queries.ListActiveTenants(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to list active tenants: %w", err)
+			}
+			break
+		}
+	}
+
+	for _, def := range s.definitions {
+		// Validated in New
+		cron, _ := ParseCron(def.DefaultSpec)
+		params := repository.EnsureJobScheduleParams{
+			JobType:   def.JobType,
+			CronSpec:  def.DefaultSpec,
+			NextRunAt: pgtype.Timestamptz{Time: cron.Next(now), Valid: true},
+		}
+
+		if def.Scope == ScopeSystem {
+			if err := s.queries.EnsureJobSchedule(ctx, params); err != nil {
+				return fmt.Errorf("failed to create %s schedule: %w", def.JobType, err)
+			}
+			continue
+		}
+
+		for _, t := range tenants {
+			params.TenantID = t.ID
+			if err := s.queries.EnsureJobSchedule(ctx, params); err != nil {
+				return fmt.Errorf("failed to create %s schedule: %w", def.JobType, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// runDue enqueues a job for each due schedule and moves it to its next run.
+func (s *Scheduler) runDue(ctx context.Context, now time.Time) error {
+	schedules, err := s.queries.ListDueJobSchedules(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to list due schedules: %w", err)
+	}
+
+	for _, sched := range schedules {
+		s.run(ctx, sched, now)
+	}
+
+	return nil
+}
+
+// run enqueues one schedule's job. A failed enqueue is recorded and the
+// schedule stays due, so it is tried again on the next tick.
+func (s *Scheduler) run(ctx context.Context, sched repository.JobSchedule, now time.Time) {
+	// uuid.Nil for system schedules
+	var tenantID uuid.UUID
+	if sched.TenantID.Valid {
+		tenantID = sched.TenantID.Bytes
+	}
+
+	logger := s.logger.With(
+		"schedule_id", sched.ID.String(),
+		"job_type", sched.JobType,
+		"tenant_id", tenantID,
+	)
+
+	def, ok := s.byJobType[sched.JobType]
+	if !ok {
+		logger.Error("no job registered for schedule")
+		s.record(ctx, sched, sched.LastRunAt, now.Add(invalidScheduleRetry), "no job registered for this job type")
+		return
+	}
+
+	cron, err := ParseCron(sched.CronSpec)
+	if err != nil {
+		logger.Error("invalid schedule", "error", err)
+		s.record(ctx, sched, sched.LastRunAt, now.Add(invalidScheduleRetry), err.Error())
+		return
+	}
+
+	// Missed runs are coalesced into this one
+	if missed := cron.Next(sched.NextRunAt.Time); !missed.IsZero() && !missed.After(now) {
+		logger.Info("catching up missed schedule", "due_at", sched.NextRunAt.Time)
+	}
+
+	if err := def.Enqueue(ctx, s.queries, tenantID, sched.NextRunAt.Time); err != nil {
+		logger.Error("failed to enqueue scheduled job", "error", err)
+		s.record(ctx, sched, sched.LastRunAt, sched.NextRunAt.Time, err.Error())
+		return
+	}
+
+	next := cron.Next(now)
+	if next.IsZero() {
+		next = now.Add(invalidScheduleRetry)
+	}
+	s.record(ctx, sched, pgtype.Timestamptz{Time: now, Valid: true}, next, "")
+
+	logger.Info("scheduled job enqueued", "next_run_at", next)
+}
+
+// record saves the outcome of a run. An empty errMsg clears last_error.
+func (s *Scheduler) record(ctx context.Context, sched repository.JobSchedule, lastRunAt pgtype.Timestamptz, next time.Time, errMsg string) {
+	err := s.queries.UpdateJobScheduleRun(ctx, repository.UpdateJobScheduleRunParams{
+		ID:        sched.ID,
+		LastRunAt: lastRunAt,
+		NextRunAt: pgtype.Timestamptz{Time: next, Valid: true},
+		LastError: pgtype.Text{String: errMsg, Valid: errMsg != ""},
+	})
+	if err != nil {
+		s.logger.Error("failed to update job schedule",
+			"schedule_id", sched.ID.String(),
+			"error", err,
+		)
+	}
+}
@@ -0,0 +1,313 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+// fakeLocker is a Locker that is either held or not.
+type fakeLocker struct {
+	held     bool
+	unlocked bool
+}
+
+func (l *fakeLocker) TryLock(ctx context.Context) (bool, error) { return l.held, nil }
+
+func (l *fakeLocker) Unlock(ctx context.Context) error {
+	l.unlocked = true
+	return nil
+}
+
+var testNow = time.Date(2026, time.March, 11, 14, 37, 20, 0, time.UTC)
+
+func newUUID() pgtype.UUID {
+	return pgtype.UUID{Bytes: uuid.New(), Valid: true}
+}
+
+func testDefinitions() []Definition {
+	var defs []Definition
+	for _, def := range DefaultDefinitions() {
+		if def.JobType == jobs.JobTypeCheckLowStock || def.JobType == jobs.JobTypeCleanupExpiredTokens {
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
+
+func newTestScheduler(t *testing.T, q repository.Querier, locker Locker) *Scheduler {
+	t.Helper()
+
+	s, err := New(q, locker, testDefinitions(), Config{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	s.now = func() time.Time { return testNow }
+	return s
+}
+
+func TestNew_InvalidDefaultSpec(t *testing.T) {
+	defs := []Definition{{JobType: "test:job", Scope: ScopeSystem, DefaultSpec: "every day"}}
+
+	_, err := New(nil, &fakeLocker{}, defs, Config{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	assert.Error(t, err)
+}
+
+func TestScheduler_Follower(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	// No expectations: a follower must not touch the database
+	s := newTestScheduler(t, mockRepo, &fakeLocker{held: false})
+	s.tick(context.Background())
+
+	assert.False(t, s.leader)
+}
+
+func TestScheduler_Sync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	tenantA, tenantB := newUUID(), newUUID()
+
+	mockRepo.EXPECT().ListActiveTenants(gomock.Any()).
+		Return([]repository.Tenant{{ID: tenantA}, {ID: tenantB}}, nil)
+
+	var ensured []repository.EnsureJobScheduleParams
+	mockRepo.EXPECT().EnsureJobSchedule(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg repository.EnsureJobScheduleParams) error {
+			ensured = append(ensured, arg)
+			return nil
+		}).Times(3)
+	mockRepo.EXPECT().ListDueJobSchedules(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+
+	s := newTestScheduler(t, mockRepo, &fakeLocker{held: true})
+	s.tick(context.Background())
+
+	require.Len(t, ensured, 3)
+	assert.Equal(t, repository.EnsureJobScheduleParams{
+		TenantID:  tenantA,
+		JobType:   jobs.JobTypeCheckLowStock,
+		CronSpec:  "0 13 * * *",
+		NextRunAt: pgtype.Timestamptz{Time: time.Date(2026, time.March, 12, 13, 0, 0, 0, time.UTC), Valid: true},
+	}, ensured[0])
+	assert.Equal(t, tenantB, ensured[1].TenantID)
+	assert.Equal(t, jobs.JobTypeCleanupExpiredTokens, ensured[2].JobType)
+	assert.False(t, ensured[2].TenantID.Valid, "system schedules have no tenant")
+
+	// Within the sync interval only due schedules are checked
+	s.tick(context.Background())
+}
+
+func TestScheduler_RunDue(t *testing.T) {
+	tenantID := newUUID()
+	lowStock := repository.JobSchedule{
+		ID:        newUUID(),
+		TenantID:  tenantID,
+		JobType:   jobs.JobTypeCheckLowStock,
+		CronSpec:  "0 13 * * *",
+		Enabled:   true,
+		NextRunAt: pgtype.Timestamptz{Time: time.Date(2026, time.March, 11, 13, 0, 0, 0, time.UTC), Valid: true},
+	}
+	cleanup := repository.JobSchedule{
+		ID:        newUUID(),
+		JobType:   jobs.JobTypeCleanupExpiredTokens,
+		CronSpec:  "30 4 * * *",
+		Enabled:   true,
+		NextRunAt: pgtype.Timestamptz{Time: time.Date(2026, time.March, 11, 4, 30, 0, 0, time.UTC), Valid: true},
+	}
+	tomorrow := func(hour, minute int) pgtype.Timestamptz {
+		return pgtype.Timestamptz{Time: time.Date(2026, time.March, 12, hour, minute, 0, 0, time.UTC), Valid: true}
+	}
+
+	t.Run("enqueues tenant and system jobs", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+
+		mockRepo.EXPECT().ListDueJobSchedules(gomock.Any(), pgtype.Timestamptz{Time: testNow, Valid: true}).
+			Return([]repository.JobSchedule{cleanup, lowStock}, nil)
+
+		var enqueued []repository.EnqueueJobParams
+		mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg repository.EnqueueJobParams) (repository.Job, error) {
+				enqueued = append(enqueued, arg)
+				return repository.Job{}, nil
+			}).Times(2)
+		mockRepo.EXPECT().UpdateJobScheduleRun(gomock.Any(), repository.UpdateJobScheduleRunParams{
+			ID:        cleanup.ID,
+			LastRunAt: pgtype.Timestamptz{Time: testNow, Valid: true},
+			NextRunAt: tomorrow(4, 30),
+		})
+		mockRepo.EXPECT().UpdateJobScheduleRun(gomock.Any(), repository.UpdateJobScheduleRunParams{
+			ID:        lowStock.ID,
+			LastRunAt: pgtype.Timestamptz{Time: testNow, Valid: true},
+			NextRunAt: tomorrow(13, 0),
+		})
+
+		s := newTestScheduler(t, mockRepo, &fakeLocker{held: true})
+		require.NoError(t, s.runDue(context.Background(), testNow))
+
+		require.Len(t, enqueued, 2)
+		assert.Equal(t, jobs.JobTypeCleanupExpiredTokens, enqueued[0].JobType)
+		assert.False(t, enqueued[0].TenantID.Valid)
+		assert.Equal(t, jobs.JobTypeCheckLowStock, enqueued[1].JobType)
+		assert.Equal(t, tenantID, enqueued[1].TenantID)
+	})
+
+	t.Run("missed runs are enqueued once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+
+		// Down for three days: three runs missed
+		missed := lowStock
+		missed.NextRunAt = pgtype.Timestamptz{Time: time.Date(2026, time.March, 8, 13, 0, 0, 0, time.UTC), Valid: true}
+
+		mockRepo.EXPECT().ListDueJobSchedules(gomock.Any(), gomock.Any()).Return([]repository.JobSchedule{missed}, nil)
+		mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).Return(repository.Job{}, nil).Times(1)
+		mockRepo.EXPECT().UpdateJobScheduleRun(gomock.Any(), repository.UpdateJobScheduleRunParams{
+			ID:        missed.ID,
+			LastRunAt: pgtype.Timestamptz{Time: testNow, Valid: true},
+			NextRunAt: tomorrow(13, 0),
+		})
+
+		s := newTestScheduler(t, mockRepo, &fakeLocker{held: true})
+		require.NoError(t, s.runDue(context.Background(), testNow))
+	})
+
+	t.Run("failed enqueue stays due", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+
+		mockRepo.EXPECT().ListDueJobSchedules(gomock.Any(), gomock.Any()).Return([]repository.JobSchedule{lowStock}, nil)
+		mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).Return(repository.Job{}, errors.New("connection reset"))
+		mockRepo.EXPECT().UpdateJobScheduleRun(gomock.Any(), repository.UpdateJobScheduleRunParams{
+			ID:        lowStock.ID,
+			NextRunAt: lowStock.NextRunAt,
+			LastError: pgtype.Text{String: "connection reset", Valid: true},
+		})
+
+		s := newTestScheduler(t, mockRepo, &fakeLocker{held: true})
+		require.NoError(t, s.runDue(context.Background(), testNow))
+	})
+
+	t.Run("unknown job type is retried later", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+
+		unknown := lowStock
+		unknown.JobType = "inventory:retired_job"
+
+		mockRepo.EXPECT().ListDueJobSchedules(gomock.Any(), gomock.Any()).Return([]repository.JobSchedule{unknown}, nil)
+		mockRepo.EXPECT().UpdateJobScheduleRun(gomock.Any(), repository.UpdateJobScheduleRunParams{
+			ID:        unknown.ID,
+			NextRunAt: pgtype.Timestamptz{Time: testNow.Add(invalidScheduleRetry), Valid: true},
+			LastError: pgtype.Text{String: "no job registered for this job type", Valid: true},
+		})
+
+		s := newTestScheduler(t, mockRepo, &fakeLocker{held: true})
+		require.NoError(t, s.runDue(context.Background(), testNow))
+	})
+}
+
+func TestScheduler_Start_ReleasesLock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	locker := &fakeLocker{held: false}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s := newTestScheduler(t, mockRepo, locker)
+	err := s.Start(ctx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.True(t, locker.unlocked)
+}
+
+func TestBillingPeriodEnding(t *testing.T) {
+	// Monday of ISO week 12
+	monday := time.Date(2026, time.March, 16, 3, 0, 0, 0, time.UTC)
+	midnight := time.Date(2026, time.March, 16, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		cycle     domain.BillingCycle
+		day       pgtype.Int4
+		asOf      time.Time
+		wantDue   bool
+		wantStart time.Time
+	}{
+		{"weekly on the default day", domain.BillingCycleWeekly, pgtype.Int4{}, monday, true, midnight.AddDate(0, 0, -7)},
+		{"weekly on another day", domain.BillingCycleWeekly, pgtype.Int4{Int32: 5, Valid: true}, monday, false, time.Time{}},
+		{"biweekly in an even week", domain.BillingCycleBiweekly, pgtype.Int4{Int32: 1, Valid: true}, monday, true, midnight.AddDate(0, 0, -14)},
+		{"biweekly in an odd week", domain.BillingCycleBiweekly, pgtype.Int4{Int32: 1, Valid: true}, monday.AddDate(0, 0, 7), false, time.Time{}},
+		{"monthly on the billing day", domain.BillingCycleMonthly, pgtype.Int4{Int32: 16, Valid: true}, monday, true, time.Date(2026, time.February, 16, 0, 0, 0, 0, time.UTC)},
+		{"monthly on another day", domain.BillingCycleMonthly, pgtype.Int4{}, monday, false, time.Time{}},
+		{"invoiced on order", domain.BillingCycleOnOrder, pgtype.Int4{}, monday, false, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, due := billingPeriodEnding(tt.cycle, tt.day, tt.asOf)
+
+			assert.Equal(t, tt.wantDue, due)
+			if tt.wantDue {
+				assert.Equal(t, tt.wantStart, start)
+				assert.Equal(t, midnight, end)
+			}
+		})
+	}
+}
+
+func TestEnqueueConsolidatedInvoices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	tenantID := uuid.New()
+	weeklyCustomer := newUUID()
+
+	// Monday
+	runAt := time.Date(2026, time.March, 16, 3, 0, 0, 0, time.UTC)
+
+	mockRepo.EXPECT().GetCustomersForBillingCycle(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg repository.GetCustomersForBillingCycleParams) ([]repository.GetCustomersForBillingCycleRow, error) {
+			assert.Equal(t, pgtype.UUID{Bytes: tenantID, Valid: true}, arg.TenantID)
+			switch domain.BillingCycle(arg.BillingCycle.String) {
+			case domain.BillingCycleWeekly:
+				return []repository.GetCustomersForBillingCycleRow{
+					{ID: weeklyCustomer, BillingCycleDay: pgtype.Int4{Int32: 1, Valid: true}},
+					{ID: newUUID(), BillingCycleDay: pgtype.Int4{Int32: 3, Valid: true}},
+				}, nil
+			case domain.BillingCycleMonthly:
+				return []repository.GetCustomersForBillingCycleRow{
+					{ID: newUUID(), BillingCycleDay: pgtype.Int4{Int32: 1, Valid: true}},
+				}, nil
+			}
+			return nil, nil
+		}).Times(3)
+
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg repository.EnqueueJobParams) (repository.Job, error) {
+			assert.Equal(t, jobs.JobTypeGenerateConsolidatedInvoice, arg.JobType)
+
+			var payload jobs.GenerateConsolidatedInvoicePayload
+			require.NoError(t, json.Unmarshal(arg.Payload, &payload))
+			assert.Equal(t, uuid.UUID(weeklyCustomer.Bytes), payload.UserID)
+			assert.Equal(t, time.Date(2026, time.March, 9, 0, 0, 0, 0, time.UTC), payload.BillingPeriodStart)
+			assert.Equal(t, time.Date(2026, time.March, 16, 0, 0, 0, 0, time.UTC), payload.BillingPeriodEnd)
+			return repository.Job{}, nil
+		}).Times(1)
+
+	err := enqueueConsolidatedInvoices(context.Background(), mockRepo, tenantID, runAt)
+	require.NoError(t, err)
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/email"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/storage"
+	"github.com/dukerupert/hiri/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// brandImageTypes maps the content types accepted for each image to the
+// file extension they are stored with.
+var brandImageTypes = map[domain.BrandImageKind]map[string]string{
+	domain.BrandImageLogo: {
+		"image/png":  ".png",
+		"image/jpeg": ".jpg",
+		"image/webp": ".webp",
+	},
+	domain.BrandImageFavicon: {
+		"image/png":    ".png",
+		"image/x-icon": ".ico",
+	},
+}
+
+type brandingService struct {
+	repo    repository.Querier
+	storage storage.Storage
+}
+
+// NewBrandingService creates a new BrandingService instance.
+func NewBrandingService(repo repository.Querier, fileStorage storage.Storage) domain.BrandingService {
+	return &brandingService{
+		repo:    repo,
+		storage: fileStorage,
+	}
+}
+
+// GetBranding returns the tenant's branding, with defaults for anything
+// unset.
+func (s *brandingService) GetBranding(ctx context.Context) (*domain.Branding, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	branding, err := loadBranding(ctx, s.repo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &branding, nil
+}
+
+// UpdateBranding validates and saves the tenant's colors and fonts.
+func (s *brandingService) UpdateBranding(ctx context.Context, params domain.UpdateBrandingParams) (*domain.Branding, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	branding, err := loadBranding(ctx, s.repo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	branding.PrimaryColor = strings.ToUpper(strings.TrimSpace(params.PrimaryColor))
+	branding.AccentColor = strings.ToUpper(strings.TrimSpace(params.AccentColor))
+	branding.HeadingFont = params.HeadingFont
+	branding.BodyFont = params.BodyFont
+	if err := branding.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, tenantID, branding); err != nil {
+		return nil, err
+	}
+
+	return &branding, nil
+}
+
+// UploadImage stores a new logo or favicon, replacing the previous one.
+// The content type is detected from the file rather than trusted from the
+// upload.
+func (s *brandingService) UploadImage(ctx context.Context, params domain.UploadBrandImageParams) (*domain.Branding, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	types, ok := brandImageTypes[params.Kind]
+	if !ok {
+		return nil, ErrInvalidBrandImageKind
+	}
+	if params.Size > domain.MaxBrandImageSize {
+		return nil, ErrBrandImageTooLarge
+	}
+
+	content, err := io.ReadAll(io.LimitReader(params.Content, domain.MaxBrandImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if len(content) > domain.MaxBrandImageSize {
+		return nil, ErrBrandImageTooLarge
+	}
+
+	contentType := http.DetectContentType(content)
+	ext, ok := types[contentType]
+	if !ok {
+		if params.Kind == domain.BrandImageFavicon {
+			return nil, ErrBrandFaviconType
+		}
+		return nil, ErrBrandLogoType
+	}
+
+	branding, err := loadBranding(ctx, s.repo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	key := path.Join("branding", uuidToString(tenantID), string(params.Kind)+"-"+uuid.New().String()+ext)
+	url, err := s.storage.Put(ctx, key, bytes.NewReader(content), contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store %s: %w", params.Kind, err)
+	}
+
+	var previousKey string
+	switch params.Kind {
+	case domain.BrandImageLogo:
+		previousKey = branding.LogoKey
+		branding.LogoURL, branding.LogoKey = url, key
+	case domain.BrandImageFavicon:
+		previousKey = branding.FaviconKey
+		branding.FaviconURL, branding.FaviconKey = url, key
+	}
+
+	if err := s.save(ctx, tenantID, branding); err != nil {
+		// Clean up the orphaned file (best-effort)
+		_ = s.storage.Delete(ctx, key)
+		return nil, err
+	}
+
+	if previousKey != "" {
+		_ = s.storage.Delete(ctx, previousKey)
+	}
+
+	return &branding, nil
+}
+
+// RemoveImage deletes the logo or favicon.
+func (s *brandingService) RemoveImage(ctx context.Context, kind domain.BrandImageKind) (*domain.Branding, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := brandImageTypes[kind]; !ok {
+		return nil, ErrInvalidBrandImageKind
+	}
+
+	branding, err := loadBranding(ctx, s.repo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var key string
+	switch kind {
+	case domain.BrandImageLogo:
+		key = branding.LogoKey
+		branding.LogoURL, branding.LogoKey = "", ""
+	case domain.BrandImageFavicon:
+		key = branding.FaviconKey
+		branding.FaviconURL, branding.FaviconKey = "", ""
+	}
+
+	if err := s.save(ctx, tenantID, branding); err != nil {
+		return nil, err
+	}
+
+	if key != "" {
+		_ = s.storage.Delete(ctx, key)
+	}
+
+	return &branding, nil
+}
+
+func (s *brandingService) save(ctx context.Context, tenantID pgtype.UUID, branding domain.Branding) error {
+	brandingJSON, err := json.Marshal(branding)
+	if err != nil {
+		return fmt.Errorf("failed to marshal branding: %w", err)
+	}
+
+	err = s.repo.UpdateTenantBranding(ctx, repository.UpdateTenantBrandingParams{
+		ID:       tenantID,
+		Branding: brandingJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update branding: %w", err)
+	}
+
+	return nil
+}
+
+// loadBranding reads the tenant's branding, with defaults for anything unset.
+func loadBranding(ctx context.Context, repo repository.Querier, tenantID pgtype.UUID) (domain.Branding, error) {
+	raw, err := repo.GetTenantBranding(ctx, tenantID)
+	if err != nil {
+		return domain.Branding{}, fmt.Errorf("failed to get branding: %w", err)
+	}
+
+	var branding domain.Branding
+	if err := json.Unmarshal(raw, &branding); err != nil {
+		return domain.Branding{}, fmt.Errorf("failed to unmarshal branding: %w", err)
+	}
+
+	return branding.WithDefaults(), nil
+}
+
+// emailBrandingStore serves a tenant's branding to the email service.
+type emailBrandingStore struct {
+	repo repository.Querier
+}
+
+// NewEmailBrandingStore creates the store email.Service uses to find a
+// tenant's branding.
+func NewEmailBrandingStore(repo repository.Querier) email.BrandingStore {
+	return &emailBrandingStore{repo: repo}
+}
+
+// Branding returns the branding for the tenant on the context. Emails sent
+// without a tenant, such as platform emails to operators, use the default.
+func (s *emailBrandingStore) Branding(ctx context.Context) (*email.Branding, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		if errors.Is(err, tenant.ErrNoTenant) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	branding, err := loadBranding(ctx, s.repo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Email clients can only load absolute image URLs, which local storage
+	// doesn't provide
+	logoURL := branding.LogoURL
+	if !strings.HasPrefix(logoURL, "https://") && !strings.HasPrefix(logoURL, "http://") {
+		logoURL = ""
+	}
+
+	return &email.Branding{
+		LogoURL:      logoURL,
+		PrimaryColor: branding.PrimaryColor,
+		HeadingFont:  branding.HeadingFontStack(),
+		BodyFont:     branding.BodyFontStack(),
+	}, nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// pngHeader is enough of a PNG for content type detection.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newTestBrandingService(t *testing.T) (domain.BrandingService, *repository.MockQuerier, storage.Storage) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	fileStorage, err := storage.NewLocalStorage(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+
+	return NewBrandingService(mockRepo, fileStorage), mockRepo, fileStorage
+}
+
+func brandingJSON(t *testing.T, branding domain.Branding) []byte {
+	t.Helper()
+	raw, err := json.Marshal(branding)
+	require.NoError(t, err)
+	return raw
+}
+
+func TestBrandingService_GetBranding_Defaults(t *testing.T) {
+	svc, mockRepo, _ := newTestBrandingService(t)
+	tenantID := newUUID()
+
+	mockRepo.EXPECT().GetTenantBranding(gomock.Any(), tenantID).Return([]byte(`{"primary_color":"#112233"}`), nil)
+
+	branding, err := svc.GetBranding(contextWithTenant(tenantID))
+	require.NoError(t, err)
+	assert.Equal(t, "#112233", branding.PrimaryColor)
+	assert.Equal(t, domain.DefaultBranding().AccentColor, branding.AccentColor)
+	assert.Equal(t, "system", branding.HeadingFont)
+}
+
+func TestBrandingService_UpdateBranding(t *testing.T) {
+	svc, mockRepo, _ := newTestBrandingService(t)
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+
+	mockRepo.EXPECT().GetTenantBranding(gomock.Any(), tenantID).
+		Return(brandingJSON(t, domain.Branding{LogoURL: "/uploads/logo.png", LogoKey: "logo.png"}), nil)
+	mockRepo.EXPECT().UpdateTenantBranding(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg repository.UpdateTenantBrandingParams) error {
+			var saved domain.Branding
+			require.NoError(t, json.Unmarshal(arg.Branding, &saved))
+			assert.Equal(t, "#AA0011", saved.PrimaryColor)
+			assert.Equal(t, "classic", saved.HeadingFont)
+			// The logo is kept
+			assert.Equal(t, "logo.png", saved.LogoKey)
+			return nil
+		})
+
+	branding, err := svc.UpdateBranding(ctx, domain.UpdateBrandingParams{
+		PrimaryColor: " #aa0011 ",
+		AccentColor:  "#B5873A",
+		HeadingFont:  "classic",
+		BodyFont:     "system",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "#AA0011", branding.PrimaryColor)
+}
+
+func TestBrandingService_UpdateBranding_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  domain.UpdateBrandingParams
+		wantErr error
+	}{
+		{name: "named color", params: domain.UpdateBrandingParams{PrimaryColor: "red", AccentColor: "#B5873A", HeadingFont: "system", BodyFont: "system"}, wantErr: ErrInvalidBrandColor},
+		{name: "short hex", params: domain.UpdateBrandingParams{PrimaryColor: "#2A7D7D", AccentColor: "#fff", HeadingFont: "system", BodyFont: "system"}, wantErr: ErrInvalidBrandColor},
+		{name: "css injection", params: domain.UpdateBrandingParams{PrimaryColor: "#2A7D7D;}body{", AccentColor: "#B5873A", HeadingFont: "system", BodyFont: "system"}, wantErr: ErrInvalidBrandColor},
+		{name: "unknown font", params: domain.UpdateBrandingParams{PrimaryColor: "#2A7D7D", AccentColor: "#B5873A", HeadingFont: "Comic Sans", BodyFont: "system"}, wantErr: ErrInvalidBrandFont},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockRepo, _ := newTestBrandingService(t)
+			tenantID := newUUID()
+
+			// Nothing is saved
+			mockRepo.EXPECT().GetTenantBranding(gomock.Any(), tenantID).Return([]byte(`{}`), nil)
+
+			_, err := svc.UpdateBranding(contextWithTenant(tenantID), tt.params)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBrandingService_UploadImage(t *testing.T) {
+	svc, mockRepo, fileStorage := newTestBrandingService(t)
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+
+	// An existing logo is replaced
+	_, err := fileStorage.Put(ctx, "branding/old-logo.png", bytes.NewReader(pngHeader), "image/png")
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().GetTenantBranding(gomock.Any(), tenantID).
+		Return(brandingJSON(t, domain.Branding{LogoURL: "/uploads/branding/old-logo.png", LogoKey: "branding/old-logo.png"}), nil)
+	mockRepo.EXPECT().UpdateTenantBranding(gomock.Any(), gomock.Any()).Return(nil)
+
+	branding, err := svc.UploadImage(ctx, domain.UploadBrandImageParams{
+		Kind:    domain.BrandImageLogo,
+		Content: bytes.NewReader(pngHeader),
+		Size:    int64(len(pngHeader)),
+	})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(branding.LogoKey, "branding/"+uuidToString(tenantID)+"/logo-"))
+	assert.True(t, strings.HasSuffix(branding.LogoKey, ".png"))
+	exists, err := fileStorage.Exists(ctx, branding.LogoKey)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = fileStorage.Exists(ctx, "branding/old-logo.png")
+	require.NoError(t, err)
+	assert.False(t, exists, "the replaced logo is deleted")
+}
+
+func TestBrandingService_UploadImage_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  domain.UploadBrandImageParams
+		wantErr error
+	}{
+		{
+			name:    "logo not an image",
+			params:  domain.UploadBrandImageParams{Kind: domain.BrandImageLogo, Content: strings.NewReader("<svg onload=alert(1)>"), Size: 21},
+			wantErr: ErrBrandLogoType,
+		},
+		{
+			name:    "favicon jpeg",
+			params:  domain.UploadBrandImageParams{Kind: domain.BrandImageFavicon, Content: bytes.NewReader([]byte("\xff\xd8\xff\xe0")), Size: 4},
+			wantErr: ErrBrandFaviconType,
+		},
+		{
+			name:    "too large",
+			params:  domain.UploadBrandImageParams{Kind: domain.BrandImageLogo, Content: bytes.NewReader(make([]byte, domain.MaxBrandImageSize+1)), Size: domain.MaxBrandImageSize + 1},
+			wantErr: ErrBrandImageTooLarge,
+		},
+		{
+			name:    "unknown kind",
+			params:  domain.UploadBrandImageParams{Kind: "banner", Content: bytes.NewReader(pngHeader), Size: int64(len(pngHeader))},
+			wantErr: ErrInvalidBrandImageKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// No repository calls are expected
+			svc, _, _ := newTestBrandingService(t)
+
+			_, err := svc.UploadImage(contextWithTenant(newUUID()), tt.params)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBrandingService_RemoveImage(t *testing.T) {
+	svc, mockRepo, fileStorage := newTestBrandingService(t)
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+
+	_, err := fileStorage.Put(ctx, "branding/favicon.ico", bytes.NewReader([]byte("\x00\x00\x01\x00")), "image/x-icon")
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().GetTenantBranding(gomock.Any(), tenantID).
+		Return(brandingJSON(t, domain.Branding{FaviconURL: "/uploads/branding/favicon.ico", FaviconKey: "branding/favicon.ico"}), nil)
+	mockRepo.EXPECT().UpdateTenantBranding(gomock.Any(), gomock.Any()).Return(nil)
+
+	branding, err := svc.RemoveImage(ctx, domain.BrandImageFavicon)
+	require.NoError(t, err)
+	assert.Empty(t, branding.FaviconURL)
+
+	exists, err := fileStorage.Exists(ctx, "branding/favicon.ico")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestEmailBrandingStore_Branding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	store := NewEmailBrandingStore(mockRepo)
+	tenantID := newUUID()
+
+	// Without a tenant the default look is used
+	branding, err := store.Branding(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, branding)
+
+	mockRepo.EXPECT().GetTenantBranding(gomock.Any(), tenantID).
+		Return(brandingJSON(t, domain.Branding{LogoURL: "https://cdn.example.com/logo.png", PrimaryColor: "#112233", HeadingFont: "slab"}), nil)
+
+	branding, err = store.Branding(contextWithTenant(tenantID))
+	require.NoError(t, err)
+	require.NotNil(t, branding)
+	assert.Equal(t, "https://cdn.example.com/logo.png", branding.LogoURL)
+	assert.Equal(t, "#112233", branding.PrimaryColor)
+	assert.Contains(t, branding.HeadingFont, "Rockwell")
+
+	// Relative URLs from local storage can't be loaded by email clients
+	mockRepo.EXPECT().GetTenantBranding(gomock.Any(), tenantID).
+		Return(brandingJSON(t, domain.Branding{LogoURL: "/uploads/logo.png"}), nil)
+
+	branding, err = store.Branding(contextWithTenant(tenantID))
+	require.NoError(t, err)
+	assert.Empty(t, branding.LogoURL)
+}
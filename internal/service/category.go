@@ -0,0 +1,638 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// slugPattern matches lowercase words joined by single hyphens.
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type categoryService struct {
+	repo repository.Querier
+}
+
+// NewCategoryService creates a new CategoryService instance
+func NewCategoryService(repo repository.Querier) domain.CategoryService {
+	return &categoryService{repo: repo}
+}
+
+// ListCategories returns every category in tree order.
+func (s *categoryService) ListCategories(ctx context.Context) ([]domain.CategoryTreeItem, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.ListProductCategories(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	return buildCategoryTree(rows), nil
+}
+
+// GetCategory retrieves a category by ID.
+func (s *categoryService) GetCategory(ctx context.Context, categoryID string) (*repository.ProductCategory, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := s.getCategory(ctx, tenantID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// CreateCategory creates a category.
+func (s *categoryService) CreateCategory(ctx context.Context, params domain.CategoryParams) (*repository.ProductCategory, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateCategoryParams(&params); err != nil {
+		return nil, err
+	}
+
+	parentID, err := s.resolveParent(ctx, tenantID, pgtype.UUID{}, params.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetProductCategoryBySlug(ctx, repository.GetProductCategoryBySlugParams{
+		TenantID: tenantID,
+		Slug:     params.Slug,
+	})
+	if err == nil && existing.ID.Valid {
+		return nil, ErrDuplicateCategorySlug
+	}
+
+	category, err := s.repo.CreateProductCategory(ctx, repository.CreateProductCategoryParams{
+		TenantID:        tenantID,
+		Name:            params.Name,
+		Slug:            params.Slug,
+		Description:     makePgText(params.Description),
+		ParentID:        parentID,
+		SortOrder:       params.SortOrder,
+		IsActive:        params.IsActive,
+		MetaTitle:       makePgText(params.MetaTitle),
+		MetaDescription: makePgText(params.MetaDescription),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return &category, nil
+}
+
+// UpdateCategory updates a category.
+func (s *categoryService) UpdateCategory(ctx context.Context, categoryID string, params domain.CategoryParams) (*repository.ProductCategory, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateCategoryParams(&params); err != nil {
+		return nil, err
+	}
+
+	current, err := s.getCategory(ctx, tenantID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, err := s.resolveParent(ctx, tenantID, current.ID, params.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Slug != current.Slug {
+		existing, err := s.repo.GetProductCategoryBySlug(ctx, repository.GetProductCategoryBySlugParams{
+			TenantID: tenantID,
+			Slug:     params.Slug,
+		})
+		if err == nil && existing.ID.Valid {
+			return nil, ErrDuplicateCategorySlug
+		}
+	}
+
+	category, err := s.repo.UpdateProductCategory(ctx, repository.UpdateProductCategoryParams{
+		TenantID:        tenantID,
+		ID:              current.ID,
+		Name:            params.Name,
+		Slug:            params.Slug,
+		Description:     makePgText(params.Description),
+		ParentID:        parentID,
+		SortOrder:       params.SortOrder,
+		IsActive:        params.IsActive,
+		MetaTitle:       makePgText(params.MetaTitle),
+		MetaDescription: makePgText(params.MetaDescription),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return &category, nil
+}
+
+// DeleteCategory deletes a category after moving its subcategories up a level.
+func (s *categoryService) DeleteCategory(ctx context.Context, categoryID string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	category, err := s.getCategory(ctx, tenantID, categoryID)
+	if err != nil {
+		return err
+	}
+
+	// Subcategories would otherwise be deleted with it by the foreign key
+	if err := s.repo.ReparentProductCategories(ctx, repository.ReparentProductCategoriesParams{
+		TenantID:    tenantID,
+		ParentID:    category.ID,
+		NewParentID: category.ParentID,
+	}); err != nil {
+		return fmt.Errorf("failed to move subcategories: %w", err)
+	}
+
+	if err := s.repo.DeleteProductCategory(ctx, repository.DeleteProductCategoryParams{
+		TenantID: tenantID,
+		ID:       category.ID,
+	}); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return nil
+}
+
+// ListTags returns every tag with the number of products tagged.
+func (s *categoryService) ListTags(ctx context.Context) ([]repository.ListProductTagsRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.repo.ListProductTags(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// GetTag retrieves a tag by ID.
+func (s *categoryService) GetTag(ctx context.Context, tagID string) (*repository.ProductTag, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := s.getTag(ctx, tenantID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// CreateTag creates a tag.
+func (s *categoryService) CreateTag(ctx context.Context, params domain.TagParams) (*repository.ProductTag, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTagParams(&params); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetProductTagBySlug(ctx, repository.GetProductTagBySlugParams{
+		TenantID: tenantID,
+		Slug:     params.Slug,
+	})
+	if err == nil && existing.ID.Valid {
+		return nil, ErrDuplicateTagSlug
+	}
+
+	tag, err := s.repo.CreateProductTag(ctx, repository.CreateProductTagParams{
+		TenantID: tenantID,
+		Name:     params.Name,
+		Slug:     params.Slug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	return &tag, nil
+}
+
+// UpdateTag renames a tag.
+func (s *categoryService) UpdateTag(ctx context.Context, tagID string, params domain.TagParams) (*repository.ProductTag, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTagParams(&params); err != nil {
+		return nil, err
+	}
+
+	current, err := s.getTag(ctx, tenantID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Slug != current.Slug {
+		existing, err := s.repo.GetProductTagBySlug(ctx, repository.GetProductTagBySlugParams{
+			TenantID: tenantID,
+			Slug:     params.Slug,
+		})
+		if err == nil && existing.ID.Valid {
+			return nil, ErrDuplicateTagSlug
+		}
+	}
+
+	tag, err := s.repo.UpdateProductTag(ctx, repository.UpdateProductTagParams{
+		TenantID: tenantID,
+		ID:       current.ID,
+		Name:     params.Name,
+		Slug:     params.Slug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update tag: %w", err)
+	}
+
+	return &tag, nil
+}
+
+// DeleteTag deletes a tag and removes it from products.
+func (s *categoryService) DeleteTag(ctx context.Context, tagID string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tag, err := s.getTag(ctx, tenantID, tagID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteProductTag(ctx, repository.DeleteProductTagParams{
+		TenantID: tenantID,
+		ID:       tag.ID,
+	}); err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	return nil
+}
+
+// GetProductAssignments returns the categories and tags of a product.
+func (s *categoryService) GetProductAssignments(ctx context.Context, productID string) (*domain.ProductAssignments, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var productUUID pgtype.UUID
+	if err := productUUID.Scan(productID); err != nil {
+		return nil, domain.ErrProductNotFound
+	}
+
+	categoryIDs, err := s.repo.ListCategoryIDsForProduct(ctx, repository.ListCategoryIDsForProductParams{
+		TenantID:  tenantID,
+		ProductID: productUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product categories: %w", err)
+	}
+
+	tagIDs, err := s.repo.ListTagIDsForProduct(ctx, repository.ListTagIDsForProductParams{
+		TenantID:  tenantID,
+		ProductID: productUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product tags: %w", err)
+	}
+
+	assignments := &domain.ProductAssignments{
+		CategoryIDs: make([]string, len(categoryIDs)),
+		TagIDs:      make([]string, len(tagIDs)),
+	}
+	for i, id := range categoryIDs {
+		assignments.CategoryIDs[i] = uuidToString(id)
+	}
+	for i, id := range tagIDs {
+		assignments.TagIDs[i] = uuidToString(id)
+	}
+
+	return assignments, nil
+}
+
+// SetProductAssignments replaces the categories and tags of a product.
+func (s *categoryService) SetProductAssignments(ctx context.Context, productID string, assignments domain.ProductAssignments) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	var productUUID pgtype.UUID
+	if err := productUUID.Scan(productID); err != nil {
+		return domain.ErrProductNotFound
+	}
+
+	product, err := s.repo.GetProductByID(ctx, repository.GetProductByIDParams{
+		TenantID: tenantID,
+		ID:       productUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrProductNotFound
+		}
+		return fmt.Errorf("failed to get product: %w", err)
+	}
+
+	categoryIDs := parseUUIDList(assignments.CategoryIDs)
+	if err := s.repo.UnassignProductCategoriesExcept(ctx, repository.UnassignProductCategoriesExceptParams{
+		TenantID:    tenantID,
+		ProductID:   product.ID,
+		CategoryIds: categoryIDs,
+	}); err != nil {
+		return fmt.Errorf("failed to unassign product categories: %w", err)
+	}
+	if len(categoryIDs) > 0 {
+		if err := s.repo.AssignProductCategories(ctx, repository.AssignProductCategoriesParams{
+			TenantID:    tenantID,
+			ProductID:   product.ID,
+			CategoryIds: categoryIDs,
+		}); err != nil {
+			return fmt.Errorf("failed to assign product categories: %w", err)
+		}
+	}
+
+	tagIDs := parseUUIDList(assignments.TagIDs)
+	if err := s.repo.UnassignProductTagsExcept(ctx, repository.UnassignProductTagsExceptParams{
+		TenantID:  tenantID,
+		ProductID: product.ID,
+		TagIds:    tagIDs,
+	}); err != nil {
+		return fmt.Errorf("failed to unassign product tags: %w", err)
+	}
+	if len(tagIDs) > 0 {
+		if err := s.repo.AssignProductTags(ctx, repository.AssignProductTagsParams{
+			TenantID:  tenantID,
+			ProductID: product.ID,
+			TagIds:    tagIDs,
+		}); err != nil {
+			return fmt.Errorf("failed to assign product tags: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetCollection returns an active category by slug for its landing page.
+func (s *categoryService) GetCollection(ctx context.Context, slug string) (*domain.Collection, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.ListProductCategories(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	byID := make(map[[16]byte]repository.ListProductCategoriesRow, len(rows))
+	var collection *domain.Collection
+	for _, row := range rows {
+		byID[row.ID.Bytes] = row
+		if row.Slug == slug && row.IsActive {
+			collection = &domain.Collection{Category: row}
+		}
+	}
+	if collection == nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	for _, row := range rows {
+		if row.IsActive && row.ParentID.Valid && row.ParentID.Bytes == collection.Category.ID.Bytes {
+			collection.Subcategories = append(collection.Subcategories, row)
+		}
+	}
+
+	// Walk up to the top level, guarding against a corrupt cycle
+	parentID := collection.Category.ParentID
+	for depth := 0; parentID.Valid && depth < len(rows); depth++ {
+		parent, ok := byID[parentID.Bytes]
+		if !ok {
+			break
+		}
+		if parent.IsActive {
+			collection.Breadcrumbs = append([]repository.ListProductCategoriesRow{parent}, collection.Breadcrumbs...)
+		}
+		parentID = parent.ParentID
+	}
+
+	return collection, nil
+}
+
+func (s *categoryService) getCategory(ctx context.Context, tenantID pgtype.UUID, categoryID string) (repository.ProductCategory, error) {
+	var categoryUUID pgtype.UUID
+	if err := categoryUUID.Scan(categoryID); err != nil {
+		return repository.ProductCategory{}, ErrCategoryNotFound
+	}
+
+	category, err := s.repo.GetProductCategory(ctx, repository.GetProductCategoryParams{
+		TenantID: tenantID,
+		ID:       categoryUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return category, ErrCategoryNotFound
+		}
+		return category, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	return category, nil
+}
+
+func (s *categoryService) getTag(ctx context.Context, tenantID pgtype.UUID, tagID string) (repository.ProductTag, error) {
+	var tagUUID pgtype.UUID
+	if err := tagUUID.Scan(tagID); err != nil {
+		return repository.ProductTag{}, ErrTagNotFound
+	}
+
+	tag, err := s.repo.GetProductTag(ctx, repository.GetProductTagParams{
+		TenantID: tenantID,
+		ID:       tagUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return tag, ErrTagNotFound
+		}
+		return tag, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// resolveParent loads the parent of a category being saved. The parent
+// can't be the category itself or one of its subcategories.
+func (s *categoryService) resolveParent(ctx context.Context, tenantID, categoryID pgtype.UUID, parentID string) (pgtype.UUID, error) {
+	if parentID == "" {
+		return pgtype.UUID{}, nil
+	}
+
+	parent, err := s.getCategory(ctx, tenantID, parentID)
+	if err != nil {
+		if errors.Is(err, ErrCategoryNotFound) {
+			return pgtype.UUID{}, ErrInvalidCategoryParent
+		}
+		return pgtype.UUID{}, err
+	}
+
+	if !categoryID.Valid {
+		return parent.ID, nil
+	}
+	if parent.ID.Bytes == categoryID.Bytes {
+		return pgtype.UUID{}, ErrInvalidCategoryParent
+	}
+
+	rows, err := s.repo.ListProductCategories(ctx, tenantID)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("failed to list categories: %w", err)
+	}
+	if isCategoryDescendant(rows, parent.ID, categoryID) {
+		return pgtype.UUID{}, ErrInvalidCategoryParent
+	}
+
+	return parent.ID, nil
+}
+
+// isCategoryDescendant reports whether the category is below the ancestor.
+func isCategoryDescendant(rows []repository.ListProductCategoriesRow, categoryID, ancestorID pgtype.UUID) bool {
+	parents := make(map[[16]byte]pgtype.UUID, len(rows))
+	for _, row := range rows {
+		parents[row.ID.Bytes] = row.ParentID
+	}
+
+	current := parents[categoryID.Bytes]
+	for depth := 0; current.Valid && depth < len(rows); depth++ {
+		if current.Bytes == ancestorID.Bytes {
+			return true
+		}
+		current = parents[current.Bytes]
+	}
+	return false
+}
+
+// buildCategoryTree orders categories depth-first, keeping the sort order
+// of siblings. Categories whose parent is missing are listed at the top level.
+func buildCategoryTree(rows []repository.ListProductCategoriesRow) []domain.CategoryTreeItem {
+	present := make(map[[16]byte]bool, len(rows))
+	for _, row := range rows {
+		present[row.ID.Bytes] = true
+	}
+
+	children := make(map[[16]byte][]repository.ListProductCategoriesRow)
+	var roots []repository.ListProductCategoriesRow
+	for _, row := range rows {
+		if row.ParentID.Valid && present[row.ParentID.Bytes] {
+			children[row.ParentID.Bytes] = append(children[row.ParentID.Bytes], row)
+			continue
+		}
+		roots = append(roots, row)
+	}
+
+	tree := make([]domain.CategoryTreeItem, 0, len(rows))
+	visited := make(map[[16]byte]bool, len(rows))
+	var walk func(row repository.ListProductCategoriesRow, depth int)
+	walk = func(row repository.ListProductCategoriesRow, depth int) {
+		if visited[row.ID.Bytes] {
+			return
+		}
+		visited[row.ID.Bytes] = true
+		tree = append(tree, domain.CategoryTreeItem{ListProductCategoriesRow: row, Depth: depth})
+		for _, child := range children[row.ID.Bytes] {
+			walk(child, depth+1)
+		}
+	}
+	for _, row := range roots {
+		walk(row, 0)
+	}
+
+	return tree
+}
+
+// validateCategoryParams trims the category fields and derives the slug.
+func validateCategoryParams(params *domain.CategoryParams) error {
+	params.Name = strings.TrimSpace(params.Name)
+	params.Description = strings.TrimSpace(params.Description)
+	params.MetaTitle = strings.TrimSpace(params.MetaTitle)
+	params.MetaDescription = strings.TrimSpace(params.MetaDescription)
+	if params.Name == "" {
+		return ErrCategoryNameRequired
+	}
+
+	slug, err := normalizeSlug(params.Slug, params.Name)
+	if err != nil {
+		return err
+	}
+	params.Slug = slug
+
+	return nil
+}
+
+// validateTagParams trims the tag name and derives the slug.
+func validateTagParams(params *domain.TagParams) error {
+	params.Name = strings.TrimSpace(params.Name)
+	if params.Name == "" {
+		return ErrTagNameRequired
+	}
+
+	slug, err := normalizeSlug(params.Slug, params.Name)
+	if err != nil {
+		return err
+	}
+	params.Slug = slug
+
+	return nil
+}
+
+// normalizeSlug lowercases an entered slug, or derives one from the name.
+func normalizeSlug(slug, name string) (string, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug == "" {
+		slug = generateSlug(name)
+	}
+	if !slugPattern.MatchString(slug) {
+		return "", ErrInvalidCatalogSlug
+	}
+	return slug, nil
+}
+
+// parseUUIDList parses IDs, skipping any that are not valid UUIDs.
+func parseUUIDList(ids []string) []pgtype.UUID {
+	parsed := make([]pgtype.UUID, 0, len(ids))
+	for _, id := range ids {
+		var u pgtype.UUID
+		if err := u.Scan(id); err == nil {
+			parsed = append(parsed, u)
+		}
+	}
+	return parsed
+}
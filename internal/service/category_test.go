@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestCategoryService(t *testing.T) (domain.CategoryService, *repository.MockQuerier) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	return NewCategoryService(mockRepo), mockRepo
+}
+
+func categoryRow(id, parentID pgtype.UUID, name, slug string, sortOrder int32) repository.ListProductCategoriesRow {
+	return repository.ListProductCategoriesRow{
+		ID:        id,
+		ParentID:  parentID,
+		Name:      name,
+		Slug:      slug,
+		SortOrder: sortOrder,
+		IsActive:  true,
+	}
+}
+
+func TestCategoryService_CreateCategory_DerivesSlug(t *testing.T) {
+	svc, mockRepo := newTestCategoryService(t)
+	tenantID := newUUID()
+
+	mockRepo.EXPECT().GetProductCategoryBySlug(gomock.Any(), repository.GetProductCategoryBySlugParams{
+		TenantID: tenantID,
+		Slug:     "single-origin",
+	}).Return(repository.ProductCategory{}, pgx.ErrNoRows)
+	mockRepo.EXPECT().CreateProductCategory(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg repository.CreateProductCategoryParams) (repository.ProductCategory, error) {
+			assert.Equal(t, "Single Origin", arg.Name)
+			assert.Equal(t, "single-origin", arg.Slug)
+			assert.False(t, arg.ParentID.Valid)
+			return repository.ProductCategory{ID: newUUID(), Name: arg.Name, Slug: arg.Slug}, nil
+		})
+
+	category, err := svc.CreateCategory(contextWithTenant(tenantID), domain.CategoryParams{
+		Name:     "  Single Origin ",
+		IsActive: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "single-origin", category.Slug)
+}
+
+func TestCategoryService_CreateCategory_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  domain.CategoryParams
+		wantErr error
+	}{
+		{name: "missing name", params: domain.CategoryParams{Name: " "}, wantErr: ErrCategoryNameRequired},
+		{name: "invalid slug", params: domain.CategoryParams{Name: "Blends", Slug: "house blends"}, wantErr: ErrInvalidCatalogSlug},
+		{name: "unknown parent", params: domain.CategoryParams{Name: "Blends", ParentID: "not-a-uuid"}, wantErr: ErrInvalidCategoryParent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// No repository calls are expected
+			svc, _ := newTestCategoryService(t)
+
+			_, err := svc.CreateCategory(contextWithTenant(newUUID()), tt.params)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCategoryService_CreateCategory_DuplicateSlug(t *testing.T) {
+	svc, mockRepo := newTestCategoryService(t)
+	tenantID := newUUID()
+
+	mockRepo.EXPECT().GetProductCategoryBySlug(gomock.Any(), gomock.Any()).
+		Return(repository.ProductCategory{ID: newUUID(), Slug: "blends"}, nil)
+
+	_, err := svc.CreateCategory(contextWithTenant(tenantID), domain.CategoryParams{Name: "Blends"})
+	assert.ErrorIs(t, err, ErrDuplicateCategorySlug)
+}
+
+func TestCategoryService_UpdateCategory_RejectsCycle(t *testing.T) {
+	svc, mockRepo := newTestCategoryService(t)
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+
+	// coffee > africa > ethiopia
+	coffee, africa, ethiopia := newUUID(), newUUID(), newUUID()
+	rows := []repository.ListProductCategoriesRow{
+		categoryRow(coffee, pgtype.UUID{}, "Coffee", "coffee", 0),
+		categoryRow(africa, coffee, "Africa", "africa", 0),
+		categoryRow(ethiopia, africa, "Ethiopia", "ethiopia", 0),
+	}
+
+	mockRepo.EXPECT().GetProductCategory(gomock.Any(), repository.GetProductCategoryParams{TenantID: tenantID, ID: coffee}).
+		Return(repository.ProductCategory{ID: coffee, Slug: "coffee"}, nil)
+	mockRepo.EXPECT().GetProductCategory(gomock.Any(), repository.GetProductCategoryParams{TenantID: tenantID, ID: ethiopia}).
+		Return(repository.ProductCategory{ID: ethiopia, ParentID: africa, Slug: "ethiopia"}, nil)
+	mockRepo.EXPECT().ListProductCategories(gomock.Any(), tenantID).Return(rows, nil)
+
+	// Moving coffee under its grandchild would create a cycle
+	_, err := svc.UpdateCategory(ctx, uuidToString(coffee), domain.CategoryParams{
+		Name:     "Coffee",
+		Slug:     "coffee",
+		ParentID: uuidToString(ethiopia),
+	})
+	assert.ErrorIs(t, err, ErrInvalidCategoryParent)
+}
+
+func TestCategoryService_DeleteCategory_ReparentsChildren(t *testing.T) {
+	svc, mockRepo := newTestCategoryService(t)
+	tenantID := newUUID()
+	categoryID, parentID := newUUID(), newUUID()
+
+	mockRepo.EXPECT().GetProductCategory(gomock.Any(), gomock.Any()).
+		Return(repository.ProductCategory{ID: categoryID, ParentID: parentID}, nil)
+	gomock.InOrder(
+		mockRepo.EXPECT().ReparentProductCategories(gomock.Any(), repository.ReparentProductCategoriesParams{
+			TenantID:    tenantID,
+			ParentID:    categoryID,
+			NewParentID: parentID,
+		}).Return(nil),
+		mockRepo.EXPECT().DeleteProductCategory(gomock.Any(), repository.DeleteProductCategoryParams{
+			TenantID: tenantID,
+			ID:       categoryID,
+		}).Return(nil),
+	)
+
+	err := svc.DeleteCategory(contextWithTenant(tenantID), uuidToString(categoryID))
+	require.NoError(t, err)
+}
+
+func TestCategoryService_SetProductAssignments(t *testing.T) {
+	svc, mockRepo := newTestCategoryService(t)
+	tenantID := newUUID()
+	productID, categoryID := newUUID(), newUUID()
+
+	mockRepo.EXPECT().GetProductByID(gomock.Any(), gomock.Any()).
+		Return(repository.Product{ID: productID}, nil)
+	mockRepo.EXPECT().UnassignProductCategoriesExcept(gomock.Any(), repository.UnassignProductCategoriesExceptParams{
+		TenantID:    tenantID,
+		ProductID:   productID,
+		CategoryIds: []pgtype.UUID{categoryID},
+	}).Return(nil)
+	mockRepo.EXPECT().AssignProductCategories(gomock.Any(), repository.AssignProductCategoriesParams{
+		TenantID:    tenantID,
+		ProductID:   productID,
+		CategoryIds: []pgtype.UUID{categoryID},
+	}).Return(nil)
+	// Clearing every tag removes them all without assigning any
+	mockRepo.EXPECT().UnassignProductTagsExcept(gomock.Any(), repository.UnassignProductTagsExceptParams{
+		TenantID:  tenantID,
+		ProductID: productID,
+		TagIds:    []pgtype.UUID{},
+	}).Return(nil)
+
+	err := svc.SetProductAssignments(contextWithTenant(tenantID), uuidToString(productID), domain.ProductAssignments{
+		CategoryIDs: []string{uuidToString(categoryID), "garbage"},
+	})
+	require.NoError(t, err)
+}
+
+func TestCategoryService_SetProductAssignments_ProductNotFound(t *testing.T) {
+	svc, mockRepo := newTestCategoryService(t)
+
+	mockRepo.EXPECT().GetProductByID(gomock.Any(), gomock.Any()).
+		Return(repository.Product{}, pgx.ErrNoRows)
+
+	err := svc.SetProductAssignments(contextWithTenant(newUUID()), uuidToString(newUUID()), domain.ProductAssignments{})
+	assert.ErrorIs(t, err, domain.ErrProductNotFound)
+}
+
+func TestCategoryService_GetCollection(t *testing.T) {
+	svc, mockRepo := newTestCategoryService(t)
+	tenantID := newUUID()
+
+	coffee, africa, ethiopia, kenya, hidden := newUUID(), newUUID(), newUUID(), newUUID(), newUUID()
+	inactive := categoryRow(hidden, africa, "Hidden", "hidden", 2)
+	inactive.IsActive = false
+	rows := []repository.ListProductCategoriesRow{
+		categoryRow(coffee, pgtype.UUID{}, "Coffee", "coffee", 0),
+		categoryRow(africa, coffee, "Africa", "africa", 0),
+		categoryRow(ethiopia, africa, "Ethiopia", "ethiopia", 0),
+		categoryRow(kenya, africa, "Kenya", "kenya", 1),
+		inactive,
+	}
+	mockRepo.EXPECT().ListProductCategories(gomock.Any(), tenantID).Return(rows, nil).Times(2)
+
+	collection, err := svc.GetCollection(contextWithTenant(tenantID), "africa")
+	require.NoError(t, err)
+	assert.Equal(t, "Africa", collection.Category.Name)
+	require.Len(t, collection.Breadcrumbs, 1)
+	assert.Equal(t, "coffee", collection.Breadcrumbs[0].Slug)
+	require.Len(t, collection.Subcategories, 2)
+	assert.Equal(t, "ethiopia", collection.Subcategories[0].Slug)
+	assert.Equal(t, "kenya", collection.Subcategories[1].Slug)
+
+	// Inactive categories have no landing page
+	_, err = svc.GetCollection(contextWithTenant(tenantID), "hidden")
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	coffee, africa, ethiopia, gear, orphan := newUUID(), newUUID(), newUUID(), newUUID(), newUUID()
+
+	// Rows arrive ordered by sort order, not by tree position
+	tree := buildCategoryTree([]repository.ListProductCategoriesRow{
+		categoryRow(coffee, pgtype.UUID{}, "Coffee", "coffee", 0),
+		categoryRow(ethiopia, africa, "Ethiopia", "ethiopia", 0),
+		categoryRow(africa, coffee, "Africa", "africa", 1),
+		categoryRow(gear, pgtype.UUID{}, "Gear", "gear", 1),
+		categoryRow(orphan, newUUID(), "Orphan", "orphan", 2),
+	})
+
+	var slugs []string
+	var depths []int
+	for _, item := range tree {
+		slugs = append(slugs, item.Slug)
+		depths = append(depths, item.Depth)
+	}
+	assert.Equal(t, []string{"coffee", "africa", "ethiopia", "gear", "orphan"}, slugs)
+	assert.Equal(t, []int{0, 1, 2, 0, 0}, depths)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/dukerupert/hiri/internal/address"
 	"github.com/dukerupert/hiri/internal/billing"
@@ -50,6 +51,7 @@ type OrderTotalParams struct {
 	BillingAddress       address.Address
 	SelectedShippingRate shipping.Rate
 	DiscountCode         string
+	CustomerEmail        string // Used to enforce per-customer discount limits
 }
 
 // OrderTotal contains the breakdown of an order's total cost.
@@ -115,6 +117,7 @@ type checkoutService struct {
 	shippingProvider shipping.Provider
 	taxCalculator    tax.Calculator
 	addrValidator    address.Validator
+	discountService  domain.DiscountService
 }
 
 // NewCheckoutService creates a new CheckoutService instance.
@@ -125,6 +128,7 @@ func NewCheckoutService(
 	shippingProvider shipping.Provider,
 	taxCalculator tax.Calculator,
 	addrValidator address.Validator,
+	discountService domain.DiscountService,
 ) CheckoutService {
 	return &checkoutService{
 		repo:             repo,
@@ -133,6 +137,7 @@ func NewCheckoutService(
 		shippingProvider: shippingProvider,
 		taxCalculator:    taxCalculator,
 		addrValidator:    addrValidator,
+		discountService:  discountService,
 	}
 }
 
@@ -204,7 +209,9 @@ func (s *checkoutService) GetShippingRates(ctx context.Context, cartID string, s
 	return rates, nil
 }
 
-// CalculateOrderTotal computes the complete order total including tax and shipping.
+// CalculateOrderTotal computes the complete order total including tax, shipping and discount.
+// Discounts reduce the taxable amount: free shipping removes shipping from the
+// tax calculation, and order discounts are spread across line items.
 func (s *checkoutService) CalculateOrderTotal(ctx context.Context, params OrderTotalParams) (*OrderTotal, error) {
 	cartSummary, err := s.cartService.GetCartSummary(ctx, params.CartID)
 	if err != nil {
@@ -214,6 +221,19 @@ func (s *checkoutService) CalculateOrderTotal(ctx context.Context, params OrderT
 	// Convert int64 to int32 - safe for shipping costs which are always < $21M
 	shippingCents := int32(params.SelectedShippingRate.CostCents)
 
+	var discount *domain.AppliedDiscount
+	if strings.TrimSpace(params.DiscountCode) != "" {
+		discount, err = s.discountService.ApplyCode(ctx, domain.ApplyDiscountParams{
+			Code:          params.DiscountCode,
+			SubtotalCents: cartSummary.Subtotal,
+			ShippingCents: shippingCents,
+			CustomerEmail: params.CustomerEmail,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	lineItems := make([]tax.LineItem, len(cartSummary.Items))
 	for i, item := range cartSummary.Items {
 		lineItems[i] = tax.LineItem{
@@ -226,29 +246,42 @@ func (s *checkoutService) CalculateOrderTotal(ctx context.Context, params OrderT
 		}
 	}
 
+	taxableShippingCents := shippingCents
+	if discount != nil {
+		if discount.FreeShipping {
+			taxableShippingCents = 0
+		} else {
+			allocateDiscount(lineItems, discount.DiscountCents)
+		}
+	}
+
 	taxResult, err := s.taxCalculator.CalculateTax(ctx, tax.TaxParams{
 		ShippingAddress: convertAddressToTax(params.ShippingAddress),
 		LineItems:       lineItems,
-		ShippingCents:   shippingCents,
+		ShippingCents:   taxableShippingCents,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate tax: %w", err)
 	}
 
-	total := cartSummary.Subtotal + shippingCents + taxResult.TotalTaxCents
-
-	return &OrderTotal{
-		SubtotalCents:     cartSummary.Subtotal,
-		ShippingCents:     shippingCents,
-		TaxCents:          taxResult.TotalTaxCents,
-		DiscountCents:     0,
-		TotalCents:        total,
-		TaxBreakdown:      taxResult.Breakdown,
-		TaxCalculationID:  taxResult.ProviderTxID,
-		ShippingRateID:    params.SelectedShippingRate.RateID,
-		DiscountCodeID:    pgtype.UUID{},
-		DiscountCodeValue: "",
-	}, nil
+	total := &OrderTotal{
+		SubtotalCents:    cartSummary.Subtotal,
+		ShippingCents:    shippingCents,
+		TaxCents:         taxResult.TotalTaxCents,
+		TaxBreakdown:     taxResult.Breakdown,
+		TaxCalculationID: taxResult.ProviderTxID,
+		ShippingRateID:   params.SelectedShippingRate.RateID,
+	}
+
+	if discount != nil {
+		total.DiscountCents = discount.DiscountCents
+		total.DiscountCodeID = discount.DiscountCodeID
+		total.DiscountCodeValue = discount.Code
+	}
+
+	total.TotalCents = total.SubtotalCents + total.ShippingCents + total.TaxCents - total.DiscountCents
+
+	return total, nil
 }
 
 // CreatePaymentIntent initiates a Stripe Payment Intent.
@@ -262,6 +295,26 @@ func (s *checkoutService) CreatePaymentIntent(ctx context.Context, params Paymen
 		return nil, errors.New("order total is required")
 	}
 
+	// The order total round-trips through the browser, so re-validate the
+	// discount before charging: the code may have expired or run out since
+	// the total was calculated.
+	if params.OrderTotal.DiscountCodeValue != "" {
+		discount, err := s.discountService.ApplyCode(ctx, domain.ApplyDiscountParams{
+			Code:          params.OrderTotal.DiscountCodeValue,
+			SubtotalCents: params.OrderTotal.SubtotalCents,
+			ShippingCents: params.OrderTotal.ShippingCents,
+			CustomerEmail: params.CustomerEmail,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if discount.DiscountCents != params.OrderTotal.DiscountCents {
+			return nil, ErrDiscountAmountChanged
+		}
+	} else if params.OrderTotal.DiscountCents != 0 {
+		return nil, ErrDiscountAmountChanged
+	}
+
 	shippingAddrJSON, err := json.Marshal(params.ShippingAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize shipping address: %w", err)
@@ -285,6 +338,12 @@ func (s *checkoutService) CreatePaymentIntent(ctx context.Context, params Paymen
 		"tax_calculation_id": params.OrderTotal.TaxCalculationID,
 	}
 
+	if params.OrderTotal.DiscountCodeValue != "" {
+		metadata["discount_code_id"] = uuidToString(params.OrderTotal.DiscountCodeID)
+		metadata["discount_code"] = params.OrderTotal.DiscountCodeValue
+		metadata["discount_cents"] = strconv.FormatInt(int64(params.OrderTotal.DiscountCents), 10)
+	}
+
 	paymentIntent, err := s.billingProvider.CreatePaymentIntent(ctx, billing.CreatePaymentIntentParams{
 		AmountCents:    params.OrderTotal.TotalCents,
 		Currency:       "usd",
@@ -309,6 +368,37 @@ func (s *checkoutService) CompleteCheckout(ctx context.Context, params CompleteC
 
 // Helper functions (package-private)
 
+// allocateDiscount spreads an order-level discount across line items in
+// proportion to their totals so the tax calculation sees the discounted price.
+// Any rounding remainder is taken from the last line.
+func allocateDiscount(items []tax.LineItem, discountCents int32) {
+	if discountCents <= 0 || len(items) == 0 {
+		return
+	}
+
+	var subtotal int64
+	for _, item := range items {
+		subtotal += int64(item.TotalPrice)
+	}
+	if subtotal == 0 {
+		return
+	}
+
+	remaining := discountCents
+	for i := range items {
+		share := int32(int64(discountCents) * int64(items[i].TotalPrice) / subtotal)
+		if i == len(items)-1 {
+			share = remaining
+		}
+		share = min(share, items[i].TotalPrice)
+		items[i].TotalPrice -= share
+		remaining -= share
+	}
+}
+
+// bagWeightGrams is the packed weight of a standard 12oz bag.
+const bagWeightGrams = 340
+
 // calculatePackage estimates package dimensions and weight from cart items.
 func calculatePackage(items []domain.CartItem) shipping.Package {
 	var totalBags int32
@@ -316,8 +406,11 @@ func calculatePackage(items []domain.CartItem) shipping.Package {
 		totalBags += item.Quantity
 	}
 
-	weightGrams := totalBags * 340
+	return packageForBags(totalBags, totalBags*bagWeightGrams)
+}
 
+// packageForBags picks a box size for the number of bags being shipped.
+func packageForBags(totalBags, weightGrams int32) shipping.Package {
 	var length, width, height int
 	if totalBags <= 3 {
 		length = 20
@@ -0,0 +1,409 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type discountService struct {
+	repo repository.Querier
+	pool *pgxpool.Pool
+	now  func() time.Time
+}
+
+// NewDiscountService creates a new DiscountService instance.
+// pool is used to redeem codes under a row lock in RedeemCode.
+func NewDiscountService(repo repository.Querier, pool *pgxpool.Pool) domain.DiscountService {
+	return &discountService{
+		repo: repo,
+		pool: pool,
+		now:  time.Now,
+	}
+}
+
+// NormalizeDiscountCode trims and upper-cases a code so lookups are case-insensitive.
+func NormalizeDiscountCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ApplyCode validates a code against the order and returns the discount it yields.
+func (s *discountService) ApplyCode(ctx context.Context, params domain.ApplyDiscountParams) (*domain.AppliedDiscount, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := s.repo.GetDiscountCodeByCode(ctx, repository.GetDiscountCodeByCodeParams{
+		TenantID: tenantID,
+		Code:     NormalizeDiscountCode(params.Code),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDiscountCodeNotFound
+		}
+		return nil, fmt.Errorf("failed to get discount code: %w", err)
+	}
+
+	if err := s.checkAvailability(code, params.SubtotalCents); err != nil {
+		return nil, err
+	}
+
+	if code.UsageLimitPerCustomer.Valid {
+		userID := params.UserID
+		if !userID.Valid && params.CustomerEmail != "" {
+			user, err := s.repo.GetUserByEmail(ctx, repository.GetUserByEmailParams{
+				TenantID: tenantID,
+				Email:    params.CustomerEmail,
+			})
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("failed to look up customer: %w", err)
+			}
+			userID = user.ID
+		}
+
+		// Customers we cannot identify yet have no redemptions; the limit is
+		// enforced again when the order is created.
+		if userID.Valid {
+			used, err := s.repo.CountDiscountCodeUsageForUser(ctx, repository.CountDiscountCodeUsageForUserParams{
+				TenantID:       tenantID,
+				DiscountCodeID: code.ID,
+				UserID:         userID,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to count discount usage: %w", err)
+			}
+			if used >= int64(code.UsageLimitPerCustomer.Int32) {
+				return nil, ErrDiscountCustomerLimit
+			}
+		}
+	}
+
+	discountCents, freeShipping := CalculateDiscount(code, params.SubtotalCents, params.ShippingCents)
+
+	return &domain.AppliedDiscount{
+		DiscountCodeID: code.ID,
+		Code:           code.Code,
+		DiscountType:   code.DiscountType,
+		DiscountCents:  discountCents,
+		FreeShipping:   freeShipping,
+	}, nil
+}
+
+// checkAvailability validates the code's status, validity window, global
+// usage limit and minimum order amount.
+func (s *discountService) checkAvailability(code repository.DiscountCode, subtotalCents int32) error {
+	now := s.now()
+
+	if !code.IsActive {
+		return ErrDiscountCodeInactive
+	}
+	if code.StartsAt.Valid && now.Before(code.StartsAt.Time) {
+		return ErrDiscountCodeNotStarted
+	}
+	if code.ExpiresAt.Valid && !now.Before(code.ExpiresAt.Time) {
+		return ErrDiscountCodeExpired
+	}
+	if code.UsageLimit.Valid && code.UsageCount >= code.UsageLimit.Int32 {
+		return ErrDiscountCodeExhausted
+	}
+	if code.MinimumOrderCents.Valid && subtotalCents < code.MinimumOrderCents.Int32 {
+		return ErrDiscountMinimumNotMet
+	}
+	return nil
+}
+
+// CalculateDiscount returns the discount in cents for a code and whether it waives shipping.
+// Percentage and fixed amount discounts apply to the subtotal and never exceed it.
+// Free shipping discounts equal the shipping cost.
+func CalculateDiscount(code repository.DiscountCode, subtotalCents, shippingCents int32) (int32, bool) {
+	var discount int32
+
+	switch code.DiscountType {
+	case domain.DiscountTypePercentage:
+		pct := min(max(code.DiscountValue, 0), 100)
+		discount = int32(int64(subtotalCents) * int64(pct) / 100)
+	case domain.DiscountTypeFixedAmount:
+		discount = max(code.DiscountValue, 0)
+	case domain.DiscountTypeFreeShipping:
+		return shippingCents, true
+	}
+
+	return min(discount, subtotalCents), false
+}
+
+// RedeemCode records one use of a discount code for an order, failing if
+// the code or the customer has reached its usage limit.
+func (s *discountService) RedeemCode(ctx context.Context, params domain.RedeemDiscountParams) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	var codeID pgtype.UUID
+	if err := codeID.Scan(params.DiscountCodeID); err != nil {
+		return fmt.Errorf("invalid discount code ID: %w", err)
+	}
+
+	return withTx(ctx, s.pool, s.repo, func(repo repository.Querier) error {
+		if err := lockDiscountCodeForUse(ctx, repo, tenantID, codeID, params.UserID); err != nil {
+			return err
+		}
+
+		if err := repo.RecordDiscountCodeUsage(ctx, repository.RecordDiscountCodeUsageParams{
+			TenantID:            tenantID,
+			ID:                  codeID,
+			UserID:              params.UserID,
+			OrderID:             params.OrderID,
+			DiscountAmountCents: params.DiscountCents,
+		}); err != nil {
+			return fmt.Errorf("failed to record discount usage: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// lockDiscountCodeForUse locks the discount code row for the rest of the
+// caller's transaction and checks that one more use by userID stays within
+// the code's limits. Holding the lock until commit serializes concurrent
+// redemptions of the same code, so the counts read here are the latest.
+// Returns ErrDiscountCodeExhausted or ErrDiscountCustomerLimit when a limit
+// has been reached.
+func lockDiscountCodeForUse(ctx context.Context, repo repository.Querier, tenantID, codeID, userID pgtype.UUID) error {
+	code, err := repo.LockDiscountCode(ctx, repository.LockDiscountCodeParams{
+		TenantID: tenantID,
+		ID:       codeID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrDiscountCodeNotFound
+		}
+		return fmt.Errorf("failed to lock discount code: %w", err)
+	}
+
+	if code.UsageLimit.Valid && code.UsageCount >= code.UsageLimit.Int32 {
+		return ErrDiscountCodeExhausted
+	}
+
+	if code.UsageLimitPerCustomer.Valid && userID.Valid {
+		used, err := repo.CountDiscountCodeUsageForUser(ctx, repository.CountDiscountCodeUsageForUserParams{
+			TenantID:       tenantID,
+			DiscountCodeID: codeID,
+			UserID:         userID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to count discount usage: %w", err)
+		}
+		if used >= int64(code.UsageLimitPerCustomer.Int32) {
+			return ErrDiscountCustomerLimit
+		}
+	}
+
+	return nil
+}
+
+// ListDiscountCodes lists all discount codes for the tenant.
+func (s *discountService) ListDiscountCodes(ctx context.Context) ([]repository.DiscountCode, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.repo.ListDiscountCodes(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list discount codes: %w", err)
+	}
+
+	return codes, nil
+}
+
+// GetDiscountCode retrieves a discount code by ID.
+func (s *discountService) GetDiscountCode(ctx context.Context, discountCodeID string) (*repository.DiscountCode, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var codeID pgtype.UUID
+	if err := codeID.Scan(discountCodeID); err != nil {
+		return nil, ErrDiscountCodeNotFound
+	}
+
+	code, err := s.repo.GetDiscountCodeByID(ctx, repository.GetDiscountCodeByIDParams{
+		TenantID: tenantID,
+		ID:       codeID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDiscountCodeNotFound
+		}
+		return nil, fmt.Errorf("failed to get discount code: %w", err)
+	}
+
+	return &code, nil
+}
+
+// CreateDiscountCode creates a new discount code.
+func (s *discountService) CreateDiscountCode(ctx context.Context, params domain.DiscountCodeParams) (*repository.DiscountCode, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateDiscountCodeParams(&params); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetDiscountCodeByCode(ctx, repository.GetDiscountCodeByCodeParams{
+		TenantID: tenantID,
+		Code:     params.Code,
+	})
+	if err == nil && existing.ID.Valid {
+		return nil, ErrDuplicateDiscountCode
+	}
+
+	code, err := s.repo.CreateDiscountCode(ctx, repository.CreateDiscountCodeParams{
+		TenantID:              tenantID,
+		Code:                  params.Code,
+		Description:           makePgText(params.Description),
+		DiscountType:          params.DiscountType,
+		DiscountValue:         params.DiscountValue,
+		MinimumOrderCents:     optionalInt4(params.MinimumOrderCents),
+		UsageLimit:            optionalInt4(params.UsageLimit),
+		UsageLimitPerCustomer: optionalInt4(params.UsageLimitPerCustomer),
+		StartsAt:              pgtype.Timestamptz{Time: params.StartsAt, Valid: true},
+		ExpiresAt:             optionalTimestamptz(params.ExpiresAt),
+		IsActive:              params.IsActive,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discount code: %w", err)
+	}
+
+	return &code, nil
+}
+
+// UpdateDiscountCode updates an existing discount code.
+func (s *discountService) UpdateDiscountCode(ctx context.Context, discountCodeID string, params domain.DiscountCodeParams) (*repository.DiscountCode, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateDiscountCodeParams(&params); err != nil {
+		return nil, err
+	}
+
+	current, err := s.GetDiscountCode(ctx, discountCodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Code != current.Code {
+		existing, err := s.repo.GetDiscountCodeByCode(ctx, repository.GetDiscountCodeByCodeParams{
+			TenantID: tenantID,
+			Code:     params.Code,
+		})
+		if err == nil && existing.ID.Valid {
+			return nil, ErrDuplicateDiscountCode
+		}
+	}
+
+	code, err := s.repo.UpdateDiscountCode(ctx, repository.UpdateDiscountCodeParams{
+		TenantID:              tenantID,
+		ID:                    current.ID,
+		Code:                  params.Code,
+		Description:           makePgText(params.Description),
+		DiscountType:          params.DiscountType,
+		DiscountValue:         params.DiscountValue,
+		MinimumOrderCents:     optionalInt4(params.MinimumOrderCents),
+		UsageLimit:            optionalInt4(params.UsageLimit),
+		UsageLimitPerCustomer: optionalInt4(params.UsageLimitPerCustomer),
+		StartsAt:              pgtype.Timestamptz{Time: params.StartsAt, Valid: true},
+		ExpiresAt:             optionalTimestamptz(params.ExpiresAt),
+		IsActive:              params.IsActive,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update discount code: %w", err)
+	}
+
+	return &code, nil
+}
+
+// DeleteDiscountCode deletes a discount code and its usage history.
+func (s *discountService) DeleteDiscountCode(ctx context.Context, discountCodeID string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	code, err := s.GetDiscountCode(ctx, discountCodeID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteDiscountCode(ctx, repository.DeleteDiscountCodeParams{
+		TenantID: tenantID,
+		ID:       code.ID,
+	}); err != nil {
+		return fmt.Errorf("failed to delete discount code: %w", err)
+	}
+
+	return nil
+}
+
+// validateDiscountCodeParams normalizes the code and checks type-specific values.
+func validateDiscountCodeParams(params *domain.DiscountCodeParams) error {
+	params.Code = NormalizeDiscountCode(params.Code)
+	if params.Code == "" {
+		return domain.Errorf(domain.EINVALID, "", "Code is required")
+	}
+
+	switch params.DiscountType {
+	case domain.DiscountTypePercentage:
+		if params.DiscountValue <= 0 || params.DiscountValue > 100 {
+			return domain.Errorf(domain.EINVALID, "", "Percentage must be between 1 and 100")
+		}
+	case domain.DiscountTypeFixedAmount:
+		if params.DiscountValue <= 0 {
+			return domain.Errorf(domain.EINVALID, "", "Discount amount must be greater than zero")
+		}
+	case domain.DiscountTypeFreeShipping:
+		params.DiscountValue = 0
+	default:
+		return ErrInvalidDiscountType
+	}
+
+	if params.StartsAt.IsZero() {
+		params.StartsAt = time.Now()
+	}
+	if params.ExpiresAt != nil && !params.ExpiresAt.After(params.StartsAt) {
+		return domain.Errorf(domain.EINVALID, "", "Expiry must be after the start date")
+	}
+
+	return nil
+}
+
+// optionalInt4 converts an optional int32 to pgtype.Int4.
+func optionalInt4(v *int32) pgtype.Int4 {
+	if v == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *v, Valid: true}
+}
+
+// optionalTimestamptz converts an optional time to pgtype.Timestamptz.
+func optionalTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/billing"
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/dukerupert/hiri/internal/tax"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCalculateDiscount(t *testing.T) {
+	tests := []struct {
+		name             string
+		discountType     string
+		value            int32
+		subtotal         int32
+		shipping         int32
+		wantCents        int32
+		wantFreeShipping bool
+	}{
+		{"10 percent", domain.DiscountTypePercentage, 10, 4500, 800, 450, false},
+		{"percentage rounds down", domain.DiscountTypePercentage, 15, 1999, 800, 299, false},
+		{"percentage capped at 100", domain.DiscountTypePercentage, 150, 2000, 800, 2000, false},
+		{"fixed amount", domain.DiscountTypeFixedAmount, 500, 4500, 800, 500, false},
+		{"fixed amount capped at subtotal", domain.DiscountTypeFixedAmount, 5000, 3000, 800, 3000, false},
+		{"free shipping", domain.DiscountTypeFreeShipping, 0, 4500, 800, 800, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := repository.DiscountCode{DiscountType: tt.discountType, DiscountValue: tt.value}
+
+			gotCents, gotFree := CalculateDiscount(code, tt.subtotal, tt.shipping)
+
+			assert.Equal(t, tt.wantCents, gotCents)
+			assert.Equal(t, tt.wantFreeShipping, gotFree)
+		})
+	}
+}
+
+func TestDiscountService_ApplyCode(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	base := func() repository.DiscountCode {
+		return repository.DiscountCode{
+			ID:            newUUID(),
+			Code:          "SAVE10",
+			DiscountType:  domain.DiscountTypePercentage,
+			DiscountValue: 10,
+			StartsAt:      pgtype.Timestamptz{Time: now.Add(-24 * time.Hour), Valid: true},
+			IsActive:      true,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(*repository.DiscountCode)
+		subtotal  int32
+		userUsage int64
+		wantErr   error
+		wantCents int32
+	}{
+		{
+			name:      "valid code",
+			modify:    func(*repository.DiscountCode) {},
+			subtotal:  5000,
+			wantCents: 500,
+		},
+		{
+			name:     "inactive",
+			modify:   func(c *repository.DiscountCode) { c.IsActive = false },
+			subtotal: 5000,
+			wantErr:  ErrDiscountCodeInactive,
+		},
+		{
+			name: "not started",
+			modify: func(c *repository.DiscountCode) {
+				c.StartsAt = pgtype.Timestamptz{Time: now.Add(time.Hour), Valid: true}
+			},
+			subtotal: 5000,
+			wantErr:  ErrDiscountCodeNotStarted,
+		},
+		{
+			name: "expired",
+			modify: func(c *repository.DiscountCode) {
+				c.ExpiresAt = pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true}
+			},
+			subtotal: 5000,
+			wantErr:  ErrDiscountCodeExpired,
+		},
+		{
+			name: "global limit reached",
+			modify: func(c *repository.DiscountCode) {
+				c.UsageLimit = pgtype.Int4{Int32: 100, Valid: true}
+				c.UsageCount = 100
+			},
+			subtotal: 5000,
+			wantErr:  ErrDiscountCodeExhausted,
+		},
+		{
+			name: "below minimum order",
+			modify: func(c *repository.DiscountCode) {
+				c.MinimumOrderCents = pgtype.Int4{Int32: 4000, Valid: true}
+			},
+			subtotal: 3999,
+			wantErr:  ErrDiscountMinimumNotMet,
+		},
+		{
+			name: "per-customer limit reached",
+			modify: func(c *repository.DiscountCode) {
+				c.UsageLimitPerCustomer = pgtype.Int4{Int32: 1, Valid: true}
+			},
+			subtotal:  5000,
+			userUsage: 1,
+			wantErr:   ErrDiscountCustomerLimit,
+		},
+		{
+			name: "per-customer limit not yet reached",
+			modify: func(c *repository.DiscountCode) {
+				c.UsageLimitPerCustomer = pgtype.Int4{Int32: 2, Valid: true}
+			},
+			subtotal:  5000,
+			userUsage: 1,
+			wantCents: 500,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tenantID := newUUID()
+			ctx := contextWithTenant(tenantID)
+
+			code := base()
+			tt.modify(&code)
+
+			mockRepo := repository.NewMockQuerier(ctrl)
+			mockRepo.EXPECT().GetDiscountCodeByCode(gomock.Any(), repository.GetDiscountCodeByCodeParams{
+				TenantID: tenantID,
+				Code:     "SAVE10",
+			}).Return(code, nil)
+			mockRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Return(repository.User{ID: newUUID()}, nil).AnyTimes()
+			mockRepo.EXPECT().CountDiscountCodeUsageForUser(gomock.Any(), gomock.Any()).Return(tt.userUsage, nil).AnyTimes()
+
+			svc := &discountService{repo: mockRepo, now: func() time.Time { return now }}
+
+			applied, err := svc.ApplyCode(ctx, domain.ApplyDiscountParams{
+				Code:          " save10 ",
+				SubtotalCents: tt.subtotal,
+				ShippingCents: 800,
+				CustomerEmail: "customer@example.com",
+			})
+
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error %v, want %v", err, tt.wantErr)
+				assert.Nil(t, applied)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCents, applied.DiscountCents)
+			assert.Equal(t, code.ID, applied.DiscountCodeID)
+			assert.Equal(t, "SAVE10", applied.Code)
+		})
+	}
+}
+
+func TestDiscountService_ApplyCode_UnknownCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := contextWithTenant(newUUID())
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockRepo.EXPECT().GetDiscountCodeByCode(gomock.Any(), gomock.Any()).Return(repository.DiscountCode{}, pgx.ErrNoRows)
+
+	svc := NewDiscountService(mockRepo, nil)
+
+	_, err := svc.ApplyCode(ctx, domain.ApplyDiscountParams{Code: "NOPE", SubtotalCents: 5000})
+	assert.True(t, errors.Is(err, ErrDiscountCodeNotFound))
+}
+
+func TestDiscountService_RedeemCode_Exhausted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := contextWithTenant(newUUID())
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockRepo.EXPECT().LockDiscountCode(gomock.Any(), gomock.Any()).Return(repository.DiscountCode{
+		UsageLimit: pgtype.Int4{Int32: 100, Valid: true},
+		UsageCount: 100,
+	}, nil)
+	mockRepo.EXPECT().RecordDiscountCodeUsage(gomock.Any(), gomock.Any()).Times(0)
+
+	svc := NewDiscountService(mockRepo, nil)
+
+	err := svc.RedeemCode(ctx, domain.RedeemDiscountParams{
+		DiscountCodeID: uuidToString(newUUID()),
+		UserID:         newUUID(),
+		OrderID:        newUUID(),
+		DiscountCents:  500,
+	})
+	assert.True(t, errors.Is(err, ErrDiscountCodeExhausted))
+}
+
+func TestAllocateDiscount(t *testing.T) {
+	items := []struct{ total, want int32 }{
+		{3000, 2000},
+		{1500, 1000},
+	}
+
+	lineItems := make([]tax.LineItem, len(items))
+	for i, it := range items {
+		lineItems[i].TotalPrice = it.total
+	}
+
+	allocateDiscount(lineItems, 1500)
+
+	for i, it := range items {
+		assert.Equal(t, it.want, lineItems[i].TotalPrice)
+	}
+}
+
+// Test_CreateOrderFromPaymentIntent_Discount verifies that a discount carried in
+// payment intent metadata is applied to the order and redeemed exactly once.
+func Test_CreateOrderFromPaymentIntent_Discount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+
+	cart := createTestCart(tenantID, "active")
+	cartItems := createTestCartItems()
+	pi := createTestPaymentIntent(uuidToString(cart.ID), "succeeded")
+
+	discountCodeID := newUUID()
+	pi.Metadata["discount_code_id"] = uuidToString(discountCodeID)
+	pi.Metadata["discount_code"] = "SAVE10"
+	pi.Metadata["discount_cents"] = "500"
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	var created repository.CreateOrderParams
+	mockRepo.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, arg repository.CreateOrderParams) (repository.Order, error) {
+			created = arg
+			return repository.Order{ID: newUUID(), TenantID: arg.TenantID, DiscountCents: arg.DiscountCents}, nil
+		})
+	mockRepo.EXPECT().LockDiscountCode(gomock.Any(), repository.LockDiscountCodeParams{
+		TenantID: tenantID,
+		ID:       discountCodeID,
+	}).Return(repository.DiscountCode{ID: discountCodeID}, nil)
+	mockRepo.EXPECT().RecordDiscountCodeUsage(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, arg repository.RecordDiscountCodeUsageParams) error {
+			assert.Equal(t, discountCodeID, arg.ID)
+			assert.Equal(t, int32(500), arg.DiscountAmountCents)
+			assert.True(t, arg.UserID.Valid)
+			return nil
+		}).Times(1)
+	setupMockDefaults(mockRepo, tenantID, cart, cartItems)
+
+	mockBilling := billing.NewMockProvider()
+	mockBilling.PaymentIntents[pi.ID] = pi
+
+	svc := NewOrderService(mockRepo, nil, mockBilling, shipping.NewMockProvider())
+
+	_, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
+	require.NoError(t, err)
+
+	subtotal, _ := calculateOrderTotals(cartItems)
+	assert.Equal(t, int32(500), created.DiscountCents)
+	assert.Equal(t, subtotal+pi.TaxCents+pi.ShippingCents-500, created.TotalCents)
+}
+
+// Test_CreateOrderFromPaymentIntent_DiscountOverLimit verifies that a code
+// exhausted between checkout and payment does not fail the paid order and
+// that the use is still recorded.
+func Test_CreateOrderFromPaymentIntent_DiscountOverLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+
+	cart := createTestCart(tenantID, "active")
+	cartItems := createTestCartItems()
+	pi := createTestPaymentIntent(uuidToString(cart.ID), "succeeded")
+
+	discountCodeID := newUUID()
+	pi.Metadata["discount_code_id"] = uuidToString(discountCodeID)
+	pi.Metadata["discount_code"] = "SAVE10"
+	pi.Metadata["discount_cents"] = "500"
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockRepo.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Return(repository.Order{ID: newUUID(), TenantID: tenantID}, nil)
+	mockRepo.EXPECT().LockDiscountCode(gomock.Any(), gomock.Any()).Return(repository.DiscountCode{
+		ID:         discountCodeID,
+		UsageLimit: pgtype.Int4{Int32: 10, Valid: true},
+		UsageCount: 10,
+	}, nil)
+	mockRepo.EXPECT().RecordDiscountCodeUsage(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	setupMockDefaults(mockRepo, tenantID, cart, cartItems)
+
+	mockBilling := billing.NewMockProvider()
+	mockBilling.PaymentIntents[pi.ID] = pi
+
+	svc := NewOrderService(mockRepo, nil, mockBilling, shipping.NewMockProvider())
+
+	_, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
+	require.NoError(t, err)
+}
@@ -0,0 +1,544 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/billing"
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type dunningService struct {
+	repo                repository.Querier
+	billingProvider     billing.Provider
+	subscriptionService domain.SubscriptionService
+	baseURL             string
+}
+
+// NewDunningService creates a new DunningService instance.
+// baseURL is used to build the links in payment reminder emails.
+func NewDunningService(
+	repo repository.Querier,
+	billingProvider billing.Provider,
+	subscriptionService domain.SubscriptionService,
+	baseURL string,
+) domain.DunningService {
+	return &dunningService{
+		repo:                repo,
+		billingProvider:     billingProvider,
+		subscriptionService: subscriptionService,
+		baseURL:             baseURL,
+	}
+}
+
+// GetSettings returns the tenant's retry schedule, or the default.
+func (s *dunningService) GetSettings(ctx context.Context) (*domain.DunningSettings, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// UpdateSettings validates and saves the tenant's retry schedule.
+func (s *dunningService) UpdateSettings(ctx context.Context, settings domain.DunningSettings) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dunning settings: %w", err)
+	}
+
+	err = s.repo.UpdateTenantDunningSettings(ctx, repository.UpdateTenantDunningSettingsParams{
+		ID:      tenantID,
+		Dunning: settingsJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update dunning settings: %w", err)
+	}
+
+	return nil
+}
+
+// RecordPaymentFailure starts dunning for a failed renewal payment.
+func (s *dunningService) RecordPaymentFailure(ctx context.Context, params domain.PaymentFailureParams) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	subscription, err := s.repo.GetSubscriptionByProviderID(ctx, repository.GetSubscriptionByProviderIDParams{
+		ProviderSubscriptionID: pgtype.Text{String: params.ProviderSubscriptionID, Valid: true},
+		Provider:               "stripe",
+		TenantID:               tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSubscriptionNotFound
+		}
+		return fmt.Errorf("failed to get subscription: %w", err)
+	}
+	if subscription.Status == "cancelled" {
+		return nil
+	}
+
+	// Stripe reports the failure again when our own retries fail, and may
+	// retry an invoice after dunning has ended
+	latest, err := s.repo.GetLatestSubscriptionDunning(ctx, repository.GetLatestSubscriptionDunningParams{
+		TenantID:       tenantID,
+		SubscriptionID: subscription.ID,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to get subscription dunning: %w", err)
+	}
+	if err == nil && (latest.Status == domain.DunningStatusActive || latest.ProviderInvoiceID == params.ProviderInvoiceID) {
+		return nil
+	}
+
+	settings, err := s.getSettings(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	startedAt := time.Now()
+	retryAt, _ := settings.NextRetryAt(startedAt, 1)
+
+	token, err := generateToken()
+	if err != nil {
+		return err
+	}
+
+	dunning, err := s.repo.CreateSubscriptionDunning(ctx, repository.CreateSubscriptionDunningParams{
+		TenantID:          tenantID,
+		SubscriptionID:    subscription.ID,
+		ProviderInvoiceID: params.ProviderInvoiceID,
+		NextAttemptAt:     pgtype.Timestamptz{Time: retryAt, Valid: true},
+		UpdateTokenHash:   pgtype.Text{String: hashToken(token), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Started by a concurrent delivery of the same webhook
+			return nil
+		}
+		return fmt.Errorf("failed to create subscription dunning: %w", err)
+	}
+
+	if err := s.recordFailedPayment(ctx, dunning, ""); err != nil {
+		return err
+	}
+
+	return s.sendReminder(ctx, dunning, token, retryAt, "")
+}
+
+// RecordPaymentSuccess ends dunning for a paid invoice.
+func (s *dunningService) RecordPaymentSuccess(ctx context.Context, providerInvoiceID string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.repo.ResolveSubscriptionDunningForInvoice(ctx, repository.ResolveSubscriptionDunningForInvoiceParams{
+		TenantID:          tenantID,
+		ProviderInvoiceID: providerInvoiceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve subscription dunning: %w", err)
+	}
+
+	return nil
+}
+
+// ProcessDue retries every payment whose retry is due.
+func (s *dunningService) ProcessDue(ctx context.Context) (*domain.DunningResult, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	due, err := s.repo.ListDueSubscriptionDunning(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due payment retries: %w", err)
+	}
+
+	result := &domain.DunningResult{}
+	if len(due) == 0 {
+		return result, nil
+	}
+
+	settings, err := s.getSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dunning := range due {
+		subscription, err := s.repo.GetSubscriptionByID(ctx, repository.GetSubscriptionByIDParams{
+			ID:       dunning.SubscriptionID,
+			TenantID: tenantID,
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to get subscription: %w", err)
+		}
+		if subscription.Status == "cancelled" {
+			// Cancelled by the customer or an operator while in dunning
+			if err := s.resolve(ctx, dunning, domain.DunningStatusCancelled); err != nil {
+				return result, err
+			}
+			continue
+		}
+
+		invoice, err := s.billingProvider.PayInvoice(ctx, billing.PayInvoiceParams{
+			InvoiceID: dunning.ProviderInvoiceID,
+			TenantID:  uuidToString(tenantID),
+		})
+		var stripeErr *billing.StripeError
+		if errors.As(err, &stripeErr) && stripeErr.IsTemporary() {
+			// Stripe could not be reached; the retry stays due
+			continue
+		}
+		result.Retried++
+
+		if err == nil && (invoice.Status == "paid" || invoice.Status == "void") {
+			if err := s.resolve(ctx, dunning, domain.DunningStatusRecovered); err != nil {
+				return result, err
+			}
+			result.Recovered++
+			continue
+		}
+
+		exhausted, err := s.recordFailedRetry(ctx, settings, dunning, paymentFailureReason(err))
+		if err != nil {
+			return result, err
+		}
+		if exhausted {
+			result.Exhausted++
+		} else {
+			result.Failed++
+		}
+	}
+
+	return result, nil
+}
+
+// GetForSubscription returns the subscription's most recent dunning.
+func (s *dunningService) GetForSubscription(ctx context.Context, subscriptionID string) (*repository.SubscriptionDunning, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscriptionUUID pgtype.UUID
+	if err := subscriptionUUID.Scan(subscriptionID); err != nil {
+		return nil, ErrSubscriptionNotFound
+	}
+
+	dunning, err := s.repo.GetLatestSubscriptionDunning(ctx, repository.GetLatestSubscriptionDunningParams{
+		TenantID:       tenantID,
+		SubscriptionID: subscriptionUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get subscription dunning: %w", err)
+	}
+
+	return &dunning, nil
+}
+
+// PaymentUpdateURL exchanges a reminder link token for a billing portal
+// session.
+func (s *dunningService) PaymentUpdateURL(ctx context.Context, token string) (string, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	dunning, err := s.repo.GetSubscriptionDunningByToken(ctx, repository.GetSubscriptionDunningByTokenParams{
+		TenantID:        tenantID,
+		UpdateTokenHash: pgtype.Text{String: hashToken(token), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrDunningLinkExpired
+		}
+		return "", fmt.Errorf("failed to get subscription dunning: %w", err)
+	}
+
+	subscription, err := s.repo.GetSubscriptionByID(ctx, repository.GetSubscriptionByIDParams{
+		ID:       dunning.SubscriptionID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	return s.subscriptionService.CreateCustomerPortalSession(ctx, domain.PortalSessionParams{
+		TenantID:  tenantID,
+		UserID:    subscription.UserID,
+		ReturnURL: s.subscriptionURL(dunning.SubscriptionID),
+	})
+}
+
+// recordFailedRetry records a failed retry and either schedules the next one
+// or, after the final retry, pauses or cancels the subscription. Reports
+// whether retries are exhausted.
+func (s *dunningService) recordFailedRetry(ctx context.Context, settings domain.DunningSettings, dunning repository.SubscriptionDunning, reason string) (bool, error) {
+	retryAt, ok := settings.NextRetryAt(dunning.StartedAt.Time, dunning.AttemptCount+1)
+
+	var token string
+	arg := repository.RecordSubscriptionDunningAttemptParams{
+		ID:                dunning.ID,
+		TenantID:          dunning.TenantID,
+		LastFailureReason: makePgText(reason),
+	}
+	if ok {
+		var err error
+		token, err = generateToken()
+		if err != nil {
+			return false, err
+		}
+		arg.NextAttemptAt = pgtype.Timestamptz{Time: retryAt, Valid: true}
+		arg.UpdateTokenHash = pgtype.Text{String: hashToken(token), Valid: true}
+	}
+
+	dunning, err := s.repo.RecordSubscriptionDunningAttempt(ctx, arg)
+	if err != nil {
+		return false, fmt.Errorf("failed to record payment retry: %w", err)
+	}
+
+	if err := s.recordFailedPayment(ctx, dunning, reason); err != nil {
+		return false, err
+	}
+
+	if ok {
+		return false, s.sendReminder(ctx, dunning, token, retryAt, "")
+	}
+
+	finalAction := settings.FinalAction
+	switch finalAction {
+	case domain.DunningFinalActionCancel:
+		_, err = s.subscriptionService.CancelSubscription(ctx, domain.CancelSubscriptionParams{
+			TenantID:           dunning.TenantID,
+			SubscriptionID:     dunning.SubscriptionID,
+			CancelAtPeriodEnd:  false,
+			CancellationReason: "payment_failed",
+		})
+		if err != nil {
+			return true, fmt.Errorf("failed to cancel subscription after final retry: %w", err)
+		}
+		err = s.resolve(ctx, dunning, domain.DunningStatusCancelled)
+	default:
+		finalAction = domain.DunningFinalActionPause
+		if err := s.pause(ctx, dunning); err != nil {
+			return true, err
+		}
+		err = s.resolve(ctx, dunning, domain.DunningStatusPaused)
+	}
+	if err != nil {
+		return true, err
+	}
+
+	return true, s.sendReminder(ctx, dunning, "", time.Time{}, finalAction)
+}
+
+// pause voids the unpaid invoice and pauses the subscription, so the missed
+// delivery is skipped and the customer can resume once their card is fixed.
+func (s *dunningService) pause(ctx context.Context, dunning repository.SubscriptionDunning) error {
+	subscription, err := s.repo.GetSubscriptionByID(ctx, repository.GetSubscriptionByIDParams{
+		ID:       dunning.SubscriptionID,
+		TenantID: dunning.TenantID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	tenantID := uuidToString(dunning.TenantID)
+	err = s.billingProvider.VoidInvoice(ctx, billing.VoidInvoiceParams{
+		InvoiceID: dunning.ProviderInvoiceID,
+		TenantID:  tenantID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to void unpaid invoice: %w", err)
+	}
+
+	_, err = s.billingProvider.PauseSubscription(ctx, billing.PauseSubscriptionParams{
+		SubscriptionID: subscription.ProviderSubscriptionID.String,
+		TenantID:       tenantID,
+		Behavior:       "void",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to pause Stripe subscription: %w", err)
+	}
+
+	_, err = s.repo.UpdateSubscriptionPauseResume(ctx, repository.UpdateSubscriptionPauseResumeParams{
+		ID:       dunning.SubscriptionID,
+		TenantID: dunning.TenantID,
+		Status:   "paused",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update subscription status: %w", err)
+	}
+
+	metadata, _ := json.Marshal(map[string]string{
+		"event":  "paused",
+		"reason": "payment_failed",
+	})
+	_, err = s.repo.CreateSubscriptionScheduleEvent(ctx, repository.CreateSubscriptionScheduleEventParams{
+		TenantID:       dunning.TenantID,
+		SubscriptionID: dunning.SubscriptionID,
+		EventType:      "pause",
+		Status:         "completed",
+		ScheduledAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		Metadata:       metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create schedule event: %w", err)
+	}
+
+	return nil
+}
+
+// resolve closes dunning with the given status.
+func (s *dunningService) resolve(ctx context.Context, dunning repository.SubscriptionDunning, status string) error {
+	err := s.repo.ResolveSubscriptionDunning(ctx, repository.ResolveSubscriptionDunningParams{
+		ID:       dunning.ID,
+		TenantID: dunning.TenantID,
+		Status:   status,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve subscription dunning: %w", err)
+	}
+	return nil
+}
+
+// recordFailedPayment adds the failed attempt to the subscription's schedule.
+func (s *dunningService) recordFailedPayment(ctx context.Context, dunning repository.SubscriptionDunning, reason string) error {
+	event := map[string]string{
+		"invoice_id": dunning.ProviderInvoiceID,
+		"attempt":    fmt.Sprintf("%d", dunning.AttemptCount),
+	}
+	if reason != "" {
+		event["reason"] = reason
+	}
+	metadata, _ := json.Marshal(event)
+
+	_, err := s.repo.CreateSubscriptionScheduleEvent(ctx, repository.CreateSubscriptionScheduleEventParams{
+		TenantID:       dunning.TenantID,
+		SubscriptionID: dunning.SubscriptionID,
+		EventType:      "payment_failed",
+		Status:         "failed",
+		ScheduledAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		Metadata:       metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create schedule event: %w", err)
+	}
+	return nil
+}
+
+// sendReminder emails the customer about the failed payment. With a token
+// the email links to the update payment page; with a final action it tells
+// them the subscription was paused or cancelled.
+func (s *dunningService) sendReminder(ctx context.Context, dunning repository.SubscriptionDunning, token string, retryAt time.Time, finalAction string) error {
+	subscription, err := s.repo.GetSubscriptionWithDetails(ctx, repository.GetSubscriptionWithDetailsParams{
+		ID:       dunning.SubscriptionID,
+		TenantID: dunning.TenantID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get subscription details: %w", err)
+	}
+
+	items, err := s.repo.ListSubscriptionItemsForSubscription(ctx, repository.ListSubscriptionItemsForSubscriptionParams{
+		SubscriptionID: dunning.SubscriptionID,
+		TenantID:       dunning.TenantID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list subscription items: %w", err)
+	}
+
+	productName := "coffee"
+	if len(items) > 0 {
+		productName = items[0].ProductName
+	}
+
+	customerName := subscription.UserEmail
+	if subscription.FirstName.Valid {
+		customerName = subscription.FirstName.String
+	}
+
+	payload := jobs.SubscriptionPaymentFailedPayload{
+		SubscriptionID: uuid.UUID(dunning.SubscriptionID.Bytes),
+		Email:          subscription.UserEmail,
+		CustomerName:   customerName,
+		ProductName:    productName,
+		FailedDate:     dunning.LastAttemptAt.Time,
+		RetryDate:      retryAt,
+		AttemptNumber:  int(dunning.AttemptCount),
+		FinalAction:    finalAction,
+		ManagementURL:  s.subscriptionURL(dunning.SubscriptionID),
+	}
+	if token != "" {
+		payload.UpdatePaymentURL = s.baseURL + "/subscriptions/update-payment/" + token
+	}
+
+	if err := jobs.EnqueueSubscriptionPaymentFailedEmail(ctx, s.repo, uuid.UUID(dunning.TenantID.Bytes), payload); err != nil {
+		return fmt.Errorf("failed to enqueue payment failed email: %w", err)
+	}
+	return nil
+}
+
+// getSettings loads the tenant's retry schedule, falling back to the default
+// when none has been saved.
+func (s *dunningService) getSettings(ctx context.Context, tenantID pgtype.UUID) (domain.DunningSettings, error) {
+	raw, err := s.repo.GetTenantDunningSettings(ctx, tenantID)
+	if err != nil {
+		return domain.DunningSettings{}, fmt.Errorf("failed to get dunning settings: %w", err)
+	}
+
+	var settings domain.DunningSettings
+	if err := json.Unmarshal(raw, &settings); err != nil || settings.Validate() != nil {
+		return domain.DefaultDunningSettings(), nil
+	}
+	return settings, nil
+}
+
+// subscriptionURL links to the subscription in the customer's account.
+func (s *dunningService) subscriptionURL(subscriptionID pgtype.UUID) string {
+	return s.baseURL + "/account/subscriptions/" + subscriptionID.String()
+}
+
+// paymentFailureReason describes why a retry failed, for operators.
+func paymentFailureReason(err error) string {
+	if err == nil {
+		return "Payment was not completed"
+	}
+	var stripeErr *billing.StripeError
+	if errors.As(err, &stripeErr) {
+		if stripeErr.DeclineCode != "" {
+			return fmt.Sprintf("%s (%s)", stripeErr.Message, stripeErr.DeclineCode)
+		}
+		return stripeErr.Message
+	}
+	return err.Error()
+}
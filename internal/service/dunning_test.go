@@ -0,0 +1,396 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/billing"
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDunningSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings domain.DunningSettings
+		wantErr  error
+	}{
+		{name: "default", settings: domain.DefaultDunningSettings()},
+		{name: "cancel", settings: domain.DunningSettings{RetryDays: []int32{1}, FinalAction: "cancel"}},
+		{name: "no retries", settings: domain.DunningSettings{FinalAction: "pause"}, wantErr: ErrInvalidDunningSchedule},
+		{name: "not increasing", settings: domain.DunningSettings{RetryDays: []int32{3, 3}, FinalAction: "pause"}, wantErr: ErrInvalidDunningSchedule},
+		{name: "too late", settings: domain.DunningSettings{RetryDays: []int32{3, 31}, FinalAction: "pause"}, wantErr: ErrInvalidDunningSchedule},
+		{name: "too many", settings: domain.DunningSettings{RetryDays: []int32{1, 2, 3, 4, 5, 6, 7}, FinalAction: "pause"}, wantErr: ErrInvalidDunningSchedule},
+		{name: "unknown final action", settings: domain.DunningSettings{RetryDays: []int32{3}, FinalAction: "ignore"}, wantErr: ErrInvalidDunningFinalAction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDunningSettings_NextRetryAt(t *testing.T) {
+	settings := domain.DefaultDunningSettings()
+	started := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	next, ok := settings.NextRetryAt(started, 1)
+	require.True(t, ok)
+	assert.Equal(t, started.AddDate(0, 0, 3), next)
+
+	next, ok = settings.NextRetryAt(started, 3)
+	require.True(t, ok)
+	assert.Equal(t, started.AddDate(0, 0, 7), next)
+
+	// The failed renewal plus three retries exhausts [3, 5, 7]
+	_, ok = settings.NextRetryAt(started, 4)
+	assert.False(t, ok)
+}
+
+func TestDunningService_UpdateSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewDunningService(mockRepo, &mockSubscriptionBillingProvider{}, nil, "")
+	ctx := contextWithTenant(tenantID)
+
+	mockRepo.EXPECT().UpdateTenantDunningSettings(gomock.Any(), repository.UpdateTenantDunningSettingsParams{
+		ID:      tenantID,
+		Dunning: []byte(`{"retry_days":[2,4],"final_action":"cancel"}`),
+	}).Return(nil)
+	require.NoError(t, svc.UpdateSettings(ctx, domain.DunningSettings{RetryDays: []int32{2, 4}, FinalAction: "cancel"}))
+
+	// Invalid schedules are never saved
+	err := svc.UpdateSettings(ctx, domain.DunningSettings{RetryDays: []int32{5, 2}, FinalAction: "pause"})
+	assert.ErrorIs(t, err, ErrInvalidDunningSchedule)
+}
+
+func TestDunningService_GetSettings_DefaultsWhenUnset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewDunningService(mockRepo, &mockSubscriptionBillingProvider{}, nil, "")
+
+	mockRepo.EXPECT().GetTenantDunningSettings(gomock.Any(), gomock.Any()).Return([]byte(`{}`), nil)
+
+	settings, err := svc.GetSettings(contextWithTenant(newUUID()))
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultDunningSettings(), *settings)
+}
+
+func TestDunningService_RecordPaymentFailure_StartsDunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	subscription := repository.Subscription{ID: newUUID(), TenantID: tenantID, Status: "past_due"}
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewDunningService(mockRepo, &mockSubscriptionBillingProvider{}, nil, "https://example.com")
+
+	mockRepo.EXPECT().GetSubscriptionByProviderID(gomock.Any(), repository.GetSubscriptionByProviderIDParams{
+		ProviderSubscriptionID: pgtype.Text{String: "sub_123", Valid: true},
+		Provider:               "stripe",
+		TenantID:               tenantID,
+	}).Return(subscription, nil)
+	mockRepo.EXPECT().GetLatestSubscriptionDunning(gomock.Any(), gomock.Any()).
+		Return(repository.SubscriptionDunning{}, pgx.ErrNoRows)
+	mockRepo.EXPECT().GetTenantDunningSettings(gomock.Any(), tenantID).
+		Return([]byte(`{"retry_days":[2,4],"final_action":"cancel"}`), nil)
+
+	var tokenHash string
+	mockRepo.EXPECT().CreateSubscriptionDunning(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.CreateSubscriptionDunningParams) (repository.SubscriptionDunning, error) {
+			assert.Equal(t, subscription.ID, arg.SubscriptionID)
+			assert.Equal(t, "in_123", arg.ProviderInvoiceID)
+			assert.WithinDuration(t, time.Now().AddDate(0, 0, 2), arg.NextAttemptAt.Time, time.Minute)
+			require.True(t, arg.UpdateTokenHash.Valid)
+			tokenHash = arg.UpdateTokenHash.String
+			return repository.SubscriptionDunning{
+				ID:                newUUID(),
+				TenantID:          tenantID,
+				SubscriptionID:    subscription.ID,
+				ProviderInvoiceID: arg.ProviderInvoiceID,
+				Status:            domain.DunningStatusActive,
+				AttemptCount:      1,
+				UpdateTokenHash:   arg.UpdateTokenHash,
+			}, nil
+		})
+	mockRepo.EXPECT().CreateSubscriptionScheduleEvent(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.CreateSubscriptionScheduleEventParams) (repository.SubscriptionSchedule, error) {
+			assert.Equal(t, "payment_failed", arg.EventType)
+			return repository.SubscriptionSchedule{}, nil
+		})
+	mockRepo.EXPECT().GetSubscriptionWithDetails(gomock.Any(), gomock.Any()).Return(repository.GetSubscriptionWithDetailsRow{
+		UserEmail: "sam@example.com",
+		FirstName: pgtype.Text{String: "Sam", Valid: true},
+	}, nil)
+	mockRepo.EXPECT().ListSubscriptionItemsForSubscription(gomock.Any(), gomock.Any()).
+		Return([]repository.ListSubscriptionItemsForSubscriptionRow{{ProductName: "Ethiopia Guji"}}, nil)
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.EnqueueJobParams) (repository.Job, error) {
+			assert.Equal(t, jobs.JobTypeSubscriptionPaymentFailed, arg.JobType)
+
+			var payload jobs.SubscriptionPaymentFailedPayload
+			require.NoError(t, json.Unmarshal(arg.Payload, &payload))
+			assert.Equal(t, "sam@example.com", payload.Email)
+			assert.Equal(t, "Sam", payload.CustomerName)
+			assert.Equal(t, "Ethiopia Guji", payload.ProductName)
+			assert.Empty(t, payload.FinalAction)
+
+			// The link carries the token whose hash was stored
+			const prefix = "https://example.com/subscriptions/update-payment/"
+			require.True(t, strings.HasPrefix(payload.UpdatePaymentURL, prefix))
+			assert.Equal(t, tokenHash, hashToken(strings.TrimPrefix(payload.UpdatePaymentURL, prefix)))
+			return repository.Job{}, nil
+		})
+
+	err := svc.RecordPaymentFailure(contextWithTenant(tenantID), domain.PaymentFailureParams{
+		ProviderSubscriptionID: "sub_123",
+		ProviderInvoiceID:      "in_123",
+	})
+	require.NoError(t, err)
+}
+
+func TestDunningService_RecordPaymentFailure_AlreadyInDunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewDunningService(mockRepo, &mockSubscriptionBillingProvider{}, nil, "")
+
+	mockRepo.EXPECT().GetSubscriptionByProviderID(gomock.Any(), gomock.Any()).
+		Return(repository.Subscription{ID: newUUID(), Status: "past_due"}, nil)
+	mockRepo.EXPECT().GetLatestSubscriptionDunning(gomock.Any(), gomock.Any()).
+		Return(repository.SubscriptionDunning{ProviderInvoiceID: "in_123", Status: domain.DunningStatusActive}, nil)
+	mockRepo.EXPECT().CreateSubscriptionDunning(gomock.Any(), gomock.Any()).Times(0)
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).Times(0)
+
+	// Our own failed retries are reported by Stripe too
+	err := svc.RecordPaymentFailure(contextWithTenant(newUUID()), domain.PaymentFailureParams{
+		ProviderSubscriptionID: "sub_123",
+		ProviderInvoiceID:      "in_123",
+	})
+	require.NoError(t, err)
+}
+
+func TestDunningService_ProcessDue_Recovered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	dunning := repository.SubscriptionDunning{
+		ID:                newUUID(),
+		TenantID:          tenantID,
+		SubscriptionID:    newUUID(),
+		ProviderInvoiceID: "in_123",
+		Status:            domain.DunningStatusActive,
+		AttemptCount:      1,
+	}
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockBilling := &mockSubscriptionBillingProvider{payInvoiceResult: &billing.Invoice{ID: "in_123", Status: "paid"}}
+	svc := NewDunningService(mockRepo, mockBilling, nil, "")
+
+	mockRepo.EXPECT().ListDueSubscriptionDunning(gomock.Any(), tenantID).Return([]repository.SubscriptionDunning{dunning}, nil)
+	mockRepo.EXPECT().GetTenantDunningSettings(gomock.Any(), tenantID).Return([]byte(`{}`), nil)
+	mockRepo.EXPECT().GetSubscriptionByID(gomock.Any(), gomock.Any()).Return(repository.Subscription{Status: "past_due"}, nil)
+	mockRepo.EXPECT().ResolveSubscriptionDunning(gomock.Any(), repository.ResolveSubscriptionDunningParams{
+		ID:       dunning.ID,
+		TenantID: tenantID,
+		Status:   domain.DunningStatusRecovered,
+	}).Return(nil)
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).Times(0)
+
+	result, err := svc.ProcessDue(contextWithTenant(tenantID))
+	require.NoError(t, err)
+	assert.Equal(t, domain.DunningResult{Retried: 1, Recovered: 1}, *result)
+}
+
+func TestDunningService_ProcessDue_DeclinedSchedulesNextRetry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	started := time.Now().AddDate(0, 0, -3)
+	dunning := repository.SubscriptionDunning{
+		ID:                newUUID(),
+		TenantID:          tenantID,
+		SubscriptionID:    newUUID(),
+		ProviderInvoiceID: "in_123",
+		Status:            domain.DunningStatusActive,
+		AttemptCount:      1,
+		StartedAt:         pgtype.Timestamptz{Time: started, Valid: true},
+	}
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockBilling := &mockSubscriptionBillingProvider{
+		payInvoiceErr: billing.NewStripeError("Your card has insufficient funds.", "card_declined", "insufficient_funds", "", nil),
+	}
+	svc := NewDunningService(mockRepo, mockBilling, nil, "https://example.com")
+
+	mockRepo.EXPECT().ListDueSubscriptionDunning(gomock.Any(), tenantID).Return([]repository.SubscriptionDunning{dunning}, nil)
+	mockRepo.EXPECT().GetTenantDunningSettings(gomock.Any(), tenantID).Return([]byte(`{}`), nil)
+	mockRepo.EXPECT().GetSubscriptionByID(gomock.Any(), gomock.Any()).Return(repository.Subscription{Status: "past_due"}, nil)
+	mockRepo.EXPECT().RecordSubscriptionDunningAttempt(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.RecordSubscriptionDunningAttemptParams) (repository.SubscriptionDunning, error) {
+			// Second retry of [3, 5, 7] is five days after the failed renewal
+			assert.Equal(t, started.AddDate(0, 0, 5), arg.NextAttemptAt.Time)
+			assert.True(t, arg.UpdateTokenHash.Valid)
+			assert.Equal(t, "Your card has insufficient funds. (insufficient_funds)", arg.LastFailureReason.String)
+			updated := dunning
+			updated.AttemptCount = 2
+			updated.NextAttemptAt = arg.NextAttemptAt
+			return updated, nil
+		})
+	mockRepo.EXPECT().CreateSubscriptionScheduleEvent(gomock.Any(), gomock.Any()).Return(repository.SubscriptionSchedule{}, nil)
+	mockRepo.EXPECT().GetSubscriptionWithDetails(gomock.Any(), gomock.Any()).
+		Return(repository.GetSubscriptionWithDetailsRow{UserEmail: "sam@example.com"}, nil)
+	mockRepo.EXPECT().ListSubscriptionItemsForSubscription(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.EnqueueJobParams) (repository.Job, error) {
+			var payload jobs.SubscriptionPaymentFailedPayload
+			require.NoError(t, json.Unmarshal(arg.Payload, &payload))
+			assert.Equal(t, 2, payload.AttemptNumber)
+			assert.Equal(t, started.AddDate(0, 0, 5).Unix(), payload.RetryDate.Unix())
+			assert.NotEmpty(t, payload.UpdatePaymentURL)
+			return repository.Job{}, nil
+		})
+	mockRepo.EXPECT().ResolveSubscriptionDunning(gomock.Any(), gomock.Any()).Times(0)
+
+	result, err := svc.ProcessDue(contextWithTenant(tenantID))
+	require.NoError(t, err)
+	assert.Equal(t, domain.DunningResult{Retried: 1, Failed: 1}, *result)
+}
+
+func TestDunningService_ProcessDue_FinalRetryPauses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	subscription := repository.Subscription{
+		ID:                     newUUID(),
+		TenantID:               tenantID,
+		Status:                 "past_due",
+		ProviderSubscriptionID: pgtype.Text{String: "sub_123", Valid: true},
+	}
+	dunning := repository.SubscriptionDunning{
+		ID:                newUUID(),
+		TenantID:          tenantID,
+		SubscriptionID:    subscription.ID,
+		ProviderInvoiceID: "in_123",
+		Status:            domain.DunningStatusActive,
+		AttemptCount:      3,
+		StartedAt:         pgtype.Timestamptz{Time: time.Now().AddDate(0, 0, -7), Valid: true},
+	}
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockBilling := &mockSubscriptionBillingProvider{
+		payInvoiceErr: billing.NewStripeError("Your card was declined.", "card_declined", "generic_decline", "", nil),
+	}
+	svc := NewDunningService(mockRepo, mockBilling, nil, "https://example.com")
+
+	mockRepo.EXPECT().ListDueSubscriptionDunning(gomock.Any(), tenantID).Return([]repository.SubscriptionDunning{dunning}, nil)
+	mockRepo.EXPECT().GetTenantDunningSettings(gomock.Any(), tenantID).Return([]byte(`{}`), nil)
+	mockRepo.EXPECT().GetSubscriptionByID(gomock.Any(), gomock.Any()).Return(subscription, nil).Times(2)
+	mockRepo.EXPECT().RecordSubscriptionDunningAttempt(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.RecordSubscriptionDunningAttemptParams) (repository.SubscriptionDunning, error) {
+			// No retries remain, so nothing is scheduled and the link stops working
+			assert.False(t, arg.NextAttemptAt.Valid)
+			assert.False(t, arg.UpdateTokenHash.Valid)
+			updated := dunning
+			updated.AttemptCount = 4
+			return updated, nil
+		})
+	mockRepo.EXPECT().UpdateSubscriptionPauseResume(gomock.Any(), repository.UpdateSubscriptionPauseResumeParams{
+		ID:       subscription.ID,
+		TenantID: tenantID,
+		Status:   "paused",
+	}).Return(subscription, nil)
+
+	var events []string
+	mockRepo.EXPECT().CreateSubscriptionScheduleEvent(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.CreateSubscriptionScheduleEventParams) (repository.SubscriptionSchedule, error) {
+			events = append(events, arg.EventType)
+			return repository.SubscriptionSchedule{}, nil
+		}).Times(2)
+	mockRepo.EXPECT().ResolveSubscriptionDunning(gomock.Any(), repository.ResolveSubscriptionDunningParams{
+		ID:       dunning.ID,
+		TenantID: tenantID,
+		Status:   domain.DunningStatusPaused,
+	}).Return(nil)
+	mockRepo.EXPECT().GetSubscriptionWithDetails(gomock.Any(), gomock.Any()).
+		Return(repository.GetSubscriptionWithDetailsRow{UserEmail: "sam@example.com"}, nil)
+	mockRepo.EXPECT().ListSubscriptionItemsForSubscription(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.EnqueueJobParams) (repository.Job, error) {
+			var payload jobs.SubscriptionPaymentFailedPayload
+			require.NoError(t, json.Unmarshal(arg.Payload, &payload))
+			assert.Equal(t, domain.DunningFinalActionPause, payload.FinalAction)
+			assert.Empty(t, payload.UpdatePaymentURL)
+			return repository.Job{}, nil
+		})
+
+	result, err := svc.ProcessDue(contextWithTenant(tenantID))
+	require.NoError(t, err)
+	assert.Equal(t, domain.DunningResult{Retried: 1, Exhausted: 1}, *result)
+
+	// The unpaid invoice is voided and the Stripe subscription paused
+	require.Len(t, mockBilling.voidCalls, 1)
+	assert.Equal(t, "in_123", mockBilling.voidCalls[0].InvoiceID)
+	require.Len(t, mockBilling.pauseCalls, 1)
+	assert.Equal(t, "sub_123", mockBilling.pauseCalls[0].SubscriptionID)
+	assert.Equal(t, []string{"payment_failed", "pause"}, events)
+}
+
+func TestDunningService_ProcessDue_TemporaryErrorIsNotCounted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockBilling := &mockSubscriptionBillingProvider{
+		payInvoiceErr: billing.NewStripeError("Could not connect to Stripe", "api_connection_error", "", "", nil),
+	}
+	svc := NewDunningService(mockRepo, mockBilling, nil, "")
+
+	mockRepo.EXPECT().ListDueSubscriptionDunning(gomock.Any(), tenantID).
+		Return([]repository.SubscriptionDunning{{ID: newUUID(), TenantID: tenantID, AttemptCount: 1}}, nil)
+	mockRepo.EXPECT().GetTenantDunningSettings(gomock.Any(), tenantID).Return([]byte(`{}`), nil)
+	mockRepo.EXPECT().GetSubscriptionByID(gomock.Any(), gomock.Any()).Return(repository.Subscription{Status: "past_due"}, nil)
+	mockRepo.EXPECT().RecordSubscriptionDunningAttempt(gomock.Any(), gomock.Any()).Times(0)
+
+	result, err := svc.ProcessDue(contextWithTenant(tenantID))
+	require.NoError(t, err)
+	assert.Equal(t, domain.DunningResult{}, *result)
+}
+
+func TestDunningService_PaymentUpdateURL_Expired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewDunningService(mockRepo, &mockSubscriptionBillingProvider{}, nil, "")
+
+	mockRepo.EXPECT().GetSubscriptionDunningByToken(gomock.Any(), repository.GetSubscriptionDunningByTokenParams{
+		TenantID:        tenantID,
+		UpdateTokenHash: pgtype.Text{String: hashToken("stale"), Valid: true},
+	}).Return(repository.SubscriptionDunning{}, pgx.ErrNoRows)
+
+	_, err := svc.PaymentUpdateURL(contextWithTenant(tenantID), "stale")
+	assert.ErrorIs(t, err, ErrDunningLinkExpired)
+}
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/email"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/tenant"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// EmailRenderer renders the default and customized versions of an email.
+// It is implemented by *email.Service.
+type EmailRenderer interface {
+	DefaultTemplate(templateType string) (*email.TemplateOverride, error)
+	Preview(ctx context.Context, templateType string, override *email.TemplateOverride) (*email.Rendered, error)
+	SendTest(ctx context.Context, to, templateType string, override *email.TemplateOverride) error
+}
+
+type emailTemplateService struct {
+	repo   repository.Querier
+	emails EmailRenderer
+}
+
+// NewEmailTemplateService creates a new EmailTemplateService instance.
+func NewEmailTemplateService(repo repository.Querier, emails EmailRenderer) domain.EmailTemplateService {
+	return &emailTemplateService{
+		repo:   repo,
+		emails: emails,
+	}
+}
+
+// ListTemplates returns every customizable email, noting which the tenant
+// has customized.
+func (s *emailTemplateService) ListTemplates(ctx context.Context) ([]domain.EmailTemplateSummary, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := s.repo.ListEmailTemplates(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email templates: %w", err)
+	}
+	byType := make(map[string]repository.EmailTemplate, len(saved))
+	for _, t := range saved {
+		byType[t.TemplateType] = t
+	}
+
+	infos := email.CustomizableTemplates()
+	summaries := make([]domain.EmailTemplateSummary, 0, len(infos))
+	for _, info := range infos {
+		summary := templateSummary(info)
+		if t, ok := byType[info.Type]; ok {
+			applySaved(&summary, t)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// GetTemplate returns the tenant's version of an email, or the default
+// when it hasn't been customized.
+func (s *emailTemplateService) GetTemplate(ctx context.Context, templateType string) (*domain.EmailTemplateDetail, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info, ok := email.LookupTemplate(templateType)
+	if !ok {
+		return nil, ErrEmailTemplateNotFound
+	}
+
+	detail := &domain.EmailTemplateDetail{
+		EmailTemplateSummary: templateSummary(info),
+		Variables:            templateVariables(info.Variables),
+		Functions:            templateVariables(email.TemplateFunctions),
+	}
+
+	saved, err := s.repo.GetEmailTemplate(ctx, repository.GetEmailTemplateParams{
+		TenantID:     tenantID,
+		TemplateType: templateType,
+	})
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get email template: %w", err)
+		}
+
+		def, err := s.emails.DefaultTemplate(templateType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load default email template: %w", err)
+		}
+		detail.Subject = def.Subject
+		detail.BodyHTML = def.BodyHTML
+		detail.Active = true
+		return detail, nil
+	}
+
+	applySaved(&detail.EmailTemplateSummary, saved)
+	detail.Subject = saved.Subject
+	detail.BodyHTML = saved.BodyHtml
+	detail.BodyText = saved.BodyText.String
+	return detail, nil
+}
+
+// SaveTemplate validates and saves the tenant's version of an email.
+func (s *emailTemplateService) SaveTemplate(ctx context.Context, params domain.SaveEmailTemplateParams) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	info, override, err := s.validate(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.repo.UpsertEmailTemplate(ctx, repository.UpsertEmailTemplateParams{
+		TenantID:     tenantID,
+		TemplateType: info.Type,
+		Name:         info.Name,
+		Subject:      override.Subject,
+		BodyHtml:     override.BodyHTML,
+		BodyText:     pgtype.Text{String: override.BodyText, Valid: override.BodyText != ""},
+		IsActive:     params.Active,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save email template: %w", err)
+	}
+
+	return nil
+}
+
+// ResetTemplate deletes the tenant's version, so the default is sent.
+func (s *emailTemplateService) ResetTemplate(ctx context.Context, templateType string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := email.LookupTemplate(templateType); !ok {
+		return ErrEmailTemplateNotFound
+	}
+
+	// Resetting an email that was never customized is a no-op
+	_, err = s.repo.DeleteEmailTemplate(ctx, repository.DeleteEmailTemplateParams{
+		TenantID:     tenantID,
+		TemplateType: templateType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset email template: %w", err)
+	}
+
+	return nil
+}
+
+// PreviewTemplate renders an unsaved version against sample data.
+func (s *emailTemplateService) PreviewTemplate(ctx context.Context, params domain.SaveEmailTemplateParams) (*domain.EmailPreview, error) {
+	if _, err := ExtractTenantID(ctx); err != nil {
+		return nil, err
+	}
+
+	info, override, err := s.override(params)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := s.emails.Preview(ctx, info.Type, override)
+	if err != nil {
+		return nil, emailTemplateError(err)
+	}
+
+	return &domain.EmailPreview{
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
+	}, nil
+}
+
+// SendTestEmail sends an unsaved version, rendered against sample data, to
+// the given address.
+func (s *emailTemplateService) SendTestEmail(ctx context.Context, params domain.SaveEmailTemplateParams, to string) error {
+	if _, err := ExtractTenantID(ctx); err != nil {
+		return err
+	}
+
+	info, override, err := s.validate(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	if err := s.emails.SendTest(ctx, to, info.Type, override); err != nil {
+		return emailTemplateError(err)
+	}
+
+	return nil
+}
+
+// override checks the required fields and converts params to an override.
+func (s *emailTemplateService) override(params domain.SaveEmailTemplateParams) (email.TemplateInfo, *email.TemplateOverride, error) {
+	info, ok := email.LookupTemplate(params.Type)
+	if !ok {
+		return email.TemplateInfo{}, nil, ErrEmailTemplateNotFound
+	}
+
+	override := &email.TemplateOverride{
+		Subject:  strings.TrimSpace(params.Subject),
+		BodyHTML: strings.TrimSpace(params.BodyHTML),
+		BodyText: strings.TrimSpace(params.BodyText),
+	}
+	if override.Subject == "" {
+		return email.TemplateInfo{}, nil, ErrEmailSubjectRequired
+	}
+	if override.BodyHTML == "" {
+		return email.TemplateInfo{}, nil, ErrEmailBodyRequired
+	}
+
+	return info, override, nil
+}
+
+// validate is override plus a render against sample data, so a template
+// that would fail when sent is rejected up front.
+func (s *emailTemplateService) validate(ctx context.Context, params domain.SaveEmailTemplateParams) (email.TemplateInfo, *email.TemplateOverride, error) {
+	info, override, err := s.override(params)
+	if err != nil {
+		return email.TemplateInfo{}, nil, err
+	}
+
+	if _, err := s.emails.Preview(ctx, info.Type, override); err != nil {
+		return email.TemplateInfo{}, nil, emailTemplateError(err)
+	}
+
+	return info, override, nil
+}
+
+// emailTemplateError maps an invalid template from the email package to a
+// domain validation error.
+func emailTemplateError(err error) error {
+	if email.IsInvalidTemplate(err) {
+		return &domain.Error{Code: domain.EINVALID, Message: err.Error()}
+	}
+	return fmt.Errorf("failed to render email template: %w", err)
+}
+
+func templateSummary(info email.TemplateInfo) domain.EmailTemplateSummary {
+	return domain.EmailTemplateSummary{
+		Type:        info.Type,
+		Name:        info.Name,
+		Description: info.Description,
+	}
+}
+
+func applySaved(summary *domain.EmailTemplateSummary, saved repository.EmailTemplate) {
+	summary.Customized = true
+	summary.Active = saved.IsActive
+	if saved.UpdatedAt.Valid {
+		summary.UpdatedAt = saved.UpdatedAt.Time
+	}
+}
+
+func templateVariables(vars []email.TemplateVariable) []domain.EmailTemplateVariable {
+	out := make([]domain.EmailTemplateVariable, len(vars))
+	for i, v := range vars {
+		out[i] = domain.EmailTemplateVariable{Name: v.Name, Description: v.Description}
+	}
+	return out
+}
+
+// emailOverrideStore serves a tenant's active email templates to the email
+// service.
+type emailOverrideStore struct {
+	repo repository.Querier
+}
+
+// NewEmailOverrideStore creates the store email.Service uses to find a
+// tenant's customized emails.
+func NewEmailOverrideStore(repo repository.Querier) email.OverrideStore {
+	return &emailOverrideStore{repo: repo}
+}
+
+// ActiveOverride returns the active template for the tenant on the
+// context. Emails sent without a tenant, such as platform emails to
+// operators, always use the default.
+func (s *emailOverrideStore) ActiveOverride(ctx context.Context, templateType string) (*email.TemplateOverride, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		if errors.Is(err, tenant.ErrNoTenant) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	saved, err := s.repo.GetActiveEmailTemplate(ctx, repository.GetActiveEmailTemplateParams{
+		TenantID:     tenantID,
+		TemplateType: templateType,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get email template: %w", err)
+	}
+
+	return &email.TemplateOverride{
+		Subject:  saved.Subject,
+		BodyHTML: saved.BodyHtml,
+		BodyText: saved.BodyText.String,
+	}, nil
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/email"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// recordingEmailSender records sent emails.
+type recordingEmailSender struct {
+	sent []*email.Email
+}
+
+func (s *recordingEmailSender) Send(_ context.Context, e *email.Email) (string, error) {
+	s.sent = append(s.sent, e)
+	return "msg-1", nil
+}
+
+func (s *recordingEmailSender) SendTemplate(context.Context, string, []string, map[string]interface{}) (string, error) {
+	return "", email.ErrNotImplemented
+}
+
+func newTestEmailTemplateService(t *testing.T) (domain.EmailTemplateService, *repository.MockQuerier, *recordingEmailSender) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	sender := &recordingEmailSender{}
+	emails, err := email.NewService(sender, "shop@example.com", "Example Roasters", "../../web/templates", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	return NewEmailTemplateService(mockRepo, emails), mockRepo, sender
+}
+
+func TestEmailTemplateService_ListTemplates(t *testing.T) {
+	svc, mockRepo, _ := newTestEmailTemplateService(t)
+	tenantID := newUUID()
+	updated := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	mockRepo.EXPECT().ListEmailTemplates(gomock.Any(), tenantID).Return([]repository.EmailTemplate{
+		{TemplateType: "order_confirmation", IsActive: true, UpdatedAt: pgtype.Timestamptz{Time: updated, Valid: true}},
+		{TemplateType: "invoice_sent", IsActive: false},
+	}, nil)
+
+	summaries, err := svc.ListTemplates(contextWithTenant(tenantID))
+	require.NoError(t, err)
+	require.Len(t, summaries, len(email.CustomizableTemplates()))
+
+	byType := make(map[string]domain.EmailTemplateSummary)
+	for _, s := range summaries {
+		byType[s.Type] = s
+	}
+	assert.True(t, byType["order_confirmation"].Customized)
+	assert.True(t, byType["order_confirmation"].Active)
+	assert.Equal(t, updated, byType["order_confirmation"].UpdatedAt)
+	assert.True(t, byType["invoice_sent"].Customized)
+	assert.False(t, byType["invoice_sent"].Active)
+	assert.False(t, byType["password_reset"].Customized)
+}
+
+func TestEmailTemplateService_GetTemplate_Default(t *testing.T) {
+	svc, mockRepo, _ := newTestEmailTemplateService(t)
+	tenantID := newUUID()
+
+	mockRepo.EXPECT().GetEmailTemplate(gomock.Any(), repository.GetEmailTemplateParams{
+		TenantID:     tenantID,
+		TemplateType: "order_confirmation",
+	}).Return(repository.EmailTemplate{}, pgx.ErrNoRows)
+
+	detail, err := svc.GetTemplate(contextWithTenant(tenantID), "order_confirmation")
+	require.NoError(t, err)
+	assert.False(t, detail.Customized)
+	assert.True(t, detail.Active)
+	assert.Contains(t, detail.Subject, "{{.OrderNumber}}")
+	assert.NotEmpty(t, detail.BodyHTML)
+	assert.NotEmpty(t, detail.Variables)
+	assert.NotEmpty(t, detail.Functions)
+}
+
+func TestEmailTemplateService_GetTemplate_UnknownType(t *testing.T) {
+	svc, _, _ := newTestEmailTemplateService(t)
+
+	_, err := svc.GetTemplate(contextWithTenant(newUUID()), "operator_setup")
+	assert.ErrorIs(t, err, ErrEmailTemplateNotFound)
+}
+
+func TestEmailTemplateService_SaveTemplate(t *testing.T) {
+	svc, mockRepo, _ := newTestEmailTemplateService(t)
+	tenantID := newUUID()
+
+	mockRepo.EXPECT().UpsertEmailTemplate(gomock.Any(), repository.UpsertEmailTemplateParams{
+		TenantID:     tenantID,
+		TemplateType: "order_confirmation",
+		Name:         "Order confirmation",
+		Subject:      "Thanks for order {{.OrderNumber}}",
+		BodyHtml:     "<p>Hi {{.CustomerName}}</p>",
+		BodyText:     pgtype.Text{},
+		IsActive:     true,
+	}).Return(repository.EmailTemplate{}, nil)
+
+	err := svc.SaveTemplate(contextWithTenant(tenantID), domain.SaveEmailTemplateParams{
+		Type:     "order_confirmation",
+		Subject:  "  Thanks for order {{.OrderNumber}} ",
+		BodyHTML: "<p>Hi {{.CustomerName}}</p>\n",
+		Active:   true,
+	})
+	require.NoError(t, err)
+}
+
+func TestEmailTemplateService_SaveTemplate_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		params domain.SaveEmailTemplateParams
+	}{
+		{name: "missing subject", params: domain.SaveEmailTemplateParams{Type: "order_confirmation", BodyHTML: "<p>Hi</p>"}},
+		{name: "missing body", params: domain.SaveEmailTemplateParams{Type: "order_confirmation", Subject: "Thanks"}},
+		{name: "syntax error", params: domain.SaveEmailTemplateParams{Type: "order_confirmation", Subject: "Thanks", BodyHTML: "<p>{{.CustomerName</p>"}},
+		{name: "unknown field", params: domain.SaveEmailTemplateParams{Type: "order_confirmation", Subject: "Thanks", BodyHTML: "<p>{{.Nope}}</p>"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// No repository calls are expected: invalid templates are never saved
+			svc, _, _ := newTestEmailTemplateService(t)
+
+			err := svc.SaveTemplate(contextWithTenant(newUUID()), tt.params)
+			require.Error(t, err)
+			assert.Equal(t, domain.EINVALID, domain.ErrorCode(err))
+		})
+	}
+}
+
+func TestEmailTemplateService_ResetTemplate(t *testing.T) {
+	svc, mockRepo, _ := newTestEmailTemplateService(t)
+	tenantID := newUUID()
+
+	mockRepo.EXPECT().DeleteEmailTemplate(gomock.Any(), repository.DeleteEmailTemplateParams{
+		TenantID:     tenantID,
+		TemplateType: "invoice_sent",
+	}).Return(int64(1), nil)
+
+	require.NoError(t, svc.ResetTemplate(contextWithTenant(tenantID), "invoice_sent"))
+	assert.ErrorIs(t, svc.ResetTemplate(contextWithTenant(tenantID), "nope"), ErrEmailTemplateNotFound)
+}
+
+func TestEmailTemplateService_SendTestEmail(t *testing.T) {
+	svc, _, sender := newTestEmailTemplateService(t)
+
+	err := svc.SendTestEmail(contextWithTenant(newUUID()), domain.SaveEmailTemplateParams{
+		Type:     "order_confirmation",
+		Subject:  "Order {{.OrderNumber}}",
+		BodyHTML: "<p>Hi {{.CustomerName}}</p>",
+	}, "owner@example.com")
+	require.NoError(t, err)
+
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, []string{"owner@example.com"}, sender.sent[0].To)
+	assert.Contains(t, sender.sent[0].Subject, "[Test]")
+}
+
+func TestEmailOverrideStore_ActiveOverride(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	store := NewEmailOverrideStore(mockRepo)
+	tenantID := newUUID()
+
+	// Without a tenant the default is always used
+	override, err := store.ActiveOverride(context.Background(), "order_confirmation")
+	require.NoError(t, err)
+	assert.Nil(t, override)
+
+	mockRepo.EXPECT().GetActiveEmailTemplate(gomock.Any(), repository.GetActiveEmailTemplateParams{
+		TenantID:     tenantID,
+		TemplateType: "order_confirmation",
+	}).Return(repository.EmailTemplate{}, pgx.ErrNoRows)
+
+	override, err = store.ActiveOverride(contextWithTenant(tenantID), "order_confirmation")
+	require.NoError(t, err)
+	assert.Nil(t, override)
+
+	mockRepo.EXPECT().GetActiveEmailTemplate(gomock.Any(), gomock.Any()).Return(repository.EmailTemplate{
+		Subject:  "Order {{.OrderNumber}}",
+		BodyHtml: "<p>Hi</p>",
+		BodyText: pgtype.Text{String: "Hi", Valid: true},
+	}, nil)
+
+	override, err = store.ActiveOverride(contextWithTenant(tenantID), "order_confirmation")
+	require.NoError(t, err)
+	require.NotNil(t, override)
+	assert.Equal(t, "Hi", override.BodyText)
+}
@@ -33,16 +33,19 @@ var (
 
 // Subscription-related errors - re-exported from domain
 var (
-	ErrSubscriptionNotFound    = domain.ErrSubscriptionNotFound
-	ErrNoPaymentMethod         = domain.ErrNoPaymentMethod
-	ErrInvalidBillingInterval  = domain.ErrInvalidBillingInterval
-	ErrSubscriptionNotActive   = domain.ErrSubscriptionNotActive
-	ErrSubscriptionNotPaused   = domain.ErrSubscriptionNotPaused
-	ErrInvoiceNotFound         = domain.ErrInvoiceNotFound
-	ErrInvoiceAlreadyProcessed = domain.ErrInvoiceAlreadyProcessed
-	ErrPaymentMethodOwnership  = domain.ErrPaymentMethodOwnership
-	ErrInvoiceNotSubscription  = domain.ErrInvoiceNotSubscription
-	ErrSubscriptionHasNoItems  = domain.ErrSubscriptionHasNoItems
+	ErrSubscriptionNotFound      = domain.ErrSubscriptionNotFound
+	ErrNoPaymentMethod           = domain.ErrNoPaymentMethod
+	ErrInvalidBillingInterval    = domain.ErrInvalidBillingInterval
+	ErrSubscriptionNotActive     = domain.ErrSubscriptionNotActive
+	ErrSubscriptionNotPaused     = domain.ErrSubscriptionNotPaused
+	ErrInvoiceNotFound           = domain.ErrInvoiceNotFound
+	ErrInvoiceAlreadyProcessed   = domain.ErrInvoiceAlreadyProcessed
+	ErrPaymentMethodOwnership    = domain.ErrPaymentMethodOwnership
+	ErrInvoiceNotSubscription    = domain.ErrInvoiceNotSubscription
+	ErrSubscriptionHasNoItems    = domain.ErrSubscriptionHasNoItems
+	ErrSubscriptionNotChangeable = domain.ErrSubscriptionNotChangeable
+	ErrInvalidResumeDate         = domain.ErrInvalidResumeDate
+	ErrAddressOwnership          = domain.ErrAddressOwnership
 )
 
 // Payment terms errors - re-exported from domain
@@ -52,6 +55,33 @@ var (
 	ErrDuplicatePaymentTermsCode = domain.ErrDuplicatePaymentTermsCode
 )
 
+// Discount code errors - re-exported from domain
+var (
+	ErrDiscountCodeNotFound   = domain.ErrDiscountCodeNotFound
+	ErrDiscountCodeInactive   = domain.ErrDiscountCodeInactive
+	ErrDiscountCodeNotStarted = domain.ErrDiscountCodeNotStarted
+	ErrDiscountCodeExpired    = domain.ErrDiscountCodeExpired
+	ErrDiscountCodeExhausted  = domain.ErrDiscountCodeExhausted
+	ErrDiscountCustomerLimit  = domain.ErrDiscountCustomerLimit
+	ErrDiscountMinimumNotMet  = domain.ErrDiscountMinimumNotMet
+	ErrDuplicateDiscountCode  = domain.ErrDuplicateDiscountCode
+	ErrInvalidDiscountType    = domain.ErrInvalidDiscountType
+	ErrDiscountAmountChanged  = domain.ErrDiscountAmountChanged
+)
+
+// Refund errors - re-exported from domain
+var (
+	ErrOrderNotRefundable    = domain.ErrOrderNotRefundable
+	ErrOrderNotPaidByCard    = domain.ErrOrderNotPaidByCard
+	ErrInvalidRefundMode     = domain.ErrInvalidRefundMode
+	ErrInvalidRefundAmount   = domain.ErrInvalidRefundAmount
+	ErrRefundExceedsBalance  = domain.ErrRefundExceedsBalance
+	ErrRefundItemNotFound    = domain.ErrRefundItemNotFound
+	ErrRefundQuantityInvalid = domain.ErrRefundQuantityInvalid
+	ErrOrderNotCancellable   = domain.ErrOrderNotCancellable
+	ErrOrderInvoiced         = domain.ErrOrderInvoiced
+)
+
 // Wholesale invoice errors - re-exported from domain
 var (
 	ErrInvoiceAlreadyFinalized = domain.ErrInvoiceAlreadyFinalized
@@ -73,6 +103,151 @@ var (
 	ErrNoItemsToShip          = domain.ErrNoItemsToShip
 )
 
+// Shipping label errors - re-exported from domain
+var (
+	ErrNoLabelProvider     = domain.ErrNoLabelProvider
+	ErrOrderNotShippable   = domain.ErrOrderNotShippable
+	ErrNoShippingAddress   = domain.ErrNoShippingAddress
+	ErrNoWarehouseAddress  = domain.ErrNoWarehouseAddress
+	ErrInvalidPackage      = domain.ErrInvalidPackage
+	ErrRateRequired        = domain.ErrRateRequired
+	ErrNoLabelRates        = domain.ErrNoLabelRates
+	ErrShipmentHasNoLabel  = domain.ErrShipmentHasNoLabel
+	ErrLabelAlreadyVoided  = domain.ErrLabelAlreadyVoided
+	ErrLabelAlreadyShipped = domain.ErrLabelAlreadyShipped
+)
+
+// Fulfillment batch errors - re-exported from domain
+var (
+	ErrFulfillmentBatchNotFound = domain.ErrFulfillmentBatchNotFound
+	ErrNoOrdersSelected         = domain.ErrNoOrdersSelected
+	ErrTooManyBatchOrders       = domain.ErrTooManyBatchOrders
+	ErrOrdersNotInQueue         = domain.ErrOrdersNotInQueue
+	ErrBatchDocumentNotReady    = domain.ErrBatchDocumentNotReady
+)
+
+// Roast schedule errors - re-exported from domain
+var (
+	ErrRoastBatchNotFound       = domain.ErrRoastBatchNotFound
+	ErrRoastBatchCancelled      = domain.ErrRoastBatchCancelled
+	ErrRoastBatchAlreadyRoasted = domain.ErrRoastBatchAlreadyRoasted
+	ErrRoastProductRequired     = domain.ErrRoastProductRequired
+	ErrRoastDateRequired        = domain.ErrRoastDateRequired
+	ErrRoastDateInFuture        = domain.ErrRoastDateInFuture
+	ErrInvalidRoastWeight       = domain.ErrInvalidRoastWeight
+	ErrInvalidRestDays          = domain.ErrInvalidRestDays
+	ErrRoastAllocationMissing   = domain.ErrRoastAllocationMissing
+	ErrInvalidPlanWindow        = domain.ErrInvalidPlanWindow
+	ErrInvalidRoastLoss         = domain.ErrInvalidRoastLoss
+)
+
+// Inventory errors - re-exported from domain
+var (
+	ErrInvalidAdjustmentReason   = domain.ErrInvalidAdjustmentReason
+	ErrInvalidAdjustmentQuantity = domain.ErrInvalidAdjustmentQuantity
+	ErrInvalidStockCount         = domain.ErrInvalidStockCount
+	ErrAdjustmentBelowZero       = domain.ErrAdjustmentBelowZero
+	ErrInvalidLowStockThreshold  = domain.ErrInvalidLowStockThreshold
+)
+
+// Dunning errors - re-exported from domain
+var (
+	ErrInvalidDunningSchedule    = domain.ErrInvalidDunningSchedule
+	ErrInvalidDunningFinalAction = domain.ErrInvalidDunningFinalAction
+	ErrDunningLinkExpired        = domain.ErrDunningLinkExpired
+)
+
+// Job console errors - re-exported from domain
+var (
+	ErrJobNotFound         = domain.ErrJobNotFound
+	ErrJobNotRetryable     = domain.ErrJobNotRetryable
+	ErrJobNotCancellable   = domain.ErrJobNotCancellable
+	ErrJobNotReschedulable = domain.ErrJobNotReschedulable
+	ErrInvalidJobStatus    = domain.ErrInvalidJobStatus
+	ErrJobTypeRequired     = domain.ErrJobTypeRequired
+)
+
+// Email template errors - re-exported from domain
+var (
+	ErrEmailTemplateNotFound = domain.ErrEmailTemplateNotFound
+	ErrEmailSubjectRequired  = domain.ErrEmailSubjectRequired
+	ErrEmailBodyRequired     = domain.ErrEmailBodyRequired
+)
+
+// Category and tag errors - re-exported from domain
+var (
+	ErrCategoryNotFound      = domain.ErrCategoryNotFound
+	ErrCategoryNameRequired  = domain.ErrCategoryNameRequired
+	ErrDuplicateCategorySlug = domain.ErrDuplicateCategorySlug
+	ErrInvalidCategoryParent = domain.ErrInvalidCategoryParent
+	ErrTagNotFound           = domain.ErrTagNotFound
+	ErrTagNameRequired       = domain.ErrTagNameRequired
+	ErrDuplicateTagSlug      = domain.ErrDuplicateTagSlug
+	ErrInvalidCatalogSlug    = domain.ErrInvalidCatalogSlug
+)
+
+// Review errors - re-exported from domain
+var (
+	ErrReviewNotFound       = domain.ErrReviewNotFound
+	ErrInvalidRating        = domain.ErrInvalidRating
+	ErrReviewTitleTooLong   = domain.ErrReviewTitleTooLong
+	ErrAlreadyReviewed      = domain.ErrAlreadyReviewed
+	ErrProductNotReviewable = domain.ErrProductNotReviewable
+	ErrCannotVoteOwnReview  = domain.ErrCannotVoteOwnReview
+)
+
+// White-label errors - re-exported from domain
+var (
+	ErrWhiteLabelBaseInvalid     = domain.ErrWhiteLabelBaseInvalid
+	ErrWhiteLabelCustomerInvalid = domain.ErrWhiteLabelCustomerInvalid
+	ErrWhiteLabelNameRequired    = domain.ErrWhiteLabelNameRequired
+	ErrWhiteLabelPriceRequired   = domain.ErrWhiteLabelPriceRequired
+	ErrWhiteLabelInvalidPrice    = domain.ErrWhiteLabelInvalidPrice
+	ErrDuplicateWhiteLabel       = domain.ErrDuplicateWhiteLabel
+	ErrWhiteLabelPriceList       = domain.ErrWhiteLabelPriceList
+)
+
+// On-account ordering errors - re-exported from domain
+var (
+	ErrOnAccountNotEnabled     = domain.ErrOnAccountNotEnabled
+	ErrCreditLimitExceeded     = domain.ErrCreditLimitExceeded
+	ErrShippingRateUnavailable = domain.ErrShippingRateUnavailable
+)
+
+// Wholesale order rule errors - re-exported from domain
+var (
+	ErrQuantityRuleNotMet  = domain.ErrQuantityRuleNotMet
+	ErrInvalidQuantityRule = domain.ErrInvalidQuantityRule
+)
+
+// Standing order errors - re-exported from domain
+var (
+	ErrStandingOrderNotFound        = domain.ErrStandingOrderNotFound
+	ErrStandingOrderCancelled       = domain.ErrStandingOrderCancelled
+	ErrStandingOrderEmpty           = domain.ErrStandingOrderEmpty
+	ErrStandingOrderNotActive       = domain.ErrStandingOrderNotActive
+	ErrStandingOrderAddress         = domain.ErrStandingOrderAddress
+	ErrInvalidStandingOrderSchedule = domain.ErrInvalidStandingOrderSchedule
+	ErrInvalidStandingOrderSettings = domain.ErrInvalidStandingOrderSettings
+)
+
+// Order template errors - re-exported from domain
+var (
+	ErrOrderTemplateNotFound = domain.ErrOrderTemplateNotFound
+	ErrOrderTemplateName     = domain.ErrOrderTemplateName
+	ErrOrderTemplateEmpty    = domain.ErrOrderTemplateEmpty
+)
+
+// Branding errors - re-exported from domain
+var (
+	ErrInvalidBrandColor     = domain.ErrInvalidBrandColor
+	ErrInvalidBrandFont      = domain.ErrInvalidBrandFont
+	ErrInvalidBrandImageKind = domain.ErrInvalidBrandImageKind
+	ErrBrandImageTooLarge    = domain.ErrBrandImageTooLarge
+	ErrBrandLogoType         = domain.ErrBrandLogoType
+	ErrBrandFaviconType      = domain.ErrBrandFaviconType
+)
+
 // User/customer errors - re-exported from domain
 var (
 	ErrNotWholesaleUser   = domain.ErrNotWholesaleUser
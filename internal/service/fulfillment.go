@@ -115,25 +115,24 @@ type OrderItemFulfillment struct {
 }
 
 type fulfillmentService struct {
-	repo     repository.Querier
-	tenantID pgtype.UUID
+	repo repository.Querier
 }
 
 // NewFulfillmentService creates a new FulfillmentService instance.
-func NewFulfillmentService(repo repository.Querier, tenantID string) (FulfillmentService, error) {
-	var tenantUUID pgtype.UUID
-	if err := tenantUUID.Scan(tenantID); err != nil {
-		return nil, fmt.Errorf("invalid tenant ID: %w", err)
-	}
-
+// The tenant is read from the context of each call.
+func NewFulfillmentService(repo repository.Querier) FulfillmentService {
 	return &fulfillmentService{
-		repo:     repo,
-		tenantID: tenantUUID,
-	}, nil
+		repo: repo,
+	}
 }
 
 // CreateShipment creates a shipment for one or more order items.
 func (s *fulfillmentService) CreateShipment(ctx context.Context, params CreateShipmentParams) (*ShipmentDetail, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(params.ShipmentItems) == 0 {
 		return nil, ErrNoItemsToShip
 	}
@@ -145,7 +144,7 @@ func (s *fulfillmentService) CreateShipment(ctx context.Context, params CreateSh
 
 	// Get order to validate it exists
 	order, err := s.repo.GetOrder(ctx, repository.GetOrderParams{
-		TenantID: s.tenantID,
+		TenantID: tenantID,
 		ID:       orderID,
 	})
 	if err != nil {
@@ -193,7 +192,7 @@ func (s *fulfillmentService) CreateShipment(ctx context.Context, params CreateSh
 	}
 
 	shipment, err := s.repo.CreateShipment(ctx, repository.CreateShipmentParams{
-		TenantID:         s.tenantID,
+		TenantID:         tenantID,
 		OrderID:          order.ID,
 		Carrier:          carrier,
 		TrackingNumber:   trackingNumber,
@@ -217,7 +216,7 @@ func (s *fulfillmentService) CreateShipment(ctx context.Context, params CreateSh
 
 		// Create shipment item
 		shipmentItem, err := s.repo.CreateShipmentItem(ctx, repository.CreateShipmentItemParams{
-			TenantID:    s.tenantID,
+			TenantID:    tenantID,
 			ShipmentID:  shipment.ID,
 			OrderItemID: orderItemID,
 			Quantity:    si.Quantity,
@@ -228,7 +227,7 @@ func (s *fulfillmentService) CreateShipment(ctx context.Context, params CreateSh
 
 		// Update order item dispatched quantity
 		err = s.repo.UpdateOrderItemDispatchedQuantity(ctx, repository.UpdateOrderItemDispatchedQuantityParams{
-			TenantID:           s.tenantID,
+			TenantID:           tenantID,
 			ID:                 orderItemID,
 			QuantityDispatched: si.Quantity, // quantity to add
 		})
@@ -256,7 +255,7 @@ func (s *fulfillmentService) CreateShipment(ctx context.Context, params CreateSh
 
 	// Recalculate order fulfillment status
 	err = s.repo.RecalculateOrderFulfillmentStatus(ctx, repository.RecalculateOrderFulfillmentStatusParams{
-		TenantID: s.tenantID,
+		TenantID: tenantID,
 		ID:       orderID,
 	})
 	if err != nil {
@@ -432,13 +431,18 @@ func (s *fulfillmentService) UpdateShipmentTracking(ctx context.Context, params
 
 // UpdateShipmentStatus updates the status of a shipment.
 func (s *fulfillmentService) UpdateShipmentStatus(ctx context.Context, shipmentID string, status string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
 	var sID pgtype.UUID
 	if err := sID.Scan(shipmentID); err != nil {
 		return fmt.Errorf("invalid shipment ID: %w", err)
 	}
 
-	err := s.repo.UpdateShipmentStatus(ctx, repository.UpdateShipmentStatusParams{
-		TenantID: s.tenantID,
+	err = s.repo.UpdateShipmentStatus(ctx, repository.UpdateShipmentStatusParams{
+		TenantID: tenantID,
 		ID:       sID,
 		Status:   status,
 	})
@@ -0,0 +1,563 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // Label image formats
+	_ "image/png"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/dukerupert/hiri/internal/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxBatchOrders caps how many orders a single batch job buys labels for.
+const maxBatchOrders = 100
+
+// recentBatchLimit is how many batches the fulfillment queue lists.
+const recentBatchLimit = 10
+
+type fulfillmentBatchService struct {
+	repo       repository.Querier
+	labels     domain.ShippingLabelService
+	storage    storage.Storage
+	httpClient *http.Client
+}
+
+// NewFulfillmentBatchService creates a new FulfillmentBatchService instance
+func NewFulfillmentBatchService(repo repository.Querier, labels domain.ShippingLabelService, fileStorage storage.Storage) domain.FulfillmentBatchService {
+	return &fulfillmentBatchService{
+		repo:       repo,
+		labels:     labels,
+		storage:    fileStorage,
+		httpClient: &http.Client{Timeout: labelDownloadTimeout},
+	}
+}
+
+// ListQueue returns orders waiting to ship that are not already being batched.
+func (s *fulfillmentBatchService) ListQueue(ctx context.Context) ([]repository.ListFulfillmentQueueRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.repo.ListFulfillmentQueue(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fulfillment queue: %w", err)
+	}
+
+	return orders, nil
+}
+
+// PickList aggregates the unshipped items of the selected orders by SKU.
+func (s *fulfillmentBatchService) PickList(ctx context.Context, orderIDs []string) ([]domain.PickListLine, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.queuedOrders(ctx, tenantID, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	fulfillment := NewFulfillmentService(s.repo)
+	var picks pickListBuilder
+	for _, order := range orders {
+		items, err := fulfillment.GetUnfulfilledItems(ctx, uuidToString(order.ID))
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			picks.add(order.ID, item.SKU, item.ProductName, item.VariantDescription, item.QuantityRemaining)
+		}
+	}
+
+	return picks.lines(), nil
+}
+
+// CreateBatch records a batch for the selected orders and queues the job that
+// buys their labels. Orders are printed oldest first.
+func (s *fulfillmentBatchService) CreateBatch(ctx context.Context, orderIDs []string) (*repository.FulfillmentBatch, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.queuedOrders(ctx, tenantID, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := s.repo.CreateFulfillmentBatch(ctx, repository.CreateFulfillmentBatchParams{
+		TenantID:   tenantID,
+		OrderCount: int32(len(orders)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fulfillment batch: %w", err)
+	}
+
+	for i, order := range orders {
+		_, err := s.repo.CreateFulfillmentBatchOrder(ctx, repository.CreateFulfillmentBatchOrderParams{
+			TenantID: tenantID,
+			BatchID:  batch.ID,
+			OrderID:  order.ID,
+			Position: int32(i + 1),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add order to batch: %w", err)
+		}
+	}
+
+	err = jobs.EnqueueProcessFulfillmentBatch(ctx, s.repo, uuid.UUID(tenantID.Bytes), jobs.ProcessFulfillmentBatchPayload{
+		BatchID: uuid.UUID(batch.ID.Bytes),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue fulfillment batch: %w", err)
+	}
+
+	return &batch, nil
+}
+
+// ProcessBatch buys a label for each pending order using the cheapest rate for
+// its estimated package, then stores the merged labels and packing slips PDF.
+//
+// Orders whose label cannot be bought are marked failed and return to the
+// fulfillment queue; they do not fail the batch. Orders already purchased are
+// skipped, and a label bought by an earlier run that failed before recording
+// it is reused, so the job can be retried without buying twice.
+func (s *fulfillmentBatchService) ProcessBatch(ctx context.Context, batchID string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	batch, err := s.getBatch(ctx, tenantID, batchID)
+	if err != nil {
+		return err
+	}
+
+	if batch.Status == "completed" {
+		return nil
+	}
+
+	err = s.repo.StartFulfillmentBatch(ctx, repository.StartFulfillmentBatchParams{
+		TenantID: tenantID,
+		ID:       batch.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start fulfillment batch: %w", err)
+	}
+
+	orders, err := s.batchOrders(ctx, tenantID, batch.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		if order.Status != "pending" {
+			continue
+		}
+
+		shipment, err := s.batchLabel(ctx, batch, order.OrderID)
+		if err == nil && shipment == nil {
+			shipment, err = s.buyLabel(ctx, order.OrderID)
+		}
+		if err != nil {
+			// Stop on cancellation so the retry picks up where we left off
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			err = s.repo.MarkFulfillmentBatchOrderFailed(ctx, repository.MarkFulfillmentBatchOrderFailedParams{
+				TenantID:     tenantID,
+				ID:           order.ID,
+				ErrorMessage: makePgText(batchErrorMessage(err)),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record label failure: %w", err)
+			}
+			continue
+		}
+
+		err = s.repo.MarkFulfillmentBatchOrderPurchased(ctx, repository.MarkFulfillmentBatchOrderPurchasedParams{
+			TenantID:   tenantID,
+			ID:         order.ID,
+			ShipmentID: shipment.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record purchased label: %w", err)
+		}
+	}
+
+	// Reload to pick up the shipments just created
+	orders, err = s.batchOrders(ctx, tenantID, batch.ID)
+	if err != nil {
+		return err
+	}
+
+	var documentKey pgtype.Text
+	key, err := s.storeDocument(ctx, tenantID, batch.ID, orders)
+	if err != nil {
+		_ = s.repo.FailFulfillmentBatch(ctx, repository.FailFulfillmentBatchParams{
+			TenantID:     tenantID,
+			ID:           batch.ID,
+			ErrorMessage: makePgText(err.Error()),
+		})
+		return err
+	}
+	if key != "" {
+		documentKey = pgtype.Text{String: key, Valid: true}
+	}
+
+	_, err = s.repo.CompleteFulfillmentBatch(ctx, repository.CompleteFulfillmentBatchParams{
+		TenantID:           tenantID,
+		ID:                 batch.ID,
+		DocumentStorageKey: documentKey,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete fulfillment batch: %w", err)
+	}
+
+	return nil
+}
+
+// GetBatch returns a batch with its orders.
+func (s *fulfillmentBatchService) GetBatch(ctx context.Context, batchID string) (*domain.FulfillmentBatchDetail, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := s.getBatch(ctx, tenantID, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.batchOrders(ctx, tenantID, batch.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.FulfillmentBatchDetail{Batch: batch, Orders: orders}, nil
+}
+
+// ListBatches returns recent batches, newest first.
+func (s *fulfillmentBatchService) ListBatches(ctx context.Context) ([]repository.FulfillmentBatch, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	batches, err := s.repo.ListFulfillmentBatches(ctx, repository.ListFulfillmentBatchesParams{
+		TenantID: tenantID,
+		Limit:    recentBatchLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fulfillment batches: %w", err)
+	}
+
+	return batches, nil
+}
+
+// OpenDocument opens the batch's merged labels and packing slips PDF.
+func (s *fulfillmentBatchService) OpenDocument(ctx context.Context, batchID string) (io.ReadCloser, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := s.getBatch(ctx, tenantID, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !batch.DocumentStorageKey.Valid {
+		return nil, ErrBatchDocumentNotReady
+	}
+
+	file, err := s.storage.Get(ctx, batch.DocumentStorageKey.String)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open batch document: %w", err)
+	}
+
+	return file, nil
+}
+
+// queuedOrders resolves the selected order IDs to orders still waiting to ship.
+// Every selected order must still be in the queue.
+func (s *fulfillmentBatchService) queuedOrders(ctx context.Context, tenantID pgtype.UUID, orderIDs []string) ([]repository.ListFulfillmentQueueByIDsRow, error) {
+	seen := make(map[string]bool, len(orderIDs))
+	ids := make([]pgtype.UUID, 0, len(orderIDs))
+	for _, orderID := range orderIDs {
+		if seen[orderID] {
+			continue
+		}
+		seen[orderID] = true
+
+		var id pgtype.UUID
+		if err := id.Scan(orderID); err != nil {
+			return nil, ErrOrdersNotInQueue
+		}
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		return nil, ErrNoOrdersSelected
+	}
+	if len(ids) > maxBatchOrders {
+		return nil, ErrTooManyBatchOrders
+	}
+
+	orders, err := s.repo.ListFulfillmentQueueByIDs(ctx, repository.ListFulfillmentQueueByIDsParams{
+		TenantID: tenantID,
+		OrderIds: ids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get selected orders: %w", err)
+	}
+
+	if len(orders) != len(ids) {
+		return nil, ErrOrdersNotInQueue
+	}
+
+	return orders, nil
+}
+
+// getBatch loads a batch with tenant scoping.
+func (s *fulfillmentBatchService) getBatch(ctx context.Context, tenantID pgtype.UUID, batchID string) (repository.FulfillmentBatch, error) {
+	var batchUUID pgtype.UUID
+	if err := batchUUID.Scan(batchID); err != nil {
+		return repository.FulfillmentBatch{}, ErrFulfillmentBatchNotFound
+	}
+
+	batch, err := s.repo.GetFulfillmentBatch(ctx, repository.GetFulfillmentBatchParams{
+		TenantID: tenantID,
+		ID:       batchUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return batch, ErrFulfillmentBatchNotFound
+		}
+		return batch, fmt.Errorf("failed to get fulfillment batch: %w", err)
+	}
+
+	return batch, nil
+}
+
+// batchOrders lists a batch's orders in print order.
+func (s *fulfillmentBatchService) batchOrders(ctx context.Context, tenantID, batchID pgtype.UUID) ([]repository.ListFulfillmentBatchOrdersRow, error) {
+	orders, err := s.repo.ListFulfillmentBatchOrders(ctx, repository.ListFulfillmentBatchOrdersParams{
+		TenantID: tenantID,
+		BatchID:  batchID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list batch orders: %w", err)
+	}
+	return orders, nil
+}
+
+// batchLabel returns the active label shipment bought for an order since the
+// batch was created, or nil if there is none.
+func (s *fulfillmentBatchService) batchLabel(ctx context.Context, batch repository.FulfillmentBatch, orderID pgtype.UUID) (*repository.Shipment, error) {
+	shipments, err := s.repo.GetShipmentsByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipments: %w", err)
+	}
+
+	// Newest first
+	for _, shipment := range shipments {
+		if !shipment.ProviderLabelID.Valid || shipment.LabelVoidedAt.Valid || shipment.Status == "cancelled" {
+			continue
+		}
+		if shipment.CreatedAt.Time.Before(batch.CreatedAt.Time) {
+			break
+		}
+		return &shipment, nil
+	}
+
+	return nil, nil
+}
+
+// buyLabel buys the cheapest label for an order's estimated package.
+func (s *fulfillmentBatchService) buyLabel(ctx context.Context, orderID pgtype.UUID) (*repository.Shipment, error) {
+	id := uuidToString(orderID)
+
+	pkg, err := s.labels.EstimatePackage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := s.labels.GetRates(ctx, id, pkg)
+	if err != nil {
+		return nil, err
+	}
+	if len(rates) == 0 {
+		return nil, ErrNoLabelRates
+	}
+
+	// Rates are sorted cheapest first
+	return s.labels.BuyLabel(ctx, domain.BuyLabelParams{
+		OrderID: id,
+		RateID:  rates[0].RateID,
+		Package: pkg,
+	})
+}
+
+// storeDocument builds the batch's PDF and stores it.
+// Returns an empty key if no labels were purchased.
+func (s *fulfillmentBatchService) storeDocument(ctx context.Context, tenantID, batchID pgtype.UUID, orders []repository.ListFulfillmentBatchOrdersRow) (string, error) {
+	var packages []batchPackage
+	for _, order := range orders {
+		if order.Status != "purchased" || !order.ShipmentID.Valid {
+			continue
+		}
+
+		details, err := s.repo.GetOrderWithDetails(ctx, repository.GetOrderWithDetailsParams{
+			TenantID: tenantID,
+			ID:       order.OrderID,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to get order %s: %w", order.OrderNumber, err)
+		}
+
+		items, err := s.repo.GetShipmentItems(ctx, order.ShipmentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get shipment items for order %s: %w", order.OrderNumber, err)
+		}
+
+		roastDates, err := roastDatesByOrderItem(ctx, s.repo, tenantID, order.OrderID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get roast dates for order %s: %w", order.OrderNumber, err)
+		}
+
+		packages = append(packages, batchPackage{
+			order:      order,
+			details:    details,
+			items:      items,
+			roastDates: roastDates,
+			labelImage: s.fetchLabelImage(ctx, order.LabelImageUrl.String),
+		})
+	}
+
+	if len(packages) == 0 {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if _, err := buildBatchDocument(packages).WriteTo(&buf); err != nil {
+		return "", fmt.Errorf("failed to write batch document: %w", err)
+	}
+
+	key := path.Join("fulfillment", uuidToString(tenantID), uuidToString(batchID)+".pdf")
+	if _, err := s.storage.Put(ctx, key, &buf, "application/pdf"); err != nil {
+		return "", fmt.Errorf("failed to store batch document: %w", err)
+	}
+
+	return key, nil
+}
+
+// fetchLabelImage downloads and decodes a label image.
+// Returns nil if there is no image or it cannot be read; the document then
+// tells staff to print that label from the order page.
+func (s *fulfillmentBatchService) fetchLabelImage(ctx context.Context, imageURL string) image.Image {
+	if imageURL == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	img, _, err := image.Decode(resp.Body)
+	if err != nil {
+		return nil
+	}
+
+	return img
+}
+
+// batchErrorMessage converts a label purchase error into a message for staff.
+func batchErrorMessage(err error) string {
+	if domain.ErrorCode(err) == domain.EINVALID || domain.ErrorCode(err) == domain.ENOTFOUND {
+		return domain.ErrorMessage(err)
+	}
+
+	var shipErr *shipping.ShippingError
+	if errors.As(err, &shipErr) {
+		return shipErr.Message
+	}
+
+	return err.Error()
+}
+
+// pickListBuilder totals item quantities by SKU across orders.
+type pickListBuilder struct {
+	byKey  map[string]*domain.PickListLine
+	orders map[string]map[[16]byte]bool
+}
+
+// add records qty of a SKU picked for an order.
+func (b *pickListBuilder) add(orderID pgtype.UUID, sku, productName, variant string, qty int32) {
+	if b.byKey == nil {
+		b.byKey = make(map[string]*domain.PickListLine)
+		b.orders = make(map[string]map[[16]byte]bool)
+	}
+
+	key := sku + "\x00" + variant
+	line, ok := b.byKey[key]
+	if !ok {
+		line = &domain.PickListLine{SKU: sku, ProductName: productName, VariantDescription: variant}
+		b.byKey[key] = line
+		b.orders[key] = make(map[[16]byte]bool)
+	}
+
+	line.Quantity += qty
+	if !b.orders[key][orderID.Bytes] {
+		b.orders[key][orderID.Bytes] = true
+		line.OrderCount++
+	}
+}
+
+// lines returns the pick list sorted by product, then variant.
+func (b *pickListBuilder) lines() []domain.PickListLine {
+	lines := make([]domain.PickListLine, 0, len(b.byKey))
+	for _, line := range b.byKey {
+		lines = append(lines, *line)
+	}
+
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].ProductName != lines[j].ProductName {
+			return lines[i].ProductName < lines[j].ProductName
+		}
+		if lines[i].VariantDescription != lines[j].VariantDescription {
+			return lines[i].VariantDescription < lines[j].VariantDescription
+		}
+		return lines[i].SKU < lines[j].SKU
+	})
+
+	return lines
+}
@@ -0,0 +1,336 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/dukerupert/hiri/internal/storage"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// stubLabelService buys labels from a fixed rate table.
+// Orders with no rates fail the way a carrier would.
+type stubLabelService struct {
+	domain.ShippingLabelService
+	rates  map[string][]shipping.Rate
+	bought []domain.BuyLabelParams
+}
+
+func (s *stubLabelService) EstimatePackage(_ context.Context, _ string) (shipping.Package, error) {
+	return testPackage, nil
+}
+
+func (s *stubLabelService) GetRates(_ context.Context, orderID string, _ shipping.Package) ([]shipping.Rate, error) {
+	return s.rates[orderID], nil
+}
+
+func (s *stubLabelService) BuyLabel(_ context.Context, params domain.BuyLabelParams) (*repository.Shipment, error) {
+	s.bought = append(s.bought, params)
+	return &repository.Shipment{ID: newUUID()}, nil
+}
+
+func TestFulfillmentBatchService_PickList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewFulfillmentBatchService(mockRepo, nil, nil)
+
+	first, second := newUUID(), newUUID()
+	mockRepo.EXPECT().ListFulfillmentQueueByIDs(gomock.Any(), repository.ListFulfillmentQueueByIDsParams{
+		TenantID: tenantID,
+		OrderIds: []pgtype.UUID{first, second},
+	}).Return([]repository.ListFulfillmentQueueByIDsRow{{ID: first}, {ID: second}}, nil)
+
+	wholeBean := pgtype.Text{String: "12oz - Whole Bean", Valid: true}
+	ground := pgtype.Text{String: "12oz - Drip", Valid: true}
+	mockRepo.EXPECT().GetUnfulfilledOrderItems(gomock.Any(), first).Return([]repository.GetUnfulfilledOrderItemsRow{
+		{ProductName: "Ethiopia Guji", Sku: "ETH-12-WB", VariantDescription: wholeBean, Quantity: 2, QuantityRemaining: 2},
+		{ProductName: "Colombia Huila", Sku: "COL-12-DR", VariantDescription: ground, Quantity: 1, QuantityRemaining: 1},
+	}, nil)
+	mockRepo.EXPECT().GetUnfulfilledOrderItems(gomock.Any(), second).Return([]repository.GetUnfulfilledOrderItemsRow{
+		{ProductName: "Ethiopia Guji", Sku: "ETH-12-WB", VariantDescription: wholeBean, Quantity: 3, QuantityRemaining: 1},
+	}, nil)
+
+	// The duplicate selection is ignored
+	lines, err := svc.PickList(ctx, []string{uuidToString(first), uuidToString(second), uuidToString(first)})
+	require.NoError(t, err)
+
+	assert.Equal(t, []domain.PickListLine{
+		{SKU: "COL-12-DR", ProductName: "Colombia Huila", VariantDescription: "12oz - Drip", Quantity: 1, OrderCount: 1},
+		{SKU: "ETH-12-WB", ProductName: "Ethiopia Guji", VariantDescription: "12oz - Whole Bean", Quantity: 3, OrderCount: 2},
+	}, lines)
+}
+
+func TestFulfillmentBatchService_PickList_OrderNotInQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewFulfillmentBatchService(mockRepo, nil, nil)
+
+	shipped := newUUID()
+	mockRepo.EXPECT().ListFulfillmentQueueByIDs(gomock.Any(), gomock.Any()).
+		Return([]repository.ListFulfillmentQueueByIDsRow{}, nil)
+
+	_, err := svc.PickList(ctx, []string{uuidToString(shipped)})
+	assert.ErrorIs(t, err, ErrOrdersNotInQueue)
+}
+
+func TestFulfillmentBatchService_CreateBatch_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := contextWithTenant(newUUID())
+	svc := NewFulfillmentBatchService(repository.NewMockQuerier(ctrl), nil, nil)
+
+	tooMany := make([]string, maxBatchOrders+1)
+	for i := range tooMany {
+		tooMany[i] = uuidToString(newUUID())
+	}
+
+	tests := []struct {
+		name     string
+		orderIDs []string
+		wantErr  error
+	}{
+		{"no orders", nil, ErrNoOrdersSelected},
+		{"too many orders", tooMany, ErrTooManyBatchOrders},
+		{"invalid order ID", []string{"not-a-uuid"}, ErrOrdersNotInQueue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateBatch(ctx, tt.orderIDs)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestFulfillmentBatchService_CreateBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewFulfillmentBatchService(mockRepo, nil, nil)
+
+	older, newer := newUUID(), newUUID()
+	batchID := newUUID()
+
+	// Selection order does not matter; the queue query returns oldest first
+	mockRepo.EXPECT().ListFulfillmentQueueByIDs(gomock.Any(), gomock.Any()).
+		Return([]repository.ListFulfillmentQueueByIDsRow{{ID: older}, {ID: newer}}, nil)
+	mockRepo.EXPECT().CreateFulfillmentBatch(gomock.Any(), repository.CreateFulfillmentBatchParams{
+		TenantID:   tenantID,
+		OrderCount: 2,
+	}).Return(repository.FulfillmentBatch{ID: batchID, TenantID: tenantID, Status: "pending", OrderCount: 2}, nil)
+	gomock.InOrder(
+		mockRepo.EXPECT().CreateFulfillmentBatchOrder(gomock.Any(), repository.CreateFulfillmentBatchOrderParams{
+			TenantID: tenantID, BatchID: batchID, OrderID: older, Position: 1,
+		}).Return(repository.FulfillmentBatchOrder{}, nil),
+		mockRepo.EXPECT().CreateFulfillmentBatchOrder(gomock.Any(), repository.CreateFulfillmentBatchOrderParams{
+			TenantID: tenantID, BatchID: batchID, OrderID: newer, Position: 2,
+		}).Return(repository.FulfillmentBatchOrder{}, nil),
+	)
+
+	var enqueued repository.EnqueueJobParams
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.EnqueueJobParams) (repository.Job, error) {
+			enqueued = arg
+			return repository.Job{}, nil
+		})
+
+	batch, err := svc.CreateBatch(ctx, []string{uuidToString(newer), uuidToString(older)})
+	require.NoError(t, err)
+	assert.Equal(t, batchID, batch.ID)
+
+	assert.Equal(t, jobs.JobTypeProcessFulfillmentBatch, enqueued.JobType)
+	assert.Equal(t, tenantID, enqueued.TenantID)
+	var payload jobs.ProcessFulfillmentBatchPayload
+	require.NoError(t, json.Unmarshal(enqueued.Payload, &payload))
+	assert.Equal(t, uuidToString(batchID), payload.BatchID.String())
+}
+
+func TestFulfillmentBatchService_ProcessBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	labelServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_ = png.Encode(w, image.NewGray(image.Rect(0, 0, 40, 60)))
+	}))
+	defer labelServer.Close()
+
+	fileStorage, err := storage.NewLocalStorage(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+
+	shippable, unshippable := newUUID(), newUUID()
+	labels := &stubLabelService{rates: map[string][]shipping.Rate{
+		// Rates are returned cheapest first
+		uuidToString(shippable): {{RateID: "rate_ground", CostCents: 650}, {RateID: "rate_priority", CostCents: 980}},
+	}}
+	svc := NewFulfillmentBatchService(mockRepo, labels, fileStorage)
+
+	batch := repository.FulfillmentBatch{ID: newUUID(), TenantID: tenantID, Status: "pending", OrderCount: 2}
+	shipmentID := newUUID()
+	pending := []repository.ListFulfillmentBatchOrdersRow{
+		{ID: newUUID(), OrderID: shippable, Position: 1, Status: "pending", OrderNumber: "ORD-1001"},
+		{ID: newUUID(), OrderID: unshippable, Position: 2, Status: "pending", OrderNumber: "ORD-1002"},
+	}
+	processed := []repository.ListFulfillmentBatchOrdersRow{
+		{ID: pending[0].ID, OrderID: shippable, ShipmentID: shipmentID, Position: 1, Status: "purchased", OrderNumber: "ORD-1001",
+			Carrier:        pgtype.Text{String: "usps", Valid: true},
+			TrackingNumber: pgtype.Text{String: "9400100000000000000000", Valid: true},
+			LabelImageUrl:  pgtype.Text{String: labelServer.URL + "/label.png", Valid: true}},
+		{ID: pending[1].ID, OrderID: unshippable, Position: 2, Status: "failed", OrderNumber: "ORD-1002",
+			ErrorMessage: pgtype.Text{String: ErrNoLabelRates.Message, Valid: true}},
+	}
+
+	mockRepo.EXPECT().GetFulfillmentBatch(gomock.Any(), repository.GetFulfillmentBatchParams{
+		TenantID: tenantID,
+		ID:       batch.ID,
+	}).Return(batch, nil)
+	mockRepo.EXPECT().StartFulfillmentBatch(gomock.Any(), gomock.Any()).Return(nil)
+	gomock.InOrder(
+		mockRepo.EXPECT().ListFulfillmentBatchOrders(gomock.Any(), gomock.Any()).Return(pending, nil),
+		mockRepo.EXPECT().ListFulfillmentBatchOrders(gomock.Any(), gomock.Any()).Return(processed, nil),
+	)
+	mockRepo.EXPECT().GetShipmentsByOrderID(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	mockRepo.EXPECT().MarkFulfillmentBatchOrderPurchased(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().MarkFulfillmentBatchOrderFailed(gomock.Any(), repository.MarkFulfillmentBatchOrderFailedParams{
+		TenantID:     tenantID,
+		ID:           pending[1].ID,
+		ErrorMessage: pgtype.Text{String: ErrNoLabelRates.Message, Valid: true},
+	}).Return(nil)
+	mockRepo.EXPECT().GetOrderWithDetails(gomock.Any(), gomock.Any()).Return(repository.GetOrderWithDetailsRow{
+		ID:            shippable,
+		OrderNumber:   "ORD-1001",
+		ShippingName:  pgtype.Text{String: "Ada Lovelace", Valid: true},
+		CustomerNotes: pgtype.Text{String: "Leave at the side door", Valid: true},
+	}, nil)
+	orderItemID := newUUID()
+	mockRepo.EXPECT().GetShipmentItems(gomock.Any(), shipmentID).Return([]repository.GetShipmentItemsRow{
+		{OrderItemID: orderItemID, Quantity: 2, ProductName: "Ethiopia Guji", Sku: "ETH-12-WB"},
+	}, nil)
+	mockRepo.EXPECT().ListOrderItemRoastDates(gomock.Any(), repository.ListOrderItemRoastDatesParams{
+		TenantID: tenantID,
+		OrderID:  shippable,
+	}).Return([]repository.ListOrderItemRoastDatesRow{
+		{OrderItemID: orderItemID, Status: "roasted", RoastedOn: pgtype.Date{Time: time.Now().AddDate(0, 0, -3), Valid: true}},
+	}, nil)
+
+	var completed repository.CompleteFulfillmentBatchParams
+	mockRepo.EXPECT().CompleteFulfillmentBatch(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.CompleteFulfillmentBatchParams) (repository.FulfillmentBatch, error) {
+			completed = arg
+			return batch, nil
+		})
+
+	err = svc.ProcessBatch(ctx, uuidToString(batch.ID))
+	require.NoError(t, err)
+
+	// Only the order with rates was bought, at the cheapest rate
+	require.Len(t, labels.bought, 1)
+	assert.Equal(t, uuidToString(shippable), labels.bought[0].OrderID)
+	assert.Equal(t, "rate_ground", labels.bought[0].RateID)
+
+	require.True(t, completed.DocumentStorageKey.Valid)
+	file, err := fileStorage.Get(ctx, completed.DocumentStorageKey.String)
+	require.NoError(t, err)
+	defer file.Close()
+	doc, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(doc, []byte("%PDF-")))
+	// Pick list, label and packing slip
+	assert.Contains(t, string(doc), "/Count 3")
+	assert.Contains(t, string(doc), "/Subtype /Image")
+}
+
+// TestFulfillmentBatchService_ProcessBatch_ReusesBoughtLabel covers a retry
+// after a run bought a label but failed before recording it on the batch.
+func TestFulfillmentBatchService_ProcessBatch_ReusesBoughtLabel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	orderID := newUUID()
+	labels := &stubLabelService{rates: map[string][]shipping.Rate{
+		uuidToString(orderID): {{RateID: "rate_ground", CostCents: 650}},
+	}}
+	svc := NewFulfillmentBatchService(mockRepo, labels, nil)
+
+	createdAt := time.Now().Add(-time.Hour)
+	batch := repository.FulfillmentBatch{ID: newUUID(), TenantID: tenantID, Status: "processing", OrderCount: 1,
+		CreatedAt: pgtype.Timestamptz{Time: createdAt, Valid: true}}
+	pending := []repository.ListFulfillmentBatchOrdersRow{
+		{ID: newUUID(), OrderID: orderID, Position: 1, Status: "pending", OrderNumber: "ORD-1001"},
+	}
+	bought := repository.Shipment{ID: newUUID(), OrderID: orderID, Status: "label_created",
+		ProviderLabelID: pgtype.Text{String: "se-123", Valid: true},
+		CreatedAt:       pgtype.Timestamptz{Time: createdAt.Add(time.Minute), Valid: true}}
+	earlier := repository.Shipment{ID: newUUID(), OrderID: orderID, Status: "shipped",
+		ProviderLabelID: pgtype.Text{String: "se-100", Valid: true},
+		CreatedAt:       pgtype.Timestamptz{Time: createdAt.Add(-24 * time.Hour), Valid: true}}
+
+	mockRepo.EXPECT().GetFulfillmentBatch(gomock.Any(), gomock.Any()).Return(batch, nil)
+	mockRepo.EXPECT().StartFulfillmentBatch(gomock.Any(), gomock.Any()).Return(nil)
+	gomock.InOrder(
+		mockRepo.EXPECT().ListFulfillmentBatchOrders(gomock.Any(), gomock.Any()).Return(pending, nil),
+		mockRepo.EXPECT().ListFulfillmentBatchOrders(gomock.Any(), gomock.Any()).Return(nil, nil),
+	)
+	mockRepo.EXPECT().GetShipmentsByOrderID(gomock.Any(), orderID).Return([]repository.Shipment{bought, earlier}, nil)
+	mockRepo.EXPECT().MarkFulfillmentBatchOrderPurchased(gomock.Any(), repository.MarkFulfillmentBatchOrderPurchasedParams{
+		TenantID:   tenantID,
+		ID:         pending[0].ID,
+		ShipmentID: bought.ID,
+	}).Return(nil)
+	mockRepo.EXPECT().CompleteFulfillmentBatch(gomock.Any(), gomock.Any()).Return(batch, nil)
+
+	err := svc.ProcessBatch(ctx, uuidToString(batch.ID))
+	require.NoError(t, err)
+	assert.Empty(t, labels.bought)
+}
+
+func TestFulfillmentBatchService_ProcessBatch_AlreadyCompleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewFulfillmentBatchService(mockRepo, &stubLabelService{}, nil)
+
+	batch := repository.FulfillmentBatch{ID: newUUID(), TenantID: tenantID, Status: "completed"}
+	mockRepo.EXPECT().GetFulfillmentBatch(gomock.Any(), gomock.Any()).Return(batch, nil)
+
+	err := svc.ProcessBatch(ctx, uuidToString(batch.ID))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"fmt"
+	"image"
+	"strings"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/pdf"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Batch documents use 4x6 inch pages so labels, slips and the pick list all
+// print on the same thermal label stock.
+const (
+	batchPageWidth  = 4 * pdf.PointsPerInch
+	batchPageHeight = 6 * pdf.PointsPerInch
+	batchMargin     = 18.0
+)
+
+// batchPackage is one purchased order's paperwork in a batch document.
+type batchPackage struct {
+	order      repository.ListFulfillmentBatchOrdersRow
+	details    repository.GetOrderWithDetailsRow
+	items      []repository.GetShipmentItemsRow
+	roastDates map[pgtype.UUID]roastDate // by order item ID
+	labelImage image.Image               // nil if the label image could not be fetched
+}
+
+// buildBatchDocument lays out a batch's pick list followed by a label page and
+// a packing slip for each package, in batch order.
+func buildBatchDocument(packages []batchPackage) *pdf.Document {
+	doc := pdf.New()
+
+	var picks pickListBuilder
+	for _, p := range packages {
+		for _, item := range p.items {
+			picks.add(p.order.OrderID, item.Sku, item.ProductName, item.VariantDescription.String, item.Quantity)
+		}
+	}
+	writePickList(doc, picks.lines(), len(packages))
+
+	for _, p := range packages {
+		writeLabelPage(doc, p)
+		writePackingSlip(doc, p)
+	}
+
+	return doc
+}
+
+// batchPageWriter places lines of text top to bottom, starting a new page
+// when the current one is full.
+type batchPageWriter struct {
+	doc     *pdf.Document
+	page    *pdf.Page
+	y       float64
+	heading string // repeated on continuation pages
+}
+
+func newBatchPageWriter(doc *pdf.Document, heading string) *batchPageWriter {
+	w := &batchPageWriter{doc: doc, heading: heading + " (continued)"}
+	w.newPage()
+	return w
+}
+
+func (w *batchPageWriter) newPage() {
+	w.page = w.doc.AddPage(batchPageWidth, batchPageHeight)
+	w.y = batchMargin
+}
+
+// ensure starts a new page unless height points remain on the current one.
+func (w *batchPageWriter) ensure(height float64) {
+	if w.y+height <= batchPageHeight-batchMargin {
+		return
+	}
+	w.newPage()
+	w.text(pdf.HelveticaBold, 10, w.heading)
+	w.gap(4)
+}
+
+// text writes a line at the left margin, truncated to the page width.
+func (w *batchPageWriter) text(font pdf.Font, size float64, s string) {
+	w.textAt(batchMargin, font, size, s)
+}
+
+// textAt writes a line starting at x, truncated to the page width.
+func (w *batchPageWriter) textAt(x float64, font pdf.Font, size float64, s string) {
+	w.ensure(size * 1.3)
+	w.y += size
+	w.page.Text(x, w.y, font, size, pdf.Truncate(font, size, batchPageWidth-batchMargin-x, s))
+	w.y += size * 0.3
+}
+
+// rule draws a horizontal line across the page.
+func (w *batchPageWriter) rule() {
+	w.ensure(6)
+	w.y += 3
+	w.page.Line(batchMargin, w.y, batchPageWidth-batchMargin, w.y, 0.5)
+	w.y += 3
+}
+
+func (w *batchPageWriter) gap(height float64) {
+	w.y += height
+}
+
+// writePickList writes the batch's items totalled by SKU.
+func writePickList(doc *pdf.Document, lines []domain.PickListLine, orderCount int) {
+	w := newBatchPageWriter(doc, "Pick List")
+
+	w.text(pdf.HelveticaBold, 14, "Pick List")
+	w.text(pdf.Helvetica, 9, fmt.Sprintf("%d orders, %d SKUs", orderCount, len(lines)))
+	w.rule()
+
+	const qtyWidth = 36.0
+	for _, line := range lines {
+		w.ensure(24)
+		top := w.y
+		w.textAt(batchMargin, pdf.HelveticaBold, 12, fmt.Sprintf("%d", line.Quantity))
+		w.y = top
+		w.textAt(batchMargin+qtyWidth, pdf.HelveticaBold, 9, line.ProductName)
+		detail := line.SKU
+		if line.VariantDescription != "" {
+			detail = line.VariantDescription + "  " + line.SKU
+		}
+		w.textAt(batchMargin+qtyWidth, pdf.Helvetica, 8, detail)
+		w.gap(3)
+	}
+}
+
+// writeLabelPage writes the shipping label, or a note to print it separately
+// if the image was not available.
+func writeLabelPage(doc *pdf.Document, p batchPackage) {
+	if p.labelImage != nil {
+		page := doc.AddPage(batchPageWidth, batchPageHeight)
+		if err := page.Image(p.labelImage, 0, 0, batchPageWidth, batchPageHeight); err == nil {
+			return
+		}
+	}
+
+	w := newBatchPageWriter(doc, "Shipping Label")
+	w.text(pdf.HelveticaBold, 14, "Label unavailable")
+	w.gap(6)
+	w.text(pdf.Helvetica, 10, "Order "+p.order.OrderNumber)
+	w.text(pdf.Helvetica, 10, strings.ToUpper(p.order.Carrier.String)+" "+p.order.TrackingNumber.String)
+	w.gap(6)
+	for _, line := range pdf.Wrap(pdf.Helvetica, 9, batchPageWidth-2*batchMargin,
+		"The label was purchased but its image could not be included. Print it from the order page.") {
+		w.text(pdf.Helvetica, 9, line)
+	}
+}
+
+// writePackingSlip writes the slip that goes in the box.
+func writePackingSlip(doc *pdf.Document, p batchPackage) {
+	d := p.details
+	w := newBatchPageWriter(doc, "Packing Slip "+d.OrderNumber)
+
+	w.text(pdf.HelveticaBold, 14, "Packing Slip")
+	w.text(pdf.Helvetica, 9, fmt.Sprintf("Order %s  |  %s", d.OrderNumber, d.CreatedAt.Time.Format("Jan 2, 2006")))
+	w.rule()
+
+	w.text(pdf.HelveticaBold, 9, "Ship to")
+	for _, line := range shipToLines(d) {
+		w.text(pdf.Helvetica, 9, line)
+	}
+	w.rule()
+
+	const qtyWidth = 28.0
+	top := w.y
+	w.textAt(batchMargin, pdf.HelveticaBold, 8, "QTY")
+	w.y = top
+	w.textAt(batchMargin+qtyWidth, pdf.HelveticaBold, 8, "ITEM")
+	for _, item := range p.items {
+		roast, ok := p.roastDates[item.OrderItemID]
+		roasted := ok && roast.RoastedOn.Valid
+		if roasted {
+			w.ensure(32)
+		} else {
+			w.ensure(22)
+		}
+		top := w.y
+		w.textAt(batchMargin, pdf.Helvetica, 10, fmt.Sprintf("%d", item.Quantity))
+		w.y = top
+		w.textAt(batchMargin+qtyWidth, pdf.Helvetica, 10, item.ProductName)
+		detail := item.Sku
+		if item.VariantDescription.Valid && item.VariantDescription.String != "" {
+			detail = item.VariantDescription.String + "  " + item.Sku
+		}
+		w.textAt(batchMargin+qtyWidth, pdf.Helvetica, 8, detail)
+		if roasted {
+			w.textAt(batchMargin+qtyWidth, pdf.Helvetica, 8, "Roasted on "+roast.RoastedOn.Time.Format("Jan 2, 2006"))
+		}
+		w.gap(2)
+	}
+	w.rule()
+
+	if p.order.TrackingNumber.Valid {
+		w.text(pdf.Helvetica, 8, fmt.Sprintf("%s %s  %s",
+			strings.ToUpper(p.order.Carrier.String), p.order.ServiceName.String, p.order.TrackingNumber.String))
+	}
+
+	if d.CustomerNotes.Valid && strings.TrimSpace(d.CustomerNotes.String) != "" {
+		w.gap(6)
+		w.text(pdf.HelveticaBold, 9, "Notes")
+		for _, line := range pdf.Wrap(pdf.Helvetica, 9, batchPageWidth-2*batchMargin, d.CustomerNotes.String) {
+			w.text(pdf.Helvetica, 9, line)
+		}
+	}
+}
+
+// shipToLines formats the order's shipping address.
+func shipToLines(d repository.GetOrderWithDetailsRow) []string {
+	var lines []string
+	add := func(s string) {
+		if s = strings.TrimSpace(s); s != "" {
+			lines = append(lines, s)
+		}
+	}
+
+	add(d.ShippingName.String)
+	add(d.ShippingCompany.String)
+	add(d.ShippingAddressLine1.String)
+	add(d.ShippingAddressLine2.String)
+	add(strings.TrimSpace(fmt.Sprintf("%s, %s %s", d.ShippingCity.String, d.ShippingState.String, d.ShippingPostalCode.String)))
+	if d.ShippingCountry.String != "" && d.ShippingCountry.String != "US" {
+		add(d.ShippingCountry.String)
+	}
+
+	return lines
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type inventoryService struct {
+	repo    repository.Querier
+	baseURL string
+}
+
+// NewInventoryService creates a new InventoryService instance.
+// baseURL is used to link the low stock digest to the inventory page.
+func NewInventoryService(repo repository.Querier, baseURL string) domain.InventoryService {
+	return &inventoryService{repo: repo, baseURL: baseURL}
+}
+
+// Adjust changes a SKU's stock for a manual reason and records it in the
+// ledger.
+func (s *inventoryService) Adjust(ctx context.Context, params domain.InventoryAdjustmentParams) (*repository.InventoryAdjustment, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.IsManualAdjustmentReason(params.Reason) {
+		return nil, ErrInvalidAdjustmentReason
+	}
+
+	sku, err := s.getSKU(ctx, tenantID, params.SKUID)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := repository.AdjustSKUInventoryParams{
+		TenantID: tenantID,
+		ID:       sku.ID,
+		Reason:   params.Reason,
+		Note:     makePgText(params.Note),
+	}
+
+	switch params.Reason {
+	case domain.AdjustmentReasonCount:
+		if params.Quantity < 0 {
+			return nil, ErrInvalidStockCount
+		}
+		arg.Counted = pgtype.Int4{Int32: params.Quantity, Valid: true}
+	case domain.AdjustmentReasonSpoilage:
+		if params.Quantity <= 0 {
+			return nil, ErrInvalidAdjustmentQuantity
+		}
+		if params.Quantity > sku.InventoryQuantity {
+			return nil, ErrAdjustmentBelowZero
+		}
+		arg.QuantityChange = -params.Quantity
+	case domain.AdjustmentReasonRoastReceipt:
+		if params.Quantity <= 0 {
+			return nil, ErrInvalidAdjustmentQuantity
+		}
+		arg.QuantityChange = params.Quantity
+		if params.RoastBatchID != "" {
+			batch, err := s.getRoastedBatch(ctx, tenantID, params.RoastBatchID)
+			if err != nil {
+				return nil, err
+			}
+			if batch.ProductID != sku.ProductID {
+				return nil, ErrRoastBatchNotFound
+			}
+			arg.RoastBatchID = batch.ID
+		}
+	}
+
+	if params.OperatorID != "" {
+		if err := arg.OperatorID.Scan(params.OperatorID); err != nil {
+			return nil, fmt.Errorf("invalid operator ID: %w", err)
+		}
+	}
+
+	adjustment, err := s.repo.AdjustSKUInventory(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// The SKU exists, so stock sold since it was loaded
+			return nil, ErrAdjustmentBelowZero
+		}
+		return nil, fmt.Errorf("failed to adjust inventory: %w", err)
+	}
+
+	return &adjustment, nil
+}
+
+// GetSKU returns a SKU's stock and low stock threshold.
+func (s *inventoryService) GetSKU(ctx context.Context, skuID string) (*repository.GetInventorySKURow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sku, err := s.getSKU(ctx, tenantID, skuID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sku, nil
+}
+
+// ListAdjustments returns a SKU's most recent ledger entries, newest first.
+func (s *inventoryService) ListAdjustments(ctx context.Context, skuID string, limit int32) ([]repository.ListSKUInventoryAdjustmentsRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var skuUUID pgtype.UUID
+	if err := skuUUID.Scan(skuID); err != nil {
+		return nil, ErrSKUNotFound
+	}
+
+	adjustments, err := s.repo.ListSKUInventoryAdjustments(ctx, repository.ListSKUInventoryAdjustmentsParams{
+		TenantID:     tenantID,
+		ProductSkuID: skuUUID,
+		Limit:        limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventory adjustments: %w", err)
+	}
+
+	return adjustments, nil
+}
+
+// ListInventory returns the stock of every active SKU.
+func (s *inventoryService) ListInventory(ctx context.Context) ([]repository.ListInventorySKUsRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	skus, err := s.repo.ListInventorySKUs(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventory: %w", err)
+	}
+
+	return skus, nil
+}
+
+// ListLowStock returns active SKUs at or below their low stock threshold.
+func (s *inventoryService) ListLowStock(ctx context.Context) ([]repository.ListLowStockSKUsRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	skus, err := s.repo.ListLowStockSKUs(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list low stock SKUs: %w", err)
+	}
+
+	return skus, nil
+}
+
+// SetLowStockThreshold sets the stock level at which a SKU is reported as low.
+func (s *inventoryService) SetLowStockThreshold(ctx context.Context, skuID string, threshold *int32) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	var skuUUID pgtype.UUID
+	if err := skuUUID.Scan(skuID); err != nil {
+		return ErrSKUNotFound
+	}
+
+	var lowStock pgtype.Int4
+	if threshold != nil {
+		if *threshold < 0 {
+			return ErrInvalidLowStockThreshold
+		}
+		lowStock = pgtype.Int4{Int32: *threshold, Valid: true}
+	}
+
+	updated, err := s.repo.UpdateSKULowStockThreshold(ctx, repository.UpdateSKULowStockThresholdParams{
+		TenantID:          tenantID,
+		ID:                skuUUID,
+		LowStockThreshold: lowStock,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update low stock threshold: %w", err)
+	}
+	if updated == 0 {
+		return ErrSKUNotFound
+	}
+
+	return nil
+}
+
+// SendLowStockDigest emails each active operator the SKUs running low.
+func (s *inventoryService) SendLowStockDigest(ctx context.Context) (int, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	skus, err := s.repo.ListLowStockSKUs(ctx, tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list low stock SKUs: %w", err)
+	}
+	if len(skus) == 0 {
+		return 0, nil
+	}
+
+	operators, err := s.repo.ListTenantOperators(ctx, tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list operators: %w", err)
+	}
+
+	items := make([]jobs.LowStockItemData, len(skus))
+	for i, sku := range skus {
+		items[i] = jobs.LowStockItemData{
+			ProductName:       sku.ProductName,
+			SKU:               sku.Sku,
+			Variant:           skuVariant(sku.WeightValue, sku.WeightUnit, sku.Grind),
+			InventoryQuantity: sku.InventoryQuantity,
+		}
+		if sku.LowStockThreshold.Valid {
+			threshold := sku.LowStockThreshold.Int32
+			items[i].LowStockThreshold = &threshold
+		}
+	}
+
+	for _, operator := range operators {
+		if operator.Status != "active" {
+			continue
+		}
+		payload := jobs.LowStockDigestPayload{
+			Email:        operator.Email,
+			Name:         operator.Name.String,
+			Items:        items,
+			InventoryURL: s.baseURL + "/admin/inventory?low=1",
+		}
+		if err := jobs.EnqueueLowStockDigestEmail(ctx, s.repo, uuid.UUID(tenantID.Bytes), payload); err != nil {
+			return 0, fmt.Errorf("failed to enqueue low stock digest: %w", err)
+		}
+	}
+
+	return len(skus), nil
+}
+
+// getSKU loads a tenant's SKU by ID.
+func (s *inventoryService) getSKU(ctx context.Context, tenantID pgtype.UUID, skuID string) (repository.GetInventorySKURow, error) {
+	var skuUUID pgtype.UUID
+	if err := skuUUID.Scan(skuID); err != nil {
+		return repository.GetInventorySKURow{}, ErrSKUNotFound
+	}
+
+	sku, err := s.repo.GetInventorySKU(ctx, repository.GetInventorySKUParams{
+		TenantID: tenantID,
+		ID:       skuUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sku, ErrSKUNotFound
+		}
+		return sku, fmt.Errorf("failed to get SKU: %w", err)
+	}
+
+	return sku, nil
+}
+
+// getRoastedBatch loads a batch that stock can be received from.
+func (s *inventoryService) getRoastedBatch(ctx context.Context, tenantID pgtype.UUID, batchID string) (repository.GetRoastBatchRow, error) {
+	var batchUUID pgtype.UUID
+	if err := batchUUID.Scan(batchID); err != nil {
+		return repository.GetRoastBatchRow{}, ErrRoastBatchNotFound
+	}
+
+	batch, err := s.repo.GetRoastBatch(ctx, repository.GetRoastBatchParams{
+		TenantID: tenantID,
+		ID:       batchUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return batch, ErrRoastBatchNotFound
+		}
+		return batch, fmt.Errorf("failed to get roast batch: %w", err)
+	}
+	if batch.Status != "roasted" {
+		return batch, ErrRoastBatchNotFound
+	}
+
+	return batch, nil
+}
+
+// skuVariant describes a SKU's bag size and grind, e.g. "12 oz - espresso".
+func skuVariant(weight pgtype.Numeric, unit, grind string) string {
+	variant := ""
+	if weight.Valid {
+		variant = fmt.Sprintf("%s %s", weight.Int.String(), unit)
+	}
+	if grind != "" && grind != "whole_bean" {
+		if variant != "" {
+			variant += " - "
+		}
+		variant += grind
+	}
+	return variant
+}
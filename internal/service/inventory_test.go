@@ -0,0 +1,289 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInventoryService_Adjust(t *testing.T) {
+	tenantID := newUUID()
+	skuID := newUUID()
+	operatorID := newUUID()
+	sku := repository.GetInventorySKURow{ID: skuID, ProductID: newUUID(), InventoryQuantity: 10}
+
+	tests := []struct {
+		name   string
+		params domain.InventoryAdjustmentParams
+		want   repository.AdjustSKUInventoryParams
+	}{
+		{
+			name:   "count sets stock",
+			params: domain.InventoryAdjustmentParams{Reason: domain.AdjustmentReasonCount, Quantity: 7, Note: "Shelf count"},
+			want: repository.AdjustSKUInventoryParams{
+				Counted: pgtype.Int4{Int32: 7, Valid: true},
+				Reason:  domain.AdjustmentReasonCount,
+				Note:    pgtype.Text{String: "Shelf count", Valid: true},
+			},
+		},
+		{
+			name:   "spoilage removes stock",
+			params: domain.InventoryAdjustmentParams{Reason: domain.AdjustmentReasonSpoilage, Quantity: 3},
+			want: repository.AdjustSKUInventoryParams{
+				QuantityChange: -3,
+				Reason:         domain.AdjustmentReasonSpoilage,
+			},
+		},
+		{
+			name:   "roast receipt adds stock",
+			params: domain.InventoryAdjustmentParams{Reason: domain.AdjustmentReasonRoastReceipt, Quantity: 24},
+			want: repository.AdjustSKUInventoryParams{
+				QuantityChange: 24,
+				Reason:         domain.AdjustmentReasonRoastReceipt,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repository.NewMockQuerier(ctrl)
+			svc := NewInventoryService(mockRepo, "https://example.com")
+
+			tt.want.TenantID = tenantID
+			tt.want.ID = skuID
+			tt.want.OperatorID = operatorID
+
+			mockRepo.EXPECT().GetInventorySKU(gomock.Any(), repository.GetInventorySKUParams{
+				TenantID: tenantID,
+				ID:       skuID,
+			}).Return(sku, nil)
+			mockRepo.EXPECT().AdjustSKUInventory(gomock.Any(), tt.want).
+				Return(repository.InventoryAdjustment{Reason: tt.want.Reason}, nil)
+
+			tt.params.SKUID = uuidToString(skuID)
+			tt.params.OperatorID = uuidToString(operatorID)
+			adjustment, err := svc.Adjust(contextWithTenant(tenantID), tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Reason, adjustment.Reason)
+		})
+	}
+}
+
+func TestInventoryService_Adjust_Validation(t *testing.T) {
+	skuID := newUUID()
+	sku := repository.GetInventorySKURow{ID: skuID, ProductID: newUUID(), InventoryQuantity: 5}
+
+	tests := []struct {
+		name    string
+		params  domain.InventoryAdjustmentParams
+		wantErr error
+	}{
+		{
+			name:    "order entries are not manual",
+			params:  domain.InventoryAdjustmentParams{Reason: domain.AdjustmentReasonOrder, Quantity: 1},
+			wantErr: ErrInvalidAdjustmentReason,
+		},
+		{
+			name:    "negative count",
+			params:  domain.InventoryAdjustmentParams{Reason: domain.AdjustmentReasonCount, Quantity: -1},
+			wantErr: ErrInvalidStockCount,
+		},
+		{
+			name:    "zero spoilage",
+			params:  domain.InventoryAdjustmentParams{Reason: domain.AdjustmentReasonSpoilage},
+			wantErr: ErrInvalidAdjustmentQuantity,
+		},
+		{
+			name:    "spoilage beyond stock",
+			params:  domain.InventoryAdjustmentParams{Reason: domain.AdjustmentReasonSpoilage, Quantity: 6},
+			wantErr: ErrAdjustmentBelowZero,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repository.NewMockQuerier(ctrl)
+			svc := NewInventoryService(mockRepo, "")
+
+			mockRepo.EXPECT().GetInventorySKU(gomock.Any(), gomock.Any()).Return(sku, nil).AnyTimes()
+			mockRepo.EXPECT().AdjustSKUInventory(gomock.Any(), gomock.Any()).Times(0)
+
+			tt.params.SKUID = uuidToString(skuID)
+			_, err := svc.Adjust(contextWithTenant(newUUID()), tt.params)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestInventoryService_Adjust_RoastBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewInventoryService(mockRepo, "")
+
+	sku := repository.GetInventorySKURow{ID: newUUID(), ProductID: newUUID()}
+	batch := repository.GetRoastBatchRow{ID: newUUID(), ProductID: sku.ProductID, Status: "roasted"}
+	otherBatch := repository.GetRoastBatchRow{ID: newUUID(), ProductID: newUUID(), Status: "roasted"}
+
+	mockRepo.EXPECT().GetInventorySKU(gomock.Any(), gomock.Any()).Return(sku, nil).Times(2)
+	mockRepo.EXPECT().GetRoastBatch(gomock.Any(), repository.GetRoastBatchParams{TenantID: tenantID, ID: batch.ID}).Return(batch, nil)
+	mockRepo.EXPECT().GetRoastBatch(gomock.Any(), repository.GetRoastBatchParams{TenantID: tenantID, ID: otherBatch.ID}).Return(otherBatch, nil)
+	mockRepo.EXPECT().AdjustSKUInventory(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.AdjustSKUInventoryParams) (repository.InventoryAdjustment, error) {
+			assert.Equal(t, batch.ID, arg.RoastBatchID)
+			assert.Equal(t, int32(12), arg.QuantityChange)
+			return repository.InventoryAdjustment{}, nil
+		})
+
+	ctx := contextWithTenant(tenantID)
+	params := domain.InventoryAdjustmentParams{
+		SKUID:        uuidToString(sku.ID),
+		Reason:       domain.AdjustmentReasonRoastReceipt,
+		Quantity:     12,
+		RoastBatchID: uuidToString(batch.ID),
+	}
+	_, err := svc.Adjust(ctx, params)
+	require.NoError(t, err)
+
+	// A batch of another coffee cannot be received into this SKU
+	params.RoastBatchID = uuidToString(otherBatch.ID)
+	_, err = svc.Adjust(ctx, params)
+	assert.ErrorIs(t, err, ErrRoastBatchNotFound)
+}
+
+func TestInventoryService_Adjust_StockSoldMeanwhile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewInventoryService(mockRepo, "")
+
+	sku := repository.GetInventorySKURow{ID: newUUID(), InventoryQuantity: 2}
+	mockRepo.EXPECT().GetInventorySKU(gomock.Any(), gomock.Any()).Return(sku, nil)
+	mockRepo.EXPECT().AdjustSKUInventory(gomock.Any(), gomock.Any()).Return(repository.InventoryAdjustment{}, pgx.ErrNoRows)
+
+	_, err := svc.Adjust(contextWithTenant(newUUID()), domain.InventoryAdjustmentParams{
+		SKUID:    uuidToString(sku.ID),
+		Reason:   domain.AdjustmentReasonSpoilage,
+		Quantity: 2,
+	})
+	assert.ErrorIs(t, err, ErrAdjustmentBelowZero)
+}
+
+func TestInventoryService_SetLowStockThreshold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	skuID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewInventoryService(mockRepo, "")
+	ctx := contextWithTenant(tenantID)
+
+	threshold := int32(6)
+	mockRepo.EXPECT().UpdateSKULowStockThreshold(gomock.Any(), repository.UpdateSKULowStockThresholdParams{
+		TenantID:          tenantID,
+		ID:                skuID,
+		LowStockThreshold: pgtype.Int4{Int32: 6, Valid: true},
+	}).Return(int64(1), nil)
+	require.NoError(t, svc.SetLowStockThreshold(ctx, uuidToString(skuID), &threshold))
+
+	// No threshold reports the SKU only when it runs out
+	mockRepo.EXPECT().UpdateSKULowStockThreshold(gomock.Any(), repository.UpdateSKULowStockThresholdParams{
+		TenantID: tenantID,
+		ID:       skuID,
+	}).Return(int64(0), nil)
+	assert.ErrorIs(t, svc.SetLowStockThreshold(ctx, uuidToString(skuID), nil), ErrSKUNotFound)
+
+	negative := int32(-1)
+	assert.ErrorIs(t, svc.SetLowStockThreshold(ctx, uuidToString(skuID), &negative), ErrInvalidLowStockThreshold)
+}
+
+func TestInventoryService_SendLowStockDigest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewInventoryService(mockRepo, "https://example.com")
+	ctx := contextWithTenant(tenantID)
+
+	mockRepo.EXPECT().ListLowStockSKUs(gomock.Any(), tenantID).Return([]repository.ListLowStockSKUsRow{
+		{
+			ID:                newUUID(),
+			Sku:               "ETH-12OZ-WB",
+			WeightValue:       pgtype.Numeric{Int: bigIntFromInt64(12), Valid: true},
+			WeightUnit:        "oz",
+			Grind:             "whole_bean",
+			InventoryQuantity: 3,
+			LowStockThreshold: pgtype.Int4{Int32: 5, Valid: true},
+			ProductName:       "Ethiopia Guji",
+		},
+		{
+			ID:          newUUID(),
+			Sku:         "COL-12OZ-ESP",
+			WeightValue: pgtype.Numeric{Int: bigIntFromInt64(12), Valid: true},
+			WeightUnit:  "oz",
+			Grind:       "espresso",
+			ProductName: "Colombia Huila",
+		},
+	}, nil)
+	mockRepo.EXPECT().ListTenantOperators(gomock.Any(), tenantID).Return([]repository.TenantOperator{
+		{Email: "owner@example.com", Name: pgtype.Text{String: "Sam", Valid: true}, Status: "active"},
+		{Email: "former@example.com", Status: "suspended"},
+	}, nil)
+
+	// One digest for the active operator
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.EnqueueJobParams) (repository.Job, error) {
+			assert.Equal(t, jobs.JobTypeLowStockDigest, arg.JobType)
+
+			var payload jobs.LowStockDigestPayload
+			require.NoError(t, json.Unmarshal(arg.Payload, &payload))
+			assert.Equal(t, "owner@example.com", payload.Email)
+			assert.Equal(t, "https://example.com/admin/inventory?low=1", payload.InventoryURL)
+			require.Len(t, payload.Items, 2)
+			assert.Equal(t, "12 oz", payload.Items[0].Variant)
+			require.NotNil(t, payload.Items[0].LowStockThreshold)
+			assert.Equal(t, int32(5), *payload.Items[0].LowStockThreshold)
+			assert.Equal(t, "12 oz - espresso", payload.Items[1].Variant)
+			assert.Nil(t, payload.Items[1].LowStockThreshold)
+			return repository.Job{}, nil
+		})
+
+	listed, err := svc.SendLowStockDigest(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, listed)
+}
+
+func TestInventoryService_SendLowStockDigest_NothingLow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewInventoryService(mockRepo, "")
+
+	mockRepo.EXPECT().ListLowStockSKUs(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().ListTenantOperators(gomock.Any(), gomock.Any()).Times(0)
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).Times(0)
+
+	listed, err := svc.SendLowStockDigest(contextWithTenant(newUUID()))
+	require.NoError(t, err)
+	assert.Zero(t, listed)
+}
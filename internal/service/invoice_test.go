@@ -162,6 +162,10 @@ func (m *mockBillingProvider) CancelSubscription(ctx context.Context, params bil
 	return nil
 }
 
+func (m *mockBillingProvider) UpdateSubscription(ctx context.Context, params billing.UpdateSubscriptionParams) (*billing.Subscription, error) {
+	return nil, nil
+}
+
 func (m *mockBillingProvider) PauseSubscription(ctx context.Context, params billing.PauseSubscriptionParams) (*billing.Subscription, error) {
 	return nil, nil
 }
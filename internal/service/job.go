@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// defaultJobListLimit and maxJobListLimit bound a page of the jobs console.
+	defaultJobListLimit = 50
+	maxJobListLimit     = 200
+)
+
+type jobService struct {
+	repo repository.Querier
+}
+
+// NewJobService creates a new JobService instance.
+func NewJobService(repo repository.Querier) domain.JobService {
+	return &jobService{
+		repo: repo,
+	}
+}
+
+// IsPlatform reports whether the current tenant is the platform's master
+// tenant, whose operators see every tenant's jobs.
+func (s *jobService) IsPlatform(ctx context.Context) (bool, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	isMaster, err := s.repo.IsMasterTenant(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check master tenant: %w", err)
+	}
+
+	return isMaster, nil
+}
+
+// scope returns the tenant_id the console queries are limited to: the
+// current tenant, or NULL (every tenant and system jobs) for the platform.
+func (s *jobService) scope(ctx context.Context) (pgtype.UUID, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+
+	isPlatform, err := s.IsPlatform(ctx)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	if isPlatform {
+		return pgtype.UUID{}, nil
+	}
+
+	return tenantID, nil
+}
+
+// ListJobs returns the jobs matching the filter, newest first.
+func (s *jobService) ListJobs(ctx context.Context, filter domain.JobFilter) (*domain.JobList, error) {
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch filter.Status {
+	case "", domain.JobStatusPending, domain.JobStatusProcessing, domain.JobStatusCompleted,
+		domain.JobStatusFailed, domain.JobStatusCancelled:
+	default:
+		return nil, ErrInvalidJobStatus
+	}
+
+	// Only the platform can look at another tenant
+	if !scope.Valid && filter.TenantID != "" {
+		if err := scope.Scan(strings.TrimSpace(filter.TenantID)); err != nil {
+			return nil, domain.Errorf(domain.EINVALID, "", "Invalid tenant ID")
+		}
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultJobListLimit
+	}
+	if limit > maxJobListLimit {
+		limit = maxJobListLimit
+	}
+	offset := filter.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	jobs, err := s.repo.ListJobs(ctx, repository.ListJobsParams{
+		TenantID: scope,
+		Queue:    filter.Queue,
+		JobType:  filter.JobType,
+		Status:   filter.Status,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	total, err := s.repo.CountJobs(ctx, repository.CountJobsParams{
+		TenantID: scope,
+		Queue:    filter.Queue,
+		JobType:  filter.JobType,
+		Status:   filter.Status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count jobs: %w", err)
+	}
+
+	return &domain.JobList{Jobs: jobs, Total: total}, nil
+}
+
+// GetJob returns a job by ID.
+func (s *jobService) GetJob(ctx context.Context, jobID string) (*repository.Job, error) {
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getJob(ctx, scope, jobID)
+}
+
+func (s *jobService) getJob(ctx context.Context, scope pgtype.UUID, jobID string) (*repository.Job, error) {
+	var id pgtype.UUID
+	if err := id.Scan(jobID); err != nil {
+		return nil, ErrJobNotFound
+	}
+
+	job, err := s.repo.GetJobInScope(ctx, repository.GetJobInScopeParams{
+		ID:       id,
+		TenantID: scope,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// RetryJob queues a failed or cancelled job to run now.
+func (s *jobService) RetryJob(ctx context.Context, jobID string) (*repository.Job, error) {
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.getJob(ctx, scope, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != domain.JobStatusFailed && job.Status != domain.JobStatusCancelled {
+		return nil, ErrJobNotRetryable
+	}
+
+	return s.requeue(ctx, scope, job.ID, time.Now(), ErrJobNotRetryable)
+}
+
+// RescheduleJob moves a pending, failed or cancelled job to run at the
+// given time.
+func (s *jobService) RescheduleJob(ctx context.Context, jobID string, at time.Time) (*repository.Job, error) {
+	if at.IsZero() {
+		return nil, domain.Errorf(domain.EINVALID, "", "Choose when the job should run")
+	}
+
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.getJob(ctx, scope, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.requeue(ctx, scope, job.ID, at, ErrJobNotReschedulable)
+}
+
+// requeue puts a job back in the queue. The status check is repeated in the
+// query, so a job a worker claims in the meantime returns conflictErr.
+func (s *jobService) requeue(ctx context.Context, scope, id pgtype.UUID, at time.Time, conflictErr error) (*repository.Job, error) {
+	job, err := s.repo.RequeueJob(ctx, repository.RequeueJobParams{
+		ScheduledAt: pgtype.Timestamptz{Time: at, Valid: true},
+		ID:          id,
+		TenantID:    scope,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, conflictErr
+		}
+		return nil, fmt.Errorf("failed to requeue job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// CancelJob cancels a pending job.
+func (s *jobService) CancelJob(ctx context.Context, jobID string) (*repository.Job, error) {
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.getJob(ctx, scope, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled, err := s.repo.CancelJobInScope(ctx, repository.CancelJobInScopeParams{
+		ID:       job.ID,
+		TenantID: scope,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrJobNotCancellable
+		}
+		return nil, fmt.Errorf("failed to cancel job: %w", err)
+	}
+
+	return &cancelled, nil
+}
+
+// RequeueFailed retries every failed job of a type.
+func (s *jobService) RequeueFailed(ctx context.Context, jobType string, failedSince *time.Time) (int64, error) {
+	jobType = strings.TrimSpace(jobType)
+	if jobType == "" {
+		return 0, ErrJobTypeRequired
+	}
+
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var since pgtype.Timestamptz
+	if failedSince != nil {
+		since = pgtype.Timestamptz{Time: *failedSince, Valid: true}
+	}
+
+	count, err := s.repo.RequeueFailedJobs(ctx, repository.RequeueFailedJobsParams{
+		JobType:     jobType,
+		TenantID:    scope,
+		FailedSince: since,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue failed jobs: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListFailedJobTypes summarizes the dead-letter view by job type.
+func (s *jobService) ListFailedJobTypes(ctx context.Context) ([]repository.ListFailedJobTypesRow, error) {
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.ListFailedJobTypes(ctx, scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed job types: %w", err)
+	}
+
+	return rows, nil
+}
+
+// QueueStats returns pending, processing and failed counts per queue.
+func (s *jobService) QueueStats(ctx context.Context) ([]repository.ListJobQueueStatsRow, error) {
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.ListJobQueueStats(ctx, scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// ListJobTypes returns the job types present, for filtering.
+func (s *jobService) ListJobTypes(ctx context.Context) ([]string, error) {
+	scope, err := s.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	types, err := s.repo.ListJobTypes(ctx, scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job types: %w", err)
+	}
+
+	return types, nil
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestJobService_ListJobs_Scope(t *testing.T) {
+	otherTenant := newUUID()
+
+	tests := []struct {
+		name       string
+		isPlatform bool
+		filter     domain.JobFilter
+		wantScope  func(tenantID pgtype.UUID) pgtype.UUID
+		wantLimit  int32
+	}{
+		{
+			name:      "tenant sees its own jobs",
+			wantScope: func(tenantID pgtype.UUID) pgtype.UUID { return tenantID },
+			wantLimit: defaultJobListLimit,
+		},
+		{
+			name:      "tenant filter is ignored for tenants",
+			filter:    domain.JobFilter{TenantID: otherTenant.String(), Limit: 10},
+			wantScope: func(tenantID pgtype.UUID) pgtype.UUID { return tenantID },
+			wantLimit: 10,
+		},
+		{
+			name:       "platform sees all jobs",
+			isPlatform: true,
+			filter:     domain.JobFilter{Limit: 1000},
+			wantScope:  func(pgtype.UUID) pgtype.UUID { return pgtype.UUID{} },
+			wantLimit:  maxJobListLimit,
+		},
+		{
+			name:       "platform can filter by tenant",
+			isPlatform: true,
+			filter:     domain.JobFilter{TenantID: otherTenant.String()},
+			wantScope:  func(pgtype.UUID) pgtype.UUID { return otherTenant },
+			wantLimit:  defaultJobListLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tenantID := newUUID()
+			mockRepo := repository.NewMockQuerier(ctrl)
+			svc := NewJobService(mockRepo)
+			scope := tt.wantScope(tenantID)
+
+			mockRepo.EXPECT().IsMasterTenant(gomock.Any(), tenantID).Return(tt.isPlatform, nil)
+			mockRepo.EXPECT().ListJobs(gomock.Any(), repository.ListJobsParams{
+				TenantID: scope,
+				Status:   domain.JobStatusFailed,
+				Limit:    tt.wantLimit,
+			}).Return([]repository.Job{{ID: newUUID()}}, nil)
+			mockRepo.EXPECT().CountJobs(gomock.Any(), repository.CountJobsParams{
+				TenantID: scope,
+				Status:   domain.JobStatusFailed,
+			}).Return(int64(1), nil)
+
+			tt.filter.Status = domain.JobStatusFailed
+			list, err := svc.ListJobs(contextWithTenant(tenantID), tt.filter)
+
+			require.NoError(t, err)
+			assert.Len(t, list.Jobs, 1)
+			assert.Equal(t, int64(1), list.Total)
+		})
+	}
+}
+
+func TestJobService_ListJobs_InvalidStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewJobService(mockRepo)
+
+	mockRepo.EXPECT().IsMasterTenant(gomock.Any(), tenantID).Return(false, nil)
+
+	_, err := svc.ListJobs(contextWithTenant(tenantID), domain.JobFilter{Status: "stuck"})
+	assert.ErrorIs(t, err, ErrInvalidJobStatus)
+}
+
+func TestJobService_RetryJob(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		wantErr error
+	}{
+		{name: "failed", status: domain.JobStatusFailed},
+		{name: "cancelled", status: domain.JobStatusCancelled},
+		{name: "pending", status: domain.JobStatusPending, wantErr: ErrJobNotRetryable},
+		{name: "processing", status: domain.JobStatusProcessing, wantErr: ErrJobNotRetryable},
+		{name: "completed", status: domain.JobStatusCompleted, wantErr: ErrJobNotRetryable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tenantID := newUUID()
+			jobID := newUUID()
+			mockRepo := repository.NewMockQuerier(ctrl)
+			svc := NewJobService(mockRepo)
+
+			mockRepo.EXPECT().IsMasterTenant(gomock.Any(), tenantID).Return(false, nil)
+			mockRepo.EXPECT().GetJobInScope(gomock.Any(), repository.GetJobInScopeParams{
+				ID:       jobID,
+				TenantID: tenantID,
+			}).Return(repository.Job{ID: jobID, TenantID: tenantID, Status: tt.status}, nil)
+
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().RequeueJob(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, arg repository.RequeueJobParams) (repository.Job, error) {
+						assert.Equal(t, jobID, arg.ID)
+						assert.Equal(t, tenantID, arg.TenantID)
+						assert.WithinDuration(t, time.Now(), arg.ScheduledAt.Time, time.Minute)
+						return repository.Job{ID: jobID, Status: domain.JobStatusPending}, nil
+					})
+			}
+
+			job, err := svc.RetryJob(contextWithTenant(tenantID), jobID.String())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, domain.JobStatusPending, job.Status)
+		})
+	}
+}
+
+func TestJobService_GetJob_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewJobService(mockRepo)
+	ctx := contextWithTenant(tenantID)
+
+	mockRepo.EXPECT().IsMasterTenant(gomock.Any(), tenantID).Return(false, nil).Times(2)
+
+	// Another tenant's job is out of scope
+	mockRepo.EXPECT().GetJobInScope(gomock.Any(), gomock.Any()).Return(repository.Job{}, pgx.ErrNoRows)
+	_, err := svc.GetJob(ctx, newUUID().String())
+	assert.ErrorIs(t, err, ErrJobNotFound)
+
+	_, err = svc.GetJob(ctx, "not-a-uuid")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestJobService_CancelJob_NotPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	jobID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewJobService(mockRepo)
+
+	mockRepo.EXPECT().IsMasterTenant(gomock.Any(), tenantID).Return(false, nil)
+	mockRepo.EXPECT().GetJobInScope(gomock.Any(), gomock.Any()).
+		Return(repository.Job{ID: jobID, Status: domain.JobStatusProcessing}, nil)
+	// A worker claimed the job, so the pending-only update matches nothing
+	mockRepo.EXPECT().CancelJobInScope(gomock.Any(), gomock.Any()).Return(repository.Job{}, pgx.ErrNoRows)
+
+	_, err := svc.CancelJob(contextWithTenant(tenantID), jobID.String())
+	assert.ErrorIs(t, err, ErrJobNotCancellable)
+}
+
+func TestJobService_RescheduleJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	jobID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewJobService(mockRepo)
+	ctx := contextWithTenant(tenantID)
+	at := time.Date(2026, 11, 2, 15, 0, 0, 0, time.UTC)
+
+	_, err := svc.RescheduleJob(ctx, jobID.String(), time.Time{})
+	assert.Equal(t, domain.EINVALID, domain.ErrorCode(err))
+
+	mockRepo.EXPECT().IsMasterTenant(gomock.Any(), tenantID).Return(false, nil).Times(2)
+	mockRepo.EXPECT().GetJobInScope(gomock.Any(), gomock.Any()).
+		Return(repository.Job{ID: jobID, Status: domain.JobStatusPending}, nil).Times(2)
+
+	mockRepo.EXPECT().RequeueJob(gomock.Any(), repository.RequeueJobParams{
+		ScheduledAt: pgtype.Timestamptz{Time: at, Valid: true},
+		ID:          jobID,
+		TenantID:    tenantID,
+	}).Return(repository.Job{ID: jobID, Status: domain.JobStatusPending, ScheduledAt: pgtype.Timestamptz{Time: at, Valid: true}}, nil)
+
+	job, err := svc.RescheduleJob(ctx, jobID.String(), at)
+	require.NoError(t, err)
+	assert.Equal(t, at, job.ScheduledAt.Time)
+
+	// The job started running before the update
+	mockRepo.EXPECT().RequeueJob(gomock.Any(), gomock.Any()).Return(repository.Job{}, pgx.ErrNoRows)
+	_, err = svc.RescheduleJob(ctx, jobID.String(), at)
+	assert.ErrorIs(t, err, ErrJobNotReschedulable)
+}
+
+func TestJobService_RequeueFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewJobService(mockRepo)
+	ctx := contextWithTenant(tenantID)
+	since := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+
+	_, err := svc.RequeueFailed(ctx, "  ", nil)
+	assert.ErrorIs(t, err, ErrJobTypeRequired)
+
+	mockRepo.EXPECT().IsMasterTenant(gomock.Any(), tenantID).Return(false, nil)
+	mockRepo.EXPECT().RequeueFailedJobs(gomock.Any(), repository.RequeueFailedJobsParams{
+		JobType:     "email:order_confirmation",
+		TenantID:    tenantID,
+		FailedSince: pgtype.Timestamptz{Time: since, Valid: true},
+	}).Return(int64(12), nil)
+
+	count, err := svc.RequeueFailed(ctx, "email:order_confirmation", &since)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), count)
+}
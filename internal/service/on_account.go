@@ -0,0 +1,402 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dukerupert/hiri/internal/address"
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type onAccountService struct {
+	repo              repository.Querier
+	pool              *pgxpool.Pool
+	checkoutService   CheckoutService
+	invoiceService    InvoiceService
+	orderRulesService domain.OrderRulesService
+}
+
+// NewOnAccountService creates a new OnAccountService instance.
+// Totals and shipping are recalculated through the checkout service, so an
+// on-account order costs the same as paying by card, and the cart is held to
+// the customer's order rules just as it is at card checkout.
+// pool is used to place each order in a single transaction.
+func NewOnAccountService(repo repository.Querier, pool *pgxpool.Pool, checkoutService CheckoutService, invoiceService InvoiceService, orderRulesService domain.OrderRulesService) domain.OnAccountService {
+	return &onAccountService{
+		repo:              repo,
+		pool:              pool,
+		checkoutService:   checkoutService,
+		invoiceService:    invoiceService,
+		orderRulesService: orderRulesService,
+	}
+}
+
+// GetAccountCredit returns a customer's payment terms and credit.
+func (s *onAccountService) GetAccountCredit(ctx context.Context, userID string) (*domain.AccountCredit, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var userUUID pgtype.UUID
+	if err := userUUID.Scan(userID); err != nil {
+		return nil, ErrOnAccountNotEnabled
+	}
+
+	user, err := s.repo.GetUserByIDAndTenant(ctx, repository.GetUserByIDAndTenantParams{
+		ID:       userUUID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOnAccountNotEnabled
+		}
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	return s.accountCredit(ctx, user)
+}
+
+// PlaceOrder creates a wholesale order from the customer's cart and bills it
+// to their account.
+func (s *onAccountService) PlaceOrder(ctx context.Context, params domain.PlaceOnAccountOrderParams) (*OrderDetail, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var userID pgtype.UUID
+	if err := userID.Scan(params.UserID); err != nil {
+		return nil, ErrOnAccountNotEnabled
+	}
+
+	user, err := s.repo.GetUserByIDAndTenant(ctx, repository.GetUserByIDAndTenantParams{
+		ID:       userID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOnAccountNotEnabled
+		}
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	credit, err := s.accountCredit(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	var cartID pgtype.UUID
+	if err := cartID.Scan(params.CartID); err != nil {
+		return nil, ErrCartNotFound
+	}
+
+	cart, err := s.repo.GetCartByID(ctx, repository.GetCartByIDParams{
+		TenantID: tenantID,
+		ID:       cartID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCartNotFound
+		}
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+
+	// Session carts are not always linked to the user, but a cart that
+	// belongs to someone else is never billed to this account
+	if cart.UserID.Valid && cart.UserID != user.ID {
+		return nil, ErrCartNotFound
+	}
+	if cart.Status == "converted" {
+		return nil, ErrCartAlreadyConverted
+	}
+
+	cartItems, err := s.repo.GetCartItems(ctx, cart.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart items: %w", err)
+	}
+	if len(cartItems) == 0 {
+		return nil, ErrEmptyCart
+	}
+
+	rules, err := s.orderRulesService.CheckCart(ctx, params.UserID, params.CartID)
+	if err != nil {
+		return nil, err
+	}
+	if err := rules.Err(); err != nil {
+		return nil, err
+	}
+
+	// Nothing is charged, so the total is recalculated here rather than
+	// trusted from the browser. Rates are re-quoted and matched by service
+	// because some carriers issue new rate IDs on every quote.
+	rate, err := s.requoteShippingRate(ctx, params.CartID, params.ShippingAddress, params.ShippingRate)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.checkoutService.CalculateOrderTotal(ctx, OrderTotalParams{
+		CartID:               params.CartID,
+		ShippingAddress:      params.ShippingAddress,
+		BillingAddress:       params.BillingAddress,
+		SelectedShippingRate: rate,
+		DiscountCode:         params.DiscountCode,
+		CustomerEmail:        user.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Checked again below once the customer is locked; this only saves
+	// placing an order that cannot fit
+	if !credit.Allows(total.TotalCents) {
+		return nil, ErrCreditLimitExceeded
+	}
+
+	orderNumber, err := generateOrderNumber()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate order number: %w", err)
+	}
+
+	var order repository.Order
+	var orderItems []repository.GetOrderItemsRow
+	var shippingAddress, billingAddress repository.Address
+	err = withTx(ctx, s.pool, s.repo, func(repo repository.Querier) error {
+		// Claiming the cart first turns a double submit into
+		// ErrCartAlreadyConverted: the second request waits on the cart row
+		// and finds it converted once the first commits
+		_, err := repo.ClaimCartForOrder(ctx, repository.ClaimCartForOrderParams{
+			TenantID: tenantID,
+			ID:       cart.ID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrCartAlreadyConverted
+			}
+			return fmt.Errorf("failed to claim cart: %w", err)
+		}
+
+		// The customer's orders are placed one at a time, so the credit
+		// check includes any order placed since the one above
+		_, err = repo.LockCustomerForOrder(ctx, repository.LockCustomerForOrderParams{
+			TenantID: tenantID,
+			ID:       user.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to lock customer: %w", err)
+		}
+
+		credit.OutstandingCents, err = repo.GetCustomerOutstandingBalance(ctx, repository.GetCustomerOutstandingBalanceParams{
+			TenantID: tenantID,
+			UserID:   user.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get outstanding balance: %w", err)
+		}
+		if !credit.Allows(total.TotalCents) {
+			return ErrCreditLimitExceeded
+		}
+
+		shippingAddress, err = createOrderAddress(ctx, repo, tenantID, "shipping", params.ShippingAddress)
+		if err != nil {
+			return fmt.Errorf("failed to create shipping address: %w", err)
+		}
+
+		billingAddress, err = createOrderAddress(ctx, repo, tenantID, "billing", params.BillingAddress)
+		if err != nil {
+			return fmt.Errorf("failed to create billing address: %w", err)
+		}
+
+		// Processing, not pending: there is no payment to wait for, and
+		// consolidated invoicing only picks up accepted orders
+		order, err = repo.CreateOrder(ctx, repository.CreateOrderParams{
+			TenantID:          tenantID,
+			CartID:            cart.ID,
+			UserID:            user.ID,
+			OrderNumber:       orderNumber,
+			OrderType:         "wholesale",
+			Status:            "processing",
+			SubtotalCents:     total.SubtotalCents,
+			ShippingCents:     total.ShippingCents,
+			TaxCents:          total.TaxCents,
+			TotalCents:        total.TotalCents,
+			Currency:          "usd",
+			ShippingAddressID: shippingAddress.ID,
+			BillingAddressID:  billingAddress.ID,
+			CustomerNotes:     makePgText(params.CustomerNotes),
+			CustomerPoNumber:  makePgText(params.PONumber),
+			DiscountCents:     total.DiscountCents,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create order: %w", err)
+		}
+
+		// Nothing has been charged, so a code that ran out since the total was
+		// quoted fails the order rather than being honoured over its limit
+		if total.DiscountCodeID.Valid {
+			if err := lockDiscountCodeForUse(ctx, repo, tenantID, total.DiscountCodeID, user.ID); err != nil {
+				return err
+			}
+
+			err := repo.RecordDiscountCodeUsage(ctx, repository.RecordDiscountCodeUsageParams{
+				TenantID:            tenantID,
+				ID:                  total.DiscountCodeID,
+				UserID:              user.ID,
+				OrderID:             order.ID,
+				DiscountAmountCents: total.DiscountCents,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record discount usage: %w", err)
+			}
+		}
+
+		for _, item := range cartItems {
+			_, err := repo.CreateOrderItem(ctx, repository.CreateOrderItemParams{
+				TenantID:           tenantID,
+				OrderID:            order.ID,
+				ProductSkuID:       item.ProductSkuID,
+				ProductName:        item.ProductName,
+				Sku:                item.Sku,
+				VariantDescription: makePgText(buildVariantDescription(item)),
+				Quantity:           item.Quantity,
+				UnitPriceCents:     item.UnitPriceCents,
+				TotalPriceCents:    item.Quantity * item.UnitPriceCents,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create order item: %w", err)
+			}
+		}
+
+		orderItems, err = repo.GetOrderItems(ctx, order.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get order items: %w", err)
+		}
+
+		for _, item := range cartItems {
+			err := repo.DecrementSKUStock(ctx, repository.DecrementSKUStockParams{
+				TenantID:          tenantID,
+				ID:                item.ProductSkuID,
+				InventoryQuantity: item.Quantity,
+				OrderID:           order.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to decrement stock for SKU %s: %w", item.Sku, ErrInsufficientStock)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Unallocated items simply ship without a roast date
+	_, _ = s.repo.AllocateOrderToRoastBatches(ctx, repository.AllocateOrderToRoastBatchesParams{
+		TenantID: tenantID,
+		OrderID:  order.ID,
+	})
+
+	// Customers billed per order get their invoice now; the rest wait for
+	// the consolidated invoice at the end of their billing period. A failed
+	// invoice does not undo the order: it stays uninvoiced, counts against
+	// the credit limit and can be invoiced from the admin.
+	if credit.BillingCycle == domain.BillingCycleOnOrder {
+		_, _ = s.invoiceService.CreateInvoice(ctx, CreateInvoiceParams{
+			UserID:          uuidToString(user.ID),
+			OrderIDs:        []string{uuidToString(order.ID)},
+			PaymentTermsID:  uuidToString(credit.PaymentTerms.ID),
+			SendImmediately: true,
+		})
+	}
+
+	customerName := user.FirstName.String
+	if user.LastName.Valid {
+		customerName = customerName + " " + user.LastName.String
+	}
+	enqueueOrderConfirmationEmail(ctx, s.repo, order, orderItems, shippingAddress, user.Email, customerName)
+
+	return buildOrderDetail(order, orderItems, shippingAddress, billingAddress, repository.Payment{}), nil
+}
+
+// accountCredit checks a customer can order on account and totals what
+// they owe. Customers without a billing cycle are invoiced per order.
+func (s *onAccountService) accountCredit(ctx context.Context, user repository.User) (*domain.AccountCredit, error) {
+	if user.AccountType != "wholesale" || user.Status != "active" || !user.PaymentTermsID.Valid {
+		return nil, ErrOnAccountNotEnabled
+	}
+
+	terms, err := s.repo.GetPaymentTermsByID(ctx, repository.GetPaymentTermsByIDParams{
+		ID:       user.PaymentTermsID,
+		TenantID: user.TenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOnAccountNotEnabled
+		}
+		return nil, fmt.Errorf("failed to get payment terms: %w", err)
+	}
+	if !terms.IsActive {
+		return nil, ErrOnAccountNotEnabled
+	}
+
+	outstanding, err := s.repo.GetCustomerOutstandingBalance(ctx, repository.GetCustomerOutstandingBalanceParams{
+		TenantID: user.TenantID,
+		UserID:   user.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outstanding balance: %w", err)
+	}
+
+	cycle := domain.BillingCycleOnOrder
+	if user.BillingCycle.Valid && user.BillingCycle.String != "" {
+		cycle = domain.BillingCycle(user.BillingCycle.String)
+	}
+
+	return &domain.AccountCredit{
+		PaymentTerms:     terms,
+		BillingCycle:     cycle,
+		HasLimit:         user.CreditLimitCents.Valid,
+		LimitCents:       user.CreditLimitCents.Int32,
+		OutstandingCents: outstanding,
+	}, nil
+}
+
+// requoteShippingRate fetches current rates and returns the one for the
+// same carrier and service as the selected rate.
+func (s *onAccountService) requoteShippingRate(ctx context.Context, cartID string, shippingAddr address.Address, selected shipping.Rate) (shipping.Rate, error) {
+	rates, err := s.checkoutService.GetShippingRates(ctx, cartID, shippingAddr)
+	if err != nil {
+		return shipping.Rate{}, err
+	}
+
+	for _, rate := range rates {
+		if rate.Carrier == selected.Carrier && rate.ServiceCode == selected.ServiceCode {
+			return rate, nil
+		}
+	}
+
+	return shipping.Rate{}, ErrShippingRateUnavailable
+}
+
+// createOrderAddress stores a checkout address for the order.
+func createOrderAddress(ctx context.Context, repo repository.Querier, tenantID pgtype.UUID, addressType string, addr address.Address) (repository.Address, error) {
+	return repo.CreateAddress(ctx, repository.CreateAddressParams{
+		TenantID:     tenantID,
+		AddressType:  addressType,
+		FullName:     makePgText(addr.FullName),
+		Company:      makePgText(addr.Company),
+		AddressLine1: addr.AddressLine1,
+		AddressLine2: makePgText(addr.AddressLine2),
+		City:         addr.City,
+		State:        addr.State,
+		PostalCode:   addr.PostalCode,
+		Country:      addr.Country,
+		Phone:        makePgText(addr.Phone),
+	})
+}
@@ -0,0 +1,388 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/address"
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// stubCheckoutService quotes a single ground rate and a fixed total.
+type stubCheckoutService struct {
+	CheckoutService
+	rates []shipping.Rate
+	total OrderTotal
+}
+
+func (s *stubCheckoutService) GetShippingRates(_ context.Context, _ string, _ address.Address) ([]shipping.Rate, error) {
+	return s.rates, nil
+}
+
+func (s *stubCheckoutService) CalculateOrderTotal(_ context.Context, params OrderTotalParams) (*OrderTotal, error) {
+	total := s.total
+	total.ShippingCents = int32(params.SelectedShippingRate.CostCents)
+	total.TotalCents = total.SubtotalCents + total.ShippingCents
+	return &total, nil
+}
+
+// recordingInvoiceService records the invoices it is asked to create.
+type recordingInvoiceService struct {
+	InvoiceService
+	created []CreateInvoiceParams
+}
+
+func (s *recordingInvoiceService) CreateInvoice(_ context.Context, params CreateInvoiceParams) (*InvoiceDetail, error) {
+	s.created = append(s.created, params)
+	return &InvoiceDetail{}, nil
+}
+
+// stubOrderRulesService returns a fixed order rules check.
+type stubOrderRulesService struct {
+	domain.OrderRulesService
+	check domain.OrderRulesCheck
+}
+
+func (s *stubOrderRulesService) CheckCart(_ context.Context, _, _ string) (*domain.OrderRulesCheck, error) {
+	check := s.check
+	return &check, nil
+}
+
+// onAccountFixture is an active wholesale customer on Net 30 terms with a
+// cart holding one 5lb bag.
+type onAccountFixture struct {
+	tenantID pgtype.UUID
+	user     repository.User
+	terms    repository.PaymentTerm
+	cart     repository.Cart
+	item     repository.GetCartItemsRow
+	rate     shipping.Rate
+	rules    domain.OrderRulesCheck
+}
+
+func newOnAccountFixture(billingCycle string) onAccountFixture {
+	tenantID := newUUID()
+	terms := repository.PaymentTerm{ID: newUUID(), TenantID: tenantID, Name: "Net 30", Days: 30, IsActive: true}
+	user := repository.User{
+		ID:             newUUID(),
+		TenantID:       tenantID,
+		Email:          "orders@cornercafe.test",
+		AccountType:    "wholesale",
+		Status:         "active",
+		PaymentTermsID: terms.ID,
+		BillingCycle:   pgtype.Text{String: billingCycle, Valid: billingCycle != ""},
+	}
+
+	return onAccountFixture{
+		tenantID: tenantID,
+		user:     user,
+		terms:    terms,
+		cart:     repository.Cart{ID: newUUID(), TenantID: tenantID, UserID: user.ID, Status: "active"},
+		item: repository.GetCartItemsRow{
+			ProductSkuID:   newUUID(),
+			ProductName:    "House Espresso",
+			Sku:            "ESP-5LB-WB",
+			Quantity:       1,
+			UnitPriceCents: 6000,
+		},
+		rate: shipping.Rate{RateID: "rate_1", Carrier: "USPS", ServiceCode: "Priority", CostCents: 1500},
+	}
+}
+
+func (f onAccountFixture) newService(t *testing.T) (domain.OnAccountService, *repository.MockQuerier, *recordingInvoiceService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	// Carriers issue a new rate ID on every quote
+	requoted := f.rate
+	requoted.RateID = "rate_2"
+	checkout := &stubCheckoutService{
+		rates: []shipping.Rate{requoted},
+		total: OrderTotal{SubtotalCents: f.item.UnitPriceCents * f.item.Quantity},
+	}
+	invoices := &recordingInvoiceService{}
+	rules := &stubOrderRulesService{check: f.rules}
+
+	return NewOnAccountService(mockRepo, nil, checkout, invoices, rules), mockRepo, invoices
+}
+
+func (f onAccountFixture) params() domain.PlaceOnAccountOrderParams {
+	return domain.PlaceOnAccountOrderParams{
+		CartID:          uuidToString(f.cart.ID),
+		UserID:          uuidToString(f.user.ID),
+		ShippingAddress: address.Address{FullName: "Corner Café", AddressLine1: "1 Main St", City: "Helena", State: "MT", PostalCode: "59601", Country: "US"},
+		BillingAddress:  address.Address{FullName: "Corner Café", AddressLine1: "1 Main St", City: "Helena", State: "MT", PostalCode: "59601", Country: "US"},
+		ShippingRate:    f.rate,
+		PONumber:        "PO-1042",
+	}
+}
+
+// expectCredit sets up the customer, their terms and what they already owe.
+func (f onAccountFixture) expectCredit(mockRepo *repository.MockQuerier, outstandingCents int32) {
+	mockRepo.EXPECT().GetUserByIDAndTenant(gomock.Any(), repository.GetUserByIDAndTenantParams{
+		ID:       f.user.ID,
+		TenantID: f.tenantID,
+	}).Return(f.user, nil)
+	mockRepo.EXPECT().GetPaymentTermsByID(gomock.Any(), repository.GetPaymentTermsByIDParams{
+		ID:       f.terms.ID,
+		TenantID: f.tenantID,
+	}).Return(f.terms, nil)
+	mockRepo.EXPECT().GetCustomerOutstandingBalance(gomock.Any(), repository.GetCustomerOutstandingBalanceParams{
+		TenantID: f.tenantID,
+		UserID:   f.user.ID,
+	}).Return(outstandingCents, nil)
+}
+
+// expectCart sets up the customer's cart.
+func (f onAccountFixture) expectCart(mockRepo *repository.MockQuerier) {
+	mockRepo.EXPECT().GetCartByID(gomock.Any(), repository.GetCartByIDParams{
+		TenantID: f.tenantID,
+		ID:       f.cart.ID,
+	}).Return(f.cart, nil)
+	mockRepo.EXPECT().GetCartItems(gomock.Any(), f.cart.ID).Return([]repository.GetCartItemsRow{f.item}, nil)
+}
+
+// expectPlaced sets up claiming the cart and re-reading what the customer
+// owes under the customer's lock.
+func (f onAccountFixture) expectPlaced(mockRepo *repository.MockQuerier, outstandingCents int32) {
+	mockRepo.EXPECT().ClaimCartForOrder(gomock.Any(), repository.ClaimCartForOrderParams{
+		TenantID: f.tenantID,
+		ID:       f.cart.ID,
+	}).Return(f.cart.ID, nil)
+	mockRepo.EXPECT().LockCustomerForOrder(gomock.Any(), repository.LockCustomerForOrderParams{
+		TenantID: f.tenantID,
+		ID:       f.user.ID,
+	}).Return(f.user.ID, nil)
+	mockRepo.EXPECT().GetCustomerOutstandingBalance(gomock.Any(), repository.GetCustomerOutstandingBalanceParams{
+		TenantID: f.tenantID,
+		UserID:   f.user.ID,
+	}).Return(outstandingCents, nil)
+}
+
+// expectOrderCreated sets up a successful order and returns it.
+func (f onAccountFixture) expectOrderCreated(mockRepo *repository.MockQuerier) repository.Order {
+	order := repository.Order{ID: newUUID(), TenantID: f.tenantID, UserID: f.user.ID, OrderNumber: "ORD-1", Status: "processing"}
+
+	mockRepo.EXPECT().CreateAddress(gomock.Any(), gomock.Any()).Return(repository.Address{ID: newUUID()}, nil).Times(2)
+	mockRepo.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, params repository.CreateOrderParams) (repository.Order, error) {
+			order.OrderType = params.OrderType
+			order.Status = params.Status
+			order.TotalCents = params.TotalCents
+			order.CustomerPoNumber = params.CustomerPoNumber
+			return order, nil
+		})
+	mockRepo.EXPECT().CreateOrderItem(gomock.Any(), gomock.Any()).Return(repository.OrderItem{}, nil)
+	mockRepo.EXPECT().GetOrderItems(gomock.Any(), order.ID).Return(nil, nil)
+	mockRepo.EXPECT().DecrementSKUStock(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().AllocateOrderToRoastBatches(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+	mockRepo.EXPECT().ListOrderItemRoastDates(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).Return(repository.Job{}, nil).AnyTimes()
+
+	return order
+}
+
+func TestOnAccountService_PlaceOrder_InvoicesOnOrderCustomers(t *testing.T) {
+	f := newOnAccountFixture("")
+	svc, mockRepo, invoices := f.newService(t)
+
+	f.expectCredit(mockRepo, 0)
+	f.expectCart(mockRepo)
+	f.expectPlaced(mockRepo, 0)
+	order := f.expectOrderCreated(mockRepo)
+
+	detail, err := svc.PlaceOrder(contextWithTenant(f.tenantID), f.params())
+	require.NoError(t, err)
+
+	assert.Equal(t, "wholesale", detail.Order.OrderType)
+	assert.Equal(t, "processing", detail.Order.Status)
+	assert.Equal(t, int32(7500), detail.Order.TotalCents)
+	assert.Equal(t, "PO-1042", detail.Order.CustomerPoNumber.String)
+
+	require.Len(t, invoices.created, 1)
+	assert.Equal(t, []string{uuidToString(order.ID)}, invoices.created[0].OrderIDs)
+	assert.Equal(t, uuidToString(f.terms.ID), invoices.created[0].PaymentTermsID)
+	assert.True(t, invoices.created[0].SendImmediately)
+}
+
+func TestOnAccountService_PlaceOrder_LeavesConsolidatedOrdersUninvoiced(t *testing.T) {
+	f := newOnAccountFixture("monthly")
+	svc, mockRepo, invoices := f.newService(t)
+
+	f.expectCredit(mockRepo, 0)
+	f.expectCart(mockRepo)
+	f.expectPlaced(mockRepo, 0)
+	f.expectOrderCreated(mockRepo)
+
+	_, err := svc.PlaceOrder(contextWithTenant(f.tenantID), f.params())
+	require.NoError(t, err)
+
+	assert.Empty(t, invoices.created, "monthly customers are invoiced at the end of the period")
+}
+
+func TestOnAccountService_PlaceOrder_CreditLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		outstanding int32
+		wantErr     error
+	}{
+		{name: "within limit", outstanding: 50000},
+		{name: "exactly at limit", outstanding: 92500},
+		{name: "over limit", outstanding: 92501, wantErr: ErrCreditLimitExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newOnAccountFixture("monthly")
+			f.user.CreditLimitCents = pgtype.Int4{Int32: 100000, Valid: true}
+			svc, mockRepo, _ := f.newService(t)
+
+			f.expectCredit(mockRepo, tt.outstanding)
+			f.expectCart(mockRepo)
+			if tt.wantErr == nil {
+				f.expectPlaced(mockRepo, tt.outstanding)
+				f.expectOrderCreated(mockRepo)
+			}
+
+			_, err := svc.PlaceOrder(contextWithTenant(f.tenantID), f.params())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestOnAccountService_PlaceOrder_CreditUsedByConcurrentOrder(t *testing.T) {
+	f := newOnAccountFixture("monthly")
+	f.user.CreditLimitCents = pgtype.Int4{Int32: 100000, Valid: true}
+	svc, mockRepo, invoices := f.newService(t)
+
+	// Another order was placed between the quote and taking the lock
+	f.expectCredit(mockRepo, 50000)
+	f.expectCart(mockRepo)
+	f.expectPlaced(mockRepo, 92501)
+
+	_, err := svc.PlaceOrder(contextWithTenant(f.tenantID), f.params())
+	assert.ErrorIs(t, err, ErrCreditLimitExceeded)
+	assert.Empty(t, invoices.created)
+}
+
+func TestOnAccountService_PlaceOrder_DoubleSubmit(t *testing.T) {
+	f := newOnAccountFixture("")
+	svc, mockRepo, invoices := f.newService(t)
+
+	// The first submit converted the cart after this one read it
+	f.expectCredit(mockRepo, 0)
+	f.expectCart(mockRepo)
+	mockRepo.EXPECT().ClaimCartForOrder(gomock.Any(), gomock.Any()).Return(pgtype.UUID{}, pgx.ErrNoRows)
+
+	_, err := svc.PlaceOrder(contextWithTenant(f.tenantID), f.params())
+	assert.ErrorIs(t, err, ErrCartAlreadyConverted)
+	assert.Empty(t, invoices.created)
+}
+
+func TestOnAccountService_PlaceOrder_NotEnabled(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*repository.User)
+	}{
+		{name: "retail customer", modify: func(u *repository.User) { u.AccountType = "retail" }},
+		{name: "no payment terms", modify: func(u *repository.User) { u.PaymentTermsID = pgtype.UUID{} }},
+		{name: "suspended account", modify: func(u *repository.User) { u.Status = "suspended" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newOnAccountFixture("")
+			tt.modify(&f.user)
+			svc, mockRepo, _ := f.newService(t)
+
+			mockRepo.EXPECT().GetUserByIDAndTenant(gomock.Any(), gomock.Any()).Return(f.user, nil)
+
+			_, err := svc.PlaceOrder(contextWithTenant(f.tenantID), f.params())
+			assert.ErrorIs(t, err, ErrOnAccountNotEnabled)
+		})
+	}
+}
+
+func TestOnAccountService_PlaceOrder_RejectsAnotherCustomersCart(t *testing.T) {
+	f := newOnAccountFixture("")
+	f.cart.UserID = newUUID()
+	svc, mockRepo, _ := f.newService(t)
+
+	f.expectCredit(mockRepo, 0)
+	mockRepo.EXPECT().GetCartByID(gomock.Any(), gomock.Any()).Return(f.cart, nil)
+
+	_, err := svc.PlaceOrder(contextWithTenant(f.tenantID), f.params())
+	assert.ErrorIs(t, err, ErrCartNotFound)
+}
+
+func TestOnAccountService_PlaceOrder_ShippingRateUnavailable(t *testing.T) {
+	f := newOnAccountFixture("")
+	svc, mockRepo, _ := f.newService(t)
+
+	f.expectCredit(mockRepo, 0)
+	f.expectCart(mockRepo)
+
+	params := f.params()
+	params.ShippingRate.ServiceCode = "Express"
+
+	_, err := svc.PlaceOrder(contextWithTenant(f.tenantID), params)
+	assert.ErrorIs(t, err, ErrShippingRateUnavailable)
+}
+
+func TestOnAccountService_PlaceOrder_OrderRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   domain.OrderRulesCheck
+		wantErr error
+	}{
+		{name: "below minimum order", rules: domain.OrderRulesCheck{MinimumSpendCents: 10000, SubtotalCents: 6000}, wantErr: ErrMinimumSpendNotMet},
+		{
+			name: "broken case pack",
+			rules: domain.OrderRulesCheck{QuantityIssues: []domain.QuantityIssue{
+				{Quantity: 1, Rule: domain.QuantityRule{CasePack: 4}, Problem: "Order in cases of 4"},
+			}},
+			wantErr: ErrQuantityRuleNotMet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newOnAccountFixture("")
+			f.rules = tt.rules
+			svc, mockRepo, invoices := f.newService(t)
+
+			f.expectCredit(mockRepo, 0)
+			f.expectCart(mockRepo)
+
+			_, err := svc.PlaceOrder(contextWithTenant(f.tenantID), f.params())
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Empty(t, invoices.created)
+		})
+	}
+}
+
+func TestAccountCredit_Allows(t *testing.T) {
+	unlimited := domain.AccountCredit{OutstandingCents: 1_000_000}
+	assert.True(t, unlimited.Allows(500_000))
+
+	limited := domain.AccountCredit{HasLimit: true, LimitCents: 100_000, OutstandingCents: 60_000}
+	assert.Equal(t, int32(40_000), limited.AvailableCents())
+	assert.True(t, limited.Allows(40_000))
+	assert.False(t, limited.Allows(40_001))
+
+	overdrawn := domain.AccountCredit{HasLimit: true, LimitCents: 100_000, OutstandingCents: 120_000}
+	assert.Equal(t, int32(0), overdrawn.AvailableCents())
+}
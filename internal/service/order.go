@@ -8,15 +8,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/dukerupert/hiri/internal/billing"
 	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
 	"github.com/dukerupert/hiri/internal/repository"
 	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // OrderService is re-exported from domain for backwards compatibility.
@@ -27,15 +32,18 @@ type OrderDetail = domain.OrderDetail
 
 type orderService struct {
 	repo             repository.Querier
+	pool             *pgxpool.Pool
 	billingProvider  billing.Provider
 	shippingProvider shipping.Provider
 }
 
 // NewOrderService creates a new OrderService instance
 // Requires billing and shipping providers for order creation flow
-func NewOrderService(repo repository.Querier, billingProvider billing.Provider, shippingProvider shipping.Provider) OrderService {
+// pool is used for the order creation transaction
+func NewOrderService(repo repository.Querier, pool *pgxpool.Pool, billingProvider billing.Provider, shippingProvider shipping.Provider) OrderService {
 	return &orderService{
 		repo:             repo,
+		pool:             pool,
 		billingProvider:  billingProvider,
 		shippingProvider: shippingProvider,
 	}
@@ -53,13 +61,13 @@ func NewOrderService(repo repository.Querier, billingProvider billing.Provider,
 // 5. Retrieve cart and validate tenant isolation
 // 6. Load cart items with product details
 // 7. Extract shipping/billing addresses from payment metadata
-// 8. Begin database transaction for atomicity
-// 9. Create address records (shipping and billing)
-// 10. Create billing customer record (Stripe customer linkage)
-// 11. Create payment method record (for saved cards)
-// 12. Create payment record (transaction log)
-// 13. Generate order number
-// 14. Create order record
+// 8. Create address records (shipping and billing)
+// 9. Create billing customer record (Stripe customer linkage)
+// 10. Create payment method record (for saved cards)
+// 11. Create payment record (transaction log)
+// 12. Generate order number
+// 13. Begin database transaction for atomicity
+// 14. Create order record and record discount redemption
 // 15. Create order items (snapshot cart state)
 // 16. Decrement inventory for each SKU (with optimistic locking)
 // 17. Mark cart as converted
@@ -126,7 +134,10 @@ func (s *orderService) CreateOrderFromPaymentIntent(ctx context.Context, payment
 	}
 
 	// Step 5: Retrieve cart and validate tenant isolation
-	cart, err := s.repo.GetCartByID(ctx, cartUUID)
+	cart, err := s.repo.GetCartByID(ctx, repository.GetCartByIDParams{
+		TenantID: tenantID,
+		ID:       cartUUID,
+	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrCartNotFound
@@ -172,10 +183,6 @@ func (s *orderService) CreateOrderFromPaymentIntent(ctx context.Context, payment
 		return nil, fmt.Errorf("failed to parse billing address: %w", err)
 	}
 
-	// Steps 8-19: Since we're using mocks without transaction support,
-	// we'll execute operations sequentially without actual transaction management
-	// In production with real pgx, this would use BeginTx
-
 	// Step 9: Create address records
 	shippingAddress, err := s.repo.CreateAddress(ctx, repository.CreateAddressParams{
 		TenantID:     tenantID,
@@ -358,91 +365,147 @@ func (s *orderService) CreateOrderFromPaymentIntent(ctx context.Context, payment
 
 	// Step 14: Create order record
 	subtotalCents, _ := calculateOrderTotals(cartItems)
-	totalCents := subtotalCents + paymentIntent.TaxCents + paymentIntent.ShippingCents
-
-	customerNotes := paymentIntent.Metadata["customer_notes"]
-
-	order, err := s.repo.CreateOrder(ctx, repository.CreateOrderParams{
-		TenantID:          tenantID,
-		CartID:            cart.ID,
-		UserID:            userID,
-		OrderNumber:       orderNumber,
-		OrderType:         "retail",
-		Status:            "pending",
-		SubtotalCents:     subtotalCents,
-		ShippingCents:     paymentIntent.ShippingCents,
-		TaxCents:          paymentIntent.TaxCents,
-		TotalCents:        totalCents,
-		Currency:          "usd",
-		ShippingAddressID: shippingAddress.ID,
-		BillingAddressID:  billingAddress.ID,
-		CustomerNotes:     makePgText(customerNotes),
-		SubscriptionID:    pgtype.UUID{}, // Not a subscription order
-	})
+	discountCodeID, discountCents, err := parseDiscountMetadata(paymentIntent.Metadata)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create order: %w", err)
+		return nil, err
 	}
+	totalCents := subtotalCents + paymentIntent.TaxCents + paymentIntent.ShippingCents - discountCents
 
-	// Step 15: Create order items
-	for _, item := range cartItems {
-		variantDesc := buildVariantDescription(item)
+	customerNotes := paymentIntent.Metadata["customer_notes"]
 
-		_, err := s.repo.CreateOrderItem(ctx, repository.CreateOrderItemParams{
-			TenantID:           tenantID,
-			OrderID:            order.ID,
-			ProductSkuID:       item.ProductSkuID,
-			ProductName:        item.ProductName,
-			Sku:                item.Sku,
-			VariantDescription: makePgText(variantDesc),
-			Quantity:           item.Quantity,
-			UnitPriceCents:     item.UnitPriceCents,
-			TotalPriceCents:    item.Quantity * item.UnitPriceCents,
+	// Steps 13-19: Create the order, its items and the discount redemption,
+	// take the stock and convert the cart in one transaction
+	var order repository.Order
+	var orderItems []repository.GetOrderItemsRow
+	err = withTx(ctx, s.pool, s.repo, func(repo repository.Querier) error {
+		var err error
+		order, err = repo.CreateOrder(ctx, repository.CreateOrderParams{
+			TenantID:          tenantID,
+			CartID:            cart.ID,
+			UserID:            userID,
+			OrderNumber:       orderNumber,
+			OrderType:         "retail",
+			Status:            "pending",
+			SubtotalCents:     subtotalCents,
+			ShippingCents:     paymentIntent.ShippingCents,
+			TaxCents:          paymentIntent.TaxCents,
+			TotalCents:        totalCents,
+			Currency:          "usd",
+			ShippingAddressID: shippingAddress.ID,
+			BillingAddressID:  billingAddress.ID,
+			CustomerNotes:     makePgText(customerNotes),
+			SubscriptionID:    pgtype.UUID{}, // Not a subscription order
+			DiscountCents:     discountCents,
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to create order item: %w", err)
+			return fmt.Errorf("failed to create order: %w", err)
 		}
-	}
 
-	// Fetch order items with image URLs
-	orderItems, err := s.repo.GetOrderItems(ctx, order.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get order items: %w", err)
-	}
+		// Step 14b: Record discount redemption
+		// The code row stays locked until commit, so concurrent orders see
+		// each other's usage. The customer has already been charged the
+		// discounted amount, so a code that ran out since checkout does not
+		// fail the order: the use is still recorded and flagged for review.
+		if discountCodeID.Valid {
+			err := lockDiscountCodeForUse(ctx, repo, tenantID, discountCodeID, userID)
+			if errors.Is(err, ErrDiscountCodeExhausted) || errors.Is(err, ErrDiscountCustomerLimit) {
+				slog.WarnContext(ctx, "discount code redeemed over its usage limit",
+					"tenant_id", uuidToString(tenantID),
+					"discount_code_id", uuidToString(discountCodeID),
+					"order_number", orderNumber,
+					"reason", err.Error(),
+				)
+			} else if err != nil {
+				return err
+			}
 
-	// Step 16: Decrement inventory
-	for _, item := range cartItems {
-		err := s.repo.DecrementSKUStock(ctx, repository.DecrementSKUStockParams{
-			TenantID:          tenantID,
-			ID:                item.ProductSkuID,
-			InventoryQuantity: item.Quantity,
+			if err := repo.RecordDiscountCodeUsage(ctx, repository.RecordDiscountCodeUsageParams{
+				TenantID:            tenantID,
+				ID:                  discountCodeID,
+				UserID:              userID,
+				OrderID:             order.ID,
+				DiscountAmountCents: discountCents,
+			}); err != nil {
+				return fmt.Errorf("failed to record discount usage: %w", err)
+			}
+		}
+
+		// Step 15: Create order items
+		for _, item := range cartItems {
+			variantDesc := buildVariantDescription(item)
+
+			_, err := repo.CreateOrderItem(ctx, repository.CreateOrderItemParams{
+				TenantID:           tenantID,
+				OrderID:            order.ID,
+				ProductSkuID:       item.ProductSkuID,
+				ProductName:        item.ProductName,
+				Sku:                item.Sku,
+				VariantDescription: makePgText(variantDesc),
+				Quantity:           item.Quantity,
+				UnitPriceCents:     item.UnitPriceCents,
+				TotalPriceCents:    item.Quantity * item.UnitPriceCents,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create order item: %w", err)
+			}
+		}
+
+		// Fetch order items with image URLs
+		orderItems, err = repo.GetOrderItems(ctx, order.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get order items: %w", err)
+		}
+
+		// Step 16: Decrement inventory
+		for _, item := range cartItems {
+			err := repo.DecrementSKUStock(ctx, repository.DecrementSKUStockParams{
+				TenantID:          tenantID,
+				ID:                item.ProductSkuID,
+				InventoryQuantity: item.Quantity,
+				OrderID:           order.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to decrement stock for SKU %s: %w", item.Sku, ErrInsufficientStock)
+			}
+		}
+
+		// Step 17: Mark cart as converted
+		err = repo.UpdateCartStatus(ctx, repository.UpdateCartStatusParams{
+			TenantID: tenantID,
+			ID:       cart.ID,
+			Status:   "converted",
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to decrement stock for SKU %s: %w", item.Sku, ErrInsufficientStock)
+			return fmt.Errorf("failed to update cart status: %w", err)
 		}
-	}
 
-	// Step 17: Mark cart as converted
-	err = s.repo.UpdateCartStatus(ctx, repository.UpdateCartStatusParams{
-		TenantID: tenantID,
-		ID:       cart.ID,
-		Status:   "converted",
+		// Step 18: Link payment to order
+		err = repo.UpdateOrderPaymentID(ctx, repository.UpdateOrderPaymentIDParams{
+			TenantID:  tenantID,
+			ID:        order.ID,
+			PaymentID: payment.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to link payment to order: %w", err)
+		}
+
+		// Step 19: Commit transaction
+		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update cart status: %w", err)
+		return nil, err
 	}
 
-	// Step 18: Link payment to order
-	err = s.repo.UpdateOrderPaymentID(ctx, repository.UpdateOrderPaymentIDParams{
-		TenantID:  tenantID,
-		ID:        order.ID,
-		PaymentID: payment.ID,
+	// Step 18b: Allocate coffee to the next planned roast batches.
+	// Unallocated items simply ship without a roast date, so a failure
+	// here does not fail the order.
+	_, _ = s.repo.AllocateOrderToRoastBatches(ctx, repository.AllocateOrderToRoastBatchesParams{
+		TenantID: tenantID,
+		OrderID:  order.ID,
 	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to link payment to order: %w", err)
-	}
 
-	// Step 19: Commit transaction (N/A with mocks)
-	// In production: tx.Commit(ctx)
+	// Step 18c: Queue the order confirmation email (best effort)
+	enqueueOrderConfirmationEmail(ctx, s.repo, order, orderItems, shippingAddress, customerEmail, customerName)
 
 	// Step 20: Return complete order detail
 	return buildOrderDetail(order, orderItems, shippingAddress, billingAddress, payment), nil
@@ -601,6 +664,58 @@ func calculateOrderTotals(items []repository.GetCartItemsRow) (subtotal, total i
 	return
 }
 
+// enqueueOrderConfirmationEmail queues the order confirmation email with
+// each item's roast date. Failures are not returned: the order has already
+// been placed and stands.
+func enqueueOrderConfirmationEmail(ctx context.Context, repo repository.Querier, order repository.Order, items []repository.GetOrderItemsRow, shippingAddr repository.Address, customerEmail, customerName string) {
+	if customerEmail == "" {
+		return
+	}
+
+	// Without roast dates the email simply omits them
+	roastDates, _ := roastDatesByOrderItem(ctx, repo, order.TenantID, order.ID)
+
+	emailItems := make([]jobs.OrderItemData, len(items))
+	for i, item := range items {
+		emailItems[i] = jobs.OrderItemData{
+			ProductName: item.ProductName,
+			VariantName: item.VariantDescription.String,
+			Quantity:    int(item.Quantity),
+			TotalCents:  int64(item.TotalPriceCents),
+		}
+		if roast, ok := roastDates[item.ID]; ok {
+			roastDate := roast.Date()
+			emailItems[i].RoastDate = &roastDate
+			emailItems[i].RoastPlanned = roast.Status == "planned"
+		}
+	}
+
+	payload := jobs.OrderConfirmationPayload{
+		OrderID:       uuid.UUID(order.ID.Bytes),
+		Email:         customerEmail,
+		CustomerName:  customerName,
+		OrderNumber:   order.OrderNumber,
+		OrderDate:     order.CreatedAt.Time,
+		SubtotalCents: int64(order.SubtotalCents),
+		ShippingCents: int64(order.ShippingCents),
+		TaxCents:      int64(order.TaxCents),
+		TotalCents:    int64(order.TotalCents),
+		Items:         emailItems,
+		ShippingAddress: jobs.AddressData{
+			Name:       shippingAddr.FullName.String,
+			Company:    shippingAddr.Company.String,
+			Line1:      shippingAddr.AddressLine1,
+			Line2:      shippingAddr.AddressLine2.String,
+			City:       shippingAddr.City,
+			State:      shippingAddr.State,
+			PostalCode: shippingAddr.PostalCode,
+			Country:    shippingAddr.Country,
+		},
+	}
+
+	_ = jobs.EnqueueOrderConfirmationEmail(ctx, repo, uuid.UUID(order.TenantID.Bytes), payload)
+}
+
 // buildOrderDetail constructs an OrderDetail from components
 func buildOrderDetail(order repository.Order, items []repository.GetOrderItemsRow, shippingAddr, billingAddr repository.Address, payment repository.Payment) *OrderDetail {
 	return &OrderDetail{
@@ -612,6 +727,29 @@ func buildOrderDetail(order repository.Order, items []repository.GetOrderItemsRo
 	}
 }
 
+// parseDiscountMetadata extracts the discount code ID and amount that
+// checkout stored in the payment intent metadata. Orders without a
+// discount return an invalid UUID and zero cents.
+func parseDiscountMetadata(metadata map[string]string) (pgtype.UUID, int32, error) {
+	var codeID pgtype.UUID
+
+	idStr := metadata["discount_code_id"]
+	if idStr == "" {
+		return codeID, 0, nil
+	}
+
+	if err := codeID.Scan(idStr); err != nil {
+		return pgtype.UUID{}, 0, fmt.Errorf("invalid discount_code_id in metadata: %w", err)
+	}
+
+	cents, err := strconv.ParseInt(metadata["discount_cents"], 10, 32)
+	if err != nil {
+		return pgtype.UUID{}, 0, fmt.Errorf("invalid discount_cents in metadata: %w", err)
+	}
+
+	return codeID, int32(cents), nil
+}
+
 // makePgText creates a pgtype.Text from a string
 func makePgText(s string) pgtype.Text {
 	if s == "" {
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type orderRulesService struct {
+	repo repository.Querier
+}
+
+// NewOrderRulesService creates a new OrderRulesService instance
+func NewOrderRulesService(repo repository.Querier) domain.OrderRulesService {
+	return &orderRulesService{repo: repo}
+}
+
+// CheckCart checks a customer's cart against their minimum order value and
+// the quantity rules of each line.
+func (s *orderRulesService) CheckCart(ctx context.Context, userID, cartID string) (*domain.OrderRulesCheck, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.getCustomer(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &domain.OrderRulesCheck{}
+	if user.AccountType != "wholesale" {
+		return check, nil
+	}
+	check.MinimumSpendCents = user.MinimumSpendCents.Int32
+
+	var cartUUID pgtype.UUID
+	if err := cartUUID.Scan(cartID); err != nil {
+		return nil, ErrCartNotFound
+	}
+
+	lines, err := s.repo.ListCartOrderRules(ctx, repository.ListCartOrderRulesParams{
+		TenantID: tenantID,
+		CartID:   cartUUID,
+		UserID:   user.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart order rules: %w", err)
+	}
+
+	for _, line := range lines {
+		check.SubtotalCents += line.Quantity * line.UnitPriceCents
+
+		rule := domain.EffectiveQuantityRule(line.WholesaleMinQuantity, line.WholesaleCasePack,
+			line.HasCustomerRule, line.CustomerMinQuantity, line.CustomerCasePack)
+		if problem := rule.Check(line.Quantity); problem != "" {
+			check.QuantityIssues = append(check.QuantityIssues, domain.QuantityIssue{
+				SKUID:       line.ProductSkuID,
+				ProductName: line.ProductName,
+				SKU:         line.Sku,
+				Quantity:    line.Quantity,
+				Rule:        rule,
+				Problem:     problem,
+			})
+		}
+	}
+
+	return check, nil
+}
+
+// ListCustomerRules returns a customer's overrides and orderable SKUs.
+func (s *orderRulesService) ListCustomerRules(ctx context.Context, userID string) (*domain.CustomerOrderRules, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.getCustomer(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.repo.ListCustomerSKUOrderRules(ctx, repository.ListCustomerSKUOrderRulesParams{
+		TenantID: tenantID,
+		UserID:   user.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list customer order rules: %w", err)
+	}
+
+	skus, err := s.repo.ListCustomerOrderableSKUs(ctx, repository.ListCustomerOrderableSKUsParams{
+		TenantID:   tenantID,
+		CustomerID: user.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orderable SKUs: %w", err)
+	}
+
+	return &domain.CustomerOrderRules{
+		Overrides: overrides,
+		SKUs:      skus,
+	}, nil
+}
+
+// SetCustomerRule overrides a SKU's quantity rules for a customer.
+func (s *orderRulesService) SetCustomerRule(ctx context.Context, params domain.SetCustomerRuleParams) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if params.MinQuantity < 0 || params.CasePack < 0 {
+		return ErrInvalidQuantityRule
+	}
+
+	user, err := s.getCustomer(ctx, tenantID, params.UserID)
+	if err != nil {
+		return err
+	}
+	if user.AccountType != "wholesale" {
+		return ErrNotWholesaleUser
+	}
+
+	sku, err := s.getSKU(ctx, tenantID, params.SKUID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.repo.UpsertCustomerSKUOrderRule(ctx, repository.UpsertCustomerSKUOrderRuleParams{
+		TenantID:     tenantID,
+		UserID:       user.ID,
+		ProductSkuID: sku.ID,
+		MinQuantity:  pgtype.Int4{Int32: params.MinQuantity, Valid: params.MinQuantity > 0},
+		CasePack:     pgtype.Int4{Int32: params.CasePack, Valid: params.CasePack > 0},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save customer order rule: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCustomerRule removes a customer's override for a SKU.
+func (s *orderRulesService) DeleteCustomerRule(ctx context.Context, userID, skuID string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	var userUUID, skuUUID pgtype.UUID
+	if err := userUUID.Scan(userID); err != nil {
+		return domain.ErrUserNotFound
+	}
+	if err := skuUUID.Scan(skuID); err != nil {
+		return ErrSKUNotFound
+	}
+
+	err = s.repo.DeleteCustomerSKUOrderRule(ctx, repository.DeleteCustomerSKUOrderRuleParams{
+		TenantID:     tenantID,
+		UserID:       userUUID,
+		ProductSkuID: skuUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete customer order rule: %w", err)
+	}
+
+	return nil
+}
+
+// getCustomer loads a customer in the tenant.
+func (s *orderRulesService) getCustomer(ctx context.Context, tenantID pgtype.UUID, userID string) (repository.User, error) {
+	var userUUID pgtype.UUID
+	if err := userUUID.Scan(userID); err != nil {
+		return repository.User{}, domain.ErrUserNotFound
+	}
+
+	user, err := s.repo.GetUserByIDAndTenant(ctx, repository.GetUserByIDAndTenantParams{
+		ID:       userUUID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.User{}, domain.ErrUserNotFound
+		}
+		return repository.User{}, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	return user, nil
+}
+
+// getSKU loads an active SKU in the tenant.
+func (s *orderRulesService) getSKU(ctx context.Context, tenantID pgtype.UUID, skuID string) (repository.ProductSku, error) {
+	var skuUUID pgtype.UUID
+	if err := skuUUID.Scan(skuID); err != nil {
+		return repository.ProductSku{}, ErrSKUNotFound
+	}
+
+	sku, err := s.repo.GetSKUByID(ctx, skuUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ProductSku{}, ErrSKUNotFound
+		}
+		return repository.ProductSku{}, fmt.Errorf("failed to get SKU: %w", err)
+	}
+	if sku.TenantID != tenantID {
+		return repository.ProductSku{}, ErrSKUNotFound
+	}
+
+	return sku, nil
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func int4(n int32) pgtype.Int4 {
+	return pgtype.Int4{Int32: n, Valid: true}
+}
+
+func TestOrderRulesService_CheckCart(t *testing.T) {
+	tenantID := newUUID()
+	cartID := newUUID()
+	espresso := repository.ListCartOrderRulesRow{
+		ProductSkuID:      newUUID(),
+		ProductName:       "House Espresso",
+		Sku:               "ESP-5LB-WB",
+		UnitPriceCents:    6000,
+		WholesaleCasePack: int4(4),
+	}
+
+	tests := []struct {
+		name          string
+		minimumSpend  pgtype.Int4
+		line          func() repository.ListCartOrderRulesRow
+		wantRemaining int32
+		wantProblems  []string
+	}{
+		{
+			name:         "full cases over minimum",
+			minimumSpend: int4(15000),
+			line: func() repository.ListCartOrderRulesRow {
+				l := espresso
+				l.Quantity = 4
+				return l
+			},
+		},
+		{
+			name:         "below minimum order",
+			minimumSpend: int4(30000),
+			line: func() repository.ListCartOrderRulesRow {
+				l := espresso
+				l.Quantity = 4
+				return l
+			},
+			wantRemaining: 6000,
+		},
+		{
+			name: "broken case",
+			line: func() repository.ListCartOrderRulesRow {
+				l := espresso
+				l.Quantity = 3
+				return l
+			},
+			wantProblems: []string{"Order in cases of 4"},
+		},
+		{
+			name: "below minimum quantity",
+			line: func() repository.ListCartOrderRulesRow {
+				l := espresso
+				l.Quantity = 4
+				l.WholesaleMinQuantity = int4(8)
+				return l
+			},
+			wantProblems: []string{"Order at least 8"},
+		},
+		{
+			name: "customer override replaces case pack",
+			line: func() repository.ListCartOrderRulesRow {
+				l := espresso
+				l.Quantity = 2
+				l.HasCustomerRule = true
+				l.CustomerCasePack = int4(2)
+				return l
+			},
+		},
+		{
+			name: "customer override waives rules",
+			line: func() repository.ListCartOrderRulesRow {
+				l := espresso
+				l.Quantity = 1
+				l.HasCustomerRule = true
+				return l
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockQuerier(ctrl)
+			svc := NewOrderRulesService(mockRepo)
+
+			user := repository.User{ID: newUUID(), TenantID: tenantID, AccountType: "wholesale", MinimumSpendCents: tt.minimumSpend}
+			mockRepo.EXPECT().GetUserByIDAndTenant(gomock.Any(), gomock.Any()).Return(user, nil)
+			mockRepo.EXPECT().ListCartOrderRules(gomock.Any(), repository.ListCartOrderRulesParams{
+				TenantID: tenantID,
+				CartID:   cartID,
+				UserID:   user.ID,
+			}).Return([]repository.ListCartOrderRulesRow{tt.line()}, nil)
+
+			check, err := svc.CheckCart(contextWithTenant(tenantID), uuidToString(user.ID), uuidToString(cartID))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantRemaining, check.RemainingCents())
+			var problems []string
+			for _, issue := range check.QuantityIssues {
+				problems = append(problems, issue.Problem)
+			}
+			assert.Equal(t, tt.wantProblems, problems)
+			assert.Equal(t, tt.wantRemaining == 0 && len(tt.wantProblems) == 0, check.OK())
+		})
+	}
+}
+
+func TestOrderRulesService_CheckCart_RetailCustomersHaveNoRules(t *testing.T) {
+	tenantID := newUUID()
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewOrderRulesService(mockRepo)
+
+	user := repository.User{ID: newUUID(), TenantID: tenantID, AccountType: "retail", MinimumSpendCents: int4(15000)}
+	mockRepo.EXPECT().GetUserByIDAndTenant(gomock.Any(), gomock.Any()).Return(user, nil)
+
+	check, err := svc.CheckCart(contextWithTenant(tenantID), uuidToString(user.ID), uuidToString(newUUID()))
+	require.NoError(t, err)
+	assert.True(t, check.OK())
+}
+
+func TestOrderRulesService_SetCustomerRule(t *testing.T) {
+	tenantID := newUUID()
+	user := repository.User{ID: newUUID(), TenantID: tenantID, AccountType: "wholesale"}
+	sku := repository.ProductSku{ID: newUUID(), TenantID: tenantID}
+
+	t.Run("blank rule is stored as waived", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		svc := NewOrderRulesService(mockRepo)
+
+		mockRepo.EXPECT().GetUserByIDAndTenant(gomock.Any(), gomock.Any()).Return(user, nil)
+		mockRepo.EXPECT().GetSKUByID(gomock.Any(), sku.ID).Return(sku, nil)
+		mockRepo.EXPECT().UpsertCustomerSKUOrderRule(gomock.Any(), repository.UpsertCustomerSKUOrderRuleParams{
+			TenantID:     tenantID,
+			UserID:       user.ID,
+			ProductSkuID: sku.ID,
+			MinQuantity:  pgtype.Int4{},
+			CasePack:     int4(2),
+		}).Return(repository.CustomerSkuOrderRule{}, nil)
+
+		err := svc.SetCustomerRule(contextWithTenant(tenantID), domain.SetCustomerRuleParams{
+			UserID:   uuidToString(user.ID),
+			SKUID:    uuidToString(sku.ID),
+			CasePack: 2,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("negative quantity", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		svc := NewOrderRulesService(repository.NewMockQuerier(ctrl))
+
+		err := svc.SetCustomerRule(contextWithTenant(tenantID), domain.SetCustomerRuleParams{
+			UserID:      uuidToString(user.ID),
+			SKUID:       uuidToString(sku.ID),
+			MinQuantity: -1,
+		})
+		assert.ErrorIs(t, err, ErrInvalidQuantityRule)
+	})
+
+	t.Run("SKU from another tenant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockQuerier(ctrl)
+		svc := NewOrderRulesService(mockRepo)
+
+		other := sku
+		other.TenantID = newUUID()
+		mockRepo.EXPECT().GetUserByIDAndTenant(gomock.Any(), gomock.Any()).Return(user, nil)
+		mockRepo.EXPECT().GetSKUByID(gomock.Any(), sku.ID).Return(other, nil)
+
+		err := svc.SetCustomerRule(contextWithTenant(tenantID), domain.SetCustomerRuleParams{
+			UserID:   uuidToString(user.ID),
+			SKUID:    uuidToString(sku.ID),
+			CasePack: 2,
+		})
+		assert.ErrorIs(t, err, ErrSKUNotFound)
+	})
+}
@@ -181,6 +181,9 @@ func setupMockDefaults(mockRepo *repository.MockQuerier, tenantID pgtype.UUID, c
 	mockRepo.EXPECT().DecrementSKUStock(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	mockRepo.EXPECT().UpdateCartStatus(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	mockRepo.EXPECT().UpdateOrderPaymentID(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockRepo.EXPECT().AllocateOrderToRoastBatches(gomock.Any(), gomock.Any()).Return(int64(0), nil).AnyTimes()
+	mockRepo.EXPECT().ListOrderItemRoastDates(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).Return(repository.Job{}, nil).AnyTimes()
 	mockRepo.EXPECT().GetAddressByID(gomock.Any(), gomock.Any()).Return(repository.Address{}, nil).AnyTimes()
 	mockRepo.EXPECT().GetPaymentByID(gomock.Any(), gomock.Any()).Return(repository.Payment{}, nil).AnyTimes()
 	mockRepo.EXPECT().GetOrder(gomock.Any(), gomock.Any()).Return(repository.Order{}, nil).AnyTimes()
@@ -223,7 +226,7 @@ func Test_CreateOrderFromPaymentIntent_Idempotency(t *testing.T) {
 	mockBilling := billing.NewMockProvider()
 	mockShipping := shipping.NewMockProvider()
 
-	svc := NewOrderService(mockRepo, mockBilling, mockShipping)
+	svc := NewOrderService(mockRepo, nil, mockBilling, mockShipping)
 
 	// First call
 	order1, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
@@ -358,7 +361,7 @@ func Test_CreateOrderFromPaymentIntent_PaymentValidation(t *testing.T) {
 
 			mockShipping := shipping.NewMockProvider()
 
-			svc := NewOrderService(mockRepo, mockBilling, mockShipping)
+			svc := NewOrderService(mockRepo, nil, mockBilling, mockShipping)
 
 			order, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
 			assert.Error(t, err)
@@ -394,7 +397,7 @@ func Test_CreateOrderFromPaymentIntent_TenantIsolation(t *testing.T) {
 
 		mockShipping := shipping.NewMockProvider()
 
-		svc := NewOrderService(mockRepo, mockBilling, mockShipping)
+		svc := NewOrderService(mockRepo, nil, mockBilling, mockShipping)
 
 		order, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
 		assert.Error(t, err)
@@ -462,13 +465,16 @@ func Test_CreateOrderFromPaymentIntent_TenantIsolation(t *testing.T) {
 		mockRepo.EXPECT().DecrementSKUStock(gomock.Any(), gomock.Any()).Return(nil).Times(len(cartItems))
 		mockRepo.EXPECT().UpdateCartStatus(gomock.Any(), gomock.Any()).Return(nil)
 		mockRepo.EXPECT().UpdateOrderPaymentID(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().AllocateOrderToRoastBatches(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+		mockRepo.EXPECT().ListOrderItemRoastDates(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).Return(repository.Job{}, nil)
 
 		mockBilling := billing.NewMockProvider()
 		mockBilling.PaymentIntents[pi.ID] = pi
 
 		mockShipping := shipping.NewMockProvider()
 
-		svc := NewOrderService(mockRepo, mockBilling, mockShipping)
+		svc := NewOrderService(mockRepo, nil, mockBilling, mockShipping)
 
 		_, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
 		require.NoError(t, err, "order creation should succeed with correct tenant_id on all records")
@@ -497,7 +503,7 @@ func Test_CreateOrderFromPaymentIntent_CartStateManagement(t *testing.T) {
 
 		mockShipping := shipping.NewMockProvider()
 
-		svc := NewOrderService(mockRepo, mockBilling, mockShipping)
+		svc := NewOrderService(mockRepo, nil, mockBilling, mockShipping)
 
 		order, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
 		assert.Error(t, err)
@@ -526,7 +532,7 @@ func Test_CreateOrderFromPaymentIntent_CartStateManagement(t *testing.T) {
 
 		mockShipping := shipping.NewMockProvider()
 
-		svc := NewOrderService(mockRepo, mockBilling, mockShipping)
+		svc := NewOrderService(mockRepo, nil, mockBilling, mockShipping)
 
 		order, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
 		assert.Error(t, err)
@@ -573,13 +579,16 @@ func Test_CreateOrderFromPaymentIntent_CartStateManagement(t *testing.T) {
 				return nil
 			})
 		mockRepo.EXPECT().UpdateOrderPaymentID(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().AllocateOrderToRoastBatches(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+		mockRepo.EXPECT().ListOrderItemRoastDates(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).Return(repository.Job{}, nil)
 
 		mockBilling := billing.NewMockProvider()
 		mockBilling.PaymentIntents[pi.ID] = pi
 
 		mockShipping := shipping.NewMockProvider()
 
-		svc := NewOrderService(mockRepo, mockBilling, mockShipping)
+		svc := NewOrderService(mockRepo, nil, mockBilling, mockShipping)
 
 		_, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
 		require.NoError(t, err)
@@ -627,13 +636,16 @@ func Test_CreateOrderFromPaymentIntent_InventoryManagement(t *testing.T) {
 			}).Times(len(cartItems))
 		mockRepo.EXPECT().UpdateCartStatus(gomock.Any(), gomock.Any()).Return(nil)
 		mockRepo.EXPECT().UpdateOrderPaymentID(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().AllocateOrderToRoastBatches(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+		mockRepo.EXPECT().ListOrderItemRoastDates(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).Return(repository.Job{}, nil)
 
 		mockBilling := billing.NewMockProvider()
 		mockBilling.PaymentIntents[pi.ID] = pi
 
 		mockShipping := shipping.NewMockProvider()
 
-		svc := NewOrderService(mockRepo, mockBilling, mockShipping)
+		svc := NewOrderService(mockRepo, nil, mockBilling, mockShipping)
 
 		_, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
 		require.NoError(t, err)
@@ -682,7 +694,7 @@ func Test_CreateOrderFromPaymentIntent_InventoryManagement(t *testing.T) {
 
 		mockShipping := shipping.NewMockProvider()
 
-		svc := NewOrderService(mockRepo, mockBilling, mockShipping)
+		svc := NewOrderService(mockRepo, nil, mockBilling, mockShipping)
 
 		order, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
 		assert.Error(t, err)
@@ -746,13 +758,16 @@ func Test_CreateOrderFromPaymentIntent_OrderDataIntegrity(t *testing.T) {
 		mockRepo.EXPECT().DecrementSKUStock(gomock.Any(), gomock.Any()).Return(nil)
 		mockRepo.EXPECT().UpdateCartStatus(gomock.Any(), gomock.Any()).Return(nil)
 		mockRepo.EXPECT().UpdateOrderPaymentID(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().AllocateOrderToRoastBatches(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+		mockRepo.EXPECT().ListOrderItemRoastDates(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).Return(repository.Job{}, nil)
 
 		mockBilling := billing.NewMockProvider()
 		mockBilling.PaymentIntents[pi.ID] = pi
 
 		mockShipping := shipping.NewMockProvider()
 
-		svc := NewOrderService(mockRepo, mockBilling, mockShipping)
+		svc := NewOrderService(mockRepo, nil, mockBilling, mockShipping)
 
 		_, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
 		require.NoError(t, err)
@@ -795,13 +810,16 @@ func Test_CreateOrderFromPaymentIntent_OrderDataIntegrity(t *testing.T) {
 		mockRepo.EXPECT().DecrementSKUStock(gomock.Any(), gomock.Any()).Return(nil).Times(len(cartItems))
 		mockRepo.EXPECT().UpdateCartStatus(gomock.Any(), gomock.Any()).Return(nil)
 		mockRepo.EXPECT().UpdateOrderPaymentID(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().AllocateOrderToRoastBatches(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+		mockRepo.EXPECT().ListOrderItemRoastDates(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).Return(repository.Job{}, nil)
 
 		mockBilling := billing.NewMockProvider()
 		mockBilling.PaymentIntents[pi.ID] = pi
 
 		mockShipping := shipping.NewMockProvider()
 
-		svc := NewOrderService(mockRepo, mockBilling, mockShipping)
+		svc := NewOrderService(mockRepo, nil, mockBilling, mockShipping)
 
 		_, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
 		require.NoError(t, err)
@@ -842,7 +860,7 @@ func Test_CreateOrderFromPaymentIntent_SuccessfulFlow(t *testing.T) {
 
 	mockShipping := shipping.NewMockProvider()
 
-	svc := NewOrderService(mockRepo, mockBilling, mockShipping)
+	svc := NewOrderService(mockRepo, nil, mockBilling, mockShipping)
 
 	order, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
 	require.NoError(t, err, "successful order creation should not error")
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ProductionPlan totals the coffee to roast per product per day for the
+// given number of days from start.
+func (s *roastService) ProductionPlan(ctx context.Context, start time.Time, days int) (*domain.ProductionPlan, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if start.IsZero() {
+		return nil, ErrRoastDateRequired
+	}
+	if days < 1 || days > domain.MaxProductionPlanDays {
+		return nil, ErrInvalidPlanWindow
+	}
+
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	plan := &domain.ProductionPlan{
+		Start: start,
+		End:   start.AddDate(0, 0, days-1),
+	}
+
+	rows, err := s.repo.ListProductionDemand(ctx, repository.ListProductionDemandParams{
+		TenantID:  tenantID,
+		StartDate: makePgDate(plan.Start),
+		EndDate:   makePgDate(plan.End),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list production demand: %w", err)
+	}
+
+	// Rows arrive ordered by roast date
+	for _, row := range rows {
+		if n := len(plan.Days); n == 0 || !plan.Days[n-1].Date.Equal(row.RoastOn.Time) {
+			plan.Days = append(plan.Days, domain.ProductionDay{Date: row.RoastOn.Time})
+		}
+		day := &plan.Days[len(plan.Days)-1]
+
+		line := domain.ProductionLine{
+			ProductID:         uuidToString(row.ProductID),
+			ProductName:       row.ProductName,
+			Units:             int(row.Units),
+			OrderGrams:        int(row.OrderGrams),
+			WholesaleGrams:    int(row.WholesaleGrams),
+			SubscriptionGrams: int(row.SubscriptionGrams),
+			RoastLossPercent:  row.RoastLossPercent,
+		}
+		line.RoastedGrams = line.OrderGrams + line.WholesaleGrams + line.SubscriptionGrams
+		line.GreenGrams = greenGrams(line.RoastedGrams, line.RoastLossPercent)
+
+		day.Lines = append(day.Lines, line)
+		day.RoastedGrams += line.RoastedGrams
+		day.GreenGrams += line.GreenGrams
+	}
+
+	return plan, nil
+}
+
+// ListRoastLoss returns the roast loss of each active product.
+func (s *roastService) ListRoastLoss(ctx context.Context) ([]repository.ListProductRoastLossRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.repo.ListProductRoastLoss(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roast loss: %w", err)
+	}
+
+	return products, nil
+}
+
+// SetRoastLoss sets the percent of green weight a product loses when roasted.
+func (s *roastService) SetRoastLoss(ctx context.Context, productID string, percent float64) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if math.IsNaN(percent) || percent < 0 || percent > domain.MaxRoastLossPercent {
+		return ErrInvalidRoastLoss
+	}
+
+	var productUUID pgtype.UUID
+	if err := productUUID.Scan(productID); err != nil {
+		return ErrProductNotFound
+	}
+
+	updated, err := s.repo.UpdateProductRoastLoss(ctx, repository.UpdateProductRoastLossParams{
+		TenantID:         tenantID,
+		ID:               productUUID,
+		RoastLossPercent: math.Round(percent*10) / 10,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update roast loss: %w", err)
+	}
+	if updated == 0 {
+		return ErrProductNotFound
+	}
+
+	return nil
+}
+
+// greenGrams estimates the green coffee that roasts down to the given
+// weight, rounded up to the next gram.
+func greenGrams(roastedGrams int, lossPercent float64) int {
+	if roastedGrams <= 0 {
+		return 0
+	}
+	// Work in tenths of a percent, the precision roast loss is stored at,
+	// so whole results are not rounded up by float error
+	keptTenths := 1000 - int(math.Round(lossPercent*10))
+	return (roastedGrams*1000 + keptTenths - 1) / keptTenths
+}
@@ -0,0 +1,667 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/billing"
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Refund sources recorded in refunds.source.
+const (
+	RefundSourceAdmin    = "admin"
+	RefundSourceProvider = "provider"
+)
+
+// refundableStatuses lists order statuses that can still be refunded.
+var refundableStatuses = map[string]bool{
+	"paid":               true,
+	"processing":         true,
+	"shipped":            true,
+	"delivered":          true,
+	"partially_refunded": true,
+}
+
+// cancellableStatuses lists order statuses that can still be cancelled.
+// Refunded orders are included so a cancellation interrupted after its
+// refund can be retried.
+var cancellableStatuses = map[string]bool{
+	"pending":            true,
+	"payment_processing": true,
+	"paid":               true,
+	"processing":         true,
+	"partially_refunded": true,
+	"refunded":           true,
+}
+
+type refundService struct {
+	repo            repository.Querier
+	billingProvider billing.Provider
+}
+
+// NewRefundService creates a new RefundService instance
+func NewRefundService(repo repository.Querier, billingProvider billing.Provider) domain.RefundService {
+	return &refundService{
+		repo:            repo,
+		billingProvider: billingProvider,
+	}
+}
+
+// refundLine is a line item and quantity selected for refund.
+type refundLine struct {
+	item     repository.GetOrderItemsRow
+	quantity int32
+}
+
+// RefundOrder issues a refund through the billing provider and records it.
+//
+// Modes:
+//   - full: refunds the unrefunded balance and all unrefunded line items
+//   - items: refunds selected line items, including their share of tax and discount
+//   - amount: refunds an arbitrary amount without line items (goodwill, shipping)
+//
+// The provider refund is issued first. If recording it fails afterwards, the
+// charge.refunded webhook records it through RecordProviderRefunds.
+func (s *refundService) RefundOrder(ctx context.Context, params domain.RefundOrderParams) (*repository.Refund, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var orderID pgtype.UUID
+	if err := orderID.Scan(params.OrderID); err != nil {
+		return nil, ErrOrderNotFound
+	}
+
+	order, err := s.repo.GetOrder(ctx, repository.GetOrderParams{
+		TenantID: tenantID,
+		ID:       orderID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if !refundableStatuses[order.Status] {
+		return nil, ErrOrderNotRefundable
+	}
+
+	if !order.PaymentID.Valid {
+		return nil, ErrOrderNotPaidByCard
+	}
+
+	payment, err := s.repo.GetPaymentByID(ctx, order.PaymentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	if payment.Provider != "stripe" || !strings.HasPrefix(payment.ProviderPaymentID, "pi_") {
+		return nil, ErrOrderNotPaidByCard
+	}
+
+	refundedCents, err := s.repo.GetOrderRefundedTotal(ctx, repository.GetOrderRefundedTotalParams{
+		TenantID: tenantID,
+		OrderID:  orderID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refunded total: %w", err)
+	}
+	remaining := int64(order.TotalCents) - refundedCents
+
+	lines, amount, err := s.buildRefund(ctx, tenantID, order, params, remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount <= 0 {
+		return nil, ErrInvalidRefundAmount
+	}
+	if amount > remaining {
+		return nil, ErrRefundExceedsBalance
+	}
+
+	tenantIDStr := uuidToString(tenantID)
+	providerRefund, err := s.billingProvider.RefundPayment(ctx, billing.RefundParams{
+		PaymentIntentID: payment.ProviderPaymentID,
+		TenantID:        tenantIDStr,
+		AmountCents:     int32(amount),
+		Reason:          params.Reason,
+		Metadata: map[string]string{
+			"order_id":     uuidToString(order.ID),
+			"order_number": order.OrderNumber,
+			"source":       RefundSourceAdmin,
+		},
+		IdempotencyKey: fmt.Sprintf("refund_%s_%d_%d", uuidToString(order.ID), refundedCents, amount),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue refund: %w", err)
+	}
+
+	refund, inserted, err := s.recordRefund(ctx, repository.CreateRefundParams{
+		TenantID:         tenantID,
+		OrderID:          order.ID,
+		PaymentID:        payment.ID,
+		Provider:         "stripe",
+		ProviderRefundID: providerRefund.ID,
+		AmountCents:      int32(providerRefund.Amount),
+		Reason:           pgtype.Text{String: params.Reason, Valid: params.Reason != ""},
+		Note:             pgtype.Text{String: strings.TrimSpace(params.Note), Valid: strings.TrimSpace(params.Note) != ""},
+		Status:           refundStatus(providerRefund.Status),
+		Source:           RefundSourceAdmin,
+		Restocked:        params.Restock && len(lines) > 0,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("refund %s issued but failed to record: %w", providerRefund.ID, err)
+	}
+
+	for _, line := range lines {
+		_, err := s.repo.CreateRefundItem(ctx, repository.CreateRefundItemParams{
+			TenantID:    tenantID,
+			RefundID:    refund.ID,
+			OrderItemID: line.item.ID,
+			Quantity:    line.quantity,
+			AmountCents: line.item.UnitPriceCents * line.quantity,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record refund item: %w", err)
+		}
+
+		if params.Restock {
+			err := s.repo.IncrementSKUStock(ctx, repository.IncrementSKUStockParams{
+				TenantID:          tenantID,
+				ID:                line.item.ProductSkuID,
+				InventoryQuantity: line.quantity,
+				OrderID:           order.ID,
+				RefundID:          refund.ID,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to restock %s: %w", line.item.Sku, err)
+			}
+		}
+	}
+
+	totalRefunded, err := s.syncRefundStatus(ctx, order, payment)
+	if err != nil {
+		return nil, err
+	}
+
+	// The webhook may have recorded (and announced) this refund already
+	if inserted {
+		s.enqueueRefundEmail(ctx, order, int64(refund.AmountCents), totalRefunded)
+	}
+
+	return refund, nil
+}
+
+// buildRefund resolves the line items and amount for a refund request.
+func (s *refundService) buildRefund(ctx context.Context, tenantID pgtype.UUID, order repository.Order, params domain.RefundOrderParams, remaining int64) ([]refundLine, int64, error) {
+	if params.Mode == domain.RefundModeAmount {
+		return nil, int64(params.AmountCents), nil
+	}
+
+	if params.Mode != domain.RefundModeFull && params.Mode != domain.RefundModeItems {
+		return nil, 0, ErrInvalidRefundMode
+	}
+
+	items, err := s.repo.GetOrderItems(ctx, order.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get order items: %w", err)
+	}
+
+	refundedRows, err := s.repo.ListRefundedQuantitiesForOrder(ctx, repository.ListRefundedQuantitiesForOrderParams{
+		TenantID: tenantID,
+		OrderID:  order.ID,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get refunded quantities: %w", err)
+	}
+
+	refundedQty := make(map[string]int32, len(refundedRows))
+	for _, row := range refundedRows {
+		refundedQty[uuidToString(row.OrderItemID)] = row.RefundedQuantity
+	}
+
+	var lines []refundLine
+
+	if params.Mode == domain.RefundModeFull {
+		for _, item := range items {
+			if qty := item.Quantity - refundedQty[uuidToString(item.ID)]; qty > 0 {
+				lines = append(lines, refundLine{item: item, quantity: qty})
+			}
+		}
+		return lines, remaining, nil
+	}
+
+	byID := make(map[string]repository.GetOrderItemsRow, len(items))
+	for _, item := range items {
+		byID[uuidToString(item.ID)] = item
+	}
+
+	var merchandiseCents int64
+	for _, sel := range params.Items {
+		if sel.Quantity <= 0 {
+			continue
+		}
+
+		item, ok := byID[strings.ToLower(sel.OrderItemID)]
+		if !ok {
+			return nil, 0, ErrRefundItemNotFound
+		}
+
+		if sel.Quantity > item.Quantity-refundedQty[uuidToString(item.ID)] {
+			return nil, 0, ErrRefundQuantityInvalid
+		}
+
+		lines = append(lines, refundLine{item: item, quantity: sel.Quantity})
+		merchandiseCents += int64(item.UnitPriceCents) * int64(sel.Quantity)
+	}
+
+	if len(lines) == 0 {
+		return nil, 0, ErrInvalidRefundAmount
+	}
+
+	return lines, itemRefundAmount(order, merchandiseCents), nil
+}
+
+// itemRefundAmount converts the merchandise value of refunded units into the
+// amount to refund: the merchandise value less its share of any order discount,
+// plus its share of tax. Shipping is only refunded by full or amount refunds.
+func itemRefundAmount(order repository.Order, merchandiseCents int64) int64 {
+	if order.SubtotalCents <= 0 {
+		return 0
+	}
+
+	goodsCents := int64(order.SubtotalCents) - int64(order.DiscountCents) + int64(order.TaxCents)
+	return merchandiseCents * goodsCents / int64(order.SubtotalCents)
+}
+
+// RecordProviderRefunds records refunds issued outside the app.
+// Refunds already recorded (including those issued from the admin) are skipped.
+// When the provider does not include the refund list, the unrecorded
+// difference in the cumulative refunded amount is recorded instead.
+func (s *refundService) RecordProviderRefunds(ctx context.Context, params domain.ProviderRefundParams) error {
+	order, err := s.repo.GetOrderByPaymentIntentID(ctx, repository.GetOrderByPaymentIntentIDParams{
+		TenantID:          params.TenantID,
+		ProviderPaymentID: params.PaymentIntentID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		return fmt.Errorf("failed to get order for payment intent: %w", err)
+	}
+
+	payment, err := s.repo.GetPaymentByID(ctx, order.PaymentID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	base := repository.CreateRefundParams{
+		TenantID:  params.TenantID,
+		OrderID:   order.ID,
+		PaymentID: payment.ID,
+		Provider:  "stripe",
+		Source:    RefundSourceProvider,
+	}
+
+	var newlyRefunded int64
+
+	for _, r := range params.Refunds {
+		if r.Status == "failed" || r.Status == "canceled" || r.AmountCents <= 0 {
+			continue
+		}
+
+		arg := base
+		arg.ProviderRefundID = r.ID
+		arg.AmountCents = int32(r.AmountCents)
+		arg.Reason = pgtype.Text{String: r.Reason, Valid: r.Reason != ""}
+		arg.Status = refundStatus(r.Status)
+		if r.Metadata["source"] == RefundSourceAdmin {
+			arg.Source = RefundSourceAdmin
+		}
+
+		refund, inserted, err := s.recordRefund(ctx, arg)
+		if err != nil {
+			return fmt.Errorf("failed to record refund %s: %w", r.ID, err)
+		}
+		if inserted {
+			newlyRefunded += int64(refund.AmountCents)
+		}
+	}
+
+	if len(params.Refunds) == 0 && params.AmountRefundedCents > 0 {
+		recorded, err := s.repo.GetOrderRefundedTotal(ctx, repository.GetOrderRefundedTotalParams{
+			TenantID: params.TenantID,
+			OrderID:  order.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get refunded total: %w", err)
+		}
+
+		if diff := params.AmountRefundedCents - recorded; diff > 0 {
+			arg := base
+			// Deterministic ID keeps webhook retries idempotent
+			arg.ProviderRefundID = params.ChargeID + ":" + strconv.FormatInt(params.AmountRefundedCents, 10)
+			arg.AmountCents = int32(diff)
+			arg.Status = "succeeded"
+
+			refund, inserted, err := s.recordRefund(ctx, arg)
+			if err != nil {
+				return fmt.Errorf("failed to record refund for charge %s: %w", params.ChargeID, err)
+			}
+			if inserted {
+				newlyRefunded += int64(refund.AmountCents)
+			}
+		}
+	}
+
+	if newlyRefunded == 0 {
+		return nil
+	}
+
+	totalRefunded, err := s.syncRefundStatus(ctx, order, payment)
+	if err != nil {
+		return err
+	}
+
+	s.enqueueRefundEmail(ctx, order, newlyRefunded, totalRefunded)
+
+	return nil
+}
+
+// CancelOrder cancels an order that hasn't shipped.
+//
+// A captured card payment is refunded in full through RefundOrder, which
+// restocks the refunded units; a payment that hasn't been captured is voided
+// at the provider. Orders on account can only be cancelled once their invoice
+// is voided. The status change is logged to order_status_history by trigger
+// and the reason is recorded on that entry.
+func (s *refundService) CancelOrder(ctx context.Context, params domain.CancelOrderParams) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	var orderID pgtype.UUID
+	if err := orderID.Scan(params.OrderID); err != nil {
+		return ErrOrderNotFound
+	}
+
+	order, err := s.repo.GetOrder(ctx, repository.GetOrderParams{
+		TenantID: tenantID,
+		ID:       orderID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if !cancellableStatuses[order.Status] {
+		return ErrOrderNotCancellable
+	}
+
+	// A shipment that hasn't been cancelled means the order is on its way out
+	shipments, err := s.repo.GetShipmentsByOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get shipments: %w", err)
+	}
+	for _, shipment := range shipments {
+		if shipment.Status != "cancelled" {
+			return ErrOrderNotCancellable
+		}
+	}
+
+	reason := strings.TrimSpace(params.Reason)
+	restocked := false
+
+	if order.PaymentID.Valid {
+		payment, err := s.repo.GetPaymentByID(ctx, order.PaymentID)
+		if err != nil {
+			return fmt.Errorf("failed to get payment: %w", err)
+		}
+
+		if payment.Provider == "stripe" && strings.HasPrefix(payment.ProviderPaymentID, "pi_") {
+			switch payment.Status {
+			case "succeeded", "partially_refunded":
+				refundedCents, err := s.repo.GetOrderRefundedTotal(ctx, repository.GetOrderRefundedTotalParams{
+					TenantID: tenantID,
+					OrderID:  order.ID,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to get refunded total: %w", err)
+				}
+
+				if refundedCents < int64(order.TotalCents) {
+					note := "Order cancelled"
+					if reason != "" {
+						note += ": " + reason
+					}
+					_, err := s.RefundOrder(ctx, domain.RefundOrderParams{
+						OrderID: params.OrderID,
+						Mode:    domain.RefundModeFull,
+						Note:    note,
+						Restock: params.Restock,
+					})
+					if err != nil {
+						return err
+					}
+					restocked = params.Restock
+				}
+
+			case "pending", "processing":
+				if err := s.billingProvider.CancelPaymentIntent(ctx, payment.ProviderPaymentID, uuidToString(tenantID)); err != nil {
+					return fmt.Errorf("failed to cancel payment: %w", err)
+				}
+				_, err := s.repo.UpdatePaymentStatus(ctx, repository.UpdatePaymentStatusParams{
+					TenantID: tenantID,
+					ID:       payment.ID,
+					Status:   "cancelled",
+				})
+				if err != nil {
+					return fmt.Errorf("failed to update payment status: %w", err)
+				}
+			}
+		}
+	} else {
+		// Orders on account are billed by invoice, which has to be voided first
+		invoice, err := s.repo.GetInvoiceForOrder(ctx, order.ID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to get invoice: %w", err)
+		}
+		if err == nil && invoice.Status != "void" && invoice.Status != "cancelled" {
+			return ErrOrderInvoiced
+		}
+	}
+
+	if params.Restock && !restocked {
+		lines, _, err := s.buildRefund(ctx, tenantID, order, domain.RefundOrderParams{Mode: domain.RefundModeFull}, 0)
+		if err != nil {
+			return err
+		}
+		for _, line := range lines {
+			err := s.repo.RestockCancelledOrderItem(ctx, repository.RestockCancelledOrderItemParams{
+				TenantID:          tenantID,
+				ID:                line.item.ProductSkuID,
+				InventoryQuantity: line.quantity,
+				OrderID:           order.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to restock %s: %w", line.item.Sku, err)
+			}
+		}
+	}
+
+	err = s.repo.UpdateOrderStatus(ctx, repository.UpdateOrderStatusParams{
+		TenantID: tenantID,
+		ID:       order.ID,
+		Status:   "cancelled",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	err = s.repo.UpdateOrderFulfillmentStatus(ctx, repository.UpdateOrderFulfillmentStatusParams{
+		TenantID:          tenantID,
+		ID:                order.ID,
+		FulfillmentStatus: "cancelled",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update fulfillment status: %w", err)
+	}
+
+	if reason != "" {
+		err = s.repo.SetOrderStatusChangeReason(ctx, repository.SetOrderStatusChangeReasonParams{
+			TenantID:     tenantID,
+			OrderID:      order.ID,
+			ToStatus:     "cancelled",
+			ChangeReason: pgtype.Text{String: reason, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record cancellation reason: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListRefunds returns all refunds recorded for an order.
+func (s *refundService) ListRefunds(ctx context.Context, orderID string) ([]repository.Refund, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var orderUUID pgtype.UUID
+	if err := orderUUID.Scan(orderID); err != nil {
+		return nil, ErrOrderNotFound
+	}
+
+	refunds, err := s.repo.ListRefundsForOrder(ctx, repository.ListRefundsForOrderParams{
+		TenantID: tenantID,
+		OrderID:  orderUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refunds: %w", err)
+	}
+
+	return refunds, nil
+}
+
+// recordRefund inserts a refund, returning the existing row when the provider
+// refund has already been recorded. inserted reports whether a row was created.
+func (s *refundService) recordRefund(ctx context.Context, arg repository.CreateRefundParams) (*repository.Refund, bool, error) {
+	refund, err := s.repo.CreateRefund(ctx, arg)
+	if err == nil {
+		return &refund, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, err
+	}
+
+	existing, err := s.repo.GetRefundByProviderID(ctx, repository.GetRefundByProviderIDParams{
+		TenantID:         arg.TenantID,
+		Provider:         arg.Provider,
+		ProviderRefundID: arg.ProviderRefundID,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &existing, false, nil
+}
+
+// syncRefundStatus moves the order and payment to refunded or
+// partially_refunded based on the total recorded refunds.
+// The order status change is logged to order_status_history by trigger.
+func (s *refundService) syncRefundStatus(ctx context.Context, order repository.Order, payment repository.Payment) (int64, error) {
+	totalRefunded, err := s.repo.GetOrderRefundedTotal(ctx, repository.GetOrderRefundedTotalParams{
+		TenantID: order.TenantID,
+		OrderID:  order.ID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get refunded total: %w", err)
+	}
+
+	orderStatus := "partially_refunded"
+	if totalRefunded >= int64(order.TotalCents) {
+		orderStatus = "refunded"
+	}
+
+	err = s.repo.UpdateOrderStatus(ctx, repository.UpdateOrderStatusParams{
+		TenantID: order.TenantID,
+		ID:       order.ID,
+		Status:   orderStatus,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	paymentStatus := "partially_refunded"
+	if totalRefunded >= int64(payment.AmountCents) {
+		paymentStatus = "refunded"
+	}
+
+	err = s.repo.UpdatePaymentRefund(ctx, repository.UpdatePaymentRefundParams{
+		TenantID:            payment.TenantID,
+		ID:                  payment.ID,
+		RefundedAmountCents: int32(totalRefunded),
+		Status:              paymentStatus,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to update payment refund: %w", err)
+	}
+
+	return totalRefunded, nil
+}
+
+// enqueueRefundEmail queues the refund confirmation email.
+// Failures are not returned: the refund itself has already been issued.
+func (s *refundService) enqueueRefundEmail(ctx context.Context, order repository.Order, refundCents, totalRefunded int64) {
+	details, err := s.repo.GetOrderWithDetails(ctx, repository.GetOrderWithDetailsParams{
+		TenantID: order.TenantID,
+		ID:       order.ID,
+	})
+	if err != nil || !details.CustomerEmail.Valid || details.CustomerEmail.String == "" {
+		return
+	}
+
+	customerName := details.CustomerFirstName.String
+	if details.CustomerLastName.Valid && details.CustomerLastName.String != "" {
+		customerName += " " + details.CustomerLastName.String
+	}
+
+	payload := jobs.RefundConfirmationPayload{
+		OrderID:            uuid.UUID(order.ID.Bytes),
+		Email:              details.CustomerEmail.String,
+		CustomerName:       customerName,
+		OrderNumber:        order.OrderNumber,
+		RefundCents:        refundCents,
+		TotalRefundedCents: totalRefunded,
+		OrderTotalCents:    int64(order.TotalCents),
+		FullyRefunded:      totalRefunded >= int64(order.TotalCents),
+		RefundedAt:         time.Now(),
+	}
+
+	_ = jobs.EnqueueRefundConfirmationEmail(ctx, s.repo, uuid.UUID(order.TenantID.Bytes), payload)
+}
+
+// refundStatus normalizes a provider refund status for storage.
+func refundStatus(status string) string {
+	if status == "" {
+		return "succeeded"
+	}
+	return status
+}
@@ -0,0 +1,484 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/billing"
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestItemRefundAmount(t *testing.T) {
+	tests := []struct {
+		name        string
+		subtotal    int32
+		discount    int32
+		tax         int32
+		merchandise int64
+		want        int64
+	}{
+		{"no discount or tax", 4000, 0, 0, 1000, 1000},
+		{"includes share of tax", 4000, 0, 400, 1000, 1100},
+		{"less share of discount", 4000, 400, 0, 1000, 900},
+		{"discount and tax", 4000, 400, 360, 1000, 990},
+		{"zero subtotal", 0, 0, 0, 1000, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := repository.Order{SubtotalCents: tt.subtotal, DiscountCents: tt.discount, TaxCents: tt.tax}
+			assert.Equal(t, tt.want, itemRefundAmount(order, tt.merchandise))
+		})
+	}
+}
+
+// refundFixture is a paid order with two line items:
+// 2 x $10.00 and 1 x $20.00, $4.00 discount, $3.60 tax, $8.00 shipping.
+type refundFixture struct {
+	order    repository.Order
+	payment  repository.Payment
+	items    []repository.GetOrderItemsRow
+	provider *billing.MockProvider
+}
+
+func newRefundFixture(status string) refundFixture {
+	tenantID := newUUID()
+	payment := repository.Payment{
+		ID:                newUUID(),
+		TenantID:          tenantID,
+		Provider:          "stripe",
+		ProviderPaymentID: "pi_refund_test",
+		AmountCents:       4760,
+	}
+	order := repository.Order{
+		ID:            newUUID(),
+		TenantID:      tenantID,
+		OrderNumber:   "ORD-1001",
+		Status:        status,
+		SubtotalCents: 4000,
+		DiscountCents: 400,
+		TaxCents:      360,
+		ShippingCents: 800,
+		TotalCents:    4760,
+		PaymentID:     payment.ID,
+	}
+	items := []repository.GetOrderItemsRow{
+		{ID: newUUID(), ProductSkuID: newUUID(), Sku: "ETH-12", Quantity: 2, UnitPriceCents: 1000, TotalPriceCents: 2000},
+		{ID: newUUID(), ProductSkuID: newUUID(), Sku: "COL-32", Quantity: 1, UnitPriceCents: 2000, TotalPriceCents: 2000},
+	}
+
+	provider := billing.NewMockProvider()
+	provider.PaymentIntents[payment.ProviderPaymentID] = &billing.PaymentIntent{
+		ID:          payment.ProviderPaymentID,
+		AmountCents: 4760,
+		Status:      "succeeded",
+		Metadata:    map[string]string{"tenant_id": uuidToString(tenantID)},
+	}
+
+	return refundFixture{order: order, payment: payment, items: items, provider: provider}
+}
+
+func (f refundFixture) expectLoad(mockRepo *repository.MockQuerier, refundedCents int64) {
+	mockRepo.EXPECT().GetOrder(gomock.Any(), gomock.Any()).Return(f.order, nil)
+	mockRepo.EXPECT().GetPaymentByID(gomock.Any(), f.payment.ID).Return(f.payment, nil)
+	mockRepo.EXPECT().GetOrderRefundedTotal(gomock.Any(), gomock.Any()).Return(refundedCents, nil)
+}
+
+func TestRefundService_RefundOrder_Items(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := newRefundFixture("delivered")
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewRefundService(mockRepo, f.provider)
+
+	f.expectLoad(mockRepo, 0)
+	mockRepo.EXPECT().GetOrderItems(gomock.Any(), f.order.ID).Return(f.items, nil)
+	mockRepo.EXPECT().ListRefundedQuantitiesForOrder(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	var created repository.CreateRefundParams
+	refundID := newUUID()
+	mockRepo.EXPECT().CreateRefund(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.CreateRefundParams) (repository.Refund, error) {
+			created = arg
+			return repository.Refund{ID: refundID, OrderID: arg.OrderID, AmountCents: arg.AmountCents}, nil
+		})
+	mockRepo.EXPECT().CreateRefundItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.CreateRefundItemParams) (repository.RefundItem, error) {
+			assert.Equal(t, f.items[0].ID, arg.OrderItemID)
+			assert.Equal(t, int32(1), arg.Quantity)
+			return repository.RefundItem{}, nil
+		})
+	mockRepo.EXPECT().IncrementSKUStock(gomock.Any(), repository.IncrementSKUStockParams{
+		TenantID:          f.order.TenantID,
+		ID:                f.items[0].ProductSkuID,
+		InventoryQuantity: 1,
+		OrderID:           f.order.ID,
+		RefundID:          refundID,
+	}).Return(nil)
+
+	// Status sync after the refund is recorded
+	mockRepo.EXPECT().GetOrderRefundedTotal(gomock.Any(), gomock.Any()).Return(int64(990), nil)
+	mockRepo.EXPECT().UpdateOrderStatus(gomock.Any(), repository.UpdateOrderStatusParams{
+		TenantID: f.order.TenantID,
+		ID:       f.order.ID,
+		Status:   "partially_refunded",
+	}).Return(nil)
+	mockRepo.EXPECT().UpdatePaymentRefund(gomock.Any(), repository.UpdatePaymentRefundParams{
+		TenantID:            f.payment.TenantID,
+		ID:                  f.payment.ID,
+		RefundedAmountCents: 990,
+		Status:              "partially_refunded",
+	}).Return(nil)
+	mockRepo.EXPECT().GetOrderWithDetails(gomock.Any(), gomock.Any()).
+		Return(repository.GetOrderWithDetailsRow{}, pgx.ErrNoRows)
+
+	refund, err := svc.RefundOrder(contextWithTenant(f.order.TenantID), domain.RefundOrderParams{
+		OrderID: uuidToString(f.order.ID),
+		Mode:    domain.RefundModeItems,
+		Items:   []domain.RefundItemParams{{OrderItemID: uuidToString(f.items[0].ID), Quantity: 1}},
+		Reason:  "requested_by_customer",
+		Restock: true,
+	})
+
+	require.NoError(t, err)
+	// $10.00 of merchandise less 10% discount plus 10% tax
+	assert.Equal(t, int32(990), refund.AmountCents)
+	assert.Equal(t, RefundSourceAdmin, created.Source)
+	assert.True(t, created.Restocked)
+	assert.Contains(t, f.provider.CallLog, "RefundPayment(pi_refund_test, 990)")
+}
+
+func TestRefundService_RefundOrder_Validation(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        string
+		refundedCents int64
+		params        domain.RefundOrderParams
+		loadsItems    bool
+		wantErr       error
+	}{
+		{
+			name:    "pending order",
+			status:  "pending",
+			params:  domain.RefundOrderParams{Mode: domain.RefundModeFull},
+			wantErr: ErrOrderNotRefundable,
+		},
+		{
+			name:    "already refunded",
+			status:  "refunded",
+			params:  domain.RefundOrderParams{Mode: domain.RefundModeFull},
+			wantErr: ErrOrderNotRefundable,
+		},
+		{
+			name:          "amount exceeds balance",
+			status:        "partially_refunded",
+			refundedCents: 4000,
+			params:        domain.RefundOrderParams{Mode: domain.RefundModeAmount, AmountCents: 1000},
+			wantErr:       ErrRefundExceedsBalance,
+		},
+		{
+			name:    "zero amount",
+			status:  "paid",
+			params:  domain.RefundOrderParams{Mode: domain.RefundModeAmount},
+			wantErr: ErrInvalidRefundAmount,
+		},
+		{
+			name:    "unknown mode",
+			status:  "paid",
+			params:  domain.RefundOrderParams{Mode: "partial"},
+			wantErr: ErrInvalidRefundMode,
+		},
+		{
+			name:       "quantity exceeds ordered",
+			status:     "paid",
+			loadsItems: true,
+			wantErr:    ErrRefundQuantityInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := newRefundFixture(tt.status)
+			mockRepo := repository.NewMockQuerier(ctrl)
+			svc := NewRefundService(mockRepo, f.provider)
+
+			params := tt.params
+			if tt.loadsItems {
+				params = domain.RefundOrderParams{
+					Mode:  domain.RefundModeItems,
+					Items: []domain.RefundItemParams{{OrderItemID: uuidToString(f.items[1].ID), Quantity: 2}},
+				}
+				mockRepo.EXPECT().GetOrderItems(gomock.Any(), f.order.ID).Return(f.items, nil)
+				mockRepo.EXPECT().ListRefundedQuantitiesForOrder(gomock.Any(), gomock.Any()).Return(nil, nil)
+			}
+			params.OrderID = uuidToString(f.order.ID)
+
+			if tt.wantErr == ErrOrderNotRefundable {
+				mockRepo.EXPECT().GetOrder(gomock.Any(), gomock.Any()).Return(f.order, nil)
+			} else {
+				f.expectLoad(mockRepo, tt.refundedCents)
+			}
+
+			_, err := svc.RefundOrder(contextWithTenant(f.order.TenantID), params)
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got %v, want %v", err, tt.wantErr)
+			assert.Empty(t, f.provider.Refunds, "no refund should be issued")
+		})
+	}
+}
+
+func TestRefundService_CancelOrder_RefundsCardPayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := newRefundFixture("paid")
+	f.payment.Status = "succeeded"
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewRefundService(mockRepo, f.provider)
+
+	// Loaded once by CancelOrder and again by the full refund
+	mockRepo.EXPECT().GetOrder(gomock.Any(), gomock.Any()).Return(f.order, nil).Times(2)
+	mockRepo.EXPECT().GetShipmentsByOrderID(gomock.Any(), f.order.ID).
+		Return([]repository.Shipment{{Status: "cancelled"}}, nil)
+	mockRepo.EXPECT().GetPaymentByID(gomock.Any(), f.payment.ID).Return(f.payment, nil).Times(2)
+	mockRepo.EXPECT().GetOrderRefundedTotal(gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(2)
+	mockRepo.EXPECT().GetOrderItems(gomock.Any(), f.order.ID).Return(f.items, nil)
+	mockRepo.EXPECT().ListRefundedQuantitiesForOrder(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	var created repository.CreateRefundParams
+	mockRepo.EXPECT().CreateRefund(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.CreateRefundParams) (repository.Refund, error) {
+			created = arg
+			return repository.Refund{ID: newUUID(), OrderID: arg.OrderID, AmountCents: arg.AmountCents}, nil
+		})
+	mockRepo.EXPECT().CreateRefundItem(gomock.Any(), gomock.Any()).Return(repository.RefundItem{}, nil).Times(2)
+	mockRepo.EXPECT().IncrementSKUStock(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	mockRepo.EXPECT().GetOrderRefundedTotal(gomock.Any(), gomock.Any()).Return(int64(4760), nil)
+	mockRepo.EXPECT().UpdateOrderStatus(gomock.Any(), repository.UpdateOrderStatusParams{
+		TenantID: f.order.TenantID,
+		ID:       f.order.ID,
+		Status:   "refunded",
+	}).Return(nil)
+	mockRepo.EXPECT().UpdatePaymentRefund(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetOrderWithDetails(gomock.Any(), gomock.Any()).
+		Return(repository.GetOrderWithDetailsRow{}, pgx.ErrNoRows)
+
+	mockRepo.EXPECT().UpdateOrderStatus(gomock.Any(), repository.UpdateOrderStatusParams{
+		TenantID: f.order.TenantID,
+		ID:       f.order.ID,
+		Status:   "cancelled",
+	}).Return(nil)
+	mockRepo.EXPECT().UpdateOrderFulfillmentStatus(gomock.Any(), repository.UpdateOrderFulfillmentStatusParams{
+		TenantID:          f.order.TenantID,
+		ID:                f.order.ID,
+		FulfillmentStatus: "cancelled",
+	}).Return(nil)
+	mockRepo.EXPECT().SetOrderStatusChangeReason(gomock.Any(), repository.SetOrderStatusChangeReasonParams{
+		TenantID:     f.order.TenantID,
+		OrderID:      f.order.ID,
+		ToStatus:     "cancelled",
+		ChangeReason: pgtype.Text{String: "Customer changed their mind", Valid: true},
+	}).Return(nil)
+
+	err := svc.CancelOrder(contextWithTenant(f.order.TenantID), domain.CancelOrderParams{
+		OrderID: uuidToString(f.order.ID),
+		Reason:  " Customer changed their mind ",
+		Restock: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Order cancelled: Customer changed their mind", created.Note.String)
+	assert.True(t, created.Restocked)
+	assert.Contains(t, f.provider.CallLog, "RefundPayment(pi_refund_test, 4760)")
+}
+
+func TestRefundService_CancelOrder_OnAccountRestocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := newRefundFixture("processing")
+	f.order.PaymentID = pgtype.UUID{}
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewRefundService(mockRepo, f.provider)
+
+	mockRepo.EXPECT().GetOrder(gomock.Any(), gomock.Any()).Return(f.order, nil)
+	mockRepo.EXPECT().GetShipmentsByOrderID(gomock.Any(), f.order.ID).Return(nil, nil)
+	mockRepo.EXPECT().GetInvoiceForOrder(gomock.Any(), f.order.ID).
+		Return(repository.Invoice{Status: "void"}, nil)
+	mockRepo.EXPECT().GetOrderItems(gomock.Any(), f.order.ID).Return(f.items, nil)
+	mockRepo.EXPECT().ListRefundedQuantitiesForOrder(gomock.Any(), gomock.Any()).Return(nil, nil)
+	for _, item := range f.items {
+		mockRepo.EXPECT().RestockCancelledOrderItem(gomock.Any(), repository.RestockCancelledOrderItemParams{
+			TenantID:          f.order.TenantID,
+			ID:                item.ProductSkuID,
+			InventoryQuantity: item.Quantity,
+			OrderID:           f.order.ID,
+		}).Return(nil)
+	}
+	mockRepo.EXPECT().UpdateOrderStatus(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().UpdateOrderFulfillmentStatus(gomock.Any(), gomock.Any()).Return(nil)
+
+	err := svc.CancelOrder(contextWithTenant(f.order.TenantID), domain.CancelOrderParams{
+		OrderID: uuidToString(f.order.ID),
+		Restock: true,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, f.provider.Refunds)
+}
+
+func TestRefundService_CancelOrder_Validation(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    string
+		shipments []repository.Shipment
+		invoice   *repository.Invoice
+		wantErr   error
+	}{
+		{
+			name:    "shipped order",
+			status:  "shipped",
+			wantErr: ErrOrderNotCancellable,
+		},
+		{
+			name:    "already cancelled",
+			status:  "cancelled",
+			wantErr: ErrOrderNotCancellable,
+		},
+		{
+			name:      "label not voided",
+			status:    "paid",
+			shipments: []repository.Shipment{{Status: "label_created"}},
+			wantErr:   ErrOrderNotCancellable,
+		},
+		{
+			name:    "open invoice",
+			status:  "processing",
+			invoice: &repository.Invoice{Status: "sent"},
+			wantErr: ErrOrderInvoiced,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := newRefundFixture(tt.status)
+			mockRepo := repository.NewMockQuerier(ctrl)
+			svc := NewRefundService(mockRepo, f.provider)
+
+			if tt.invoice != nil {
+				// Orders on account have no card payment
+				f.order.PaymentID = pgtype.UUID{}
+				mockRepo.EXPECT().GetInvoiceForOrder(gomock.Any(), f.order.ID).Return(*tt.invoice, nil)
+			}
+			mockRepo.EXPECT().GetOrder(gomock.Any(), gomock.Any()).Return(f.order, nil)
+			if cancellableStatuses[tt.status] {
+				mockRepo.EXPECT().GetShipmentsByOrderID(gomock.Any(), f.order.ID).Return(tt.shipments, nil)
+			}
+
+			err := svc.CancelOrder(contextWithTenant(f.order.TenantID), domain.CancelOrderParams{
+				OrderID: uuidToString(f.order.ID),
+				Restock: true,
+			})
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got %v, want %v", err, tt.wantErr)
+			assert.Empty(t, f.provider.Refunds, "no refund should be issued")
+		})
+	}
+}
+
+func TestRefundService_RecordProviderRefunds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := newRefundFixture("paid")
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewRefundService(mockRepo, f.provider)
+
+	mockRepo.EXPECT().GetOrderByPaymentIntentID(gomock.Any(), repository.GetOrderByPaymentIntentIDParams{
+		TenantID:          f.order.TenantID,
+		ProviderPaymentID: f.payment.ProviderPaymentID,
+	}).Return(f.order, nil)
+	mockRepo.EXPECT().GetPaymentByID(gomock.Any(), f.payment.ID).Return(f.payment, nil)
+
+	// re_admin was already recorded by the admin refund flow
+	mockRepo.EXPECT().CreateRefund(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.CreateRefundParams) (repository.Refund, error) {
+			if arg.ProviderRefundID == "re_admin" {
+				assert.Equal(t, RefundSourceAdmin, arg.Source)
+				return repository.Refund{}, pgx.ErrNoRows
+			}
+			assert.Equal(t, "re_dashboard", arg.ProviderRefundID)
+			assert.Equal(t, RefundSourceProvider, arg.Source)
+			return repository.Refund{ID: newUUID(), AmountCents: arg.AmountCents}, nil
+		}).Times(2)
+	mockRepo.EXPECT().GetRefundByProviderID(gomock.Any(), repository.GetRefundByProviderIDParams{
+		TenantID:         f.order.TenantID,
+		Provider:         "stripe",
+		ProviderRefundID: "re_admin",
+	}).Return(repository.Refund{ID: newUUID(), AmountCents: 990}, nil)
+
+	mockRepo.EXPECT().GetOrderRefundedTotal(gomock.Any(), gomock.Any()).Return(int64(4760), nil)
+	mockRepo.EXPECT().UpdateOrderStatus(gomock.Any(), repository.UpdateOrderStatusParams{
+		TenantID: f.order.TenantID,
+		ID:       f.order.ID,
+		Status:   "refunded",
+	}).Return(nil)
+	mockRepo.EXPECT().UpdatePaymentRefund(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetOrderWithDetails(gomock.Any(), gomock.Any()).
+		Return(repository.GetOrderWithDetailsRow{}, pgx.ErrNoRows)
+
+	err := svc.RecordProviderRefunds(contextWithTenant(f.order.TenantID), domain.ProviderRefundParams{
+		TenantID:            f.order.TenantID,
+		PaymentIntentID:     f.payment.ProviderPaymentID,
+		ChargeID:            "ch_refund_test",
+		AmountRefundedCents: 4760,
+		Refunds: []domain.ProviderRefund{
+			{ID: "re_admin", AmountCents: 990, Status: "succeeded", Metadata: map[string]string{"source": "admin"}},
+			{ID: "re_dashboard", AmountCents: 3770, Status: "succeeded"},
+			{ID: "re_failed", AmountCents: 500, Status: "failed"},
+		},
+	})
+
+	require.NoError(t, err)
+}
+
+func TestRefundService_RecordProviderRefunds_AlreadyRecorded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := newRefundFixture("partially_refunded")
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewRefundService(mockRepo, f.provider)
+
+	mockRepo.EXPECT().GetOrderByPaymentIntentID(gomock.Any(), gomock.Any()).Return(f.order, nil)
+	mockRepo.EXPECT().GetPaymentByID(gomock.Any(), f.payment.ID).Return(f.payment, nil)
+	mockRepo.EXPECT().CreateRefund(gomock.Any(), gomock.Any()).Return(repository.Refund{}, pgx.ErrNoRows)
+	mockRepo.EXPECT().GetRefundByProviderID(gomock.Any(), gomock.Any()).Return(repository.Refund{ID: newUUID(), AmountCents: 990}, nil)
+
+	// No status update or email when nothing new was recorded
+	mockRepo.EXPECT().UpdateOrderStatus(gomock.Any(), gomock.Any()).Times(0)
+	mockRepo.EXPECT().GetOrderWithDetails(gomock.Any(), gomock.Any()).Times(0)
+
+	err := svc.RecordProviderRefunds(contextWithTenant(f.order.TenantID), domain.ProviderRefundParams{
+		TenantID:            f.order.TenantID,
+		PaymentIntentID:     f.payment.ProviderPaymentID,
+		AmountRefundedCents: 990,
+		Refunds:             []domain.ProviderRefund{{ID: "re_admin", AmountCents: 990, Status: "succeeded"}},
+	})
+
+	require.NoError(t, err)
+}
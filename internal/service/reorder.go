@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type reorderService struct {
+	repo        repository.Querier
+	cartService domain.CartService
+}
+
+// NewReorderService creates a new ReorderService instance. Reordered items
+// are added through the cart service, so they're priced from the customer's
+// price list just like items they add themselves.
+func NewReorderService(repo repository.Querier, cartService domain.CartService) domain.ReorderService {
+	return &reorderService{
+		repo:        repo,
+		cartService: cartService,
+	}
+}
+
+// Reorder adds the items from one of the customer's orders to the cart.
+func (s *reorderService) Reorder(ctx context.Context, userID, orderID, cartID string) (*domain.ReorderResult, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var userUUID, orderUUID pgtype.UUID
+	if err := userUUID.Scan(userID); err != nil {
+		return nil, ErrOrderNotFound
+	}
+	if err := orderUUID.Scan(orderID); err != nil {
+		return nil, ErrOrderNotFound
+	}
+
+	// Only the customer's own orders have items here
+	items, err := s.repo.ListReorderItems(ctx, repository.ListReorderItemsParams{
+		TenantID: tenantID,
+		ID:       orderUUID,
+		UserID:   userUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order items: %w", err)
+	}
+	if len(items) == 0 {
+		return nil, ErrOrderNotFound
+	}
+
+	result := &domain.ReorderResult{}
+	added := make(map[string]repository.ListReorderItemsRow, len(items))
+
+	for _, item := range items {
+		name := fmt.Sprintf("%s (%s)", item.ProductName, item.Sku)
+		if !item.Available {
+			result.Warnings = append(result.Warnings, name+" is no longer available")
+			continue
+		}
+
+		quantity := item.Quantity
+		if item.InventoryPolicy != "allow" && item.InventoryQuantity < quantity {
+			if item.InventoryQuantity <= 0 {
+				result.Warnings = append(result.Warnings, name+" is out of stock")
+				continue
+			}
+			quantity = item.InventoryQuantity
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("%s: only %d in stock, so %d of %d were added", name, quantity, quantity, item.Quantity))
+		}
+
+		skuID := uuidToString(item.ProductSkuID)
+		if _, err := s.cartService.AddItem(ctx, cartID, skuID, int(quantity)); err != nil {
+			// A SKU with no price can't be ordered any more
+			if domain.ErrorCode(err) == domain.ENOTFOUND {
+				result.Warnings = append(result.Warnings, name+" is no longer available")
+				continue
+			}
+			return nil, err
+		}
+
+		result.ItemsAdded++
+		added[skuID] = item
+	}
+
+	if result.ItemsAdded == 0 {
+		return result, nil
+	}
+
+	// Items are priced today, so point out what costs something different
+	summary, err := s.cartService.GetCartSummary(ctx, cartID)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range summary.Items {
+		item, ok := added[line.SKUID.String()]
+		if !ok || line.UnitPriceCents == item.UnitPriceCents {
+			continue
+		}
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%s (%s) is now $%.2f each, was $%.2f",
+			item.ProductName, item.Sku, float64(line.UnitPriceCents)/100, float64(item.UnitPriceCents)/100))
+	}
+
+	return result, nil
+}
+
+// ListTemplates returns a customer's order templates by name.
+func (s *reorderService) ListTemplates(ctx context.Context, userID string) ([]repository.ListOrderTemplatesForUserRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var userUUID pgtype.UUID
+	if err := userUUID.Scan(userID); err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	templates, err := s.repo.ListOrderTemplatesForUser(ctx, repository.ListOrderTemplatesForUserParams{
+		TenantID: tenantID,
+		UserID:   userUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// GetTemplate returns one of a customer's order templates with its quantities.
+func (s *reorderService) GetTemplate(ctx context.Context, userID, templateID string) (*domain.OrderTemplateDetail, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.getForCustomer(ctx, tenantID, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.detail(ctx, template)
+}
+
+// SaveTemplate saves the quantities under a name, replacing the quantities of
+// the customer's template with that name if they have one.
+func (s *reorderService) SaveTemplate(ctx context.Context, params domain.SaveOrderTemplateParams) (*domain.OrderTemplateDetail, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var userUUID pgtype.UUID
+	if err := userUUID.Scan(params.UserID); err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	name := strings.TrimSpace(params.Name)
+	if name == "" || len(name) > domain.MaxOrderTemplateNameLength {
+		return nil, ErrOrderTemplateName
+	}
+
+	skuIDs := make([]string, 0, len(params.Quantities))
+	for skuID, quantity := range params.Quantities {
+		if quantity < 0 {
+			return nil, ErrInvalidQuantity
+		}
+		if quantity > 0 {
+			skuIDs = append(skuIDs, skuID)
+		}
+	}
+	if len(skuIDs) == 0 {
+		return nil, ErrOrderTemplateEmpty
+	}
+	sort.Strings(skuIDs)
+
+	skuUUIDs := make([]pgtype.UUID, len(skuIDs))
+	for i, skuID := range skuIDs {
+		if err := skuUUIDs[i].Scan(skuID); err != nil {
+			return nil, fmt.Errorf("invalid SKU ID: %w", err)
+		}
+	}
+
+	template, err := s.repo.UpsertOrderTemplate(ctx, repository.UpsertOrderTemplateParams{
+		TenantID: tenantID,
+		UserID:   userUUID,
+		Name:     name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save order template: %w", err)
+	}
+
+	err = s.repo.DeleteOrderTemplateItems(ctx, repository.DeleteOrderTemplateItemsParams{
+		TenantID:        tenantID,
+		OrderTemplateID: template.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear order template items: %w", err)
+	}
+
+	for i, skuID := range skuIDs {
+		err := s.repo.CreateOrderTemplateItem(ctx, repository.CreateOrderTemplateItemParams{
+			TenantID:        tenantID,
+			OrderTemplateID: template.ID,
+			ProductSkuID:    skuUUIDs[i],
+			Quantity:        int32(params.Quantities[skuID]),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to save order template item: %w", err)
+		}
+	}
+
+	return s.detail(ctx, template)
+}
+
+// DeleteTemplate deletes one of a customer's order templates.
+func (s *reorderService) DeleteTemplate(ctx context.Context, userID, templateID string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	template, err := s.getForCustomer(ctx, tenantID, userID, templateID)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.DeleteOrderTemplate(ctx, repository.DeleteOrderTemplateParams{
+		ID:       template.ID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete order template: %w", err)
+	}
+
+	return nil
+}
+
+// getForCustomer loads an order template, validating it belongs to the customer.
+func (s *reorderService) getForCustomer(ctx context.Context, tenantID pgtype.UUID, userID, templateID string) (repository.OrderTemplate, error) {
+	var userUUID, templateUUID pgtype.UUID
+	if err := userUUID.Scan(userID); err != nil {
+		return repository.OrderTemplate{}, ErrOrderTemplateNotFound
+	}
+	if err := templateUUID.Scan(templateID); err != nil {
+		return repository.OrderTemplate{}, ErrOrderTemplateNotFound
+	}
+
+	template, err := s.repo.GetOrderTemplateForUser(ctx, repository.GetOrderTemplateForUserParams{
+		ID:       templateUUID,
+		TenantID: tenantID,
+		UserID:   userUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.OrderTemplate{}, ErrOrderTemplateNotFound
+		}
+		return repository.OrderTemplate{}, fmt.Errorf("failed to get order template: %w", err)
+	}
+
+	return template, nil
+}
+
+// detail loads an order template's quantities.
+func (s *reorderService) detail(ctx context.Context, template repository.OrderTemplate) (*domain.OrderTemplateDetail, error) {
+	items, err := s.repo.ListOrderTemplateItems(ctx, repository.ListOrderTemplateItemsParams{
+		TenantID:        template.TenantID,
+		OrderTemplateID: template.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order template items: %w", err)
+	}
+
+	quantities := make(map[string]int32, len(items))
+	for _, item := range items {
+		quantities[uuidToString(item.ProductSkuID)] = item.Quantity
+	}
+
+	return &domain.OrderTemplateDetail{
+		OrderTemplate: template,
+		Quantities:    quantities,
+	}, nil
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// pricedCartService records the items added to a cart and prices them from
+// a fixed price list.
+type pricedCartService struct {
+	domain.CartService
+	prices map[string]int32
+	added  map[string]int
+}
+
+func (s *pricedCartService) AddItem(_ context.Context, _, skuID string, quantity int) (*domain.CartSummary, error) {
+	if _, ok := s.prices[skuID]; !ok {
+		return nil, domain.ErrPriceNotFound
+	}
+	if s.added == nil {
+		s.added = make(map[string]int)
+	}
+	s.added[skuID] += quantity
+	return &domain.CartSummary{}, nil
+}
+
+func (s *pricedCartService) GetCartSummary(_ context.Context, _ string) (*domain.CartSummary, error) {
+	summary := &domain.CartSummary{}
+	for skuID, quantity := range s.added {
+		var id pgtype.UUID
+		if err := id.Scan(skuID); err != nil {
+			return nil, err
+		}
+		summary.Items = append(summary.Items, domain.CartItem{
+			SKUID:          id,
+			Quantity:       int32(quantity),
+			UnitPriceCents: s.prices[skuID],
+		})
+	}
+	return summary, nil
+}
+
+func TestReorderService_Reorder_AddsAvailableItemsWithWarnings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	userID := newUUID()
+	orderID := newUUID()
+
+	item := func(name string, quantity, priceCents int32, available bool, stock int32, policy string) repository.ListReorderItemsRow {
+		return repository.ListReorderItemsRow{
+			ProductSkuID:      newUUID(),
+			ProductName:       name,
+			Sku:               name + "-5LB",
+			Quantity:          quantity,
+			UnitPriceCents:    priceCents,
+			Available:         available,
+			InventoryQuantity: stock,
+			InventoryPolicy:   policy,
+		}
+	}
+	espresso := item("Espresso", 6, 5000, true, 100, "deny")
+	repriced := item("Decaf", 2, 5200, true, 100, "deny")
+	retired := item("Holiday", 4, 4800, false, 100, "deny")
+	soldOut := item("Kenya", 2, 6000, true, 0, "deny")
+	lowStock := item("Colombia", 10, 4500, true, 3, "deny")
+	backorder := item("Brazil", 5, 4000, true, 0, "allow")
+	unpriced := item("Sumatra", 1, 4600, true, 100, "deny")
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	cartService := &pricedCartService{prices: map[string]int32{
+		uuidToString(espresso.ProductSkuID):  5000,
+		uuidToString(repriced.ProductSkuID):  5500,
+		uuidToString(retired.ProductSkuID):   4800,
+		uuidToString(soldOut.ProductSkuID):   6000,
+		uuidToString(lowStock.ProductSkuID):  4500,
+		uuidToString(backorder.ProductSkuID): 4000,
+	}}
+	svc := NewReorderService(mockRepo, cartService)
+
+	mockRepo.EXPECT().
+		ListReorderItems(gomock.Any(), repository.ListReorderItemsParams{
+			TenantID: tenantID,
+			ID:       orderID,
+			UserID:   userID,
+		}).
+		Return([]repository.ListReorderItemsRow{espresso, repriced, retired, soldOut, lowStock, backorder, unpriced}, nil)
+
+	result, err := svc.Reorder(contextWithTenant(tenantID), uuidToString(userID), uuidToString(orderID), uuidToString(newUUID()))
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, result.ItemsAdded)
+	assert.Equal(t, map[string]int{
+		uuidToString(espresso.ProductSkuID):  6,
+		uuidToString(repriced.ProductSkuID):  2,
+		uuidToString(lowStock.ProductSkuID):  3,
+		uuidToString(backorder.ProductSkuID): 5,
+	}, cartService.added)
+	assert.ElementsMatch(t, []string{
+		"Holiday (Holiday-5LB) is no longer available",
+		"Kenya (Kenya-5LB) is out of stock",
+		"Colombia (Colombia-5LB): only 3 in stock, so 3 of 10 were added",
+		"Sumatra (Sumatra-5LB) is no longer available",
+		"Decaf (Decaf-5LB) is now $55.00 each, was $52.00",
+	}, result.Warnings)
+}
+
+func TestReorderService_Reorder_OtherCustomersOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	cartService := &pricedCartService{}
+	svc := NewReorderService(mockRepo, cartService)
+
+	// The order isn't the customer's, so none of its items are returned
+	mockRepo.EXPECT().ListReorderItems(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	_, err := svc.Reorder(contextWithTenant(newUUID()), uuidToString(newUUID()), uuidToString(newUUID()), uuidToString(newUUID()))
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+	assert.Empty(t, cartService.added)
+}
+
+func TestReorderService_SaveTemplate_ReplacesItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	userID := newUUID()
+	skuA := newUUID()
+	skuB := newUUID()
+	template := repository.OrderTemplate{ID: newUUID(), TenantID: tenantID, UserID: userID, Name: "Monday"}
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewReorderService(mockRepo, &pricedCartService{})
+
+	mockRepo.EXPECT().
+		UpsertOrderTemplate(gomock.Any(), repository.UpsertOrderTemplateParams{
+			TenantID: tenantID,
+			UserID:   userID,
+			Name:     "Monday",
+		}).
+		Return(template, nil)
+	mockRepo.EXPECT().
+		DeleteOrderTemplateItems(gomock.Any(), repository.DeleteOrderTemplateItemsParams{
+			TenantID:        tenantID,
+			OrderTemplateID: template.ID,
+		}).
+		Return(nil)
+	mockRepo.EXPECT().
+		CreateOrderTemplateItem(gomock.Any(), repository.CreateOrderTemplateItemParams{
+			TenantID:        tenantID,
+			OrderTemplateID: template.ID,
+			ProductSkuID:    skuA,
+			Quantity:        12,
+		}).
+		Return(nil)
+	mockRepo.EXPECT().
+		ListOrderTemplateItems(gomock.Any(), repository.ListOrderTemplateItemsParams{
+			TenantID:        tenantID,
+			OrderTemplateID: template.ID,
+		}).
+		Return([]repository.OrderTemplateItem{{ProductSkuID: skuA, Quantity: 12}}, nil)
+
+	detail, err := svc.SaveTemplate(contextWithTenant(tenantID), domain.SaveOrderTemplateParams{
+		UserID: uuidToString(userID),
+		Name:   "  Monday ",
+		Quantities: map[string]int{
+			uuidToString(skuA): 12,
+			uuidToString(skuB): 0,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Monday", detail.Name)
+	assert.Equal(t, map[string]int32{uuidToString(skuA): 12}, detail.Quantities)
+}
+
+func TestReorderService_SaveTemplate_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewReorderService(mockRepo, &pricedCartService{})
+	ctx := contextWithTenant(newUUID())
+	userID := uuidToString(newUUID())
+	skuID := uuidToString(newUUID())
+
+	tests := []struct {
+		name    string
+		params  domain.SaveOrderTemplateParams
+		wantErr error
+	}{
+		{
+			name:    "blank name",
+			params:  domain.SaveOrderTemplateParams{UserID: userID, Name: "  ", Quantities: map[string]int{skuID: 1}},
+			wantErr: ErrOrderTemplateName,
+		},
+		{
+			name:    "no quantities",
+			params:  domain.SaveOrderTemplateParams{UserID: userID, Name: "Monday", Quantities: map[string]int{skuID: 0}},
+			wantErr: ErrOrderTemplateEmpty,
+		},
+		{
+			name:    "negative quantity",
+			params:  domain.SaveOrderTemplateParams{UserID: userID, Name: "Monday", Quantities: map[string]int{skuID: -1}},
+			wantErr: ErrInvalidQuantity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.SaveTemplate(ctx, tt.params)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
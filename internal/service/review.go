@@ -0,0 +1,471 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// reviewRequestWindow is how long after the request delay an order can
+// still be sent a review request, so enabling the emails doesn't email
+// every customer who ever received an order.
+const reviewRequestWindow = 30 * 24 * time.Hour
+
+// maxReviewTitleLength matches the product_reviews.title column.
+const maxReviewTitleLength = 255
+
+type reviewService struct {
+	repo             repository.Querier
+	baseURL          string
+	requestDelayDays int
+}
+
+// NewReviewService creates a new ReviewService instance. Review request
+// emails go out requestDelayDays after delivery; 0 disables them.
+func NewReviewService(repo repository.Querier, baseURL string, requestDelayDays int) domain.ReviewService {
+	return &reviewService{
+		repo:             repo,
+		baseURL:          baseURL,
+		requestDelayDays: requestDelayDays,
+	}
+}
+
+// ListReviewableItems returns the reviewable products in a customer's
+// delivered orders.
+func (s *reviewService) ListReviewableItems(ctx context.Context, userID string, orderIDs []string) ([]repository.ListReviewableItemsForOrdersRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var userUUID pgtype.UUID
+	if err := userUUID.Scan(userID); err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	ids := parseUUIDList(orderIDs)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	items, err := s.repo.ListReviewableItemsForOrders(ctx, repository.ListReviewableItemsForOrdersParams{
+		TenantID: tenantID,
+		UserID:   userUUID,
+		OrderIds: ids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviewable items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetReviewableItem returns a product from a customer's delivered order.
+func (s *reviewService) GetReviewableItem(ctx context.Context, userID, orderID, productID string) (*repository.GetReviewableOrderProductRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.getReviewableItem(ctx, tenantID, userID, orderID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// SubmitReview creates a verified purchase review awaiting moderation.
+func (s *reviewService) SubmitReview(ctx context.Context, params domain.SubmitReviewParams) (*repository.ProductReview, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Rating < 1 || params.Rating > 5 {
+		return nil, ErrInvalidRating
+	}
+	title := strings.TrimSpace(params.Title)
+	if len(title) > maxReviewTitleLength {
+		return nil, ErrReviewTitleTooLong
+	}
+
+	item, err := s.getReviewableItem(ctx, tenantID, params.UserID, params.OrderID, params.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	var userUUID pgtype.UUID
+	if err := userUUID.Scan(params.UserID); err != nil {
+		return nil, ErrProductNotReviewable
+	}
+
+	_, err = s.repo.GetProductReviewForUser(ctx, repository.GetProductReviewForUserParams{
+		TenantID:  tenantID,
+		ProductID: item.ProductID,
+		UserID:    userUUID,
+	})
+	if err == nil {
+		return nil, ErrAlreadyReviewed
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check for existing review: %w", err)
+	}
+
+	review, err := s.repo.CreateProductReview(ctx, repository.CreateProductReviewParams{
+		TenantID:           tenantID,
+		ProductID:          item.ProductID,
+		UserID:             userUUID,
+		OrderID:            item.OrderID,
+		Rating:             params.Rating,
+		Title:              makePgText(title),
+		ReviewText:         makePgText(strings.TrimSpace(params.Body)),
+		IsVerifiedPurchase: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create review: %w", err)
+	}
+
+	return &review, nil
+}
+
+// ListReviewsForModeration returns the reviews with a moderation status.
+func (s *reviewService) ListReviewsForModeration(ctx context.Context, status string, limit, offset int32) ([]repository.ListReviewsForModerationRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.repo.ListReviewsForModeration(ctx, repository.ListReviewsForModerationParams{
+		TenantID: tenantID,
+		Status:   status,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// CountReviewsByStatus returns the number of reviews in each moderation
+// status.
+func (s *reviewService) CountReviewsByStatus(ctx context.Context) (map[string]int32, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.CountReviewsByStatus(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reviews: %w", err)
+	}
+
+	counts := make(map[string]int32, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.ReviewCount
+	}
+
+	return counts, nil
+}
+
+// ApproveReview publishes a review on the product page.
+func (s *reviewService) ApproveReview(ctx context.Context, reviewID, notes string) (*repository.ProductReview, error) {
+	return s.moderate(ctx, reviewID, domain.ReviewStatusApproved, notes)
+}
+
+// RejectReview hides a review from the product page.
+func (s *reviewService) RejectReview(ctx context.Context, reviewID, notes string) (*repository.ProductReview, error) {
+	return s.moderate(ctx, reviewID, domain.ReviewStatusRejected, notes)
+}
+
+// ReplyToReview sets or removes the public reply shown under a review.
+func (s *reviewService) ReplyToReview(ctx context.Context, reviewID, reply string) (*repository.ProductReview, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviewUUID pgtype.UUID
+	if err := reviewUUID.Scan(reviewID); err != nil {
+		return nil, ErrReviewNotFound
+	}
+
+	review, err := s.repo.ReplyToProductReview(ctx, repository.ReplyToProductReviewParams{
+		TenantID:  tenantID,
+		ID:        reviewUUID,
+		ReplyText: makePgText(strings.TrimSpace(reply)),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to reply to review: %w", err)
+	}
+
+	return &review, nil
+}
+
+// GetProductRatings returns the rating summary and approved reviews of a
+// product.
+func (s *reviewService) GetProductRatings(ctx context.Context, productID string, limit int32) (*domain.ProductRatings, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var productUUID pgtype.UUID
+	if err := productUUID.Scan(productID); err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	summary, err := s.repo.GetProductRatingSummary(ctx, repository.GetProductRatingSummaryParams{
+		TenantID:  tenantID,
+		ProductID: productUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating summary: %w", err)
+	}
+
+	ratings := &domain.ProductRatings{Summary: summary}
+	if summary.ReviewCount == 0 {
+		return ratings, nil
+	}
+
+	ratings.Reviews, err = s.repo.ListApprovedReviewsForProduct(ctx, repository.ListApprovedReviewsForProductParams{
+		TenantID:  tenantID,
+		ProductID: productUUID,
+		Limit:     limit,
+		Offset:    0,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	return ratings, nil
+}
+
+// VoteHelpful records whether a customer found an approved review helpful
+// and returns the review with its updated vote counts.
+func (s *reviewService) VoteHelpful(ctx context.Context, userID, reviewID string, helpful bool) (*repository.ProductReview, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var userUUID pgtype.UUID
+	if err := userUUID.Scan(userID); err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	review, err := s.getReview(ctx, tenantID, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	// Unpublished reviews can't be seen, so they can't be voted on
+	if review.Status != domain.ReviewStatusApproved {
+		return nil, ErrReviewNotFound
+	}
+	if review.UserID == userUUID {
+		return nil, ErrCannotVoteOwnReview
+	}
+
+	err = s.repo.UpsertReviewHelpfulness(ctx, repository.UpsertReviewHelpfulnessParams{
+		TenantID:  tenantID,
+		ReviewID:  review.ID,
+		UserID:    userUUID,
+		IsHelpful: helpful,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record vote: %w", err)
+	}
+
+	err = s.repo.RefreshReviewHelpfulCounts(ctx, repository.RefreshReviewHelpfulCountsParams{
+		TenantID: tenantID,
+		ID:       review.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update vote counts: %w", err)
+	}
+
+	updated, err := s.repo.GetProductReview(ctx, repository.GetProductReviewParams{
+		TenantID: tenantID,
+		ID:       review.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// SendReviewRequests enqueues a review request email for each retail order
+// delivered requestDelayDays ago.
+func (s *reviewService) SendReviewRequests(ctx context.Context) (int, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if s.requestDelayDays <= 0 {
+		return 0, nil
+	}
+
+	deliveredBefore := time.Now().AddDate(0, 0, -s.requestDelayDays)
+	orders, err := s.repo.ListOrdersDueForReviewRequest(ctx, repository.ListOrdersDueForReviewRequestParams{
+		TenantID:        tenantID,
+		DeliveredBefore: pgtype.Timestamptz{Time: deliveredBefore, Valid: true},
+		DeliveredAfter:  pgtype.Timestamptz{Time: deliveredBefore.Add(-reviewRequestWindow), Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list delivered orders: %w", err)
+	}
+
+	sent := 0
+	for _, order := range orders {
+		items, err := s.repo.ListReviewableItemsForOrders(ctx, repository.ListReviewableItemsForOrdersParams{
+			TenantID: tenantID,
+			UserID:   order.UserID,
+			OrderIds: []pgtype.UUID{order.ID},
+		})
+		if err != nil {
+			return sent, fmt.Errorf("failed to list order products: %w", err)
+		}
+
+		// Record the request first so an order is never emailed twice, even
+		// if every product in it has already been reviewed
+		recorded, err := s.repo.CreateReviewRequest(ctx, repository.CreateReviewRequestParams{
+			TenantID: tenantID,
+			OrderID:  order.ID,
+		})
+		if err != nil {
+			return sent, fmt.Errorf("failed to record review request: %w", err)
+		}
+		if recorded == 0 {
+			continue
+		}
+
+		var requestItems []jobs.ReviewRequestItemData
+		for _, item := range items {
+			if item.ReviewID.Valid {
+				continue
+			}
+			requestItems = append(requestItems, jobs.ReviewRequestItemData{
+				ProductName: item.ProductName,
+				ReviewURL:   s.reviewURL(order.ID, item.ProductID),
+			})
+		}
+		if len(requestItems) == 0 {
+			continue
+		}
+
+		customerName := order.Email
+		if order.FirstName.Valid {
+			customerName = order.FirstName.String
+		}
+
+		payload := jobs.ReviewRequestPayload{
+			Email:        order.Email,
+			CustomerName: customerName,
+			OrderNumber:  order.OrderNumber,
+			Items:        requestItems,
+		}
+		if err := jobs.EnqueueReviewRequestEmail(ctx, s.repo, uuid.UUID(tenantID.Bytes), payload); err != nil {
+			return sent, fmt.Errorf("failed to enqueue review request: %w", err)
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// reviewURL links to the review form for a product in an order.
+func (s *reviewService) reviewURL(orderID, productID pgtype.UUID) string {
+	query := url.Values{}
+	query.Set("order_id", uuidToString(orderID))
+	query.Set("product_id", uuidToString(productID))
+	return s.baseURL + "/account/reviews/new?" + query.Encode()
+}
+
+// moderate sets a review's moderation status.
+func (s *reviewService) moderate(ctx context.Context, reviewID, status, notes string) (*repository.ProductReview, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviewUUID pgtype.UUID
+	if err := reviewUUID.Scan(reviewID); err != nil {
+		return nil, ErrReviewNotFound
+	}
+
+	review, err := s.repo.ModerateProductReview(ctx, repository.ModerateProductReviewParams{
+		TenantID:        tenantID,
+		ID:              reviewUUID,
+		Status:          status,
+		ModerationNotes: makePgText(strings.TrimSpace(notes)),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to moderate review: %w", err)
+	}
+
+	return &review, nil
+}
+
+// getReview loads a tenant's review by ID.
+func (s *reviewService) getReview(ctx context.Context, tenantID pgtype.UUID, reviewID string) (repository.ProductReview, error) {
+	var reviewUUID pgtype.UUID
+	if err := reviewUUID.Scan(reviewID); err != nil {
+		return repository.ProductReview{}, ErrReviewNotFound
+	}
+
+	review, err := s.repo.GetProductReview(ctx, repository.GetProductReviewParams{
+		TenantID: tenantID,
+		ID:       reviewUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ProductReview{}, ErrReviewNotFound
+		}
+		return repository.ProductReview{}, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	return review, nil
+}
+
+// getReviewableItem loads a product from a customer's delivered order.
+func (s *reviewService) getReviewableItem(ctx context.Context, tenantID pgtype.UUID, userID, orderID, productID string) (repository.GetReviewableOrderProductRow, error) {
+	var userUUID, orderUUID, productUUID pgtype.UUID
+	if userUUID.Scan(userID) != nil || orderUUID.Scan(orderID) != nil || productUUID.Scan(productID) != nil {
+		return repository.GetReviewableOrderProductRow{}, ErrProductNotReviewable
+	}
+
+	item, err := s.repo.GetReviewableOrderProduct(ctx, repository.GetReviewableOrderProductParams{
+		TenantID:  tenantID,
+		UserID:    userUUID,
+		OrderID:   orderUUID,
+		ProductID: productUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.GetReviewableOrderProductRow{}, ErrProductNotReviewable
+		}
+		return repository.GetReviewableOrderProductRow{}, fmt.Errorf("failed to get order product: %w", err)
+	}
+
+	return item, nil
+}
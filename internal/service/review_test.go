@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestReviewService(t *testing.T, requestDelayDays int) (domain.ReviewService, *repository.MockQuerier) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	return NewReviewService(mockRepo, "https://example.com", requestDelayDays), mockRepo
+}
+
+func TestReviewService_SubmitReview(t *testing.T) {
+	svc, mockRepo := newTestReviewService(t, 7)
+	tenantID := newUUID()
+	userID, orderID, productID := newUUID(), newUUID(), newUUID()
+
+	mockRepo.EXPECT().GetReviewableOrderProduct(gomock.Any(), repository.GetReviewableOrderProductParams{
+		TenantID:  tenantID,
+		UserID:    userID,
+		OrderID:   orderID,
+		ProductID: productID,
+	}).Return(repository.GetReviewableOrderProductRow{OrderID: orderID, ProductID: productID}, nil)
+	mockRepo.EXPECT().GetProductReviewForUser(gomock.Any(), gomock.Any()).
+		Return(repository.ProductReview{}, pgx.ErrNoRows)
+	mockRepo.EXPECT().CreateProductReview(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg repository.CreateProductReviewParams) (repository.ProductReview, error) {
+			assert.Equal(t, int32(4), arg.Rating)
+			assert.Equal(t, "Bright and juicy", arg.Title.String)
+			assert.False(t, arg.ReviewText.Valid)
+			assert.Equal(t, orderID, arg.OrderID)
+			assert.True(t, arg.IsVerifiedPurchase)
+			return repository.ProductReview{ID: newUUID(), Status: domain.ReviewStatusPending}, nil
+		})
+
+	review, err := svc.SubmitReview(contextWithTenant(tenantID), domain.SubmitReviewParams{
+		UserID:    uuidToString(userID),
+		OrderID:   uuidToString(orderID),
+		ProductID: uuidToString(productID),
+		Rating:    4,
+		Title:     " Bright and juicy ",
+		Body:      "  ",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusPending, review.Status)
+}
+
+func TestReviewService_SubmitReview_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  domain.SubmitReviewParams
+		wantErr error
+	}{
+		{name: "no rating", params: domain.SubmitReviewParams{Rating: 0}, wantErr: ErrInvalidRating},
+		{name: "rating too high", params: domain.SubmitReviewParams{Rating: 6}, wantErr: ErrInvalidRating},
+		{name: "title too long", params: domain.SubmitReviewParams{Rating: 5, Title: strings.Repeat("a", 256)}, wantErr: ErrReviewTitleTooLong},
+		{name: "invalid order", params: domain.SubmitReviewParams{Rating: 5, OrderID: "not-a-uuid"}, wantErr: ErrProductNotReviewable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// No repository calls are expected
+			svc, _ := newTestReviewService(t, 7)
+
+			_, err := svc.SubmitReview(contextWithTenant(newUUID()), tt.params)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReviewService_SubmitReview_NotDelivered(t *testing.T) {
+	svc, mockRepo := newTestReviewService(t, 7)
+
+	// The query only matches delivered orders belonging to the customer
+	mockRepo.EXPECT().GetReviewableOrderProduct(gomock.Any(), gomock.Any()).
+		Return(repository.GetReviewableOrderProductRow{}, pgx.ErrNoRows)
+
+	_, err := svc.SubmitReview(contextWithTenant(newUUID()), domain.SubmitReviewParams{
+		UserID:    uuidToString(newUUID()),
+		OrderID:   uuidToString(newUUID()),
+		ProductID: uuidToString(newUUID()),
+		Rating:    5,
+	})
+	assert.ErrorIs(t, err, ErrProductNotReviewable)
+}
+
+func TestReviewService_SubmitReview_AlreadyReviewed(t *testing.T) {
+	svc, mockRepo := newTestReviewService(t, 7)
+
+	mockRepo.EXPECT().GetReviewableOrderProduct(gomock.Any(), gomock.Any()).
+		Return(repository.GetReviewableOrderProductRow{OrderID: newUUID(), ProductID: newUUID()}, nil)
+	mockRepo.EXPECT().GetProductReviewForUser(gomock.Any(), gomock.Any()).
+		Return(repository.ProductReview{ID: newUUID()}, nil)
+
+	_, err := svc.SubmitReview(contextWithTenant(newUUID()), domain.SubmitReviewParams{
+		UserID:    uuidToString(newUUID()),
+		OrderID:   uuidToString(newUUID()),
+		ProductID: uuidToString(newUUID()),
+		Rating:    5,
+	})
+	assert.ErrorIs(t, err, ErrAlreadyReviewed)
+}
+
+func TestReviewService_VoteHelpful(t *testing.T) {
+	svc, mockRepo := newTestReviewService(t, 7)
+	tenantID := newUUID()
+	reviewID, voterID := newUUID(), newUUID()
+
+	mockRepo.EXPECT().GetProductReview(gomock.Any(), repository.GetProductReviewParams{TenantID: tenantID, ID: reviewID}).
+		Return(repository.ProductReview{ID: reviewID, UserID: newUUID(), Status: domain.ReviewStatusApproved}, nil)
+	gomock.InOrder(
+		mockRepo.EXPECT().UpsertReviewHelpfulness(gomock.Any(), repository.UpsertReviewHelpfulnessParams{
+			TenantID:  tenantID,
+			ReviewID:  reviewID,
+			UserID:    voterID,
+			IsHelpful: true,
+		}).Return(nil),
+		mockRepo.EXPECT().RefreshReviewHelpfulCounts(gomock.Any(), repository.RefreshReviewHelpfulCountsParams{
+			TenantID: tenantID,
+			ID:       reviewID,
+		}).Return(nil),
+		mockRepo.EXPECT().GetProductReview(gomock.Any(), gomock.Any()).
+			Return(repository.ProductReview{ID: reviewID, HelpfulCount: 3}, nil),
+	)
+
+	review, err := svc.VoteHelpful(contextWithTenant(tenantID), uuidToString(voterID), uuidToString(reviewID), true)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), review.HelpfulCount)
+}
+
+func TestReviewService_VoteHelpful_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		ownerIs string
+		wantErr error
+	}{
+		{name: "own review", status: domain.ReviewStatusApproved, ownerIs: "voter", wantErr: ErrCannotVoteOwnReview},
+		{name: "unpublished review", status: domain.ReviewStatusPending, wantErr: ErrReviewNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockRepo := newTestReviewService(t, 7)
+			voterID := newUUID()
+			ownerID := newUUID()
+			if tt.ownerIs == "voter" {
+				ownerID = voterID
+			}
+
+			mockRepo.EXPECT().GetProductReview(gomock.Any(), gomock.Any()).
+				Return(repository.ProductReview{ID: newUUID(), UserID: ownerID, Status: tt.status}, nil)
+			mockRepo.EXPECT().UpsertReviewHelpfulness(gomock.Any(), gomock.Any()).Times(0)
+
+			_, err := svc.VoteHelpful(contextWithTenant(newUUID()), uuidToString(voterID), uuidToString(newUUID()), true)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReviewService_SendReviewRequests(t *testing.T) {
+	svc, mockRepo := newTestReviewService(t, 7)
+	tenantID := newUUID()
+
+	sentOrder := repository.ListOrdersDueForReviewRequestRow{
+		ID:          newUUID(),
+		UserID:      newUUID(),
+		OrderNumber: "ORD-1001",
+		Email:       "jane@example.com",
+		FirstName:   pgtype.Text{String: "Jane", Valid: true},
+	}
+	reviewedOrder := repository.ListOrdersDueForReviewRequestRow{
+		ID:          newUUID(),
+		UserID:      newUUID(),
+		OrderNumber: "ORD-1002",
+		Email:       "sam@example.com",
+	}
+	espresso, guji := newUUID(), newUUID()
+
+	mockRepo.EXPECT().ListOrdersDueForReviewRequest(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg repository.ListOrdersDueForReviewRequestParams) ([]repository.ListOrdersDueForReviewRequestRow, error) {
+			assert.True(t, arg.DeliveredAfter.Time.Before(arg.DeliveredBefore.Time))
+			return []repository.ListOrdersDueForReviewRequestRow{sentOrder, reviewedOrder}, nil
+		})
+
+	mockRepo.EXPECT().ListReviewableItemsForOrders(gomock.Any(), repository.ListReviewableItemsForOrdersParams{
+		TenantID: tenantID,
+		UserID:   sentOrder.UserID,
+		OrderIds: []pgtype.UUID{sentOrder.ID},
+	}).Return([]repository.ListReviewableItemsForOrdersRow{
+		{OrderID: sentOrder.ID, ProductID: espresso, ProductName: "House Espresso"},
+		{OrderID: sentOrder.ID, ProductID: guji, ProductName: "Ethiopia Guji", ReviewID: newUUID()},
+	}, nil)
+	mockRepo.EXPECT().CreateReviewRequest(gomock.Any(), repository.CreateReviewRequestParams{
+		TenantID: tenantID,
+		OrderID:  sentOrder.ID,
+	}).Return(int64(1), nil)
+
+	// Every product in this order is already reviewed, so it is recorded
+	// but not emailed
+	mockRepo.EXPECT().ListReviewableItemsForOrders(gomock.Any(), repository.ListReviewableItemsForOrdersParams{
+		TenantID: tenantID,
+		UserID:   reviewedOrder.UserID,
+		OrderIds: []pgtype.UUID{reviewedOrder.ID},
+	}).Return([]repository.ListReviewableItemsForOrdersRow{
+		{OrderID: reviewedOrder.ID, ProductID: espresso, ProductName: "House Espresso", ReviewID: newUUID()},
+	}, nil)
+	mockRepo.EXPECT().CreateReviewRequest(gomock.Any(), repository.CreateReviewRequestParams{
+		TenantID: tenantID,
+		OrderID:  reviewedOrder.ID,
+	}).Return(int64(1), nil)
+
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.EnqueueJobParams) (repository.Job, error) {
+			assert.Equal(t, jobs.JobTypeReviewRequest, arg.JobType)
+
+			var payload jobs.ReviewRequestPayload
+			require.NoError(t, json.Unmarshal(arg.Payload, &payload))
+			assert.Equal(t, "jane@example.com", payload.Email)
+			assert.Equal(t, "Jane", payload.CustomerName)
+			assert.Equal(t, "ORD-1001", payload.OrderNumber)
+			require.Len(t, payload.Items, 1)
+			assert.Equal(t, "House Espresso", payload.Items[0].ProductName)
+			assert.Equal(t, "https://example.com/account/reviews/new?order_id="+uuidToString(sentOrder.ID)+"&product_id="+uuidToString(espresso), payload.Items[0].ReviewURL)
+			return repository.Job{}, nil
+		})
+
+	sent, err := svc.SendReviewRequests(contextWithTenant(tenantID))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+}
+
+func TestReviewService_SendReviewRequests_AlreadySent(t *testing.T) {
+	svc, mockRepo := newTestReviewService(t, 7)
+
+	mockRepo.EXPECT().ListOrdersDueForReviewRequest(gomock.Any(), gomock.Any()).
+		Return([]repository.ListOrdersDueForReviewRequestRow{{ID: newUUID(), UserID: newUUID()}}, nil)
+	mockRepo.EXPECT().ListReviewableItemsForOrders(gomock.Any(), gomock.Any()).
+		Return([]repository.ListReviewableItemsForOrdersRow{{ProductID: newUUID(), ProductName: "House Espresso"}}, nil)
+	// Another run recorded the request first
+	mockRepo.EXPECT().CreateReviewRequest(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).Times(0)
+
+	sent, err := svc.SendReviewRequests(contextWithTenant(newUUID()))
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+}
+
+func TestReviewService_SendReviewRequests_Disabled(t *testing.T) {
+	// No repository calls are expected
+	svc, _ := newTestReviewService(t, 0)
+
+	sent, err := svc.SendReviewRequests(contextWithTenant(newUUID()))
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+}
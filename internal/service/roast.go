@@ -0,0 +1,441 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type roastService struct {
+	repo repository.Querier
+}
+
+// NewRoastService creates a new RoastService instance
+func NewRoastService(repo repository.Querier) domain.RoastService {
+	return &roastService{repo: repo}
+}
+
+// ListBatches returns batches planned or roasted on or after since.
+func (s *roastService) ListBatches(ctx context.Context, since time.Time) ([]repository.ListRoastBatchesRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	batches, err := s.repo.ListRoastBatches(ctx, repository.ListRoastBatchesParams{
+		TenantID: tenantID,
+		Since:    makePgDate(since),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roast batches: %w", err)
+	}
+
+	return batches, nil
+}
+
+// GetBatch returns a batch with its allocations.
+func (s *roastService) GetBatch(ctx context.Context, batchID string) (*domain.RoastBatchDetail, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := s.getBatch(ctx, tenantID, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	allocations, err := s.repo.ListRoastBatchAllocations(ctx, repository.ListRoastBatchAllocationsParams{
+		TenantID:     tenantID,
+		RoastBatchID: batch.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roast batch allocations: %w", err)
+	}
+
+	return &domain.RoastBatchDetail{Batch: batch, Allocations: allocations}, nil
+}
+
+// CreateBatch plans a roast of one product.
+func (s *roastService) CreateBatch(ctx context.Context, params domain.RoastBatchParams) (*repository.RoastBatch, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var productID pgtype.UUID
+	if params.ProductID == "" || productID.Scan(params.ProductID) != nil {
+		return nil, ErrRoastProductRequired
+	}
+	if err := validateRoastBatchParams(params); err != nil {
+		return nil, err
+	}
+
+	batch, err := s.repo.CreateRoastBatch(ctx, repository.CreateRoastBatchParams{
+		TenantID:         tenantID,
+		ProductID:        productID,
+		PlannedFor:       makePgDate(params.PlannedFor),
+		GreenLot:         makePgText(params.GreenLot),
+		GreenWeightGrams: makePgGrams(params.GreenWeightGrams),
+		Notes:            makePgText(params.Notes),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create roast batch: %w", err)
+	}
+
+	return &batch, nil
+}
+
+// UpdateBatch changes a batch's plan.
+func (s *roastService) UpdateBatch(ctx context.Context, batchID string, params domain.RoastBatchParams) (*repository.RoastBatch, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateRoastBatchParams(params); err != nil {
+		return nil, err
+	}
+
+	current, err := s.getBatch(ctx, tenantID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status == "cancelled" {
+		return nil, ErrRoastBatchCancelled
+	}
+
+	batch, err := s.repo.UpdateRoastBatch(ctx, repository.UpdateRoastBatchParams{
+		TenantID:         tenantID,
+		ID:               current.ID,
+		PlannedFor:       makePgDate(params.PlannedFor),
+		GreenLot:         makePgText(params.GreenLot),
+		GreenWeightGrams: makePgGrams(params.GreenWeightGrams),
+		Notes:            makePgText(params.Notes),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRoastBatchCancelled
+		}
+		return nil, fmt.Errorf("failed to update roast batch: %w", err)
+	}
+
+	return &batch, nil
+}
+
+// RecordRoast marks a batch roasted with its roast date and yield.
+func (s *roastService) RecordRoast(ctx context.Context, batchID string, params domain.RecordRoastParams) (*repository.RoastBatch, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.RoastedOn.IsZero() {
+		return nil, ErrRoastDateRequired
+	}
+	if params.RoastedOn.After(time.Now()) {
+		return nil, ErrRoastDateInFuture
+	}
+	if params.RoastedWeightGrams < 0 {
+		return nil, ErrInvalidRoastWeight
+	}
+
+	current, err := s.getBatch(ctx, tenantID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status == "cancelled" {
+		return nil, ErrRoastBatchCancelled
+	}
+
+	batch, err := s.repo.RecordRoastBatchRoasted(ctx, repository.RecordRoastBatchRoastedParams{
+		TenantID:           tenantID,
+		ID:                 current.ID,
+		RoastedOn:          makePgDate(params.RoastedOn),
+		RoastedWeightGrams: makePgGrams(params.RoastedWeightGrams),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRoastBatchCancelled
+		}
+		return nil, fmt.Errorf("failed to record roast: %w", err)
+	}
+
+	return &batch, nil
+}
+
+// CancelBatch cancels a batch that has not been roasted and releases its
+// allocations. Released order items ship without a roast date unless they
+// are allocated to another batch.
+func (s *roastService) CancelBatch(ctx context.Context, batchID string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.getBatch(ctx, tenantID, batchID)
+	if err != nil {
+		return err
+	}
+
+	switch current.Status {
+	case "cancelled":
+		return nil
+	case "roasted":
+		return ErrRoastBatchAlreadyRoasted
+	}
+
+	_, err = s.repo.CancelRoastBatch(ctx, repository.CancelRoastBatchParams{
+		TenantID: tenantID,
+		ID:       current.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRoastBatchAlreadyRoasted
+		}
+		return fmt.Errorf("failed to cancel roast batch: %w", err)
+	}
+
+	err = s.repo.DeleteRoastBatchAllocations(ctx, repository.DeleteRoastBatchAllocationsParams{
+		TenantID:     tenantID,
+		RoastBatchID: current.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release roast batch allocations: %w", err)
+	}
+
+	return nil
+}
+
+// AllocateOpenOrders allocates unshipped items of the batch's product on
+// paid orders not allocated to another batch.
+func (s *roastService) AllocateOpenOrders(ctx context.Context, batchID string) (int64, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	batch, err := s.getOpenBatch(ctx, tenantID, batchID)
+	if err != nil {
+		return 0, err
+	}
+
+	allocated, err := s.repo.AllocateOpenOrderItemsToRoastBatch(ctx, repository.AllocateOpenOrderItemsToRoastBatchParams{
+		TenantID: tenantID,
+		ID:       batch.ID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate orders to roast batch: %w", err)
+	}
+
+	return allocated, nil
+}
+
+// AllocateRenewals allocates the batch's product on active subscriptions
+// renewing from today through the given date.
+func (s *roastService) AllocateRenewals(ctx context.Context, batchID string, through time.Time) (int64, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if through.IsZero() {
+		return 0, ErrRoastDateRequired
+	}
+
+	batch, err := s.getOpenBatch(ctx, tenantID, batchID)
+	if err != nil {
+		return 0, err
+	}
+
+	allocated, err := s.repo.AllocateRenewalsToRoastBatch(ctx, repository.AllocateRenewalsToRoastBatchParams{
+		TenantID:      tenantID,
+		ID:            batch.ID,
+		RenewsThrough: makePgDate(through),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate renewals to roast batch: %w", err)
+	}
+
+	return allocated, nil
+}
+
+// RemoveAllocation releases one allocation from a batch.
+func (s *roastService) RemoveAllocation(ctx context.Context, batchID, allocationID string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	var batchUUID, allocationUUID pgtype.UUID
+	if err := batchUUID.Scan(batchID); err != nil {
+		return ErrRoastBatchNotFound
+	}
+	if err := allocationUUID.Scan(allocationID); err != nil {
+		return ErrRoastAllocationMissing
+	}
+
+	removed, err := s.repo.DeleteRoastBatchAllocation(ctx, repository.DeleteRoastBatchAllocationParams{
+		TenantID:     tenantID,
+		RoastBatchID: batchUUID,
+		ID:           allocationUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove roast batch allocation: %w", err)
+	}
+	if removed == 0 {
+		return ErrRoastAllocationMissing
+	}
+
+	return nil
+}
+
+// ListRestingOrders returns paid orders held until their coffee has rested.
+func (s *roastService) ListRestingOrders(ctx context.Context) ([]repository.ListRestingOrdersRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.repo.ListRestingOrders(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resting orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// GetRestDays returns the tenant's rest period in days.
+func (s *roastService) GetRestDays(ctx context.Context) (int32, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	days, err := s.repo.GetTenantRoastRestDays(ctx, tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get roast rest days: %w", err)
+	}
+
+	return days, nil
+}
+
+// SetRestDays sets the tenant's rest period in days.
+func (s *roastService) SetRestDays(ctx context.Context, days int32) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if days < 0 || days > domain.MaxRoastRestDays {
+		return ErrInvalidRestDays
+	}
+
+	err = s.repo.UpdateTenantRoastRestDays(ctx, repository.UpdateTenantRoastRestDaysParams{
+		ID:       tenantID,
+		RestDays: days,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update roast rest days: %w", err)
+	}
+
+	return nil
+}
+
+// roastDate is an order item's roast batch date.
+type roastDate repository.ListOrderItemRoastDatesRow
+
+// Date returns the roast date, or the planned date while the batch has not
+// been roasted.
+func (r roastDate) Date() time.Time {
+	if r.RoastedOn.Valid {
+		return r.RoastedOn.Time
+	}
+	return r.PlannedFor.Time
+}
+
+// roastDatesByOrderItem returns the roast batch of each allocated item of an
+// order, keyed by order item ID.
+func roastDatesByOrderItem(ctx context.Context, repo repository.Querier, tenantID, orderID pgtype.UUID) (map[pgtype.UUID]roastDate, error) {
+	rows, err := repo.ListOrderItemRoastDates(ctx, repository.ListOrderItemRoastDatesParams{
+		TenantID: tenantID,
+		OrderID:  orderID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roast dates: %w", err)
+	}
+
+	dates := make(map[pgtype.UUID]roastDate, len(rows))
+	for _, row := range rows {
+		dates[row.OrderItemID] = roastDate(row)
+	}
+	return dates, nil
+}
+
+// getBatch loads a batch by its string ID.
+func (s *roastService) getBatch(ctx context.Context, tenantID pgtype.UUID, batchID string) (repository.GetRoastBatchRow, error) {
+	var batchUUID pgtype.UUID
+	if err := batchUUID.Scan(batchID); err != nil {
+		return repository.GetRoastBatchRow{}, ErrRoastBatchNotFound
+	}
+
+	batch, err := s.repo.GetRoastBatch(ctx, repository.GetRoastBatchParams{
+		TenantID: tenantID,
+		ID:       batchUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return batch, ErrRoastBatchNotFound
+		}
+		return batch, fmt.Errorf("failed to get roast batch: %w", err)
+	}
+
+	return batch, nil
+}
+
+// getOpenBatch loads a batch that can still take allocations.
+func (s *roastService) getOpenBatch(ctx context.Context, tenantID pgtype.UUID, batchID string) (repository.GetRoastBatchRow, error) {
+	batch, err := s.getBatch(ctx, tenantID, batchID)
+	if err != nil {
+		return batch, err
+	}
+	if batch.Status == "cancelled" {
+		return batch, ErrRoastBatchCancelled
+	}
+	return batch, nil
+}
+
+// validateRoastBatchParams checks the fields shared by creating and
+// updating a batch.
+func validateRoastBatchParams(params domain.RoastBatchParams) error {
+	if params.PlannedFor.IsZero() {
+		return ErrRoastDateRequired
+	}
+	if params.GreenWeightGrams < 0 {
+		return ErrInvalidRoastWeight
+	}
+	return nil
+}
+
+// makePgDate converts a time to a pgtype.Date, NULL for the zero time.
+func makePgDate(t time.Time) pgtype.Date {
+	if t.IsZero() {
+		return pgtype.Date{Valid: false}
+	}
+	return pgtype.Date{Time: t, Valid: true}
+}
+
+// makePgGrams converts a weight in grams to a pgtype.Int4, NULL when not
+// weighed.
+func makePgGrams(grams int32) pgtype.Int4 {
+	if grams <= 0 {
+		return pgtype.Int4{Valid: false}
+	}
+	return pgtype.Int4{Int32: grams, Valid: true}
+}
@@ -0,0 +1,363 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/billing"
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRoastService_CreateBatch_Validation(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1)
+
+	tests := []struct {
+		name    string
+		params  domain.RoastBatchParams
+		wantErr error
+	}{
+		{
+			name:    "missing product",
+			params:  domain.RoastBatchParams{PlannedFor: tomorrow},
+			wantErr: ErrRoastProductRequired,
+		},
+		{
+			name:    "missing planned date",
+			params:  domain.RoastBatchParams{ProductID: uuidToString(newUUID())},
+			wantErr: ErrRoastDateRequired,
+		},
+		{
+			name:    "negative green weight",
+			params:  domain.RoastBatchParams{ProductID: uuidToString(newUUID()), PlannedFor: tomorrow, GreenWeightGrams: -1},
+			wantErr: ErrInvalidRoastWeight,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewRoastService(repository.NewMockQuerier(ctrl))
+
+			_, err := svc.CreateBatch(contextWithTenant(newUUID()), tt.params)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRoastService_CreateBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewRoastService(mockRepo)
+
+	productID := newUUID()
+	plannedFor := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	mockRepo.EXPECT().CreateRoastBatch(gomock.Any(), repository.CreateRoastBatchParams{
+		TenantID:         tenantID,
+		ProductID:        productID,
+		PlannedFor:       pgtype.Date{Time: plannedFor, Valid: true},
+		GreenLot:         pgtype.Text{String: "ETH-2026-04", Valid: true},
+		GreenWeightGrams: pgtype.Int4{Int32: 12000, Valid: true},
+	}).Return(repository.RoastBatch{ID: newUUID(), Status: "planned"}, nil)
+
+	batch, err := svc.CreateBatch(ctx, domain.RoastBatchParams{
+		ProductID:        uuidToString(productID),
+		PlannedFor:       plannedFor,
+		GreenLot:         "ETH-2026-04",
+		GreenWeightGrams: 12000,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "planned", batch.Status)
+}
+
+func TestRoastService_RecordRoast(t *testing.T) {
+	t.Run("rejects roast dates in the future", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := NewRoastService(repository.NewMockQuerier(ctrl))
+
+		_, err := svc.RecordRoast(contextWithTenant(newUUID()), uuidToString(newUUID()), domain.RecordRoastParams{
+			RoastedOn: time.Now().AddDate(0, 0, 2),
+		})
+		assert.ErrorIs(t, err, ErrRoastDateInFuture)
+	})
+
+	t.Run("rejects cancelled batches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repository.NewMockQuerier(ctrl)
+		svc := NewRoastService(mockRepo)
+
+		mockRepo.EXPECT().GetRoastBatch(gomock.Any(), gomock.Any()).
+			Return(repository.GetRoastBatchRow{ID: newUUID(), Status: "cancelled"}, nil)
+
+		_, err := svc.RecordRoast(contextWithTenant(newUUID()), uuidToString(newUUID()), domain.RecordRoastParams{
+			RoastedOn: time.Now(),
+		})
+		assert.ErrorIs(t, err, ErrRoastBatchCancelled)
+	})
+
+	t.Run("records roast date and yield", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		tenantID := newUUID()
+		mockRepo := repository.NewMockQuerier(ctrl)
+		svc := NewRoastService(mockRepo)
+
+		batchID := newUUID()
+		roastedOn := time.Now().AddDate(0, 0, -1)
+
+		mockRepo.EXPECT().GetRoastBatch(gomock.Any(), gomock.Any()).
+			Return(repository.GetRoastBatchRow{ID: batchID, Status: "planned"}, nil)
+		mockRepo.EXPECT().RecordRoastBatchRoasted(gomock.Any(), repository.RecordRoastBatchRoastedParams{
+			TenantID:           tenantID,
+			ID:                 batchID,
+			RoastedOn:          pgtype.Date{Time: roastedOn, Valid: true},
+			RoastedWeightGrams: pgtype.Int4{Int32: 10200, Valid: true},
+		}).Return(repository.RoastBatch{ID: batchID, Status: "roasted"}, nil)
+
+		batch, err := svc.RecordRoast(contextWithTenant(tenantID), uuidToString(batchID), domain.RecordRoastParams{
+			RoastedOn:          roastedOn,
+			RoastedWeightGrams: 10200,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "roasted", batch.Status)
+	})
+}
+
+func TestRoastService_CancelBatch(t *testing.T) {
+	t.Run("releases allocations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		tenantID := newUUID()
+		mockRepo := repository.NewMockQuerier(ctrl)
+		svc := NewRoastService(mockRepo)
+
+		batchID := newUUID()
+		mockRepo.EXPECT().GetRoastBatch(gomock.Any(), gomock.Any()).
+			Return(repository.GetRoastBatchRow{ID: batchID, Status: "planned"}, nil)
+		gomock.InOrder(
+			mockRepo.EXPECT().CancelRoastBatch(gomock.Any(), repository.CancelRoastBatchParams{
+				TenantID: tenantID,
+				ID:       batchID,
+			}).Return(repository.RoastBatch{ID: batchID, Status: "cancelled"}, nil),
+			mockRepo.EXPECT().DeleteRoastBatchAllocations(gomock.Any(), repository.DeleteRoastBatchAllocationsParams{
+				TenantID:     tenantID,
+				RoastBatchID: batchID,
+			}).Return(nil),
+		)
+
+		err := svc.CancelBatch(contextWithTenant(tenantID), uuidToString(batchID))
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects roasted batches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := repository.NewMockQuerier(ctrl)
+		svc := NewRoastService(mockRepo)
+
+		mockRepo.EXPECT().GetRoastBatch(gomock.Any(), gomock.Any()).
+			Return(repository.GetRoastBatchRow{ID: newUUID(), Status: "roasted"}, nil)
+
+		err := svc.CancelBatch(contextWithTenant(newUUID()), uuidToString(newUUID()))
+		assert.ErrorIs(t, err, ErrRoastBatchAlreadyRoasted)
+	})
+}
+
+func TestRoastService_SetRestDays(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewRoastService(mockRepo)
+
+	assert.ErrorIs(t, svc.SetRestDays(ctx, -1), ErrInvalidRestDays)
+	assert.ErrorIs(t, svc.SetRestDays(ctx, domain.MaxRoastRestDays+1), ErrInvalidRestDays)
+
+	mockRepo.EXPECT().UpdateTenantRoastRestDays(gomock.Any(), repository.UpdateTenantRoastRestDaysParams{
+		ID:       tenantID,
+		RestDays: 5,
+	}).Return(nil)
+	require.NoError(t, svc.SetRestDays(ctx, 5))
+}
+
+// Test_CreateOrderFromPaymentIntent_RoastDates verifies that a new order is
+// allocated to roast batches and its confirmation email carries each item's
+// roast date.
+func Test_CreateOrderFromPaymentIntent_RoastDates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+
+	cart := createTestCart(tenantID, "active")
+	cartItems := createTestCartItems()
+	pi := createTestPaymentIntent(uuidToString(cart.ID), "succeeded")
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	plannedItem, unallocatedItem := newUUID(), newUUID()
+	plannedFor := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)
+
+	mockRepo.EXPECT().GetOrderItems(gomock.Any(), gomock.Any()).Return([]repository.GetOrderItemsRow{
+		{ID: plannedItem, ProductName: "Ethiopia Guji", Quantity: 2, TotalPriceCents: 3600},
+		{ID: unallocatedItem, ProductName: "Pour Over Filters", Quantity: 1, TotalPriceCents: 800},
+	}, nil)
+	mockRepo.EXPECT().AllocateOrderToRoastBatches(gomock.Any(), gomock.Any()).Return(int64(1), nil).Times(1)
+	mockRepo.EXPECT().ListOrderItemRoastDates(gomock.Any(), gomock.Any()).Return([]repository.ListOrderItemRoastDatesRow{
+		{OrderItemID: plannedItem, Status: "planned", PlannedFor: pgtype.Date{Time: plannedFor, Valid: true}},
+	}, nil)
+
+	var enqueued repository.EnqueueJobParams
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, arg repository.EnqueueJobParams) (repository.Job, error) {
+			enqueued = arg
+			return repository.Job{}, nil
+		}).Times(1)
+	setupMockDefaults(mockRepo, tenantID, cart, cartItems)
+
+	mockBilling := billing.NewMockProvider()
+	mockBilling.PaymentIntents[pi.ID] = pi
+
+	svc := NewOrderService(mockRepo, nil, mockBilling, shipping.NewMockProvider())
+
+	_, err := svc.CreateOrderFromPaymentIntent(ctx, pi.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, jobs.JobTypeOrderConfirmation, enqueued.JobType)
+	var payload jobs.OrderConfirmationPayload
+	require.NoError(t, json.Unmarshal(enqueued.Payload, &payload))
+	require.Len(t, payload.Items, 2)
+
+	require.NotNil(t, payload.Items[0].RoastDate)
+	assert.True(t, payload.Items[0].RoastDate.Equal(plannedFor))
+	assert.True(t, payload.Items[0].RoastPlanned)
+	assert.Nil(t, payload.Items[1].RoastDate)
+}
+
+func TestRoastService_ProductionPlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewRoastService(mockRepo)
+
+	start := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	nextDay := start.AddDate(0, 0, 1)
+	guji, huila := newUUID(), newUUID()
+
+	mockRepo.EXPECT().ListProductionDemand(gomock.Any(), repository.ListProductionDemandParams{
+		TenantID:  tenantID,
+		StartDate: pgtype.Date{Time: start, Valid: true},
+		EndDate:   pgtype.Date{Time: start.AddDate(0, 0, 6), Valid: true},
+	}).Return([]repository.ListProductionDemandRow{
+		{RoastOn: pgtype.Date{Time: start, Valid: true}, ProductID: guji, ProductName: "Ethiopia Guji", RoastLossPercent: 15, Units: 4, OrderGrams: 1360, SubscriptionGrams: 340},
+		{RoastOn: pgtype.Date{Time: start, Valid: true}, ProductID: huila, ProductName: "Colombia Huila", RoastLossPercent: 20, Units: 2, WholesaleGrams: 4000},
+		{RoastOn: pgtype.Date{Time: nextDay, Valid: true}, ProductID: guji, ProductName: "Ethiopia Guji", RoastLossPercent: 15, Units: 1, SubscriptionGrams: 340},
+	}, nil)
+
+	plan, err := svc.ProductionPlan(ctx, start.Add(15*time.Hour), 7)
+	require.NoError(t, err)
+
+	assert.True(t, plan.Start.Equal(start))
+	assert.True(t, plan.End.Equal(start.AddDate(0, 0, 6)))
+	require.Len(t, plan.Days, 2)
+
+	first := plan.Days[0]
+	require.Len(t, first.Lines, 2)
+	assert.Equal(t, uuidToString(guji), first.Lines[0].ProductID)
+	assert.Equal(t, 1700, first.Lines[0].RoastedGrams)
+	assert.Equal(t, 2000, first.Lines[0].GreenGrams)
+	assert.Equal(t, 4000, first.Lines[1].RoastedGrams)
+	assert.Equal(t, 5000, first.Lines[1].GreenGrams)
+	assert.Equal(t, 5700, first.RoastedGrams)
+	assert.Equal(t, 7000, first.GreenGrams)
+
+	second := plan.Days[1]
+	assert.True(t, second.Date.Equal(nextDay))
+	require.Len(t, second.Lines, 1)
+	assert.Equal(t, 400, second.GreenGrams)
+}
+
+func TestRoastService_ProductionPlan_Window(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := NewRoastService(repository.NewMockQuerier(ctrl))
+	ctx := contextWithTenant(newUUID())
+
+	_, err := svc.ProductionPlan(ctx, time.Now(), 0)
+	assert.ErrorIs(t, err, ErrInvalidPlanWindow)
+
+	_, err = svc.ProductionPlan(ctx, time.Now(), domain.MaxProductionPlanDays+1)
+	assert.ErrorIs(t, err, ErrInvalidPlanWindow)
+}
+
+func TestGreenGrams(t *testing.T) {
+	tests := []struct {
+		roasted int
+		loss    float64
+		want    int
+	}{
+		{roasted: 0, loss: 15, want: 0},
+		{roasted: 1000, loss: 0, want: 1000},
+		{roasted: 1000, loss: 20, want: 1250},
+		{roasted: 1000, loss: 15, want: 1177},
+		{roasted: 340, loss: 17.5, want: 413},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, greenGrams(tt.roasted, tt.loss), "%dg at %.1f%% loss", tt.roasted, tt.loss)
+	}
+}
+
+func TestRoastService_SetRoastLoss(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewRoastService(mockRepo)
+
+	productID := newUUID()
+
+	assert.ErrorIs(t, svc.SetRoastLoss(ctx, uuidToString(productID), -1), ErrInvalidRoastLoss)
+	assert.ErrorIs(t, svc.SetRoastLoss(ctx, uuidToString(productID), domain.MaxRoastLossPercent+1), ErrInvalidRoastLoss)
+
+	mockRepo.EXPECT().UpdateProductRoastLoss(gomock.Any(), repository.UpdateProductRoastLossParams{
+		TenantID:         tenantID,
+		ID:               productID,
+		RoastLossPercent: 16.5,
+	}).Return(int64(1), nil)
+	require.NoError(t, svc.SetRoastLoss(ctx, uuidToString(productID), 16.5))
+
+	mockRepo.EXPECT().UpdateProductRoastLoss(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+	assert.ErrorIs(t, svc.SetRoastLoss(ctx, uuidToString(newUUID()), 16.5), ErrProductNotFound)
+}
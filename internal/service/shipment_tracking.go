@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// TrackingPollInterval is how often in-transit shipments are polled,
+	// matching the default shipment tracking schedule. Shipments updated by
+	// a webhook within the interval are skipped.
+	TrackingPollInterval = 2 * time.Hour
+
+	// trackingPollBatchSize bounds the carrier requests made by one poll.
+	trackingPollBatchSize = 100
+)
+
+type shipmentTrackingService struct {
+	repo            repository.Querier
+	providers       ShippingProviderResolver
+	notifyCustomers bool
+}
+
+// NewShipmentTrackingService creates a new ShipmentTrackingService instance.
+// When notifyCustomers is set, customers are emailed when a package is
+// delivered, fails delivery or is returned to sender.
+func NewShipmentTrackingService(repo repository.Querier, providers ShippingProviderResolver, notifyCustomers bool) domain.ShipmentTrackingService {
+	return &shipmentTrackingService{
+		repo:            repo,
+		providers:       providers,
+		notifyCustomers: notifyCustomers,
+	}
+}
+
+// RecordTracking stores a tracking update for the shipment with its tracking number.
+func (s *shipmentTrackingService) RecordTracking(ctx context.Context, info *shipping.TrackingInfo) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if info == nil || info.TrackingNumber == "" {
+		return nil
+	}
+
+	shipment, err := s.repo.GetShipmentByTrackingNumber(ctx, repository.GetShipmentByTrackingNumberParams{
+		TenantID:       tenantID,
+		TrackingNumber: makePgText(info.TrackingNumber),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Trackers can be created outside the platform; nothing to update
+			return nil
+		}
+		return fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	_, err = s.applyTracking(ctx, shipment, info)
+	return err
+}
+
+// PollTracking refreshes tracking for in-transit shipments from the tenant's provider.
+func (s *shipmentTrackingService) PollTracking(ctx context.Context) (*domain.TrackingPollResult, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shipments, err := s.repo.ListShipmentsToTrack(ctx, repository.ListShipmentsToTrackParams{
+		TenantID:          tenantID,
+		TrackingCheckedAt: pgtype.Timestamptz{Time: time.Now().Add(-TrackingPollInterval), Valid: true},
+		Limit:             trackingPollBatchSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shipments to track: %w", err)
+	}
+
+	result := &domain.TrackingPollResult{}
+	if len(shipments) == 0 {
+		return result, nil
+	}
+
+	provider, err := s.providers.GetShippingProvider(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipping provider: %w", err)
+	}
+
+	for _, shipment := range shipments {
+		info, err := provider.TrackShipment(ctx, shipment.TrackingNumber.String)
+		if errors.Is(err, shipping.ErrNotImplemented) {
+			// Provider has no tracking; statuses are updated by hand
+			return result, nil
+		}
+		result.Checked++
+
+		if err != nil {
+			// Try again next poll rather than on every job retry
+			result.Failed++
+			s.touch(ctx, shipment)
+			continue
+		}
+
+		advanced, err := s.applyTracking(ctx, shipment, info)
+		if err != nil {
+			return result, err
+		}
+		if advanced {
+			result.Updated++
+		}
+	}
+
+	return result, nil
+}
+
+// applyTracking stores the update's events and advances the shipment.
+// Reports whether the shipment's status changed.
+func (s *shipmentTrackingService) applyTracking(ctx context.Context, shipment repository.Shipment, info *shipping.TrackingInfo) (bool, error) {
+	for _, event := range info.Events {
+		if event.Timestamp.IsZero() {
+			continue
+		}
+
+		err := s.repo.CreateShipmentTrackingEvent(ctx, repository.CreateShipmentTrackingEventParams{
+			TenantID:   shipment.TenantID,
+			ShipmentID: shipment.ID,
+			Status:     event.Status,
+			Message:    makePgText(event.Description),
+			Location:   makePgText(event.Location),
+			EventAt:    pgtype.Timestamptz{Time: event.Timestamp, Valid: true},
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to record tracking event: %w", err)
+		}
+	}
+
+	if info.Status == "" || info.Status == shipment.Status {
+		s.touch(ctx, shipment)
+		return false, nil
+	}
+
+	advanced, err := s.repo.AdvanceShipmentTrackingStatus(ctx, repository.AdvanceShipmentTrackingStatusParams{
+		Status:   info.Status,
+		TenantID: shipment.TenantID,
+		ID:       shipment.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Already delivered, returned or cancelled
+			s.touch(ctx, shipment)
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to update shipment status: %w", err)
+	}
+
+	switch advanced.Status {
+	case shipping.TrackingStatusDelivered:
+		_, err := s.repo.MarkOrderDeliveredIfComplete(ctx, repository.MarkOrderDeliveredIfCompleteParams{
+			TenantID: advanced.TenantID,
+			ID:       advanced.OrderID,
+		})
+		if err != nil {
+			return true, fmt.Errorf("failed to mark order delivered: %w", err)
+		}
+		s.enqueueUpdateEmail(ctx, advanced, info)
+
+	case shipping.TrackingStatusFailed, shipping.TrackingStatusReturned:
+		s.enqueueUpdateEmail(ctx, advanced, info)
+	}
+
+	return true, nil
+}
+
+// touch records that the shipment's tracking was refreshed.
+// Failures only cause the shipment to be polled again sooner.
+func (s *shipmentTrackingService) touch(ctx context.Context, shipment repository.Shipment) {
+	_ = s.repo.TouchShipmentTrackingChecked(ctx, repository.TouchShipmentTrackingCheckedParams{
+		TenantID: shipment.TenantID,
+		ID:       shipment.ID,
+	})
+}
+
+// enqueueUpdateEmail queues the delivered or delivery problem email.
+// Failures are ignored so the tracking update is still recorded.
+func (s *shipmentTrackingService) enqueueUpdateEmail(ctx context.Context, shipment repository.Shipment, info *shipping.TrackingInfo) {
+	if !s.notifyCustomers {
+		return
+	}
+
+	order, err := s.repo.GetOrderWithDetails(ctx, repository.GetOrderWithDetailsParams{
+		TenantID: shipment.TenantID,
+		ID:       shipment.OrderID,
+	})
+	if err != nil || !order.CustomerEmail.Valid || order.CustomerEmail.String == "" {
+		return
+	}
+
+	customerName := order.CustomerFirstName.String
+	if order.CustomerLastName.Valid && order.CustomerLastName.String != "" {
+		customerName += " " + order.CustomerLastName.String
+	}
+
+	payload := jobs.ShipmentUpdatePayload{
+		OrderID:        uuid.UUID(order.ID.Bytes),
+		Email:          order.CustomerEmail.String,
+		CustomerName:   customerName,
+		OrderNumber:    order.OrderNumber,
+		Status:         shipment.Status,
+		UpdatedAt:      time.Now(),
+		Carrier:        shipment.Carrier.String,
+		TrackingNumber: shipment.TrackingNumber.String,
+		TrackingURL:    shipment.TrackingUrl.String,
+	}
+	if latest, ok := latestTrackingEvent(info.Events); ok {
+		payload.Message = latest.Description
+		payload.UpdatedAt = latest.Timestamp
+	}
+
+	_ = jobs.EnqueueShipmentUpdateEmail(ctx, s.repo, uuid.UUID(shipment.TenantID.Bytes), payload)
+}
+
+// latestTrackingEvent returns the most recent timestamped event.
+// Providers do not agree on the order events are listed in.
+func latestTrackingEvent(events []shipping.TrackingEvent) (shipping.TrackingEvent, bool) {
+	var latest shipping.TrackingEvent
+	for _, event := range events {
+		if event.Timestamp.After(latest.Timestamp) {
+			latest = event
+		}
+	}
+	return latest, !latest.Timestamp.IsZero()
+}
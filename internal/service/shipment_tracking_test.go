@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTrackedShipment(tenantID pgtype.UUID, status string) repository.Shipment {
+	return repository.Shipment{
+		ID:             newUUID(),
+		TenantID:       tenantID,
+		OrderID:        newUUID(),
+		Status:         status,
+		Carrier:        pgtype.Text{String: "USPS", Valid: true},
+		TrackingNumber: pgtype.Text{String: "9400100000000000000000", Valid: true},
+		Provider:       pgtype.Text{String: "easypost", Valid: true},
+	}
+}
+
+func deliveredTracking(trackingNumber string) *shipping.TrackingInfo {
+	inTransitAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	deliveredAt := inTransitAt.Add(26 * time.Hour)
+	return &shipping.TrackingInfo{
+		TrackingNumber: trackingNumber,
+		Status:         shipping.TrackingStatusDelivered,
+		Events: []shipping.TrackingEvent{
+			{Timestamp: deliveredAt, Status: shipping.TrackingStatusDelivered, Description: "Delivered, Front Door", Location: "Portland, OR 97201"},
+			{Timestamp: inTransitAt, Status: shipping.TrackingStatusInTransit, Description: "Departed USPS Facility"},
+			{Status: shipping.TrackingStatusLabelCreated}, // No timestamp; not stored
+		},
+	}
+}
+
+func TestShipmentTrackingService_RecordTracking_Delivered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	tenantID := newUUID()
+	shipment := newTrackedShipment(tenantID, "in_transit")
+	info := deliveredTracking(shipment.TrackingNumber.String)
+
+	svc := NewShipmentTrackingService(mockRepo, staticShippingProviders{}, true)
+
+	mockRepo.EXPECT().GetShipmentByTrackingNumber(gomock.Any(), repository.GetShipmentByTrackingNumberParams{
+		TenantID:       tenantID,
+		TrackingNumber: shipment.TrackingNumber,
+	}).Return(shipment, nil)
+	mockRepo.EXPECT().CreateShipmentTrackingEvent(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	advanced := shipment
+	advanced.Status = "delivered"
+	mockRepo.EXPECT().AdvanceShipmentTrackingStatus(gomock.Any(), repository.AdvanceShipmentTrackingStatusParams{
+		Status:   "delivered",
+		TenantID: tenantID,
+		ID:       shipment.ID,
+	}).Return(advanced, nil)
+	mockRepo.EXPECT().MarkOrderDeliveredIfComplete(gomock.Any(), repository.MarkOrderDeliveredIfCompleteParams{
+		TenantID: tenantID,
+		ID:       shipment.OrderID,
+	}).Return(int64(1), nil)
+	mockRepo.EXPECT().GetOrderWithDetails(gomock.Any(), gomock.Any()).Return(repository.GetOrderWithDetailsRow{
+		ID:                shipment.OrderID,
+		TenantID:          tenantID,
+		OrderNumber:       "ORD-3001",
+		CustomerEmail:     pgtype.Text{String: "buyer@example.com", Valid: true},
+		CustomerFirstName: pgtype.Text{String: "Ada", Valid: true},
+	}, nil)
+
+	var enqueued repository.EnqueueJobParams
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.EnqueueJobParams) (repository.Job, error) {
+			enqueued = arg
+			return repository.Job{}, nil
+		})
+
+	err := svc.RecordTracking(contextWithTenant(tenantID), info)
+
+	require.NoError(t, err)
+	assert.Equal(t, jobs.JobTypeShipmentUpdate, enqueued.JobType)
+
+	var payload jobs.ShipmentUpdatePayload
+	require.NoError(t, json.Unmarshal(enqueued.Payload, &payload))
+	assert.Equal(t, "delivered", payload.Status)
+	assert.Equal(t, "Delivered, Front Door", payload.Message)
+	assert.Equal(t, "ORD-3001", payload.OrderNumber)
+}
+
+func TestShipmentTrackingService_RecordTracking_NoStatusChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	tenantID := newUUID()
+	shipment := newTrackedShipment(tenantID, "in_transit")
+	info := &shipping.TrackingInfo{
+		TrackingNumber: shipment.TrackingNumber.String,
+		Status:         shipping.TrackingStatusInTransit,
+		Events: []shipping.TrackingEvent{
+			{Timestamp: time.Now(), Status: shipping.TrackingStatusInTransit, Description: "Arrived at USPS Facility"},
+		},
+	}
+
+	svc := NewShipmentTrackingService(mockRepo, staticShippingProviders{}, true)
+
+	mockRepo.EXPECT().GetShipmentByTrackingNumber(gomock.Any(), gomock.Any()).Return(shipment, nil)
+	mockRepo.EXPECT().CreateShipmentTrackingEvent(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().TouchShipmentTrackingChecked(gomock.Any(), repository.TouchShipmentTrackingCheckedParams{
+		TenantID: tenantID,
+		ID:       shipment.ID,
+	}).Return(nil)
+
+	err := svc.RecordTracking(contextWithTenant(tenantID), info)
+
+	require.NoError(t, err)
+}
+
+func TestShipmentTrackingService_RecordTracking_AlreadyFinished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	tenantID := newUUID()
+	shipment := newTrackedShipment(tenantID, "delivered")
+	info := &shipping.TrackingInfo{
+		TrackingNumber: shipment.TrackingNumber.String,
+		Status:         shipping.TrackingStatusInTransit, // Late, out-of-order update
+	}
+
+	svc := NewShipmentTrackingService(mockRepo, staticShippingProviders{}, true)
+
+	mockRepo.EXPECT().GetShipmentByTrackingNumber(gomock.Any(), gomock.Any()).Return(shipment, nil)
+	mockRepo.EXPECT().AdvanceShipmentTrackingStatus(gomock.Any(), gomock.Any()).
+		Return(repository.Shipment{}, pgx.ErrNoRows)
+	mockRepo.EXPECT().TouchShipmentTrackingChecked(gomock.Any(), gomock.Any()).Return(nil)
+
+	err := svc.RecordTracking(contextWithTenant(tenantID), info)
+
+	require.NoError(t, err)
+}
+
+func TestShipmentTrackingService_RecordTracking_UnknownTrackingNumber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	tenantID := newUUID()
+	svc := NewShipmentTrackingService(mockRepo, staticShippingProviders{}, true)
+
+	mockRepo.EXPECT().GetShipmentByTrackingNumber(gomock.Any(), gomock.Any()).
+		Return(repository.Shipment{}, pgx.ErrNoRows)
+
+	err := svc.RecordTracking(contextWithTenant(tenantID), deliveredTracking("1Z999"))
+
+	require.NoError(t, err)
+}
+
+func TestShipmentTrackingService_RecordTracking_NotificationsDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	tenantID := newUUID()
+	shipment := newTrackedShipment(tenantID, "in_transit")
+	info := &shipping.TrackingInfo{
+		TrackingNumber: shipment.TrackingNumber.String,
+		Status:         shipping.TrackingStatusFailed,
+	}
+
+	svc := NewShipmentTrackingService(mockRepo, staticShippingProviders{}, false)
+
+	advanced := shipment
+	advanced.Status = "failed"
+	mockRepo.EXPECT().GetShipmentByTrackingNumber(gomock.Any(), gomock.Any()).Return(shipment, nil)
+	mockRepo.EXPECT().AdvanceShipmentTrackingStatus(gomock.Any(), gomock.Any()).Return(advanced, nil)
+	// No GetOrderWithDetails or EnqueueJob: the customer is not emailed
+
+	err := svc.RecordTracking(contextWithTenant(tenantID), info)
+
+	require.NoError(t, err)
+}
+
+func TestShipmentTrackingService_PollTracking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	tenantID := newUUID()
+	moving := newTrackedShipment(tenantID, "label_created")
+	lost := newTrackedShipment(tenantID, "in_transit")
+	lost.TrackingNumber = pgtype.Text{String: "LOST123", Valid: true}
+
+	provider := shipping.NewMockProvider()
+	provider.TrackShipmentFunc = func(_ context.Context, trackingNumber string) (*shipping.TrackingInfo, error) {
+		if trackingNumber == "LOST123" {
+			return nil, errors.New("carrier unavailable")
+		}
+		return &shipping.TrackingInfo{TrackingNumber: trackingNumber, Status: shipping.TrackingStatusInTransit}, nil
+	}
+
+	svc := NewShipmentTrackingService(mockRepo, staticShippingProviders{provider: provider}, true)
+
+	mockRepo.EXPECT().ListShipmentsToTrack(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.ListShipmentsToTrackParams) ([]repository.Shipment, error) {
+			assert.Equal(t, tenantID, arg.TenantID)
+			assert.WithinDuration(t, time.Now().Add(-TrackingPollInterval), arg.TrackingCheckedAt.Time, time.Minute)
+			return []repository.Shipment{moving, lost}, nil
+		})
+
+	advanced := moving
+	advanced.Status = "in_transit"
+	mockRepo.EXPECT().AdvanceShipmentTrackingStatus(gomock.Any(), repository.AdvanceShipmentTrackingStatusParams{
+		Status:   "in_transit",
+		TenantID: tenantID,
+		ID:       moving.ID,
+	}).Return(advanced, nil)
+	mockRepo.EXPECT().TouchShipmentTrackingChecked(gomock.Any(), repository.TouchShipmentTrackingCheckedParams{
+		TenantID: tenantID,
+		ID:       lost.ID,
+	}).Return(nil)
+
+	result, err := svc.PollTracking(contextWithTenant(tenantID))
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.TrackingPollResult{Checked: 2, Updated: 1, Failed: 1}, *result)
+}
+
+func TestShipmentTrackingService_PollTracking_ProviderWithoutTracking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	tenantID := newUUID()
+	svc := NewShipmentTrackingService(mockRepo, staticShippingProviders{provider: shipping.NewMockProvider()}, true)
+
+	mockRepo.EXPECT().ListShipmentsToTrack(gomock.Any(), gomock.Any()).
+		Return([]repository.Shipment{newTrackedShipment(tenantID, "in_transit")}, nil)
+
+	result, err := svc.PollTracking(contextWithTenant(tenantID))
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Checked)
+}
@@ -0,0 +1,634 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/dukerupert/hiri/internal/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ShippingProviderResolver resolves the shipping provider configured for a tenant.
+// Satisfied by provider.ProviderRegistry.
+type ShippingProviderResolver interface {
+	GetShippingProvider(ctx context.Context, tenantID pgtype.UUID) (shipping.Provider, error)
+}
+
+// shippableStatuses lists order statuses that labels can be bought for.
+var shippableStatuses = map[string]bool{
+	"paid":               true,
+	"processing":         true,
+	"shipped":            true, // Remaining items of a partially shipped order
+	"partially_refunded": true,
+}
+
+// labelDownloadTimeout bounds how long we wait for the provider's label file.
+const labelDownloadTimeout = 30 * time.Second
+
+type shippingLabelService struct {
+	repo       repository.Querier
+	providers  ShippingProviderResolver
+	storage    storage.Storage
+	httpClient *http.Client
+}
+
+// NewShippingLabelService creates a new ShippingLabelService instance
+func NewShippingLabelService(repo repository.Querier, providers ShippingProviderResolver, fileStorage storage.Storage) domain.ShippingLabelService {
+	return &shippingLabelService{
+		repo:       repo,
+		providers:  providers,
+		storage:    fileStorage,
+		httpClient: &http.Client{Timeout: labelDownloadTimeout},
+	}
+}
+
+// EstimatePackage suggests a package for the order's unshipped items.
+// SKUs without a weight are counted as a standard bag.
+func (s *shippingLabelService) EstimatePackage(ctx context.Context, orderID string) (shipping.Package, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return shipping.Package{}, err
+	}
+
+	var orderUUID pgtype.UUID
+	if err := orderUUID.Scan(orderID); err != nil {
+		return shipping.Package{}, ErrOrderNotFound
+	}
+
+	weightGrams, err := s.repo.GetOrderPackageWeight(ctx, repository.GetOrderPackageWeightParams{
+		TenantID:         tenantID,
+		OrderID:          orderUUID,
+		DefaultItemGrams: bagWeightGrams,
+	})
+	if err != nil {
+		return shipping.Package{}, fmt.Errorf("failed to estimate package weight: %w", err)
+	}
+
+	bags := int32((weightGrams + bagWeightGrams - 1) / bagWeightGrams)
+	return packageForBags(bags, int32(weightGrams)), nil
+}
+
+// GetRates returns label rates for the order, cheapest first.
+func (s *shippingLabelService) GetRates(ctx context.Context, orderID string, pkg shipping.Package) ([]shipping.Rate, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.getShippableOrder(ctx, tenantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validPackage(pkg) {
+		return nil, ErrInvalidPackage
+	}
+
+	origin, err := s.originAddress(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := s.provider(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := provider.GetRates(ctx, shipping.RateParams{
+		TenantID:           uuidToString(tenantID),
+		OriginAddress:      origin,
+		DestinationAddress: destinationAddress(order),
+		Packages:           []shipping.Package{pkg},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get label rates: %w", err)
+	}
+
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].CostCents < rates[j].CostCents
+	})
+
+	return rates, nil
+}
+
+// BuyLabel purchases a label for the order's unshipped items.
+//
+// The label is bought before anything is written. Retrying with the same
+// rate returns the already-purchased label (the provider call is idempotent),
+// so a failure after purchase can be recovered by buying again.
+func (s *shippingLabelService) BuyLabel(ctx context.Context, params domain.BuyLabelParams) (*repository.Shipment, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.RateID == "" {
+		return nil, ErrRateRequired
+	}
+	if !validPackage(params.Package) {
+		return nil, ErrInvalidPackage
+	}
+
+	order, err := s.getShippableOrder(ctx, tenantID, params.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	unfulfilled, err := s.repo.GetUnfulfilledOrderItems(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unfulfilled items: %w", err)
+	}
+	if len(unfulfilled) == 0 {
+		return nil, ErrNoItemsToShip
+	}
+
+	origin, err := s.originAddress(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := s.provider(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetShipmentsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipments: %w", err)
+	}
+	shipmentNumber := fmt.Sprintf("%s-%d", order.OrderNumber, len(existing)+1)
+
+	tenantIDStr := uuidToString(tenantID)
+	label, err := provider.CreateLabel(ctx, shipping.LabelParams{
+		TenantID:           tenantIDStr,
+		RateID:             params.RateID,
+		OriginAddress:      origin,
+		DestinationAddress: destinationAddress(order),
+		Package:            params.Package,
+		IdempotencyKey:     fmt.Sprintf("label_%s_%s", uuidToString(order.ID), params.RateID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to purchase label: %w", err)
+	}
+
+	// Keep our own copy of the label; provider-hosted URLs expire.
+	// If the copy fails the provider URL is still usable for now.
+	labelURL := label.LabelURL
+	var storageKey pgtype.Text
+	if key, url, err := s.storeLabel(ctx, tenantIDStr, shipmentNumber, label.LabelURL); err == nil {
+		labelURL = url
+		storageKey = pgtype.Text{String: key, Valid: true}
+	}
+
+	trackingURL := label.TrackingURL
+	if trackingURL == "" {
+		trackingURL = shipping.TrackingURL(label.Carrier, label.TrackingNumber)
+	}
+
+	shipment, err := s.repo.CreateLabelShipment(ctx, repository.CreateLabelShipmentParams{
+		TenantID:          tenantID,
+		OrderID:           order.ID,
+		ShipmentNumber:    shipmentNumber,
+		Carrier:           makePgText(label.Carrier),
+		ServiceName:       makePgText(label.ServiceName),
+		TrackingNumber:    makePgText(label.TrackingNumber),
+		TrackingUrl:       makePgText(trackingURL),
+		ShippingCostCents: order.ShippingCents,
+		LabelCostCents:    pgtype.Int4{Int32: int32(label.CostCents), Valid: label.CostCents > 0},
+		WeightGrams:       pgtype.Int4{Int32: params.Package.WeightGrams, Valid: true},
+		LengthCm:          numericFromInt(params.Package.LengthCm),
+		WidthCm:           numericFromInt(params.Package.WidthCm),
+		HeightCm:          numericFromInt(params.Package.HeightCm),
+		Provider:          makePgText(shippingProviderName(provider)),
+		ProviderLabelID:   makePgText(label.LabelID),
+		LabelUrl:          makePgText(labelURL),
+		LabelStorageKey:   storageKey,
+		LabelImageUrl:     makePgText(label.ImageURL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("label %s purchased but failed to record shipment: %w", label.LabelID, err)
+	}
+
+	for _, item := range unfulfilled {
+		_, err := s.repo.CreateShipmentItem(ctx, repository.CreateShipmentItemParams{
+			TenantID:    tenantID,
+			ShipmentID:  shipment.ID,
+			OrderItemID: item.ID,
+			Quantity:    item.QuantityRemaining,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create shipment item: %w", err)
+		}
+
+		err = s.repo.UpdateOrderItemDispatchedQuantity(ctx, repository.UpdateOrderItemDispatchedQuantityParams{
+			TenantID:           tenantID,
+			ID:                 item.ID,
+			QuantityDispatched: item.QuantityRemaining, // quantity to add
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update dispatched quantity: %w", err)
+		}
+	}
+
+	err = s.repo.RecalculateOrderFulfillmentStatus(ctx, repository.RecalculateOrderFulfillmentStatusParams{
+		TenantID: tenantID,
+		ID:       order.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to recalculate fulfillment status: %w", err)
+	}
+
+	// Refund statuses take precedence over shipped
+	if order.Status == "paid" || order.Status == "processing" {
+		err = s.repo.UpdateOrderStatus(ctx, repository.UpdateOrderStatusParams{
+			TenantID: tenantID,
+			ID:       order.ID,
+			Status:   "shipped",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update order status: %w", err)
+		}
+	}
+
+	s.enqueueShippingEmail(ctx, order, shipment)
+
+	return &shipment, nil
+}
+
+// VoidLabel voids a shipment's label and cancels the shipment.
+// Only labels that have not started moving can be voided.
+func (s *shippingLabelService) VoidLabel(ctx context.Context, orderID, shipmentID string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	shipment, err := s.getOrderShipment(ctx, tenantID, orderID, shipmentID)
+	if err != nil {
+		return err
+	}
+
+	if !shipment.ProviderLabelID.Valid {
+		return ErrShipmentHasNoLabel
+	}
+	if shipment.LabelVoidedAt.Valid || shipment.Status == "cancelled" {
+		return ErrLabelAlreadyVoided
+	}
+	if shipment.Status != "label_created" && shipment.Status != "pending" {
+		return ErrLabelAlreadyShipped
+	}
+
+	provider, err := s.provider(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	err = provider.VoidLabel(ctx, shipping.VoidLabelParams{
+		TenantID: uuidToString(tenantID),
+		LabelID:  shipment.ProviderLabelID.String,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to void label: %w", err)
+	}
+
+	err = s.repo.VoidShipmentLabel(ctx, repository.VoidShipmentLabelParams{
+		TenantID: tenantID,
+		ID:       shipment.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("label voided but failed to cancel shipment: %w", err)
+	}
+
+	items, err := s.repo.GetShipmentItems(ctx, shipment.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get shipment items: %w", err)
+	}
+
+	for _, item := range items {
+		err := s.repo.ReleaseOrderItemDispatchedQuantity(ctx, repository.ReleaseOrderItemDispatchedQuantityParams{
+			TenantID:           tenantID,
+			ID:                 item.OrderItemID,
+			QuantityDispatched: item.Quantity,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to release dispatched quantity: %w", err)
+		}
+	}
+
+	err = s.repo.RecalculateOrderFulfillmentStatus(ctx, repository.RecalculateOrderFulfillmentStatusParams{
+		TenantID: tenantID,
+		ID:       shipment.OrderID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to recalculate fulfillment status: %w", err)
+	}
+
+	if err := s.revertShippedStatus(ctx, tenantID, shipment.OrderID); err != nil {
+		return err
+	}
+
+	if shipment.LabelStorageKey.Valid {
+		_ = s.storage.Delete(ctx, shipment.LabelStorageKey.String)
+	}
+
+	return nil
+}
+
+// OpenLabel opens the stored label file for a shipment.
+func (s *shippingLabelService) OpenLabel(ctx context.Context, shipmentID string) (io.ReadCloser, *repository.Shipment, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shipment, err := s.getShipment(ctx, tenantID, shipmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !shipment.LabelStorageKey.Valid {
+		return nil, nil, ErrShipmentHasNoLabel
+	}
+
+	file, err := s.storage.Get(ctx, shipment.LabelStorageKey.String)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open label: %w", err)
+	}
+
+	return file, shipment, nil
+}
+
+// getShippableOrder loads an order and verifies labels can be bought for it.
+func (s *shippingLabelService) getShippableOrder(ctx context.Context, tenantID pgtype.UUID, orderID string) (repository.GetOrderWithDetailsRow, error) {
+	var orderUUID pgtype.UUID
+	if err := orderUUID.Scan(orderID); err != nil {
+		return repository.GetOrderWithDetailsRow{}, ErrOrderNotFound
+	}
+
+	order, err := s.repo.GetOrderWithDetails(ctx, repository.GetOrderWithDetailsParams{
+		TenantID: tenantID,
+		ID:       orderUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return order, ErrOrderNotFound
+		}
+		return order, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if !shippableStatuses[order.Status] {
+		return order, ErrOrderNotShippable
+	}
+
+	if !order.ShippingAddressLine1.Valid || order.ShippingAddressLine1.String == "" {
+		return order, ErrNoShippingAddress
+	}
+
+	return order, nil
+}
+
+// getShipment loads a shipment with tenant scoping.
+func (s *shippingLabelService) getShipment(ctx context.Context, tenantID pgtype.UUID, shipmentID string) (*repository.Shipment, error) {
+	var shipmentUUID pgtype.UUID
+	if err := shipmentUUID.Scan(shipmentID); err != nil {
+		return nil, ErrShipmentNotFound
+	}
+
+	shipment, err := s.repo.GetShipment(ctx, repository.GetShipmentParams{
+		TenantID: tenantID,
+		ID:       shipmentUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrShipmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	return &shipment, nil
+}
+
+// getOrderShipment loads one of an order's shipments with tenant scoping.
+// A shipment belonging to another order is reported as not found.
+func (s *shippingLabelService) getOrderShipment(ctx context.Context, tenantID pgtype.UUID, orderID, shipmentID string) (*repository.Shipment, error) {
+	var orderUUID, shipmentUUID pgtype.UUID
+	if err := orderUUID.Scan(orderID); err != nil {
+		return nil, ErrShipmentNotFound
+	}
+	if err := shipmentUUID.Scan(shipmentID); err != nil {
+		return nil, ErrShipmentNotFound
+	}
+
+	shipment, err := s.repo.GetShipmentForOrder(ctx, repository.GetShipmentForOrderParams{
+		TenantID: tenantID,
+		ID:       shipmentUUID,
+		OrderID:  orderUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrShipmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	return &shipment, nil
+}
+
+// provider resolves the tenant's shipping provider.
+func (s *shippingLabelService) provider(ctx context.Context, tenantID pgtype.UUID) (shipping.Provider, error) {
+	provider, err := s.providers.GetShippingProvider(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoLabelProvider, err)
+	}
+	return provider, nil
+}
+
+// originAddress returns the tenant's warehouse address as the label origin.
+func (s *shippingLabelService) originAddress(ctx context.Context, tenantID pgtype.UUID) (shipping.ShippingAddress, error) {
+	warehouse, err := s.repo.GetTenantWarehouseAddress(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return shipping.ShippingAddress{}, ErrNoWarehouseAddress
+		}
+		return shipping.ShippingAddress{}, fmt.Errorf("failed to get warehouse address: %w", err)
+	}
+
+	return shipping.ShippingAddress{
+		Name:       warehouse.FullName.String,
+		Company:    warehouse.Company.String,
+		Line1:      warehouse.AddressLine1,
+		Line2:      warehouse.AddressLine2.String,
+		City:       warehouse.City,
+		State:      warehouse.State,
+		PostalCode: warehouse.PostalCode,
+		Country:    warehouse.Country,
+		Phone:      warehouse.Phone.String,
+	}, nil
+}
+
+// storeLabel copies the provider's label file into storage.
+// Returns the storage key and URL.
+func (s *shippingLabelService) storeLabel(ctx context.Context, tenantID, shipmentNumber, labelURL string) (string, string, error) {
+	if labelURL == "" {
+		return "", "", ErrShipmentHasNoLabel
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, labelURL, nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to download label: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("failed to download label: status %d", resp.StatusCode)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/pdf"
+	}
+
+	key := path.Join("labels", tenantID, shipmentNumber+labelExtension(contentType, labelURL))
+
+	url, err := s.storage.Put(ctx, key, resp.Body, contentType)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to store label: %w", err)
+	}
+
+	return key, url, nil
+}
+
+// revertShippedStatus moves a shipped order back to processing once it has
+// no remaining active shipments.
+func (s *shippingLabelService) revertShippedStatus(ctx context.Context, tenantID, orderID pgtype.UUID) error {
+	order, err := s.repo.GetOrder(ctx, repository.GetOrderParams{
+		TenantID: tenantID,
+		ID:       orderID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if order.Status != "shipped" {
+		return nil
+	}
+
+	shipments, err := s.repo.GetShipmentsByOrderID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get shipments: %w", err)
+	}
+
+	for _, sh := range shipments {
+		if sh.Status != "cancelled" {
+			return nil
+		}
+	}
+
+	err = s.repo.UpdateOrderStatus(ctx, repository.UpdateOrderStatusParams{
+		TenantID: tenantID,
+		ID:       orderID,
+		Status:   "processing",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	return nil
+}
+
+// enqueueShippingEmail queues the shipping confirmation email.
+// Failures are not returned: the label has already been purchased.
+func (s *shippingLabelService) enqueueShippingEmail(ctx context.Context, order repository.GetOrderWithDetailsRow, shipment repository.Shipment) {
+	if !order.CustomerEmail.Valid || order.CustomerEmail.String == "" {
+		return
+	}
+
+	customerName := order.CustomerFirstName.String
+	if order.CustomerLastName.Valid && order.CustomerLastName.String != "" {
+		customerName += " " + order.CustomerLastName.String
+	}
+
+	payload := jobs.ShippingConfirmationPayload{
+		OrderID:        uuid.UUID(order.ID.Bytes),
+		Email:          order.CustomerEmail.String,
+		CustomerName:   customerName,
+		OrderNumber:    order.OrderNumber,
+		Carrier:        shipment.Carrier.String,
+		TrackingNumber: shipment.TrackingNumber.String,
+		TrackingURL:    shipment.TrackingUrl.String,
+	}
+
+	_ = jobs.EnqueueShippingConfirmationEmail(ctx, s.repo, uuid.UUID(order.TenantID.Bytes), payload)
+}
+
+// destinationAddress builds the label destination from the order's shipping address.
+func destinationAddress(order repository.GetOrderWithDetailsRow) shipping.ShippingAddress {
+	return shipping.ShippingAddress{
+		Name:       order.ShippingName.String,
+		Company:    order.ShippingCompany.String,
+		Line1:      order.ShippingAddressLine1.String,
+		Line2:      order.ShippingAddressLine2.String,
+		City:       order.ShippingCity.String,
+		State:      order.ShippingState.String,
+		PostalCode: order.ShippingPostalCode.String,
+		Country:    order.ShippingCountry.String,
+		Phone:      order.ShippingPhone.String,
+		Email:      order.CustomerEmail.String,
+	}
+}
+
+// validPackage reports whether a package has a weight and all dimensions.
+func validPackage(pkg shipping.Package) bool {
+	return pkg.WeightGrams > 0 && pkg.LengthCm > 0 && pkg.WidthCm > 0 && pkg.HeightCm > 0
+}
+
+// shippingProviderName returns the provider name recorded on shipments.
+func shippingProviderName(provider shipping.Provider) string {
+	switch provider.(type) {
+	case *shipping.EasyPostProvider:
+		return "easypost"
+	default:
+		return ""
+	}
+}
+
+// labelExtension picks a file extension for a label file.
+func labelExtension(contentType, labelURL string) string {
+	switch {
+	case strings.Contains(contentType, "pdf"):
+		return ".pdf"
+	case strings.Contains(contentType, "png"):
+		return ".png"
+	case strings.Contains(contentType, "zpl"):
+		return ".zpl"
+	}
+
+	if ext := path.Ext(strings.SplitN(labelURL, "?", 2)[0]); ext != "" {
+		return ext
+	}
+	return ".pdf"
+}
+
+// numericFromInt converts a whole number to pgtype.Numeric.
+func numericFromInt(n int32) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(int64(n)), Valid: true}
+}
@@ -0,0 +1,400 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/dukerupert/hiri/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// staticShippingProviders resolves every tenant to the same provider.
+type staticShippingProviders struct {
+	provider shipping.Provider
+	err      error
+}
+
+func (s staticShippingProviders) GetShippingProvider(_ context.Context, _ pgtype.UUID) (shipping.Provider, error) {
+	return s.provider, s.err
+}
+
+// labelFixture is a paid order with one line item of 2 x 12oz bags.
+type labelFixture struct {
+	order    repository.GetOrderWithDetailsRow
+	item     repository.GetUnfulfilledOrderItemsRow
+	provider *shipping.MockProvider
+	storage  storage.Storage
+}
+
+func newLabelFixture(t *testing.T, status string) labelFixture {
+	t.Helper()
+
+	tenantID := newUUID()
+	order := repository.GetOrderWithDetailsRow{
+		ID:                   newUUID(),
+		TenantID:             tenantID,
+		OrderNumber:          "ORD-2001",
+		Status:               status,
+		ShippingCents:        800,
+		CustomerEmail:        pgtype.Text{String: "buyer@example.com", Valid: true},
+		CustomerFirstName:    pgtype.Text{String: "Ada", Valid: true},
+		ShippingName:         pgtype.Text{String: "Ada Lovelace", Valid: true},
+		ShippingAddressLine1: pgtype.Text{String: "1 Main St", Valid: true},
+		ShippingCity:         pgtype.Text{String: "Portland", Valid: true},
+		ShippingState:        pgtype.Text{String: "OR", Valid: true},
+		ShippingPostalCode:   pgtype.Text{String: "97201", Valid: true},
+		ShippingCountry:      pgtype.Text{String: "US", Valid: true},
+	}
+	item := repository.GetUnfulfilledOrderItemsRow{
+		ID:                newUUID(),
+		OrderID:           order.ID,
+		Sku:               "ETH-12",
+		Quantity:          2,
+		QuantityRemaining: 2,
+	}
+
+	fileStorage, err := storage.NewLocalStorage(t.TempDir(), "/uploads")
+	require.NoError(t, err)
+
+	return labelFixture{order: order, item: item, provider: shipping.NewMockProvider(), storage: fileStorage}
+}
+
+func (f labelFixture) warehouse() repository.GetTenantWarehouseAddressRow {
+	return repository.GetTenantWarehouseAddressRow{
+		AddressLine1: "200 Roastery Rd",
+		City:         "Seattle",
+		State:        "WA",
+		PostalCode:   "98101",
+		Country:      "US",
+	}
+}
+
+var testPackage = shipping.Package{WeightGrams: 680, LengthCm: 25, WidthCm: 20, HeightCm: 10}
+
+func TestShippingLabelService_BuyLabel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := newLabelFixture(t, "paid")
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	labelServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("%PDF-label"))
+	}))
+	defer labelServer.Close()
+
+	var labelParams shipping.LabelParams
+	f.provider.CreateLabelFunc = func(_ context.Context, params shipping.LabelParams) (*shipping.Label, error) {
+		labelParams = params
+		return &shipping.Label{
+			LabelID:        "shp_123",
+			TrackingNumber: "9400100000000000000000",
+			Carrier:        "USPS",
+			ServiceName:    "Priority",
+			LabelURL:       labelServer.URL + "/label.pdf",
+			CostCents:      845,
+		}, nil
+	}
+
+	svc := NewShippingLabelService(mockRepo, staticShippingProviders{provider: f.provider}, f.storage)
+
+	mockRepo.EXPECT().GetOrderWithDetails(gomock.Any(), gomock.Any()).Return(f.order, nil)
+	mockRepo.EXPECT().GetUnfulfilledOrderItems(gomock.Any(), f.order.ID).
+		Return([]repository.GetUnfulfilledOrderItemsRow{f.item}, nil)
+	mockRepo.EXPECT().GetTenantWarehouseAddress(gomock.Any(), f.order.TenantID).Return(f.warehouse(), nil)
+	mockRepo.EXPECT().GetShipmentsByOrderID(gomock.Any(), f.order.ID).Return(nil, nil)
+
+	var created repository.CreateLabelShipmentParams
+	mockRepo.EXPECT().CreateLabelShipment(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.CreateLabelShipmentParams) (repository.Shipment, error) {
+			created = arg
+			return repository.Shipment{
+				ID:             newUUID(),
+				OrderID:        arg.OrderID,
+				ShipmentNumber: arg.ShipmentNumber,
+				Carrier:        arg.Carrier,
+				TrackingNumber: arg.TrackingNumber,
+				TrackingUrl:    arg.TrackingUrl,
+			}, nil
+		})
+	mockRepo.EXPECT().CreateShipmentItem(gomock.Any(), gomock.Any()).Return(repository.ShipmentItem{}, nil)
+	mockRepo.EXPECT().UpdateOrderItemDispatchedQuantity(gomock.Any(), repository.UpdateOrderItemDispatchedQuantityParams{
+		TenantID:           f.order.TenantID,
+		ID:                 f.item.ID,
+		QuantityDispatched: 2,
+	}).Return(nil)
+	mockRepo.EXPECT().RecalculateOrderFulfillmentStatus(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().UpdateOrderStatus(gomock.Any(), repository.UpdateOrderStatusParams{
+		TenantID: f.order.TenantID,
+		ID:       f.order.ID,
+		Status:   "shipped",
+	}).Return(nil)
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).Return(repository.Job{}, nil)
+
+	shipment, err := svc.BuyLabel(contextWithTenant(f.order.TenantID), domain.BuyLabelParams{
+		OrderID: uuidToString(f.order.ID),
+		RateID:  "rate_priority",
+		Package: testPackage,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "ORD-2001-1", shipment.ShipmentNumber)
+	assert.Equal(t, "98101", labelParams.OriginAddress.PostalCode)
+	assert.Equal(t, "97201", labelParams.DestinationAddress.PostalCode)
+	assert.Equal(t, "shp_123", created.ProviderLabelID.String)
+	assert.Equal(t, int32(845), created.LabelCostCents.Int32)
+	assert.Contains(t, created.TrackingUrl.String, "9400100000000000000000")
+	require.True(t, created.LabelStorageKey.Valid)
+
+	stored, err := f.storage.Get(context.Background(), created.LabelStorageKey.String)
+	require.NoError(t, err)
+	defer stored.Close()
+	body, _ := io.ReadAll(stored)
+	assert.Equal(t, "%PDF-label", string(body))
+}
+
+func TestShippingLabelService_BuyLabel_Validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      string
+		noAddress   bool
+		rateID      string
+		pkg         shipping.Package
+		loadsOrder  bool
+		providerErr error
+		wantErr     error
+	}{
+		{
+			name:    "missing rate",
+			status:  "paid",
+			pkg:     testPackage,
+			wantErr: ErrRateRequired,
+		},
+		{
+			name:    "zero weight",
+			status:  "paid",
+			rateID:  "rate_1",
+			pkg:     shipping.Package{LengthCm: 10, WidthCm: 10, HeightCm: 10},
+			wantErr: ErrInvalidPackage,
+		},
+		{
+			name:       "pending order",
+			status:     "pending",
+			rateID:     "rate_1",
+			pkg:        testPackage,
+			loadsOrder: true,
+			wantErr:    ErrOrderNotShippable,
+		},
+		{
+			name:       "no shipping address",
+			status:     "paid",
+			noAddress:  true,
+			rateID:     "rate_1",
+			pkg:        testPackage,
+			loadsOrder: true,
+			wantErr:    ErrNoShippingAddress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := newLabelFixture(t, tt.status)
+			if tt.noAddress {
+				f.order.ShippingAddressLine1 = pgtype.Text{}
+			}
+			mockRepo := repository.NewMockQuerier(ctrl)
+			svc := NewShippingLabelService(mockRepo, staticShippingProviders{provider: f.provider}, f.storage)
+
+			if tt.loadsOrder {
+				mockRepo.EXPECT().GetOrderWithDetails(gomock.Any(), gomock.Any()).Return(f.order, nil)
+			}
+
+			_, err := svc.BuyLabel(contextWithTenant(f.order.TenantID), domain.BuyLabelParams{
+				OrderID: uuidToString(f.order.ID),
+				RateID:  tt.rateID,
+				Package: tt.pkg,
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestShippingLabelService_GetRates_NoProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := newLabelFixture(t, "paid")
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewShippingLabelService(mockRepo, staticShippingProviders{err: errors.New("no shipping provider configured")}, f.storage)
+
+	mockRepo.EXPECT().GetOrderWithDetails(gomock.Any(), gomock.Any()).Return(f.order, nil)
+	mockRepo.EXPECT().GetTenantWarehouseAddress(gomock.Any(), f.order.TenantID).Return(f.warehouse(), nil)
+
+	_, err := svc.GetRates(contextWithTenant(f.order.TenantID), uuidToString(f.order.ID), testPackage)
+
+	assert.ErrorIs(t, err, ErrNoLabelProvider)
+	assert.Equal(t, domain.EINVALID, domain.ErrorCode(err))
+}
+
+func TestShippingLabelService_GetRates_SortedByCost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := newLabelFixture(t, "processing")
+	f.provider.GetRatesFunc = func(_ context.Context, _ shipping.RateParams) ([]shipping.Rate, error) {
+		return []shipping.Rate{
+			{RateID: "express", CostCents: 2400},
+			{RateID: "ground", CostCents: 650},
+			{RateID: "priority", CostCents: 845},
+		}, nil
+	}
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewShippingLabelService(mockRepo, staticShippingProviders{provider: f.provider}, f.storage)
+
+	mockRepo.EXPECT().GetOrderWithDetails(gomock.Any(), gomock.Any()).Return(f.order, nil)
+	mockRepo.EXPECT().GetTenantWarehouseAddress(gomock.Any(), f.order.TenantID).Return(f.warehouse(), nil)
+
+	rates, err := svc.GetRates(contextWithTenant(f.order.TenantID), uuidToString(f.order.ID), testPackage)
+
+	require.NoError(t, err)
+	require.Len(t, rates, 3)
+	assert.Equal(t, []string{"ground", "priority", "express"}, []string{rates[0].RateID, rates[1].RateID, rates[2].RateID})
+}
+
+func TestShippingLabelService_VoidLabel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := newLabelFixture(t, "shipped")
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	var voided string
+	f.provider.VoidLabelFunc = func(_ context.Context, params shipping.VoidLabelParams) error {
+		voided = params.LabelID
+		return nil
+	}
+	svc := NewShippingLabelService(mockRepo, staticShippingProviders{provider: f.provider}, f.storage)
+
+	shipment := repository.Shipment{
+		ID:              newUUID(),
+		TenantID:        f.order.TenantID,
+		OrderID:         f.order.ID,
+		Status:          "label_created",
+		ProviderLabelID: pgtype.Text{String: "shp_123", Valid: true},
+	}
+
+	mockRepo.EXPECT().GetShipmentForOrder(gomock.Any(), repository.GetShipmentForOrderParams{
+		TenantID: f.order.TenantID,
+		ID:       shipment.ID,
+		OrderID:  f.order.ID,
+	}).Return(shipment, nil)
+	mockRepo.EXPECT().VoidShipmentLabel(gomock.Any(), repository.VoidShipmentLabelParams{
+		TenantID: f.order.TenantID,
+		ID:       shipment.ID,
+	}).Return(nil)
+	mockRepo.EXPECT().GetShipmentItems(gomock.Any(), shipment.ID).Return([]repository.GetShipmentItemsRow{
+		{OrderItemID: f.item.ID, Quantity: 2},
+	}, nil)
+	mockRepo.EXPECT().ReleaseOrderItemDispatchedQuantity(gomock.Any(), repository.ReleaseOrderItemDispatchedQuantityParams{
+		TenantID:           f.order.TenantID,
+		ID:                 f.item.ID,
+		QuantityDispatched: 2,
+	}).Return(nil)
+	mockRepo.EXPECT().RecalculateOrderFulfillmentStatus(gomock.Any(), gomock.Any()).Return(nil)
+
+	// The only shipment is now cancelled, so the order goes back to processing
+	mockRepo.EXPECT().GetOrder(gomock.Any(), gomock.Any()).Return(repository.Order{ID: f.order.ID, Status: "shipped"}, nil)
+	cancelled := shipment
+	cancelled.Status = "cancelled"
+	mockRepo.EXPECT().GetShipmentsByOrderID(gomock.Any(), f.order.ID).Return([]repository.Shipment{cancelled}, nil)
+	mockRepo.EXPECT().UpdateOrderStatus(gomock.Any(), repository.UpdateOrderStatusParams{
+		TenantID: f.order.TenantID,
+		ID:       f.order.ID,
+		Status:   "processing",
+	}).Return(nil)
+
+	err := svc.VoidLabel(contextWithTenant(f.order.TenantID), uuidToString(f.order.ID), uuidToString(shipment.ID))
+
+	require.NoError(t, err)
+	assert.Equal(t, "shp_123", voided)
+}
+
+func TestShippingLabelService_VoidLabel_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		shipment repository.Shipment
+		wantErr  error
+	}{
+		{
+			name:     "manual shipment",
+			shipment: repository.Shipment{Status: "label_created"},
+			wantErr:  ErrShipmentHasNoLabel,
+		},
+		{
+			name: "already voided",
+			shipment: repository.Shipment{
+				Status:          "cancelled",
+				ProviderLabelID: pgtype.Text{String: "shp_1", Valid: true},
+			},
+			wantErr: ErrLabelAlreadyVoided,
+		},
+		{
+			name: "in transit",
+			shipment: repository.Shipment{
+				Status:          "in_transit",
+				ProviderLabelID: pgtype.Text{String: "shp_1", Valid: true},
+			},
+			wantErr: ErrLabelAlreadyShipped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := newLabelFixture(t, "shipped")
+			mockRepo := repository.NewMockQuerier(ctrl)
+			svc := NewShippingLabelService(mockRepo, staticShippingProviders{provider: f.provider}, f.storage)
+
+			mockRepo.EXPECT().GetShipmentForOrder(gomock.Any(), gomock.Any()).Return(tt.shipment, nil)
+
+			err := svc.VoidLabel(contextWithTenant(f.order.TenantID), uuidToString(f.order.ID), uuidToString(newUUID()))
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestShippingLabelService_VoidLabel_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := newLabelFixture(t, "shipped")
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewShippingLabelService(mockRepo, staticShippingProviders{provider: f.provider}, f.storage)
+
+	// The shipment belongs to another order
+	mockRepo.EXPECT().GetShipmentForOrder(gomock.Any(), gomock.Any()).Return(repository.Shipment{}, pgx.ErrNoRows)
+	mockRepo.EXPECT().VoidShipmentLabel(gomock.Any(), gomock.Any()).Times(0)
+
+	err := svc.VoidLabel(contextWithTenant(f.order.TenantID), uuidToString(f.order.ID), uuidToString(newUUID()))
+
+	assert.ErrorIs(t, err, ErrShipmentNotFound)
+}
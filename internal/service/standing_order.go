@@ -0,0 +1,873 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/address"
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type standingOrderService struct {
+	repo             repository.Querier
+	cartService      domain.CartService
+	checkoutService  CheckoutService
+	onAccountService domain.OnAccountService
+	baseURL          string
+	now              func() time.Time
+}
+
+// NewStandingOrderService creates a new StandingOrderService instance.
+// Each order is built in a fresh cart and placed through the on-account
+// service, so it is priced, checked against order rules and credit limits,
+// and invoiced exactly like an order the customer places themselves.
+// baseURL is used to build the links in reminder emails.
+func NewStandingOrderService(
+	repo repository.Querier,
+	cartService domain.CartService,
+	checkoutService CheckoutService,
+	onAccountService domain.OnAccountService,
+	baseURL string,
+) domain.StandingOrderService {
+	return &standingOrderService{
+		repo:             repo,
+		cartService:      cartService,
+		checkoutService:  checkoutService,
+		onAccountService: onAccountService,
+		baseURL:          baseURL,
+		now:              time.Now,
+	}
+}
+
+// GetSettings returns the tenant's lead times, or the default.
+func (s *standingOrderService) GetSettings(ctx context.Context) (*domain.StandingOrderSettings, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// UpdateSettings validates and saves the tenant's lead times.
+func (s *standingOrderService) UpdateSettings(ctx context.Context, settings domain.StandingOrderSettings) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal standing order settings: %w", err)
+	}
+
+	err = s.repo.UpdateTenantStandingOrderSettings(ctx, repository.UpdateTenantStandingOrderSettingsParams{
+		ID:             tenantID,
+		StandingOrders: settingsJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update standing order settings: %w", err)
+	}
+
+	return nil
+}
+
+// ListForCustomer returns a customer's standing orders.
+func (s *standingOrderService) ListForCustomer(ctx context.Context, userID string) ([]domain.StandingOrderDetail, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var userUUID pgtype.UUID
+	if err := userUUID.Scan(userID); err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	standingOrders, err := s.repo.ListStandingOrdersForUser(ctx, repository.ListStandingOrdersForUserParams{
+		TenantID: tenantID,
+		UserID:   userUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list standing orders: %w", err)
+	}
+
+	settings, err := s.getSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]domain.StandingOrderDetail, len(standingOrders))
+	for i, standingOrder := range standingOrders {
+		details[i] = domain.StandingOrderDetail{
+			StandingOrder: standingOrder,
+			CutoffDate:    settings.CutoffDate(standingOrder.NextDeliveryDate.Time),
+		}
+	}
+
+	return details, nil
+}
+
+// GetForCustomer returns one of a customer's standing orders with its items
+// and delivery address.
+func (s *standingOrderService) GetForCustomer(ctx context.Context, userID, standingOrderID string) (*domain.StandingOrderDetail, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	standingOrder, err := s.getForCustomer(ctx, tenantID, userID, standingOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.detail(ctx, tenantID, standingOrder)
+}
+
+// CreateFromCart saves the items in the customer's cart as a new standing
+// order.
+func (s *standingOrderService) CreateFromCart(ctx context.Context, params domain.CreateStandingOrderParams) (*domain.StandingOrderDetail, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.onAccountService.GetAccountCredit(ctx, params.UserID); err != nil {
+		return nil, err
+	}
+
+	if !domain.ValidStandingOrderSchedule(params.IntervalWeeks, params.DeliveryWeekday) {
+		return nil, ErrInvalidStandingOrderSchedule
+	}
+
+	var userID pgtype.UUID
+	if err := userID.Scan(params.UserID); err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	shippingAddress, err := s.customerAddress(ctx, tenantID, userID, params.AddressID)
+	if err != nil {
+		return nil, err
+	}
+
+	var cartID pgtype.UUID
+	if err := cartID.Scan(params.CartID); err != nil {
+		return nil, ErrCartNotFound
+	}
+
+	cart, err := s.repo.GetCartByID(ctx, repository.GetCartByIDParams{
+		TenantID: tenantID,
+		ID:       cartID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCartNotFound
+		}
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+	if cart.UserID.Valid && cart.UserID != userID {
+		return nil, ErrCartNotFound
+	}
+
+	cartItems, err := s.repo.GetCartItems(ctx, cart.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart items: %w", err)
+	}
+	if len(cartItems) == 0 {
+		return nil, ErrEmptyCart
+	}
+
+	settings, err := s.getSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		name = "Standing order"
+	}
+
+	standingOrder, err := s.repo.CreateStandingOrder(ctx, repository.CreateStandingOrderParams{
+		TenantID:          tenantID,
+		UserID:            userID,
+		Name:              name,
+		IntervalWeeks:     params.IntervalWeeks,
+		DeliveryWeekday:   params.DeliveryWeekday,
+		NextDeliveryDate:  pgDate(settings.NextDeliveryDate(s.today(), params.DeliveryWeekday)),
+		ShippingAddressID: shippingAddress.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create standing order: %w", err)
+	}
+
+	for _, item := range cartItems {
+		err := s.repo.UpsertStandingOrderItem(ctx, repository.UpsertStandingOrderItemParams{
+			TenantID:        tenantID,
+			StandingOrderID: standingOrder.ID,
+			ProductSkuID:    item.ProductSkuID,
+			Quantity:        item.Quantity,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add standing order item: %w", err)
+		}
+	}
+
+	return &domain.StandingOrderDetail{
+		StandingOrder: standingOrder,
+		CutoffDate:    settings.CutoffDate(standingOrder.NextDeliveryDate.Time),
+	}, nil
+}
+
+// UpdateItems sets the quantity of each SKU in the standing order.
+func (s *standingOrderService) UpdateItems(ctx context.Context, userID, standingOrderID string, quantities map[string]int) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	standingOrder, err := s.getEditable(ctx, tenantID, userID, standingOrderID)
+	if err != nil {
+		return err
+	}
+
+	items, err := s.repo.ListStandingOrderItems(ctx, repository.ListStandingOrderItemsParams{
+		TenantID:        tenantID,
+		StandingOrderID: standingOrder.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get standing order items: %w", err)
+	}
+
+	// Only SKUs already in the standing order are changed
+	remaining := len(items)
+	for _, item := range items {
+		quantity, ok := quantities[uuidToString(item.ProductSkuID)]
+		if !ok {
+			continue
+		}
+		if quantity < 0 {
+			return ErrInvalidQuantity
+		}
+		if quantity == 0 {
+			remaining--
+		}
+	}
+	if remaining == 0 {
+		return ErrStandingOrderEmpty
+	}
+
+	for _, item := range items {
+		quantity, ok := quantities[uuidToString(item.ProductSkuID)]
+		if !ok || int32(quantity) == item.Quantity {
+			continue
+		}
+
+		if quantity == 0 {
+			err = s.repo.DeleteStandingOrderItem(ctx, repository.DeleteStandingOrderItemParams{
+				TenantID:        tenantID,
+				StandingOrderID: standingOrder.ID,
+				ProductSkuID:    item.ProductSkuID,
+			})
+		} else {
+			err = s.repo.UpsertStandingOrderItem(ctx, repository.UpsertStandingOrderItemParams{
+				TenantID:        tenantID,
+				StandingOrderID: standingOrder.ID,
+				ProductSkuID:    item.ProductSkuID,
+				Quantity:        int32(quantity),
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update standing order item: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// UpdateSchedule changes the name, cadence, delivery day and address.
+func (s *standingOrderService) UpdateSchedule(ctx context.Context, params domain.UpdateStandingOrderScheduleParams) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	standingOrder, err := s.getEditable(ctx, tenantID, params.UserID, params.StandingOrderID)
+	if err != nil {
+		return err
+	}
+
+	if !domain.ValidStandingOrderSchedule(params.IntervalWeeks, params.DeliveryWeekday) {
+		return ErrInvalidStandingOrderSchedule
+	}
+
+	shippingAddress, err := s.customerAddress(ctx, tenantID, standingOrder.UserID, params.AddressID)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		name = standingOrder.Name
+	}
+
+	next := standingOrder.NextDeliveryDate.Time
+	if params.DeliveryWeekday != standingOrder.DeliveryWeekday {
+		settings, err := s.getSettings(ctx, tenantID)
+		if err != nil {
+			return err
+		}
+
+		// Same week as the delivery it replaces, so a skip isn't undone
+		next = next.AddDate(0, 0, int(params.DeliveryWeekday-isoWeekday(next)))
+		if earliest := settings.NextDeliveryDate(s.today(), params.DeliveryWeekday); next.Before(earliest) {
+			next = earliest
+		}
+	}
+
+	_, err = s.repo.UpdateStandingOrderSchedule(ctx, repository.UpdateStandingOrderScheduleParams{
+		ID:                standingOrder.ID,
+		TenantID:          tenantID,
+		Name:              name,
+		IntervalWeeks:     params.IntervalWeeks,
+		DeliveryWeekday:   params.DeliveryWeekday,
+		NextDeliveryDate:  pgDate(next),
+		ShippingAddressID: shippingAddress.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update standing order schedule: %w", err)
+	}
+
+	return nil
+}
+
+// Skip moves an active standing order to its following delivery.
+func (s *standingOrderService) Skip(ctx context.Context, userID, standingOrderID string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	standingOrder, err := s.getEditable(ctx, tenantID, userID, standingOrderID)
+	if err != nil {
+		return err
+	}
+	if standingOrder.Status != domain.StandingOrderStatusActive {
+		return ErrStandingOrderNotActive
+	}
+
+	_, err = s.repo.SkipStandingOrderDelivery(ctx, repository.SkipStandingOrderDeliveryParams{
+		ID:               standingOrder.ID,
+		TenantID:         tenantID,
+		NextDeliveryDate: pgDate(followingDelivery(standingOrder)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to skip standing order delivery: %w", err)
+	}
+
+	return nil
+}
+
+// Pause stops generating orders until the standing order is resumed.
+func (s *standingOrderService) Pause(ctx context.Context, userID, standingOrderID string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	standingOrder, err := s.getEditable(ctx, tenantID, userID, standingOrderID)
+	if err != nil {
+		return err
+	}
+	if standingOrder.Status == domain.StandingOrderStatusPaused {
+		return nil
+	}
+
+	return s.setStatus(ctx, standingOrder, domain.StandingOrderStatusPaused, standingOrder.NextDeliveryDate.Time)
+}
+
+// Resume restarts a paused standing order from its next delivery day.
+func (s *standingOrderService) Resume(ctx context.Context, userID, standingOrderID string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	standingOrder, err := s.getEditable(ctx, tenantID, userID, standingOrderID)
+	if err != nil {
+		return err
+	}
+	if standingOrder.Status == domain.StandingOrderStatusActive {
+		return nil
+	}
+
+	settings, err := s.getSettings(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	// Deliveries missed while paused are not placed
+	next := standingOrder.NextDeliveryDate.Time
+	if earliest := settings.NextDeliveryDate(s.today(), standingOrder.DeliveryWeekday); next.Before(earliest) {
+		next = earliest
+	}
+
+	return s.setStatus(ctx, standingOrder, domain.StandingOrderStatusActive, next)
+}
+
+// Cancel stops a standing order for good.
+func (s *standingOrderService) Cancel(ctx context.Context, userID, standingOrderID string) error {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	standingOrder, err := s.getForCustomer(ctx, tenantID, userID, standingOrderID)
+	if err != nil {
+		return err
+	}
+	if standingOrder.Status == domain.StandingOrderStatusCancelled {
+		return nil
+	}
+
+	return s.setStatus(ctx, standingOrder, domain.StandingOrderStatusCancelled, standingOrder.NextDeliveryDate.Time)
+}
+
+// ListAll returns every standing order in the tenant.
+func (s *standingOrderService) ListAll(ctx context.Context) ([]repository.ListStandingOrdersRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	standingOrders, err := s.repo.ListStandingOrders(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list standing orders: %w", err)
+	}
+
+	return standingOrders, nil
+}
+
+// ProcessDue places the orders whose cutoff has been reached and emails
+// reminders for those whose cutoff is coming up.
+func (s *standingOrderService) ProcessDue(ctx context.Context) (*domain.StandingOrderResult, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := s.today()
+	generateThrough := today.AddDate(0, 0, int(settings.CutoffDays))
+
+	due, err := s.repo.ListDueStandingOrders(ctx, repository.ListDueStandingOrdersParams{
+		TenantID:        tenantID,
+		GenerateThrough: pgDate(generateThrough),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due standing orders: %w", err)
+	}
+
+	result := &domain.StandingOrderResult{}
+	for _, standingOrder := range due {
+		// A delivery that couldn't be placed is not retried: the next run
+		// would be past its cutoff too. Deliveries missed while the worker
+		// was down are skipped the same way.
+		next := followingDelivery(standingOrder)
+		for !next.After(generateThrough) {
+			next = next.AddDate(0, 0, 7*int(standingOrder.IntervalWeeks))
+		}
+
+		// Claim the delivery before placing it, so a retried or overlapping
+		// run can't place it a second time
+		_, err := s.repo.ClaimStandingOrderDelivery(ctx, repository.ClaimStandingOrderDeliveryParams{
+			ID:               standingOrder.ID,
+			TenantID:         tenantID,
+			NextDeliveryDate: pgDate(next),
+			DueDate:          standingOrder.NextDeliveryDate,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return result, fmt.Errorf("failed to claim standing order delivery: %w", err)
+		}
+
+		orderID, placeErr := s.placeOrder(ctx, standingOrder)
+
+		params := repository.RecordStandingOrderGeneratedParams{
+			ID:       standingOrder.ID,
+			TenantID: tenantID,
+			OrderID:  orderID,
+		}
+		if placeErr != nil {
+			params.LastError = makePgText(standingOrderFailureReason(placeErr))
+			result.Failed++
+		} else {
+			result.Placed++
+		}
+
+		if err := s.repo.RecordStandingOrderGenerated(ctx, params); err != nil {
+			return result, fmt.Errorf("failed to record standing order: %w", err)
+		}
+	}
+
+	if settings.ReminderDays == 0 {
+		return result, nil
+	}
+
+	upcoming, err := s.repo.ListStandingOrdersToRemind(ctx, repository.ListStandingOrdersToRemindParams{
+		TenantID:      tenantID,
+		RemindThrough: pgDate(generateThrough.AddDate(0, 0, int(settings.ReminderDays))),
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to list standing orders to remind: %w", err)
+	}
+
+	for _, standingOrder := range upcoming {
+		if err := s.sendReminder(ctx, tenantID, settings, standingOrder); err != nil {
+			return result, err
+		}
+		result.Reminded++
+	}
+
+	return result, nil
+}
+
+// placeOrder builds the standing order in a new cart and places it on
+// account for delivery to the saved address by the cheapest shipping rate.
+// It returns the new order's ID.
+func (s *standingOrderService) placeOrder(ctx context.Context, standingOrder repository.StandingOrder) (pgtype.UUID, error) {
+	items, err := s.repo.ListStandingOrderItems(ctx, repository.ListStandingOrderItemsParams{
+		TenantID:        standingOrder.TenantID,
+		StandingOrderID: standingOrder.ID,
+	})
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("failed to get standing order items: %w", err)
+	}
+	if len(items) == 0 {
+		return pgtype.UUID{}, ErrStandingOrderEmpty
+	}
+	for _, item := range items {
+		if !item.Available {
+			return pgtype.UUID{}, domain.Errorf(domain.EINVALID, "", "%s (%s) is no longer available", item.ProductName, item.Sku)
+		}
+	}
+
+	row, err := s.repo.GetAddressByIDForUser(ctx, repository.GetAddressByIDForUserParams{
+		ID:       standingOrder.ShippingAddressID,
+		TenantID: standingOrder.TenantID,
+		UserID:   standingOrder.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgtype.UUID{}, ErrStandingOrderAddress
+		}
+		return pgtype.UUID{}, fmt.Errorf("failed to get delivery address: %w", err)
+	}
+	shippingAddress := address.Address{
+		FullName:     row.FullName.String,
+		Company:      row.Company.String,
+		AddressLine1: row.AddressLine1,
+		AddressLine2: row.AddressLine2.String,
+		City:         row.City,
+		State:        row.State,
+		PostalCode:   row.PostalCode,
+		Country:      row.Country,
+		Phone:        row.Phone.String,
+	}
+
+	// The cart has no session: it belongs to the customer only
+	cart, err := s.repo.CreateCart(ctx, repository.CreateCartParams{
+		TenantID: standingOrder.TenantID,
+	})
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("failed to create cart: %w", err)
+	}
+	cartID := uuidToString(cart.ID)
+	userID := uuidToString(standingOrder.UserID)
+
+	order, err := s.placeCart(ctx, cartID, userID, standingOrder, items, shippingAddress)
+	if err != nil {
+		_ = s.repo.UpdateCartStatus(ctx, repository.UpdateCartStatusParams{
+			TenantID: standingOrder.TenantID,
+			ID:       cart.ID,
+			Status:   "abandoned",
+		})
+		return pgtype.UUID{}, err
+	}
+
+	return order.Order.ID, nil
+}
+
+// placeCart fills the cart, priced from the customer's price list, and
+// places it on account.
+func (s *standingOrderService) placeCart(ctx context.Context, cartID, userID string, standingOrder repository.StandingOrder, items []repository.ListStandingOrderItemsRow, shippingAddress address.Address) (*OrderDetail, error) {
+	if err := s.cartService.SetUser(ctx, cartID, userID); err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if _, err := s.cartService.AddItem(ctx, cartID, uuidToString(item.ProductSkuID), int(item.Quantity)); err != nil {
+			return nil, err
+		}
+	}
+
+	rates, err := s.checkoutService.GetShippingRates(ctx, cartID, shippingAddress)
+	if err != nil {
+		return nil, err
+	}
+	rate, ok := cheapestRate(rates)
+	if !ok {
+		return nil, ErrShippingRateUnavailable
+	}
+
+	return s.onAccountService.PlaceOrder(ctx, domain.PlaceOnAccountOrderParams{
+		CartID:          cartID,
+		UserID:          userID,
+		ShippingAddress: shippingAddress,
+		BillingAddress:  shippingAddress,
+		ShippingRate:    rate,
+		CustomerNotes: fmt.Sprintf("Standing order %q for delivery %s",
+			standingOrder.Name, standingOrder.NextDeliveryDate.Time.Format("Monday, January 2")),
+	})
+}
+
+// sendReminder emails the customer the items in an upcoming standing order
+// and records the delivery it was sent for.
+func (s *standingOrderService) sendReminder(ctx context.Context, tenantID pgtype.UUID, settings domain.StandingOrderSettings, standingOrder repository.ListStandingOrdersToRemindRow) error {
+	items, err := s.repo.ListStandingOrderItems(ctx, repository.ListStandingOrderItemsParams{
+		TenantID:        tenantID,
+		StandingOrderID: standingOrder.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get standing order items: %w", err)
+	}
+
+	reminderItems := make([]jobs.StandingOrderReminderItemData, len(items))
+	for i, item := range items {
+		reminderItems[i] = jobs.StandingOrderReminderItemData{
+			ProductName: item.ProductName,
+			SKU:         item.Sku,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	customerName := standingOrder.CompanyName.String
+	if customerName == "" {
+		customerName = strings.TrimSpace(standingOrder.FirstName.String + " " + standingOrder.LastName.String)
+	}
+
+	delivery := standingOrder.NextDeliveryDate.Time
+	payload := jobs.StandingOrderReminderPayload{
+		Email:             standingOrder.Email,
+		CustomerName:      customerName,
+		StandingOrderName: standingOrder.Name,
+		DeliveryDate:      delivery,
+		CutoffDate:        settings.CutoffDate(delivery),
+		Items:             reminderItems,
+		ManageURL:         s.baseURL + "/account/standing-orders/" + uuidToString(standingOrder.ID),
+	}
+	if err := jobs.EnqueueStandingOrderReminderEmail(ctx, s.repo, uuid.UUID(tenantID.Bytes), payload); err != nil {
+		return fmt.Errorf("failed to enqueue standing order reminder: %w", err)
+	}
+
+	err = s.repo.MarkStandingOrderReminded(ctx, repository.MarkStandingOrderRemindedParams{
+		ID:              standingOrder.ID,
+		TenantID:        tenantID,
+		RemindedForDate: standingOrder.NextDeliveryDate,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record standing order reminder: %w", err)
+	}
+
+	return nil
+}
+
+// getForCustomer loads a standing order, validating it belongs to the customer.
+func (s *standingOrderService) getForCustomer(ctx context.Context, tenantID pgtype.UUID, userID, standingOrderID string) (repository.StandingOrder, error) {
+	var userUUID, standingOrderUUID pgtype.UUID
+	if err := userUUID.Scan(userID); err != nil {
+		return repository.StandingOrder{}, ErrStandingOrderNotFound
+	}
+	if err := standingOrderUUID.Scan(standingOrderID); err != nil {
+		return repository.StandingOrder{}, ErrStandingOrderNotFound
+	}
+
+	standingOrder, err := s.repo.GetStandingOrderForUser(ctx, repository.GetStandingOrderForUserParams{
+		ID:       standingOrderUUID,
+		TenantID: tenantID,
+		UserID:   userUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.StandingOrder{}, ErrStandingOrderNotFound
+		}
+		return repository.StandingOrder{}, fmt.Errorf("failed to get standing order: %w", err)
+	}
+
+	return standingOrder, nil
+}
+
+// getEditable loads a customer's standing order that hasn't been cancelled.
+func (s *standingOrderService) getEditable(ctx context.Context, tenantID pgtype.UUID, userID, standingOrderID string) (repository.StandingOrder, error) {
+	standingOrder, err := s.getForCustomer(ctx, tenantID, userID, standingOrderID)
+	if err != nil {
+		return repository.StandingOrder{}, err
+	}
+	if standingOrder.Status == domain.StandingOrderStatusCancelled {
+		return repository.StandingOrder{}, ErrStandingOrderCancelled
+	}
+	return standingOrder, nil
+}
+
+// detail loads the items and delivery address for a standing order.
+func (s *standingOrderService) detail(ctx context.Context, tenantID pgtype.UUID, standingOrder repository.StandingOrder) (*domain.StandingOrderDetail, error) {
+	settings, err := s.getSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.ListStandingOrderItems(ctx, repository.ListStandingOrderItemsParams{
+		TenantID:        tenantID,
+		StandingOrderID: standingOrder.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get standing order items: %w", err)
+	}
+
+	detail := &domain.StandingOrderDetail{
+		StandingOrder: standingOrder,
+		CutoffDate:    settings.CutoffDate(standingOrder.NextDeliveryDate.Time),
+		Items:         items,
+	}
+
+	// The address may since have been removed from the address book
+	shippingAddress, err := s.repo.GetAddressByIDForUser(ctx, repository.GetAddressByIDForUserParams{
+		ID:       standingOrder.ShippingAddressID,
+		TenantID: tenantID,
+		UserID:   standingOrder.UserID,
+	})
+	if err == nil {
+		detail.ShippingAddress = &shippingAddress
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get delivery address: %w", err)
+	}
+
+	return detail, nil
+}
+
+// customerAddress loads an address from the customer's address book.
+func (s *standingOrderService) customerAddress(ctx context.Context, tenantID, userID pgtype.UUID, addressID string) (repository.GetAddressByIDForUserRow, error) {
+	var addressUUID pgtype.UUID
+	if err := addressUUID.Scan(addressID); err != nil {
+		return repository.GetAddressByIDForUserRow{}, ErrStandingOrderAddress
+	}
+
+	row, err := s.repo.GetAddressByIDForUser(ctx, repository.GetAddressByIDForUserParams{
+		ID:       addressUUID,
+		TenantID: tenantID,
+		UserID:   userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.GetAddressByIDForUserRow{}, ErrStandingOrderAddress
+		}
+		return repository.GetAddressByIDForUserRow{}, fmt.Errorf("failed to get address: %w", err)
+	}
+
+	return row, nil
+}
+
+// setStatus pauses, resumes or cancels a standing order.
+func (s *standingOrderService) setStatus(ctx context.Context, standingOrder repository.StandingOrder, status string, next time.Time) error {
+	_, err := s.repo.UpdateStandingOrderStatus(ctx, repository.UpdateStandingOrderStatusParams{
+		ID:               standingOrder.ID,
+		TenantID:         standingOrder.TenantID,
+		Status:           status,
+		NextDeliveryDate: pgDate(next),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update standing order status: %w", err)
+	}
+	return nil
+}
+
+func (s *standingOrderService) getSettings(ctx context.Context, tenantID pgtype.UUID) (domain.StandingOrderSettings, error) {
+	raw, err := s.repo.GetTenantStandingOrderSettings(ctx, tenantID)
+	if err != nil {
+		return domain.StandingOrderSettings{}, fmt.Errorf("failed to get standing order settings: %w", err)
+	}
+
+	var settings domain.StandingOrderSettings
+	if err := json.Unmarshal(raw, &settings); err != nil || settings.Validate() != nil {
+		return domain.DefaultStandingOrderSettings(), nil
+	}
+	return settings, nil
+}
+
+// today returns the current date in UTC, the zone schedules run in.
+func (s *standingOrderService) today() time.Time {
+	now := s.now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// followingDelivery returns the delivery after the standing order's next one.
+func followingDelivery(standingOrder repository.StandingOrder) time.Time {
+	return standingOrder.NextDeliveryDate.Time.AddDate(0, 0, 7*int(standingOrder.IntervalWeeks))
+}
+
+// cheapestRate returns the lowest cost shipping rate.
+func cheapestRate(rates []shipping.Rate) (shipping.Rate, bool) {
+	if len(rates) == 0 {
+		return shipping.Rate{}, false
+	}
+	cheapest := rates[0]
+	for _, rate := range rates[1:] {
+		if rate.CostCents < cheapest.CostCents {
+			cheapest = rate
+		}
+	}
+	return cheapest, true
+}
+
+// standingOrderFailureReason describes why a standing order couldn't be
+// placed. Customer-facing messages are kept as they are; anything else is
+// recorded in full for operators.
+func standingOrderFailureReason(err error) string {
+	if domain.ErrorCode(err) != domain.EINTERNAL {
+		return domain.ErrorMessage(err)
+	}
+	return err.Error()
+}
+
+// isoWeekday returns the ISO weekday of t: 1 = Monday, 7 = Sunday.
+func isoWeekday(t time.Time) int32 {
+	return int32((int(t.Weekday())+6)%7 + 1)
+}
+
+// pgDate converts a date for a DATE column.
+func pgDate(t time.Time) pgtype.Date {
+	return pgtype.Date{Time: t, Valid: true}
+}
@@ -0,0 +1,440 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/jobs"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// recordingCartService records the items added to a cart.
+type recordingCartService struct {
+	domain.CartService
+	userID string
+	added  map[string]int
+}
+
+func (s *recordingCartService) SetUser(_ context.Context, _, userID string) error {
+	s.userID = userID
+	return nil
+}
+
+func (s *recordingCartService) AddItem(_ context.Context, _, skuID string, quantity int) (*domain.CartSummary, error) {
+	if s.added == nil {
+		s.added = make(map[string]int)
+	}
+	s.added[skuID] = quantity
+	return &domain.CartSummary{}, nil
+}
+
+// stubOnAccountService lets every customer order on account and records the
+// orders placed.
+type stubOnAccountService struct {
+	domain.OnAccountService
+	creditErr error
+	orderID   pgtype.UUID
+	placed    []domain.PlaceOnAccountOrderParams
+}
+
+func (s *stubOnAccountService) GetAccountCredit(_ context.Context, _ string) (*domain.AccountCredit, error) {
+	if s.creditErr != nil {
+		return nil, s.creditErr
+	}
+	return &domain.AccountCredit{}, nil
+}
+
+func (s *stubOnAccountService) PlaceOrder(_ context.Context, params domain.PlaceOnAccountOrderParams) (*OrderDetail, error) {
+	s.placed = append(s.placed, params)
+	return &OrderDetail{Order: repository.Order{ID: s.orderID}}, nil
+}
+
+// standingOrderNow is Friday, October 16, 2026.
+var standingOrderNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func deliveryDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestStandingOrderSettings_NextDeliveryDate(t *testing.T) {
+	settings := domain.DefaultStandingOrderSettings()
+	today := deliveryDate(2026, 10, 16) // Friday; the earliest delivery is Monday 19th
+
+	tests := []struct {
+		weekday int32
+		want    time.Time
+	}{
+		{weekday: 1, want: deliveryDate(2026, 10, 19)},
+		{weekday: 2, want: deliveryDate(2026, 10, 20)},
+		{weekday: 5, want: deliveryDate(2026, 10, 23)},
+		{weekday: 7, want: deliveryDate(2026, 10, 25)},
+	}
+
+	for _, tt := range tests {
+		got := settings.NextDeliveryDate(today, tt.weekday)
+		assert.Equal(t, tt.want, got, "weekday %d", tt.weekday)
+		assert.Equal(t, tt.weekday, isoWeekday(got))
+	}
+}
+
+func TestStandingOrderService_ProcessDue_PlacesOrderAndReminds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	orderID := newUUID()
+	standingOrder := repository.StandingOrder{
+		ID:                newUUID(),
+		TenantID:          tenantID,
+		UserID:            newUUID(),
+		Name:              "Weekly beans",
+		Status:            domain.StandingOrderStatusActive,
+		IntervalWeeks:     1,
+		DeliveryWeekday:   7,
+		NextDeliveryDate:  pgDate(deliveryDate(2026, 10, 18)),
+		ShippingAddressID: newUUID(),
+	}
+	item := repository.ListStandingOrderItemsRow{
+		ProductSkuID: newUUID(),
+		Quantity:     6,
+		ProductName:  "House Espresso",
+		Sku:          "ESP-5LB-WB",
+		Available:    true,
+	}
+	cart := repository.Cart{ID: newUUID(), TenantID: tenantID}
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	cartService := &recordingCartService{}
+	onAccountService := &stubOnAccountService{orderID: orderID}
+	checkoutService := &stubCheckoutService{rates: []shipping.Rate{
+		{ServiceCode: "express", CostCents: 2500},
+		{ServiceCode: "ground", CostCents: 1200},
+	}}
+	svc := &standingOrderService{
+		repo:             mockRepo,
+		cartService:      cartService,
+		checkoutService:  checkoutService,
+		onAccountService: onAccountService,
+		baseURL:          "https://example.com",
+		now:              func() time.Time { return standingOrderNow },
+	}
+
+	mockRepo.EXPECT().GetTenantStandingOrderSettings(gomock.Any(), tenantID).Return([]byte(`{}`), nil)
+	mockRepo.EXPECT().ListDueStandingOrders(gomock.Any(), repository.ListDueStandingOrdersParams{
+		TenantID:        tenantID,
+		GenerateThrough: pgDate(deliveryDate(2026, 10, 18)),
+	}).Return([]repository.StandingOrder{standingOrder}, nil)
+	mockRepo.EXPECT().ClaimStandingOrderDelivery(gomock.Any(), repository.ClaimStandingOrderDeliveryParams{
+		ID:               standingOrder.ID,
+		TenantID:         tenantID,
+		NextDeliveryDate: pgDate(deliveryDate(2026, 10, 25)),
+		DueDate:          standingOrder.NextDeliveryDate,
+	}).Return(standingOrder, nil)
+	mockRepo.EXPECT().ListStandingOrderItems(gomock.Any(), gomock.Any()).
+		Return([]repository.ListStandingOrderItemsRow{item}, nil).Times(2)
+	mockRepo.EXPECT().GetAddressByIDForUser(gomock.Any(), repository.GetAddressByIDForUserParams{
+		ID:       standingOrder.ShippingAddressID,
+		TenantID: tenantID,
+		UserID:   standingOrder.UserID,
+	}).Return(repository.GetAddressByIDForUserRow{
+		ID:           standingOrder.ShippingAddressID,
+		Company:      pgtype.Text{String: "Corner Cafe", Valid: true},
+		AddressLine1: "1 Main St",
+		City:         "Portland",
+		State:        "OR",
+		PostalCode:   "97201",
+		Country:      "US",
+	}, nil)
+	mockRepo.EXPECT().CreateCart(gomock.Any(), gomock.Any()).Return(cart, nil)
+	mockRepo.EXPECT().RecordStandingOrderGenerated(gomock.Any(), repository.RecordStandingOrderGeneratedParams{
+		ID:       standingOrder.ID,
+		TenantID: tenantID,
+		OrderID:  orderID,
+	}).Return(nil)
+
+	// Another customer's delivery closes tomorrow
+	upcoming := repository.ListStandingOrdersToRemindRow{
+		ID:               newUUID(),
+		Name:             "Cafe restock",
+		NextDeliveryDate: pgDate(deliveryDate(2026, 10, 19)),
+		Email:            "orders@cornercafe.test",
+		CompanyName:      pgtype.Text{String: "Corner Cafe", Valid: true},
+	}
+	mockRepo.EXPECT().ListStandingOrdersToRemind(gomock.Any(), repository.ListStandingOrdersToRemindParams{
+		TenantID:      tenantID,
+		RemindThrough: pgDate(deliveryDate(2026, 10, 19)),
+	}).Return([]repository.ListStandingOrdersToRemindRow{upcoming}, nil)
+	mockRepo.EXPECT().EnqueueJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg repository.EnqueueJobParams) (repository.Job, error) {
+			assert.Equal(t, jobs.JobTypeStandingOrderReminder, arg.JobType)
+
+			var payload jobs.StandingOrderReminderPayload
+			require.NoError(t, json.Unmarshal(arg.Payload, &payload))
+			assert.Equal(t, "Corner Cafe", payload.CustomerName)
+			assert.Equal(t, deliveryDate(2026, 10, 17), payload.CutoffDate)
+			assert.Equal(t, "https://example.com/account/standing-orders/"+uuidToString(upcoming.ID), payload.ManageURL)
+			require.Len(t, payload.Items, 1)
+			assert.Equal(t, int32(6), payload.Items[0].Quantity)
+			return repository.Job{}, nil
+		})
+	mockRepo.EXPECT().MarkStandingOrderReminded(gomock.Any(), repository.MarkStandingOrderRemindedParams{
+		ID:              upcoming.ID,
+		TenantID:        tenantID,
+		RemindedForDate: upcoming.NextDeliveryDate,
+	}).Return(nil)
+
+	result, err := svc.ProcessDue(contextWithTenant(tenantID))
+	require.NoError(t, err)
+	assert.Equal(t, domain.StandingOrderResult{Placed: 1, Reminded: 1}, *result)
+
+	// The order is built for the customer and placed on account by the
+	// cheapest rate, shipped and billed to the saved address
+	assert.Equal(t, uuidToString(standingOrder.UserID), cartService.userID)
+	assert.Equal(t, map[string]int{uuidToString(item.ProductSkuID): 6}, cartService.added)
+	require.Len(t, onAccountService.placed, 1)
+	placed := onAccountService.placed[0]
+	assert.Equal(t, uuidToString(cart.ID), placed.CartID)
+	assert.Equal(t, "ground", placed.ShippingRate.ServiceCode)
+	assert.Equal(t, "Corner Cafe", placed.ShippingAddress.Company)
+	assert.Equal(t, placed.ShippingAddress, placed.BillingAddress)
+	assert.Contains(t, placed.CustomerNotes, "Sunday, October 18")
+}
+
+func TestStandingOrderService_ProcessDue_RecordsFailureAndMovesOn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	// Due a week ago: the worker was down, so that delivery is missed too
+	standingOrder := repository.StandingOrder{
+		ID:               newUUID(),
+		TenantID:         tenantID,
+		UserID:           newUUID(),
+		Status:           domain.StandingOrderStatusActive,
+		IntervalWeeks:    1,
+		DeliveryWeekday:  7,
+		NextDeliveryDate: pgDate(deliveryDate(2026, 10, 11)),
+	}
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := &standingOrderService{
+		repo: mockRepo,
+		now:  func() time.Time { return standingOrderNow },
+	}
+
+	mockRepo.EXPECT().GetTenantStandingOrderSettings(gomock.Any(), tenantID).
+		Return([]byte(`{"cutoff_days":2,"reminder_days":0}`), nil)
+	mockRepo.EXPECT().ListDueStandingOrders(gomock.Any(), gomock.Any()).
+		Return([]repository.StandingOrder{standingOrder}, nil)
+	mockRepo.EXPECT().ClaimStandingOrderDelivery(gomock.Any(), repository.ClaimStandingOrderDeliveryParams{
+		ID:               standingOrder.ID,
+		TenantID:         tenantID,
+		NextDeliveryDate: pgDate(deliveryDate(2026, 10, 25)),
+		DueDate:          standingOrder.NextDeliveryDate,
+	}).Return(standingOrder, nil)
+	mockRepo.EXPECT().ListStandingOrderItems(gomock.Any(), gomock.Any()).Return([]repository.ListStandingOrderItemsRow{{
+		ProductSkuID: newUUID(),
+		Quantity:     2,
+		ProductName:  "Holiday Blend",
+		Sku:          "HOL-5LB-WB",
+		Available:    false,
+	}}, nil)
+	mockRepo.EXPECT().CreateCart(gomock.Any(), gomock.Any()).Times(0)
+	mockRepo.EXPECT().RecordStandingOrderGenerated(gomock.Any(), repository.RecordStandingOrderGeneratedParams{
+		ID:        standingOrder.ID,
+		TenantID:  tenantID,
+		LastError: pgtype.Text{String: "Holiday Blend (HOL-5LB-WB) is no longer available", Valid: true},
+	}).Return(nil)
+	mockRepo.EXPECT().ListStandingOrdersToRemind(gomock.Any(), gomock.Any()).Times(0)
+
+	result, err := svc.ProcessDue(contextWithTenant(tenantID))
+	require.NoError(t, err)
+	assert.Equal(t, domain.StandingOrderResult{Failed: 1}, *result)
+}
+
+func TestStandingOrderService_ProcessDue_RetryDoesNotPlaceClaimedDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	standingOrder := repository.StandingOrder{
+		ID:                newUUID(),
+		TenantID:          tenantID,
+		UserID:            newUUID(),
+		Status:            domain.StandingOrderStatusActive,
+		IntervalWeeks:     1,
+		DeliveryWeekday:   7,
+		NextDeliveryDate:  pgDate(deliveryDate(2026, 10, 18)),
+		ShippingAddressID: newUUID(),
+	}
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	onAccountService := &stubOnAccountService{orderID: newUUID()}
+	svc := &standingOrderService{
+		repo:             mockRepo,
+		cartService:      &recordingCartService{},
+		checkoutService:  &stubCheckoutService{rates: []shipping.Rate{{ServiceCode: "ground", CostCents: 1200}}},
+		onAccountService: onAccountService,
+		now:              func() time.Time { return standingOrderNow },
+	}
+
+	mockRepo.EXPECT().GetTenantStandingOrderSettings(gomock.Any(), tenantID).
+		Return([]byte(`{"cutoff_days":2,"reminder_days":0}`), nil).Times(2)
+	// The retry lists the delivery again, as a run that read it before the
+	// first run claimed it would
+	mockRepo.EXPECT().ListDueStandingOrders(gomock.Any(), gomock.Any()).
+		Return([]repository.StandingOrder{standingOrder}, nil).Times(2)
+	gomock.InOrder(
+		mockRepo.EXPECT().ClaimStandingOrderDelivery(gomock.Any(), gomock.Any()).Return(standingOrder, nil),
+		mockRepo.EXPECT().ClaimStandingOrderDelivery(gomock.Any(), gomock.Any()).Return(repository.StandingOrder{}, pgx.ErrNoRows),
+	)
+	mockRepo.EXPECT().ListStandingOrderItems(gomock.Any(), gomock.Any()).Return([]repository.ListStandingOrderItemsRow{{
+		ProductSkuID: newUUID(),
+		Quantity:     6,
+		ProductName:  "House Espresso",
+		Sku:          "ESP-5LB-WB",
+		Available:    true,
+	}}, nil)
+	mockRepo.EXPECT().GetAddressByIDForUser(gomock.Any(), gomock.Any()).
+		Return(repository.GetAddressByIDForUserRow{ID: standingOrder.ShippingAddressID, Country: "US"}, nil)
+	mockRepo.EXPECT().CreateCart(gomock.Any(), gomock.Any()).Return(repository.Cart{ID: newUUID(), TenantID: tenantID}, nil)
+	mockRepo.EXPECT().RecordStandingOrderGenerated(gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+
+	_, err := svc.ProcessDue(contextWithTenant(tenantID))
+	require.Error(t, err)
+	require.Len(t, onAccountService.placed, 1)
+
+	// The job is retried after the failed record
+	result, err := svc.ProcessDue(contextWithTenant(tenantID))
+	require.NoError(t, err)
+	assert.Equal(t, domain.StandingOrderResult{}, *result)
+	assert.Len(t, onAccountService.placed, 1)
+}
+
+func TestStandingOrderService_CreateFromCart_RequiresPaymentTerms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewStandingOrderService(mockRepo, nil, nil, &stubOnAccountService{creditErr: ErrOnAccountNotEnabled}, "")
+
+	mockRepo.EXPECT().CreateStandingOrder(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := svc.CreateFromCart(contextWithTenant(newUUID()), domain.CreateStandingOrderParams{
+		UserID:          uuidToString(newUUID()),
+		CartID:          uuidToString(newUUID()),
+		IntervalWeeks:   1,
+		DeliveryWeekday: 2,
+		AddressID:       uuidToString(newUUID()),
+	})
+	assert.ErrorIs(t, err, ErrOnAccountNotEnabled)
+}
+
+func TestStandingOrderService_UpdateItems_KeepsLastItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	standingOrder := repository.StandingOrder{ID: newUUID(), TenantID: tenantID, UserID: newUUID(), Status: domain.StandingOrderStatusActive}
+	skuID := newUUID()
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewStandingOrderService(mockRepo, nil, nil, nil, "")
+
+	mockRepo.EXPECT().GetStandingOrderForUser(gomock.Any(), gomock.Any()).Return(standingOrder, nil)
+	mockRepo.EXPECT().ListStandingOrderItems(gomock.Any(), gomock.Any()).
+		Return([]repository.ListStandingOrderItemsRow{{ProductSkuID: skuID, Quantity: 4}}, nil)
+	mockRepo.EXPECT().DeleteStandingOrderItem(gomock.Any(), gomock.Any()).Times(0)
+
+	err := svc.UpdateItems(contextWithTenant(tenantID), uuidToString(standingOrder.UserID), uuidToString(standingOrder.ID),
+		map[string]int{uuidToString(skuID): 0})
+	assert.ErrorIs(t, err, ErrStandingOrderEmpty)
+}
+
+func TestStandingOrderService_UpdateSchedule_NewDeliveryDay(t *testing.T) {
+	tests := []struct {
+		name    string
+		next    time.Time
+		weekday int32
+		want    time.Time
+	}{
+		{
+			name:    "later in the same week",
+			next:    deliveryDate(2026, 10, 27), // Tuesday
+			weekday: 4,
+			want:    deliveryDate(2026, 10, 29),
+		},
+		{
+			name:    "earlier in the same week",
+			next:    deliveryDate(2026, 10, 29), // Thursday
+			weekday: 1,
+			want:    deliveryDate(2026, 10, 26),
+		},
+		{
+			name:    "same week is past the cutoff",
+			next:    deliveryDate(2026, 10, 20), // Tuesday; Monday 19th closes today
+			weekday: 1,
+			want:    deliveryDate(2026, 10, 26),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tenantID := newUUID()
+			standingOrder := repository.StandingOrder{
+				ID:               newUUID(),
+				TenantID:         tenantID,
+				UserID:           newUUID(),
+				Name:             "Weekly beans",
+				Status:           domain.StandingOrderStatusActive,
+				IntervalWeeks:    1,
+				DeliveryWeekday:  isoWeekday(tt.next),
+				NextDeliveryDate: pgDate(tt.next),
+			}
+			addressID := newUUID()
+
+			mockRepo := repository.NewMockQuerier(ctrl)
+			// Saturday 17th: with a two day cutoff, Monday 19th can't be changed
+			svc := &standingOrderService{
+				repo: mockRepo,
+				now:  func() time.Time { return deliveryDate(2026, 10, 17) },
+			}
+
+			mockRepo.EXPECT().GetStandingOrderForUser(gomock.Any(), gomock.Any()).Return(standingOrder, nil)
+			mockRepo.EXPECT().GetAddressByIDForUser(gomock.Any(), gomock.Any()).
+				Return(repository.GetAddressByIDForUserRow{ID: addressID}, nil)
+			mockRepo.EXPECT().GetTenantStandingOrderSettings(gomock.Any(), tenantID).Return([]byte(`{}`), nil)
+			mockRepo.EXPECT().UpdateStandingOrderSchedule(gomock.Any(), repository.UpdateStandingOrderScheduleParams{
+				ID:                standingOrder.ID,
+				TenantID:          tenantID,
+				Name:              "Weekly beans",
+				IntervalWeeks:     2,
+				DeliveryWeekday:   tt.weekday,
+				NextDeliveryDate:  pgDate(tt.want),
+				ShippingAddressID: addressID,
+			}).Return(repository.StandingOrder{}, nil)
+
+			err := svc.UpdateSchedule(contextWithTenant(tenantID), domain.UpdateStandingOrderScheduleParams{
+				UserID:          uuidToString(standingOrder.UserID),
+				StandingOrderID: uuidToString(standingOrder.ID),
+				IntervalWeeks:   2,
+				DeliveryWeekday: tt.weekday,
+				AddressID:       uuidToString(addressID),
+			})
+			require.NoError(t, err)
+		})
+	}
+}
@@ -9,21 +9,21 @@ type SubscriptionService = domain.SubscriptionService
 
 // Type aliases for backwards compatibility - all types now live in domain package.
 type (
-	SubscriptionCounts        = domain.SubscriptionCounts
-	CreateSubscriptionParams  = domain.CreateSubscriptionParams
-	GetSubscriptionParams     = domain.GetSubscriptionParams
-	ListSubscriptionsParams   = domain.ListSubscriptionsParams
-	PauseSubscriptionParams   = domain.PauseSubscriptionParams
-	ResumeSubscriptionParams  = domain.ResumeSubscriptionParams
-	CancelSubscriptionParams  = domain.CancelSubscriptionParams
-	PortalSessionParams       = domain.PortalSessionParams
-	SyncSubscriptionParams    = domain.SyncSubscriptionParams
-	SubscriptionDetail        = domain.SubscriptionDetail
-	SubscriptionSummary       = domain.SubscriptionSummary
-	SubscriptionItemDetail    = domain.SubscriptionItemDetail
-	AddressDetail             = domain.SubscriptionAddressDetail
-	PaymentMethodDetail       = domain.SubscriptionPaymentMethodDetail
-	UpcomingInvoiceDetail     = domain.UpcomingInvoiceDetail
+	SubscriptionCounts       = domain.SubscriptionCounts
+	CreateSubscriptionParams = domain.CreateSubscriptionParams
+	GetSubscriptionParams    = domain.GetSubscriptionParams
+	ListSubscriptionsParams  = domain.ListSubscriptionsParams
+	PauseSubscriptionParams  = domain.PauseSubscriptionParams
+	ResumeSubscriptionParams = domain.ResumeSubscriptionParams
+	CancelSubscriptionParams = domain.CancelSubscriptionParams
+	PortalSessionParams      = domain.PortalSessionParams
+	SyncSubscriptionParams   = domain.SyncSubscriptionParams
+	SubscriptionDetail       = domain.SubscriptionDetail
+	SubscriptionSummary      = domain.SubscriptionSummary
+	SubscriptionItemDetail   = domain.SubscriptionItemDetail
+	AddressDetail            = domain.SubscriptionAddressDetail
+	PaymentMethodDetail      = domain.SubscriptionPaymentMethodDetail
+	UpcomingInvoiceDetail    = domain.UpcomingInvoiceDetail
 )
 
 // Billing interval constants re-exported from domain for backwards compatibility.
@@ -7,10 +7,13 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/dukerupert/hiri/internal/billing"
+	"github.com/dukerupert/hiri/internal/domain"
 	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -156,66 +159,12 @@ func (s *subscriptionService) CreateSubscription(ctx context.Context, params Cre
 		return nil, fmt.Errorf("failed to create subscription record: %w", err)
 	}
 
-	// Step 7: Get product name for Stripe Product creation
-	product, err := s.repo.GetProductByID(ctx, repository.GetProductByIDParams{
-		ID:       sku.ProductID,
-		TenantID: tenantID,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get product: %w", err)
-	}
-
-	// Step 8: Map billing interval to Stripe format
-	stripeInterval, intervalCount, err := MapBillingIntervalToStripe(params.BillingInterval)
+	// Steps 7-9: Create Stripe Product and recurring price for this SKU and interval
+	stripePrice, err := s.createProviderPrice(ctx, tenantID, subscription.ID, sku, unitPriceCents, currency, params.BillingInterval)
 	if err != nil {
 		return nil, err
 	}
 
-	// Step 9: Create or get Stripe Product for this SKU
-	// Product name includes weight and grind for uniqueness
-	weightStr := ""
-	if sku.WeightValue.Valid {
-		weightStr = sku.WeightValue.Int.String()
-	}
-	productName := fmt.Sprintf("%s - %s %s", product.Name, weightStr, sku.WeightUnit)
-	if sku.Grind != "" && sku.Grind != "whole_bean" {
-		productName = fmt.Sprintf("%s (%s)", productName, sku.Grind)
-	}
-
-	stripeProduct, err := s.billingProvider.CreateProduct(ctx, billing.CreateProductParams{
-		Name:        productName,
-		Description: product.Description.String,
-		Active:      true,
-		Metadata: map[string]string{
-			"tenant_id":      uuidToString(tenantID),
-			"product_id":     uuidToString(product.ID),
-			"product_sku_id": uuidToString(params.ProductSKUID),
-		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Stripe product: %w", err)
-	}
-
-	priceNickname := fmt.Sprintf("%s - %s", sku.Sku, params.BillingInterval)
-
-	stripePrice, err := s.billingProvider.CreateRecurringPrice(ctx, billing.CreateRecurringPriceParams{
-		Currency:        currency,
-		UnitAmountCents: unitPriceCents,
-		BillingInterval: stripeInterval,
-		IntervalCount:   intervalCount,
-		ProductID:       stripeProduct.ID,
-		Metadata: map[string]string{
-			"tenant_id":        uuidToString(tenantID),
-			"subscription_id":  uuidToString(subscription.ID),
-			"product_sku_id":   uuidToString(params.ProductSKUID),
-			"billing_interval": params.BillingInterval,
-		},
-		Nickname: priceNickname,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Stripe price: %w", err)
-	}
-
 	// Step 9: Create Stripe subscription
 	stripeSubscription, err := s.billingProvider.CreateSubscription(ctx, billing.CreateSubscriptionParams{
 		TenantID:               uuidToString(tenantID),
@@ -475,6 +424,9 @@ func (s *subscriptionService) PauseSubscription(ctx context.Context, params Paus
 	if subscription.Status != "active" {
 		return nil, ErrSubscriptionNotActive
 	}
+	if params.ResumesAt != nil && !params.ResumesAt.After(time.Now()) {
+		return nil, ErrInvalidResumeDate
+	}
 
 	// Pause in Stripe
 	_, err = s.billingProvider.PauseSubscription(ctx, billing.PauseSubscriptionParams{
@@ -517,6 +469,17 @@ func (s *subscriptionService) PauseSubscription(ctx context.Context, params Paus
 		return nil, fmt.Errorf("failed to create schedule event: %w", err)
 	}
 
+	// Stripe resumes collection on its own; schedule the resume so it shows
+	// on the subscription until then
+	if params.ResumesAt != nil {
+		err = s.recordScheduleEvent(ctx, params.TenantID, params.SubscriptionID, "resume", "scheduled", *params.ResumesAt, map[string]string{
+			"event": "resumed",
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Return updated subscription
 	return s.GetSubscription(ctx, GetSubscriptionParams{
 		TenantID:               params.TenantID,
@@ -557,7 +520,7 @@ func (s *subscriptionService) ResumeSubscription(ctx context.Context, params Res
 	_, err = s.repo.UpdateSubscriptionStatus(ctx, repository.UpdateSubscriptionStatusParams{
 		ID:                 params.SubscriptionID,
 		TenantID:           params.TenantID,
-		Status:             stripeSubscription.Status,
+		Status:             localSubscriptionStatus(stripeSubscription),
 		CurrentPeriodStart: pgtype.Timestamptz{Time: stripeSubscription.CurrentPeriodStart, Valid: true},
 		CurrentPeriodEnd:   pgtype.Timestamptz{Time: stripeSubscription.CurrentPeriodEnd, Valid: true},
 		NextBillingDate:    pgtype.Timestamptz{Time: stripeSubscription.CurrentPeriodEnd, Valid: true},
@@ -568,6 +531,17 @@ func (s *subscriptionService) ResumeSubscription(ctx context.Context, params Res
 		return nil, fmt.Errorf("failed to update subscription status: %w", err)
 	}
 
+	// Resumed early: a resume scheduled by a pause with an end date no longer applies
+	err = s.repo.CloseScheduledSubscriptionEvents(ctx, repository.CloseScheduledSubscriptionEventsParams{
+		SubscriptionID: params.SubscriptionID,
+		TenantID:       params.TenantID,
+		EventType:      "resume",
+		Status:         "cancelled",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel scheduled resume: %w", err)
+	}
+
 	// Create schedule event
 	scheduleMetadata, _ := json.Marshal(map[string]string{
 		"event": "resumed",
@@ -665,6 +639,410 @@ func (s *subscriptionService) CancelSubscription(ctx context.Context, params Can
 	})
 }
 
+// SkipNextDelivery skips the upcoming delivery by moving the next billing
+// date forward one interval. Stripe holds the next invoice until then.
+func (s *subscriptionService) SkipNextDelivery(ctx context.Context, params domain.SkipDeliveryParams) (*SubscriptionDetail, error) {
+	subscription, err := s.getChangeableSubscription(ctx, params.TenantID, params.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.Status != "active" {
+		return nil, ErrSubscriptionNotActive
+	}
+
+	skipped := subscription.NextBillingDate.Time
+	nextBillingDate := domain.AddBillingInterval(skipped, subscription.BillingInterval)
+
+	_, err = s.billingProvider.UpdateSubscription(ctx, billing.UpdateSubscriptionParams{
+		SubscriptionID: subscription.ProviderSubscriptionID.String,
+		TenantID:       uuidToString(params.TenantID),
+		TrialEnd:       &nextBillingDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to skip Stripe invoice: %w", err)
+	}
+
+	_, err = s.repo.UpdateSubscriptionSettings(ctx, repository.UpdateSubscriptionSettingsParams{
+		ID:              params.SubscriptionID,
+		TenantID:        params.TenantID,
+		NextBillingDate: pgtype.Timestamptz{Time: nextBillingDate, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update next billing date: %w", err)
+	}
+
+	err = s.recordScheduleEvent(ctx, params.TenantID, params.SubscriptionID, "skip", "completed", skipped, map[string]string{
+		"event":             "skipped",
+		"skipped_date":      skipped.Format(time.RFC3339),
+		"next_billing_date": nextBillingDate.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSubscription(ctx, GetSubscriptionParams{
+		TenantID:       params.TenantID,
+		SubscriptionID: params.SubscriptionID,
+	})
+}
+
+// ChangeFrequency moves the subscription to a new billing interval.
+//
+// Stripe prices carry their interval, so the item is moved to a new price.
+// Changing interval would otherwise bill immediately; holding the invoice
+// until the current next billing date keeps the upcoming delivery where it is.
+func (s *subscriptionService) ChangeFrequency(ctx context.Context, params domain.ChangeFrequencyParams) (*SubscriptionDetail, error) {
+	if !IsValidBillingInterval(params.BillingInterval) {
+		return nil, ErrInvalidBillingInterval
+	}
+
+	subscription, err := s.getChangeableSubscription(ctx, params.TenantID, params.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.BillingInterval != params.BillingInterval {
+		item, err := s.getSubscriptionItem(ctx, params.TenantID, params.SubscriptionID)
+		if err != nil {
+			return nil, err
+		}
+
+		sku, err := s.repo.GetSKUByID(ctx, item.ProductSkuID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get product SKU: %w", err)
+		}
+
+		stripePrice, err := s.createProviderPrice(ctx, params.TenantID, params.SubscriptionID, sku, item.UnitPriceCents, subscription.Currency, params.BillingInterval)
+		if err != nil {
+			return nil, err
+		}
+
+		providerItemID, err := s.getProviderItemID(ctx, params.TenantID, subscription)
+		if err != nil {
+			return nil, err
+		}
+
+		update := billing.UpdateSubscriptionParams{
+			SubscriptionID: subscription.ProviderSubscriptionID.String,
+			TenantID:       uuidToString(params.TenantID),
+			ItemID:         providerItemID,
+			PriceID:        stripePrice.ID,
+			Quantity:       item.Quantity,
+			Metadata:       map[string]string{"billing_interval": params.BillingInterval},
+		}
+		if nextBillingDate := subscription.NextBillingDate.Time; nextBillingDate.After(time.Now()) {
+			update.TrialEnd = &nextBillingDate
+		}
+
+		if _, err := s.billingProvider.UpdateSubscription(ctx, update); err != nil {
+			return nil, fmt.Errorf("failed to update Stripe subscription: %w", err)
+		}
+
+		_, err = s.repo.UpdateSubscriptionSettings(ctx, repository.UpdateSubscriptionSettingsParams{
+			ID:              params.SubscriptionID,
+			TenantID:        params.TenantID,
+			BillingInterval: pgtype.Text{String: params.BillingInterval, Valid: true},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update billing interval: %w", err)
+		}
+
+		err = s.recordScheduleEvent(ctx, params.TenantID, params.SubscriptionID, "frequency_change", "completed", time.Now(), map[string]string{
+			"from": subscription.BillingInterval,
+			"to":   params.BillingInterval,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetSubscription(ctx, GetSubscriptionParams{
+		TenantID:       params.TenantID,
+		SubscriptionID: params.SubscriptionID,
+	})
+}
+
+// ChangeItem swaps the subscribed SKU or its quantity. A new SKU is billed at
+// its current default price from the next delivery.
+func (s *subscriptionService) ChangeItem(ctx context.Context, params domain.ChangeItemParams) (*SubscriptionDetail, error) {
+	if params.Quantity < 1 {
+		return nil, ErrInvalidQuantity
+	}
+
+	subscription, err := s.getChangeableSubscription(ctx, params.TenantID, params.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.getSubscriptionItem(ctx, params.TenantID, params.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.ProductSkuID == params.ProductSKUID && item.Quantity == params.Quantity {
+		return s.GetSubscription(ctx, GetSubscriptionParams{
+			TenantID:       params.TenantID,
+			SubscriptionID: params.SubscriptionID,
+		})
+	}
+
+	providerItemID, err := s.getProviderItemID(ctx, params.TenantID, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	update := billing.UpdateSubscriptionParams{
+		SubscriptionID: subscription.ProviderSubscriptionID.String,
+		TenantID:       uuidToString(params.TenantID),
+		ItemID:         providerItemID,
+		Quantity:       params.Quantity,
+	}
+
+	sku := repository.ProductSku{Sku: item.Sku}
+	unitPriceCents := item.UnitPriceCents
+	if item.ProductSkuID != params.ProductSKUID {
+		sku, err = s.repo.GetSKUByID(ctx, params.ProductSKUID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrSKUNotFound
+			}
+			return nil, fmt.Errorf("failed to get product SKU: %w", err)
+		}
+		if sku.TenantID != params.TenantID || !sku.IsActive {
+			return nil, ErrSKUNotFound
+		}
+
+		unitPriceCents, err = s.getSKUPriceCents(ctx, params.TenantID, sku.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		stripePrice, err := s.createProviderPrice(ctx, params.TenantID, params.SubscriptionID, sku, unitPriceCents, subscription.Currency, subscription.BillingInterval)
+		if err != nil {
+			return nil, err
+		}
+		update.PriceID = stripePrice.ID
+		update.Metadata = map[string]string{"product_sku_id": uuidToString(sku.ID)}
+	}
+
+	if _, err := s.billingProvider.UpdateSubscription(ctx, update); err != nil {
+		return nil, fmt.Errorf("failed to update Stripe subscription: %w", err)
+	}
+
+	_, err = s.repo.UpdateSubscriptionItem(ctx, repository.UpdateSubscriptionItemParams{
+		ID:             item.ID,
+		TenantID:       params.TenantID,
+		ProductSkuID:   params.ProductSKUID,
+		Quantity:       params.Quantity,
+		UnitPriceCents: unitPriceCents,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update subscription item: %w", err)
+	}
+
+	_, err = s.repo.UpdateSubscriptionSettings(ctx, repository.UpdateSubscriptionSettingsParams{
+		ID:            params.SubscriptionID,
+		TenantID:      params.TenantID,
+		SubtotalCents: pgtype.Int4{Int32: unitPriceCents * params.Quantity, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update subscription totals: %w", err)
+	}
+
+	err = s.recordScheduleEvent(ctx, params.TenantID, params.SubscriptionID, "item_change", "completed", time.Now(), map[string]string{
+		"from":          item.Sku,
+		"to":            sku.Sku,
+		"from_quantity": strconv.Itoa(int(item.Quantity)),
+		"to_quantity":   strconv.Itoa(int(params.Quantity)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSubscription(ctx, GetSubscriptionParams{
+		TenantID:       params.TenantID,
+		SubscriptionID: params.SubscriptionID,
+	})
+}
+
+// ChangeShippingAddress ships future deliveries to another saved address.
+func (s *subscriptionService) ChangeShippingAddress(ctx context.Context, params domain.ChangeShippingAddressParams) (*SubscriptionDetail, error) {
+	subscription, err := s.getChangeableSubscription(ctx, params.TenantID, params.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the address belongs to the subscriber
+	_, err = s.repo.GetAddressByIDForUser(ctx, repository.GetAddressByIDForUserParams{
+		ID:       params.AddressID,
+		TenantID: params.TenantID,
+		UserID:   subscription.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAddressOwnership
+		}
+		return nil, fmt.Errorf("failed to get address: %w", err)
+	}
+
+	_, err = s.billingProvider.UpdateSubscription(ctx, billing.UpdateSubscriptionParams{
+		SubscriptionID: subscription.ProviderSubscriptionID.String,
+		TenantID:       uuidToString(params.TenantID),
+		Metadata:       map[string]string{"shipping_address_id": uuidToString(params.AddressID)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update Stripe subscription: %w", err)
+	}
+
+	_, err = s.repo.UpdateSubscriptionSettings(ctx, repository.UpdateSubscriptionSettingsParams{
+		ID:                params.SubscriptionID,
+		TenantID:          params.TenantID,
+		ShippingAddressID: params.AddressID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update shipping address: %w", err)
+	}
+
+	err = s.recordScheduleEvent(ctx, params.TenantID, params.SubscriptionID, "address_change", "completed", time.Now(), map[string]string{
+		"from": uuidToString(subscription.ShippingAddressID),
+		"to":   uuidToString(params.AddressID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSubscription(ctx, GetSubscriptionParams{
+		TenantID:       params.TenantID,
+		SubscriptionID: params.SubscriptionID,
+	})
+}
+
+// ChangePaymentMethod charges future deliveries to another saved payment method.
+func (s *subscriptionService) ChangePaymentMethod(ctx context.Context, params domain.ChangePaymentMethodParams) (*SubscriptionDetail, error) {
+	subscription, err := s.getChangeableSubscription(ctx, params.TenantID, params.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the payment method belongs to the subscriber
+	paymentMethod, err := s.repo.GetPaymentMethodByID(ctx, repository.GetPaymentMethodByIDParams{
+		ID:       params.PaymentMethodID,
+		TenantID: params.TenantID,
+		UserID:   subscription.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPaymentMethodOwnership
+		}
+		return nil, fmt.Errorf("failed to get payment method: %w", err)
+	}
+
+	_, err = s.billingProvider.UpdateSubscription(ctx, billing.UpdateSubscriptionParams{
+		SubscriptionID:         subscription.ProviderSubscriptionID.String,
+		TenantID:               uuidToString(params.TenantID),
+		DefaultPaymentMethodID: paymentMethod.ProviderPaymentMethodID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update Stripe payment method: %w", err)
+	}
+
+	_, err = s.repo.UpdateSubscriptionSettings(ctx, repository.UpdateSubscriptionSettingsParams{
+		ID:              params.SubscriptionID,
+		TenantID:        params.TenantID,
+		PaymentMethodID: params.PaymentMethodID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update payment method: %w", err)
+	}
+
+	err = s.recordScheduleEvent(ctx, params.TenantID, params.SubscriptionID, "payment_method_change", "completed", time.Now(), map[string]string{
+		"from": uuidToString(subscription.PaymentMethodID),
+		"to":   uuidToString(params.PaymentMethodID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSubscription(ctx, GetSubscriptionParams{
+		TenantID:       params.TenantID,
+		SubscriptionID: params.SubscriptionID,
+	})
+}
+
+// ListSKUOptions returns the active SKUs of the same coffee as skuID.
+func (s *subscriptionService) ListSKUOptions(ctx context.Context, tenantID, skuID pgtype.UUID) ([]domain.SubscriptionSKUOption, error) {
+	current, err := s.repo.GetSKUByID(ctx, skuID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSKUNotFound
+		}
+		return nil, fmt.Errorf("failed to get product SKU: %w", err)
+	}
+	if current.TenantID != tenantID {
+		return nil, ErrSKUNotFound
+	}
+
+	skus, err := s.repo.GetProductSKUs(ctx, current.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product SKUs: %w", err)
+	}
+
+	options := make([]domain.SubscriptionSKUOption, 0, len(skus))
+	for _, sku := range skus {
+		priceCents, err := s.getSKUPriceCents(ctx, tenantID, sku.ID)
+		if err != nil {
+			if errors.Is(err, ErrPriceNotFound) {
+				continue
+			}
+			return nil, err
+		}
+
+		weightValue := ""
+		if sku.WeightValue.Valid {
+			weightValue = sku.WeightValue.Int.String()
+		}
+
+		options = append(options, domain.SubscriptionSKUOption{
+			ID:          sku.ID,
+			SKU:         sku.Sku,
+			WeightValue: weightValue,
+			WeightUnit:  sku.WeightUnit,
+			Grind:       sku.Grind,
+			PriceCents:  priceCents,
+		})
+	}
+
+	return options, nil
+}
+
+// ListSubscriptionEvents returns a subscription's schedule, newest first.
+func (s *subscriptionService) ListSubscriptionEvents(ctx context.Context, tenantID, subscriptionID pgtype.UUID, limit int32) ([]domain.SubscriptionEvent, error) {
+	rows, err := s.repo.ListSubscriptionScheduleEvents(ctx, repository.ListSubscriptionScheduleEventsParams{
+		SubscriptionID: subscriptionID,
+		TenantID:       tenantID,
+		Limit:          limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscription events: %w", err)
+	}
+
+	events := make([]domain.SubscriptionEvent, len(rows))
+	for i, row := range rows {
+		events[i] = domain.SubscriptionEvent{
+			ID:          row.ID,
+			EventType:   row.EventType,
+			Status:      row.Status,
+			ScheduledAt: row.ScheduledAt.Time,
+			CreatedAt:   row.CreatedAt.Time,
+			Metadata:    map[string]string{},
+		}
+		// Older events may hold non-string values; those are left out
+		_ = json.Unmarshal(row.Metadata, &events[i].Metadata)
+	}
+
+	return events, nil
+}
+
 // CreateCustomerPortalSession creates a Stripe Customer Portal session
 func (s *subscriptionService) CreateCustomerPortalSession(ctx context.Context, params PortalSessionParams) (string, error) {
 	// Get billing customer
@@ -755,10 +1133,12 @@ func (s *subscriptionService) SyncSubscriptionFromWebhook(ctx context.Context, p
 		cancelledAt = pgtype.Timestamptz{Time: *stripeSubscription.CanceledAt, Valid: true}
 	}
 
+	status := localSubscriptionStatus(stripeSubscription)
+
 	_, err = s.repo.UpdateSubscriptionStatus(ctx, repository.UpdateSubscriptionStatusParams{
 		ID:                 subscription.ID,
 		TenantID:           params.TenantID,
-		Status:             stripeSubscription.Status,
+		Status:             status,
 		CurrentPeriodStart: pgtype.Timestamptz{Time: stripeSubscription.CurrentPeriodStart, Valid: true},
 		CurrentPeriodEnd:   pgtype.Timestamptz{Time: stripeSubscription.CurrentPeriodEnd, Valid: true},
 		NextBillingDate:    pgtype.Timestamptz{Time: stripeSubscription.CurrentPeriodEnd, Valid: true},
@@ -769,6 +1149,19 @@ func (s *subscriptionService) SyncSubscriptionFromWebhook(ctx context.Context, p
 		return fmt.Errorf("failed to update subscription status: %w", err)
 	}
 
+	// Stripe resumed collection on the date the customer chose
+	if subscription.Status == "paused" && status != "paused" {
+		err = s.repo.CloseScheduledSubscriptionEvents(ctx, repository.CloseScheduledSubscriptionEventsParams{
+			SubscriptionID: subscription.ID,
+			TenantID:       params.TenantID,
+			EventType:      "resume",
+			Status:         "completed",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to complete scheduled resume: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -925,6 +1318,7 @@ func (s *subscriptionService) CreateOrderFromSubscriptionInvoice(ctx context.Con
 			TenantID:          contextTenantID,
 			ID:                item.ProductSkuID,
 			InventoryQuantity: item.Quantity,
+			OrderID:           order.ID,
 		})
 		if err != nil {
 			// Log warning but don't fail - inventory tracking is best-effort for MVP
@@ -955,6 +1349,16 @@ func (s *subscriptionService) CreateOrderFromSubscriptionInvoice(ctx context.Con
 		log.Printf("WARNING: Failed to create subscription schedule event: %v", err)
 	}
 
+	// Step 10b: Move the renewal's roast allocation onto the new order items
+	_, err = s.repo.AllocateOrderToRoastBatches(ctx, repository.AllocateOrderToRoastBatchesParams{
+		TenantID: contextTenantID,
+		OrderID:  order.ID,
+	})
+	if err != nil {
+		// Log warning but don't fail - the order ships without a roast date
+		log.Printf("WARNING: Failed to allocate order to roast batches: %v", err)
+	}
+
 	// Step 11: Get shipping address for response
 	shippingAddress, err := s.repo.GetAddressByID(ctx, subscription.ShippingAddressID)
 	if err != nil {
@@ -1009,3 +1413,179 @@ func (s *subscriptionService) GetSubscriptionCountsForUser(ctx context.Context,
 		CancelledCount: int(counts.CancelledCount),
 	}, nil
 }
+
+// createProviderPrice creates the Stripe Product and recurring Price a
+// subscription is billed at for a SKU and billing interval.
+func (s *subscriptionService) createProviderPrice(ctx context.Context, tenantID, subscriptionID pgtype.UUID, sku repository.ProductSku, unitPriceCents int32, currency, billingInterval string) (*billing.Price, error) {
+	product, err := s.repo.GetProductByID(ctx, repository.GetProductByIDParams{
+		ID:       sku.ProductID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	// Map billing interval to Stripe format
+	stripeInterval, intervalCount, err := MapBillingIntervalToStripe(billingInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create or get Stripe Product for this SKU
+	// Product name includes weight and grind for uniqueness
+	weightStr := ""
+	if sku.WeightValue.Valid {
+		weightStr = sku.WeightValue.Int.String()
+	}
+	productName := fmt.Sprintf("%s - %s %s", product.Name, weightStr, sku.WeightUnit)
+	if sku.Grind != "" && sku.Grind != "whole_bean" {
+		productName = fmt.Sprintf("%s (%s)", productName, sku.Grind)
+	}
+
+	stripeProduct, err := s.billingProvider.CreateProduct(ctx, billing.CreateProductParams{
+		Name:        productName,
+		Description: product.Description.String,
+		Active:      true,
+		Metadata: map[string]string{
+			"tenant_id":      uuidToString(tenantID),
+			"product_id":     uuidToString(product.ID),
+			"product_sku_id": uuidToString(sku.ID),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Stripe product: %w", err)
+	}
+
+	priceNickname := fmt.Sprintf("%s - %s", sku.Sku, billingInterval)
+
+	stripePrice, err := s.billingProvider.CreateRecurringPrice(ctx, billing.CreateRecurringPriceParams{
+		Currency:        currency,
+		UnitAmountCents: unitPriceCents,
+		BillingInterval: stripeInterval,
+		IntervalCount:   intervalCount,
+		ProductID:       stripeProduct.ID,
+		Metadata: map[string]string{
+			"tenant_id":        uuidToString(tenantID),
+			"subscription_id":  uuidToString(subscriptionID),
+			"product_sku_id":   uuidToString(sku.ID),
+			"billing_interval": billingInterval,
+		},
+		Nickname: priceNickname,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Stripe price: %w", err)
+	}
+
+	return stripePrice, nil
+}
+
+// getChangeableSubscription loads a subscription the customer can still
+// change: active or paused.
+func (s *subscriptionService) getChangeableSubscription(ctx context.Context, tenantID, subscriptionID pgtype.UUID) (repository.Subscription, error) {
+	subscription, err := s.repo.GetSubscriptionByID(ctx, repository.GetSubscriptionByIDParams{
+		ID:       subscriptionID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return subscription, ErrSubscriptionNotFound
+		}
+		return subscription, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	if subscription.Status != "active" && subscription.Status != "paused" {
+		return subscription, ErrSubscriptionNotChangeable
+	}
+
+	return subscription, nil
+}
+
+// getSubscriptionItem returns a subscription's line. Subscriptions are
+// created with a single item.
+func (s *subscriptionService) getSubscriptionItem(ctx context.Context, tenantID, subscriptionID pgtype.UUID) (repository.ListSubscriptionItemsForSubscriptionRow, error) {
+	items, err := s.repo.ListSubscriptionItemsForSubscription(ctx, repository.ListSubscriptionItemsForSubscriptionParams{
+		SubscriptionID: subscriptionID,
+		TenantID:       tenantID,
+	})
+	if err != nil {
+		return repository.ListSubscriptionItemsForSubscriptionRow{}, fmt.Errorf("failed to get subscription items: %w", err)
+	}
+	if len(items) == 0 {
+		return repository.ListSubscriptionItemsForSubscriptionRow{}, ErrSubscriptionHasNoItems
+	}
+
+	return items[0], nil
+}
+
+// getProviderItemID returns the Stripe subscription item (si_...) for the
+// subscription's line.
+func (s *subscriptionService) getProviderItemID(ctx context.Context, tenantID pgtype.UUID, subscription repository.Subscription) (string, error) {
+	stripeSubscription, err := s.billingProvider.GetSubscription(ctx, billing.GetSubscriptionParams{
+		SubscriptionID: subscription.ProviderSubscriptionID.String,
+		TenantID:       uuidToString(tenantID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get Stripe subscription: %w", err)
+	}
+	if len(stripeSubscription.Items) == 0 {
+		return "", ErrSubscriptionHasNoItems
+	}
+
+	return stripeSubscription.Items[0].ID, nil
+}
+
+// getSKUPriceCents returns a SKU's price on the tenant's default price list.
+func (s *subscriptionService) getSKUPriceCents(ctx context.Context, tenantID, skuID pgtype.UUID) (int32, error) {
+	defaultPriceList, err := s.repo.GetDefaultPriceList(ctx, tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get default price list: %w", err)
+	}
+
+	priceEntry, err := s.repo.GetPriceForSKU(ctx, repository.GetPriceForSKUParams{
+		PriceListID:  defaultPriceList.ID,
+		ProductSkuID: skuID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrPriceNotFound
+		}
+		return 0, fmt.Errorf("failed to get price: %w", err)
+	}
+
+	return priceEntry.PriceCents, nil
+}
+
+// recordScheduleEvent adds an event to the subscription's schedule.
+func (s *subscriptionService) recordScheduleEvent(ctx context.Context, tenantID, subscriptionID pgtype.UUID, eventType, status string, scheduledAt time.Time, metadata map[string]string) error {
+	scheduleMetadata, _ := json.Marshal(metadata)
+
+	_, err := s.repo.CreateSubscriptionScheduleEvent(ctx, repository.CreateSubscriptionScheduleEventParams{
+		TenantID:       tenantID,
+		SubscriptionID: subscriptionID,
+		EventType:      eventType,
+		Status:         status,
+		ScheduledAt:    pgtype.Timestamptz{Time: scheduledAt, Valid: true},
+		OrderID:        pgtype.UUID{},
+		PaymentID:      pgtype.UUID{},
+		Metadata:       scheduleMetadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create schedule event: %w", err)
+	}
+
+	return nil
+}
+
+// localSubscriptionStatus maps a Stripe subscription to our status. Stripe
+// keeps a subscription "active" while collection is paused, and "trialing"
+// while a skipped or rescheduled invoice is held.
+func localSubscriptionStatus(stripeSubscription *billing.Subscription) string {
+	switch {
+	case stripeSubscription.PauseCollection != nil:
+		return "paused"
+	case stripeSubscription.Status == "trialing":
+		return "active"
+	default:
+		return stripeSubscription.Status
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/dukerupert/hiri/internal/billing"
+	"github.com/dukerupert/hiri/internal/domain"
 	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -39,15 +41,20 @@ type mockSubscriptionBillingProvider struct {
 	resumeSubscriptionResult *billing.Subscription
 	resumeSubscriptionErr    error
 	cancelSubscriptionErr    error
+	updateSubscriptionErr    error
 
 	// Invoice operations
 	getInvoiceResult *billing.Invoice
 	getInvoiceErr    error
+	payInvoiceResult *billing.Invoice
+	payInvoiceErr    error
 
 	// Tracking calls
 	pauseCalls   []billing.PauseSubscriptionParams
 	resumeCalls  []billing.ResumeSubscriptionParams
 	cancelCalls  []billing.CancelSubscriptionParams
+	updateCalls  []billing.UpdateSubscriptionParams
+	voidCalls    []billing.VoidInvoiceParams
 }
 
 func (m *mockSubscriptionBillingProvider) CreateCustomer(ctx context.Context, params billing.CreateCustomerParams) (*billing.Customer, error) {
@@ -110,11 +117,15 @@ func (m *mockSubscriptionBillingProvider) SendInvoice(ctx context.Context, param
 }
 
 func (m *mockSubscriptionBillingProvider) VoidInvoice(ctx context.Context, params billing.VoidInvoiceParams) error {
+	m.voidCalls = append(m.voidCalls, params)
 	return nil
 }
 
 func (m *mockSubscriptionBillingProvider) PayInvoice(ctx context.Context, params billing.PayInvoiceParams) (*billing.Invoice, error) {
-	return nil, nil
+	if m.payInvoiceErr != nil {
+		return nil, m.payInvoiceErr
+	}
+	return m.payInvoiceResult, nil
 }
 
 func (m *mockSubscriptionBillingProvider) CreateProduct(ctx context.Context, params billing.CreateProductParams) (*billing.Product, error) {
@@ -226,6 +237,14 @@ func (m *mockSubscriptionBillingProvider) CancelSubscription(ctx context.Context
 	return m.cancelSubscriptionErr
 }
 
+func (m *mockSubscriptionBillingProvider) UpdateSubscription(ctx context.Context, params billing.UpdateSubscriptionParams) (*billing.Subscription, error) {
+	m.updateCalls = append(m.updateCalls, params)
+	if m.updateSubscriptionErr != nil {
+		return nil, m.updateSubscriptionErr
+	}
+	return &billing.Subscription{ID: params.SubscriptionID, Status: "active"}, nil
+}
+
 func (m *mockSubscriptionBillingProvider) CreateCustomerPortalSession(ctx context.Context, params billing.CreatePortalSessionParams) (*billing.PortalSession, error) {
 	return &billing.PortalSession{
 		ID:        "cs_test123",
@@ -829,6 +848,7 @@ func Test_ResumeSubscription_OnlyPausedCanBeResumed(t *testing.T) {
 
 			if tt.shouldSucceed {
 				mockRepo.EXPECT().UpdateSubscriptionStatus(gomock.Any(), gomock.Any()).Return(repository.Subscription{}, nil)
+				mockRepo.EXPECT().CloseScheduledSubscriptionEvents(gomock.Any(), gomock.Any()).Return(nil)
 				mockRepo.EXPECT().CreateSubscriptionScheduleEvent(gomock.Any(), gomock.Any()).Return(repository.SubscriptionSchedule{}, nil)
 				mockRepo.EXPECT().GetSubscriptionWithDetails(gomock.Any(), gomock.Any()).Return(repository.GetSubscriptionWithDetailsRow{
 					ID:       subscriptionID,
@@ -892,6 +912,7 @@ func Test_ResumeSubscription_UpdatesPeriodDatesFromStripe(t *testing.T) {
 			return repository.Subscription{}, nil
 		})
 
+	mockRepo.EXPECT().CloseScheduledSubscriptionEvents(gomock.Any(), gomock.Any()).Return(nil)
 	mockRepo.EXPECT().CreateSubscriptionScheduleEvent(gomock.Any(), gomock.Any()).Return(repository.SubscriptionSchedule{}, nil)
 	mockRepo.EXPECT().GetSubscriptionWithDetails(gomock.Any(), gomock.Any()).Return(repository.GetSubscriptionWithDetailsRow{
 		ID:       subscriptionID,
@@ -1137,6 +1158,7 @@ func Test_CreateOrderFromSubscriptionInvoice_CreatesOrderLinkedToSubscription(t
 	mockRepo.EXPECT().CreatePayment(gomock.Any(), gomock.Any()).Return(repository.Payment{ID: newUUID()}, nil)
 	mockRepo.EXPECT().DecrementSKUStock(gomock.Any(), gomock.Any()).Return(nil)
 	mockRepo.EXPECT().CreateSubscriptionScheduleEvent(gomock.Any(), gomock.Any()).Return(repository.SubscriptionSchedule{}, nil)
+	mockRepo.EXPECT().AllocateOrderToRoastBatches(gomock.Any(), gomock.Any()).Return(int64(1), nil)
 	mockRepo.EXPECT().GetAddressByID(gomock.Any(), shippingAddressID).Return(repository.Address{ID: shippingAddressID}, nil)
 
 	result, err := svc.CreateOrderFromSubscriptionInvoice(ctx, invoiceID, tenantID)
@@ -1213,6 +1235,7 @@ func Test_CreateOrderFromSubscriptionInvoice_CreatesOrderItemsFromSubscriptionIt
 	mockRepo.EXPECT().CreatePayment(gomock.Any(), gomock.Any()).Return(repository.Payment{}, nil)
 	mockRepo.EXPECT().DecrementSKUStock(gomock.Any(), gomock.Any()).Return(nil)
 	mockRepo.EXPECT().CreateSubscriptionScheduleEvent(gomock.Any(), gomock.Any()).Return(repository.SubscriptionSchedule{}, nil)
+	mockRepo.EXPECT().AllocateOrderToRoastBatches(gomock.Any(), gomock.Any()).Return(int64(1), nil)
 	mockRepo.EXPECT().GetAddressByID(gomock.Any(), gomock.Any()).Return(repository.Address{}, nil)
 
 	_, err := svc.CreateOrderFromSubscriptionInvoice(ctx, invoiceID, tenantID)
@@ -1287,6 +1310,7 @@ func Test_CreateOrderFromSubscriptionInvoice_DecrementsInventory(t *testing.T) {
 		})
 
 	mockRepo.EXPECT().CreateSubscriptionScheduleEvent(gomock.Any(), gomock.Any()).Return(repository.SubscriptionSchedule{}, nil)
+	mockRepo.EXPECT().AllocateOrderToRoastBatches(gomock.Any(), gomock.Any()).Return(int64(1), nil)
 	mockRepo.EXPECT().GetAddressByID(gomock.Any(), gomock.Any()).Return(repository.Address{}, nil)
 
 	_, err := svc.CreateOrderFromSubscriptionInvoice(ctx, invoiceID, tenantID)
@@ -1502,6 +1526,455 @@ func Test_SyncSubscriptionFromWebhook_HandlesAllStatusTransitions(t *testing.T)
 	assert.True(t, capturedCancelAtPeriodEnd.Bool, "Should sync cancel_at_period_end flag")
 }
 
+// =============================================================================
+// TEST: Customer changes
+// =============================================================================
+
+func Test_SkipNextDelivery_MovesNextBillingDateOneInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockBilling := &mockSubscriptionBillingProvider{}
+	svc := NewSubscriptionService(mockRepo, mockBilling)
+
+	subscription := createTestSubscription(tenantID, newUUID(), "active")
+	subscription.BillingInterval = "biweekly"
+	skipped := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	subscription.NextBillingDate = pgtype.Timestamptz{Time: skipped, Valid: true}
+	next := skipped.AddDate(0, 0, 14)
+
+	mockRepo.EXPECT().GetSubscriptionByID(gomock.Any(), gomock.Any()).Return(subscription, nil)
+	mockRepo.EXPECT().UpdateSubscriptionSettings(gomock.Any(), repository.UpdateSubscriptionSettingsParams{
+		ID:              subscription.ID,
+		TenantID:        tenantID,
+		NextBillingDate: pgtype.Timestamptz{Time: next, Valid: true},
+	}).Return(repository.Subscription{}, nil)
+	mockRepo.EXPECT().CreateSubscriptionScheduleEvent(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, params repository.CreateSubscriptionScheduleEventParams) (repository.SubscriptionSchedule, error) {
+			assert.Equal(t, "skip", params.EventType)
+			assert.Equal(t, "completed", params.Status)
+			assert.Equal(t, skipped, params.ScheduledAt.Time)
+			return repository.SubscriptionSchedule{}, nil
+		})
+	mockRepo.EXPECT().GetSubscriptionWithDetails(gomock.Any(), gomock.Any()).Return(repository.GetSubscriptionWithDetailsRow{}, nil)
+	mockRepo.EXPECT().ListSubscriptionItemsForSubscription(gomock.Any(), gomock.Any()).Return([]repository.ListSubscriptionItemsForSubscriptionRow{}, nil)
+
+	_, err := svc.SkipNextDelivery(ctx, domain.SkipDeliveryParams{TenantID: tenantID, SubscriptionID: subscription.ID})
+
+	require.NoError(t, err)
+	require.Len(t, mockBilling.updateCalls, 1)
+	require.NotNil(t, mockBilling.updateCalls[0].TrialEnd, "Stripe should hold the invoice until the new date")
+	assert.Equal(t, next, *mockBilling.updateCalls[0].TrialEnd)
+}
+
+func Test_SkipNextDelivery_OnlyActive(t *testing.T) {
+	tests := []struct {
+		status        string
+		expectedError error
+	}{
+		{status: "paused", expectedError: ErrSubscriptionNotActive},
+		{status: "cancelled", expectedError: ErrSubscriptionNotChangeable},
+		{status: "past_due", expectedError: ErrSubscriptionNotChangeable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tenantID := newUUID()
+			mockRepo := repository.NewMockQuerier(ctrl)
+			mockBilling := &mockSubscriptionBillingProvider{}
+			svc := NewSubscriptionService(mockRepo, mockBilling)
+
+			subscription := createTestSubscription(tenantID, newUUID(), tt.status)
+			mockRepo.EXPECT().GetSubscriptionByID(gomock.Any(), gomock.Any()).Return(subscription, nil)
+
+			_, err := svc.SkipNextDelivery(contextWithTenant(tenantID), domain.SkipDeliveryParams{
+				TenantID:       tenantID,
+				SubscriptionID: subscription.ID,
+			})
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Empty(t, mockBilling.updateCalls)
+		})
+	}
+}
+
+func Test_ChangeFrequency_MovesItemToPriceForNewInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockBilling := &mockSubscriptionBillingProvider{
+		getSubscriptionResult: &billing.Subscription{
+			ID:    "sub_test123",
+			Items: []billing.SubscriptionItem{{ID: "si_test123", PriceID: "price_old", Quantity: 2}},
+		},
+		createPriceResult: &billing.Price{ID: "price_weekly"},
+	}
+	svc := NewSubscriptionService(mockRepo, mockBilling)
+
+	subscription := createTestSubscription(tenantID, newUUID(), "active")
+	nextBillingDate := time.Now().AddDate(0, 0, 10).Truncate(time.Second)
+	subscription.NextBillingDate = pgtype.Timestamptz{Time: nextBillingDate, Valid: true}
+	sku := repository.ProductSku{ID: newUUID(), TenantID: tenantID, ProductID: newUUID(), Sku: "ETH-12OZ-WB", WeightUnit: "oz"}
+
+	mockRepo.EXPECT().GetSubscriptionByID(gomock.Any(), gomock.Any()).Return(subscription, nil)
+	mockRepo.EXPECT().ListSubscriptionItemsForSubscription(gomock.Any(), gomock.Any()).Return([]repository.ListSubscriptionItemsForSubscriptionRow{
+		{ID: newUUID(), ProductSkuID: sku.ID, Quantity: 2, UnitPriceCents: 1800, Sku: sku.Sku},
+	}, nil)
+	mockRepo.EXPECT().GetSKUByID(gomock.Any(), sku.ID).Return(sku, nil)
+	mockRepo.EXPECT().GetProductByID(gomock.Any(), gomock.Any()).Return(repository.Product{ID: sku.ProductID, Name: "Ethiopia Guji"}, nil)
+	mockRepo.EXPECT().UpdateSubscriptionSettings(gomock.Any(), repository.UpdateSubscriptionSettingsParams{
+		ID:              subscription.ID,
+		TenantID:        tenantID,
+		BillingInterval: pgtype.Text{String: "weekly", Valid: true},
+	}).Return(repository.Subscription{}, nil)
+	mockRepo.EXPECT().CreateSubscriptionScheduleEvent(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, params repository.CreateSubscriptionScheduleEventParams) (repository.SubscriptionSchedule, error) {
+			assert.Equal(t, "frequency_change", params.EventType)
+			assert.JSONEq(t, `{"from":"monthly","to":"weekly"}`, string(params.Metadata))
+			return repository.SubscriptionSchedule{}, nil
+		})
+	mockRepo.EXPECT().GetSubscriptionWithDetails(gomock.Any(), gomock.Any()).Return(repository.GetSubscriptionWithDetailsRow{}, nil)
+	mockRepo.EXPECT().ListSubscriptionItemsForSubscription(gomock.Any(), gomock.Any()).Return([]repository.ListSubscriptionItemsForSubscriptionRow{}, nil)
+
+	_, err := svc.ChangeFrequency(ctx, domain.ChangeFrequencyParams{
+		TenantID:        tenantID,
+		SubscriptionID:  subscription.ID,
+		BillingInterval: "weekly",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, mockBilling.updateCalls, 1)
+	update := mockBilling.updateCalls[0]
+	assert.Equal(t, "si_test123", update.ItemID)
+	assert.Equal(t, "price_weekly", update.PriceID)
+	assert.Equal(t, int32(2), update.Quantity)
+	require.NotNil(t, update.TrialEnd, "Next delivery should stay on its current date")
+	assert.Equal(t, nextBillingDate, *update.TrialEnd)
+}
+
+func Test_ChangeFrequency_RejectsUnsupportedInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	svc := NewSubscriptionService(mockRepo, &mockSubscriptionBillingProvider{})
+
+	_, err := svc.ChangeFrequency(context.Background(), domain.ChangeFrequencyParams{
+		TenantID:        newUUID(),
+		SubscriptionID:  newUUID(),
+		BillingInterval: "daily",
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidBillingInterval)
+}
+
+func Test_ChangeItem_NewSKUUsesDefaultPrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	ctx := contextWithTenant(tenantID)
+
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockBilling := &mockSubscriptionBillingProvider{
+		getSubscriptionResult: &billing.Subscription{
+			ID:    "sub_test123",
+			Items: []billing.SubscriptionItem{{ID: "si_test123"}},
+		},
+		createPriceResult: &billing.Price{ID: "price_espresso"},
+	}
+	svc := NewSubscriptionService(mockRepo, mockBilling)
+
+	subscription := createTestSubscription(tenantID, newUUID(), "active")
+	item := repository.ListSubscriptionItemsForSubscriptionRow{ID: newUUID(), ProductSkuID: newUUID(), Quantity: 1, UnitPriceCents: 1800, Sku: "ETH-12OZ-WB"}
+	newSKU := repository.ProductSku{ID: newUUID(), TenantID: tenantID, ProductID: newUUID(), Sku: "ETH-12OZ-ESP", Grind: "espresso", IsActive: true}
+	priceListID := newUUID()
+
+	mockRepo.EXPECT().GetSubscriptionByID(gomock.Any(), gomock.Any()).Return(subscription, nil)
+	mockRepo.EXPECT().ListSubscriptionItemsForSubscription(gomock.Any(), gomock.Any()).Return([]repository.ListSubscriptionItemsForSubscriptionRow{item}, nil)
+	mockRepo.EXPECT().GetSKUByID(gomock.Any(), newSKU.ID).Return(newSKU, nil)
+	mockRepo.EXPECT().GetDefaultPriceList(gomock.Any(), tenantID).Return(repository.PriceList{ID: priceListID}, nil)
+	mockRepo.EXPECT().GetPriceForSKU(gomock.Any(), repository.GetPriceForSKUParams{
+		PriceListID:  priceListID,
+		ProductSkuID: newSKU.ID,
+	}).Return(repository.PriceListEntry{PriceCents: 1900}, nil)
+	mockRepo.EXPECT().GetProductByID(gomock.Any(), gomock.Any()).Return(repository.Product{ID: newSKU.ProductID}, nil)
+	mockRepo.EXPECT().UpdateSubscriptionItem(gomock.Any(), repository.UpdateSubscriptionItemParams{
+		ID:             item.ID,
+		TenantID:       tenantID,
+		ProductSkuID:   newSKU.ID,
+		Quantity:       3,
+		UnitPriceCents: 1900,
+	}).Return(repository.SubscriptionItem{}, nil)
+	mockRepo.EXPECT().UpdateSubscriptionSettings(gomock.Any(), repository.UpdateSubscriptionSettingsParams{
+		ID:            subscription.ID,
+		TenantID:      tenantID,
+		SubtotalCents: pgtype.Int4{Int32: 5700, Valid: true},
+	}).Return(repository.Subscription{}, nil)
+	mockRepo.EXPECT().CreateSubscriptionScheduleEvent(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, params repository.CreateSubscriptionScheduleEventParams) (repository.SubscriptionSchedule, error) {
+			assert.Equal(t, "item_change", params.EventType)
+			return repository.SubscriptionSchedule{}, nil
+		})
+	mockRepo.EXPECT().GetSubscriptionWithDetails(gomock.Any(), gomock.Any()).Return(repository.GetSubscriptionWithDetailsRow{}, nil)
+	mockRepo.EXPECT().ListSubscriptionItemsForSubscription(gomock.Any(), gomock.Any()).Return([]repository.ListSubscriptionItemsForSubscriptionRow{}, nil)
+
+	_, err := svc.ChangeItem(ctx, domain.ChangeItemParams{
+		TenantID:       tenantID,
+		SubscriptionID: subscription.ID,
+		ProductSKUID:   newSKU.ID,
+		Quantity:       3,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, mockBilling.updateCalls, 1)
+	assert.Equal(t, "price_espresso", mockBilling.updateCalls[0].PriceID)
+	assert.Equal(t, int32(3), mockBilling.updateCalls[0].Quantity)
+	assert.Nil(t, mockBilling.updateCalls[0].TrialEnd, "Item changes keep the billing date")
+}
+
+func Test_ChangeItem_RejectsSKUFromAnotherTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockBilling := &mockSubscriptionBillingProvider{
+		getSubscriptionResult: &billing.Subscription{Items: []billing.SubscriptionItem{{ID: "si_test123"}}},
+	}
+	svc := NewSubscriptionService(mockRepo, mockBilling)
+
+	subscription := createTestSubscription(tenantID, newUUID(), "active")
+	otherSKU := repository.ProductSku{ID: newUUID(), TenantID: newUUID(), IsActive: true}
+
+	mockRepo.EXPECT().GetSubscriptionByID(gomock.Any(), gomock.Any()).Return(subscription, nil)
+	mockRepo.EXPECT().ListSubscriptionItemsForSubscription(gomock.Any(), gomock.Any()).Return([]repository.ListSubscriptionItemsForSubscriptionRow{
+		{ID: newUUID(), ProductSkuID: newUUID(), Quantity: 1},
+	}, nil)
+	mockRepo.EXPECT().GetSKUByID(gomock.Any(), otherSKU.ID).Return(otherSKU, nil)
+	mockRepo.EXPECT().UpdateSubscriptionItem(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := svc.ChangeItem(contextWithTenant(tenantID), domain.ChangeItemParams{
+		TenantID:       tenantID,
+		SubscriptionID: subscription.ID,
+		ProductSKUID:   otherSKU.ID,
+		Quantity:       1,
+	})
+
+	assert.ErrorIs(t, err, ErrSKUNotFound)
+	assert.Empty(t, mockBilling.updateCalls)
+}
+
+func Test_ChangeShippingAddress_RequiresSubscribersAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	userID := newUUID()
+	addressID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockBilling := &mockSubscriptionBillingProvider{}
+	svc := NewSubscriptionService(mockRepo, mockBilling)
+
+	subscription := createTestSubscription(tenantID, userID, "paused")
+
+	mockRepo.EXPECT().GetSubscriptionByID(gomock.Any(), gomock.Any()).Return(subscription, nil)
+	mockRepo.EXPECT().GetAddressByIDForUser(gomock.Any(), repository.GetAddressByIDForUserParams{
+		ID:       addressID,
+		TenantID: tenantID,
+		UserID:   userID,
+	}).Return(repository.GetAddressByIDForUserRow{}, pgx.ErrNoRows)
+
+	_, err := svc.ChangeShippingAddress(contextWithTenant(tenantID), domain.ChangeShippingAddressParams{
+		TenantID:       tenantID,
+		SubscriptionID: subscription.ID,
+		AddressID:      addressID,
+	})
+
+	assert.ErrorIs(t, err, ErrAddressOwnership)
+	assert.Empty(t, mockBilling.updateCalls)
+}
+
+func Test_ChangePaymentMethod_UpdatesStripeDefaultPaymentMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	userID := newUUID()
+	paymentMethodID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockBilling := &mockSubscriptionBillingProvider{}
+	svc := NewSubscriptionService(mockRepo, mockBilling)
+
+	subscription := createTestSubscription(tenantID, userID, "active")
+
+	mockRepo.EXPECT().GetSubscriptionByID(gomock.Any(), gomock.Any()).Return(subscription, nil)
+	mockRepo.EXPECT().GetPaymentMethodByID(gomock.Any(), repository.GetPaymentMethodByIDParams{
+		ID:       paymentMethodID,
+		TenantID: tenantID,
+		UserID:   userID,
+	}).Return(repository.GetPaymentMethodByIDRow{ID: paymentMethodID, ProviderPaymentMethodID: "pm_new"}, nil)
+	mockRepo.EXPECT().UpdateSubscriptionSettings(gomock.Any(), repository.UpdateSubscriptionSettingsParams{
+		ID:              subscription.ID,
+		TenantID:        tenantID,
+		PaymentMethodID: paymentMethodID,
+	}).Return(repository.Subscription{}, nil)
+	mockRepo.EXPECT().CreateSubscriptionScheduleEvent(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, params repository.CreateSubscriptionScheduleEventParams) (repository.SubscriptionSchedule, error) {
+			assert.Equal(t, "payment_method_change", params.EventType)
+			return repository.SubscriptionSchedule{}, nil
+		})
+	mockRepo.EXPECT().GetSubscriptionWithDetails(gomock.Any(), gomock.Any()).Return(repository.GetSubscriptionWithDetailsRow{}, nil)
+	mockRepo.EXPECT().ListSubscriptionItemsForSubscription(gomock.Any(), gomock.Any()).Return([]repository.ListSubscriptionItemsForSubscriptionRow{}, nil)
+
+	_, err := svc.ChangePaymentMethod(contextWithTenant(tenantID), domain.ChangePaymentMethodParams{
+		TenantID:        tenantID,
+		SubscriptionID:  subscription.ID,
+		PaymentMethodID: paymentMethodID,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, mockBilling.updateCalls, 1)
+	assert.Equal(t, "pm_new", mockBilling.updateCalls[0].DefaultPaymentMethodID)
+}
+
+func Test_PauseSubscription_UntilDateSchedulesResume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := newUUID()
+	mockRepo := repository.NewMockQuerier(ctrl)
+	mockBilling := &mockSubscriptionBillingProvider{}
+	svc := NewSubscriptionService(mockRepo, mockBilling)
+
+	subscription := createTestSubscription(tenantID, newUUID(), "active")
+	resumesAt := time.Now().AddDate(0, 1, 0).Truncate(time.Second)
+
+	mockRepo.EXPECT().GetSubscriptionByID(gomock.Any(), gomock.Any()).Return(subscription, nil).Times(2)
+	mockRepo.EXPECT().UpdateSubscriptionPauseResume(gomock.Any(), gomock.Any()).Return(repository.Subscription{}, nil)
+
+	var eventTypes []string
+	mockRepo.EXPECT().CreateSubscriptionScheduleEvent(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, params repository.CreateSubscriptionScheduleEventParams) (repository.SubscriptionSchedule, error) {
+			eventTypes = append(eventTypes, params.EventType+"/"+params.Status)
+			if params.EventType == "resume" {
+				assert.Equal(t, resumesAt, params.ScheduledAt.Time)
+			}
+			return repository.SubscriptionSchedule{}, nil
+		}).Times(2)
+	mockRepo.EXPECT().GetSubscriptionWithDetails(gomock.Any(), gomock.Any()).Return(repository.GetSubscriptionWithDetailsRow{}, nil)
+	mockRepo.EXPECT().ListSubscriptionItemsForSubscription(gomock.Any(), gomock.Any()).Return([]repository.ListSubscriptionItemsForSubscriptionRow{}, nil)
+
+	ctx := contextWithTenant(tenantID)
+	_, err := svc.PauseSubscription(ctx, PauseSubscriptionParams{
+		TenantID:       tenantID,
+		SubscriptionID: subscription.ID,
+		ResumesAt:      &resumesAt,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pause/completed", "resume/scheduled"}, eventTypes)
+	require.Len(t, mockBilling.pauseCalls, 1)
+	assert.Equal(t, &resumesAt, mockBilling.pauseCalls[0].ResumesAt)
+
+	// A resume date in the past is rejected before Stripe is called
+	past := time.Now().Add(-time.Hour)
+	_, err = svc.PauseSubscription(ctx, PauseSubscriptionParams{
+		TenantID:       tenantID,
+		SubscriptionID: subscription.ID,
+		ResumesAt:      &past,
+	})
+	assert.ErrorIs(t, err, ErrInvalidResumeDate)
+	assert.Len(t, mockBilling.pauseCalls, 1)
+}
+
+func Test_SyncSubscriptionFromWebhook_MapsHeldAndPausedCollection(t *testing.T) {
+	tests := []struct {
+		name            string
+		localStatus     string
+		stripe          billing.Subscription
+		expectedLocal   string
+		completesResume bool
+	}{
+		{
+			name:          "skipped delivery keeps subscription active",
+			localStatus:   "active",
+			stripe:        billing.Subscription{Status: "trialing"},
+			expectedLocal: "active",
+		},
+		{
+			name:          "paused collection is paused",
+			localStatus:   "active",
+			stripe:        billing.Subscription{Status: "active", PauseCollection: &billing.SubscriptionPauseCollection{Behavior: "void"}},
+			expectedLocal: "paused",
+		},
+		{
+			name:            "collection resumed on the chosen date",
+			localStatus:     "paused",
+			stripe:          billing.Subscription{Status: "active"},
+			expectedLocal:   "active",
+			completesResume: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tenantID := newUUID()
+			subscriptionID := newUUID()
+			mockRepo := repository.NewMockQuerier(ctrl)
+			stripeSubscription := tt.stripe
+			svc := NewSubscriptionService(mockRepo, &mockSubscriptionBillingProvider{getSubscriptionResult: &stripeSubscription})
+
+			mockRepo.EXPECT().GetWebhookEventByProviderID(gomock.Any(), gomock.Any()).Return(repository.WebhookEvent{}, sql.ErrNoRows)
+			mockRepo.EXPECT().CreateWebhookEvent(gomock.Any(), gomock.Any()).Return(repository.WebhookEvent{}, nil)
+			mockRepo.EXPECT().GetSubscriptionByProviderID(gomock.Any(), gomock.Any()).Return(repository.Subscription{
+				ID:       subscriptionID,
+				TenantID: tenantID,
+				Status:   tt.localStatus,
+			}, nil)
+
+			var capturedStatus string
+			mockRepo.EXPECT().UpdateSubscriptionStatus(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, params repository.UpdateSubscriptionStatusParams) (repository.Subscription, error) {
+					capturedStatus = params.Status
+					return repository.Subscription{}, nil
+				})
+			if tt.completesResume {
+				mockRepo.EXPECT().CloseScheduledSubscriptionEvents(gomock.Any(), repository.CloseScheduledSubscriptionEventsParams{
+					SubscriptionID: subscriptionID,
+					TenantID:       tenantID,
+					EventType:      "resume",
+					Status:         "completed",
+				}).Return(nil)
+			}
+
+			err := svc.SyncSubscriptionFromWebhook(contextWithTenant(tenantID), SyncSubscriptionParams{
+				TenantID:               tenantID,
+				EventID:                "evt_test123",
+				EventType:              "customer.subscription.updated",
+				ProviderSubscriptionID: "sub_stripe123",
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLocal, capturedStatus)
+		})
+	}
+}
+
 // =============================================================================
 // HELPER: bigIntFromInt64
 // =============================================================================
@@ -86,6 +86,10 @@
 //    - Operator middleware sets tenant in context from TenantOperator.TenantID
 //    - Service code is identical - just extracts from context
 //
+//    For storefront routes, tenant comes from the request host (ResolveTenant)
+//    or, in single-tenant mode, the configured tenant (WithTenant). Handlers
+//    read it with tenant.IDFromContext rather than storing a tenantID.
+//
 // 3. For background jobs, worker injects tenant context
 //    - Worker reads tenant_id from job record
 //    - Creates context with tenant before calling service
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// withTx runs fn against a transaction-scoped repository and commits if fn
+// succeeds. When pool is nil (unit tests with a mock Querier) fn runs against
+// repo directly.
+func withTx(ctx context.Context, pool *pgxpool.Pool, repo repository.Querier, fn func(repository.Querier) error) (err error) {
+	if pool == nil {
+		return fn(repo)
+	}
+
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err = fn(repo.(*repository.Queries).WithTx(tx)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxWhiteLabelCustomers caps the customer list offered when creating a
+// white-label product.
+const maxWhiteLabelCustomers = 500
+
+type whiteLabelService struct {
+	repo repository.Querier
+}
+
+// NewWhiteLabelService creates a new WhiteLabelService instance
+func NewWhiteLabelService(repo repository.Querier) domain.WhiteLabelService {
+	return &whiteLabelService{repo: repo}
+}
+
+// GetWhiteLabelBase returns a standard product and its active SKUs.
+func (s *whiteLabelService) GetWhiteLabelBase(ctx context.Context, productID string) (*domain.WhiteLabelBase, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getBase(ctx, tenantID, productID)
+}
+
+// ListEligibleCustomers returns the tenant's wholesale customers.
+func (s *whiteLabelService) ListEligibleCustomers(ctx context.Context) ([]repository.ListWholesaleCustomersRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	customers, err := s.repo.ListWholesaleCustomers(ctx, repository.ListWholesaleCustomersParams{
+		TenantID: tenantID,
+		Limit:    maxWhiteLabelCustomers,
+		Offset:   0,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wholesale customers: %w", err)
+	}
+
+	return customers, nil
+}
+
+// CreateWhiteLabelProduct clones a base product into a white-label product.
+func (s *whiteLabelService) CreateWhiteLabelProduct(ctx context.Context, params domain.CreateWhiteLabelParams) (*repository.Product, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params.Name = strings.TrimSpace(params.Name)
+	params.ShortDescription = strings.TrimSpace(params.ShortDescription)
+	params.Description = strings.TrimSpace(params.Description)
+	if params.Name == "" {
+		return nil, ErrWhiteLabelNameRequired
+	}
+
+	base, err := s.getBase(ctx, tenantID, params.BaseProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	customer, err := s.getCustomer(ctx, tenantID, params.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pricedSKUs []repository.ProductSku
+	for _, sku := range base.SKUs {
+		price, ok := params.Prices[uuidToString(sku.ID)]
+		if !ok {
+			continue
+		}
+		if price < 0 {
+			return nil, ErrWhiteLabelInvalidPrice
+		}
+		pricedSKUs = append(pricedSKUs, sku)
+	}
+	if len(pricedSKUs) == 0 {
+		return nil, ErrWhiteLabelPriceRequired
+	}
+
+	existing, err := s.repo.GetWhiteLabelProductsForCustomer(ctx, repository.GetWhiteLabelProductsForCustomerParams{
+		TenantID:             tenantID,
+		WhiteLabelCustomerID: customer.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list customer white-label products: %w", err)
+	}
+	for _, product := range existing {
+		if strings.EqualFold(product.Name, params.Name) {
+			return nil, ErrDuplicateWhiteLabel
+		}
+	}
+
+	priceListID, err := s.priceListFor(ctx, tenantID, customer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Slugs and SKU codes are unique per tenant, but white-label products
+	// never appear on the storefront, so a random suffix keeps them apart
+	suffix := uuid.New().String()[:8]
+
+	shortDescription := base.Product.ShortDescription
+	if params.ShortDescription != "" {
+		shortDescription = makePgText(params.ShortDescription)
+	}
+	description := base.Product.Description
+	if params.Description != "" {
+		description = makePgText(params.Description)
+	}
+
+	product, err := s.repo.CreateProduct(ctx, repository.CreateProductParams{
+		TenantID:             tenantID,
+		Name:                 params.Name,
+		Slug:                 generateSlug(params.Name) + "-" + suffix,
+		ShortDescription:     shortDescription,
+		Description:          description,
+		Status:               "active",
+		Visibility:           string(domain.ProductVisibilityWholesaleOnly),
+		Origin:               base.Product.Origin,
+		Region:               base.Product.Region,
+		Producer:             base.Product.Producer,
+		Process:              base.Product.Process,
+		RoastLevel:           base.Product.RoastLevel,
+		TastingNotes:         base.Product.TastingNotes,
+		ElevationMin:         base.Product.ElevationMin,
+		ElevationMax:         base.Product.ElevationMax,
+		IsWhiteLabel:         true,
+		BaseProductID:        base.Product.ID,
+		WhiteLabelCustomerID: customer.ID,
+		SortOrder:            base.Product.SortOrder,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create white-label product: %w", err)
+	}
+
+	for _, baseSKU := range pricedSKUs {
+		price := params.Prices[uuidToString(baseSKU.ID)]
+
+		sku, err := s.repo.CreateWhiteLabelSKU(ctx, repository.CreateWhiteLabelSKUParams{
+			TenantID:       tenantID,
+			ProductID:      product.ID,
+			Sku:            baseSKU.Sku + "-" + strings.ToUpper(suffix),
+			BasePriceCents: price,
+			BaseSkuID:      baseSKU.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create white-label SKU: %w", err)
+		}
+
+		err = s.repo.UpsertPriceListEntry(ctx, repository.UpsertPriceListEntryParams{
+			TenantID:     tenantID,
+			PriceListID:  priceListID,
+			ProductSkuID: sku.ID,
+			PriceCents:   price,
+			IsAvailable:  true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to set white-label price: %w", err)
+		}
+	}
+
+	return &product, nil
+}
+
+// ListWhiteLabelProducts returns the white-label products of a base product.
+func (s *whiteLabelService) ListWhiteLabelProducts(ctx context.Context, baseProductID string) ([]repository.ListWhiteLabelProductsRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var productUUID pgtype.UUID
+	if err := productUUID.Scan(baseProductID); err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	products, err := s.repo.ListWhiteLabelProducts(ctx, repository.ListWhiteLabelProductsParams{
+		TenantID:      tenantID,
+		BaseProductID: productUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list white-label products: %w", err)
+	}
+
+	return products, nil
+}
+
+// GetWhiteLabelDetails returns the base product and customer of a
+// white-label product.
+func (s *whiteLabelService) GetWhiteLabelDetails(ctx context.Context, productID string) (*repository.GetWhiteLabelDetailsRow, error) {
+	tenantID, err := ExtractTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var productUUID pgtype.UUID
+	if err := productUUID.Scan(productID); err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	details, err := s.repo.GetWhiteLabelDetails(ctx, repository.GetWhiteLabelDetailsParams{
+		TenantID: tenantID,
+		ID:       productUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get white-label details: %w", err)
+	}
+
+	return &details, nil
+}
+
+// getBase loads a standard product and its active SKUs.
+func (s *whiteLabelService) getBase(ctx context.Context, tenantID pgtype.UUID, productID string) (*domain.WhiteLabelBase, error) {
+	var productUUID pgtype.UUID
+	if err := productUUID.Scan(productID); err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	product, err := s.repo.GetProductByID(ctx, repository.GetProductByIDParams{
+		TenantID: tenantID,
+		ID:       productUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	if product.IsWhiteLabel {
+		return nil, ErrWhiteLabelBaseInvalid
+	}
+
+	skus, err := s.repo.GetProductSKUs(ctx, product.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product SKUs: %w", err)
+	}
+
+	return &domain.WhiteLabelBase{Product: product, SKUs: skus}, nil
+}
+
+// getCustomer loads an active wholesale customer.
+func (s *whiteLabelService) getCustomer(ctx context.Context, tenantID pgtype.UUID, customerID string) (repository.User, error) {
+	var customerUUID pgtype.UUID
+	if err := customerUUID.Scan(customerID); err != nil {
+		return repository.User{}, ErrWhiteLabelCustomerInvalid
+	}
+
+	customer, err := s.repo.GetUserByIDAndTenant(ctx, repository.GetUserByIDAndTenantParams{
+		ID:       customerUUID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.User{}, ErrWhiteLabelCustomerInvalid
+		}
+		return repository.User{}, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	if customer.AccountType != "wholesale" {
+		return repository.User{}, ErrWhiteLabelCustomerInvalid
+	}
+
+	return customer, nil
+}
+
+// priceListFor returns the customer's own price list, which the white-label
+// prices go on. They're kept off the default price list every customer
+// shares, so a customer priced from it can't have white-label products.
+func (s *whiteLabelService) priceListFor(ctx context.Context, tenantID, customerID pgtype.UUID) (pgtype.UUID, error) {
+	customerList, err := s.repo.GetPriceListForUser(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgtype.UUID{}, ErrWhiteLabelPriceList
+		}
+		return pgtype.UUID{}, fmt.Errorf("failed to get customer price list: %w", err)
+	}
+	if !customerList.Valid {
+		return pgtype.UUID{}, ErrWhiteLabelPriceList
+	}
+
+	defaultList, err := s.repo.GetDefaultPriceList(ctx, tenantID)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("failed to get default price list: %w", err)
+	}
+	if customerList == defaultList.ID {
+		return pgtype.UUID{}, ErrWhiteLabelPriceList
+	}
+
+	return customerList, nil
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dukerupert/hiri/internal/domain"
+	"github.com/dukerupert/hiri/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestWhiteLabelService(t *testing.T) (domain.WhiteLabelService, *repository.MockQuerier) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	return NewWhiteLabelService(mockRepo), mockRepo
+}
+
+// expectWhiteLabelBase sets up a standard base product with two SKUs.
+func expectWhiteLabelBase(mockRepo *repository.MockQuerier, tenantID pgtype.UUID) (repository.Product, []repository.ProductSku) {
+	base := repository.Product{
+		ID:               newUUID(),
+		TenantID:         tenantID,
+		Name:             "Ethiopia Guji",
+		Origin:           pgtype.Text{String: "Ethiopia", Valid: true},
+		ShortDescription: pgtype.Text{String: "Stone fruit and florals", Valid: true},
+		TastingNotes:     []string{"peach", "jasmine"},
+	}
+	skus := []repository.ProductSku{
+		{ID: newUUID(), ProductID: base.ID, Sku: "GUJI-12OZ-WB"},
+		{ID: newUUID(), ProductID: base.ID, Sku: "GUJI-5LB-WB"},
+	}
+
+	mockRepo.EXPECT().GetProductByID(gomock.Any(), repository.GetProductByIDParams{
+		TenantID: tenantID,
+		ID:       base.ID,
+	}).Return(base, nil)
+	mockRepo.EXPECT().GetProductSKUs(gomock.Any(), base.ID).Return(skus, nil)
+
+	return base, skus
+}
+
+func TestWhiteLabelService_CreateWhiteLabelProduct(t *testing.T) {
+	svc, mockRepo := newTestWhiteLabelService(t)
+	tenantID := newUUID()
+	customerID := newUUID()
+	productID := newUUID()
+	defaultListID := newUUID()
+	customerListID := newUUID()
+
+	base, skus := expectWhiteLabelBase(mockRepo, tenantID)
+
+	mockRepo.EXPECT().GetUserByIDAndTenant(gomock.Any(), repository.GetUserByIDAndTenantParams{
+		ID:       customerID,
+		TenantID: tenantID,
+	}).Return(repository.User{ID: customerID, AccountType: "wholesale"}, nil)
+	mockRepo.EXPECT().GetWhiteLabelProductsForCustomer(gomock.Any(), gomock.Any()).Return([]repository.Product{}, nil)
+	mockRepo.EXPECT().CreateProduct(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg repository.CreateProductParams) (repository.Product, error) {
+			assert.Equal(t, "Corner Café House Roast", arg.Name)
+			assert.True(t, strings.HasPrefix(arg.Slug, "corner-caf-house-roast-"))
+			assert.True(t, arg.IsWhiteLabel)
+			assert.Equal(t, base.ID, arg.BaseProductID)
+			assert.Equal(t, customerID, arg.WhiteLabelCustomerID)
+			assert.Equal(t, "active", arg.Status)
+			assert.Equal(t, "wholesale_only", arg.Visibility)
+			assert.Equal(t, base.Origin, arg.Origin)
+			assert.Equal(t, base.TastingNotes, arg.TastingNotes)
+			// Descriptions fall back to the base product's when left blank
+			assert.Equal(t, base.ShortDescription, arg.ShortDescription)
+			return repository.Product{ID: productID, Name: arg.Name, IsWhiteLabel: true}, nil
+		})
+	mockRepo.EXPECT().GetDefaultPriceList(gomock.Any(), tenantID).Return(repository.PriceList{ID: defaultListID}, nil)
+	mockRepo.EXPECT().GetPriceListForUser(gomock.Any(), customerID).Return(customerListID, nil)
+
+	// Only the priced 12oz SKU is cloned
+	cloneID := newUUID()
+	mockRepo.EXPECT().CreateWhiteLabelSKU(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg repository.CreateWhiteLabelSKUParams) (repository.ProductSku, error) {
+			assert.Equal(t, productID, arg.ProductID)
+			assert.Equal(t, skus[0].ID, arg.BaseSkuID)
+			assert.True(t, strings.HasPrefix(arg.Sku, "GUJI-12OZ-WB-"))
+			assert.Equal(t, int32(1250), arg.BasePriceCents)
+			return repository.ProductSku{ID: cloneID, BaseSkuID: arg.BaseSkuID}, nil
+		})
+	// The price only goes on the customer's own price list
+	mockRepo.EXPECT().UpsertPriceListEntry(gomock.Any(), repository.UpsertPriceListEntryParams{
+		TenantID:     tenantID,
+		PriceListID:  customerListID,
+		ProductSkuID: cloneID,
+		PriceCents:   1250,
+		IsAvailable:  true,
+	}).Return(nil)
+
+	product, err := svc.CreateWhiteLabelProduct(contextWithTenant(tenantID), domain.CreateWhiteLabelParams{
+		BaseProductID: uuidToString(base.ID),
+		CustomerID:    uuidToString(customerID),
+		Name:          " Corner Café House Roast ",
+		Prices:        map[string]int32{uuidToString(skus[0].ID): 1250},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, productID, product.ID)
+}
+
+func TestWhiteLabelService_CreateWhiteLabelProduct_RequiresOwnPriceList(t *testing.T) {
+	tenantID := newUUID()
+	customerID := newUUID()
+	defaultListID := newUUID()
+
+	tests := []struct {
+		name        string
+		customerErr error
+		customerID  pgtype.UUID
+	}{
+		{name: "no price list", customerErr: pgx.ErrNoRows},
+		{name: "default price list", customerID: defaultListID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockRepo := newTestWhiteLabelService(t)
+			base, skus := expectWhiteLabelBase(mockRepo, tenantID)
+
+			mockRepo.EXPECT().GetUserByIDAndTenant(gomock.Any(), gomock.Any()).
+				Return(repository.User{ID: customerID, AccountType: "wholesale"}, nil)
+			mockRepo.EXPECT().GetWhiteLabelProductsForCustomer(gomock.Any(), gomock.Any()).Return([]repository.Product{}, nil)
+			mockRepo.EXPECT().GetPriceListForUser(gomock.Any(), customerID).Return(tt.customerID, tt.customerErr)
+			mockRepo.EXPECT().GetDefaultPriceList(gomock.Any(), tenantID).
+				Return(repository.PriceList{ID: defaultListID}, nil).AnyTimes()
+			mockRepo.EXPECT().CreateProduct(gomock.Any(), gomock.Any()).Times(0)
+			mockRepo.EXPECT().UpsertPriceListEntry(gomock.Any(), gomock.Any()).Times(0)
+
+			_, err := svc.CreateWhiteLabelProduct(contextWithTenant(tenantID), domain.CreateWhiteLabelParams{
+				BaseProductID: uuidToString(base.ID),
+				CustomerID:    uuidToString(customerID),
+				Name:          "Corner Café House Roast",
+				Prices:        map[string]int32{uuidToString(skus[0].ID): 1250},
+			})
+			assert.ErrorIs(t, err, ErrWhiteLabelPriceList)
+		})
+	}
+}
+
+func TestWhiteLabelService_CreateWhiteLabelProduct_WhiteLabelBase(t *testing.T) {
+	svc, mockRepo := newTestWhiteLabelService(t)
+	tenantID := newUUID()
+	productID := newUUID()
+
+	mockRepo.EXPECT().GetProductByID(gomock.Any(), gomock.Any()).
+		Return(repository.Product{ID: productID, IsWhiteLabel: true}, nil)
+
+	_, err := svc.CreateWhiteLabelProduct(contextWithTenant(tenantID), domain.CreateWhiteLabelParams{
+		BaseProductID: uuidToString(productID),
+		CustomerID:    uuidToString(newUUID()),
+		Name:          "Corner Café House Roast",
+	})
+	assert.ErrorIs(t, err, ErrWhiteLabelBaseInvalid)
+}
+
+func TestWhiteLabelService_CreateWhiteLabelProduct_RetailCustomer(t *testing.T) {
+	svc, mockRepo := newTestWhiteLabelService(t)
+	tenantID := newUUID()
+	customerID := newUUID()
+
+	base, skus := expectWhiteLabelBase(mockRepo, tenantID)
+
+	mockRepo.EXPECT().GetUserByIDAndTenant(gomock.Any(), gomock.Any()).
+		Return(repository.User{ID: customerID, AccountType: "retail"}, nil)
+
+	_, err := svc.CreateWhiteLabelProduct(contextWithTenant(tenantID), domain.CreateWhiteLabelParams{
+		BaseProductID: uuidToString(base.ID),
+		CustomerID:    uuidToString(customerID),
+		Name:          "Corner Café House Roast",
+		Prices:        map[string]int32{uuidToString(skus[0].ID): 1250},
+	})
+	assert.ErrorIs(t, err, ErrWhiteLabelCustomerInvalid)
+}
+
+func TestWhiteLabelService_CreateWhiteLabelProduct_NoPrices(t *testing.T) {
+	svc, mockRepo := newTestWhiteLabelService(t)
+	tenantID := newUUID()
+	customerID := newUUID()
+
+	base, _ := expectWhiteLabelBase(mockRepo, tenantID)
+
+	mockRepo.EXPECT().GetUserByIDAndTenant(gomock.Any(), gomock.Any()).
+		Return(repository.User{ID: customerID, AccountType: "wholesale"}, nil)
+
+	// Prices for SKUs of another product are ignored
+	_, err := svc.CreateWhiteLabelProduct(contextWithTenant(tenantID), domain.CreateWhiteLabelParams{
+		BaseProductID: uuidToString(base.ID),
+		CustomerID:    uuidToString(customerID),
+		Name:          "Corner Café House Roast",
+		Prices:        map[string]int32{uuidToString(newUUID()): 1250},
+	})
+	assert.ErrorIs(t, err, ErrWhiteLabelPriceRequired)
+}
+
+func TestWhiteLabelService_CreateWhiteLabelProduct_DuplicateName(t *testing.T) {
+	svc, mockRepo := newTestWhiteLabelService(t)
+	tenantID := newUUID()
+	customerID := newUUID()
+
+	base, skus := expectWhiteLabelBase(mockRepo, tenantID)
+
+	mockRepo.EXPECT().GetUserByIDAndTenant(gomock.Any(), gomock.Any()).
+		Return(repository.User{ID: customerID, AccountType: "wholesale"}, nil)
+	mockRepo.EXPECT().GetWhiteLabelProductsForCustomer(gomock.Any(), repository.GetWhiteLabelProductsForCustomerParams{
+		TenantID:             tenantID,
+		WhiteLabelCustomerID: customerID,
+	}).Return([]repository.Product{{ID: newUUID(), Name: "Corner Café House Roast"}}, nil)
+
+	_, err := svc.CreateWhiteLabelProduct(contextWithTenant(tenantID), domain.CreateWhiteLabelParams{
+		BaseProductID: uuidToString(base.ID),
+		CustomerID:    uuidToString(customerID),
+		Name:          "corner café house roast",
+		Prices:        map[string]int32{uuidToString(skus[0].ID): 1250},
+	})
+	assert.ErrorIs(t, err, ErrDuplicateWhiteLabel)
+}
+
+func TestWhiteLabelService_CreateWhiteLabelProduct_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  domain.CreateWhiteLabelParams
+		wantErr error
+	}{
+		{name: "missing name", params: domain.CreateWhiteLabelParams{Name: " "}, wantErr: ErrWhiteLabelNameRequired},
+		{name: "unknown base product", params: domain.CreateWhiteLabelParams{Name: "House Roast", BaseProductID: "not-a-uuid"}, wantErr: ErrProductNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// No repository calls are expected
+			svc, _ := newTestWhiteLabelService(t)
+
+			_, err := svc.CreateWhiteLabelProduct(contextWithTenant(newUUID()), tt.params)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWhiteLabelService_GetWhiteLabelDetails_NotWhiteLabel(t *testing.T) {
+	svc, mockRepo := newTestWhiteLabelService(t)
+
+	mockRepo.EXPECT().GetWhiteLabelDetails(gomock.Any(), gomock.Any()).
+		Return(repository.GetWhiteLabelDetailsRow{}, pgx.ErrNoRows)
+
+	_, err := svc.GetWhiteLabelDetails(contextWithTenant(newUUID()), uuidToString(newUUID()))
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
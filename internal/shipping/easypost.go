@@ -2,6 +2,10 @@ package shipping
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"math"
@@ -10,6 +14,7 @@ import (
 	"time"
 
 	"github.com/EasyPost/easypost-go/v5"
+	"golang.org/x/text/unicode/norm"
 )
 
 // Conversion constants for metric to imperial units.
@@ -171,16 +176,7 @@ func (p *EasyPostProvider) CreateLabel(ctx context.Context, params LabelParams)
 	// IDEMPOTENCY: Check if already purchased
 	if shipment.PostageLabel != nil && shipment.PostageLabel.LabelURL != "" {
 		logger.Info("returning existing label (idempotent)")
-		createdAt := time.Now()
-		if shipment.CreatedAt != nil {
-			createdAt = shipment.CreatedAt.AsTime()
-		}
-		return &Label{
-			LabelID:        shipment.ID,
-			TrackingNumber: shipment.TrackingCode,
-			LabelURL:       shipment.PostageLabel.LabelURL,
-			CreatedAt:      createdAt,
-		}, nil
+		return p.fromEasyPostShipment(shipment, shipment.SelectedRate)
 	}
 
 	// Find the selected rate
@@ -203,22 +199,12 @@ func (p *EasyPostProvider) CreateLabel(ctx context.Context, params LabelParams)
 		return nil, fmt.Errorf("failed to purchase label: %w", err)
 	}
 
-	createdAt := time.Now()
-	if boughtShipment.CreatedAt != nil {
-		createdAt = boughtShipment.CreatedAt.AsTime()
-	}
-
 	logger.Info("label purchased successfully",
 		"tracking_number", boughtShipment.TrackingCode,
 		"label_id", boughtShipment.ID,
 	)
 
-	return &Label{
-		LabelID:        boughtShipment.ID,
-		TrackingNumber: boughtShipment.TrackingCode,
-		LabelURL:       boughtShipment.PostageLabel.LabelURL,
-		CreatedAt:      createdAt,
-	}, nil
+	return p.fromEasyPostShipment(boughtShipment, selectedRate)
 }
 
 // VoidLabel cancels a shipping label and requests a refund.
@@ -272,7 +258,7 @@ func (p *EasyPostProvider) TrackShipment(ctx context.Context, trackingNumber str
 	}
 
 	logger.Info("tracking info fetched", "status", tracker.Status)
-	return p.fromEasyPostTracker(tracker), nil
+	return fromEasyPostTracker(tracker), nil
 }
 
 // ValidateAddress validates and potentially corrects a shipping address.
@@ -406,11 +392,53 @@ func (p *EasyPostProvider) fromEasyPostRate(r *easypost.Rate, shipmentID string,
 	}, nil
 }
 
+// fromEasyPostShipment converts a purchased EasyPost Shipment to our Label type.
+func (p *EasyPostProvider) fromEasyPostShipment(shipment *easypost.Shipment, rate *easypost.Rate) (*Label, error) {
+	if shipment.PostageLabel == nil {
+		return nil, ErrLabelNotFound
+	}
+
+	createdAt := time.Now()
+	if shipment.CreatedAt != nil {
+		createdAt = shipment.CreatedAt.AsTime()
+	}
+
+	label := &Label{
+		LabelID:        shipment.ID,
+		TrackingNumber: shipment.TrackingCode,
+		LabelURL:       shipment.PostageLabel.LabelURL,
+		CreatedAt:      createdAt,
+	}
+
+	// Labels are generated as PNG; keep the image for merged batch documents
+	// and prefer a PDF rendition for printing when one has been generated
+	if strings.HasPrefix(shipment.PostageLabel.LabelFileType, "image/") {
+		label.ImageURL = shipment.PostageLabel.LabelURL
+	}
+	if shipment.PostageLabel.LabelPDFURL != "" {
+		label.LabelURL = shipment.PostageLabel.LabelPDFURL
+	}
+
+	if shipment.Tracker != nil {
+		label.TrackingURL = shipment.Tracker.PublicURL
+	}
+
+	if rate != nil {
+		label.Carrier = rate.Carrier
+		label.ServiceName = rate.Service
+		if costCents, err := dollarsToCents(rate.Rate); err == nil {
+			label.CostCents = costCents
+		}
+	}
+
+	return label, nil
+}
+
 // fromEasyPostTracker converts EasyPost Tracker to our TrackingInfo.
-func (p *EasyPostProvider) fromEasyPostTracker(t *easypost.Tracker) *TrackingInfo {
+func fromEasyPostTracker(t *easypost.Tracker) *TrackingInfo {
 	info := &TrackingInfo{
 		TrackingNumber: t.TrackingCode,
-		Status:         t.Status,
+		Status:         easyPostTrackingStatus(t.Status),
 	}
 
 	// Parse estimated delivery
@@ -421,7 +449,7 @@ func (p *EasyPostProvider) fromEasyPostTracker(t *easypost.Tracker) *TrackingInf
 	// Convert tracking details to events
 	for _, detail := range t.TrackingDetails {
 		event := TrackingEvent{
-			Status:      detail.Status,
+			Status:      easyPostTrackingStatus(detail.Status),
 			Description: detail.Message,
 		}
 		if detail.DateTime != "" {
@@ -430,11 +458,7 @@ func (p *EasyPostProvider) fromEasyPostTracker(t *easypost.Tracker) *TrackingInf
 			}
 		}
 		if detail.TrackingLocation != nil {
-			event.Location = fmt.Sprintf("%s, %s %s",
-				detail.TrackingLocation.City,
-				detail.TrackingLocation.State,
-				detail.TrackingLocation.Zip,
-			)
+			event.Location = formatTrackingLocation(detail.TrackingLocation)
 		}
 		info.Events = append(info.Events, event)
 	}
@@ -442,6 +466,76 @@ func (p *EasyPostProvider) fromEasyPostTracker(t *easypost.Tracker) *TrackingInf
 	return info
 }
 
+// easyPostTrackingStatus maps an EasyPost tracker status to a tracking status.
+// Unrecognized statuses are treated as not yet moving.
+func easyPostTrackingStatus(status string) string {
+	switch status {
+	case "in_transit", "available_for_pickup":
+		return TrackingStatusInTransit
+	case "out_for_delivery":
+		return TrackingStatusOutForDelivery
+	case "delivered":
+		return TrackingStatusDelivered
+	case "return_to_sender":
+		return TrackingStatusReturned
+	case "failure", "error":
+		return TrackingStatusFailed
+	case "cancelled":
+		return TrackingStatusCancelled
+	default: // pre_transit, unknown
+		return TrackingStatusLabelCreated
+	}
+}
+
+// formatTrackingLocation renders a tracking location as "City, ST 12345",
+// leaving out any parts the carrier did not report.
+func formatTrackingLocation(loc *easypost.TrackingLocation) string {
+	location := loc.City
+	if region := strings.TrimSpace(loc.State + " " + loc.Zip); region != "" {
+		if location != "" {
+			location += ", "
+		}
+		location += region
+	}
+	return location
+}
+
+// ParseEasyPostWebhook verifies an EasyPost webhook against the webhook secret
+// and returns the tracking update it carries.
+// Returns nil without error for events that are not tracker updates.
+func ParseEasyPostWebhook(body []byte, signature, secret string) (*TrackingInfo, error) {
+	if secret == "" || signature == "" {
+		return nil, ErrInvalidWebhookSignature
+	}
+
+	// Same digest as the SDK's ValidateWebhook. The SDK's Event decoder is
+	// not used: it recurses forever on newer encoding/json implementations.
+	mac := hmac.New(sha256.New, []byte(norm.NFKD.String(secret)))
+	mac.Write(body)
+	expected := "hmac-sha256-hex=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, ErrInvalidWebhookSignature
+	}
+
+	var event struct {
+		Description string          `json:"description"`
+		Result      json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook: %w", err)
+	}
+	if !strings.HasPrefix(event.Description, "tracker.") || len(event.Result) == 0 {
+		return nil, nil
+	}
+
+	var tracker easypost.Tracker
+	if err := json.Unmarshal(event.Result, &tracker); err != nil {
+		return nil, fmt.Errorf("failed to parse tracker: %w", err)
+	}
+
+	return fromEasyPostTracker(&tracker), nil
+}
+
 // filterRatesByService filters rates to only include specified service types.
 func (p *EasyPostProvider) filterRatesByService(rates []Rate, services []string) []Rate {
 	serviceSet := make(map[string]bool)
@@ -0,0 +1,92 @@
+package shipping_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/dukerupert/hiri/internal/shipping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "whsec_test"
+
+const trackerUpdatedEvent = `{
+  "object": "Event",
+  "id": "evt_123",
+  "description": "tracker.updated",
+  "result": {
+    "object": "Tracker",
+    "id": "trk_123",
+    "tracking_code": "9400100000000000000000",
+    "status": "out_for_delivery",
+    "tracking_details": [
+      {
+        "object": "TrackingDetail",
+        "message": "Pre-Shipment Info Sent to USPS",
+        "status": "pre_transit",
+        "datetime": "2026-03-01T10:00:00Z"
+      },
+      {
+        "object": "TrackingDetail",
+        "message": "Out for Delivery",
+        "status": "out_for_delivery",
+        "datetime": "2026-03-03T07:15:00Z",
+        "tracking_location": {"object": "TrackingLocation", "city": "PORTLAND", "state": "OR", "zip": "97201"}
+      }
+    ]
+  }
+}`
+
+func signWebhook(body, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "hmac-sha256-hex=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseEasyPostWebhook_TrackerUpdated(t *testing.T) {
+	info, err := shipping.ParseEasyPostWebhook([]byte(trackerUpdatedEvent), signWebhook(trackerUpdatedEvent, testWebhookSecret), testWebhookSecret)
+
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, "9400100000000000000000", info.TrackingNumber)
+	assert.Equal(t, shipping.TrackingStatusOutForDelivery, info.Status)
+	require.Len(t, info.Events, 2)
+	assert.Equal(t, shipping.TrackingStatusLabelCreated, info.Events[0].Status)
+	assert.Equal(t, "Out for Delivery", info.Events[1].Description)
+	assert.Equal(t, "PORTLAND, OR 97201", info.Events[1].Location)
+	assert.True(t, info.Events[1].Timestamp.Equal(time.Date(2026, 3, 3, 7, 15, 0, 0, time.UTC)))
+}
+
+func TestParseEasyPostWebhook_InvalidSignature(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+		secret    string
+	}{
+		{name: "wrong secret", signature: signWebhook(trackerUpdatedEvent, "whsec_other"), secret: testWebhookSecret},
+		{name: "missing signature", signature: "", secret: testWebhookSecret},
+		{name: "secret not configured", signature: signWebhook(trackerUpdatedEvent, ""), secret: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := shipping.ParseEasyPostWebhook([]byte(trackerUpdatedEvent), tt.signature, tt.secret)
+
+			assert.ErrorIs(t, err, shipping.ErrInvalidWebhookSignature)
+			assert.Nil(t, info)
+		})
+	}
+}
+
+func TestParseEasyPostWebhook_IgnoresOtherEvents(t *testing.T) {
+	body := `{"object": "Event", "description": "batch.updated", "result": {"object": "Batch", "id": "batch_123"}}`
+
+	info, err := shipping.ParseEasyPostWebhook([]byte(body), signWebhook(body, testWebhookSecret), testWebhookSecret)
+
+	require.NoError(t, err)
+	assert.Nil(t, info)
+}
@@ -91,6 +91,9 @@ var (
 
 	// ErrInvalidRateIDFormat is returned when the rate ID format is invalid.
 	ErrInvalidRateIDFormat = newShippingError(codeInvalid, "Invalid rate ID format")
+
+	// ErrInvalidWebhookSignature is returned when a provider webhook fails signature verification.
+	ErrInvalidWebhookSignature = newShippingError(codeForbidden, "Invalid webhook signature")
 )
 
 // ErrInvalidAmount creates an error for invalid amount parsing.
@@ -2,6 +2,7 @@ package shipping
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
@@ -78,7 +79,12 @@ type Rate struct {
 type Label struct {
 	LabelID        string
 	TrackingNumber string
-	LabelURL       string
+	TrackingURL    string // Public tracking page, if the provider offers one
+	LabelURL       string // Provider-hosted label file (PDF when supported)
+	ImageURL       string // Provider-hosted PNG/JPEG rendition of the label, if available
+	Carrier        string
+	ServiceName    string
+	CostCents      int64 // Postage paid to the carrier
 	CreatedAt      time.Time
 }
 
@@ -104,6 +110,18 @@ type ValidateAddressParams struct {
 	Address  ShippingAddress // Required: Address to validate
 }
 
+// Tracking statuses reported in TrackingInfo and TrackingEvent.
+// Providers map their own codes onto these, which match shipment statuses.
+const (
+	TrackingStatusLabelCreated   = "label_created"
+	TrackingStatusInTransit      = "in_transit"
+	TrackingStatusOutForDelivery = "out_for_delivery"
+	TrackingStatusDelivered      = "delivered"
+	TrackingStatusReturned       = "returned"
+	TrackingStatusFailed         = "failed"
+	TrackingStatusCancelled      = "cancelled"
+)
+
 // TrackingInfo contains shipment tracking information.
 type TrackingInfo struct {
 	TrackingNumber        string
@@ -139,3 +157,20 @@ type AddressValidation struct {
 	SuggestedAddress *ShippingAddress // nil if no suggestion available
 	Messages         []string         // Validation messages or errors
 }
+
+// TrackingURL returns the public tracking page for common carriers.
+// Returns an empty string if the carrier is not recognized.
+func TrackingURL(carrier, trackingNumber string) string {
+	switch strings.ToUpper(carrier) {
+	case "USPS":
+		return "https://tools.usps.com/go/TrackConfirmAction?tLabels=" + trackingNumber
+	case "UPS":
+		return "https://www.ups.com/track?tracknum=" + trackingNumber
+	case "FEDEX":
+		return "https://www.fedex.com/fedextrack/?trknbr=" + trackingNumber
+	case "DHL", "DHLEXPRESS":
+		return "https://www.dhl.com/en/express/tracking.html?AWB=" + trackingNumber
+	default:
+		return ""
+	}
+}
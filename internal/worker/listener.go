@@ -0,0 +1,97 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NotifyChannelPrefix prefixes the queue name in the channel the
+// notify_job_queued trigger signals when a job is ready, e.g. jobs_email.
+const NotifyChannelPrefix = "jobs_"
+
+// listenRetryInterval is how long to wait before reconnecting a dropped
+// listener connection.
+const listenRetryInterval = 5 * time.Second
+
+// Listener tells the worker when jobs are queued. Listen calls wake with
+// the queue name whenever a job becomes ready on one of queues, and blocks
+// until ctx is done.
+type Listener interface {
+	Listen(ctx context.Context, queues []string, wake func(queue string)) error
+}
+
+// PGListener is a Listener using Postgres LISTEN/NOTIFY. It holds one pool
+// connection for as long as it listens.
+type PGListener struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewPGListener creates a LISTEN/NOTIFY listener on pool.
+func NewPGListener(pool *pgxpool.Pool, logger *slog.Logger) *PGListener {
+	return &PGListener{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// Listen listens on each queue's channel, reconnecting if the connection
+// drops. Notifications sent while disconnected are lost, so every queue is
+// woken after each (re)connect.
+func (l *PGListener) Listen(ctx context.Context, queues []string, wake func(queue string)) error {
+	for {
+		err := l.listen(ctx, queues, wake)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		l.logger.Error("job listener disconnected, falling back to polling",
+			"error", err,
+			"retry_in", listenRetryInterval,
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+func (l *PGListener) listen(ctx context.Context, queues []string, wake func(queue string)) error {
+	poolConn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	// A connection with active LISTENs is closed, never returned to the pool
+	conn := poolConn.Hijack()
+	defer conn.Close(context.WithoutCancel(ctx))
+
+	for _, queue := range queues {
+		channel := pgx.Identifier{NotifyChannelPrefix + queue}.Sanitize()
+		if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", channel, err)
+		}
+	}
+
+	l.logger.Info("job listener connected", "queues", queues)
+
+	for _, queue := range queues {
+		wake(queue)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		wake(strings.TrimPrefix(notification.Channel, NotifyChannelPrefix))
+	}
+}
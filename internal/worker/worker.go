@@ -3,11 +3,16 @@ package worker
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/dukerupert/hiri/internal/domain"
@@ -21,26 +26,107 @@ type Config struct {
 	// WorkerID uniquely identifies this worker instance
 	WorkerID string
 
-	// PollInterval is how often to check for new jobs
+	// PollInterval is how often to check for new jobs. With a Listener,
+	// polling is only a fallback for missed notifications and jobs
+	// scheduled in the future.
 	PollInterval time.Duration
 
 	// MaxConcurrency is the maximum number of jobs to process concurrently
+	// from queues without their own limit in QueueConcurrency
 	MaxConcurrency int
 
+	// QueueConcurrency limits how many jobs run at once per queue, so a
+	// large batch on one queue cannot starve another. Each listed queue has
+	// its own slots. Nil means DefaultQueueConcurrency.
+	QueueConcurrency map[string]int
+
 	// Queue name to process (empty string = all queues)
 	Queue string
 
 	// TenantID to process jobs for (nil = all tenants)
 	TenantID *uuid.UUID
+
+	// ShutdownTimeout is how long in-flight jobs may run after shutdown
+	// begins. Jobs still running then are cancelled and put back in the
+	// queue for another worker.
+	ShutdownTimeout time.Duration
+
+	// HeartbeatInterval is how often the worker marks its running jobs as
+	// alive
+	HeartbeatInterval time.Duration
+
+	// StaleAfter is how long a running job can go without a heartbeat
+	// before the reaper assumes its worker died and requeues it
+	StaleAfter time.Duration
+
+	// ReapInterval is how often to look for stale jobs
+	ReapInterval time.Duration
+
+	// MaxBackoff caps the exponential delay between retries
+	MaxBackoff time.Duration
+}
+
+// DefaultQueueConcurrency gives each queue the worker knows about its own
+// limit. Email has the most slots since customers wait on it.
+func DefaultQueueConcurrency() map[string]int {
+	return map[string]int{
+		"email":        5,
+		"invoicing":    2,
+		"fulfillment":  2,
+		"subscription": 2,
+		"inventory":    1,
+		"reviews":      1,
+		"wholesale":    1,
+		"cleanup":      1,
+		"onboarding":   1,
+	}
+}
+
+// defaultJobTimeout applies to jobs with no timeout_seconds.
+const defaultJobTimeout = 5 * time.Minute
+
+// storeTimeout bounds the queue updates made after a job finishes.
+const storeTimeout = 10 * time.Second
+
+// jobStore is the part of the queue the worker loop uses. It is implemented
+// by *repository.Queries.
+type jobStore interface {
+	ClaimNextJob(ctx context.Context, arg repository.ClaimNextJobParams) (repository.Job, error)
+	CompleteJob(ctx context.Context, arg repository.CompleteJobParams) error
+	FailJob(ctx context.Context, arg repository.FailJobParams) (repository.Job, error)
+	HeartbeatWorkerJobs(ctx context.Context, workerID pgtype.Text) (int64, error)
+	ReapStaleJobs(ctx context.Context, staleBefore pgtype.Timestamptz) ([]repository.ReapStaleJobsRow, error)
+	ReleaseWorkerJobs(ctx context.Context, workerID pgtype.Text) (int64, error)
+}
+
+// GracePeriodExpirer suspends tenants whose payment grace period has ended.
+// It is implemented by the SaaS onboarding service.
+type GracePeriodExpirer interface {
+	ExpireGracePeriods(ctx context.Context) (int, error)
 }
 
 // Worker processes background jobs
 type Worker struct {
-	config         Config
-	queries        *repository.Queries
-	emailService   *email.Service
-	invoiceService domain.InvoiceService
-	logger         *slog.Logger
+	config                  Config
+	queries                 *repository.Queries
+	store                   jobStore
+	emailService            *email.Service
+	invoiceService          domain.InvoiceService
+	fulfillmentBatchService domain.FulfillmentBatchService
+	trackingService         domain.ShipmentTrackingService
+	inventoryService        domain.InventoryService
+	reviewService           domain.ReviewService
+	dunningService          domain.DunningService
+	standingOrderService    domain.StandingOrderService
+	gracePeriodExpirer      GracePeriodExpirer
+	listener                Listener
+	logger                  *slog.Logger
+
+	// process runs a claimed job; it is processJob outside of tests
+	process func(ctx context.Context, job *repository.Job) error
+
+	// now is overridable for tests
+	now func() time.Time
 }
 
 // NewWorker creates a new background job worker
@@ -48,6 +134,14 @@ func NewWorker(
 	queries *repository.Queries,
 	emailService *email.Service,
 	invoiceService domain.InvoiceService,
+	fulfillmentBatchService domain.FulfillmentBatchService,
+	trackingService domain.ShipmentTrackingService,
+	inventoryService domain.InventoryService,
+	reviewService domain.ReviewService,
+	dunningService domain.DunningService,
+	standingOrderService domain.StandingOrderService,
+	gracePeriodExpirer GracePeriodExpirer,
+	listener Listener,
 	config Config,
 	logger *slog.Logger,
 ) *Worker {
@@ -61,98 +155,356 @@ func NewWorker(
 	if config.MaxConcurrency == 0 {
 		config.MaxConcurrency = 5
 	}
+	if config.QueueConcurrency == nil {
+		config.QueueConcurrency = DefaultQueueConcurrency()
+	}
+	if config.ShutdownTimeout == 0 {
+		config.ShutdownTimeout = 30 * time.Second
+	}
+	if config.HeartbeatInterval == 0 {
+		config.HeartbeatInterval = 30 * time.Second
+	}
+	if config.StaleAfter == 0 {
+		config.StaleAfter = 5 * time.Minute
+	}
+	if config.ReapInterval == 0 {
+		config.ReapInterval = time.Minute
+	}
+	if config.MaxBackoff == 0 {
+		config.MaxBackoff = time.Hour
+	}
+
+	w := &Worker{
+		config:                  config,
+		queries:                 queries,
+		store:                   queries,
+		emailService:            emailService,
+		invoiceService:          invoiceService,
+		fulfillmentBatchService: fulfillmentBatchService,
+		trackingService:         trackingService,
+		inventoryService:        inventoryService,
+		reviewService:           reviewService,
+		dunningService:          dunningService,
+		standingOrderService:    standingOrderService,
+		gracePeriodExpirer:      gracePeriodExpirer,
+		listener:                listener,
+		logger:                  logger,
+		now:                     time.Now,
+	}
+	w.process = w.processJob
+
+	return w
+}
+
+// queuePool is a set of job slots and the queue they claim from.
+type queuePool struct {
+	// queue is claimed from; empty claims from any queue not in exclude
+	queue   string
+	exclude []string
+	slots   chan struct{}
+	wake    chan struct{}
+}
+
+// newQueuePool creates a pool with limit slots.
+func newQueuePool(queue string, exclude []string, limit int) *queuePool {
+	return &queuePool{
+		queue:   queue,
+		exclude: exclude,
+		slots:   make(chan struct{}, limit),
+		wake:    make(chan struct{}, 1),
+	}
+}
 
-	return &Worker{
-		config:         config,
-		queries:        queries,
-		emailService:   emailService,
-		invoiceService: invoiceService,
-		logger:         logger,
+// nudge asks the pool to try claiming, without blocking.
+func (p *queuePool) nudge() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
 	}
 }
 
-// Start begins processing jobs until the context is cancelled
+// queuePools returns a pool per queue in QueueConcurrency plus a shared
+// pool for every other queue, or a single pool when Queue is set.
+func (w *Worker) queuePools() []*queuePool {
+	if w.config.Queue != "" {
+		limit, ok := w.config.QueueConcurrency[w.config.Queue]
+		if !ok {
+			limit = w.config.MaxConcurrency
+		}
+		return []*queuePool{newQueuePool(w.config.Queue, nil, limit)}
+	}
+
+	queues := make([]string, 0, len(w.config.QueueConcurrency))
+	for queue := range w.config.QueueConcurrency {
+		queues = append(queues, queue)
+	}
+	sort.Strings(queues)
+
+	pools := make([]*queuePool, 0, len(queues)+1)
+	for _, queue := range queues {
+		pools = append(pools, newQueuePool(queue, nil, w.config.QueueConcurrency[queue]))
+	}
+	return append(pools, newQueuePool("", queues, w.config.MaxConcurrency))
+}
+
+// Start begins processing jobs until the context is cancelled. Each queue
+// pool claims as soon as the Listener reports a job, and on every
+// PollInterval as a fallback. On cancellation the worker stops claiming
+// jobs and waits up to ShutdownTimeout for in-flight jobs to finish; any
+// still running are cancelled and released back to the queue before Start
+// returns.
 func (w *Worker) Start(ctx context.Context) error {
+	pools := w.queuePools()
+
 	w.logger.Info("worker starting",
 		"worker_id", w.config.WorkerID,
 		"queue", w.config.Queue,
 		"poll_interval", w.config.PollInterval,
 		"max_concurrency", w.config.MaxConcurrency,
+		"queue_concurrency", w.config.QueueConcurrency,
+		"listening", w.listener != nil,
 	)
 
-	// TODO: Implement main worker loop
-	// 1. Create a ticker for polling
-	// 2. Use a semaphore or goroutine pool for concurrency control
-	// 3. In each poll:
-	//    - Claim next job using ClaimNextJob query
-	//    - If job found, spawn goroutine to process it
-	//    - Process job via processJob()
-	//    - Mark as complete or failed
-	// 4. Handle graceful shutdown on ctx.Done()
+	// Jobs outlive ctx so they can finish during the drain
+	jobsCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelJobs()
 
-	ticker := time.NewTicker(w.config.PollInterval)
-	defer ticker.Stop()
+	var inFlight sync.WaitGroup
 
-	// Semaphore for concurrency control
-	sem := make(chan struct{}, w.config.MaxConcurrency)
+	// Heartbeats continue while draining, so a slow drain isn't reaped
+	stopHeartbeat := make(chan struct{})
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		w.heartbeatLoop(jobsCtx, stopHeartbeat)
+	}()
+
+	var dispatchers sync.WaitGroup
+	for _, pool := range pools {
+		dispatchers.Add(1)
+		go func() {
+			defer dispatchers.Done()
+			w.dispatch(ctx, jobsCtx, pool, &inFlight)
+		}()
+	}
+
+	if w.listener != nil {
+		dispatchers.Add(1)
+		go func() {
+			defer dispatchers.Done()
+			w.listen(ctx, pools)
+		}()
+	}
+
+	reapTicker := time.NewTicker(w.config.ReapInterval)
+	defer reapTicker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			w.logger.Info("worker shutting down", "worker_id", w.config.WorkerID)
-			// Wait for in-flight jobs to complete
-			// TODO: Implement graceful shutdown with timeout
+			// No job is claimed once the dispatchers have stopped
+			dispatchers.Wait()
+			w.drain(jobsCtx, cancelJobs, &inFlight)
+			close(stopHeartbeat)
+			<-heartbeatDone
 			return ctx.Err()
 
-		case <-ticker.C:
-			// Try to claim a job
-			select {
-			case sem <- struct{}{}:
-				// Acquired semaphore, try to claim job
-				go func() {
-					defer func() { <-sem }()
-					w.claimAndProcess(ctx)
-				}()
-			default:
-				// At max concurrency, skip this poll
-			}
+		case <-reapTicker.C:
+			w.reapStaleJobs(ctx)
 		}
 	}
 }
 
-// claimAndProcess claims and processes a single job
-func (w *Worker) claimAndProcess(ctx context.Context) {
-	// Recover from panics so the worker doesn't die silently
-	defer func() {
-		if r := recover(); r != nil {
-			w.logger.Error("job panicked",
-				"panic", fmt.Sprintf("%v", r),
-			)
+// listen wakes the matching pool for each queued-job notification. Queues
+// without their own pool wake the shared pool.
+func (w *Worker) listen(ctx context.Context, pools []*queuePool) {
+	byQueue := make(map[string]*queuePool, len(pools))
+	queues := make([]string, 0, len(pools))
+	var shared *queuePool
+	for _, pool := range pools {
+		if pool.queue == "" {
+			shared = pool
+			continue
 		}
-	}()
+		byQueue[pool.queue] = pool
+		queues = append(queues, pool.queue)
+	}
+
+	wake := func(queue string) {
+		if pool, ok := byQueue[queue]; ok {
+			pool.nudge()
+		} else if shared != nil {
+			shared.nudge()
+		}
+	}
+
+	if err := w.listener.Listen(ctx, queues, wake); err != nil && ctx.Err() == nil {
+		w.logger.Error("job listener stopped, falling back to polling", "error", err)
+	}
+}
+
+// dispatch claims jobs for a pool whenever it is woken or the poll
+// interval passes, until ctx is cancelled.
+func (w *Worker) dispatch(ctx, jobsCtx context.Context, pool *queuePool, inFlight *sync.WaitGroup) {
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.fill(ctx, jobsCtx, pool, inFlight)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-pool.wake:
+		}
+	}
+}
 
+// fill claims jobs until the pool's slots are full or its queue is empty.
+func (w *Worker) fill(ctx, jobsCtx context.Context, pool *queuePool, inFlight *sync.WaitGroup) {
 	var tenantID pgtype.UUID
 	if w.config.TenantID != nil {
 		tenantID = pgtype.UUID{Bytes: *w.config.TenantID, Valid: true}
 	}
 
-	job, err := w.queries.ClaimNextJob(ctx, repository.ClaimNextJobParams{
-		WorkerID: pgtype.Text{String: w.config.WorkerID, Valid: true},
-		TenantID: tenantID,
-		Queue:    w.config.Queue,
-	})
+	for ctx.Err() == nil {
+		select {
+		case pool.slots <- struct{}{}:
+		default:
+			// At the pool's concurrency limit; a finishing job nudges it
+			return
+		}
+
+		job, err := w.store.ClaimNextJob(ctx, repository.ClaimNextJobParams{
+			WorkerID:      w.workerID(),
+			TenantID:      tenantID,
+			Queue:         pool.queue,
+			ExcludeQueues: pool.exclude,
+		})
+		if err != nil {
+			<-pool.slots
+			if !errors.Is(err, pgx.ErrNoRows) && ctx.Err() == nil {
+				w.logger.Error("failed to claim job", "queue", pool.queue, "error", err)
+			}
+			return
+		}
+
+		inFlight.Add(1)
+		go func() {
+			defer inFlight.Done()
+			defer pool.nudge()
+			defer func() { <-pool.slots }()
+			w.runClaimed(jobsCtx, &job)
+		}()
+	}
+}
+
+// drain waits for in-flight jobs. After ShutdownTimeout it cancels them
+// and releases whatever this worker still holds, so the jobs run again on
+// another worker instead of waiting for the reaper.
+func (w *Worker) drain(jobsCtx context.Context, cancelJobs context.CancelFunc, inFlight *sync.WaitGroup) {
+	done := make(chan struct{})
+	go func() {
+		inFlight.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(w.config.ShutdownTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		w.logger.Info("worker drained", "worker_id", w.config.WorkerID)
+		return
+	case <-timer.C:
+	}
+
+	cancelJobs()
+
+	releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(jobsCtx), storeTimeout)
+	defer cancel()
+
+	released, err := w.store.ReleaseWorkerJobs(releaseCtx, w.workerID())
 	if err != nil {
-		// No job available or database error
+		w.logger.Error("failed to release unfinished jobs",
+			"worker_id", w.config.WorkerID,
+			"error", err,
+		)
 		return
 	}
 
+	w.logger.Warn("shutdown timeout reached, released unfinished jobs",
+		"worker_id", w.config.WorkerID,
+		"timeout", w.config.ShutdownTimeout,
+		"released", released,
+	)
+}
+
+// heartbeatLoop refreshes heartbeat_at on this worker's running jobs until
+// stop is closed.
+func (w *Worker) heartbeatLoop(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(w.config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if _, err := w.store.HeartbeatWorkerJobs(ctx, w.workerID()); err != nil && ctx.Err() == nil {
+				w.logger.Error("failed to record job heartbeat",
+					"worker_id", w.config.WorkerID,
+					"error", err,
+				)
+			}
+		}
+	}
+}
+
+// reapStaleJobs requeues jobs whose worker stopped sending heartbeats.
+// Every worker reaps; the update is atomic, so each job is reaped once.
+func (w *Worker) reapStaleJobs(ctx context.Context) {
+	staleBefore := w.now().Add(-w.config.StaleAfter)
+
+	reaped, err := w.store.ReapStaleJobs(ctx, pgtype.Timestamptz{Time: staleBefore, Valid: true})
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Error("failed to reap stale jobs", "error", err)
+		}
+		return
+	}
+
+	for _, job := range reaped {
+		w.logger.Warn("requeued job from unresponsive worker",
+			"job_id", job.ID,
+			"job_type", job.JobType,
+			"status", job.Status,
+			"retry_count", job.RetryCount,
+		)
+	}
+}
+
+// runClaimed processes a claimed job and records the outcome. The job runs
+// on jobsCtx, which is only cancelled when the shutdown drain times out.
+func (w *Worker) runClaimed(jobsCtx context.Context, job *repository.Job) {
 	w.logger.Info("processing job",
 		"job_id", job.ID,
 		"job_type", job.JobType,
 		"retry_count", job.RetryCount,
 	)
 
-	err = w.processJob(ctx, &job)
+	err := w.runJob(jobsCtx, job, jobTimeout(job))
+
+	// A job cut short by the drain timeout is released, not failed
+	if err != nil && jobsCtx.Err() != nil {
+		return
+	}
+
+	storeCtx, cancel := context.WithTimeout(context.WithoutCancel(jobsCtx), storeTimeout)
+	defer cancel()
+
 	if err != nil {
 		w.logger.Error("job failed",
 			"job_id", job.ID,
@@ -160,11 +512,16 @@ func (w *Worker) claimAndProcess(ctx context.Context) {
 			"error", err,
 		)
 		// Mark job as failed (will retry or mark as failed based on retry count)
-		_, _ = w.queries.FailJob(ctx, repository.FailJobParams{
-			ID:           job.ID,
+		retryAt := w.now().Add(retryDelay(job.RetryBackoffSeconds, job.RetryCount, w.config.MaxBackoff))
+		if _, err := w.store.FailJob(storeCtx, repository.FailJobParams{
+			RetryAt:      pgtype.Timestamptz{Time: retryAt, Valid: true},
 			ErrorMessage: pgtype.Text{String: err.Error(), Valid: true},
-			ErrorDetails: []byte("{}"),
-		})
+			ErrorDetails: errorDetails(err),
+			ID:           job.ID,
+			WorkerID:     w.workerID(),
+		}); err != nil {
+			w.logger.Error("failed to record job failure", "job_id", job.ID, "error", err)
+		}
 		return
 	}
 
@@ -174,16 +531,116 @@ func (w *Worker) claimAndProcess(ctx context.Context) {
 	)
 
 	// Mark job as completed
-	_ = w.queries.CompleteJob(ctx, job.ID)
+	if err := w.store.CompleteJob(storeCtx, repository.CompleteJobParams{
+		ID:       job.ID,
+		WorkerID: w.workerID(),
+	}); err != nil {
+		w.logger.Error("failed to mark job completed", "job_id", job.ID, "error", err)
+	}
+}
+
+// errJobTimeout is returned for a job that runs past its timeout.
+var errJobTimeout = errors.New("job timed out")
+
+// runJob runs a job with a deadline. A job that ignores its context is
+// abandoned at the deadline so it can't hold a worker slot forever. Panics
+// are returned as errors so the job is failed rather than left processing.
+func (w *Worker) runJob(ctx context.Context, job *repository.Job, timeout time.Duration) error {
+	jobCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	process := w.process
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("job panicked: %v", r)
+			}
+		}()
+		done <- process(jobCtx, job)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil && errors.Is(jobCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w after %s: %w", errJobTimeout, timeout, err)
+		}
+		return err
+	case <-jobCtx.Done():
+		if errors.Is(jobCtx.Err(), context.DeadlineExceeded) {
+			w.logger.Warn("abandoning job that ignored its timeout",
+				"job_id", job.ID,
+				"job_type", job.JobType,
+				"timeout", timeout,
+			)
+			return fmt.Errorf("%w after %s", errJobTimeout, timeout)
+		}
+		return jobCtx.Err()
+	}
+}
+
+// jobTimeout returns how long a job may run, from timeout_seconds.
+func jobTimeout(job *repository.Job) time.Duration {
+	if job.TimeoutSeconds <= 0 {
+		return defaultJobTimeout
+	}
+	return time.Duration(job.TimeoutSeconds) * time.Second
+}
+
+// retryDelay is the wait before the next attempt: retry_backoff_seconds
+// doubled for each retry already made, capped at maxBackoff, plus up to 10%
+// jitter so jobs that failed together don't retry together.
+func retryDelay(backoffSeconds, retryCount int32, maxBackoff time.Duration) time.Duration {
+	if backoffSeconds <= 0 {
+		return 0
+	}
+
+	delay := time.Duration(backoffSeconds) * time.Second
+	for i := int32(0); i < retryCount && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+
+	return delay + rand.N(delay/10+1)
+}
+
+// errorDetails records the error chain in error_details for the jobs console.
+func errorDetails(err error) []byte {
+	details := map[string]interface{}{
+		"error":     err.Error(),
+		"timed_out": errors.Is(err, errJobTimeout),
+	}
+
+	var chain []string
+	for e := errors.Unwrap(err); e != nil; e = errors.Unwrap(e) {
+		chain = append(chain, e.Error())
+	}
+	if len(chain) > 0 {
+		details["causes"] = chain
+	}
+
+	out, err := json.Marshal(details)
+	if err != nil {
+		return []byte("{}")
+	}
+	return out
+}
+
+func (w *Worker) workerID() pgtype.Text {
+	return pgtype.Text{String: w.config.WorkerID, Valid: true}
 }
 
 // processJob processes a single job
 func (w *Worker) processJob(ctx context.Context, job *repository.Job) error {
-	jobCtx, cancel := context.WithTimeout(ctx, time.Duration(job.TimeoutSeconds)*time.Second)
-	defer cancel()
+	// System jobs work across all tenants and have no tenant_id
+	if isSystemJob(job.JobType) {
+		return w.processSystemJob(ctx, job)
+	}
 
 	// Inject tenant context before calling services
-	tenantCtx, err := withTenantContext(jobCtx, job)
+	tenantCtx, err := withTenantContext(ctx, job)
 	if err != nil {
 		return fmt.Errorf("failed to create tenant context: %w", err)
 	}
@@ -196,8 +653,34 @@ func (w *Worker) processJob(ctx context.Context, job *repository.Job) error {
 		return w.processInvoiceJob(tenantCtx, job)
 	}
 
-	if jobs.IsCleanupJob(job.JobType) {
-		result, err := jobs.ProcessCleanupJob(tenantCtx, job, w.queries)
+	if isFulfillmentJob(job.JobType) {
+		return w.processFulfillmentJob(tenantCtx, job)
+	}
+
+	if jobs.IsInventoryJob(job.JobType) {
+		return w.processInventoryJob(tenantCtx, job)
+	}
+
+	if jobs.IsReviewJob(job.JobType) {
+		return w.processReviewJob(tenantCtx, job)
+	}
+
+	if jobs.IsSubscriptionJob(job.JobType) {
+		return w.processSubscriptionJob(tenantCtx, job)
+	}
+
+	if jobs.IsWholesaleJob(job.JobType) {
+		return w.processWholesaleJob(tenantCtx, job)
+	}
+
+	return fmt.Errorf("unknown job type: %s", job.JobType)
+}
+
+// processSystemJob processes a job that is not scoped to a tenant
+func (w *Worker) processSystemJob(ctx context.Context, job *repository.Job) error {
+	switch job.JobType {
+	case jobs.JobTypeCleanupExpiredTokens:
+		result, err := jobs.ProcessCleanupJob(ctx, job, w.queries)
 		if err != nil {
 			return err
 		}
@@ -207,9 +690,18 @@ func (w *Worker) processJob(ctx context.Context, job *repository.Job) error {
 			"password_tokens_deleted", result.PasswordResetTokensDeleted,
 		)
 		return nil
-	}
 
-	return fmt.Errorf("unknown job type: %s", job.JobType)
+	case jobs.JobTypeExpireGracePeriods:
+		suspended, err := w.gracePeriodExpirer.ExpireGracePeriods(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to expire grace periods: %w", err)
+		}
+		w.logger.Info("grace periods expired", "job_id", job.ID, "suspended", suspended)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown system job type: %s", job.JobType)
+	}
 }
 
 // processInvoiceJob processes an invoice job based on its type
@@ -323,6 +815,114 @@ func (w *Worker) processInvoiceJob(ctx context.Context, job *repository.Job) err
 	}
 }
 
+// processFulfillmentJob processes a fulfillment job based on its type
+func (w *Worker) processFulfillmentJob(ctx context.Context, job *repository.Job) error {
+	switch job.JobType {
+	case jobs.JobTypeProcessFulfillmentBatch:
+		var payload jobs.ProcessFulfillmentBatchPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal fulfillment batch payload: %w", err)
+		}
+
+		if err := w.fulfillmentBatchService.ProcessBatch(ctx, payload.BatchID.String()); err != nil {
+			return fmt.Errorf("failed to process fulfillment batch: %w", err)
+		}
+		w.logger.Info("fulfillment batch processed", "batch_id", payload.BatchID)
+		return nil
+
+	case jobs.JobTypePollShipmentTracking:
+		result, err := w.trackingService.PollTracking(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to poll shipment tracking: %w", err)
+		}
+		w.logger.Info("shipment tracking polled",
+			"job_id", job.ID,
+			"checked", result.Checked,
+			"updated", result.Updated,
+			"failed", result.Failed,
+		)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown fulfillment job type: %s", job.JobType)
+	}
+}
+
+// processInventoryJob processes an inventory job based on its type
+func (w *Worker) processInventoryJob(ctx context.Context, job *repository.Job) error {
+	switch job.JobType {
+	case jobs.JobTypeCheckLowStock:
+		lowStock, err := w.inventoryService.SendLowStockDigest(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to send low stock digest: %w", err)
+		}
+		w.logger.Info("low stock checked", "job_id", job.ID, "low_stock_skus", lowStock)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown inventory job type: %s", job.JobType)
+	}
+}
+
+// processReviewJob processes a review job based on its type
+func (w *Worker) processReviewJob(ctx context.Context, job *repository.Job) error {
+	switch job.JobType {
+	case jobs.JobTypeSendReviewRequests:
+		sent, err := w.reviewService.SendReviewRequests(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to send review requests: %w", err)
+		}
+		w.logger.Info("review requests sent", "job_id", job.ID, "orders", sent)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown review job type: %s", job.JobType)
+	}
+}
+
+// processSubscriptionJob processes a subscription job based on its type
+func (w *Worker) processSubscriptionJob(ctx context.Context, job *repository.Job) error {
+	switch job.JobType {
+	case jobs.JobTypeProcessDunning:
+		result, err := w.dunningService.ProcessDue(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to process payment retries: %w", err)
+		}
+		w.logger.Info("payment retries processed",
+			"job_id", job.ID,
+			"retried", result.Retried,
+			"recovered", result.Recovered,
+			"failed", result.Failed,
+			"exhausted", result.Exhausted,
+		)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown subscription job type: %s", job.JobType)
+	}
+}
+
+// processWholesaleJob processes a wholesale job based on its type
+func (w *Worker) processWholesaleJob(ctx context.Context, job *repository.Job) error {
+	switch job.JobType {
+	case jobs.JobTypeProcessStandingOrders:
+		result, err := w.standingOrderService.ProcessDue(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to process standing orders: %w", err)
+		}
+		w.logger.Info("standing orders processed",
+			"job_id", job.ID,
+			"placed", result.Placed,
+			"failed", result.Failed,
+			"reminded", result.Reminded,
+		)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown wholesale job type: %s", job.JobType)
+	}
+}
+
 // isEmailJob checks if a job type is an email job
 func isEmailJob(jobType string) bool {
 	switch jobType {
@@ -330,17 +930,27 @@ func isEmailJob(jobType string) bool {
 		jobs.JobTypeEmailVerification,
 		jobs.JobTypeOrderConfirmation,
 		jobs.JobTypeShippingConfirmation,
+		jobs.JobTypeShipmentUpdate,
+		jobs.JobTypeRefundConfirmation,
 		jobs.JobTypeSubscriptionWelcome,
 		jobs.JobTypeSubscriptionPaymentFailed,
 		jobs.JobTypeSubscriptionCancelled,
 		jobs.JobTypeInvoiceSent,
 		jobs.JobTypeInvoiceReminder,
-		jobs.JobTypeInvoiceOverdue:
+		jobs.JobTypeInvoiceOverdue,
+		jobs.JobTypeReviewRequest,
+		jobs.JobTypeStandingOrderReminder,
+		jobs.JobTypeLowStockDigest:
 		return true
 	}
 	return false
 }
 
+// isSystemJob checks if a job type runs across all tenants
+func isSystemJob(jobType string) bool {
+	return jobs.IsCleanupJob(jobType) || jobs.IsOnboardingJob(jobType)
+}
+
 // isInvoiceJob checks if a job type is an invoice job
 func isInvoiceJob(jobType string) bool {
 	switch jobType {
@@ -352,3 +962,14 @@ func isInvoiceJob(jobType string) bool {
 	}
 	return false
 }
+
+// isFulfillmentJob checks if a job type is a fulfillment job
+func isFulfillmentJob(jobType string) bool {
+	switch jobType {
+	case jobs.JobTypeProcessFulfillmentBatch,
+		jobs.JobTypePollShipmentTracking:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,374 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dukerupert/hiri/internal/repository"
+)
+
+func newUUID() pgtype.UUID {
+	return pgtype.UUID{Bytes: uuid.New(), Valid: true}
+}
+
+func newTestWorker(t *testing.T, store jobStore, listener Listener, config Config) *Worker {
+	t.Helper()
+
+	config.WorkerID = "worker-test"
+	if config.PollInterval == 0 {
+		config.PollInterval = 5 * time.Millisecond
+	}
+	if config.MaxConcurrency == 0 {
+		config.MaxConcurrency = 1
+	}
+
+	w := NewWorker(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, listener, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	w.store = store
+	return w
+}
+
+// expectOneJob makes the first claim return job and later claims find nothing.
+func expectOneJob(mockRepo *repository.MockQuerier, job repository.Job) {
+	var once sync.Once
+	mockRepo.EXPECT().ClaimNextJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, repository.ClaimNextJobParams) (repository.Job, error) {
+			claimed := false
+			once.Do(func() { claimed = true })
+			if claimed {
+				return job, nil
+			}
+			return repository.Job{}, pgx.ErrNoRows
+		}).AnyTimes()
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		backoff    int32
+		retryCount int32
+		want       time.Duration
+	}{
+		{name: "first retry", backoff: 60, retryCount: 0, want: time.Minute},
+		{name: "doubles per retry", backoff: 60, retryCount: 3, want: 8 * time.Minute},
+		{name: "capped", backoff: 60, retryCount: 30, want: time.Hour},
+		{name: "no backoff", backoff: 0, retryCount: 2, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := retryDelay(tt.backoff, tt.retryCount, time.Hour)
+
+			// Up to 10% jitter is added
+			assert.GreaterOrEqual(t, got, tt.want)
+			assert.LessOrEqual(t, got, tt.want+tt.want/10)
+		})
+	}
+}
+
+func TestJobTimeout(t *testing.T) {
+	assert.Equal(t, 90*time.Second, jobTimeout(&repository.Job{TimeoutSeconds: 90}))
+	assert.Equal(t, defaultJobTimeout, jobTimeout(&repository.Job{}))
+}
+
+func TestWorker_RunJob(t *testing.T) {
+	w := newTestWorker(t, nil, nil, Config{})
+	job := &repository.Job{ID: newUUID(), JobType: "test:job"}
+
+	t.Run("honours the deadline", func(t *testing.T) {
+		w.process = func(ctx context.Context, _ *repository.Job) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+
+		err := w.runJob(context.Background(), job, 10*time.Millisecond)
+		assert.ErrorIs(t, err, errJobTimeout)
+	})
+
+	t.Run("abandons a job that ignores the deadline", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		w.process = func(context.Context, *repository.Job) error {
+			<-release
+			return nil
+		}
+
+		err := w.runJob(context.Background(), job, 10*time.Millisecond)
+		assert.ErrorIs(t, err, errJobTimeout)
+	})
+
+	t.Run("panics become errors", func(t *testing.T) {
+		w.process = func(context.Context, *repository.Job) error {
+			panic("boom")
+		}
+
+		err := w.runJob(context.Background(), job, time.Second)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "boom")
+	})
+}
+
+func TestWorker_FailedJobIsRetriedWithBackoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	now := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+	job := repository.Job{ID: newUUID(), JobType: "test:job", RetryCount: 2, RetryBackoffSeconds: 30, TimeoutSeconds: 1}
+	w := newTestWorker(t, mockRepo, nil, Config{})
+	w.now = func() time.Time { return now }
+	w.process = func(context.Context, *repository.Job) error { return assert.AnError }
+
+	mockRepo.EXPECT().FailJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg repository.FailJobParams) (repository.Job, error) {
+			assert.Equal(t, job.ID, arg.ID)
+			assert.Equal(t, "worker-test", arg.WorkerID.String)
+			assert.Equal(t, assert.AnError.Error(), arg.ErrorMessage.String)
+			// 30s doubled twice, plus jitter
+			assert.WithinDuration(t, now.Add(2*time.Minute), arg.RetryAt.Time, 12*time.Second)
+			assert.False(t, arg.RetryAt.Time.Before(now.Add(2*time.Minute)))
+			return repository.Job{}, nil
+		})
+
+	w.runClaimed(context.Background(), &job)
+}
+
+func TestWorker_DrainsInFlightJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	job := repository.Job{ID: newUUID(), JobType: "test:job", TimeoutSeconds: 5}
+	w := newTestWorker(t, mockRepo, nil, Config{ShutdownTimeout: time.Second})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	w.process = func(jobCtx context.Context, _ *repository.Job) error {
+		close(started)
+		// Shutdown begins while the job is running
+		cancel()
+		time.Sleep(20 * time.Millisecond)
+		return jobCtx.Err()
+	}
+
+	expectOneJob(mockRepo, job)
+	mockRepo.EXPECT().CompleteJob(gomock.Any(), repository.CompleteJobParams{
+		ID:       job.ID,
+		WorkerID: pgtype.Text{String: "worker-test", Valid: true},
+	}).Return(nil)
+
+	err := w.Start(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	<-started
+}
+
+func TestWorker_ReleasesJobsAfterShutdownTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	job := repository.Job{ID: newUUID(), JobType: "test:job", TimeoutSeconds: 60}
+	w := newTestWorker(t, mockRepo, nil, Config{ShutdownTimeout: 20 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.process = func(jobCtx context.Context, _ *repository.Job) error {
+		cancel()
+		<-jobCtx.Done()
+		return jobCtx.Err()
+	}
+
+	// The interrupted job is released, not failed: FailJob has no expectation
+	expectOneJob(mockRepo, job)
+	mockRepo.EXPECT().ReleaseWorkerJobs(gomock.Any(), pgtype.Text{String: "worker-test", Valid: true}).Return(int64(1), nil)
+
+	err := w.Start(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Give the cancelled job goroutine time to return
+	time.Sleep(20 * time.Millisecond)
+}
+
+func TestWorker_ReapStaleJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	now := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+	w := newTestWorker(t, mockRepo, nil, Config{StaleAfter: 5 * time.Minute})
+	w.now = func() time.Time { return now }
+
+	mockRepo.EXPECT().ReapStaleJobs(gomock.Any(), pgtype.Timestamptz{Time: now.Add(-5 * time.Minute), Valid: true}).
+		Return([]repository.ReapStaleJobsRow{{ID: newUUID(), JobType: "test:job", Status: "pending", RetryCount: 1}}, nil)
+
+	w.reapStaleJobs(context.Background())
+}
+
+func TestWorker_QueuePools(t *testing.T) {
+	t.Run("a pool per queue plus a shared pool", func(t *testing.T) {
+		w := newTestWorker(t, nil, nil, Config{
+			MaxConcurrency:   3,
+			QueueConcurrency: map[string]int{"invoicing": 2, "email": 5},
+		})
+
+		pools := w.queuePools()
+		require.Len(t, pools, 3)
+		assert.Equal(t, "email", pools[0].queue)
+		assert.Equal(t, 5, cap(pools[0].slots))
+		assert.Equal(t, "invoicing", pools[1].queue)
+		assert.Equal(t, 2, cap(pools[1].slots))
+		assert.Equal(t, "", pools[2].queue)
+		assert.Equal(t, []string{"email", "invoicing"}, pools[2].exclude)
+		assert.Equal(t, 3, cap(pools[2].slots))
+	})
+
+	t.Run("a single pool for a dedicated queue", func(t *testing.T) {
+		w := newTestWorker(t, nil, nil, Config{
+			Queue:            "email",
+			MaxConcurrency:   3,
+			QueueConcurrency: map[string]int{"email": 5},
+		})
+
+		pools := w.queuePools()
+		require.Len(t, pools, 1)
+		assert.Equal(t, "email", pools[0].queue)
+		assert.Empty(t, pools[0].exclude)
+		assert.Equal(t, 5, cap(pools[0].slots))
+	})
+}
+
+// fakeListener reports a notification for each queue sent on notify.
+type fakeListener struct {
+	notify chan string
+}
+
+func (l *fakeListener) Listen(ctx context.Context, _ []string, wake func(queue string)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case queue := <-l.notify:
+			wake(queue)
+		}
+	}
+}
+
+func TestWorker_ClaimsOnNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	listener := &fakeListener{notify: make(chan string)}
+	// Polling alone would not claim within the test
+	w := newTestWorker(t, mockRepo, listener, Config{
+		PollInterval:     time.Hour,
+		QueueConcurrency: map[string]int{"email": 1},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	job := repository.Job{ID: newUUID(), JobType: "test:job", Queue: "email", TimeoutSeconds: 5}
+	var queued sync.Mutex
+	available := false
+	mockRepo.EXPECT().ClaimNextJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg repository.ClaimNextJobParams) (repository.Job, error) {
+			queued.Lock()
+			defer queued.Unlock()
+			if arg.Queue == "email" && available {
+				available = false
+				return job, nil
+			}
+			return repository.Job{}, pgx.ErrNoRows
+		}).AnyTimes()
+
+	processed := make(chan struct{})
+	w.process = func(context.Context, *repository.Job) error {
+		close(processed)
+		return nil
+	}
+	mockRepo.EXPECT().CompleteJob(gomock.Any(), gomock.Any()).Return(nil)
+
+	errc := make(chan error, 1)
+	go func() { errc <- w.Start(ctx) }()
+
+	queued.Lock()
+	available = true
+	queued.Unlock()
+	listener.notify <- "email"
+
+	select {
+	case <-processed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job was not claimed after the notification")
+	}
+
+	cancel()
+	assert.ErrorIs(t, <-errc, context.Canceled)
+}
+
+func TestWorker_QueueLimitsDoNotStarveOtherQueues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockQuerier(ctrl)
+
+	w := newTestWorker(t, mockRepo, nil, Config{
+		MaxConcurrency:   1,
+		QueueConcurrency: map[string]int{"invoicing": 1, "email": 1},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// The invoicing queue is deep; email has one job
+	var mu sync.Mutex
+	emailQueued := true
+	mockRepo.EXPECT().ClaimNextJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg repository.ClaimNextJobParams) (repository.Job, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			switch arg.Queue {
+			case "invoicing":
+				return repository.Job{ID: newUUID(), JobType: "invoice:generate", Queue: "invoicing", TimeoutSeconds: 5}, nil
+			case "email":
+				if emailQueued {
+					emailQueued = false
+					return repository.Job{ID: newUUID(), JobType: "email:password_reset", Queue: "email", TimeoutSeconds: 5}, nil
+				}
+			case "":
+				assert.ElementsMatch(t, []string{"email", "invoicing"}, arg.ExcludeQueues)
+			}
+			return repository.Job{}, pgx.ErrNoRows
+		}).AnyTimes()
+
+	release := make(chan struct{})
+	emailed := make(chan struct{})
+	w.process = func(jobCtx context.Context, job *repository.Job) error {
+		if job.Queue == "email" {
+			close(emailed)
+			return nil
+		}
+		// Invoices hold their slot until the test ends
+		select {
+		case <-release:
+		case <-jobCtx.Done():
+		}
+		return nil
+	}
+	mockRepo.EXPECT().CompleteJob(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	errc := make(chan error, 1)
+	go func() { errc <- w.Start(ctx) }()
+
+	select {
+	case <-emailed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("email job was starved by the invoicing queue")
+	}
+
+	close(release)
+	cancel()
+	assert.ErrorIs(t, <-errc, context.Canceled)
+}
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Discount usage is now counted by the statement that records it
+-- (RecordDiscountCodeUsage), while the code row is locked for the order.
+-- The insert trigger would double-count those redemptions, so remove it.
+DROP TRIGGER IF EXISTS increment_usage_on_discount_application ON discount_code_usage;
+DROP FUNCTION IF EXISTS increment_discount_code_usage();
+
+-- Prevent recording the same discount twice for one order (webhook retries)
+ALTER TABLE discount_code_usage
+ADD CONSTRAINT discount_code_usage_code_order_unique
+UNIQUE (discount_code_id, order_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE discount_code_usage DROP CONSTRAINT IF EXISTS discount_code_usage_code_order_unique;
+
+CREATE OR REPLACE FUNCTION increment_discount_code_usage()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE discount_codes
+    SET usage_count = usage_count + 1
+    WHERE id = NEW.discount_code_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER increment_usage_on_discount_application
+    AFTER INSERT ON discount_code_usage
+    FOR EACH ROW
+    EXECUTE FUNCTION increment_discount_code_usage();
+
+-- +goose StatementEnd
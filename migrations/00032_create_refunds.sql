@@ -0,0 +1,87 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Allow orders to record partial refunds alongside full refunds
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (status IN (
+    'pending',
+    'payment_processing',
+    'paid',
+    'processing',
+    'shipped',
+    'delivered',
+    'cancelled',
+    'refunded',
+    'partially_refunded'
+));
+
+-- Refunds: one row per refund issued through the payment provider
+-- Covers refunds issued from the admin as well as those issued directly
+-- in the provider dashboard (recorded from the charge.refunded webhook)
+CREATE TABLE refunds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    payment_id UUID REFERENCES payments(id) ON DELETE SET NULL,
+
+    -- Payment provider
+    provider VARCHAR(50) NOT NULL DEFAULT 'stripe',
+    provider_refund_id VARCHAR(255) NOT NULL, -- e.g., Stripe Refund ID (re_...)
+
+    -- Amount
+    amount_cents INTEGER NOT NULL CHECK (amount_cents > 0),
+
+    -- Details
+    reason VARCHAR(50), -- duplicate, fraudulent, requested_by_customer
+    note TEXT,
+    status VARCHAR(50) NOT NULL DEFAULT 'succeeded',
+    source VARCHAR(20) NOT NULL DEFAULT 'admin' CHECK (source IN ('admin', 'provider')),
+    restocked BOOLEAN NOT NULL DEFAULT FALSE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT refunds_provider_unique UNIQUE (provider, provider_refund_id)
+);
+
+-- Refund items: line items (and quantities) covered by a refund
+CREATE TABLE refund_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    refund_id UUID NOT NULL REFERENCES refunds(id) ON DELETE CASCADE,
+    order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    amount_cents INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_refunds_tenant_id ON refunds(tenant_id);
+CREATE INDEX idx_refunds_order_id ON refunds(order_id);
+CREATE INDEX idx_refund_items_refund_id ON refund_items(refund_id);
+CREATE INDEX idx_refund_items_order_item_id ON refund_items(order_item_id);
+
+COMMENT ON TABLE refunds IS 'Refunds issued against order payments';
+COMMENT ON TABLE refund_items IS 'Order line items covered by a refund';
+COMMENT ON COLUMN refunds.source IS 'admin: issued from the admin dashboard, provider: issued outside the app and received via webhook';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS refund_items CASCADE;
+DROP TABLE IF EXISTS refunds CASCADE;
+
+UPDATE orders SET status = 'refunded' WHERE status = 'partially_refunded';
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (status IN (
+    'pending',
+    'payment_processing',
+    'paid',
+    'processing',
+    'shipped',
+    'delivered',
+    'cancelled',
+    'refunded'
+));
+
+-- +goose StatementEnd
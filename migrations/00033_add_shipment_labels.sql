@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Purchased shipping labels: the label file is copied to our storage so it
+-- stays printable after the provider's hosted URL expires
+ALTER TABLE shipments ADD COLUMN label_storage_key TEXT;
+ALTER TABLE shipments ADD COLUMN label_voided_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_shipments_provider_label_id ON shipments(tenant_id, provider_label_id)
+    WHERE provider_label_id IS NOT NULL;
+
+COMMENT ON COLUMN shipments.label_storage_key IS 'Storage key of the label file copied from the shipping provider';
+COMMENT ON COLUMN shipments.label_voided_at IS 'When the purchased label was voided with the shipping provider';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_shipments_provider_label_id;
+ALTER TABLE shipments DROP COLUMN IF EXISTS label_voided_at;
+ALTER TABLE shipments DROP COLUMN IF EXISTS label_storage_key;
+
+-- +goose StatementEnd
@@ -0,0 +1,86 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Fulfillment batches: labels bought for many orders in one background job,
+-- printed from a single merged document of labels and packing slips
+CREATE TABLE fulfillment_batches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN (
+        'pending',
+        'processing',
+        'completed',
+        'failed'
+    )),
+
+    -- Progress
+    order_count INTEGER NOT NULL DEFAULT 0,
+    labels_purchased INTEGER NOT NULL DEFAULT 0,
+    labels_failed INTEGER NOT NULL DEFAULT 0,
+
+    -- Merged labels + packing slips document
+    document_storage_key TEXT,
+    error_message TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Orders included in a fulfillment batch, in print order
+CREATE TABLE fulfillment_batch_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    batch_id UUID NOT NULL REFERENCES fulfillment_batches(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    shipment_id UUID REFERENCES shipments(id) ON DELETE SET NULL,
+    position INTEGER NOT NULL,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN (
+        'pending',
+        'purchased',
+        'failed'
+    )),
+    error_message TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fulfillment_batch_orders_unique UNIQUE (batch_id, order_id)
+);
+
+-- Image rendition of purchased labels, embedded in batch documents
+ALTER TABLE shipments ADD COLUMN label_image_url TEXT;
+
+CREATE INDEX idx_fulfillment_batches_tenant_created ON fulfillment_batches(tenant_id, created_at DESC);
+CREATE INDEX idx_fulfillment_batch_orders_batch_id ON fulfillment_batch_orders(batch_id, position);
+CREATE INDEX idx_fulfillment_batch_orders_order_id ON fulfillment_batch_orders(order_id);
+
+CREATE TRIGGER update_fulfillment_batches_updated_at
+    BEFORE UPDATE ON fulfillment_batches
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_fulfillment_batch_orders_updated_at
+    BEFORE UPDATE ON fulfillment_batch_orders
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE fulfillment_batches IS 'Batch label purchases for multiple orders';
+COMMENT ON TABLE fulfillment_batch_orders IS 'Orders included in a fulfillment batch';
+COMMENT ON COLUMN fulfillment_batches.document_storage_key IS 'Storage key of the merged labels and packing slips PDF';
+COMMENT ON COLUMN shipments.label_image_url IS 'Provider-hosted PNG/JPEG rendition of the label';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS update_fulfillment_batch_orders_updated_at ON fulfillment_batch_orders;
+DROP TRIGGER IF EXISTS update_fulfillment_batches_updated_at ON fulfillment_batches;
+ALTER TABLE shipments DROP COLUMN IF EXISTS label_image_url;
+DROP TABLE IF EXISTS fulfillment_batch_orders;
+DROP TABLE IF EXISTS fulfillment_batches;
+
+-- +goose StatementEnd
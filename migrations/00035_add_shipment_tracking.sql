@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- When tracking was last refreshed from the provider, by webhook or polling
+ALTER TABLE shipments ADD COLUMN tracking_checked_at TIMESTAMP WITH TIME ZONE;
+
+-- Providers resend the full event history on every update; store each event once
+CREATE UNIQUE INDEX idx_shipment_tracking_events_unique
+    ON shipment_tracking_events(shipment_id, event_at, status, md5(COALESCE(message, '')));
+
+-- Shipments still moving, oldest check first, for tracking polls
+CREATE INDEX idx_shipments_tracking_poll ON shipments(tenant_id, tracking_checked_at NULLS FIRST)
+    WHERE status IN ('label_created', 'in_transit', 'out_for_delivery')
+      AND tracking_number IS NOT NULL;
+
+COMMENT ON COLUMN shipments.tracking_checked_at IS 'When tracking was last refreshed from the provider';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_shipments_tracking_poll;
+DROP INDEX IF EXISTS idx_shipment_tracking_events_unique;
+ALTER TABLE shipments DROP COLUMN IF EXISTS tracking_checked_at;
+
+-- +goose StatementEnd
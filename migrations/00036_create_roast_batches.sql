@@ -0,0 +1,78 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Roast batches: a planned or completed roast of one product
+CREATE TABLE roast_batches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'planned' CHECK (status IN (
+        'planned',
+        'roasted',
+        'cancelled'
+    )),
+
+    -- Schedule
+    planned_for DATE NOT NULL,
+    roasted_on DATE,
+
+    -- Green coffee in, roasted coffee out
+    green_lot VARCHAR(100),
+    green_weight_grams INTEGER CHECK (green_weight_grams > 0),
+    roasted_weight_grams INTEGER CHECK (roasted_weight_grams > 0),
+
+    notes TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT roast_batches_roasted_on_required CHECK (status <> 'roasted' OR roasted_on IS NOT NULL)
+);
+
+-- Coffee set aside from a batch for a paid order item or an upcoming
+-- subscription renewal. Renewal allocations move to the order item when
+-- the renewal order is created.
+CREATE TABLE roast_batch_allocations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    roast_batch_id UUID NOT NULL REFERENCES roast_batches(id) ON DELETE CASCADE,
+    product_sku_id UUID NOT NULL REFERENCES product_skus(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+
+    order_item_id UUID REFERENCES order_items(id) ON DELETE CASCADE,
+    subscription_item_id UUID REFERENCES subscription_items(id) ON DELETE CASCADE,
+    renews_on DATE, -- Renewal the subscription allocation is for
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT roast_batch_allocations_target CHECK (
+        order_item_id IS NOT NULL OR (subscription_item_id IS NOT NULL AND renews_on IS NOT NULL)
+    ),
+    CONSTRAINT roast_batch_allocations_order_item_unique UNIQUE (order_item_id),
+    CONSTRAINT roast_batch_allocations_renewal_unique UNIQUE (subscription_item_id, renews_on)
+);
+
+CREATE INDEX idx_roast_batches_tenant_planned ON roast_batches(tenant_id, planned_for);
+CREATE INDEX idx_roast_batches_product_planned ON roast_batches(product_id, planned_for) WHERE status = 'planned';
+CREATE INDEX idx_roast_batch_allocations_batch_id ON roast_batch_allocations(roast_batch_id);
+
+CREATE TRIGGER update_roast_batches_updated_at
+    BEFORE UPDATE ON roast_batches
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE roast_batches IS 'Planned and completed roasts per product';
+COMMENT ON TABLE roast_batch_allocations IS 'Order items and subscription renewals allocated to a roast batch';
+COMMENT ON COLUMN roast_batches.green_lot IS 'Green coffee lot or purchase reference';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS update_roast_batches_updated_at ON roast_batches;
+DROP TABLE IF EXISTS roast_batch_allocations;
+DROP TABLE IF EXISTS roast_batches;
+
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Weight lost in the roaster, used to estimate the green coffee to pull
+ALTER TABLE products ADD COLUMN roast_loss_percent NUMERIC(4, 1) NOT NULL DEFAULT 15.0
+    CHECK (roast_loss_percent >= 0 AND roast_loss_percent < 100);
+
+-- Pending wholesale orders by requested delivery date, for production planning
+CREATE INDEX idx_orders_requested_delivery ON orders(tenant_id, requested_delivery_date)
+    WHERE requested_delivery_date IS NOT NULL;
+
+COMMENT ON COLUMN products.roast_loss_percent IS 'Percent of green weight lost when roasting';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_orders_requested_delivery;
+ALTER TABLE products DROP COLUMN IF EXISTS roast_loss_percent;
+
+-- +goose StatementEnd
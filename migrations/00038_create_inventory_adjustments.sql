@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Inventory ledger: one row per change to a SKU's stock
+CREATE TABLE inventory_adjustments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    product_sku_id UUID NOT NULL REFERENCES product_skus(id) ON DELETE CASCADE,
+
+    reason VARCHAR(30) NOT NULL CHECK (reason IN (
+        'order',
+        'order_cancelled',
+        'refund_restock',
+        'count',
+        'spoilage',
+        'roast_receipt'
+    )),
+
+    quantity_change INTEGER NOT NULL,
+    quantity_after INTEGER NOT NULL,
+
+    -- What caused the change, where known
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    refund_id UUID REFERENCES refunds(id) ON DELETE SET NULL,
+    roast_batch_id UUID REFERENCES roast_batches(id) ON DELETE SET NULL,
+    operator_id UUID REFERENCES tenant_operators(id) ON DELETE SET NULL,
+    note TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_inventory_adjustments_sku ON inventory_adjustments(product_sku_id, created_at DESC);
+CREATE INDEX idx_inventory_adjustments_tenant ON inventory_adjustments(tenant_id, created_at DESC);
+
+COMMENT ON TABLE inventory_adjustments IS 'Ledger of SKU stock changes with their reason';
+COMMENT ON COLUMN inventory_adjustments.reason IS 'order: sold, order_cancelled: returned by a cancelled order, refund_restock: returned by a refund, count: stock count, spoilage: written off, roast_receipt: bagged from a roast';
+COMMENT ON COLUMN inventory_adjustments.operator_id IS 'Operator who made a manual adjustment';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS inventory_adjustments;
+
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Customers can change their subscriptions from their account; record each
+-- change alongside skips, pauses and cancellations
+ALTER TABLE subscription_schedule DROP CONSTRAINT IF EXISTS subscription_schedule_event_type_check;
+ALTER TABLE subscription_schedule ADD CONSTRAINT subscription_schedule_event_type_check
+CHECK (event_type IN (
+    'billing',
+    'renewal',
+    'skip',
+    'pause',
+    'resume',
+    'cancel',
+    'payment_failed',
+    'frequency_change',
+    'item_change',
+    'address_change',
+    'payment_method_change'
+));
+
+CREATE INDEX idx_subscription_schedule_history
+    ON subscription_schedule(subscription_id, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_subscription_schedule_history;
+
+DELETE FROM subscription_schedule
+WHERE event_type IN ('frequency_change', 'item_change', 'address_change', 'payment_method_change');
+
+ALTER TABLE subscription_schedule DROP CONSTRAINT IF EXISTS subscription_schedule_event_type_check;
+ALTER TABLE subscription_schedule ADD CONSTRAINT subscription_schedule_event_type_check
+CHECK (event_type IN (
+    'billing',
+    'renewal',
+    'skip',
+    'pause',
+    'resume',
+    'cancel',
+    'payment_failed'
+));
+
+-- +goose StatementEnd
@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Dunning: retries and reminders after a subscription renewal payment fails.
+-- One row per failed invoice, open until the invoice is paid or the final
+-- retry fails and the subscription is paused or cancelled.
+CREATE TABLE subscription_dunning (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    provider_invoice_id VARCHAR(255) NOT NULL,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN (
+        'active',
+        'recovered',
+        'paused',
+        'cancelled'
+    )),
+
+    -- Payment attempts so far, including the renewal that failed
+    attempt_count INTEGER NOT NULL DEFAULT 1,
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    last_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_failure_reason TEXT,
+
+    -- SHA-256 of the token in the latest reminder's update payment link
+    update_token_hash VARCHAR(64),
+
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- A subscription is in at most one dunning sequence at a time
+CREATE UNIQUE INDEX idx_subscription_dunning_active ON subscription_dunning(subscription_id) WHERE status = 'active';
+CREATE INDEX idx_subscription_dunning_due ON subscription_dunning(tenant_id, next_attempt_at) WHERE status = 'active';
+CREATE UNIQUE INDEX idx_subscription_dunning_token ON subscription_dunning(update_token_hash) WHERE update_token_hash IS NOT NULL;
+CREATE INDEX idx_subscription_dunning_subscription ON subscription_dunning(subscription_id, started_at DESC);
+
+CREATE TRIGGER update_subscription_dunning_updated_at
+    BEFORE UPDATE ON subscription_dunning
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE subscription_dunning IS 'Payment retries and reminders for a failed subscription invoice';
+COMMENT ON COLUMN subscription_dunning.status IS 'active: retrying, recovered: paid, paused/cancelled: final retry failed and the subscription was paused or cancelled';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS update_subscription_dunning_updated_at ON subscription_dunning;
+DROP TABLE IF EXISTS subscription_dunning;
+
+-- +goose StatementEnd
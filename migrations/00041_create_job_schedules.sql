@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Recurring jobs: one row per tenant and job type, plus system-wide rows
+-- with no tenant. The scheduler enqueues a job when next_run_at passes and
+-- moves next_run_at to the following run of cron_spec.
+CREATE TABLE job_schedules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE, -- NULL for system schedules
+    job_type VARCHAR(100) NOT NULL,
+
+    -- Five-field cron expression, evaluated in UTC
+    cron_spec VARCHAR(100) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_error TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- One schedule per job type per tenant, and one per system job type
+CREATE UNIQUE INDEX idx_job_schedules_tenant_job ON job_schedules(tenant_id, job_type) WHERE tenant_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_job_schedules_system_job ON job_schedules(job_type) WHERE tenant_id IS NULL;
+CREATE INDEX idx_job_schedules_due ON job_schedules(next_run_at) WHERE enabled = TRUE;
+
+CREATE TRIGGER update_job_schedules_updated_at
+    BEFORE UPDATE ON job_schedules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE job_schedules IS 'Recurring background jobs per tenant, or system-wide when tenant_id is NULL';
+COMMENT ON COLUMN job_schedules.next_run_at IS 'When the job is next enqueued; a run missed while no scheduler was running is enqueued once on startup';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS update_job_schedules_updated_at ON job_schedules;
+DROP TABLE IF EXISTS job_schedules;
+
+-- +goose StatementEnd
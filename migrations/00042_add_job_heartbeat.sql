@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Workers refresh heartbeat_at on the jobs they are running. A processing
+-- job whose heartbeat goes stale belongs to a worker that has died, and is
+-- put back in the queue by the reaper.
+ALTER TABLE jobs ADD COLUMN heartbeat_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_jobs_heartbeat ON jobs(heartbeat_at)
+    WHERE status = 'processing';
+
+COMMENT ON COLUMN jobs.heartbeat_at IS 'Last time the worker running this job reported it was alive';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_jobs_heartbeat;
+ALTER TABLE jobs DROP COLUMN IF EXISTS heartbeat_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Wake workers as soon as a job can run. Each queue has its own channel,
+-- jobs_<queue>, so a worker only hears about queues it has capacity for.
+-- The notification is sent when the inserting or updating transaction
+-- commits, so workers never see a job before it is visible. The payload is
+-- empty so a bulk requeue collapses into one notification per queue.
+--
+-- Jobs scheduled in the future are picked up by the worker's fallback poll.
+CREATE OR REPLACE FUNCTION notify_job_queued()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.scheduled_at <= NOW() THEN
+        PERFORM pg_notify('jobs_' || NEW.queue, '');
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_job_queued
+    AFTER INSERT OR UPDATE OF status, scheduled_at ON jobs
+    FOR EACH ROW
+    WHEN (NEW.status = 'pending')
+    EXECUTE FUNCTION notify_job_queued();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS notify_job_queued ON jobs;
+DROP FUNCTION IF EXISTS notify_job_queued();
+
+-- +goose StatementEnd
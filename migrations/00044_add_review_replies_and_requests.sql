@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Public replies from the roaster, shown under the review on the product page
+ALTER TABLE product_reviews
+    ADD COLUMN reply_text TEXT,
+    ADD COLUMN replied_at TIMESTAMP WITH TIME ZONE;
+
+-- Post-delivery emails asking the customer to review what they ordered.
+-- One row per order so a customer is only asked once.
+CREATE TABLE review_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT review_requests_order_unique UNIQUE (order_id)
+);
+
+CREATE INDEX idx_review_requests_tenant_id ON review_requests(tenant_id);
+
+COMMENT ON TABLE review_requests IS 'Review request emails sent after delivery';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS review_requests;
+
+ALTER TABLE product_reviews
+    DROP COLUMN IF EXISTS replied_at,
+    DROP COLUMN IF EXISTS reply_text;
+
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- White-label SKUs are cloned from a SKU of the base product and sell from
+-- its stock, so the roaster keeps a single inventory count per coffee
+ALTER TABLE product_skus
+    ADD COLUMN base_sku_id UUID REFERENCES product_skus(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_product_skus_base_sku ON product_skus(base_sku_id)
+    WHERE base_sku_id IS NOT NULL;
+
+COMMENT ON COLUMN product_skus.base_sku_id IS 'Base product SKU whose inventory a white-label SKU draws from';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_product_skus_base_sku;
+
+ALTER TABLE product_skus
+    DROP COLUMN IF EXISTS base_sku_id;
+
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Wholesale customers with payment terms can order on account; the credit
+-- limit caps what they may owe across unpaid invoices and uninvoiced orders
+ALTER TABLE users ADD COLUMN credit_limit_cents INTEGER
+    CHECK (credit_limit_cents >= 0);
+
+COMMENT ON COLUMN users.credit_limit_cents IS 'Maximum outstanding balance in cents when ordering on account (NULL for no limit)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE users DROP COLUMN IF EXISTS credit_limit_cents;
+
+-- +goose StatementEnd
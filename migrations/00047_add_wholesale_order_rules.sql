@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Wholesale quantity rules per SKU: a minimum quantity per order line and a
+-- case pack the quantity must be a multiple of (e.g. 5lb bags in cases of 4)
+ALTER TABLE product_skus
+    ADD COLUMN wholesale_min_quantity INTEGER CHECK (wholesale_min_quantity >= 1),
+    ADD COLUMN wholesale_case_pack INTEGER CHECK (wholesale_case_pack >= 1);
+
+COMMENT ON COLUMN product_skus.wholesale_min_quantity IS 'Minimum quantity per wholesale order line (NULL for no minimum)';
+COMMENT ON COLUMN product_skus.wholesale_case_pack IS 'Wholesale quantities must be a multiple of this (NULL to allow any quantity)';
+
+-- Per-customer overrides of a SKU's wholesale quantity rules. A row replaces
+-- both SKU rules for that customer; NULL columns waive the rule.
+CREATE TABLE customer_sku_order_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    product_sku_id UUID NOT NULL REFERENCES product_skus(id) ON DELETE CASCADE,
+
+    min_quantity INTEGER CHECK (min_quantity >= 1),
+    case_pack INTEGER CHECK (case_pack >= 1),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT customer_sku_order_rules_unique UNIQUE (user_id, product_sku_id)
+);
+
+CREATE INDEX idx_customer_sku_order_rules_tenant ON customer_sku_order_rules(tenant_id);
+CREATE INDEX idx_customer_sku_order_rules_sku ON customer_sku_order_rules(product_sku_id);
+
+CREATE TRIGGER update_customer_sku_order_rules_updated_at
+    BEFORE UPDATE ON customer_sku_order_rules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE customer_sku_order_rules IS 'Per-customer overrides of SKU wholesale quantity rules';
+COMMENT ON COLUMN customer_sku_order_rules.min_quantity IS 'Minimum quantity per order line for this customer (NULL for no minimum)';
+COMMENT ON COLUMN customer_sku_order_rules.case_pack IS 'Case pack for this customer (NULL to allow any quantity)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS update_customer_sku_order_rules_updated_at ON customer_sku_order_rules;
+DROP TABLE IF EXISTS customer_sku_order_rules;
+
+ALTER TABLE product_skus
+    DROP COLUMN IF EXISTS wholesale_case_pack,
+    DROP COLUMN IF EXISTS wholesale_min_quantity;
+
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Quantity-break pricing on price list entries. The entry's price_cents is the
+-- unit price from a quantity of 1; each tier lowers the unit price once a cart
+-- line reaches its minimum quantity (e.g. $14.00 a bag, $12.50 at 20+)
+CREATE TABLE price_list_entry_tiers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    price_list_entry_id UUID NOT NULL REFERENCES price_list_entries(id) ON DELETE CASCADE,
+
+    min_quantity INTEGER NOT NULL CHECK (min_quantity >= 2),
+    price_cents INTEGER NOT NULL CHECK (price_cents >= 0),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT price_list_entry_tiers_unique UNIQUE (price_list_entry_id, min_quantity)
+);
+
+CREATE INDEX idx_price_list_entry_tiers_tenant_id ON price_list_entry_tiers(tenant_id);
+
+CREATE TRIGGER update_price_list_entry_tiers_updated_at
+    BEFORE UPDATE ON price_list_entry_tiers
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE price_list_entry_tiers IS 'Quantity-break prices for a price list entry';
+COMMENT ON COLUMN price_list_entry_tiers.min_quantity IS 'Cart line quantity at which this tier applies';
+COMMENT ON COLUMN price_list_entry_tiers.price_cents IS 'Unit price at this quantity and above';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS update_price_list_entry_tiers_updated_at ON price_list_entry_tiers;
+DROP TABLE IF EXISTS price_list_entry_tiers;
+
+-- +goose StatementEnd
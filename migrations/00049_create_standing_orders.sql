@@ -0,0 +1,81 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Standing orders: a wholesale customer's saved basket, placed on account
+-- every interval_weeks for delivery on the same weekday. The job worker
+-- generates the order at the tenant's cutoff (settings->'standing_orders')
+-- and bills it through the customer's payment terms, not a card
+CREATE TABLE standing_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    name VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'paused', 'cancelled')),
+
+    -- Schedule
+    interval_weeks INTEGER NOT NULL DEFAULT 1 CHECK (interval_weeks BETWEEN 1 AND 4),
+    delivery_weekday INTEGER NOT NULL CHECK (delivery_weekday BETWEEN 1 AND 7),
+    next_delivery_date DATE NOT NULL,
+    shipping_address_id UUID NOT NULL REFERENCES addresses(id) ON DELETE RESTRICT,
+
+    -- Generation tracking
+    reminded_for_date DATE,
+    last_order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    last_generated_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_standing_orders_tenant_id ON standing_orders(tenant_id);
+CREATE INDEX idx_standing_orders_user_id ON standing_orders(user_id);
+CREATE INDEX idx_standing_orders_due ON standing_orders(tenant_id, next_delivery_date)
+    WHERE status = 'active';
+
+CREATE TRIGGER update_standing_orders_updated_at
+    BEFORE UPDATE ON standing_orders
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE standing_orders IS 'Recurring wholesale orders placed on account';
+COMMENT ON COLUMN standing_orders.delivery_weekday IS 'ISO weekday of delivery: 1 = Monday, 7 = Sunday';
+COMMENT ON COLUMN standing_orders.next_delivery_date IS 'Delivery the next generated order is for';
+COMMENT ON COLUMN standing_orders.reminded_for_date IS 'Delivery date the last reminder email was sent for';
+COMMENT ON COLUMN standing_orders.last_error IS 'Why the last generation failed, cleared on success';
+
+CREATE TABLE standing_order_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    standing_order_id UUID NOT NULL REFERENCES standing_orders(id) ON DELETE CASCADE,
+    product_sku_id UUID NOT NULL REFERENCES product_skus(id) ON DELETE CASCADE,
+
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT standing_order_items_unique UNIQUE (standing_order_id, product_sku_id)
+);
+
+CREATE INDEX idx_standing_order_items_tenant_id ON standing_order_items(tenant_id);
+
+CREATE TRIGGER update_standing_order_items_updated_at
+    BEFORE UPDATE ON standing_order_items
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE standing_order_items IS 'SKUs and quantities in a standing order; priced when each order is generated';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS update_standing_order_items_updated_at ON standing_order_items;
+DROP TABLE IF EXISTS standing_order_items;
+DROP TRIGGER IF EXISTS update_standing_orders_updated_at ON standing_orders;
+DROP TABLE IF EXISTS standing_orders;
+
+-- +goose StatementEnd
@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Order templates: named quantities a wholesale customer saves from the
+-- order form and loads to prefill it. Templates aren't orders; prices and
+-- availability are checked when the items are added to the cart
+CREATE TABLE order_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    name VARCHAR(100) NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT order_templates_name_unique UNIQUE (tenant_id, user_id, name)
+);
+
+CREATE INDEX idx_order_templates_tenant_id ON order_templates(tenant_id);
+CREATE INDEX idx_order_templates_user_id ON order_templates(user_id);
+
+CREATE TRIGGER update_order_templates_updated_at
+    BEFORE UPDATE ON order_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE order_templates IS 'Named wholesale order form quantities saved by a customer';
+
+CREATE TABLE order_template_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    order_template_id UUID NOT NULL REFERENCES order_templates(id) ON DELETE CASCADE,
+    product_sku_id UUID NOT NULL REFERENCES product_skus(id) ON DELETE CASCADE,
+
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT order_template_items_unique UNIQUE (order_template_id, product_sku_id)
+);
+
+CREATE INDEX idx_order_template_items_tenant_id ON order_template_items(tenant_id);
+
+COMMENT ON TABLE order_template_items IS 'SKUs and quantities in an order template; replaced whenever the template is saved';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS order_template_items;
+DROP TRIGGER IF EXISTS update_order_templates_updated_at ON order_templates;
+DROP TABLE IF EXISTS order_templates;
+
+-- +goose StatementEnd
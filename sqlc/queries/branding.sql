@@ -0,0 +1,15 @@
+-- Branding Queries
+-- Logo, favicon, colors and fonts applied to the storefront and emails
+
+-- name: GetTenantBranding :one
+-- Branding from the tenant's settings, '{}' if unset
+SELECT COALESCE(settings->'branding', '{}'::jsonb)::jsonb
+FROM tenants
+WHERE id = $1;
+
+-- name: UpdateTenantBranding :exec
+-- Sets the branding in the tenant's settings
+UPDATE tenants
+SET settings = jsonb_set(settings, '{branding}', sqlc.arg('branding')::jsonb),
+    updated_at = NOW()
+WHERE id = $1;
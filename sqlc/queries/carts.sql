@@ -10,7 +10,9 @@ INSERT INTO carts (
 ) RETURNING *;
 
 -- name: GetCartBySessionID :one
--- Get active cart for a session
+-- Get active cart for a session within a tenant. A session cookie can be
+-- shared across storefronts on the same base domain, so each tenant has its
+-- own cart for the session.
 SELECT
     id,
     tenant_id,
@@ -25,12 +27,13 @@ SELECT
     created_at,
     updated_at
 FROM carts
-WHERE session_id = $1
+WHERE tenant_id = $1
+  AND session_id = $2
   AND status = 'active'
 LIMIT 1;
 
 -- name: GetCartByID :one
--- Get cart by ID
+-- Get cart by ID within a tenant
 SELECT
     id,
     tenant_id,
@@ -45,9 +48,19 @@ SELECT
     created_at,
     updated_at
 FROM carts
-WHERE id = $1
+WHERE tenant_id = $1
+  AND id = $2
 LIMIT 1;
 
+-- name: SetCartUser :exec
+-- Link a cart to the signed-in customer it's priced for
+UPDATE carts
+SET
+    user_id = $3,
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2;
+
 -- name: AddCartItem :one
 -- Add an item to cart (or update quantity if exists)
 INSERT INTO cart_items (
@@ -74,6 +87,15 @@ SET
 WHERE cart_id = $1
   AND product_sku_id = $2;
 
+-- name: UpdateCartItemPrice :exec
+-- Update the unit price of a cart item after its quantity or price list changes
+UPDATE cart_items
+SET
+    unit_price_cents = $3,
+    updated_at = NOW()
+WHERE cart_id = $1
+  AND product_sku_id = $2;
+
 -- name: RemoveCartItem :exec
 -- Remove an item from cart
 DELETE FROM cart_items
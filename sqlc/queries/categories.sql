@@ -0,0 +1,136 @@
+-- name: ListProductCategories :many
+-- List all categories for a tenant with the number of products assigned directly
+SELECT
+    pc.id,
+    pc.tenant_id,
+    pc.name,
+    pc.slug,
+    pc.description,
+    pc.parent_id,
+    pc.sort_order,
+    pc.is_active,
+    pc.meta_title,
+    pc.meta_description,
+    pc.created_at,
+    pc.updated_at,
+    (SELECT COUNT(*)
+     FROM product_category_assignments pca
+     WHERE pca.category_id = pc.id
+    )::int AS product_count
+FROM product_categories pc
+WHERE pc.tenant_id = $1
+ORDER BY pc.sort_order ASC, pc.name ASC;
+
+-- name: GetProductCategory :one
+-- Get a single category by ID
+SELECT *
+FROM product_categories
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: GetProductCategoryBySlug :one
+-- Get a single category by slug (includes inactive)
+SELECT *
+FROM product_categories
+WHERE tenant_id = $1
+  AND slug = $2;
+
+-- name: CreateProductCategory :one
+-- Create a new category
+INSERT INTO product_categories (
+    tenant_id,
+    name,
+    slug,
+    description,
+    parent_id,
+    sort_order,
+    is_active,
+    meta_title,
+    meta_description
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: UpdateProductCategory :one
+-- Update an existing category
+UPDATE product_categories
+SET
+    name = $3,
+    slug = $4,
+    description = $5,
+    parent_id = $6,
+    sort_order = $7,
+    is_active = $8,
+    meta_title = $9,
+    meta_description = $10,
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2
+RETURNING *;
+
+-- name: ReparentProductCategories :exec
+-- Move the subcategories of a category to a new parent (NULL for top level)
+UPDATE product_categories
+SET
+    parent_id = sqlc.narg('new_parent_id'),
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND parent_id = $2;
+
+-- name: DeleteProductCategory :exec
+-- Delete a category and its product assignments
+DELETE FROM product_categories
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: ListCategoryIDsForProduct :many
+-- List the categories a product is assigned to
+SELECT category_id
+FROM product_category_assignments
+WHERE tenant_id = $1
+  AND product_id = $2;
+
+-- name: AssignProductCategories :exec
+-- Assign a product to categories, ignoring IDs that don't belong to the tenant
+INSERT INTO product_category_assignments (tenant_id, product_id, category_id)
+SELECT $1, $2, pc.id
+FROM product_categories pc
+WHERE pc.tenant_id = $1
+  AND pc.id = ANY(sqlc.arg('category_ids')::uuid[])
+ON CONFLICT (product_id, category_id) DO NOTHING;
+
+-- name: UnassignProductCategoriesExcept :exec
+-- Remove a product from every category not in the given list
+DELETE FROM product_category_assignments
+WHERE tenant_id = $1
+  AND product_id = $2
+  AND NOT (category_id = ANY(sqlc.arg('category_ids')::uuid[]));
+
+-- name: ListCategoryFacets :many
+-- List active categories with the number of storefront products in each,
+-- counting products in their active subcategories
+WITH RECURSIVE category_tree AS (
+    SELECT pc.id AS root_id, pc.id
+    FROM product_categories pc
+    WHERE pc.tenant_id = $1
+      AND pc.is_active = TRUE
+    UNION ALL
+    SELECT ct.root_id, child.id
+    FROM product_categories child
+    JOIN category_tree ct ON child.parent_id = ct.id
+    WHERE child.is_active = TRUE
+)
+SELECT
+    pc.slug,
+    pc.name,
+    COUNT(DISTINCT p.id)::int AS product_count
+FROM product_categories pc
+JOIN category_tree ct ON ct.root_id = pc.id
+JOIN product_category_assignments pca ON pca.category_id = ct.id
+JOIN products p ON p.id = pca.product_id
+WHERE pc.tenant_id = $1
+  AND p.status = 'active'
+  AND p.visibility = 'public'
+GROUP BY pc.id, pc.slug, pc.name, pc.sort_order
+ORDER BY pc.sort_order ASC, pc.name ASC;
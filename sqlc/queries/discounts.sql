@@ -0,0 +1,96 @@
+-- name: GetDiscountCodeByCode :one
+-- Get a discount code by its (upper-cased) code within a tenant
+SELECT * FROM discount_codes
+WHERE tenant_id = $1
+  AND code = $2
+LIMIT 1;
+
+-- name: GetDiscountCodeByID :one
+-- Get a discount code by ID within a tenant
+SELECT * FROM discount_codes
+WHERE tenant_id = $1
+  AND id = $2
+LIMIT 1;
+
+-- name: ListDiscountCodes :many
+-- List all discount codes for a tenant (admin view)
+SELECT * FROM discount_codes
+WHERE tenant_id = $1
+ORDER BY created_at DESC;
+
+-- name: CreateDiscountCode :one
+-- Create a new discount code
+INSERT INTO discount_codes (
+    tenant_id,
+    code,
+    description,
+    discount_type,
+    discount_value,
+    minimum_order_cents,
+    usage_limit,
+    usage_limit_per_customer,
+    starts_at,
+    expires_at,
+    is_active
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: UpdateDiscountCode :one
+-- Update an existing discount code
+UPDATE discount_codes
+SET
+    code = $3,
+    description = $4,
+    discount_type = $5,
+    discount_value = $6,
+    minimum_order_cents = $7,
+    usage_limit = $8,
+    usage_limit_per_customer = $9,
+    starts_at = $10,
+    expires_at = $11,
+    is_active = $12,
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2
+RETURNING *;
+
+-- name: DeleteDiscountCode :exec
+-- Delete a discount code (usage history is removed by cascade)
+DELETE FROM discount_codes
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: CountDiscountCodeUsageForUser :one
+-- Count how many times a customer has redeemed a discount code
+SELECT COUNT(*) FROM discount_code_usage
+WHERE tenant_id = $1
+  AND discount_code_id = $2
+  AND user_id = $3;
+
+-- name: LockDiscountCode :one
+-- Lock a discount code row for the rest of the transaction so usage limit
+-- checks and the usage insert are serialized across concurrent orders.
+SELECT * FROM discount_codes
+WHERE tenant_id = $1
+  AND id = $2
+FOR UPDATE;
+
+-- name: RecordDiscountCodeUsage :exec
+-- Count one use of a discount code and record the redemption. Callers must
+-- hold the row lock from LockDiscountCode and have checked the limits.
+WITH counted AS (
+    UPDATE discount_codes
+    SET usage_count = usage_count + 1
+    WHERE tenant_id = $1
+      AND id = $2
+    RETURNING id
+)
+INSERT INTO discount_code_usage (
+    tenant_id,
+    discount_code_id,
+    user_id,
+    order_id,
+    discount_amount_cents
+)
+SELECT $1, counted.id, $3, $4, $5
+FROM counted;
@@ -0,0 +1,41 @@
+-- name: GetEmailTemplate :one
+-- Get a tenant's customized email by type (for admin)
+SELECT * FROM email_templates
+WHERE tenant_id = $1 AND template_type = $2;
+
+-- name: GetActiveEmailTemplate :one
+-- Get a tenant's customized email by type, if it is switched on (for sending)
+SELECT * FROM email_templates
+WHERE tenant_id = $1 AND template_type = $2 AND is_active = TRUE;
+
+-- name: ListEmailTemplates :many
+-- List a tenant's customized emails (for admin)
+SELECT * FROM email_templates
+WHERE tenant_id = $1
+ORDER BY template_type;
+
+-- name: UpsertEmailTemplate :one
+-- Create or update a tenant's customized email
+INSERT INTO email_templates (
+    tenant_id,
+    template_type,
+    name,
+    subject,
+    body_html,
+    body_text,
+    is_active
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (tenant_id, template_type) DO UPDATE SET
+    name = EXCLUDED.name,
+    subject = EXCLUDED.subject,
+    body_html = EXCLUDED.body_html,
+    body_text = EXCLUDED.body_text,
+    is_active = EXCLUDED.is_active
+RETURNING *;
+
+-- name: DeleteEmailTemplate :execrows
+-- Remove a tenant's customized email, so the default is sent again
+DELETE FROM email_templates
+WHERE tenant_id = $1 AND template_type = $2;
@@ -0,0 +1,208 @@
+-- name: ListFulfillmentQueue :many
+-- Paid orders with unshipped items that are not already queued in a batch
+-- and are not waiting on a roast
+SELECT
+    o.id,
+    o.order_number,
+    o.status,
+    o.created_at,
+    u.email as customer_email,
+    sa.full_name as shipping_name,
+    sa.city as shipping_city,
+    sa.state as shipping_state,
+    SUM(oi.quantity - oi.quantity_dispatched)::INTEGER as units_remaining
+FROM orders o
+JOIN tenants t ON t.id = o.tenant_id
+JOIN addresses sa ON sa.id = o.shipping_address_id
+JOIN order_items oi ON oi.order_id = o.id AND oi.quantity_dispatched < oi.quantity
+LEFT JOIN users u ON u.id = o.user_id
+WHERE o.tenant_id = $1
+  AND o.status IN ('paid', 'processing')
+  AND NOT EXISTS (
+      SELECT 1
+      FROM fulfillment_batch_orders bo
+      JOIN fulfillment_batches b ON b.id = bo.batch_id
+      WHERE bo.order_id = o.id
+        AND bo.status = 'pending'
+        AND b.status IN ('pending', 'processing')
+  )
+  -- Held until allocated coffee is roasted and rested
+  AND NOT EXISTS (
+      SELECT 1
+      FROM order_items ho
+      JOIN roast_batch_allocations ra ON ra.order_item_id = ho.id
+      JOIN roast_batches rb ON rb.id = ra.roast_batch_id
+      WHERE ho.order_id = o.id
+        AND ho.quantity_dispatched < ho.quantity
+        AND (
+            rb.status = 'planned'
+            OR (rb.status = 'roasted'
+                AND rb.roasted_on + COALESCE((t.settings->>'roast_rest_days')::INTEGER, 0) > CURRENT_DATE)
+        )
+  )
+GROUP BY o.id, u.email, sa.full_name, sa.city, sa.state
+ORDER BY o.created_at ASC
+LIMIT 200;
+
+-- name: ListFulfillmentQueueByIDs :many
+-- Selected orders that are still in the fulfillment queue
+SELECT
+    o.id,
+    o.order_number,
+    o.status,
+    o.created_at,
+    u.email as customer_email,
+    sa.full_name as shipping_name,
+    sa.city as shipping_city,
+    sa.state as shipping_state,
+    SUM(oi.quantity - oi.quantity_dispatched)::INTEGER as units_remaining
+FROM orders o
+JOIN tenants t ON t.id = o.tenant_id
+JOIN addresses sa ON sa.id = o.shipping_address_id
+JOIN order_items oi ON oi.order_id = o.id AND oi.quantity_dispatched < oi.quantity
+LEFT JOIN users u ON u.id = o.user_id
+WHERE o.tenant_id = $1
+  AND o.id = ANY(sqlc.arg('order_ids')::uuid[])
+  AND o.status IN ('paid', 'processing')
+  AND NOT EXISTS (
+      SELECT 1
+      FROM fulfillment_batch_orders bo
+      JOIN fulfillment_batches b ON b.id = bo.batch_id
+      WHERE bo.order_id = o.id
+        AND bo.status = 'pending'
+        AND b.status IN ('pending', 'processing')
+  )
+  -- Held until allocated coffee is roasted and rested
+  AND NOT EXISTS (
+      SELECT 1
+      FROM order_items ho
+      JOIN roast_batch_allocations ra ON ra.order_item_id = ho.id
+      JOIN roast_batches rb ON rb.id = ra.roast_batch_id
+      WHERE ho.order_id = o.id
+        AND ho.quantity_dispatched < ho.quantity
+        AND (
+            rb.status = 'planned'
+            OR (rb.status = 'roasted'
+                AND rb.roasted_on + COALESCE((t.settings->>'roast_rest_days')::INTEGER, 0) > CURRENT_DATE)
+        )
+  )
+GROUP BY o.id, u.email, sa.full_name, sa.city, sa.state
+ORDER BY o.created_at ASC;
+
+-- name: CreateFulfillmentBatch :one
+-- Creates a fulfillment batch awaiting label purchase
+INSERT INTO fulfillment_batches (
+    tenant_id,
+    order_count
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: CreateFulfillmentBatchOrder :one
+-- Adds an order to a fulfillment batch
+INSERT INTO fulfillment_batch_orders (
+    tenant_id,
+    batch_id,
+    order_id,
+    position
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetFulfillmentBatch :one
+-- Retrieves a fulfillment batch with tenant scoping
+SELECT * FROM fulfillment_batches
+WHERE tenant_id = $1
+  AND id = $2
+LIMIT 1;
+
+-- name: ListFulfillmentBatches :many
+-- Lists recent fulfillment batches, newest first
+SELECT * FROM fulfillment_batches
+WHERE tenant_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: ListFulfillmentBatchOrders :many
+-- Lists the orders in a batch in print order with their shipment details
+SELECT
+    bo.id,
+    bo.order_id,
+    bo.shipment_id,
+    bo.position,
+    bo.status,
+    bo.error_message,
+    o.order_number,
+    s.carrier,
+    s.service_name,
+    s.tracking_number,
+    s.label_image_url
+FROM fulfillment_batch_orders bo
+JOIN orders o ON o.id = bo.order_id
+LEFT JOIN shipments s ON s.id = bo.shipment_id
+WHERE bo.tenant_id = $1
+  AND bo.batch_id = $2
+ORDER BY bo.position ASC;
+
+-- name: StartFulfillmentBatch :exec
+-- Marks a batch as being processed by the background worker
+UPDATE fulfillment_batches
+SET
+    status = 'processing',
+    error_message = NULL,
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: MarkFulfillmentBatchOrderPurchased :exec
+-- Records the shipment created for a batch order
+UPDATE fulfillment_batch_orders
+SET
+    status = 'purchased',
+    shipment_id = $3,
+    error_message = NULL,
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: MarkFulfillmentBatchOrderFailed :exec
+-- Records why a label could not be bought for a batch order
+UPDATE fulfillment_batch_orders
+SET
+    status = 'failed',
+    error_message = $3,
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: CompleteFulfillmentBatch :one
+-- Marks a batch as completed with its merged document and label counts
+UPDATE fulfillment_batches b
+SET
+    status = 'completed',
+    document_storage_key = $3,
+    labels_purchased = (
+        SELECT COUNT(*) FROM fulfillment_batch_orders bo
+        WHERE bo.batch_id = b.id AND bo.status = 'purchased'
+    ),
+    labels_failed = (
+        SELECT COUNT(*) FROM fulfillment_batch_orders bo
+        WHERE bo.batch_id = b.id AND bo.status = 'failed'
+    ),
+    completed_at = NOW(),
+    updated_at = NOW()
+WHERE b.tenant_id = $1
+  AND b.id = $2
+RETURNING *;
+
+-- name: FailFulfillmentBatch :exec
+-- Marks a batch as failed
+UPDATE fulfillment_batches
+SET
+    status = 'failed',
+    error_message = $3,
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2;
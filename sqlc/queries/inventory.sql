@@ -0,0 +1,140 @@
+-- name: AdjustSKUInventory :one
+-- Changes a SKU's stock by quantity_change, or sets it to counted when given,
+-- and records the change in the inventory ledger. Returns no rows if the SKU
+-- does not exist or its stock would go below zero.
+WITH current_sku AS (
+    SELECT id, inventory_quantity
+    FROM product_skus
+    WHERE tenant_id = $1
+      AND id = $2
+    FOR UPDATE
+),
+updated AS (
+    UPDATE product_skus ps
+    SET inventory_quantity = COALESCE(sqlc.narg('counted')::integer, c.inventory_quantity + sqlc.arg('quantity_change')::integer),
+        updated_at = NOW()
+    FROM current_sku c
+    WHERE ps.id = c.id
+      AND COALESCE(sqlc.narg('counted')::integer, c.inventory_quantity + sqlc.arg('quantity_change')::integer) >= 0
+    RETURNING ps.tenant_id, ps.id, ps.inventory_quantity, c.inventory_quantity as quantity_before
+)
+INSERT INTO inventory_adjustments (
+    tenant_id,
+    product_sku_id,
+    reason,
+    quantity_change,
+    quantity_after,
+    roast_batch_id,
+    operator_id,
+    note
+)
+SELECT
+    tenant_id,
+    id,
+    sqlc.arg('reason'),
+    inventory_quantity - quantity_before,
+    inventory_quantity,
+    sqlc.narg('roast_batch_id'),
+    sqlc.narg('operator_id'),
+    sqlc.narg('note')
+FROM updated
+RETURNING *;
+
+-- name: ListSKUInventoryAdjustments :many
+-- A SKU's stock history, newest first, with what caused each change
+SELECT
+    ia.id,
+    ia.reason,
+    ia.quantity_change,
+    ia.quantity_after,
+    ia.note,
+    ia.created_at,
+    ia.order_id,
+    o.order_number,
+    ia.refund_id,
+    ia.roast_batch_id,
+    rb.roasted_on,
+    op.email as operator_email,
+    op.name as operator_name
+FROM inventory_adjustments ia
+LEFT JOIN orders o ON o.id = ia.order_id
+LEFT JOIN roast_batches rb ON rb.id = ia.roast_batch_id
+LEFT JOIN tenant_operators op ON op.id = ia.operator_id
+WHERE ia.tenant_id = $1
+  AND ia.product_sku_id = $2
+ORDER BY ia.created_at DESC
+LIMIT $3;
+
+-- name: GetInventorySKU :one
+-- Retrieves a SKU's stock and low stock threshold with its product name
+SELECT
+    ps.id,
+    ps.product_id,
+    ps.sku,
+    ps.weight_value,
+    ps.weight_unit,
+    ps.grind,
+    ps.inventory_quantity,
+    ps.low_stock_threshold,
+    ps.is_active,
+    p.name as product_name
+FROM product_skus ps
+JOIN products p ON p.id = ps.product_id
+WHERE ps.tenant_id = $1
+  AND ps.id = $2
+LIMIT 1;
+
+-- name: ListInventorySKUs :many
+-- Lists the stock of active SKUs on products that are not archived. A SKU is
+-- low when its stock is at or below its threshold, or out of stock when it
+-- has no threshold. White-label SKUs are left out as they sell from the stock
+-- of their base SKU.
+SELECT
+    ps.id,
+    ps.product_id,
+    ps.sku,
+    ps.weight_value,
+    ps.weight_unit,
+    ps.grind,
+    ps.inventory_quantity,
+    ps.low_stock_threshold,
+    p.name as product_name,
+    (ps.inventory_quantity <= COALESCE(ps.low_stock_threshold, 0))::boolean as is_low
+FROM product_skus ps
+JOIN products p ON p.id = ps.product_id
+WHERE ps.tenant_id = $1
+  AND ps.is_active = TRUE
+  AND ps.base_sku_id IS NULL
+  AND p.status <> 'archived'
+ORDER BY p.name ASC, ps.sku ASC;
+
+-- name: ListLowStockSKUs :many
+-- Active SKUs at or below their low stock threshold, or out of stock when
+-- they have no threshold, emptiest first. White-label SKUs are left out as
+-- they sell from the stock of their base SKU.
+SELECT
+    ps.id,
+    ps.sku,
+    ps.weight_value,
+    ps.weight_unit,
+    ps.grind,
+    ps.inventory_quantity,
+    ps.low_stock_threshold,
+    p.name as product_name
+FROM product_skus ps
+JOIN products p ON p.id = ps.product_id
+WHERE ps.tenant_id = $1
+  AND ps.is_active = TRUE
+  AND ps.base_sku_id IS NULL
+  AND p.status <> 'archived'
+  AND ps.inventory_quantity <= COALESCE(ps.low_stock_threshold, 0)
+ORDER BY ps.inventory_quantity ASC, p.name ASC, ps.sku ASC;
+
+-- name: UpdateSKULowStockThreshold :execrows
+-- Sets the stock level at which a SKU is reported as low. NULL reports it
+-- only when out of stock.
+UPDATE product_skus
+SET low_stock_threshold = $3,
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2;
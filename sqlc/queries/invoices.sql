@@ -297,6 +297,29 @@ WHERE o.tenant_id = $1
   AND io.id IS NULL
 ORDER BY o.created_at ASC;
 
+-- name: GetCustomerOutstandingBalance :one
+-- Get what a wholesale customer owes on account: unpaid invoice balances
+-- plus wholesale orders not yet invoiced. Used to enforce credit limits.
+SELECT (
+    COALESCE((
+        SELECT SUM(i.balance_cents)
+        FROM invoices i
+        WHERE i.tenant_id = $1
+          AND i.user_id = $2
+          AND i.status NOT IN ('paid', 'cancelled', 'void')
+    ), 0)
+    + COALESCE((
+        SELECT SUM(o.total_cents)
+        FROM orders o
+        LEFT JOIN invoice_orders io ON io.order_id = o.id
+        WHERE o.tenant_id = $1
+          AND o.user_id = $2
+          AND o.order_type = 'wholesale'
+          AND o.status IN ('paid', 'processing', 'shipped', 'delivered')
+          AND io.id IS NULL
+    ), 0)
+)::integer AS outstanding_cents;
+
 -- =============================================================================
 -- STATISTICS
 -- =============================================================================
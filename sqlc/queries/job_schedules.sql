@@ -0,0 +1,54 @@
+-- Job Schedule Queries
+-- Recurring jobs enqueued by the scheduler
+
+-- name: EnsureJobSchedule :exec
+-- Creates the schedule for a job type unless the tenant (or the system,
+-- when tenant_id is NULL) already has one. Existing schedules keep their
+-- cron expression and run times.
+INSERT INTO job_schedules (
+    tenant_id,
+    job_type,
+    cron_spec,
+    next_run_at
+)
+SELECT
+    sqlc.narg('tenant_id')::uuid,
+    sqlc.arg('job_type')::varchar,
+    sqlc.arg('cron_spec')::varchar,
+    sqlc.arg('next_run_at')::timestamptz
+WHERE NOT EXISTS (
+    SELECT 1 FROM job_schedules
+    WHERE tenant_id IS NOT DISTINCT FROM sqlc.narg('tenant_id')::uuid
+      AND job_type = sqlc.arg('job_type')
+);
+
+-- name: ListDueJobSchedules :many
+-- Enabled schedules whose next run has passed. Schedules of tenants that
+-- are no longer active are skipped.
+SELECT s.*
+FROM job_schedules s
+WHERE s.enabled = TRUE
+  AND s.next_run_at <= $1
+  AND (
+      s.tenant_id IS NULL
+      OR EXISTS (
+          SELECT 1 FROM tenants t
+          WHERE t.id = s.tenant_id AND t.status = 'active'
+      )
+  )
+ORDER BY s.next_run_at ASC;
+
+-- name: UpdateJobScheduleRun :exec
+-- Records a run (or a failed attempt to enqueue one) and the next run time
+UPDATE job_schedules
+SET last_run_at = $2,
+    next_run_at = $3,
+    last_error = $4
+WHERE id = $1;
+
+-- name: ListJobSchedulesForTenant :many
+-- All schedules for a tenant, for the admin schedules page
+SELECT *
+FROM job_schedules
+WHERE tenant_id = $1
+ORDER BY job_type ASC;
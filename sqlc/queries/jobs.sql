@@ -16,19 +16,23 @@ INSERT INTO jobs (
 
 -- name: ClaimNextJob :one
 -- Claim the next pending job using SKIP LOCKED for safe concurrent access
--- This query finds the highest priority job that's ready to run
+-- This query finds the highest priority job that's ready to run. An empty
+-- queue matches any queue except exclude_queues, which have their own
+-- concurrency limits.
 UPDATE jobs
 SET
     status = 'processing',
     processing_started_at = NOW(),
-    worker_id = $1
+    heartbeat_at = NOW(),
+    worker_id = sqlc.arg('worker_id')
 WHERE id = (
     SELECT j.id
     FROM jobs j
     WHERE j.status = 'pending'
       AND j.scheduled_at <= NOW()
-      AND (j.tenant_id = $2 OR $2 IS NULL)
-      AND (j.queue = $3 OR $3 = '')
+      AND (j.tenant_id = sqlc.narg('tenant_id') OR sqlc.narg('tenant_id')::uuid IS NULL)
+      AND (j.queue = sqlc.arg('queue') OR sqlc.arg('queue') = '')
+      AND j.queue <> ALL(COALESCE(sqlc.arg('exclude_queues')::text[], '{}'))
     ORDER BY j.priority ASC, j.scheduled_at ASC
     FOR UPDATE SKIP LOCKED
     LIMIT 1
@@ -36,16 +40,18 @@ WHERE id = (
 RETURNING *;
 
 -- name: CompleteJob :exec
--- Mark a job as completed
+-- Mark a job as completed. The worker must still own the job: a job the
+-- reaper took back from a silent worker may be running elsewhere.
 UPDATE jobs
 SET
     status = 'completed',
     processing_completed_at = NOW()
 WHERE id = $1
+  AND worker_id = $2
   AND status = 'processing';
 
 -- name: FailJob :one
--- Mark a job as failed or reschedule it for retry
+-- Mark a job as failed or reschedule it for retry at retry_at
 -- If retry_count < max_retries, reschedule; otherwise mark as failed
 UPDATE jobs
 SET
@@ -55,8 +61,7 @@ SET
     END,
     retry_count = retry_count + 1,
     scheduled_at = CASE
-        WHEN retry_count + 1 < max_retries
-        THEN NOW() + (retry_backoff_seconds * POWER(2, retry_count) || ' seconds')::INTERVAL
+        WHEN retry_count + 1 < max_retries THEN sqlc.arg('retry_at')::timestamptz
         ELSE scheduled_at
     END,
     processing_completed_at = CASE
@@ -71,12 +76,68 @@ SET
         WHEN retry_count + 1 < max_retries THEN NULL
         ELSE processing_started_at
     END,
-    error_message = $2,
-    error_details = $3
-WHERE id = $1
+    error_message = sqlc.arg('error_message'),
+    error_details = sqlc.arg('error_details')
+WHERE id = sqlc.arg('id')
+  AND worker_id = sqlc.arg('worker_id')
   AND status = 'processing'
 RETURNING *;
 
+-- name: HeartbeatWorkerJobs :execrows
+-- Record that a worker is still running its claimed jobs
+UPDATE jobs
+SET heartbeat_at = NOW()
+WHERE worker_id = $1
+  AND status = 'processing';
+
+-- name: ReapStaleJobs :many
+-- Take back processing jobs whose worker stopped sending heartbeats, e.g.
+-- after a crash or a deploy that killed the process. The lost run counts
+-- as an attempt, so a job that keeps killing its worker ends up failed.
+UPDATE jobs
+SET
+    status = CASE
+        WHEN retry_count + 1 < max_retries THEN 'pending'
+        ELSE 'failed'
+    END,
+    retry_count = retry_count + 1,
+    scheduled_at = CASE
+        WHEN retry_count + 1 < max_retries THEN NOW()
+        ELSE scheduled_at
+    END,
+    processing_completed_at = CASE
+        WHEN retry_count + 1 >= max_retries THEN NOW()
+        ELSE NULL
+    END,
+    worker_id = CASE
+        WHEN retry_count + 1 < max_retries THEN NULL
+        ELSE worker_id
+    END,
+    processing_started_at = CASE
+        WHEN retry_count + 1 < max_retries THEN NULL
+        ELSE processing_started_at
+    END,
+    error_message = 'Worker ' || COALESCE(worker_id, 'unknown') || ' stopped responding',
+    error_details = jsonb_build_object(
+        'worker_id', worker_id,
+        'last_heartbeat_at', COALESCE(heartbeat_at, processing_started_at)
+    )
+WHERE status = 'processing'
+  AND COALESCE(heartbeat_at, processing_started_at) < sqlc.arg('stale_before')::timestamptz
+RETURNING id, job_type, status, retry_count;
+
+-- name: ReleaseWorkerJobs :execrows
+-- Put a stopping worker's unfinished jobs back in the queue without using
+-- up a retry
+UPDATE jobs
+SET
+    status = 'pending',
+    worker_id = NULL,
+    processing_started_at = NULL,
+    heartbeat_at = NULL
+WHERE worker_id = $1
+  AND status = 'processing';
+
 -- name: GetJobByID :one
 -- Fetch a job by ID
 SELECT *
@@ -126,3 +187,113 @@ SET
     processing_completed_at = NOW()
 WHERE id = $1
   AND status = 'pending';
+
+-- Jobs console
+-- A NULL tenant_id scope matches every tenant and system jobs (platform
+-- staff); tenant operators pass their own tenant.
+
+-- name: ListJobs :many
+-- Jobs matching the console filters, newest first. Empty filters match all.
+SELECT *
+FROM jobs
+WHERE (tenant_id = sqlc.narg('tenant_id') OR sqlc.narg('tenant_id')::uuid IS NULL)
+  AND (queue = sqlc.arg('queue') OR sqlc.arg('queue')::varchar = '')
+  AND (job_type = sqlc.arg('job_type') OR sqlc.arg('job_type')::varchar = '')
+  AND (status = sqlc.arg('status') OR sqlc.arg('status')::varchar = '')
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountJobs :one
+-- Count of jobs matching the console filters
+SELECT COUNT(*)
+FROM jobs
+WHERE (tenant_id = sqlc.narg('tenant_id') OR sqlc.narg('tenant_id')::uuid IS NULL)
+  AND (queue = sqlc.arg('queue') OR sqlc.arg('queue')::varchar = '')
+  AND (job_type = sqlc.arg('job_type') OR sqlc.arg('job_type')::varchar = '')
+  AND (status = sqlc.arg('status') OR sqlc.arg('status')::varchar = '');
+
+-- name: GetJobInScope :one
+-- Fetch a job by ID within the console scope
+SELECT *
+FROM jobs
+WHERE id = sqlc.arg('id')
+  AND (tenant_id = sqlc.narg('tenant_id') OR sqlc.narg('tenant_id')::uuid IS NULL);
+
+-- name: RequeueJob :one
+-- Put a pending, failed or cancelled job back in the queue to run at
+-- scheduled_at. Failed and cancelled jobs get a fresh set of retries; the
+-- previous attempt's error stays in job_history.
+UPDATE jobs
+SET
+    status = 'pending',
+    scheduled_at = sqlc.arg('scheduled_at'),
+    retry_count = CASE WHEN status = 'pending' THEN retry_count ELSE 0 END,
+    worker_id = NULL,
+    processing_started_at = NULL,
+    processing_completed_at = NULL,
+    error_message = NULL,
+    error_details = NULL
+WHERE id = sqlc.arg('id')
+  AND (tenant_id = sqlc.narg('tenant_id') OR sqlc.narg('tenant_id')::uuid IS NULL)
+  AND status IN ('pending', 'failed', 'cancelled')
+RETURNING *;
+
+-- name: CancelJobInScope :one
+-- Cancel a pending job within the console scope
+UPDATE jobs
+SET
+    status = 'cancelled',
+    processing_completed_at = NOW()
+WHERE id = sqlc.arg('id')
+  AND (tenant_id = sqlc.narg('tenant_id') OR sqlc.narg('tenant_id')::uuid IS NULL)
+  AND status = 'pending'
+RETURNING *;
+
+-- name: RequeueFailedJobs :execrows
+-- Requeue every dead-lettered (failed) job of a type, e.g. after an
+-- outage. failed_since limits it to jobs that failed from then on.
+UPDATE jobs
+SET
+    status = 'pending',
+    scheduled_at = NOW(),
+    retry_count = 0,
+    worker_id = NULL,
+    processing_started_at = NULL,
+    processing_completed_at = NULL,
+    error_message = NULL,
+    error_details = NULL
+WHERE status = 'failed'
+  AND job_type = sqlc.arg('job_type')
+  AND (tenant_id = sqlc.narg('tenant_id') OR sqlc.narg('tenant_id')::uuid IS NULL)
+  AND (processing_completed_at >= sqlc.narg('failed_since') OR sqlc.narg('failed_since')::timestamptz IS NULL);
+
+-- name: ListFailedJobTypes :many
+-- Dead-lettered jobs grouped by type, for the dead-letter view
+SELECT
+    job_type,
+    COUNT(*) AS failed_count,
+    MAX(processing_completed_at)::timestamptz AS last_failed_at
+FROM jobs
+WHERE status = 'failed'
+  AND (tenant_id = sqlc.narg('tenant_id') OR sqlc.narg('tenant_id')::uuid IS NULL)
+GROUP BY job_type
+ORDER BY failed_count DESC, job_type ASC;
+
+-- name: ListJobQueueStats :many
+-- Pending, processing and failed counts per queue
+SELECT
+    queue,
+    COUNT(*) FILTER (WHERE status = 'pending') AS pending_count,
+    COUNT(*) FILTER (WHERE status = 'processing') AS processing_count,
+    COUNT(*) FILTER (WHERE status = 'failed') AS failed_count
+FROM jobs
+WHERE (tenant_id = sqlc.narg('tenant_id') OR sqlc.narg('tenant_id')::uuid IS NULL)
+GROUP BY queue
+ORDER BY queue ASC;
+
+-- name: ListJobTypes :many
+-- Job types present in the queue, for the console filters
+SELECT DISTINCT job_type
+FROM jobs
+WHERE (tenant_id = sqlc.narg('tenant_id') OR sqlc.narg('tenant_id')::uuid IS NULL)
+ORDER BY job_type ASC;
@@ -0,0 +1,84 @@
+-- name: ListCustomerSKUOrderRules :many
+-- List a customer's quantity rule overrides alongside the SKU's own rules
+SELECT
+    r.product_sku_id,
+    r.min_quantity,
+    r.case_pack,
+    ps.sku,
+    ps.wholesale_min_quantity,
+    ps.wholesale_case_pack,
+    p.name AS product_name
+FROM customer_sku_order_rules r
+INNER JOIN product_skus ps ON ps.id = r.product_sku_id
+INNER JOIN products p ON p.id = ps.product_id
+WHERE r.tenant_id = $1
+  AND r.user_id = $2
+ORDER BY p.name ASC, ps.sku ASC;
+
+-- name: ListCustomerOrderableSKUs :many
+-- Active SKUs a wholesale customer can order: catalog SKUs plus their own
+-- white-label SKUs
+SELECT
+    ps.id,
+    ps.sku,
+    ps.wholesale_min_quantity,
+    ps.wholesale_case_pack,
+    p.name AS product_name
+FROM product_skus ps
+INNER JOIN products p ON p.id = ps.product_id
+WHERE ps.tenant_id = $1
+  AND ps.is_active = TRUE
+  AND p.status = 'active'
+  AND (
+    (p.is_white_label = FALSE AND (p.visibility = 'public' OR p.visibility = 'wholesale_only'))
+    OR
+    (p.is_white_label = TRUE AND p.white_label_customer_id = sqlc.arg('customer_id'))
+  )
+ORDER BY p.name ASC, ps.weight_value ASC, ps.grind ASC;
+
+-- name: UpsertCustomerSKUOrderRule :one
+-- Set a customer's quantity rules for a SKU, replacing any existing override
+INSERT INTO customer_sku_order_rules (
+    tenant_id,
+    user_id,
+    product_sku_id,
+    min_quantity,
+    case_pack
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (user_id, product_sku_id)
+DO UPDATE SET
+    min_quantity = EXCLUDED.min_quantity,
+    case_pack = EXCLUDED.case_pack,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteCustomerSKUOrderRule :exec
+-- Remove a customer's override so the SKU's own rules apply again
+DELETE FROM customer_sku_order_rules
+WHERE tenant_id = $1
+  AND user_id = $2
+  AND product_sku_id = $3;
+
+-- name: ListCartOrderRules :many
+-- Get each cart line with the SKU's quantity rules and the customer's
+-- override, if any
+SELECT
+    ci.product_sku_id,
+    ci.quantity,
+    ci.unit_price_cents,
+    p.name AS product_name,
+    ps.sku,
+    ps.wholesale_min_quantity,
+    ps.wholesale_case_pack,
+    (rule.id IS NOT NULL)::boolean AS has_customer_rule,
+    rule.min_quantity AS customer_min_quantity,
+    rule.case_pack AS customer_case_pack
+FROM cart_items ci
+INNER JOIN product_skus ps ON ps.id = ci.product_sku_id
+INNER JOIN products p ON p.id = ps.product_id
+LEFT JOIN customer_sku_order_rules rule ON rule.product_sku_id = ps.id AND rule.user_id = sqlc.arg('user_id')
+WHERE ci.tenant_id = $1
+  AND ci.cart_id = $2
+ORDER BY ci.created_at ASC;
@@ -0,0 +1,61 @@
+-- name: UpsertOrderTemplate :one
+-- Creates a customer's order template, or touches the one with the same name
+-- so its items can be replaced
+INSERT INTO order_templates (
+    tenant_id,
+    user_id,
+    name
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (tenant_id, user_id, name) DO UPDATE
+SET updated_at = NOW()
+RETURNING *;
+
+-- name: GetOrderTemplateForUser :one
+-- Gets an order template, scoped to the customer who saved it
+SELECT * FROM order_templates
+WHERE id = $1
+  AND tenant_id = $2
+  AND user_id = $3;
+
+-- name: ListOrderTemplatesForUser :many
+-- A customer's order templates with their item counts, by name
+SELECT
+    ot.id,
+    ot.name,
+    ot.updated_at,
+    COUNT(oti.id)::int AS item_count
+FROM order_templates ot
+LEFT JOIN order_template_items oti ON oti.order_template_id = ot.id
+WHERE ot.tenant_id = $1
+  AND ot.user_id = $2
+GROUP BY ot.id
+ORDER BY ot.name ASC;
+
+-- name: DeleteOrderTemplate :exec
+DELETE FROM order_templates
+WHERE id = $1
+  AND tenant_id = $2;
+
+-- name: ListOrderTemplateItems :many
+-- SKUs and quantities in an order template
+SELECT * FROM order_template_items
+WHERE tenant_id = $1
+  AND order_template_id = $2;
+
+-- name: CreateOrderTemplateItem :exec
+INSERT INTO order_template_items (
+    tenant_id,
+    order_template_id,
+    product_sku_id,
+    quantity
+) VALUES (
+    $1, $2, $3, $4
+);
+
+-- name: DeleteOrderTemplateItems :exec
+-- Clears an order template's items before it is saved again
+DELETE FROM order_template_items
+WHERE tenant_id = $1
+  AND order_template_id = $2;
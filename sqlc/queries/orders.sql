@@ -27,9 +27,10 @@ INSERT INTO orders (
     customer_notes,
     subscription_id,
     customer_po_number,
-    requested_delivery_date
+    requested_delivery_date,
+    discount_cents
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
 )
 RETURNING *;
 
@@ -52,14 +53,33 @@ INSERT INTO order_items (
 RETURNING *;
 
 -- name: DecrementSKUStock :exec
--- Decrements inventory for a SKU after order placement
+-- Decrements inventory for a SKU after order placement and records the sale
+-- in the inventory ledger. White-label SKUs sell from their base SKU's stock.
 -- Uses optimistic locking to prevent overselling
-UPDATE product_skus
-SET inventory_quantity = inventory_quantity - $3,
-    updated_at = NOW()
-WHERE tenant_id = $1
-  AND id = $2
-  AND inventory_quantity >= $3;  -- Ensures sufficient stock
+WITH updated AS (
+    UPDATE product_skus
+    SET inventory_quantity = inventory_quantity - $3,
+        updated_at = NOW()
+    WHERE tenant_id = $1
+      AND id = (
+          SELECT COALESCE(base_sku_id, id)
+          FROM product_skus
+          WHERE tenant_id = $1
+            AND id = $2
+      )
+      AND inventory_quantity >= $3  -- Ensures sufficient stock
+    RETURNING tenant_id, id, inventory_quantity
+)
+INSERT INTO inventory_adjustments (
+    tenant_id,
+    product_sku_id,
+    reason,
+    quantity_change,
+    quantity_after,
+    order_id
+)
+SELECT tenant_id, id, 'order', -$3::integer, inventory_quantity, sqlc.narg('order_id')
+FROM updated;
 
 -- name: UpdateCartStatus :exec
 -- Marks cart as converted to order
@@ -70,6 +90,17 @@ SET status = $3,
 WHERE tenant_id = $1
   AND id = $2;
 
+-- name: ClaimCartForOrder :one
+-- Marks cart as converted unless another order already did
+-- Returns no rows when the cart was already converted
+UPDATE carts
+SET status = 'converted',
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2
+  AND status <> 'converted'
+RETURNING id;
+
 -- name: GetOrder :one
 -- Retrieves a single order by ID with tenant scoping
 SELECT * FROM orders
@@ -237,6 +268,21 @@ SET
 WHERE tenant_id = $1
   AND id = $2;
 
+-- name: SetOrderStatusChangeReason :exec
+-- Records why an order's status changed on the history row the status
+-- trigger wrote for it
+UPDATE order_status_history
+SET change_reason = $4
+WHERE id = (
+    SELECT h.id
+    FROM order_status_history h
+    WHERE h.tenant_id = $1
+      AND h.order_id = $2
+      AND h.to_status = $3
+    ORDER BY h.created_at DESC
+    LIMIT 1
+);
+
 -- name: GetOrderStats :one
 -- Get order statistics for dashboard
 SELECT
@@ -261,6 +307,7 @@ SELECT
     o.subtotal_cents,
     o.shipping_cents,
     o.tax_cents,
+    o.discount_cents,
     o.total_cents,
     o.currency,
     o.customer_notes,
@@ -529,3 +576,31 @@ FROM shipment_items si
 JOIN shipments s ON s.id = si.shipment_id
 WHERE si.order_item_id = $1
 ORDER BY s.created_at DESC;
+
+-- name: ListReorderItems :many
+-- Items from a customer's past order with what can be ordered today. A SKU is
+-- available while it and its product are active and the customer can still
+-- see the product; white-label SKUs use the stock of their base SKU.
+SELECT
+    oi.product_sku_id,
+    oi.product_name,
+    oi.sku,
+    oi.quantity,
+    oi.unit_price_cents,
+    (ps.is_active AND p.status = 'active' AND (
+        (p.is_white_label = FALSE AND (p.visibility = 'public' OR (p.visibility = 'wholesale_only' AND u.account_type = 'wholesale')))
+        OR
+        (p.is_white_label = TRUE AND p.white_label_customer_id = o.user_id)
+    ))::boolean AS available,
+    stock.inventory_quantity,
+    stock.inventory_policy
+FROM orders o
+INNER JOIN users u ON u.id = o.user_id
+INNER JOIN order_items oi ON oi.order_id = o.id
+INNER JOIN product_skus ps ON ps.id = oi.product_sku_id
+INNER JOIN products p ON p.id = ps.product_id
+INNER JOIN product_skus stock ON stock.id = COALESCE(ps.base_sku_id, ps.id)
+WHERE o.tenant_id = $1
+  AND o.id = $2
+  AND o.user_id = $3
+ORDER BY oi.created_at ASC;
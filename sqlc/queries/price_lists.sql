@@ -202,3 +202,58 @@ SET
 -- Delete a price list entry
 DELETE FROM price_list_entries
 WHERE tenant_id = $1 AND id = $2;
+
+-- name: ListPriceTiersForEntry :many
+-- Get the quantity-break prices for a price list entry, lowest quantity first
+SELECT *
+FROM price_list_entry_tiers
+WHERE tenant_id = $1
+  AND price_list_entry_id = $2
+ORDER BY min_quantity ASC;
+
+-- name: ListPriceListTiers :many
+-- List the quantity-break prices of every entry on a price list
+SELECT
+    t.id,
+    t.price_list_entry_id,
+    ple.product_sku_id,
+    t.min_quantity,
+    t.price_cents,
+    ps.sku,
+    p.name as product_name
+FROM price_list_entry_tiers t
+INNER JOIN price_list_entries ple ON ple.id = t.price_list_entry_id
+INNER JOIN product_skus ps ON ps.id = ple.product_sku_id
+INNER JOIN products p ON p.id = ps.product_id
+WHERE t.tenant_id = $1
+  AND ple.price_list_id = $2
+ORDER BY p.name ASC, ps.sku ASC, t.min_quantity ASC;
+
+-- name: UpsertPriceListEntryTier :one
+-- Create or update the quantity-break price at a quantity. Returns no row if
+-- the entry isn't on the tenant's price list.
+INSERT INTO price_list_entry_tiers (
+    tenant_id,
+    price_list_entry_id,
+    min_quantity,
+    price_cents
+)
+SELECT ple.tenant_id, ple.id, sqlc.arg('min_quantity'), sqlc.arg('price_cents')
+FROM price_list_entries ple
+WHERE ple.tenant_id = sqlc.arg('tenant_id')
+  AND ple.price_list_id = sqlc.arg('price_list_id')
+  AND ple.id = sqlc.arg('price_list_entry_id')
+ON CONFLICT (price_list_entry_id, min_quantity) DO UPDATE
+SET
+    price_cents = EXCLUDED.price_cents,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: DeletePriceListEntryTier :exec
+-- Delete a quantity-break price from a price list
+DELETE FROM price_list_entry_tiers t
+USING price_list_entries ple
+WHERE ple.id = t.price_list_entry_id
+  AND t.tenant_id = $1
+  AND ple.price_list_id = $2
+  AND t.id = $3;
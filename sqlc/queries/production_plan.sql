@@ -0,0 +1,110 @@
+-- name: ListProductionDemand :many
+-- Coffee to roast per product per day from start_date through end_date:
+-- unshipped items on paid orders (due on their requested delivery date, or
+-- start_date when unset or overdue), pending wholesale orders by requested
+-- delivery date, and subscription renewals by next billing date. Coffee
+-- allocated to a batch that has been roasted is left out. Grams use each
+-- SKU's bag size. White-label SKUs count towards their base product, which
+-- is the coffee that gets roasted.
+WITH sku_grams AS (
+    SELECT
+        ps.id,
+        COALESCE(base.product_id, ps.product_id) as product_id,
+        CASE ps.weight_unit
+            WHEN 'oz' THEN ps.weight_value * 28.3495
+            WHEN 'lb' THEN ps.weight_value * 453.592
+            WHEN 'kg' THEN ps.weight_value * 1000
+            ELSE ps.weight_value
+        END as grams
+    FROM product_skus ps
+    LEFT JOIN product_skus base ON base.id = ps.base_sku_id
+    WHERE ps.tenant_id = $1
+),
+demand AS (
+    SELECT
+        oi.product_sku_id,
+        oi.quantity - oi.quantity_dispatched as quantity,
+        GREATEST(COALESCE(o.requested_delivery_date, sqlc.arg('start_date')::date), sqlc.arg('start_date')::date) as roast_on,
+        CASE WHEN o.order_type = 'wholesale' THEN 'wholesale' ELSE 'order' END as source
+    FROM order_items oi
+    JOIN orders o ON o.id = oi.order_id
+    WHERE o.tenant_id = $1
+      AND o.status IN ('paid', 'processing')
+      AND oi.quantity_dispatched < oi.quantity
+      AND NOT EXISTS (
+          SELECT 1
+          FROM roast_batch_allocations ra
+          JOIN roast_batches rb ON rb.id = ra.roast_batch_id
+          WHERE ra.order_item_id = oi.id
+            AND rb.status = 'roasted'
+      )
+
+    UNION ALL
+
+    SELECT
+        oi.product_sku_id,
+        oi.quantity,
+        GREATEST(o.requested_delivery_date, sqlc.arg('start_date')::date),
+        'wholesale'
+    FROM order_items oi
+    JOIN orders o ON o.id = oi.order_id
+    WHERE o.tenant_id = $1
+      AND o.order_type = 'wholesale'
+      AND o.status = 'pending'
+      AND o.requested_delivery_date IS NOT NULL
+
+    UNION ALL
+
+    SELECT
+        si.product_sku_id,
+        si.quantity,
+        s.next_billing_date::date,
+        'subscription'
+    FROM subscription_items si
+    JOIN subscriptions s ON s.id = si.subscription_id
+    WHERE s.tenant_id = $1
+      AND s.status IN ('trial', 'active')
+      AND s.cancel_at_period_end = FALSE
+      AND s.next_billing_date::date BETWEEN sqlc.arg('start_date')::date AND sqlc.arg('end_date')::date
+      AND NOT EXISTS (
+          SELECT 1
+          FROM roast_batch_allocations ra
+          JOIN roast_batches rb ON rb.id = ra.roast_batch_id
+          WHERE ra.subscription_item_id = si.id
+            AND ra.renews_on = s.next_billing_date::date
+            AND rb.status = 'roasted'
+      )
+)
+SELECT
+    d.roast_on::date as roast_on,
+    p.id as product_id,
+    p.name as product_name,
+    p.roast_loss_percent::float8 as roast_loss_percent,
+    SUM(d.quantity)::INTEGER as units,
+    COALESCE(SUM(d.quantity * sg.grams) FILTER (WHERE d.source = 'order'), 0)::INTEGER as order_grams,
+    COALESCE(SUM(d.quantity * sg.grams) FILTER (WHERE d.source = 'wholesale'), 0)::INTEGER as wholesale_grams,
+    COALESCE(SUM(d.quantity * sg.grams) FILTER (WHERE d.source = 'subscription'), 0)::INTEGER as subscription_grams
+FROM demand d
+JOIN sku_grams sg ON sg.id = d.product_sku_id
+JOIN products p ON p.id = sg.product_id
+WHERE d.roast_on <= sqlc.arg('end_date')::date
+GROUP BY d.roast_on, p.id
+ORDER BY d.roast_on ASC, p.name ASC;
+
+-- name: ListProductRoastLoss :many
+-- Roast loss of each active product, for production planning
+SELECT
+    id,
+    name,
+    roast_loss_percent::float8 as roast_loss_percent
+FROM products
+WHERE tenant_id = $1
+  AND status = 'active'
+ORDER BY name ASC;
+
+-- name: UpdateProductRoastLoss :execrows
+-- Sets the percent of green weight a product loses when roasted
+UPDATE products
+SET roast_loss_percent = sqlc.arg('roast_loss_percent')::float8
+WHERE tenant_id = $1
+  AND id = $2;
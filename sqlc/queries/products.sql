@@ -17,10 +17,12 @@ LEFT JOIN product_images pi ON pi.product_id = p.id AND pi.is_primary = TRUE
 WHERE p.tenant_id = $1
   AND p.status = 'active'
   AND p.visibility = 'public'
+  AND p.is_white_label = FALSE
 ORDER BY p.sort_order ASC, p.created_at DESC;
 
 -- name: ListActiveProductsFiltered :many
--- List active products with optional filters for roast level and origin
+-- List active products with optional filters for roast level, origin, tasting
+-- note, category (including its subcategories) and tag
 SELECT
     p.id,
     p.tenant_id,
@@ -44,9 +46,37 @@ LEFT JOIN product_images pi ON pi.product_id = p.id AND pi.is_primary = TRUE
 WHERE p.tenant_id = $1
   AND p.status = 'active'
   AND p.visibility = 'public'
+  AND p.is_white_label = FALSE
   AND (sqlc.narg('roast_level')::text IS NULL OR p.roast_level = sqlc.narg('roast_level')::text)
   AND (sqlc.narg('origin')::text IS NULL OR p.origin = sqlc.narg('origin')::text)
   AND (sqlc.narg('tasting_note')::text IS NULL OR sqlc.narg('tasting_note')::text = ANY(p.tasting_notes))
+  AND (sqlc.narg('category_slug')::text IS NULL OR EXISTS (
+      SELECT 1
+      FROM product_category_assignments pca
+      WHERE pca.product_id = p.id
+        AND pca.category_id IN (
+            WITH RECURSIVE category_tree AS (
+                SELECT pc.id
+                FROM product_categories pc
+                WHERE pc.tenant_id = $1
+                  AND pc.slug = sqlc.narg('category_slug')::text
+                  AND pc.is_active = TRUE
+                UNION ALL
+                SELECT child.id
+                FROM product_categories child
+                JOIN category_tree ct ON child.parent_id = ct.id
+                WHERE child.is_active = TRUE
+            )
+            SELECT id FROM category_tree
+        )
+  ))
+  AND (sqlc.narg('tag_slug')::text IS NULL OR EXISTS (
+      SELECT 1
+      FROM product_tag_assignments pta
+      JOIN product_tags pt ON pt.id = pta.tag_id
+      WHERE pta.product_id = p.id
+        AND pt.slug = sqlc.narg('tag_slug')::text
+  ))
 ORDER BY p.sort_order ASC, p.created_at DESC;
 
 -- name: GetProductFilterOptions :one
@@ -54,15 +84,15 @@ ORDER BY p.sort_order ASC, p.created_at DESC;
 SELECT
     (SELECT ARRAY_AGG(DISTINCT p2.roast_level ORDER BY p2.roast_level)
      FROM products p2
-     WHERE p2.tenant_id = $1 AND p2.status = 'active' AND p2.visibility = 'public' AND p2.roast_level IS NOT NULL
+     WHERE p2.tenant_id = $1 AND p2.status = 'active' AND p2.visibility = 'public' AND p2.is_white_label = FALSE AND p2.roast_level IS NOT NULL
     ) as roast_levels,
     (SELECT ARRAY_AGG(DISTINCT p3.origin ORDER BY p3.origin)
      FROM products p3
-     WHERE p3.tenant_id = $1 AND p3.status = 'active' AND p3.visibility = 'public' AND p3.origin IS NOT NULL
+     WHERE p3.tenant_id = $1 AND p3.status = 'active' AND p3.visibility = 'public' AND p3.is_white_label = FALSE AND p3.origin IS NOT NULL
     ) as origins,
     (SELECT ARRAY_AGG(DISTINCT note ORDER BY note)
      FROM products p4, UNNEST(p4.tasting_notes) AS note
-     WHERE p4.tenant_id = $1 AND p4.status = 'active' AND p4.visibility = 'public'
+     WHERE p4.tenant_id = $1 AND p4.status = 'active' AND p4.visibility = 'public' AND p4.is_white_label = FALSE
     ) as tasting_notes;
 
 -- name: GetProductBySlug :one
@@ -72,6 +102,7 @@ FROM products
 WHERE tenant_id = $1
   AND slug = $2
   AND status = 'active'
+  AND is_white_label = FALSE
 LIMIT 1;
 
 -- name: GetProductSKUs :many
@@ -92,7 +123,10 @@ SELECT
     weight_grams,
     requires_shipping,
     created_at,
-    updated_at
+    updated_at,
+    base_sku_id,
+    wholesale_min_quantity,
+    wholesale_case_pack
 FROM product_skus
 WHERE product_id = $1
   AND is_active = TRUE
@@ -156,7 +190,10 @@ SELECT
     weight_grams,
     requires_shipping,
     created_at,
-    updated_at
+    updated_at,
+    base_sku_id,
+    wholesale_min_quantity,
+    wholesale_case_pack
 FROM product_skus
 WHERE id = $1
   AND is_active = TRUE
@@ -222,6 +259,87 @@ INNER JOIN products base ON base.id = p.base_product_id
 WHERE p.id = $1
   AND p.is_white_label = TRUE;
 
+-- name: GetWhiteLabelDetails :one
+-- Get the base product and customer of a white-label product
+SELECT
+    base.id as base_product_id,
+    base.name as base_product_name,
+    u.id as customer_id,
+    u.email as customer_email,
+    u.first_name as customer_first_name,
+    u.last_name as customer_last_name,
+    u.company_name as customer_company_name
+FROM products p
+INNER JOIN products base ON base.id = p.base_product_id
+INNER JOIN users u ON u.id = p.white_label_customer_id
+WHERE p.tenant_id = $1
+  AND p.id = $2
+  AND p.is_white_label = TRUE;
+
+-- name: ListWhiteLabelProducts :many
+-- List the white-label variants of a base product with their customers
+SELECT
+    p.id,
+    p.name,
+    p.slug,
+    p.status,
+    p.created_at,
+    u.id as customer_id,
+    u.email as customer_email,
+    u.first_name as customer_first_name,
+    u.last_name as customer_last_name,
+    u.company_name as customer_company_name
+FROM products p
+INNER JOIN users u ON u.id = p.white_label_customer_id
+WHERE p.tenant_id = $1
+  AND p.base_product_id = $2
+  AND p.is_white_label = TRUE
+ORDER BY u.company_name ASC, p.name ASC;
+
+-- name: CreateWhiteLabelSKU :one
+-- Clone a base product SKU into a white-label product. The clone keeps the
+-- bag size, grind, stock policy and wholesale quantity rules, and draws from
+-- the base SKU's inventory.
+INSERT INTO product_skus (
+    tenant_id,
+    product_id,
+    sku,
+    weight_value,
+    weight_unit,
+    grind,
+    base_price_cents,
+    inventory_quantity,
+    inventory_policy,
+    low_stock_threshold,
+    is_active,
+    weight_grams,
+    requires_shipping,
+    base_sku_id,
+    wholesale_min_quantity,
+    wholesale_case_pack
+)
+SELECT
+    base.tenant_id,
+    sqlc.arg('product_id'),
+    sqlc.arg('sku'),
+    base.weight_value,
+    base.weight_unit,
+    base.grind,
+    sqlc.arg('base_price_cents'),
+    0,
+    base.inventory_policy,
+    base.low_stock_threshold,
+    TRUE,
+    base.weight_grams,
+    base.requires_shipping,
+    base.id,
+    base.wholesale_min_quantity,
+    base.wholesale_case_pack
+FROM product_skus base
+WHERE base.tenant_id = $1
+  AND base.id = sqlc.arg('base_sku_id')
+RETURNING *;
+
 -- Admin queries
 
 -- name: ListAllProducts :many
@@ -329,14 +447,17 @@ INSERT INTO product_skus (
     low_stock_threshold,
     is_active,
     weight_grams,
-    requires_shipping
+    requires_shipping,
+    wholesale_min_quantity,
+    wholesale_case_pack
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 )
 RETURNING *;
 
 -- name: UpdateProductSKU :one
--- Update an existing product SKU
+-- Update an existing product SKU. Stock changes go through the inventory
+-- ledger (AdjustSKUInventory).
 UPDATE product_skus
 SET
     sku = $3,
@@ -344,12 +465,13 @@ SET
     weight_unit = $5,
     grind = $6,
     base_price_cents = $7,
-    inventory_quantity = $8,
-    inventory_policy = $9,
-    low_stock_threshold = $10,
-    is_active = $11,
-    weight_grams = $12,
-    requires_shipping = $13,
+    inventory_policy = $8,
+    low_stock_threshold = $9,
+    is_active = $10,
+    weight_grams = $11,
+    requires_shipping = $12,
+    wholesale_min_quantity = $13,
+    wholesale_case_pack = $14,
     updated_at = NOW()
 WHERE tenant_id = $1
   AND id = $2
@@ -419,26 +541,41 @@ LIMIT 1;
 -- name: ListProductsWithSKUsForWholesale :many
 -- Get all active products with their SKUs and prices for wholesale ordering matrix view
 -- This query denormalizes the data for efficient display in a table format
+-- White-label products are only included for their customer, with the stock
+-- of the base product SKU they draw from. Quantity rules are the SKU's, plus
+-- the customer's override if they have one.
 SELECT
     p.id as product_id,
     p.name as product_name,
     p.slug as product_slug,
     p.origin as product_origin,
+    p.is_white_label as product_is_white_label,
     pi.url as product_image_url,
     ps.id as sku_id,
     ps.sku as sku_code,
     ps.weight_value,
     ps.weight_unit,
     ps.grind,
-    ps.inventory_quantity,
-    ps.inventory_policy,
-    ps.low_stock_threshold,
-    ple.price_cents
+    stock.inventory_quantity,
+    stock.inventory_policy,
+    stock.low_stock_threshold,
+    ple.price_cents,
+    ps.wholesale_min_quantity,
+    ps.wholesale_case_pack,
+    (rule.id IS NOT NULL)::boolean AS has_customer_rule,
+    rule.min_quantity AS customer_min_quantity,
+    rule.case_pack AS customer_case_pack
 FROM products p
 INNER JOIN product_skus ps ON ps.product_id = p.id AND ps.is_active = TRUE
+INNER JOIN product_skus stock ON stock.id = COALESCE(ps.base_sku_id, ps.id)
 INNER JOIN price_list_entries ple ON ple.product_sku_id = ps.id AND ple.price_list_id = $2
 LEFT JOIN product_images pi ON pi.product_id = p.id AND pi.is_primary = TRUE
+LEFT JOIN customer_sku_order_rules rule ON rule.product_sku_id = ps.id AND rule.user_id = sqlc.arg('customer_id')
 WHERE p.tenant_id = $1
   AND p.status = 'active'
-  AND (p.visibility = 'public' OR p.visibility = 'wholesale_only')
+  AND (
+    (p.is_white_label = FALSE AND (p.visibility = 'public' OR p.visibility = 'wholesale_only'))
+    OR
+    (p.is_white_label = TRUE AND p.white_label_customer_id = sqlc.arg('customer_id'))
+  )
 ORDER BY p.sort_order ASC, p.name ASC, ps.weight_value ASC, ps.grind ASC;
@@ -0,0 +1,133 @@
+-- name: CreateRefund :one
+-- Records a refund issued through the payment provider
+-- Returns no rows if the provider refund has already been recorded,
+-- which keeps admin refunds and charge.refunded webhooks idempotent
+INSERT INTO refunds (
+    tenant_id,
+    order_id,
+    payment_id,
+    provider,
+    provider_refund_id,
+    amount_cents,
+    reason,
+    note,
+    status,
+    source,
+    restocked
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+)
+ON CONFLICT (provider, provider_refund_id) DO NOTHING
+RETURNING *;
+
+-- name: CreateRefundItem :one
+-- Records a line item (and quantity) covered by a refund
+INSERT INTO refund_items (
+    tenant_id,
+    refund_id,
+    order_item_id,
+    quantity,
+    amount_cents
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetRefundByProviderID :one
+-- Retrieves a refund by its provider refund ID with tenant scoping
+SELECT * FROM refunds
+WHERE tenant_id = $1
+  AND provider = $2
+  AND provider_refund_id = $3
+LIMIT 1;
+
+-- name: ListRefundsForOrder :many
+-- Lists all refunds for an order, newest first
+SELECT * FROM refunds
+WHERE tenant_id = $1
+  AND order_id = $2
+ORDER BY created_at DESC;
+
+-- name: GetOrderRefundedTotal :one
+-- Sums all refunds recorded against an order
+SELECT COALESCE(SUM(amount_cents), 0)::bigint AS refunded_cents
+FROM refunds
+WHERE tenant_id = $1
+  AND order_id = $2;
+
+-- name: ListRefundedQuantitiesForOrder :many
+-- Sums refunded quantities per order line item
+SELECT
+    ri.order_item_id,
+    SUM(ri.quantity)::integer AS refunded_quantity
+FROM refund_items ri
+INNER JOIN refunds r ON r.id = ri.refund_id
+WHERE r.tenant_id = $1
+  AND r.order_id = $2
+GROUP BY ri.order_item_id;
+
+-- name: IncrementSKUStock :exec
+-- Returns refunded units to inventory and records the restock in the
+-- inventory ledger. White-label SKUs restock their base SKU.
+WITH updated AS (
+    UPDATE product_skus
+    SET inventory_quantity = inventory_quantity + $3,
+        updated_at = NOW()
+    WHERE tenant_id = $1
+      AND id = (
+          SELECT COALESCE(base_sku_id, id)
+          FROM product_skus
+          WHERE tenant_id = $1
+            AND id = $2
+      )
+    RETURNING tenant_id, id, inventory_quantity
+)
+INSERT INTO inventory_adjustments (
+    tenant_id,
+    product_sku_id,
+    reason,
+    quantity_change,
+    quantity_after,
+    order_id,
+    refund_id
+)
+SELECT tenant_id, id, 'refund_restock', $3::integer, inventory_quantity, sqlc.narg('order_id'), sqlc.narg('refund_id')
+FROM updated;
+
+-- name: RestockCancelledOrderItem :exec
+-- Returns a cancelled order's units to inventory and records the restock in
+-- the inventory ledger. White-label SKUs restock their base SKU.
+WITH updated AS (
+    UPDATE product_skus
+    SET inventory_quantity = inventory_quantity + $3,
+        updated_at = NOW()
+    WHERE tenant_id = $1
+      AND id = (
+          SELECT COALESCE(base_sku_id, id)
+          FROM product_skus
+          WHERE tenant_id = $1
+            AND id = $2
+      )
+    RETURNING tenant_id, id, inventory_quantity
+)
+INSERT INTO inventory_adjustments (
+    tenant_id,
+    product_sku_id,
+    reason,
+    quantity_change,
+    quantity_after,
+    order_id
+)
+SELECT tenant_id, id, 'order_cancelled', $3::integer, inventory_quantity, sqlc.arg('order_id')
+FROM updated;
+
+-- name: UpdatePaymentRefund :exec
+-- Updates the refunded amount and status of a payment
+UPDATE payments
+SET
+    refunded_amount_cents = $3,
+    status = $4,
+    refunded_at = NOW(),
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2;
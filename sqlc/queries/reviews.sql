@@ -0,0 +1,224 @@
+-- name: ListReviewableItemsForOrders :many
+-- List the products in a customer's delivered orders with the customer's
+-- review of each product, if any
+SELECT DISTINCT ON (o.id, p.id)
+    o.id AS order_id,
+    p.id AS product_id,
+    p.name AS product_name,
+    p.slug AS product_slug,
+    pr.id AS review_id,
+    pr.rating AS review_rating,
+    pr.status AS review_status
+FROM orders o
+INNER JOIN order_items oi ON oi.order_id = o.id AND oi.tenant_id = o.tenant_id
+INNER JOIN product_skus ps ON ps.id = oi.product_sku_id
+INNER JOIN products p ON p.id = ps.product_id
+LEFT JOIN product_reviews pr ON pr.tenant_id = o.tenant_id
+    AND pr.product_id = p.id
+    AND pr.user_id = o.user_id
+WHERE o.tenant_id = $1
+  AND o.user_id = $2
+  AND o.id = ANY(sqlc.arg('order_ids')::uuid[])
+  AND o.status = 'delivered'
+ORDER BY o.id, p.id;
+
+-- name: GetReviewableOrderProduct :one
+-- Get a product from a customer's delivered order, for a verified purchase review
+SELECT
+    o.id AS order_id,
+    o.order_number,
+    p.id AS product_id,
+    p.name AS product_name,
+    p.slug AS product_slug
+FROM orders o
+INNER JOIN order_items oi ON oi.order_id = o.id AND oi.tenant_id = o.tenant_id
+INNER JOIN product_skus ps ON ps.id = oi.product_sku_id
+INNER JOIN products p ON p.id = ps.product_id
+WHERE o.tenant_id = $1
+  AND o.user_id = $2
+  AND o.id = sqlc.arg('order_id')
+  AND p.id = sqlc.arg('product_id')
+  AND o.status = 'delivered'
+LIMIT 1;
+
+-- name: GetProductReview :one
+-- Get a single review by ID
+SELECT *
+FROM product_reviews
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: GetProductReviewForUser :one
+-- Get a customer's review of a product
+SELECT *
+FROM product_reviews
+WHERE tenant_id = $1
+  AND product_id = $2
+  AND user_id = $3;
+
+-- name: CreateProductReview :one
+-- Create a review awaiting moderation
+INSERT INTO product_reviews (
+    tenant_id,
+    product_id,
+    user_id,
+    order_id,
+    rating,
+    title,
+    review_text,
+    is_verified_purchase
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: ListReviewsForModeration :many
+-- List reviews with a moderation status, oldest first
+SELECT
+    pr.id,
+    pr.product_id,
+    pr.rating,
+    pr.title,
+    pr.review_text,
+    pr.status,
+    pr.is_verified_purchase,
+    pr.helpful_count,
+    pr.not_helpful_count,
+    pr.moderation_notes,
+    pr.reply_text,
+    pr.created_at,
+    p.name AS product_name,
+    p.slug AS product_slug,
+    u.email AS customer_email,
+    u.first_name AS customer_first_name,
+    u.last_name AS customer_last_name
+FROM product_reviews pr
+INNER JOIN products p ON p.id = pr.product_id
+INNER JOIN users u ON u.id = pr.user_id
+WHERE pr.tenant_id = $1
+  AND pr.status = $2
+ORDER BY pr.created_at ASC
+LIMIT $3 OFFSET $4;
+
+-- name: CountReviewsByStatus :many
+-- Count a tenant's reviews in each moderation status
+SELECT
+    status,
+    COUNT(*)::int AS review_count
+FROM product_reviews
+WHERE tenant_id = $1
+GROUP BY status;
+
+-- name: ModerateProductReview :one
+-- Approve or reject a review
+UPDATE product_reviews
+SET
+    status = $3,
+    moderation_notes = $4,
+    moderated_at = NOW(),
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2
+RETURNING *;
+
+-- name: ReplyToProductReview :one
+-- Set or clear the roaster's public reply to a review
+UPDATE product_reviews
+SET
+    reply_text = sqlc.narg('reply_text'),
+    replied_at = CASE WHEN sqlc.narg('reply_text')::text IS NULL THEN NULL ELSE NOW() END,
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2
+RETURNING *;
+
+-- name: GetProductRatingSummary :one
+-- Get the average rating and rating distribution of a product's approved reviews
+SELECT
+    COUNT(*)::int AS review_count,
+    COALESCE(AVG(rating), 0)::float8 AS average_rating,
+    COUNT(*) FILTER (WHERE rating = 5)::int AS five_star_count,
+    COUNT(*) FILTER (WHERE rating = 4)::int AS four_star_count,
+    COUNT(*) FILTER (WHERE rating = 3)::int AS three_star_count,
+    COUNT(*) FILTER (WHERE rating = 2)::int AS two_star_count,
+    COUNT(*) FILTER (WHERE rating = 1)::int AS one_star_count
+FROM product_reviews
+WHERE tenant_id = $1
+  AND product_id = $2
+  AND status = 'approved';
+
+-- name: ListApprovedReviewsForProduct :many
+-- List a product's approved reviews, most helpful first
+SELECT
+    pr.id,
+    pr.user_id,
+    pr.rating,
+    pr.title,
+    pr.review_text,
+    pr.is_verified_purchase,
+    pr.helpful_count,
+    pr.not_helpful_count,
+    pr.reply_text,
+    pr.replied_at,
+    pr.created_at,
+    u.first_name AS reviewer_first_name
+FROM product_reviews pr
+INNER JOIN users u ON u.id = pr.user_id
+WHERE pr.tenant_id = $1
+  AND pr.product_id = $2
+  AND pr.status = 'approved'
+ORDER BY pr.helpful_count DESC, pr.created_at DESC
+LIMIT $3 OFFSET $4;
+
+-- name: UpsertReviewHelpfulness :exec
+-- Record or change a customer's helpful vote on a review
+INSERT INTO review_helpfulness (tenant_id, review_id, user_id, is_helpful)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (review_id, user_id) DO UPDATE
+SET is_helpful = EXCLUDED.is_helpful;
+
+-- name: RefreshReviewHelpfulCounts :exec
+-- Recount the helpful and not helpful votes on a review
+UPDATE product_reviews pr
+SET
+    helpful_count = (
+        SELECT COUNT(*) FROM review_helpfulness rh
+        WHERE rh.review_id = pr.id AND rh.is_helpful
+    ),
+    not_helpful_count = (
+        SELECT COUNT(*) FROM review_helpfulness rh
+        WHERE rh.review_id = pr.id AND NOT rh.is_helpful
+    )
+WHERE pr.tenant_id = $1
+  AND pr.id = $2;
+
+-- name: ListOrdersDueForReviewRequest :many
+-- List retail orders delivered in a window that haven't been sent a review request
+SELECT
+    o.id,
+    o.user_id,
+    o.order_number,
+    o.delivered_at,
+    u.email,
+    u.first_name,
+    u.last_name
+FROM orders o
+INNER JOIN users u ON u.id = o.user_id
+WHERE o.tenant_id = $1
+  AND o.status = 'delivered'
+  AND o.order_type <> 'wholesale'
+  AND o.delivered_at <= sqlc.arg('delivered_before')
+  AND o.delivered_at > sqlc.arg('delivered_after')
+  AND NOT EXISTS (
+      SELECT 1 FROM review_requests rr
+      WHERE rr.order_id = o.id
+  )
+ORDER BY o.delivered_at ASC
+LIMIT 100;
+
+-- name: CreateReviewRequest :execrows
+-- Record that an order was sent a review request. Affects no rows if one
+-- was already sent.
+INSERT INTO review_requests (tenant_id, order_id)
+VALUES ($1, $2)
+ON CONFLICT (order_id) DO NOTHING;
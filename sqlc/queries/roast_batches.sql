@@ -0,0 +1,304 @@
+-- name: CreateRoastBatch :one
+-- Plans a roast of one product
+INSERT INTO roast_batches (
+    tenant_id,
+    product_id,
+    planned_for,
+    green_lot,
+    green_weight_grams,
+    notes
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetRoastBatch :one
+-- Retrieves a roast batch with its product name
+SELECT
+    rb.id,
+    rb.tenant_id,
+    rb.product_id,
+    rb.status,
+    rb.planned_for,
+    rb.roasted_on,
+    rb.green_lot,
+    rb.green_weight_grams,
+    rb.roasted_weight_grams,
+    rb.notes,
+    rb.created_at,
+    rb.updated_at,
+    p.name as product_name
+FROM roast_batches rb
+JOIN products p ON p.id = rb.product_id
+WHERE rb.tenant_id = $1
+  AND rb.id = $2
+LIMIT 1;
+
+-- name: ListRoastBatches :many
+-- Lists batches planned or roasted on or after the given date, soonest first,
+-- with allocation totals. Allocated grams use each SKU's bag size.
+SELECT
+    rb.id,
+    rb.product_id,
+    rb.status,
+    rb.planned_for,
+    rb.roasted_on,
+    rb.green_lot,
+    rb.green_weight_grams,
+    rb.roasted_weight_grams,
+    p.name as product_name,
+    COALESCE(SUM(ra.quantity), 0)::INTEGER as allocated_units,
+    COALESCE(SUM(ra.quantity * (
+        CASE ps.weight_unit
+            WHEN 'oz' THEN ps.weight_value * 28.3495
+            WHEN 'lb' THEN ps.weight_value * 453.592
+            WHEN 'kg' THEN ps.weight_value * 1000
+            ELSE ps.weight_value
+        END
+    )), 0)::INTEGER as allocated_grams
+FROM roast_batches rb
+JOIN products p ON p.id = rb.product_id
+LEFT JOIN roast_batch_allocations ra ON ra.roast_batch_id = rb.id
+LEFT JOIN product_skus ps ON ps.id = ra.product_sku_id
+WHERE rb.tenant_id = $1
+  AND COALESCE(rb.roasted_on, rb.planned_for) >= sqlc.arg('since')::date
+GROUP BY rb.id, p.name
+ORDER BY COALESCE(rb.roasted_on, rb.planned_for) ASC, p.name ASC;
+
+-- name: UpdateRoastBatch :one
+-- Updates the plan of a batch that has not been cancelled
+UPDATE roast_batches
+SET
+    planned_for = $3,
+    green_lot = $4,
+    green_weight_grams = $5,
+    notes = $6
+WHERE tenant_id = $1
+  AND id = $2
+  AND status <> 'cancelled'
+RETURNING *;
+
+-- name: RecordRoastBatchRoasted :one
+-- Records the roast date and yield; may be repeated to correct them
+UPDATE roast_batches
+SET
+    status = 'roasted',
+    roasted_on = $3,
+    roasted_weight_grams = $4
+WHERE tenant_id = $1
+  AND id = $2
+  AND status <> 'cancelled'
+RETURNING *;
+
+-- name: CancelRoastBatch :one
+-- Cancels a batch that has not been roasted
+UPDATE roast_batches
+SET status = 'cancelled'
+WHERE tenant_id = $1
+  AND id = $2
+  AND status = 'planned'
+RETURNING *;
+
+-- name: DeleteRoastBatchAllocations :exec
+-- Releases all of a batch's allocations
+DELETE FROM roast_batch_allocations
+WHERE tenant_id = $1
+  AND roast_batch_id = $2;
+
+-- name: DeleteRoastBatchAllocation :execrows
+-- Releases one allocation from a batch
+DELETE FROM roast_batch_allocations
+WHERE tenant_id = $1
+  AND roast_batch_id = $2
+  AND id = $3;
+
+-- name: AllocateOpenOrderItemsToRoastBatch :execrows
+-- Allocates unshipped items of the batch's product on paid orders that are
+-- not allocated to another batch
+INSERT INTO roast_batch_allocations (
+    tenant_id,
+    roast_batch_id,
+    product_sku_id,
+    quantity,
+    order_item_id
+)
+SELECT
+    rb.tenant_id,
+    rb.id,
+    oi.product_sku_id,
+    oi.quantity - oi.quantity_dispatched,
+    oi.id
+FROM roast_batches rb
+JOIN product_skus ps ON ps.product_id = rb.product_id
+JOIN order_items oi ON oi.product_sku_id = ps.id AND oi.quantity_dispatched < oi.quantity
+JOIN orders o ON o.id = oi.order_id
+WHERE rb.tenant_id = $1
+  AND rb.id = $2
+  AND rb.status <> 'cancelled'
+  AND o.tenant_id = rb.tenant_id
+  AND o.status IN ('paid', 'processing')
+ON CONFLICT (order_item_id) DO NOTHING;
+
+-- name: AllocateRenewalsToRoastBatch :execrows
+-- Allocates the batch's product on active subscriptions renewing between
+-- today and the given date
+INSERT INTO roast_batch_allocations (
+    tenant_id,
+    roast_batch_id,
+    product_sku_id,
+    quantity,
+    subscription_item_id,
+    renews_on
+)
+SELECT
+    rb.tenant_id,
+    rb.id,
+    si.product_sku_id,
+    si.quantity,
+    si.id,
+    s.next_billing_date::date
+FROM roast_batches rb
+JOIN product_skus ps ON ps.product_id = rb.product_id
+JOIN subscription_items si ON si.product_sku_id = ps.id
+JOIN subscriptions s ON s.id = si.subscription_id
+WHERE rb.tenant_id = $1
+  AND rb.id = $2
+  AND rb.status <> 'cancelled'
+  AND s.tenant_id = rb.tenant_id
+  AND s.status IN ('trial', 'active')
+  AND s.cancel_at_period_end = FALSE
+  AND s.next_billing_date::date BETWEEN CURRENT_DATE AND sqlc.arg('renews_through')::date
+ON CONFLICT (subscription_item_id, renews_on) DO NOTHING;
+
+-- name: AllocateOrderToRoastBatches :execrows
+-- Allocates a new order's items: renewal orders take the allocation made for
+-- the renewal, other items go to the next planned batch of their product
+WITH claimed AS (
+    UPDATE roast_batch_allocations ra
+    SET order_item_id = oi.id
+    FROM order_items oi
+    JOIN orders o ON o.id = oi.order_id
+    JOIN subscription_items si ON si.subscription_id = o.subscription_id
+        AND si.product_sku_id = oi.product_sku_id
+    WHERE oi.tenant_id = $1
+      AND oi.order_id = $2
+      AND ra.subscription_item_id = si.id
+      AND ra.order_item_id IS NULL
+      AND ra.renews_on = (
+          SELECT MIN(earliest.renews_on)
+          FROM roast_batch_allocations earliest
+          JOIN roast_batches eb ON eb.id = earliest.roast_batch_id
+          WHERE earliest.subscription_item_id = si.id
+            AND earliest.order_item_id IS NULL
+            AND eb.status <> 'cancelled'
+      )
+    RETURNING ra.order_item_id
+)
+INSERT INTO roast_batch_allocations (
+    tenant_id,
+    roast_batch_id,
+    product_sku_id,
+    quantity,
+    order_item_id
+)
+SELECT
+    oi.tenant_id,
+    next_batch.id,
+    oi.product_sku_id,
+    oi.quantity,
+    oi.id
+FROM order_items oi
+JOIN product_skus ps ON ps.id = oi.product_sku_id
+JOIN LATERAL (
+    SELECT rb.id
+    FROM roast_batches rb
+    WHERE rb.tenant_id = oi.tenant_id
+      AND rb.product_id = ps.product_id
+      AND rb.status = 'planned'
+      AND rb.planned_for >= CURRENT_DATE
+    ORDER BY rb.planned_for ASC
+    LIMIT 1
+) next_batch ON TRUE
+WHERE oi.tenant_id = $1
+  AND oi.order_id = $2
+  AND oi.id NOT IN (SELECT order_item_id FROM claimed)
+ON CONFLICT (order_item_id) DO NOTHING;
+
+-- name: ListRoastBatchAllocations :many
+-- Lists a batch's allocations with the order or subscription they are for
+SELECT
+    ra.id,
+    ra.quantity,
+    ra.renews_on,
+    ra.order_item_id,
+    ps.sku,
+    o.id as order_id,
+    o.order_number,
+    o.status as order_status,
+    oi.quantity_dispatched,
+    si.subscription_id,
+    COALESCE(ou.email, su.email) as customer_email
+FROM roast_batch_allocations ra
+JOIN product_skus ps ON ps.id = ra.product_sku_id
+LEFT JOIN order_items oi ON oi.id = ra.order_item_id
+LEFT JOIN orders o ON o.id = oi.order_id
+LEFT JOIN users ou ON ou.id = o.user_id
+LEFT JOIN subscription_items si ON si.id = ra.subscription_item_id
+LEFT JOIN subscriptions s ON s.id = si.subscription_id
+LEFT JOIN users su ON su.id = s.user_id
+WHERE ra.tenant_id = $1
+  AND ra.roast_batch_id = $2
+ORDER BY ra.order_item_id IS NULL, ra.renews_on ASC, ra.created_at ASC;
+
+-- name: ListOrderItemRoastDates :many
+-- Roast dates of an order's allocated items. roasted_on is NULL until the
+-- batch is roasted.
+SELECT
+    ra.order_item_id,
+    rb.status,
+    rb.planned_for,
+    rb.roasted_on
+FROM roast_batch_allocations ra
+JOIN roast_batches rb ON rb.id = ra.roast_batch_id
+JOIN order_items oi ON oi.id = ra.order_item_id
+WHERE ra.tenant_id = $1
+  AND oi.order_id = $2
+  AND rb.status <> 'cancelled';
+
+-- name: ListRestingOrders :many
+-- Paid orders held out of the fulfillment queue until their coffee is roasted
+-- and rested. ready_on is NULL while any allocated batch is still planned.
+SELECT
+    o.id,
+    o.order_number,
+    o.created_at,
+    (MIN(rb.planned_for) FILTER (WHERE rb.status = 'planned'))::date as next_roast_on,
+    (CASE
+        WHEN bool_or(rb.status = 'planned') THEN NULL
+        ELSE MAX(rb.roasted_on + COALESCE((t.settings->>'roast_rest_days')::INTEGER, 0))
+    END)::date as ready_on
+FROM orders o
+JOIN tenants t ON t.id = o.tenant_id
+JOIN order_items oi ON oi.order_id = o.id AND oi.quantity_dispatched < oi.quantity
+JOIN roast_batch_allocations ra ON ra.order_item_id = oi.id
+JOIN roast_batches rb ON rb.id = ra.roast_batch_id AND rb.status <> 'cancelled'
+WHERE o.tenant_id = $1
+  AND o.status IN ('paid', 'processing')
+GROUP BY o.id
+HAVING bool_or(
+    rb.status = 'planned'
+    OR rb.roasted_on + COALESCE((t.settings->>'roast_rest_days')::INTEGER, 0) > CURRENT_DATE
+)
+ORDER BY ready_on ASC NULLS LAST, o.created_at ASC;
+
+-- name: GetTenantRoastRestDays :one
+-- Days roasted coffee rests before allocated orders can ship
+SELECT COALESCE((settings->>'roast_rest_days')::INTEGER, 0)::INTEGER
+FROM tenants
+WHERE id = $1;
+
+-- name: UpdateTenantRoastRestDays :exec
+-- Sets the rest period in the tenant's settings
+UPDATE tenants
+SET settings = jsonb_set(settings, '{roast_rest_days}', to_jsonb(sqlc.arg('rest_days')::integer))
+WHERE id = $1;
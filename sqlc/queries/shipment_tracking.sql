@@ -0,0 +1,100 @@
+-- name: GetShipmentByTrackingNumber :one
+-- Finds the most recent shipment for a carrier tracking number
+SELECT * FROM shipments
+WHERE tenant_id = $1
+  AND tracking_number = $2
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: CreateShipmentTrackingEvent :exec
+-- Records a carrier tracking event, ignoring events already stored
+INSERT INTO shipment_tracking_events (
+    tenant_id,
+    shipment_id,
+    status,
+    message,
+    location,
+    event_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT DO NOTHING;
+
+-- name: AdvanceShipmentTrackingStatus :one
+-- Moves a shipment to a new tracking status
+-- Returns no row when the shipment is already in that status or has finished
+UPDATE shipments
+SET
+    status = sqlc.arg('status'),
+    shipped_at = CASE
+        WHEN sqlc.arg('status') IN ('in_transit', 'out_for_delivery', 'delivered') THEN COALESCE(shipped_at, NOW())
+        ELSE shipped_at
+    END,
+    delivered_at = CASE WHEN sqlc.arg('status') = 'delivered' THEN NOW() ELSE delivered_at END,
+    failed_at = CASE WHEN sqlc.arg('status') IN ('failed', 'returned') THEN NOW() ELSE failed_at END,
+    tracking_checked_at = NOW(),
+    updated_at = NOW()
+WHERE tenant_id = sqlc.arg('tenant_id')
+  AND id = sqlc.arg('id')
+  AND status <> sqlc.arg('status')
+  AND status NOT IN ('delivered', 'returned', 'cancelled')
+RETURNING *;
+
+-- name: TouchShipmentTrackingChecked :exec
+-- Records that tracking was refreshed without a status change
+UPDATE shipments
+SET tracking_checked_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: ListShipmentsToTrack :many
+-- Lists shipments still in transit whose tracking has not been refreshed since the cutoff
+SELECT * FROM shipments
+WHERE tenant_id = $1
+  AND status IN ('label_created', 'in_transit', 'out_for_delivery')
+  AND tracking_number IS NOT NULL
+  AND provider IS NOT NULL
+  AND (tracking_checked_at IS NULL OR tracking_checked_at < $2)
+ORDER BY tracking_checked_at NULLS FIRST
+LIMIT $3;
+
+-- name: MarkOrderDeliveredIfComplete :execrows
+-- Marks a shipped, fully fulfilled order delivered once all of its shipments are delivered
+UPDATE orders o
+SET
+    status = 'delivered',
+    delivered_at = NOW(),
+    updated_at = NOW()
+WHERE o.tenant_id = $1
+  AND o.id = $2
+  AND o.status = 'shipped'
+  AND o.fulfillment_status = 'fulfilled'
+  AND NOT EXISTS (
+      SELECT 1 FROM shipments s
+      WHERE s.order_id = o.id
+        AND s.tenant_id = o.tenant_id
+        AND s.status NOT IN ('delivered', 'cancelled')
+  );
+
+-- name: ListOrderTrackingEvents :many
+-- Lists tracking events for all shipments of an order, newest first
+SELECT e.* FROM shipment_tracking_events e
+JOIN shipments s ON s.id = e.shipment_id
+WHERE e.tenant_id = $1
+  AND s.order_id = $2
+ORDER BY e.event_at DESC;
+
+-- name: ListTrackingEventsForOrders :many
+-- Lists tracking events for the given orders' shipments, newest first
+SELECT
+    s.order_id,
+    e.shipment_id,
+    e.status,
+    e.message,
+    e.location,
+    e.event_at
+FROM shipment_tracking_events e
+JOIN shipments s ON s.id = e.shipment_id
+WHERE e.tenant_id = sqlc.arg('tenant_id')
+  AND s.order_id = ANY(sqlc.arg('order_ids')::uuid[])
+ORDER BY e.event_at DESC;
@@ -0,0 +1,74 @@
+-- name: CreateLabelShipment :one
+-- Records a shipment for a purchased shipping label
+INSERT INTO shipments (
+    tenant_id,
+    order_id,
+    shipment_number,
+    carrier,
+    service_name,
+    tracking_number,
+    tracking_url,
+    status,
+    shipping_cost_cents,
+    label_cost_cents,
+    weight_grams,
+    length_cm,
+    width_cm,
+    height_cm,
+    provider,
+    provider_shipment_id,
+    provider_label_id,
+    label_url,
+    label_storage_key,
+    label_image_url,
+    label_created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, 'label_created', $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NOW()
+)
+RETURNING *;
+
+-- name: GetShipment :one
+-- Retrieves a shipment by ID with tenant scoping
+SELECT * FROM shipments
+WHERE tenant_id = $1
+  AND id = $2
+LIMIT 1;
+
+-- name: GetShipmentForOrder :one
+-- Retrieves one of an order's shipments with tenant scoping
+SELECT * FROM shipments
+WHERE tenant_id = $1
+  AND id = $2
+  AND order_id = $3
+LIMIT 1;
+
+-- name: VoidShipmentLabel :exec
+-- Marks a shipment's label as voided and cancels the shipment
+UPDATE shipments
+SET
+    status = 'cancelled',
+    label_voided_at = NOW(),
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: ReleaseOrderItemDispatchedQuantity :exec
+-- Returns dispatched units to the unfulfilled pool when a shipment is cancelled
+UPDATE order_items
+SET
+    quantity_dispatched = GREATEST(quantity_dispatched - $3, 0),
+    fulfillment_status = 'unfulfilled',
+    updated_at = NOW()
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: GetOrderPackageWeight :one
+-- Estimates the packed weight of an order's unshipped items
+-- SKUs without a weight fall back to the supplied default
+SELECT COALESCE(SUM(
+    (oi.quantity - oi.quantity_dispatched) * COALESCE(ps.weight_grams, sqlc.arg('default_item_grams')::integer)
+), 0)::bigint AS weight_grams
+FROM order_items oi
+LEFT JOIN product_skus ps ON ps.id = oi.product_sku_id
+WHERE oi.tenant_id = $1
+  AND oi.order_id = $2;
@@ -0,0 +1,200 @@
+-- Standing Order Queries
+-- Recurring wholesale orders, generated by the job worker and billed on account
+
+-- name: GetTenantStandingOrderSettings :one
+-- Cutoff and reminder lead times from the tenant's settings, '{}' if unset
+SELECT COALESCE(settings->'standing_orders', '{}'::jsonb)::jsonb
+FROM tenants
+WHERE id = $1;
+
+-- name: UpdateTenantStandingOrderSettings :exec
+-- Sets the cutoff and reminder lead times in the tenant's settings
+UPDATE tenants
+SET settings = jsonb_set(settings, '{standing_orders}', sqlc.arg('standing_orders')::jsonb)
+WHERE id = $1;
+
+-- name: CreateStandingOrder :one
+INSERT INTO standing_orders (
+    tenant_id,
+    user_id,
+    name,
+    interval_weeks,
+    delivery_weekday,
+    next_delivery_date,
+    shipping_address_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetStandingOrderForUser :one
+-- Get a standing order, validating it belongs to the customer
+SELECT * FROM standing_orders
+WHERE id = $1
+  AND tenant_id = $2
+  AND user_id = $3;
+
+-- name: ListStandingOrdersForUser :many
+-- A customer's standing orders, cancelled ones last
+SELECT * FROM standing_orders
+WHERE tenant_id = $1
+  AND user_id = $2
+ORDER BY status = 'cancelled', next_delivery_date ASC, created_at ASC;
+
+-- name: ListStandingOrders :many
+-- All standing orders with the customer and basket size, for the admin
+SELECT
+    so.id,
+    so.user_id,
+    so.name,
+    so.status,
+    so.interval_weeks,
+    so.delivery_weekday,
+    so.next_delivery_date,
+    so.last_order_id,
+    so.last_generated_at,
+    so.last_error,
+    u.email,
+    u.first_name,
+    u.last_name,
+    u.company_name,
+    o.order_number AS last_order_number,
+    COUNT(soi.id)::int AS item_count
+FROM standing_orders so
+INNER JOIN users u ON u.id = so.user_id
+LEFT JOIN orders o ON o.id = so.last_order_id
+LEFT JOIN standing_order_items soi ON soi.standing_order_id = so.id
+WHERE so.tenant_id = $1
+GROUP BY so.id, u.id, o.id
+ORDER BY so.status = 'cancelled', so.next_delivery_date ASC, u.company_name ASC;
+
+-- name: ListStandingOrderItems :many
+-- Items in a standing order with product details
+SELECT
+    soi.id,
+    soi.product_sku_id,
+    soi.quantity,
+    p.name AS product_name,
+    ps.sku,
+    ps.weight_value,
+    ps.weight_unit,
+    ps.grind,
+    (ps.is_active AND p.status = 'active')::boolean AS available
+FROM standing_order_items soi
+INNER JOIN product_skus ps ON ps.id = soi.product_sku_id
+INNER JOIN products p ON p.id = ps.product_id
+WHERE soi.tenant_id = $1
+  AND soi.standing_order_id = $2
+ORDER BY p.name ASC, ps.sku ASC;
+
+-- name: UpsertStandingOrderItem :exec
+-- Adds a SKU to a standing order or sets its quantity
+INSERT INTO standing_order_items (
+    tenant_id,
+    standing_order_id,
+    product_sku_id,
+    quantity
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (standing_order_id, product_sku_id) DO UPDATE
+SET quantity = EXCLUDED.quantity;
+
+-- name: DeleteStandingOrderItem :exec
+DELETE FROM standing_order_items
+WHERE tenant_id = $1
+  AND standing_order_id = $2
+  AND product_sku_id = $3;
+
+-- name: UpdateStandingOrderSchedule :one
+-- Changes the name, cadence, delivery day and address. A moved delivery
+-- date gets a new reminder.
+UPDATE standing_orders
+SET name = $3,
+    interval_weeks = $4,
+    delivery_weekday = $5,
+    next_delivery_date = $6,
+    shipping_address_id = $7,
+    reminded_for_date = NULL
+WHERE id = $1
+  AND tenant_id = $2
+RETURNING *;
+
+-- name: UpdateStandingOrderStatus :one
+-- Pauses, resumes or cancels a standing order, moving the next delivery
+-- when resuming
+UPDATE standing_orders
+SET status = $3,
+    next_delivery_date = $4,
+    reminded_for_date = NULL
+WHERE id = $1
+  AND tenant_id = $2
+RETURNING *;
+
+-- name: SkipStandingOrderDelivery :one
+-- Moves a standing order to its following delivery
+UPDATE standing_orders
+SET next_delivery_date = $3,
+    reminded_for_date = NULL
+WHERE id = $1
+  AND tenant_id = $2
+  AND status = 'active'
+RETURNING *;
+
+-- name: ListDueStandingOrders :many
+-- Active standing orders whose cutoff has been reached
+SELECT * FROM standing_orders
+WHERE tenant_id = $1
+  AND status = 'active'
+  AND next_delivery_date <= sqlc.arg('generate_through')::date
+ORDER BY next_delivery_date ASC
+LIMIT 100;
+
+-- name: ClaimStandingOrderDelivery :one
+-- Moves a due standing order on to its following delivery before the due
+-- delivery is placed. Returns no row when another run has already claimed
+-- the delivery.
+UPDATE standing_orders
+SET next_delivery_date = $3,
+    reminded_for_date = NULL
+WHERE id = $1
+  AND tenant_id = $2
+  AND status = 'active'
+  AND next_delivery_date = sqlc.arg('due_date')::date
+RETURNING *;
+
+-- name: RecordStandingOrderGenerated :exec
+-- Records the outcome of placing a claimed delivery. last_order_id is kept
+-- when the attempt failed.
+UPDATE standing_orders
+SET last_order_id = COALESCE(sqlc.narg('order_id'), last_order_id),
+    last_error = sqlc.narg('last_error'),
+    last_generated_at = NOW()
+WHERE id = $1
+  AND tenant_id = $2;
+
+-- name: ListStandingOrdersToRemind :many
+-- Active standing orders with a cutoff coming up that the customer hasn't
+-- been reminded about
+SELECT
+    so.id,
+    so.name,
+    so.next_delivery_date,
+    u.email,
+    u.first_name,
+    u.last_name,
+    u.company_name
+FROM standing_orders so
+INNER JOIN users u ON u.id = so.user_id
+WHERE so.tenant_id = $1
+  AND so.status = 'active'
+  AND so.next_delivery_date <= sqlc.arg('remind_through')::date
+  AND (so.reminded_for_date IS NULL OR so.reminded_for_date <> so.next_delivery_date)
+ORDER BY so.next_delivery_date ASC
+LIMIT 100;
+
+-- name: MarkStandingOrderReminded :exec
+UPDATE standing_orders
+SET reminded_for_date = $3
+WHERE id = $1
+  AND tenant_id = $2;
@@ -0,0 +1,95 @@
+-- Subscription Dunning Queries
+-- Retries and reminders after a subscription renewal payment fails
+
+-- name: GetTenantDunningSettings :one
+-- Retry schedule and final action from the tenant's settings, '{}' if unset
+SELECT COALESCE(settings->'dunning', '{}'::jsonb)::jsonb
+FROM tenants
+WHERE id = $1;
+
+-- name: UpdateTenantDunningSettings :exec
+-- Sets the retry schedule and final action in the tenant's settings
+UPDATE tenants
+SET settings = jsonb_set(settings, '{dunning}', sqlc.arg('dunning')::jsonb)
+WHERE id = $1;
+
+-- name: CreateSubscriptionDunning :one
+-- Starts dunning for a failed invoice. Returns no rows when the
+-- subscription is already in dunning.
+INSERT INTO subscription_dunning (
+    tenant_id,
+    subscription_id,
+    provider_invoice_id,
+    next_attempt_at,
+    last_failure_reason,
+    update_token_hash
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (subscription_id) WHERE status = 'active' DO NOTHING
+RETURNING *;
+
+-- name: GetActiveSubscriptionDunning :one
+SELECT * FROM subscription_dunning
+WHERE tenant_id = $1
+  AND subscription_id = $2
+  AND status = 'active';
+
+-- name: GetLatestSubscriptionDunning :one
+-- Most recent dunning for a subscription, open or closed
+SELECT * FROM subscription_dunning
+WHERE tenant_id = $1
+  AND subscription_id = $2
+ORDER BY started_at DESC
+LIMIT 1;
+
+-- name: GetSubscriptionDunningByToken :one
+-- Open dunning whose latest reminder carried the update payment link token
+SELECT * FROM subscription_dunning
+WHERE tenant_id = $1
+  AND update_token_hash = $2
+  AND status = 'active';
+
+-- name: ListDueSubscriptionDunning :many
+-- Open dunning with a retry due
+SELECT * FROM subscription_dunning
+WHERE tenant_id = $1
+  AND status = 'active'
+  AND next_attempt_at <= NOW()
+ORDER BY next_attempt_at ASC
+LIMIT 100;
+
+-- name: RecordSubscriptionDunningAttempt :one
+-- Records a failed retry. next_attempt_at is null after the final retry.
+UPDATE subscription_dunning
+SET attempt_count = attempt_count + 1,
+    last_attempt_at = NOW(),
+    next_attempt_at = $3,
+    last_failure_reason = $4,
+    update_token_hash = $5
+WHERE id = $1
+  AND tenant_id = $2
+  AND status = 'active'
+RETURNING *;
+
+-- name: ResolveSubscriptionDunning :exec
+-- Closes dunning as recovered, paused or cancelled
+UPDATE subscription_dunning
+SET status = $3,
+    next_attempt_at = NULL,
+    update_token_hash = NULL,
+    resolved_at = NOW()
+WHERE id = $1
+  AND tenant_id = $2
+  AND status = 'active';
+
+-- name: ResolveSubscriptionDunningForInvoice :execrows
+-- Closes dunning as recovered once its invoice is paid
+UPDATE subscription_dunning
+SET status = 'recovered',
+    next_attempt_at = NULL,
+    update_token_hash = NULL,
+    resolved_at = NOW()
+WHERE tenant_id = $1
+  AND provider_invoice_id = $2
+  AND status = 'active';
@@ -187,6 +187,32 @@ SET
 WHERE id = $1 AND tenant_id = $2
 RETURNING *;
 
+-- name: UpdateSubscriptionSettings :one
+-- Applies a customer's change to frequency, next delivery, shipping address,
+-- payment method or price. NULL leaves a field unchanged.
+UPDATE subscriptions
+SET
+    billing_interval = COALESCE(sqlc.narg('billing_interval'), billing_interval),
+    next_billing_date = COALESCE(sqlc.narg('next_billing_date'), next_billing_date),
+    shipping_address_id = COALESCE(sqlc.narg('shipping_address_id'), shipping_address_id),
+    payment_method_id = COALESCE(sqlc.narg('payment_method_id'), payment_method_id),
+    subtotal_cents = COALESCE(sqlc.narg('subtotal_cents'), subtotal_cents),
+    total_cents = COALESCE(sqlc.narg('subtotal_cents') + shipping_cents + tax_cents, total_cents),
+    updated_at = NOW()
+WHERE id = $1 AND tenant_id = $2
+RETURNING *;
+
+-- name: UpdateSubscriptionItem :one
+-- Swaps a subscription item's SKU or quantity, at the price of the new SKU
+UPDATE subscription_items
+SET
+    product_sku_id = $3,
+    quantity = $4,
+    unit_price_cents = $5,
+    updated_at = NOW()
+WHERE id = $1 AND tenant_id = $2
+RETURNING *;
+
 -- Billing customer queries
 
 -- name: GetBillingCustomerForUser :one
@@ -273,6 +299,27 @@ WHERE ss.tenant_id = $1
 ORDER BY ss.scheduled_at ASC
 LIMIT $3;
 
+-- name: ListSubscriptionScheduleEvents :many
+-- Lists a subscription's events, newest first, for its history
+SELECT * FROM subscription_schedule
+WHERE subscription_id = $1
+  AND tenant_id = $2
+ORDER BY created_at DESC
+LIMIT $3;
+
+-- name: CloseScheduledSubscriptionEvents :exec
+-- Marks a subscription's still-scheduled events of one type as completed or
+-- cancelled, e.g. a pending resume once the subscription is resumed
+UPDATE subscription_schedule
+SET
+    status = $4,
+    processed_at = NOW(),
+    updated_at = NOW()
+WHERE subscription_id = $1
+  AND tenant_id = $2
+  AND event_type = $3
+  AND status = 'scheduled';
+
 -- Admin queries
 
 -- name: ListSubscriptions :many
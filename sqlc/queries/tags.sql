@@ -0,0 +1,88 @@
+-- name: ListProductTags :many
+-- List all tags for a tenant with the number of products tagged
+SELECT
+    pt.id,
+    pt.name,
+    pt.slug,
+    pt.created_at,
+    (SELECT COUNT(*)
+     FROM product_tag_assignments pta
+     WHERE pta.tag_id = pt.id
+    )::int AS product_count
+FROM product_tags pt
+WHERE pt.tenant_id = $1
+ORDER BY pt.name ASC;
+
+-- name: GetProductTag :one
+-- Get a single tag by ID
+SELECT *
+FROM product_tags
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: GetProductTagBySlug :one
+-- Get a single tag by slug
+SELECT *
+FROM product_tags
+WHERE tenant_id = $1
+  AND slug = $2;
+
+-- name: CreateProductTag :one
+-- Create a new tag
+INSERT INTO product_tags (tenant_id, name, slug)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateProductTag :one
+-- Rename a tag
+UPDATE product_tags
+SET
+    name = $3,
+    slug = $4
+WHERE tenant_id = $1
+  AND id = $2
+RETURNING *;
+
+-- name: DeleteProductTag :exec
+-- Delete a tag and its product assignments
+DELETE FROM product_tags
+WHERE tenant_id = $1
+  AND id = $2;
+
+-- name: ListTagIDsForProduct :many
+-- List the tags on a product
+SELECT tag_id
+FROM product_tag_assignments
+WHERE tenant_id = $1
+  AND product_id = $2;
+
+-- name: AssignProductTags :exec
+-- Tag a product, ignoring IDs that don't belong to the tenant
+INSERT INTO product_tag_assignments (tenant_id, product_id, tag_id)
+SELECT $1, $2, pt.id
+FROM product_tags pt
+WHERE pt.tenant_id = $1
+  AND pt.id = ANY(sqlc.arg('tag_ids')::uuid[])
+ON CONFLICT (product_id, tag_id) DO NOTHING;
+
+-- name: UnassignProductTagsExcept :exec
+-- Remove every tag not in the given list from a product
+DELETE FROM product_tag_assignments
+WHERE tenant_id = $1
+  AND product_id = $2
+  AND NOT (tag_id = ANY(sqlc.arg('tag_ids')::uuid[]));
+
+-- name: ListTagFacets :many
+-- List tags on at least one storefront product with their product counts
+SELECT
+    pt.slug,
+    pt.name,
+    COUNT(DISTINCT p.id)::int AS product_count
+FROM product_tags pt
+JOIN product_tag_assignments pta ON pta.tag_id = pt.id
+JOIN products p ON p.id = pta.product_id
+WHERE pt.tenant_id = $1
+  AND p.status = 'active'
+  AND p.visibility = 'public'
+GROUP BY pt.id, pt.slug, pt.name
+ORDER BY pt.name ASC;
@@ -149,3 +149,10 @@ SET
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
+
+-- name: IsMasterTenant :one
+-- Whether the tenant is the platform owner, whose operators can see
+-- every tenant's background jobs
+SELECT COALESCE((settings->>'is_master')::BOOLEAN, FALSE)::BOOLEAN
+FROM tenants
+WHERE id = $1;
@@ -55,6 +55,15 @@ WHERE id = $1
   AND status != 'closed'
 LIMIT 1;
 
+-- name: LockCustomerForOrder :one
+-- Lock a customer row for the rest of the transaction so their on-account
+-- orders are placed one at a time and each credit check sees the last order
+SELECT id
+FROM users
+WHERE tenant_id = $1
+  AND id = $2
+FOR UPDATE;
+
 -- name: UpdateUserProfile :exec
 -- Update user profile information (tenant-scoped for security)
 UPDATE users
@@ -242,6 +251,7 @@ SET
     email_orders = $9,
     email_dispatches = $10,
     email_invoices = $11,
+    credit_limit_cents = $12,
     updated_at = NOW()
 WHERE id = $1;
 